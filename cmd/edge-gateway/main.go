@@ -2,8 +2,12 @@ package main
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,19 +18,38 @@ import (
 	"connectrpc.com/connect"
 
 	"hooks.dx314.com/internal/api/hookly/v1/hooklyv1connect"
+	"hooks.dx314.com/internal/audit"
 	"hooks.dx314.com/internal/auth"
+	"hooks.dx314.com/internal/buildinfo"
+	"hooks.dx314.com/internal/coldstore"
 	"hooks.dx314.com/internal/config"
 	"hooks.dx314.com/internal/db"
+	"hooks.dx314.com/internal/errreport"
+	"hooks.dx314.com/internal/id"
+	"hooks.dx314.com/internal/jobs"
+	"hooks.dx314.com/internal/kms"
+	"hooks.dx314.com/internal/logging"
+	"hooks.dx314.com/internal/metrics"
 	"hooks.dx314.com/internal/notify"
+	"hooks.dx314.com/internal/region"
 	"hooks.dx314.com/internal/relay"
 	"hooks.dx314.com/internal/server"
 	"hooks.dx314.com/internal/service/edge"
+	"hooks.dx314.com/internal/status"
+	"hooks.dx314.com/internal/telemetry"
+	"hooks.dx314.com/internal/tracing"
 	"hooks.dx314.com/internal/ui"
 	"hooks.dx314.com/internal/webhook"
 )
 
+// build identifies this binary; version is reported with errors and traces.
+var (
+	build   = buildinfo.Get()
+	version = build.Version
+)
+
 func main() {
-	// Setup structured logging
+	// Log at info until the configured logger is set up
 	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
 		Level: slog.LevelInfo,
 	})))
@@ -38,6 +61,10 @@ func main() {
 }
 
 func run() error {
+	allowDegraded := flag.Bool("allow-degraded", os.Getenv("ALLOW_DEGRADED") == "true",
+		"start with an incomplete configuration (e.g. without GitHub auth) instead of failing")
+	flag.Parse()
+
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
@@ -46,33 +73,142 @@ func run() error {
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
+	logger, err := logging.Setup(cfg.LogOptions())
+	if err != nil {
+		return fmt.Errorf("setup logging: %w", err)
+	}
+	defer logger.Close()
+
+	// Report panics and error logs if configured
+	reporter := errreport.Install(cfg.SentryDSN, errreport.Options{
+		Release:     "edge-gateway@" + version,
+		Environment: cfg.SentryEnvironment,
+	})
+	defer reporter.Close(5 * time.Second)
+	defer reporter.Recover()
+	if err := checkConfig(cfg, *allowDegraded); err != nil {
+		return err
+	}
 
-	// Open database
-	conn, err := db.Open(ctx, cfg.DatabasePath)
+	// Unwrap the encryption key with the configured KMS
+	if cfg.EncryptionKey == nil {
+		provider, err := kms.NewProvider(cfg.EncryptionKeySource, os.Getenv)
+		if err != nil {
+			return err
+		}
+		cfg.EncryptionKey, err = kms.LoadDataKey(ctx, provider, cfg.EncryptionKeyWrapped)
+		if err != nil {
+			return err
+		}
+		if r, ok := provider.(kms.Renewer); ok {
+			go r.Renew(ctx)
+		}
+		slog.Info("encryption key unwrapped", "source", cfg.EncryptionKeySource)
+	}
+
+	// Open database, timing every query
+	queryMetrics := db.NewQueryMetrics(cfg.SlowQueryThreshold)
+	conn, err := db.OpenInstrumented(ctx, cfg.DatabasePath, queryMetrics)
 	if err != nil {
 		return fmt.Errorf("open database: %w", err)
 	}
 	defer conn.Close()
 
 	queries := db.New(conn)
+
+	// Endpoints are looked up on every webhook, so hot ones are cached
+	var endpointCache *db.EndpointCache
+	if cfg.EndpointCacheTTL > 0 {
+		endpointCache = db.NewEndpointCache(queries, cfg.EndpointCacheTTL)
+	}
+
+	// Read-only connections for the UI's heavy reads, keeping them off the writer
+	reads := queries
+	if cfg.DatabaseReadPath != "" {
+		readConn, err := db.OpenReadOnly(ctx, cfg.DatabaseReadPath, queryMetrics)
+		if err != nil {
+			return fmt.Errorf("open read database: %w", err)
+		}
+		defer readConn.Close()
+		reads = db.New(readConn)
+		slog.Info("read database enabled", "path", cfg.DatabaseReadPath)
+	}
 	secretManager := db.NewSecretManager(cfg.EncryptionKey)
 
+	// Persistent queue for background side effects; its worker runs in the scheduler
+	jobQueue := jobs.New(queries)
+
 	// Create relay connection manager
 	connMgr := relay.NewConnectionManager()
 
+	// Stored webhooks by status, for metrics and /statusz
+	queueDepth := func(ctx context.Context) (map[string]int64, error) {
+		rows, err := queries.CountWebhooksByStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+		depth := make(map[string]int64, len(rows))
+		for _, row := range rows {
+			depth[row.Status] = row.Count
+		}
+		return depth, nil
+	}
+
+	// Gateway metrics, only recorded when they are served
+	var edgeMetrics *metrics.Metrics
+	if cfg.MetricsAddr != "" {
+		edgeMetrics = metrics.New()
+		edgeMetrics.SetConnectedHubs(connMgr.HubCount)
+		edgeMetrics.SetQueueDepth(queueDepth)
+	}
+
+	// Trace webhooks from ingestion to delivery; spans are only exported
+	// to a configured collector
+	cfg.Tracing.ServiceVersion = version
+	tracer, err := tracing.New(cfg.Tracing)
+	if err != nil {
+		return fmt.Errorf("setup tracing: %w", err)
+	}
+	defer tracer.Close(5 * time.Second)
+	if tracer != nil {
+		slog.Info("tracing enabled", "endpoint", cfg.Tracing.Endpoint)
+	}
+
 	// Create notifier with per-user config support
-	var globalNotifier notify.Notifier = notify.NopNotifier{}
+	var systemNotifiers notify.MultiNotifier
 	if cfg.TelegramEnabled() {
-		globalNotifier = notify.NewTelegramNotifier(cfg.TelegramBotToken, cfg.TelegramChatID, cfg.BaseURL)
+		systemNotifiers = append(systemNotifiers, notify.NewTelegramNotifier(cfg.TelegramBotToken, cfg.TelegramChatID, cfg.BaseURL))
 		slog.Info("system telegram notifications enabled")
 	}
-	// Wrap with UserNotifier to support per-user Telegram config
+	if cfg.DiscordEnabled() {
+		systemNotifiers = append(systemNotifiers, notify.NewDiscordNotifier(cfg.DiscordWebhookURL, cfg.BaseURL))
+		slog.Info("system discord notifications enabled")
+	}
+	if cfg.EmailEnabled() {
+		systemNotifiers = append(systemNotifiers, notify.NewSMTPNotifier(cfg.SMTP, cfg.SMTPTo, cfg.BaseURL))
+		slog.Info("system email notifications enabled", "smtp_host", cfg.SMTP.Host)
+	}
+	if cfg.NotifyWebhookEnabled() {
+		systemNotifiers = append(systemNotifiers, notify.NewWebhookNotifier(cfg.NotifyWebhookURL, cfg.NotifyWebhookSecret, cfg.BaseURL))
+		slog.Info("system notification webhook enabled")
+	}
+	var globalNotifier notify.Notifier = notify.NopNotifier{}
+	if len(systemNotifiers) > 0 {
+		globalNotifier = systemNotifiers
+	}
+	// Wrap with UserNotifier to support per-user Telegram, Discord, email and webhook config
 	notifier := notify.NewUserNotifier(queries, secretManager, globalNotifier, cfg.BaseURL)
+	notifier.SetSMTP(cfg.SMTP)
 
 	// Create server
 	srv := server.New(fmt.Sprintf(":%d", cfg.Port))
 
 	// Setup routes
+	srv.SetTrustedProxies(cfg.TrustedProxies)
+	srv.SetIngestPathPrefix(cfg.WebhookPathPrefix)
+	if err := id.Configure(cfg.EndpointIDFormat, cfg.WebhookIDFormat); err != nil {
+		return err
+	}
 	r := srv.Router()
 
 	// Health check
@@ -81,13 +217,43 @@ func run() error {
 		w.Write([]byte("ok"))
 	})
 
+	// Build info, for support and client version checks
+	r.Get("/version", build.ServeHTTP)
+
+	// Anonymous usage reports from CLIs that opted in, counted in metrics
+	telemetryCollector := telemetry.NewCollector()
+	r.Post(telemetry.Path, telemetryCollector.ServeHTTP)
+
+	// Webhook dispatcher, relaying pending webhooks to connected hubs
+	dispatcher := relay.NewDispatcher(queries, connMgr)
+	dispatcher.SetTracer(tracer)
+
 	// Webhook ingestion (no auth required)
-	webhookHandler := webhook.NewHandler(queries, secretManager)
-	r.Post("/h/{endpointID}", webhookHandler.ServeHTTP)
+	webhookHandler := webhook.NewHandler(queries, secretManager, notifier)
+	webhookHandler.SetBaseURL(cfg.BaseURL)
+	webhookHandler.SetDispatcher(dispatcher)
+	webhookHandler.SetEndpointCache(endpointCache)
+	webhookHandler.SetJobQueue(jobQueue)
+	webhookHandler.SetMetrics(edgeMetrics)
+	webhookHandler.SetTracer(tracer)
+	webhookHandler.SetGuards(webhook.Guards{
+		RequireJSON:    cfg.IngestRequireJSON,
+		BannedPatterns: cfg.IngestBannedPatterns,
+		DailyBytes:     int64(cfg.IngestDailyLimitMB) << 20,
+	})
+	rateLimiter := webhook.NewRateLimiter(webhook.RateLimits{
+		PerEndpoint: cfg.IngestRateLimit,
+		PerIP:       cfg.IngestIPRateLimit,
+	}, nil)
+	webhookHandler.SetRateLimiter(rateLimiter)
+	// Every method: the handler answers HEAD and OPTIONS probes per endpoint and
+	// rejects other non-POST requests, except on honeypot endpoints
+	r.HandleFunc(webhook.Route(cfg.WebhookPathPrefix), webhookHandler.ServeHTTP)
 
 	// Authentication
 	var sessionManager *auth.SessionManager
 	var tokenManager *auth.TokenManager
+	auditRecorder := audit.NewRecorder(queries)
 	if cfg.GitHubAuthEnabled() {
 		// Determine if running securely
 		secure := strings.HasPrefix(cfg.BaseURL, "https://")
@@ -96,8 +262,10 @@ func run() error {
 		githubClient := auth.NewGitHubClient(cfg.GitHubClientID, cfg.GitHubClientSecret, redirectURI)
 		sessionManager = auth.NewSessionManager(queries, secure, "/")
 		tokenManager = auth.NewTokenManager(queries)
+		tokenManager.SetJobQueue(jobQueue)
 		authorizer := auth.NewAuthorizer(githubClient, cfg.GitHubOrg, cfg.GitHubAllowedUsers)
 		authHandlers := auth.NewHandlers(githubClient, sessionManager, authorizer, tokenManager)
+		authHandlers.SetAuditRecorder(auditRecorder)
 
 		// Auth routes (no auth required)
 		r.Get("/auth/login", authHandlers.Login)
@@ -119,8 +287,15 @@ func run() error {
 	}
 
 	// Relay service (ConnectRPC, uses bearer token auth)
+	var relayHandler *relay.Handler
 	if tokenManager != nil {
-		relayHandler := relay.NewHandler(tokenManager, connMgr, queries, notifier)
+		relayHandler = relay.NewHandler(tokenManager, connMgr, queries, notifier)
+		relayHandler.SetEndpointCache(endpointCache)
+		relayHandler.SetJobQueue(jobQueue)
+		relayHandler.SetMetrics(edgeMetrics)
+		relayHandler.SetTracer(tracer)
+		relayHandler.SetTunnelAllowedNets(cfg.TunnelAllowedNets)
+		relayHandler.SetKeepalive(cfg.RelayHeartbeatInterval, cfg.RelayStaleTimeout)
 		path, handler := hooklyv1connect.NewRelayServiceHandler(relayHandler, connect.WithInterceptors())
 		r.Mount(path, handler)
 		slog.Info("relay service enabled")
@@ -130,11 +305,27 @@ func run() error {
 
 	// EdgeService (API for UI/MCP)
 	edgeSvc := edge.New(queries, secretManager, connMgr, cfg)
+	edgeSvc.SetLogLevelVar(logger.LevelVar())
+	edgeSvc.SetReadQueries(reads)
+	edgeSvc.SetEndpointCache(endpointCache)
+	edgeSvc.SetTokenManager(tokenManager)
+	if cfg.RegionsEnabled() {
+		edgeSvc.SetRegionChecker(region.NewChecker(region.Region{Name: cfg.Region, URL: cfg.BaseURL}, cfg.Regions))
+		slog.Info("multi-region enabled", "region", cfg.Region, "regions", len(cfg.Regions))
+	}
 	if sessionManager != nil {
 		// With auth interceptor (supports both cookies and Bearer tokens)
 		authInterceptor := server.NewAuthInterceptor(sessionManager, tokenManager)
-		edgePath, edgeHandler := hooklyv1connect.NewEdgeServiceHandler(edgeSvc, connect.WithInterceptors(authInterceptor))
+		authInterceptor.SetOrgResolver(auth.NewOrgResolver(queries))
+		authInterceptor.SetAuditRecorder(auditRecorder)
+		auditInterceptor := server.NewAuditInterceptor(auditRecorder)
+		edgePath, edgeHandler := hooklyv1connect.NewEdgeServiceHandler(edgeSvc, connect.WithInterceptors(authInterceptor, auditInterceptor))
 		r.Handle(edgePath+"*", edgeHandler)
+
+		// Raw payload downloads, outside protobuf so large payloads stream
+		payloadHandler := webhook.NewPayloadHandler(queries)
+		r.With(authInterceptor.Middleware).Get("/api/webhooks/{webhookID}/payload", payloadHandler.ServeHTTP)
+		r.With(authInterceptor.Middleware).Head("/api/webhooks/{webhookID}/payload", payloadHandler.ServeHTTP)
 		slog.Info("edge service enabled with auth")
 	} else {
 		// Without auth (development only)
@@ -168,7 +359,6 @@ func run() error {
 	slog.Info("ui handler enabled")
 
 	// Start webhook dispatcher
-	dispatcher := relay.NewDispatcher(queries, connMgr)
 	go func() {
 		if err := dispatcher.Run(ctx); err != nil && err != context.Canceled {
 			slog.Error("dispatcher error", "error", err)
@@ -176,11 +366,83 @@ func run() error {
 	}()
 
 	// Start webhook scheduler (dead-letter processing, cleanup)
+	jobQueue.Register(jobDeadLetterNotifications, func(ctx context.Context, _ []byte) error {
+		return sendDeadLetterNotifications(ctx, queries, notifier)
+	})
+	jobQueue.Register(jobSLOBreachNotification, func(ctx context.Context, payload []byte) error {
+		info, err := jobs.Decode[notify.SLOInfo](payload)
+		if err != nil {
+			return err
+		}
+		return notifier.NotifySLOBreach(ctx, info)
+	})
+	jobQueue.Register(jobEndpointArchivedNotification, func(ctx context.Context, payload []byte) error {
+		info, err := jobs.Decode[notify.ArchiveInfo](payload)
+		if err != nil {
+			return err
+		}
+		return notifier.NotifyEndpointArchived(ctx, info)
+	})
+
 	scheduler := webhook.NewScheduler(queries)
+	scheduler.SetConfig(webhook.SchedulerConfig{
+		Interval:            cfg.SchedulerInterval,
+		DeadLetterAge:       cfg.DeadLetterAge,
+		DeliveredRetention:  cfg.DeliveredRetention,
+		FailedRetention:     cfg.FailedRetention,
+		DeadLetterRetention: cfg.DeadLetterRetention,
+		SkippedRetention:    cfg.SkippedRetention,
+		ActivityRetention:   cfg.ActivityRetention,
+		AuditRetention:      cfg.AuditRetention,
+		PurgeGrace:          cfg.RetentionGrace,
+		ArchiveAfter:        cfg.EndpointArchiveAfter,
+	})
+	scheduler.SetJobQueue(jobQueue)
+	if cfg.ColdStorageURL != "" {
+		store, err := coldstore.Open(cfg.ColdStorageURL, os.Getenv)
+		if err != nil {
+			return fmt.Errorf("cold storage: %w", err)
+		}
+		scheduler.SetExporter(coldstore.NewExporter(store, nil))
+		slog.Info("webhooks past retention are exported to cold storage", "url", cfg.ColdStorageURL)
+	}
+	edgeSvc.SetScheduler(scheduler)
+	edgeSvc.SetRateLimiter(rateLimiter)
 	scheduler.SetDeadLetterCallback(func(count int64) {
 		slog.Warn("webhooks moved to dead letter", "count", count)
+		edgeMetrics.DeadLettered(count)
 		// Send dead letter notifications
-		go sendDeadLetterNotifications(context.Background(), queries, notifier)
+		if err := jobQueue.Enqueue(ctx, jobDeadLetterNotifications, nil); err != nil {
+			slog.Error("failed to enqueue dead letter notifications", "error", err)
+		}
+	})
+	scheduler.SetSLOBreachCallback(func(ep db.ListSLOEndpointsRow, status webhook.SLOStatus) {
+		err := jobQueue.Enqueue(ctx, jobSLOBreachNotification, notify.SLOInfo{
+			EndpointID:     ep.ID,
+			EndpointName:   ep.Name,
+			DestinationURL: ep.DestinationUrl,
+			Target:         status.Target,
+			Compliance:     status.Compliance(),
+			Latency:        status.Latency,
+			Window:         status.Window,
+			Total:          status.Total,
+			Met:            status.Met,
+		})
+		if err != nil {
+			slog.Error("failed to enqueue slo breach notification", "endpoint_id", ep.ID, "error", err)
+		}
+	})
+	scheduler.SetArchiveCallback(func(ep db.ArchiveInactiveEndpointsRow) {
+		endpointCache.Invalidate(ep.ID) // Muted, so webhooks are dropped now
+		info := notify.ArchiveInfo{
+			EndpointID:   ep.ID,
+			EndpointName: ep.Name,
+			InactiveFor:  cfg.EndpointArchiveAfter,
+		}
+		info.LastWebhookAt, _ = db.ParseNullTime(ep.LastWebhookReceivedAt)
+		if err := jobQueue.Enqueue(ctx, jobEndpointArchivedNotification, info); err != nil {
+			slog.Error("failed to enqueue archive notification", "endpoint_id", ep.ID, "error", err)
+		}
 	})
 	go func() {
 		if err := scheduler.Start(ctx); err != nil && err != context.Canceled {
@@ -188,6 +450,16 @@ func run() error {
 		}
 	}()
 
+	// Aggregate health for external status pages (no per-user data)
+	r.Get("/statusz", newStatusHandler(conn, dispatcher, scheduler, jobQueue, queueDepth).ServeHTTP)
+
+	// Metrics on a separate, usually private, address
+	if cfg.MetricsAddr != "" {
+		if err := serveMetrics(ctx, cfg.MetricsAddr, metrics.Handler(edgeMetrics, queryMetrics, telemetryCollector)); err != nil {
+			slog.Error("metrics disabled", "error", err)
+		}
+	}
+
 	// Start server in goroutine
 	errCh := make(chan error, 1)
 	go func() {
@@ -199,17 +471,37 @@ func run() error {
 		"base_url", cfg.BaseURL,
 		"github_auth", cfg.GitHubAuthEnabled(),
 		"telegram", cfg.TelegramEnabled(),
+		"discord", cfg.DiscordEnabled(),
+		"email", cfg.EmailEnabled(),
+		"notify_webhook", cfg.NotifyWebhookEnabled(),
 	)
 
-	// Wait for shutdown signal
+	// Wait for shutdown signal; SIGHUP reloads logging
 	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+
+wait:
+	for {
+		select {
+		case err := <-errCh:
+			return fmt.Errorf("server error: %w", err)
+		case sig := <-sigCh:
+			if sig == syscall.SIGHUP {
+				reloadLogging(logger)
+				continue
+			}
+			slog.Info("received shutdown signal", "signal", sig)
+			break wait
+		}
+	}
 
-	select {
-	case err := <-errCh:
-		return fmt.Errorf("server error: %w", err)
-	case sig := <-sigCh:
-		slog.Info("received shutdown signal", "signal", sig)
+	// Tell hubs the restart is planned, so they reconnect without backing off
+	if relayHandler != nil {
+		announceCtx, announceCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if n := relayHandler.AnnounceMaintenance(announceCtx, cfg.MaintenanceReconnectAfter, cfg.MaintenanceReconnectURL, "edge restarting"); n > 0 {
+			slog.Info("announced maintenance to hubs", "hubs", n, "reconnect_url", cfg.MaintenanceReconnectURL)
+		}
+		announceCancel()
 	}
 
 	// Graceful shutdown
@@ -225,18 +517,134 @@ func run() error {
 	return nil
 }
 
-// sendDeadLetterNotifications sends notifications for recently dead-lettered webhooks.
-func sendDeadLetterNotifications(ctx context.Context, queries *db.Queries, notifier notify.Notifier) {
+// dispatcherStallAfter is how long the dispatcher loop can go without
+// running before /statusz reports it down.
+const dispatcherStallAfter = 30 * time.Second
+
+// notificationJobs are the job kinds /statusz reports as the notifier.
+var notificationJobs = []string{jobDeadLetterNotifications, jobSLOBreachNotification, jobEndpointArchivedNotification}
+
+// newStatusHandler creates the /statusz handler checking the database,
+// dispatcher, scheduler and notification jobs. Errors are logged, not
+// reported, since they can name users' endpoints.
+func newStatusHandler(conn *sql.DB, dispatcher *relay.Dispatcher, scheduler *webhook.Scheduler, jobQueue *jobs.Queue, queueDepth func(context.Context) (map[string]int64, error)) *status.Handler {
+	h := status.NewHandler(build)
+	h.SetQueueCounts(queueDepth)
+
+	h.AddCheck("db", func(ctx context.Context) status.Component {
+		if err := conn.PingContext(ctx); err != nil {
+			slog.Warn("status: database ping failed", "error", err)
+			return status.Component{Status: status.StateDown, Detail: "ping failed"}
+		}
+		return status.Component{Status: status.StateOK}
+	})
+
+	h.AddCheck("dispatcher", func(context.Context) status.Component {
+		lastRun, err := dispatcher.LastRun()
+		switch {
+		case time.Since(lastRun) > dispatcherStallAfter:
+			return status.Component{Status: status.StateDown, Detail: "not running"}
+		case err != nil:
+			return status.Component{Status: status.StateDegraded, Detail: "last dispatch failed"}
+		}
+		return status.Component{Status: status.StateOK}
+	})
+
+	h.AddCheck("scheduler", func(context.Context) status.Component {
+		if !scheduler.Running() {
+			return status.Component{Status: status.StateDown, Detail: "not running"}
+		}
+		var failed []string
+		for _, job := range scheduler.JobStatuses() {
+			if job.LastError != "" {
+				failed = append(failed, job.Name)
+			}
+		}
+		if len(failed) > 0 {
+			return status.Component{Status: status.StateDegraded, Detail: "last run failed: " + strings.Join(failed, ", ")}
+		}
+		return status.Component{Status: status.StateOK}
+	})
+
+	h.AddCheck("notifier", func(context.Context) status.Component {
+		var failed []string
+		for _, kind := range notificationJobs {
+			if o, ok := jobQueue.LastOutcome(kind); ok && o.Err != nil {
+				failed = append(failed, kind)
+			}
+		}
+		if len(failed) > 0 {
+			return status.Component{Status: status.StateDegraded, Detail: "last run failed: " + strings.Join(failed, ", ")}
+		}
+		return status.Component{Status: status.StateOK}
+	})
+	return h
+}
+
+// serveMetrics serves /metrics on addr until ctx is cancelled.
+func serveMetrics(ctx context.Context, addr string, metrics http.Handler) error {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics)
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("metrics server: %w", err)
+	}
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+	go func() {
+		<-ctx.Done()
+		srv.Close()
+	}()
+
+	slog.Info("metrics server listening", "addr", ln.Addr().String())
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("metrics server error", "error", err)
+		}
+	}()
+	return nil
+}
+
+// reloadLogging reopens the log file and applies LOG_LEVEL again, undoing any
+// change made with SetLogLevel.
+func reloadLogging(logger *logging.Logger) {
+	if err := logger.Reopen(); err != nil {
+		slog.Error("failed to reopen log file", "error", err)
+	}
+	level, err := config.ReloadLogLevel()
+	if err != nil {
+		slog.Error("failed to reload log level", "error", err)
+		return
+	}
+	logger.LevelVar().Set(level)
+	slog.Info("logging reloaded", "level", level)
+}
+
+// Job kinds handled by the edge gateway itself.
+const (
+	jobDeadLetterNotifications      = "dead_letter_notifications"
+	jobSLOBreachNotification        = "slo_breach_notification"
+	jobEndpointArchivedNotification = "endpoint_archived_notification"
+)
+
+// sendDeadLetterNotifications sends notifications for recently dead-lettered
+// webhooks. It returns the last notification error, so the job is retried for
+// the webhooks that are still unnotified.
+func sendDeadLetterNotifications(ctx context.Context, queries *db.Queries, notifier notify.Notifier) error {
 	// Get unnotified dead letters (limit to prevent spam)
 	rows, err := queries.GetUnnotifiedDeadLetters(ctx, 50)
 	if err != nil {
 		slog.Error("failed to get dead letter webhooks", "error", err)
-		return
+		return err
 	}
 
+	var lastErr error
+
 	for _, row := range rows {
-		// Parse received_at time
-		receivedAt, _ := time.Parse("2006-01-02 15:04:05", row.ReceivedAt)
+		receivedAt, err := db.ParseTime(row.ReceivedAt)
+		if err != nil {
+			slog.Warn("dead letter has an invalid received_at", "webhook_id", row.ID, "error", err)
+		}
 
 		info := notify.WebhookInfo{
 			ID:             row.ID,
@@ -248,7 +656,8 @@ func sendDeadLetterNotifications(ctx context.Context, queries *db.Queries, notif
 		}
 
 		if err := notifier.NotifyDeadLetter(ctx, info); err != nil {
-			// Log but continue with other notifications
+			// Continue with other notifications
+			lastErr = err
 			continue
 		}
 
@@ -257,4 +666,29 @@ func sendDeadLetterNotifications(ctx context.Context, queries *db.Queries, notif
 			slog.Error("failed to mark notification sent", "webhook_id", row.ID, "error", err)
 		}
 	}
+	return lastErr
+}
+
+// checkConfig logs every configuration problem. It fails unless all of them
+// are non-fatal and allowDegraded is set.
+func checkConfig(cfg *config.Config, allowDegraded bool) error {
+	var verr *config.ValidationError
+	if err := cfg.Validate(); !errors.As(err, &verr) {
+		return err
+	}
+
+	if allowDegraded && !verr.Fatal() {
+		for _, p := range verr.Problems {
+			slog.Warn("configuration problem, running degraded", "key", p.Key, "problem", p.Message)
+		}
+		return nil
+	}
+
+	for _, p := range verr.Problems {
+		slog.Error("configuration problem", "key", p.Key, "problem", p.Message, "fatal", p.Fatal)
+	}
+	if verr.Fatal() {
+		return fmt.Errorf("invalid configuration: %d problem(s)", len(verr.Problems))
+	}
+	return fmt.Errorf("incomplete configuration: %d problem(s); fix them or start with --allow-degraded", len(verr.Problems))
 }
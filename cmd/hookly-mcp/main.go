@@ -3,14 +3,18 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
 	"os"
 
+	"hooks.dx314.com/internal/auth"
 	"hooks.dx314.com/internal/cli"
 	"hooks.dx314.com/internal/crypto"
 	"hooks.dx314.com/internal/db"
+	"hooks.dx314.com/internal/kms"
 	"hooks.dx314.com/internal/mcp"
+	"hooks.dx314.com/internal/webhook"
 
 	"github.com/joho/godotenv"
 )
@@ -30,6 +34,11 @@ func main() {
 func run() error {
 	ctx := context.Background()
 
+	// Read-only mode offers only the list, get, status and summary tools
+	readOnly := flag.Bool("read-only", os.Getenv("HOOKLY_MCP_READ_ONLY") == "true", "only offer tools that change nothing (also HOOKLY_MCP_READ_ONLY=true)")
+	org := flag.String("org", os.Getenv("HOOKLY_ORG"), "act on the endpoints of this organization instead of your own (also HOOKLY_ORG)")
+	flag.Parse()
+
 	// Load .env file if present
 	_ = godotenv.Load()
 
@@ -53,13 +62,28 @@ func run() error {
 		databasePath = "./hookly.db"
 	}
 
-	keyHex := os.Getenv("ENCRYPTION_KEY")
-	if keyHex == "" {
-		return errors.New("ENCRYPTION_KEY is required")
-	}
-	key, err := crypto.ParseKey(keyHex)
-	if err != nil {
-		return fmt.Errorf("invalid ENCRYPTION_KEY: %w", err)
+	var key []byte
+	if source := os.Getenv("ENCRYPTION_KEY_SOURCE"); source != "" && source != kms.SourceEnv {
+		provider, err := kms.NewProvider(source, os.Getenv)
+		if err != nil {
+			return err
+		}
+		key, err = kms.LoadDataKey(ctx, provider, os.Getenv("ENCRYPTION_KEY_WRAPPED"))
+		if err != nil {
+			return err
+		}
+		if r, ok := provider.(kms.Renewer); ok {
+			go r.Renew(ctx)
+		}
+	} else {
+		keyHex := os.Getenv("ENCRYPTION_KEY")
+		if keyHex == "" {
+			return errors.New("ENCRYPTION_KEY is required")
+		}
+		key, err = crypto.ParseKey(keyHex)
+		if err != nil {
+			return fmt.Errorf("invalid ENCRYPTION_KEY: %w", err)
+		}
 	}
 
 	baseURL := os.Getenv("BASE_URL")
@@ -77,7 +101,30 @@ func run() error {
 	queries := db.New(conn)
 	secretManager := db.NewSecretManager(key)
 
+	// An org's endpoints are owned by its owner ID; viewers only read them
+	ownerID := creds.UserID
+	if *org != "" {
+		role, err := auth.NewOrgResolver(queries).Role(ctx, creds.UserID, *org)
+		if err != nil {
+			return fmt.Errorf("org %s: %w", *org, err)
+		}
+		ownerID = auth.OrgOwnerID(*org)
+		if !auth.RoleCanWrite(role) {
+			*readOnly = true
+		}
+	}
+
 	// Create and run MCP server using credentials from CLI
-	server := mcp.NewServer(queries, secretManager, baseURL, creds.UserID)
+	server := mcp.NewServer(queries, secretManager, baseURL, ownerID, creds.Username)
+	if v := os.Getenv("WEBHOOK_PATH_PREFIX"); v != "" {
+		prefix, err := webhook.CleanPathPrefix(v)
+		if err != nil {
+			return fmt.Errorf("invalid WEBHOOK_PATH_PREFIX: %w", err)
+		}
+		server.SetWebhookPathPrefix(prefix)
+	}
+	if *readOnly {
+		server.SetReadOnly()
+	}
 	return server.ServeStdio()
 }
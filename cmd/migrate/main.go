@@ -16,7 +16,7 @@ import (
 func main() {
 	if len(os.Args) < 2 {
 		fmt.Println("Usage: migrate <command> [database]")
-		fmt.Println("Commands: up, down, status, baseline")
+		fmt.Println("Commands: up, down, status, baseline, analyze")
 		fmt.Println("Database path from DATABASE_PATH env or argument (default: ./hookly.db)")
 		os.Exit(1)
 	}
@@ -38,6 +38,9 @@ func main() {
 		os.Exit(1)
 	}
 	defer database.Close()
+	// Migrations that toggle PRAGMA foreign_keys need every statement on the
+	// same connection
+	database.SetMaxOpenConns(1)
 
 	ctx := context.Background()
 
@@ -75,12 +78,45 @@ func main() {
 			os.Exit(1)
 		}
 
+	case "analyze":
+		warnings, err := analyze(ctx, database)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Analyze failed: %v\n", err)
+			os.Exit(1)
+		}
+		if warnings > 0 {
+			fmt.Printf("%d warning(s); apply pending migrations with `migrate up`\n", warnings)
+			os.Exit(2)
+		}
+		fmt.Println("All hot queries use their indexes")
+
 	default:
 		fmt.Fprintf(os.Stderr, "Unknown command: %s\n", command)
 		os.Exit(1)
 	}
 }
 
+// analyze prints the query plan of each hot webhooks query with its warnings
+// and returns the number of warnings.
+func analyze(ctx context.Context, database *sql.DB) (int, error) {
+	plans, err := db.Analyze(ctx, database)
+	if err != nil {
+		return 0, err
+	}
+	warnings := 0
+	for _, p := range plans {
+		fmt.Println(p.Query)
+		for _, step := range p.Plan {
+			fmt.Printf("    %s\n", step)
+		}
+		for _, w := range p.Warnings {
+			fmt.Printf("  ! %s\n", w)
+		}
+		warnings += len(p.Warnings)
+	}
+	return warnings, nil
+}
+
 // baseline marks migrations 1-2 as applied for existing production databases.
 // This should only be run once on databases that existed before goose was added.
 func baseline(ctx context.Context, database *sql.DB) error {
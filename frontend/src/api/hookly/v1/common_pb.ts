@@ -12,7 +12,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file hookly/v1/common.proto.
  */
 export const file_hookly_v1_common: GenFile = /*@__PURE__*/
-  fileDesc("ChZob29rbHkvdjEvY29tbW9uLnByb3RvEglob29rbHkudjEirgEKElZlcmlmaWNhdGlvbkNvbmZpZxItCgZtZXRob2QYASABKA4yHS5ob29rbHkudjEuVmVyaWZpY2F0aW9uTWV0aG9kEhgKEHNpZ25hdHVyZV9oZWFkZXIYAiABKAkSGAoQc2lnbmF0dXJlX3ByZWZpeBgDIAEoCRIYChB0aW1lc3RhbXBfaGVhZGVyGAQgASgJEhsKE3RpbWVzdGFtcF90b2xlcmFuY2UYBSABKAMimAIKCEVuZHBvaW50EgoKAmlkGAEgASgJEgwKBG5hbWUYAiABKAkSLgoNcHJvdmlkZXJfdHlwZRgDIAEoDjIXLmhvb2tseS52MS5Qcm92aWRlclR5cGUSFwoPZGVzdGluYXRpb25fdXJsGAQgASgJEg0KBW11dGVkGAUgASgIEi4KCmNyZWF0ZWRfYXQYBiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCnVwZGF0ZWRfYXQYByABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjoKE3ZlcmlmaWNhdGlvbl9jb25maWcYCCABKAsyHS5ob29rbHkudjEuVmVyaWZpY2F0aW9uQ29uZmlnIqEDCgdXZWJob29rEgoKAmlkGAEgASgJEhMKC2VuZHBvaW50X2lkGAIgASgJEi8KC3JlY2VpdmVkX2F0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIwCgdoZWFkZXJzGAQgAygLMh8uaG9va2x5LnYxLldlYmhvb2suSGVhZGVyc0VudHJ5Eg8KB3BheWxvYWQYBSABKAwSFwoPc2lnbmF0dXJlX3ZhbGlkGAYgASgIEigKBnN0YXR1cxgHIAEoDjIYLmhvb2tseS52MS5XZWJob29rU3RhdHVzEhAKCGF0dGVtcHRzGAggASgFEjMKD2xhc3RfYXR0ZW1wdF9hdBgJIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASMAoMZGVsaXZlcmVkX2F0GAogASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIVCg1lcnJvcl9tZXNzYWdlGAsgASgJGi4KDEhlYWRlcnNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIjoKEVBhZ2luYXRpb25SZXF1ZXN0EhEKCXBhZ2Vfc2l6ZRgBIAEoBRISCgpwYWdlX3Rva2VuGAIgASgJIkIKElBhZ2luYXRpb25SZXNwb25zZRIXCg9uZXh0X3BhZ2VfdG9rZW4YASABKAkSEwoLdG90YWxfY291bnQYAiABKAUiLQoRQ29ubmVjdGVkRW5kcG9pbnQSCgoCaWQYASABKAkSDAoEbmFtZRgCIAEoCSLyAQoMU3lzdGVtU3RhdHVzEhUKDXBlbmRpbmdfY291bnQYASABKAUSFAoMZmFpbGVkX2NvdW50GAIgASgFEhkKEWRlYWRfbGV0dGVyX2NvdW50GAMgASgFEh4KEmhvbWVfaHViX2Nvbm5lY3RlZBgEIAEoCEICGAESPwoXbGFzdF9ob21lX2h1Yl9oZWFydGJlYXQYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wQgIYARI5ChNjb25uZWN0ZWRfZW5kcG9pbnRzGAYgAygLMhwuaG9va2x5LnYxLkNvbm5lY3RlZEVuZHBvaW50IrwDCgxVc2VyU2V0dGluZ3MSDwoHdXNlcl9pZBgBIAEoCRIQCgh1c2VybmFtZRgCIAEoCRITCgtnaXRodWJfbmFtZRgDIAEoCRIUCgxnaXRodWJfZW1haWwYBCABKAkSGgoSZ2l0aHViX3Byb2ZpbGVfdXJsGAUgASgJEhIKCmF2YXRhcl91cmwYBiABKAkSGwoTdGVsZWdyYW1fY29uZmlndXJlZBgHIAEoCBIYChB0ZWxlZ3JhbV9jaGF0X2lkGAggASgJEhgKEHRlbGVncmFtX2VuYWJsZWQYCSABKAgSNAoQdGhlbWVfcHJlZmVyZW5jZRgKIAEoDjIaLmhvb2tseS52MS5UaGVtZVByZWZlcmVuY2USFAoMaXNfc3VwZXJ1c2VyGAsgASgIEi4KCmNyZWF0ZWRfYXQYDCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCnVwZGF0ZWRfYXQYDSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjEKDWxhc3RfbG9naW5fYXQYDiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wIqMBCg5TeXN0ZW1TZXR0aW5ncxIQCghiYXNlX3VybBgBIAEoCRISCgpnaXRodWJfb3JnGAIgASgJEhwKFGdpdGh1Yl9hbGxvd2VkX3VzZXJzGAMgAygJEh8KF3N5c3RlbV90ZWxlZ3JhbV9lbmFibGVkGAQgASgIEhMKC3RvdGFsX3VzZXJzGAUgASgFEhcKD3RvdGFsX2VuZHBvaW50cxgGIAEoBSqyAQoMUHJvdmlkZXJUeXBlEh0KGVBST1ZJREVSX1RZUEVfVU5TUEVDSUZJRUQQABIYChRQUk9WSURFUl9UWVBFX1NUUklQRRABEhgKFFBST1ZJREVSX1RZUEVfR0lUSFVCEAISGgoWUFJPVklERVJfVFlQRV9URUxFR1JBTRADEhkKFVBST1ZJREVSX1RZUEVfR0VORVJJQxAEEhgKFFBST1ZJREVSX1RZUEVfQ1VTVE9NEAUqywEKElZlcmlmaWNhdGlvbk1ldGhvZBIjCh9WRVJJRklDQVRJT05fTUVUSE9EX1VOU1BFQ0lGSUVEEAASHgoaVkVSSUZJQ0FUSU9OX01FVEhPRF9TVEFUSUMQARIjCh9WRVJJRklDQVRJT05fTUVUSE9EX0hNQUNfU0hBMjU2EAISIQodVkVSSUZJQ0FUSU9OX01FVEhPRF9ITUFDX1NIQTEQAxIoCiRWRVJJRklDQVRJT05fTUVUSE9EX1RJTUVTVEFNUEVEX0hNQUMQBCqkAQoNV2ViaG9va1N0YXR1cxIeChpXRUJIT09LX1NUQVRVU19VTlNQRUNJRklFRBAAEhoKFldFQkhPT0tfU1RBVFVTX1BFTkRJTkcQARIcChhXRUJIT09LX1NUQVRVU19ERUxJVkVSRUQQAhIZChVXRUJIT09LX1NUQVRVU19GQUlMRUQQAxIeChpXRUJIT09LX1NUQVRVU19ERUFEX0xFVFRFUhAEKtYBCg9UaGVtZVByZWZlcmVuY2USIAocVEhFTUVfUFJFRkVSRU5DRV9VTlNQRUNJRklFRBAAEhsKF1RIRU1FX1BSRUZFUkVOQ0VfU1lTVEVNEAESGgoWVEhFTUVfUFJFRkVSRU5DRV9MSUdIVBACEhkKFVRIRU1FX1BSRUZFUkVOQ0VfREFSSxADEiYKIlRIRU1FX1BSRUZFUkVOQ0VfUExBQ0lEX0JMVUVfTElHSFQQBBIlCiFUSEVNRV9QUkVGRVJFTkNFX1BMQUNJRF9CTFVFX0RBUksQBUKSAQoNY29tLmhvb2tseS52MUILQ29tbW9uUHJvdG9QAVovaG9va3MuZHgzMTQuY29tL2ludGVybmFsL2FwaS9ob29rbHkvdjE7aG9va2x5djGiAgNIWFiqAglIb29rbHkuVjHKAglIb29rbHlcVjHiAhVIb29rbHlcVjFcR1BCTWV0YWRhdGHqAgpIb29rbHk6OlYxYgZwcm90bzM", [file_google_protobuf_timestamp]);
+  fileDesc("ChZob29rbHkvdjEvY29tbW9uLnByb3RvEglob29rbHkudjEirgEKElZlcmlmaWNhdGlvbkNvbmZpZxItCgZtZXRob2QYASABKA4yHS5ob29rbHkudjEuVmVyaWZpY2F0aW9uTWV0aG9kEhgKEHNpZ25hdHVyZV9oZWFkZXIYAiABKAkSGAoQc2lnbmF0dXJlX3ByZWZpeBgDIAEoCRIYChB0aW1lc3RhbXBfaGVhZGVyGAQgASgJEhsKE3RpbWVzdGFtcF90b2xlcmFuY2UYBSABKAMiawoKSW5nZXN0QXV0aBIrCgZtZXRob2QYASABKA4yGy5ob29rbHkudjEuSW5nZXN0QXV0aE1ldGhvZBIQCgh1c2VybmFtZRgCIAEoCRIOCgZoZWFkZXIYAyABKAkSDgoGc2VjcmV0GAQgASgJIpIBCglUcmFuc2Zvcm0SDwoHZXh0cmFjdBgBIAEoCRIQCgh0ZW1wbGF0ZRgCIAEoCRIyCgdoZWFkZXJzGAMgAygLMiEuaG9va2x5LnYxLlRyYW5zZm9ybS5IZWFkZXJzRW50cnkaLgoMSGVhZGVyc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEiSQoOSW5nZXN0UmVzcG9uc2USEwoLc3RhdHVzX2NvZGUYASABKAUSDAoEYm9keRgCIAEoCRIUCgxjb250ZW50X3R5cGUYAyABKAkibwoLUmV0cnlQb2xpY3kSFAoMbWF4X2F0dGVtcHRzGAEgASgFEhwKFGJhY2tvZmZfYmFzZV9zZWNvbmRzGAIgASgFEhwKFG1heF9pbnRlcnZhbF9zZWNvbmRzGAMgASgFEg4KBmppdHRlchgEIAEoASI5Cg1QYXlsb2FkTGltaXRzEhEKCW1heF9ieXRlcxgBIAEoAxIVCg1jb250ZW50X3R5cGVzGAIgAygJIiYKC0Rlc3RpbmF0aW9uEgoKAmlkGAEgASgJEgsKA3VybBgCIAEoCSKJAgoTRGVzdGluYXRpb25EZWxpdmVyeRIWCg5kZXN0aW5hdGlvbl9pZBgBIAEoCRILCgN1cmwYAiABKAkSKAoGc3RhdHVzGAMgASgOMhguaG9va2x5LnYxLldlYmhvb2tTdGF0dXMSEAoIYXR0ZW1wdHMYBCABKAUSEwoLc3RhdHVzX2NvZGUYBSABKAUSFQoNZXJyb3JfbWVzc2FnZRgGIAEoCRIzCg9sYXN0X2F0dGVtcHRfYXQYByABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjAKDGRlbGl2ZXJlZF9hdBgIIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXAivAgKCEVuZHBvaW50EgoKAmlkGAEgASgJEgwKBG5hbWUYAiABKAkSLgoNcHJvdmlkZXJfdHlwZRgDIAEoDjIXLmhvb2tseS52MS5Qcm92aWRlclR5cGUSFwoPZGVzdGluYXRpb25fdXJsGAQgASgJEg0KBW11dGVkGAUgASgIEi4KCmNyZWF0ZWRfYXQYBiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCnVwZGF0ZWRfYXQYByABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjoKE3ZlcmlmaWNhdGlvbl9jb25maWcYCCABKAsyHS5ob29rbHkudjEuVmVyaWZpY2F0aW9uQ29uZmlnEhoKEm5vdGlmeV9maXJzdF9ldmVudBgJIAEoCBIyCg5maXJzdF9ldmVudF9hdBgKIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASHgoWaGFzX3RlbGVncmFtX2JvdF90b2tlbhgLIAEoCBISCgpzbG9fdGFyZ2V0GAwgASgBEhsKE3Nsb19sYXRlbmN5X3NlY29uZHMYDSABKAUSGAoQc2xvX3dpbmRvd19ob3VycxgOIAEoBRIZChFyZWplY3RfZHVwbGljYXRlcxgPIAEoCBITCgtob21lX3JlZ2lvbhgQIAEoCRIqCgtpbmdlc3RfYXV0aBgRIAEoCzIVLmhvb2tseS52MS5Jbmdlc3RBdXRoEhAKCGhvbmV5cG90GBIgASgIEjwKGGxhc3Rfd2ViaG9va19yZWNlaXZlZF9hdBgTIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASNQoRbGFzdF9kZWxpdmVyZWRfYXQYFCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi8KC2FyY2hpdmVkX2F0GBUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIdChVjb25mbGljdF9hc19kdXBsaWNhdGUYFiABKAgSHQoVcmF0ZV9saW1pdF9wZXJfbWludXRlGBcgASgFEicKCXRyYW5zZm9ybRgYIAEoCzIULmhvb2tseS52MS5UcmFuc2Zvcm0SLAoMZGVzdGluYXRpb25zGBkgAygLMhYuaG9va2x5LnYxLkRlc3RpbmF0aW9uEhUKDWFuc3dlcl9wcm9iZXMYGiABKAgSMgoPaW5nZXN0X3Jlc3BvbnNlGBsgASgLMhkuaG9va2x5LnYxLkluZ2VzdFJlc3BvbnNlEiwKDHJldHJ5X3BvbGljeRgcIAEoCzIWLmhvb2tseS52MS5SZXRyeVBvbGljeRIwCg5wYXlsb2FkX2xpbWl0cxgdIAEoCzIYLmhvb2tseS52MS5QYXlsb2FkTGltaXRzEhMKC3dlYmhvb2tfdXJsGB4gASgJIsgGCgdXZWJob29rEgoKAmlkGAEgASgJEhMKC2VuZHBvaW50X2lkGAIgASgJEi8KC3JlY2VpdmVkX2F0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIwCgdoZWFkZXJzGAQgAygLMh8uaG9va2x5LnYxLldlYmhvb2suSGVhZGVyc0VudHJ5Eg8KB3BheWxvYWQYBSABKAwSFwoPc2lnbmF0dXJlX3ZhbGlkGAYgASgIEigKBnN0YXR1cxgHIAEoDjIYLmhvb2tseS52MS5XZWJob29rU3RhdHVzEhAKCGF0dGVtcHRzGAggASgFEjMKD2xhc3RfYXR0ZW1wdF9hdBgJIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASMAoMZGVsaXZlcmVkX2F0GAogASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIVCg1lcnJvcl9tZXNzYWdlGAsgASgJEhIKCmV2ZW50X3R5cGUYDCABKAkSFwoPcGF5bG9hZF9wcmV2aWV3GA0gASgMEhQKDHBheWxvYWRfc2l6ZRgOIAEoAxIZChFwYXlsb2FkX3RydW5jYXRlZBgPIAEoCBITCgtkZWxpdmVyeV9pZBgQIAEoCRIUCgxkdXBsaWNhdGVfb2YYESABKAkSEQoJc291cmNlX2lwGBIgASgJEjYKDnN0YXR1c19oaXN0b3J5GBMgAygLMh4uaG9va2x5LnYxLldlYmhvb2tTdGF0dXNDaGFuZ2USLwoLcmVwbGF5ZWRfYXQYFCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhMKC3JlcGxheWVkX2J5GBUgASgJEhQKDHJlcGxheV9jb3VudBgWIAEoBRIQCgh0cmFjZV9pZBgXIAEoCRItCglwdXJnZWRfYXQYGCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjQKEHB1cmdlX2V4cGlyZXNfYXQYGSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wGi4KDEhlYWRlcnNFbnRyeRILCgNrZXkYASABKAkSDQoFdmFsdWUYAiABKAk6AjgBIsUBChNXZWJob29rU3RhdHVzQ2hhbmdlEi0KC2Zyb21fc3RhdHVzGAEgASgOMhguaG9va2x5LnYxLldlYmhvb2tTdGF0dXMSKwoJdG9fc3RhdHVzGAIgASgOMhguaG9va2x5LnYxLldlYmhvb2tTdGF0dXMSDgoGcmVhc29uGAMgASgJEi4KCmNoYW5nZWRfYXQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhIKCmNoYW5nZWRfYnkYBSABKAkiOgoRUGFnaW5hdGlvblJlcXVlc3QSEQoJcGFnZV9zaXplGAEgASgFEhIKCnBhZ2VfdG9rZW4YAiABKAkiQgoSUGFnaW5hdGlvblJlc3BvbnNlEhcKD25leHRfcGFnZV90b2tlbhgBIAEoCRITCgt0b3RhbF9jb3VudBgCIAEoBSItChFDb25uZWN0ZWRFbmRwb2ludBIKCgJpZBgBIAEoCRIMCgRuYW1lGAIgASgJIkcKE1JhdGVMaW1pdGVkRW5kcG9pbnQSCgoCaWQYASABKAkSDAoEbmFtZRgCIAEoCRIWCg5yZWplY3RlZF9jb3VudBgDIAEoBCLAAQoMQ29ubmVjdGVkSHViEg4KBmh1Yl9pZBgBIAEoCRIRCgl0cmFuc3BvcnQYAiABKAkSFAoMZW5kcG9pbnRfaWRzGAMgAygJEjAKDGNvbm5lY3RlZF9hdBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASNQoRbGFzdF9oZWFydGJlYXRfYXQYBSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEg4KBnBhdXNlZBgGIAEoCCJOChBIdWJDb21tYW5kUmVzdWx0EgoKAmlkGAEgASgJEg8KB3N1Y2Nlc3MYAiABKAgSDQoFZXJyb3IYAyABKAkSDgoGb3V0cHV0GAQgASgJIpgDCgxTeXN0ZW1TdGF0dXMSFQoNcGVuZGluZ19jb3VudBgBIAEoBRIUCgxmYWlsZWRfY291bnQYAiABKAUSGQoRZGVhZF9sZXR0ZXJfY291bnQYAyABKAUSHgoSaG9tZV9odWJfY29ubmVjdGVkGAQgASgIQgIYARI/ChdsYXN0X2hvbWVfaHViX2hlYXJ0YmVhdBgFIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXBCAhgBEjkKE2Nvbm5lY3RlZF9lbmRwb2ludHMYBiADKAsyHC5ob29rbHkudjEuQ29ubmVjdGVkRW5kcG9pbnQSMwoQbWFpbnRlbmFuY2Vfam9icxgHIAMoCzIZLmhvb2tseS52MS5NYWludGVuYW5jZUpvYhIvCg5jb25uZWN0ZWRfaHVicxgIIAMoCzIXLmhvb2tseS52MS5Db25uZWN0ZWRIdWISPgoWcmF0ZV9saW1pdGVkX2VuZHBvaW50cxgJIAMoCzIeLmhvb2tseS52MS5SYXRlTGltaXRlZEVuZHBvaW50Iq4BCg5NYWludGVuYW5jZUpvYhIMCgRuYW1lGAEgASgJEi8KC2xhc3RfcnVuX2F0GAIgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIvCgtuZXh0X3J1bl9hdBgDIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASGAoQbGFzdF9kdXJhdGlvbl9tcxgEIAEoAxISCgpsYXN0X2Vycm9yGAUgASgJIuIECgxVc2VyU2V0dGluZ3MSDwoHdXNlcl9pZBgBIAEoCRIQCgh1c2VybmFtZRgCIAEoCRITCgtnaXRodWJfbmFtZRgDIAEoCRIUCgxnaXRodWJfZW1haWwYBCABKAkSGgoSZ2l0aHViX3Byb2ZpbGVfdXJsGAUgASgJEhIKCmF2YXRhcl91cmwYBiABKAkSGwoTdGVsZWdyYW1fY29uZmlndXJlZBgHIAEoCBIYChB0ZWxlZ3JhbV9jaGF0X2lkGAggASgJEhgKEHRlbGVncmFtX2VuYWJsZWQYCSABKAgSNAoQdGhlbWVfcHJlZmVyZW5jZRgKIAEoDjIaLmhvb2tseS52MS5UaGVtZVByZWZlcmVuY2USFAoMaXNfc3VwZXJ1c2VyGAsgASgIEi4KCmNyZWF0ZWRfYXQYDCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEi4KCnVwZGF0ZWRfYXQYDSABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjEKDWxhc3RfbG9naW5fYXQYDiABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEhoKEmRpc2NvcmRfY29uZmlndXJlZBgPIAEoCBIXCg9kaXNjb3JkX2VuYWJsZWQYECABKAgSFQoNZW1haWxfYWRkcmVzcxgRIAEoCRIVCg1lbWFpbF9lbmFibGVkGBIgASgIEiEKGW5vdGlmeV93ZWJob29rX2NvbmZpZ3VyZWQYEyABKAgSHgoWbm90aWZ5X3dlYmhvb2tfZW5hYmxlZBgUIAEoCCLtAQoIQXBpVG9rZW4SCgoCaWQYASABKAkSDAoEbmFtZRgCIAEoCRIuCgpjcmVhdGVkX2F0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIwCgxsYXN0X3VzZWRfYXQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEiQKBXNjb3BlGAUgASgOMhUuaG9va2x5LnYxLlRva2VuU2NvcGUSLgoKZXhwaXJlc19hdBgGIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASDwoHZXhwaXJlZBgHIAEoCCJxCgNPcmcSCgoCaWQYASABKAkSDAoEbmFtZRgCIAEoCRIuCgpjcmVhdGVkX2F0GAMgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIgCgRyb2xlGAQgASgOMhIuaG9va2x5LnYxLk9yZ1JvbGUigAEKCU9yZ01lbWJlchIPCgd1c2VyX2lkGAEgASgJEhAKCHVzZXJuYW1lGAIgASgJEiAKBHJvbGUYAyABKA4yEi5ob29rbHkudjEuT3JnUm9sZRIuCgpjcmVhdGVkX2F0GAQgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCLKAQoKQXVkaXRFdmVudBIKCgJpZBgBIAEoCRIPCgd1c2VyX2lkGAIgASgJEhAKCHVzZXJuYW1lGAMgASgJEhAKCHRva2VuX2lkGAQgASgJEg4KBmFjdGlvbhgFIAEoCRIOCgZ0YXJnZXQYBiABKAkSCgoCaXAYByABKAkSDgoGcmVzdWx0GAggASgJEi8KC29jY3VycmVkX2F0GAkgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIOCgZvcmdfaWQYCiABKAkiiAIKDlN5c3RlbVNldHRpbmdzEhAKCGJhc2VfdXJsGAEgASgJEhIKCmdpdGh1Yl9vcmcYAiABKAkSHAoUZ2l0aHViX2FsbG93ZWRfdXNlcnMYAyADKAkSHwoXc3lzdGVtX3RlbGVncmFtX2VuYWJsZWQYBCABKAgSEwoLdG90YWxfdXNlcnMYBSABKAUSFwoPdG90YWxfZW5kcG9pbnRzGAYgASgFEh4KFnN5c3RlbV9kaXNjb3JkX2VuYWJsZWQYByABKAgSHAoUc3lzdGVtX2VtYWlsX2VuYWJsZWQYCCABKAgSJQodc3lzdGVtX25vdGlmeV93ZWJob29rX2VuYWJsZWQYCSABKAgiywEKD0Nvbm5lY3Rpb25FdmVudBIKCgJpZBgBIAEoCRITCgtlbmRwb2ludF9pZBgCIAEoCRIVCg1lbmRwb2ludF9uYW1lGAMgASgJEg4KBmh1Yl9pZBgEIAEoCRIsCgR0eXBlGAUgASgOMh4uaG9va2x5LnYxLkNvbm5lY3Rpb25FdmVudFR5cGUSEQoJdHJhbnNwb3J0GAYgASgJEi8KC29jY3VycmVkX2F0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCLtAQoMQWN0aXZpdHlJdGVtEgoKAmlkGAEgASgJEiUKBGtpbmQYAiABKA4yFy5ob29rbHkudjEuQWN0aXZpdHlLaW5kEhMKC2VuZHBvaW50X2lkGAMgASgJEhUKDWVuZHBvaW50X25hbWUYBCABKAkSDgoGaHViX2lkGAUgASgJEg0KBWNvdW50GAYgASgFEi8KC29jY3VycmVkX2F0GAcgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIuCgp1cGRhdGVkX2F0GAggASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCKYAQoGUmVnaW9uEgwKBG5hbWUYASABKAkSCwoDdXJsGAIgASgJEg8KB2hlYWx0aHkYAyABKAgSEgoKbGF0ZW5jeV9tcxgEIAEoAxINCgVlcnJvchgFIAEoCRIuCgpjaGVja2VkX2F0GAYgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcBIPCgdjdXJyZW50GAcgASgIKpoCCgxQcm92aWRlclR5cGUSHQoZUFJPVklERVJfVFlQRV9VTlNQRUNJRklFRBAAEhgKFFBST1ZJREVSX1RZUEVfU1RSSVBFEAESGAoUUFJPVklERVJfVFlQRV9HSVRIVUIQAhIaChZQUk9WSURFUl9UWVBFX1RFTEVHUkFNEAMSGQoVUFJPVklERVJfVFlQRV9HRU5FUklDEAQSGAoUUFJPVklERVJfVFlQRV9DVVNUT00QBRIXChNQUk9WSURFUl9UWVBFX1NMQUNLEAYSGQoVUFJPVklERVJfVFlQRV9TSE9QSUZZEAcSGAoUUFJPVklERVJfVFlQRV9TUVVBUkUQCBIYChRQUk9WSURFUl9UWVBFX1BBWVBBTBAJKssBChJWZXJpZmljYXRpb25NZXRob2QSIwofVkVSSUZJQ0FUSU9OX01FVEhPRF9VTlNQRUNJRklFRBAAEh4KGlZFUklGSUNBVElPTl9NRVRIT0RfU1RBVElDEAESIwofVkVSSUZJQ0FUSU9OX01FVEhPRF9ITUFDX1NIQTI1NhACEiEKHVZFUklGSUNBVElPTl9NRVRIT0RfSE1BQ19TSEExEAMSKAokVkVSSUZJQ0FUSU9OX01FVEhPRF9USU1FU1RBTVBFRF9ITUFDEAQqcwoQSW5nZXN0QXV0aE1ldGhvZBIiCh5JTkdFU1RfQVVUSF9NRVRIT0RfVU5TUEVDSUZJRUQQABIcChhJTkdFU1RfQVVUSF9NRVRIT0RfQkFTSUMQARIdChlJTkdFU1RfQVVUSF9NRVRIT0RfSEVBREVSEAIqbQoMRW5kcG9pbnRTb3J0Eh0KGUVORFBPSU5UX1NPUlRfVU5TUEVDSUZJRUQQABIdChlFTkRQT0lOVF9TT1JUX0NSRUFURURfQVNDEAESHwobRU5EUE9JTlRfU09SVF9MQVNUX1JFQ0VJVkVEEAIq6wEKDVdlYmhvb2tTdGF0dXMSHgoaV0VCSE9PS19TVEFUVVNfVU5TUEVDSUZJRUQQABIaChZXRUJIT09LX1NUQVRVU19QRU5ESU5HEAESHAoYV0VCSE9PS19TVEFUVVNfREVMSVZFUkVEEAISGQoVV0VCSE9PS19TVEFUVVNfRkFJTEVEEAMSHgoaV0VCSE9PS19TVEFUVVNfREVBRF9MRVRURVIQBBIaChZXRUJIT09LX1NUQVRVU19TS0lQUEVEEAUSKQolV0VCSE9PS19TVEFUVVNfQUNLTk9XTEVER0VEX0RVUExJQ0FURRAGKu0BCg5IdWJDb21tYW5kVHlwZRIgChxIVUJfQ09NTUFORF9UWVBFX1VOU1BFQ0lGSUVEEAASIgoeSFVCX0NPTU1BTkRfVFlQRV9SRUxPQURfQ09ORklHEAESGgoWSFVCX0NPTU1BTkRfVFlQRV9QQVVTRRACEhsKF0hVQl9DT01NQU5EX1RZUEVfUkVTVU1FEAMSIAocSFVCX0NPTU1BTkRfVFlQRV9ESUFHTk9TVElDUxAEEh8KG0hVQl9DT01NQU5EX1RZUEVfRElTQ09OTkVDVBAFEhkKFUhVQl9DT01NQU5EX1RZUEVfTE9HUxAGKtYBCg9UaGVtZVByZWZlcmVuY2USIAocVEhFTUVfUFJFRkVSRU5DRV9VTlNQRUNJRklFRBAAEhsKF1RIRU1FX1BSRUZFUkVOQ0VfU1lTVEVNEAESGgoWVEhFTUVfUFJFRkVSRU5DRV9MSUdIVBACEhkKFVRIRU1FX1BSRUZFUkVOQ0VfREFSSxADEiYKIlRIRU1FX1BSRUZFUkVOQ0VfUExBQ0lEX0JMVUVfTElHSFQQBBIlCiFUSEVNRV9QUkVGRVJFTkNFX1BMQUNJRF9CTFVFX0RBUksQBSptCgpUb2tlblNjb3BlEhsKF1RPS0VOX1NDT1BFX1VOU1BFQ0lGSUVEEAASFQoRVE9LRU5fU0NPUEVfQURNSU4QARIUChBUT0tFTl9TQ09QRV9SRUFEEAISFQoRVE9LRU5fU0NPUEVfUkVMQVkQAyphCgdPcmdSb2xlEhgKFE9SR19ST0xFX1VOU1BFQ0lGSUVEEAASEgoOT1JHX1JPTEVfT1dORVIQARITCg9PUkdfUk9MRV9NRU1CRVIQAhITCg9PUkdfUk9MRV9WSUVXRVIQAyqQAQoMQWN0aXZpdHlLaW5kEh0KGUFDVElWSVRZX0tJTkRfVU5TUEVDSUZJRUQQABIcChhBQ1RJVklUWV9LSU5EX0RFTElWRVJJRVMQARIfChtBQ1RJVklUWV9LSU5EX0hVQl9DT05ORUNURUQQAhIiCh5BQ1RJVklUWV9LSU5EX0hVQl9ESVNDT05ORUNURUQQAyqJAQoTQ29ubmVjdGlvbkV2ZW50VHlwZRIlCiFDT05ORUNUSU9OX0VWRU5UX1RZUEVfVU5TUEVDSUZJRUQQABIjCh9DT05ORUNUSU9OX0VWRU5UX1RZUEVfQ09OTkVDVEVEEAESJgoiQ09OTkVDVElPTl9FVkVOVF9UWVBFX0RJU0NPTk5FQ1RFRBACQpIBCg1jb20uaG9va2x5LnYxQgtDb21tb25Qcm90b1ABWi9ob29rcy5keDMxNC5jb20vaW50ZXJuYWwvYXBpL2hvb2tseS92MTtob29rbHl2MaICA0hYWKoCCUhvb2tseS5WMcoCCUhvb2tseVxWMeICFUhvb2tseVxWMVxHUEJNZXRhZGF0YeoCCkhvb2tseTo6VjFiBnByb3RvMw", [file_google_protobuf_timestamp]);
 
 /**
  * Custom verification configuration for PROVIDER_TYPE_CUSTOM
@@ -61,6 +61,275 @@ export type VerificationConfig = Message<"hookly.v1.VerificationConfig"> & {
 export const VerificationConfigSchema: GenMessage<VerificationConfig> = /*@__PURE__*/
   messageDesc(file_hookly_v1_common, 0);
 
+/**
+ * Ingestion auth configuration of an endpoint
+ *
+ * @generated from message hookly.v1.IngestAuth
+ */
+export type IngestAuth = Message<"hookly.v1.IngestAuth"> & {
+  /**
+   * @generated from field: hookly.v1.IngestAuthMethod method = 1;
+   */
+  method: IngestAuthMethod;
+
+  /**
+   * Basic auth user name
+   *
+   * @generated from field: string username = 2;
+   */
+  username: string;
+
+  /**
+   * Header carrying the token (default Authorization, with or without "Bearer ")
+   *
+   * @generated from field: string header = 3;
+   */
+  header: string;
+
+  /**
+   * Password or token. Write-only, encrypted at rest
+   *
+   * @generated from field: string secret = 4;
+   */
+  secret: string;
+};
+
+/**
+ * Describes the message hookly.v1.IngestAuth.
+ * Use `create(IngestAuthSchema)` to create a new message.
+ */
+export const IngestAuthSchema: GenMessage<IngestAuth> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 1);
+
+/**
+ * Rewrites a webhook on the hub before it is forwarded. extract runs
+ * first, then template; headers are set last, over the received ones.
+ *
+ * @generated from message hookly.v1.Transform
+ */
+export type Transform = Message<"hookly.v1.Transform"> & {
+  /**
+   * JSON path whose value becomes the body
+   *
+   * @generated from field: string extract = 1;
+   */
+  extract: string;
+
+  /**
+   * Go text/template producing the body
+   *
+   * @generated from field: string template = 2;
+   */
+  template: string;
+
+  /**
+   * Static headers to set
+   *
+   * @generated from field: map<string, string> headers = 3;
+   */
+  headers: { [key: string]: string };
+};
+
+/**
+ * Describes the message hookly.v1.Transform.
+ * Use `create(TransformSchema)` to create a new message.
+ */
+export const TransformSchema: GenMessage<Transform> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 2);
+
+/**
+ * What ingestion answers once a webhook is stored. Unset answers 200 with no
+ * body.
+ *
+ * @generated from message hookly.v1.IngestResponse
+ */
+export type IngestResponse = Message<"hookly.v1.IngestResponse"> & {
+  /**
+   * 2xx; 0 for 200
+   *
+   * @generated from field: int32 status_code = 1;
+   */
+  statusCode: number;
+
+  /**
+   * Static body, up to 4KB
+   *
+   * @generated from field: string body = 2;
+   */
+  body: string;
+
+  /**
+   * Defaults to text/plain when there is a body
+   *
+   * @generated from field: string content_type = 3;
+   */
+  contentType: string;
+};
+
+/**
+ * Describes the message hookly.v1.IngestResponse.
+ * Use `create(IngestResponseSchema)` to create a new message.
+ */
+export const IngestResponseSchema: GenMessage<IngestResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 3);
+
+/**
+ * How transient delivery failures of an endpoint's webhooks are retried. The
+ * zero value is the default: unlimited attempts, backoff doubling from 1s up
+ * to 1h, no jitter.
+ *
+ * @generated from message hookly.v1.RetryPolicy
+ */
+export type RetryPolicy = Message<"hookly.v1.RetryPolicy"> & {
+  /**
+   * Attempts before the webhook fails; 0 for unlimited
+   *
+   * @generated from field: int32 max_attempts = 1;
+   */
+  maxAttempts: number;
+
+  /**
+   * Delay before the first retry, doubled after each; 0 for 1s
+   *
+   * @generated from field: int32 backoff_base_seconds = 2;
+   */
+  backoffBaseSeconds: number;
+
+  /**
+   * Cap on the delay; 0 for 1h
+   *
+   * @generated from field: int32 max_interval_seconds = 3;
+   */
+  maxIntervalSeconds: number;
+
+  /**
+   * Fraction of each delay randomly taken off, in [0, 1]
+   *
+   * @generated from field: double jitter = 4;
+   */
+  jitter: number;
+};
+
+/**
+ * Describes the message hookly.v1.RetryPolicy.
+ * Use `create(RetryPolicySchema)` to create a new message.
+ */
+export const RetryPolicySchema: GenMessage<RetryPolicy> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 4);
+
+/**
+ * Restrictions on the webhooks an endpoint accepts, answered with 413 and
+ * 415. The zero value accepts any content type up to the edge's 100 MB.
+ *
+ * @generated from message hookly.v1.PayloadLimits
+ */
+export type PayloadLimits = Message<"hookly.v1.PayloadLimits"> & {
+  /**
+   * Largest payload accepted; 0 for 100 MB
+   *
+   * @generated from field: int64 max_bytes = 1;
+   */
+  maxBytes: bigint;
+
+  /**
+   * Media types accepted, type/* for any subtype; empty for any
+   *
+   * @generated from field: repeated string content_types = 2;
+   */
+  contentTypes: string[];
+};
+
+/**
+ * Describes the message hookly.v1.PayloadLimits.
+ * Use `create(PayloadLimitsSchema)` to create a new message.
+ */
+export const PayloadLimitsSchema: GenMessage<PayloadLimits> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 5);
+
+/**
+ * A destination an endpoint fans out to besides its destination_url
+ *
+ * @generated from message hookly.v1.Destination
+ */
+export type Destination = Message<"hookly.v1.Destination"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string url = 2;
+   */
+  url: string;
+};
+
+/**
+ * Describes the message hookly.v1.Destination.
+ * Use `create(DestinationSchema)` to create a new message.
+ */
+export const DestinationSchema: GenMessage<Destination> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 6);
+
+/**
+ * Delivery of a fanned-out webhook to one destination
+ *
+ * @generated from message hookly.v1.DestinationDelivery
+ */
+export type DestinationDelivery = Message<"hookly.v1.DestinationDelivery"> & {
+  /**
+   * Empty for the endpoint's destination_url
+   *
+   * @generated from field: string destination_id = 1;
+   */
+  destinationId: string;
+
+  /**
+   * @generated from field: string url = 2;
+   */
+  url: string;
+
+  /**
+   * Pending while retried, delivered or failed
+   *
+   * @generated from field: hookly.v1.WebhookStatus status = 3;
+   */
+  status: WebhookStatus;
+
+  /**
+   * @generated from field: int32 attempts = 4;
+   */
+  attempts: number;
+
+  /**
+   * Of the last attempt, 0 if it got no response
+   *
+   * @generated from field: int32 status_code = 5;
+   */
+  statusCode: number;
+
+  /**
+   * @generated from field: string error_message = 6;
+   */
+  errorMessage: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp last_attempt_at = 7;
+   */
+  lastAttemptAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp delivered_at = 8;
+   */
+  deliveredAt?: Timestamp;
+};
+
+/**
+ * Describes the message hookly.v1.DestinationDelivery.
+ * Use `create(DestinationDeliverySchema)` to create a new message.
+ */
+export const DestinationDeliverySchema: GenMessage<DestinationDelivery> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 7);
+
 /**
  * Endpoint configuration
  *
@@ -68,386 +337,1280 @@ export const VerificationConfigSchema: GenMessage<VerificationConfig> = /*@__PUR
  */
 export type Endpoint = Message<"hookly.v1.Endpoint"> & {
   /**
-   * @generated from field: string id = 1;
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * @generated from field: hookly.v1.ProviderType provider_type = 3;
+   */
+  providerType: ProviderType;
+
+  /**
+   * @generated from field: string destination_url = 4;
+   */
+  destinationUrl: string;
+
+  /**
+   * @generated from field: bool muted = 5;
+   */
+  muted: boolean;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp created_at = 6;
+   */
+  createdAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp updated_at = 7;
+   */
+  updatedAt?: Timestamp;
+
+  /**
+   * Note: signature_secret is not exposed in API responses
+   * Custom verification config (only for PROVIDER_TYPE_CUSTOM)
+   *
+   * @generated from field: hookly.v1.VerificationConfig verification_config = 8;
+   */
+  verificationConfig?: VerificationConfig;
+
+  /**
+   * Send a notification when the first webhook arrives
+   *
+   * @generated from field: bool notify_first_event = 9;
+   */
+  notifyFirstEvent: boolean;
+
+  /**
+   * When the first webhook was received. Unset while waiting for the first event.
+   *
+   * @generated from field: google.protobuf.Timestamp first_event_at = 10;
+   */
+  firstEventAt?: Timestamp;
+
+  /**
+   * True if a Telegram bot token is stored for setWebhook automation
+   *
+   * @generated from field: bool has_telegram_bot_token = 11;
+   */
+  hasTelegramBotToken: boolean;
+
+  /**
+   * Delivery SLO: percent of webhooks to deliver within slo_latency_seconds
+   * over the last slo_window_hours. 0 disables the SLO.
+   *
+   * @generated from field: double slo_target = 12;
+   */
+  sloTarget: number;
+
+  /**
+   * @generated from field: int32 slo_latency_seconds = 13;
+   */
+  sloLatencySeconds: number;
+
+  /**
+   * @generated from field: int32 slo_window_hours = 14;
+   */
+  sloWindowHours: number;
+
+  /**
+   * Drop re-deliveries of a known provider delivery ID instead of storing
+   * them flagged as duplicates
+   *
+   * @generated from field: bool reject_duplicates = 15;
+   */
+  rejectDuplicates: boolean;
+
+  /**
+   * Region of the edge the endpoint was created on. Empty on single-region
+   * services.
+   *
+   * @generated from field: string home_region = 16;
+   */
+  homeRegion: string;
+
+  /**
+   * Credentials required at the ingestion URL, without the secret. Unset if
+   * the URL is open.
+   *
+   * @generated from field: hookly.v1.IngestAuth ingest_auth = 17;
+   */
+  ingestAuth?: IngestAuth;
+
+  /**
+   * Never relays: every request to the URL, with any method, is stored and
+   * alerts the owner. For detecting leaked URLs and canary tokens.
+   *
+   * @generated from field: bool honeypot = 18;
+   */
+  honeypot: boolean;
+
+  /**
+   * When the last webhook was stored. Unset if none has been received.
+   *
+   * @generated from field: google.protobuf.Timestamp last_webhook_received_at = 19;
+   */
+  lastWebhookReceivedAt?: Timestamp;
+
+  /**
+   * When the hub last acknowledged a delivery. Unset if none was delivered.
+   *
+   * @generated from field: google.protobuf.Timestamp last_delivered_at = 20;
+   */
+  lastDeliveredAt?: Timestamp;
+
+  /**
+   * When the endpoint was muted automatically for inactivity. Unmuting
+   * clears it.
+   *
+   * @generated from field: google.protobuf.Timestamp archived_at = 21;
+   */
+  archivedAt?: Timestamp;
+
+  /**
+   * Record a webhook the destination answers with 409 Conflict as
+   * acknowledged_duplicate, already processed, instead of retrying it
+   *
+   * @generated from field: bool conflict_as_duplicate = 22;
+   */
+  conflictAsDuplicate: boolean;
+
+  /**
+   * Ingestion rate limit in requests per minute; 0 uses the edge's limit
+   *
+   * @generated from field: int32 rate_limit_per_minute = 23;
+   */
+  rateLimitPerMinute: number;
+
+  /**
+   * Applied by the hub before forwarding. Unset forwards webhooks as
+   * received.
+   *
+   * @generated from field: hookly.v1.Transform transform = 24;
+   */
+  transform?: Transform;
+
+  /**
+   * Also delivered to, each tracked separately. Set by GetEndpoint and
+   * UpdateEndpoint, not in lists.
+   *
+   * @generated from field: repeated hookly.v1.Destination destinations = 25;
+   */
+  destinations: Destination[];
+
+  /**
+   * Answer HEAD and OPTIONS at the ingestion URL with 204, for providers
+   * that probe it and dashboards that send CORS preflights. Otherwise 405.
+   *
+   * @generated from field: bool answer_probes = 26;
+   */
+  answerProbes: boolean;
+
+  /**
+   * @generated from field: hookly.v1.IngestResponse ingest_response = 27;
+   */
+  ingestResponse?: IngestResponse;
+
+  /**
+   * @generated from field: hookly.v1.RetryPolicy retry_policy = 28;
+   */
+  retryPolicy?: RetryPolicy;
+
+  /**
+   * @generated from field: hookly.v1.PayloadLimits payload_limits = 29;
+   */
+  payloadLimits?: PayloadLimits;
+
+  /**
+   * Full URL providers send webhooks to, under the edge's path prefix.
+   *
+   * @generated from field: string webhook_url = 30;
+   */
+  webhookUrl: string;
+};
+
+/**
+ * Describes the message hookly.v1.Endpoint.
+ * Use `create(EndpointSchema)` to create a new message.
+ */
+export const EndpointSchema: GenMessage<Endpoint> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 8);
+
+/**
+ * Webhook record
+ *
+ * @generated from message hookly.v1.Webhook
+ */
+export type Webhook = Message<"hookly.v1.Webhook"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string endpoint_id = 2;
+   */
+  endpointId: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp received_at = 3;
+   */
+  receivedAt?: Timestamp;
+
+  /**
+   * @generated from field: map<string, string> headers = 4;
+   */
+  headers: { [key: string]: string };
+
+  /**
+   * @generated from field: bytes payload = 5;
+   */
+  payload: Uint8Array;
+
+  /**
+   * @generated from field: bool signature_valid = 6;
+   */
+  signatureValid: boolean;
+
+  /**
+   * @generated from field: hookly.v1.WebhookStatus status = 7;
+   */
+  status: WebhookStatus;
+
+  /**
+   * @generated from field: int32 attempts = 8;
+   */
+  attempts: number;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp last_attempt_at = 9;
+   */
+  lastAttemptAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp delivered_at = 10;
+   */
+  deliveredAt?: Timestamp;
+
+  /**
+   * @generated from field: string error_message = 11;
+   */
+  errorMessage: string;
+
+  /**
+   * Provider event type (Stripe type, X-GitHub-Event, ...), empty if unknown
+   *
+   * @generated from field: string event_type = 12;
+   */
+  eventType: string;
+
+  /**
+   * First 4 KB of the payload, always set
+   *
+   * @generated from field: bytes payload_preview = 13;
+   */
+  payloadPreview: Uint8Array;
+
+  /**
+   * @generated from field: int64 payload_size = 14;
+   */
+  payloadSize: bigint;
+
+  /**
+   * True if payload_preview is shorter than the payload
+   *
+   * @generated from field: bool payload_truncated = 15;
+   */
+  payloadTruncated: boolean;
+
+  /**
+   * Provider delivery ID (X-GitHub-Delivery, Stripe event id, Svix webhook-id)
+   *
+   * @generated from field: string delivery_id = 16;
+   */
+  deliveryId: string;
+
+  /**
+   * ID of an earlier webhook with the same delivery ID, if this is a re-delivery
+   *
+   * @generated from field: string duplicate_of = 17;
+   */
+  duplicateOf: string;
+
+  /**
+   * Client IP the webhook came from, resolved through trusted proxies
+   *
+   * @generated from field: string source_ip = 18;
+   */
+  sourceIp: string;
+
+  /**
+   * Status changes, oldest first. Only set by GetWebhook, and empty for
+   * webhooks stored before changes were recorded.
+   *
+   * @generated from field: repeated hookly.v1.WebhookStatusChange status_history = 19;
+   */
+  statusHistory: WebhookStatusChange[];
+
+  /**
+   * Last replay, and who made it; unset if never replayed
+   *
+   * @generated from field: google.protobuf.Timestamp replayed_at = 20;
+   */
+  replayedAt?: Timestamp;
+
+  /**
+   * @generated from field: string replayed_by = 21;
+   */
+  replayedBy: string;
+
+  /**
+   * @generated from field: int32 replay_count = 22;
+   */
+  replayCount: number;
+
+  /**
+   * OpenTelemetry trace of the webhook's ingestion and delivery, hex-encoded;
+   * empty for webhooks received before tracing
+   *
+   * @generated from field: string trace_id = 23;
+   */
+  traceId: string;
+
+  /**
+   * When retention cleanup purged the webhook, unset if it wasn't. A purged
+   * webhook can be undeleted until it is deleted at purge_expires_at.
+   *
+   * @generated from field: google.protobuf.Timestamp purged_at = 24;
+   */
+  purgedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp purge_expires_at = 25;
+   */
+  purgeExpiresAt?: Timestamp;
+};
+
+/**
+ * Describes the message hookly.v1.Webhook.
+ * Use `create(WebhookSchema)` to create a new message.
+ */
+export const WebhookSchema: GenMessage<Webhook> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 9);
+
+/**
+ * A change of a webhook's status
+ *
+ * @generated from message hookly.v1.WebhookStatusChange
+ */
+export type WebhookStatusChange = Message<"hookly.v1.WebhookStatusChange"> & {
+  /**
+   * Unspecified for the status the webhook was stored with
+   *
+   * @generated from field: hookly.v1.WebhookStatus from_status = 1;
+   */
+  fromStatus: WebhookStatus;
+
+  /**
+   * @generated from field: hookly.v1.WebhookStatus to_status = 2;
+   */
+  toStatus: WebhookStatus;
+
+  /**
+   * "replayed", or the delivery error at the time of the change
+   *
+   * @generated from field: string reason = 3;
+   */
+  reason: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp changed_at = 4;
+   */
+  changedAt?: Timestamp;
+
+  /**
+   * Who replayed the webhook; empty for changes made by the edge
+   *
+   * @generated from field: string changed_by = 5;
+   */
+  changedBy: string;
+};
+
+/**
+ * Describes the message hookly.v1.WebhookStatusChange.
+ * Use `create(WebhookStatusChangeSchema)` to create a new message.
+ */
+export const WebhookStatusChangeSchema: GenMessage<WebhookStatusChange> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 10);
+
+/**
+ * Pagination request parameters
+ *
+ * @generated from message hookly.v1.PaginationRequest
+ */
+export type PaginationRequest = Message<"hookly.v1.PaginationRequest"> & {
+  /**
+   * @generated from field: int32 page_size = 1;
+   */
+  pageSize: number;
+
+  /**
+   * @generated from field: string page_token = 2;
+   */
+  pageToken: string;
+};
+
+/**
+ * Describes the message hookly.v1.PaginationRequest.
+ * Use `create(PaginationRequestSchema)` to create a new message.
+ */
+export const PaginationRequestSchema: GenMessage<PaginationRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 11);
+
+/**
+ * Pagination response metadata
+ *
+ * @generated from message hookly.v1.PaginationResponse
+ */
+export type PaginationResponse = Message<"hookly.v1.PaginationResponse"> & {
+  /**
+   * @generated from field: string next_page_token = 1;
+   */
+  nextPageToken: string;
+
+  /**
+   * @generated from field: int32 total_count = 2;
+   */
+  totalCount: number;
+};
+
+/**
+ * Describes the message hookly.v1.PaginationResponse.
+ * Use `create(PaginationResponseSchema)` to create a new message.
+ */
+export const PaginationResponseSchema: GenMessage<PaginationResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 12);
+
+/**
+ * Connected endpoint info for status display
+ *
+ * @generated from message hookly.v1.ConnectedEndpoint
+ */
+export type ConnectedEndpoint = Message<"hookly.v1.ConnectedEndpoint"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+};
+
+/**
+ * Describes the message hookly.v1.ConnectedEndpoint.
+ * Use `create(ConnectedEndpointSchema)` to create a new message.
+ */
+export const ConnectedEndpointSchema: GenMessage<ConnectedEndpoint> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 13);
+
+/**
+ * An endpoint whose webhooks were rejected by ingestion rate limits
+ *
+ * @generated from message hookly.v1.RateLimitedEndpoint
+ */
+export type RateLimitedEndpoint = Message<"hookly.v1.RateLimitedEndpoint"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: string name = 2;
+   */
+  name: string;
+
+  /**
+   * Since the edge started
+   *
+   * @generated from field: uint64 rejected_count = 3;
+   */
+  rejectedCount: bigint;
+};
+
+/**
+ * Describes the message hookly.v1.RateLimitedEndpoint.
+ * Use `create(RateLimitedEndpointSchema)` to create a new message.
+ */
+export const RateLimitedEndpointSchema: GenMessage<RateLimitedEndpoint> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 14);
+
+/**
+ * A hub connected to the edge
+ *
+ * @generated from message hookly.v1.ConnectedHub
+ */
+export type ConnectedHub = Message<"hookly.v1.ConnectedHub"> & {
+  /**
+   * @generated from field: string hub_id = 1;
+   */
+  hubId: string;
+
+  /**
+   * stream or tunnel; commands need the stream
+   *
+   * @generated from field: string transport = 2;
+   */
+  transport: string;
+
+  /**
+   * @generated from field: repeated string endpoint_ids = 3;
+   */
+  endpointIds: string[];
+
+  /**
+   * @generated from field: google.protobuf.Timestamp connected_at = 4;
+   */
+  connectedAt?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp last_heartbeat_at = 5;
+   */
+  lastHeartbeatAt?: Timestamp;
+
+  /**
+   * Deliveries paused with HUB_COMMAND_TYPE_PAUSE
+   *
+   * @generated from field: bool paused = 6;
+   */
+  paused: boolean;
+};
+
+/**
+ * Describes the message hookly.v1.ConnectedHub.
+ * Use `create(ConnectedHubSchema)` to create a new message.
+ */
+export const ConnectedHubSchema: GenMessage<ConnectedHub> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 15);
+
+/**
+ * A hub's answer to a command
+ *
+ * @generated from message hookly.v1.HubCommandResult
+ */
+export type HubCommandResult = Message<"hookly.v1.HubCommandResult"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: bool success = 2;
+   */
+  success: boolean;
+
+  /**
+   * @generated from field: string error = 3;
+   */
+  error: string;
+
+  /**
+   * Human-readable summary, JSON for diagnostics
+   *
+   * @generated from field: string output = 4;
+   */
+  output: string;
+};
+
+/**
+ * Describes the message hookly.v1.HubCommandResult.
+ * Use `create(HubCommandResultSchema)` to create a new message.
+ */
+export const HubCommandResultSchema: GenMessage<HubCommandResult> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 16);
+
+/**
+ * System status information
+ *
+ * @generated from message hookly.v1.SystemStatus
+ */
+export type SystemStatus = Message<"hookly.v1.SystemStatus"> & {
+  /**
+   * @generated from field: int32 pending_count = 1;
+   */
+  pendingCount: number;
+
+  /**
+   * @generated from field: int32 failed_count = 2;
+   */
+  failedCount: number;
+
+  /**
+   * @generated from field: int32 dead_letter_count = 3;
+   */
+  deadLetterCount: number;
+
+  /**
+   * Deprecated: use connected_endpoints instead
+   *
+   * @generated from field: bool home_hub_connected = 4 [deprecated = true];
+   * @deprecated
+   */
+  homeHubConnected: boolean;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp last_home_hub_heartbeat = 5 [deprecated = true];
+   * @deprecated
+   */
+  lastHomeHubHeartbeat?: Timestamp;
+
+  /**
+   * Endpoints with active relay connections
+   *
+   * @generated from field: repeated hookly.v1.ConnectedEndpoint connected_endpoints = 6;
+   */
+  connectedEndpoints: ConnectedEndpoint[];
+
+  /**
+   * Background maintenance jobs (dead letters, SLO checks, cleanup)
+   *
+   * @generated from field: repeated hookly.v1.MaintenanceJob maintenance_jobs = 7;
+   */
+  maintenanceJobs: MaintenanceJob[];
+
+  /**
+   * Hubs serving this user's endpoints
+   *
+   * @generated from field: repeated hookly.v1.ConnectedHub connected_hubs = 8;
+   */
+  connectedHubs: ConnectedHub[];
+
+  /**
+   * Endpoints that were rate limited, most rejected first
+   *
+   * @generated from field: repeated hookly.v1.RateLimitedEndpoint rate_limited_endpoints = 9;
+   */
+  rateLimitedEndpoints: RateLimitedEndpoint[];
+};
+
+/**
+ * Describes the message hookly.v1.SystemStatus.
+ * Use `create(SystemStatusSchema)` to create a new message.
+ */
+export const SystemStatusSchema: GenMessage<SystemStatus> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 17);
+
+/**
+ * A background maintenance job run by the edge scheduler
+ *
+ * @generated from message hookly.v1.MaintenanceJob
+ */
+export type MaintenanceJob = Message<"hookly.v1.MaintenanceJob"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * Unset if the job hasn't run yet
+   *
+   * @generated from field: google.protobuf.Timestamp last_run_at = 2;
+   */
+  lastRunAt?: Timestamp;
+
+  /**
+   * Unset if the scheduler isn't running
+   *
+   * @generated from field: google.protobuf.Timestamp next_run_at = 3;
+   */
+  nextRunAt?: Timestamp;
+
+  /**
+   * @generated from field: int64 last_duration_ms = 4;
+   */
+  lastDurationMs: bigint;
+
+  /**
+   * Empty if the last run succeeded
+   *
+   * @generated from field: string last_error = 5;
+   */
+  lastError: string;
+};
+
+/**
+ * Describes the message hookly.v1.MaintenanceJob.
+ * Use `create(MaintenanceJobSchema)` to create a new message.
+ */
+export const MaintenanceJobSchema: GenMessage<MaintenanceJob> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 18);
+
+/**
+ * User settings including profile and preferences
+ *
+ * @generated from message hookly.v1.UserSettings
+ */
+export type UserSettings = Message<"hookly.v1.UserSettings"> & {
+  /**
+   * @generated from field: string user_id = 1;
+   */
+  userId: string;
+
+  /**
+   * @generated from field: string username = 2;
+   */
+  username: string;
+
+  /**
+   * GitHub profile (from OAuth)
+   *
+   * @generated from field: string github_name = 3;
+   */
+  githubName: string;
+
+  /**
+   * @generated from field: string github_email = 4;
+   */
+  githubEmail: string;
+
+  /**
+   * @generated from field: string github_profile_url = 5;
+   */
+  githubProfileUrl: string;
+
+  /**
+   * @generated from field: string avatar_url = 6;
+   */
+  avatarUrl: string;
+
+  /**
+   * Telegram notifications (token is write-only, never returned)
+   *
+   * True if bot token is set
+   *
+   * @generated from field: bool telegram_configured = 7;
+   */
+  telegramConfigured: boolean;
+
+  /**
+   * @generated from field: string telegram_chat_id = 8;
+   */
+  telegramChatId: string;
+
+  /**
+   * @generated from field: bool telegram_enabled = 9;
+   */
+  telegramEnabled: boolean;
+
+  /**
+   * UI preferences
+   *
+   * @generated from field: hookly.v1.ThemePreference theme_preference = 10;
    */
-  id: string;
+  themePreference: ThemePreference;
 
   /**
-   * @generated from field: string name = 2;
+   * Authorization
+   *
+   * @generated from field: bool is_superuser = 11;
    */
-  name: string;
+  isSuperuser: boolean;
 
   /**
-   * @generated from field: hookly.v1.ProviderType provider_type = 3;
+   * Timestamps
+   *
+   * @generated from field: google.protobuf.Timestamp created_at = 12;
    */
-  providerType: ProviderType;
+  createdAt?: Timestamp;
 
   /**
-   * @generated from field: string destination_url = 4;
+   * @generated from field: google.protobuf.Timestamp updated_at = 13;
    */
-  destinationUrl: string;
+  updatedAt?: Timestamp;
 
   /**
-   * @generated from field: bool muted = 5;
+   * @generated from field: google.protobuf.Timestamp last_login_at = 14;
    */
-  muted: boolean;
+  lastLoginAt?: Timestamp;
 
   /**
-   * @generated from field: google.protobuf.Timestamp created_at = 6;
+   * Discord notifications (webhook URL is write-only, never returned)
+   *
+   * True if a webhook URL is set
+   *
+   * @generated from field: bool discord_configured = 15;
    */
-  createdAt?: Timestamp;
+  discordConfigured: boolean;
 
   /**
-   * @generated from field: google.protobuf.Timestamp updated_at = 7;
+   * @generated from field: bool discord_enabled = 16;
    */
-  updatedAt?: Timestamp;
+  discordEnabled: boolean;
 
   /**
-   * Note: signature_secret is not exposed in API responses
-   * Custom verification config (only for PROVIDER_TYPE_CUSTOM)
+   * Email notifications, sent through the edge's SMTP server
    *
-   * @generated from field: hookly.v1.VerificationConfig verification_config = 8;
+   * @generated from field: string email_address = 17;
    */
-  verificationConfig?: VerificationConfig;
+  emailAddress: string;
+
+  /**
+   * @generated from field: bool email_enabled = 18;
+   */
+  emailEnabled: boolean;
+
+  /**
+   * Notification webhook (URL and secret are write-only, never returned)
+   *
+   * True if a URL and secret are set
+   *
+   * @generated from field: bool notify_webhook_configured = 19;
+   */
+  notifyWebhookConfigured: boolean;
+
+  /**
+   * @generated from field: bool notify_webhook_enabled = 20;
+   */
+  notifyWebhookEnabled: boolean;
 };
 
 /**
- * Describes the message hookly.v1.Endpoint.
- * Use `create(EndpointSchema)` to create a new message.
+ * Describes the message hookly.v1.UserSettings.
+ * Use `create(UserSettingsSchema)` to create a new message.
  */
-export const EndpointSchema: GenMessage<Endpoint> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_common, 1);
+export const UserSettingsSchema: GenMessage<UserSettings> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 19);
 
 /**
- * Webhook record
+ * API token metadata; the token itself is only returned when created
  *
- * @generated from message hookly.v1.Webhook
+ * @generated from message hookly.v1.ApiToken
  */
-export type Webhook = Message<"hookly.v1.Webhook"> & {
+export type ApiToken = Message<"hookly.v1.ApiToken"> & {
   /**
    * @generated from field: string id = 1;
    */
   id: string;
 
   /**
-   * @generated from field: string endpoint_id = 2;
+   * e.g. "CLI - hostname"
+   *
+   * @generated from field: string name = 2;
    */
-  endpointId: string;
+  name: string;
 
   /**
-   * @generated from field: google.protobuf.Timestamp received_at = 3;
+   * @generated from field: google.protobuf.Timestamp created_at = 3;
    */
-  receivedAt?: Timestamp;
+  createdAt?: Timestamp;
 
   /**
-   * @generated from field: map<string, string> headers = 4;
+   * Unset if never used
+   *
+   * @generated from field: google.protobuf.Timestamp last_used_at = 4;
    */
-  headers: { [key: string]: string };
+  lastUsedAt?: Timestamp;
 
   /**
-   * @generated from field: bytes payload = 5;
+   * @generated from field: hookly.v1.TokenScope scope = 5;
    */
-  payload: Uint8Array;
+  scope: TokenScope;
 
   /**
-   * @generated from field: bool signature_valid = 6;
+   * Unset if it never expires
+   *
+   * @generated from field: google.protobuf.Timestamp expires_at = 6;
    */
-  signatureValid: boolean;
+  expiresAt?: Timestamp;
 
   /**
-   * @generated from field: hookly.v1.WebhookStatus status = 7;
+   * @generated from field: bool expired = 7;
    */
-  status: WebhookStatus;
+  expired: boolean;
+};
 
+/**
+ * Describes the message hookly.v1.ApiToken.
+ * Use `create(ApiTokenSchema)` to create a new message.
+ */
+export const ApiTokenSchema: GenMessage<ApiToken> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 20);
+
+/**
+ * An organization sharing endpoints among its members
+ *
+ * @generated from message hookly.v1.Org
+ */
+export type Org = Message<"hookly.v1.Org"> & {
   /**
-   * @generated from field: int32 attempts = 8;
+   * @generated from field: string id = 1;
    */
-  attempts: number;
+  id: string;
 
   /**
-   * @generated from field: google.protobuf.Timestamp last_attempt_at = 9;
+   * @generated from field: string name = 2;
    */
-  lastAttemptAt?: Timestamp;
+  name: string;
 
   /**
-   * @generated from field: google.protobuf.Timestamp delivered_at = 10;
+   * @generated from field: google.protobuf.Timestamp created_at = 3;
    */
-  deliveredAt?: Timestamp;
+  createdAt?: Timestamp;
 
   /**
-   * @generated from field: string error_message = 11;
+   * The caller's role
+   *
+   * @generated from field: hookly.v1.OrgRole role = 4;
    */
-  errorMessage: string;
+  role: OrgRole;
 };
 
 /**
- * Describes the message hookly.v1.Webhook.
- * Use `create(WebhookSchema)` to create a new message.
+ * Describes the message hookly.v1.Org.
+ * Use `create(OrgSchema)` to create a new message.
  */
-export const WebhookSchema: GenMessage<Webhook> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_common, 2);
+export const OrgSchema: GenMessage<Org> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 21);
 
 /**
- * Pagination request parameters
- *
- * @generated from message hookly.v1.PaginationRequest
+ * @generated from message hookly.v1.OrgMember
  */
-export type PaginationRequest = Message<"hookly.v1.PaginationRequest"> & {
+export type OrgMember = Message<"hookly.v1.OrgMember"> & {
   /**
-   * @generated from field: int32 page_size = 1;
+   * @generated from field: string user_id = 1;
    */
-  pageSize: number;
+  userId: string;
 
   /**
-   * @generated from field: string page_token = 2;
+   * @generated from field: string username = 2;
    */
-  pageToken: string;
-};
-
-/**
- * Describes the message hookly.v1.PaginationRequest.
- * Use `create(PaginationRequestSchema)` to create a new message.
- */
-export const PaginationRequestSchema: GenMessage<PaginationRequest> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_common, 3);
+  username: string;
 
-/**
- * Pagination response metadata
- *
- * @generated from message hookly.v1.PaginationResponse
- */
-export type PaginationResponse = Message<"hookly.v1.PaginationResponse"> & {
   /**
-   * @generated from field: string next_page_token = 1;
+   * @generated from field: hookly.v1.OrgRole role = 3;
    */
-  nextPageToken: string;
+  role: OrgRole;
 
   /**
-   * @generated from field: int32 total_count = 2;
+   * @generated from field: google.protobuf.Timestamp created_at = 4;
    */
-  totalCount: number;
+  createdAt?: Timestamp;
 };
 
 /**
- * Describes the message hookly.v1.PaginationResponse.
- * Use `create(PaginationResponseSchema)` to create a new message.
+ * Describes the message hookly.v1.OrgMember.
+ * Use `create(OrgMemberSchema)` to create a new message.
  */
-export const PaginationResponseSchema: GenMessage<PaginationResponse> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_common, 4);
+export const OrgMemberSchema: GenMessage<OrgMember> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 22);
 
 /**
- * Connected endpoint info for status display
+ * A recorded management action
  *
- * @generated from message hookly.v1.ConnectedEndpoint
+ * @generated from message hookly.v1.AuditEvent
  */
-export type ConnectedEndpoint = Message<"hookly.v1.ConnectedEndpoint"> & {
+export type AuditEvent = Message<"hookly.v1.AuditEvent"> & {
   /**
    * @generated from field: string id = 1;
    */
   id: string;
 
   /**
-   * @generated from field: string name = 2;
+   * Who acted
+   *
+   * @generated from field: string user_id = 2;
    */
-  name: string;
+  userId: string;
+
+  /**
+   * @generated from field: string username = 3;
+   */
+  username: string;
+
+  /**
+   * API token used; empty for web sessions
+   *
+   * @generated from field: string token_id = 4;
+   */
+  tokenId: string;
+
+  /**
+   * EdgeService method, or Login, Logout, AuthorizeCLI
+   *
+   * @generated from field: string action = 5;
+   */
+  action: string;
+
+  /**
+   * ID of the endpoint, webhook, token or org acted on
+   *
+   * @generated from field: string target = 6;
+   */
+  target: string;
+
+  /**
+   * @generated from field: string ip = 7;
+   */
+  ip: string;
+
+  /**
+   * ok, denied, or the error code, e.g. permission_denied
+   *
+   * @generated from field: string result = 8;
+   */
+  result: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp occurred_at = 9;
+   */
+  occurredAt?: Timestamp;
+
+  /**
+   * Set when the action was on an org's endpoints
+   *
+   * @generated from field: string org_id = 10;
+   */
+  orgId: string;
 };
 
 /**
- * Describes the message hookly.v1.ConnectedEndpoint.
- * Use `create(ConnectedEndpointSchema)` to create a new message.
+ * Describes the message hookly.v1.AuditEvent.
+ * Use `create(AuditEventSchema)` to create a new message.
  */
-export const ConnectedEndpointSchema: GenMessage<ConnectedEndpoint> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_common, 5);
+export const AuditEventSchema: GenMessage<AuditEvent> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 23);
 
 /**
- * System status information
+ * System settings (superuser only)
  *
- * @generated from message hookly.v1.SystemStatus
+ * @generated from message hookly.v1.SystemSettings
  */
-export type SystemStatus = Message<"hookly.v1.SystemStatus"> & {
+export type SystemSettings = Message<"hookly.v1.SystemSettings"> & {
   /**
-   * @generated from field: int32 pending_count = 1;
+   * @generated from field: string base_url = 1;
    */
-  pendingCount: number;
+  baseUrl: string;
 
   /**
-   * @generated from field: int32 failed_count = 2;
+   * @generated from field: string github_org = 2;
    */
-  failedCount: number;
+  githubOrg: string;
 
   /**
-   * @generated from field: int32 dead_letter_count = 3;
+   * @generated from field: repeated string github_allowed_users = 3;
    */
-  deadLetterCount: number;
+  githubAllowedUsers: string[];
 
   /**
-   * Deprecated: use connected_endpoints instead
-   *
-   * @generated from field: bool home_hub_connected = 4 [deprecated = true];
-   * @deprecated
+   * @generated from field: bool system_telegram_enabled = 4;
    */
-  homeHubConnected: boolean;
+  systemTelegramEnabled: boolean;
 
   /**
-   * @generated from field: google.protobuf.Timestamp last_home_hub_heartbeat = 5 [deprecated = true];
-   * @deprecated
+   * @generated from field: int32 total_users = 5;
    */
-  lastHomeHubHeartbeat?: Timestamp;
+  totalUsers: number;
 
   /**
-   * Endpoints with active relay connections
-   *
-   * @generated from field: repeated hookly.v1.ConnectedEndpoint connected_endpoints = 6;
+   * @generated from field: int32 total_endpoints = 6;
    */
-  connectedEndpoints: ConnectedEndpoint[];
+  totalEndpoints: number;
+
+  /**
+   * @generated from field: bool system_discord_enabled = 7;
+   */
+  systemDiscordEnabled: boolean;
+
+  /**
+   * @generated from field: bool system_email_enabled = 8;
+   */
+  systemEmailEnabled: boolean;
+
+  /**
+   * @generated from field: bool system_notify_webhook_enabled = 9;
+   */
+  systemNotifyWebhookEnabled: boolean;
 };
 
 /**
- * Describes the message hookly.v1.SystemStatus.
- * Use `create(SystemStatusSchema)` to create a new message.
+ * Describes the message hookly.v1.SystemSettings.
+ * Use `create(SystemSettingsSchema)` to create a new message.
  */
-export const SystemStatusSchema: GenMessage<SystemStatus> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_common, 6);
+export const SystemSettingsSchema: GenMessage<SystemSettings> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 24);
 
 /**
- * User settings including profile and preferences
+ * An entry in an endpoint's connection history
  *
- * @generated from message hookly.v1.UserSettings
+ * @generated from message hookly.v1.ConnectionEvent
  */
-export type UserSettings = Message<"hookly.v1.UserSettings"> & {
+export type ConnectionEvent = Message<"hookly.v1.ConnectionEvent"> & {
   /**
-   * @generated from field: string user_id = 1;
+   * @generated from field: string id = 1;
    */
-  userId: string;
+  id: string;
 
   /**
-   * @generated from field: string username = 2;
+   * @generated from field: string endpoint_id = 2;
    */
-  username: string;
+  endpointId: string;
 
   /**
-   * GitHub profile (from OAuth)
-   *
-   * @generated from field: string github_name = 3;
+   * @generated from field: string endpoint_name = 3;
    */
-  githubName: string;
+  endpointName: string;
 
   /**
-   * @generated from field: string github_email = 4;
+   * @generated from field: string hub_id = 4;
    */
-  githubEmail: string;
+  hubId: string;
+
+  /**
+   * @generated from field: hookly.v1.ConnectionEventType type = 5;
+   */
+  type: ConnectionEventType;
+
+  /**
+   * stream or tunnel
+   *
+   * @generated from field: string transport = 6;
+   */
+  transport: string;
 
   /**
-   * @generated from field: string github_profile_url = 5;
+   * @generated from field: google.protobuf.Timestamp occurred_at = 7;
    */
-  githubProfileUrl: string;
+  occurredAt?: Timestamp;
+};
+
+/**
+ * Describes the message hookly.v1.ConnectionEvent.
+ * Use `create(ConnectionEventSchema)` to create a new message.
+ */
+export const ConnectionEventSchema: GenMessage<ConnectionEvent> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 25);
 
+/**
+ * Activity feed entry for the UI home page
+ *
+ * @generated from message hookly.v1.ActivityItem
+ */
+export type ActivityItem = Message<"hookly.v1.ActivityItem"> & {
   /**
-   * @generated from field: string avatar_url = 6;
+   * @generated from field: string id = 1;
    */
-  avatarUrl: string;
+  id: string;
 
   /**
-   * Telegram notifications (token is write-only, never returned)
-   *
-   * True if bot token is set
-   *
-   * @generated from field: bool telegram_configured = 7;
+   * @generated from field: hookly.v1.ActivityKind kind = 2;
    */
-  telegramConfigured: boolean;
+  kind: ActivityKind;
 
   /**
-   * @generated from field: string telegram_chat_id = 8;
+   * @generated from field: string endpoint_id = 3;
    */
-  telegramChatId: string;
+  endpointId: string;
 
   /**
-   * @generated from field: bool telegram_enabled = 9;
+   * @generated from field: string endpoint_name = 4;
    */
-  telegramEnabled: boolean;
+  endpointName: string;
 
   /**
-   * UI preferences
-   *
-   * @generated from field: hookly.v1.ThemePreference theme_preference = 10;
+   * @generated from field: string hub_id = 5;
    */
-  themePreference: ThemePreference;
+  hubId: string;
 
   /**
-   * Authorization
+   * Number of deliveries (ACTIVITY_KIND_DELIVERIES only)
    *
-   * @generated from field: bool is_superuser = 11;
+   * @generated from field: int32 count = 6;
    */
-  isSuperuser: boolean;
+  count: number;
 
   /**
-   * Timestamps
+   * Start of the hour for deliveries
    *
-   * @generated from field: google.protobuf.Timestamp created_at = 12;
+   * @generated from field: google.protobuf.Timestamp occurred_at = 7;
    */
-  createdAt?: Timestamp;
+  occurredAt?: Timestamp;
 
   /**
-   * @generated from field: google.protobuf.Timestamp updated_at = 13;
+   * @generated from field: google.protobuf.Timestamp updated_at = 8;
    */
   updatedAt?: Timestamp;
-
-  /**
-   * @generated from field: google.protobuf.Timestamp last_login_at = 14;
-   */
-  lastLoginAt?: Timestamp;
 };
 
 /**
- * Describes the message hookly.v1.UserSettings.
- * Use `create(UserSettingsSchema)` to create a new message.
+ * Describes the message hookly.v1.ActivityItem.
+ * Use `create(ActivityItemSchema)` to create a new message.
  */
-export const UserSettingsSchema: GenMessage<UserSettings> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_common, 7);
+export const ActivityItemSchema: GenMessage<ActivityItem> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 26);
 
 /**
- * System settings (superuser only)
+ * A region of the hookly service, with its health as seen from the edge that
+ * answered
  *
- * @generated from message hookly.v1.SystemSettings
+ * @generated from message hookly.v1.Region
  */
-export type SystemSettings = Message<"hookly.v1.SystemSettings"> & {
+export type Region = Message<"hookly.v1.Region"> & {
   /**
-   * @generated from field: string base_url = 1;
+   * @generated from field: string name = 1;
    */
-  baseUrl: string;
+  name: string;
 
   /**
-   * @generated from field: string github_org = 2;
+   * Base URL of the region's edge
+   *
+   * @generated from field: string url = 2;
    */
-  githubOrg: string;
+  url: string;
 
   /**
-   * @generated from field: repeated string github_allowed_users = 3;
+   * @generated from field: bool healthy = 3;
    */
-  githubAllowedUsers: string[];
+  healthy: boolean;
 
   /**
-   * @generated from field: bool system_telegram_enabled = 4;
+   * Health check round trip from the answering edge
+   *
+   * @generated from field: int64 latency_ms = 4;
    */
-  systemTelegramEnabled: boolean;
+  latencyMs: bigint;
 
   /**
-   * @generated from field: int32 total_users = 5;
+   * Why the region is unhealthy
+   *
+   * @generated from field: string error = 5;
    */
-  totalUsers: number;
+  error: string;
 
   /**
-   * @generated from field: int32 total_endpoints = 6;
+   * @generated from field: google.protobuf.Timestamp checked_at = 6;
    */
-  totalEndpoints: number;
+  checkedAt?: Timestamp;
+
+  /**
+   * The region of the edge that answered
+   *
+   * @generated from field: bool current = 7;
+   */
+  current: boolean;
 };
 
 /**
- * Describes the message hookly.v1.SystemSettings.
- * Use `create(SystemSettingsSchema)` to create a new message.
+ * Describes the message hookly.v1.Region.
+ * Use `create(RegionSchema)` to create a new message.
  */
-export const SystemSettingsSchema: GenMessage<SystemSettings> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_common, 8);
+export const RegionSchema: GenMessage<Region> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_common, 27);
 
 /**
  * Provider type for webhook signature verification
@@ -484,6 +1647,26 @@ export enum ProviderType {
    * @generated from enum value: PROVIDER_TYPE_CUSTOM = 5;
    */
   CUSTOM = 5,
+
+  /**
+   * @generated from enum value: PROVIDER_TYPE_SLACK = 6;
+   */
+  SLACK = 6,
+
+  /**
+   * @generated from enum value: PROVIDER_TYPE_SHOPIFY = 7;
+   */
+  SHOPIFY = 7,
+
+  /**
+   * @generated from enum value: PROVIDER_TYPE_SQUARE = 8;
+   */
+  SQUARE = 8,
+
+  /**
+   * @generated from enum value: PROVIDER_TYPE_PAYPAL = 9;
+   */
+  PAYPAL = 9,
 }
 
 /**
@@ -538,6 +1721,75 @@ export enum VerificationMethod {
 export const VerificationMethodSchema: GenEnum<VerificationMethod> = /*@__PURE__*/
   enumDesc(file_hookly_v1_common, 1);
 
+/**
+ * Credentials required on an endpoint's ingestion URL, checked before the
+ * signature, for providers that can send basic auth or a custom header
+ *
+ * @generated from enum hookly.v1.IngestAuthMethod
+ */
+export enum IngestAuthMethod {
+  /**
+   * No ingestion auth
+   *
+   * @generated from enum value: INGEST_AUTH_METHOD_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * HTTP basic auth
+   *
+   * @generated from enum value: INGEST_AUTH_METHOD_BASIC = 1;
+   */
+  BASIC = 1,
+
+  /**
+   * Static token in a header
+   *
+   * @generated from enum value: INGEST_AUTH_METHOD_HEADER = 2;
+   */
+  HEADER = 2,
+}
+
+/**
+ * Describes the enum hookly.v1.IngestAuthMethod.
+ */
+export const IngestAuthMethodSchema: GenEnum<IngestAuthMethod> = /*@__PURE__*/
+  enumDesc(file_hookly_v1_common, 2);
+
+/**
+ * Sort order of endpoint lists
+ *
+ * @generated from enum hookly.v1.EndpointSort
+ */
+export enum EndpointSort {
+  /**
+   * Newest first
+   *
+   * @generated from enum value: ENDPOINT_SORT_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Oldest first
+   *
+   * @generated from enum value: ENDPOINT_SORT_CREATED_ASC = 1;
+   */
+  CREATED_ASC = 1,
+
+  /**
+   * Most recent webhook first, endpoints without webhooks last
+   *
+   * @generated from enum value: ENDPOINT_SORT_LAST_RECEIVED = 2;
+   */
+  LAST_RECEIVED = 2,
+}
+
+/**
+ * Describes the enum hookly.v1.EndpointSort.
+ */
+export const EndpointSortSchema: GenEnum<EndpointSort> = /*@__PURE__*/
+  enumDesc(file_hookly_v1_common, 3);
+
 /**
  * Webhook delivery status
  *
@@ -568,13 +1820,85 @@ export enum WebhookStatus {
    * @generated from enum value: WEBHOOK_STATUS_DEAD_LETTER = 4;
    */
   DEAD_LETTER = 4,
+
+  /**
+   * Event type not wanted by the hub, not relayed
+   *
+   * @generated from enum value: WEBHOOK_STATUS_SKIPPED = 5;
+   */
+  SKIPPED = 5,
+
+  /**
+   * Destination answered 409: already processed
+   *
+   * @generated from enum value: WEBHOOK_STATUS_ACKNOWLEDGED_DUPLICATE = 6;
+   */
+  ACKNOWLEDGED_DUPLICATE = 6,
 }
 
 /**
  * Describes the enum hookly.v1.WebhookStatus.
  */
 export const WebhookStatusSchema: GenEnum<WebhookStatus> = /*@__PURE__*/
-  enumDesc(file_hookly_v1_common, 2);
+  enumDesc(file_hookly_v1_common, 4);
+
+/**
+ * A remote management command for a connected hub
+ *
+ * @generated from enum hookly.v1.HubCommandType
+ */
+export enum HubCommandType {
+  /**
+   * @generated from enum value: HUB_COMMAND_TYPE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Re-read hookly.yaml and reconnect
+   *
+   * @generated from enum value: HUB_COMMAND_TYPE_RELOAD_CONFIG = 1;
+   */
+  RELOAD_CONFIG = 1,
+
+  /**
+   * Stop deliveries; webhooks stay pending on the edge
+   *
+   * @generated from enum value: HUB_COMMAND_TYPE_PAUSE = 2;
+   */
+  PAUSE = 2,
+
+  /**
+   * @generated from enum value: HUB_COMMAND_TYPE_RESUME = 3;
+   */
+  RESUME = 3,
+
+  /**
+   * Report the hub's state and configuration
+   *
+   * @generated from enum value: HUB_COMMAND_TYPE_DIAGNOSTICS = 4;
+   */
+  DIAGNOSTICS = 4,
+
+  /**
+   * Close the stream and stop relaying
+   *
+   * @generated from enum value: HUB_COMMAND_TYPE_DISCONNECT = 5;
+   */
+  DISCONNECT = 5,
+
+  /**
+   * Send recent log lines, if the hub allows it
+   *
+   * @generated from enum value: HUB_COMMAND_TYPE_LOGS = 6;
+   */
+  LOGS = 6,
+}
+
+/**
+ * Describes the enum hookly.v1.HubCommandType.
+ */
+export const HubCommandTypeSchema: GenEnum<HubCommandType> = /*@__PURE__*/
+  enumDesc(file_hookly_v1_common, 5);
 
 /**
  * Theme preference for UI
@@ -617,5 +1941,152 @@ export enum ThemePreference {
  * Describes the enum hookly.v1.ThemePreference.
  */
 export const ThemePreferenceSchema: GenEnum<ThemePreference> = /*@__PURE__*/
-  enumDesc(file_hookly_v1_common, 3);
+  enumDesc(file_hookly_v1_common, 6);
+
+/**
+ * What an API token may do
+ *
+ * @generated from enum hookly.v1.TokenScope
+ */
+export enum TokenScope {
+  /**
+   * @generated from enum value: TOKEN_SCOPE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Every API call and relay connections
+   *
+   * @generated from enum value: TOKEN_SCOPE_ADMIN = 1;
+   */
+  ADMIN = 1,
+
+  /**
+   * Read-only API calls (Get, List and Tail)
+   *
+   * @generated from enum value: TOKEN_SCOPE_READ = 2;
+   */
+  READ = 2,
+
+  /**
+   * Relay connections only
+   *
+   * @generated from enum value: TOKEN_SCOPE_RELAY = 3;
+   */
+  RELAY = 3,
+}
+
+/**
+ * Describes the enum hookly.v1.TokenScope.
+ */
+export const TokenScopeSchema: GenEnum<TokenScope> = /*@__PURE__*/
+  enumDesc(file_hookly_v1_common, 7);
+
+/**
+ * A member's role in an org
+ *
+ * @generated from enum hookly.v1.OrgRole
+ */
+export enum OrgRole {
+  /**
+   * @generated from enum value: ORG_ROLE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Everything, including members and deleting the org
+   *
+   * @generated from enum value: ORG_ROLE_OWNER = 1;
+   */
+  OWNER = 1,
+
+  /**
+   * Manage the org's endpoints and relay their webhooks
+   *
+   * @generated from enum value: ORG_ROLE_MEMBER = 2;
+   */
+  MEMBER = 2,
+
+  /**
+   * Read-only access to the org's endpoints and webhooks
+   *
+   * @generated from enum value: ORG_ROLE_VIEWER = 3;
+   */
+  VIEWER = 3,
+}
+
+/**
+ * Describes the enum hookly.v1.OrgRole.
+ */
+export const OrgRoleSchema: GenEnum<OrgRole> = /*@__PURE__*/
+  enumDesc(file_hookly_v1_common, 8);
+
+/**
+ * Kind of activity feed entry
+ *
+ * @generated from enum hookly.v1.ActivityKind
+ */
+export enum ActivityKind {
+  /**
+   * @generated from enum value: ACTIVITY_KIND_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * Webhooks delivered to an endpoint within an hour
+   *
+   * @generated from enum value: ACTIVITY_KIND_DELIVERIES = 1;
+   */
+  DELIVERIES = 1,
+
+  /**
+   * A relay hub connected
+   *
+   * @generated from enum value: ACTIVITY_KIND_HUB_CONNECTED = 2;
+   */
+  HUB_CONNECTED = 2,
+
+  /**
+   * A relay hub disconnected
+   *
+   * @generated from enum value: ACTIVITY_KIND_HUB_DISCONNECTED = 3;
+   */
+  HUB_DISCONNECTED = 3,
+}
+
+/**
+ * Describes the enum hookly.v1.ActivityKind.
+ */
+export const ActivityKindSchema: GenEnum<ActivityKind> = /*@__PURE__*/
+  enumDesc(file_hookly_v1_common, 9);
+
+/**
+ * @generated from enum hookly.v1.ConnectionEventType
+ */
+export enum ConnectionEventType {
+  /**
+   * @generated from enum value: CONNECTION_EVENT_TYPE_UNSPECIFIED = 0;
+   */
+  UNSPECIFIED = 0,
+
+  /**
+   * A hub serving the endpoint connected
+   *
+   * @generated from enum value: CONNECTION_EVENT_TYPE_CONNECTED = 1;
+   */
+  CONNECTED = 1,
+
+  /**
+   * The hub serving the endpoint disconnected
+   *
+   * @generated from enum value: CONNECTION_EVENT_TYPE_DISCONNECTED = 2;
+   */
+  DISCONNECTED = 2,
+}
+
+/**
+ * Describes the enum hookly.v1.ConnectionEventType.
+ */
+export const ConnectionEventTypeSchema: GenEnum<ConnectionEventType> = /*@__PURE__*/
+  enumDesc(file_hookly_v1_common, 10);
 
@@ -4,7 +4,9 @@
 
 import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegenv2";
 import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
-import type { Endpoint, PaginationRequest, PaginationResponse, ProviderType, SystemSettings, SystemStatus, ThemePreference, UserSettings, VerificationConfig, Webhook, WebhookStatus } from "./common_pb";
+import type { Timestamp } from "@bufbuild/protobuf/wkt";
+import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { ActivityItem, ApiToken, AuditEvent, ConnectionEvent, DestinationDelivery, Endpoint, EndpointSort, HubCommandResult, HubCommandType, IngestAuth, IngestResponse, MaintenanceJob, Org, OrgMember, OrgRole, PaginationRequest, PaginationResponse, PayloadLimits, ProviderType, Region, RetryPolicy, SystemSettings, SystemStatus, ThemePreference, TokenScope, Transform, UserSettings, VerificationConfig, Webhook, WebhookStatus, WebhookStatusChange } from "./common_pb";
 import { file_hookly_v1_common } from "./common_pb";
 import type { Message } from "@bufbuild/protobuf";
 
@@ -12,7 +14,7 @@ import type { Message } from "@bufbuild/protobuf";
  * Describes the file hookly/v1/edge.proto.
  */
 export const file_hookly_v1_edge: GenFile = /*@__PURE__*/
-  fileDesc("ChRob29rbHkvdjEvZWRnZS5wcm90bxIJaG9va2x5LnYxIsQBChVDcmVhdGVFbmRwb2ludFJlcXVlc3QSDAoEbmFtZRgBIAEoCRIuCg1wcm92aWRlcl90eXBlGAIgASgOMhcuaG9va2x5LnYxLlByb3ZpZGVyVHlwZRIYChBzaWduYXR1cmVfc2VjcmV0GAMgASgJEhcKD2Rlc3RpbmF0aW9uX3VybBgEIAEoCRI6ChN2ZXJpZmljYXRpb25fY29uZmlnGAUgASgLMh0uaG9va2x5LnYxLlZlcmlmaWNhdGlvbkNvbmZpZyJUChZDcmVhdGVFbmRwb2ludFJlc3BvbnNlEiUKCGVuZHBvaW50GAEgASgLMhMuaG9va2x5LnYxLkVuZHBvaW50EhMKC3dlYmhvb2tfdXJsGAIgASgJIiAKEkdldEVuZHBvaW50UmVxdWVzdBIKCgJpZBgBIAEoCSJRChNHZXRFbmRwb2ludFJlc3BvbnNlEiUKCGVuZHBvaW50GAEgASgLMhMuaG9va2x5LnYxLkVuZHBvaW50EhMKC3dlYmhvb2tfdXJsGAIgASgJIkgKFExpc3RFbmRwb2ludHNSZXF1ZXN0EjAKCnBhZ2luYXRpb24YASABKAsyHC5ob29rbHkudjEuUGFnaW5hdGlvblJlcXVlc3QicgoVTGlzdEVuZHBvaW50c1Jlc3BvbnNlEiYKCWVuZHBvaW50cxgBIAMoCzITLmhvb2tseS52MS5FbmRwb2ludBIxCgpwYWdpbmF0aW9uGAIgASgLMh0uaG9va2x5LnYxLlBhZ2luYXRpb25SZXNwb25zZSL/AQoVVXBkYXRlRW5kcG9pbnRSZXF1ZXN0EgoKAmlkGAEgASgJEhEKBG5hbWUYAiABKAlIAIgBARIdChBzaWduYXR1cmVfc2VjcmV0GAMgASgJSAGIAQESHAoPZGVzdGluYXRpb25fdXJsGAQgASgJSAKIAQESEgoFbXV0ZWQYBSABKAhIA4gBARI6ChN2ZXJpZmljYXRpb25fY29uZmlnGAYgASgLMh0uaG9va2x5LnYxLlZlcmlmaWNhdGlvbkNvbmZpZ0IHCgVfbmFtZUITChFfc2lnbmF0dXJlX3NlY3JldEISChBfZGVzdGluYXRpb25fdXJsQggKBl9tdXRlZCI/ChZVcGRhdGVFbmRwb2ludFJlc3BvbnNlEiUKCGVuZHBvaW50GAEgASgLMhMuaG9va2x5LnYxLkVuZHBvaW50IiMKFURlbGV0ZUVuZHBvaW50UmVxdWVzdBIKCgJpZBgBIAEoCSIYChZEZWxldGVFbmRwb2ludFJlc3BvbnNlIh8KEUdldFdlYmhvb2tSZXF1ZXN0EgoKAmlkGAEgASgJIjkKEkdldFdlYmhvb2tSZXNwb25zZRIjCgd3ZWJob29rGAEgASgLMhIuaG9va2x5LnYxLldlYmhvb2siqwEKE0xpc3RXZWJob29rc1JlcXVlc3QSGAoLZW5kcG9pbnRfaWQYASABKAlIAIgBARItCgZzdGF0dXMYAiABKA4yGC5ob29rbHkudjEuV2ViaG9va1N0YXR1c0gBiAEBEjAKCnBhZ2luYXRpb24YAyABKAsyHC5ob29rbHkudjEuUGFnaW5hdGlvblJlcXVlc3RCDgoMX2VuZHBvaW50X2lkQgkKB19zdGF0dXMibwoUTGlzdFdlYmhvb2tzUmVzcG9uc2USJAoId2ViaG9va3MYASADKAsyEi5ob29rbHkudjEuV2ViaG9vaxIxCgpwYWdpbmF0aW9uGAIgASgLMh0uaG9va2x5LnYxLlBhZ2luYXRpb25SZXNwb25zZSIiChRSZXBsYXlXZWJob29rUmVxdWVzdBIKCgJpZBgBIAEoCSI8ChVSZXBsYXlXZWJob29rUmVzcG9uc2USIwoHd2ViaG9vaxgBIAEoCzISLmhvb2tseS52MS5XZWJob29rIhIKEEdldFN0YXR1c1JlcXVlc3QiPAoRR2V0U3RhdHVzUmVzcG9uc2USJwoGc3RhdHVzGAEgASgLMhcuaG9va2x5LnYxLlN5c3RlbVN0YXR1cyIUChJHZXRTZXR0aW5nc1JlcXVlc3Qi7wEKE0dldFNldHRpbmdzUmVzcG9uc2USEAoIYmFzZV91cmwYASABKAkSGwoTZ2l0aHViX2F1dGhfZW5hYmxlZBgCIAEoCBImCh50ZWxlZ3JhbV9ub3RpZmljYXRpb25zX2VuYWJsZWQYAyABKAgSDwoHdXNlcl9pZBgEIAEoCRIQCgh1c2VybmFtZRgFIAEoCRISCgphdmF0YXJfdXJsGAYgASgJEjQKEHRoZW1lX3ByZWZlcmVuY2UYByABKA4yGi5ob29rbHkudjEuVGhlbWVQcmVmZXJlbmNlEhQKDGlzX3N1cGVydXNlchgIIAEoCCIYChZHZXRVc2VyU2V0dGluZ3NSZXF1ZXN0IkQKF0dldFVzZXJTZXR0aW5nc1Jlc3BvbnNlEikKCHNldHRpbmdzGAEgASgLMhcuaG9va2x5LnYxLlVzZXJTZXR0aW5ncyKLAgoZVXBkYXRlVXNlclNldHRpbmdzUmVxdWVzdBIfChJ0ZWxlZ3JhbV9ib3RfdG9rZW4YASABKAlIAIgBARIdChB0ZWxlZ3JhbV9jaGF0X2lkGAIgASgJSAGIAQESHQoQdGVsZWdyYW1fZW5hYmxlZBgDIAEoCEgCiAEBEjkKEHRoZW1lX3ByZWZlcmVuY2UYBCABKA4yGi5ob29rbHkudjEuVGhlbWVQcmVmZXJlbmNlSAOIAQFCFQoTX3RlbGVncmFtX2JvdF90b2tlbkITChFfdGVsZWdyYW1fY2hhdF9pZEITChFfdGVsZWdyYW1fZW5hYmxlZEITChFfdGhlbWVfcHJlZmVyZW5jZSJHChpVcGRhdGVVc2VyU2V0dGluZ3NSZXNwb25zZRIpCghzZXR0aW5ncxgBIAEoCzIXLmhvb2tseS52MS5Vc2VyU2V0dGluZ3MiGgoYR2V0U3lzdGVtU2V0dGluZ3NSZXF1ZXN0IkgKGUdldFN5c3RlbVNldHRpbmdzUmVzcG9uc2USKwoIc2V0dGluZ3MYASABKAsyGS5ob29rbHkudjEuU3lzdGVtU2V0dGluZ3My1wgKC0VkZ2VTZXJ2aWNlElUKDkNyZWF0ZUVuZHBvaW50EiAuaG9va2x5LnYxLkNyZWF0ZUVuZHBvaW50UmVxdWVzdBohLmhvb2tseS52MS5DcmVhdGVFbmRwb2ludFJlc3BvbnNlEkwKC0dldEVuZHBvaW50Eh0uaG9va2x5LnYxLkdldEVuZHBvaW50UmVxdWVzdBoeLmhvb2tseS52MS5HZXRFbmRwb2ludFJlc3BvbnNlElIKDUxpc3RFbmRwb2ludHMSHy5ob29rbHkudjEuTGlzdEVuZHBvaW50c1JlcXVlc3QaIC5ob29rbHkudjEuTGlzdEVuZHBvaW50c1Jlc3BvbnNlElUKDlVwZGF0ZUVuZHBvaW50EiAuaG9va2x5LnYxLlVwZGF0ZUVuZHBvaW50UmVxdWVzdBohLmhvb2tseS52MS5VcGRhdGVFbmRwb2ludFJlc3BvbnNlElUKDkRlbGV0ZUVuZHBvaW50EiAuaG9va2x5LnYxLkRlbGV0ZUVuZHBvaW50UmVxdWVzdBohLmhvb2tseS52MS5EZWxldGVFbmRwb2ludFJlc3BvbnNlEkkKCkdldFdlYmhvb2sSHC5ob29rbHkudjEuR2V0V2ViaG9va1JlcXVlc3QaHS5ob29rbHkudjEuR2V0V2ViaG9va1Jlc3BvbnNlEk8KDExpc3RXZWJob29rcxIeLmhvb2tseS52MS5MaXN0V2ViaG9va3NSZXF1ZXN0Gh8uaG9va2x5LnYxLkxpc3RXZWJob29rc1Jlc3BvbnNlElIKDVJlcGxheVdlYmhvb2sSHy5ob29rbHkudjEuUmVwbGF5V2ViaG9va1JlcXVlc3QaIC5ob29rbHkudjEuUmVwbGF5V2ViaG9va1Jlc3BvbnNlEkYKCUdldFN0YXR1cxIbLmhvb2tseS52MS5HZXRTdGF0dXNSZXF1ZXN0GhwuaG9va2x5LnYxLkdldFN0YXR1c1Jlc3BvbnNlEkwKC0dldFNldHRpbmdzEh0uaG9va2x5LnYxLkdldFNldHRpbmdzUmVxdWVzdBoeLmhvb2tseS52MS5HZXRTZXR0aW5nc1Jlc3BvbnNlElgKD0dldFVzZXJTZXR0aW5ncxIhLmhvb2tseS52MS5HZXRVc2VyU2V0dGluZ3NSZXF1ZXN0GiIuaG9va2x5LnYxLkdldFVzZXJTZXR0aW5nc1Jlc3BvbnNlEmEKElVwZGF0ZVVzZXJTZXR0aW5ncxIkLmhvb2tseS52MS5VcGRhdGVVc2VyU2V0dGluZ3NSZXF1ZXN0GiUuaG9va2x5LnYxLlVwZGF0ZVVzZXJTZXR0aW5nc1Jlc3BvbnNlEl4KEUdldFN5c3RlbVNldHRpbmdzEiMuaG9va2x5LnYxLkdldFN5c3RlbVNldHRpbmdzUmVxdWVzdBokLmhvb2tseS52MS5HZXRTeXN0ZW1TZXR0aW5nc1Jlc3BvbnNlQpABCg1jb20uaG9va2x5LnYxQglFZGdlUHJvdG9QAVovaG9va3MuZHgzMTQuY29tL2ludGVybmFsL2FwaS9ob29rbHkvdjE7aG9va2x5djGiAgNIWFiqAglIb29rbHkuVjHKAglIb29rbHlcVjHiAhVIb29rbHlcVjFcR1BCTWV0YWRhdGHqAgpIb29rbHk6OlYxYgZwcm90bzM", [file_hookly_v1_common]);
+  fileDesc("ChRob29rbHkvdjEvZWRnZS5wcm90bxIJaG9va2x5LnYxIp4CChVDcmVhdGVFbmRwb2ludFJlcXVlc3QSDAoEbmFtZRgBIAEoCRIuCg1wcm92aWRlcl90eXBlGAIgASgOMhcuaG9va2x5LnYxLlByb3ZpZGVyVHlwZRIYChBzaWduYXR1cmVfc2VjcmV0GAMgASgJEhcKD2Rlc3RpbmF0aW9uX3VybBgEIAEoCRI6ChN2ZXJpZmljYXRpb25fY29uZmlnGAUgASgLMh0uaG9va2x5LnYxLlZlcmlmaWNhdGlvbkNvbmZpZxIaChJub3RpZnlfZmlyc3RfZXZlbnQYBiABKAgSKgoLaW5nZXN0X2F1dGgYByABKAsyFS5ob29rbHkudjEuSW5nZXN0QXV0aBIQCghob25leXBvdBgIIAEoCCJUChZDcmVhdGVFbmRwb2ludFJlc3BvbnNlEiUKCGVuZHBvaW50GAEgASgLMhMuaG9va2x5LnYxLkVuZHBvaW50EhMKC3dlYmhvb2tfdXJsGAIgASgJIiAKEkdldEVuZHBvaW50UmVxdWVzdBIKCgJpZBgBIAEoCSJRChNHZXRFbmRwb2ludFJlc3BvbnNlEiUKCGVuZHBvaW50GAEgASgLMhMuaG9va2x5LnYxLkVuZHBvaW50EhMKC3dlYmhvb2tfdXJsGAIgASgJIuQBChRMaXN0RW5kcG9pbnRzUmVxdWVzdBIwCgpwYWdpbmF0aW9uGAEgASgLMhwuaG9va2x5LnYxLlBhZ2luYXRpb25SZXF1ZXN0Eg4KBnNlYXJjaBgCIAEoCRIuCg1wcm92aWRlcl90eXBlGAMgASgOMhcuaG9va2x5LnYxLlByb3ZpZGVyVHlwZRISCgVtdXRlZBgEIAEoCEgAiAEBEiUKBHNvcnQYBSABKA4yFy5ob29rbHkudjEuRW5kcG9pbnRTb3J0EhUKDWluYWN0aXZlX2RheXMYBiABKAVCCAoGX211dGVkInIKFUxpc3RFbmRwb2ludHNSZXNwb25zZRImCgllbmRwb2ludHMYASADKAsyEy5ob29rbHkudjEuRW5kcG9pbnQSMQoKcGFnaW5hdGlvbhgCIAEoCzIdLmhvb2tseS52MS5QYWdpbmF0aW9uUmVzcG9uc2Ui7AcKFVVwZGF0ZUVuZHBvaW50UmVxdWVzdBIKCgJpZBgBIAEoCRIRCgRuYW1lGAIgASgJSACIAQESHQoQc2lnbmF0dXJlX3NlY3JldBgDIAEoCUgBiAEBEhwKD2Rlc3RpbmF0aW9uX3VybBgEIAEoCUgCiAEBEhIKBW11dGVkGAUgASgISAOIAQESOgoTdmVyaWZpY2F0aW9uX2NvbmZpZxgGIAEoCzIdLmhvb2tseS52MS5WZXJpZmljYXRpb25Db25maWcSHwoSbm90aWZ5X2ZpcnN0X2V2ZW50GAcgASgISASIAQESFwoKc2xvX3RhcmdldBgIIAEoAUgFiAEBEiAKE3Nsb19sYXRlbmN5X3NlY29uZHMYCSABKAVIBogBARIdChBzbG9fd2luZG93X2hvdXJzGAogASgFSAeIAQESHgoRcmVqZWN0X2R1cGxpY2F0ZXMYCyABKAhICIgBARIqCgtpbmdlc3RfYXV0aBgMIAEoCzIVLmhvb2tseS52MS5Jbmdlc3RBdXRoEhUKCGhvbmV5cG90GA0gASgISAmIAQESIgoVY29uZmxpY3RfYXNfZHVwbGljYXRlGA4gASgISAqIAQESIgoVcmF0ZV9saW1pdF9wZXJfbWludXRlGA8gASgFSAuIAQESJwoJdHJhbnNmb3JtGBAgASgLMhQuaG9va2x5LnYxLlRyYW5zZm9ybRIwCgxkZXN0aW5hdGlvbnMYESABKAsyGi5ob29rbHkudjEuRGVzdGluYXRpb25MaXN0EhoKDWFuc3dlcl9wcm9iZXMYEiABKAhIDIgBARIyCg9pbmdlc3RfcmVzcG9uc2UYEyABKAsyGS5ob29rbHkudjEuSW5nZXN0UmVzcG9uc2USLAoMcmV0cnlfcG9saWN5GBQgASgLMhYuaG9va2x5LnYxLlJldHJ5UG9saWN5EjAKDnBheWxvYWRfbGltaXRzGBUgASgLMhguaG9va2x5LnYxLlBheWxvYWRMaW1pdHNCBwoFX25hbWVCEwoRX3NpZ25hdHVyZV9zZWNyZXRCEgoQX2Rlc3RpbmF0aW9uX3VybEIICgZfbXV0ZWRCFQoTX25vdGlmeV9maXJzdF9ldmVudEINCgtfc2xvX3RhcmdldEIWChRfc2xvX2xhdGVuY3lfc2Vjb25kc0ITChFfc2xvX3dpbmRvd19ob3Vyc0IUChJfcmVqZWN0X2R1cGxpY2F0ZXNCCwoJX2hvbmV5cG90QhgKFl9jb25mbGljdF9hc19kdXBsaWNhdGVCGAoWX3JhdGVfbGltaXRfcGVyX21pbnV0ZUIQCg5fYW5zd2VyX3Byb2JlcyIfCg9EZXN0aW5hdGlvbkxpc3QSDAoEdXJscxgBIAMoCSI/ChZVcGRhdGVFbmRwb2ludFJlc3BvbnNlEiUKCGVuZHBvaW50GAEgASgLMhMuaG9va2x5LnYxLkVuZHBvaW50IiMKFURlbGV0ZUVuZHBvaW50UmVxdWVzdBIKCgJpZBgBIAEoCSIYChZEZWxldGVFbmRwb2ludFJlc3BvbnNlIjIKG0dldFNldHVwSW5zdHJ1Y3Rpb25zUmVxdWVzdBITCgtlbmRwb2ludF9pZBgBIAEoCSJ5ChxHZXRTZXR1cEluc3RydWN0aW9uc1Jlc3BvbnNlEhMKC3dlYmhvb2tfdXJsGAEgASgJEi4KDXByb3ZpZGVyX3R5cGUYAiABKA4yFy5ob29rbHkudjEuUHJvdmlkZXJUeXBlEhQKDGluc3RydWN0aW9ucxgDIAEoCSKiAQoVVGVsZWdyYW1XZWJob29rU3RhdHVzEgsKA3VybBgBIAEoCRIPCgdtYXRjaGVzGAIgASgIEhwKFHBlbmRpbmdfdXBkYXRlX2NvdW50GAMgASgFEhoKEmxhc3RfZXJyb3JfbWVzc2FnZRgEIAEoCRIxCg1sYXN0X2Vycm9yX2F0GAUgASgLMhouZ29vZ2xlLnByb3RvYnVmLlRpbWVzdGFtcCJFChtTZXR1cFRlbGVncmFtV2ViaG9va1JlcXVlc3QSEwoLZW5kcG9pbnRfaWQYASABKAkSEQoJYm90X3Rva2VuGAIgASgJIlAKHFNldHVwVGVsZWdyYW1XZWJob29rUmVzcG9uc2USMAoGc3RhdHVzGAEgASgLMiAuaG9va2x5LnYxLlRlbGVncmFtV2ViaG9va1N0YXR1cyIzChxWZXJpZnlUZWxlZ3JhbVdlYmhvb2tSZXF1ZXN0EhMKC2VuZHBvaW50X2lkGAEgASgJIlEKHVZlcmlmeVRlbGVncmFtV2ViaG9va1Jlc3BvbnNlEjAKBnN0YXR1cxgBIAEoCzIgLmhvb2tseS52MS5UZWxlZ3JhbVdlYmhvb2tTdGF0dXMiLgoXR2V0RW5kcG9pbnRTdGF0c1JlcXVlc3QSEwoLZW5kcG9pbnRfaWQYASABKAkiMwoORXZlbnRUeXBlQ291bnQSEgoKZXZlbnRfdHlwZRgBIAEoCRINCgVjb3VudBgCIAEoAyKQAQoNU0xPQ29tcGxpYW5jZRIOCgZ0YXJnZXQYASABKAESFwoPbGF0ZW5jeV9zZWNvbmRzGAIgASgFEhQKDHdpbmRvd19ob3VycxgDIAEoBRINCgV0b3RhbBgEIAEoAxILCgNtZXQYBSABKAMSEgoKY29tcGxpYW5jZRgGIAEoARIQCghicmVhY2hlZBgHIAEoCCJxChhHZXRFbmRwb2ludFN0YXRzUmVzcG9uc2USLgoLZXZlbnRfdHlwZXMYASADKAsyGS5ob29rbHkudjEuRXZlbnRUeXBlQ291bnQSJQoDc2xvGAIgASgLMhguaG9va2x5LnYxLlNMT0NvbXBsaWFuY2UiVgobTGlzdENvbm5lY3Rpb25FdmVudHNSZXF1ZXN0EhgKC2VuZHBvaW50X2lkGAEgASgJSACIAQESDQoFbGltaXQYAiABKAVCDgoMX2VuZHBvaW50X2lkIkoKHExpc3RDb25uZWN0aW9uRXZlbnRzUmVzcG9uc2USKgoGZXZlbnRzGAEgAygLMhouaG9va2x5LnYxLkNvbm5lY3Rpb25FdmVudCI0Ch1HZW5lcmF0ZUVuZHBvaW50U2VjcmV0UmVxdWVzdBITCgtlbmRwb2ludF9pZBgBIAEoCSIwCh5HZW5lcmF0ZUVuZHBvaW50U2VjcmV0UmVzcG9uc2USDgoGc2VjcmV0GAEgASgJIjIKG1JldmVhbEVuZHBvaW50U2VjcmV0UmVxdWVzdBITCgtlbmRwb2ludF9pZBgBIAEoCSIuChxSZXZlYWxFbmRwb2ludFNlY3JldFJlc3BvbnNlEg4KBnNlY3JldBgBIAEoCSJ3ChFHZXRXZWJob29rUmVxdWVzdBIKCgJpZBgBIAEoCRIcCg9pbmNsdWRlX3BheWxvYWQYAiABKAhIAIgBARIWCglqc29uX3BhdGgYAyABKAlIAYgBAUISChBfaW5jbHVkZV9wYXlsb2FkQgwKCl9qc29uX3BhdGgibQoSR2V0V2ViaG9va1Jlc3BvbnNlEiMKB3dlYmhvb2sYASABKAsyEi5ob29rbHkudjEuV2ViaG9vaxIyCgpkZWxpdmVyaWVzGAIgAygLMh4uaG9va2x5LnYxLkRlc3RpbmF0aW9uRGVsaXZlcnkiJgoYR2V0V2ViaG9va1BheWxvYWRSZXF1ZXN0EgoKAmlkGAEgASgJIiwKGUdldFdlYmhvb2tQYXlsb2FkUmVzcG9uc2USDwoHcGF5bG9hZBgBIAEoDCKVAgoTTGlzdFdlYmhvb2tzUmVxdWVzdBIYCgtlbmRwb2ludF9pZBgBIAEoCUgAiAEBEi0KBnN0YXR1cxgCIAEoDjIYLmhvb2tseS52MS5XZWJob29rU3RhdHVzSAGIAQESMAoKcGFnaW5hdGlvbhgDIAEoCzIcLmhvb2tseS52MS5QYWdpbmF0aW9uUmVxdWVzdBIXCgpldmVudF90eXBlGAQgASgJSAKIAQESHAoPaW5jbHVkZV9wYXlsb2FkGAUgASgISAOIAQESDgoGcHVyZ2VkGAYgASgIQg4KDF9lbmRwb2ludF9pZEIJCgdfc3RhdHVzQg0KC19ldmVudF90eXBlQhIKEF9pbmNsdWRlX3BheWxvYWQibwoUTGlzdFdlYmhvb2tzUmVzcG9uc2USJAoId2ViaG9va3MYASADKAsyEi5ob29rbHkudjEuV2ViaG9vaxIxCgpwYWdpbmF0aW9uGAIgASgLMh0uaG9va2x5LnYxLlBhZ2luYXRpb25SZXNwb25zZSI5ChRSZXBsYXlXZWJob29rUmVxdWVzdBIKCgJpZBgBIAEoCRIVCg1jb25maXJtX3Rva2VuGAIgASgJIpABChVSZXBsYXlXZWJob29rUmVzcG9uc2USIwoHd2ViaG9vaxgBIAEoCzISLmhvb2tseS52MS5XZWJob29rEh0KFWNvbmZpcm1hdGlvbl9yZXF1aXJlZBgCIAEoCBIaChJjb25maXJtYXRpb25fdG9rZW4YAyABKAkSFwoPcGVuZGluZ19yZXBsYXlzGAQgASgFIoICChlCdWxrUmVwbGF5V2ViaG9va3NSZXF1ZXN0EhgKC2VuZHBvaW50X2lkGAEgASgJSACIAQESKAoGc3RhdHVzGAIgASgOMhguaG9va2x5LnYxLldlYmhvb2tTdGF0dXMSMgoOcmVjZWl2ZWRfYWZ0ZXIYAyABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjMKD3JlY2VpdmVkX2JlZm9yZRgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASEQoJbWF4X2NvdW50GAUgASgFEhUKDWNvbmZpcm1fdG9rZW4YBiABKAlCDgoMX2VuZHBvaW50X2lkIocBChpCdWxrUmVwbGF5V2ViaG9va3NSZXNwb25zZRIWCg5yZXBsYXllZF9jb3VudBgBIAEoBRIdChVjb25maXJtYXRpb25fcmVxdWlyZWQYAiABKAgSGgoSY29uZmlybWF0aW9uX3Rva2VuGAMgASgJEhYKDm1hdGNoaW5nX2NvdW50GAQgASgFIiQKFlVuZGVsZXRlV2ViaG9va1JlcXVlc3QSCgoCaWQYASABKAkiPgoXVW5kZWxldGVXZWJob29rUmVzcG9uc2USIwoHd2ViaG9vaxgBIAEoCzISLmhvb2tseS52MS5XZWJob29rIkcKG0NhbmNlbFBlbmRpbmdSZXBsYXlzUmVxdWVzdBIYCgtlbmRwb2ludF9pZBgBIAEoCUgAiAEBQg4KDF9lbmRwb2ludF9pZCI3ChxDYW5jZWxQZW5kaW5nUmVwbGF5c1Jlc3BvbnNlEhcKD2NhbmNlbGxlZF9jb3VudBgBIAEoBSJrChNUYWlsV2ViaG9va3NSZXF1ZXN0EhgKC2VuZHBvaW50X2lkGAEgASgJSACIAQESKgoIc3RhdHVzZXMYAiADKA4yGC5ob29rbHkudjEuV2ViaG9va1N0YXR1c0IOCgxfZW5kcG9pbnRfaWQiawoUVGFpbFdlYmhvb2tzUmVzcG9uc2USIwoHd2ViaG9vaxgBIAEoCzISLmhvb2tseS52MS5XZWJob29rEi4KBmNoYW5nZRgCIAEoCzIeLmhvb2tseS52MS5XZWJob29rU3RhdHVzQ2hhbmdlIhIKEEdldFN0YXR1c1JlcXVlc3QiPAoRR2V0U3RhdHVzUmVzcG9uc2USJwoGc3RhdHVzGAEgASgLMhcuaG9va2x5LnYxLlN5c3RlbVN0YXR1cyI8ChZHZXRBY3Rpdml0eUZlZWRSZXF1ZXN0Eg0KBWxpbWl0GAEgASgFEhMKC3NpbmNlX2hvdXJzGAIgASgFIkEKF0dldEFjdGl2aXR5RmVlZFJlc3BvbnNlEiYKBWl0ZW1zGAEgAygLMhcuaG9va2x5LnYxLkFjdGl2aXR5SXRlbSITChFHZXRSZWdpb25zUmVxdWVzdCJQChJHZXRSZWdpb25zUmVzcG9uc2USFgoOY3VycmVudF9yZWdpb24YASABKAkSIgoHcmVnaW9ucxgCIAMoCzIRLmhvb2tseS52MS5SZWdpb24iEwoRR2V0VmVyc2lvblJlcXVlc3QiXQoSR2V0VmVyc2lvblJlc3BvbnNlEg8KB3ZlcnNpb24YASABKAkSDgoGY29tbWl0GAIgASgJEhIKCmJ1aWxkX2RhdGUYAyABKAkSEgoKZ29fdmVyc2lvbhgEIAEoCSJiChVTZW5kSHViQ29tbWFuZFJlcXVlc3QSDgoGaHViX2lkGAEgASgJEioKB2NvbW1hbmQYAiABKA4yGS5ob29rbHkudjEuSHViQ29tbWFuZFR5cGUSDQoFbGluZXMYAyABKAUiRQoWU2VuZEh1YkNvbW1hbmRSZXNwb25zZRIrCgZyZXN1bHQYASABKAsyGy5ob29rbHkudjEuSHViQ29tbWFuZFJlc3VsdCIUChJHZXRTZXR0aW5nc1JlcXVlc3QirwIKE0dldFNldHRpbmdzUmVzcG9uc2USEAoIYmFzZV91cmwYASABKAkSGwoTZ2l0aHViX2F1dGhfZW5hYmxlZBgCIAEoCBImCh50ZWxlZ3JhbV9ub3RpZmljYXRpb25zX2VuYWJsZWQYAyABKAgSDwoHdXNlcl9pZBgEIAEoCRIQCgh1c2VybmFtZRgFIAEoCRISCgphdmF0YXJfdXJsGAYgASgJEjQKEHRoZW1lX3ByZWZlcmVuY2UYByABKA4yGi5ob29rbHkudjEuVGhlbWVQcmVmZXJlbmNlEhQKDGlzX3N1cGVydXNlchgIIAEoCBIlCh1kaXNjb3JkX25vdGlmaWNhdGlvbnNfZW5hYmxlZBgJIAEoCBIXCg9lbWFpbF9hdmFpbGFibGUYCiABKAgiFwoVR2V0Q3VycmVudFVzZXJSZXF1ZXN0ImMKFkdldEN1cnJlbnRVc2VyUmVzcG9uc2USJQoEdXNlchgBIAEoCzIXLmhvb2tseS52MS5Vc2VyU2V0dGluZ3MSIgoFdG9rZW4YAiABKAsyEy5ob29rbHkudjEuQXBpVG9rZW4iGAoWR2V0VXNlclNldHRpbmdzUmVxdWVzdCJEChdHZXRVc2VyU2V0dGluZ3NSZXNwb25zZRIpCghzZXR0aW5ncxgBIAEoCzIXLmhvb2tseS52MS5Vc2VyU2V0dGluZ3MiiQUKGVVwZGF0ZVVzZXJTZXR0aW5nc1JlcXVlc3QSHwoSdGVsZWdyYW1fYm90X3Rva2VuGAEgASgJSACIAQESHQoQdGVsZWdyYW1fY2hhdF9pZBgCIAEoCUgBiAEBEh0KEHRlbGVncmFtX2VuYWJsZWQYAyABKAhIAogBARI5ChB0aGVtZV9wcmVmZXJlbmNlGAQgASgOMhouaG9va2x5LnYxLlRoZW1lUHJlZmVyZW5jZUgDiAEBEiAKE2Rpc2NvcmRfd2ViaG9va191cmwYBSABKAlIBIgBARIcCg9kaXNjb3JkX2VuYWJsZWQYBiABKAhIBYgBARIaCg1lbWFpbF9hZGRyZXNzGAcgASgJSAaIAQESGgoNZW1haWxfZW5hYmxlZBgIIAEoCEgHiAEBEh8KEm5vdGlmeV93ZWJob29rX3VybBgJIAEoCUgIiAEBEiIKFW5vdGlmeV93ZWJob29rX3NlY3JldBgKIAEoCUgJiAEBEiMKFm5vdGlmeV93ZWJob29rX2VuYWJsZWQYCyABKAhICogBAUIVChNfdGVsZWdyYW1fYm90X3Rva2VuQhMKEV90ZWxlZ3JhbV9jaGF0X2lkQhMKEV90ZWxlZ3JhbV9lbmFibGVkQhMKEV90aGVtZV9wcmVmZXJlbmNlQhYKFF9kaXNjb3JkX3dlYmhvb2tfdXJsQhIKEF9kaXNjb3JkX2VuYWJsZWRCEAoOX2VtYWlsX2FkZHJlc3NCEAoOX2VtYWlsX2VuYWJsZWRCFQoTX25vdGlmeV93ZWJob29rX3VybEIYChZfbm90aWZ5X3dlYmhvb2tfc2VjcmV0QhkKF19ub3RpZnlfd2ViaG9va19lbmFibGVkIkcKGlVwZGF0ZVVzZXJTZXR0aW5nc1Jlc3BvbnNlEikKCHNldHRpbmdzGAEgASgLMhcuaG9va2x5LnYxLlVzZXJTZXR0aW5ncyIWChRTZW5kVGVzdEVtYWlsUmVxdWVzdCIuChVTZW5kVGVzdEVtYWlsUmVzcG9uc2USFQoNZW1haWxfYWRkcmVzcxgBIAEoCSIeChxTZW5kVGVzdE5vdGlmeVdlYmhvb2tSZXF1ZXN0IjEKHVNlbmRUZXN0Tm90aWZ5V2ViaG9va1Jlc3BvbnNlEhAKCGV2ZW50X2lkGAEgASgJIhYKFExpc3RBcGlUb2tlbnNSZXF1ZXN0IjwKFUxpc3RBcGlUb2tlbnNSZXNwb25zZRIjCgZ0b2tlbnMYASADKAsyEy5ob29rbHkudjEuQXBpVG9rZW4iZwoVQ3JlYXRlQXBpVG9rZW5SZXF1ZXN0EgwKBG5hbWUYASABKAkSJAoFc2NvcGUYAiABKA4yFS5ob29rbHkudjEuVG9rZW5TY29wZRIaChJleHBpcmVzX2luX3NlY29uZHMYAyABKAMiTwoWQ3JlYXRlQXBpVG9rZW5SZXNwb25zZRINCgV0b2tlbhgBIAEoCRImCglhcGlfdG9rZW4YAiABKAsyEy5ob29rbHkudjEuQXBpVG9rZW4iIwoVUm90YXRlQXBpVG9rZW5SZXF1ZXN0EgoKAmlkGAEgASgJIk8KFlJvdGF0ZUFwaVRva2VuUmVzcG9uc2USDQoFdG9rZW4YASABKAkSJgoJYXBpX3Rva2VuGAIgASgLMhMuaG9va2x5LnYxLkFwaVRva2VuIiMKFVJldm9rZUFwaVRva2VuUmVxdWVzdBIKCgJpZBgBIAEoCSIYChZSZXZva2VBcGlUb2tlblJlc3BvbnNlIhEKD0xpc3RPcmdzUmVxdWVzdCIwChBMaXN0T3Jnc1Jlc3BvbnNlEhwKBG9yZ3MYASADKAsyDi5ob29rbHkudjEuT3JnIiAKEENyZWF0ZU9yZ1JlcXVlc3QSDAoEbmFtZRgBIAEoCSIwChFDcmVhdGVPcmdSZXNwb25zZRIbCgNvcmcYASABKAsyDi5ob29rbHkudjEuT3JnIh4KEERlbGV0ZU9yZ1JlcXVlc3QSCgoCaWQYASABKAkiLgoRRGVsZXRlT3JnUmVzcG9uc2USGQoRZW5kcG9pbnRzX2RlbGV0ZWQYASABKAMiJwoVTGlzdE9yZ01lbWJlcnNSZXF1ZXN0Eg4KBm9yZ19pZBgBIAEoCSI/ChZMaXN0T3JnTWVtYmVyc1Jlc3BvbnNlEiUKB21lbWJlcnMYASADKAsyFC5ob29rbHkudjEuT3JnTWVtYmVyIlkKE0FkZE9yZ01lbWJlclJlcXVlc3QSDgoGb3JnX2lkGAEgASgJEhAKCHVzZXJuYW1lGAIgASgJEiAKBHJvbGUYAyABKA4yEi5ob29rbHkudjEuT3JnUm9sZSI8ChRBZGRPcmdNZW1iZXJSZXNwb25zZRIkCgZtZW1iZXIYASABKAsyFC5ob29rbHkudjEuT3JnTWVtYmVyIjkKFlJlbW92ZU9yZ01lbWJlclJlcXVlc3QSDgoGb3JnX2lkGAEgASgJEg8KB3VzZXJfaWQYAiABKAkiGQoXUmVtb3ZlT3JnTWVtYmVyUmVzcG9uc2UicgoWTGlzdEF1ZGl0RXZlbnRzUmVxdWVzdBITCgZhY3Rpb24YASABKAlIAIgBARIpCgVzaW5jZRgCIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASDQoFbGltaXQYAyABKAVCCQoHX2FjdGlvbiJAChdMaXN0QXVkaXRFdmVudHNSZXNwb25zZRIlCgZldmVudHMYASADKAsyFS5ob29rbHkudjEuQXVkaXRFdmVudCIaChhHZXRTeXN0ZW1TZXR0aW5nc1JlcXVlc3QiSAoZR2V0U3lzdGVtU2V0dGluZ3NSZXNwb25zZRIrCghzZXR0aW5ncxgBIAEoCzIZLmhvb2tseS52MS5TeXN0ZW1TZXR0aW5ncyIkChVSdW5NYWludGVuYW5jZVJlcXVlc3QSCwoDam9iGAEgASgJIkAKFlJ1bk1haW50ZW5hbmNlUmVzcG9uc2USJgoDam9iGAEgASgLMhkuaG9va2x5LnYxLk1haW50ZW5hbmNlSm9iIiMKElNldExvZ0xldmVsUmVxdWVzdBINCgVsZXZlbBgBIAEoCSI8ChNTZXRMb2dMZXZlbFJlc3BvbnNlEg0KBWxldmVsGAEgASgJEhYKDnByZXZpb3VzX2xldmVsGAIgASgJMpkfCgtFZGdlU2VydmljZRJVCg5DcmVhdGVFbmRwb2ludBIgLmhvb2tseS52MS5DcmVhdGVFbmRwb2ludFJlcXVlc3QaIS5ob29rbHkudjEuQ3JlYXRlRW5kcG9pbnRSZXNwb25zZRJMCgtHZXRFbmRwb2ludBIdLmhvb2tseS52MS5HZXRFbmRwb2ludFJlcXVlc3QaHi5ob29rbHkudjEuR2V0RW5kcG9pbnRSZXNwb25zZRJSCg1MaXN0RW5kcG9pbnRzEh8uaG9va2x5LnYxLkxpc3RFbmRwb2ludHNSZXF1ZXN0GiAuaG9va2x5LnYxLkxpc3RFbmRwb2ludHNSZXNwb25zZRJVCg5VcGRhdGVFbmRwb2ludBIgLmhvb2tseS52MS5VcGRhdGVFbmRwb2ludFJlcXVlc3QaIS5ob29rbHkudjEuVXBkYXRlRW5kcG9pbnRSZXNwb25zZRJVCg5EZWxldGVFbmRwb2ludBIgLmhvb2tseS52MS5EZWxldGVFbmRwb2ludFJlcXVlc3QaIS5ob29rbHkudjEuRGVsZXRlRW5kcG9pbnRSZXNwb25zZRJnChRHZXRTZXR1cEluc3RydWN0aW9ucxImLmhvb2tseS52MS5HZXRTZXR1cEluc3RydWN0aW9uc1JlcXVlc3QaJy5ob29rbHkudjEuR2V0U2V0dXBJbnN0cnVjdGlvbnNSZXNwb25zZRJnChRTZXR1cFRlbGVncmFtV2ViaG9vaxImLmhvb2tseS52MS5TZXR1cFRlbGVncmFtV2ViaG9va1JlcXVlc3QaJy5ob29rbHkudjEuU2V0dXBUZWxlZ3JhbVdlYmhvb2tSZXNwb25zZRJqChVWZXJpZnlUZWxlZ3JhbVdlYmhvb2sSJy5ob29rbHkudjEuVmVyaWZ5VGVsZWdyYW1XZWJob29rUmVxdWVzdBooLmhvb2tseS52MS5WZXJpZnlUZWxlZ3JhbVdlYmhvb2tSZXNwb25zZRJbChBHZXRFbmRwb2ludFN0YXRzEiIuaG9va2x5LnYxLkdldEVuZHBvaW50U3RhdHNSZXF1ZXN0GiMuaG9va2x5LnYxLkdldEVuZHBvaW50U3RhdHNSZXNwb25zZRJnChRMaXN0Q29ubmVjdGlvbkV2ZW50cxImLmhvb2tseS52MS5MaXN0Q29ubmVjdGlvbkV2ZW50c1JlcXVlc3QaJy5ob29rbHkudjEuTGlzdENvbm5lY3Rpb25FdmVudHNSZXNwb25zZRJtChZHZW5lcmF0ZUVuZHBvaW50U2VjcmV0EiguaG9va2x5LnYxLkdlbmVyYXRlRW5kcG9pbnRTZWNyZXRSZXF1ZXN0GikuaG9va2x5LnYxLkdlbmVyYXRlRW5kcG9pbnRTZWNyZXRSZXNwb25zZRJnChRSZXZlYWxFbmRwb2ludFNlY3JldBImLmhvb2tseS52MS5SZXZlYWxFbmRwb2ludFNlY3JldFJlcXVlc3QaJy5ob29rbHkudjEuUmV2ZWFsRW5kcG9pbnRTZWNyZXRSZXNwb25zZRJJCgpHZXRXZWJob29rEhwuaG9va2x5LnYxLkdldFdlYmhvb2tSZXF1ZXN0Gh0uaG9va2x5LnYxLkdldFdlYmhvb2tSZXNwb25zZRJeChFHZXRXZWJob29rUGF5bG9hZBIjLmhvb2tseS52MS5HZXRXZWJob29rUGF5bG9hZFJlcXVlc3QaJC5ob29rbHkudjEuR2V0V2ViaG9va1BheWxvYWRSZXNwb25zZRJPCgxMaXN0V2ViaG9va3MSHi5ob29rbHkudjEuTGlzdFdlYmhvb2tzUmVxdWVzdBofLmhvb2tseS52MS5MaXN0V2ViaG9va3NSZXNwb25zZRJSCg1SZXBsYXlXZWJob29rEh8uaG9va2x5LnYxLlJlcGxheVdlYmhvb2tSZXF1ZXN0GiAuaG9va2x5LnYxLlJlcGxheVdlYmhvb2tSZXNwb25zZRJhChJCdWxrUmVwbGF5V2ViaG9va3MSJC5ob29rbHkudjEuQnVsa1JlcGxheVdlYmhvb2tzUmVxdWVzdBolLmhvb2tseS52MS5CdWxrUmVwbGF5V2ViaG9va3NSZXNwb25zZRJnChRDYW5jZWxQZW5kaW5nUmVwbGF5cxImLmhvb2tseS52MS5DYW5jZWxQZW5kaW5nUmVwbGF5c1JlcXVlc3QaJy5ob29rbHkudjEuQ2FuY2VsUGVuZGluZ1JlcGxheXNSZXNwb25zZRJYCg9VbmRlbGV0ZVdlYmhvb2sSIS5ob29rbHkudjEuVW5kZWxldGVXZWJob29rUmVxdWVzdBoiLmhvb2tseS52MS5VbmRlbGV0ZVdlYmhvb2tSZXNwb25zZRJRCgxUYWlsV2ViaG9va3MSHi5ob29rbHkudjEuVGFpbFdlYmhvb2tzUmVxdWVzdBofLmhvb2tseS52MS5UYWlsV2ViaG9va3NSZXNwb25zZTABEkYKCUdldFN0YXR1cxIbLmhvb2tseS52MS5HZXRTdGF0dXNSZXF1ZXN0GhwuaG9va2x5LnYxLkdldFN0YXR1c1Jlc3BvbnNlEkwKC0dldFNldHRpbmdzEh0uaG9va2x5LnYxLkdldFNldHRpbmdzUmVxdWVzdBoeLmhvb2tseS52MS5HZXRTZXR0aW5nc1Jlc3BvbnNlElgKD0dldEFjdGl2aXR5RmVlZBIhLmhvb2tseS52MS5HZXRBY3Rpdml0eUZlZWRSZXF1ZXN0GiIuaG9va2x5LnYxLkdldEFjdGl2aXR5RmVlZFJlc3BvbnNlEkkKCkdldFJlZ2lvbnMSHC5ob29rbHkudjEuR2V0UmVnaW9uc1JlcXVlc3QaHS5ob29rbHkudjEuR2V0UmVnaW9uc1Jlc3BvbnNlEkkKCkdldFZlcnNpb24SHC5ob29rbHkudjEuR2V0VmVyc2lvblJlcXVlc3QaHS5ob29rbHkudjEuR2V0VmVyc2lvblJlc3BvbnNlElUKDlNlbmRIdWJDb21tYW5kEiAuaG9va2x5LnYxLlNlbmRIdWJDb21tYW5kUmVxdWVzdBohLmhvb2tseS52MS5TZW5kSHViQ29tbWFuZFJlc3BvbnNlElUKDkdldEN1cnJlbnRVc2VyEiAuaG9va2x5LnYxLkdldEN1cnJlbnRVc2VyUmVxdWVzdBohLmhvb2tseS52MS5HZXRDdXJyZW50VXNlclJlc3BvbnNlElgKD0dldFVzZXJTZXR0aW5ncxIhLmhvb2tseS52MS5HZXRVc2VyU2V0dGluZ3NSZXF1ZXN0GiIuaG9va2x5LnYxLkdldFVzZXJTZXR0aW5nc1Jlc3BvbnNlEmEKElVwZGF0ZVVzZXJTZXR0aW5ncxIkLmhvb2tseS52MS5VcGRhdGVVc2VyU2V0dGluZ3NSZXF1ZXN0GiUuaG9va2x5LnYxLlVwZGF0ZVVzZXJTZXR0aW5nc1Jlc3BvbnNlElIKDVNlbmRUZXN0RW1haWwSHy5ob29rbHkudjEuU2VuZFRlc3RFbWFpbFJlcXVlc3QaIC5ob29rbHkudjEuU2VuZFRlc3RFbWFpbFJlc3BvbnNlEmoKFVNlbmRUZXN0Tm90aWZ5V2ViaG9vaxInLmhvb2tseS52MS5TZW5kVGVzdE5vdGlmeVdlYmhvb2tSZXF1ZXN0GiguaG9va2x5LnYxLlNlbmRUZXN0Tm90aWZ5V2ViaG9va1Jlc3BvbnNlElIKDUxpc3RBcGlUb2tlbnMSHy5ob29rbHkudjEuTGlzdEFwaVRva2Vuc1JlcXVlc3QaIC5ob29rbHkudjEuTGlzdEFwaVRva2Vuc1Jlc3BvbnNlElUKDkNyZWF0ZUFwaVRva2VuEiAuaG9va2x5LnYxLkNyZWF0ZUFwaVRva2VuUmVxdWVzdBohLmhvb2tseS52MS5DcmVhdGVBcGlUb2tlblJlc3BvbnNlElUKDlJvdGF0ZUFwaVRva2VuEiAuaG9va2x5LnYxLlJvdGF0ZUFwaVRva2VuUmVxdWVzdBohLmhvb2tseS52MS5Sb3RhdGVBcGlUb2tlblJlc3BvbnNlElUKDlJldm9rZUFwaVRva2VuEiAuaG9va2x5LnYxLlJldm9rZUFwaVRva2VuUmVxdWVzdBohLmhvb2tseS52MS5SZXZva2VBcGlUb2tlblJlc3BvbnNlEkMKCExpc3RPcmdzEhouaG9va2x5LnYxLkxpc3RPcmdzUmVxdWVzdBobLmhvb2tseS52MS5MaXN0T3Jnc1Jlc3BvbnNlEkYKCUNyZWF0ZU9yZxIbLmhvb2tseS52MS5DcmVhdGVPcmdSZXF1ZXN0GhwuaG9va2x5LnYxLkNyZWF0ZU9yZ1Jlc3BvbnNlEkYKCURlbGV0ZU9yZxIbLmhvb2tseS52MS5EZWxldGVPcmdSZXF1ZXN0GhwuaG9va2x5LnYxLkRlbGV0ZU9yZ1Jlc3BvbnNlElUKDkxpc3RPcmdNZW1iZXJzEiAuaG9va2x5LnYxLkxpc3RPcmdNZW1iZXJzUmVxdWVzdBohLmhvb2tseS52MS5MaXN0T3JnTWVtYmVyc1Jlc3BvbnNlEk8KDEFkZE9yZ01lbWJlchIeLmhvb2tseS52MS5BZGRPcmdNZW1iZXJSZXF1ZXN0Gh8uaG9va2x5LnYxLkFkZE9yZ01lbWJlclJlc3BvbnNlElgKD1JlbW92ZU9yZ01lbWJlchIhLmhvb2tseS52MS5SZW1vdmVPcmdNZW1iZXJSZXF1ZXN0GiIuaG9va2x5LnYxLlJlbW92ZU9yZ01lbWJlclJlc3BvbnNlElgKD0xpc3RBdWRpdEV2ZW50cxIhLmhvb2tseS52MS5MaXN0QXVkaXRFdmVudHNSZXF1ZXN0GiIuaG9va2x5LnYxLkxpc3RBdWRpdEV2ZW50c1Jlc3BvbnNlEl4KEUdldFN5c3RlbVNldHRpbmdzEiMuaG9va2x5LnYxLkdldFN5c3RlbVNldHRpbmdzUmVxdWVzdBokLmhvb2tseS52MS5HZXRTeXN0ZW1TZXR0aW5nc1Jlc3BvbnNlElUKDlJ1bk1haW50ZW5hbmNlEiAuaG9va2x5LnYxLlJ1bk1haW50ZW5hbmNlUmVxdWVzdBohLmhvb2tseS52MS5SdW5NYWludGVuYW5jZVJlc3BvbnNlEkwKC1NldExvZ0xldmVsEh0uaG9va2x5LnYxLlNldExvZ0xldmVsUmVxdWVzdBoeLmhvb2tseS52MS5TZXRMb2dMZXZlbFJlc3BvbnNlQpABCg1jb20uaG9va2x5LnYxQglFZGdlUHJvdG9QAVovaG9va3MuZHgzMTQuY29tL2ludGVybmFsL2FwaS9ob29rbHkvdjE7aG9va2x5djGiAgNIWFiqAglIb29rbHkuVjHKAglIb29rbHlcVjHiAhVIb29rbHlcVjFcR1BCTWV0YWRhdGHqAgpIb29rbHk6OlYxYgZwcm90bzM", [file_google_protobuf_timestamp, file_hookly_v1_common]);
 
 /**
  * @generated from message hookly.v1.CreateEndpointRequest
@@ -44,6 +46,27 @@ export type CreateEndpointRequest = Message<"hookly.v1.CreateEndpointRequest"> &
    * @generated from field: hookly.v1.VerificationConfig verification_config = 5;
    */
   verificationConfig?: VerificationConfig;
+
+  /**
+   * Send a notification when the first webhook arrives
+   *
+   * @generated from field: bool notify_first_event = 6;
+   */
+  notifyFirstEvent: boolean;
+
+  /**
+   * Credentials required at the ingestion URL (optional)
+   *
+   * @generated from field: hookly.v1.IngestAuth ingest_auth = 7;
+   */
+  ingestAuth?: IngestAuth;
+
+  /**
+   * Alert on every hit and never relay; destination_url is optional
+   *
+   * @generated from field: bool honeypot = 8;
+   */
+  honeypot: boolean;
 };
 
 /**
@@ -124,6 +147,40 @@ export type ListEndpointsRequest = Message<"hookly.v1.ListEndpointsRequest"> & {
    * @generated from field: hookly.v1.PaginationRequest pagination = 1;
    */
   pagination?: PaginationRequest;
+
+  /**
+   * Case-insensitive substring of the endpoint name
+   *
+   * @generated from field: string search = 2;
+   */
+  search: string;
+
+  /**
+   * Only endpoints of this provider when set
+   *
+   * @generated from field: hookly.v1.ProviderType provider_type = 3;
+   */
+  providerType: ProviderType;
+
+  /**
+   * Only muted (true) or unmuted (false) endpoints when set
+   *
+   * @generated from field: optional bool muted = 4;
+   */
+  muted?: boolean;
+
+  /**
+   * @generated from field: hookly.v1.EndpointSort sort = 5;
+   */
+  sort: EndpointSort;
+
+  /**
+   * Only endpoints without a webhook for this many days, counting from
+   * creation for endpoints that never received one. 0 disables the filter.
+   *
+   * @generated from field: int32 inactive_days = 6;
+   */
+  inactiveDays: number;
 };
 
 /**
@@ -190,6 +247,97 @@ export type UpdateEndpointRequest = Message<"hookly.v1.UpdateEndpointRequest"> &
    * @generated from field: hookly.v1.VerificationConfig verification_config = 6;
    */
   verificationConfig?: VerificationConfig;
+
+  /**
+   * @generated from field: optional bool notify_first_event = 7;
+   */
+  notifyFirstEvent?: boolean;
+
+  /**
+   * Delivery SLO; set slo_target to 0 to disable
+   *
+   * @generated from field: optional double slo_target = 8;
+   */
+  sloTarget?: number;
+
+  /**
+   * @generated from field: optional int32 slo_latency_seconds = 9;
+   */
+  sloLatencySeconds?: number;
+
+  /**
+   * @generated from field: optional int32 slo_window_hours = 10;
+   */
+  sloWindowHours?: number;
+
+  /**
+   * @generated from field: optional bool reject_duplicates = 11;
+   */
+  rejectDuplicates?: boolean;
+
+  /**
+   * Replaces the ingestion credentials; method unspecified removes them
+   *
+   * @generated from field: hookly.v1.IngestAuth ingest_auth = 12;
+   */
+  ingestAuth?: IngestAuth;
+
+  /**
+   * @generated from field: optional bool honeypot = 13;
+   */
+  honeypot?: boolean;
+
+  /**
+   * @generated from field: optional bool conflict_as_duplicate = 14;
+   */
+  conflictAsDuplicate?: boolean;
+
+  /**
+   * Requests per minute; 0 reverts to the edge's limit
+   *
+   * @generated from field: optional int32 rate_limit_per_minute = 15;
+   */
+  rateLimitPerMinute?: number;
+
+  /**
+   * Replaces the transform; an empty one removes it
+   *
+   * @generated from field: hookly.v1.Transform transform = 16;
+   */
+  transform?: Transform;
+
+  /**
+   * Replaces the additional destinations; an empty list removes them
+   *
+   * @generated from field: hookly.v1.DestinationList destinations = 17;
+   */
+  destinations?: DestinationList;
+
+  /**
+   * @generated from field: optional bool answer_probes = 18;
+   */
+  answerProbes?: boolean;
+
+  /**
+   * Replaces the ingest response; an empty one restores the default
+   *
+   * @generated from field: hookly.v1.IngestResponse ingest_response = 19;
+   */
+  ingestResponse?: IngestResponse;
+
+  /**
+   * Replaces the retry policy when set; the zero policy restores the default
+   *
+   * @generated from field: hookly.v1.RetryPolicy retry_policy = 20;
+   */
+  retryPolicy?: RetryPolicy;
+
+  /**
+   * Replaces the payload limits when set; the zero value removes them
+   *
+   * @generated from field: hookly.v1.PayloadLimits payload_limits = 21;
+   */
+  payloadLimits?: PayloadLimits;
 };
 
 /**
@@ -199,6 +347,23 @@ export type UpdateEndpointRequest = Message<"hookly.v1.UpdateEndpointRequest"> &
 export const UpdateEndpointRequestSchema: GenMessage<UpdateEndpointRequest> = /*@__PURE__*/
   messageDesc(file_hookly_v1_edge, 6);
 
+/**
+ * @generated from message hookly.v1.DestinationList
+ */
+export type DestinationList = Message<"hookly.v1.DestinationList"> & {
+  /**
+   * @generated from field: repeated string urls = 1;
+   */
+  urls: string[];
+};
+
+/**
+ * Describes the message hookly.v1.DestinationList.
+ * Use `create(DestinationListSchema)` to create a new message.
+ */
+export const DestinationListSchema: GenMessage<DestinationList> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 7);
+
 /**
  * @generated from message hookly.v1.UpdateEndpointResponse
  */
@@ -214,7 +379,7 @@ export type UpdateEndpointResponse = Message<"hookly.v1.UpdateEndpointResponse">
  * Use `create(UpdateEndpointResponseSchema)` to create a new message.
  */
 export const UpdateEndpointResponseSchema: GenMessage<UpdateEndpointResponse> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_edge, 7);
+  messageDesc(file_hookly_v1_edge, 8);
 
 /**
  * @generated from message hookly.v1.DeleteEndpointRequest
@@ -231,7 +396,7 @@ export type DeleteEndpointRequest = Message<"hookly.v1.DeleteEndpointRequest"> &
  * Use `create(DeleteEndpointRequestSchema)` to create a new message.
  */
 export const DeleteEndpointRequestSchema: GenMessage<DeleteEndpointRequest> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_edge, 8);
+  messageDesc(file_hookly_v1_edge, 9);
 
 /**
  * @generated from message hookly.v1.DeleteEndpointResponse
@@ -244,308 +409,1792 @@ export type DeleteEndpointResponse = Message<"hookly.v1.DeleteEndpointResponse">
  * Use `create(DeleteEndpointResponseSchema)` to create a new message.
  */
 export const DeleteEndpointResponseSchema: GenMessage<DeleteEndpointResponse> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_edge, 9);
-
-/**
- * @generated from message hookly.v1.GetWebhookRequest
- */
-export type GetWebhookRequest = Message<"hookly.v1.GetWebhookRequest"> & {
-  /**
-   * @generated from field: string id = 1;
-   */
-  id: string;
-};
-
-/**
- * Describes the message hookly.v1.GetWebhookRequest.
- * Use `create(GetWebhookRequestSchema)` to create a new message.
- */
-export const GetWebhookRequestSchema: GenMessage<GetWebhookRequest> = /*@__PURE__*/
   messageDesc(file_hookly_v1_edge, 10);
 
 /**
- * @generated from message hookly.v1.GetWebhookResponse
+ * @generated from message hookly.v1.GetSetupInstructionsRequest
  */
-export type GetWebhookResponse = Message<"hookly.v1.GetWebhookResponse"> & {
+export type GetSetupInstructionsRequest = Message<"hookly.v1.GetSetupInstructionsRequest"> & {
   /**
-   * @generated from field: hookly.v1.Webhook webhook = 1;
+   * @generated from field: string endpoint_id = 1;
    */
-  webhook?: Webhook;
+  endpointId: string;
 };
 
 /**
- * Describes the message hookly.v1.GetWebhookResponse.
- * Use `create(GetWebhookResponseSchema)` to create a new message.
+ * Describes the message hookly.v1.GetSetupInstructionsRequest.
+ * Use `create(GetSetupInstructionsRequestSchema)` to create a new message.
  */
-export const GetWebhookResponseSchema: GenMessage<GetWebhookResponse> = /*@__PURE__*/
+export const GetSetupInstructionsRequestSchema: GenMessage<GetSetupInstructionsRequest> = /*@__PURE__*/
   messageDesc(file_hookly_v1_edge, 11);
 
 /**
- * @generated from message hookly.v1.ListWebhooksRequest
+ * @generated from message hookly.v1.GetSetupInstructionsResponse
  */
-export type ListWebhooksRequest = Message<"hookly.v1.ListWebhooksRequest"> & {
+export type GetSetupInstructionsResponse = Message<"hookly.v1.GetSetupInstructionsResponse"> & {
   /**
-   * @generated from field: optional string endpoint_id = 1;
+   * @generated from field: string webhook_url = 1;
    */
-  endpointId?: string;
+  webhookUrl: string;
 
   /**
-   * @generated from field: optional hookly.v1.WebhookStatus status = 2;
+   * @generated from field: hookly.v1.ProviderType provider_type = 2;
    */
-  status?: WebhookStatus;
+  providerType: ProviderType;
 
   /**
-   * @generated from field: hookly.v1.PaginationRequest pagination = 3;
+   * Plain-text setup steps with the webhook URL filled in. The signature
+   * secret is never included; a placeholder is used instead.
+   *
+   * @generated from field: string instructions = 3;
    */
-  pagination?: PaginationRequest;
+  instructions: string;
 };
 
 /**
- * Describes the message hookly.v1.ListWebhooksRequest.
- * Use `create(ListWebhooksRequestSchema)` to create a new message.
+ * Describes the message hookly.v1.GetSetupInstructionsResponse.
+ * Use `create(GetSetupInstructionsResponseSchema)` to create a new message.
  */
-export const ListWebhooksRequestSchema: GenMessage<ListWebhooksRequest> = /*@__PURE__*/
+export const GetSetupInstructionsResponseSchema: GenMessage<GetSetupInstructionsResponse> = /*@__PURE__*/
   messageDesc(file_hookly_v1_edge, 12);
 
 /**
- * @generated from message hookly.v1.ListWebhooksResponse
+ * TelegramWebhookStatus is the webhook registration reported by Telegram's getWebhookInfo.
+ *
+ * @generated from message hookly.v1.TelegramWebhookStatus
  */
-export type ListWebhooksResponse = Message<"hookly.v1.ListWebhooksResponse"> & {
+export type TelegramWebhookStatus = Message<"hookly.v1.TelegramWebhookStatus"> & {
   /**
-   * @generated from field: repeated hookly.v1.Webhook webhooks = 1;
+   * URL currently registered with Telegram
+   *
+   * @generated from field: string url = 1;
    */
-  webhooks: Webhook[];
+  url: string;
 
   /**
-   * @generated from field: hookly.v1.PaginationResponse pagination = 2;
+   * True if the registered URL is this endpoint's webhook URL
+   *
+   * @generated from field: bool matches = 2;
    */
-  pagination?: PaginationResponse;
+  matches: boolean;
+
+  /**
+   * @generated from field: int32 pending_update_count = 3;
+   */
+  pendingUpdateCount: number;
+
+  /**
+   * @generated from field: string last_error_message = 4;
+   */
+  lastErrorMessage: string;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp last_error_at = 5;
+   */
+  lastErrorAt?: Timestamp;
 };
 
 /**
- * Describes the message hookly.v1.ListWebhooksResponse.
- * Use `create(ListWebhooksResponseSchema)` to create a new message.
+ * Describes the message hookly.v1.TelegramWebhookStatus.
+ * Use `create(TelegramWebhookStatusSchema)` to create a new message.
  */
-export const ListWebhooksResponseSchema: GenMessage<ListWebhooksResponse> = /*@__PURE__*/
+export const TelegramWebhookStatusSchema: GenMessage<TelegramWebhookStatus> = /*@__PURE__*/
   messageDesc(file_hookly_v1_edge, 13);
 
 /**
- * @generated from message hookly.v1.ReplayWebhookRequest
+ * @generated from message hookly.v1.SetupTelegramWebhookRequest
  */
-export type ReplayWebhookRequest = Message<"hookly.v1.ReplayWebhookRequest"> & {
+export type SetupTelegramWebhookRequest = Message<"hookly.v1.SetupTelegramWebhookRequest"> & {
   /**
-   * @generated from field: string id = 1;
+   * @generated from field: string endpoint_id = 1;
    */
-  id: string;
+  endpointId: string;
+
+  /**
+   * Stored encrypted for later calls. The stored token is used if empty.
+   *
+   * @generated from field: string bot_token = 2;
+   */
+  botToken: string;
 };
 
 /**
- * Describes the message hookly.v1.ReplayWebhookRequest.
- * Use `create(ReplayWebhookRequestSchema)` to create a new message.
+ * Describes the message hookly.v1.SetupTelegramWebhookRequest.
+ * Use `create(SetupTelegramWebhookRequestSchema)` to create a new message.
  */
-export const ReplayWebhookRequestSchema: GenMessage<ReplayWebhookRequest> = /*@__PURE__*/
+export const SetupTelegramWebhookRequestSchema: GenMessage<SetupTelegramWebhookRequest> = /*@__PURE__*/
   messageDesc(file_hookly_v1_edge, 14);
 
 /**
- * @generated from message hookly.v1.ReplayWebhookResponse
+ * @generated from message hookly.v1.SetupTelegramWebhookResponse
  */
-export type ReplayWebhookResponse = Message<"hookly.v1.ReplayWebhookResponse"> & {
+export type SetupTelegramWebhookResponse = Message<"hookly.v1.SetupTelegramWebhookResponse"> & {
   /**
-   * @generated from field: hookly.v1.Webhook webhook = 1;
+   * @generated from field: hookly.v1.TelegramWebhookStatus status = 1;
    */
-  webhook?: Webhook;
+  status?: TelegramWebhookStatus;
 };
 
 /**
- * Describes the message hookly.v1.ReplayWebhookResponse.
- * Use `create(ReplayWebhookResponseSchema)` to create a new message.
+ * Describes the message hookly.v1.SetupTelegramWebhookResponse.
+ * Use `create(SetupTelegramWebhookResponseSchema)` to create a new message.
  */
-export const ReplayWebhookResponseSchema: GenMessage<ReplayWebhookResponse> = /*@__PURE__*/
+export const SetupTelegramWebhookResponseSchema: GenMessage<SetupTelegramWebhookResponse> = /*@__PURE__*/
   messageDesc(file_hookly_v1_edge, 15);
 
 /**
- * @generated from message hookly.v1.GetStatusRequest
+ * @generated from message hookly.v1.VerifyTelegramWebhookRequest
  */
-export type GetStatusRequest = Message<"hookly.v1.GetStatusRequest"> & {
+export type VerifyTelegramWebhookRequest = Message<"hookly.v1.VerifyTelegramWebhookRequest"> & {
+  /**
+   * @generated from field: string endpoint_id = 1;
+   */
+  endpointId: string;
 };
 
 /**
- * Describes the message hookly.v1.GetStatusRequest.
- * Use `create(GetStatusRequestSchema)` to create a new message.
+ * Describes the message hookly.v1.VerifyTelegramWebhookRequest.
+ * Use `create(VerifyTelegramWebhookRequestSchema)` to create a new message.
  */
-export const GetStatusRequestSchema: GenMessage<GetStatusRequest> = /*@__PURE__*/
+export const VerifyTelegramWebhookRequestSchema: GenMessage<VerifyTelegramWebhookRequest> = /*@__PURE__*/
   messageDesc(file_hookly_v1_edge, 16);
 
 /**
- * @generated from message hookly.v1.GetStatusResponse
+ * @generated from message hookly.v1.VerifyTelegramWebhookResponse
  */
-export type GetStatusResponse = Message<"hookly.v1.GetStatusResponse"> & {
+export type VerifyTelegramWebhookResponse = Message<"hookly.v1.VerifyTelegramWebhookResponse"> & {
   /**
-   * @generated from field: hookly.v1.SystemStatus status = 1;
+   * @generated from field: hookly.v1.TelegramWebhookStatus status = 1;
    */
-  status?: SystemStatus;
+  status?: TelegramWebhookStatus;
 };
 
 /**
- * Describes the message hookly.v1.GetStatusResponse.
- * Use `create(GetStatusResponseSchema)` to create a new message.
+ * Describes the message hookly.v1.VerifyTelegramWebhookResponse.
+ * Use `create(VerifyTelegramWebhookResponseSchema)` to create a new message.
  */
-export const GetStatusResponseSchema: GenMessage<GetStatusResponse> = /*@__PURE__*/
+export const VerifyTelegramWebhookResponseSchema: GenMessage<VerifyTelegramWebhookResponse> = /*@__PURE__*/
   messageDesc(file_hookly_v1_edge, 17);
 
 /**
- * @generated from message hookly.v1.GetSettingsRequest
+ * @generated from message hookly.v1.GetEndpointStatsRequest
  */
-export type GetSettingsRequest = Message<"hookly.v1.GetSettingsRequest"> & {
+export type GetEndpointStatsRequest = Message<"hookly.v1.GetEndpointStatsRequest"> & {
+  /**
+   * @generated from field: string endpoint_id = 1;
+   */
+  endpointId: string;
 };
 
 /**
- * Describes the message hookly.v1.GetSettingsRequest.
- * Use `create(GetSettingsRequestSchema)` to create a new message.
+ * Describes the message hookly.v1.GetEndpointStatsRequest.
+ * Use `create(GetEndpointStatsRequestSchema)` to create a new message.
  */
-export const GetSettingsRequestSchema: GenMessage<GetSettingsRequest> = /*@__PURE__*/
+export const GetEndpointStatsRequestSchema: GenMessage<GetEndpointStatsRequest> = /*@__PURE__*/
   messageDesc(file_hookly_v1_edge, 18);
 
 /**
- * @generated from message hookly.v1.GetSettingsResponse
+ * EventTypeCount is the number of webhooks received for one event type.
+ *
+ * @generated from message hookly.v1.EventTypeCount
  */
-export type GetSettingsResponse = Message<"hookly.v1.GetSettingsResponse"> & {
+export type EventTypeCount = Message<"hookly.v1.EventTypeCount"> & {
   /**
-   * @generated from field: string base_url = 1;
+   * Provider event type, empty for webhooks without a recognizable type
+   *
+   * @generated from field: string event_type = 1;
    */
-  baseUrl: string;
+  eventType: string;
 
   /**
-   * @generated from field: bool github_auth_enabled = 2;
+   * @generated from field: int64 count = 2;
    */
-  githubAuthEnabled: boolean;
+  count: bigint;
+};
 
+/**
+ * Describes the message hookly.v1.EventTypeCount.
+ * Use `create(EventTypeCountSchema)` to create a new message.
+ */
+export const EventTypeCountSchema: GenMessage<EventTypeCount> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 19);
+
+/**
+ * SLOCompliance is the current compliance with an endpoint's delivery SLO.
+ *
+ * @generated from message hookly.v1.SLOCompliance
+ */
+export type SLOCompliance = Message<"hookly.v1.SLOCompliance"> & {
   /**
-   * @generated from field: bool telegram_notifications_enabled = 3;
+   * @generated from field: double target = 1;
    */
-  telegramNotificationsEnabled: boolean;
+  target: number;
 
   /**
-   * User info (requires authentication)
-   *
-   * @generated from field: string user_id = 4;
+   * @generated from field: int32 latency_seconds = 2;
    */
-  userId: string;
+  latencySeconds: number;
 
   /**
-   * @generated from field: string username = 5;
+   * @generated from field: int32 window_hours = 3;
    */
-  username: string;
+  windowHours: number;
 
   /**
-   * @generated from field: string avatar_url = 6;
+   * Webhooks counted in the window and how many met the latency target
+   *
+   * @generated from field: int64 total = 4;
    */
-  avatarUrl: string;
+  total: bigint;
 
   /**
-   * User preferences
+   * @generated from field: int64 met = 5;
+   */
+  met: bigint;
+
+  /**
+   * Percent of webhooks that met the latency target (100 with no webhooks)
    *
-   * @generated from field: hookly.v1.ThemePreference theme_preference = 7;
+   * @generated from field: double compliance = 6;
    */
-  themePreference: ThemePreference;
+  compliance: number;
 
   /**
-   * @generated from field: bool is_superuser = 8;
+   * @generated from field: bool breached = 7;
    */
-  isSuperuser: boolean;
+  breached: boolean;
 };
 
 /**
- * Describes the message hookly.v1.GetSettingsResponse.
- * Use `create(GetSettingsResponseSchema)` to create a new message.
+ * Describes the message hookly.v1.SLOCompliance.
+ * Use `create(SLOComplianceSchema)` to create a new message.
  */
-export const GetSettingsResponseSchema: GenMessage<GetSettingsResponse> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_edge, 19);
+export const SLOComplianceSchema: GenMessage<SLOCompliance> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 20);
 
 /**
- * @generated from message hookly.v1.GetUserSettingsRequest
+ * @generated from message hookly.v1.GetEndpointStatsResponse
  */
-export type GetUserSettingsRequest = Message<"hookly.v1.GetUserSettingsRequest"> & {
+export type GetEndpointStatsResponse = Message<"hookly.v1.GetEndpointStatsResponse"> & {
+  /**
+   * Webhook counts per event type, most frequent first
+   *
+   * @generated from field: repeated hookly.v1.EventTypeCount event_types = 1;
+   */
+  eventTypes: EventTypeCount[];
+
+  /**
+   * Delivery SLO compliance, unset if the endpoint has no SLO
+   *
+   * @generated from field: hookly.v1.SLOCompliance slo = 2;
+   */
+  slo?: SLOCompliance;
 };
 
 /**
- * Describes the message hookly.v1.GetUserSettingsRequest.
- * Use `create(GetUserSettingsRequestSchema)` to create a new message.
+ * Describes the message hookly.v1.GetEndpointStatsResponse.
+ * Use `create(GetEndpointStatsResponseSchema)` to create a new message.
  */
-export const GetUserSettingsRequestSchema: GenMessage<GetUserSettingsRequest> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_edge, 20);
+export const GetEndpointStatsResponseSchema: GenMessage<GetEndpointStatsResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 21);
 
 /**
- * @generated from message hookly.v1.GetUserSettingsResponse
+ * @generated from message hookly.v1.ListConnectionEventsRequest
  */
-export type GetUserSettingsResponse = Message<"hookly.v1.GetUserSettingsResponse"> & {
+export type ListConnectionEventsRequest = Message<"hookly.v1.ListConnectionEventsRequest"> & {
   /**
-   * @generated from field: hookly.v1.UserSettings settings = 1;
+   * All of the user's endpoints if unset
+   *
+   * @generated from field: optional string endpoint_id = 1;
+   */
+  endpointId?: string;
+
+  /**
+   * Max events to return (default 50, max 500)
+   *
+   * @generated from field: int32 limit = 2;
+   */
+  limit: number;
+};
+
+/**
+ * Describes the message hookly.v1.ListConnectionEventsRequest.
+ * Use `create(ListConnectionEventsRequestSchema)` to create a new message.
+ */
+export const ListConnectionEventsRequestSchema: GenMessage<ListConnectionEventsRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 22);
+
+/**
+ * @generated from message hookly.v1.ListConnectionEventsResponse
+ */
+export type ListConnectionEventsResponse = Message<"hookly.v1.ListConnectionEventsResponse"> & {
+  /**
+   * Newest first
+   *
+   * @generated from field: repeated hookly.v1.ConnectionEvent events = 1;
+   */
+  events: ConnectionEvent[];
+};
+
+/**
+ * Describes the message hookly.v1.ListConnectionEventsResponse.
+ * Use `create(ListConnectionEventsResponseSchema)` to create a new message.
+ */
+export const ListConnectionEventsResponseSchema: GenMessage<ListConnectionEventsResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 23);
+
+/**
+ * @generated from message hookly.v1.GenerateEndpointSecretRequest
+ */
+export type GenerateEndpointSecretRequest = Message<"hookly.v1.GenerateEndpointSecretRequest"> & {
+  /**
+   * @generated from field: string endpoint_id = 1;
+   */
+  endpointId: string;
+};
+
+/**
+ * Describes the message hookly.v1.GenerateEndpointSecretRequest.
+ * Use `create(GenerateEndpointSecretRequestSchema)` to create a new message.
+ */
+export const GenerateEndpointSecretRequestSchema: GenMessage<GenerateEndpointSecretRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 24);
+
+/**
+ * @generated from message hookly.v1.GenerateEndpointSecretResponse
+ */
+export type GenerateEndpointSecretResponse = Message<"hookly.v1.GenerateEndpointSecretResponse"> & {
+  /**
+   * The new signature secret. It is stored encrypted and not returned again,
+   * so it must be copied into the provider's settings now.
+   *
+   * @generated from field: string secret = 1;
+   */
+  secret: string;
+};
+
+/**
+ * Describes the message hookly.v1.GenerateEndpointSecretResponse.
+ * Use `create(GenerateEndpointSecretResponseSchema)` to create a new message.
+ */
+export const GenerateEndpointSecretResponseSchema: GenMessage<GenerateEndpointSecretResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 25);
+
+/**
+ * @generated from message hookly.v1.RevealEndpointSecretRequest
+ */
+export type RevealEndpointSecretRequest = Message<"hookly.v1.RevealEndpointSecretRequest"> & {
+  /**
+   * @generated from field: string endpoint_id = 1;
+   */
+  endpointId: string;
+};
+
+/**
+ * Describes the message hookly.v1.RevealEndpointSecretRequest.
+ * Use `create(RevealEndpointSecretRequestSchema)` to create a new message.
+ */
+export const RevealEndpointSecretRequestSchema: GenMessage<RevealEndpointSecretRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 26);
+
+/**
+ * @generated from message hookly.v1.RevealEndpointSecretResponse
+ */
+export type RevealEndpointSecretResponse = Message<"hookly.v1.RevealEndpointSecretResponse"> & {
+  /**
+   * @generated from field: string secret = 1;
+   */
+  secret: string;
+};
+
+/**
+ * Describes the message hookly.v1.RevealEndpointSecretResponse.
+ * Use `create(RevealEndpointSecretResponseSchema)` to create a new message.
+ */
+export const RevealEndpointSecretResponseSchema: GenMessage<RevealEndpointSecretResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 27);
+
+/**
+ * @generated from message hookly.v1.GetWebhookRequest
+ */
+export type GetWebhookRequest = Message<"hookly.v1.GetWebhookRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * Set to false to return only payload_preview (default true)
+   *
+   * @generated from field: optional bool include_payload = 2;
+   */
+  includePayload?: boolean;
+
+  /**
+   * Return only the JSON value at this path as payload and payload_preview: a
+   * JSON pointer ("/data/object/id") or dot notation ("data.items[0].id").
+   * payload_size is still the size of the whole payload.
+   *
+   * @generated from field: optional string json_path = 3;
+   */
+  jsonPath?: string;
+};
+
+/**
+ * Describes the message hookly.v1.GetWebhookRequest.
+ * Use `create(GetWebhookRequestSchema)` to create a new message.
+ */
+export const GetWebhookRequestSchema: GenMessage<GetWebhookRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 28);
+
+/**
+ * @generated from message hookly.v1.GetWebhookResponse
+ */
+export type GetWebhookResponse = Message<"hookly.v1.GetWebhookResponse"> & {
+  /**
+   * @generated from field: hookly.v1.Webhook webhook = 1;
+   */
+  webhook?: Webhook;
+
+  /**
+   * Per destination, for endpoints that fan out. Empty until the first
+   * attempt and after a replay.
+   *
+   * @generated from field: repeated hookly.v1.DestinationDelivery deliveries = 2;
+   */
+  deliveries: DestinationDelivery[];
+};
+
+/**
+ * Describes the message hookly.v1.GetWebhookResponse.
+ * Use `create(GetWebhookResponseSchema)` to create a new message.
+ */
+export const GetWebhookResponseSchema: GenMessage<GetWebhookResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 29);
+
+/**
+ * @generated from message hookly.v1.GetWebhookPayloadRequest
+ */
+export type GetWebhookPayloadRequest = Message<"hookly.v1.GetWebhookPayloadRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message hookly.v1.GetWebhookPayloadRequest.
+ * Use `create(GetWebhookPayloadRequestSchema)` to create a new message.
+ */
+export const GetWebhookPayloadRequestSchema: GenMessage<GetWebhookPayloadRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 30);
+
+/**
+ * @generated from message hookly.v1.GetWebhookPayloadResponse
+ */
+export type GetWebhookPayloadResponse = Message<"hookly.v1.GetWebhookPayloadResponse"> & {
+  /**
+   * @generated from field: bytes payload = 1;
+   */
+  payload: Uint8Array;
+};
+
+/**
+ * Describes the message hookly.v1.GetWebhookPayloadResponse.
+ * Use `create(GetWebhookPayloadResponseSchema)` to create a new message.
+ */
+export const GetWebhookPayloadResponseSchema: GenMessage<GetWebhookPayloadResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 31);
+
+/**
+ * @generated from message hookly.v1.ListWebhooksRequest
+ */
+export type ListWebhooksRequest = Message<"hookly.v1.ListWebhooksRequest"> & {
+  /**
+   * @generated from field: optional string endpoint_id = 1;
+   */
+  endpointId?: string;
+
+  /**
+   * @generated from field: optional hookly.v1.WebhookStatus status = 2;
+   */
+  status?: WebhookStatus;
+
+  /**
+   * @generated from field: hookly.v1.PaginationRequest pagination = 3;
+   */
+  pagination?: PaginationRequest;
+
+  /**
+   * @generated from field: optional string event_type = 4;
+   */
+  eventType?: string;
+
+  /**
+   * Set to false to return only payload_preview (default true)
+   *
+   * @generated from field: optional bool include_payload = 5;
+   */
+  includePayload?: boolean;
+
+  /**
+   * List webhooks purged by retention cleanup, which can still be undeleted,
+   * instead of live ones
+   *
+   * @generated from field: bool purged = 6;
+   */
+  purged: boolean;
+};
+
+/**
+ * Describes the message hookly.v1.ListWebhooksRequest.
+ * Use `create(ListWebhooksRequestSchema)` to create a new message.
+ */
+export const ListWebhooksRequestSchema: GenMessage<ListWebhooksRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 32);
+
+/**
+ * @generated from message hookly.v1.ListWebhooksResponse
+ */
+export type ListWebhooksResponse = Message<"hookly.v1.ListWebhooksResponse"> & {
+  /**
+   * @generated from field: repeated hookly.v1.Webhook webhooks = 1;
+   */
+  webhooks: Webhook[];
+
+  /**
+   * @generated from field: hookly.v1.PaginationResponse pagination = 2;
+   */
+  pagination?: PaginationResponse;
+};
+
+/**
+ * Describes the message hookly.v1.ListWebhooksResponse.
+ * Use `create(ListWebhooksResponseSchema)` to create a new message.
+ */
+export const ListWebhooksResponseSchema: GenMessage<ListWebhooksResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 33);
+
+/**
+ * @generated from message hookly.v1.ReplayWebhookRequest
+ */
+export type ReplayWebhookRequest = Message<"hookly.v1.ReplayWebhookRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * Token returned by a previous call that required confirmation.
+   *
+   * @generated from field: string confirm_token = 2;
+   */
+  confirmToken: string;
+};
+
+/**
+ * Describes the message hookly.v1.ReplayWebhookRequest.
+ * Use `create(ReplayWebhookRequestSchema)` to create a new message.
+ */
+export const ReplayWebhookRequestSchema: GenMessage<ReplayWebhookRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 34);
+
+/**
+ * @generated from message hookly.v1.ReplayWebhookResponse
+ */
+export type ReplayWebhookResponse = Message<"hookly.v1.ReplayWebhookResponse"> & {
+  /**
+   * Unset when confirmation_required is true.
+   *
+   * @generated from field: hookly.v1.Webhook webhook = 1;
+   */
+  webhook?: Webhook;
+
+  /**
+   * Set when the endpoint already has many pending replays. Retry with
+   * confirm_token to queue the replay anyway.
+   *
+   * @generated from field: bool confirmation_required = 2;
+   */
+  confirmationRequired: boolean;
+
+  /**
+   * @generated from field: string confirmation_token = 3;
+   */
+  confirmationToken: string;
+
+  /**
+   * @generated from field: int32 pending_replays = 4;
+   */
+  pendingReplays: number;
+};
+
+/**
+ * Describes the message hookly.v1.ReplayWebhookResponse.
+ * Use `create(ReplayWebhookResponseSchema)` to create a new message.
+ */
+export const ReplayWebhookResponseSchema: GenMessage<ReplayWebhookResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 35);
+
+/**
+ * @generated from message hookly.v1.BulkReplayWebhooksRequest
+ */
+export type BulkReplayWebhooksRequest = Message<"hookly.v1.BulkReplayWebhooksRequest"> & {
+  /**
+   * Limit to a single endpoint. Replays on all endpoints if unset.
+   *
+   * @generated from field: optional string endpoint_id = 1;
+   */
+  endpointId?: string;
+
+  /**
+   * Status of the webhooks to replay, dead letter if unspecified. Pending
+   * webhooks can't be replayed in bulk, nor honeypot hits.
+   *
+   * @generated from field: hookly.v1.WebhookStatus status = 2;
+   */
+  status: WebhookStatus;
+
+  /**
+   * Only webhooks received at or after received_after and before
+   * received_before, when set.
+   *
+   * @generated from field: google.protobuf.Timestamp received_after = 3;
+   */
+  receivedAfter?: Timestamp;
+
+  /**
+   * @generated from field: google.protobuf.Timestamp received_before = 4;
+   */
+  receivedBefore?: Timestamp;
+
+  /**
+   * Most webhooks to replay, the oldest first: 100 if 0, at most 1000.
+   *
+   * @generated from field: int32 max_count = 5;
+   */
+  maxCount: number;
+
+  /**
+   * Token returned by a previous call with the same filter that required
+   * confirmation. If more webhooks match now, confirmation is required again.
+   *
+   * @generated from field: string confirm_token = 6;
+   */
+  confirmToken: string;
+};
+
+/**
+ * Describes the message hookly.v1.BulkReplayWebhooksRequest.
+ * Use `create(BulkReplayWebhooksRequestSchema)` to create a new message.
+ */
+export const BulkReplayWebhooksRequestSchema: GenMessage<BulkReplayWebhooksRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 36);
+
+/**
+ * @generated from message hookly.v1.BulkReplayWebhooksResponse
+ */
+export type BulkReplayWebhooksResponse = Message<"hookly.v1.BulkReplayWebhooksResponse"> & {
+  /**
+   * @generated from field: int32 replayed_count = 1;
+   */
+  replayedCount: number;
+
+  /**
+   * Set when as many webhooks as the confirmation threshold match. Retry
+   * with confirm_token to replay them anyway.
+   *
+   * @generated from field: bool confirmation_required = 2;
+   */
+  confirmationRequired: boolean;
+
+  /**
+   * @generated from field: string confirmation_token = 3;
+   */
+  confirmationToken: string;
+
+  /**
+   * @generated from field: int32 matching_count = 4;
+   */
+  matchingCount: number;
+};
+
+/**
+ * Describes the message hookly.v1.BulkReplayWebhooksResponse.
+ * Use `create(BulkReplayWebhooksResponseSchema)` to create a new message.
+ */
+export const BulkReplayWebhooksResponseSchema: GenMessage<BulkReplayWebhooksResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 37);
+
+/**
+ * @generated from message hookly.v1.UndeleteWebhookRequest
+ */
+export type UndeleteWebhookRequest = Message<"hookly.v1.UndeleteWebhookRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message hookly.v1.UndeleteWebhookRequest.
+ * Use `create(UndeleteWebhookRequestSchema)` to create a new message.
+ */
+export const UndeleteWebhookRequestSchema: GenMessage<UndeleteWebhookRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 38);
+
+/**
+ * @generated from message hookly.v1.UndeleteWebhookResponse
+ */
+export type UndeleteWebhookResponse = Message<"hookly.v1.UndeleteWebhookResponse"> & {
+  /**
+   * @generated from field: hookly.v1.Webhook webhook = 1;
+   */
+  webhook?: Webhook;
+};
+
+/**
+ * Describes the message hookly.v1.UndeleteWebhookResponse.
+ * Use `create(UndeleteWebhookResponseSchema)` to create a new message.
+ */
+export const UndeleteWebhookResponseSchema: GenMessage<UndeleteWebhookResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 39);
+
+/**
+ * @generated from message hookly.v1.CancelPendingReplaysRequest
+ */
+export type CancelPendingReplaysRequest = Message<"hookly.v1.CancelPendingReplaysRequest"> & {
+  /**
+   * Limit to a single endpoint. Cancels replays on all endpoints if unset.
+   *
+   * @generated from field: optional string endpoint_id = 1;
+   */
+  endpointId?: string;
+};
+
+/**
+ * Describes the message hookly.v1.CancelPendingReplaysRequest.
+ * Use `create(CancelPendingReplaysRequestSchema)` to create a new message.
+ */
+export const CancelPendingReplaysRequestSchema: GenMessage<CancelPendingReplaysRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 40);
+
+/**
+ * @generated from message hookly.v1.CancelPendingReplaysResponse
+ */
+export type CancelPendingReplaysResponse = Message<"hookly.v1.CancelPendingReplaysResponse"> & {
+  /**
+   * @generated from field: int32 cancelled_count = 1;
+   */
+  cancelledCount: number;
+};
+
+/**
+ * Describes the message hookly.v1.CancelPendingReplaysResponse.
+ * Use `create(CancelPendingReplaysResponseSchema)` to create a new message.
+ */
+export const CancelPendingReplaysResponseSchema: GenMessage<CancelPendingReplaysResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 41);
+
+/**
+ * @generated from message hookly.v1.TailWebhooksRequest
+ */
+export type TailWebhooksRequest = Message<"hookly.v1.TailWebhooksRequest"> & {
+  /**
+   * Limit to a single endpoint. Tails all endpoints if unset.
+   *
+   * @generated from field: optional string endpoint_id = 1;
+   */
+  endpointId?: string;
+
+  /**
+   * Only changes to these statuses; every change if empty. Webhooks are
+   * stored as pending, so include pending to see them arrive.
+   *
+   * @generated from field: repeated hookly.v1.WebhookStatus statuses = 2;
+   */
+  statuses: WebhookStatus[];
+};
+
+/**
+ * Describes the message hookly.v1.TailWebhooksRequest.
+ * Use `create(TailWebhooksRequestSchema)` to create a new message.
+ */
+export const TailWebhooksRequestSchema: GenMessage<TailWebhooksRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 42);
+
+/**
+ * @generated from message hookly.v1.TailWebhooksResponse
+ */
+export type TailWebhooksResponse = Message<"hookly.v1.TailWebhooksResponse"> & {
+  /**
+   * The webhook after the change, with payload_preview but no payload
+   *
+   * @generated from field: hookly.v1.Webhook webhook = 1;
+   */
+  webhook?: Webhook;
+
+  /**
+   * @generated from field: hookly.v1.WebhookStatusChange change = 2;
+   */
+  change?: WebhookStatusChange;
+};
+
+/**
+ * Describes the message hookly.v1.TailWebhooksResponse.
+ * Use `create(TailWebhooksResponseSchema)` to create a new message.
+ */
+export const TailWebhooksResponseSchema: GenMessage<TailWebhooksResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 43);
+
+/**
+ * @generated from message hookly.v1.GetStatusRequest
+ */
+export type GetStatusRequest = Message<"hookly.v1.GetStatusRequest"> & {
+};
+
+/**
+ * Describes the message hookly.v1.GetStatusRequest.
+ * Use `create(GetStatusRequestSchema)` to create a new message.
+ */
+export const GetStatusRequestSchema: GenMessage<GetStatusRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 44);
+
+/**
+ * @generated from message hookly.v1.GetStatusResponse
+ */
+export type GetStatusResponse = Message<"hookly.v1.GetStatusResponse"> & {
+  /**
+   * @generated from field: hookly.v1.SystemStatus status = 1;
+   */
+  status?: SystemStatus;
+};
+
+/**
+ * Describes the message hookly.v1.GetStatusResponse.
+ * Use `create(GetStatusResponseSchema)` to create a new message.
+ */
+export const GetStatusResponseSchema: GenMessage<GetStatusResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 45);
+
+/**
+ * @generated from message hookly.v1.GetActivityFeedRequest
+ */
+export type GetActivityFeedRequest = Message<"hookly.v1.GetActivityFeedRequest"> & {
+  /**
+   * Max items to return (default 50, max 200)
+   *
+   * @generated from field: int32 limit = 1;
+   */
+  limit: number;
+
+  /**
+   * Only include activity from the last N hours (default 24)
+   *
+   * @generated from field: int32 since_hours = 2;
+   */
+  sinceHours: number;
+};
+
+/**
+ * Describes the message hookly.v1.GetActivityFeedRequest.
+ * Use `create(GetActivityFeedRequestSchema)` to create a new message.
+ */
+export const GetActivityFeedRequestSchema: GenMessage<GetActivityFeedRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 46);
+
+/**
+ * @generated from message hookly.v1.GetActivityFeedResponse
+ */
+export type GetActivityFeedResponse = Message<"hookly.v1.GetActivityFeedResponse"> & {
+  /**
+   * @generated from field: repeated hookly.v1.ActivityItem items = 1;
+   */
+  items: ActivityItem[];
+};
+
+/**
+ * Describes the message hookly.v1.GetActivityFeedResponse.
+ * Use `create(GetActivityFeedResponseSchema)` to create a new message.
+ */
+export const GetActivityFeedResponseSchema: GenMessage<GetActivityFeedResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 47);
+
+/**
+ * @generated from message hookly.v1.GetRegionsRequest
+ */
+export type GetRegionsRequest = Message<"hookly.v1.GetRegionsRequest"> & {
+};
+
+/**
+ * Describes the message hookly.v1.GetRegionsRequest.
+ * Use `create(GetRegionsRequestSchema)` to create a new message.
+ */
+export const GetRegionsRequestSchema: GenMessage<GetRegionsRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 48);
+
+/**
+ * @generated from message hookly.v1.GetRegionsResponse
+ */
+export type GetRegionsResponse = Message<"hookly.v1.GetRegionsResponse"> & {
+  /**
+   * Empty on single-region services
+   *
+   * @generated from field: string current_region = 1;
+   */
+  currentRegion: string;
+
+  /**
+   * Current region first
+   *
+   * @generated from field: repeated hookly.v1.Region regions = 2;
+   */
+  regions: Region[];
+};
+
+/**
+ * Describes the message hookly.v1.GetRegionsResponse.
+ * Use `create(GetRegionsResponseSchema)` to create a new message.
+ */
+export const GetRegionsResponseSchema: GenMessage<GetRegionsResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 49);
+
+/**
+ * @generated from message hookly.v1.GetVersionRequest
+ */
+export type GetVersionRequest = Message<"hookly.v1.GetVersionRequest"> & {
+};
+
+/**
+ * Describes the message hookly.v1.GetVersionRequest.
+ * Use `create(GetVersionRequestSchema)` to create a new message.
+ */
+export const GetVersionRequestSchema: GenMessage<GetVersionRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 50);
+
+/**
+ * @generated from message hookly.v1.GetVersionResponse
+ */
+export type GetVersionResponse = Message<"hookly.v1.GetVersionResponse"> & {
+  /**
+   * e.g. 1.2.0, or "dev" for builds without one
+   *
+   * @generated from field: string version = 1;
+   */
+  version: string;
+
+  /**
+   * VCS commit, if known
+   *
+   * @generated from field: string commit = 2;
+   */
+  commit: string;
+
+  /**
+   * RFC3339, if known
+   *
+   * @generated from field: string build_date = 3;
+   */
+  buildDate: string;
+
+  /**
+   * @generated from field: string go_version = 4;
+   */
+  goVersion: string;
+};
+
+/**
+ * Describes the message hookly.v1.GetVersionResponse.
+ * Use `create(GetVersionResponseSchema)` to create a new message.
+ */
+export const GetVersionResponseSchema: GenMessage<GetVersionResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 51);
+
+/**
+ * @generated from message hookly.v1.SendHubCommandRequest
+ */
+export type SendHubCommandRequest = Message<"hookly.v1.SendHubCommandRequest"> & {
+  /**
+   * @generated from field: string hub_id = 1;
+   */
+  hubId: string;
+
+  /**
+   * @generated from field: hookly.v1.HubCommandType command = 2;
+   */
+  command: HubCommandType;
+
+  /**
+   * For HUB_COMMAND_TYPE_LOGS: the most recent lines to return (default 100,
+   * max 1000)
+   *
+   * @generated from field: int32 lines = 3;
+   */
+  lines: number;
+};
+
+/**
+ * Describes the message hookly.v1.SendHubCommandRequest.
+ * Use `create(SendHubCommandRequestSchema)` to create a new message.
+ */
+export const SendHubCommandRequestSchema: GenMessage<SendHubCommandRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 52);
+
+/**
+ * @generated from message hookly.v1.SendHubCommandResponse
+ */
+export type SendHubCommandResponse = Message<"hookly.v1.SendHubCommandResponse"> & {
+  /**
+   * @generated from field: hookly.v1.HubCommandResult result = 1;
+   */
+  result?: HubCommandResult;
+};
+
+/**
+ * Describes the message hookly.v1.SendHubCommandResponse.
+ * Use `create(SendHubCommandResponseSchema)` to create a new message.
+ */
+export const SendHubCommandResponseSchema: GenMessage<SendHubCommandResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 53);
+
+/**
+ * @generated from message hookly.v1.GetSettingsRequest
+ */
+export type GetSettingsRequest = Message<"hookly.v1.GetSettingsRequest"> & {
+};
+
+/**
+ * Describes the message hookly.v1.GetSettingsRequest.
+ * Use `create(GetSettingsRequestSchema)` to create a new message.
+ */
+export const GetSettingsRequestSchema: GenMessage<GetSettingsRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 54);
+
+/**
+ * @generated from message hookly.v1.GetSettingsResponse
+ */
+export type GetSettingsResponse = Message<"hookly.v1.GetSettingsResponse"> & {
+  /**
+   * @generated from field: string base_url = 1;
+   */
+  baseUrl: string;
+
+  /**
+   * @generated from field: bool github_auth_enabled = 2;
+   */
+  githubAuthEnabled: boolean;
+
+  /**
+   * @generated from field: bool telegram_notifications_enabled = 3;
+   */
+  telegramNotificationsEnabled: boolean;
+
+  /**
+   * User info (requires authentication)
+   *
+   * @generated from field: string user_id = 4;
+   */
+  userId: string;
+
+  /**
+   * @generated from field: string username = 5;
+   */
+  username: string;
+
+  /**
+   * @generated from field: string avatar_url = 6;
+   */
+  avatarUrl: string;
+
+  /**
+   * User preferences
+   *
+   * @generated from field: hookly.v1.ThemePreference theme_preference = 7;
+   */
+  themePreference: ThemePreference;
+
+  /**
+   * @generated from field: bool is_superuser = 8;
+   */
+  isSuperuser: boolean;
+
+  /**
+   * @generated from field: bool discord_notifications_enabled = 9;
+   */
+  discordNotificationsEnabled: boolean;
+
+  /**
+   * True if the edge can send email, so users can enable email notifications
+   *
+   * @generated from field: bool email_available = 10;
+   */
+  emailAvailable: boolean;
+};
+
+/**
+ * Describes the message hookly.v1.GetSettingsResponse.
+ * Use `create(GetSettingsResponseSchema)` to create a new message.
+ */
+export const GetSettingsResponseSchema: GenMessage<GetSettingsResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 55);
+
+/**
+ * @generated from message hookly.v1.GetCurrentUserRequest
+ */
+export type GetCurrentUserRequest = Message<"hookly.v1.GetCurrentUserRequest"> & {
+};
+
+/**
+ * Describes the message hookly.v1.GetCurrentUserRequest.
+ * Use `create(GetCurrentUserRequestSchema)` to create a new message.
+ */
+export const GetCurrentUserRequestSchema: GenMessage<GetCurrentUserRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 56);
+
+/**
+ * @generated from message hookly.v1.GetCurrentUserResponse
+ */
+export type GetCurrentUserResponse = Message<"hookly.v1.GetCurrentUserResponse"> & {
+  /**
+   * @generated from field: hookly.v1.UserSettings user = 1;
+   */
+  user?: UserSettings;
+
+  /**
+   * The API token the request authenticated with; unset for browser sessions
+   *
+   * @generated from field: hookly.v1.ApiToken token = 2;
+   */
+  token?: ApiToken;
+};
+
+/**
+ * Describes the message hookly.v1.GetCurrentUserResponse.
+ * Use `create(GetCurrentUserResponseSchema)` to create a new message.
+ */
+export const GetCurrentUserResponseSchema: GenMessage<GetCurrentUserResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 57);
+
+/**
+ * @generated from message hookly.v1.GetUserSettingsRequest
+ */
+export type GetUserSettingsRequest = Message<"hookly.v1.GetUserSettingsRequest"> & {
+};
+
+/**
+ * Describes the message hookly.v1.GetUserSettingsRequest.
+ * Use `create(GetUserSettingsRequestSchema)` to create a new message.
+ */
+export const GetUserSettingsRequestSchema: GenMessage<GetUserSettingsRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 58);
+
+/**
+ * @generated from message hookly.v1.GetUserSettingsResponse
+ */
+export type GetUserSettingsResponse = Message<"hookly.v1.GetUserSettingsResponse"> & {
+  /**
+   * @generated from field: hookly.v1.UserSettings settings = 1;
+   */
+  settings?: UserSettings;
+};
+
+/**
+ * Describes the message hookly.v1.GetUserSettingsResponse.
+ * Use `create(GetUserSettingsResponseSchema)` to create a new message.
+ */
+export const GetUserSettingsResponseSchema: GenMessage<GetUserSettingsResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 59);
+
+/**
+ * @generated from message hookly.v1.UpdateUserSettingsRequest
+ */
+export type UpdateUserSettingsRequest = Message<"hookly.v1.UpdateUserSettingsRequest"> & {
+  /**
+   * Telegram settings (all optional - only set fields are updated)
+   *
+   * Write-only, encrypted at rest
+   *
+   * @generated from field: optional string telegram_bot_token = 1;
+   */
+  telegramBotToken?: string;
+
+  /**
+   * @generated from field: optional string telegram_chat_id = 2;
+   */
+  telegramChatId?: string;
+
+  /**
+   * @generated from field: optional bool telegram_enabled = 3;
+   */
+  telegramEnabled?: boolean;
+
+  /**
+   * UI preferences
+   *
+   * @generated from field: optional hookly.v1.ThemePreference theme_preference = 4;
+   */
+  themePreference?: ThemePreference;
+
+  /**
+   * Discord settings, a channel webhook URL like
+   * https://discord.com/api/webhooks/<id>/<token>
+   *
+   * Write-only, encrypted at rest
+   *
+   * @generated from field: optional string discord_webhook_url = 5;
+   */
+  discordWebhookUrl?: string;
+
+  /**
+   * @generated from field: optional bool discord_enabled = 6;
+   */
+  discordEnabled?: boolean;
+
+  /**
+   * Email settings; an empty address clears it
+   *
+   * @generated from field: optional string email_address = 7;
+   */
+  emailAddress?: string;
+
+  /**
+   * @generated from field: optional bool email_enabled = 8;
+   */
+  emailEnabled?: boolean;
+
+  /**
+   * Notification webhook settings: JSON events POSTed to the URL, signed
+   * with the secret in X-Webhook-Signature
+   *
+   * Write-only, encrypted at rest
+   *
+   * @generated from field: optional string notify_webhook_url = 9;
+   */
+  notifyWebhookUrl?: string;
+
+  /**
+   * Write-only, encrypted at rest
+   *
+   * @generated from field: optional string notify_webhook_secret = 10;
+   */
+  notifyWebhookSecret?: string;
+
+  /**
+   * @generated from field: optional bool notify_webhook_enabled = 11;
+   */
+  notifyWebhookEnabled?: boolean;
+};
+
+/**
+ * Describes the message hookly.v1.UpdateUserSettingsRequest.
+ * Use `create(UpdateUserSettingsRequestSchema)` to create a new message.
+ */
+export const UpdateUserSettingsRequestSchema: GenMessage<UpdateUserSettingsRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 60);
+
+/**
+ * @generated from message hookly.v1.UpdateUserSettingsResponse
+ */
+export type UpdateUserSettingsResponse = Message<"hookly.v1.UpdateUserSettingsResponse"> & {
+  /**
+   * @generated from field: hookly.v1.UserSettings settings = 1;
+   */
+  settings?: UserSettings;
+};
+
+/**
+ * Describes the message hookly.v1.UpdateUserSettingsResponse.
+ * Use `create(UpdateUserSettingsResponseSchema)` to create a new message.
+ */
+export const UpdateUserSettingsResponseSchema: GenMessage<UpdateUserSettingsResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 61);
+
+/**
+ * @generated from message hookly.v1.SendTestEmailRequest
+ */
+export type SendTestEmailRequest = Message<"hookly.v1.SendTestEmailRequest"> & {
+};
+
+/**
+ * Describes the message hookly.v1.SendTestEmailRequest.
+ * Use `create(SendTestEmailRequestSchema)` to create a new message.
+ */
+export const SendTestEmailRequestSchema: GenMessage<SendTestEmailRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 62);
+
+/**
+ * @generated from message hookly.v1.SendTestEmailResponse
+ */
+export type SendTestEmailResponse = Message<"hookly.v1.SendTestEmailResponse"> & {
+  /**
+   * Where the test email was sent
+   *
+   * @generated from field: string email_address = 1;
+   */
+  emailAddress: string;
+};
+
+/**
+ * Describes the message hookly.v1.SendTestEmailResponse.
+ * Use `create(SendTestEmailResponseSchema)` to create a new message.
+ */
+export const SendTestEmailResponseSchema: GenMessage<SendTestEmailResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 63);
+
+/**
+ * @generated from message hookly.v1.SendTestNotifyWebhookRequest
+ */
+export type SendTestNotifyWebhookRequest = Message<"hookly.v1.SendTestNotifyWebhookRequest"> & {
+};
+
+/**
+ * Describes the message hookly.v1.SendTestNotifyWebhookRequest.
+ * Use `create(SendTestNotifyWebhookRequestSchema)` to create a new message.
+ */
+export const SendTestNotifyWebhookRequestSchema: GenMessage<SendTestNotifyWebhookRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 64);
+
+/**
+ * @generated from message hookly.v1.SendTestNotifyWebhookResponse
+ */
+export type SendTestNotifyWebhookResponse = Message<"hookly.v1.SendTestNotifyWebhookResponse"> & {
+  /**
+   * The test event's id, also in X-Hookly-Delivery
+   *
+   * @generated from field: string event_id = 1;
+   */
+  eventId: string;
+};
+
+/**
+ * Describes the message hookly.v1.SendTestNotifyWebhookResponse.
+ * Use `create(SendTestNotifyWebhookResponseSchema)` to create a new message.
+ */
+export const SendTestNotifyWebhookResponseSchema: GenMessage<SendTestNotifyWebhookResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 65);
+
+/**
+ * @generated from message hookly.v1.ListApiTokensRequest
+ */
+export type ListApiTokensRequest = Message<"hookly.v1.ListApiTokensRequest"> & {
+};
+
+/**
+ * Describes the message hookly.v1.ListApiTokensRequest.
+ * Use `create(ListApiTokensRequestSchema)` to create a new message.
+ */
+export const ListApiTokensRequestSchema: GenMessage<ListApiTokensRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 66);
+
+/**
+ * @generated from message hookly.v1.ListApiTokensResponse
+ */
+export type ListApiTokensResponse = Message<"hookly.v1.ListApiTokensResponse"> & {
+  /**
+   * Not revoked, newest first
+   *
+   * @generated from field: repeated hookly.v1.ApiToken tokens = 1;
+   */
+  tokens: ApiToken[];
+};
+
+/**
+ * Describes the message hookly.v1.ListApiTokensResponse.
+ * Use `create(ListApiTokensResponseSchema)` to create a new message.
+ */
+export const ListApiTokensResponseSchema: GenMessage<ListApiTokensResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 67);
+
+/**
+ * @generated from message hookly.v1.CreateApiTokenRequest
+ */
+export type CreateApiTokenRequest = Message<"hookly.v1.CreateApiTokenRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+
+  /**
+   * @generated from field: hookly.v1.TokenScope scope = 2;
+   */
+  scope: TokenScope;
+
+  /**
+   * 0 for a token that never expires
+   *
+   * @generated from field: int64 expires_in_seconds = 3;
+   */
+  expiresInSeconds: bigint;
+};
+
+/**
+ * Describes the message hookly.v1.CreateApiTokenRequest.
+ * Use `create(CreateApiTokenRequestSchema)` to create a new message.
+ */
+export const CreateApiTokenRequestSchema: GenMessage<CreateApiTokenRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 68);
+
+/**
+ * @generated from message hookly.v1.CreateApiTokenResponse
+ */
+export type CreateApiTokenResponse = Message<"hookly.v1.CreateApiTokenResponse"> & {
+  /**
+   * Shown once; only its hash is stored
+   *
+   * @generated from field: string token = 1;
+   */
+  token: string;
+
+  /**
+   * @generated from field: hookly.v1.ApiToken api_token = 2;
+   */
+  apiToken?: ApiToken;
+};
+
+/**
+ * Describes the message hookly.v1.CreateApiTokenResponse.
+ * Use `create(CreateApiTokenResponseSchema)` to create a new message.
+ */
+export const CreateApiTokenResponseSchema: GenMessage<CreateApiTokenResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 69);
+
+/**
+ * @generated from message hookly.v1.RotateApiTokenRequest
+ */
+export type RotateApiTokenRequest = Message<"hookly.v1.RotateApiTokenRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message hookly.v1.RotateApiTokenRequest.
+ * Use `create(RotateApiTokenRequestSchema)` to create a new message.
+ */
+export const RotateApiTokenRequestSchema: GenMessage<RotateApiTokenRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 70);
+
+/**
+ * @generated from message hookly.v1.RotateApiTokenResponse
+ */
+export type RotateApiTokenResponse = Message<"hookly.v1.RotateApiTokenResponse"> & {
+  /**
+   * Shown once; the old token is revoked
+   *
+   * @generated from field: string token = 1;
+   */
+  token: string;
+
+  /**
+   * @generated from field: hookly.v1.ApiToken api_token = 2;
+   */
+  apiToken?: ApiToken;
+};
+
+/**
+ * Describes the message hookly.v1.RotateApiTokenResponse.
+ * Use `create(RotateApiTokenResponseSchema)` to create a new message.
+ */
+export const RotateApiTokenResponseSchema: GenMessage<RotateApiTokenResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 71);
+
+/**
+ * @generated from message hookly.v1.RevokeApiTokenRequest
+ */
+export type RevokeApiTokenRequest = Message<"hookly.v1.RevokeApiTokenRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message hookly.v1.RevokeApiTokenRequest.
+ * Use `create(RevokeApiTokenRequestSchema)` to create a new message.
+ */
+export const RevokeApiTokenRequestSchema: GenMessage<RevokeApiTokenRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 72);
+
+/**
+ * @generated from message hookly.v1.RevokeApiTokenResponse
+ */
+export type RevokeApiTokenResponse = Message<"hookly.v1.RevokeApiTokenResponse"> & {
+};
+
+/**
+ * Describes the message hookly.v1.RevokeApiTokenResponse.
+ * Use `create(RevokeApiTokenResponseSchema)` to create a new message.
+ */
+export const RevokeApiTokenResponseSchema: GenMessage<RevokeApiTokenResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 73);
+
+/**
+ * @generated from message hookly.v1.ListOrgsRequest
+ */
+export type ListOrgsRequest = Message<"hookly.v1.ListOrgsRequest"> & {
+};
+
+/**
+ * Describes the message hookly.v1.ListOrgsRequest.
+ * Use `create(ListOrgsRequestSchema)` to create a new message.
+ */
+export const ListOrgsRequestSchema: GenMessage<ListOrgsRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 74);
+
+/**
+ * @generated from message hookly.v1.ListOrgsResponse
+ */
+export type ListOrgsResponse = Message<"hookly.v1.ListOrgsResponse"> & {
+  /**
+   * Orgs the caller belongs to
+   *
+   * @generated from field: repeated hookly.v1.Org orgs = 1;
+   */
+  orgs: Org[];
+};
+
+/**
+ * Describes the message hookly.v1.ListOrgsResponse.
+ * Use `create(ListOrgsResponseSchema)` to create a new message.
+ */
+export const ListOrgsResponseSchema: GenMessage<ListOrgsResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 75);
+
+/**
+ * @generated from message hookly.v1.CreateOrgRequest
+ */
+export type CreateOrgRequest = Message<"hookly.v1.CreateOrgRequest"> & {
+  /**
+   * @generated from field: string name = 1;
+   */
+  name: string;
+};
+
+/**
+ * Describes the message hookly.v1.CreateOrgRequest.
+ * Use `create(CreateOrgRequestSchema)` to create a new message.
+ */
+export const CreateOrgRequestSchema: GenMessage<CreateOrgRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 76);
+
+/**
+ * @generated from message hookly.v1.CreateOrgResponse
+ */
+export type CreateOrgResponse = Message<"hookly.v1.CreateOrgResponse"> & {
+  /**
+   * @generated from field: hookly.v1.Org org = 1;
+   */
+  org?: Org;
+};
+
+/**
+ * Describes the message hookly.v1.CreateOrgResponse.
+ * Use `create(CreateOrgResponseSchema)` to create a new message.
+ */
+export const CreateOrgResponseSchema: GenMessage<CreateOrgResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 77);
+
+/**
+ * @generated from message hookly.v1.DeleteOrgRequest
+ */
+export type DeleteOrgRequest = Message<"hookly.v1.DeleteOrgRequest"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+};
+
+/**
+ * Describes the message hookly.v1.DeleteOrgRequest.
+ * Use `create(DeleteOrgRequestSchema)` to create a new message.
+ */
+export const DeleteOrgRequestSchema: GenMessage<DeleteOrgRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 78);
+
+/**
+ * @generated from message hookly.v1.DeleteOrgResponse
+ */
+export type DeleteOrgResponse = Message<"hookly.v1.DeleteOrgResponse"> & {
+  /**
+   * @generated from field: int64 endpoints_deleted = 1;
+   */
+  endpointsDeleted: bigint;
+};
+
+/**
+ * Describes the message hookly.v1.DeleteOrgResponse.
+ * Use `create(DeleteOrgResponseSchema)` to create a new message.
+ */
+export const DeleteOrgResponseSchema: GenMessage<DeleteOrgResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 79);
+
+/**
+ * @generated from message hookly.v1.ListOrgMembersRequest
+ */
+export type ListOrgMembersRequest = Message<"hookly.v1.ListOrgMembersRequest"> & {
+  /**
+   * @generated from field: string org_id = 1;
+   */
+  orgId: string;
+};
+
+/**
+ * Describes the message hookly.v1.ListOrgMembersRequest.
+ * Use `create(ListOrgMembersRequestSchema)` to create a new message.
+ */
+export const ListOrgMembersRequestSchema: GenMessage<ListOrgMembersRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 80);
+
+/**
+ * @generated from message hookly.v1.ListOrgMembersResponse
+ */
+export type ListOrgMembersResponse = Message<"hookly.v1.ListOrgMembersResponse"> & {
+  /**
+   * @generated from field: repeated hookly.v1.OrgMember members = 1;
+   */
+  members: OrgMember[];
+};
+
+/**
+ * Describes the message hookly.v1.ListOrgMembersResponse.
+ * Use `create(ListOrgMembersResponseSchema)` to create a new message.
+ */
+export const ListOrgMembersResponseSchema: GenMessage<ListOrgMembersResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 81);
+
+/**
+ * @generated from message hookly.v1.AddOrgMemberRequest
+ */
+export type AddOrgMemberRequest = Message<"hookly.v1.AddOrgMemberRequest"> & {
+  /**
+   * @generated from field: string org_id = 1;
    */
-  settings?: UserSettings;
+  orgId: string;
+
+  /**
+   * GitHub username; the user must have signed in once
+   *
+   * @generated from field: string username = 2;
+   */
+  username: string;
+
+  /**
+   * @generated from field: hookly.v1.OrgRole role = 3;
+   */
+  role: OrgRole;
 };
 
 /**
- * Describes the message hookly.v1.GetUserSettingsResponse.
- * Use `create(GetUserSettingsResponseSchema)` to create a new message.
+ * Describes the message hookly.v1.AddOrgMemberRequest.
+ * Use `create(AddOrgMemberRequestSchema)` to create a new message.
  */
-export const GetUserSettingsResponseSchema: GenMessage<GetUserSettingsResponse> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_edge, 21);
+export const AddOrgMemberRequestSchema: GenMessage<AddOrgMemberRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 82);
 
 /**
- * @generated from message hookly.v1.UpdateUserSettingsRequest
+ * @generated from message hookly.v1.AddOrgMemberResponse
  */
-export type UpdateUserSettingsRequest = Message<"hookly.v1.UpdateUserSettingsRequest"> & {
+export type AddOrgMemberResponse = Message<"hookly.v1.AddOrgMemberResponse"> & {
   /**
-   * Telegram settings (all optional - only set fields are updated)
-   *
-   * Write-only, encrypted at rest
-   *
-   * @generated from field: optional string telegram_bot_token = 1;
+   * @generated from field: hookly.v1.OrgMember member = 1;
    */
-  telegramBotToken?: string;
+  member?: OrgMember;
+};
 
+/**
+ * Describes the message hookly.v1.AddOrgMemberResponse.
+ * Use `create(AddOrgMemberResponseSchema)` to create a new message.
+ */
+export const AddOrgMemberResponseSchema: GenMessage<AddOrgMemberResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 83);
+
+/**
+ * @generated from message hookly.v1.RemoveOrgMemberRequest
+ */
+export type RemoveOrgMemberRequest = Message<"hookly.v1.RemoveOrgMemberRequest"> & {
   /**
-   * @generated from field: optional string telegram_chat_id = 2;
+   * @generated from field: string org_id = 1;
    */
-  telegramChatId?: string;
+  orgId: string;
 
   /**
-   * @generated from field: optional bool telegram_enabled = 3;
+   * @generated from field: string user_id = 2;
    */
-  telegramEnabled?: boolean;
+  userId: string;
+};
+
+/**
+ * Describes the message hookly.v1.RemoveOrgMemberRequest.
+ * Use `create(RemoveOrgMemberRequestSchema)` to create a new message.
+ */
+export const RemoveOrgMemberRequestSchema: GenMessage<RemoveOrgMemberRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 84);
+
+/**
+ * @generated from message hookly.v1.RemoveOrgMemberResponse
+ */
+export type RemoveOrgMemberResponse = Message<"hookly.v1.RemoveOrgMemberResponse"> & {
+};
+
+/**
+ * Describes the message hookly.v1.RemoveOrgMemberResponse.
+ * Use `create(RemoveOrgMemberResponseSchema)` to create a new message.
+ */
+export const RemoveOrgMemberResponseSchema: GenMessage<RemoveOrgMemberResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 85);
 
+/**
+ * @generated from message hookly.v1.ListAuditEventsRequest
+ */
+export type ListAuditEventsRequest = Message<"hookly.v1.ListAuditEventsRequest"> & {
   /**
-   * UI preferences
+   * e.g. DeleteEndpoint or Login; all actions if unset
    *
-   * @generated from field: optional hookly.v1.ThemePreference theme_preference = 4;
+   * @generated from field: optional string action = 1;
    */
-  themePreference?: ThemePreference;
+  action?: string;
+
+  /**
+   * Unset for no lower bound
+   *
+   * @generated from field: google.protobuf.Timestamp since = 2;
+   */
+  since?: Timestamp;
+
+  /**
+   * Max events to return (default 50, max 500)
+   *
+   * @generated from field: int32 limit = 3;
+   */
+  limit: number;
 };
 
 /**
- * Describes the message hookly.v1.UpdateUserSettingsRequest.
- * Use `create(UpdateUserSettingsRequestSchema)` to create a new message.
+ * Describes the message hookly.v1.ListAuditEventsRequest.
+ * Use `create(ListAuditEventsRequestSchema)` to create a new message.
  */
-export const UpdateUserSettingsRequestSchema: GenMessage<UpdateUserSettingsRequest> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_edge, 22);
+export const ListAuditEventsRequestSchema: GenMessage<ListAuditEventsRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 86);
 
 /**
- * @generated from message hookly.v1.UpdateUserSettingsResponse
+ * @generated from message hookly.v1.ListAuditEventsResponse
  */
-export type UpdateUserSettingsResponse = Message<"hookly.v1.UpdateUserSettingsResponse"> & {
+export type ListAuditEventsResponse = Message<"hookly.v1.ListAuditEventsResponse"> & {
   /**
-   * @generated from field: hookly.v1.UserSettings settings = 1;
+   * Newest first
+   *
+   * @generated from field: repeated hookly.v1.AuditEvent events = 1;
    */
-  settings?: UserSettings;
+  events: AuditEvent[];
 };
 
 /**
- * Describes the message hookly.v1.UpdateUserSettingsResponse.
- * Use `create(UpdateUserSettingsResponseSchema)` to create a new message.
+ * Describes the message hookly.v1.ListAuditEventsResponse.
+ * Use `create(ListAuditEventsResponseSchema)` to create a new message.
  */
-export const UpdateUserSettingsResponseSchema: GenMessage<UpdateUserSettingsResponse> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_edge, 23);
+export const ListAuditEventsResponseSchema: GenMessage<ListAuditEventsResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 87);
 
 /**
  * @generated from message hookly.v1.GetSystemSettingsRequest
@@ -558,7 +2207,7 @@ export type GetSystemSettingsRequest = Message<"hookly.v1.GetSystemSettingsReque
  * Use `create(GetSystemSettingsRequestSchema)` to create a new message.
  */
 export const GetSystemSettingsRequestSchema: GenMessage<GetSystemSettingsRequest> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_edge, 24);
+  messageDesc(file_hookly_v1_edge, 88);
 
 /**
  * @generated from message hookly.v1.GetSystemSettingsResponse
@@ -575,7 +2224,84 @@ export type GetSystemSettingsResponse = Message<"hookly.v1.GetSystemSettingsResp
  * Use `create(GetSystemSettingsResponseSchema)` to create a new message.
  */
 export const GetSystemSettingsResponseSchema: GenMessage<GetSystemSettingsResponse> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_edge, 25);
+  messageDesc(file_hookly_v1_edge, 89);
+
+/**
+ * @generated from message hookly.v1.RunMaintenanceRequest
+ */
+export type RunMaintenanceRequest = Message<"hookly.v1.RunMaintenanceRequest"> & {
+  /**
+   * dead_letters, slo, cleanup or jobs
+   *
+   * @generated from field: string job = 1;
+   */
+  job: string;
+};
+
+/**
+ * Describes the message hookly.v1.RunMaintenanceRequest.
+ * Use `create(RunMaintenanceRequestSchema)` to create a new message.
+ */
+export const RunMaintenanceRequestSchema: GenMessage<RunMaintenanceRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 90);
+
+/**
+ * @generated from message hookly.v1.RunMaintenanceResponse
+ */
+export type RunMaintenanceResponse = Message<"hookly.v1.RunMaintenanceResponse"> & {
+  /**
+   * @generated from field: hookly.v1.MaintenanceJob job = 1;
+   */
+  job?: MaintenanceJob;
+};
+
+/**
+ * Describes the message hookly.v1.RunMaintenanceResponse.
+ * Use `create(RunMaintenanceResponseSchema)` to create a new message.
+ */
+export const RunMaintenanceResponseSchema: GenMessage<RunMaintenanceResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 91);
+
+/**
+ * @generated from message hookly.v1.SetLogLevelRequest
+ */
+export type SetLogLevelRequest = Message<"hookly.v1.SetLogLevelRequest"> & {
+  /**
+   * debug, info, warn or error; empty only reports the level
+   *
+   * @generated from field: string level = 1;
+   */
+  level: string;
+};
+
+/**
+ * Describes the message hookly.v1.SetLogLevelRequest.
+ * Use `create(SetLogLevelRequestSchema)` to create a new message.
+ */
+export const SetLogLevelRequestSchema: GenMessage<SetLogLevelRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 92);
+
+/**
+ * @generated from message hookly.v1.SetLogLevelResponse
+ */
+export type SetLogLevelResponse = Message<"hookly.v1.SetLogLevelResponse"> & {
+  /**
+   * @generated from field: string level = 1;
+   */
+  level: string;
+
+  /**
+   * @generated from field: string previous_level = 2;
+   */
+  previousLevel: string;
+};
+
+/**
+ * Describes the message hookly.v1.SetLogLevelResponse.
+ * Use `create(SetLogLevelResponseSchema)` to create a new message.
+ */
+export const SetLogLevelResponseSchema: GenMessage<SetLogLevelResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_edge, 93);
 
 /**
  * EdgeService provides the API for managing endpoints and webhooks.
@@ -626,6 +2352,64 @@ export const EdgeService: GenService<{
     input: typeof DeleteEndpointRequestSchema;
     output: typeof DeleteEndpointResponseSchema;
   },
+  /**
+   * @generated from rpc hookly.v1.EdgeService.GetSetupInstructions
+   */
+  getSetupInstructions: {
+    methodKind: "unary";
+    input: typeof GetSetupInstructionsRequestSchema;
+    output: typeof GetSetupInstructionsResponseSchema;
+  },
+  /**
+   * @generated from rpc hookly.v1.EdgeService.SetupTelegramWebhook
+   */
+  setupTelegramWebhook: {
+    methodKind: "unary";
+    input: typeof SetupTelegramWebhookRequestSchema;
+    output: typeof SetupTelegramWebhookResponseSchema;
+  },
+  /**
+   * @generated from rpc hookly.v1.EdgeService.VerifyTelegramWebhook
+   */
+  verifyTelegramWebhook: {
+    methodKind: "unary";
+    input: typeof VerifyTelegramWebhookRequestSchema;
+    output: typeof VerifyTelegramWebhookResponseSchema;
+  },
+  /**
+   * @generated from rpc hookly.v1.EdgeService.GetEndpointStats
+   */
+  getEndpointStats: {
+    methodKind: "unary";
+    input: typeof GetEndpointStatsRequestSchema;
+    output: typeof GetEndpointStatsResponseSchema;
+  },
+  /**
+   * @generated from rpc hookly.v1.EdgeService.ListConnectionEvents
+   */
+  listConnectionEvents: {
+    methodKind: "unary";
+    input: typeof ListConnectionEventsRequestSchema;
+    output: typeof ListConnectionEventsResponseSchema;
+  },
+  /**
+   * @generated from rpc hookly.v1.EdgeService.GenerateEndpointSecret
+   */
+  generateEndpointSecret: {
+    methodKind: "unary";
+    input: typeof GenerateEndpointSecretRequestSchema;
+    output: typeof GenerateEndpointSecretResponseSchema;
+  },
+  /**
+   * Web session only; every reveal is audit-logged and rate limited
+   *
+   * @generated from rpc hookly.v1.EdgeService.RevealEndpointSecret
+   */
+  revealEndpointSecret: {
+    methodKind: "unary";
+    input: typeof RevealEndpointSecretRequestSchema;
+    output: typeof RevealEndpointSecretResponseSchema;
+  },
   /**
    * Webhook management
    *
@@ -636,6 +2420,14 @@ export const EdgeService: GenService<{
     input: typeof GetWebhookRequestSchema;
     output: typeof GetWebhookResponseSchema;
   },
+  /**
+   * @generated from rpc hookly.v1.EdgeService.GetWebhookPayload
+   */
+  getWebhookPayload: {
+    methodKind: "unary";
+    input: typeof GetWebhookPayloadRequestSchema;
+    output: typeof GetWebhookPayloadResponseSchema;
+  },
   /**
    * @generated from rpc hookly.v1.EdgeService.ListWebhooks
    */
@@ -652,6 +2444,42 @@ export const EdgeService: GenService<{
     input: typeof ReplayWebhookRequestSchema;
     output: typeof ReplayWebhookResponseSchema;
   },
+  /**
+   * @generated from rpc hookly.v1.EdgeService.BulkReplayWebhooks
+   */
+  bulkReplayWebhooks: {
+    methodKind: "unary";
+    input: typeof BulkReplayWebhooksRequestSchema;
+    output: typeof BulkReplayWebhooksResponseSchema;
+  },
+  /**
+   * @generated from rpc hookly.v1.EdgeService.CancelPendingReplays
+   */
+  cancelPendingReplays: {
+    methodKind: "unary";
+    input: typeof CancelPendingReplaysRequestSchema;
+    output: typeof CancelPendingReplaysResponseSchema;
+  },
+  /**
+   * Restores a webhook purged by retention cleanup, within the grace period
+   *
+   * @generated from rpc hookly.v1.EdgeService.UndeleteWebhook
+   */
+  undeleteWebhook: {
+    methodKind: "unary";
+    input: typeof UndeleteWebhookRequestSchema;
+    output: typeof UndeleteWebhookResponseSchema;
+  },
+  /**
+   * Streams webhooks as they are received and change status
+   *
+   * @generated from rpc hookly.v1.EdgeService.TailWebhooks
+   */
+  tailWebhooks: {
+    methodKind: "server_streaming";
+    input: typeof TailWebhooksRequestSchema;
+    output: typeof TailWebhooksResponseSchema;
+  },
   /**
    * System status
    *
@@ -670,9 +2498,53 @@ export const EdgeService: GenService<{
     input: typeof GetSettingsRequestSchema;
     output: typeof GetSettingsResponseSchema;
   },
+  /**
+   * @generated from rpc hookly.v1.EdgeService.GetActivityFeed
+   */
+  getActivityFeed: {
+    methodKind: "unary";
+    input: typeof GetActivityFeedRequestSchema;
+    output: typeof GetActivityFeedResponseSchema;
+  },
+  /**
+   * @generated from rpc hookly.v1.EdgeService.GetRegions
+   */
+  getRegions: {
+    methodKind: "unary";
+    input: typeof GetRegionsRequestSchema;
+    output: typeof GetRegionsResponseSchema;
+  },
+  /**
+   * Build info of the edge; needs no auth
+   *
+   * @generated from rpc hookly.v1.EdgeService.GetVersion
+   */
+  getVersion: {
+    methodKind: "unary";
+    input: typeof GetVersionRequestSchema;
+    output: typeof GetVersionResponseSchema;
+  },
+  /**
+   * Hub management: runs a command on one of the user's connected hubs
+   *
+   * @generated from rpc hookly.v1.EdgeService.SendHubCommand
+   */
+  sendHubCommand: {
+    methodKind: "unary";
+    input: typeof SendHubCommandRequestSchema;
+    output: typeof SendHubCommandResponseSchema;
+  },
   /**
    * User settings
    *
+   * @generated from rpc hookly.v1.EdgeService.GetCurrentUser
+   */
+  getCurrentUser: {
+    methodKind: "unary";
+    input: typeof GetCurrentUserRequestSchema;
+    output: typeof GetCurrentUserResponseSchema;
+  },
+  /**
    * @generated from rpc hookly.v1.EdgeService.GetUserSettings
    */
   getUserSettings: {
@@ -688,6 +2560,133 @@ export const EdgeService: GenService<{
     input: typeof UpdateUserSettingsRequestSchema;
     output: typeof UpdateUserSettingsResponseSchema;
   },
+  /**
+   * Sends a test email to the user's notification address
+   *
+   * @generated from rpc hookly.v1.EdgeService.SendTestEmail
+   */
+  sendTestEmail: {
+    methodKind: "unary";
+    input: typeof SendTestEmailRequestSchema;
+    output: typeof SendTestEmailResponseSchema;
+  },
+  /**
+   * Sends a test event to the user's notification webhook
+   *
+   * @generated from rpc hookly.v1.EdgeService.SendTestNotifyWebhook
+   */
+  sendTestNotifyWebhook: {
+    methodKind: "unary";
+    input: typeof SendTestNotifyWebhookRequestSchema;
+    output: typeof SendTestNotifyWebhookResponseSchema;
+  },
+  /**
+   * API tokens; only admin tokens and web sessions can create, rotate or
+   * revoke them
+   *
+   * @generated from rpc hookly.v1.EdgeService.ListApiTokens
+   */
+  listApiTokens: {
+    methodKind: "unary";
+    input: typeof ListApiTokensRequestSchema;
+    output: typeof ListApiTokensResponseSchema;
+  },
+  /**
+   * @generated from rpc hookly.v1.EdgeService.CreateApiToken
+   */
+  createApiToken: {
+    methodKind: "unary";
+    input: typeof CreateApiTokenRequestSchema;
+    output: typeof CreateApiTokenResponseSchema;
+  },
+  /**
+   * Replaces a token with a new one of the same name, scope and lifetime
+   *
+   * @generated from rpc hookly.v1.EdgeService.RotateApiToken
+   */
+  rotateApiToken: {
+    methodKind: "unary";
+    input: typeof RotateApiTokenRequestSchema;
+    output: typeof RotateApiTokenResponseSchema;
+  },
+  /**
+   * @generated from rpc hookly.v1.EdgeService.RevokeApiToken
+   */
+  revokeApiToken: {
+    methodKind: "unary";
+    input: typeof RevokeApiTokenRequestSchema;
+    output: typeof RevokeApiTokenResponseSchema;
+  },
+  /**
+   * Organizations, which share endpoints among their members. Endpoint
+   * calls act for an org when the Hookly-Org header names it.
+   *
+   * @generated from rpc hookly.v1.EdgeService.ListOrgs
+   */
+  listOrgs: {
+    methodKind: "unary";
+    input: typeof ListOrgsRequestSchema;
+    output: typeof ListOrgsResponseSchema;
+  },
+  /**
+   * Creates an org with the caller as its owner
+   *
+   * @generated from rpc hookly.v1.EdgeService.CreateOrg
+   */
+  createOrg: {
+    methodKind: "unary";
+    input: typeof CreateOrgRequestSchema;
+    output: typeof CreateOrgResponseSchema;
+  },
+  /**
+   * Deletes an org with its endpoints and webhooks (owners only)
+   *
+   * @generated from rpc hookly.v1.EdgeService.DeleteOrg
+   */
+  deleteOrg: {
+    methodKind: "unary";
+    input: typeof DeleteOrgRequestSchema;
+    output: typeof DeleteOrgResponseSchema;
+  },
+  /**
+   * @generated from rpc hookly.v1.EdgeService.ListOrgMembers
+   */
+  listOrgMembers: {
+    methodKind: "unary";
+    input: typeof ListOrgMembersRequestSchema;
+    output: typeof ListOrgMembersResponseSchema;
+  },
+  /**
+   * Adds a member, or changes a member's role (owners only)
+   *
+   * @generated from rpc hookly.v1.EdgeService.AddOrgMember
+   */
+  addOrgMember: {
+    methodKind: "unary";
+    input: typeof AddOrgMemberRequestSchema;
+    output: typeof AddOrgMemberResponseSchema;
+  },
+  /**
+   * Removes a member; owners remove anyone, others only themselves
+   *
+   * @generated from rpc hookly.v1.EdgeService.RemoveOrgMember
+   */
+  removeOrgMember: {
+    methodKind: "unary";
+    input: typeof RemoveOrgMemberRequestSchema;
+    output: typeof RemoveOrgMemberResponseSchema;
+  },
+  /**
+   * Audit log of management actions: the caller's, and everyone's on the
+   * endpoints the call acts for
+   *
+   * @generated from rpc hookly.v1.EdgeService.ListAuditEvents
+   */
+  listAuditEvents: {
+    methodKind: "unary";
+    input: typeof ListAuditEventsRequestSchema;
+    output: typeof ListAuditEventsResponseSchema;
+  },
   /**
    * System settings (superuser only)
    *
@@ -698,6 +2697,22 @@ export const EdgeService: GenService<{
     input: typeof GetSystemSettingsRequestSchema;
     output: typeof GetSystemSettingsResponseSchema;
   },
+  /**
+   * @generated from rpc hookly.v1.EdgeService.RunMaintenance
+   */
+  runMaintenance: {
+    methodKind: "unary";
+    input: typeof RunMaintenanceRequestSchema;
+    output: typeof RunMaintenanceResponseSchema;
+  },
+  /**
+   * @generated from rpc hookly.v1.EdgeService.SetLogLevel
+   */
+  setLogLevel: {
+    methodKind: "unary";
+    input: typeof SetLogLevelRequestSchema;
+    output: typeof SetLogLevelResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_hookly_v1_edge, 0);
 
@@ -6,13 +6,15 @@ import type { GenFile, GenMessage, GenService } from "@bufbuild/protobuf/codegen
 import { fileDesc, messageDesc, serviceDesc } from "@bufbuild/protobuf/codegenv2";
 import type { Timestamp } from "@bufbuild/protobuf/wkt";
 import { file_google_protobuf_timestamp } from "@bufbuild/protobuf/wkt";
+import type { Destination, HubCommandResult, HubCommandType, Transform } from "./common_pb";
+import { file_hookly_v1_common } from "./common_pb";
 import type { Message } from "@bufbuild/protobuf";
 
 /**
  * Describes the file hookly/v1/relay.proto.
  */
 export const file_hookly_v1_relay: GenFile = /*@__PURE__*/
-  fileDesc("ChVob29rbHkvdjEvcmVsYXkucHJvdG8SCWhvb2tseS52MSKaAQoNU3RyZWFtUmVxdWVzdBIsCgdjb25uZWN0GAEgASgLMhkuaG9va2x5LnYxLkNvbm5lY3RSZXF1ZXN0SAASJQoDYWNrGAIgASgLMhYuaG9va2x5LnYxLkRlbGl2ZXJ5QWNrSAASKQoJaGVhcnRiZWF0GAMgASgLMhQuaG9va2x5LnYxLkhlYXJ0YmVhdEgAQgkKB21lc3NhZ2UirQEKDlN0cmVhbVJlc3BvbnNlEjYKEGNvbm5lY3RfcmVzcG9uc2UYASABKAsyGi5ob29rbHkudjEuQ29ubmVjdFJlc3BvbnNlSAASLQoHd2ViaG9vaxgCIAEoCzIaLmhvb2tseS52MS5XZWJob29rRW52ZWxvcGVIABIpCgloZWFydGJlYXQYAyABKAsyFC5ob29rbHkudjEuSGVhcnRiZWF0SABCCQoHbWVzc2FnZSJFCg5Db25uZWN0UmVxdWVzdBIOCgZodWJfaWQYASABKAkSDQoFdG9rZW4YAiABKAkSFAoMZW5kcG9pbnRfaWRzGAMgAygJIjEKD0Nvbm5lY3RSZXNwb25zZRIPCgdzdWNjZXNzGAEgASgIEg0KBWVycm9yGAIgASgJIh4KCUhlYXJ0YmVhdBIRCgl0aW1lc3RhbXAYASABKAMiiAIKD1dlYmhvb2tFbnZlbG9wZRIKCgJpZBgBIAEoCRITCgtlbmRwb2ludF9pZBgCIAEoCRIXCg9kZXN0aW5hdGlvbl91cmwYAyABKAkSLwoLcmVjZWl2ZWRfYXQYBCABKAsyGi5nb29nbGUucHJvdG9idWYuVGltZXN0YW1wEjgKB2hlYWRlcnMYBSADKAsyJy5ob29rbHkudjEuV2ViaG9va0VudmVsb3BlLkhlYWRlcnNFbnRyeRIPCgdwYXlsb2FkGAYgASgMEg8KB2F0dGVtcHQYByABKAUaLgoMSGVhZGVyc0VudHJ5EgsKA2tleRgBIAEoCRINCgV2YWx1ZRgCIAEoCToCOAEieQoLRGVsaXZlcnlBY2sSEgoKd2ViaG9va19pZBgBIAEoCRIPCgdzdWNjZXNzGAIgASgIEhMKC3N0YXR1c19jb2RlGAMgASgFEhUKDWVycm9yX21lc3NhZ2UYBCABKAkSGQoRcGVybWFuZW50X2ZhaWx1cmUYBSABKAgyUQoMUmVsYXlTZXJ2aWNlEkEKBlN0cmVhbRIYLmhvb2tseS52MS5TdHJlYW1SZXF1ZXN0GhkuaG9va2x5LnYxLlN0cmVhbVJlc3BvbnNlKAEwAUKRAQoNY29tLmhvb2tseS52MUIKUmVsYXlQcm90b1ABWi9ob29rcy5keDMxNC5jb20vaW50ZXJuYWwvYXBpL2hvb2tseS92MTtob29rbHl2MaICA0hYWKoCCUhvb2tseS5WMcoCCUhvb2tseVxWMeICFUhvb2tseVxWMVxHUEJNZXRhZGF0YeoCCkhvb2tseTo6VjFiBnByb3RvMw", [file_google_protobuf_timestamp]);
+  fileDesc("ChVob29rbHkvdjEvcmVsYXkucHJvdG8SCWhvb2tseS52MSLRAQoNU3RyZWFtUmVxdWVzdBIsCgdjb25uZWN0GAEgASgLMhkuaG9va2x5LnYxLkNvbm5lY3RSZXF1ZXN0SAASJQoDYWNrGAIgASgLMhYuaG9va2x5LnYxLkRlbGl2ZXJ5QWNrSAASKQoJaGVhcnRiZWF0GAMgASgLMhQuaG9va2x5LnYxLkhlYXJ0YmVhdEgAEjUKDmNvbW1hbmRfcmVzdWx0GAQgASgLMhsuaG9va2x5LnYxLkh1YkNvbW1hbmRSZXN1bHRIAEIJCgdtZXNzYWdlIrgCCg5TdHJlYW1SZXNwb25zZRI2ChBjb25uZWN0X3Jlc3BvbnNlGAEgASgLMhouaG9va2x5LnYxLkNvbm5lY3RSZXNwb25zZUgAEi0KB3dlYmhvb2sYAiABKAsyGi5ob29rbHkudjEuV2ViaG9va0VudmVsb3BlSAASKQoJaGVhcnRiZWF0GAMgASgLMhQuaG9va2x5LnYxLkhlYXJ0YmVhdEgAEjAKDXBheWxvYWRfY2h1bmsYBCABKAsyFy5ob29rbHkudjEuUGF5bG9hZENodW5rSAASLQoLbWFpbnRlbmFuY2UYBSABKAsyFi5ob29rbHkudjEuTWFpbnRlbmFuY2VIABIoCgdjb21tYW5kGAYgASgLMhUuaG9va2x5LnYxLkh1YkNvbW1hbmRIAEIJCgdtZXNzYWdlIogBCg5Db25uZWN0UmVxdWVzdBIOCgZodWJfaWQYASABKAkSDQoFdG9rZW4YAiABKAkSFAoMZW5kcG9pbnRfaWRzGAMgAygJEjEKDWV2ZW50X2ZpbHRlcnMYBCADKAsyGi5ob29rbHkudjEuRXZlbnRUeXBlRmlsdGVyEg4KBnBhdXNlZBgFIAEoCCI7Cg9FdmVudFR5cGVGaWx0ZXISEwoLZW5kcG9pbnRfaWQYASABKAkSEwoLZXZlbnRfdHlwZXMYAiADKAkiTgoPQ29ubmVjdFJlc3BvbnNlEg8KB3N1Y2Nlc3MYASABKAgSDQoFZXJyb3IYAiABKAkSGwoTcmV0cnlfYWZ0ZXJfc2Vjb25kcxgDIAEoBSJVCgtNYWludGVuYW5jZRIfChdyZWNvbm5lY3RfYWZ0ZXJfc2Vjb25kcxgBIAEoBRIVCg1yZWNvbm5lY3RfdXJsGAIgASgJEg4KBnJlYXNvbhgDIAEoCSJQCgpIdWJDb21tYW5kEgoKAmlkGAEgASgJEicKBHR5cGUYAiABKA4yGS5ob29rbHkudjEuSHViQ29tbWFuZFR5cGUSDQoFbGluZXMYAyABKAUiHgoJSGVhcnRiZWF0EhEKCXRpbWVzdGFtcBgBIAEoAyKzAwoPV2ViaG9va0VudmVsb3BlEgoKAmlkGAEgASgJEhMKC2VuZHBvaW50X2lkGAIgASgJEhcKD2Rlc3RpbmF0aW9uX3VybBgDIAEoCRIvCgtyZWNlaXZlZF9hdBgEIAEoCzIaLmdvb2dsZS5wcm90b2J1Zi5UaW1lc3RhbXASOAoHaGVhZGVycxgFIAMoCzInLmhvb2tseS52MS5XZWJob29rRW52ZWxvcGUuSGVhZGVyc0VudHJ5Eg8KB3BheWxvYWQYBiABKAwSDwoHYXR0ZW1wdBgHIAEoBRIPCgdjaHVua2VkGAggASgIEhQKDHBheWxvYWRfc2l6ZRgJIAEoAxIWCg5wYXlsb2FkX3NoYTI1NhgKIAEoCRInCgl0cmFuc2Zvcm0YCyABKAsyFC5ob29rbHkudjEuVHJhbnNmb3JtEiwKDGRlc3RpbmF0aW9ucxgMIAMoCzIWLmhvb2tseS52MS5EZXN0aW5hdGlvbhITCgt0cmFjZXBhcmVudBgNIAEoCRouCgxIZWFkZXJzRW50cnkSCwoDa2V5GAEgASgJEg0KBXZhbHVlGAIgASgJOgI4ASJNCgxQYXlsb2FkQ2h1bmsSEgoKd2ViaG9va19pZBgBIAEoCRINCgVpbmRleBgCIAEoBRIMCgRkYXRhGAMgASgMEgwKBGxhc3QYBCABKAgiwgEKC0RlbGl2ZXJ5QWNrEhIKCndlYmhvb2tfaWQYASABKAkSDwoHc3VjY2VzcxgCIAEoCBITCgtzdGF0dXNfY29kZRgDIAEoBRIVCg1lcnJvcl9tZXNzYWdlGAQgASgJEhkKEXBlcm1hbmVudF9mYWlsdXJlGAUgASgIEjIKDGRlc3RpbmF0aW9ucxgGIAMoCzIcLmhvb2tseS52MS5EZXN0aW5hdGlvblJlc3VsdBITCgt0cmFjZXBhcmVudBgHIAEoCSKDAQoRRGVzdGluYXRpb25SZXN1bHQSFgoOZGVzdGluYXRpb25faWQYASABKAkSDwoHc3VjY2VzcxgCIAEoCBITCgtzdGF0dXNfY29kZRgDIAEoBRIVCg1lcnJvcl9tZXNzYWdlGAQgASgJEhkKEXBlcm1hbmVudF9mYWlsdXJlGAUgASgIImQKFVJlZ2lzdGVyVHVubmVsUmVxdWVzdBIqCgdjb25uZWN0GAEgASgLMhkuaG9va2x5LnYxLkNvbm5lY3RSZXF1ZXN0Eg8KB2FkZHJlc3MYAiABKAkSDgoGc2VjcmV0GAMgASgJIj8KFlJlZ2lzdGVyVHVubmVsUmVzcG9uc2USDgoGYWN0aXZlGAEgASgIEhUKDWxlYXNlX3NlY29uZHMYAiABKAUyqAEKDFJlbGF5U2VydmljZRJBCgZTdHJlYW0SGC5ob29rbHkudjEuU3RyZWFtUmVxdWVzdBoZLmhvb2tseS52MS5TdHJlYW1SZXNwb25zZSgBMAESVQoOUmVnaXN0ZXJUdW5uZWwSIC5ob29rbHkudjEuUmVnaXN0ZXJUdW5uZWxSZXF1ZXN0GiEuaG9va2x5LnYxLlJlZ2lzdGVyVHVubmVsUmVzcG9uc2UyTgoNVHVubmVsU2VydmljZRI9CgdEZWxpdmVyEhouaG9va2x5LnYxLldlYmhvb2tFbnZlbG9wZRoWLmhvb2tseS52MS5EZWxpdmVyeUFja0KRAQoNY29tLmhvb2tseS52MUIKUmVsYXlQcm90b1ABWi9ob29rcy5keDMxNC5jb20vaW50ZXJuYWwvYXBpL2hvb2tseS92MTtob29rbHl2MaICA0hYWKoCCUhvb2tseS5WMcoCCUhvb2tseVxWMeICFUhvb2tseVxWMVxHUEJNZXRhZGF0YeoCCkhvb2tseTo6VjFiBnByb3RvMw", [file_google_protobuf_timestamp, file_hookly_v1_common]);
 
 /**
  * Messages from home-hub to edge
@@ -41,6 +43,12 @@ export type StreamRequest = Message<"hookly.v1.StreamRequest"> & {
      */
     value: Heartbeat;
     case: "heartbeat";
+  } | {
+    /**
+     * @generated from field: hookly.v1.HubCommandResult command_result = 4;
+     */
+    value: HubCommandResult;
+    case: "commandResult";
   } | { case: undefined; value?: undefined };
 };
 
@@ -78,6 +86,24 @@ export type StreamResponse = Message<"hookly.v1.StreamResponse"> & {
      */
     value: Heartbeat;
     case: "heartbeat";
+  } | {
+    /**
+     * @generated from field: hookly.v1.PayloadChunk payload_chunk = 4;
+     */
+    value: PayloadChunk;
+    case: "payloadChunk";
+  } | {
+    /**
+     * @generated from field: hookly.v1.Maintenance maintenance = 5;
+     */
+    value: Maintenance;
+    case: "maintenance";
+  } | {
+    /**
+     * @generated from field: hookly.v1.HubCommand command = 6;
+     */
+    value: HubCommand;
+    case: "command";
   } | { case: undefined; value?: undefined };
 };
 
@@ -112,6 +138,20 @@ export type ConnectRequest = Message<"hookly.v1.ConnectRequest"> & {
    * @generated from field: repeated string endpoint_ids = 3;
    */
   endpointIds: string[];
+
+  /**
+   * Event types wanted per endpoint (none = all)
+   *
+   * @generated from field: repeated hookly.v1.EventTypeFilter event_filters = 4;
+   */
+  eventFilters: EventTypeFilter[];
+
+  /**
+   * Deliveries were paused by a command before reconnecting
+   *
+   * @generated from field: bool paused = 5;
+   */
+  paused: boolean;
 };
 
 /**
@@ -121,6 +161,30 @@ export type ConnectRequest = Message<"hookly.v1.ConnectRequest"> & {
 export const ConnectRequestSchema: GenMessage<ConnectRequest> = /*@__PURE__*/
   messageDesc(file_hookly_v1_relay, 2);
 
+/**
+ * EventTypeFilter restricts relayed webhooks for an endpoint to the listed event types.
+ *
+ * @generated from message hookly.v1.EventTypeFilter
+ */
+export type EventTypeFilter = Message<"hookly.v1.EventTypeFilter"> & {
+  /**
+   * @generated from field: string endpoint_id = 1;
+   */
+  endpointId: string;
+
+  /**
+   * @generated from field: repeated string event_types = 2;
+   */
+  eventTypes: string[];
+};
+
+/**
+ * Describes the message hookly.v1.EventTypeFilter.
+ * Use `create(EventTypeFilterSchema)` to create a new message.
+ */
+export const EventTypeFilterSchema: GenMessage<EventTypeFilter> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_relay, 3);
+
 /**
  * Connection response
  *
@@ -136,6 +200,13 @@ export type ConnectResponse = Message<"hookly.v1.ConnectResponse"> & {
    * @generated from field: string error = 2;
    */
   error: string;
+
+  /**
+   * Minimum delay before reconnecting (0 = client default)
+   *
+   * @generated from field: int32 retry_after_seconds = 3;
+   */
+  retryAfterSeconds: number;
 };
 
 /**
@@ -143,7 +214,70 @@ export type ConnectResponse = Message<"hookly.v1.ConnectResponse"> & {
  * Use `create(ConnectResponseSchema)` to create a new message.
  */
 export const ConnectResponseSchema: GenMessage<ConnectResponse> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_relay, 3);
+  messageDesc(file_hookly_v1_relay, 4);
+
+/**
+ * Maintenance announces a planned edge restart. The edge closes the stream
+ * right after sending it; the hub reconnects after reconnect_after_seconds,
+ * to reconnect_url if set (a standby edge) and otherwise to the same edge.
+ *
+ * @generated from message hookly.v1.Maintenance
+ */
+export type Maintenance = Message<"hookly.v1.Maintenance"> & {
+  /**
+   * @generated from field: int32 reconnect_after_seconds = 1;
+   */
+  reconnectAfterSeconds: number;
+
+  /**
+   * @generated from field: string reconnect_url = 2;
+   */
+  reconnectUrl: string;
+
+  /**
+   * @generated from field: string reason = 3;
+   */
+  reason: string;
+};
+
+/**
+ * Describes the message hookly.v1.Maintenance.
+ * Use `create(MaintenanceSchema)` to create a new message.
+ */
+export const MaintenanceSchema: GenMessage<Maintenance> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_relay, 5);
+
+/**
+ * HubCommand is a remote management command. The hub answers with a
+ * HubCommandResult carrying the same id.
+ *
+ * @generated from message hookly.v1.HubCommand
+ */
+export type HubCommand = Message<"hookly.v1.HubCommand"> & {
+  /**
+   * @generated from field: string id = 1;
+   */
+  id: string;
+
+  /**
+   * @generated from field: hookly.v1.HubCommandType type = 2;
+   */
+  type: HubCommandType;
+
+  /**
+   * Log lines to send for HUB_COMMAND_TYPE_LOGS
+   *
+   * @generated from field: int32 lines = 3;
+   */
+  lines: number;
+};
+
+/**
+ * Describes the message hookly.v1.HubCommand.
+ * Use `create(HubCommandSchema)` to create a new message.
+ */
+export const HubCommandSchema: GenMessage<HubCommand> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_relay, 6);
 
 /**
  * Heartbeat for connection health monitoring
@@ -162,7 +296,7 @@ export type Heartbeat = Message<"hookly.v1.Heartbeat"> & {
  * Use `create(HeartbeatSchema)` to create a new message.
  */
 export const HeartbeatSchema: GenMessage<Heartbeat> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_relay, 4);
+  messageDesc(file_hookly_v1_relay, 7);
 
 /**
  * Webhook envelope for delivery to home network
@@ -204,6 +338,49 @@ export type WebhookEnvelope = Message<"hookly.v1.WebhookEnvelope"> & {
    * @generated from field: int32 attempt = 7;
    */
   attempt: number;
+
+  /**
+   * Set for large payloads: payload is empty and follows as PayloadChunk
+   * messages on the same stream.
+   *
+   * @generated from field: bool chunked = 8;
+   */
+  chunked: boolean;
+
+  /**
+   * @generated from field: int64 payload_size = 9;
+   */
+  payloadSize: bigint;
+
+  /**
+   * Hex-encoded SHA-256 of the full payload
+   *
+   * @generated from field: string payload_sha256 = 10;
+   */
+  payloadSha256: string;
+
+  /**
+   * Applied before forwarding, unset if none
+   *
+   * @generated from field: hookly.v1.Transform transform = 11;
+   */
+  transform?: Transform;
+
+  /**
+   * Set when the endpoint fans out: the destinations still to deliver to,
+   * the endpoint's own with an empty id. Unset delivers to destination_url.
+   *
+   * @generated from field: repeated hookly.v1.Destination destinations = 12;
+   */
+  destinations: Destination[];
+
+  /**
+   * W3C trace context of the span that sent the webhook, so the hub's
+   * delivery spans join the webhook's trace.
+   *
+   * @generated from field: string traceparent = 13;
+   */
+  traceparent: string;
 };
 
 /**
@@ -211,7 +388,42 @@ export type WebhookEnvelope = Message<"hookly.v1.WebhookEnvelope"> & {
  * Use `create(WebhookEnvelopeSchema)` to create a new message.
  */
 export const WebhookEnvelopeSchema: GenMessage<WebhookEnvelope> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_relay, 5);
+  messageDesc(file_hookly_v1_relay, 8);
+
+/**
+ * PayloadChunk carries part of a chunked webhook payload. Chunks are sent in
+ * order directly after their envelope.
+ *
+ * @generated from message hookly.v1.PayloadChunk
+ */
+export type PayloadChunk = Message<"hookly.v1.PayloadChunk"> & {
+  /**
+   * @generated from field: string webhook_id = 1;
+   */
+  webhookId: string;
+
+  /**
+   * @generated from field: int32 index = 2;
+   */
+  index: number;
+
+  /**
+   * @generated from field: bytes data = 3;
+   */
+  data: Uint8Array;
+
+  /**
+   * @generated from field: bool last = 4;
+   */
+  last: boolean;
+};
+
+/**
+ * Describes the message hookly.v1.PayloadChunk.
+ * Use `create(PayloadChunkSchema)` to create a new message.
+ */
+export const PayloadChunkSchema: GenMessage<PayloadChunk> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_relay, 9);
 
 /**
  * Delivery acknowledgment from home-hub
@@ -245,6 +457,21 @@ export type DeliveryAck = Message<"hookly.v1.DeliveryAck"> & {
    * @generated from field: bool permanent_failure = 5;
    */
   permanentFailure: boolean;
+
+  /**
+   * One per destination of a fanned-out webhook. The fields above then
+   * summarize them: success if all succeeded, else the first failure.
+   *
+   * @generated from field: repeated hookly.v1.DestinationResult destinations = 6;
+   */
+  destinations: DestinationResult[];
+
+  /**
+   * W3C trace context of the hub's delivery span, joined by the ACK span
+   *
+   * @generated from field: string traceparent = 7;
+   */
+  traceparent: string;
 };
 
 /**
@@ -252,7 +479,106 @@ export type DeliveryAck = Message<"hookly.v1.DeliveryAck"> & {
  * Use `create(DeliveryAckSchema)` to create a new message.
  */
 export const DeliveryAckSchema: GenMessage<DeliveryAck> = /*@__PURE__*/
-  messageDesc(file_hookly_v1_relay, 6);
+  messageDesc(file_hookly_v1_relay, 10);
+
+/**
+ * @generated from message hookly.v1.DestinationResult
+ */
+export type DestinationResult = Message<"hookly.v1.DestinationResult"> & {
+  /**
+   * @generated from field: string destination_id = 1;
+   */
+  destinationId: string;
+
+  /**
+   * @generated from field: bool success = 2;
+   */
+  success: boolean;
+
+  /**
+   * @generated from field: int32 status_code = 3;
+   */
+  statusCode: number;
+
+  /**
+   * @generated from field: string error_message = 4;
+   */
+  errorMessage: string;
+
+  /**
+   * @generated from field: bool permanent_failure = 5;
+   */
+  permanentFailure: boolean;
+};
+
+/**
+ * Describes the message hookly.v1.DestinationResult.
+ * Use `create(DestinationResultSchema)` to create a new message.
+ */
+export const DestinationResultSchema: GenMessage<DestinationResult> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_relay, 11);
+
+/**
+ * Tunnel lease request, sent as a unary call so it works on networks that
+ * drop long-lived streams
+ *
+ * @generated from message hookly.v1.RegisterTunnelRequest
+ */
+export type RegisterTunnelRequest = Message<"hookly.v1.RegisterTunnelRequest"> & {
+  /**
+   * Same authentication and endpoints as the stream
+   *
+   * @generated from field: hookly.v1.ConnectRequest connect = 1;
+   */
+  connect?: ConnectRequest;
+
+  /**
+   * ip:port the edge dials to reach the hub's tunnel listener
+   *
+   * @generated from field: string address = 2;
+   */
+  address: string;
+
+  /**
+   * Bearer secret the edge presents to the listener
+   *
+   * @generated from field: string secret = 3;
+   */
+  secret: string;
+};
+
+/**
+ * Describes the message hookly.v1.RegisterTunnelRequest.
+ * Use `create(RegisterTunnelRequestSchema)` to create a new message.
+ */
+export const RegisterTunnelRequestSchema: GenMessage<RegisterTunnelRequest> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_relay, 12);
+
+/**
+ * @generated from message hookly.v1.RegisterTunnelResponse
+ */
+export type RegisterTunnelResponse = Message<"hookly.v1.RegisterTunnelResponse"> & {
+  /**
+   * False while the hub's stream is connected
+   *
+   * @generated from field: bool active = 1;
+   */
+  active: boolean;
+
+  /**
+   * Renew before the lease expires
+   *
+   * @generated from field: int32 lease_seconds = 2;
+   */
+  leaseSeconds: number;
+};
+
+/**
+ * Describes the message hookly.v1.RegisterTunnelResponse.
+ * Use `create(RegisterTunnelResponseSchema)` to create a new message.
+ */
+export const RegisterTunnelResponseSchema: GenMessage<RegisterTunnelResponse> = /*@__PURE__*/
+  messageDesc(file_hookly_v1_relay, 13);
 
 /**
  * RelayService handles communication between edge and home-hub.
@@ -273,6 +599,36 @@ export const RelayService: GenService<{
     input: typeof StreamRequestSchema;
     output: typeof StreamResponseSchema;
   },
+  /**
+   * RegisterTunnel leases delivery over a WireGuard or SSH reverse tunnel
+   * while the hub's stream is down. The hub renews the lease by calling it
+   * again; a connected stream always takes precedence.
+   *
+   * @generated from rpc hookly.v1.RelayService.RegisterTunnel
+   */
+  registerTunnel: {
+    methodKind: "unary";
+    input: typeof RegisterTunnelRequestSchema;
+    output: typeof RegisterTunnelResponseSchema;
+  },
 }> = /*@__PURE__*/
   serviceDesc(file_hookly_v1_relay, 0);
 
+/**
+ * TunnelService is served by the hub on its tunnel listener. The edge calls
+ * it to deliver webhooks when the stream is unavailable.
+ *
+ * @generated from service hookly.v1.TunnelService
+ */
+export const TunnelService: GenService<{
+  /**
+   * @generated from rpc hookly.v1.TunnelService.Deliver
+   */
+  deliver: {
+    methodKind: "unary";
+    input: typeof WebhookEnvelopeSchema;
+    output: typeof DeliveryAckSchema;
+  },
+}> = /*@__PURE__*/
+  serviceDesc(file_hookly_v1_relay, 1);
+
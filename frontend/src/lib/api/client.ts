@@ -47,5 +47,6 @@ export const edgeClient = createClient(EdgeService, transport);
 export const edgeClientNoRedirect = createClient(EdgeService, transportNoRedirect);
 
 // Re-export types
-export { type Endpoint, type Webhook, type SystemStatus, type UserSettings, type SystemSettings } from '$api/hookly/v1/common_pb';
-export { ProviderType, WebhookStatus, ThemePreference } from '$api/hookly/v1/common_pb';
+export { type Endpoint, type Webhook, type SystemStatus, type UserSettings, type SystemSettings, type DestinationDelivery, type ConnectionEvent } from '$api/hookly/v1/common_pb';
+export { ProviderType, WebhookStatus, ThemePreference, IngestAuthMethod, EndpointSort, ConnectionEventType } from '$api/hookly/v1/common_pb';
+export { type EventTypeCount, type SLOCompliance, type TelegramWebhookStatus } from '$api/hookly/v1/edge_pb';
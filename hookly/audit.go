@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	clicmd "hooks.dx314.com/internal/cli"
+)
+
+// auditCommand returns the audit subcommand.
+func auditCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "audit",
+		Usage: "Show the audit log of management actions",
+		Description: `Lists management actions, newest first: API calls that change
+something, logins, CLI authorizations and token revocations, with who
+made them, with which token, from which IP and whether they succeeded.
+
+Shows your own actions and everyone's on your endpoints; with --org,
+every member's actions on the org's endpoints. --since takes a duration
+ago, e.g. 24h, or an RFC3339 time.`,
+		Action: runAudit,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "action",
+				Usage: "Only `ACTION`, e.g. DeleteEndpoint or Login",
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "Only actions after `TIME`",
+			},
+			&cli.IntFlag{
+				Name:  "limit",
+				Value: 50,
+				Usage: "Most events to show, at most 500",
+			},
+			jsonFlag,
+		},
+	}
+}
+
+// runAudit handles the audit command.
+func runAudit(c *cli.Context) error {
+	req := &hooklyv1.ListAuditEventsRequest{Limit: int32(c.Int("limit"))}
+	if v := c.String("action"); v != "" {
+		req.Action = &v
+	}
+	if v := c.String("since"); v != "" {
+		t, err := parseTimeFlag(v, time.Now())
+		if err != nil {
+			return fmt.Errorf("--since: %w", err)
+		}
+		req.Since = timestamppb.New(t)
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	events, err := clicmd.Spin(context.Background(), "Loading audit log", func(ctx context.Context) ([]*hooklyv1.AuditEvent, error) {
+		resp, err := client.Edge.ListAuditEvents(ctx, connect.NewRequest(req))
+		if err != nil {
+			return nil, fmt.Errorf("list audit events: %w", err)
+		}
+		return resp.Msg.Events, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.Bool("json") {
+		return printJSONList(os.Stdout, events)
+	}
+	if len(events) == 0 {
+		fmt.Fprintln(os.Stderr, "No audit events found.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "TIME\tUSER\tACTION\tTARGET\tRESULT\tIP\tVIA")
+	for _, ev := range events {
+		via := "web"
+		if ev.TokenId != "" {
+			via = "token " + ev.TokenId
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			tsTime(ev.OccurredAt).Local().Format("2006-01-02 15:04:05"),
+			ev.Username,
+			ev.Action,
+			orDash(ev.Target),
+			ev.Result,
+			orDash(ev.Ip),
+			via,
+		)
+	}
+	return tw.Flush()
+}
+
+// orDash returns s, or "-" for an empty table cell.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
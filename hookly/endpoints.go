@@ -0,0 +1,684 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"connectrpc.com/connect"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	clicmd "hooks.dx314.com/internal/cli"
+)
+
+// jsonFlag prints a command's result as JSON, for scripts.
+var jsonFlag = &cli.BoolFlag{
+	Name:  "json",
+	Usage: "Print the result as JSON",
+}
+
+// endpointFlags are the settings shared by endpoints create and update.
+var endpointFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:  "name",
+		Usage: "Endpoint `NAME`",
+	},
+	&cli.StringFlag{
+		Name:  "destination",
+		Usage: "`URL` the hub forwards webhooks to",
+	},
+	&cli.StringFlag{
+		Name:  "secret",
+		Usage: "Provider signature `SECRET` (see gen-secret to generate one)",
+	},
+	&cli.BoolFlag{
+		Name:  "notify-first-event",
+		Usage: "Notify when the first webhook arrives",
+	},
+}
+
+// endpointsCommand returns the endpoints subcommand.
+func endpointsCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "endpoints",
+		Usage: "Manage webhook endpoints",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List endpoints",
+				Description: `Lists your endpoints, newest first. Filter by name with --search,
+by provider with --provider, and by muted state with --muted or
+--muted=false. --inactive-days N lists stale endpoints, without a webhook
+in the last N days.
+
+--sort oldest lists the oldest first, --sort last-received the endpoints
+with the most recent webhook first.`,
+				Action: runEndpointsList,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "search",
+						Usage: "Only endpoints whose name contains `TEXT`",
+					},
+					&cli.StringFlag{
+						Name:  "provider",
+						Usage: "Only endpoints of `PROVIDER` (stripe, github, telegram, slack, shopify, square, paypal, generic, custom)",
+					},
+					&cli.BoolFlag{
+						Name:  "muted",
+						Usage: "Only muted endpoints, or only unmuted with --muted=false",
+					},
+					&cli.IntFlag{
+						Name:  "inactive-days",
+						Usage: "Only endpoints without a webhook for `DAYS` days",
+					},
+					&cli.StringFlag{
+						Name:  "sort",
+						Usage: "Sort `ORDER`: newest, oldest or last-received",
+						Value: "newest",
+					},
+					jsonFlag,
+				},
+			},
+			{
+				Name:      "get",
+				Usage:     "Show an endpoint",
+				ArgsUsage: "<endpoint-id>",
+				Action:    runEndpointsGet,
+				Flags:     []cli.Flag{jsonFlag},
+			},
+			{
+				Name:  "create",
+				Usage: "Create an endpoint",
+				Description: `Creates an endpoint and prints its webhook URL, to configure on the
+provider side. --name and --provider are required, and --destination
+unless --honeypot is set.
+
+Custom verification and ingestion auth are set in the web UI.`,
+				Action: runEndpointsCreate,
+				Flags: append(slices.Clone(endpointFlags),
+					&cli.StringFlag{
+						Name:  "provider",
+						Usage: "`PROVIDER`: stripe, github, telegram, slack, shopify, square, paypal or generic",
+					},
+					&cli.BoolFlag{
+						Name:  "honeypot",
+						Usage: "Alert on every hit and never relay",
+					},
+					jsonFlag,
+				),
+			},
+			{
+				Name:      "update",
+				Usage:     "Change an endpoint's settings",
+				ArgsUsage: "<endpoint-id>",
+				Description: `Changes only the settings given, e.g.
+hookly endpoints update ep_123 --destination http://localhost:3000/hook
+
+Boolean settings are turned off with =false, e.g. --notify-first-event=false.`,
+				Action: runEndpointsUpdate,
+				Flags: append(slices.Clone(endpointFlags),
+					&cli.BoolFlag{
+						Name:  "reject-duplicates",
+						Usage: "Drop re-deliveries of a known delivery ID instead of flagging them",
+					},
+					&cli.IntFlag{
+						Name:  "rate-limit",
+						Usage: "Ingestion limit in `REQUESTS` per minute, 0 for the edge's",
+					},
+					&cli.Int64Flag{
+						Name:  "max-payload-size",
+						Usage: "Largest payload accepted in `BYTES`, 0 for the edge's 100 MB",
+					},
+					&cli.StringFlag{
+						Name:  "content-types",
+						Usage: "Comma-separated media `TYPES` accepted, e.g. application/json,text/*; empty for any",
+					},
+					jsonFlag,
+				),
+			},
+			{
+				Name:      "delete",
+				Usage:     "Delete an endpoint and its webhooks",
+				ArgsUsage: "<endpoint-id>",
+				Description: `Deletes the endpoint and every webhook it received. Asks for
+confirmation unless --yes is given, which scripts must pass.`,
+				Action: runEndpointsDelete,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:    "yes",
+						Aliases: []string{"y"},
+						Usage:   "Don't ask for confirmation",
+					},
+				},
+			},
+			{
+				Name:      "mute",
+				Usage:     "Mute an endpoint: webhooks are answered but discarded",
+				ArgsUsage: "<endpoint-id>",
+				Action:    func(c *cli.Context) error { return setEndpointMuted(c, true) },
+			},
+			{
+				Name:      "unmute",
+				Usage:     "Unmute an endpoint, also bringing back an archived one",
+				ArgsUsage: "<endpoint-id>",
+				Action:    func(c *cli.Context) error { return setEndpointMuted(c, false) },
+			},
+			{
+				Name:      "instructions",
+				Usage:     "Show provider setup instructions for an endpoint",
+				ArgsUsage: "<endpoint-id>",
+				Description: `Prints the steps to configure the webhook on the provider side
+(Stripe dashboard, GitHub repository settings, Telegram setWebhook, ...)
+with the endpoint's webhook URL filled in.
+
+The signature secret is never printed; a placeholder is shown instead.`,
+				Action: runEndpointsInstructions,
+			},
+			{
+				Name:      "gen-secret",
+				Usage:     "Generate a new signature secret for an endpoint",
+				ArgsUsage: "<endpoint-id>",
+				Description: `Generates a cryptographically strong signature secret in the format
+the endpoint's provider expects (e.g. whsec_... for Stripe), stores it
+encrypted on the edge and prints it once.
+
+This replaces the current secret: paste the new one into the provider's
+webhook settings right away, or signature verification will fail.`,
+				Action: runEndpointsGenSecret,
+			},
+		},
+	}
+}
+
+// newAPIClient creates an authenticated API client from stored credentials.
+func newAPIClient() (*clicmd.Client, error) {
+	return loadAPIClient(orgFlag)
+}
+
+// newUserAPIClient creates a client that ignores --org, for calls about the
+// user rather than endpoints, such as managing orgs.
+func newUserAPIClient() (*clicmd.Client, error) {
+	return loadAPIClient("")
+}
+
+// loadAPIClient creates a client from the stored credentials, acting for
+// org if set.
+func loadAPIClient(org string) (*clicmd.Client, error) {
+	credsMgr, err := clicmd.NewCredentialsManager()
+	if err != nil {
+		return nil, fmt.Errorf("init credentials manager: %w", err)
+	}
+
+	creds, err := credsMgr.Load()
+	if err != nil {
+		return nil, fmt.Errorf("load credentials: %w", err)
+	}
+	if creds == nil {
+		return nil, fmt.Errorf("not logged in\n\nRun 'hookly login' to authenticate first")
+	}
+
+	return clicmd.NewOrgClient(creds.EdgeURL, creds.APIToken, org), nil
+}
+
+// endpointSorts maps --sort values to the API sort order.
+var endpointSorts = map[string]hooklyv1.EndpointSort{
+	"newest":        hooklyv1.EndpointSort_ENDPOINT_SORT_UNSPECIFIED,
+	"oldest":        hooklyv1.EndpointSort_ENDPOINT_SORT_CREATED_ASC,
+	"last-received": hooklyv1.EndpointSort_ENDPOINT_SORT_LAST_RECEIVED,
+}
+
+// runEndpointsList handles the endpoints list command.
+func runEndpointsList(c *cli.Context) error {
+	sort, ok := endpointSorts[c.String("sort")]
+	if !ok {
+		return fmt.Errorf("invalid --sort %q: use newest, oldest or last-received", c.String("sort"))
+	}
+	req := &hooklyv1.ListEndpointsRequest{
+		Search:       c.String("search"),
+		Sort:         sort,
+		InactiveDays: int32(c.Int("inactive-days")),
+	}
+	if p := c.String("provider"); p != "" {
+		pt, err := parseProvider(p)
+		if err != nil {
+			return err
+		}
+		req.ProviderType = pt
+	}
+	if c.IsSet("muted") {
+		muted := c.Bool("muted")
+		req.Muted = &muted
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	endpoints, err := clicmd.Spin(context.Background(), "Loading endpoints", func(ctx context.Context) ([]*hooklyv1.Endpoint, error) {
+		var endpoints []*hooklyv1.Endpoint
+		for {
+			resp, err := client.Edge.ListEndpoints(ctx, connect.NewRequest(req))
+			if err != nil {
+				return nil, fmt.Errorf("list endpoints: %w", err)
+			}
+			endpoints = append(endpoints, resp.Msg.Endpoints...)
+			if resp.Msg.Pagination.GetNextPageToken() == "" {
+				return endpoints, nil
+			}
+			req.Pagination = &hooklyv1.PaginationRequest{PageToken: resp.Msg.Pagination.NextPageToken}
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.Bool("json") {
+		return printJSONList(os.Stdout, endpoints)
+	}
+	if len(endpoints) == 0 {
+		fmt.Fprintln(os.Stderr, "No endpoints found.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tPROVIDER\tSTATE\tCREATED\tLAST WEBHOOK")
+	for _, ep := range endpoints {
+		lastReceived := "never"
+		if ep.LastWebhookReceivedAt != nil {
+			lastReceived = ep.LastWebhookReceivedAt.AsTime().Local().Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			ep.Id,
+			ep.Name,
+			providerName(ep.ProviderType),
+			endpointState(ep),
+			tsTime(ep.CreatedAt).Local().Format("2006-01-02 15:04"),
+			lastReceived,
+		)
+	}
+	return tw.Flush()
+}
+
+// parseProvider parses a --provider value.
+func parseProvider(p string) (hooklyv1.ProviderType, error) {
+	pt, ok := hooklyv1.ProviderType_value["PROVIDER_TYPE_"+strings.ToUpper(p)]
+	if !ok || pt == 0 {
+		return 0, fmt.Errorf("invalid --provider %q: use stripe, github, telegram, slack, shopify, square, paypal, generic or custom", p)
+	}
+	return hooklyv1.ProviderType(pt), nil
+}
+
+func providerName(pt hooklyv1.ProviderType) string {
+	return strings.ToLower(strings.TrimPrefix(pt.String(), "PROVIDER_TYPE_"))
+}
+
+// endpointIDArg returns the endpoint ID argument of a subcommand.
+func endpointIDArg(c *cli.Context) (string, error) {
+	id := c.Args().First()
+	if id == "" {
+		return "", fmt.Errorf("endpoint ID is required\n\nUsage: hookly endpoints %s <endpoint-id>", c.Command.Name)
+	}
+	return id, nil
+}
+
+// runEndpointsGet handles the endpoints get command.
+func runEndpointsGet(c *cli.Context) error {
+	id, err := endpointIDArg(c)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Edge.GetEndpoint(context.Background(), connect.NewRequest(&hooklyv1.GetEndpointRequest{Id: id}))
+	if err != nil {
+		return fmt.Errorf("get endpoint: %w", err)
+	}
+	if c.Bool("json") {
+		return printJSON(os.Stdout, resp.Msg)
+	}
+	printEndpoint(os.Stdout, resp.Msg.Endpoint, resp.Msg.WebhookUrl)
+	return nil
+}
+
+// printEndpoint prints an endpoint's settings. webhookURL may be empty.
+func printEndpoint(out io.Writer, ep *hooklyv1.Endpoint, webhookURL string) {
+	formatTime := func(ts *timestamppb.Timestamp, never string) string {
+		if ts == nil {
+			return never
+		}
+		return ts.AsTime().Local().Format("2006-01-02 15:04:05")
+	}
+
+	fmt.Fprintf(out, "%s (%s)\n\n", ep.Name, ep.Id)
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "  Provider:\t%s\n", providerName(ep.ProviderType))
+	fmt.Fprintf(tw, "  State:\t%s\n", endpointState(ep))
+	if webhookURL != "" {
+		fmt.Fprintf(tw, "  Webhook URL:\t%s\n", webhookURL)
+	}
+	if ep.DestinationUrl != "" {
+		fmt.Fprintf(tw, "  Destination:\t%s\n", ep.DestinationUrl)
+	}
+	for _, d := range ep.Destinations {
+		fmt.Fprintf(tw, "  Also to:\t%s\n", d.Url)
+	}
+	if ep.IngestAuth != nil && ep.IngestAuth.Method != hooklyv1.IngestAuthMethod_INGEST_AUTH_METHOD_UNSPECIFIED {
+		fmt.Fprintf(tw, "  Ingestion auth:\t%s\n", strings.ToLower(strings.TrimPrefix(ep.IngestAuth.Method.String(), "INGEST_AUTH_METHOD_")))
+	}
+	if ep.RateLimitPerMinute > 0 {
+		fmt.Fprintf(tw, "  Rate limit:\t%d/min\n", ep.RateLimitPerMinute)
+	}
+	if l := ep.PayloadLimits; l != nil && l.MaxBytes > 0 {
+		fmt.Fprintf(tw, "  Max payload:\t%d bytes\n", l.MaxBytes)
+	}
+	if l := ep.PayloadLimits; l != nil && len(l.ContentTypes) > 0 {
+		fmt.Fprintf(tw, "  Content types:\t%s\n", strings.Join(l.ContentTypes, ", "))
+	}
+	if ep.RejectDuplicates {
+		fmt.Fprintf(tw, "  Duplicates:\trejected\n")
+	}
+	if ep.NotifyFirstEvent {
+		fmt.Fprintf(tw, "  First event:\tnotify\n")
+	}
+	if ep.HomeRegion != "" {
+		fmt.Fprintf(tw, "  Region:\t%s\n", ep.HomeRegion)
+	}
+	fmt.Fprintf(tw, "  Created:\t%s\n", formatTime(ep.CreatedAt, ""))
+	fmt.Fprintf(tw, "  Last webhook:\t%s\n", formatTime(ep.LastWebhookReceivedAt, "never"))
+	fmt.Fprintf(tw, "  Last delivered:\t%s\n", formatTime(ep.LastDeliveredAt, "never"))
+	tw.Flush()
+}
+
+// runEndpointsCreate handles the endpoints create command.
+func runEndpointsCreate(c *cli.Context) error {
+	if c.String("name") == "" || c.String("provider") == "" {
+		return fmt.Errorf("--name and --provider are required")
+	}
+	pt, err := parseProvider(c.String("provider"))
+	if err != nil {
+		return err
+	}
+	if pt == hooklyv1.ProviderType_PROVIDER_TYPE_CUSTOM {
+		return fmt.Errorf("custom endpoints need a verification config: create them in the web UI")
+	}
+	if c.String("destination") == "" && !c.Bool("honeypot") {
+		return fmt.Errorf("--destination is required, unless --honeypot is set")
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Edge.CreateEndpoint(context.Background(), connect.NewRequest(&hooklyv1.CreateEndpointRequest{
+		Name:             c.String("name"),
+		ProviderType:     pt,
+		SignatureSecret:  c.String("secret"),
+		DestinationUrl:   c.String("destination"),
+		NotifyFirstEvent: c.Bool("notify-first-event"),
+		Honeypot:         c.Bool("honeypot"),
+	}))
+	if err != nil {
+		return fmt.Errorf("create endpoint: %w", err)
+	}
+	if c.Bool("json") {
+		return printJSON(os.Stdout, resp.Msg)
+	}
+
+	// The ID goes to stdout alone so it can be captured; the rest to stderr
+	fmt.Println(resp.Msg.Endpoint.Id)
+	fmt.Fprintf(os.Stderr, "\nCreated %s. Configure this webhook URL at the provider:\n  %s\n", resp.Msg.Endpoint.Name, resp.Msg.WebhookUrl)
+	if c.String("secret") == "" && !c.Bool("honeypot") {
+		fmt.Fprintf(os.Stderr, "\nNo signature secret is set: run 'hookly endpoints gen-secret %s' to generate one.\n", resp.Msg.Endpoint.Id)
+	}
+	return nil
+}
+
+// runEndpointsUpdate handles the endpoints update command.
+func runEndpointsUpdate(c *cli.Context) error {
+	id, err := endpointIDArg(c)
+	if err != nil {
+		return err
+	}
+
+	req := &hooklyv1.UpdateEndpointRequest{Id: id}
+	if c.IsSet("name") {
+		req.Name = proto.String(c.String("name"))
+	}
+	if c.IsSet("destination") {
+		req.DestinationUrl = proto.String(c.String("destination"))
+	}
+	if c.IsSet("secret") {
+		req.SignatureSecret = proto.String(c.String("secret"))
+	}
+	if c.IsSet("notify-first-event") {
+		req.NotifyFirstEvent = proto.Bool(c.Bool("notify-first-event"))
+	}
+	if c.IsSet("reject-duplicates") {
+		req.RejectDuplicates = proto.Bool(c.Bool("reject-duplicates"))
+	}
+	if c.IsSet("rate-limit") {
+		req.RateLimitPerMinute = proto.Int32(int32(c.Int("rate-limit")))
+	}
+	setLimits := c.IsSet("max-payload-size") || c.IsSet("content-types")
+	if !setLimits && proto.Equal(req, &hooklyv1.UpdateEndpointRequest{Id: id}) {
+		return fmt.Errorf("nothing to update: pass the settings to change, see 'hookly endpoints update --help'")
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	// Payload limits are replaced as a whole: keep the one not given
+	if setLimits {
+		if req.PayloadLimits, err = updatedPayloadLimits(ctx, client, c, id); err != nil {
+			return err
+		}
+	}
+
+	resp, err := client.Edge.UpdateEndpoint(ctx, connect.NewRequest(req))
+	if err != nil {
+		return fmt.Errorf("update endpoint: %w", err)
+	}
+	if c.Bool("json") {
+		return printJSON(os.Stdout, resp.Msg)
+	}
+	printEndpoint(os.Stdout, resp.Msg.Endpoint, "")
+	return nil
+}
+
+// updatedPayloadLimits returns the endpoint's payload limits with the
+// --max-payload-size and --content-types flags applied.
+func updatedPayloadLimits(ctx context.Context, client *clicmd.Client, c *cli.Context, id string) (*hooklyv1.PayloadLimits, error) {
+	limits := &hooklyv1.PayloadLimits{}
+	if !c.IsSet("max-payload-size") || !c.IsSet("content-types") {
+		resp, err := client.Edge.GetEndpoint(ctx, connect.NewRequest(&hooklyv1.GetEndpointRequest{Id: id}))
+		if err != nil {
+			return nil, fmt.Errorf("get endpoint: %w", err)
+		}
+		if resp.Msg.Endpoint.PayloadLimits != nil {
+			limits = resp.Msg.Endpoint.PayloadLimits
+		}
+	}
+	if c.IsSet("max-payload-size") {
+		limits.MaxBytes = c.Int64("max-payload-size")
+	}
+	if c.IsSet("content-types") {
+		limits.ContentTypes = nil
+		for ct := range strings.SplitSeq(c.String("content-types"), ",") {
+			if ct = strings.TrimSpace(ct); ct != "" {
+				limits.ContentTypes = append(limits.ContentTypes, ct)
+			}
+		}
+	}
+	return limits, nil
+}
+
+// runEndpointsDelete handles the endpoints delete command.
+func runEndpointsDelete(c *cli.Context) error {
+	id, err := endpointIDArg(c)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	if !c.Bool("yes") {
+		if !term.IsTerminal(int(os.Stdin.Fd())) {
+			return fmt.Errorf("refusing to delete without confirmation: pass --yes")
+		}
+		ep, err := client.Edge.GetEndpoint(ctx, connect.NewRequest(&hooklyv1.GetEndpointRequest{Id: id}))
+		if err != nil {
+			return fmt.Errorf("get endpoint: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Delete %s (%s) and all its webhooks? [y/N] ", ep.Msg.Endpoint.Name, id)
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+			fmt.Fprintln(os.Stderr, "Cancelled.")
+			return nil
+		}
+	}
+
+	if _, err := client.Edge.DeleteEndpoint(ctx, connect.NewRequest(&hooklyv1.DeleteEndpointRequest{Id: id})); err != nil {
+		return fmt.Errorf("delete endpoint: %w", err)
+	}
+	fmt.Printf("Deleted endpoint %s.\n", id)
+	return nil
+}
+
+// setEndpointMuted handles the endpoints mute and unmute commands.
+func setEndpointMuted(c *cli.Context, muted bool) error {
+	id, err := endpointIDArg(c)
+	if err != nil {
+		return err
+	}
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Edge.UpdateEndpoint(context.Background(), connect.NewRequest(&hooklyv1.UpdateEndpointRequest{
+		Id:    id,
+		Muted: proto.Bool(muted),
+	})); err != nil {
+		return fmt.Errorf("%s endpoint: %w", c.Command.Name, err)
+	}
+	if muted {
+		fmt.Printf("Muted endpoint %s: webhooks are answered and discarded.\n", id)
+	} else {
+		fmt.Printf("Unmuted endpoint %s.\n", id)
+	}
+	return nil
+}
+
+// printJSON prints a message as indented JSON.
+func printJSON(out io.Writer, m proto.Message) error {
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "  "}.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
+
+// printJSONList prints messages as an indented JSON array, [] if empty.
+func printJSONList[M proto.Message](out io.Writer, msgs []M) error {
+	items := make([]json.RawMessage, len(msgs))
+	for i, m := range msgs {
+		data, err := protojson.Marshal(m)
+		if err != nil {
+			return err
+		}
+		items[i] = data
+	}
+	data, err := json.MarshalIndent(items, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(data))
+	return err
+}
+
+// endpointState returns a short label for an endpoint's state.
+func endpointState(ep *hooklyv1.Endpoint) string {
+	switch {
+	case ep.ArchivedAt != nil:
+		return "archived"
+	case ep.Muted:
+		return "muted"
+	case ep.Honeypot:
+		return "honeypot"
+	case ep.FirstEventAt == nil:
+		return "waiting"
+	default:
+		return "active"
+	}
+}
+
+// runEndpointsInstructions handles the endpoints instructions command.
+func runEndpointsInstructions(c *cli.Context) error {
+	endpointID := c.Args().First()
+	if endpointID == "" {
+		return fmt.Errorf("endpoint ID is required\n\nUsage: hookly endpoints instructions <endpoint-id>")
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Edge.GetSetupInstructions(context.Background(), connect.NewRequest(&hooklyv1.GetSetupInstructionsRequest{
+		EndpointId: endpointID,
+	}))
+	if err != nil {
+		return fmt.Errorf("get setup instructions: %w", err)
+	}
+
+	fmt.Print(resp.Msg.Instructions)
+	return nil
+}
+
+// runEndpointsGenSecret handles the endpoints gen-secret command.
+func runEndpointsGenSecret(c *cli.Context) error {
+	endpointID := c.Args().First()
+	if endpointID == "" {
+		return fmt.Errorf("endpoint ID is required\n\nUsage: hookly endpoints gen-secret <endpoint-id>")
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Edge.GenerateEndpointSecret(context.Background(), connect.NewRequest(&hooklyv1.GenerateEndpointSecretRequest{
+		EndpointId: endpointID,
+	}))
+	if err != nil {
+		return fmt.Errorf("generate secret: %w", err)
+	}
+
+	// The secret goes to stdout alone so it can be piped; the reminder to stderr
+	fmt.Println(resp.Msg.Secret)
+	fmt.Fprintln(os.Stderr, "\nThis secret is shown only once. Update it in your provider's webhook settings now.")
+	return nil
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"hooks.dx314.com/internal/db"
+	"hooks.dx314.com/internal/listen"
+)
+
+// listenCommand returns the listen command.
+func listenCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "listen",
+		Usage: "Receive webhooks locally and forward them, without an edge server",
+		Description: `Starts a local HTTP server that stands in for the edge: webhooks posted
+to http://localhost:<port>/h/<provider> are verified, recorded in a local
+SQLite database and forwarded to --forward, retried after a backoff like
+on the edge. No login or hookly.yaml is needed.
+
+Verifying signatures needs --provider and the provider's --secret.
+Webhooks are kept in --db between runs; use 'sqlite3 hookly-listen.db'
+to inspect them. Stop with Ctrl-C.`,
+		Action: runListen,
+		Flags: []cli.Flag{
+			&cli.IntFlag{
+				Name:  "port",
+				Usage: "Port to receive webhooks on",
+				Value: 9000,
+			},
+			&cli.StringFlag{
+				Name:     "forward",
+				Usage:    "Destination `URL` to forward webhooks to, e.g. http://localhost:3000/webhooks",
+				Required: true,
+			},
+			&cli.StringFlag{
+				Name:  "provider",
+				Usage: "Provider to verify signatures and detect event types for (stripe, github, telegram, slack, shopify, square, paypal, generic)",
+				Value: "generic",
+			},
+			&cli.StringFlag{
+				Name:    "secret",
+				Usage:   "Signature secret of the provider (webhooks aren't verified without one)",
+				EnvVars: []string{"HOOKLY_LISTEN_SECRET"},
+			},
+			&cli.StringFlag{
+				Name:  "db",
+				Usage: "SQLite database to record webhooks in",
+				Value: "hookly-listen.db",
+			},
+		},
+	}
+}
+
+// runListen handles the listen command.
+func runListen(c *cli.Context) error {
+	closeLog, err := setupLogger(c.Bool("debug"), logFileOptions{}, 0, nil)
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	conn, err := db.Open(ctx, c.String("db"))
+	if err != nil {
+		return fmt.Errorf("open database: %w", err)
+	}
+	defer conn.Close()
+
+	l, err := listen.New(ctx, db.New(conn), listen.Options{
+		Destination:  c.String("forward"),
+		ProviderType: c.String("provider"),
+		Secret:       c.String("secret"),
+	})
+	if err != nil {
+		return err
+	}
+
+	// Listen first, so a port in use is reported before anything is printed
+	port := strconv.Itoa(c.Int("port"))
+	ln, err := net.Listen("tcp", ":"+port)
+	if err != nil {
+		return fmt.Errorf("listen on port %s: %w", port, err)
+	}
+	srv := &http.Server{Handler: l.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+
+	fmt.Printf("Receiving webhooks at http://localhost:%s%s\n", port, l.Path())
+	fmt.Printf("Forwarding to %s\n", c.String("forward"))
+	if c.String("secret") == "" {
+		fmt.Println("Signatures are not verified (no --secret)")
+	}
+	fmt.Printf("Recording in %s\n\n", c.String("db"))
+
+	go l.Run(ctx)
+
+	select {
+	case err := <-errCh:
+		return fmt.Errorf("serve: %w", err)
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+	return nil
+}
@@ -10,7 +10,8 @@ import (
 	"sync"
 	"time"
 
-	"golang.org/x/term"
+	clicmd "hooks.dx314.com/internal/cli"
+	"hooks.dx314.com/internal/logging"
 )
 
 // Pretty logger colors and symbols
@@ -18,14 +19,22 @@ const (
 	colorRed = "\033[31m"
 )
 
-const (
-	symbolSuccess = "✓"
-	symbolError   = "✗"
-	symbolArrow   = "→"
-	symbolInfo    = "•"
-	symbolWarn    = "⚠"
+// Output symbols, as Unicode and ASCII (see clicmd.Symbol)
+var (
+	symbolSuccess = [2]string{"✓", "ok"}
+	symbolError   = [2]string{"✗", "x"}
+	symbolArrow   = [2]string{"→", "->"}
+	symbolInfo    = [2]string{"•", "*"}
+	symbolWarn    = [2]string{"⚠", "!"}
+	symbolIn      = [2]string{"←", "<-"}
+	symbolMore    = [2]string{"…", "..."}
 )
 
+// sym returns a symbol as Unicode, or ASCII with --ascii.
+func sym(s [2]string) string {
+	return clicmd.Symbol(s[0], s[1])
+}
+
 // prettyHandler is a custom slog.Handler for human-friendly CLI output.
 type prettyHandler struct {
 	level    slog.Leveler
@@ -38,16 +47,10 @@ type prettyHandler struct {
 
 // newPrettyHandler creates a new pretty handler.
 func newPrettyHandler(out io.Writer, level slog.Leveler) *prettyHandler {
-	// Detect if stdout is a TTY for color support
-	useColor := false
-	if f, ok := out.(*os.File); ok {
-		useColor = term.IsTerminal(int(f.Fd()))
-	}
-
 	return &prettyHandler{
 		level:    level,
 		out:      out,
-		useColor: useColor,
+		useColor: clicmd.UseColor(out),
 	}
 }
 
@@ -133,13 +136,13 @@ func (h *prettyHandler) Handle(_ context.Context, r slog.Record) error {
 func (h *prettyHandler) getSymbolAndColor(level slog.Level) (string, string) {
 	switch {
 	case level >= slog.LevelError:
-		return symbolError, colorRed
+		return sym(symbolError), colorRed
 	case level >= slog.LevelWarn:
-		return symbolWarn, colorYellow
+		return sym(symbolWarn), colorYellow
 	case level >= slog.LevelInfo:
-		return symbolInfo, colorGreen
+		return sym(symbolInfo), colorGreen
 	default: // Debug
-		return symbolArrow, colorDim
+		return sym(symbolArrow), colorDim
 	}
 }
 
@@ -171,24 +174,70 @@ func (h *prettyHandler) WithGroup(name string) slog.Handler {
 	}
 }
 
-// setupLogger configures the global logger based on debug mode.
-func setupLogger(debug bool) {
+// logFileOptions configures the relay's log file.
+type logFileOptions struct {
+	path       string // No log file if empty
+	maxSizeMB  int    // Rotate at this size (0 disables rotation)
+	maxBackups int    // Rotated files to keep
+	tee        bool   // Also log to stdout
+}
+
+// setupLogger configures the global logger based on debug mode. With a log
+// file, records are written to it as JSON instead of to stdout, or as well as
+// to stdout with tee. Records are also kept in recent, if set, for the edge
+// to fetch. Unless debugging, per-webhook records are summarized over sample
+// (see logging.Sampler). The returned function closes the file.
+func setupLogger(debug bool, logFile logFileOptions, sample time.Duration, recent *logging.Recent) (func(), error) {
+	level := slog.LevelInfo
+	if debug {
+		level = slog.LevelDebug
+	}
+
+	var stdout slog.Handler
 	if debug {
 		// Debug mode: JSON output with full details
-		slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
+		stdout = slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{
 			Level:     slog.LevelDebug,
 			AddSource: true,
-		})))
+		})
 	} else {
 		// Normal mode: pretty human-readable output
-		slog.SetDefault(slog.New(newPrettyHandler(os.Stdout, slog.LevelInfo)))
+		stdout = newPrettyHandler(os.Stdout, slog.LevelInfo)
+	}
+
+	keep := func(h slog.Handler) slog.Handler {
+		if recent == nil {
+			return h
+		}
+		return logging.Fanout(h, recent.Handler(level))
+	}
+
+	if logFile.path == "" {
+		sampler := logging.NewSampler(keep(stdout), sample)
+		slog.SetDefault(slog.New(sampler))
+		return sampler.Close, nil
+	}
+
+	f, err := logging.OpenFile(logFile.path, logFile.maxSizeMB, logFile.maxBackups)
+	if err != nil {
+		return nil, err
+	}
+	handler := slog.Handler(slog.NewJSONHandler(f, &slog.HandlerOptions{Level: level, AddSource: debug}))
+	if logFile.tee {
+		handler = logging.Fanout(stdout, handler)
 	}
+	sampler := logging.NewSampler(keep(handler), sample)
+	slog.SetDefault(slog.New(sampler))
+	return func() {
+		sampler.Close()
+		f.Close()
+	}, nil
 }
 
 // formatDuration formats a duration for display.
 func formatDuration(d time.Duration) string {
 	if d < time.Millisecond {
-		return fmt.Sprintf("%dµs", d.Microseconds())
+		return fmt.Sprintf("%d%ss", d.Microseconds(), clicmd.Symbol("µ", "u"))
 	}
 	if d < time.Second {
 		return fmt.Sprintf("%dms", d.Milliseconds())
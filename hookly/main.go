@@ -8,20 +8,35 @@ import (
 	"log/slog"
 	"os"
 	"os/signal"
+	"runtime"
 	"strings"
 	"syscall"
 	"text/template"
 	"time"
 
+	"connectrpc.com/connect"
 	"github.com/urfave/cli/v2"
 
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/buildinfo"
 	clicmd "hooks.dx314.com/internal/cli"
 	"hooks.dx314.com/internal/config"
+	"hooks.dx314.com/internal/errreport"
+	"hooks.dx314.com/internal/exitcode"
+	"hooks.dx314.com/internal/logging"
+	"hooks.dx314.com/internal/region"
 	"hooks.dx314.com/internal/relay"
 	svc "hooks.dx314.com/internal/service"
+	"hooks.dx314.com/internal/telemetry"
+	"hooks.dx314.com/internal/tracing"
+)
+
+// build identifies this binary; version is reported with errors and traces.
+var (
+	build   = buildinfo.Get()
+	version = build.Version
 )
 
-const version = "0.1.0"
 const defaultEdgeURL = "https://hooks.dx314.com"
 
 // ANSI color codes
@@ -49,13 +64,30 @@ var appHelpTemplate = `{{ cyan .Name | bold }} {{ dim .Version }}
     {{ green "logout" }}    Clear stored credentials
     {{ green "whoami" }}    Show current user
     {{ green "status" }}    Show connection and config status
+    {{ green "token" }}     Manage API tokens
+              {{ branch }} list, create, rotate, revoke
+    {{ green "org" }}       Manage organizations and members
+              {{ branch }} list, create, delete, members, add, remove
 
   {{ bold "Setup" }}
     {{ green "init" }}      Create hookly.yaml interactively
+    {{ green "endpoints" }} Setup instructions and signature secrets
+              {{ branch }} instructions, gen-secret
+    {{ green "telemetry" }} Anonymous usage reports, off unless turned on
+              {{ branch }} on, off, status
+
+  {{ bold "Inspection" }}
+    {{ green "webhooks" }}  Inspect received webhooks
+              {{ branch }} show
+    {{ green "audit" }}     Show the audit log of management actions
+
+  {{ bold "Local Development" }}
+    {{ green "listen" }}    Receive and forward webhooks without an edge server
 
   {{ bold "Service Management" }}
     {{ green "service" }}   Install/manage as system service
-              └─ install, uninstall, start, stop, restart, status, logs
+              {{ branch }} install, uninstall, start, stop, restart, status, logs, repair
+    {{ green "verify-binary" }} Verify the signature of a release binary
 
 {{ bold "QUICK START" }}
     {{ dim "$" }} hookly login                    {{ dim "# authenticate with GitHub" }}
@@ -70,15 +102,22 @@ var appHelpTemplate = `{{ cyan .Name | bold }} {{ dim .Version }}
     {{ dim "$" }} hookly service install --config ./hookly.yaml
     {{ dim "$" }} hookly service start
 
+    {{ dim "# Forward webhooks from localhost:9000 without an edge server" }}
+    {{ dim "$" }} hookly listen --port 9000 --forward http://localhost:3000/webhooks
+
     {{ dim "# Connect to a custom edge server" }}
     {{ dim "$" }} hookly login --edge-url https://hooks.example.com
 
 {{ bold "FILES" }}
     {{ dim "~/.config/hookly/credentials.json" }}    Encrypted auth credentials
+    {{ dim "~/.config/hookly/telemetry.json" }}      Telemetry setting and pending counts
     {{ dim "./hookly.yaml" }}                        Endpoint configuration
 
 {{ bold "GLOBAL OPTIONS" }}
     {{ green "--debug" }}         Enable debug logging (JSON output)
+    {{ green "--log-file" }}      Write JSON logs to a size-rotated file ({{ green "--log-tee" }} to keep stdout)
+    {{ green "--color" }}         Color output: auto, always or never ({{ green "--no-color" }}, {{ dim "HOOKLY_COLOR" }}, {{ dim "NO_COLOR" }})
+    {{ green "--ascii" }}         Use ASCII instead of Unicode symbols ({{ dim "HOOKLY_ASCII" }})
     {{ green "--help, -h" }}      Show help
     {{ green "--version, -v" }}   Print version ({{ .Version }})
 
@@ -117,15 +156,63 @@ var subcommandHelpTemplate = `{{ cyan .FullName | bold }}
     {{ green (flagNames .) }}	{{ .Usage }}{{ if (flagDefault .) }} {{ dim (flagDefault .) }}{{ end }}{{ end }}
 {{ end }}`
 
-// Template functions for colorized output
-var templateFuncs = template.FuncMap{
-	"cyan":        func(s string) string { return colorCyan + s + colorReset },
-	"green":       func(s string) string { return colorGreen + s + colorReset },
-	"yellow":      func(s string) string { return colorYellow + s + colorReset },
-	"bold":        func(s string) string { return colorBold + s + colorReset },
-	"dim":         func(s string) string { return colorDim + s + colorReset },
-	"flagNames":   flagNames,
-	"flagDefault": flagDefault,
+// helpFuncs returns the template functions for help written to out,
+// colorized if out is.
+func helpFuncs(out io.Writer) template.FuncMap {
+	useColor := clicmd.UseColor(out)
+	paint := func(color string) func(string) string {
+		return func(s string) string {
+			if !useColor {
+				return s
+			}
+			return color + s + colorReset
+		}
+	}
+	return template.FuncMap{
+		"cyan":        paint(colorCyan),
+		"green":       paint(colorGreen),
+		"yellow":      paint(colorYellow),
+		"bold":        paint(colorBold),
+		"dim":         paint(colorDim),
+		"branch":      func() string { return clicmd.Symbol("└─", "`-") },
+		"flagNames":   flagNames,
+		"flagDefault": flagDefault,
+	}
+}
+
+// Output style flags. They are read through their destinations because
+// help is printed before the app's Before runs.
+var (
+	colorFlag   string
+	noColorFlag bool
+	asciiFlag   bool
+)
+
+// orgFlag is the org, set with --org, that API commands act for.
+var orgFlag string
+
+// applyStyle applies --color, --no-color and --ascii.
+func applyStyle() error {
+	mode, err := clicmd.ParseColorMode(colorFlag)
+	if err != nil {
+		return err
+	}
+	if noColorFlag {
+		mode = clicmd.ColorNever
+	}
+	clicmd.SetColorMode(mode)
+	clicmd.SetASCII(asciiFlag)
+	return nil
+}
+
+// beforeCommand applies the output style flags and sends a usage report if
+// one is due.
+func beforeCommand(c *cli.Context) error {
+	if err := applyStyle(); err != nil {
+		return err
+	}
+	startTelemetry(c)
+	return nil
 }
 
 func init() {
@@ -135,7 +222,8 @@ func init() {
 
 	// Custom help printer with our template functions
 	cli.HelpPrinter = func(out io.Writer, templ string, data interface{}) {
-		t := template.Must(template.New("help").Funcs(templateFuncs).Parse(templ))
+		_ = applyStyle() // An invalid --color is reported when a command runs
+		t := template.Must(template.New("help").Funcs(helpFuncs(out)).Parse(templ))
 		_ = t.Execute(out, data)
 	}
 }
@@ -181,9 +269,9 @@ func main() {
 	// Check if running in service mode (invoked by service manager)
 	if isServiceMode() {
 		configPath := getServiceConfigPath()
-		if err := svc.RunServiceMode(configPath); err != nil {
+		if err := svc.RunServiceMode(configPath, "hookly@"+version); err != nil {
 			fmt.Fprintf(os.Stderr, "Service error: %v\n", err)
-			os.Exit(1)
+			os.Exit(exitcode.Code(err))
 		}
 		return
 	}
@@ -196,14 +284,78 @@ func main() {
 	app := &cli.App{
 		Name:                 "hookly",
 		Usage:                "Relay webhooks from the public internet to your local network",
-		Version:              version,
+		Version:              build.String(),
 		Action:               runRelay,
+		Before:               beforeCommand,
 		EnableBashCompletion: true,
+		// --env values may contain commas
+		DisableSliceFlagSeparator: true,
 		Flags: []cli.Flag{
 			&cli.BoolFlag{
 				Name:  "debug",
 				Usage: "Enable debug logging with full structured JSON output",
 			},
+			&cli.StringFlag{
+				Name:  "log-file",
+				Usage: "Write JSON logs to this file instead of stdout, rotating by size",
+			},
+			&cli.IntFlag{
+				Name:  "log-max-size",
+				Usage: "Rotate the log file at this size in MB (0 disables rotation)",
+				Value: 10,
+			},
+			&cli.IntFlag{
+				Name:  "log-max-files",
+				Usage: "Rotated log files to keep",
+				Value: 5,
+			},
+			&cli.BoolFlag{
+				Name:  "log-tee",
+				Usage: "With --log-file, also log to stdout",
+			},
+			&cli.DurationFlag{
+				Name:  "log-sample-interval",
+				Usage: "Log the first webhook per endpoint in this interval and a count of the rest (0 logs every webhook; --debug logs all in full)",
+				Value: time.Minute,
+			},
+			&cli.StringFlag{
+				Name:  "metrics-addr",
+				Usage: "Serve OpenMetrics on this address at /metrics (overrides metrics_addr in hookly.yaml)",
+			},
+			&cli.StringFlag{
+				Name:    "sentry-dsn",
+				Usage:   "Report crashes and errors to this Sentry DSN (overrides sentry_dsn in hookly.yaml)",
+				EnvVars: []string{"SENTRY_DSN"},
+			},
+			&cli.StringFlag{
+				Name:        "org",
+				Usage:       "Act on the endpoints of organization `ORG_ID` instead of your own",
+				EnvVars:     []string{"HOOKLY_ORG"},
+				Destination: &orgFlag,
+			},
+			&cli.StringFlag{
+				Name:        "color",
+				Usage:       "When to color output: auto, always or never (auto respects NO_COLOR)",
+				Value:       string(clicmd.ColorAuto),
+				EnvVars:     []string{"HOOKLY_COLOR"},
+				Destination: &colorFlag,
+			},
+			&cli.BoolFlag{
+				Name:        "no-color",
+				Usage:       "Never color output, like --color never",
+				Destination: &noColorFlag,
+			},
+			&cli.BoolFlag{
+				Name:        "ascii",
+				Usage:       "Use ASCII instead of Unicode symbols, for terminals that mangle them",
+				EnvVars:     []string{"HOOKLY_ASCII"},
+				Destination: &asciiFlag,
+			},
+			&cli.StringFlag{
+				Name:   "chaos",
+				Usage:  "Inject delivery faults for testing, e.g. fail=0.1,nack=0.02,delay=0.2,max_delay=5s",
+				Hidden: true,
+			},
 		},
 		Commands: []*cli.Command{
 			{
@@ -228,14 +380,26 @@ func main() {
 			{
 				Name:        "whoami",
 				Usage:       "Show current authenticated user",
-				Description: "Displays your username and the edge server you're connected to.",
+				Description: "Displays your username and the edge server you're connected to.\nWith --verbose, asks the edge for your GitHub profile and the name,\ncreation date and last use of this machine's API token.",
 				Action:      runWhoami,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "verbose",
+						Usage: "Show profile and token details from the edge",
+					},
+				},
 			},
 			{
 				Name:        "status",
 				Usage:       "Show current user, edge URL, and connection status",
-				Description: "Displays authentication status, configuration details,\nand the number of configured endpoints.",
-				Action:      runStatus,
+				Description: "Displays authentication status, configuration details,\nthe number of configured endpoints, and this CLI's version next to\nthe edge's, noting when a newer release is available. With --remote,\nalso asks the edge which hubs are connected and how many webhooks\nare queued.",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "remote",
+						Usage: "Also show connected hubs and queued webhooks from the edge",
+					},
+				},
+				Action: runStatus,
 			},
 			{
 				Name:        "init",
@@ -243,20 +407,49 @@ func main() {
 				Description: "Interactively creates a hookly.yaml config file.\nIf logged in, lets you select from your existing endpoints\nor create a new one.",
 				Action:      runInit,
 			},
+			endpointsCommand(),
+			webhooksCommand(),
+			tokenCommand(),
+			orgCommand(),
+			auditCommand(),
+			tailCommand(),
+			listenCommand(),
 			serviceCommand(),
+			verifyBinaryCommand(),
+			telemetryCommand(),
 		},
 	}
 
-	if err := app.Run(os.Args); err != nil {
+	err := app.Run(os.Args)
+	finishTelemetry(err)
+	if err != nil {
+		// Ctrl-C during a slow call exits like the shell would
+		if errors.Is(err, clicmd.ErrInterrupted) {
+			fmt.Fprintln(os.Stderr, "Interrupted.")
+			os.Exit(exitcode.Interrupted)
+		}
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+		os.Exit(exitcode.Code(err))
 	}
 }
 
 // runRelay is the default action - starts the relay client.
 func runRelay(c *cli.Context) error {
-	// Setup logger based on debug flag
-	setupLogger(c.Bool("debug"))
+	// Setup logger based on debug and log file flags
+	recentLogs := logging.NewRecent(relay.MaxLogLines)
+	closeLog, err := setupLogger(c.Bool("debug"), logFileOptions{
+		path:       c.String("log-file"),
+		maxSizeMB:  c.Int("log-max-size"),
+		maxBackups: c.Int("log-max-files"),
+		tee:        c.Bool("log-tee"),
+	}, c.Duration("log-sample-interval"), recentLogs)
+	if err != nil {
+		return err
+	}
+	defer closeLog()
+	if path := c.String("log-file"); path != "" && !c.Bool("log-tee") {
+		fmt.Printf("Logging to %s\n", path)
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -267,36 +460,79 @@ func runRelay(c *cli.Context) error {
 		return fmt.Errorf("init credentials manager: %w", err)
 	}
 
-	creds, err := credsMgr.Load()
+	// Load config from hookly.yaml
+	cfg, err := config.LoadHooklyYAML("hookly.yaml")
+	if err != nil {
+		return exitcode.With(exitcode.Config, fmt.Errorf("load config: %w\n\nRun 'hookly init' to create a hookly.yaml file", err))
+	}
+
+	recordTelemetry(func(s *telemetry.Store) error { return s.RecordEndpoints(len(cfg.Endpoints)) })
+
+	// Load the credentials issued by the configured edge
+	creds, err := credsMgr.LoadForEdge(cfg.EdgeURL)
 	if err != nil {
 		return fmt.Errorf("load credentials: %w", err)
 	}
 
 	if creds == nil {
-		return fmt.Errorf("not logged in\n\nRun 'hookly login' to authenticate first")
+		defaultCreds, err := credsMgr.Load()
+		if err != nil {
+			return fmt.Errorf("load credentials: %w", err)
+		}
+		if defaultCreds == nil {
+			return exitcode.With(exitcode.Auth, fmt.Errorf("not logged in\n\nRun 'hookly login' to authenticate first"))
+		}
+		return exitcode.With(exitcode.Auth, edgeMismatchError(clicmd.CheckEdgeURL(defaultCreds, cfg.EdgeURL)))
 	}
 
-	// Load config from hookly.yaml
-	cfg, err := config.LoadHooklyYAML("hookly.yaml")
-	if err != nil {
-		return fmt.Errorf("load config: %w\n\nRun 'hookly init' to create a hookly.yaml file", err)
+	// Inject token from credentials, and connect to the nearest region
+	edgeURL := cfg.EdgeURL
+	cfg.Token = creds.APIToken
+	cfg.EdgeURL = creds.ConnectURL()
+	if addr := c.String("metrics-addr"); addr != "" {
+		cfg.MetricsAddr = addr
+	}
+	if dsn := c.String("sentry-dsn"); dsn != "" {
+		cfg.SentryDSN = dsn
 	}
 
-	// Inject token from credentials
-	cfg.Token = creds.APIToken
+	reporter := errreport.Install(cfg.SentryDSN, errreport.Options{Release: "hookly@" + version})
+	defer reporter.Close(5 * time.Second)
+	defer reporter.Recover()
+
+	// Trace deliveries if an OpenTelemetry collector is configured
+	tracer := tracing.FromEnv(os.Getenv, "hookly-hub", version)
+	defer tracer.Close(5 * time.Second)
 
 	slog.Info("hookly starting",
 		"edge_url", cfg.EdgeURL,
+		"region", creds.Region,
 		"hub_id", cfg.GetHubID(),
 		"endpoints", len(cfg.Endpoints),
 	)
 
 	// Create relay client
 	client := relay.NewClient(cfg)
+	client.OnStateChange(reportStateChange)
+	client.SetReloader(func() (*config.HooklyConfig, error) {
+		return config.LoadHooklyYAML("hookly.yaml")
+	})
+	client.SetRecentLogs(recentLogs)
+	client.SetTracer(tracer)
+
+	if spec := c.String("chaos"); spec != "" {
+		chaos, err := relay.ParseChaos(spec)
+		if err != nil {
+			return exitcode.With(exitcode.Config, err)
+		}
+		slog.Warn("chaos mode enabled, deliveries will be delayed and failed on purpose", "chaos", chaos.String())
+		client.SetChaos(chaos)
+	}
 
 	// Run client in goroutine
 	errCh := make(chan error, 1)
 	go func() {
+		defer reporter.Recover()
 		errCh <- client.Run(ctx)
 	}()
 
@@ -307,7 +543,7 @@ func runRelay(c *cli.Context) error {
 	select {
 	case err := <-errCh:
 		if err != nil && err != context.Canceled {
-			return handleRelayError(err, credsMgr)
+			return handleRelayError(err, credsMgr, edgeURL)
 		}
 	case sig := <-sigCh:
 		slog.Info("received shutdown signal", "signal", sig)
@@ -319,8 +555,41 @@ func runRelay(c *cli.Context) error {
 	return nil
 }
 
+// reportStateChange shows reconnect countdowns so long backoffs aren't silent.
+func reportStateChange(ev relay.StateEvent) {
+	if !ev.Countdown() {
+		return
+	}
+	secs := int(ev.RetryIn.Round(time.Second).Seconds())
+	if secs <= 0 || secs%10 != 0 {
+		return
+	}
+	var maintenance *relay.MaintenanceError
+	if errors.As(ev.Err, &maintenance) {
+		slog.Info(fmt.Sprintf("edge maintenance, reconnecting in %ds", secs))
+		return
+	}
+	slog.Info(fmt.Sprintf("next retry in %ds", secs))
+}
+
+// edgeMismatchError explains how to fix credentials issued by a different edge.
+func edgeMismatchError(err error) error {
+	var mismatch *clicmd.EdgeMismatchError
+	if !errors.As(err, &mismatch) {
+		return err
+	}
+
+	return fmt.Errorf("%w\n\n"+
+		"Your credentials were issued by %s, but hookly.yaml points at %s.\n"+
+		"Either log in to the configured edge:\n\n"+
+		"  hookly login --edge-url %s\n\n"+
+		"or update edge_url in hookly.yaml to %s",
+		err, mismatch.CredentialsEdgeURL, mismatch.ConfigEdgeURL,
+		mismatch.ConfigEdgeURL, mismatch.CredentialsEdgeURL)
+}
+
 // handleRelayError handles errors from the relay client and takes appropriate action.
-func handleRelayError(err error, credsMgr *clicmd.CredentialsManager) error {
+func handleRelayError(err error, credsMgr *clicmd.CredentialsManager, edgeURL string) error {
 	// Token errors - clear credentials and prompt re-login
 	if errors.Is(err, relay.ErrTokenInvalid) || errors.Is(err, relay.ErrTokenRevoked) {
 		fmt.Fprintln(os.Stderr)
@@ -328,7 +597,7 @@ func handleRelayError(err error, credsMgr *clicmd.CredentialsManager) error {
 		fmt.Fprintln(os.Stderr)
 
 		// Clear the invalid credentials
-		if delErr := credsMgr.Delete(); delErr != nil {
+		if delErr := credsMgr.DeleteForEdge(edgeURL); delErr != nil {
 			slog.Warn("failed to clear credentials", "error", delErr)
 		} else {
 			fmt.Fprintln(os.Stderr, "Credentials have been cleared.")
@@ -339,6 +608,14 @@ func handleRelayError(err error, credsMgr *clicmd.CredentialsManager) error {
 		return err
 	}
 
+	// Disconnected on purpose from the web UI
+	if errors.Is(err, relay.ErrDisconnectedByEdge) {
+		fmt.Fprintln(os.Stderr)
+		fmt.Fprintln(os.Stderr, "Disconnected from the edge by a command from the web UI.")
+		fmt.Fprintln(os.Stderr, "Run 'hookly' again to reconnect.")
+		return nil
+	}
+
 	// Endpoint not found - suggest reconfiguring
 	if errors.Is(err, relay.ErrEndpointNotFound) {
 		fmt.Fprintln(os.Stderr)
@@ -390,14 +667,16 @@ func runLogin(c *cli.Context) error {
 		return fmt.Errorf("load credentials: %w", err)
 	}
 
-	if existing != nil {
+	if existing != nil && clicmd.SameEdge(existing.EdgeURL, edgeURL) {
 		fmt.Printf("Already logged in as %s (%s)\n", existing.Username, existing.EdgeURL)
 		fmt.Print("Log out first with 'hookly logout' to switch accounts.\n")
 		return nil
 	}
 
 	// Perform OAuth login
-	result, err := clicmd.Login(c.Context, edgeURL)
+	result, err := clicmd.Spin(c.Context, "Waiting for login in the browser", func(ctx context.Context) (*clicmd.LoginResult, error) {
+		return clicmd.Login(ctx, edgeURL)
+	})
 	if err != nil {
 		return fmt.Errorf("login failed: %w", err)
 	}
@@ -410,6 +689,18 @@ func runLogin(c *cli.Context) error {
 		Username:  result.Username,
 		CreatedAt: time.Now(),
 	}
+	pickRegion(c.Context, creds)
+
+	// Keep the default credentials when logging in to an additional edge
+	if existing != nil {
+		if err := credsMgr.SaveForEdge(creds); err != nil {
+			return fmt.Errorf("save credentials: %w", err)
+		}
+
+		fmt.Printf("\nLogged in as %s (%s)\n", result.Username, edgeURL)
+		fmt.Printf("Default credentials for %s were kept.\n", existing.EdgeURL)
+		return nil
+	}
 
 	if err := credsMgr.Save(creds); err != nil {
 		return fmt.Errorf("save credentials: %w", err)
@@ -420,6 +711,27 @@ func runLogin(c *cli.Context) error {
 	return nil
 }
 
+// pickRegion records the nearest region of a multi-region service in creds.
+// Failing to check regions isn't fatal: the hub then connects to EdgeURL.
+func pickRegion(ctx context.Context, creds *clicmd.Credentials) {
+	var ok bool
+	nearest, err := clicmd.Spin(ctx, "Finding the nearest region", func(ctx context.Context) (region.Status, error) {
+		nearest, found, err := clicmd.NearestRegion(ctx, clicmd.NewClient(creds.EdgeURL, creds.APIToken))
+		ok = found
+		return nearest, err
+	})
+	if err != nil {
+		fmt.Printf("Could not pick the nearest region, using %s: %v\n", creds.EdgeURL, err)
+		return
+	}
+	if !ok {
+		return
+	}
+	creds.Region = nearest.Name
+	creds.RegionURL = nearest.URL
+	fmt.Printf("Nearest region: %s (%s, %dms)\n", nearest.Name, nearest.URL, nearest.Latency.Milliseconds())
+}
+
 // runLogout handles the logout command.
 func runLogout(c *cli.Context) error {
 	credsMgr, err := clicmd.NewCredentialsManager()
@@ -470,6 +782,45 @@ func runWhoami(c *cli.Context) error {
 	}
 
 	fmt.Printf("%s (%s)\n", creds.Username, creds.EdgeURL)
+	if !c.Bool("verbose") {
+		return nil
+	}
+
+	client := clicmd.NewClient(creds.EdgeURL, creds.APIToken)
+	resp, err := clicmd.Spin(context.Background(), "Loading account", func(ctx context.Context) (*connect.Response[hooklyv1.GetCurrentUserResponse], error) {
+		return client.Edge.GetCurrentUser(ctx, connect.NewRequest(&hooklyv1.GetCurrentUserRequest{}))
+	})
+	if err != nil {
+		return fmt.Errorf("get current user: %w", err)
+	}
+
+	user := resp.Msg.User
+	fmt.Println()
+	fmt.Printf("User ID:    %s\n", user.UserId)
+	if user.GithubName != "" {
+		fmt.Printf("Name:       %s\n", user.GithubName)
+	}
+	if user.GithubProfileUrl != "" {
+		fmt.Printf("Profile:    %s\n", user.GithubProfileUrl)
+	}
+	if user.AvatarUrl != "" {
+		fmt.Printf("Avatar:     %s\n", user.AvatarUrl)
+	}
+	if user.IsSuperuser {
+		fmt.Println("Superuser:  yes")
+	}
+
+	if token := resp.Msg.Token; token != nil {
+		lastUsed := "never"
+		if token.LastUsedAt != nil {
+			lastUsed = tsTime(token.LastUsedAt).Local().Format("2006-01-02 15:04:05")
+		}
+		fmt.Println()
+		fmt.Printf("Token:      %s\n", token.Name)
+		fmt.Printf("Token ID:   %s\n", token.Id)
+		fmt.Printf("Created:    %s\n", tsTime(token.CreatedAt).Local().Format("2006-01-02 15:04:05"))
+		fmt.Printf("Last used:  %s\n", lastUsed)
+	}
 	return nil
 }
 
@@ -495,6 +846,9 @@ func runStatus(c *cli.Context) error {
 		fmt.Printf("Logged in: Yes\n")
 		fmt.Printf("User:      %s\n", creds.Username)
 		fmt.Printf("Edge URL:  %s\n", creds.EdgeURL)
+		if creds.Region != "" {
+			fmt.Printf("Region:    %s (%s)\n", creds.Region, creds.RegionURL)
+		}
 		fmt.Printf("Since:     %s\n", creds.CreatedAt.Format(time.RFC3339))
 	}
 
@@ -508,11 +862,74 @@ func runStatus(c *cli.Context) error {
 			fmt.Printf("Config:    hookly.yaml\n")
 			fmt.Printf("Hub ID:    %s\n", cfg.HubID)
 			fmt.Printf("Endpoints: %d\n", len(cfg.Endpoints))
+			if mismatch := clicmd.CheckEdgeURL(creds, cfg.EdgeURL); mismatch != nil {
+				edgeCreds, _ := credsMgr.LoadForEdge(cfg.EdgeURL)
+				if edgeCreds == nil {
+					fmt.Printf("\nWarning:   %v\n", mismatch)
+					fmt.Printf("           Run 'hookly login --edge-url %s'\n", cfg.EdgeURL)
+				}
+			}
 		}
 	} else {
 		fmt.Println("Config:    Not found (run 'hookly init')")
 	}
 
+	edgeURL := defaultEdgeURL
+	if creds != nil {
+		edgeURL = creds.EdgeURL
+	}
+	printVersionCheck(edgeURL)
+
+	if c.Bool("remote") && creds != nil {
+		return printRemoteStatus(creds)
+	}
+	return nil
+}
+
+// printVersionCheck prints this CLI's version and the edge's, noting an
+// upgrade if the edge runs a newer release.
+func printVersionCheck(edgeURL string) {
+	fmt.Println()
+	fmt.Printf("Version:   hookly %s\n", build)
+	check, err := clicmd.CheckVersion(context.Background(), clicmd.NewClient(edgeURL, ""), build)
+	if err != nil {
+		fmt.Printf("Edge:      unknown (%v)\n", err)
+		return
+	}
+	fmt.Printf("Edge:      %s\n", check.Edge)
+	switch {
+	case check.UpgradeAvailable():
+		fmt.Printf("\nUpgrade:   hookly %s is available; run '%s'\n", check.Edge.Version, clicmd.UpgradeCommand)
+		fmt.Printf("           or download hookly-%s-%s and its .sig from the release and check\n", runtime.GOOS, runtime.GOARCH)
+		fmt.Printf("           them with 'hookly verify-binary' before installing\n")
+	case check.EdgeOlder():
+		fmt.Println("\nNote:      the edge runs an older release, so newer commands may fail")
+	}
+}
+
+// printRemoteStatus prints the edge's view of the user's hubs and queue.
+func printRemoteStatus(creds *clicmd.Credentials) error {
+	client := clicmd.NewOrgClient(creds.EdgeURL, creds.APIToken, orgFlag)
+	resp, err := clicmd.Spin(context.Background(), "Contacting "+creds.EdgeURL, func(ctx context.Context) (*connect.Response[hooklyv1.GetStatusResponse], error) {
+		return client.Edge.GetStatus(ctx, connect.NewRequest(&hooklyv1.GetStatusRequest{}))
+	})
+	if err != nil {
+		return fmt.Errorf("get status: %w", err)
+	}
+
+	status := resp.Msg.Status
+	fmt.Println()
+	fmt.Printf("Hubs:      %d connected\n", len(status.ConnectedHubs))
+	for _, hub := range status.ConnectedHubs {
+		paused := ""
+		if hub.Paused {
+			paused = ", paused"
+		}
+		fmt.Printf("           %s (%s, %d endpoints%s)\n", hub.HubId, hub.Transport, len(hub.EndpointIds), paused)
+	}
+	fmt.Printf("Pending:   %d\n", status.PendingCount)
+	fmt.Printf("Failed:    %d\n", status.FailedCount)
+	fmt.Printf("Dead:      %d\n", status.DeadLetterCount)
 	return nil
 }
 
@@ -568,7 +985,7 @@ func runInitWizard(c *cli.Context, creds *clicmd.Credentials, credsMgr *clicmd.C
 	}
 
 	// Create API client
-	client := clicmd.NewClient(creds.EdgeURL, creds.APIToken)
+	client := clicmd.NewOrgClient(creds.ConnectURL(), creds.APIToken, orgFlag)
 
 	// Run wizard
 	cfg, err := clicmd.RunWizard(client, creds)
@@ -0,0 +1,274 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"connectrpc.com/connect"
+	"github.com/urfave/cli/v2"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	clicmd "hooks.dx314.com/internal/cli"
+)
+
+// orgCommand returns the org subcommand.
+func orgCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "org",
+		Aliases: []string{"orgs"},
+		Usage:   "Manage organizations and their members",
+		Description: `Organizations share endpoints among their members. Pass --org <org-id>,
+or set HOOKLY_ORG, to make other commands act on an org's endpoints.
+Hubs relay an org's endpoints listed in hookly.yaml without it.
+
+Roles:
+
+  owner   everything, including members and deleting the org
+  member  manage the org's endpoints and relay their webhooks
+  viewer  read-only access to the org's endpoints and webhooks`,
+		Subcommands: []*cli.Command{
+			{
+				Name:   "list",
+				Usage:  "List your organizations",
+				Action: runOrgList,
+				Flags:  []cli.Flag{jsonFlag},
+			},
+			{
+				Name:      "create",
+				Usage:     "Create an organization, with you as its owner",
+				ArgsUsage: "<name>",
+				Action:    runOrgCreate,
+				Flags:     []cli.Flag{jsonFlag},
+			},
+			{
+				Name:        "delete",
+				Usage:       "Delete an organization",
+				ArgsUsage:   "<org-id>",
+				Description: "Deletes the org with its endpoints and their webhooks. Owners only.",
+				Action:      runOrgDelete,
+			},
+			{
+				Name:      "members",
+				Usage:     "List an organization's members",
+				ArgsUsage: "<org-id>",
+				Action:    runOrgMembers,
+				Flags:     []cli.Flag{jsonFlag},
+			},
+			{
+				Name:      "add",
+				Usage:     "Add a member, or change a member's role",
+				ArgsUsage: "<org-id> <github-username>",
+				Description: `Adds a GitHub user, who must have signed in to hookly once, to the
+org. Adding an existing member changes their role. Owners only.`,
+				Action: runOrgAdd,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "role",
+						Usage: "Member `ROLE`: owner, member or viewer",
+						Value: "member",
+					},
+				},
+			},
+			{
+				Name:        "remove",
+				Usage:       "Remove a member",
+				ArgsUsage:   "<org-id> <user-id>",
+				Description: "Removes a member by user ID, as listed by 'hookly org members'.\nOwners remove anyone; others can only leave.",
+				Action:      runOrgRemove,
+			},
+		},
+	}
+}
+
+// parseOrgRole parses a --role value.
+func parseOrgRole(s string) (hooklyv1.OrgRole, error) {
+	role, ok := hooklyv1.OrgRole_value["ORG_ROLE_"+strings.ToUpper(s)]
+	if !ok || role == 0 {
+		return 0, fmt.Errorf("invalid --role %q: use owner, member or viewer", s)
+	}
+	return hooklyv1.OrgRole(role), nil
+}
+
+func orgRoleName(role hooklyv1.OrgRole) string {
+	return strings.ToLower(strings.TrimPrefix(role.String(), "ORG_ROLE_"))
+}
+
+// orgArgs returns the n arguments of an org subcommand.
+func orgArgs(c *cli.Context, usage string) ([]string, error) {
+	n := len(strings.Fields(usage))
+	if c.NArg() != n {
+		return nil, fmt.Errorf("wrong number of arguments\n\nUsage: hookly org %s %s", c.Command.Name, usage)
+	}
+	return c.Args().Slice(), nil
+}
+
+// runOrgList handles the org list command.
+func runOrgList(c *cli.Context) error {
+	client, err := newUserAPIClient()
+	if err != nil {
+		return err
+	}
+
+	orgs, err := clicmd.Spin(context.Background(), "Loading organizations", func(ctx context.Context) ([]*hooklyv1.Org, error) {
+		resp, err := client.Edge.ListOrgs(ctx, connect.NewRequest(&hooklyv1.ListOrgsRequest{}))
+		if err != nil {
+			return nil, fmt.Errorf("list orgs: %w", err)
+		}
+		return resp.Msg.Orgs, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.Bool("json") {
+		return printJSONList(os.Stdout, orgs)
+	}
+	if len(orgs) == 0 {
+		fmt.Fprintln(os.Stderr, "No organizations found. Create one with 'hookly org create <name>'.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tROLE\tCREATED")
+	for _, o := range orgs {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			o.Id,
+			o.Name,
+			orgRoleName(o.Role),
+			tsTime(o.CreatedAt).Local().Format("2006-01-02 15:04"),
+		)
+	}
+	return tw.Flush()
+}
+
+// runOrgCreate handles the org create command.
+func runOrgCreate(c *cli.Context) error {
+	args, err := orgArgs(c, "<name>")
+	if err != nil {
+		return err
+	}
+	client, err := newUserAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Edge.CreateOrg(context.Background(), connect.NewRequest(&hooklyv1.CreateOrgRequest{Name: args[0]}))
+	if err != nil {
+		return fmt.Errorf("create org: %w", err)
+	}
+	if c.Bool("json") {
+		return printJSON(os.Stdout, resp.Msg.Org)
+	}
+	fmt.Printf("Created org %s (%s).\n", resp.Msg.Org.Name, resp.Msg.Org.Id)
+	fmt.Printf("Use --org %s to manage its endpoints.\n", resp.Msg.Org.Id)
+	return nil
+}
+
+// runOrgDelete handles the org delete command.
+func runOrgDelete(c *cli.Context) error {
+	args, err := orgArgs(c, "<org-id>")
+	if err != nil {
+		return err
+	}
+	client, err := newUserAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Edge.DeleteOrg(context.Background(), connect.NewRequest(&hooklyv1.DeleteOrgRequest{Id: args[0]}))
+	if err != nil {
+		return fmt.Errorf("delete org: %w", err)
+	}
+	fmt.Printf("Deleted org %s and %d endpoint(s).\n", args[0], resp.Msg.EndpointsDeleted)
+	return nil
+}
+
+// runOrgMembers handles the org members command.
+func runOrgMembers(c *cli.Context) error {
+	args, err := orgArgs(c, "<org-id>")
+	if err != nil {
+		return err
+	}
+	client, err := newUserAPIClient()
+	if err != nil {
+		return err
+	}
+
+	members, err := clicmd.Spin(context.Background(), "Loading members", func(ctx context.Context) ([]*hooklyv1.OrgMember, error) {
+		resp, err := client.Edge.ListOrgMembers(ctx, connect.NewRequest(&hooklyv1.ListOrgMembersRequest{OrgId: args[0]}))
+		if err != nil {
+			return nil, fmt.Errorf("list members: %w", err)
+		}
+		return resp.Msg.Members, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.Bool("json") {
+		return printJSONList(os.Stdout, members)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "USER ID\tUSERNAME\tROLE\tADDED")
+	for _, m := range members {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n",
+			m.UserId,
+			m.Username,
+			orgRoleName(m.Role),
+			tsTime(m.CreatedAt).Local().Format("2006-01-02 15:04"),
+		)
+	}
+	return tw.Flush()
+}
+
+// runOrgAdd handles the org add command.
+func runOrgAdd(c *cli.Context) error {
+	args, err := orgArgs(c, "<org-id> <github-username>")
+	if err != nil {
+		return err
+	}
+	role, err := parseOrgRole(c.String("role"))
+	if err != nil {
+		return err
+	}
+	client, err := newUserAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Edge.AddOrgMember(context.Background(), connect.NewRequest(&hooklyv1.AddOrgMemberRequest{
+		OrgId:    args[0],
+		Username: args[1],
+		Role:     role,
+	}))
+	if err != nil {
+		return fmt.Errorf("add member: %w", err)
+	}
+	fmt.Printf("%s is now a %s of %s.\n", resp.Msg.Member.Username, orgRoleName(resp.Msg.Member.Role), args[0])
+	return nil
+}
+
+// runOrgRemove handles the org remove command.
+func runOrgRemove(c *cli.Context) error {
+	args, err := orgArgs(c, "<org-id> <user-id>")
+	if err != nil {
+		return err
+	}
+	client, err := newUserAPIClient()
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Edge.RemoveOrgMember(context.Background(), connect.NewRequest(&hooklyv1.RemoveOrgMemberRequest{
+		OrgId:  args[0],
+		UserId: args[1],
+	})); err != nil {
+		return fmt.Errorf("remove member: %w", err)
+	}
+	fmt.Printf("Removed %s from %s.\n", args[1], args[0])
+	return nil
+}
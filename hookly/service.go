@@ -1,8 +1,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/kardianos/service"
 	"github.com/urfave/cli/v2"
@@ -29,7 +31,20 @@ User services run when you log in and don't need sudo.`,
 				Description: `Installs hookly to run automatically as a background service.
 
 The --config flag is required and must point to your hookly.yaml.
-Use --user to install as a user service (no sudo required).`,
+Use --user to install as a user service (no sudo required).
+
+Like systemctl, --now also starts the service, and --enable=false
+installs it without starting it at boot or login.
+
+The remaining flags customize the systemd unit or launchd plist.
+launchd has no unit ordering, so --after only applies to systemd, and
+its only I/O class is idle.
+
+Examples:
+  sudo hookly service install --config /etc/hookly/hookly.yaml \
+    --run-as hookly --after network-online.target --nice 5
+  hookly service install --user --config ./hookly.yaml \
+    --env HTTPS_PROXY=http://proxy:3128 --restart on-failure`,
 				Action: runServiceInstall,
 				Flags: []cli.Flag{
 					&cli.StringFlag{
@@ -40,6 +55,40 @@ Use --user to install as a user service (no sudo required).`,
 						Name:  "user",
 						Usage: "Install as user service (no sudo, runs on login)",
 					},
+					&cli.StringFlag{
+						Name:  "run-as",
+						Usage: "Run the service as this user (system services only)",
+					},
+					&cli.StringSliceFlag{
+						Name:  "env",
+						Usage: "Set an environment variable, as KEY=VALUE (repeatable)",
+					},
+					&cli.IntFlag{
+						Name:  "nice",
+						Usage: "CPU priority, from -20 (highest) to 19 (lowest)",
+					},
+					&cli.StringFlag{
+						Name:  "io-class",
+						Usage: "I/O scheduling class: best-effort, idle or realtime",
+					},
+					&cli.StringFlag{
+						Name:  "restart",
+						Usage: "Restart policy: always, on-failure or never",
+						Value: svc.RestartAlways,
+					},
+					&cli.StringSliceFlag{
+						Name:  "after",
+						Usage: "Start after this systemd unit, e.g. network-online.target (repeatable)",
+					},
+					&cli.BoolFlag{
+						Name:  "now",
+						Usage: "Start the service right after installing it",
+					},
+					&cli.BoolFlag{
+						Name:  "enable",
+						Usage: "Start the service at boot (or login, with --user); --enable=false needs systemd",
+						Value: true,
+					},
 				},
 			},
 			{
@@ -102,6 +151,28 @@ Use --user to install as a user service (no sudo required).`,
 					},
 				},
 			},
+			{
+				Name:  "repair",
+				Usage: "Point the service at the current hookly binary",
+				Description: `Rewrites the service definition to run the current executable.
+
+Use this after the hookly binary moves, for example when a package
+manager upgrade changes its path, and 'hookly service status' warns that
+the service runs a different binary. Only the command is rewritten, so
+install options and manual edits are kept, as is the installed --config
+path unless --config is given. A running service is restarted.`,
+				Action: runServiceRepair,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "config",
+						Usage: "Path to hookly.yaml (default: the installed one)",
+					},
+					&cli.BoolFlag{
+						Name:  "user",
+						Usage: "Repair user service",
+					},
+				},
+			},
 			{
 				Name:  "logs",
 				Usage: "View hookly service logs",
@@ -145,8 +216,32 @@ func buildServiceConfig(c *cli.Context) *svc.ServiceConfig {
 	return cfg
 }
 
+// applyUnitFlags sets the unit customization options from install flags.
+func applyUnitFlags(c *cli.Context, cfg *svc.ServiceConfig) error {
+	cfg.RunAs = c.String("run-as")
+	cfg.Nice = c.Int("nice")
+	cfg.IOClass = c.String("io-class")
+	cfg.Restart = c.String("restart")
+	cfg.After = c.StringSlice("after")
+	cfg.NoAutostart = !c.Bool("enable")
+	for _, kv := range c.StringSlice("env") {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			return fmt.Errorf("invalid --env %q: expected KEY=VALUE", kv)
+		}
+		if cfg.Env == nil {
+			cfg.Env = make(map[string]string)
+		}
+		cfg.Env[key] = value
+	}
+	return nil
+}
+
 func runServiceInstall(c *cli.Context) error {
 	cfg := buildServiceConfig(c)
+	if err := applyUnitFlags(c, cfg); err != nil {
+		return err
+	}
 
 	// Validate for installation
 	if err := cfg.ValidateForInstall(); err != nil {
@@ -168,14 +263,23 @@ func runServiceInstall(c *cli.Context) error {
 	}
 
 	fmt.Printf("Service installed successfully\n")
+	if unitPath, err := svc.UnitPath(cfg.UserService); err == nil {
+		fmt.Printf("Unit:   %s\n", unitPath)
+	}
 	fmt.Printf("Config: %s\n", cfg.ConfigPath)
 	fmt.Printf("Logs:   %s\n", svc.GetLogPath(cfg.UserService))
-	fmt.Printf("\nStart with: hookly service start")
-	if cfg.UserService {
-		fmt.Printf(" --user")
+	if cfg.NoAutostart {
+		fmt.Printf("Boot:   disabled (start it manually)\n")
 	}
-	fmt.Printf("\n")
 
+	if !c.Bool("now") {
+		fmt.Printf("\nStart with: hookly service start%s\n", userFlag(cfg))
+		return nil
+	}
+	if err := svc.ControlService(cfg, "start"); err != nil {
+		return fmt.Errorf("start service: %w", err)
+	}
+	fmt.Println("\nService started")
 	return nil
 }
 
@@ -267,6 +371,74 @@ func runServiceStatus(c *cli.Context) error {
 
 	fmt.Printf("Status: %s\n", svc.StatusString(status))
 	fmt.Printf("Logs:   %s\n", svc.GetLogPath(cfg.UserService))
+
+	if drift, err := svc.CheckExecutable(cfg.UserService); err == nil && drift != nil {
+		fmt.Printf("\nWarning: %s\n", drift)
+		fmt.Printf("Fix with: hookly service repair%s\n", userFlag(cfg))
+	}
+	return nil
+}
+
+func runServiceRepair(c *cli.Context) error {
+	cfg := buildServiceConfig(c)
+
+	installed, err := svc.ReadInstalledCommand(cfg.UserService)
+	if err != nil {
+		if errors.Is(err, svc.ErrNotInstalled) {
+			return fmt.Errorf("service not installed\n\nInstall first with: hookly service install --config PATH")
+		}
+		return fmt.Errorf("read service definition: %w", err)
+	}
+	drift, err := installed.Drift()
+	if err != nil {
+		return err
+	}
+	if drift == nil && c.String("config") == "" {
+		fmt.Println("Service already runs the current binary")
+		return nil
+	}
+
+	if c.String("config") == "" && installed.ConfigPath != "" {
+		cfg.ConfigPath = installed.ConfigPath
+	}
+	if err := cfg.ValidateForInstall(); err != nil {
+		return err
+	}
+	absConfigPath, err := makeAbsolute(cfg.ConfigPath)
+	if err != nil {
+		return fmt.Errorf("resolve config path: %w", err)
+	}
+	cfg.ConfigPath = absConfigPath
+
+	status, _ := svc.GetServiceStatus(cfg)
+	running := status == service.StatusRunning
+	if running {
+		if err := svc.ControlService(cfg, "stop"); err != nil {
+			fmt.Printf("Warning: failed to stop service: %v\n", err)
+		}
+	}
+
+	if err := svc.Repair(cfg, installed); err != nil {
+		if isPermissionError(err) {
+			return fmt.Errorf("permission denied\n\nTry: sudo hookly service repair")
+		}
+		return fmt.Errorf("repair service: %w", err)
+	}
+
+	fmt.Printf("Service repaired\n")
+	if drift != nil {
+		fmt.Printf("Binary: %s (was %s)\n", drift.Current, drift.Installed)
+	}
+	fmt.Printf("Config: %s\n", cfg.ConfigPath)
+
+	if !running {
+		fmt.Printf("\nStart with: hookly service start%s\n", userFlag(cfg))
+		return nil
+	}
+	if err := svc.ControlService(cfg, "start"); err != nil {
+		return fmt.Errorf("start service: %w", err)
+	}
+	fmt.Println("Service restarted")
 	return nil
 }
 
@@ -280,6 +452,14 @@ func runServiceLogs(c *cli.Context) error {
 	return svc.ViewLogs(logsCfg)
 }
 
+// userFlag returns " --user" for user services, for printing commands.
+func userFlag(cfg *svc.ServiceConfig) string {
+	if cfg.UserService {
+		return " --user"
+	}
+	return ""
+}
+
 // makeAbsolute converts a relative path to absolute.
 func makeAbsolute(path string) (string, error) {
 	if len(path) > 0 && path[0] == '/' {
@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+
+	"connectrpc.com/connect"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	clicmd "hooks.dx314.com/internal/cli"
+)
+
+// tailPreviewLen is the payload preview printed per webhook, in characters.
+const tailPreviewLen = 200
+
+// tailCommand returns the tail command.
+func tailCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "tail",
+		Usage:     "Stream webhooks live as they arrive and are delivered",
+		ArgsUsage: "[endpoint-id]",
+		Description: `Prints each webhook as the edge receives it, with its headers and a
+truncated payload, then a line for every delivery result. Tails all
+your endpoints unless an endpoint ID is given. Stop with Ctrl-C.
+
+--filter keeps only changes to the given statuses, e.g.
+--filter failed,dead_letter to watch for failing deliveries. Webhooks
+arrive as pending. --json prints one JSON object per event.`,
+		Action: runTail,
+		Flags: []cli.Flag{
+			&cli.BoolFlag{
+				Name:  "json",
+				Usage: "Print events as JSON lines",
+			},
+			&cli.StringFlag{
+				Name:  "filter",
+				Usage: "Only `STATUSES` (comma-separated: pending, delivered, failed, dead_letter, skipped, acknowledged_duplicate)",
+			},
+		},
+	}
+}
+
+// runTail handles the tail command.
+func runTail(c *cli.Context) error {
+	statuses, err := parseStatusFilter(c.String("filter"))
+	if err != nil {
+		return err
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	req := &hooklyv1.TailWebhooksRequest{Statuses: statuses}
+	if id := c.Args().First(); id != "" {
+		req.EndpointId = &id
+	}
+	stream, err := client.Edge.TailWebhooks(ctx, connect.NewRequest(req))
+	if err != nil {
+		return fmt.Errorf("tail webhooks: %w", err)
+	}
+	defer stream.Close()
+
+	out := os.Stdout
+	printer := &tailPrinter{
+		out:      out,
+		useColor: clicmd.UseColor(out),
+		client:   client,
+		names:    make(map[string]string),
+	}
+	if !c.Bool("json") {
+		fmt.Fprintln(os.Stderr, "Waiting for webhooks... (Ctrl-C to stop)")
+	}
+
+	for stream.Receive() {
+		msg := stream.Msg()
+		if c.Bool("json") {
+			data, err := protojson.Marshal(msg)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(out, string(data))
+			continue
+		}
+		printer.print(ctx, msg)
+	}
+	if err := stream.Err(); err != nil && ctx.Err() == nil && !errors.Is(err, context.Canceled) {
+		return fmt.Errorf("tail webhooks: %w", err)
+	}
+	return nil
+}
+
+// parseStatusFilter parses a comma-separated list of webhook statuses.
+func parseStatusFilter(spec string) ([]hooklyv1.WebhookStatus, error) {
+	var statuses []hooklyv1.WebhookStatus
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		v, ok := hooklyv1.WebhookStatus_value["WEBHOOK_STATUS_"+strings.ToUpper(strings.ReplaceAll(name, "-", "_"))]
+		if !ok || v == 0 {
+			return nil, fmt.Errorf("unknown status %q (valid: pending, delivered, failed, dead_letter, skipped, acknowledged_duplicate)", name)
+		}
+		if st := hooklyv1.WebhookStatus(v); !slices.Contains(statuses, st) {
+			statuses = append(statuses, st)
+		}
+	}
+	return statuses, nil
+}
+
+// tailPrinter renders tailed webhooks for a terminal.
+type tailPrinter struct {
+	out      io.Writer
+	useColor bool
+	client   *clicmd.Client
+	names    map[string]string // Endpoint ID → name
+}
+
+func (p *tailPrinter) paint(color, s string) string {
+	if !p.useColor {
+		return s
+	}
+	return color + s + colorReset
+}
+
+func (p *tailPrinter) print(ctx context.Context, msg *hooklyv1.TailWebhooksResponse) {
+	wh, change := msg.Webhook, msg.Change
+	at := tsTime(change.ChangedAt).Local().Format("15:04:05")
+
+	if change.FromStatus != hooklyv1.WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED {
+		line := fmt.Sprintf("%s  %s %s  %s", p.paint(colorDim, at), sym(symbolArrow), p.paint(statusColor(change.ToStatus), webhookStatusLabel(change.ToStatus)), wh.Id)
+		if change.Reason != "" {
+			line += "  " + p.paint(colorDim, change.Reason)
+		}
+		fmt.Fprintln(p.out, line)
+		return
+	}
+
+	// Webhooks are only accepted as POST
+	line := fmt.Sprintf("%s  %s %s %s", p.paint(colorDim, at), sym(symbolIn), p.paint(colorBold, "POST"), p.endpointName(ctx, wh.EndpointId))
+	if wh.EventType != "" {
+		line += "  " + p.paint(colorCyan, wh.EventType)
+	}
+	line += "  " + wh.Id
+	if change.ToStatus != hooklyv1.WebhookStatus_WEBHOOK_STATUS_PENDING {
+		line += "  " + p.paint(statusColor(change.ToStatus), webhookStatusLabel(change.ToStatus))
+	}
+	if !wh.SignatureValid {
+		line += "  " + p.paint(colorRed, sym(symbolError)+" invalid signature")
+	}
+	fmt.Fprintln(p.out, line)
+
+	names := make([]string, 0, len(wh.Headers))
+	for name := range wh.Headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		fmt.Fprintf(p.out, "          %s: %s\n", p.paint(colorCyan, name), wh.Headers[name])
+	}
+	if preview := tailPreview(wh); preview != "" {
+		fmt.Fprintf(p.out, "          %s\n", p.paint(colorDim, preview))
+	}
+}
+
+// endpointName returns the endpoint's name, looked up once per endpoint.
+func (p *tailPrinter) endpointName(ctx context.Context, id string) string {
+	if name, ok := p.names[id]; ok {
+		return name
+	}
+	name := id
+	if ep, err := p.client.Edge.GetEndpoint(ctx, connect.NewRequest(&hooklyv1.GetEndpointRequest{Id: id})); err == nil && ep.Msg.Endpoint != nil {
+		name = ep.Msg.Endpoint.Name
+	}
+	p.names[id] = name
+	return name
+}
+
+// tailPreview returns the start of the payload on a single line.
+func tailPreview(wh *hooklyv1.Webhook) string {
+	preview := strings.Join(strings.Fields(string(wh.PayloadPreview)), " ")
+	runes := []rune(preview)
+	if len(runes) > tailPreviewLen {
+		return string(runes[:tailPreviewLen]) + sym(symbolMore)
+	}
+	if wh.PayloadTruncated {
+		return preview + sym(symbolMore)
+	}
+	return preview
+}
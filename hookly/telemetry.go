@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	clicmd "hooks.dx314.com/internal/cli"
+	"hooks.dx314.com/internal/exitcode"
+	"hooks.dx314.com/internal/telemetry"
+)
+
+// telemetryCommand returns the telemetry command.
+func telemetryCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "telemetry",
+		Usage: "Turn anonymous usage reports on or off",
+		Description: `Telemetry is off unless you turn it on. When on, hookly sends the edge
+you are logged in to one report a day with:
+
+  - this CLI's version, OS and architecture
+  - how many endpoints the relay runs with, as a range such as 2-5
+  - how many commands failed since the last report, by class
+    (auth, config, endpoint, network, interrupted or error)
+
+Nothing else: no webhook payloads, endpoint IDs or URLs, hostnames,
+usernames, tokens or machine IDs. 'hookly telemetry status' shows the
+next report exactly. Turning it off discards what was recorded.
+
+` + telemetry.EnvVar + `=off or DO_NOT_TRACK=1 keep it off whatever the setting.`,
+		Subcommands: []*cli.Command{
+			{
+				Name:   "on",
+				Usage:  "Send anonymous usage reports",
+				Action: func(c *cli.Context) error { return setTelemetry(true) },
+			},
+			{
+				Name:   "off",
+				Usage:  "Stop sending usage reports and discard recorded counts",
+				Action: func(c *cli.Context) error { return setTelemetry(false) },
+			},
+			{
+				Name:   "status",
+				Usage:  "Show whether reports are sent, and the next report",
+				Action: runTelemetryStatus,
+			},
+		},
+	}
+}
+
+func setTelemetry(enabled bool) error {
+	store, err := clicmd.TelemetryStore()
+	if err != nil {
+		return err
+	}
+	if err := store.SetEnabled(enabled, time.Now()); err != nil {
+		return err
+	}
+	if enabled {
+		fmt.Printf("%s Telemetry on. Thanks for helping prioritize hookly's development.\n", sym(symbolSuccess))
+		fmt.Println("  See what is sent with 'hookly telemetry status'; turn it off with 'hookly telemetry off'.")
+	} else {
+		fmt.Printf("%s Telemetry off. Nothing will be recorded or sent.\n", sym(symbolSuccess))
+	}
+	if telemetry.Disabled(os.Getenv) {
+		fmt.Printf("  %s %s or DO_NOT_TRACK keeps it off in this environment.\n", sym(symbolInfo), telemetry.EnvVar)
+	}
+	return nil
+}
+
+func runTelemetryStatus(c *cli.Context) error {
+	store, err := clicmd.TelemetryStore()
+	if err != nil {
+		return err
+	}
+	state, err := store.Load()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case !state.Enabled:
+		fmt.Println("Telemetry: off")
+		fmt.Println("\nNothing is recorded or sent. Run 'hookly telemetry on' to send anonymous usage reports.")
+		return nil
+	case telemetry.Disabled(os.Getenv):
+		fmt.Printf("Telemetry: on, but kept off by %s or DO_NOT_TRACK\n", telemetry.EnvVar)
+	default:
+		fmt.Printf("Telemetry: on since %s\n", state.DecidedAt.Local().Format("2006-01-02"))
+	}
+	fmt.Printf("Settings:  %s\n", store.Path())
+	if state.LastSent.IsZero() {
+		fmt.Println("Last sent: never")
+	} else {
+		fmt.Printf("Last sent: %s\n", state.LastSent.Local().Format("2006-01-02 15:04"))
+	}
+
+	fmt.Printf("\nNext report, to %s:\n", telemetryEdgeURL())
+	data, err := json.MarshalIndent(clicmd.TelemetryReport(state, build), "  ", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  %s\n", data)
+	return nil
+}
+
+// telemetryEdgeURL returns the edge reports are sent to: the one logged in
+// to, or the default edge.
+func telemetryEdgeURL() string {
+	if credsMgr, err := clicmd.NewCredentialsManager(); err == nil {
+		if creds, err := credsMgr.Load(); err == nil && creds != nil {
+			return creds.EdgeURL
+		}
+	}
+	return defaultEdgeURL
+}
+
+// telemetrySent is closed once a report started by startTelemetry is done.
+var telemetrySent chan struct{}
+
+// startTelemetry sends a usage report in the background if one is due. It
+// does nothing unless telemetry was turned on, and never for the telemetry
+// command itself, so turning it off sends nothing.
+func startTelemetry(c *cli.Context) {
+	if c.Args().First() == "telemetry" {
+		return
+	}
+	store, err := clicmd.TelemetryStore()
+	if err != nil {
+		return
+	}
+	telemetrySent = make(chan struct{})
+	go func() {
+		defer close(telemetrySent)
+		if _, err := clicmd.SendTelemetry(context.Background(), store, telemetryEdgeURL(), build); err != nil {
+			slog.Debug("failed to send telemetry", "error", err)
+		}
+	}()
+}
+
+// finishTelemetry waits for a report being sent, then counts a failed
+// command.
+func finishTelemetry(err error) {
+	if telemetrySent != nil {
+		<-telemetrySent
+	}
+	if err != nil {
+		code := exitcode.Code(err)
+		if errors.Is(err, clicmd.ErrInterrupted) {
+			code = exitcode.Interrupted
+		}
+		recordTelemetry(func(s *telemetry.Store) error { return s.RecordError(exitcode.Name(code)) })
+	}
+}
+
+// recordTelemetry updates the telemetry counters with fn. The store only
+// records while telemetry is on.
+func recordTelemetry(fn func(*telemetry.Store) error) {
+	store, err := clicmd.TelemetryStore()
+	if err != nil {
+		return
+	}
+	if err := fn(store); err != nil {
+		slog.Debug("failed to record telemetry", "error", err)
+	}
+}
@@ -0,0 +1,239 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/urfave/cli/v2"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	clicmd "hooks.dx314.com/internal/cli"
+)
+
+// tokenCommand returns the token subcommand.
+func tokenCommand() *cli.Command {
+	return &cli.Command{
+		Name:    "token",
+		Aliases: []string{"tokens"},
+		Usage:   "Manage API tokens",
+		Subcommands: []*cli.Command{
+			{
+				Name:  "list",
+				Usage: "List API tokens",
+				Description: `Lists your API tokens that aren't revoked, newest first, with their
+scope and expiry. The token strings themselves are only shown when
+they are created or rotated.`,
+				Action: runTokenList,
+				Flags:  []cli.Flag{jsonFlag},
+			},
+			{
+				Name:      "create",
+				Usage:     "Create an API token",
+				ArgsUsage: "<name>",
+				Description: `Creates a token and prints it once. Its scope limits what it can do:
+
+  admin  every API call and relay connections, like 'hookly login'
+  read   read-only API calls (get, list and tail), for dashboards
+  relay  relay connections only, for hubs running on servers
+
+--expires-in sets a lifetime, e.g. 720h; by default the token never expires.`,
+				Action: runTokenCreate,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "scope",
+						Usage:    "Token `SCOPE`: admin, read or relay",
+						Required: true,
+					},
+					&cli.DurationFlag{
+						Name:  "expires-in",
+						Usage: "Expire the token after `DURATION`",
+					},
+					jsonFlag,
+				},
+			},
+			{
+				Name:      "rotate",
+				Usage:     "Replace an API token with a new one",
+				ArgsUsage: "<token-id>",
+				Description: `Creates a token with the same name, scope and lifetime, prints it
+once and revokes the old one. Update whatever used the old token.`,
+				Action: runTokenRotate,
+				Flags:  []cli.Flag{jsonFlag},
+			},
+			{
+				Name:      "revoke",
+				Usage:     "Revoke an API token",
+				ArgsUsage: "<token-id>",
+				Action:    runTokenRevoke,
+			},
+		},
+	}
+}
+
+// parseTokenScope parses a --scope value.
+func parseTokenScope(s string) (hooklyv1.TokenScope, error) {
+	scope, ok := hooklyv1.TokenScope_value["TOKEN_SCOPE_"+strings.ToUpper(s)]
+	if !ok || scope == 0 {
+		return 0, fmt.Errorf("invalid --scope %q: use admin, read or relay", s)
+	}
+	return hooklyv1.TokenScope(scope), nil
+}
+
+func tokenScopeName(scope hooklyv1.TokenScope) string {
+	return strings.ToLower(strings.TrimPrefix(scope.String(), "TOKEN_SCOPE_"))
+}
+
+// tokenIDArg returns the token ID argument of a subcommand.
+func tokenIDArg(c *cli.Context) (string, error) {
+	id := c.Args().First()
+	if id == "" {
+		return "", fmt.Errorf("token ID is required\n\nUsage: hookly token %s <token-id>", c.Command.Name)
+	}
+	return id, nil
+}
+
+// runTokenList handles the token list command.
+func runTokenList(c *cli.Context) error {
+	client, err := newUserAPIClient()
+	if err != nil {
+		return err
+	}
+
+	tokens, err := clicmd.Spin(context.Background(), "Loading tokens", func(ctx context.Context) ([]*hooklyv1.ApiToken, error) {
+		resp, err := client.Edge.ListApiTokens(ctx, connect.NewRequest(&hooklyv1.ListApiTokensRequest{}))
+		if err != nil {
+			return nil, fmt.Errorf("list tokens: %w", err)
+		}
+		return resp.Msg.Tokens, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if c.Bool("json") {
+		return printJSONList(os.Stdout, tokens)
+	}
+	if len(tokens) == 0 {
+		fmt.Fprintln(os.Stderr, "No tokens found.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tNAME\tSCOPE\tCREATED\tLAST USED\tEXPIRES")
+	for _, t := range tokens {
+		lastUsed := "never"
+		if t.LastUsedAt != nil {
+			lastUsed = t.LastUsedAt.AsTime().Local().Format("2006-01-02 15:04")
+		}
+		expires := "never"
+		if t.ExpiresAt != nil {
+			expires = t.ExpiresAt.AsTime().Local().Format("2006-01-02 15:04")
+			if t.Expired {
+				expires += " (expired)"
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			t.Id,
+			t.Name,
+			tokenScopeName(t.Scope),
+			tsTime(t.CreatedAt).Local().Format("2006-01-02 15:04"),
+			lastUsed,
+			expires,
+		)
+	}
+	return tw.Flush()
+}
+
+// runTokenCreate handles the token create command.
+func runTokenCreate(c *cli.Context) error {
+	name := c.Args().First()
+	if name == "" {
+		return fmt.Errorf("token name is required\n\nUsage: hookly token create --scope <scope> <name>")
+	}
+	scope, err := parseTokenScope(c.String("scope"))
+	if err != nil {
+		return err
+	}
+	expiresIn := c.Duration("expires-in")
+	if expiresIn < 0 || (expiresIn > 0 && expiresIn < time.Second) {
+		return fmt.Errorf("invalid --expires-in %s: use a duration of at least 1s", expiresIn)
+	}
+
+	client, err := newUserAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Edge.CreateApiToken(context.Background(), connect.NewRequest(&hooklyv1.CreateApiTokenRequest{
+		Name:             name,
+		Scope:            scope,
+		ExpiresInSeconds: int64(expiresIn / time.Second),
+	}))
+	if err != nil {
+		return fmt.Errorf("create token: %w", err)
+	}
+	return printNewToken(c, resp.Msg.Token, resp.Msg.ApiToken)
+}
+
+// runTokenRotate handles the token rotate command.
+func runTokenRotate(c *cli.Context) error {
+	id, err := tokenIDArg(c)
+	if err != nil {
+		return err
+	}
+	client, err := newUserAPIClient()
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Edge.RotateApiToken(context.Background(), connect.NewRequest(&hooklyv1.RotateApiTokenRequest{Id: id}))
+	if err != nil {
+		return fmt.Errorf("rotate token: %w", err)
+	}
+	if err := printNewToken(c, resp.Msg.Token, resp.Msg.ApiToken); err != nil {
+		return err
+	}
+	if !c.Bool("json") {
+		fmt.Fprintf(os.Stderr, "Token %s is revoked.\n", id)
+	}
+	return nil
+}
+
+// printNewToken prints a created or rotated token. The token goes to stdout
+// alone so it can be piped; the reminder to stderr.
+func printNewToken(c *cli.Context, token string, info *hooklyv1.ApiToken) error {
+	if c.Bool("json") {
+		return printJSON(os.Stdout, &hooklyv1.CreateApiTokenResponse{Token: token, ApiToken: info})
+	}
+	fmt.Println(token)
+	expires := "never expires"
+	if info.ExpiresAt != nil {
+		expires = "expires " + info.ExpiresAt.AsTime().Local().Format("2006-01-02 15:04")
+	}
+	fmt.Fprintf(os.Stderr, "\nCreated %s token %s (%s, %s).\n", tokenScopeName(info.Scope), info.Id, info.Name, expires)
+	fmt.Fprintln(os.Stderr, "This token is shown only once. Store it somewhere safe now.")
+	return nil
+}
+
+// runTokenRevoke handles the token revoke command.
+func runTokenRevoke(c *cli.Context) error {
+	id, err := tokenIDArg(c)
+	if err != nil {
+		return err
+	}
+	client, err := newUserAPIClient()
+	if err != nil {
+		return err
+	}
+
+	if _, err := client.Edge.RevokeApiToken(context.Background(), connect.NewRequest(&hooklyv1.RevokeApiTokenRequest{Id: id})); err != nil {
+		return fmt.Errorf("revoke token: %w", err)
+	}
+	fmt.Printf("Revoked token %s.\n", id)
+	return nil
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/urfave/cli/v2"
+
+	"hooks.dx314.com/internal/release"
+)
+
+// verifyBinaryCommand returns the verify-binary command.
+func verifyBinaryCommand() *cli.Command {
+	return &cli.Command{
+		Name:      "verify-binary",
+		Usage:     "Verify the signature of a downloaded hookly release binary",
+		ArgsUsage: "<path>",
+		Description: fmt.Sprintf(`Checks that a binary was signed with the hookly release key, which is
+embedded in this binary. Run it with a hookly you already trust, such as
+the one installed, on a binary you downloaded, before running that one:
+a binary can't vouch for itself.
+
+Release binaries are published with a cosign signature next to them, e.g.
+hookly-%[1]s-%[2]s and hookly-%[1]s-%[2]s.sig; the signature is read from the
+path plus .sig unless --signature is given. Binaries built with
+'go install' are not signed.`, runtime.GOOS, runtime.GOARCH),
+		Action: runVerifyBinary,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "signature",
+				Usage: "Read the signature from `FILE` instead of <path>.sig",
+			},
+			&cli.StringFlag{
+				Name:  "key",
+				Usage: "Verify with the PEM public key in `FILE` instead of the release key",
+			},
+		},
+	}
+}
+
+func runVerifyBinary(c *cli.Context) error {
+	path := c.Args().First()
+	if path == "" {
+		return errors.New("usage: hookly verify-binary <path>\n\nPass the binary to check, e.g. a downloaded hookly-" + runtime.GOOS + "-" + runtime.GOARCH)
+	}
+	if _, err := os.Stat(path); err != nil {
+		return err
+	}
+
+	key, err := verifyKey(c.String("key"))
+	if err != nil {
+		return err
+	}
+
+	sigPath := c.String("signature")
+	result, err := release.VerifyFile(path, sigPath, key)
+	if errors.Is(err, os.ErrNotExist) && sigPath == "" {
+		return fmt.Errorf("no signature at %s%s\n\nDownload it from the release next to the binary, or pass --signature", path, release.SignatureSuffix)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+
+	fmt.Printf("%s %s\n", sym(symbolSuccess), path)
+	fmt.Printf("  Signed by the %s\n", keyName(c.String("key")))
+	fmt.Printf("  SHA-256 %s\n", result.SHA256)
+	return nil
+}
+
+// verifyKey returns the key in keyPath, or the release key.
+func verifyKey(keyPath string) (*ecdsa.PublicKey, error) {
+	if keyPath == "" {
+		key, err := release.PublicKey()
+		if errors.Is(err, release.ErrNoPublicKey) {
+			return nil, fmt.Errorf("%w\n\nPass internal/release/cosign.pub from the hookly repository with --key", err)
+		}
+		return key, err
+	}
+	data, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("read key: %w", err)
+	}
+	return release.ParsePublicKey(data)
+}
+
+// keyName describes the key a binary was verified with.
+func keyName(keyPath string) string {
+	if keyPath != "" {
+		return "key in " + keyPath
+	}
+	return "hookly release key"
+}
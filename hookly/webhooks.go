@@ -0,0 +1,393 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"connectrpc.com/connect"
+	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	clicmd "hooks.dx314.com/internal/cli"
+)
+
+// webhooksCommand returns the webhooks subcommand.
+func webhooksCommand() *cli.Command {
+	return &cli.Command{
+		Name:  "webhooks",
+		Usage: "Inspect and replay received webhooks",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "show",
+				Usage:     "Show a webhook with its headers, delivery timeline and payload",
+				ArgsUsage: "<webhook-id>",
+				Description: `Prints the webhook's status, signature verdict, delivery timeline,
+headers and pretty-printed payload.
+
+Use --raw to print the payload bytes exactly as received, or --jq to
+extract part of a JSON payload, e.g. --jq '.data.object.id'.
+With both, string results are printed without quotes.`,
+				Action: runWebhooksShow,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "raw",
+						Usage: "Print only the raw payload",
+					},
+					&cli.StringFlag{
+						Name:  "jq",
+						Usage: "Print the result of a jq path expression on the payload",
+					},
+				},
+			},
+			{
+				Name:  "replay",
+				Usage: "Replay webhooks in bulk, dead letters by default",
+				Description: `Resets the matching webhooks for re-delivery, the oldest first.
+Filter by endpoint, status (dead_letter, failed, delivered,
+acknowledged_duplicate or skipped) and received time; --since and --until
+take a duration ago, e.g. 24h, or an RFC3339 time. Replaying skipped
+webhooks delivers those of event types a hub has since subscribed to.
+
+Replaying many webhooks asks for confirmation unless --yes is given,
+which scripts must pass.`,
+				Action: runWebhooksReplay,
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:  "endpoint",
+						Usage: "Only webhooks of endpoint `ID`",
+					},
+					&cli.StringFlag{
+						Name:  "status",
+						Value: "dead_letter",
+						Usage: "Only webhooks with `STATUS`",
+					},
+					&cli.StringFlag{
+						Name:  "since",
+						Usage: "Only webhooks received after `TIME`",
+					},
+					&cli.StringFlag{
+						Name:  "until",
+						Usage: "Only webhooks received before `TIME`",
+					},
+					&cli.IntFlag{
+						Name:  "max",
+						Value: 100,
+						Usage: "Most webhooks to replay, at most 1000",
+					},
+					&cli.BoolFlag{
+						Name:    "yes",
+						Aliases: []string{"y"},
+						Usage:   "Don't ask for confirmation",
+					},
+				},
+			},
+		},
+	}
+}
+
+// runWebhooksShow handles the webhooks show command.
+func runWebhooksShow(c *cli.Context) error {
+	webhookID := c.Args().First()
+	if webhookID == "" {
+		return fmt.Errorf("webhook ID is required\n\nUsage: hookly webhooks show <webhook-id>")
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	resp, err := client.Edge.GetWebhook(ctx, connect.NewRequest(&hooklyv1.GetWebhookRequest{
+		Id: webhookID,
+	}))
+	if err != nil {
+		return fmt.Errorf("get webhook: %w", err)
+	}
+	wh := resp.Msg.Webhook
+
+	out := os.Stdout
+	useColor := clicmd.UseColor(out)
+
+	if expr := c.String("jq"); expr != "" {
+		return printJQ(out, wh.Payload, expr, c.Bool("raw"), useColor)
+	}
+	if c.Bool("raw") {
+		_, err := out.Write(wh.Payload)
+		return err
+	}
+
+	endpointName := wh.EndpointId
+	if ep, err := client.Edge.GetEndpoint(ctx, connect.NewRequest(&hooklyv1.GetEndpointRequest{Id: wh.EndpointId})); err == nil && ep.Msg.Endpoint != nil {
+		endpointName = fmt.Sprintf("%s (%s)", ep.Msg.Endpoint.Name, wh.EndpointId)
+	}
+
+	paint := func(color, s string) string {
+		if !useColor {
+			return s
+		}
+		return color + s + colorReset
+	}
+
+	fmt.Fprintf(out, "%s %s\n\n", paint(colorBold, "Webhook"), wh.Id)
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "  Endpoint:\t%s\n", endpointName)
+	if wh.EventType != "" {
+		fmt.Fprintf(tw, "  Event:\t%s\n", wh.EventType)
+	}
+	fmt.Fprintf(tw, "  Status:\t%s\n", paint(statusColor(wh.Status), webhookStatusLabel(wh.Status)))
+	if wh.SignatureValid {
+		fmt.Fprintf(tw, "  Signature:\t%s\n", paint(colorGreen, sym(symbolSuccess)+" valid"))
+	} else {
+		fmt.Fprintf(tw, "  Signature:\t%s\n", paint(colorRed, sym(symbolError)+" invalid"))
+	}
+	fmt.Fprintf(tw, "  Attempts:\t%d\n", wh.Attempts)
+	if wh.ReplayCount > 0 {
+		fmt.Fprintf(tw, "  Replays:\t%d\n", wh.ReplayCount)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(out, "\n%s\n", paint(colorBold, "Timeline"))
+	tw = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, ev := range webhookTimeline(wh) {
+		line := ev.label
+		if ev.detail != "" {
+			line += "  " + paint(colorDim, ev.detail)
+		}
+		fmt.Fprintf(tw, "  %s\t%s\n", ev.at.Local().Format("2006-01-02 15:04:05"), line)
+	}
+	tw.Flush()
+
+	if len(resp.Msg.Deliveries) > 0 {
+		fmt.Fprintf(out, "\n%s\n", paint(colorBold, "Destinations"))
+		tw = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+		for _, d := range resp.Msg.Deliveries {
+			url := d.Url
+			if url == "" {
+				url = "(removed destination)"
+			}
+			status := webhookStatusLabel(d.Status)
+			if d.Status == hooklyv1.WebhookStatus_WEBHOOK_STATUS_PENDING {
+				status = "retrying"
+			}
+			detail := fmt.Sprintf("%d attempts", d.Attempts)
+			if d.ErrorMessage != "" && d.Status != hooklyv1.WebhookStatus_WEBHOOK_STATUS_DELIVERED {
+				detail += ", " + d.ErrorMessage
+			}
+			fmt.Fprintf(tw, "  %s\t%s\t%s\n", url, paint(statusColor(d.Status), status), paint(colorDim, detail))
+		}
+		tw.Flush()
+	}
+
+	fmt.Fprintf(out, "\n%s\n", paint(colorBold, "Headers"))
+	names := make([]string, 0, len(wh.Headers))
+	for name := range wh.Headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	tw = tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(tw, "  %s\t%s\n", paint(colorCyan, name), wh.Headers[name])
+	}
+	tw.Flush()
+
+	fmt.Fprintf(out, "\n%s %s\n", paint(colorBold, "Payload"), paint(colorDim, fmt.Sprintf("(%d bytes)", len(wh.Payload))))
+	if pretty, ok := clicmd.PrettyJSON(wh.Payload); ok {
+		if useColor {
+			pretty = clicmd.HighlightJSON(pretty)
+		}
+		fmt.Fprintln(out, pretty)
+	} else {
+		fmt.Fprintln(out, string(wh.Payload))
+	}
+	return nil
+}
+
+// printJQ prints the results of a jq path expression, one per line.
+func printJQ(out io.Writer, payload []byte, expr string, raw, useColor bool) error {
+	results, err := clicmd.QueryJSON(payload, expr)
+	if err != nil {
+		return err
+	}
+
+	for _, v := range results {
+		if s, ok := v.(string); ok && raw {
+			fmt.Fprintln(out, s)
+			continue
+		}
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		text := string(data)
+		if useColor {
+			text = clicmd.HighlightJSON(text)
+		}
+		fmt.Fprintln(out, text)
+	}
+	return nil
+}
+
+type timelineEvent struct {
+	at     time.Time
+	label  string
+	detail string
+}
+
+// webhookTimeline builds the delivery timeline from the webhook's timestamps
+// and status changes.
+func webhookTimeline(wh *hooklyv1.Webhook) []timelineEvent {
+	events := []timelineEvent{{at: tsTime(wh.ReceivedAt), label: "Received"}}
+
+	if wh.LastAttemptAt != nil {
+		label := fmt.Sprintf("Attempt %d", wh.Attempts)
+		events = append(events, timelineEvent{at: tsTime(wh.LastAttemptAt), label: label, detail: wh.ErrorMessage})
+	}
+	if len(wh.StatusHistory) == 0 {
+		// Stored before status changes were recorded
+		if wh.DeliveredAt != nil {
+			events = append(events, timelineEvent{at: tsTime(wh.DeliveredAt), label: "Delivered"})
+		}
+	}
+	for i, ch := range wh.StatusHistory {
+		if i == 0 && ch.FromStatus == hooklyv1.WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED {
+			if ch.ToStatus != hooklyv1.WebhookStatus_WEBHOOK_STATUS_PENDING {
+				events[0].detail = "stored as " + webhookStatusLabel(ch.ToStatus)
+			}
+			continue
+		}
+		label := webhookStatusLabel(ch.ToStatus)
+		ev := timelineEvent{at: tsTime(ch.ChangedAt), label: strings.ToUpper(label[:1]) + label[1:], detail: ch.Reason}
+		if ch.Reason == "replayed" {
+			ev.label, ev.detail = "Replayed", ""
+			if ch.ChangedBy != "" {
+				ev.detail = "by " + ch.ChangedBy
+			}
+		}
+		events = append(events, ev)
+	}
+
+	slices.SortStableFunc(events, func(a, b timelineEvent) int {
+		return a.at.Compare(b.at)
+	})
+	return events
+}
+
+// runWebhooksReplay handles the webhooks replay command.
+func runWebhooksReplay(c *cli.Context) error {
+	statuses, err := parseStatusFilter(c.String("status"))
+	if err != nil {
+		return err
+	}
+	if len(statuses) != 1 {
+		return fmt.Errorf("--status takes a single status")
+	}
+	req := &hooklyv1.BulkReplayWebhooksRequest{
+		Status:   statuses[0],
+		MaxCount: int32(c.Int("max")),
+	}
+	if id := c.String("endpoint"); id != "" {
+		req.EndpointId = &id
+	}
+	now := time.Now()
+	if v := c.String("since"); v != "" {
+		t, err := parseTimeFlag(v, now)
+		if err != nil {
+			return fmt.Errorf("--since: %w", err)
+		}
+		req.ReceivedAfter = timestamppb.New(t)
+	}
+	if v := c.String("until"); v != "" {
+		t, err := parseTimeFlag(v, now)
+		if err != nil {
+			return fmt.Errorf("--until: %w", err)
+		}
+		req.ReceivedBefore = timestamppb.New(t)
+	}
+
+	client, err := newAPIClient()
+	if err != nil {
+		return err
+	}
+
+	replay := func(ctx context.Context) (*connect.Response[hooklyv1.BulkReplayWebhooksResponse], error) {
+		return client.Edge.BulkReplayWebhooks(ctx, connect.NewRequest(req))
+	}
+	resp, err := clicmd.Spin(context.Background(), "Replaying webhooks", replay)
+	if err != nil {
+		return fmt.Errorf("replay webhooks: %w", err)
+	}
+	if resp.Msg.ConfirmationRequired {
+		if !c.Bool("yes") {
+			if !term.IsTerminal(int(os.Stdin.Fd())) {
+				return fmt.Errorf("refusing to replay %d webhooks without confirmation: pass --yes", resp.Msg.MatchingCount)
+			}
+			fmt.Fprintf(os.Stderr, "Replay %d webhooks? [y/N] ", resp.Msg.MatchingCount)
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+				fmt.Fprintln(os.Stderr, "Cancelled.")
+				return nil
+			}
+		}
+		req.ConfirmToken = resp.Msg.ConfirmationToken
+		msg := fmt.Sprintf("Replaying %d webhooks", resp.Msg.MatchingCount)
+		if resp, err = clicmd.Spin(context.Background(), msg, replay); err != nil {
+			return fmt.Errorf("replay webhooks: %w", err)
+		}
+		if resp.Msg.ConfirmationRequired {
+			return fmt.Errorf("%d webhooks match now, more than confirmed; nothing was replayed, run the command again", resp.Msg.MatchingCount)
+		}
+	}
+
+	fmt.Printf("Replayed %d webhooks.\n", resp.Msg.ReplayedCount)
+	return nil
+}
+
+// parseTimeFlag parses a duration before now, such as 24h, or an RFC3339
+// time.
+func parseTimeFlag(v string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(v); err == nil {
+		return now.Add(-d), nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: expected a duration such as 24h or an RFC3339 time", v)
+	}
+	return t, nil
+}
+
+func tsTime(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
+
+func webhookStatusLabel(s hooklyv1.WebhookStatus) string {
+	name := strings.TrimPrefix(s.String(), "WEBHOOK_STATUS_")
+	return strings.ReplaceAll(strings.ToLower(name), "_", " ")
+}
+
+func statusColor(s hooklyv1.WebhookStatus) string {
+	switch s {
+	case hooklyv1.WebhookStatus_WEBHOOK_STATUS_DELIVERED, hooklyv1.WebhookStatus_WEBHOOK_STATUS_ACKNOWLEDGED_DUPLICATE:
+		return colorGreen
+	case hooklyv1.WebhookStatus_WEBHOOK_STATUS_PENDING:
+		return colorYellow
+	case hooklyv1.WebhookStatus_WEBHOOK_STATUS_SKIPPED:
+		return colorDim
+	default:
+		return colorRed
+	}
+}
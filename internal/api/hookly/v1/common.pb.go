@@ -32,6 +32,10 @@ const (
 	ProviderType_PROVIDER_TYPE_TELEGRAM    ProviderType = 3
 	ProviderType_PROVIDER_TYPE_GENERIC     ProviderType = 4
 	ProviderType_PROVIDER_TYPE_CUSTOM      ProviderType = 5
+	ProviderType_PROVIDER_TYPE_SLACK       ProviderType = 6
+	ProviderType_PROVIDER_TYPE_SHOPIFY     ProviderType = 7
+	ProviderType_PROVIDER_TYPE_SQUARE      ProviderType = 8
+	ProviderType_PROVIDER_TYPE_PAYPAL      ProviderType = 9
 )
 
 // Enum value maps for ProviderType.
@@ -43,6 +47,10 @@ var (
 		3: "PROVIDER_TYPE_TELEGRAM",
 		4: "PROVIDER_TYPE_GENERIC",
 		5: "PROVIDER_TYPE_CUSTOM",
+		6: "PROVIDER_TYPE_SLACK",
+		7: "PROVIDER_TYPE_SHOPIFY",
+		8: "PROVIDER_TYPE_SQUARE",
+		9: "PROVIDER_TYPE_PAYPAL",
 	}
 	ProviderType_value = map[string]int32{
 		"PROVIDER_TYPE_UNSPECIFIED": 0,
@@ -51,6 +59,10 @@ var (
 		"PROVIDER_TYPE_TELEGRAM":    3,
 		"PROVIDER_TYPE_GENERIC":     4,
 		"PROVIDER_TYPE_CUSTOM":      5,
+		"PROVIDER_TYPE_SLACK":       6,
+		"PROVIDER_TYPE_SHOPIFY":     7,
+		"PROVIDER_TYPE_SQUARE":      8,
+		"PROVIDER_TYPE_PAYPAL":      9,
 	}
 )
 
@@ -137,15 +149,118 @@ func (VerificationMethod) EnumDescriptor() ([]byte, []int) {
 	return file_hookly_v1_common_proto_rawDescGZIP(), []int{1}
 }
 
+// Credentials required on an endpoint's ingestion URL, checked before the
+// signature, for providers that can send basic auth or a custom header
+type IngestAuthMethod int32
+
+const (
+	IngestAuthMethod_INGEST_AUTH_METHOD_UNSPECIFIED IngestAuthMethod = 0 // No ingestion auth
+	IngestAuthMethod_INGEST_AUTH_METHOD_BASIC       IngestAuthMethod = 1 // HTTP basic auth
+	IngestAuthMethod_INGEST_AUTH_METHOD_HEADER      IngestAuthMethod = 2 // Static token in a header
+)
+
+// Enum value maps for IngestAuthMethod.
+var (
+	IngestAuthMethod_name = map[int32]string{
+		0: "INGEST_AUTH_METHOD_UNSPECIFIED",
+		1: "INGEST_AUTH_METHOD_BASIC",
+		2: "INGEST_AUTH_METHOD_HEADER",
+	}
+	IngestAuthMethod_value = map[string]int32{
+		"INGEST_AUTH_METHOD_UNSPECIFIED": 0,
+		"INGEST_AUTH_METHOD_BASIC":       1,
+		"INGEST_AUTH_METHOD_HEADER":      2,
+	}
+)
+
+func (x IngestAuthMethod) Enum() *IngestAuthMethod {
+	p := new(IngestAuthMethod)
+	*p = x
+	return p
+}
+
+func (x IngestAuthMethod) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (IngestAuthMethod) Descriptor() protoreflect.EnumDescriptor {
+	return file_hookly_v1_common_proto_enumTypes[2].Descriptor()
+}
+
+func (IngestAuthMethod) Type() protoreflect.EnumType {
+	return &file_hookly_v1_common_proto_enumTypes[2]
+}
+
+func (x IngestAuthMethod) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use IngestAuthMethod.Descriptor instead.
+func (IngestAuthMethod) EnumDescriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{2}
+}
+
+// Sort order of endpoint lists
+type EndpointSort int32
+
+const (
+	EndpointSort_ENDPOINT_SORT_UNSPECIFIED   EndpointSort = 0 // Newest first
+	EndpointSort_ENDPOINT_SORT_CREATED_ASC   EndpointSort = 1 // Oldest first
+	EndpointSort_ENDPOINT_SORT_LAST_RECEIVED EndpointSort = 2 // Most recent webhook first, endpoints without webhooks last
+)
+
+// Enum value maps for EndpointSort.
+var (
+	EndpointSort_name = map[int32]string{
+		0: "ENDPOINT_SORT_UNSPECIFIED",
+		1: "ENDPOINT_SORT_CREATED_ASC",
+		2: "ENDPOINT_SORT_LAST_RECEIVED",
+	}
+	EndpointSort_value = map[string]int32{
+		"ENDPOINT_SORT_UNSPECIFIED":   0,
+		"ENDPOINT_SORT_CREATED_ASC":   1,
+		"ENDPOINT_SORT_LAST_RECEIVED": 2,
+	}
+)
+
+func (x EndpointSort) Enum() *EndpointSort {
+	p := new(EndpointSort)
+	*p = x
+	return p
+}
+
+func (x EndpointSort) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (EndpointSort) Descriptor() protoreflect.EnumDescriptor {
+	return file_hookly_v1_common_proto_enumTypes[3].Descriptor()
+}
+
+func (EndpointSort) Type() protoreflect.EnumType {
+	return &file_hookly_v1_common_proto_enumTypes[3]
+}
+
+func (x EndpointSort) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use EndpointSort.Descriptor instead.
+func (EndpointSort) EnumDescriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{3}
+}
+
 // Webhook delivery status
 type WebhookStatus int32
 
 const (
-	WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED WebhookStatus = 0
-	WebhookStatus_WEBHOOK_STATUS_PENDING     WebhookStatus = 1
-	WebhookStatus_WEBHOOK_STATUS_DELIVERED   WebhookStatus = 2
-	WebhookStatus_WEBHOOK_STATUS_FAILED      WebhookStatus = 3
-	WebhookStatus_WEBHOOK_STATUS_DEAD_LETTER WebhookStatus = 4
+	WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED            WebhookStatus = 0
+	WebhookStatus_WEBHOOK_STATUS_PENDING                WebhookStatus = 1
+	WebhookStatus_WEBHOOK_STATUS_DELIVERED              WebhookStatus = 2
+	WebhookStatus_WEBHOOK_STATUS_FAILED                 WebhookStatus = 3
+	WebhookStatus_WEBHOOK_STATUS_DEAD_LETTER            WebhookStatus = 4
+	WebhookStatus_WEBHOOK_STATUS_SKIPPED                WebhookStatus = 5 // Event type not wanted by the hub, not relayed
+	WebhookStatus_WEBHOOK_STATUS_ACKNOWLEDGED_DUPLICATE WebhookStatus = 6 // Destination answered 409: already processed
 )
 
 // Enum value maps for WebhookStatus.
@@ -156,13 +271,17 @@ var (
 		2: "WEBHOOK_STATUS_DELIVERED",
 		3: "WEBHOOK_STATUS_FAILED",
 		4: "WEBHOOK_STATUS_DEAD_LETTER",
+		5: "WEBHOOK_STATUS_SKIPPED",
+		6: "WEBHOOK_STATUS_ACKNOWLEDGED_DUPLICATE",
 	}
 	WebhookStatus_value = map[string]int32{
-		"WEBHOOK_STATUS_UNSPECIFIED": 0,
-		"WEBHOOK_STATUS_PENDING":     1,
-		"WEBHOOK_STATUS_DELIVERED":   2,
-		"WEBHOOK_STATUS_FAILED":      3,
-		"WEBHOOK_STATUS_DEAD_LETTER": 4,
+		"WEBHOOK_STATUS_UNSPECIFIED":            0,
+		"WEBHOOK_STATUS_PENDING":                1,
+		"WEBHOOK_STATUS_DELIVERED":              2,
+		"WEBHOOK_STATUS_FAILED":                 3,
+		"WEBHOOK_STATUS_DEAD_LETTER":            4,
+		"WEBHOOK_STATUS_SKIPPED":                5,
+		"WEBHOOK_STATUS_ACKNOWLEDGED_DUPLICATE": 6,
 	}
 )
 
@@ -177,11 +296,11 @@ func (x WebhookStatus) String() string {
 }
 
 func (WebhookStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_hookly_v1_common_proto_enumTypes[2].Descriptor()
+	return file_hookly_v1_common_proto_enumTypes[4].Descriptor()
 }
 
 func (WebhookStatus) Type() protoreflect.EnumType {
-	return &file_hookly_v1_common_proto_enumTypes[2]
+	return &file_hookly_v1_common_proto_enumTypes[4]
 }
 
 func (x WebhookStatus) Number() protoreflect.EnumNumber {
@@ -190,7 +309,69 @@ func (x WebhookStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use WebhookStatus.Descriptor instead.
 func (WebhookStatus) EnumDescriptor() ([]byte, []int) {
-	return file_hookly_v1_common_proto_rawDescGZIP(), []int{2}
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{4}
+}
+
+// A remote management command for a connected hub
+type HubCommandType int32
+
+const (
+	HubCommandType_HUB_COMMAND_TYPE_UNSPECIFIED   HubCommandType = 0
+	HubCommandType_HUB_COMMAND_TYPE_RELOAD_CONFIG HubCommandType = 1 // Re-read hookly.yaml and reconnect
+	HubCommandType_HUB_COMMAND_TYPE_PAUSE         HubCommandType = 2 // Stop deliveries; webhooks stay pending on the edge
+	HubCommandType_HUB_COMMAND_TYPE_RESUME        HubCommandType = 3
+	HubCommandType_HUB_COMMAND_TYPE_DIAGNOSTICS   HubCommandType = 4 // Report the hub's state and configuration
+	HubCommandType_HUB_COMMAND_TYPE_DISCONNECT    HubCommandType = 5 // Close the stream and stop relaying
+	HubCommandType_HUB_COMMAND_TYPE_LOGS          HubCommandType = 6 // Send recent log lines, if the hub allows it
+)
+
+// Enum value maps for HubCommandType.
+var (
+	HubCommandType_name = map[int32]string{
+		0: "HUB_COMMAND_TYPE_UNSPECIFIED",
+		1: "HUB_COMMAND_TYPE_RELOAD_CONFIG",
+		2: "HUB_COMMAND_TYPE_PAUSE",
+		3: "HUB_COMMAND_TYPE_RESUME",
+		4: "HUB_COMMAND_TYPE_DIAGNOSTICS",
+		5: "HUB_COMMAND_TYPE_DISCONNECT",
+		6: "HUB_COMMAND_TYPE_LOGS",
+	}
+	HubCommandType_value = map[string]int32{
+		"HUB_COMMAND_TYPE_UNSPECIFIED":   0,
+		"HUB_COMMAND_TYPE_RELOAD_CONFIG": 1,
+		"HUB_COMMAND_TYPE_PAUSE":         2,
+		"HUB_COMMAND_TYPE_RESUME":        3,
+		"HUB_COMMAND_TYPE_DIAGNOSTICS":   4,
+		"HUB_COMMAND_TYPE_DISCONNECT":    5,
+		"HUB_COMMAND_TYPE_LOGS":          6,
+	}
+)
+
+func (x HubCommandType) Enum() *HubCommandType {
+	p := new(HubCommandType)
+	*p = x
+	return p
+}
+
+func (x HubCommandType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (HubCommandType) Descriptor() protoreflect.EnumDescriptor {
+	return file_hookly_v1_common_proto_enumTypes[5].Descriptor()
+}
+
+func (HubCommandType) Type() protoreflect.EnumType {
+	return &file_hookly_v1_common_proto_enumTypes[5]
+}
+
+func (x HubCommandType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use HubCommandType.Descriptor instead.
+func (HubCommandType) EnumDescriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{5}
 }
 
 // Theme preference for UI
@@ -236,11 +417,11 @@ func (x ThemePreference) String() string {
 }
 
 func (ThemePreference) Descriptor() protoreflect.EnumDescriptor {
-	return file_hookly_v1_common_proto_enumTypes[3].Descriptor()
+	return file_hookly_v1_common_proto_enumTypes[6].Descriptor()
 }
 
 func (ThemePreference) Type() protoreflect.EnumType {
-	return &file_hookly_v1_common_proto_enumTypes[3]
+	return &file_hookly_v1_common_proto_enumTypes[6]
 }
 
 func (x ThemePreference) Number() protoreflect.EnumNumber {
@@ -249,7 +430,215 @@ func (x ThemePreference) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ThemePreference.Descriptor instead.
 func (ThemePreference) EnumDescriptor() ([]byte, []int) {
-	return file_hookly_v1_common_proto_rawDescGZIP(), []int{3}
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{6}
+}
+
+// What an API token may do
+type TokenScope int32
+
+const (
+	TokenScope_TOKEN_SCOPE_UNSPECIFIED TokenScope = 0
+	TokenScope_TOKEN_SCOPE_ADMIN       TokenScope = 1 // Every API call and relay connections
+	TokenScope_TOKEN_SCOPE_READ        TokenScope = 2 // Read-only API calls (Get, List and Tail)
+	TokenScope_TOKEN_SCOPE_RELAY       TokenScope = 3 // Relay connections only
+)
+
+// Enum value maps for TokenScope.
+var (
+	TokenScope_name = map[int32]string{
+		0: "TOKEN_SCOPE_UNSPECIFIED",
+		1: "TOKEN_SCOPE_ADMIN",
+		2: "TOKEN_SCOPE_READ",
+		3: "TOKEN_SCOPE_RELAY",
+	}
+	TokenScope_value = map[string]int32{
+		"TOKEN_SCOPE_UNSPECIFIED": 0,
+		"TOKEN_SCOPE_ADMIN":       1,
+		"TOKEN_SCOPE_READ":        2,
+		"TOKEN_SCOPE_RELAY":       3,
+	}
+)
+
+func (x TokenScope) Enum() *TokenScope {
+	p := new(TokenScope)
+	*p = x
+	return p
+}
+
+func (x TokenScope) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TokenScope) Descriptor() protoreflect.EnumDescriptor {
+	return file_hookly_v1_common_proto_enumTypes[7].Descriptor()
+}
+
+func (TokenScope) Type() protoreflect.EnumType {
+	return &file_hookly_v1_common_proto_enumTypes[7]
+}
+
+func (x TokenScope) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TokenScope.Descriptor instead.
+func (TokenScope) EnumDescriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{7}
+}
+
+// A member's role in an org
+type OrgRole int32
+
+const (
+	OrgRole_ORG_ROLE_UNSPECIFIED OrgRole = 0
+	OrgRole_ORG_ROLE_OWNER       OrgRole = 1 // Everything, including members and deleting the org
+	OrgRole_ORG_ROLE_MEMBER      OrgRole = 2 // Manage the org's endpoints and relay their webhooks
+	OrgRole_ORG_ROLE_VIEWER      OrgRole = 3 // Read-only access to the org's endpoints and webhooks
+)
+
+// Enum value maps for OrgRole.
+var (
+	OrgRole_name = map[int32]string{
+		0: "ORG_ROLE_UNSPECIFIED",
+		1: "ORG_ROLE_OWNER",
+		2: "ORG_ROLE_MEMBER",
+		3: "ORG_ROLE_VIEWER",
+	}
+	OrgRole_value = map[string]int32{
+		"ORG_ROLE_UNSPECIFIED": 0,
+		"ORG_ROLE_OWNER":       1,
+		"ORG_ROLE_MEMBER":      2,
+		"ORG_ROLE_VIEWER":      3,
+	}
+)
+
+func (x OrgRole) Enum() *OrgRole {
+	p := new(OrgRole)
+	*p = x
+	return p
+}
+
+func (x OrgRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrgRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_hookly_v1_common_proto_enumTypes[8].Descriptor()
+}
+
+func (OrgRole) Type() protoreflect.EnumType {
+	return &file_hookly_v1_common_proto_enumTypes[8]
+}
+
+func (x OrgRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrgRole.Descriptor instead.
+func (OrgRole) EnumDescriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{8}
+}
+
+// Kind of activity feed entry
+type ActivityKind int32
+
+const (
+	ActivityKind_ACTIVITY_KIND_UNSPECIFIED      ActivityKind = 0
+	ActivityKind_ACTIVITY_KIND_DELIVERIES       ActivityKind = 1 // Webhooks delivered to an endpoint within an hour
+	ActivityKind_ACTIVITY_KIND_HUB_CONNECTED    ActivityKind = 2 // A relay hub connected
+	ActivityKind_ACTIVITY_KIND_HUB_DISCONNECTED ActivityKind = 3 // A relay hub disconnected
+)
+
+// Enum value maps for ActivityKind.
+var (
+	ActivityKind_name = map[int32]string{
+		0: "ACTIVITY_KIND_UNSPECIFIED",
+		1: "ACTIVITY_KIND_DELIVERIES",
+		2: "ACTIVITY_KIND_HUB_CONNECTED",
+		3: "ACTIVITY_KIND_HUB_DISCONNECTED",
+	}
+	ActivityKind_value = map[string]int32{
+		"ACTIVITY_KIND_UNSPECIFIED":      0,
+		"ACTIVITY_KIND_DELIVERIES":       1,
+		"ACTIVITY_KIND_HUB_CONNECTED":    2,
+		"ACTIVITY_KIND_HUB_DISCONNECTED": 3,
+	}
+)
+
+func (x ActivityKind) Enum() *ActivityKind {
+	p := new(ActivityKind)
+	*p = x
+	return p
+}
+
+func (x ActivityKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ActivityKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_hookly_v1_common_proto_enumTypes[9].Descriptor()
+}
+
+func (ActivityKind) Type() protoreflect.EnumType {
+	return &file_hookly_v1_common_proto_enumTypes[9]
+}
+
+func (x ActivityKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ActivityKind.Descriptor instead.
+func (ActivityKind) EnumDescriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{9}
+}
+
+type ConnectionEventType int32
+
+const (
+	ConnectionEventType_CONNECTION_EVENT_TYPE_UNSPECIFIED  ConnectionEventType = 0
+	ConnectionEventType_CONNECTION_EVENT_TYPE_CONNECTED    ConnectionEventType = 1 // A hub serving the endpoint connected
+	ConnectionEventType_CONNECTION_EVENT_TYPE_DISCONNECTED ConnectionEventType = 2 // The hub serving the endpoint disconnected
+)
+
+// Enum value maps for ConnectionEventType.
+var (
+	ConnectionEventType_name = map[int32]string{
+		0: "CONNECTION_EVENT_TYPE_UNSPECIFIED",
+		1: "CONNECTION_EVENT_TYPE_CONNECTED",
+		2: "CONNECTION_EVENT_TYPE_DISCONNECTED",
+	}
+	ConnectionEventType_value = map[string]int32{
+		"CONNECTION_EVENT_TYPE_UNSPECIFIED":  0,
+		"CONNECTION_EVENT_TYPE_CONNECTED":    1,
+		"CONNECTION_EVENT_TYPE_DISCONNECTED": 2,
+	}
+)
+
+func (x ConnectionEventType) Enum() *ConnectionEventType {
+	p := new(ConnectionEventType)
+	*p = x
+	return p
+}
+
+func (x ConnectionEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ConnectionEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_hookly_v1_common_proto_enumTypes[10].Descriptor()
+}
+
+func (ConnectionEventType) Type() protoreflect.EnumType {
+	return &file_hookly_v1_common_proto_enumTypes[10]
+}
+
+func (x ConnectionEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ConnectionEventType.Descriptor instead.
+func (ConnectionEventType) EnumDescriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{10}
 }
 
 // Custom verification configuration for PROVIDER_TYPE_CUSTOM
@@ -329,37 +718,31 @@ func (x *VerificationConfig) GetTimestampTolerance() int64 {
 	return 0
 }
 
-// Endpoint configuration
-type Endpoint struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	ProviderType   ProviderType           `protobuf:"varint,3,opt,name=provider_type,json=providerType,proto3,enum=hookly.v1.ProviderType" json:"provider_type,omitempty"`
-	DestinationUrl string                 `protobuf:"bytes,4,opt,name=destination_url,json=destinationUrl,proto3" json:"destination_url,omitempty"`
-	Muted          bool                   `protobuf:"varint,5,opt,name=muted,proto3" json:"muted,omitempty"`
-	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	// Note: signature_secret is not exposed in API responses
-	// Custom verification config (only for PROVIDER_TYPE_CUSTOM)
-	VerificationConfig *VerificationConfig `protobuf:"bytes,8,opt,name=verification_config,json=verificationConfig,proto3" json:"verification_config,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+// Ingestion auth configuration of an endpoint
+type IngestAuth struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Method        IngestAuthMethod       `protobuf:"varint,1,opt,name=method,proto3,enum=hookly.v1.IngestAuthMethod" json:"method,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"` // Basic auth user name
+	Header        string                 `protobuf:"bytes,3,opt,name=header,proto3" json:"header,omitempty"`     // Header carrying the token (default Authorization, with or without "Bearer ")
+	Secret        string                 `protobuf:"bytes,4,opt,name=secret,proto3" json:"secret,omitempty"`     // Password or token. Write-only, encrypted at rest
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Endpoint) Reset() {
-	*x = Endpoint{}
+func (x *IngestAuth) Reset() {
+	*x = IngestAuth{}
 	mi := &file_hookly_v1_common_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Endpoint) String() string {
+func (x *IngestAuth) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Endpoint) ProtoMessage() {}
+func (*IngestAuth) ProtoMessage() {}
 
-func (x *Endpoint) ProtoReflect() protoreflect.Message {
+func (x *IngestAuth) ProtoReflect() protoreflect.Message {
 	mi := &file_hookly_v1_common_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -371,100 +754,127 @@ func (x *Endpoint) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Endpoint.ProtoReflect.Descriptor instead.
-func (*Endpoint) Descriptor() ([]byte, []int) {
+// Deprecated: Use IngestAuth.ProtoReflect.Descriptor instead.
+func (*IngestAuth) Descriptor() ([]byte, []int) {
 	return file_hookly_v1_common_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *Endpoint) GetId() string {
+func (x *IngestAuth) GetMethod() IngestAuthMethod {
 	if x != nil {
-		return x.Id
+		return x.Method
 	}
-	return ""
+	return IngestAuthMethod_INGEST_AUTH_METHOD_UNSPECIFIED
 }
 
-func (x *Endpoint) GetName() string {
+func (x *IngestAuth) GetUsername() string {
 	if x != nil {
-		return x.Name
+		return x.Username
 	}
 	return ""
 }
 
-func (x *Endpoint) GetProviderType() ProviderType {
+func (x *IngestAuth) GetHeader() string {
 	if x != nil {
-		return x.ProviderType
+		return x.Header
 	}
-	return ProviderType_PROVIDER_TYPE_UNSPECIFIED
+	return ""
 }
 
-func (x *Endpoint) GetDestinationUrl() string {
+func (x *IngestAuth) GetSecret() string {
 	if x != nil {
-		return x.DestinationUrl
+		return x.Secret
 	}
 	return ""
 }
 
-func (x *Endpoint) GetMuted() bool {
-	if x != nil {
-		return x.Muted
-	}
-	return false
+// Rewrites a webhook on the hub before it is forwarded. extract runs
+// first, then template; headers are set last, over the received ones.
+type Transform struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Extract       string                 `protobuf:"bytes,1,opt,name=extract,proto3" json:"extract,omitempty"`                                                                           // JSON path whose value becomes the body
+	Template      string                 `protobuf:"bytes,2,opt,name=template,proto3" json:"template,omitempty"`                                                                         // Go text/template producing the body
+	Headers       map[string]string      `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Static headers to set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Endpoint) GetCreatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.CreatedAt
-	}
-	return nil
+func (x *Transform) Reset() {
+	*x = Transform{}
+	mi := &file_hookly_v1_common_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Endpoint) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *Transform) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transform) ProtoMessage() {}
+
+func (x *Transform) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[2]
 	if x != nil {
-		return x.UpdatedAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Endpoint) GetVerificationConfig() *VerificationConfig {
+// Deprecated: Use Transform.ProtoReflect.Descriptor instead.
+func (*Transform) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Transform) GetExtract() string {
 	if x != nil {
-		return x.VerificationConfig
+		return x.Extract
+	}
+	return ""
+}
+
+func (x *Transform) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *Transform) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
 	}
 	return nil
 }
 
-// Webhook record
-type Webhook struct {
-	state          protoimpl.MessageState `protogen:"open.v1"`
-	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	EndpointId     string                 `protobuf:"bytes,2,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
-	ReceivedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
-	Headers        map[string]string      `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	Payload        []byte                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
-	SignatureValid bool                   `protobuf:"varint,6,opt,name=signature_valid,json=signatureValid,proto3" json:"signature_valid,omitempty"`
-	Status         WebhookStatus          `protobuf:"varint,7,opt,name=status,proto3,enum=hookly.v1.WebhookStatus" json:"status,omitempty"`
-	Attempts       int32                  `protobuf:"varint,8,opt,name=attempts,proto3" json:"attempts,omitempty"`
-	LastAttemptAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_attempt_at,json=lastAttemptAt,proto3" json:"last_attempt_at,omitempty"`
-	DeliveredAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
-	ErrorMessage   string                 `protobuf:"bytes,11,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+// What ingestion answers once a webhook is stored. Unset answers 200 with no
+// body.
+type IngestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StatusCode    int32                  `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`   // 2xx; 0 for 200
+	Body          string                 `protobuf:"bytes,2,opt,name=body,proto3" json:"body,omitempty"`                                  // Static body, up to 4KB
+	ContentType   string                 `protobuf:"bytes,3,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"` // Defaults to text/plain when there is a body
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Webhook) Reset() {
-	*x = Webhook{}
-	mi := &file_hookly_v1_common_proto_msgTypes[2]
+func (x *IngestResponse) Reset() {
+	*x = IngestResponse{}
+	mi := &file_hookly_v1_common_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Webhook) String() string {
+func (x *IngestResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Webhook) ProtoMessage() {}
+func (*IngestResponse) ProtoMessage() {}
 
-func (x *Webhook) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_common_proto_msgTypes[2]
+func (x *IngestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -475,112 +885,181 @@ func (x *Webhook) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
-func (*Webhook) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_common_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use IngestResponse.ProtoReflect.Descriptor instead.
+func (*IngestResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *Webhook) GetId() string {
+func (x *IngestResponse) GetStatusCode() int32 {
 	if x != nil {
-		return x.Id
+		return x.StatusCode
 	}
-	return ""
+	return 0
 }
 
-func (x *Webhook) GetEndpointId() string {
+func (x *IngestResponse) GetBody() string {
 	if x != nil {
-		return x.EndpointId
+		return x.Body
 	}
 	return ""
 }
 
-func (x *Webhook) GetReceivedAt() *timestamppb.Timestamp {
+func (x *IngestResponse) GetContentType() string {
 	if x != nil {
-		return x.ReceivedAt
+		return x.ContentType
 	}
-	return nil
+	return ""
 }
 
-func (x *Webhook) GetHeaders() map[string]string {
+// How transient delivery failures of an endpoint's webhooks are retried. The
+// zero value is the default: unlimited attempts, backoff doubling from 1s up
+// to 1h, no jitter.
+type RetryPolicy struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	MaxAttempts        int32                  `protobuf:"varint,1,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`                        // Attempts before the webhook fails; 0 for unlimited
+	BackoffBaseSeconds int32                  `protobuf:"varint,2,opt,name=backoff_base_seconds,json=backoffBaseSeconds,proto3" json:"backoff_base_seconds,omitempty"` // Delay before the first retry, doubled after each; 0 for 1s
+	MaxIntervalSeconds int32                  `protobuf:"varint,3,opt,name=max_interval_seconds,json=maxIntervalSeconds,proto3" json:"max_interval_seconds,omitempty"` // Cap on the delay; 0 for 1h
+	Jitter             float64                `protobuf:"fixed64,4,opt,name=jitter,proto3" json:"jitter,omitempty"`                                                    // Fraction of each delay randomly taken off, in [0, 1]
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *RetryPolicy) Reset() {
+	*x = RetryPolicy{}
+	mi := &file_hookly_v1_common_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryPolicy) ProtoMessage() {}
+
+func (x *RetryPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[4]
 	if x != nil {
-		return x.Headers
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Webhook) GetPayload() []byte {
+// Deprecated: Use RetryPolicy.ProtoReflect.Descriptor instead.
+func (*RetryPolicy) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RetryPolicy) GetMaxAttempts() int32 {
 	if x != nil {
-		return x.Payload
+		return x.MaxAttempts
 	}
-	return nil
+	return 0
 }
 
-func (x *Webhook) GetSignatureValid() bool {
+func (x *RetryPolicy) GetBackoffBaseSeconds() int32 {
 	if x != nil {
-		return x.SignatureValid
+		return x.BackoffBaseSeconds
 	}
-	return false
+	return 0
 }
 
-func (x *Webhook) GetStatus() WebhookStatus {
+func (x *RetryPolicy) GetMaxIntervalSeconds() int32 {
 	if x != nil {
-		return x.Status
+		return x.MaxIntervalSeconds
 	}
-	return WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED
+	return 0
 }
 
-func (x *Webhook) GetAttempts() int32 {
+func (x *RetryPolicy) GetJitter() float64 {
 	if x != nil {
-		return x.Attempts
+		return x.Jitter
 	}
 	return 0
 }
 
-func (x *Webhook) GetLastAttemptAt() *timestamppb.Timestamp {
+// Restrictions on the webhooks an endpoint accepts, answered with 413 and
+// 415. The zero value accepts any content type up to the edge's 100 MB.
+type PayloadLimits struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MaxBytes      int64                  `protobuf:"varint,1,opt,name=max_bytes,json=maxBytes,proto3" json:"max_bytes,omitempty"`            // Largest payload accepted; 0 for 100 MB
+	ContentTypes  []string               `protobuf:"bytes,2,rep,name=content_types,json=contentTypes,proto3" json:"content_types,omitempty"` // Media types accepted, type/* for any subtype; empty for any
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayloadLimits) Reset() {
+	*x = PayloadLimits{}
+	mi := &file_hookly_v1_common_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayloadLimits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayloadLimits) ProtoMessage() {}
+
+func (x *PayloadLimits) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[5]
 	if x != nil {
-		return x.LastAttemptAt
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Webhook) GetDeliveredAt() *timestamppb.Timestamp {
+// Deprecated: Use PayloadLimits.ProtoReflect.Descriptor instead.
+func (*PayloadLimits) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *PayloadLimits) GetMaxBytes() int64 {
 	if x != nil {
-		return x.DeliveredAt
+		return x.MaxBytes
 	}
-	return nil
+	return 0
 }
 
-func (x *Webhook) GetErrorMessage() string {
+func (x *PayloadLimits) GetContentTypes() []string {
 	if x != nil {
-		return x.ErrorMessage
+		return x.ContentTypes
 	}
-	return ""
+	return nil
 }
 
-// Pagination request parameters
-type PaginationRequest struct {
+// A destination an endpoint fans out to besides its destination_url
+type Destination struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
-	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PaginationRequest) Reset() {
-	*x = PaginationRequest{}
-	mi := &file_hookly_v1_common_proto_msgTypes[3]
+func (x *Destination) Reset() {
+	*x = Destination{}
+	mi := &file_hookly_v1_common_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PaginationRequest) String() string {
+func (x *Destination) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PaginationRequest) ProtoMessage() {}
+func (*Destination) ProtoMessage() {}
 
-func (x *PaginationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_common_proto_msgTypes[3]
+func (x *Destination) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -591,49 +1070,55 @@ func (x *PaginationRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PaginationRequest.ProtoReflect.Descriptor instead.
-func (*PaginationRequest) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_common_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use Destination.ProtoReflect.Descriptor instead.
+func (*Destination) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *PaginationRequest) GetPageSize() int32 {
+func (x *Destination) GetId() string {
 	if x != nil {
-		return x.PageSize
+		return x.Id
 	}
-	return 0
+	return ""
 }
 
-func (x *PaginationRequest) GetPageToken() string {
+func (x *Destination) GetUrl() string {
 	if x != nil {
-		return x.PageToken
+		return x.Url
 	}
 	return ""
 }
 
-// Pagination response metadata
-type PaginationResponse struct {
+// Delivery of a fanned-out webhook to one destination
+type DestinationDelivery struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	NextPageToken string                 `protobuf:"bytes,1,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
-	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	DestinationId string                 `protobuf:"bytes,1,opt,name=destination_id,json=destinationId,proto3" json:"destination_id,omitempty"` // Empty for the endpoint's destination_url
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Status        WebhookStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=hookly.v1.WebhookStatus" json:"status,omitempty"` // Pending while retried, delivered or failed
+	Attempts      int32                  `protobuf:"varint,4,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	StatusCode    int32                  `protobuf:"varint,5,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"` // Of the last attempt, 0 if it got no response
+	ErrorMessage  string                 `protobuf:"bytes,6,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	LastAttemptAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_attempt_at,json=lastAttemptAt,proto3" json:"last_attempt_at,omitempty"`
+	DeliveredAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *PaginationResponse) Reset() {
-	*x = PaginationResponse{}
-	mi := &file_hookly_v1_common_proto_msgTypes[4]
+func (x *DestinationDelivery) Reset() {
+	*x = DestinationDelivery{}
+	mi := &file_hookly_v1_common_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *PaginationResponse) String() string {
+func (x *DestinationDelivery) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*PaginationResponse) ProtoMessage() {}
+func (*DestinationDelivery) ProtoMessage() {}
 
-func (x *PaginationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_common_proto_msgTypes[4]
+func (x *DestinationDelivery) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -644,49 +1129,148 @@ func (x *PaginationResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use PaginationResponse.ProtoReflect.Descriptor instead.
-func (*PaginationResponse) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_common_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use DestinationDelivery.ProtoReflect.Descriptor instead.
+func (*DestinationDelivery) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *PaginationResponse) GetNextPageToken() string {
+func (x *DestinationDelivery) GetDestinationId() string {
 	if x != nil {
-		return x.NextPageToken
+		return x.DestinationId
 	}
 	return ""
 }
 
-func (x *PaginationResponse) GetTotalCount() int32 {
+func (x *DestinationDelivery) GetUrl() string {
 	if x != nil {
-		return x.TotalCount
+		return x.Url
+	}
+	return ""
+}
+
+func (x *DestinationDelivery) GetStatus() WebhookStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED
+}
+
+func (x *DestinationDelivery) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
 	}
 	return 0
 }
 
-// Connected endpoint info for status display
-type ConnectedEndpoint struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+func (x *DestinationDelivery) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *DestinationDelivery) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *DestinationDelivery) GetLastAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAttemptAt
+	}
+	return nil
+}
+
+func (x *DestinationDelivery) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+// Endpoint configuration
+type Endpoint struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name           string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ProviderType   ProviderType           `protobuf:"varint,3,opt,name=provider_type,json=providerType,proto3,enum=hookly.v1.ProviderType" json:"provider_type,omitempty"`
+	DestinationUrl string                 `protobuf:"bytes,4,opt,name=destination_url,json=destinationUrl,proto3" json:"destination_url,omitempty"`
+	Muted          bool                   `protobuf:"varint,5,opt,name=muted,proto3" json:"muted,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	// Note: signature_secret is not exposed in API responses
+	// Custom verification config (only for PROVIDER_TYPE_CUSTOM)
+	VerificationConfig *VerificationConfig `protobuf:"bytes,8,opt,name=verification_config,json=verificationConfig,proto3" json:"verification_config,omitempty"`
+	// Send a notification when the first webhook arrives
+	NotifyFirstEvent bool `protobuf:"varint,9,opt,name=notify_first_event,json=notifyFirstEvent,proto3" json:"notify_first_event,omitempty"`
+	// When the first webhook was received. Unset while waiting for the first event.
+	FirstEventAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=first_event_at,json=firstEventAt,proto3" json:"first_event_at,omitempty"`
+	// True if a Telegram bot token is stored for setWebhook automation
+	HasTelegramBotToken bool `protobuf:"varint,11,opt,name=has_telegram_bot_token,json=hasTelegramBotToken,proto3" json:"has_telegram_bot_token,omitempty"`
+	// Delivery SLO: percent of webhooks to deliver within slo_latency_seconds
+	// over the last slo_window_hours. 0 disables the SLO.
+	SloTarget         float64 `protobuf:"fixed64,12,opt,name=slo_target,json=sloTarget,proto3" json:"slo_target,omitempty"`
+	SloLatencySeconds int32   `protobuf:"varint,13,opt,name=slo_latency_seconds,json=sloLatencySeconds,proto3" json:"slo_latency_seconds,omitempty"`
+	SloWindowHours    int32   `protobuf:"varint,14,opt,name=slo_window_hours,json=sloWindowHours,proto3" json:"slo_window_hours,omitempty"`
+	// Drop re-deliveries of a known provider delivery ID instead of storing
+	// them flagged as duplicates
+	RejectDuplicates bool `protobuf:"varint,15,opt,name=reject_duplicates,json=rejectDuplicates,proto3" json:"reject_duplicates,omitempty"`
+	// Region of the edge the endpoint was created on. Empty on single-region
+	// services.
+	HomeRegion string `protobuf:"bytes,16,opt,name=home_region,json=homeRegion,proto3" json:"home_region,omitempty"`
+	// Credentials required at the ingestion URL, without the secret. Unset if
+	// the URL is open.
+	IngestAuth *IngestAuth `protobuf:"bytes,17,opt,name=ingest_auth,json=ingestAuth,proto3" json:"ingest_auth,omitempty"`
+	// Never relays: every request to the URL, with any method, is stored and
+	// alerts the owner. For detecting leaked URLs and canary tokens.
+	Honeypot bool `protobuf:"varint,18,opt,name=honeypot,proto3" json:"honeypot,omitempty"`
+	// When the last webhook was stored. Unset if none has been received.
+	LastWebhookReceivedAt *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=last_webhook_received_at,json=lastWebhookReceivedAt,proto3" json:"last_webhook_received_at,omitempty"`
+	// When the hub last acknowledged a delivery. Unset if none was delivered.
+	LastDeliveredAt *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=last_delivered_at,json=lastDeliveredAt,proto3" json:"last_delivered_at,omitempty"`
+	// When the endpoint was muted automatically for inactivity. Unmuting
+	// clears it.
+	ArchivedAt *timestamppb.Timestamp `protobuf:"bytes,21,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	// Record a webhook the destination answers with 409 Conflict as
+	// acknowledged_duplicate, already processed, instead of retrying it
+	ConflictAsDuplicate bool `protobuf:"varint,22,opt,name=conflict_as_duplicate,json=conflictAsDuplicate,proto3" json:"conflict_as_duplicate,omitempty"`
+	// Ingestion rate limit in requests per minute; 0 uses the edge's limit
+	RateLimitPerMinute int32 `protobuf:"varint,23,opt,name=rate_limit_per_minute,json=rateLimitPerMinute,proto3" json:"rate_limit_per_minute,omitempty"`
+	// Applied by the hub before forwarding. Unset forwards webhooks as
+	// received.
+	Transform *Transform `protobuf:"bytes,24,opt,name=transform,proto3" json:"transform,omitempty"`
+	// Also delivered to, each tracked separately. Set by GetEndpoint and
+	// UpdateEndpoint, not in lists.
+	Destinations []*Destination `protobuf:"bytes,25,rep,name=destinations,proto3" json:"destinations,omitempty"`
+	// Answer HEAD and OPTIONS at the ingestion URL with 204, for providers
+	// that probe it and dashboards that send CORS preflights. Otherwise 405.
+	AnswerProbes   bool            `protobuf:"varint,26,opt,name=answer_probes,json=answerProbes,proto3" json:"answer_probes,omitempty"`
+	IngestResponse *IngestResponse `protobuf:"bytes,27,opt,name=ingest_response,json=ingestResponse,proto3" json:"ingest_response,omitempty"`
+	RetryPolicy    *RetryPolicy    `protobuf:"bytes,28,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
+	PayloadLimits  *PayloadLimits  `protobuf:"bytes,29,opt,name=payload_limits,json=payloadLimits,proto3" json:"payload_limits,omitempty"`
+	// Full URL providers send webhooks to, under the edge's path prefix.
+	WebhookUrl    string `protobuf:"bytes,30,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ConnectedEndpoint) Reset() {
-	*x = ConnectedEndpoint{}
-	mi := &file_hookly_v1_common_proto_msgTypes[5]
+func (x *Endpoint) Reset() {
+	*x = Endpoint{}
+	mi := &file_hookly_v1_common_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ConnectedEndpoint) String() string {
+func (x *Endpoint) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ConnectedEndpoint) ProtoMessage() {}
+func (*Endpoint) ProtoMessage() {}
 
-func (x *ConnectedEndpoint) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_common_proto_msgTypes[5]
+func (x *Endpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -697,58 +1281,1704 @@ func (x *ConnectedEndpoint) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ConnectedEndpoint.ProtoReflect.Descriptor instead.
-func (*ConnectedEndpoint) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_common_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use Endpoint.ProtoReflect.Descriptor instead.
+func (*Endpoint) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *ConnectedEndpoint) GetId() string {
+func (x *Endpoint) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Endpoint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Endpoint) GetProviderType() ProviderType {
+	if x != nil {
+		return x.ProviderType
+	}
+	return ProviderType_PROVIDER_TYPE_UNSPECIFIED
+}
+
+func (x *Endpoint) GetDestinationUrl() string {
+	if x != nil {
+		return x.DestinationUrl
+	}
+	return ""
+}
+
+func (x *Endpoint) GetMuted() bool {
+	if x != nil {
+		return x.Muted
+	}
+	return false
+}
+
+func (x *Endpoint) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Endpoint) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Endpoint) GetVerificationConfig() *VerificationConfig {
+	if x != nil {
+		return x.VerificationConfig
+	}
+	return nil
+}
+
+func (x *Endpoint) GetNotifyFirstEvent() bool {
+	if x != nil {
+		return x.NotifyFirstEvent
+	}
+	return false
+}
+
+func (x *Endpoint) GetFirstEventAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstEventAt
+	}
+	return nil
+}
+
+func (x *Endpoint) GetHasTelegramBotToken() bool {
+	if x != nil {
+		return x.HasTelegramBotToken
+	}
+	return false
+}
+
+func (x *Endpoint) GetSloTarget() float64 {
+	if x != nil {
+		return x.SloTarget
+	}
+	return 0
+}
+
+func (x *Endpoint) GetSloLatencySeconds() int32 {
+	if x != nil {
+		return x.SloLatencySeconds
+	}
+	return 0
+}
+
+func (x *Endpoint) GetSloWindowHours() int32 {
+	if x != nil {
+		return x.SloWindowHours
+	}
+	return 0
+}
+
+func (x *Endpoint) GetRejectDuplicates() bool {
+	if x != nil {
+		return x.RejectDuplicates
+	}
+	return false
+}
+
+func (x *Endpoint) GetHomeRegion() string {
+	if x != nil {
+		return x.HomeRegion
+	}
+	return ""
+}
+
+func (x *Endpoint) GetIngestAuth() *IngestAuth {
+	if x != nil {
+		return x.IngestAuth
+	}
+	return nil
+}
+
+func (x *Endpoint) GetHoneypot() bool {
+	if x != nil {
+		return x.Honeypot
+	}
+	return false
+}
+
+func (x *Endpoint) GetLastWebhookReceivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastWebhookReceivedAt
+	}
+	return nil
+}
+
+func (x *Endpoint) GetLastDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastDeliveredAt
+	}
+	return nil
+}
+
+func (x *Endpoint) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+func (x *Endpoint) GetConflictAsDuplicate() bool {
+	if x != nil {
+		return x.ConflictAsDuplicate
+	}
+	return false
+}
+
+func (x *Endpoint) GetRateLimitPerMinute() int32 {
+	if x != nil {
+		return x.RateLimitPerMinute
+	}
+	return 0
+}
+
+func (x *Endpoint) GetTransform() *Transform {
+	if x != nil {
+		return x.Transform
+	}
+	return nil
+}
+
+func (x *Endpoint) GetDestinations() []*Destination {
+	if x != nil {
+		return x.Destinations
+	}
+	return nil
+}
+
+func (x *Endpoint) GetAnswerProbes() bool {
+	if x != nil {
+		return x.AnswerProbes
+	}
+	return false
+}
+
+func (x *Endpoint) GetIngestResponse() *IngestResponse {
+	if x != nil {
+		return x.IngestResponse
+	}
+	return nil
+}
+
+func (x *Endpoint) GetRetryPolicy() *RetryPolicy {
+	if x != nil {
+		return x.RetryPolicy
+	}
+	return nil
+}
+
+func (x *Endpoint) GetPayloadLimits() *PayloadLimits {
+	if x != nil {
+		return x.PayloadLimits
+	}
+	return nil
+}
+
+func (x *Endpoint) GetWebhookUrl() string {
+	if x != nil {
+		return x.WebhookUrl
+	}
+	return ""
+}
+
+// Webhook record
+type Webhook struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EndpointId     string                 `protobuf:"bytes,2,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	ReceivedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=received_at,json=receivedAt,proto3" json:"received_at,omitempty"`
+	Headers        map[string]string      `protobuf:"bytes,4,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Payload        []byte                 `protobuf:"bytes,5,opt,name=payload,proto3" json:"payload,omitempty"`
+	SignatureValid bool                   `protobuf:"varint,6,opt,name=signature_valid,json=signatureValid,proto3" json:"signature_valid,omitempty"`
+	Status         WebhookStatus          `protobuf:"varint,7,opt,name=status,proto3,enum=hookly.v1.WebhookStatus" json:"status,omitempty"`
+	Attempts       int32                  `protobuf:"varint,8,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	LastAttemptAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=last_attempt_at,json=lastAttemptAt,proto3" json:"last_attempt_at,omitempty"`
+	DeliveredAt    *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=delivered_at,json=deliveredAt,proto3" json:"delivered_at,omitempty"`
+	ErrorMessage   string                 `protobuf:"bytes,11,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// Provider event type (Stripe type, X-GitHub-Event, ...), empty if unknown
+	EventType string `protobuf:"bytes,12,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	// First 4 KB of the payload, always set
+	PayloadPreview []byte `protobuf:"bytes,13,opt,name=payload_preview,json=payloadPreview,proto3" json:"payload_preview,omitempty"`
+	PayloadSize    int64  `protobuf:"varint,14,opt,name=payload_size,json=payloadSize,proto3" json:"payload_size,omitempty"`
+	// True if payload_preview is shorter than the payload
+	PayloadTruncated bool `protobuf:"varint,15,opt,name=payload_truncated,json=payloadTruncated,proto3" json:"payload_truncated,omitempty"`
+	// Provider delivery ID (X-GitHub-Delivery, Stripe event id, Svix webhook-id)
+	DeliveryId string `protobuf:"bytes,16,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	// ID of an earlier webhook with the same delivery ID, if this is a re-delivery
+	DuplicateOf string `protobuf:"bytes,17,opt,name=duplicate_of,json=duplicateOf,proto3" json:"duplicate_of,omitempty"`
+	// Client IP the webhook came from, resolved through trusted proxies
+	SourceIp string `protobuf:"bytes,18,opt,name=source_ip,json=sourceIp,proto3" json:"source_ip,omitempty"`
+	// Status changes, oldest first. Only set by GetWebhook, and empty for
+	// webhooks stored before changes were recorded.
+	StatusHistory []*WebhookStatusChange `protobuf:"bytes,19,rep,name=status_history,json=statusHistory,proto3" json:"status_history,omitempty"`
+	// Last replay, and who made it; unset if never replayed
+	ReplayedAt  *timestamppb.Timestamp `protobuf:"bytes,20,opt,name=replayed_at,json=replayedAt,proto3" json:"replayed_at,omitempty"`
+	ReplayedBy  string                 `protobuf:"bytes,21,opt,name=replayed_by,json=replayedBy,proto3" json:"replayed_by,omitempty"`
+	ReplayCount int32                  `protobuf:"varint,22,opt,name=replay_count,json=replayCount,proto3" json:"replay_count,omitempty"`
+	// OpenTelemetry trace of the webhook's ingestion and delivery, hex-encoded;
+	// empty for webhooks received before tracing
+	TraceId string `protobuf:"bytes,23,opt,name=trace_id,json=traceId,proto3" json:"trace_id,omitempty"`
+	// When retention cleanup purged the webhook, unset if it wasn't. A purged
+	// webhook can be undeleted until it is deleted at purge_expires_at.
+	PurgedAt       *timestamppb.Timestamp `protobuf:"bytes,24,opt,name=purged_at,json=purgedAt,proto3" json:"purged_at,omitempty"`
+	PurgeExpiresAt *timestamppb.Timestamp `protobuf:"bytes,25,opt,name=purge_expires_at,json=purgeExpiresAt,proto3" json:"purge_expires_at,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Webhook) Reset() {
+	*x = Webhook{}
+	mi := &file_hookly_v1_common_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Webhook) ProtoMessage() {}
+
+func (x *Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Webhook.ProtoReflect.Descriptor instead.
+func (*Webhook) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *Webhook) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Webhook) GetEndpointId() string {
+	if x != nil {
+		return x.EndpointId
+	}
+	return ""
+}
+
+func (x *Webhook) GetReceivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceivedAt
+	}
+	return nil
+}
+
+func (x *Webhook) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *Webhook) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Webhook) GetSignatureValid() bool {
+	if x != nil {
+		return x.SignatureValid
+	}
+	return false
+}
+
+func (x *Webhook) GetStatus() WebhookStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED
+}
+
+func (x *Webhook) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *Webhook) GetLastAttemptAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastAttemptAt
+	}
+	return nil
+}
+
+func (x *Webhook) GetDeliveredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeliveredAt
+	}
+	return nil
+}
+
+func (x *Webhook) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *Webhook) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *Webhook) GetPayloadPreview() []byte {
+	if x != nil {
+		return x.PayloadPreview
+	}
+	return nil
+}
+
+func (x *Webhook) GetPayloadSize() int64 {
+	if x != nil {
+		return x.PayloadSize
+	}
+	return 0
+}
+
+func (x *Webhook) GetPayloadTruncated() bool {
+	if x != nil {
+		return x.PayloadTruncated
+	}
+	return false
+}
+
+func (x *Webhook) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+func (x *Webhook) GetDuplicateOf() string {
+	if x != nil {
+		return x.DuplicateOf
+	}
+	return ""
+}
+
+func (x *Webhook) GetSourceIp() string {
+	if x != nil {
+		return x.SourceIp
+	}
+	return ""
+}
+
+func (x *Webhook) GetStatusHistory() []*WebhookStatusChange {
+	if x != nil {
+		return x.StatusHistory
+	}
+	return nil
+}
+
+func (x *Webhook) GetReplayedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReplayedAt
+	}
+	return nil
+}
+
+func (x *Webhook) GetReplayedBy() string {
+	if x != nil {
+		return x.ReplayedBy
+	}
+	return ""
+}
+
+func (x *Webhook) GetReplayCount() int32 {
+	if x != nil {
+		return x.ReplayCount
+	}
+	return 0
+}
+
+func (x *Webhook) GetTraceId() string {
+	if x != nil {
+		return x.TraceId
+	}
+	return ""
+}
+
+func (x *Webhook) GetPurgedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PurgedAt
+	}
+	return nil
+}
+
+func (x *Webhook) GetPurgeExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PurgeExpiresAt
+	}
+	return nil
+}
+
+// A change of a webhook's status
+type WebhookStatusChange struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unspecified for the status the webhook was stored with
+	FromStatus WebhookStatus `protobuf:"varint,1,opt,name=from_status,json=fromStatus,proto3,enum=hookly.v1.WebhookStatus" json:"from_status,omitempty"`
+	ToStatus   WebhookStatus `protobuf:"varint,2,opt,name=to_status,json=toStatus,proto3,enum=hookly.v1.WebhookStatus" json:"to_status,omitempty"`
+	// "replayed", or the delivery error at the time of the change
+	Reason    string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	ChangedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=changed_at,json=changedAt,proto3" json:"changed_at,omitempty"`
+	// Who replayed the webhook; empty for changes made by the edge
+	ChangedBy     string `protobuf:"bytes,5,opt,name=changed_by,json=changedBy,proto3" json:"changed_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebhookStatusChange) Reset() {
+	*x = WebhookStatusChange{}
+	mi := &file_hookly_v1_common_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebhookStatusChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebhookStatusChange) ProtoMessage() {}
+
+func (x *WebhookStatusChange) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebhookStatusChange.ProtoReflect.Descriptor instead.
+func (*WebhookStatusChange) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *WebhookStatusChange) GetFromStatus() WebhookStatus {
+	if x != nil {
+		return x.FromStatus
+	}
+	return WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED
+}
+
+func (x *WebhookStatusChange) GetToStatus() WebhookStatus {
+	if x != nil {
+		return x.ToStatus
+	}
+	return WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED
+}
+
+func (x *WebhookStatusChange) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *WebhookStatusChange) GetChangedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ChangedAt
+	}
+	return nil
+}
+
+func (x *WebhookStatusChange) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+// Pagination request parameters
+type PaginationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageSize      int32                  `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaginationRequest) Reset() {
+	*x = PaginationRequest{}
+	mi := &file_hookly_v1_common_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaginationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaginationRequest) ProtoMessage() {}
+
+func (x *PaginationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaginationRequest.ProtoReflect.Descriptor instead.
+func (*PaginationRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *PaginationRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *PaginationRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// Pagination response metadata
+type PaginationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NextPageToken string                 `protobuf:"bytes,1,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	TotalCount    int32                  `protobuf:"varint,2,opt,name=total_count,json=totalCount,proto3" json:"total_count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaginationResponse) Reset() {
+	*x = PaginationResponse{}
+	mi := &file_hookly_v1_common_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaginationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaginationResponse) ProtoMessage() {}
+
+func (x *PaginationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaginationResponse.ProtoReflect.Descriptor instead.
+func (*PaginationResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PaginationResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *PaginationResponse) GetTotalCount() int32 {
+	if x != nil {
+		return x.TotalCount
+	}
+	return 0
+}
+
+// Connected endpoint info for status display
+type ConnectedEndpoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectedEndpoint) Reset() {
+	*x = ConnectedEndpoint{}
+	mi := &file_hookly_v1_common_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectedEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectedEndpoint) ProtoMessage() {}
+
+func (x *ConnectedEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectedEndpoint.ProtoReflect.Descriptor instead.
+func (*ConnectedEndpoint) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ConnectedEndpoint) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ConnectedEndpoint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// An endpoint whose webhooks were rejected by ingestion rate limits
+type RateLimitedEndpoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	RejectedCount uint64                 `protobuf:"varint,3,opt,name=rejected_count,json=rejectedCount,proto3" json:"rejected_count,omitempty"` // Since the edge started
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateLimitedEndpoint) Reset() {
+	*x = RateLimitedEndpoint{}
+	mi := &file_hookly_v1_common_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimitedEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitedEndpoint) ProtoMessage() {}
+
+func (x *RateLimitedEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitedEndpoint.ProtoReflect.Descriptor instead.
+func (*RateLimitedEndpoint) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RateLimitedEndpoint) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RateLimitedEndpoint) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RateLimitedEndpoint) GetRejectedCount() uint64 {
+	if x != nil {
+		return x.RejectedCount
+	}
+	return 0
+}
+
+// A hub connected to the edge
+type ConnectedHub struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	HubId           string                 `protobuf:"bytes,1,opt,name=hub_id,json=hubId,proto3" json:"hub_id,omitempty"`
+	Transport       string                 `protobuf:"bytes,2,opt,name=transport,proto3" json:"transport,omitempty"` // stream or tunnel; commands need the stream
+	EndpointIds     []string               `protobuf:"bytes,3,rep,name=endpoint_ids,json=endpointIds,proto3" json:"endpoint_ids,omitempty"`
+	ConnectedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=connected_at,json=connectedAt,proto3" json:"connected_at,omitempty"`
+	LastHeartbeatAt *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_heartbeat_at,json=lastHeartbeatAt,proto3" json:"last_heartbeat_at,omitempty"`
+	Paused          bool                   `protobuf:"varint,6,opt,name=paused,proto3" json:"paused,omitempty"` // Deliveries paused with HUB_COMMAND_TYPE_PAUSE
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ConnectedHub) Reset() {
+	*x = ConnectedHub{}
+	mi := &file_hookly_v1_common_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectedHub) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectedHub) ProtoMessage() {}
+
+func (x *ConnectedHub) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectedHub.ProtoReflect.Descriptor instead.
+func (*ConnectedHub) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ConnectedHub) GetHubId() string {
+	if x != nil {
+		return x.HubId
+	}
+	return ""
+}
+
+func (x *ConnectedHub) GetTransport() string {
+	if x != nil {
+		return x.Transport
+	}
+	return ""
+}
+
+func (x *ConnectedHub) GetEndpointIds() []string {
+	if x != nil {
+		return x.EndpointIds
+	}
+	return nil
+}
+
+func (x *ConnectedHub) GetConnectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ConnectedAt
+	}
+	return nil
+}
+
+func (x *ConnectedHub) GetLastHeartbeatAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastHeartbeatAt
+	}
+	return nil
+}
+
+func (x *ConnectedHub) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+// A hub's answer to a command
+type HubCommandResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Success       bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	Output        string                 `protobuf:"bytes,4,opt,name=output,proto3" json:"output,omitempty"` // Human-readable summary, JSON for diagnostics
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HubCommandResult) Reset() {
+	*x = HubCommandResult{}
+	mi := &file_hookly_v1_common_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HubCommandResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HubCommandResult) ProtoMessage() {}
+
+func (x *HubCommandResult) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HubCommandResult.ProtoReflect.Descriptor instead.
+func (*HubCommandResult) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *HubCommandResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HubCommandResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *HubCommandResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *HubCommandResult) GetOutput() string {
+	if x != nil {
+		return x.Output
+	}
+	return ""
+}
+
+// System status information
+type SystemStatus struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PendingCount    int32                  `protobuf:"varint,1,opt,name=pending_count,json=pendingCount,proto3" json:"pending_count,omitempty"`
+	FailedCount     int32                  `protobuf:"varint,2,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
+	DeadLetterCount int32                  `protobuf:"varint,3,opt,name=dead_letter_count,json=deadLetterCount,proto3" json:"dead_letter_count,omitempty"`
+	// Deprecated: use connected_endpoints instead
+	//
+	// Deprecated: Marked as deprecated in hookly/v1/common.proto.
+	HomeHubConnected bool `protobuf:"varint,4,opt,name=home_hub_connected,json=homeHubConnected,proto3" json:"home_hub_connected,omitempty"`
+	// Deprecated: Marked as deprecated in hookly/v1/common.proto.
+	LastHomeHubHeartbeat *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_home_hub_heartbeat,json=lastHomeHubHeartbeat,proto3" json:"last_home_hub_heartbeat,omitempty"`
+	// Endpoints with active relay connections
+	ConnectedEndpoints []*ConnectedEndpoint `protobuf:"bytes,6,rep,name=connected_endpoints,json=connectedEndpoints,proto3" json:"connected_endpoints,omitempty"`
+	// Background maintenance jobs (dead letters, SLO checks, cleanup)
+	MaintenanceJobs []*MaintenanceJob `protobuf:"bytes,7,rep,name=maintenance_jobs,json=maintenanceJobs,proto3" json:"maintenance_jobs,omitempty"`
+	// Hubs serving this user's endpoints
+	ConnectedHubs []*ConnectedHub `protobuf:"bytes,8,rep,name=connected_hubs,json=connectedHubs,proto3" json:"connected_hubs,omitempty"`
+	// Endpoints that were rate limited, most rejected first
+	RateLimitedEndpoints []*RateLimitedEndpoint `protobuf:"bytes,9,rep,name=rate_limited_endpoints,json=rateLimitedEndpoints,proto3" json:"rate_limited_endpoints,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *SystemStatus) Reset() {
+	*x = SystemStatus{}
+	mi := &file_hookly_v1_common_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SystemStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SystemStatus) ProtoMessage() {}
+
+func (x *SystemStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SystemStatus.ProtoReflect.Descriptor instead.
+func (*SystemStatus) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SystemStatus) GetPendingCount() int32 {
+	if x != nil {
+		return x.PendingCount
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetFailedCount() int32 {
+	if x != nil {
+		return x.FailedCount
+	}
+	return 0
+}
+
+func (x *SystemStatus) GetDeadLetterCount() int32 {
+	if x != nil {
+		return x.DeadLetterCount
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in hookly/v1/common.proto.
+func (x *SystemStatus) GetHomeHubConnected() bool {
+	if x != nil {
+		return x.HomeHubConnected
+	}
+	return false
+}
+
+// Deprecated: Marked as deprecated in hookly/v1/common.proto.
+func (x *SystemStatus) GetLastHomeHubHeartbeat() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastHomeHubHeartbeat
+	}
+	return nil
+}
+
+func (x *SystemStatus) GetConnectedEndpoints() []*ConnectedEndpoint {
+	if x != nil {
+		return x.ConnectedEndpoints
+	}
+	return nil
+}
+
+func (x *SystemStatus) GetMaintenanceJobs() []*MaintenanceJob {
+	if x != nil {
+		return x.MaintenanceJobs
+	}
+	return nil
+}
+
+func (x *SystemStatus) GetConnectedHubs() []*ConnectedHub {
+	if x != nil {
+		return x.ConnectedHubs
+	}
+	return nil
+}
+
+func (x *SystemStatus) GetRateLimitedEndpoints() []*RateLimitedEndpoint {
+	if x != nil {
+		return x.RateLimitedEndpoints
+	}
+	return nil
+}
+
+// A background maintenance job run by the edge scheduler
+type MaintenanceJob struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	LastRunAt      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=last_run_at,json=lastRunAt,proto3" json:"last_run_at,omitempty"` // Unset if the job hasn't run yet
+	NextRunAt      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=next_run_at,json=nextRunAt,proto3" json:"next_run_at,omitempty"` // Unset if the scheduler isn't running
+	LastDurationMs int64                  `protobuf:"varint,4,opt,name=last_duration_ms,json=lastDurationMs,proto3" json:"last_duration_ms,omitempty"`
+	LastError      string                 `protobuf:"bytes,5,opt,name=last_error,json=lastError,proto3" json:"last_error,omitempty"` // Empty if the last run succeeded
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *MaintenanceJob) Reset() {
+	*x = MaintenanceJob{}
+	mi := &file_hookly_v1_common_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MaintenanceJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MaintenanceJob) ProtoMessage() {}
+
+func (x *MaintenanceJob) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MaintenanceJob.ProtoReflect.Descriptor instead.
+func (*MaintenanceJob) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *MaintenanceJob) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MaintenanceJob) GetLastRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastRunAt
+	}
+	return nil
+}
+
+func (x *MaintenanceJob) GetNextRunAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.NextRunAt
+	}
+	return nil
+}
+
+func (x *MaintenanceJob) GetLastDurationMs() int64 {
+	if x != nil {
+		return x.LastDurationMs
+	}
+	return 0
+}
+
+func (x *MaintenanceJob) GetLastError() string {
+	if x != nil {
+		return x.LastError
+	}
+	return ""
+}
+
+// User settings including profile and preferences
+type UserSettings struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// GitHub profile (from OAuth)
+	GithubName       string `protobuf:"bytes,3,opt,name=github_name,json=githubName,proto3" json:"github_name,omitempty"`
+	GithubEmail      string `protobuf:"bytes,4,opt,name=github_email,json=githubEmail,proto3" json:"github_email,omitempty"`
+	GithubProfileUrl string `protobuf:"bytes,5,opt,name=github_profile_url,json=githubProfileUrl,proto3" json:"github_profile_url,omitempty"`
+	AvatarUrl        string `protobuf:"bytes,6,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	// Telegram notifications (token is write-only, never returned)
+	TelegramConfigured bool   `protobuf:"varint,7,opt,name=telegram_configured,json=telegramConfigured,proto3" json:"telegram_configured,omitempty"` // True if bot token is set
+	TelegramChatId     string `protobuf:"bytes,8,opt,name=telegram_chat_id,json=telegramChatId,proto3" json:"telegram_chat_id,omitempty"`
+	TelegramEnabled    bool   `protobuf:"varint,9,opt,name=telegram_enabled,json=telegramEnabled,proto3" json:"telegram_enabled,omitempty"`
+	// UI preferences
+	ThemePreference ThemePreference `protobuf:"varint,10,opt,name=theme_preference,json=themePreference,proto3,enum=hookly.v1.ThemePreference" json:"theme_preference,omitempty"`
+	// Authorization
+	IsSuperuser bool `protobuf:"varint,11,opt,name=is_superuser,json=isSuperuser,proto3" json:"is_superuser,omitempty"`
+	// Timestamps
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	LastLoginAt *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=last_login_at,json=lastLoginAt,proto3" json:"last_login_at,omitempty"`
+	// Discord notifications (webhook URL is write-only, never returned)
+	DiscordConfigured bool `protobuf:"varint,15,opt,name=discord_configured,json=discordConfigured,proto3" json:"discord_configured,omitempty"` // True if a webhook URL is set
+	DiscordEnabled    bool `protobuf:"varint,16,opt,name=discord_enabled,json=discordEnabled,proto3" json:"discord_enabled,omitempty"`
+	// Email notifications, sent through the edge's SMTP server
+	EmailAddress string `protobuf:"bytes,17,opt,name=email_address,json=emailAddress,proto3" json:"email_address,omitempty"`
+	EmailEnabled bool   `protobuf:"varint,18,opt,name=email_enabled,json=emailEnabled,proto3" json:"email_enabled,omitempty"`
+	// Notification webhook (URL and secret are write-only, never returned)
+	NotifyWebhookConfigured bool `protobuf:"varint,19,opt,name=notify_webhook_configured,json=notifyWebhookConfigured,proto3" json:"notify_webhook_configured,omitempty"` // True if a URL and secret are set
+	NotifyWebhookEnabled    bool `protobuf:"varint,20,opt,name=notify_webhook_enabled,json=notifyWebhookEnabled,proto3" json:"notify_webhook_enabled,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *UserSettings) Reset() {
+	*x = UserSettings{}
+	mi := &file_hookly_v1_common_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserSettings) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserSettings) ProtoMessage() {}
+
+func (x *UserSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserSettings.ProtoReflect.Descriptor instead.
+func (*UserSettings) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UserSettings) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserSettings) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserSettings) GetGithubName() string {
+	if x != nil {
+		return x.GithubName
+	}
+	return ""
+}
+
+func (x *UserSettings) GetGithubEmail() string {
+	if x != nil {
+		return x.GithubEmail
+	}
+	return ""
+}
+
+func (x *UserSettings) GetGithubProfileUrl() string {
+	if x != nil {
+		return x.GithubProfileUrl
+	}
+	return ""
+}
+
+func (x *UserSettings) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *UserSettings) GetTelegramConfigured() bool {
+	if x != nil {
+		return x.TelegramConfigured
+	}
+	return false
+}
+
+func (x *UserSettings) GetTelegramChatId() string {
+	if x != nil {
+		return x.TelegramChatId
+	}
+	return ""
+}
+
+func (x *UserSettings) GetTelegramEnabled() bool {
+	if x != nil {
+		return x.TelegramEnabled
+	}
+	return false
+}
+
+func (x *UserSettings) GetThemePreference() ThemePreference {
+	if x != nil {
+		return x.ThemePreference
+	}
+	return ThemePreference_THEME_PREFERENCE_UNSPECIFIED
+}
+
+func (x *UserSettings) GetIsSuperuser() bool {
+	if x != nil {
+		return x.IsSuperuser
+	}
+	return false
+}
+
+func (x *UserSettings) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *UserSettings) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *UserSettings) GetLastLoginAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastLoginAt
+	}
+	return nil
+}
+
+func (x *UserSettings) GetDiscordConfigured() bool {
+	if x != nil {
+		return x.DiscordConfigured
+	}
+	return false
+}
+
+func (x *UserSettings) GetDiscordEnabled() bool {
+	if x != nil {
+		return x.DiscordEnabled
+	}
+	return false
+}
+
+func (x *UserSettings) GetEmailAddress() string {
+	if x != nil {
+		return x.EmailAddress
+	}
+	return ""
+}
+
+func (x *UserSettings) GetEmailEnabled() bool {
+	if x != nil {
+		return x.EmailEnabled
+	}
+	return false
+}
+
+func (x *UserSettings) GetNotifyWebhookConfigured() bool {
+	if x != nil {
+		return x.NotifyWebhookConfigured
+	}
+	return false
+}
+
+func (x *UserSettings) GetNotifyWebhookEnabled() bool {
+	if x != nil {
+		return x.NotifyWebhookEnabled
+	}
+	return false
+}
+
+// API token metadata; the token itself is only returned when created
+type ApiToken struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"` // e.g. "CLI - hostname"
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	LastUsedAt    *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_used_at,json=lastUsedAt,proto3" json:"last_used_at,omitempty"` // Unset if never used
+	Scope         TokenScope             `protobuf:"varint,5,opt,name=scope,proto3,enum=hookly.v1.TokenScope" json:"scope,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unset if it never expires
+	Expired       bool                   `protobuf:"varint,7,opt,name=expired,proto3" json:"expired,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApiToken) Reset() {
+	*x = ApiToken{}
+	mi := &file_hookly_v1_common_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApiToken) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApiToken) ProtoMessage() {}
+
+func (x *ApiToken) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApiToken.ProtoReflect.Descriptor instead.
+func (*ApiToken) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ApiToken) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApiToken) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ApiToken) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ApiToken) GetLastUsedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastUsedAt
+	}
+	return nil
+}
+
+func (x *ApiToken) GetScope() TokenScope {
+	if x != nil {
+		return x.Scope
+	}
+	return TokenScope_TOKEN_SCOPE_UNSPECIFIED
+}
+
+func (x *ApiToken) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *ApiToken) GetExpired() bool {
+	if x != nil {
+		return x.Expired
+	}
+	return false
+}
+
+// An organization sharing endpoints among its members
+type Org struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Role          OrgRole                `protobuf:"varint,4,opt,name=role,proto3,enum=hookly.v1.OrgRole" json:"role,omitempty"` // The caller's role
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Org) Reset() {
+	*x = Org{}
+	mi := &file_hookly_v1_common_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Org) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Org) ProtoMessage() {}
+
+func (x *Org) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Org.ProtoReflect.Descriptor instead.
+func (*Org) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *Org) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Org) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Org) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Org) GetRole() OrgRole {
+	if x != nil {
+		return x.Role
+	}
+	return OrgRole_ORG_ROLE_UNSPECIFIED
+}
+
+type OrgMember struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	Role          OrgRole                `protobuf:"varint,3,opt,name=role,proto3,enum=hookly.v1.OrgRole" json:"role,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrgMember) Reset() {
+	*x = OrgMember{}
+	mi := &file_hookly_v1_common_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrgMember) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrgMember) ProtoMessage() {}
+
+func (x *OrgMember) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrgMember.ProtoReflect.Descriptor instead.
+func (*OrgMember) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *OrgMember) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *OrgMember) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *OrgMember) GetRole() OrgRole {
+	if x != nil {
+		return x.Role
+	}
+	return OrgRole_ORG_ROLE_UNSPECIFIED
+}
+
+func (x *OrgMember) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+// A recorded management action
+type AuditEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // Who acted
+	Username      string                 `protobuf:"bytes,3,opt,name=username,proto3" json:"username,omitempty"`
+	TokenId       string                 `protobuf:"bytes,4,opt,name=token_id,json=tokenId,proto3" json:"token_id,omitempty"` // API token used; empty for web sessions
+	Action        string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`                  // EdgeService method, or Login, Logout, AuthorizeCLI
+	Target        string                 `protobuf:"bytes,6,opt,name=target,proto3" json:"target,omitempty"`                  // ID of the endpoint, webhook, token or org acted on
+	Ip            string                 `protobuf:"bytes,7,opt,name=ip,proto3" json:"ip,omitempty"`
+	Result        string                 `protobuf:"bytes,8,opt,name=result,proto3" json:"result,omitempty"` // ok, denied, or the error code, e.g. permission_denied
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	OrgId         string                 `protobuf:"bytes,10,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"` // Set when the action was on an org's endpoints
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AuditEvent) Reset() {
+	*x = AuditEvent{}
+	mi := &file_hookly_v1_common_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AuditEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AuditEvent) ProtoMessage() {}
+
+func (x *AuditEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AuditEvent.ProtoReflect.Descriptor instead.
+func (*AuditEvent) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *AuditEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTokenId() string {
+	if x != nil {
+		return x.TokenId
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *AuditEvent) GetOccurredAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Id
+		return x.OccurredAt
 	}
-	return ""
+	return nil
 }
 
-func (x *ConnectedEndpoint) GetName() string {
+func (x *AuditEvent) GetOrgId() string {
 	if x != nil {
-		return x.Name
+		return x.OrgId
 	}
 	return ""
 }
 
-// System status information
-type SystemStatus struct {
-	state           protoimpl.MessageState `protogen:"open.v1"`
-	PendingCount    int32                  `protobuf:"varint,1,opt,name=pending_count,json=pendingCount,proto3" json:"pending_count,omitempty"`
-	FailedCount     int32                  `protobuf:"varint,2,opt,name=failed_count,json=failedCount,proto3" json:"failed_count,omitempty"`
-	DeadLetterCount int32                  `protobuf:"varint,3,opt,name=dead_letter_count,json=deadLetterCount,proto3" json:"dead_letter_count,omitempty"`
-	// Deprecated: use connected_endpoints instead
-	//
-	// Deprecated: Marked as deprecated in hookly/v1/common.proto.
-	HomeHubConnected bool `protobuf:"varint,4,opt,name=home_hub_connected,json=homeHubConnected,proto3" json:"home_hub_connected,omitempty"`
-	// Deprecated: Marked as deprecated in hookly/v1/common.proto.
-	LastHomeHubHeartbeat *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_home_hub_heartbeat,json=lastHomeHubHeartbeat,proto3" json:"last_home_hub_heartbeat,omitempty"`
-	// Endpoints with active relay connections
-	ConnectedEndpoints []*ConnectedEndpoint `protobuf:"bytes,6,rep,name=connected_endpoints,json=connectedEndpoints,proto3" json:"connected_endpoints,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+// System settings (superuser only)
+type SystemSettings struct {
+	state                      protoimpl.MessageState `protogen:"open.v1"`
+	BaseUrl                    string                 `protobuf:"bytes,1,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	GithubOrg                  string                 `protobuf:"bytes,2,opt,name=github_org,json=githubOrg,proto3" json:"github_org,omitempty"`
+	GithubAllowedUsers         []string               `protobuf:"bytes,3,rep,name=github_allowed_users,json=githubAllowedUsers,proto3" json:"github_allowed_users,omitempty"`
+	SystemTelegramEnabled      bool                   `protobuf:"varint,4,opt,name=system_telegram_enabled,json=systemTelegramEnabled,proto3" json:"system_telegram_enabled,omitempty"`
+	TotalUsers                 int32                  `protobuf:"varint,5,opt,name=total_users,json=totalUsers,proto3" json:"total_users,omitempty"`
+	TotalEndpoints             int32                  `protobuf:"varint,6,opt,name=total_endpoints,json=totalEndpoints,proto3" json:"total_endpoints,omitempty"`
+	SystemDiscordEnabled       bool                   `protobuf:"varint,7,opt,name=system_discord_enabled,json=systemDiscordEnabled,proto3" json:"system_discord_enabled,omitempty"`
+	SystemEmailEnabled         bool                   `protobuf:"varint,8,opt,name=system_email_enabled,json=systemEmailEnabled,proto3" json:"system_email_enabled,omitempty"`
+	SystemNotifyWebhookEnabled bool                   `protobuf:"varint,9,opt,name=system_notify_webhook_enabled,json=systemNotifyWebhookEnabled,proto3" json:"system_notify_webhook_enabled,omitempty"`
+	unknownFields              protoimpl.UnknownFields
+	sizeCache                  protoimpl.SizeCache
 }
 
-func (x *SystemStatus) Reset() {
-	*x = SystemStatus{}
-	mi := &file_hookly_v1_common_proto_msgTypes[6]
+func (x *SystemSettings) Reset() {
+	*x = SystemSettings{}
+	mi := &file_hookly_v1_common_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SystemStatus) String() string {
+func (x *SystemSettings) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SystemStatus) ProtoMessage() {}
+func (*SystemSettings) ProtoMessage() {}
 
-func (x *SystemStatus) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_common_proto_msgTypes[6]
+func (x *SystemSettings) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -759,96 +2989,103 @@ func (x *SystemStatus) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SystemStatus.ProtoReflect.Descriptor instead.
-func (*SystemStatus) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_common_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use SystemSettings.ProtoReflect.Descriptor instead.
+func (*SystemSettings) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{24}
 }
 
-func (x *SystemStatus) GetPendingCount() int32 {
+func (x *SystemSettings) GetBaseUrl() string {
 	if x != nil {
-		return x.PendingCount
+		return x.BaseUrl
 	}
-	return 0
+	return ""
 }
 
-func (x *SystemStatus) GetFailedCount() int32 {
+func (x *SystemSettings) GetGithubOrg() string {
 	if x != nil {
-		return x.FailedCount
+		return x.GithubOrg
+	}
+	return ""
+}
+
+func (x *SystemSettings) GetGithubAllowedUsers() []string {
+	if x != nil {
+		return x.GithubAllowedUsers
+	}
+	return nil
+}
+
+func (x *SystemSettings) GetSystemTelegramEnabled() bool {
+	if x != nil {
+		return x.SystemTelegramEnabled
+	}
+	return false
+}
+
+func (x *SystemSettings) GetTotalUsers() int32 {
+	if x != nil {
+		return x.TotalUsers
 	}
 	return 0
 }
 
-func (x *SystemStatus) GetDeadLetterCount() int32 {
+func (x *SystemSettings) GetTotalEndpoints() int32 {
 	if x != nil {
-		return x.DeadLetterCount
+		return x.TotalEndpoints
 	}
 	return 0
 }
 
-// Deprecated: Marked as deprecated in hookly/v1/common.proto.
-func (x *SystemStatus) GetHomeHubConnected() bool {
+func (x *SystemSettings) GetSystemDiscordEnabled() bool {
 	if x != nil {
-		return x.HomeHubConnected
+		return x.SystemDiscordEnabled
 	}
 	return false
 }
 
-// Deprecated: Marked as deprecated in hookly/v1/common.proto.
-func (x *SystemStatus) GetLastHomeHubHeartbeat() *timestamppb.Timestamp {
+func (x *SystemSettings) GetSystemEmailEnabled() bool {
 	if x != nil {
-		return x.LastHomeHubHeartbeat
+		return x.SystemEmailEnabled
 	}
-	return nil
+	return false
 }
 
-func (x *SystemStatus) GetConnectedEndpoints() []*ConnectedEndpoint {
+func (x *SystemSettings) GetSystemNotifyWebhookEnabled() bool {
 	if x != nil {
-		return x.ConnectedEndpoints
+		return x.SystemNotifyWebhookEnabled
 	}
-	return nil
+	return false
 }
 
-// User settings including profile and preferences
-type UserSettings struct {
-	state    protoimpl.MessageState `protogen:"open.v1"`
-	UserId   string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
-	// GitHub profile (from OAuth)
-	GithubName       string `protobuf:"bytes,3,opt,name=github_name,json=githubName,proto3" json:"github_name,omitempty"`
-	GithubEmail      string `protobuf:"bytes,4,opt,name=github_email,json=githubEmail,proto3" json:"github_email,omitempty"`
-	GithubProfileUrl string `protobuf:"bytes,5,opt,name=github_profile_url,json=githubProfileUrl,proto3" json:"github_profile_url,omitempty"`
-	AvatarUrl        string `protobuf:"bytes,6,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
-	// Telegram notifications (token is write-only, never returned)
-	TelegramConfigured bool   `protobuf:"varint,7,opt,name=telegram_configured,json=telegramConfigured,proto3" json:"telegram_configured,omitempty"` // True if bot token is set
-	TelegramChatId     string `protobuf:"bytes,8,opt,name=telegram_chat_id,json=telegramChatId,proto3" json:"telegram_chat_id,omitempty"`
-	TelegramEnabled    bool   `protobuf:"varint,9,opt,name=telegram_enabled,json=telegramEnabled,proto3" json:"telegram_enabled,omitempty"`
-	// UI preferences
-	ThemePreference ThemePreference `protobuf:"varint,10,opt,name=theme_preference,json=themePreference,proto3,enum=hookly.v1.ThemePreference" json:"theme_preference,omitempty"`
-	// Authorization
-	IsSuperuser bool `protobuf:"varint,11,opt,name=is_superuser,json=isSuperuser,proto3" json:"is_superuser,omitempty"`
-	// Timestamps
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	LastLoginAt   *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=last_login_at,json=lastLoginAt,proto3" json:"last_login_at,omitempty"`
+// An entry in an endpoint's connection history
+type ConnectionEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	EndpointId    string                 `protobuf:"bytes,2,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	EndpointName  string                 `protobuf:"bytes,3,opt,name=endpoint_name,json=endpointName,proto3" json:"endpoint_name,omitempty"`
+	HubId         string                 `protobuf:"bytes,4,opt,name=hub_id,json=hubId,proto3" json:"hub_id,omitempty"`
+	Type          ConnectionEventType    `protobuf:"varint,5,opt,name=type,proto3,enum=hookly.v1.ConnectionEventType" json:"type,omitempty"`
+	Transport     string                 `protobuf:"bytes,6,opt,name=transport,proto3" json:"transport,omitempty"` // stream or tunnel
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserSettings) Reset() {
-	*x = UserSettings{}
-	mi := &file_hookly_v1_common_proto_msgTypes[7]
+func (x *ConnectionEvent) Reset() {
+	*x = ConnectionEvent{}
+	mi := &file_hookly_v1_common_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserSettings) String() string {
+func (x *ConnectionEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserSettings) ProtoMessage() {}
+func (*ConnectionEvent) ProtoMessage() {}
 
-func (x *UserSettings) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_common_proto_msgTypes[7]
+func (x *ConnectionEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -859,137 +3096,191 @@ func (x *UserSettings) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserSettings.ProtoReflect.Descriptor instead.
-func (*UserSettings) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_common_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use ConnectionEvent.ProtoReflect.Descriptor instead.
+func (*ConnectionEvent) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{25}
 }
 
-func (x *UserSettings) GetUserId() string {
+func (x *ConnectionEvent) GetId() string {
 	if x != nil {
-		return x.UserId
+		return x.Id
 	}
 	return ""
 }
 
-func (x *UserSettings) GetUsername() string {
+func (x *ConnectionEvent) GetEndpointId() string {
 	if x != nil {
-		return x.Username
+		return x.EndpointId
 	}
 	return ""
 }
 
-func (x *UserSettings) GetGithubName() string {
+func (x *ConnectionEvent) GetEndpointName() string {
 	if x != nil {
-		return x.GithubName
+		return x.EndpointName
 	}
 	return ""
 }
 
-func (x *UserSettings) GetGithubEmail() string {
+func (x *ConnectionEvent) GetHubId() string {
 	if x != nil {
-		return x.GithubEmail
+		return x.HubId
 	}
 	return ""
 }
 
-func (x *UserSettings) GetGithubProfileUrl() string {
+func (x *ConnectionEvent) GetType() ConnectionEventType {
 	if x != nil {
-		return x.GithubProfileUrl
+		return x.Type
 	}
-	return ""
+	return ConnectionEventType_CONNECTION_EVENT_TYPE_UNSPECIFIED
 }
 
-func (x *UserSettings) GetAvatarUrl() string {
+func (x *ConnectionEvent) GetTransport() string {
 	if x != nil {
-		return x.AvatarUrl
+		return x.Transport
 	}
 	return ""
 }
 
-func (x *UserSettings) GetTelegramConfigured() bool {
+func (x *ConnectionEvent) GetOccurredAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.TelegramConfigured
+		return x.OccurredAt
 	}
-	return false
+	return nil
 }
 
-func (x *UserSettings) GetTelegramChatId() string {
+// Activity feed entry for the UI home page
+type ActivityItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind          ActivityKind           `protobuf:"varint,2,opt,name=kind,proto3,enum=hookly.v1.ActivityKind" json:"kind,omitempty"`
+	EndpointId    string                 `protobuf:"bytes,3,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	EndpointName  string                 `protobuf:"bytes,4,opt,name=endpoint_name,json=endpointName,proto3" json:"endpoint_name,omitempty"`
+	HubId         string                 `protobuf:"bytes,5,opt,name=hub_id,json=hubId,proto3" json:"hub_id,omitempty"`
+	Count         int32                  `protobuf:"varint,6,opt,name=count,proto3" json:"count,omitempty"`                            // Number of deliveries (ACTIVITY_KIND_DELIVERIES only)
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"` // Start of the hour for deliveries
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ActivityItem) Reset() {
+	*x = ActivityItem{}
+	mi := &file_hookly_v1_common_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ActivityItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ActivityItem) ProtoMessage() {}
+
+func (x *ActivityItem) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[26]
 	if x != nil {
-		return x.TelegramChatId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ActivityItem.ProtoReflect.Descriptor instead.
+func (*ActivityItem) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ActivityItem) GetId() string {
+	if x != nil {
+		return x.Id
 	}
 	return ""
 }
 
-func (x *UserSettings) GetTelegramEnabled() bool {
+func (x *ActivityItem) GetKind() ActivityKind {
 	if x != nil {
-		return x.TelegramEnabled
+		return x.Kind
 	}
-	return false
+	return ActivityKind_ACTIVITY_KIND_UNSPECIFIED
 }
 
-func (x *UserSettings) GetThemePreference() ThemePreference {
+func (x *ActivityItem) GetEndpointId() string {
 	if x != nil {
-		return x.ThemePreference
+		return x.EndpointId
 	}
-	return ThemePreference_THEME_PREFERENCE_UNSPECIFIED
+	return ""
 }
 
-func (x *UserSettings) GetIsSuperuser() bool {
+func (x *ActivityItem) GetEndpointName() string {
 	if x != nil {
-		return x.IsSuperuser
+		return x.EndpointName
 	}
-	return false
+	return ""
 }
 
-func (x *UserSettings) GetCreatedAt() *timestamppb.Timestamp {
+func (x *ActivityItem) GetHubId() string {
 	if x != nil {
-		return x.CreatedAt
+		return x.HubId
 	}
-	return nil
+	return ""
 }
 
-func (x *UserSettings) GetUpdatedAt() *timestamppb.Timestamp {
+func (x *ActivityItem) GetCount() int32 {
 	if x != nil {
-		return x.UpdatedAt
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ActivityItem) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
 	}
 	return nil
 }
 
-func (x *UserSettings) GetLastLoginAt() *timestamppb.Timestamp {
+func (x *ActivityItem) GetUpdatedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.LastLoginAt
+		return x.UpdatedAt
 	}
 	return nil
 }
 
-// System settings (superuser only)
-type SystemSettings struct {
-	state                 protoimpl.MessageState `protogen:"open.v1"`
-	BaseUrl               string                 `protobuf:"bytes,1,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
-	GithubOrg             string                 `protobuf:"bytes,2,opt,name=github_org,json=githubOrg,proto3" json:"github_org,omitempty"`
-	GithubAllowedUsers    []string               `protobuf:"bytes,3,rep,name=github_allowed_users,json=githubAllowedUsers,proto3" json:"github_allowed_users,omitempty"`
-	SystemTelegramEnabled bool                   `protobuf:"varint,4,opt,name=system_telegram_enabled,json=systemTelegramEnabled,proto3" json:"system_telegram_enabled,omitempty"`
-	TotalUsers            int32                  `protobuf:"varint,5,opt,name=total_users,json=totalUsers,proto3" json:"total_users,omitempty"`
-	TotalEndpoints        int32                  `protobuf:"varint,6,opt,name=total_endpoints,json=totalEndpoints,proto3" json:"total_endpoints,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+// A region of the hookly service, with its health as seen from the edge that
+// answered
+type Region struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"` // Base URL of the region's edge
+	Healthy       bool                   `protobuf:"varint,3,opt,name=healthy,proto3" json:"healthy,omitempty"`
+	LatencyMs     int64                  `protobuf:"varint,4,opt,name=latency_ms,json=latencyMs,proto3" json:"latency_ms,omitempty"` // Health check round trip from the answering edge
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                           // Why the region is unhealthy
+	CheckedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=checked_at,json=checkedAt,proto3" json:"checked_at,omitempty"`
+	Current       bool                   `protobuf:"varint,7,opt,name=current,proto3" json:"current,omitempty"` // The region of the edge that answered
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SystemSettings) Reset() {
-	*x = SystemSettings{}
-	mi := &file_hookly_v1_common_proto_msgTypes[8]
+func (x *Region) Reset() {
+	*x = Region{}
+	mi := &file_hookly_v1_common_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SystemSettings) String() string {
+func (x *Region) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SystemSettings) ProtoMessage() {}
+func (*Region) ProtoMessage() {}
 
-func (x *SystemSettings) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_common_proto_msgTypes[8]
+func (x *Region) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_common_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1000,51 +3291,58 @@ func (x *SystemSettings) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SystemSettings.ProtoReflect.Descriptor instead.
-func (*SystemSettings) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_common_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use Region.ProtoReflect.Descriptor instead.
+func (*Region) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_common_proto_rawDescGZIP(), []int{27}
 }
 
-func (x *SystemSettings) GetBaseUrl() string {
+func (x *Region) GetName() string {
 	if x != nil {
-		return x.BaseUrl
+		return x.Name
 	}
 	return ""
 }
 
-func (x *SystemSettings) GetGithubOrg() string {
+func (x *Region) GetUrl() string {
 	if x != nil {
-		return x.GithubOrg
+		return x.Url
 	}
 	return ""
 }
 
-func (x *SystemSettings) GetGithubAllowedUsers() []string {
+func (x *Region) GetHealthy() bool {
 	if x != nil {
-		return x.GithubAllowedUsers
+		return x.Healthy
 	}
-	return nil
+	return false
 }
 
-func (x *SystemSettings) GetSystemTelegramEnabled() bool {
+func (x *Region) GetLatencyMs() int64 {
 	if x != nil {
-		return x.SystemTelegramEnabled
+		return x.LatencyMs
 	}
-	return false
+	return 0
 }
 
-func (x *SystemSettings) GetTotalUsers() int32 {
+func (x *Region) GetError() string {
 	if x != nil {
-		return x.TotalUsers
+		return x.Error
 	}
-	return 0
+	return ""
 }
 
-func (x *SystemSettings) GetTotalEndpoints() int32 {
+func (x *Region) GetCheckedAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.TotalEndpoints
+		return x.CheckedAt
 	}
-	return 0
+	return nil
+}
+
+func (x *Region) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
 }
 
 var File_hookly_v1_common_proto protoreflect.FileDescriptor
@@ -1057,7 +3355,46 @@ const file_hookly_v1_common_proto_rawDesc = "" +
 	"\x10signature_header\x18\x02 \x01(\tR\x0fsignatureHeader\x12)\n" +
 	"\x10signature_prefix\x18\x03 \x01(\tR\x0fsignaturePrefix\x12)\n" +
 	"\x10timestamp_header\x18\x04 \x01(\tR\x0ftimestampHeader\x12/\n" +
-	"\x13timestamp_tolerance\x18\x05 \x01(\x03R\x12timestampTolerance\"\xf1\x02\n" +
+	"\x13timestamp_tolerance\x18\x05 \x01(\x03R\x12timestampTolerance\"\x8d\x01\n" +
+	"\n" +
+	"IngestAuth\x123\n" +
+	"\x06method\x18\x01 \x01(\x0e2\x1b.hookly.v1.IngestAuthMethodR\x06method\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x16\n" +
+	"\x06header\x18\x03 \x01(\tR\x06header\x12\x16\n" +
+	"\x06secret\x18\x04 \x01(\tR\x06secret\"\xba\x01\n" +
+	"\tTransform\x12\x18\n" +
+	"\aextract\x18\x01 \x01(\tR\aextract\x12\x1a\n" +
+	"\btemplate\x18\x02 \x01(\tR\btemplate\x12;\n" +
+	"\aheaders\x18\x03 \x03(\v2!.hookly.v1.Transform.HeadersEntryR\aheaders\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"h\n" +
+	"\x0eIngestResponse\x12\x1f\n" +
+	"\vstatus_code\x18\x01 \x01(\x05R\n" +
+	"statusCode\x12\x12\n" +
+	"\x04body\x18\x02 \x01(\tR\x04body\x12!\n" +
+	"\fcontent_type\x18\x03 \x01(\tR\vcontentType\"\xac\x01\n" +
+	"\vRetryPolicy\x12!\n" +
+	"\fmax_attempts\x18\x01 \x01(\x05R\vmaxAttempts\x120\n" +
+	"\x14backoff_base_seconds\x18\x02 \x01(\x05R\x12backoffBaseSeconds\x120\n" +
+	"\x14max_interval_seconds\x18\x03 \x01(\x05R\x12maxIntervalSeconds\x12\x16\n" +
+	"\x06jitter\x18\x04 \x01(\x01R\x06jitter\"Q\n" +
+	"\rPayloadLimits\x12\x1b\n" +
+	"\tmax_bytes\x18\x01 \x01(\x03R\bmaxBytes\x12#\n" +
+	"\rcontent_types\x18\x02 \x03(\tR\fcontentTypes\"/\n" +
+	"\vDestination\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"\xe5\x02\n" +
+	"\x13DestinationDelivery\x12%\n" +
+	"\x0edestination_id\x18\x01 \x01(\tR\rdestinationId\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x120\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x18.hookly.v1.WebhookStatusR\x06status\x12\x1a\n" +
+	"\battempts\x18\x04 \x01(\x05R\battempts\x12\x1f\n" +
+	"\vstatus_code\x18\x05 \x01(\x05R\n" +
+	"statusCode\x12#\n" +
+	"\rerror_message\x18\x06 \x01(\tR\ferrorMessage\x12B\n" +
+	"\x0flast_attempt_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\rlastAttemptAt\x12=\n" +
+	"\fdelivered_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\"\xe8\v\n" +
 	"\bEndpoint\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12<\n" +
@@ -1068,7 +3405,35 @@ const file_hookly_v1_common_proto_rawDesc = "" +
 	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12N\n" +
-	"\x13verification_config\x18\b \x01(\v2\x1d.hookly.v1.VerificationConfigR\x12verificationConfig\"\xa7\x04\n" +
+	"\x13verification_config\x18\b \x01(\v2\x1d.hookly.v1.VerificationConfigR\x12verificationConfig\x12,\n" +
+	"\x12notify_first_event\x18\t \x01(\bR\x10notifyFirstEvent\x12@\n" +
+	"\x0efirst_event_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\ffirstEventAt\x123\n" +
+	"\x16has_telegram_bot_token\x18\v \x01(\bR\x13hasTelegramBotToken\x12\x1d\n" +
+	"\n" +
+	"slo_target\x18\f \x01(\x01R\tsloTarget\x12.\n" +
+	"\x13slo_latency_seconds\x18\r \x01(\x05R\x11sloLatencySeconds\x12(\n" +
+	"\x10slo_window_hours\x18\x0e \x01(\x05R\x0esloWindowHours\x12+\n" +
+	"\x11reject_duplicates\x18\x0f \x01(\bR\x10rejectDuplicates\x12\x1f\n" +
+	"\vhome_region\x18\x10 \x01(\tR\n" +
+	"homeRegion\x126\n" +
+	"\vingest_auth\x18\x11 \x01(\v2\x15.hookly.v1.IngestAuthR\n" +
+	"ingestAuth\x12\x1a\n" +
+	"\bhoneypot\x18\x12 \x01(\bR\bhoneypot\x12S\n" +
+	"\x18last_webhook_received_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\x15lastWebhookReceivedAt\x12F\n" +
+	"\x11last_delivered_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\x0flastDeliveredAt\x12;\n" +
+	"\varchived_at\x18\x15 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAt\x122\n" +
+	"\x15conflict_as_duplicate\x18\x16 \x01(\bR\x13conflictAsDuplicate\x121\n" +
+	"\x15rate_limit_per_minute\x18\x17 \x01(\x05R\x12rateLimitPerMinute\x122\n" +
+	"\ttransform\x18\x18 \x01(\v2\x14.hookly.v1.TransformR\ttransform\x12:\n" +
+	"\fdestinations\x18\x19 \x03(\v2\x16.hookly.v1.DestinationR\fdestinations\x12#\n" +
+	"\ranswer_probes\x18\x1a \x01(\bR\fanswerProbes\x12B\n" +
+	"\x0fingest_response\x18\x1b \x01(\v2\x19.hookly.v1.IngestResponseR\x0eingestResponse\x129\n" +
+	"\fretry_policy\x18\x1c \x01(\v2\x16.hookly.v1.RetryPolicyR\vretryPolicy\x12?\n" +
+	"\x0epayload_limits\x18\x1d \x01(\v2\x18.hookly.v1.PayloadLimitsR\rpayloadLimits\x12\x1f\n" +
+	"\vwebhook_url\x18\x1e \x01(\tR\n" +
+	"webhookUrl\"\x82\t\n" +
 	"\aWebhook\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vendpoint_id\x18\x02 \x01(\tR\n" +
@@ -1083,10 +3448,37 @@ const file_hookly_v1_common_proto_rawDesc = "" +
 	"\x0flast_attempt_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\rlastAttemptAt\x12=\n" +
 	"\fdelivered_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\vdeliveredAt\x12#\n" +
-	"\rerror_message\x18\v \x01(\tR\ferrorMessage\x1a:\n" +
+	"\rerror_message\x18\v \x01(\tR\ferrorMessage\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\f \x01(\tR\teventType\x12'\n" +
+	"\x0fpayload_preview\x18\r \x01(\fR\x0epayloadPreview\x12!\n" +
+	"\fpayload_size\x18\x0e \x01(\x03R\vpayloadSize\x12+\n" +
+	"\x11payload_truncated\x18\x0f \x01(\bR\x10payloadTruncated\x12\x1f\n" +
+	"\vdelivery_id\x18\x10 \x01(\tR\n" +
+	"deliveryId\x12!\n" +
+	"\fduplicate_of\x18\x11 \x01(\tR\vduplicateOf\x12\x1b\n" +
+	"\tsource_ip\x18\x12 \x01(\tR\bsourceIp\x12E\n" +
+	"\x0estatus_history\x18\x13 \x03(\v2\x1e.hookly.v1.WebhookStatusChangeR\rstatusHistory\x12;\n" +
+	"\vreplayed_at\x18\x14 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"replayedAt\x12\x1f\n" +
+	"\vreplayed_by\x18\x15 \x01(\tR\n" +
+	"replayedBy\x12!\n" +
+	"\freplay_count\x18\x16 \x01(\x05R\vreplayCount\x12\x19\n" +
+	"\btrace_id\x18\x17 \x01(\tR\atraceId\x127\n" +
+	"\tpurged_at\x18\x18 \x01(\v2\x1a.google.protobuf.TimestampR\bpurgedAt\x12D\n" +
+	"\x10purge_expires_at\x18\x19 \x01(\v2\x1a.google.protobuf.TimestampR\x0epurgeExpiresAt\x1a:\n" +
 	"\fHeadersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"O\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf9\x01\n" +
+	"\x13WebhookStatusChange\x129\n" +
+	"\vfrom_status\x18\x01 \x01(\x0e2\x18.hookly.v1.WebhookStatusR\n" +
+	"fromStatus\x125\n" +
+	"\tto_status\x18\x02 \x01(\x0e2\x18.hookly.v1.WebhookStatusR\btoStatus\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\x129\n" +
+	"\n" +
+	"changed_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tchangedAt\x12\x1d\n" +
+	"\n" +
+	"changed_by\x18\x05 \x01(\tR\tchangedBy\"O\n" +
 	"\x11PaginationRequest\x12\x1b\n" +
 	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
 	"\n" +
@@ -1097,14 +3489,40 @@ const file_hookly_v1_common_proto_rawDesc = "" +
 	"totalCount\"7\n" +
 	"\x11ConnectedEndpoint\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"\xda\x02\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"`\n" +
+	"\x13RateLimitedEndpoint\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12%\n" +
+	"\x0erejected_count\x18\x03 \x01(\x04R\rrejectedCount\"\x85\x02\n" +
+	"\fConnectedHub\x12\x15\n" +
+	"\x06hub_id\x18\x01 \x01(\tR\x05hubId\x12\x1c\n" +
+	"\ttransport\x18\x02 \x01(\tR\ttransport\x12!\n" +
+	"\fendpoint_ids\x18\x03 \x03(\tR\vendpointIds\x12=\n" +
+	"\fconnected_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vconnectedAt\x12F\n" +
+	"\x11last_heartbeat_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0flastHeartbeatAt\x12\x16\n" +
+	"\x06paused\x18\x06 \x01(\bR\x06paused\"j\n" +
+	"\x10HubCommandResult\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\x12\x16\n" +
+	"\x06output\x18\x04 \x01(\tR\x06output\"\xb6\x04\n" +
 	"\fSystemStatus\x12#\n" +
 	"\rpending_count\x18\x01 \x01(\x05R\fpendingCount\x12!\n" +
 	"\ffailed_count\x18\x02 \x01(\x05R\vfailedCount\x12*\n" +
 	"\x11dead_letter_count\x18\x03 \x01(\x05R\x0fdeadLetterCount\x120\n" +
 	"\x12home_hub_connected\x18\x04 \x01(\bB\x02\x18\x01R\x10homeHubConnected\x12U\n" +
 	"\x17last_home_hub_heartbeat\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampB\x02\x18\x01R\x14lastHomeHubHeartbeat\x12M\n" +
-	"\x13connected_endpoints\x18\x06 \x03(\v2\x1c.hookly.v1.ConnectedEndpointR\x12connectedEndpoints\"\xfa\x04\n" +
+	"\x13connected_endpoints\x18\x06 \x03(\v2\x1c.hookly.v1.ConnectedEndpointR\x12connectedEndpoints\x12D\n" +
+	"\x10maintenance_jobs\x18\a \x03(\v2\x19.hookly.v1.MaintenanceJobR\x0fmaintenanceJobs\x12>\n" +
+	"\x0econnected_hubs\x18\b \x03(\v2\x17.hookly.v1.ConnectedHubR\rconnectedHubs\x12T\n" +
+	"\x16rate_limited_endpoints\x18\t \x03(\v2\x1e.hookly.v1.RateLimitedEndpointR\x14rateLimitedEndpoints\"\xe5\x01\n" +
+	"\x0eMaintenanceJob\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12:\n" +
+	"\vlast_run_at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\tlastRunAt\x12:\n" +
+	"\vnext_run_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tnextRunAt\x12(\n" +
+	"\x10last_duration_ms\x18\x04 \x01(\x03R\x0elastDurationMs\x12\x1d\n" +
+	"\n" +
+	"last_error\x18\x05 \x01(\tR\tlastError\"\x8e\a\n" +
 	"\fUserSettings\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\x12\x1f\n" +
@@ -1124,7 +3542,50 @@ const file_hookly_v1_common_proto_rawDesc = "" +
 	"created_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
 	"updated_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12>\n" +
-	"\rlast_login_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\vlastLoginAt\"\xfe\x01\n" +
+	"\rlast_login_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\vlastLoginAt\x12-\n" +
+	"\x12discord_configured\x18\x0f \x01(\bR\x11discordConfigured\x12'\n" +
+	"\x0fdiscord_enabled\x18\x10 \x01(\bR\x0ediscordEnabled\x12#\n" +
+	"\remail_address\x18\x11 \x01(\tR\femailAddress\x12#\n" +
+	"\remail_enabled\x18\x12 \x01(\bR\femailEnabled\x12:\n" +
+	"\x19notify_webhook_configured\x18\x13 \x01(\bR\x17notifyWebhookConfigured\x124\n" +
+	"\x16notify_webhook_enabled\x18\x14 \x01(\bR\x14notifyWebhookEnabled\"\xa9\x02\n" +
+	"\bApiToken\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12<\n" +
+	"\flast_used_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"lastUsedAt\x12+\n" +
+	"\x05scope\x18\x05 \x01(\x0e2\x15.hookly.v1.TokenScopeR\x05scope\x129\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x18\n" +
+	"\aexpired\x18\a \x01(\bR\aexpired\"\x8c\x01\n" +
+	"\x03Org\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12&\n" +
+	"\x04role\x18\x04 \x01(\x0e2\x12.hookly.v1.OrgRoleR\x04role\"\xa3\x01\n" +
+	"\tOrgMember\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12&\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x12.hookly.v1.OrgRoleR\x04role\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"\x98\x02\n" +
+	"\n" +
+	"AuditEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x1a\n" +
+	"\busername\x18\x03 \x01(\tR\busername\x12\x19\n" +
+	"\btoken_id\x18\x04 \x01(\tR\atokenId\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x16\n" +
+	"\x06target\x18\x06 \x01(\tR\x06target\x12\x0e\n" +
+	"\x02ip\x18\a \x01(\tR\x02ip\x12\x16\n" +
+	"\x06result\x18\b \x01(\tR\x06result\x12;\n" +
+	"\voccurred_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12\x15\n" +
+	"\x06org_id\x18\n" +
+	" \x01(\tR\x05orgId\"\xa9\x03\n" +
 	"\x0eSystemSettings\x12\x19\n" +
 	"\bbase_url\x18\x01 \x01(\tR\abaseUrl\x12\x1d\n" +
 	"\n" +
@@ -1133,33 +3594,110 @@ const file_hookly_v1_common_proto_rawDesc = "" +
 	"\x17system_telegram_enabled\x18\x04 \x01(\bR\x15systemTelegramEnabled\x12\x1f\n" +
 	"\vtotal_users\x18\x05 \x01(\x05R\n" +
 	"totalUsers\x12'\n" +
-	"\x0ftotal_endpoints\x18\x06 \x01(\x05R\x0etotalEndpoints*\xb2\x01\n" +
+	"\x0ftotal_endpoints\x18\x06 \x01(\x05R\x0etotalEndpoints\x124\n" +
+	"\x16system_discord_enabled\x18\a \x01(\bR\x14systemDiscordEnabled\x120\n" +
+	"\x14system_email_enabled\x18\b \x01(\bR\x12systemEmailEnabled\x12A\n" +
+	"\x1dsystem_notify_webhook_enabled\x18\t \x01(\bR\x1asystemNotifyWebhookEnabled\"\x8d\x02\n" +
+	"\x0fConnectionEvent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
+	"\vendpoint_id\x18\x02 \x01(\tR\n" +
+	"endpointId\x12#\n" +
+	"\rendpoint_name\x18\x03 \x01(\tR\fendpointName\x12\x15\n" +
+	"\x06hub_id\x18\x04 \x01(\tR\x05hubId\x122\n" +
+	"\x04type\x18\x05 \x01(\x0e2\x1e.hookly.v1.ConnectionEventTypeR\x04type\x12\x1c\n" +
+	"\ttransport\x18\x06 \x01(\tR\ttransport\x12;\n" +
+	"\voccurred_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\"\xb6\x02\n" +
+	"\fActivityItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12+\n" +
+	"\x04kind\x18\x02 \x01(\x0e2\x17.hookly.v1.ActivityKindR\x04kind\x12\x1f\n" +
+	"\vendpoint_id\x18\x03 \x01(\tR\n" +
+	"endpointId\x12#\n" +
+	"\rendpoint_name\x18\x04 \x01(\tR\fendpointName\x12\x15\n" +
+	"\x06hub_id\x18\x05 \x01(\tR\x05hubId\x12\x14\n" +
+	"\x05count\x18\x06 \x01(\x05R\x05count\x12;\n" +
+	"\voccurred_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xd2\x01\n" +
+	"\x06Region\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\x12\x18\n" +
+	"\ahealthy\x18\x03 \x01(\bR\ahealthy\x12\x1d\n" +
+	"\n" +
+	"latency_ms\x18\x04 \x01(\x03R\tlatencyMs\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x129\n" +
+	"\n" +
+	"checked_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcheckedAt\x12\x18\n" +
+	"\acurrent\x18\a \x01(\bR\acurrent*\x9a\x02\n" +
 	"\fProviderType\x12\x1d\n" +
 	"\x19PROVIDER_TYPE_UNSPECIFIED\x10\x00\x12\x18\n" +
 	"\x14PROVIDER_TYPE_STRIPE\x10\x01\x12\x18\n" +
 	"\x14PROVIDER_TYPE_GITHUB\x10\x02\x12\x1a\n" +
 	"\x16PROVIDER_TYPE_TELEGRAM\x10\x03\x12\x19\n" +
 	"\x15PROVIDER_TYPE_GENERIC\x10\x04\x12\x18\n" +
-	"\x14PROVIDER_TYPE_CUSTOM\x10\x05*\xcb\x01\n" +
+	"\x14PROVIDER_TYPE_CUSTOM\x10\x05\x12\x17\n" +
+	"\x13PROVIDER_TYPE_SLACK\x10\x06\x12\x19\n" +
+	"\x15PROVIDER_TYPE_SHOPIFY\x10\a\x12\x18\n" +
+	"\x14PROVIDER_TYPE_SQUARE\x10\b\x12\x18\n" +
+	"\x14PROVIDER_TYPE_PAYPAL\x10\t*\xcb\x01\n" +
 	"\x12VerificationMethod\x12#\n" +
 	"\x1fVERIFICATION_METHOD_UNSPECIFIED\x10\x00\x12\x1e\n" +
 	"\x1aVERIFICATION_METHOD_STATIC\x10\x01\x12#\n" +
 	"\x1fVERIFICATION_METHOD_HMAC_SHA256\x10\x02\x12!\n" +
 	"\x1dVERIFICATION_METHOD_HMAC_SHA1\x10\x03\x12(\n" +
-	"$VERIFICATION_METHOD_TIMESTAMPED_HMAC\x10\x04*\xa4\x01\n" +
+	"$VERIFICATION_METHOD_TIMESTAMPED_HMAC\x10\x04*s\n" +
+	"\x10IngestAuthMethod\x12\"\n" +
+	"\x1eINGEST_AUTH_METHOD_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18INGEST_AUTH_METHOD_BASIC\x10\x01\x12\x1d\n" +
+	"\x19INGEST_AUTH_METHOD_HEADER\x10\x02*m\n" +
+	"\fEndpointSort\x12\x1d\n" +
+	"\x19ENDPOINT_SORT_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19ENDPOINT_SORT_CREATED_ASC\x10\x01\x12\x1f\n" +
+	"\x1bENDPOINT_SORT_LAST_RECEIVED\x10\x02*\xeb\x01\n" +
 	"\rWebhookStatus\x12\x1e\n" +
 	"\x1aWEBHOOK_STATUS_UNSPECIFIED\x10\x00\x12\x1a\n" +
 	"\x16WEBHOOK_STATUS_PENDING\x10\x01\x12\x1c\n" +
 	"\x18WEBHOOK_STATUS_DELIVERED\x10\x02\x12\x19\n" +
 	"\x15WEBHOOK_STATUS_FAILED\x10\x03\x12\x1e\n" +
-	"\x1aWEBHOOK_STATUS_DEAD_LETTER\x10\x04*\xd6\x01\n" +
+	"\x1aWEBHOOK_STATUS_DEAD_LETTER\x10\x04\x12\x1a\n" +
+	"\x16WEBHOOK_STATUS_SKIPPED\x10\x05\x12)\n" +
+	"%WEBHOOK_STATUS_ACKNOWLEDGED_DUPLICATE\x10\x06*\xed\x01\n" +
+	"\x0eHubCommandType\x12 \n" +
+	"\x1cHUB_COMMAND_TYPE_UNSPECIFIED\x10\x00\x12\"\n" +
+	"\x1eHUB_COMMAND_TYPE_RELOAD_CONFIG\x10\x01\x12\x1a\n" +
+	"\x16HUB_COMMAND_TYPE_PAUSE\x10\x02\x12\x1b\n" +
+	"\x17HUB_COMMAND_TYPE_RESUME\x10\x03\x12 \n" +
+	"\x1cHUB_COMMAND_TYPE_DIAGNOSTICS\x10\x04\x12\x1f\n" +
+	"\x1bHUB_COMMAND_TYPE_DISCONNECT\x10\x05\x12\x19\n" +
+	"\x15HUB_COMMAND_TYPE_LOGS\x10\x06*\xd6\x01\n" +
 	"\x0fThemePreference\x12 \n" +
 	"\x1cTHEME_PREFERENCE_UNSPECIFIED\x10\x00\x12\x1b\n" +
 	"\x17THEME_PREFERENCE_SYSTEM\x10\x01\x12\x1a\n" +
 	"\x16THEME_PREFERENCE_LIGHT\x10\x02\x12\x19\n" +
 	"\x15THEME_PREFERENCE_DARK\x10\x03\x12&\n" +
 	"\"THEME_PREFERENCE_PLACID_BLUE_LIGHT\x10\x04\x12%\n" +
-	"!THEME_PREFERENCE_PLACID_BLUE_DARK\x10\x05B\x92\x01\n" +
+	"!THEME_PREFERENCE_PLACID_BLUE_DARK\x10\x05*m\n" +
+	"\n" +
+	"TokenScope\x12\x1b\n" +
+	"\x17TOKEN_SCOPE_UNSPECIFIED\x10\x00\x12\x15\n" +
+	"\x11TOKEN_SCOPE_ADMIN\x10\x01\x12\x14\n" +
+	"\x10TOKEN_SCOPE_READ\x10\x02\x12\x15\n" +
+	"\x11TOKEN_SCOPE_RELAY\x10\x03*a\n" +
+	"\aOrgRole\x12\x18\n" +
+	"\x14ORG_ROLE_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eORG_ROLE_OWNER\x10\x01\x12\x13\n" +
+	"\x0fORG_ROLE_MEMBER\x10\x02\x12\x13\n" +
+	"\x0fORG_ROLE_VIEWER\x10\x03*\x90\x01\n" +
+	"\fActivityKind\x12\x1d\n" +
+	"\x19ACTIVITY_KIND_UNSPECIFIED\x10\x00\x12\x1c\n" +
+	"\x18ACTIVITY_KIND_DELIVERIES\x10\x01\x12\x1f\n" +
+	"\x1bACTIVITY_KIND_HUB_CONNECTED\x10\x02\x12\"\n" +
+	"\x1eACTIVITY_KIND_HUB_DISCONNECTED\x10\x03*\x89\x01\n" +
+	"\x13ConnectionEventType\x12%\n" +
+	"!CONNECTION_EVENT_TYPE_UNSPECIFIED\x10\x00\x12#\n" +
+	"\x1fCONNECTION_EVENT_TYPE_CONNECTED\x10\x01\x12&\n" +
+	"\"CONNECTION_EVENT_TYPE_DISCONNECTED\x10\x02B\x92\x01\n" +
 	"\rcom.hookly.v1B\vCommonProtoP\x01Z/hooks.dx314.com/internal/api/hookly/v1;hooklyv1\xa2\x02\x03HXX\xaa\x02\tHookly.V1\xca\x02\tHookly\\V1\xe2\x02\x15Hookly\\V1\\GPBMetadata\xea\x02\n" +
 	"Hookly::V1b\x06proto3"
 
@@ -1175,47 +3713,118 @@ func file_hookly_v1_common_proto_rawDescGZIP() []byte {
 	return file_hookly_v1_common_proto_rawDescData
 }
 
-var file_hookly_v1_common_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_hookly_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_hookly_v1_common_proto_enumTypes = make([]protoimpl.EnumInfo, 11)
+var file_hookly_v1_common_proto_msgTypes = make([]protoimpl.MessageInfo, 30)
 var file_hookly_v1_common_proto_goTypes = []any{
 	(ProviderType)(0),             // 0: hookly.v1.ProviderType
 	(VerificationMethod)(0),       // 1: hookly.v1.VerificationMethod
-	(WebhookStatus)(0),            // 2: hookly.v1.WebhookStatus
-	(ThemePreference)(0),          // 3: hookly.v1.ThemePreference
-	(*VerificationConfig)(nil),    // 4: hookly.v1.VerificationConfig
-	(*Endpoint)(nil),              // 5: hookly.v1.Endpoint
-	(*Webhook)(nil),               // 6: hookly.v1.Webhook
-	(*PaginationRequest)(nil),     // 7: hookly.v1.PaginationRequest
-	(*PaginationResponse)(nil),    // 8: hookly.v1.PaginationResponse
-	(*ConnectedEndpoint)(nil),     // 9: hookly.v1.ConnectedEndpoint
-	(*SystemStatus)(nil),          // 10: hookly.v1.SystemStatus
-	(*UserSettings)(nil),          // 11: hookly.v1.UserSettings
-	(*SystemSettings)(nil),        // 12: hookly.v1.SystemSettings
-	nil,                           // 13: hookly.v1.Webhook.HeadersEntry
-	(*timestamppb.Timestamp)(nil), // 14: google.protobuf.Timestamp
+	(IngestAuthMethod)(0),         // 2: hookly.v1.IngestAuthMethod
+	(EndpointSort)(0),             // 3: hookly.v1.EndpointSort
+	(WebhookStatus)(0),            // 4: hookly.v1.WebhookStatus
+	(HubCommandType)(0),           // 5: hookly.v1.HubCommandType
+	(ThemePreference)(0),          // 6: hookly.v1.ThemePreference
+	(TokenScope)(0),               // 7: hookly.v1.TokenScope
+	(OrgRole)(0),                  // 8: hookly.v1.OrgRole
+	(ActivityKind)(0),             // 9: hookly.v1.ActivityKind
+	(ConnectionEventType)(0),      // 10: hookly.v1.ConnectionEventType
+	(*VerificationConfig)(nil),    // 11: hookly.v1.VerificationConfig
+	(*IngestAuth)(nil),            // 12: hookly.v1.IngestAuth
+	(*Transform)(nil),             // 13: hookly.v1.Transform
+	(*IngestResponse)(nil),        // 14: hookly.v1.IngestResponse
+	(*RetryPolicy)(nil),           // 15: hookly.v1.RetryPolicy
+	(*PayloadLimits)(nil),         // 16: hookly.v1.PayloadLimits
+	(*Destination)(nil),           // 17: hookly.v1.Destination
+	(*DestinationDelivery)(nil),   // 18: hookly.v1.DestinationDelivery
+	(*Endpoint)(nil),              // 19: hookly.v1.Endpoint
+	(*Webhook)(nil),               // 20: hookly.v1.Webhook
+	(*WebhookStatusChange)(nil),   // 21: hookly.v1.WebhookStatusChange
+	(*PaginationRequest)(nil),     // 22: hookly.v1.PaginationRequest
+	(*PaginationResponse)(nil),    // 23: hookly.v1.PaginationResponse
+	(*ConnectedEndpoint)(nil),     // 24: hookly.v1.ConnectedEndpoint
+	(*RateLimitedEndpoint)(nil),   // 25: hookly.v1.RateLimitedEndpoint
+	(*ConnectedHub)(nil),          // 26: hookly.v1.ConnectedHub
+	(*HubCommandResult)(nil),      // 27: hookly.v1.HubCommandResult
+	(*SystemStatus)(nil),          // 28: hookly.v1.SystemStatus
+	(*MaintenanceJob)(nil),        // 29: hookly.v1.MaintenanceJob
+	(*UserSettings)(nil),          // 30: hookly.v1.UserSettings
+	(*ApiToken)(nil),              // 31: hookly.v1.ApiToken
+	(*Org)(nil),                   // 32: hookly.v1.Org
+	(*OrgMember)(nil),             // 33: hookly.v1.OrgMember
+	(*AuditEvent)(nil),            // 34: hookly.v1.AuditEvent
+	(*SystemSettings)(nil),        // 35: hookly.v1.SystemSettings
+	(*ConnectionEvent)(nil),       // 36: hookly.v1.ConnectionEvent
+	(*ActivityItem)(nil),          // 37: hookly.v1.ActivityItem
+	(*Region)(nil),                // 38: hookly.v1.Region
+	nil,                           // 39: hookly.v1.Transform.HeadersEntry
+	nil,                           // 40: hookly.v1.Webhook.HeadersEntry
+	(*timestamppb.Timestamp)(nil), // 41: google.protobuf.Timestamp
 }
 var file_hookly_v1_common_proto_depIdxs = []int32{
 	1,  // 0: hookly.v1.VerificationConfig.method:type_name -> hookly.v1.VerificationMethod
-	0,  // 1: hookly.v1.Endpoint.provider_type:type_name -> hookly.v1.ProviderType
-	14, // 2: hookly.v1.Endpoint.created_at:type_name -> google.protobuf.Timestamp
-	14, // 3: hookly.v1.Endpoint.updated_at:type_name -> google.protobuf.Timestamp
-	4,  // 4: hookly.v1.Endpoint.verification_config:type_name -> hookly.v1.VerificationConfig
-	14, // 5: hookly.v1.Webhook.received_at:type_name -> google.protobuf.Timestamp
-	13, // 6: hookly.v1.Webhook.headers:type_name -> hookly.v1.Webhook.HeadersEntry
-	2,  // 7: hookly.v1.Webhook.status:type_name -> hookly.v1.WebhookStatus
-	14, // 8: hookly.v1.Webhook.last_attempt_at:type_name -> google.protobuf.Timestamp
-	14, // 9: hookly.v1.Webhook.delivered_at:type_name -> google.protobuf.Timestamp
-	14, // 10: hookly.v1.SystemStatus.last_home_hub_heartbeat:type_name -> google.protobuf.Timestamp
-	9,  // 11: hookly.v1.SystemStatus.connected_endpoints:type_name -> hookly.v1.ConnectedEndpoint
-	3,  // 12: hookly.v1.UserSettings.theme_preference:type_name -> hookly.v1.ThemePreference
-	14, // 13: hookly.v1.UserSettings.created_at:type_name -> google.protobuf.Timestamp
-	14, // 14: hookly.v1.UserSettings.updated_at:type_name -> google.protobuf.Timestamp
-	14, // 15: hookly.v1.UserSettings.last_login_at:type_name -> google.protobuf.Timestamp
-	16, // [16:16] is the sub-list for method output_type
-	16, // [16:16] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	2,  // 1: hookly.v1.IngestAuth.method:type_name -> hookly.v1.IngestAuthMethod
+	39, // 2: hookly.v1.Transform.headers:type_name -> hookly.v1.Transform.HeadersEntry
+	4,  // 3: hookly.v1.DestinationDelivery.status:type_name -> hookly.v1.WebhookStatus
+	41, // 4: hookly.v1.DestinationDelivery.last_attempt_at:type_name -> google.protobuf.Timestamp
+	41, // 5: hookly.v1.DestinationDelivery.delivered_at:type_name -> google.protobuf.Timestamp
+	0,  // 6: hookly.v1.Endpoint.provider_type:type_name -> hookly.v1.ProviderType
+	41, // 7: hookly.v1.Endpoint.created_at:type_name -> google.protobuf.Timestamp
+	41, // 8: hookly.v1.Endpoint.updated_at:type_name -> google.protobuf.Timestamp
+	11, // 9: hookly.v1.Endpoint.verification_config:type_name -> hookly.v1.VerificationConfig
+	41, // 10: hookly.v1.Endpoint.first_event_at:type_name -> google.protobuf.Timestamp
+	12, // 11: hookly.v1.Endpoint.ingest_auth:type_name -> hookly.v1.IngestAuth
+	41, // 12: hookly.v1.Endpoint.last_webhook_received_at:type_name -> google.protobuf.Timestamp
+	41, // 13: hookly.v1.Endpoint.last_delivered_at:type_name -> google.protobuf.Timestamp
+	41, // 14: hookly.v1.Endpoint.archived_at:type_name -> google.protobuf.Timestamp
+	13, // 15: hookly.v1.Endpoint.transform:type_name -> hookly.v1.Transform
+	17, // 16: hookly.v1.Endpoint.destinations:type_name -> hookly.v1.Destination
+	14, // 17: hookly.v1.Endpoint.ingest_response:type_name -> hookly.v1.IngestResponse
+	15, // 18: hookly.v1.Endpoint.retry_policy:type_name -> hookly.v1.RetryPolicy
+	16, // 19: hookly.v1.Endpoint.payload_limits:type_name -> hookly.v1.PayloadLimits
+	41, // 20: hookly.v1.Webhook.received_at:type_name -> google.protobuf.Timestamp
+	40, // 21: hookly.v1.Webhook.headers:type_name -> hookly.v1.Webhook.HeadersEntry
+	4,  // 22: hookly.v1.Webhook.status:type_name -> hookly.v1.WebhookStatus
+	41, // 23: hookly.v1.Webhook.last_attempt_at:type_name -> google.protobuf.Timestamp
+	41, // 24: hookly.v1.Webhook.delivered_at:type_name -> google.protobuf.Timestamp
+	21, // 25: hookly.v1.Webhook.status_history:type_name -> hookly.v1.WebhookStatusChange
+	41, // 26: hookly.v1.Webhook.replayed_at:type_name -> google.protobuf.Timestamp
+	41, // 27: hookly.v1.Webhook.purged_at:type_name -> google.protobuf.Timestamp
+	41, // 28: hookly.v1.Webhook.purge_expires_at:type_name -> google.protobuf.Timestamp
+	4,  // 29: hookly.v1.WebhookStatusChange.from_status:type_name -> hookly.v1.WebhookStatus
+	4,  // 30: hookly.v1.WebhookStatusChange.to_status:type_name -> hookly.v1.WebhookStatus
+	41, // 31: hookly.v1.WebhookStatusChange.changed_at:type_name -> google.protobuf.Timestamp
+	41, // 32: hookly.v1.ConnectedHub.connected_at:type_name -> google.protobuf.Timestamp
+	41, // 33: hookly.v1.ConnectedHub.last_heartbeat_at:type_name -> google.protobuf.Timestamp
+	41, // 34: hookly.v1.SystemStatus.last_home_hub_heartbeat:type_name -> google.protobuf.Timestamp
+	24, // 35: hookly.v1.SystemStatus.connected_endpoints:type_name -> hookly.v1.ConnectedEndpoint
+	29, // 36: hookly.v1.SystemStatus.maintenance_jobs:type_name -> hookly.v1.MaintenanceJob
+	26, // 37: hookly.v1.SystemStatus.connected_hubs:type_name -> hookly.v1.ConnectedHub
+	25, // 38: hookly.v1.SystemStatus.rate_limited_endpoints:type_name -> hookly.v1.RateLimitedEndpoint
+	41, // 39: hookly.v1.MaintenanceJob.last_run_at:type_name -> google.protobuf.Timestamp
+	41, // 40: hookly.v1.MaintenanceJob.next_run_at:type_name -> google.protobuf.Timestamp
+	6,  // 41: hookly.v1.UserSettings.theme_preference:type_name -> hookly.v1.ThemePreference
+	41, // 42: hookly.v1.UserSettings.created_at:type_name -> google.protobuf.Timestamp
+	41, // 43: hookly.v1.UserSettings.updated_at:type_name -> google.protobuf.Timestamp
+	41, // 44: hookly.v1.UserSettings.last_login_at:type_name -> google.protobuf.Timestamp
+	41, // 45: hookly.v1.ApiToken.created_at:type_name -> google.protobuf.Timestamp
+	41, // 46: hookly.v1.ApiToken.last_used_at:type_name -> google.protobuf.Timestamp
+	7,  // 47: hookly.v1.ApiToken.scope:type_name -> hookly.v1.TokenScope
+	41, // 48: hookly.v1.ApiToken.expires_at:type_name -> google.protobuf.Timestamp
+	41, // 49: hookly.v1.Org.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 50: hookly.v1.Org.role:type_name -> hookly.v1.OrgRole
+	8,  // 51: hookly.v1.OrgMember.role:type_name -> hookly.v1.OrgRole
+	41, // 52: hookly.v1.OrgMember.created_at:type_name -> google.protobuf.Timestamp
+	41, // 53: hookly.v1.AuditEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	10, // 54: hookly.v1.ConnectionEvent.type:type_name -> hookly.v1.ConnectionEventType
+	41, // 55: hookly.v1.ConnectionEvent.occurred_at:type_name -> google.protobuf.Timestamp
+	9,  // 56: hookly.v1.ActivityItem.kind:type_name -> hookly.v1.ActivityKind
+	41, // 57: hookly.v1.ActivityItem.occurred_at:type_name -> google.protobuf.Timestamp
+	41, // 58: hookly.v1.ActivityItem.updated_at:type_name -> google.protobuf.Timestamp
+	41, // 59: hookly.v1.Region.checked_at:type_name -> google.protobuf.Timestamp
+	60, // [60:60] is the sub-list for method output_type
+	60, // [60:60] is the sub-list for method input_type
+	60, // [60:60] is the sub-list for extension type_name
+	60, // [60:60] is the sub-list for extension extendee
+	0,  // [0:60] is the sub-list for field type_name
 }
 
 func init() { file_hookly_v1_common_proto_init() }
@@ -1228,8 +3837,8 @@ func file_hookly_v1_common_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hookly_v1_common_proto_rawDesc), len(file_hookly_v1_common_proto_rawDesc)),
-			NumEnums:      4,
-			NumMessages:   10,
+			NumEnums:      11,
+			NumMessages:   30,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
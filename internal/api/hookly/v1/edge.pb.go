@@ -9,6 +9,7 @@ package hooklyv1
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -29,8 +30,14 @@ type CreateEndpointRequest struct {
 	DestinationUrl  string                 `protobuf:"bytes,4,opt,name=destination_url,json=destinationUrl,proto3" json:"destination_url,omitempty"`
 	// Custom verification config (required for PROVIDER_TYPE_CUSTOM)
 	VerificationConfig *VerificationConfig `protobuf:"bytes,5,opt,name=verification_config,json=verificationConfig,proto3" json:"verification_config,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	// Send a notification when the first webhook arrives
+	NotifyFirstEvent bool `protobuf:"varint,6,opt,name=notify_first_event,json=notifyFirstEvent,proto3" json:"notify_first_event,omitempty"`
+	// Credentials required at the ingestion URL (optional)
+	IngestAuth *IngestAuth `protobuf:"bytes,7,opt,name=ingest_auth,json=ingestAuth,proto3" json:"ingest_auth,omitempty"`
+	// Alert on every hit and never relay; destination_url is optional
+	Honeypot      bool `protobuf:"varint,8,opt,name=honeypot,proto3" json:"honeypot,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateEndpointRequest) Reset() {
@@ -98,6 +105,27 @@ func (x *CreateEndpointRequest) GetVerificationConfig() *VerificationConfig {
 	return nil
 }
 
+func (x *CreateEndpointRequest) GetNotifyFirstEvent() bool {
+	if x != nil {
+		return x.NotifyFirstEvent
+	}
+	return false
+}
+
+func (x *CreateEndpointRequest) GetIngestAuth() *IngestAuth {
+	if x != nil {
+		return x.IngestAuth
+	}
+	return nil
+}
+
+func (x *CreateEndpointRequest) GetHoneypot() bool {
+	if x != nil {
+		return x.Honeypot
+	}
+	return false
+}
+
 type CreateEndpointResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Endpoint      *Endpoint              `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
@@ -247,8 +275,18 @@ func (x *GetEndpointResponse) GetWebhookUrl() string {
 }
 
 type ListEndpointsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Pagination    *PaginationRequest     `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Pagination *PaginationRequest     `protobuf:"bytes,1,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	// Case-insensitive substring of the endpoint name
+	Search string `protobuf:"bytes,2,opt,name=search,proto3" json:"search,omitempty"`
+	// Only endpoints of this provider when set
+	ProviderType ProviderType `protobuf:"varint,3,opt,name=provider_type,json=providerType,proto3,enum=hookly.v1.ProviderType" json:"provider_type,omitempty"`
+	// Only muted (true) or unmuted (false) endpoints when set
+	Muted *bool        `protobuf:"varint,4,opt,name=muted,proto3,oneof" json:"muted,omitempty"`
+	Sort  EndpointSort `protobuf:"varint,5,opt,name=sort,proto3,enum=hookly.v1.EndpointSort" json:"sort,omitempty"`
+	// Only endpoints without a webhook for this many days, counting from
+	// creation for endpoints that never received one. 0 disables the filter.
+	InactiveDays  int32 `protobuf:"varint,6,opt,name=inactive_days,json=inactiveDays,proto3" json:"inactive_days,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -290,6 +328,41 @@ func (x *ListEndpointsRequest) GetPagination() *PaginationRequest {
 	return nil
 }
 
+func (x *ListEndpointsRequest) GetSearch() string {
+	if x != nil {
+		return x.Search
+	}
+	return ""
+}
+
+func (x *ListEndpointsRequest) GetProviderType() ProviderType {
+	if x != nil {
+		return x.ProviderType
+	}
+	return ProviderType_PROVIDER_TYPE_UNSPECIFIED
+}
+
+func (x *ListEndpointsRequest) GetMuted() bool {
+	if x != nil && x.Muted != nil {
+		return *x.Muted
+	}
+	return false
+}
+
+func (x *ListEndpointsRequest) GetSort() EndpointSort {
+	if x != nil {
+		return x.Sort
+	}
+	return EndpointSort_ENDPOINT_SORT_UNSPECIFIED
+}
+
+func (x *ListEndpointsRequest) GetInactiveDays() int32 {
+	if x != nil {
+		return x.InactiveDays
+	}
+	return 0
+}
+
 type ListEndpointsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Endpoints     []*Endpoint            `protobuf:"bytes,1,rep,name=endpoints,proto3" json:"endpoints,omitempty"`
@@ -351,8 +424,31 @@ type UpdateEndpointRequest struct {
 	Muted           *bool                  `protobuf:"varint,5,opt,name=muted,proto3,oneof" json:"muted,omitempty"`
 	// Custom verification config (only for PROVIDER_TYPE_CUSTOM endpoints)
 	VerificationConfig *VerificationConfig `protobuf:"bytes,6,opt,name=verification_config,json=verificationConfig,proto3" json:"verification_config,omitempty"`
-	unknownFields      protoimpl.UnknownFields
-	sizeCache          protoimpl.SizeCache
+	NotifyFirstEvent   *bool               `protobuf:"varint,7,opt,name=notify_first_event,json=notifyFirstEvent,proto3,oneof" json:"notify_first_event,omitempty"`
+	// Delivery SLO; set slo_target to 0 to disable
+	SloTarget         *float64 `protobuf:"fixed64,8,opt,name=slo_target,json=sloTarget,proto3,oneof" json:"slo_target,omitempty"`
+	SloLatencySeconds *int32   `protobuf:"varint,9,opt,name=slo_latency_seconds,json=sloLatencySeconds,proto3,oneof" json:"slo_latency_seconds,omitempty"`
+	SloWindowHours    *int32   `protobuf:"varint,10,opt,name=slo_window_hours,json=sloWindowHours,proto3,oneof" json:"slo_window_hours,omitempty"`
+	RejectDuplicates  *bool    `protobuf:"varint,11,opt,name=reject_duplicates,json=rejectDuplicates,proto3,oneof" json:"reject_duplicates,omitempty"`
+	// Replaces the ingestion credentials; method unspecified removes them
+	IngestAuth          *IngestAuth `protobuf:"bytes,12,opt,name=ingest_auth,json=ingestAuth,proto3" json:"ingest_auth,omitempty"`
+	Honeypot            *bool       `protobuf:"varint,13,opt,name=honeypot,proto3,oneof" json:"honeypot,omitempty"`
+	ConflictAsDuplicate *bool       `protobuf:"varint,14,opt,name=conflict_as_duplicate,json=conflictAsDuplicate,proto3,oneof" json:"conflict_as_duplicate,omitempty"`
+	// Requests per minute; 0 reverts to the edge's limit
+	RateLimitPerMinute *int32 `protobuf:"varint,15,opt,name=rate_limit_per_minute,json=rateLimitPerMinute,proto3,oneof" json:"rate_limit_per_minute,omitempty"`
+	// Replaces the transform; an empty one removes it
+	Transform *Transform `protobuf:"bytes,16,opt,name=transform,proto3" json:"transform,omitempty"`
+	// Replaces the additional destinations; an empty list removes them
+	Destinations *DestinationList `protobuf:"bytes,17,opt,name=destinations,proto3" json:"destinations,omitempty"`
+	AnswerProbes *bool            `protobuf:"varint,18,opt,name=answer_probes,json=answerProbes,proto3,oneof" json:"answer_probes,omitempty"`
+	// Replaces the ingest response; an empty one restores the default
+	IngestResponse *IngestResponse `protobuf:"bytes,19,opt,name=ingest_response,json=ingestResponse,proto3" json:"ingest_response,omitempty"`
+	// Replaces the retry policy when set; the zero policy restores the default
+	RetryPolicy *RetryPolicy `protobuf:"bytes,20,opt,name=retry_policy,json=retryPolicy,proto3" json:"retry_policy,omitempty"`
+	// Replaces the payload limits when set; the zero value removes them
+	PayloadLimits *PayloadLimits `protobuf:"bytes,21,opt,name=payload_limits,json=payloadLimits,proto3" json:"payload_limits,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateEndpointRequest) Reset() {
@@ -427,27 +523,132 @@ func (x *UpdateEndpointRequest) GetVerificationConfig() *VerificationConfig {
 	return nil
 }
 
-type UpdateEndpointResponse struct {
+func (x *UpdateEndpointRequest) GetNotifyFirstEvent() bool {
+	if x != nil && x.NotifyFirstEvent != nil {
+		return *x.NotifyFirstEvent
+	}
+	return false
+}
+
+func (x *UpdateEndpointRequest) GetSloTarget() float64 {
+	if x != nil && x.SloTarget != nil {
+		return *x.SloTarget
+	}
+	return 0
+}
+
+func (x *UpdateEndpointRequest) GetSloLatencySeconds() int32 {
+	if x != nil && x.SloLatencySeconds != nil {
+		return *x.SloLatencySeconds
+	}
+	return 0
+}
+
+func (x *UpdateEndpointRequest) GetSloWindowHours() int32 {
+	if x != nil && x.SloWindowHours != nil {
+		return *x.SloWindowHours
+	}
+	return 0
+}
+
+func (x *UpdateEndpointRequest) GetRejectDuplicates() bool {
+	if x != nil && x.RejectDuplicates != nil {
+		return *x.RejectDuplicates
+	}
+	return false
+}
+
+func (x *UpdateEndpointRequest) GetIngestAuth() *IngestAuth {
+	if x != nil {
+		return x.IngestAuth
+	}
+	return nil
+}
+
+func (x *UpdateEndpointRequest) GetHoneypot() bool {
+	if x != nil && x.Honeypot != nil {
+		return *x.Honeypot
+	}
+	return false
+}
+
+func (x *UpdateEndpointRequest) GetConflictAsDuplicate() bool {
+	if x != nil && x.ConflictAsDuplicate != nil {
+		return *x.ConflictAsDuplicate
+	}
+	return false
+}
+
+func (x *UpdateEndpointRequest) GetRateLimitPerMinute() int32 {
+	if x != nil && x.RateLimitPerMinute != nil {
+		return *x.RateLimitPerMinute
+	}
+	return 0
+}
+
+func (x *UpdateEndpointRequest) GetTransform() *Transform {
+	if x != nil {
+		return x.Transform
+	}
+	return nil
+}
+
+func (x *UpdateEndpointRequest) GetDestinations() *DestinationList {
+	if x != nil {
+		return x.Destinations
+	}
+	return nil
+}
+
+func (x *UpdateEndpointRequest) GetAnswerProbes() bool {
+	if x != nil && x.AnswerProbes != nil {
+		return *x.AnswerProbes
+	}
+	return false
+}
+
+func (x *UpdateEndpointRequest) GetIngestResponse() *IngestResponse {
+	if x != nil {
+		return x.IngestResponse
+	}
+	return nil
+}
+
+func (x *UpdateEndpointRequest) GetRetryPolicy() *RetryPolicy {
+	if x != nil {
+		return x.RetryPolicy
+	}
+	return nil
+}
+
+func (x *UpdateEndpointRequest) GetPayloadLimits() *PayloadLimits {
+	if x != nil {
+		return x.PayloadLimits
+	}
+	return nil
+}
+
+type DestinationList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Endpoint      *Endpoint              `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	Urls          []string               `protobuf:"bytes,1,rep,name=urls,proto3" json:"urls,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateEndpointResponse) Reset() {
-	*x = UpdateEndpointResponse{}
+func (x *DestinationList) Reset() {
+	*x = DestinationList{}
 	mi := &file_hookly_v1_edge_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateEndpointResponse) String() string {
+func (x *DestinationList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateEndpointResponse) ProtoMessage() {}
+func (*DestinationList) ProtoMessage() {}
 
-func (x *UpdateEndpointResponse) ProtoReflect() protoreflect.Message {
+func (x *DestinationList) ProtoReflect() protoreflect.Message {
 	mi := &file_hookly_v1_edge_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -459,39 +660,39 @@ func (x *UpdateEndpointResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateEndpointResponse.ProtoReflect.Descriptor instead.
-func (*UpdateEndpointResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DestinationList.ProtoReflect.Descriptor instead.
+func (*DestinationList) Descriptor() ([]byte, []int) {
 	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *UpdateEndpointResponse) GetEndpoint() *Endpoint {
+func (x *DestinationList) GetUrls() []string {
 	if x != nil {
-		return x.Endpoint
+		return x.Urls
 	}
 	return nil
 }
 
-type DeleteEndpointRequest struct {
+type UpdateEndpointResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Endpoint      *Endpoint              `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteEndpointRequest) Reset() {
-	*x = DeleteEndpointRequest{}
+func (x *UpdateEndpointResponse) Reset() {
+	*x = UpdateEndpointResponse{}
 	mi := &file_hookly_v1_edge_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteEndpointRequest) String() string {
+func (x *UpdateEndpointResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteEndpointRequest) ProtoMessage() {}
+func (*UpdateEndpointResponse) ProtoMessage() {}
 
-func (x *DeleteEndpointRequest) ProtoReflect() protoreflect.Message {
+func (x *UpdateEndpointResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_hookly_v1_edge_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -503,38 +704,39 @@ func (x *DeleteEndpointRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteEndpointRequest.ProtoReflect.Descriptor instead.
-func (*DeleteEndpointRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use UpdateEndpointResponse.ProtoReflect.Descriptor instead.
+func (*UpdateEndpointResponse) Descriptor() ([]byte, []int) {
 	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *DeleteEndpointRequest) GetId() string {
+func (x *UpdateEndpointResponse) GetEndpoint() *Endpoint {
 	if x != nil {
-		return x.Id
+		return x.Endpoint
 	}
-	return ""
+	return nil
 }
 
-type DeleteEndpointResponse struct {
+type DeleteEndpointRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteEndpointResponse) Reset() {
-	*x = DeleteEndpointResponse{}
+func (x *DeleteEndpointRequest) Reset() {
+	*x = DeleteEndpointRequest{}
 	mi := &file_hookly_v1_edge_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteEndpointResponse) String() string {
+func (x *DeleteEndpointRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteEndpointResponse) ProtoMessage() {}
+func (*DeleteEndpointRequest) ProtoMessage() {}
 
-func (x *DeleteEndpointResponse) ProtoReflect() protoreflect.Message {
+func (x *DeleteEndpointRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_hookly_v1_edge_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -546,32 +748,38 @@ func (x *DeleteEndpointResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteEndpointResponse.ProtoReflect.Descriptor instead.
-func (*DeleteEndpointResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteEndpointRequest.ProtoReflect.Descriptor instead.
+func (*DeleteEndpointRequest) Descriptor() ([]byte, []int) {
 	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{9}
 }
 
-type GetWebhookRequest struct {
+func (x *DeleteEndpointRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteEndpointResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetWebhookRequest) Reset() {
-	*x = GetWebhookRequest{}
+func (x *DeleteEndpointResponse) Reset() {
+	*x = DeleteEndpointResponse{}
 	mi := &file_hookly_v1_edge_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetWebhookRequest) String() string {
+func (x *DeleteEndpointResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetWebhookRequest) ProtoMessage() {}
+func (*DeleteEndpointResponse) ProtoMessage() {}
 
-func (x *GetWebhookRequest) ProtoReflect() protoreflect.Message {
+func (x *DeleteEndpointResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_hookly_v1_edge_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -583,39 +791,32 @@ func (x *GetWebhookRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetWebhookRequest.ProtoReflect.Descriptor instead.
-func (*GetWebhookRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use DeleteEndpointResponse.ProtoReflect.Descriptor instead.
+func (*DeleteEndpointResponse) Descriptor() ([]byte, []int) {
 	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *GetWebhookRequest) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
-}
-
-type GetWebhookResponse struct {
+type GetSetupInstructionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	EndpointId    string                 `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetWebhookResponse) Reset() {
-	*x = GetWebhookResponse{}
+func (x *GetSetupInstructionsRequest) Reset() {
+	*x = GetSetupInstructionsRequest{}
 	mi := &file_hookly_v1_edge_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetWebhookResponse) String() string {
+func (x *GetSetupInstructionsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetWebhookResponse) ProtoMessage() {}
+func (*GetSetupInstructionsRequest) ProtoMessage() {}
 
-func (x *GetWebhookResponse) ProtoReflect() protoreflect.Message {
+func (x *GetSetupInstructionsRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_hookly_v1_edge_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -627,41 +828,43 @@ func (x *GetWebhookResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetWebhookResponse.ProtoReflect.Descriptor instead.
-func (*GetWebhookResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetSetupInstructionsRequest.ProtoReflect.Descriptor instead.
+func (*GetSetupInstructionsRequest) Descriptor() ([]byte, []int) {
 	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GetWebhookResponse) GetWebhook() *Webhook {
+func (x *GetSetupInstructionsRequest) GetEndpointId() string {
 	if x != nil {
-		return x.Webhook
+		return x.EndpointId
 	}
-	return nil
+	return ""
 }
 
-type ListWebhooksRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	EndpointId    *string                `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3,oneof" json:"endpoint_id,omitempty"`
-	Status        *WebhookStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=hookly.v1.WebhookStatus,oneof" json:"status,omitempty"`
-	Pagination    *PaginationRequest     `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+type GetSetupInstructionsResponse struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	WebhookUrl   string                 `protobuf:"bytes,1,opt,name=webhook_url,json=webhookUrl,proto3" json:"webhook_url,omitempty"`
+	ProviderType ProviderType           `protobuf:"varint,2,opt,name=provider_type,json=providerType,proto3,enum=hookly.v1.ProviderType" json:"provider_type,omitempty"`
+	// Plain-text setup steps with the webhook URL filled in. The signature
+	// secret is never included; a placeholder is used instead.
+	Instructions  string `protobuf:"bytes,3,opt,name=instructions,proto3" json:"instructions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListWebhooksRequest) Reset() {
-	*x = ListWebhooksRequest{}
+func (x *GetSetupInstructionsResponse) Reset() {
+	*x = GetSetupInstructionsResponse{}
 	mi := &file_hookly_v1_edge_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListWebhooksRequest) String() string {
+func (x *GetSetupInstructionsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListWebhooksRequest) ProtoMessage() {}
+func (*GetSetupInstructionsResponse) ProtoMessage() {}
 
-func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+func (x *GetSetupInstructionsResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_hookly_v1_edge_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -673,54 +876,60 @@ func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
-func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetSetupInstructionsResponse.ProtoReflect.Descriptor instead.
+func (*GetSetupInstructionsResponse) Descriptor() ([]byte, []int) {
 	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *ListWebhooksRequest) GetEndpointId() string {
-	if x != nil && x.EndpointId != nil {
-		return *x.EndpointId
+func (x *GetSetupInstructionsResponse) GetWebhookUrl() string {
+	if x != nil {
+		return x.WebhookUrl
 	}
 	return ""
 }
 
-func (x *ListWebhooksRequest) GetStatus() WebhookStatus {
-	if x != nil && x.Status != nil {
-		return *x.Status
+func (x *GetSetupInstructionsResponse) GetProviderType() ProviderType {
+	if x != nil {
+		return x.ProviderType
 	}
-	return WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED
+	return ProviderType_PROVIDER_TYPE_UNSPECIFIED
 }
 
-func (x *ListWebhooksRequest) GetPagination() *PaginationRequest {
+func (x *GetSetupInstructionsResponse) GetInstructions() string {
 	if x != nil {
-		return x.Pagination
+		return x.Instructions
 	}
-	return nil
+	return ""
 }
 
-type ListWebhooksResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Webhooks      []*Webhook             `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
-	Pagination    *PaginationResponse    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// TelegramWebhookStatus is the webhook registration reported by Telegram's getWebhookInfo.
+type TelegramWebhookStatus struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// URL currently registered with Telegram
+	Url string `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	// True if the registered URL is this endpoint's webhook URL
+	Matches            bool                   `protobuf:"varint,2,opt,name=matches,proto3" json:"matches,omitempty"`
+	PendingUpdateCount int32                  `protobuf:"varint,3,opt,name=pending_update_count,json=pendingUpdateCount,proto3" json:"pending_update_count,omitempty"`
+	LastErrorMessage   string                 `protobuf:"bytes,4,opt,name=last_error_message,json=lastErrorMessage,proto3" json:"last_error_message,omitempty"`
+	LastErrorAt        *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=last_error_at,json=lastErrorAt,proto3" json:"last_error_at,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
-func (x *ListWebhooksResponse) Reset() {
-	*x = ListWebhooksResponse{}
+func (x *TelegramWebhookStatus) Reset() {
+	*x = TelegramWebhookStatus{}
 	mi := &file_hookly_v1_edge_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListWebhooksResponse) String() string {
+func (x *TelegramWebhookStatus) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListWebhooksResponse) ProtoMessage() {}
+func (*TelegramWebhookStatus) ProtoMessage() {}
 
-func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+func (x *TelegramWebhookStatus) ProtoReflect() protoreflect.Message {
 	mi := &file_hookly_v1_edge_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -732,46 +941,69 @@ func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
-func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use TelegramWebhookStatus.ProtoReflect.Descriptor instead.
+func (*TelegramWebhookStatus) Descriptor() ([]byte, []int) {
 	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ListWebhooksResponse) GetWebhooks() []*Webhook {
+func (x *TelegramWebhookStatus) GetUrl() string {
 	if x != nil {
-		return x.Webhooks
+		return x.Url
 	}
-	return nil
+	return ""
 }
 
-func (x *ListWebhooksResponse) GetPagination() *PaginationResponse {
+func (x *TelegramWebhookStatus) GetMatches() bool {
 	if x != nil {
-		return x.Pagination
+		return x.Matches
 	}
-	return nil
+	return false
 }
 
-type ReplayWebhookRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (x *TelegramWebhookStatus) GetPendingUpdateCount() int32 {
+	if x != nil {
+		return x.PendingUpdateCount
+	}
+	return 0
+}
+
+func (x *TelegramWebhookStatus) GetLastErrorMessage() string {
+	if x != nil {
+		return x.LastErrorMessage
+	}
+	return ""
+}
+
+func (x *TelegramWebhookStatus) GetLastErrorAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastErrorAt
+	}
+	return nil
+}
+
+type SetupTelegramWebhookRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	EndpointId string                 `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	// Stored encrypted for later calls. The stored token is used if empty.
+	BotToken      string `protobuf:"bytes,2,opt,name=bot_token,json=botToken,proto3" json:"bot_token,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReplayWebhookRequest) Reset() {
-	*x = ReplayWebhookRequest{}
+func (x *SetupTelegramWebhookRequest) Reset() {
+	*x = SetupTelegramWebhookRequest{}
 	mi := &file_hookly_v1_edge_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReplayWebhookRequest) String() string {
+func (x *SetupTelegramWebhookRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReplayWebhookRequest) ProtoMessage() {}
+func (*SetupTelegramWebhookRequest) ProtoMessage() {}
 
-func (x *ReplayWebhookRequest) ProtoReflect() protoreflect.Message {
+func (x *SetupTelegramWebhookRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_hookly_v1_edge_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -783,39 +1015,46 @@ func (x *ReplayWebhookRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReplayWebhookRequest.ProtoReflect.Descriptor instead.
-func (*ReplayWebhookRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetupTelegramWebhookRequest.ProtoReflect.Descriptor instead.
+func (*SetupTelegramWebhookRequest) Descriptor() ([]byte, []int) {
 	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ReplayWebhookRequest) GetId() string {
+func (x *SetupTelegramWebhookRequest) GetEndpointId() string {
 	if x != nil {
-		return x.Id
+		return x.EndpointId
 	}
 	return ""
 }
 
-type ReplayWebhookResponse struct {
+func (x *SetupTelegramWebhookRequest) GetBotToken() string {
+	if x != nil {
+		return x.BotToken
+	}
+	return ""
+}
+
+type SetupTelegramWebhookResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	Status        *TelegramWebhookStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReplayWebhookResponse) Reset() {
-	*x = ReplayWebhookResponse{}
+func (x *SetupTelegramWebhookResponse) Reset() {
+	*x = SetupTelegramWebhookResponse{}
 	mi := &file_hookly_v1_edge_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReplayWebhookResponse) String() string {
+func (x *SetupTelegramWebhookResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReplayWebhookResponse) ProtoMessage() {}
+func (*SetupTelegramWebhookResponse) ProtoMessage() {}
 
-func (x *ReplayWebhookResponse) ProtoReflect() protoreflect.Message {
+func (x *SetupTelegramWebhookResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_hookly_v1_edge_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -827,38 +1066,39 @@ func (x *ReplayWebhookResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReplayWebhookResponse.ProtoReflect.Descriptor instead.
-func (*ReplayWebhookResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SetupTelegramWebhookResponse.ProtoReflect.Descriptor instead.
+func (*SetupTelegramWebhookResponse) Descriptor() ([]byte, []int) {
 	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ReplayWebhookResponse) GetWebhook() *Webhook {
+func (x *SetupTelegramWebhookResponse) GetStatus() *TelegramWebhookStatus {
 	if x != nil {
-		return x.Webhook
+		return x.Status
 	}
 	return nil
 }
 
-type GetStatusRequest struct {
+type VerifyTelegramWebhookRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	EndpointId    string                 `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetStatusRequest) Reset() {
-	*x = GetStatusRequest{}
+func (x *VerifyTelegramWebhookRequest) Reset() {
+	*x = VerifyTelegramWebhookRequest{}
 	mi := &file_hookly_v1_edge_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetStatusRequest) String() string {
+func (x *VerifyTelegramWebhookRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetStatusRequest) ProtoMessage() {}
+func (*VerifyTelegramWebhookRequest) ProtoMessage() {}
 
-func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+func (x *VerifyTelegramWebhookRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_hookly_v1_edge_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -870,33 +1110,3471 @@ func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
-func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use VerifyTelegramWebhookRequest.ProtoReflect.Descriptor instead.
+func (*VerifyTelegramWebhookRequest) Descriptor() ([]byte, []int) {
 	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{16}
 }
 
-type GetStatusResponse struct {
+func (x *VerifyTelegramWebhookRequest) GetEndpointId() string {
+	if x != nil {
+		return x.EndpointId
+	}
+	return ""
+}
+
+type VerifyTelegramWebhookResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        *SystemStatus          `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Status        *TelegramWebhookStatus `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyTelegramWebhookResponse) Reset() {
+	*x = VerifyTelegramWebhookResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyTelegramWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyTelegramWebhookResponse) ProtoMessage() {}
+
+func (x *VerifyTelegramWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyTelegramWebhookResponse.ProtoReflect.Descriptor instead.
+func (*VerifyTelegramWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *VerifyTelegramWebhookResponse) GetStatus() *TelegramWebhookStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type GetEndpointStatsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EndpointId    string                 `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEndpointStatsRequest) Reset() {
+	*x = GetEndpointStatsRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEndpointStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEndpointStatsRequest) ProtoMessage() {}
+
+func (x *GetEndpointStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEndpointStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetEndpointStatsRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *GetEndpointStatsRequest) GetEndpointId() string {
+	if x != nil {
+		return x.EndpointId
+	}
+	return ""
+}
+
+// EventTypeCount is the number of webhooks received for one event type.
+type EventTypeCount struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Provider event type, empty for webhooks without a recognizable type
+	EventType     string `protobuf:"bytes,1,opt,name=event_type,json=eventType,proto3" json:"event_type,omitempty"`
+	Count         int64  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventTypeCount) Reset() {
+	*x = EventTypeCount{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventTypeCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventTypeCount) ProtoMessage() {}
+
+func (x *EventTypeCount) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventTypeCount.ProtoReflect.Descriptor instead.
+func (*EventTypeCount) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *EventTypeCount) GetEventType() string {
+	if x != nil {
+		return x.EventType
+	}
+	return ""
+}
+
+func (x *EventTypeCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// SLOCompliance is the current compliance with an endpoint's delivery SLO.
+type SLOCompliance struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Target         float64                `protobuf:"fixed64,1,opt,name=target,proto3" json:"target,omitempty"`
+	LatencySeconds int32                  `protobuf:"varint,2,opt,name=latency_seconds,json=latencySeconds,proto3" json:"latency_seconds,omitempty"`
+	WindowHours    int32                  `protobuf:"varint,3,opt,name=window_hours,json=windowHours,proto3" json:"window_hours,omitempty"`
+	// Webhooks counted in the window and how many met the latency target
+	Total int64 `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Met   int64 `protobuf:"varint,5,opt,name=met,proto3" json:"met,omitempty"`
+	// Percent of webhooks that met the latency target (100 with no webhooks)
+	Compliance    float64 `protobuf:"fixed64,6,opt,name=compliance,proto3" json:"compliance,omitempty"`
+	Breached      bool    `protobuf:"varint,7,opt,name=breached,proto3" json:"breached,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SLOCompliance) Reset() {
+	*x = SLOCompliance{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SLOCompliance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SLOCompliance) ProtoMessage() {}
+
+func (x *SLOCompliance) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SLOCompliance.ProtoReflect.Descriptor instead.
+func (*SLOCompliance) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SLOCompliance) GetTarget() float64 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+func (x *SLOCompliance) GetLatencySeconds() int32 {
+	if x != nil {
+		return x.LatencySeconds
+	}
+	return 0
+}
+
+func (x *SLOCompliance) GetWindowHours() int32 {
+	if x != nil {
+		return x.WindowHours
+	}
+	return 0
+}
+
+func (x *SLOCompliance) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SLOCompliance) GetMet() int64 {
+	if x != nil {
+		return x.Met
+	}
+	return 0
+}
+
+func (x *SLOCompliance) GetCompliance() float64 {
+	if x != nil {
+		return x.Compliance
+	}
+	return 0
+}
+
+func (x *SLOCompliance) GetBreached() bool {
+	if x != nil {
+		return x.Breached
+	}
+	return false
+}
+
+type GetEndpointStatsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Webhook counts per event type, most frequent first
+	EventTypes []*EventTypeCount `protobuf:"bytes,1,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
+	// Delivery SLO compliance, unset if the endpoint has no SLO
+	Slo           *SLOCompliance `protobuf:"bytes,2,opt,name=slo,proto3" json:"slo,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetEndpointStatsResponse) Reset() {
+	*x = GetEndpointStatsResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetEndpointStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetEndpointStatsResponse) ProtoMessage() {}
+
+func (x *GetEndpointStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetEndpointStatsResponse.ProtoReflect.Descriptor instead.
+func (*GetEndpointStatsResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *GetEndpointStatsResponse) GetEventTypes() []*EventTypeCount {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+func (x *GetEndpointStatsResponse) GetSlo() *SLOCompliance {
+	if x != nil {
+		return x.Slo
+	}
+	return nil
+}
+
+type ListConnectionEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EndpointId    *string                `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3,oneof" json:"endpoint_id,omitempty"` // All of the user's endpoints if unset
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`                                  // Max events to return (default 50, max 500)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConnectionEventsRequest) Reset() {
+	*x = ListConnectionEventsRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConnectionEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConnectionEventsRequest) ProtoMessage() {}
+
+func (x *ListConnectionEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConnectionEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListConnectionEventsRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ListConnectionEventsRequest) GetEndpointId() string {
+	if x != nil && x.EndpointId != nil {
+		return *x.EndpointId
+	}
+	return ""
+}
+
+func (x *ListConnectionEventsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListConnectionEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*ConnectionEvent     `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // Newest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConnectionEventsResponse) Reset() {
+	*x = ListConnectionEventsResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConnectionEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConnectionEventsResponse) ProtoMessage() {}
+
+func (x *ListConnectionEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConnectionEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListConnectionEventsResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListConnectionEventsResponse) GetEvents() []*ConnectionEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type GenerateEndpointSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EndpointId    string                 `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateEndpointSecretRequest) Reset() {
+	*x = GenerateEndpointSecretRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateEndpointSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateEndpointSecretRequest) ProtoMessage() {}
+
+func (x *GenerateEndpointSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateEndpointSecretRequest.ProtoReflect.Descriptor instead.
+func (*GenerateEndpointSecretRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GenerateEndpointSecretRequest) GetEndpointId() string {
+	if x != nil {
+		return x.EndpointId
+	}
+	return ""
+}
+
+type GenerateEndpointSecretResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The new signature secret. It is stored encrypted and not returned again,
+	// so it must be copied into the provider's settings now.
+	Secret        string `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateEndpointSecretResponse) Reset() {
+	*x = GenerateEndpointSecretResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateEndpointSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateEndpointSecretResponse) ProtoMessage() {}
+
+func (x *GenerateEndpointSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateEndpointSecretResponse.ProtoReflect.Descriptor instead.
+func (*GenerateEndpointSecretResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GenerateEndpointSecretResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type RevealEndpointSecretRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EndpointId    string                 `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevealEndpointSecretRequest) Reset() {
+	*x = RevealEndpointSecretRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevealEndpointSecretRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevealEndpointSecretRequest) ProtoMessage() {}
+
+func (x *RevealEndpointSecretRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevealEndpointSecretRequest.ProtoReflect.Descriptor instead.
+func (*RevealEndpointSecretRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *RevealEndpointSecretRequest) GetEndpointId() string {
+	if x != nil {
+		return x.EndpointId
+	}
+	return ""
+}
+
+type RevealEndpointSecretResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevealEndpointSecretResponse) Reset() {
+	*x = RevealEndpointSecretResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevealEndpointSecretResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevealEndpointSecretResponse) ProtoMessage() {}
+
+func (x *RevealEndpointSecretResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevealEndpointSecretResponse.ProtoReflect.Descriptor instead.
+func (*RevealEndpointSecretResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *RevealEndpointSecretResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type GetWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Set to false to return only payload_preview (default true)
+	IncludePayload *bool `protobuf:"varint,2,opt,name=include_payload,json=includePayload,proto3,oneof" json:"include_payload,omitempty"`
+	// Return only the JSON value at this path as payload and payload_preview: a
+	// JSON pointer ("/data/object/id") or dot notation ("data.items[0].id").
+	// payload_size is still the size of the whole payload.
+	JsonPath      *string `protobuf:"bytes,3,opt,name=json_path,json=jsonPath,proto3,oneof" json:"json_path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookRequest) Reset() {
+	*x = GetWebhookRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookRequest) ProtoMessage() {}
+
+func (x *GetWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookRequest.ProtoReflect.Descriptor instead.
+func (*GetWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetWebhookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetWebhookRequest) GetIncludePayload() bool {
+	if x != nil && x.IncludePayload != nil {
+		return *x.IncludePayload
+	}
+	return false
+}
+
+func (x *GetWebhookRequest) GetJsonPath() string {
+	if x != nil && x.JsonPath != nil {
+		return *x.JsonPath
+	}
+	return ""
+}
+
+type GetWebhookResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Webhook *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	// Per destination, for endpoints that fan out. Empty until the first
+	// attempt and after a replay.
+	Deliveries    []*DestinationDelivery `protobuf:"bytes,2,rep,name=deliveries,proto3" json:"deliveries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookResponse) Reset() {
+	*x = GetWebhookResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookResponse) ProtoMessage() {}
+
+func (x *GetWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookResponse.ProtoReflect.Descriptor instead.
+func (*GetWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetWebhookResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *GetWebhookResponse) GetDeliveries() []*DestinationDelivery {
+	if x != nil {
+		return x.Deliveries
+	}
+	return nil
+}
+
+type GetWebhookPayloadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookPayloadRequest) Reset() {
+	*x = GetWebhookPayloadRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookPayloadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookPayloadRequest) ProtoMessage() {}
+
+func (x *GetWebhookPayloadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookPayloadRequest.ProtoReflect.Descriptor instead.
+func (*GetWebhookPayloadRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetWebhookPayloadRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetWebhookPayloadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payload       []byte                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetWebhookPayloadResponse) Reset() {
+	*x = GetWebhookPayloadResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetWebhookPayloadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetWebhookPayloadResponse) ProtoMessage() {}
+
+func (x *GetWebhookPayloadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetWebhookPayloadResponse.ProtoReflect.Descriptor instead.
+func (*GetWebhookPayloadResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetWebhookPayloadResponse) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type ListWebhooksRequest struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	EndpointId *string                `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3,oneof" json:"endpoint_id,omitempty"`
+	Status     *WebhookStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=hookly.v1.WebhookStatus,oneof" json:"status,omitempty"`
+	Pagination *PaginationRequest     `protobuf:"bytes,3,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	EventType  *string                `protobuf:"bytes,4,opt,name=event_type,json=eventType,proto3,oneof" json:"event_type,omitempty"`
+	// Set to false to return only payload_preview (default true)
+	IncludePayload *bool `protobuf:"varint,5,opt,name=include_payload,json=includePayload,proto3,oneof" json:"include_payload,omitempty"`
+	// List webhooks purged by retention cleanup, which can still be undeleted,
+	// instead of live ones
+	Purged        bool `protobuf:"varint,6,opt,name=purged,proto3" json:"purged,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksRequest) Reset() {
+	*x = ListWebhooksRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksRequest) ProtoMessage() {}
+
+func (x *ListWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*ListWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListWebhooksRequest) GetEndpointId() string {
+	if x != nil && x.EndpointId != nil {
+		return *x.EndpointId
+	}
+	return ""
+}
+
+func (x *ListWebhooksRequest) GetStatus() WebhookStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED
+}
+
+func (x *ListWebhooksRequest) GetPagination() *PaginationRequest {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+func (x *ListWebhooksRequest) GetEventType() string {
+	if x != nil && x.EventType != nil {
+		return *x.EventType
+	}
+	return ""
+}
+
+func (x *ListWebhooksRequest) GetIncludePayload() bool {
+	if x != nil && x.IncludePayload != nil {
+		return *x.IncludePayload
+	}
+	return false
+}
+
+func (x *ListWebhooksRequest) GetPurged() bool {
+	if x != nil {
+		return x.Purged
+	}
+	return false
+}
+
+type ListWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhooks      []*Webhook             `protobuf:"bytes,1,rep,name=webhooks,proto3" json:"webhooks,omitempty"`
+	Pagination    *PaginationResponse    `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListWebhooksResponse) Reset() {
+	*x = ListWebhooksResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListWebhooksResponse) ProtoMessage() {}
+
+func (x *ListWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*ListWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListWebhooksResponse) GetWebhooks() []*Webhook {
+	if x != nil {
+		return x.Webhooks
+	}
+	return nil
+}
+
+func (x *ListWebhooksResponse) GetPagination() *PaginationResponse {
+	if x != nil {
+		return x.Pagination
+	}
+	return nil
+}
+
+type ReplayWebhookRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Token returned by a previous call that required confirmation.
+	ConfirmToken  string `protobuf:"bytes,2,opt,name=confirm_token,json=confirmToken,proto3" json:"confirm_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayWebhookRequest) Reset() {
+	*x = ReplayWebhookRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayWebhookRequest) ProtoMessage() {}
+
+func (x *ReplayWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayWebhookRequest.ProtoReflect.Descriptor instead.
+func (*ReplayWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ReplayWebhookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReplayWebhookRequest) GetConfirmToken() string {
+	if x != nil {
+		return x.ConfirmToken
+	}
+	return ""
+}
+
+type ReplayWebhookResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unset when confirmation_required is true.
+	Webhook *Webhook `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	// Set when the endpoint already has many pending replays. Retry with
+	// confirm_token to queue the replay anyway.
+	ConfirmationRequired bool   `protobuf:"varint,2,opt,name=confirmation_required,json=confirmationRequired,proto3" json:"confirmation_required,omitempty"`
+	ConfirmationToken    string `protobuf:"bytes,3,opt,name=confirmation_token,json=confirmationToken,proto3" json:"confirmation_token,omitempty"`
+	PendingReplays       int32  `protobuf:"varint,4,opt,name=pending_replays,json=pendingReplays,proto3" json:"pending_replays,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ReplayWebhookResponse) Reset() {
+	*x = ReplayWebhookResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayWebhookResponse) ProtoMessage() {}
+
+func (x *ReplayWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayWebhookResponse.ProtoReflect.Descriptor instead.
+func (*ReplayWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ReplayWebhookResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *ReplayWebhookResponse) GetConfirmationRequired() bool {
+	if x != nil {
+		return x.ConfirmationRequired
+	}
+	return false
+}
+
+func (x *ReplayWebhookResponse) GetConfirmationToken() string {
+	if x != nil {
+		return x.ConfirmationToken
+	}
+	return ""
+}
+
+func (x *ReplayWebhookResponse) GetPendingReplays() int32 {
+	if x != nil {
+		return x.PendingReplays
+	}
+	return 0
+}
+
+type BulkReplayWebhooksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Limit to a single endpoint. Replays on all endpoints if unset.
+	EndpointId *string `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3,oneof" json:"endpoint_id,omitempty"`
+	// Status of the webhooks to replay, dead letter if unspecified. Pending
+	// webhooks can't be replayed in bulk, nor honeypot hits.
+	Status WebhookStatus `protobuf:"varint,2,opt,name=status,proto3,enum=hookly.v1.WebhookStatus" json:"status,omitempty"`
+	// Only webhooks received at or after received_after and before
+	// received_before, when set.
+	ReceivedAfter  *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=received_after,json=receivedAfter,proto3" json:"received_after,omitempty"`
+	ReceivedBefore *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=received_before,json=receivedBefore,proto3" json:"received_before,omitempty"`
+	// Most webhooks to replay, the oldest first: 100 if 0, at most 1000.
+	MaxCount int32 `protobuf:"varint,5,opt,name=max_count,json=maxCount,proto3" json:"max_count,omitempty"`
+	// Token returned by a previous call with the same filter that required
+	// confirmation. If more webhooks match now, confirmation is required again.
+	ConfirmToken  string `protobuf:"bytes,6,opt,name=confirm_token,json=confirmToken,proto3" json:"confirm_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkReplayWebhooksRequest) Reset() {
+	*x = BulkReplayWebhooksRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkReplayWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkReplayWebhooksRequest) ProtoMessage() {}
+
+func (x *BulkReplayWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkReplayWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*BulkReplayWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *BulkReplayWebhooksRequest) GetEndpointId() string {
+	if x != nil && x.EndpointId != nil {
+		return *x.EndpointId
+	}
+	return ""
+}
+
+func (x *BulkReplayWebhooksRequest) GetStatus() WebhookStatus {
+	if x != nil {
+		return x.Status
+	}
+	return WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED
+}
+
+func (x *BulkReplayWebhooksRequest) GetReceivedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceivedAfter
+	}
+	return nil
+}
+
+func (x *BulkReplayWebhooksRequest) GetReceivedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReceivedBefore
+	}
+	return nil
+}
+
+func (x *BulkReplayWebhooksRequest) GetMaxCount() int32 {
+	if x != nil {
+		return x.MaxCount
+	}
+	return 0
+}
+
+func (x *BulkReplayWebhooksRequest) GetConfirmToken() string {
+	if x != nil {
+		return x.ConfirmToken
+	}
+	return ""
+}
+
+type BulkReplayWebhooksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReplayedCount int32                  `protobuf:"varint,1,opt,name=replayed_count,json=replayedCount,proto3" json:"replayed_count,omitempty"`
+	// Set when as many webhooks as the confirmation threshold match. Retry
+	// with confirm_token to replay them anyway.
+	ConfirmationRequired bool   `protobuf:"varint,2,opt,name=confirmation_required,json=confirmationRequired,proto3" json:"confirmation_required,omitempty"`
+	ConfirmationToken    string `protobuf:"bytes,3,opt,name=confirmation_token,json=confirmationToken,proto3" json:"confirmation_token,omitempty"`
+	MatchingCount        int32  `protobuf:"varint,4,opt,name=matching_count,json=matchingCount,proto3" json:"matching_count,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *BulkReplayWebhooksResponse) Reset() {
+	*x = BulkReplayWebhooksResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkReplayWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkReplayWebhooksResponse) ProtoMessage() {}
+
+func (x *BulkReplayWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkReplayWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*BulkReplayWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *BulkReplayWebhooksResponse) GetReplayedCount() int32 {
+	if x != nil {
+		return x.ReplayedCount
+	}
+	return 0
+}
+
+func (x *BulkReplayWebhooksResponse) GetConfirmationRequired() bool {
+	if x != nil {
+		return x.ConfirmationRequired
+	}
+	return false
+}
+
+func (x *BulkReplayWebhooksResponse) GetConfirmationToken() string {
+	if x != nil {
+		return x.ConfirmationToken
+	}
+	return ""
+}
+
+func (x *BulkReplayWebhooksResponse) GetMatchingCount() int32 {
+	if x != nil {
+		return x.MatchingCount
+	}
+	return 0
+}
+
+type UndeleteWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndeleteWebhookRequest) Reset() {
+	*x = UndeleteWebhookRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndeleteWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndeleteWebhookRequest) ProtoMessage() {}
+
+func (x *UndeleteWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndeleteWebhookRequest.ProtoReflect.Descriptor instead.
+func (*UndeleteWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *UndeleteWebhookRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UndeleteWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Webhook       *Webhook               `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UndeleteWebhookResponse) Reset() {
+	*x = UndeleteWebhookResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UndeleteWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UndeleteWebhookResponse) ProtoMessage() {}
+
+func (x *UndeleteWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UndeleteWebhookResponse.ProtoReflect.Descriptor instead.
+func (*UndeleteWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *UndeleteWebhookResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+type CancelPendingReplaysRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Limit to a single endpoint. Cancels replays on all endpoints if unset.
+	EndpointId    *string `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3,oneof" json:"endpoint_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelPendingReplaysRequest) Reset() {
+	*x = CancelPendingReplaysRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPendingReplaysRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPendingReplaysRequest) ProtoMessage() {}
+
+func (x *CancelPendingReplaysRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPendingReplaysRequest.ProtoReflect.Descriptor instead.
+func (*CancelPendingReplaysRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CancelPendingReplaysRequest) GetEndpointId() string {
+	if x != nil && x.EndpointId != nil {
+		return *x.EndpointId
+	}
+	return ""
+}
+
+type CancelPendingReplaysResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	CancelledCount int32                  `protobuf:"varint,1,opt,name=cancelled_count,json=cancelledCount,proto3" json:"cancelled_count,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CancelPendingReplaysResponse) Reset() {
+	*x = CancelPendingReplaysResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelPendingReplaysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelPendingReplaysResponse) ProtoMessage() {}
+
+func (x *CancelPendingReplaysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelPendingReplaysResponse.ProtoReflect.Descriptor instead.
+func (*CancelPendingReplaysResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *CancelPendingReplaysResponse) GetCancelledCount() int32 {
+	if x != nil {
+		return x.CancelledCount
+	}
+	return 0
+}
+
+type TailWebhooksRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Limit to a single endpoint. Tails all endpoints if unset.
+	EndpointId *string `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3,oneof" json:"endpoint_id,omitempty"`
+	// Only changes to these statuses; every change if empty. Webhooks are
+	// stored as pending, so include pending to see them arrive.
+	Statuses      []WebhookStatus `protobuf:"varint,2,rep,packed,name=statuses,proto3,enum=hookly.v1.WebhookStatus" json:"statuses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TailWebhooksRequest) Reset() {
+	*x = TailWebhooksRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TailWebhooksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TailWebhooksRequest) ProtoMessage() {}
+
+func (x *TailWebhooksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TailWebhooksRequest.ProtoReflect.Descriptor instead.
+func (*TailWebhooksRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *TailWebhooksRequest) GetEndpointId() string {
+	if x != nil && x.EndpointId != nil {
+		return *x.EndpointId
+	}
+	return ""
+}
+
+func (x *TailWebhooksRequest) GetStatuses() []WebhookStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+type TailWebhooksResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The webhook after the change, with payload_preview but no payload
+	Webhook       *Webhook             `protobuf:"bytes,1,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	Change        *WebhookStatusChange `protobuf:"bytes,2,opt,name=change,proto3" json:"change,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TailWebhooksResponse) Reset() {
+	*x = TailWebhooksResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TailWebhooksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TailWebhooksResponse) ProtoMessage() {}
+
+func (x *TailWebhooksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TailWebhooksResponse.ProtoReflect.Descriptor instead.
+func (*TailWebhooksResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *TailWebhooksResponse) GetWebhook() *Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *TailWebhooksResponse) GetChange() *WebhookStatusChange {
+	if x != nil {
+		return x.Change
+	}
+	return nil
+}
+
+type GetStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusRequest) Reset() {
+	*x = GetStatusRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusRequest) ProtoMessage() {}
+
+func (x *GetStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetStatusRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{44}
+}
+
+type GetStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        *SystemStatus          `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatusResponse) Reset() {
+	*x = GetStatusResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatusResponse) ProtoMessage() {}
+
+func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetStatusResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetStatusResponse) GetStatus() *SystemStatus {
+	if x != nil {
+		return x.Status
+	}
+	return nil
+}
+
+type GetActivityFeedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Limit         int32                  `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`                             // Max items to return (default 50, max 200)
+	SinceHours    int32                  `protobuf:"varint,2,opt,name=since_hours,json=sinceHours,proto3" json:"since_hours,omitempty"` // Only include activity from the last N hours (default 24)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActivityFeedRequest) Reset() {
+	*x = GetActivityFeedRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActivityFeedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivityFeedRequest) ProtoMessage() {}
+
+func (x *GetActivityFeedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivityFeedRequest.ProtoReflect.Descriptor instead.
+func (*GetActivityFeedRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *GetActivityFeedRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *GetActivityFeedRequest) GetSinceHours() int32 {
+	if x != nil {
+		return x.SinceHours
+	}
+	return 0
+}
+
+type GetActivityFeedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ActivityItem        `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetActivityFeedResponse) Reset() {
+	*x = GetActivityFeedResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetActivityFeedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetActivityFeedResponse) ProtoMessage() {}
+
+func (x *GetActivityFeedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetActivityFeedResponse.ProtoReflect.Descriptor instead.
+func (*GetActivityFeedResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *GetActivityFeedResponse) GetItems() []*ActivityItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type GetRegionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRegionsRequest) Reset() {
+	*x = GetRegionsRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRegionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRegionsRequest) ProtoMessage() {}
+
+func (x *GetRegionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRegionsRequest.ProtoReflect.Descriptor instead.
+func (*GetRegionsRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{48}
+}
+
+type GetRegionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CurrentRegion string                 `protobuf:"bytes,1,opt,name=current_region,json=currentRegion,proto3" json:"current_region,omitempty"` // Empty on single-region services
+	Regions       []*Region              `protobuf:"bytes,2,rep,name=regions,proto3" json:"regions,omitempty"`                                  // Current region first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRegionsResponse) Reset() {
+	*x = GetRegionsResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRegionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRegionsResponse) ProtoMessage() {}
+
+func (x *GetRegionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRegionsResponse.ProtoReflect.Descriptor instead.
+func (*GetRegionsResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetRegionsResponse) GetCurrentRegion() string {
+	if x != nil {
+		return x.CurrentRegion
+	}
+	return ""
+}
+
+func (x *GetRegionsResponse) GetRegions() []*Region {
+	if x != nil {
+		return x.Regions
+	}
+	return nil
+}
+
+type GetVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionRequest) Reset() {
+	*x = GetVersionRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionRequest) ProtoMessage() {}
+
+func (x *GetVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetVersionRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{50}
+}
+
+type GetVersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`                      // e.g. 1.2.0, or "dev" for builds without one
+	Commit        string                 `protobuf:"bytes,2,opt,name=commit,proto3" json:"commit,omitempty"`                        // VCS commit, if known
+	BuildDate     string                 `protobuf:"bytes,3,opt,name=build_date,json=buildDate,proto3" json:"build_date,omitempty"` // RFC3339, if known
+	GoVersion     string                 `protobuf:"bytes,4,opt,name=go_version,json=goVersion,proto3" json:"go_version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetVersionResponse) Reset() {
+	*x = GetVersionResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetVersionResponse) ProtoMessage() {}
+
+func (x *GetVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetVersionResponse.ProtoReflect.Descriptor instead.
+func (*GetVersionResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetVersionResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *GetVersionResponse) GetCommit() string {
+	if x != nil {
+		return x.Commit
+	}
+	return ""
+}
+
+func (x *GetVersionResponse) GetBuildDate() string {
+	if x != nil {
+		return x.BuildDate
+	}
+	return ""
+}
+
+func (x *GetVersionResponse) GetGoVersion() string {
+	if x != nil {
+		return x.GoVersion
+	}
+	return ""
+}
+
+type SendHubCommandRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	HubId   string                 `protobuf:"bytes,1,opt,name=hub_id,json=hubId,proto3" json:"hub_id,omitempty"`
+	Command HubCommandType         `protobuf:"varint,2,opt,name=command,proto3,enum=hookly.v1.HubCommandType" json:"command,omitempty"`
+	// For HUB_COMMAND_TYPE_LOGS: the most recent lines to return (default 100,
+	// max 1000)
+	Lines         int32 `protobuf:"varint,3,opt,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendHubCommandRequest) Reset() {
+	*x = SendHubCommandRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendHubCommandRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendHubCommandRequest) ProtoMessage() {}
+
+func (x *SendHubCommandRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendHubCommandRequest.ProtoReflect.Descriptor instead.
+func (*SendHubCommandRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *SendHubCommandRequest) GetHubId() string {
+	if x != nil {
+		return x.HubId
+	}
+	return ""
+}
+
+func (x *SendHubCommandRequest) GetCommand() HubCommandType {
+	if x != nil {
+		return x.Command
+	}
+	return HubCommandType_HUB_COMMAND_TYPE_UNSPECIFIED
+}
+
+func (x *SendHubCommandRequest) GetLines() int32 {
+	if x != nil {
+		return x.Lines
+	}
+	return 0
+}
+
+type SendHubCommandResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        *HubCommandResult      `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendHubCommandResponse) Reset() {
+	*x = SendHubCommandResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendHubCommandResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendHubCommandResponse) ProtoMessage() {}
+
+func (x *SendHubCommandResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendHubCommandResponse.ProtoReflect.Descriptor instead.
+func (*SendHubCommandResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *SendHubCommandResponse) GetResult() *HubCommandResult {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+type GetSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetSettingsRequest) Reset() {
+	*x = GetSettingsRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSettingsRequest) ProtoMessage() {}
+
+func (x *GetSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{54}
+}
+
+type GetSettingsResponse struct {
+	state                        protoimpl.MessageState `protogen:"open.v1"`
+	BaseUrl                      string                 `protobuf:"bytes,1,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	GithubAuthEnabled            bool                   `protobuf:"varint,2,opt,name=github_auth_enabled,json=githubAuthEnabled,proto3" json:"github_auth_enabled,omitempty"`
+	TelegramNotificationsEnabled bool                   `protobuf:"varint,3,opt,name=telegram_notifications_enabled,json=telegramNotificationsEnabled,proto3" json:"telegram_notifications_enabled,omitempty"`
+	// User info (requires authentication)
+	UserId    string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username  string `protobuf:"bytes,5,opt,name=username,proto3" json:"username,omitempty"`
+	AvatarUrl string `protobuf:"bytes,6,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	// User preferences
+	ThemePreference             ThemePreference `protobuf:"varint,7,opt,name=theme_preference,json=themePreference,proto3,enum=hookly.v1.ThemePreference" json:"theme_preference,omitempty"`
+	IsSuperuser                 bool            `protobuf:"varint,8,opt,name=is_superuser,json=isSuperuser,proto3" json:"is_superuser,omitempty"`
+	DiscordNotificationsEnabled bool            `protobuf:"varint,9,opt,name=discord_notifications_enabled,json=discordNotificationsEnabled,proto3" json:"discord_notifications_enabled,omitempty"`
+	// True if the edge can send email, so users can enable email notifications
+	EmailAvailable bool `protobuf:"varint,10,opt,name=email_available,json=emailAvailable,proto3" json:"email_available,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetSettingsResponse) Reset() {
+	*x = GetSettingsResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetSettingsResponse) ProtoMessage() {}
+
+func (x *GetSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *GetSettingsResponse) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
+	}
+	return ""
+}
+
+func (x *GetSettingsResponse) GetGithubAuthEnabled() bool {
+	if x != nil {
+		return x.GithubAuthEnabled
+	}
+	return false
+}
+
+func (x *GetSettingsResponse) GetTelegramNotificationsEnabled() bool {
+	if x != nil {
+		return x.TelegramNotificationsEnabled
+	}
+	return false
+}
+
+func (x *GetSettingsResponse) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *GetSettingsResponse) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *GetSettingsResponse) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *GetSettingsResponse) GetThemePreference() ThemePreference {
+	if x != nil {
+		return x.ThemePreference
+	}
+	return ThemePreference_THEME_PREFERENCE_UNSPECIFIED
+}
+
+func (x *GetSettingsResponse) GetIsSuperuser() bool {
+	if x != nil {
+		return x.IsSuperuser
+	}
+	return false
+}
+
+func (x *GetSettingsResponse) GetDiscordNotificationsEnabled() bool {
+	if x != nil {
+		return x.DiscordNotificationsEnabled
+	}
+	return false
+}
+
+func (x *GetSettingsResponse) GetEmailAvailable() bool {
+	if x != nil {
+		return x.EmailAvailable
+	}
+	return false
+}
+
+type GetCurrentUserRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentUserRequest) Reset() {
+	*x = GetCurrentUserRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentUserRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentUserRequest) ProtoMessage() {}
+
+func (x *GetCurrentUserRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentUserRequest.ProtoReflect.Descriptor instead.
+func (*GetCurrentUserRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{56}
+}
+
+type GetCurrentUserResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	User  *UserSettings          `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	// The API token the request authenticated with; unset for browser sessions
+	Token         *ApiToken `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCurrentUserResponse) Reset() {
+	*x = GetCurrentUserResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCurrentUserResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCurrentUserResponse) ProtoMessage() {}
+
+func (x *GetCurrentUserResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCurrentUserResponse.ProtoReflect.Descriptor instead.
+func (*GetCurrentUserResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *GetCurrentUserResponse) GetUser() *UserSettings {
+	if x != nil {
+		return x.User
+	}
+	return nil
+}
+
+func (x *GetCurrentUserResponse) GetToken() *ApiToken {
+	if x != nil {
+		return x.Token
+	}
+	return nil
+}
+
+type GetUserSettingsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserSettingsRequest) Reset() {
+	*x = GetUserSettingsRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserSettingsRequest) ProtoMessage() {}
+
+func (x *GetUserSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetUserSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{58}
+}
+
+type GetUserSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *UserSettings          `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserSettingsResponse) Reset() {
+	*x = GetUserSettingsResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserSettingsResponse) ProtoMessage() {}
+
+func (x *GetUserSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetUserSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetUserSettingsResponse) GetSettings() *UserSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type UpdateUserSettingsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Telegram settings (all optional - only set fields are updated)
+	TelegramBotToken *string `protobuf:"bytes,1,opt,name=telegram_bot_token,json=telegramBotToken,proto3,oneof" json:"telegram_bot_token,omitempty"` // Write-only, encrypted at rest
+	TelegramChatId   *string `protobuf:"bytes,2,opt,name=telegram_chat_id,json=telegramChatId,proto3,oneof" json:"telegram_chat_id,omitempty"`
+	TelegramEnabled  *bool   `protobuf:"varint,3,opt,name=telegram_enabled,json=telegramEnabled,proto3,oneof" json:"telegram_enabled,omitempty"`
+	// UI preferences
+	ThemePreference *ThemePreference `protobuf:"varint,4,opt,name=theme_preference,json=themePreference,proto3,enum=hookly.v1.ThemePreference,oneof" json:"theme_preference,omitempty"`
+	// Discord settings, a channel webhook URL like
+	// https://discord.com/api/webhooks/<id>/<token>
+	DiscordWebhookUrl *string `protobuf:"bytes,5,opt,name=discord_webhook_url,json=discordWebhookUrl,proto3,oneof" json:"discord_webhook_url,omitempty"` // Write-only, encrypted at rest
+	DiscordEnabled    *bool   `protobuf:"varint,6,opt,name=discord_enabled,json=discordEnabled,proto3,oneof" json:"discord_enabled,omitempty"`
+	// Email settings; an empty address clears it
+	EmailAddress *string `protobuf:"bytes,7,opt,name=email_address,json=emailAddress,proto3,oneof" json:"email_address,omitempty"`
+	EmailEnabled *bool   `protobuf:"varint,8,opt,name=email_enabled,json=emailEnabled,proto3,oneof" json:"email_enabled,omitempty"`
+	// Notification webhook settings: JSON events POSTed to the URL, signed
+	// with the secret in X-Webhook-Signature
+	NotifyWebhookUrl     *string `protobuf:"bytes,9,opt,name=notify_webhook_url,json=notifyWebhookUrl,proto3,oneof" json:"notify_webhook_url,omitempty"`           // Write-only, encrypted at rest
+	NotifyWebhookSecret  *string `protobuf:"bytes,10,opt,name=notify_webhook_secret,json=notifyWebhookSecret,proto3,oneof" json:"notify_webhook_secret,omitempty"` // Write-only, encrypted at rest
+	NotifyWebhookEnabled *bool   `protobuf:"varint,11,opt,name=notify_webhook_enabled,json=notifyWebhookEnabled,proto3,oneof" json:"notify_webhook_enabled,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *UpdateUserSettingsRequest) Reset() {
+	*x = UpdateUserSettingsRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserSettingsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserSettingsRequest) ProtoMessage() {}
+
+func (x *UpdateUserSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserSettingsRequest.ProtoReflect.Descriptor instead.
+func (*UpdateUserSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *UpdateUserSettingsRequest) GetTelegramBotToken() string {
+	if x != nil && x.TelegramBotToken != nil {
+		return *x.TelegramBotToken
+	}
+	return ""
+}
+
+func (x *UpdateUserSettingsRequest) GetTelegramChatId() string {
+	if x != nil && x.TelegramChatId != nil {
+		return *x.TelegramChatId
+	}
+	return ""
+}
+
+func (x *UpdateUserSettingsRequest) GetTelegramEnabled() bool {
+	if x != nil && x.TelegramEnabled != nil {
+		return *x.TelegramEnabled
+	}
+	return false
+}
+
+func (x *UpdateUserSettingsRequest) GetThemePreference() ThemePreference {
+	if x != nil && x.ThemePreference != nil {
+		return *x.ThemePreference
+	}
+	return ThemePreference_THEME_PREFERENCE_UNSPECIFIED
+}
+
+func (x *UpdateUserSettingsRequest) GetDiscordWebhookUrl() string {
+	if x != nil && x.DiscordWebhookUrl != nil {
+		return *x.DiscordWebhookUrl
+	}
+	return ""
+}
+
+func (x *UpdateUserSettingsRequest) GetDiscordEnabled() bool {
+	if x != nil && x.DiscordEnabled != nil {
+		return *x.DiscordEnabled
+	}
+	return false
+}
+
+func (x *UpdateUserSettingsRequest) GetEmailAddress() string {
+	if x != nil && x.EmailAddress != nil {
+		return *x.EmailAddress
+	}
+	return ""
+}
+
+func (x *UpdateUserSettingsRequest) GetEmailEnabled() bool {
+	if x != nil && x.EmailEnabled != nil {
+		return *x.EmailEnabled
+	}
+	return false
+}
+
+func (x *UpdateUserSettingsRequest) GetNotifyWebhookUrl() string {
+	if x != nil && x.NotifyWebhookUrl != nil {
+		return *x.NotifyWebhookUrl
+	}
+	return ""
+}
+
+func (x *UpdateUserSettingsRequest) GetNotifyWebhookSecret() string {
+	if x != nil && x.NotifyWebhookSecret != nil {
+		return *x.NotifyWebhookSecret
+	}
+	return ""
+}
+
+func (x *UpdateUserSettingsRequest) GetNotifyWebhookEnabled() bool {
+	if x != nil && x.NotifyWebhookEnabled != nil {
+		return *x.NotifyWebhookEnabled
+	}
+	return false
+}
+
+type UpdateUserSettingsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Settings      *UserSettings          `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateUserSettingsResponse) Reset() {
+	*x = UpdateUserSettingsResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateUserSettingsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateUserSettingsResponse) ProtoMessage() {}
+
+func (x *UpdateUserSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateUserSettingsResponse.ProtoReflect.Descriptor instead.
+func (*UpdateUserSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *UpdateUserSettingsResponse) GetSettings() *UserSettings {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SendTestEmailRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendTestEmailRequest) Reset() {
+	*x = SendTestEmailRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendTestEmailRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendTestEmailRequest) ProtoMessage() {}
+
+func (x *SendTestEmailRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendTestEmailRequest.ProtoReflect.Descriptor instead.
+func (*SendTestEmailRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{62}
+}
+
+type SendTestEmailResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EmailAddress  string                 `protobuf:"bytes,1,opt,name=email_address,json=emailAddress,proto3" json:"email_address,omitempty"` // Where the test email was sent
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendTestEmailResponse) Reset() {
+	*x = SendTestEmailResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendTestEmailResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendTestEmailResponse) ProtoMessage() {}
+
+func (x *SendTestEmailResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendTestEmailResponse.ProtoReflect.Descriptor instead.
+func (*SendTestEmailResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *SendTestEmailResponse) GetEmailAddress() string {
+	if x != nil {
+		return x.EmailAddress
+	}
+	return ""
+}
+
+type SendTestNotifyWebhookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendTestNotifyWebhookRequest) Reset() {
+	*x = SendTestNotifyWebhookRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendTestNotifyWebhookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendTestNotifyWebhookRequest) ProtoMessage() {}
+
+func (x *SendTestNotifyWebhookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendTestNotifyWebhookRequest.ProtoReflect.Descriptor instead.
+func (*SendTestNotifyWebhookRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{64}
+}
+
+type SendTestNotifyWebhookResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	EventId       string                 `protobuf:"bytes,1,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"` // The test event's id, also in X-Hookly-Delivery
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SendTestNotifyWebhookResponse) Reset() {
+	*x = SendTestNotifyWebhookResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SendTestNotifyWebhookResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SendTestNotifyWebhookResponse) ProtoMessage() {}
+
+func (x *SendTestNotifyWebhookResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SendTestNotifyWebhookResponse.ProtoReflect.Descriptor instead.
+func (*SendTestNotifyWebhookResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *SendTestNotifyWebhookResponse) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
+type ListApiTokensRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiTokensRequest) Reset() {
+	*x = ListApiTokensRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiTokensRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiTokensRequest) ProtoMessage() {}
+
+func (x *ListApiTokensRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiTokensRequest.ProtoReflect.Descriptor instead.
+func (*ListApiTokensRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{66}
+}
+
+type ListApiTokensResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tokens        []*ApiToken            `protobuf:"bytes,1,rep,name=tokens,proto3" json:"tokens,omitempty"` // Not revoked, newest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListApiTokensResponse) Reset() {
+	*x = ListApiTokensResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListApiTokensResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListApiTokensResponse) ProtoMessage() {}
+
+func (x *ListApiTokensResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListApiTokensResponse.ProtoReflect.Descriptor instead.
+func (*ListApiTokensResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *ListApiTokensResponse) GetTokens() []*ApiToken {
+	if x != nil {
+		return x.Tokens
+	}
+	return nil
+}
+
+type CreateApiTokenRequest struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Name             string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Scope            TokenScope             `protobuf:"varint,2,opt,name=scope,proto3,enum=hookly.v1.TokenScope" json:"scope,omitempty"`
+	ExpiresInSeconds int64                  `protobuf:"varint,3,opt,name=expires_in_seconds,json=expiresInSeconds,proto3" json:"expires_in_seconds,omitempty"` // 0 for a token that never expires
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *CreateApiTokenRequest) Reset() {
+	*x = CreateApiTokenRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiTokenRequest) ProtoMessage() {}
+
+func (x *CreateApiTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiTokenRequest.ProtoReflect.Descriptor instead.
+func (*CreateApiTokenRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *CreateApiTokenRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateApiTokenRequest) GetScope() TokenScope {
+	if x != nil {
+		return x.Scope
+	}
+	return TokenScope_TOKEN_SCOPE_UNSPECIFIED
+}
+
+func (x *CreateApiTokenRequest) GetExpiresInSeconds() int64 {
+	if x != nil {
+		return x.ExpiresInSeconds
+	}
+	return 0
+}
+
+type CreateApiTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // Shown once; only its hash is stored
+	ApiToken      *ApiToken              `protobuf:"bytes,2,opt,name=api_token,json=apiToken,proto3" json:"api_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateApiTokenResponse) Reset() {
+	*x = CreateApiTokenResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateApiTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateApiTokenResponse) ProtoMessage() {}
+
+func (x *CreateApiTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateApiTokenResponse.ProtoReflect.Descriptor instead.
+func (*CreateApiTokenResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *CreateApiTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CreateApiTokenResponse) GetApiToken() *ApiToken {
+	if x != nil {
+		return x.ApiToken
+	}
+	return nil
+}
+
+type RotateApiTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateApiTokenRequest) Reset() {
+	*x = RotateApiTokenRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateApiTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateApiTokenRequest) ProtoMessage() {}
+
+func (x *RotateApiTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateApiTokenRequest.ProtoReflect.Descriptor instead.
+func (*RotateApiTokenRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *RotateApiTokenRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RotateApiTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"` // Shown once; the old token is revoked
+	ApiToken      *ApiToken              `protobuf:"bytes,2,opt,name=api_token,json=apiToken,proto3" json:"api_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RotateApiTokenResponse) Reset() {
+	*x = RotateApiTokenResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RotateApiTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RotateApiTokenResponse) ProtoMessage() {}
+
+func (x *RotateApiTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RotateApiTokenResponse.ProtoReflect.Descriptor instead.
+func (*RotateApiTokenResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *RotateApiTokenResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *RotateApiTokenResponse) GetApiToken() *ApiToken {
+	if x != nil {
+		return x.ApiToken
+	}
+	return nil
+}
+
+type RevokeApiTokenRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeApiTokenRequest) Reset() {
+	*x = RevokeApiTokenRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeApiTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeApiTokenRequest) ProtoMessage() {}
+
+func (x *RevokeApiTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeApiTokenRequest.ProtoReflect.Descriptor instead.
+func (*RevokeApiTokenRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *RevokeApiTokenRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RevokeApiTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeApiTokenResponse) Reset() {
+	*x = RevokeApiTokenResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeApiTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeApiTokenResponse) ProtoMessage() {}
+
+func (x *RevokeApiTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeApiTokenResponse.ProtoReflect.Descriptor instead.
+func (*RevokeApiTokenResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{73}
+}
+
+type ListOrgsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrgsRequest) Reset() {
+	*x = ListOrgsRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrgsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrgsRequest) ProtoMessage() {}
+
+func (x *ListOrgsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrgsRequest.ProtoReflect.Descriptor instead.
+func (*ListOrgsRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{74}
+}
+
+type ListOrgsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orgs          []*Org                 `protobuf:"bytes,1,rep,name=orgs,proto3" json:"orgs,omitempty"` // Orgs the caller belongs to
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrgsResponse) Reset() {
+	*x = ListOrgsResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrgsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrgsResponse) ProtoMessage() {}
+
+func (x *ListOrgsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrgsResponse.ProtoReflect.Descriptor instead.
+func (*ListOrgsResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *ListOrgsResponse) GetOrgs() []*Org {
+	if x != nil {
+		return x.Orgs
+	}
+	return nil
+}
+
+type CreateOrgRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrgRequest) Reset() {
+	*x = CreateOrgRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrgRequest) ProtoMessage() {}
+
+func (x *CreateOrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrgRequest.ProtoReflect.Descriptor instead.
+func (*CreateOrgRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *CreateOrgRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type CreateOrgResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Org           *Org                   `protobuf:"bytes,1,opt,name=org,proto3" json:"org,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateOrgResponse) Reset() {
+	*x = CreateOrgResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateOrgResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateOrgResponse) ProtoMessage() {}
+
+func (x *CreateOrgResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateOrgResponse.ProtoReflect.Descriptor instead.
+func (*CreateOrgResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *CreateOrgResponse) GetOrg() *Org {
+	if x != nil {
+		return x.Org
+	}
+	return nil
+}
+
+type DeleteOrgRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteOrgRequest) Reset() {
+	*x = DeleteOrgRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrgRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrgRequest) ProtoMessage() {}
+
+func (x *DeleteOrgRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrgRequest.ProtoReflect.Descriptor instead.
+func (*DeleteOrgRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *DeleteOrgRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteOrgResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	EndpointsDeleted int64                  `protobuf:"varint,1,opt,name=endpoints_deleted,json=endpointsDeleted,proto3" json:"endpoints_deleted,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DeleteOrgResponse) Reset() {
+	*x = DeleteOrgResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteOrgResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteOrgResponse) ProtoMessage() {}
+
+func (x *DeleteOrgResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteOrgResponse.ProtoReflect.Descriptor instead.
+func (*DeleteOrgResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *DeleteOrgResponse) GetEndpointsDeleted() int64 {
+	if x != nil {
+		return x.EndpointsDeleted
+	}
+	return 0
+}
+
+type ListOrgMembersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrgMembersRequest) Reset() {
+	*x = ListOrgMembersRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrgMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrgMembersRequest) ProtoMessage() {}
+
+func (x *ListOrgMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrgMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrgMembersRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *ListOrgMembersRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+type ListOrgMembersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Members       []*OrgMember           `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrgMembersResponse) Reset() {
+	*x = ListOrgMembersResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrgMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrgMembersResponse) ProtoMessage() {}
+
+func (x *ListOrgMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrgMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrgMembersResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *ListOrgMembersResponse) GetMembers() []*OrgMember {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+type AddOrgMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"` // GitHub username; the user must have signed in once
+	Role          OrgRole                `protobuf:"varint,3,opt,name=role,proto3,enum=hookly.v1.OrgRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddOrgMemberRequest) Reset() {
+	*x = AddOrgMemberRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddOrgMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddOrgMemberRequest) ProtoMessage() {}
+
+func (x *AddOrgMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddOrgMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddOrgMemberRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *AddOrgMemberRequest) GetOrgId() string {
+	if x != nil {
+		return x.OrgId
+	}
+	return ""
+}
+
+func (x *AddOrgMemberRequest) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *AddOrgMemberRequest) GetRole() OrgRole {
+	if x != nil {
+		return x.Role
+	}
+	return OrgRole_ORG_ROLE_UNSPECIFIED
+}
+
+type AddOrgMemberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Member        *OrgMember             `protobuf:"bytes,1,opt,name=member,proto3" json:"member,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddOrgMemberResponse) Reset() {
+	*x = AddOrgMemberResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddOrgMemberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddOrgMemberResponse) ProtoMessage() {}
+
+func (x *AddOrgMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddOrgMemberResponse.ProtoReflect.Descriptor instead.
+func (*AddOrgMemberResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *AddOrgMemberResponse) GetMember() *OrgMember {
+	if x != nil {
+		return x.Member
+	}
+	return nil
+}
+
+type RemoveOrgMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrgId         string                 `protobuf:"bytes,1,opt,name=org_id,json=orgId,proto3" json:"org_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetStatusResponse) Reset() {
-	*x = GetStatusResponse{}
-	mi := &file_hookly_v1_edge_proto_msgTypes[17]
+func (x *RemoveOrgMemberRequest) Reset() {
+	*x = RemoveOrgMemberRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[84]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetStatusResponse) String() string {
+func (x *RemoveOrgMemberRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetStatusResponse) ProtoMessage() {}
+func (*RemoveOrgMemberRequest) ProtoMessage() {}
 
-func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_edge_proto_msgTypes[17]
+func (x *RemoveOrgMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[84]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -907,39 +4585,46 @@ func (x *GetStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetStatusResponse.ProtoReflect.Descriptor instead.
-func (*GetStatusResponse) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use RemoveOrgMemberRequest.ProtoReflect.Descriptor instead.
+func (*RemoveOrgMemberRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{84}
 }
 
-func (x *GetStatusResponse) GetStatus() *SystemStatus {
+func (x *RemoveOrgMemberRequest) GetOrgId() string {
 	if x != nil {
-		return x.Status
+		return x.OrgId
 	}
-	return nil
+	return ""
 }
 
-type GetSettingsRequest struct {
+func (x *RemoveOrgMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RemoveOrgMemberResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSettingsRequest) Reset() {
-	*x = GetSettingsRequest{}
-	mi := &file_hookly_v1_edge_proto_msgTypes[18]
+func (x *RemoveOrgMemberResponse) Reset() {
+	*x = RemoveOrgMemberResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[85]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSettingsRequest) String() string {
+func (x *RemoveOrgMemberResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSettingsRequest) ProtoMessage() {}
+func (*RemoveOrgMemberResponse) ProtoMessage() {}
 
-func (x *GetSettingsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_edge_proto_msgTypes[18]
+func (x *RemoveOrgMemberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[85]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -950,42 +4635,35 @@ func (x *GetSettingsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSettingsRequest.ProtoReflect.Descriptor instead.
-func (*GetSettingsRequest) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use RemoveOrgMemberResponse.ProtoReflect.Descriptor instead.
+func (*RemoveOrgMemberResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{85}
 }
 
-type GetSettingsResponse struct {
-	state                        protoimpl.MessageState `protogen:"open.v1"`
-	BaseUrl                      string                 `protobuf:"bytes,1,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
-	GithubAuthEnabled            bool                   `protobuf:"varint,2,opt,name=github_auth_enabled,json=githubAuthEnabled,proto3" json:"github_auth_enabled,omitempty"`
-	TelegramNotificationsEnabled bool                   `protobuf:"varint,3,opt,name=telegram_notifications_enabled,json=telegramNotificationsEnabled,proto3" json:"telegram_notifications_enabled,omitempty"`
-	// User info (requires authentication)
-	UserId    string `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Username  string `protobuf:"bytes,5,opt,name=username,proto3" json:"username,omitempty"`
-	AvatarUrl string `protobuf:"bytes,6,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
-	// User preferences
-	ThemePreference ThemePreference `protobuf:"varint,7,opt,name=theme_preference,json=themePreference,proto3,enum=hookly.v1.ThemePreference" json:"theme_preference,omitempty"`
-	IsSuperuser     bool            `protobuf:"varint,8,opt,name=is_superuser,json=isSuperuser,proto3" json:"is_superuser,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type ListAuditEventsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Action        *string                `protobuf:"bytes,1,opt,name=action,proto3,oneof" json:"action,omitempty"` // e.g. DeleteEndpoint or Login; all actions if unset
+	Since         *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`         // Unset for no lower bound
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`        // Max events to return (default 50, max 500)
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSettingsResponse) Reset() {
-	*x = GetSettingsResponse{}
-	mi := &file_hookly_v1_edge_proto_msgTypes[19]
+func (x *ListAuditEventsRequest) Reset() {
+	*x = ListAuditEventsRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[86]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSettingsResponse) String() string {
+func (x *ListAuditEventsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSettingsResponse) ProtoMessage() {}
+func (*ListAuditEventsRequest) ProtoMessage() {}
 
-func (x *GetSettingsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_edge_proto_msgTypes[19]
+func (x *ListAuditEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[86]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -996,88 +4674,97 @@ func (x *GetSettingsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSettingsResponse.ProtoReflect.Descriptor instead.
-func (*GetSettingsResponse) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use ListAuditEventsRequest.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{86}
 }
 
-func (x *GetSettingsResponse) GetBaseUrl() string {
-	if x != nil {
-		return x.BaseUrl
+func (x *ListAuditEventsRequest) GetAction() string {
+	if x != nil && x.Action != nil {
+		return *x.Action
 	}
 	return ""
 }
 
-func (x *GetSettingsResponse) GetGithubAuthEnabled() bool {
+func (x *ListAuditEventsRequest) GetSince() *timestamppb.Timestamp {
 	if x != nil {
-		return x.GithubAuthEnabled
+		return x.Since
 	}
-	return false
+	return nil
 }
 
-func (x *GetSettingsResponse) GetTelegramNotificationsEnabled() bool {
+func (x *ListAuditEventsRequest) GetLimit() int32 {
 	if x != nil {
-		return x.TelegramNotificationsEnabled
+		return x.Limit
 	}
-	return false
+	return 0
 }
 
-func (x *GetSettingsResponse) GetUserId() string {
-	if x != nil {
-		return x.UserId
-	}
-	return ""
+type ListAuditEventsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Events        []*AuditEvent          `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"` // Newest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSettingsResponse) GetUsername() string {
-	if x != nil {
-		return x.Username
-	}
-	return ""
+func (x *ListAuditEventsResponse) Reset() {
+	*x = ListAuditEventsResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSettingsResponse) GetAvatarUrl() string {
-	if x != nil {
-		return x.AvatarUrl
-	}
-	return ""
+func (x *ListAuditEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *GetSettingsResponse) GetThemePreference() ThemePreference {
+func (*ListAuditEventsResponse) ProtoMessage() {}
+
+func (x *ListAuditEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[87]
 	if x != nil {
-		return x.ThemePreference
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ThemePreference_THEME_PREFERENCE_UNSPECIFIED
+	return mi.MessageOf(x)
 }
 
-func (x *GetSettingsResponse) GetIsSuperuser() bool {
+// Deprecated: Use ListAuditEventsResponse.ProtoReflect.Descriptor instead.
+func (*ListAuditEventsResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *ListAuditEventsResponse) GetEvents() []*AuditEvent {
 	if x != nil {
-		return x.IsSuperuser
+		return x.Events
 	}
-	return false
+	return nil
 }
 
-type GetUserSettingsRequest struct {
+type GetSystemSettingsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserSettingsRequest) Reset() {
-	*x = GetUserSettingsRequest{}
-	mi := &file_hookly_v1_edge_proto_msgTypes[20]
+func (x *GetSystemSettingsRequest) Reset() {
+	*x = GetSystemSettingsRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[88]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserSettingsRequest) String() string {
+func (x *GetSystemSettingsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserSettingsRequest) ProtoMessage() {}
+func (*GetSystemSettingsRequest) ProtoMessage() {}
 
-func (x *GetUserSettingsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_edge_proto_msgTypes[20]
+func (x *GetSystemSettingsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[88]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1088,33 +4775,33 @@ func (x *GetUserSettingsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserSettingsRequest.ProtoReflect.Descriptor instead.
-func (*GetUserSettingsRequest) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use GetSystemSettingsRequest.ProtoReflect.Descriptor instead.
+func (*GetSystemSettingsRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{88}
 }
 
-type GetUserSettingsResponse struct {
+type GetSystemSettingsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Settings      *UserSettings          `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	Settings      *SystemSettings        `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetUserSettingsResponse) Reset() {
-	*x = GetUserSettingsResponse{}
-	mi := &file_hookly_v1_edge_proto_msgTypes[21]
+func (x *GetSystemSettingsResponse) Reset() {
+	*x = GetSystemSettingsResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[89]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetUserSettingsResponse) String() string {
+func (x *GetSystemSettingsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetUserSettingsResponse) ProtoMessage() {}
+func (*GetSystemSettingsResponse) ProtoMessage() {}
 
-func (x *GetUserSettingsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_edge_proto_msgTypes[21]
+func (x *GetSystemSettingsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[89]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1125,45 +4812,40 @@ func (x *GetUserSettingsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetUserSettingsResponse.ProtoReflect.Descriptor instead.
-func (*GetUserSettingsResponse) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use GetSystemSettingsResponse.ProtoReflect.Descriptor instead.
+func (*GetSystemSettingsResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{89}
 }
 
-func (x *GetUserSettingsResponse) GetSettings() *UserSettings {
+func (x *GetSystemSettingsResponse) GetSettings() *SystemSettings {
 	if x != nil {
 		return x.Settings
 	}
 	return nil
 }
 
-type UpdateUserSettingsRequest struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Telegram settings (all optional - only set fields are updated)
-	TelegramBotToken *string `protobuf:"bytes,1,opt,name=telegram_bot_token,json=telegramBotToken,proto3,oneof" json:"telegram_bot_token,omitempty"` // Write-only, encrypted at rest
-	TelegramChatId   *string `protobuf:"bytes,2,opt,name=telegram_chat_id,json=telegramChatId,proto3,oneof" json:"telegram_chat_id,omitempty"`
-	TelegramEnabled  *bool   `protobuf:"varint,3,opt,name=telegram_enabled,json=telegramEnabled,proto3,oneof" json:"telegram_enabled,omitempty"`
-	// UI preferences
-	ThemePreference *ThemePreference `protobuf:"varint,4,opt,name=theme_preference,json=themePreference,proto3,enum=hookly.v1.ThemePreference,oneof" json:"theme_preference,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+type RunMaintenanceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           string                 `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"` // dead_letters, slo, cleanup or jobs
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserSettingsRequest) Reset() {
-	*x = UpdateUserSettingsRequest{}
-	mi := &file_hookly_v1_edge_proto_msgTypes[22]
+func (x *RunMaintenanceRequest) Reset() {
+	*x = RunMaintenanceRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserSettingsRequest) String() string {
+func (x *RunMaintenanceRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserSettingsRequest) ProtoMessage() {}
+func (*RunMaintenanceRequest) ProtoMessage() {}
 
-func (x *UpdateUserSettingsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_edge_proto_msgTypes[22]
+func (x *RunMaintenanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1174,61 +4856,40 @@ func (x *UpdateUserSettingsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserSettingsRequest.ProtoReflect.Descriptor instead.
-func (*UpdateUserSettingsRequest) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{22}
-}
-
-func (x *UpdateUserSettingsRequest) GetTelegramBotToken() string {
-	if x != nil && x.TelegramBotToken != nil {
-		return *x.TelegramBotToken
-	}
-	return ""
+// Deprecated: Use RunMaintenanceRequest.ProtoReflect.Descriptor instead.
+func (*RunMaintenanceRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{90}
 }
 
-func (x *UpdateUserSettingsRequest) GetTelegramChatId() string {
-	if x != nil && x.TelegramChatId != nil {
-		return *x.TelegramChatId
+func (x *RunMaintenanceRequest) GetJob() string {
+	if x != nil {
+		return x.Job
 	}
 	return ""
 }
 
-func (x *UpdateUserSettingsRequest) GetTelegramEnabled() bool {
-	if x != nil && x.TelegramEnabled != nil {
-		return *x.TelegramEnabled
-	}
-	return false
-}
-
-func (x *UpdateUserSettingsRequest) GetThemePreference() ThemePreference {
-	if x != nil && x.ThemePreference != nil {
-		return *x.ThemePreference
-	}
-	return ThemePreference_THEME_PREFERENCE_UNSPECIFIED
-}
-
-type UpdateUserSettingsResponse struct {
+type RunMaintenanceResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Settings      *UserSettings          `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	Job           *MaintenanceJob        `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateUserSettingsResponse) Reset() {
-	*x = UpdateUserSettingsResponse{}
-	mi := &file_hookly_v1_edge_proto_msgTypes[23]
+func (x *RunMaintenanceResponse) Reset() {
+	*x = RunMaintenanceResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[91]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateUserSettingsResponse) String() string {
+func (x *RunMaintenanceResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateUserSettingsResponse) ProtoMessage() {}
+func (*RunMaintenanceResponse) ProtoMessage() {}
 
-func (x *UpdateUserSettingsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_edge_proto_msgTypes[23]
+func (x *RunMaintenanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[91]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1239,39 +4900,40 @@ func (x *UpdateUserSettingsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateUserSettingsResponse.ProtoReflect.Descriptor instead.
-func (*UpdateUserSettingsResponse) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use RunMaintenanceResponse.ProtoReflect.Descriptor instead.
+func (*RunMaintenanceResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{91}
 }
 
-func (x *UpdateUserSettingsResponse) GetSettings() *UserSettings {
+func (x *RunMaintenanceResponse) GetJob() *MaintenanceJob {
 	if x != nil {
-		return x.Settings
+		return x.Job
 	}
 	return nil
 }
 
-type GetSystemSettingsRequest struct {
+type SetLogLevelRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"` // debug, info, warn or error; empty only reports the level
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSystemSettingsRequest) Reset() {
-	*x = GetSystemSettingsRequest{}
-	mi := &file_hookly_v1_edge_proto_msgTypes[24]
+func (x *SetLogLevelRequest) Reset() {
+	*x = SetLogLevelRequest{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSystemSettingsRequest) String() string {
+func (x *SetLogLevelRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSystemSettingsRequest) ProtoMessage() {}
+func (*SetLogLevelRequest) ProtoMessage() {}
 
-func (x *GetSystemSettingsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_edge_proto_msgTypes[24]
+func (x *SetLogLevelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1282,33 +4944,41 @@ func (x *GetSystemSettingsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSystemSettingsRequest.ProtoReflect.Descriptor instead.
-func (*GetSystemSettingsRequest) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use SetLogLevelRequest.ProtoReflect.Descriptor instead.
+func (*SetLogLevelRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{92}
 }
 
-type GetSystemSettingsResponse struct {
+func (x *SetLogLevelRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+type SetLogLevelResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Settings      *SystemSettings        `protobuf:"bytes,1,opt,name=settings,proto3" json:"settings,omitempty"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	PreviousLevel string                 `protobuf:"bytes,2,opt,name=previous_level,json=previousLevel,proto3" json:"previous_level,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetSystemSettingsResponse) Reset() {
-	*x = GetSystemSettingsResponse{}
-	mi := &file_hookly_v1_edge_proto_msgTypes[25]
+func (x *SetLogLevelResponse) Reset() {
+	*x = SetLogLevelResponse{}
+	mi := &file_hookly_v1_edge_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetSystemSettingsResponse) String() string {
+func (x *SetLogLevelResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetSystemSettingsResponse) ProtoMessage() {}
+func (*SetLogLevelResponse) ProtoMessage() {}
 
-func (x *GetSystemSettingsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_edge_proto_msgTypes[25]
+func (x *SetLogLevelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_edge_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1319,29 +4989,40 @@ func (x *GetSystemSettingsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetSystemSettingsResponse.ProtoReflect.Descriptor instead.
-func (*GetSystemSettingsResponse) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use SetLogLevelResponse.ProtoReflect.Descriptor instead.
+func (*SetLogLevelResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_edge_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *GetSystemSettingsResponse) GetSettings() *SystemSettings {
+func (x *SetLogLevelResponse) GetLevel() string {
 	if x != nil {
-		return x.Settings
+		return x.Level
 	}
-	return nil
+	return ""
+}
+
+func (x *SetLogLevelResponse) GetPreviousLevel() string {
+	if x != nil {
+		return x.PreviousLevel
+	}
+	return ""
 }
 
 var File_hookly_v1_edge_proto protoreflect.FileDescriptor
 
 const file_hookly_v1_edge_proto_rawDesc = "" +
 	"\n" +
-	"\x14hookly/v1/edge.proto\x12\thookly.v1\x1a\x16hookly/v1/common.proto\"\x8d\x02\n" +
+	"\x14hookly/v1/edge.proto\x12\thookly.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16hookly/v1/common.proto\"\x8f\x03\n" +
 	"\x15CreateEndpointRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12<\n" +
 	"\rprovider_type\x18\x02 \x01(\x0e2\x17.hookly.v1.ProviderTypeR\fproviderType\x12)\n" +
 	"\x10signature_secret\x18\x03 \x01(\tR\x0fsignatureSecret\x12'\n" +
 	"\x0fdestination_url\x18\x04 \x01(\tR\x0edestinationUrl\x12N\n" +
-	"\x13verification_config\x18\x05 \x01(\v2\x1d.hookly.v1.VerificationConfigR\x12verificationConfig\"j\n" +
+	"\x13verification_config\x18\x05 \x01(\v2\x1d.hookly.v1.VerificationConfigR\x12verificationConfig\x12,\n" +
+	"\x12notify_first_event\x18\x06 \x01(\bR\x10notifyFirstEvent\x126\n" +
+	"\vingest_auth\x18\a \x01(\v2\x15.hookly.v1.IngestAuthR\n" +
+	"ingestAuth\x12\x1a\n" +
+	"\bhoneypot\x18\b \x01(\bR\bhoneypot\"j\n" +
 	"\x16CreateEndpointResponse\x12/\n" +
 	"\bendpoint\x18\x01 \x01(\v2\x13.hookly.v1.EndpointR\bendpoint\x12\x1f\n" +
 	"\vwebhook_url\x18\x02 \x01(\tR\n" +
@@ -1351,58 +5032,236 @@ const file_hookly_v1_edge_proto_rawDesc = "" +
 	"\x13GetEndpointResponse\x12/\n" +
 	"\bendpoint\x18\x01 \x01(\v2\x13.hookly.v1.EndpointR\bendpoint\x12\x1f\n" +
 	"\vwebhook_url\x18\x02 \x01(\tR\n" +
-	"webhookUrl\"T\n" +
+	"webhookUrl\"\xa1\x02\n" +
 	"\x14ListEndpointsRequest\x12<\n" +
 	"\n" +
 	"pagination\x18\x01 \x01(\v2\x1c.hookly.v1.PaginationRequestR\n" +
-	"pagination\"\x89\x01\n" +
+	"pagination\x12\x16\n" +
+	"\x06search\x18\x02 \x01(\tR\x06search\x12<\n" +
+	"\rprovider_type\x18\x03 \x01(\x0e2\x17.hookly.v1.ProviderTypeR\fproviderType\x12\x19\n" +
+	"\x05muted\x18\x04 \x01(\bH\x00R\x05muted\x88\x01\x01\x12+\n" +
+	"\x04sort\x18\x05 \x01(\x0e2\x17.hookly.v1.EndpointSortR\x04sort\x12#\n" +
+	"\rinactive_days\x18\x06 \x01(\x05R\finactiveDaysB\b\n" +
+	"\x06_muted\"\x89\x01\n" +
 	"\x15ListEndpointsResponse\x121\n" +
 	"\tendpoints\x18\x01 \x03(\v2\x13.hookly.v1.EndpointR\tendpoints\x12=\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2\x1d.hookly.v1.PaginationResponseR\n" +
-	"pagination\"\xc5\x02\n" +
+	"pagination\"\x96\n" +
+	"\n" +
 	"\x15UpdateEndpointRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x17\n" +
 	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12.\n" +
 	"\x10signature_secret\x18\x03 \x01(\tH\x01R\x0fsignatureSecret\x88\x01\x01\x12,\n" +
 	"\x0fdestination_url\x18\x04 \x01(\tH\x02R\x0edestinationUrl\x88\x01\x01\x12\x19\n" +
 	"\x05muted\x18\x05 \x01(\bH\x03R\x05muted\x88\x01\x01\x12N\n" +
-	"\x13verification_config\x18\x06 \x01(\v2\x1d.hookly.v1.VerificationConfigR\x12verificationConfigB\a\n" +
+	"\x13verification_config\x18\x06 \x01(\v2\x1d.hookly.v1.VerificationConfigR\x12verificationConfig\x121\n" +
+	"\x12notify_first_event\x18\a \x01(\bH\x04R\x10notifyFirstEvent\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"slo_target\x18\b \x01(\x01H\x05R\tsloTarget\x88\x01\x01\x123\n" +
+	"\x13slo_latency_seconds\x18\t \x01(\x05H\x06R\x11sloLatencySeconds\x88\x01\x01\x12-\n" +
+	"\x10slo_window_hours\x18\n" +
+	" \x01(\x05H\aR\x0esloWindowHours\x88\x01\x01\x120\n" +
+	"\x11reject_duplicates\x18\v \x01(\bH\bR\x10rejectDuplicates\x88\x01\x01\x126\n" +
+	"\vingest_auth\x18\f \x01(\v2\x15.hookly.v1.IngestAuthR\n" +
+	"ingestAuth\x12\x1f\n" +
+	"\bhoneypot\x18\r \x01(\bH\tR\bhoneypot\x88\x01\x01\x127\n" +
+	"\x15conflict_as_duplicate\x18\x0e \x01(\bH\n" +
+	"R\x13conflictAsDuplicate\x88\x01\x01\x126\n" +
+	"\x15rate_limit_per_minute\x18\x0f \x01(\x05H\vR\x12rateLimitPerMinute\x88\x01\x01\x122\n" +
+	"\ttransform\x18\x10 \x01(\v2\x14.hookly.v1.TransformR\ttransform\x12>\n" +
+	"\fdestinations\x18\x11 \x01(\v2\x1a.hookly.v1.DestinationListR\fdestinations\x12(\n" +
+	"\ranswer_probes\x18\x12 \x01(\bH\fR\fanswerProbes\x88\x01\x01\x12B\n" +
+	"\x0fingest_response\x18\x13 \x01(\v2\x19.hookly.v1.IngestResponseR\x0eingestResponse\x129\n" +
+	"\fretry_policy\x18\x14 \x01(\v2\x16.hookly.v1.RetryPolicyR\vretryPolicy\x12?\n" +
+	"\x0epayload_limits\x18\x15 \x01(\v2\x18.hookly.v1.PayloadLimitsR\rpayloadLimitsB\a\n" +
 	"\x05_nameB\x13\n" +
 	"\x11_signature_secretB\x12\n" +
 	"\x10_destination_urlB\b\n" +
-	"\x06_muted\"I\n" +
+	"\x06_mutedB\x15\n" +
+	"\x13_notify_first_eventB\r\n" +
+	"\v_slo_targetB\x16\n" +
+	"\x14_slo_latency_secondsB\x13\n" +
+	"\x11_slo_window_hoursB\x14\n" +
+	"\x12_reject_duplicatesB\v\n" +
+	"\t_honeypotB\x18\n" +
+	"\x16_conflict_as_duplicateB\x18\n" +
+	"\x16_rate_limit_per_minuteB\x10\n" +
+	"\x0e_answer_probes\"%\n" +
+	"\x0fDestinationList\x12\x12\n" +
+	"\x04urls\x18\x01 \x03(\tR\x04urls\"I\n" +
 	"\x16UpdateEndpointResponse\x12/\n" +
 	"\bendpoint\x18\x01 \x01(\v2\x13.hookly.v1.EndpointR\bendpoint\"'\n" +
 	"\x15DeleteEndpointRequest\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\"\x18\n" +
-	"\x16DeleteEndpointResponse\"#\n" +
+	"\x16DeleteEndpointResponse\">\n" +
+	"\x1bGetSetupInstructionsRequest\x12\x1f\n" +
+	"\vendpoint_id\x18\x01 \x01(\tR\n" +
+	"endpointId\"\xa1\x01\n" +
+	"\x1cGetSetupInstructionsResponse\x12\x1f\n" +
+	"\vwebhook_url\x18\x01 \x01(\tR\n" +
+	"webhookUrl\x12<\n" +
+	"\rprovider_type\x18\x02 \x01(\x0e2\x17.hookly.v1.ProviderTypeR\fproviderType\x12\"\n" +
+	"\finstructions\x18\x03 \x01(\tR\finstructions\"\xe3\x01\n" +
+	"\x15TelegramWebhookStatus\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x18\n" +
+	"\amatches\x18\x02 \x01(\bR\amatches\x120\n" +
+	"\x14pending_update_count\x18\x03 \x01(\x05R\x12pendingUpdateCount\x12,\n" +
+	"\x12last_error_message\x18\x04 \x01(\tR\x10lastErrorMessage\x12>\n" +
+	"\rlast_error_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\vlastErrorAt\"[\n" +
+	"\x1bSetupTelegramWebhookRequest\x12\x1f\n" +
+	"\vendpoint_id\x18\x01 \x01(\tR\n" +
+	"endpointId\x12\x1b\n" +
+	"\tbot_token\x18\x02 \x01(\tR\bbotToken\"X\n" +
+	"\x1cSetupTelegramWebhookResponse\x128\n" +
+	"\x06status\x18\x01 \x01(\v2 .hookly.v1.TelegramWebhookStatusR\x06status\"?\n" +
+	"\x1cVerifyTelegramWebhookRequest\x12\x1f\n" +
+	"\vendpoint_id\x18\x01 \x01(\tR\n" +
+	"endpointId\"Y\n" +
+	"\x1dVerifyTelegramWebhookResponse\x128\n" +
+	"\x06status\x18\x01 \x01(\v2 .hookly.v1.TelegramWebhookStatusR\x06status\":\n" +
+	"\x17GetEndpointStatsRequest\x12\x1f\n" +
+	"\vendpoint_id\x18\x01 \x01(\tR\n" +
+	"endpointId\"E\n" +
+	"\x0eEventTypeCount\x12\x1d\n" +
+	"\n" +
+	"event_type\x18\x01 \x01(\tR\teventType\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\xd7\x01\n" +
+	"\rSLOCompliance\x12\x16\n" +
+	"\x06target\x18\x01 \x01(\x01R\x06target\x12'\n" +
+	"\x0flatency_seconds\x18\x02 \x01(\x05R\x0elatencySeconds\x12!\n" +
+	"\fwindow_hours\x18\x03 \x01(\x05R\vwindowHours\x12\x14\n" +
+	"\x05total\x18\x04 \x01(\x03R\x05total\x12\x10\n" +
+	"\x03met\x18\x05 \x01(\x03R\x03met\x12\x1e\n" +
+	"\n" +
+	"compliance\x18\x06 \x01(\x01R\n" +
+	"compliance\x12\x1a\n" +
+	"\bbreached\x18\a \x01(\bR\bbreached\"\x82\x01\n" +
+	"\x18GetEndpointStatsResponse\x12:\n" +
+	"\vevent_types\x18\x01 \x03(\v2\x19.hookly.v1.EventTypeCountR\n" +
+	"eventTypes\x12*\n" +
+	"\x03slo\x18\x02 \x01(\v2\x18.hookly.v1.SLOComplianceR\x03slo\"i\n" +
+	"\x1bListConnectionEventsRequest\x12$\n" +
+	"\vendpoint_id\x18\x01 \x01(\tH\x00R\n" +
+	"endpointId\x88\x01\x01\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limitB\x0e\n" +
+	"\f_endpoint_id\"R\n" +
+	"\x1cListConnectionEventsResponse\x122\n" +
+	"\x06events\x18\x01 \x03(\v2\x1a.hookly.v1.ConnectionEventR\x06events\"@\n" +
+	"\x1dGenerateEndpointSecretRequest\x12\x1f\n" +
+	"\vendpoint_id\x18\x01 \x01(\tR\n" +
+	"endpointId\"8\n" +
+	"\x1eGenerateEndpointSecretResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\">\n" +
+	"\x1bRevealEndpointSecretRequest\x12\x1f\n" +
+	"\vendpoint_id\x18\x01 \x01(\tR\n" +
+	"endpointId\"6\n" +
+	"\x1cRevealEndpointSecretResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\"\x95\x01\n" +
 	"\x11GetWebhookRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"B\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12,\n" +
+	"\x0finclude_payload\x18\x02 \x01(\bH\x00R\x0eincludePayload\x88\x01\x01\x12 \n" +
+	"\tjson_path\x18\x03 \x01(\tH\x01R\bjsonPath\x88\x01\x01B\x12\n" +
+	"\x10_include_payloadB\f\n" +
+	"\n" +
+	"_json_path\"\x82\x01\n" +
 	"\x12GetWebhookResponse\x12,\n" +
-	"\awebhook\x18\x01 \x01(\v2\x12.hookly.v1.WebhookR\awebhook\"\xcb\x01\n" +
+	"\awebhook\x18\x01 \x01(\v2\x12.hookly.v1.WebhookR\awebhook\x12>\n" +
+	"\n" +
+	"deliveries\x18\x02 \x03(\v2\x1e.hookly.v1.DestinationDeliveryR\n" +
+	"deliveries\"*\n" +
+	"\x18GetWebhookPayloadRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"5\n" +
+	"\x19GetWebhookPayloadResponse\x12\x18\n" +
+	"\apayload\x18\x01 \x01(\fR\apayload\"\xd8\x02\n" +
 	"\x13ListWebhooksRequest\x12$\n" +
 	"\vendpoint_id\x18\x01 \x01(\tH\x00R\n" +
 	"endpointId\x88\x01\x01\x125\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x18.hookly.v1.WebhookStatusH\x01R\x06status\x88\x01\x01\x12<\n" +
 	"\n" +
 	"pagination\x18\x03 \x01(\v2\x1c.hookly.v1.PaginationRequestR\n" +
-	"paginationB\x0e\n" +
+	"pagination\x12\"\n" +
+	"\n" +
+	"event_type\x18\x04 \x01(\tH\x02R\teventType\x88\x01\x01\x12,\n" +
+	"\x0finclude_payload\x18\x05 \x01(\bH\x03R\x0eincludePayload\x88\x01\x01\x12\x16\n" +
+	"\x06purged\x18\x06 \x01(\bR\x06purgedB\x0e\n" +
 	"\f_endpoint_idB\t\n" +
-	"\a_status\"\x85\x01\n" +
+	"\a_statusB\r\n" +
+	"\v_event_typeB\x12\n" +
+	"\x10_include_payload\"\x85\x01\n" +
 	"\x14ListWebhooksResponse\x12.\n" +
 	"\bwebhooks\x18\x01 \x03(\v2\x12.hookly.v1.WebhookR\bwebhooks\x12=\n" +
 	"\n" +
 	"pagination\x18\x02 \x01(\v2\x1d.hookly.v1.PaginationResponseR\n" +
-	"pagination\"&\n" +
+	"pagination\"K\n" +
 	"\x14ReplayWebhookRequest\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"E\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rconfirm_token\x18\x02 \x01(\tR\fconfirmToken\"\xd2\x01\n" +
 	"\x15ReplayWebhookResponse\x12,\n" +
-	"\awebhook\x18\x01 \x01(\v2\x12.hookly.v1.WebhookR\awebhook\"\x12\n" +
+	"\awebhook\x18\x01 \x01(\v2\x12.hookly.v1.WebhookR\awebhook\x123\n" +
+	"\x15confirmation_required\x18\x02 \x01(\bR\x14confirmationRequired\x12-\n" +
+	"\x12confirmation_token\x18\x03 \x01(\tR\x11confirmationToken\x12'\n" +
+	"\x0fpending_replays\x18\x04 \x01(\x05R\x0ependingReplays\"\xcd\x02\n" +
+	"\x19BulkReplayWebhooksRequest\x12$\n" +
+	"\vendpoint_id\x18\x01 \x01(\tH\x00R\n" +
+	"endpointId\x88\x01\x01\x120\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x18.hookly.v1.WebhookStatusR\x06status\x12A\n" +
+	"\x0ereceived_after\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\rreceivedAfter\x12C\n" +
+	"\x0freceived_before\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x0ereceivedBefore\x12\x1b\n" +
+	"\tmax_count\x18\x05 \x01(\x05R\bmaxCount\x12#\n" +
+	"\rconfirm_token\x18\x06 \x01(\tR\fconfirmTokenB\x0e\n" +
+	"\f_endpoint_id\"\xce\x01\n" +
+	"\x1aBulkReplayWebhooksResponse\x12%\n" +
+	"\x0ereplayed_count\x18\x01 \x01(\x05R\rreplayedCount\x123\n" +
+	"\x15confirmation_required\x18\x02 \x01(\bR\x14confirmationRequired\x12-\n" +
+	"\x12confirmation_token\x18\x03 \x01(\tR\x11confirmationToken\x12%\n" +
+	"\x0ematching_count\x18\x04 \x01(\x05R\rmatchingCount\"(\n" +
+	"\x16UndeleteWebhookRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"G\n" +
+	"\x17UndeleteWebhookResponse\x12,\n" +
+	"\awebhook\x18\x01 \x01(\v2\x12.hookly.v1.WebhookR\awebhook\"S\n" +
+	"\x1bCancelPendingReplaysRequest\x12$\n" +
+	"\vendpoint_id\x18\x01 \x01(\tH\x00R\n" +
+	"endpointId\x88\x01\x01B\x0e\n" +
+	"\f_endpoint_id\"G\n" +
+	"\x1cCancelPendingReplaysResponse\x12'\n" +
+	"\x0fcancelled_count\x18\x01 \x01(\x05R\x0ecancelledCount\"\x81\x01\n" +
+	"\x13TailWebhooksRequest\x12$\n" +
+	"\vendpoint_id\x18\x01 \x01(\tH\x00R\n" +
+	"endpointId\x88\x01\x01\x124\n" +
+	"\bstatuses\x18\x02 \x03(\x0e2\x18.hookly.v1.WebhookStatusR\bstatusesB\x0e\n" +
+	"\f_endpoint_id\"|\n" +
+	"\x14TailWebhooksResponse\x12,\n" +
+	"\awebhook\x18\x01 \x01(\v2\x12.hookly.v1.WebhookR\awebhook\x126\n" +
+	"\x06change\x18\x02 \x01(\v2\x1e.hookly.v1.WebhookStatusChangeR\x06change\"\x12\n" +
 	"\x10GetStatusRequest\"D\n" +
 	"\x11GetStatusResponse\x12/\n" +
-	"\x06status\x18\x01 \x01(\v2\x17.hookly.v1.SystemStatusR\x06status\"\x14\n" +
-	"\x12GetSettingsRequest\"\xe4\x02\n" +
+	"\x06status\x18\x01 \x01(\v2\x17.hookly.v1.SystemStatusR\x06status\"O\n" +
+	"\x16GetActivityFeedRequest\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x1f\n" +
+	"\vsince_hours\x18\x02 \x01(\x05R\n" +
+	"sinceHours\"H\n" +
+	"\x17GetActivityFeedResponse\x12-\n" +
+	"\x05items\x18\x01 \x03(\v2\x17.hookly.v1.ActivityItemR\x05items\"\x13\n" +
+	"\x11GetRegionsRequest\"h\n" +
+	"\x12GetRegionsResponse\x12%\n" +
+	"\x0ecurrent_region\x18\x01 \x01(\tR\rcurrentRegion\x12+\n" +
+	"\aregions\x18\x02 \x03(\v2\x11.hookly.v1.RegionR\aregions\"\x13\n" +
+	"\x11GetVersionRequest\"\x84\x01\n" +
+	"\x12GetVersionResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12\x16\n" +
+	"\x06commit\x18\x02 \x01(\tR\x06commit\x12\x1d\n" +
+	"\n" +
+	"build_date\x18\x03 \x01(\tR\tbuildDate\x12\x1d\n" +
+	"\n" +
+	"go_version\x18\x04 \x01(\tR\tgoVersion\"y\n" +
+	"\x15SendHubCommandRequest\x12\x15\n" +
+	"\x06hub_id\x18\x01 \x01(\tR\x05hubId\x123\n" +
+	"\acommand\x18\x02 \x01(\x0e2\x19.hookly.v1.HubCommandTypeR\acommand\x12\x14\n" +
+	"\x05lines\x18\x03 \x01(\x05R\x05lines\"M\n" +
+	"\x16SendHubCommandResponse\x123\n" +
+	"\x06result\x18\x01 \x01(\v2\x1b.hookly.v1.HubCommandResultR\x06result\"\x14\n" +
+	"\x12GetSettingsRequest\"\xd1\x03\n" +
 	"\x13GetSettingsResponse\x12\x19\n" +
 	"\bbase_url\x18\x01 \x01(\tR\abaseUrl\x12.\n" +
 	"\x13github_auth_enabled\x18\x02 \x01(\bR\x11githubAuthEnabled\x12D\n" +
@@ -1412,39 +5271,161 @@ const file_hookly_v1_edge_proto_rawDesc = "" +
 	"\n" +
 	"avatar_url\x18\x06 \x01(\tR\tavatarUrl\x12E\n" +
 	"\x10theme_preference\x18\a \x01(\x0e2\x1a.hookly.v1.ThemePreferenceR\x0fthemePreference\x12!\n" +
-	"\fis_superuser\x18\b \x01(\bR\visSuperuser\"\x18\n" +
+	"\fis_superuser\x18\b \x01(\bR\visSuperuser\x12B\n" +
+	"\x1ddiscord_notifications_enabled\x18\t \x01(\bR\x1bdiscordNotificationsEnabled\x12'\n" +
+	"\x0femail_available\x18\n" +
+	" \x01(\bR\x0eemailAvailable\"\x17\n" +
+	"\x15GetCurrentUserRequest\"p\n" +
+	"\x16GetCurrentUserResponse\x12+\n" +
+	"\x04user\x18\x01 \x01(\v2\x17.hookly.v1.UserSettingsR\x04user\x12)\n" +
+	"\x05token\x18\x02 \x01(\v2\x13.hookly.v1.ApiTokenR\x05token\"\x18\n" +
 	"\x16GetUserSettingsRequest\"N\n" +
 	"\x17GetUserSettingsResponse\x123\n" +
-	"\bsettings\x18\x01 \x01(\v2\x17.hookly.v1.UserSettingsR\bsettings\"\xcf\x02\n" +
+	"\bsettings\x18\x01 \x01(\v2\x17.hookly.v1.UserSettingsR\bsettings\"\xc9\x06\n" +
 	"\x19UpdateUserSettingsRequest\x121\n" +
 	"\x12telegram_bot_token\x18\x01 \x01(\tH\x00R\x10telegramBotToken\x88\x01\x01\x12-\n" +
 	"\x10telegram_chat_id\x18\x02 \x01(\tH\x01R\x0etelegramChatId\x88\x01\x01\x12.\n" +
 	"\x10telegram_enabled\x18\x03 \x01(\bH\x02R\x0ftelegramEnabled\x88\x01\x01\x12J\n" +
-	"\x10theme_preference\x18\x04 \x01(\x0e2\x1a.hookly.v1.ThemePreferenceH\x03R\x0fthemePreference\x88\x01\x01B\x15\n" +
+	"\x10theme_preference\x18\x04 \x01(\x0e2\x1a.hookly.v1.ThemePreferenceH\x03R\x0fthemePreference\x88\x01\x01\x123\n" +
+	"\x13discord_webhook_url\x18\x05 \x01(\tH\x04R\x11discordWebhookUrl\x88\x01\x01\x12,\n" +
+	"\x0fdiscord_enabled\x18\x06 \x01(\bH\x05R\x0ediscordEnabled\x88\x01\x01\x12(\n" +
+	"\remail_address\x18\a \x01(\tH\x06R\femailAddress\x88\x01\x01\x12(\n" +
+	"\remail_enabled\x18\b \x01(\bH\aR\femailEnabled\x88\x01\x01\x121\n" +
+	"\x12notify_webhook_url\x18\t \x01(\tH\bR\x10notifyWebhookUrl\x88\x01\x01\x127\n" +
+	"\x15notify_webhook_secret\x18\n" +
+	" \x01(\tH\tR\x13notifyWebhookSecret\x88\x01\x01\x129\n" +
+	"\x16notify_webhook_enabled\x18\v \x01(\bH\n" +
+	"R\x14notifyWebhookEnabled\x88\x01\x01B\x15\n" +
 	"\x13_telegram_bot_tokenB\x13\n" +
 	"\x11_telegram_chat_idB\x13\n" +
 	"\x11_telegram_enabledB\x13\n" +
-	"\x11_theme_preference\"Q\n" +
+	"\x11_theme_preferenceB\x16\n" +
+	"\x14_discord_webhook_urlB\x12\n" +
+	"\x10_discord_enabledB\x10\n" +
+	"\x0e_email_addressB\x10\n" +
+	"\x0e_email_enabledB\x15\n" +
+	"\x13_notify_webhook_urlB\x18\n" +
+	"\x16_notify_webhook_secretB\x19\n" +
+	"\x17_notify_webhook_enabled\"Q\n" +
 	"\x1aUpdateUserSettingsResponse\x123\n" +
-	"\bsettings\x18\x01 \x01(\v2\x17.hookly.v1.UserSettingsR\bsettings\"\x1a\n" +
+	"\bsettings\x18\x01 \x01(\v2\x17.hookly.v1.UserSettingsR\bsettings\"\x16\n" +
+	"\x14SendTestEmailRequest\"<\n" +
+	"\x15SendTestEmailResponse\x12#\n" +
+	"\remail_address\x18\x01 \x01(\tR\femailAddress\"\x1e\n" +
+	"\x1cSendTestNotifyWebhookRequest\":\n" +
+	"\x1dSendTestNotifyWebhookResponse\x12\x19\n" +
+	"\bevent_id\x18\x01 \x01(\tR\aeventId\"\x16\n" +
+	"\x14ListApiTokensRequest\"D\n" +
+	"\x15ListApiTokensResponse\x12+\n" +
+	"\x06tokens\x18\x01 \x03(\v2\x13.hookly.v1.ApiTokenR\x06tokens\"\x86\x01\n" +
+	"\x15CreateApiTokenRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12+\n" +
+	"\x05scope\x18\x02 \x01(\x0e2\x15.hookly.v1.TokenScopeR\x05scope\x12,\n" +
+	"\x12expires_in_seconds\x18\x03 \x01(\x03R\x10expiresInSeconds\"`\n" +
+	"\x16CreateApiTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x120\n" +
+	"\tapi_token\x18\x02 \x01(\v2\x13.hookly.v1.ApiTokenR\bapiToken\"'\n" +
+	"\x15RotateApiTokenRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"`\n" +
+	"\x16RotateApiTokenResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x120\n" +
+	"\tapi_token\x18\x02 \x01(\v2\x13.hookly.v1.ApiTokenR\bapiToken\"'\n" +
+	"\x15RevokeApiTokenRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x18\n" +
+	"\x16RevokeApiTokenResponse\"\x11\n" +
+	"\x0fListOrgsRequest\"6\n" +
+	"\x10ListOrgsResponse\x12\"\n" +
+	"\x04orgs\x18\x01 \x03(\v2\x0e.hookly.v1.OrgR\x04orgs\"&\n" +
+	"\x10CreateOrgRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\"5\n" +
+	"\x11CreateOrgResponse\x12 \n" +
+	"\x03org\x18\x01 \x01(\v2\x0e.hookly.v1.OrgR\x03org\"\"\n" +
+	"\x10DeleteOrgRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"@\n" +
+	"\x11DeleteOrgResponse\x12+\n" +
+	"\x11endpoints_deleted\x18\x01 \x01(\x03R\x10endpointsDeleted\".\n" +
+	"\x15ListOrgMembersRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\"H\n" +
+	"\x16ListOrgMembersResponse\x12.\n" +
+	"\amembers\x18\x01 \x03(\v2\x14.hookly.v1.OrgMemberR\amembers\"p\n" +
+	"\x13AddOrgMemberRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12&\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x12.hookly.v1.OrgRoleR\x04role\"D\n" +
+	"\x14AddOrgMemberResponse\x12,\n" +
+	"\x06member\x18\x01 \x01(\v2\x14.hookly.v1.OrgMemberR\x06member\"H\n" +
+	"\x16RemoveOrgMemberRequest\x12\x15\n" +
+	"\x06org_id\x18\x01 \x01(\tR\x05orgId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"\x19\n" +
+	"\x17RemoveOrgMemberResponse\"\x88\x01\n" +
+	"\x16ListAuditEventsRequest\x12\x1b\n" +
+	"\x06action\x18\x01 \x01(\tH\x00R\x06action\x88\x01\x01\x120\n" +
+	"\x05since\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x05since\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limitB\t\n" +
+	"\a_action\"H\n" +
+	"\x17ListAuditEventsResponse\x12-\n" +
+	"\x06events\x18\x01 \x03(\v2\x15.hookly.v1.AuditEventR\x06events\"\x1a\n" +
 	"\x18GetSystemSettingsRequest\"R\n" +
 	"\x19GetSystemSettingsResponse\x125\n" +
-	"\bsettings\x18\x01 \x01(\v2\x19.hookly.v1.SystemSettingsR\bsettings2\xd7\b\n" +
+	"\bsettings\x18\x01 \x01(\v2\x19.hookly.v1.SystemSettingsR\bsettings\")\n" +
+	"\x15RunMaintenanceRequest\x12\x10\n" +
+	"\x03job\x18\x01 \x01(\tR\x03job\"E\n" +
+	"\x16RunMaintenanceResponse\x12+\n" +
+	"\x03job\x18\x01 \x01(\v2\x19.hookly.v1.MaintenanceJobR\x03job\"*\n" +
+	"\x12SetLogLevelRequest\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\"R\n" +
+	"\x13SetLogLevelResponse\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12%\n" +
+	"\x0eprevious_level\x18\x02 \x01(\tR\rpreviousLevel2\x99\x1f\n" +
 	"\vEdgeService\x12U\n" +
 	"\x0eCreateEndpoint\x12 .hookly.v1.CreateEndpointRequest\x1a!.hookly.v1.CreateEndpointResponse\x12L\n" +
 	"\vGetEndpoint\x12\x1d.hookly.v1.GetEndpointRequest\x1a\x1e.hookly.v1.GetEndpointResponse\x12R\n" +
 	"\rListEndpoints\x12\x1f.hookly.v1.ListEndpointsRequest\x1a .hookly.v1.ListEndpointsResponse\x12U\n" +
 	"\x0eUpdateEndpoint\x12 .hookly.v1.UpdateEndpointRequest\x1a!.hookly.v1.UpdateEndpointResponse\x12U\n" +
-	"\x0eDeleteEndpoint\x12 .hookly.v1.DeleteEndpointRequest\x1a!.hookly.v1.DeleteEndpointResponse\x12I\n" +
+	"\x0eDeleteEndpoint\x12 .hookly.v1.DeleteEndpointRequest\x1a!.hookly.v1.DeleteEndpointResponse\x12g\n" +
+	"\x14GetSetupInstructions\x12&.hookly.v1.GetSetupInstructionsRequest\x1a'.hookly.v1.GetSetupInstructionsResponse\x12g\n" +
+	"\x14SetupTelegramWebhook\x12&.hookly.v1.SetupTelegramWebhookRequest\x1a'.hookly.v1.SetupTelegramWebhookResponse\x12j\n" +
+	"\x15VerifyTelegramWebhook\x12'.hookly.v1.VerifyTelegramWebhookRequest\x1a(.hookly.v1.VerifyTelegramWebhookResponse\x12[\n" +
+	"\x10GetEndpointStats\x12\".hookly.v1.GetEndpointStatsRequest\x1a#.hookly.v1.GetEndpointStatsResponse\x12g\n" +
+	"\x14ListConnectionEvents\x12&.hookly.v1.ListConnectionEventsRequest\x1a'.hookly.v1.ListConnectionEventsResponse\x12m\n" +
+	"\x16GenerateEndpointSecret\x12(.hookly.v1.GenerateEndpointSecretRequest\x1a).hookly.v1.GenerateEndpointSecretResponse\x12g\n" +
+	"\x14RevealEndpointSecret\x12&.hookly.v1.RevealEndpointSecretRequest\x1a'.hookly.v1.RevealEndpointSecretResponse\x12I\n" +
 	"\n" +
-	"GetWebhook\x12\x1c.hookly.v1.GetWebhookRequest\x1a\x1d.hookly.v1.GetWebhookResponse\x12O\n" +
+	"GetWebhook\x12\x1c.hookly.v1.GetWebhookRequest\x1a\x1d.hookly.v1.GetWebhookResponse\x12^\n" +
+	"\x11GetWebhookPayload\x12#.hookly.v1.GetWebhookPayloadRequest\x1a$.hookly.v1.GetWebhookPayloadResponse\x12O\n" +
 	"\fListWebhooks\x12\x1e.hookly.v1.ListWebhooksRequest\x1a\x1f.hookly.v1.ListWebhooksResponse\x12R\n" +
-	"\rReplayWebhook\x12\x1f.hookly.v1.ReplayWebhookRequest\x1a .hookly.v1.ReplayWebhookResponse\x12F\n" +
+	"\rReplayWebhook\x12\x1f.hookly.v1.ReplayWebhookRequest\x1a .hookly.v1.ReplayWebhookResponse\x12a\n" +
+	"\x12BulkReplayWebhooks\x12$.hookly.v1.BulkReplayWebhooksRequest\x1a%.hookly.v1.BulkReplayWebhooksResponse\x12g\n" +
+	"\x14CancelPendingReplays\x12&.hookly.v1.CancelPendingReplaysRequest\x1a'.hookly.v1.CancelPendingReplaysResponse\x12X\n" +
+	"\x0fUndeleteWebhook\x12!.hookly.v1.UndeleteWebhookRequest\x1a\".hookly.v1.UndeleteWebhookResponse\x12Q\n" +
+	"\fTailWebhooks\x12\x1e.hookly.v1.TailWebhooksRequest\x1a\x1f.hookly.v1.TailWebhooksResponse0\x01\x12F\n" +
 	"\tGetStatus\x12\x1b.hookly.v1.GetStatusRequest\x1a\x1c.hookly.v1.GetStatusResponse\x12L\n" +
 	"\vGetSettings\x12\x1d.hookly.v1.GetSettingsRequest\x1a\x1e.hookly.v1.GetSettingsResponse\x12X\n" +
+	"\x0fGetActivityFeed\x12!.hookly.v1.GetActivityFeedRequest\x1a\".hookly.v1.GetActivityFeedResponse\x12I\n" +
+	"\n" +
+	"GetRegions\x12\x1c.hookly.v1.GetRegionsRequest\x1a\x1d.hookly.v1.GetRegionsResponse\x12I\n" +
+	"\n" +
+	"GetVersion\x12\x1c.hookly.v1.GetVersionRequest\x1a\x1d.hookly.v1.GetVersionResponse\x12U\n" +
+	"\x0eSendHubCommand\x12 .hookly.v1.SendHubCommandRequest\x1a!.hookly.v1.SendHubCommandResponse\x12U\n" +
+	"\x0eGetCurrentUser\x12 .hookly.v1.GetCurrentUserRequest\x1a!.hookly.v1.GetCurrentUserResponse\x12X\n" +
 	"\x0fGetUserSettings\x12!.hookly.v1.GetUserSettingsRequest\x1a\".hookly.v1.GetUserSettingsResponse\x12a\n" +
-	"\x12UpdateUserSettings\x12$.hookly.v1.UpdateUserSettingsRequest\x1a%.hookly.v1.UpdateUserSettingsResponse\x12^\n" +
-	"\x11GetSystemSettings\x12#.hookly.v1.GetSystemSettingsRequest\x1a$.hookly.v1.GetSystemSettingsResponseB\x90\x01\n" +
+	"\x12UpdateUserSettings\x12$.hookly.v1.UpdateUserSettingsRequest\x1a%.hookly.v1.UpdateUserSettingsResponse\x12R\n" +
+	"\rSendTestEmail\x12\x1f.hookly.v1.SendTestEmailRequest\x1a .hookly.v1.SendTestEmailResponse\x12j\n" +
+	"\x15SendTestNotifyWebhook\x12'.hookly.v1.SendTestNotifyWebhookRequest\x1a(.hookly.v1.SendTestNotifyWebhookResponse\x12R\n" +
+	"\rListApiTokens\x12\x1f.hookly.v1.ListApiTokensRequest\x1a .hookly.v1.ListApiTokensResponse\x12U\n" +
+	"\x0eCreateApiToken\x12 .hookly.v1.CreateApiTokenRequest\x1a!.hookly.v1.CreateApiTokenResponse\x12U\n" +
+	"\x0eRotateApiToken\x12 .hookly.v1.RotateApiTokenRequest\x1a!.hookly.v1.RotateApiTokenResponse\x12U\n" +
+	"\x0eRevokeApiToken\x12 .hookly.v1.RevokeApiTokenRequest\x1a!.hookly.v1.RevokeApiTokenResponse\x12C\n" +
+	"\bListOrgs\x12\x1a.hookly.v1.ListOrgsRequest\x1a\x1b.hookly.v1.ListOrgsResponse\x12F\n" +
+	"\tCreateOrg\x12\x1b.hookly.v1.CreateOrgRequest\x1a\x1c.hookly.v1.CreateOrgResponse\x12F\n" +
+	"\tDeleteOrg\x12\x1b.hookly.v1.DeleteOrgRequest\x1a\x1c.hookly.v1.DeleteOrgResponse\x12U\n" +
+	"\x0eListOrgMembers\x12 .hookly.v1.ListOrgMembersRequest\x1a!.hookly.v1.ListOrgMembersResponse\x12O\n" +
+	"\fAddOrgMember\x12\x1e.hookly.v1.AddOrgMemberRequest\x1a\x1f.hookly.v1.AddOrgMemberResponse\x12X\n" +
+	"\x0fRemoveOrgMember\x12!.hookly.v1.RemoveOrgMemberRequest\x1a\".hookly.v1.RemoveOrgMemberResponse\x12X\n" +
+	"\x0fListAuditEvents\x12!.hookly.v1.ListAuditEventsRequest\x1a\".hookly.v1.ListAuditEventsResponse\x12^\n" +
+	"\x11GetSystemSettings\x12#.hookly.v1.GetSystemSettingsRequest\x1a$.hookly.v1.GetSystemSettingsResponse\x12U\n" +
+	"\x0eRunMaintenance\x12 .hookly.v1.RunMaintenanceRequest\x1a!.hookly.v1.RunMaintenanceResponse\x12L\n" +
+	"\vSetLogLevel\x12\x1d.hookly.v1.SetLogLevelRequest\x1a\x1e.hookly.v1.SetLogLevelResponseB\x90\x01\n" +
 	"\rcom.hookly.v1B\tEdgeProtoP\x01Z/hooks.dx314.com/internal/api/hookly/v1;hooklyv1\xa2\x02\x03HXX\xaa\x02\tHookly.V1\xca\x02\tHookly\\V1\xe2\x02\x15Hookly\\V1\\GPBMetadata\xea\x02\n" +
 	"Hookly::V1b\x06proto3"
 
@@ -1460,99 +5441,294 @@ func file_hookly_v1_edge_proto_rawDescGZIP() []byte {
 	return file_hookly_v1_edge_proto_rawDescData
 }
 
-var file_hookly_v1_edge_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
+var file_hookly_v1_edge_proto_msgTypes = make([]protoimpl.MessageInfo, 94)
 var file_hookly_v1_edge_proto_goTypes = []any{
-	(*CreateEndpointRequest)(nil),      // 0: hookly.v1.CreateEndpointRequest
-	(*CreateEndpointResponse)(nil),     // 1: hookly.v1.CreateEndpointResponse
-	(*GetEndpointRequest)(nil),         // 2: hookly.v1.GetEndpointRequest
-	(*GetEndpointResponse)(nil),        // 3: hookly.v1.GetEndpointResponse
-	(*ListEndpointsRequest)(nil),       // 4: hookly.v1.ListEndpointsRequest
-	(*ListEndpointsResponse)(nil),      // 5: hookly.v1.ListEndpointsResponse
-	(*UpdateEndpointRequest)(nil),      // 6: hookly.v1.UpdateEndpointRequest
-	(*UpdateEndpointResponse)(nil),     // 7: hookly.v1.UpdateEndpointResponse
-	(*DeleteEndpointRequest)(nil),      // 8: hookly.v1.DeleteEndpointRequest
-	(*DeleteEndpointResponse)(nil),     // 9: hookly.v1.DeleteEndpointResponse
-	(*GetWebhookRequest)(nil),          // 10: hookly.v1.GetWebhookRequest
-	(*GetWebhookResponse)(nil),         // 11: hookly.v1.GetWebhookResponse
-	(*ListWebhooksRequest)(nil),        // 12: hookly.v1.ListWebhooksRequest
-	(*ListWebhooksResponse)(nil),       // 13: hookly.v1.ListWebhooksResponse
-	(*ReplayWebhookRequest)(nil),       // 14: hookly.v1.ReplayWebhookRequest
-	(*ReplayWebhookResponse)(nil),      // 15: hookly.v1.ReplayWebhookResponse
-	(*GetStatusRequest)(nil),           // 16: hookly.v1.GetStatusRequest
-	(*GetStatusResponse)(nil),          // 17: hookly.v1.GetStatusResponse
-	(*GetSettingsRequest)(nil),         // 18: hookly.v1.GetSettingsRequest
-	(*GetSettingsResponse)(nil),        // 19: hookly.v1.GetSettingsResponse
-	(*GetUserSettingsRequest)(nil),     // 20: hookly.v1.GetUserSettingsRequest
-	(*GetUserSettingsResponse)(nil),    // 21: hookly.v1.GetUserSettingsResponse
-	(*UpdateUserSettingsRequest)(nil),  // 22: hookly.v1.UpdateUserSettingsRequest
-	(*UpdateUserSettingsResponse)(nil), // 23: hookly.v1.UpdateUserSettingsResponse
-	(*GetSystemSettingsRequest)(nil),   // 24: hookly.v1.GetSystemSettingsRequest
-	(*GetSystemSettingsResponse)(nil),  // 25: hookly.v1.GetSystemSettingsResponse
-	(ProviderType)(0),                  // 26: hookly.v1.ProviderType
-	(*VerificationConfig)(nil),         // 27: hookly.v1.VerificationConfig
-	(*Endpoint)(nil),                   // 28: hookly.v1.Endpoint
-	(*PaginationRequest)(nil),          // 29: hookly.v1.PaginationRequest
-	(*PaginationResponse)(nil),         // 30: hookly.v1.PaginationResponse
-	(*Webhook)(nil),                    // 31: hookly.v1.Webhook
-	(WebhookStatus)(0),                 // 32: hookly.v1.WebhookStatus
-	(*SystemStatus)(nil),               // 33: hookly.v1.SystemStatus
-	(ThemePreference)(0),               // 34: hookly.v1.ThemePreference
-	(*UserSettings)(nil),               // 35: hookly.v1.UserSettings
-	(*SystemSettings)(nil),             // 36: hookly.v1.SystemSettings
+	(*CreateEndpointRequest)(nil),          // 0: hookly.v1.CreateEndpointRequest
+	(*CreateEndpointResponse)(nil),         // 1: hookly.v1.CreateEndpointResponse
+	(*GetEndpointRequest)(nil),             // 2: hookly.v1.GetEndpointRequest
+	(*GetEndpointResponse)(nil),            // 3: hookly.v1.GetEndpointResponse
+	(*ListEndpointsRequest)(nil),           // 4: hookly.v1.ListEndpointsRequest
+	(*ListEndpointsResponse)(nil),          // 5: hookly.v1.ListEndpointsResponse
+	(*UpdateEndpointRequest)(nil),          // 6: hookly.v1.UpdateEndpointRequest
+	(*DestinationList)(nil),                // 7: hookly.v1.DestinationList
+	(*UpdateEndpointResponse)(nil),         // 8: hookly.v1.UpdateEndpointResponse
+	(*DeleteEndpointRequest)(nil),          // 9: hookly.v1.DeleteEndpointRequest
+	(*DeleteEndpointResponse)(nil),         // 10: hookly.v1.DeleteEndpointResponse
+	(*GetSetupInstructionsRequest)(nil),    // 11: hookly.v1.GetSetupInstructionsRequest
+	(*GetSetupInstructionsResponse)(nil),   // 12: hookly.v1.GetSetupInstructionsResponse
+	(*TelegramWebhookStatus)(nil),          // 13: hookly.v1.TelegramWebhookStatus
+	(*SetupTelegramWebhookRequest)(nil),    // 14: hookly.v1.SetupTelegramWebhookRequest
+	(*SetupTelegramWebhookResponse)(nil),   // 15: hookly.v1.SetupTelegramWebhookResponse
+	(*VerifyTelegramWebhookRequest)(nil),   // 16: hookly.v1.VerifyTelegramWebhookRequest
+	(*VerifyTelegramWebhookResponse)(nil),  // 17: hookly.v1.VerifyTelegramWebhookResponse
+	(*GetEndpointStatsRequest)(nil),        // 18: hookly.v1.GetEndpointStatsRequest
+	(*EventTypeCount)(nil),                 // 19: hookly.v1.EventTypeCount
+	(*SLOCompliance)(nil),                  // 20: hookly.v1.SLOCompliance
+	(*GetEndpointStatsResponse)(nil),       // 21: hookly.v1.GetEndpointStatsResponse
+	(*ListConnectionEventsRequest)(nil),    // 22: hookly.v1.ListConnectionEventsRequest
+	(*ListConnectionEventsResponse)(nil),   // 23: hookly.v1.ListConnectionEventsResponse
+	(*GenerateEndpointSecretRequest)(nil),  // 24: hookly.v1.GenerateEndpointSecretRequest
+	(*GenerateEndpointSecretResponse)(nil), // 25: hookly.v1.GenerateEndpointSecretResponse
+	(*RevealEndpointSecretRequest)(nil),    // 26: hookly.v1.RevealEndpointSecretRequest
+	(*RevealEndpointSecretResponse)(nil),   // 27: hookly.v1.RevealEndpointSecretResponse
+	(*GetWebhookRequest)(nil),              // 28: hookly.v1.GetWebhookRequest
+	(*GetWebhookResponse)(nil),             // 29: hookly.v1.GetWebhookResponse
+	(*GetWebhookPayloadRequest)(nil),       // 30: hookly.v1.GetWebhookPayloadRequest
+	(*GetWebhookPayloadResponse)(nil),      // 31: hookly.v1.GetWebhookPayloadResponse
+	(*ListWebhooksRequest)(nil),            // 32: hookly.v1.ListWebhooksRequest
+	(*ListWebhooksResponse)(nil),           // 33: hookly.v1.ListWebhooksResponse
+	(*ReplayWebhookRequest)(nil),           // 34: hookly.v1.ReplayWebhookRequest
+	(*ReplayWebhookResponse)(nil),          // 35: hookly.v1.ReplayWebhookResponse
+	(*BulkReplayWebhooksRequest)(nil),      // 36: hookly.v1.BulkReplayWebhooksRequest
+	(*BulkReplayWebhooksResponse)(nil),     // 37: hookly.v1.BulkReplayWebhooksResponse
+	(*UndeleteWebhookRequest)(nil),         // 38: hookly.v1.UndeleteWebhookRequest
+	(*UndeleteWebhookResponse)(nil),        // 39: hookly.v1.UndeleteWebhookResponse
+	(*CancelPendingReplaysRequest)(nil),    // 40: hookly.v1.CancelPendingReplaysRequest
+	(*CancelPendingReplaysResponse)(nil),   // 41: hookly.v1.CancelPendingReplaysResponse
+	(*TailWebhooksRequest)(nil),            // 42: hookly.v1.TailWebhooksRequest
+	(*TailWebhooksResponse)(nil),           // 43: hookly.v1.TailWebhooksResponse
+	(*GetStatusRequest)(nil),               // 44: hookly.v1.GetStatusRequest
+	(*GetStatusResponse)(nil),              // 45: hookly.v1.GetStatusResponse
+	(*GetActivityFeedRequest)(nil),         // 46: hookly.v1.GetActivityFeedRequest
+	(*GetActivityFeedResponse)(nil),        // 47: hookly.v1.GetActivityFeedResponse
+	(*GetRegionsRequest)(nil),              // 48: hookly.v1.GetRegionsRequest
+	(*GetRegionsResponse)(nil),             // 49: hookly.v1.GetRegionsResponse
+	(*GetVersionRequest)(nil),              // 50: hookly.v1.GetVersionRequest
+	(*GetVersionResponse)(nil),             // 51: hookly.v1.GetVersionResponse
+	(*SendHubCommandRequest)(nil),          // 52: hookly.v1.SendHubCommandRequest
+	(*SendHubCommandResponse)(nil),         // 53: hookly.v1.SendHubCommandResponse
+	(*GetSettingsRequest)(nil),             // 54: hookly.v1.GetSettingsRequest
+	(*GetSettingsResponse)(nil),            // 55: hookly.v1.GetSettingsResponse
+	(*GetCurrentUserRequest)(nil),          // 56: hookly.v1.GetCurrentUserRequest
+	(*GetCurrentUserResponse)(nil),         // 57: hookly.v1.GetCurrentUserResponse
+	(*GetUserSettingsRequest)(nil),         // 58: hookly.v1.GetUserSettingsRequest
+	(*GetUserSettingsResponse)(nil),        // 59: hookly.v1.GetUserSettingsResponse
+	(*UpdateUserSettingsRequest)(nil),      // 60: hookly.v1.UpdateUserSettingsRequest
+	(*UpdateUserSettingsResponse)(nil),     // 61: hookly.v1.UpdateUserSettingsResponse
+	(*SendTestEmailRequest)(nil),           // 62: hookly.v1.SendTestEmailRequest
+	(*SendTestEmailResponse)(nil),          // 63: hookly.v1.SendTestEmailResponse
+	(*SendTestNotifyWebhookRequest)(nil),   // 64: hookly.v1.SendTestNotifyWebhookRequest
+	(*SendTestNotifyWebhookResponse)(nil),  // 65: hookly.v1.SendTestNotifyWebhookResponse
+	(*ListApiTokensRequest)(nil),           // 66: hookly.v1.ListApiTokensRequest
+	(*ListApiTokensResponse)(nil),          // 67: hookly.v1.ListApiTokensResponse
+	(*CreateApiTokenRequest)(nil),          // 68: hookly.v1.CreateApiTokenRequest
+	(*CreateApiTokenResponse)(nil),         // 69: hookly.v1.CreateApiTokenResponse
+	(*RotateApiTokenRequest)(nil),          // 70: hookly.v1.RotateApiTokenRequest
+	(*RotateApiTokenResponse)(nil),         // 71: hookly.v1.RotateApiTokenResponse
+	(*RevokeApiTokenRequest)(nil),          // 72: hookly.v1.RevokeApiTokenRequest
+	(*RevokeApiTokenResponse)(nil),         // 73: hookly.v1.RevokeApiTokenResponse
+	(*ListOrgsRequest)(nil),                // 74: hookly.v1.ListOrgsRequest
+	(*ListOrgsResponse)(nil),               // 75: hookly.v1.ListOrgsResponse
+	(*CreateOrgRequest)(nil),               // 76: hookly.v1.CreateOrgRequest
+	(*CreateOrgResponse)(nil),              // 77: hookly.v1.CreateOrgResponse
+	(*DeleteOrgRequest)(nil),               // 78: hookly.v1.DeleteOrgRequest
+	(*DeleteOrgResponse)(nil),              // 79: hookly.v1.DeleteOrgResponse
+	(*ListOrgMembersRequest)(nil),          // 80: hookly.v1.ListOrgMembersRequest
+	(*ListOrgMembersResponse)(nil),         // 81: hookly.v1.ListOrgMembersResponse
+	(*AddOrgMemberRequest)(nil),            // 82: hookly.v1.AddOrgMemberRequest
+	(*AddOrgMemberResponse)(nil),           // 83: hookly.v1.AddOrgMemberResponse
+	(*RemoveOrgMemberRequest)(nil),         // 84: hookly.v1.RemoveOrgMemberRequest
+	(*RemoveOrgMemberResponse)(nil),        // 85: hookly.v1.RemoveOrgMemberResponse
+	(*ListAuditEventsRequest)(nil),         // 86: hookly.v1.ListAuditEventsRequest
+	(*ListAuditEventsResponse)(nil),        // 87: hookly.v1.ListAuditEventsResponse
+	(*GetSystemSettingsRequest)(nil),       // 88: hookly.v1.GetSystemSettingsRequest
+	(*GetSystemSettingsResponse)(nil),      // 89: hookly.v1.GetSystemSettingsResponse
+	(*RunMaintenanceRequest)(nil),          // 90: hookly.v1.RunMaintenanceRequest
+	(*RunMaintenanceResponse)(nil),         // 91: hookly.v1.RunMaintenanceResponse
+	(*SetLogLevelRequest)(nil),             // 92: hookly.v1.SetLogLevelRequest
+	(*SetLogLevelResponse)(nil),            // 93: hookly.v1.SetLogLevelResponse
+	(ProviderType)(0),                      // 94: hookly.v1.ProviderType
+	(*VerificationConfig)(nil),             // 95: hookly.v1.VerificationConfig
+	(*IngestAuth)(nil),                     // 96: hookly.v1.IngestAuth
+	(*Endpoint)(nil),                       // 97: hookly.v1.Endpoint
+	(*PaginationRequest)(nil),              // 98: hookly.v1.PaginationRequest
+	(EndpointSort)(0),                      // 99: hookly.v1.EndpointSort
+	(*PaginationResponse)(nil),             // 100: hookly.v1.PaginationResponse
+	(*Transform)(nil),                      // 101: hookly.v1.Transform
+	(*IngestResponse)(nil),                 // 102: hookly.v1.IngestResponse
+	(*RetryPolicy)(nil),                    // 103: hookly.v1.RetryPolicy
+	(*PayloadLimits)(nil),                  // 104: hookly.v1.PayloadLimits
+	(*timestamppb.Timestamp)(nil),          // 105: google.protobuf.Timestamp
+	(*ConnectionEvent)(nil),                // 106: hookly.v1.ConnectionEvent
+	(*Webhook)(nil),                        // 107: hookly.v1.Webhook
+	(*DestinationDelivery)(nil),            // 108: hookly.v1.DestinationDelivery
+	(WebhookStatus)(0),                     // 109: hookly.v1.WebhookStatus
+	(*WebhookStatusChange)(nil),            // 110: hookly.v1.WebhookStatusChange
+	(*SystemStatus)(nil),                   // 111: hookly.v1.SystemStatus
+	(*ActivityItem)(nil),                   // 112: hookly.v1.ActivityItem
+	(*Region)(nil),                         // 113: hookly.v1.Region
+	(HubCommandType)(0),                    // 114: hookly.v1.HubCommandType
+	(*HubCommandResult)(nil),               // 115: hookly.v1.HubCommandResult
+	(ThemePreference)(0),                   // 116: hookly.v1.ThemePreference
+	(*UserSettings)(nil),                   // 117: hookly.v1.UserSettings
+	(*ApiToken)(nil),                       // 118: hookly.v1.ApiToken
+	(TokenScope)(0),                        // 119: hookly.v1.TokenScope
+	(*Org)(nil),                            // 120: hookly.v1.Org
+	(*OrgMember)(nil),                      // 121: hookly.v1.OrgMember
+	(OrgRole)(0),                           // 122: hookly.v1.OrgRole
+	(*AuditEvent)(nil),                     // 123: hookly.v1.AuditEvent
+	(*SystemSettings)(nil),                 // 124: hookly.v1.SystemSettings
+	(*MaintenanceJob)(nil),                 // 125: hookly.v1.MaintenanceJob
 }
 var file_hookly_v1_edge_proto_depIdxs = []int32{
-	26, // 0: hookly.v1.CreateEndpointRequest.provider_type:type_name -> hookly.v1.ProviderType
-	27, // 1: hookly.v1.CreateEndpointRequest.verification_config:type_name -> hookly.v1.VerificationConfig
-	28, // 2: hookly.v1.CreateEndpointResponse.endpoint:type_name -> hookly.v1.Endpoint
-	28, // 3: hookly.v1.GetEndpointResponse.endpoint:type_name -> hookly.v1.Endpoint
-	29, // 4: hookly.v1.ListEndpointsRequest.pagination:type_name -> hookly.v1.PaginationRequest
-	28, // 5: hookly.v1.ListEndpointsResponse.endpoints:type_name -> hookly.v1.Endpoint
-	30, // 6: hookly.v1.ListEndpointsResponse.pagination:type_name -> hookly.v1.PaginationResponse
-	27, // 7: hookly.v1.UpdateEndpointRequest.verification_config:type_name -> hookly.v1.VerificationConfig
-	28, // 8: hookly.v1.UpdateEndpointResponse.endpoint:type_name -> hookly.v1.Endpoint
-	31, // 9: hookly.v1.GetWebhookResponse.webhook:type_name -> hookly.v1.Webhook
-	32, // 10: hookly.v1.ListWebhooksRequest.status:type_name -> hookly.v1.WebhookStatus
-	29, // 11: hookly.v1.ListWebhooksRequest.pagination:type_name -> hookly.v1.PaginationRequest
-	31, // 12: hookly.v1.ListWebhooksResponse.webhooks:type_name -> hookly.v1.Webhook
-	30, // 13: hookly.v1.ListWebhooksResponse.pagination:type_name -> hookly.v1.PaginationResponse
-	31, // 14: hookly.v1.ReplayWebhookResponse.webhook:type_name -> hookly.v1.Webhook
-	33, // 15: hookly.v1.GetStatusResponse.status:type_name -> hookly.v1.SystemStatus
-	34, // 16: hookly.v1.GetSettingsResponse.theme_preference:type_name -> hookly.v1.ThemePreference
-	35, // 17: hookly.v1.GetUserSettingsResponse.settings:type_name -> hookly.v1.UserSettings
-	34, // 18: hookly.v1.UpdateUserSettingsRequest.theme_preference:type_name -> hookly.v1.ThemePreference
-	35, // 19: hookly.v1.UpdateUserSettingsResponse.settings:type_name -> hookly.v1.UserSettings
-	36, // 20: hookly.v1.GetSystemSettingsResponse.settings:type_name -> hookly.v1.SystemSettings
-	0,  // 21: hookly.v1.EdgeService.CreateEndpoint:input_type -> hookly.v1.CreateEndpointRequest
-	2,  // 22: hookly.v1.EdgeService.GetEndpoint:input_type -> hookly.v1.GetEndpointRequest
-	4,  // 23: hookly.v1.EdgeService.ListEndpoints:input_type -> hookly.v1.ListEndpointsRequest
-	6,  // 24: hookly.v1.EdgeService.UpdateEndpoint:input_type -> hookly.v1.UpdateEndpointRequest
-	8,  // 25: hookly.v1.EdgeService.DeleteEndpoint:input_type -> hookly.v1.DeleteEndpointRequest
-	10, // 26: hookly.v1.EdgeService.GetWebhook:input_type -> hookly.v1.GetWebhookRequest
-	12, // 27: hookly.v1.EdgeService.ListWebhooks:input_type -> hookly.v1.ListWebhooksRequest
-	14, // 28: hookly.v1.EdgeService.ReplayWebhook:input_type -> hookly.v1.ReplayWebhookRequest
-	16, // 29: hookly.v1.EdgeService.GetStatus:input_type -> hookly.v1.GetStatusRequest
-	18, // 30: hookly.v1.EdgeService.GetSettings:input_type -> hookly.v1.GetSettingsRequest
-	20, // 31: hookly.v1.EdgeService.GetUserSettings:input_type -> hookly.v1.GetUserSettingsRequest
-	22, // 32: hookly.v1.EdgeService.UpdateUserSettings:input_type -> hookly.v1.UpdateUserSettingsRequest
-	24, // 33: hookly.v1.EdgeService.GetSystemSettings:input_type -> hookly.v1.GetSystemSettingsRequest
-	1,  // 34: hookly.v1.EdgeService.CreateEndpoint:output_type -> hookly.v1.CreateEndpointResponse
-	3,  // 35: hookly.v1.EdgeService.GetEndpoint:output_type -> hookly.v1.GetEndpointResponse
-	5,  // 36: hookly.v1.EdgeService.ListEndpoints:output_type -> hookly.v1.ListEndpointsResponse
-	7,  // 37: hookly.v1.EdgeService.UpdateEndpoint:output_type -> hookly.v1.UpdateEndpointResponse
-	9,  // 38: hookly.v1.EdgeService.DeleteEndpoint:output_type -> hookly.v1.DeleteEndpointResponse
-	11, // 39: hookly.v1.EdgeService.GetWebhook:output_type -> hookly.v1.GetWebhookResponse
-	13, // 40: hookly.v1.EdgeService.ListWebhooks:output_type -> hookly.v1.ListWebhooksResponse
-	15, // 41: hookly.v1.EdgeService.ReplayWebhook:output_type -> hookly.v1.ReplayWebhookResponse
-	17, // 42: hookly.v1.EdgeService.GetStatus:output_type -> hookly.v1.GetStatusResponse
-	19, // 43: hookly.v1.EdgeService.GetSettings:output_type -> hookly.v1.GetSettingsResponse
-	21, // 44: hookly.v1.EdgeService.GetUserSettings:output_type -> hookly.v1.GetUserSettingsResponse
-	23, // 45: hookly.v1.EdgeService.UpdateUserSettings:output_type -> hookly.v1.UpdateUserSettingsResponse
-	25, // 46: hookly.v1.EdgeService.GetSystemSettings:output_type -> hookly.v1.GetSystemSettingsResponse
-	34, // [34:47] is the sub-list for method output_type
-	21, // [21:34] is the sub-list for method input_type
-	21, // [21:21] is the sub-list for extension type_name
-	21, // [21:21] is the sub-list for extension extendee
-	0,  // [0:21] is the sub-list for field type_name
+	94,  // 0: hookly.v1.CreateEndpointRequest.provider_type:type_name -> hookly.v1.ProviderType
+	95,  // 1: hookly.v1.CreateEndpointRequest.verification_config:type_name -> hookly.v1.VerificationConfig
+	96,  // 2: hookly.v1.CreateEndpointRequest.ingest_auth:type_name -> hookly.v1.IngestAuth
+	97,  // 3: hookly.v1.CreateEndpointResponse.endpoint:type_name -> hookly.v1.Endpoint
+	97,  // 4: hookly.v1.GetEndpointResponse.endpoint:type_name -> hookly.v1.Endpoint
+	98,  // 5: hookly.v1.ListEndpointsRequest.pagination:type_name -> hookly.v1.PaginationRequest
+	94,  // 6: hookly.v1.ListEndpointsRequest.provider_type:type_name -> hookly.v1.ProviderType
+	99,  // 7: hookly.v1.ListEndpointsRequest.sort:type_name -> hookly.v1.EndpointSort
+	97,  // 8: hookly.v1.ListEndpointsResponse.endpoints:type_name -> hookly.v1.Endpoint
+	100, // 9: hookly.v1.ListEndpointsResponse.pagination:type_name -> hookly.v1.PaginationResponse
+	95,  // 10: hookly.v1.UpdateEndpointRequest.verification_config:type_name -> hookly.v1.VerificationConfig
+	96,  // 11: hookly.v1.UpdateEndpointRequest.ingest_auth:type_name -> hookly.v1.IngestAuth
+	101, // 12: hookly.v1.UpdateEndpointRequest.transform:type_name -> hookly.v1.Transform
+	7,   // 13: hookly.v1.UpdateEndpointRequest.destinations:type_name -> hookly.v1.DestinationList
+	102, // 14: hookly.v1.UpdateEndpointRequest.ingest_response:type_name -> hookly.v1.IngestResponse
+	103, // 15: hookly.v1.UpdateEndpointRequest.retry_policy:type_name -> hookly.v1.RetryPolicy
+	104, // 16: hookly.v1.UpdateEndpointRequest.payload_limits:type_name -> hookly.v1.PayloadLimits
+	97,  // 17: hookly.v1.UpdateEndpointResponse.endpoint:type_name -> hookly.v1.Endpoint
+	94,  // 18: hookly.v1.GetSetupInstructionsResponse.provider_type:type_name -> hookly.v1.ProviderType
+	105, // 19: hookly.v1.TelegramWebhookStatus.last_error_at:type_name -> google.protobuf.Timestamp
+	13,  // 20: hookly.v1.SetupTelegramWebhookResponse.status:type_name -> hookly.v1.TelegramWebhookStatus
+	13,  // 21: hookly.v1.VerifyTelegramWebhookResponse.status:type_name -> hookly.v1.TelegramWebhookStatus
+	19,  // 22: hookly.v1.GetEndpointStatsResponse.event_types:type_name -> hookly.v1.EventTypeCount
+	20,  // 23: hookly.v1.GetEndpointStatsResponse.slo:type_name -> hookly.v1.SLOCompliance
+	106, // 24: hookly.v1.ListConnectionEventsResponse.events:type_name -> hookly.v1.ConnectionEvent
+	107, // 25: hookly.v1.GetWebhookResponse.webhook:type_name -> hookly.v1.Webhook
+	108, // 26: hookly.v1.GetWebhookResponse.deliveries:type_name -> hookly.v1.DestinationDelivery
+	109, // 27: hookly.v1.ListWebhooksRequest.status:type_name -> hookly.v1.WebhookStatus
+	98,  // 28: hookly.v1.ListWebhooksRequest.pagination:type_name -> hookly.v1.PaginationRequest
+	107, // 29: hookly.v1.ListWebhooksResponse.webhooks:type_name -> hookly.v1.Webhook
+	100, // 30: hookly.v1.ListWebhooksResponse.pagination:type_name -> hookly.v1.PaginationResponse
+	107, // 31: hookly.v1.ReplayWebhookResponse.webhook:type_name -> hookly.v1.Webhook
+	109, // 32: hookly.v1.BulkReplayWebhooksRequest.status:type_name -> hookly.v1.WebhookStatus
+	105, // 33: hookly.v1.BulkReplayWebhooksRequest.received_after:type_name -> google.protobuf.Timestamp
+	105, // 34: hookly.v1.BulkReplayWebhooksRequest.received_before:type_name -> google.protobuf.Timestamp
+	107, // 35: hookly.v1.UndeleteWebhookResponse.webhook:type_name -> hookly.v1.Webhook
+	109, // 36: hookly.v1.TailWebhooksRequest.statuses:type_name -> hookly.v1.WebhookStatus
+	107, // 37: hookly.v1.TailWebhooksResponse.webhook:type_name -> hookly.v1.Webhook
+	110, // 38: hookly.v1.TailWebhooksResponse.change:type_name -> hookly.v1.WebhookStatusChange
+	111, // 39: hookly.v1.GetStatusResponse.status:type_name -> hookly.v1.SystemStatus
+	112, // 40: hookly.v1.GetActivityFeedResponse.items:type_name -> hookly.v1.ActivityItem
+	113, // 41: hookly.v1.GetRegionsResponse.regions:type_name -> hookly.v1.Region
+	114, // 42: hookly.v1.SendHubCommandRequest.command:type_name -> hookly.v1.HubCommandType
+	115, // 43: hookly.v1.SendHubCommandResponse.result:type_name -> hookly.v1.HubCommandResult
+	116, // 44: hookly.v1.GetSettingsResponse.theme_preference:type_name -> hookly.v1.ThemePreference
+	117, // 45: hookly.v1.GetCurrentUserResponse.user:type_name -> hookly.v1.UserSettings
+	118, // 46: hookly.v1.GetCurrentUserResponse.token:type_name -> hookly.v1.ApiToken
+	117, // 47: hookly.v1.GetUserSettingsResponse.settings:type_name -> hookly.v1.UserSettings
+	116, // 48: hookly.v1.UpdateUserSettingsRequest.theme_preference:type_name -> hookly.v1.ThemePreference
+	117, // 49: hookly.v1.UpdateUserSettingsResponse.settings:type_name -> hookly.v1.UserSettings
+	118, // 50: hookly.v1.ListApiTokensResponse.tokens:type_name -> hookly.v1.ApiToken
+	119, // 51: hookly.v1.CreateApiTokenRequest.scope:type_name -> hookly.v1.TokenScope
+	118, // 52: hookly.v1.CreateApiTokenResponse.api_token:type_name -> hookly.v1.ApiToken
+	118, // 53: hookly.v1.RotateApiTokenResponse.api_token:type_name -> hookly.v1.ApiToken
+	120, // 54: hookly.v1.ListOrgsResponse.orgs:type_name -> hookly.v1.Org
+	120, // 55: hookly.v1.CreateOrgResponse.org:type_name -> hookly.v1.Org
+	121, // 56: hookly.v1.ListOrgMembersResponse.members:type_name -> hookly.v1.OrgMember
+	122, // 57: hookly.v1.AddOrgMemberRequest.role:type_name -> hookly.v1.OrgRole
+	121, // 58: hookly.v1.AddOrgMemberResponse.member:type_name -> hookly.v1.OrgMember
+	105, // 59: hookly.v1.ListAuditEventsRequest.since:type_name -> google.protobuf.Timestamp
+	123, // 60: hookly.v1.ListAuditEventsResponse.events:type_name -> hookly.v1.AuditEvent
+	124, // 61: hookly.v1.GetSystemSettingsResponse.settings:type_name -> hookly.v1.SystemSettings
+	125, // 62: hookly.v1.RunMaintenanceResponse.job:type_name -> hookly.v1.MaintenanceJob
+	0,   // 63: hookly.v1.EdgeService.CreateEndpoint:input_type -> hookly.v1.CreateEndpointRequest
+	2,   // 64: hookly.v1.EdgeService.GetEndpoint:input_type -> hookly.v1.GetEndpointRequest
+	4,   // 65: hookly.v1.EdgeService.ListEndpoints:input_type -> hookly.v1.ListEndpointsRequest
+	6,   // 66: hookly.v1.EdgeService.UpdateEndpoint:input_type -> hookly.v1.UpdateEndpointRequest
+	9,   // 67: hookly.v1.EdgeService.DeleteEndpoint:input_type -> hookly.v1.DeleteEndpointRequest
+	11,  // 68: hookly.v1.EdgeService.GetSetupInstructions:input_type -> hookly.v1.GetSetupInstructionsRequest
+	14,  // 69: hookly.v1.EdgeService.SetupTelegramWebhook:input_type -> hookly.v1.SetupTelegramWebhookRequest
+	16,  // 70: hookly.v1.EdgeService.VerifyTelegramWebhook:input_type -> hookly.v1.VerifyTelegramWebhookRequest
+	18,  // 71: hookly.v1.EdgeService.GetEndpointStats:input_type -> hookly.v1.GetEndpointStatsRequest
+	22,  // 72: hookly.v1.EdgeService.ListConnectionEvents:input_type -> hookly.v1.ListConnectionEventsRequest
+	24,  // 73: hookly.v1.EdgeService.GenerateEndpointSecret:input_type -> hookly.v1.GenerateEndpointSecretRequest
+	26,  // 74: hookly.v1.EdgeService.RevealEndpointSecret:input_type -> hookly.v1.RevealEndpointSecretRequest
+	28,  // 75: hookly.v1.EdgeService.GetWebhook:input_type -> hookly.v1.GetWebhookRequest
+	30,  // 76: hookly.v1.EdgeService.GetWebhookPayload:input_type -> hookly.v1.GetWebhookPayloadRequest
+	32,  // 77: hookly.v1.EdgeService.ListWebhooks:input_type -> hookly.v1.ListWebhooksRequest
+	34,  // 78: hookly.v1.EdgeService.ReplayWebhook:input_type -> hookly.v1.ReplayWebhookRequest
+	36,  // 79: hookly.v1.EdgeService.BulkReplayWebhooks:input_type -> hookly.v1.BulkReplayWebhooksRequest
+	40,  // 80: hookly.v1.EdgeService.CancelPendingReplays:input_type -> hookly.v1.CancelPendingReplaysRequest
+	38,  // 81: hookly.v1.EdgeService.UndeleteWebhook:input_type -> hookly.v1.UndeleteWebhookRequest
+	42,  // 82: hookly.v1.EdgeService.TailWebhooks:input_type -> hookly.v1.TailWebhooksRequest
+	44,  // 83: hookly.v1.EdgeService.GetStatus:input_type -> hookly.v1.GetStatusRequest
+	54,  // 84: hookly.v1.EdgeService.GetSettings:input_type -> hookly.v1.GetSettingsRequest
+	46,  // 85: hookly.v1.EdgeService.GetActivityFeed:input_type -> hookly.v1.GetActivityFeedRequest
+	48,  // 86: hookly.v1.EdgeService.GetRegions:input_type -> hookly.v1.GetRegionsRequest
+	50,  // 87: hookly.v1.EdgeService.GetVersion:input_type -> hookly.v1.GetVersionRequest
+	52,  // 88: hookly.v1.EdgeService.SendHubCommand:input_type -> hookly.v1.SendHubCommandRequest
+	56,  // 89: hookly.v1.EdgeService.GetCurrentUser:input_type -> hookly.v1.GetCurrentUserRequest
+	58,  // 90: hookly.v1.EdgeService.GetUserSettings:input_type -> hookly.v1.GetUserSettingsRequest
+	60,  // 91: hookly.v1.EdgeService.UpdateUserSettings:input_type -> hookly.v1.UpdateUserSettingsRequest
+	62,  // 92: hookly.v1.EdgeService.SendTestEmail:input_type -> hookly.v1.SendTestEmailRequest
+	64,  // 93: hookly.v1.EdgeService.SendTestNotifyWebhook:input_type -> hookly.v1.SendTestNotifyWebhookRequest
+	66,  // 94: hookly.v1.EdgeService.ListApiTokens:input_type -> hookly.v1.ListApiTokensRequest
+	68,  // 95: hookly.v1.EdgeService.CreateApiToken:input_type -> hookly.v1.CreateApiTokenRequest
+	70,  // 96: hookly.v1.EdgeService.RotateApiToken:input_type -> hookly.v1.RotateApiTokenRequest
+	72,  // 97: hookly.v1.EdgeService.RevokeApiToken:input_type -> hookly.v1.RevokeApiTokenRequest
+	74,  // 98: hookly.v1.EdgeService.ListOrgs:input_type -> hookly.v1.ListOrgsRequest
+	76,  // 99: hookly.v1.EdgeService.CreateOrg:input_type -> hookly.v1.CreateOrgRequest
+	78,  // 100: hookly.v1.EdgeService.DeleteOrg:input_type -> hookly.v1.DeleteOrgRequest
+	80,  // 101: hookly.v1.EdgeService.ListOrgMembers:input_type -> hookly.v1.ListOrgMembersRequest
+	82,  // 102: hookly.v1.EdgeService.AddOrgMember:input_type -> hookly.v1.AddOrgMemberRequest
+	84,  // 103: hookly.v1.EdgeService.RemoveOrgMember:input_type -> hookly.v1.RemoveOrgMemberRequest
+	86,  // 104: hookly.v1.EdgeService.ListAuditEvents:input_type -> hookly.v1.ListAuditEventsRequest
+	88,  // 105: hookly.v1.EdgeService.GetSystemSettings:input_type -> hookly.v1.GetSystemSettingsRequest
+	90,  // 106: hookly.v1.EdgeService.RunMaintenance:input_type -> hookly.v1.RunMaintenanceRequest
+	92,  // 107: hookly.v1.EdgeService.SetLogLevel:input_type -> hookly.v1.SetLogLevelRequest
+	1,   // 108: hookly.v1.EdgeService.CreateEndpoint:output_type -> hookly.v1.CreateEndpointResponse
+	3,   // 109: hookly.v1.EdgeService.GetEndpoint:output_type -> hookly.v1.GetEndpointResponse
+	5,   // 110: hookly.v1.EdgeService.ListEndpoints:output_type -> hookly.v1.ListEndpointsResponse
+	8,   // 111: hookly.v1.EdgeService.UpdateEndpoint:output_type -> hookly.v1.UpdateEndpointResponse
+	10,  // 112: hookly.v1.EdgeService.DeleteEndpoint:output_type -> hookly.v1.DeleteEndpointResponse
+	12,  // 113: hookly.v1.EdgeService.GetSetupInstructions:output_type -> hookly.v1.GetSetupInstructionsResponse
+	15,  // 114: hookly.v1.EdgeService.SetupTelegramWebhook:output_type -> hookly.v1.SetupTelegramWebhookResponse
+	17,  // 115: hookly.v1.EdgeService.VerifyTelegramWebhook:output_type -> hookly.v1.VerifyTelegramWebhookResponse
+	21,  // 116: hookly.v1.EdgeService.GetEndpointStats:output_type -> hookly.v1.GetEndpointStatsResponse
+	23,  // 117: hookly.v1.EdgeService.ListConnectionEvents:output_type -> hookly.v1.ListConnectionEventsResponse
+	25,  // 118: hookly.v1.EdgeService.GenerateEndpointSecret:output_type -> hookly.v1.GenerateEndpointSecretResponse
+	27,  // 119: hookly.v1.EdgeService.RevealEndpointSecret:output_type -> hookly.v1.RevealEndpointSecretResponse
+	29,  // 120: hookly.v1.EdgeService.GetWebhook:output_type -> hookly.v1.GetWebhookResponse
+	31,  // 121: hookly.v1.EdgeService.GetWebhookPayload:output_type -> hookly.v1.GetWebhookPayloadResponse
+	33,  // 122: hookly.v1.EdgeService.ListWebhooks:output_type -> hookly.v1.ListWebhooksResponse
+	35,  // 123: hookly.v1.EdgeService.ReplayWebhook:output_type -> hookly.v1.ReplayWebhookResponse
+	37,  // 124: hookly.v1.EdgeService.BulkReplayWebhooks:output_type -> hookly.v1.BulkReplayWebhooksResponse
+	41,  // 125: hookly.v1.EdgeService.CancelPendingReplays:output_type -> hookly.v1.CancelPendingReplaysResponse
+	39,  // 126: hookly.v1.EdgeService.UndeleteWebhook:output_type -> hookly.v1.UndeleteWebhookResponse
+	43,  // 127: hookly.v1.EdgeService.TailWebhooks:output_type -> hookly.v1.TailWebhooksResponse
+	45,  // 128: hookly.v1.EdgeService.GetStatus:output_type -> hookly.v1.GetStatusResponse
+	55,  // 129: hookly.v1.EdgeService.GetSettings:output_type -> hookly.v1.GetSettingsResponse
+	47,  // 130: hookly.v1.EdgeService.GetActivityFeed:output_type -> hookly.v1.GetActivityFeedResponse
+	49,  // 131: hookly.v1.EdgeService.GetRegions:output_type -> hookly.v1.GetRegionsResponse
+	51,  // 132: hookly.v1.EdgeService.GetVersion:output_type -> hookly.v1.GetVersionResponse
+	53,  // 133: hookly.v1.EdgeService.SendHubCommand:output_type -> hookly.v1.SendHubCommandResponse
+	57,  // 134: hookly.v1.EdgeService.GetCurrentUser:output_type -> hookly.v1.GetCurrentUserResponse
+	59,  // 135: hookly.v1.EdgeService.GetUserSettings:output_type -> hookly.v1.GetUserSettingsResponse
+	61,  // 136: hookly.v1.EdgeService.UpdateUserSettings:output_type -> hookly.v1.UpdateUserSettingsResponse
+	63,  // 137: hookly.v1.EdgeService.SendTestEmail:output_type -> hookly.v1.SendTestEmailResponse
+	65,  // 138: hookly.v1.EdgeService.SendTestNotifyWebhook:output_type -> hookly.v1.SendTestNotifyWebhookResponse
+	67,  // 139: hookly.v1.EdgeService.ListApiTokens:output_type -> hookly.v1.ListApiTokensResponse
+	69,  // 140: hookly.v1.EdgeService.CreateApiToken:output_type -> hookly.v1.CreateApiTokenResponse
+	71,  // 141: hookly.v1.EdgeService.RotateApiToken:output_type -> hookly.v1.RotateApiTokenResponse
+	73,  // 142: hookly.v1.EdgeService.RevokeApiToken:output_type -> hookly.v1.RevokeApiTokenResponse
+	75,  // 143: hookly.v1.EdgeService.ListOrgs:output_type -> hookly.v1.ListOrgsResponse
+	77,  // 144: hookly.v1.EdgeService.CreateOrg:output_type -> hookly.v1.CreateOrgResponse
+	79,  // 145: hookly.v1.EdgeService.DeleteOrg:output_type -> hookly.v1.DeleteOrgResponse
+	81,  // 146: hookly.v1.EdgeService.ListOrgMembers:output_type -> hookly.v1.ListOrgMembersResponse
+	83,  // 147: hookly.v1.EdgeService.AddOrgMember:output_type -> hookly.v1.AddOrgMemberResponse
+	85,  // 148: hookly.v1.EdgeService.RemoveOrgMember:output_type -> hookly.v1.RemoveOrgMemberResponse
+	87,  // 149: hookly.v1.EdgeService.ListAuditEvents:output_type -> hookly.v1.ListAuditEventsResponse
+	89,  // 150: hookly.v1.EdgeService.GetSystemSettings:output_type -> hookly.v1.GetSystemSettingsResponse
+	91,  // 151: hookly.v1.EdgeService.RunMaintenance:output_type -> hookly.v1.RunMaintenanceResponse
+	93,  // 152: hookly.v1.EdgeService.SetLogLevel:output_type -> hookly.v1.SetLogLevelResponse
+	108, // [108:153] is the sub-list for method output_type
+	63,  // [63:108] is the sub-list for method input_type
+	63,  // [63:63] is the sub-list for extension type_name
+	63,  // [63:63] is the sub-list for extension extendee
+	0,   // [0:63] is the sub-list for field type_name
 }
 
 func init() { file_hookly_v1_edge_proto_init() }
@@ -1561,16 +5737,23 @@ func file_hookly_v1_edge_proto_init() {
 		return
 	}
 	file_hookly_v1_common_proto_init()
+	file_hookly_v1_edge_proto_msgTypes[4].OneofWrappers = []any{}
 	file_hookly_v1_edge_proto_msgTypes[6].OneofWrappers = []any{}
-	file_hookly_v1_edge_proto_msgTypes[12].OneofWrappers = []any{}
 	file_hookly_v1_edge_proto_msgTypes[22].OneofWrappers = []any{}
+	file_hookly_v1_edge_proto_msgTypes[28].OneofWrappers = []any{}
+	file_hookly_v1_edge_proto_msgTypes[32].OneofWrappers = []any{}
+	file_hookly_v1_edge_proto_msgTypes[36].OneofWrappers = []any{}
+	file_hookly_v1_edge_proto_msgTypes[40].OneofWrappers = []any{}
+	file_hookly_v1_edge_proto_msgTypes[42].OneofWrappers = []any{}
+	file_hookly_v1_edge_proto_msgTypes[60].OneofWrappers = []any{}
+	file_hookly_v1_edge_proto_msgTypes[86].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hookly_v1_edge_proto_rawDesc), len(file_hookly_v1_edge_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   26,
+			NumMessages:   94,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
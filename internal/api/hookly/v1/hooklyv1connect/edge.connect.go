@@ -47,27 +47,117 @@ const (
 	// EdgeServiceDeleteEndpointProcedure is the fully-qualified name of the EdgeService's
 	// DeleteEndpoint RPC.
 	EdgeServiceDeleteEndpointProcedure = "/hookly.v1.EdgeService/DeleteEndpoint"
+	// EdgeServiceGetSetupInstructionsProcedure is the fully-qualified name of the EdgeService's
+	// GetSetupInstructions RPC.
+	EdgeServiceGetSetupInstructionsProcedure = "/hookly.v1.EdgeService/GetSetupInstructions"
+	// EdgeServiceSetupTelegramWebhookProcedure is the fully-qualified name of the EdgeService's
+	// SetupTelegramWebhook RPC.
+	EdgeServiceSetupTelegramWebhookProcedure = "/hookly.v1.EdgeService/SetupTelegramWebhook"
+	// EdgeServiceVerifyTelegramWebhookProcedure is the fully-qualified name of the EdgeService's
+	// VerifyTelegramWebhook RPC.
+	EdgeServiceVerifyTelegramWebhookProcedure = "/hookly.v1.EdgeService/VerifyTelegramWebhook"
+	// EdgeServiceGetEndpointStatsProcedure is the fully-qualified name of the EdgeService's
+	// GetEndpointStats RPC.
+	EdgeServiceGetEndpointStatsProcedure = "/hookly.v1.EdgeService/GetEndpointStats"
+	// EdgeServiceListConnectionEventsProcedure is the fully-qualified name of the EdgeService's
+	// ListConnectionEvents RPC.
+	EdgeServiceListConnectionEventsProcedure = "/hookly.v1.EdgeService/ListConnectionEvents"
+	// EdgeServiceGenerateEndpointSecretProcedure is the fully-qualified name of the EdgeService's
+	// GenerateEndpointSecret RPC.
+	EdgeServiceGenerateEndpointSecretProcedure = "/hookly.v1.EdgeService/GenerateEndpointSecret"
+	// EdgeServiceRevealEndpointSecretProcedure is the fully-qualified name of the EdgeService's
+	// RevealEndpointSecret RPC.
+	EdgeServiceRevealEndpointSecretProcedure = "/hookly.v1.EdgeService/RevealEndpointSecret"
 	// EdgeServiceGetWebhookProcedure is the fully-qualified name of the EdgeService's GetWebhook RPC.
 	EdgeServiceGetWebhookProcedure = "/hookly.v1.EdgeService/GetWebhook"
+	// EdgeServiceGetWebhookPayloadProcedure is the fully-qualified name of the EdgeService's
+	// GetWebhookPayload RPC.
+	EdgeServiceGetWebhookPayloadProcedure = "/hookly.v1.EdgeService/GetWebhookPayload"
 	// EdgeServiceListWebhooksProcedure is the fully-qualified name of the EdgeService's ListWebhooks
 	// RPC.
 	EdgeServiceListWebhooksProcedure = "/hookly.v1.EdgeService/ListWebhooks"
 	// EdgeServiceReplayWebhookProcedure is the fully-qualified name of the EdgeService's ReplayWebhook
 	// RPC.
 	EdgeServiceReplayWebhookProcedure = "/hookly.v1.EdgeService/ReplayWebhook"
+	// EdgeServiceBulkReplayWebhooksProcedure is the fully-qualified name of the EdgeService's
+	// BulkReplayWebhooks RPC.
+	EdgeServiceBulkReplayWebhooksProcedure = "/hookly.v1.EdgeService/BulkReplayWebhooks"
+	// EdgeServiceCancelPendingReplaysProcedure is the fully-qualified name of the EdgeService's
+	// CancelPendingReplays RPC.
+	EdgeServiceCancelPendingReplaysProcedure = "/hookly.v1.EdgeService/CancelPendingReplays"
+	// EdgeServiceUndeleteWebhookProcedure is the fully-qualified name of the EdgeService's
+	// UndeleteWebhook RPC.
+	EdgeServiceUndeleteWebhookProcedure = "/hookly.v1.EdgeService/UndeleteWebhook"
+	// EdgeServiceTailWebhooksProcedure is the fully-qualified name of the EdgeService's TailWebhooks
+	// RPC.
+	EdgeServiceTailWebhooksProcedure = "/hookly.v1.EdgeService/TailWebhooks"
 	// EdgeServiceGetStatusProcedure is the fully-qualified name of the EdgeService's GetStatus RPC.
 	EdgeServiceGetStatusProcedure = "/hookly.v1.EdgeService/GetStatus"
 	// EdgeServiceGetSettingsProcedure is the fully-qualified name of the EdgeService's GetSettings RPC.
 	EdgeServiceGetSettingsProcedure = "/hookly.v1.EdgeService/GetSettings"
+	// EdgeServiceGetActivityFeedProcedure is the fully-qualified name of the EdgeService's
+	// GetActivityFeed RPC.
+	EdgeServiceGetActivityFeedProcedure = "/hookly.v1.EdgeService/GetActivityFeed"
+	// EdgeServiceGetRegionsProcedure is the fully-qualified name of the EdgeService's GetRegions RPC.
+	EdgeServiceGetRegionsProcedure = "/hookly.v1.EdgeService/GetRegions"
+	// EdgeServiceGetVersionProcedure is the fully-qualified name of the EdgeService's GetVersion RPC.
+	EdgeServiceGetVersionProcedure = "/hookly.v1.EdgeService/GetVersion"
+	// EdgeServiceSendHubCommandProcedure is the fully-qualified name of the EdgeService's
+	// SendHubCommand RPC.
+	EdgeServiceSendHubCommandProcedure = "/hookly.v1.EdgeService/SendHubCommand"
+	// EdgeServiceGetCurrentUserProcedure is the fully-qualified name of the EdgeService's
+	// GetCurrentUser RPC.
+	EdgeServiceGetCurrentUserProcedure = "/hookly.v1.EdgeService/GetCurrentUser"
 	// EdgeServiceGetUserSettingsProcedure is the fully-qualified name of the EdgeService's
 	// GetUserSettings RPC.
 	EdgeServiceGetUserSettingsProcedure = "/hookly.v1.EdgeService/GetUserSettings"
 	// EdgeServiceUpdateUserSettingsProcedure is the fully-qualified name of the EdgeService's
 	// UpdateUserSettings RPC.
 	EdgeServiceUpdateUserSettingsProcedure = "/hookly.v1.EdgeService/UpdateUserSettings"
+	// EdgeServiceSendTestEmailProcedure is the fully-qualified name of the EdgeService's SendTestEmail
+	// RPC.
+	EdgeServiceSendTestEmailProcedure = "/hookly.v1.EdgeService/SendTestEmail"
+	// EdgeServiceSendTestNotifyWebhookProcedure is the fully-qualified name of the EdgeService's
+	// SendTestNotifyWebhook RPC.
+	EdgeServiceSendTestNotifyWebhookProcedure = "/hookly.v1.EdgeService/SendTestNotifyWebhook"
+	// EdgeServiceListApiTokensProcedure is the fully-qualified name of the EdgeService's ListApiTokens
+	// RPC.
+	EdgeServiceListApiTokensProcedure = "/hookly.v1.EdgeService/ListApiTokens"
+	// EdgeServiceCreateApiTokenProcedure is the fully-qualified name of the EdgeService's
+	// CreateApiToken RPC.
+	EdgeServiceCreateApiTokenProcedure = "/hookly.v1.EdgeService/CreateApiToken"
+	// EdgeServiceRotateApiTokenProcedure is the fully-qualified name of the EdgeService's
+	// RotateApiToken RPC.
+	EdgeServiceRotateApiTokenProcedure = "/hookly.v1.EdgeService/RotateApiToken"
+	// EdgeServiceRevokeApiTokenProcedure is the fully-qualified name of the EdgeService's
+	// RevokeApiToken RPC.
+	EdgeServiceRevokeApiTokenProcedure = "/hookly.v1.EdgeService/RevokeApiToken"
+	// EdgeServiceListOrgsProcedure is the fully-qualified name of the EdgeService's ListOrgs RPC.
+	EdgeServiceListOrgsProcedure = "/hookly.v1.EdgeService/ListOrgs"
+	// EdgeServiceCreateOrgProcedure is the fully-qualified name of the EdgeService's CreateOrg RPC.
+	EdgeServiceCreateOrgProcedure = "/hookly.v1.EdgeService/CreateOrg"
+	// EdgeServiceDeleteOrgProcedure is the fully-qualified name of the EdgeService's DeleteOrg RPC.
+	EdgeServiceDeleteOrgProcedure = "/hookly.v1.EdgeService/DeleteOrg"
+	// EdgeServiceListOrgMembersProcedure is the fully-qualified name of the EdgeService's
+	// ListOrgMembers RPC.
+	EdgeServiceListOrgMembersProcedure = "/hookly.v1.EdgeService/ListOrgMembers"
+	// EdgeServiceAddOrgMemberProcedure is the fully-qualified name of the EdgeService's AddOrgMember
+	// RPC.
+	EdgeServiceAddOrgMemberProcedure = "/hookly.v1.EdgeService/AddOrgMember"
+	// EdgeServiceRemoveOrgMemberProcedure is the fully-qualified name of the EdgeService's
+	// RemoveOrgMember RPC.
+	EdgeServiceRemoveOrgMemberProcedure = "/hookly.v1.EdgeService/RemoveOrgMember"
+	// EdgeServiceListAuditEventsProcedure is the fully-qualified name of the EdgeService's
+	// ListAuditEvents RPC.
+	EdgeServiceListAuditEventsProcedure = "/hookly.v1.EdgeService/ListAuditEvents"
 	// EdgeServiceGetSystemSettingsProcedure is the fully-qualified name of the EdgeService's
 	// GetSystemSettings RPC.
 	EdgeServiceGetSystemSettingsProcedure = "/hookly.v1.EdgeService/GetSystemSettings"
+	// EdgeServiceRunMaintenanceProcedure is the fully-qualified name of the EdgeService's
+	// RunMaintenance RPC.
+	EdgeServiceRunMaintenanceProcedure = "/hookly.v1.EdgeService/RunMaintenance"
+	// EdgeServiceSetLogLevelProcedure is the fully-qualified name of the EdgeService's SetLogLevel RPC.
+	EdgeServiceSetLogLevelProcedure = "/hookly.v1.EdgeService/SetLogLevel"
 )
 
 // EdgeServiceClient is a client for the hookly.v1.EdgeService service.
@@ -78,18 +168,68 @@ type EdgeServiceClient interface {
 	ListEndpoints(context.Context, *connect.Request[v1.ListEndpointsRequest]) (*connect.Response[v1.ListEndpointsResponse], error)
 	UpdateEndpoint(context.Context, *connect.Request[v1.UpdateEndpointRequest]) (*connect.Response[v1.UpdateEndpointResponse], error)
 	DeleteEndpoint(context.Context, *connect.Request[v1.DeleteEndpointRequest]) (*connect.Response[v1.DeleteEndpointResponse], error)
+	GetSetupInstructions(context.Context, *connect.Request[v1.GetSetupInstructionsRequest]) (*connect.Response[v1.GetSetupInstructionsResponse], error)
+	SetupTelegramWebhook(context.Context, *connect.Request[v1.SetupTelegramWebhookRequest]) (*connect.Response[v1.SetupTelegramWebhookResponse], error)
+	VerifyTelegramWebhook(context.Context, *connect.Request[v1.VerifyTelegramWebhookRequest]) (*connect.Response[v1.VerifyTelegramWebhookResponse], error)
+	GetEndpointStats(context.Context, *connect.Request[v1.GetEndpointStatsRequest]) (*connect.Response[v1.GetEndpointStatsResponse], error)
+	ListConnectionEvents(context.Context, *connect.Request[v1.ListConnectionEventsRequest]) (*connect.Response[v1.ListConnectionEventsResponse], error)
+	GenerateEndpointSecret(context.Context, *connect.Request[v1.GenerateEndpointSecretRequest]) (*connect.Response[v1.GenerateEndpointSecretResponse], error)
+	// Web session only; every reveal is audit-logged and rate limited
+	RevealEndpointSecret(context.Context, *connect.Request[v1.RevealEndpointSecretRequest]) (*connect.Response[v1.RevealEndpointSecretResponse], error)
 	// Webhook management
 	GetWebhook(context.Context, *connect.Request[v1.GetWebhookRequest]) (*connect.Response[v1.GetWebhookResponse], error)
+	GetWebhookPayload(context.Context, *connect.Request[v1.GetWebhookPayloadRequest]) (*connect.Response[v1.GetWebhookPayloadResponse], error)
 	ListWebhooks(context.Context, *connect.Request[v1.ListWebhooksRequest]) (*connect.Response[v1.ListWebhooksResponse], error)
 	ReplayWebhook(context.Context, *connect.Request[v1.ReplayWebhookRequest]) (*connect.Response[v1.ReplayWebhookResponse], error)
+	BulkReplayWebhooks(context.Context, *connect.Request[v1.BulkReplayWebhooksRequest]) (*connect.Response[v1.BulkReplayWebhooksResponse], error)
+	CancelPendingReplays(context.Context, *connect.Request[v1.CancelPendingReplaysRequest]) (*connect.Response[v1.CancelPendingReplaysResponse], error)
+	// Restores a webhook purged by retention cleanup, within the grace period
+	UndeleteWebhook(context.Context, *connect.Request[v1.UndeleteWebhookRequest]) (*connect.Response[v1.UndeleteWebhookResponse], error)
+	// Streams webhooks as they are received and change status
+	TailWebhooks(context.Context, *connect.Request[v1.TailWebhooksRequest]) (*connect.ServerStreamForClient[v1.TailWebhooksResponse], error)
 	// System status
 	GetStatus(context.Context, *connect.Request[v1.GetStatusRequest]) (*connect.Response[v1.GetStatusResponse], error)
 	GetSettings(context.Context, *connect.Request[v1.GetSettingsRequest]) (*connect.Response[v1.GetSettingsResponse], error)
+	GetActivityFeed(context.Context, *connect.Request[v1.GetActivityFeedRequest]) (*connect.Response[v1.GetActivityFeedResponse], error)
+	GetRegions(context.Context, *connect.Request[v1.GetRegionsRequest]) (*connect.Response[v1.GetRegionsResponse], error)
+	// Build info of the edge; needs no auth
+	GetVersion(context.Context, *connect.Request[v1.GetVersionRequest]) (*connect.Response[v1.GetVersionResponse], error)
+	// Hub management: runs a command on one of the user's connected hubs
+	SendHubCommand(context.Context, *connect.Request[v1.SendHubCommandRequest]) (*connect.Response[v1.SendHubCommandResponse], error)
 	// User settings
+	GetCurrentUser(context.Context, *connect.Request[v1.GetCurrentUserRequest]) (*connect.Response[v1.GetCurrentUserResponse], error)
 	GetUserSettings(context.Context, *connect.Request[v1.GetUserSettingsRequest]) (*connect.Response[v1.GetUserSettingsResponse], error)
 	UpdateUserSettings(context.Context, *connect.Request[v1.UpdateUserSettingsRequest]) (*connect.Response[v1.UpdateUserSettingsResponse], error)
+	// Sends a test email to the user's notification address
+	SendTestEmail(context.Context, *connect.Request[v1.SendTestEmailRequest]) (*connect.Response[v1.SendTestEmailResponse], error)
+	// Sends a test event to the user's notification webhook
+	SendTestNotifyWebhook(context.Context, *connect.Request[v1.SendTestNotifyWebhookRequest]) (*connect.Response[v1.SendTestNotifyWebhookResponse], error)
+	// API tokens; only admin tokens and web sessions can create, rotate or
+	// revoke them
+	ListApiTokens(context.Context, *connect.Request[v1.ListApiTokensRequest]) (*connect.Response[v1.ListApiTokensResponse], error)
+	CreateApiToken(context.Context, *connect.Request[v1.CreateApiTokenRequest]) (*connect.Response[v1.CreateApiTokenResponse], error)
+	// Replaces a token with a new one of the same name, scope and lifetime
+	RotateApiToken(context.Context, *connect.Request[v1.RotateApiTokenRequest]) (*connect.Response[v1.RotateApiTokenResponse], error)
+	RevokeApiToken(context.Context, *connect.Request[v1.RevokeApiTokenRequest]) (*connect.Response[v1.RevokeApiTokenResponse], error)
+	// Organizations, which share endpoints among their members. Endpoint
+	// calls act for an org when the Hookly-Org header names it.
+	ListOrgs(context.Context, *connect.Request[v1.ListOrgsRequest]) (*connect.Response[v1.ListOrgsResponse], error)
+	// Creates an org with the caller as its owner
+	CreateOrg(context.Context, *connect.Request[v1.CreateOrgRequest]) (*connect.Response[v1.CreateOrgResponse], error)
+	// Deletes an org with its endpoints and webhooks (owners only)
+	DeleteOrg(context.Context, *connect.Request[v1.DeleteOrgRequest]) (*connect.Response[v1.DeleteOrgResponse], error)
+	ListOrgMembers(context.Context, *connect.Request[v1.ListOrgMembersRequest]) (*connect.Response[v1.ListOrgMembersResponse], error)
+	// Adds a member, or changes a member's role (owners only)
+	AddOrgMember(context.Context, *connect.Request[v1.AddOrgMemberRequest]) (*connect.Response[v1.AddOrgMemberResponse], error)
+	// Removes a member; owners remove anyone, others only themselves
+	RemoveOrgMember(context.Context, *connect.Request[v1.RemoveOrgMemberRequest]) (*connect.Response[v1.RemoveOrgMemberResponse], error)
+	// Audit log of management actions: the caller's, and everyone's on the
+	// endpoints the call acts for
+	ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error)
 	// System settings (superuser only)
 	GetSystemSettings(context.Context, *connect.Request[v1.GetSystemSettingsRequest]) (*connect.Response[v1.GetSystemSettingsResponse], error)
+	RunMaintenance(context.Context, *connect.Request[v1.RunMaintenanceRequest]) (*connect.Response[v1.RunMaintenanceResponse], error)
+	SetLogLevel(context.Context, *connect.Request[v1.SetLogLevelRequest]) (*connect.Response[v1.SetLogLevelResponse], error)
 }
 
 // NewEdgeServiceClient constructs a client for the hookly.v1.EdgeService service. By default, it
@@ -133,12 +273,60 @@ func NewEdgeServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(edgeServiceMethods.ByName("DeleteEndpoint")),
 			connect.WithClientOptions(opts...),
 		),
+		getSetupInstructions: connect.NewClient[v1.GetSetupInstructionsRequest, v1.GetSetupInstructionsResponse](
+			httpClient,
+			baseURL+EdgeServiceGetSetupInstructionsProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("GetSetupInstructions")),
+			connect.WithClientOptions(opts...),
+		),
+		setupTelegramWebhook: connect.NewClient[v1.SetupTelegramWebhookRequest, v1.SetupTelegramWebhookResponse](
+			httpClient,
+			baseURL+EdgeServiceSetupTelegramWebhookProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("SetupTelegramWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		verifyTelegramWebhook: connect.NewClient[v1.VerifyTelegramWebhookRequest, v1.VerifyTelegramWebhookResponse](
+			httpClient,
+			baseURL+EdgeServiceVerifyTelegramWebhookProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("VerifyTelegramWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		getEndpointStats: connect.NewClient[v1.GetEndpointStatsRequest, v1.GetEndpointStatsResponse](
+			httpClient,
+			baseURL+EdgeServiceGetEndpointStatsProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("GetEndpointStats")),
+			connect.WithClientOptions(opts...),
+		),
+		listConnectionEvents: connect.NewClient[v1.ListConnectionEventsRequest, v1.ListConnectionEventsResponse](
+			httpClient,
+			baseURL+EdgeServiceListConnectionEventsProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("ListConnectionEvents")),
+			connect.WithClientOptions(opts...),
+		),
+		generateEndpointSecret: connect.NewClient[v1.GenerateEndpointSecretRequest, v1.GenerateEndpointSecretResponse](
+			httpClient,
+			baseURL+EdgeServiceGenerateEndpointSecretProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("GenerateEndpointSecret")),
+			connect.WithClientOptions(opts...),
+		),
+		revealEndpointSecret: connect.NewClient[v1.RevealEndpointSecretRequest, v1.RevealEndpointSecretResponse](
+			httpClient,
+			baseURL+EdgeServiceRevealEndpointSecretProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("RevealEndpointSecret")),
+			connect.WithClientOptions(opts...),
+		),
 		getWebhook: connect.NewClient[v1.GetWebhookRequest, v1.GetWebhookResponse](
 			httpClient,
 			baseURL+EdgeServiceGetWebhookProcedure,
 			connect.WithSchema(edgeServiceMethods.ByName("GetWebhook")),
 			connect.WithClientOptions(opts...),
 		),
+		getWebhookPayload: connect.NewClient[v1.GetWebhookPayloadRequest, v1.GetWebhookPayloadResponse](
+			httpClient,
+			baseURL+EdgeServiceGetWebhookPayloadProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("GetWebhookPayload")),
+			connect.WithClientOptions(opts...),
+		),
 		listWebhooks: connect.NewClient[v1.ListWebhooksRequest, v1.ListWebhooksResponse](
 			httpClient,
 			baseURL+EdgeServiceListWebhooksProcedure,
@@ -151,6 +339,30 @@ func NewEdgeServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(edgeServiceMethods.ByName("ReplayWebhook")),
 			connect.WithClientOptions(opts...),
 		),
+		bulkReplayWebhooks: connect.NewClient[v1.BulkReplayWebhooksRequest, v1.BulkReplayWebhooksResponse](
+			httpClient,
+			baseURL+EdgeServiceBulkReplayWebhooksProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("BulkReplayWebhooks")),
+			connect.WithClientOptions(opts...),
+		),
+		cancelPendingReplays: connect.NewClient[v1.CancelPendingReplaysRequest, v1.CancelPendingReplaysResponse](
+			httpClient,
+			baseURL+EdgeServiceCancelPendingReplaysProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("CancelPendingReplays")),
+			connect.WithClientOptions(opts...),
+		),
+		undeleteWebhook: connect.NewClient[v1.UndeleteWebhookRequest, v1.UndeleteWebhookResponse](
+			httpClient,
+			baseURL+EdgeServiceUndeleteWebhookProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("UndeleteWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		tailWebhooks: connect.NewClient[v1.TailWebhooksRequest, v1.TailWebhooksResponse](
+			httpClient,
+			baseURL+EdgeServiceTailWebhooksProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("TailWebhooks")),
+			connect.WithClientOptions(opts...),
+		),
 		getStatus: connect.NewClient[v1.GetStatusRequest, v1.GetStatusResponse](
 			httpClient,
 			baseURL+EdgeServiceGetStatusProcedure,
@@ -163,6 +375,36 @@ func NewEdgeServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(edgeServiceMethods.ByName("GetSettings")),
 			connect.WithClientOptions(opts...),
 		),
+		getActivityFeed: connect.NewClient[v1.GetActivityFeedRequest, v1.GetActivityFeedResponse](
+			httpClient,
+			baseURL+EdgeServiceGetActivityFeedProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("GetActivityFeed")),
+			connect.WithClientOptions(opts...),
+		),
+		getRegions: connect.NewClient[v1.GetRegionsRequest, v1.GetRegionsResponse](
+			httpClient,
+			baseURL+EdgeServiceGetRegionsProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("GetRegions")),
+			connect.WithClientOptions(opts...),
+		),
+		getVersion: connect.NewClient[v1.GetVersionRequest, v1.GetVersionResponse](
+			httpClient,
+			baseURL+EdgeServiceGetVersionProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("GetVersion")),
+			connect.WithClientOptions(opts...),
+		),
+		sendHubCommand: connect.NewClient[v1.SendHubCommandRequest, v1.SendHubCommandResponse](
+			httpClient,
+			baseURL+EdgeServiceSendHubCommandProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("SendHubCommand")),
+			connect.WithClientOptions(opts...),
+		),
+		getCurrentUser: connect.NewClient[v1.GetCurrentUserRequest, v1.GetCurrentUserResponse](
+			httpClient,
+			baseURL+EdgeServiceGetCurrentUserProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("GetCurrentUser")),
+			connect.WithClientOptions(opts...),
+		),
 		getUserSettings: connect.NewClient[v1.GetUserSettingsRequest, v1.GetUserSettingsResponse](
 			httpClient,
 			baseURL+EdgeServiceGetUserSettingsProcedure,
@@ -175,30 +417,152 @@ func NewEdgeServiceClient(httpClient connect.HTTPClient, baseURL string, opts ..
 			connect.WithSchema(edgeServiceMethods.ByName("UpdateUserSettings")),
 			connect.WithClientOptions(opts...),
 		),
+		sendTestEmail: connect.NewClient[v1.SendTestEmailRequest, v1.SendTestEmailResponse](
+			httpClient,
+			baseURL+EdgeServiceSendTestEmailProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("SendTestEmail")),
+			connect.WithClientOptions(opts...),
+		),
+		sendTestNotifyWebhook: connect.NewClient[v1.SendTestNotifyWebhookRequest, v1.SendTestNotifyWebhookResponse](
+			httpClient,
+			baseURL+EdgeServiceSendTestNotifyWebhookProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("SendTestNotifyWebhook")),
+			connect.WithClientOptions(opts...),
+		),
+		listApiTokens: connect.NewClient[v1.ListApiTokensRequest, v1.ListApiTokensResponse](
+			httpClient,
+			baseURL+EdgeServiceListApiTokensProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("ListApiTokens")),
+			connect.WithClientOptions(opts...),
+		),
+		createApiToken: connect.NewClient[v1.CreateApiTokenRequest, v1.CreateApiTokenResponse](
+			httpClient,
+			baseURL+EdgeServiceCreateApiTokenProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("CreateApiToken")),
+			connect.WithClientOptions(opts...),
+		),
+		rotateApiToken: connect.NewClient[v1.RotateApiTokenRequest, v1.RotateApiTokenResponse](
+			httpClient,
+			baseURL+EdgeServiceRotateApiTokenProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("RotateApiToken")),
+			connect.WithClientOptions(opts...),
+		),
+		revokeApiToken: connect.NewClient[v1.RevokeApiTokenRequest, v1.RevokeApiTokenResponse](
+			httpClient,
+			baseURL+EdgeServiceRevokeApiTokenProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("RevokeApiToken")),
+			connect.WithClientOptions(opts...),
+		),
+		listOrgs: connect.NewClient[v1.ListOrgsRequest, v1.ListOrgsResponse](
+			httpClient,
+			baseURL+EdgeServiceListOrgsProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("ListOrgs")),
+			connect.WithClientOptions(opts...),
+		),
+		createOrg: connect.NewClient[v1.CreateOrgRequest, v1.CreateOrgResponse](
+			httpClient,
+			baseURL+EdgeServiceCreateOrgProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("CreateOrg")),
+			connect.WithClientOptions(opts...),
+		),
+		deleteOrg: connect.NewClient[v1.DeleteOrgRequest, v1.DeleteOrgResponse](
+			httpClient,
+			baseURL+EdgeServiceDeleteOrgProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("DeleteOrg")),
+			connect.WithClientOptions(opts...),
+		),
+		listOrgMembers: connect.NewClient[v1.ListOrgMembersRequest, v1.ListOrgMembersResponse](
+			httpClient,
+			baseURL+EdgeServiceListOrgMembersProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("ListOrgMembers")),
+			connect.WithClientOptions(opts...),
+		),
+		addOrgMember: connect.NewClient[v1.AddOrgMemberRequest, v1.AddOrgMemberResponse](
+			httpClient,
+			baseURL+EdgeServiceAddOrgMemberProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("AddOrgMember")),
+			connect.WithClientOptions(opts...),
+		),
+		removeOrgMember: connect.NewClient[v1.RemoveOrgMemberRequest, v1.RemoveOrgMemberResponse](
+			httpClient,
+			baseURL+EdgeServiceRemoveOrgMemberProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("RemoveOrgMember")),
+			connect.WithClientOptions(opts...),
+		),
+		listAuditEvents: connect.NewClient[v1.ListAuditEventsRequest, v1.ListAuditEventsResponse](
+			httpClient,
+			baseURL+EdgeServiceListAuditEventsProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("ListAuditEvents")),
+			connect.WithClientOptions(opts...),
+		),
 		getSystemSettings: connect.NewClient[v1.GetSystemSettingsRequest, v1.GetSystemSettingsResponse](
 			httpClient,
 			baseURL+EdgeServiceGetSystemSettingsProcedure,
 			connect.WithSchema(edgeServiceMethods.ByName("GetSystemSettings")),
 			connect.WithClientOptions(opts...),
 		),
+		runMaintenance: connect.NewClient[v1.RunMaintenanceRequest, v1.RunMaintenanceResponse](
+			httpClient,
+			baseURL+EdgeServiceRunMaintenanceProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("RunMaintenance")),
+			connect.WithClientOptions(opts...),
+		),
+		setLogLevel: connect.NewClient[v1.SetLogLevelRequest, v1.SetLogLevelResponse](
+			httpClient,
+			baseURL+EdgeServiceSetLogLevelProcedure,
+			connect.WithSchema(edgeServiceMethods.ByName("SetLogLevel")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // edgeServiceClient implements EdgeServiceClient.
 type edgeServiceClient struct {
-	createEndpoint     *connect.Client[v1.CreateEndpointRequest, v1.CreateEndpointResponse]
-	getEndpoint        *connect.Client[v1.GetEndpointRequest, v1.GetEndpointResponse]
-	listEndpoints      *connect.Client[v1.ListEndpointsRequest, v1.ListEndpointsResponse]
-	updateEndpoint     *connect.Client[v1.UpdateEndpointRequest, v1.UpdateEndpointResponse]
-	deleteEndpoint     *connect.Client[v1.DeleteEndpointRequest, v1.DeleteEndpointResponse]
-	getWebhook         *connect.Client[v1.GetWebhookRequest, v1.GetWebhookResponse]
-	listWebhooks       *connect.Client[v1.ListWebhooksRequest, v1.ListWebhooksResponse]
-	replayWebhook      *connect.Client[v1.ReplayWebhookRequest, v1.ReplayWebhookResponse]
-	getStatus          *connect.Client[v1.GetStatusRequest, v1.GetStatusResponse]
-	getSettings        *connect.Client[v1.GetSettingsRequest, v1.GetSettingsResponse]
-	getUserSettings    *connect.Client[v1.GetUserSettingsRequest, v1.GetUserSettingsResponse]
-	updateUserSettings *connect.Client[v1.UpdateUserSettingsRequest, v1.UpdateUserSettingsResponse]
-	getSystemSettings  *connect.Client[v1.GetSystemSettingsRequest, v1.GetSystemSettingsResponse]
+	createEndpoint         *connect.Client[v1.CreateEndpointRequest, v1.CreateEndpointResponse]
+	getEndpoint            *connect.Client[v1.GetEndpointRequest, v1.GetEndpointResponse]
+	listEndpoints          *connect.Client[v1.ListEndpointsRequest, v1.ListEndpointsResponse]
+	updateEndpoint         *connect.Client[v1.UpdateEndpointRequest, v1.UpdateEndpointResponse]
+	deleteEndpoint         *connect.Client[v1.DeleteEndpointRequest, v1.DeleteEndpointResponse]
+	getSetupInstructions   *connect.Client[v1.GetSetupInstructionsRequest, v1.GetSetupInstructionsResponse]
+	setupTelegramWebhook   *connect.Client[v1.SetupTelegramWebhookRequest, v1.SetupTelegramWebhookResponse]
+	verifyTelegramWebhook  *connect.Client[v1.VerifyTelegramWebhookRequest, v1.VerifyTelegramWebhookResponse]
+	getEndpointStats       *connect.Client[v1.GetEndpointStatsRequest, v1.GetEndpointStatsResponse]
+	listConnectionEvents   *connect.Client[v1.ListConnectionEventsRequest, v1.ListConnectionEventsResponse]
+	generateEndpointSecret *connect.Client[v1.GenerateEndpointSecretRequest, v1.GenerateEndpointSecretResponse]
+	revealEndpointSecret   *connect.Client[v1.RevealEndpointSecretRequest, v1.RevealEndpointSecretResponse]
+	getWebhook             *connect.Client[v1.GetWebhookRequest, v1.GetWebhookResponse]
+	getWebhookPayload      *connect.Client[v1.GetWebhookPayloadRequest, v1.GetWebhookPayloadResponse]
+	listWebhooks           *connect.Client[v1.ListWebhooksRequest, v1.ListWebhooksResponse]
+	replayWebhook          *connect.Client[v1.ReplayWebhookRequest, v1.ReplayWebhookResponse]
+	bulkReplayWebhooks     *connect.Client[v1.BulkReplayWebhooksRequest, v1.BulkReplayWebhooksResponse]
+	cancelPendingReplays   *connect.Client[v1.CancelPendingReplaysRequest, v1.CancelPendingReplaysResponse]
+	undeleteWebhook        *connect.Client[v1.UndeleteWebhookRequest, v1.UndeleteWebhookResponse]
+	tailWebhooks           *connect.Client[v1.TailWebhooksRequest, v1.TailWebhooksResponse]
+	getStatus              *connect.Client[v1.GetStatusRequest, v1.GetStatusResponse]
+	getSettings            *connect.Client[v1.GetSettingsRequest, v1.GetSettingsResponse]
+	getActivityFeed        *connect.Client[v1.GetActivityFeedRequest, v1.GetActivityFeedResponse]
+	getRegions             *connect.Client[v1.GetRegionsRequest, v1.GetRegionsResponse]
+	getVersion             *connect.Client[v1.GetVersionRequest, v1.GetVersionResponse]
+	sendHubCommand         *connect.Client[v1.SendHubCommandRequest, v1.SendHubCommandResponse]
+	getCurrentUser         *connect.Client[v1.GetCurrentUserRequest, v1.GetCurrentUserResponse]
+	getUserSettings        *connect.Client[v1.GetUserSettingsRequest, v1.GetUserSettingsResponse]
+	updateUserSettings     *connect.Client[v1.UpdateUserSettingsRequest, v1.UpdateUserSettingsResponse]
+	sendTestEmail          *connect.Client[v1.SendTestEmailRequest, v1.SendTestEmailResponse]
+	sendTestNotifyWebhook  *connect.Client[v1.SendTestNotifyWebhookRequest, v1.SendTestNotifyWebhookResponse]
+	listApiTokens          *connect.Client[v1.ListApiTokensRequest, v1.ListApiTokensResponse]
+	createApiToken         *connect.Client[v1.CreateApiTokenRequest, v1.CreateApiTokenResponse]
+	rotateApiToken         *connect.Client[v1.RotateApiTokenRequest, v1.RotateApiTokenResponse]
+	revokeApiToken         *connect.Client[v1.RevokeApiTokenRequest, v1.RevokeApiTokenResponse]
+	listOrgs               *connect.Client[v1.ListOrgsRequest, v1.ListOrgsResponse]
+	createOrg              *connect.Client[v1.CreateOrgRequest, v1.CreateOrgResponse]
+	deleteOrg              *connect.Client[v1.DeleteOrgRequest, v1.DeleteOrgResponse]
+	listOrgMembers         *connect.Client[v1.ListOrgMembersRequest, v1.ListOrgMembersResponse]
+	addOrgMember           *connect.Client[v1.AddOrgMemberRequest, v1.AddOrgMemberResponse]
+	removeOrgMember        *connect.Client[v1.RemoveOrgMemberRequest, v1.RemoveOrgMemberResponse]
+	listAuditEvents        *connect.Client[v1.ListAuditEventsRequest, v1.ListAuditEventsResponse]
+	getSystemSettings      *connect.Client[v1.GetSystemSettingsRequest, v1.GetSystemSettingsResponse]
+	runMaintenance         *connect.Client[v1.RunMaintenanceRequest, v1.RunMaintenanceResponse]
+	setLogLevel            *connect.Client[v1.SetLogLevelRequest, v1.SetLogLevelResponse]
 }
 
 // CreateEndpoint calls hookly.v1.EdgeService.CreateEndpoint.
@@ -226,11 +590,51 @@ func (c *edgeServiceClient) DeleteEndpoint(ctx context.Context, req *connect.Req
 	return c.deleteEndpoint.CallUnary(ctx, req)
 }
 
+// GetSetupInstructions calls hookly.v1.EdgeService.GetSetupInstructions.
+func (c *edgeServiceClient) GetSetupInstructions(ctx context.Context, req *connect.Request[v1.GetSetupInstructionsRequest]) (*connect.Response[v1.GetSetupInstructionsResponse], error) {
+	return c.getSetupInstructions.CallUnary(ctx, req)
+}
+
+// SetupTelegramWebhook calls hookly.v1.EdgeService.SetupTelegramWebhook.
+func (c *edgeServiceClient) SetupTelegramWebhook(ctx context.Context, req *connect.Request[v1.SetupTelegramWebhookRequest]) (*connect.Response[v1.SetupTelegramWebhookResponse], error) {
+	return c.setupTelegramWebhook.CallUnary(ctx, req)
+}
+
+// VerifyTelegramWebhook calls hookly.v1.EdgeService.VerifyTelegramWebhook.
+func (c *edgeServiceClient) VerifyTelegramWebhook(ctx context.Context, req *connect.Request[v1.VerifyTelegramWebhookRequest]) (*connect.Response[v1.VerifyTelegramWebhookResponse], error) {
+	return c.verifyTelegramWebhook.CallUnary(ctx, req)
+}
+
+// GetEndpointStats calls hookly.v1.EdgeService.GetEndpointStats.
+func (c *edgeServiceClient) GetEndpointStats(ctx context.Context, req *connect.Request[v1.GetEndpointStatsRequest]) (*connect.Response[v1.GetEndpointStatsResponse], error) {
+	return c.getEndpointStats.CallUnary(ctx, req)
+}
+
+// ListConnectionEvents calls hookly.v1.EdgeService.ListConnectionEvents.
+func (c *edgeServiceClient) ListConnectionEvents(ctx context.Context, req *connect.Request[v1.ListConnectionEventsRequest]) (*connect.Response[v1.ListConnectionEventsResponse], error) {
+	return c.listConnectionEvents.CallUnary(ctx, req)
+}
+
+// GenerateEndpointSecret calls hookly.v1.EdgeService.GenerateEndpointSecret.
+func (c *edgeServiceClient) GenerateEndpointSecret(ctx context.Context, req *connect.Request[v1.GenerateEndpointSecretRequest]) (*connect.Response[v1.GenerateEndpointSecretResponse], error) {
+	return c.generateEndpointSecret.CallUnary(ctx, req)
+}
+
+// RevealEndpointSecret calls hookly.v1.EdgeService.RevealEndpointSecret.
+func (c *edgeServiceClient) RevealEndpointSecret(ctx context.Context, req *connect.Request[v1.RevealEndpointSecretRequest]) (*connect.Response[v1.RevealEndpointSecretResponse], error) {
+	return c.revealEndpointSecret.CallUnary(ctx, req)
+}
+
 // GetWebhook calls hookly.v1.EdgeService.GetWebhook.
 func (c *edgeServiceClient) GetWebhook(ctx context.Context, req *connect.Request[v1.GetWebhookRequest]) (*connect.Response[v1.GetWebhookResponse], error) {
 	return c.getWebhook.CallUnary(ctx, req)
 }
 
+// GetWebhookPayload calls hookly.v1.EdgeService.GetWebhookPayload.
+func (c *edgeServiceClient) GetWebhookPayload(ctx context.Context, req *connect.Request[v1.GetWebhookPayloadRequest]) (*connect.Response[v1.GetWebhookPayloadResponse], error) {
+	return c.getWebhookPayload.CallUnary(ctx, req)
+}
+
 // ListWebhooks calls hookly.v1.EdgeService.ListWebhooks.
 func (c *edgeServiceClient) ListWebhooks(ctx context.Context, req *connect.Request[v1.ListWebhooksRequest]) (*connect.Response[v1.ListWebhooksResponse], error) {
 	return c.listWebhooks.CallUnary(ctx, req)
@@ -241,6 +645,26 @@ func (c *edgeServiceClient) ReplayWebhook(ctx context.Context, req *connect.Requ
 	return c.replayWebhook.CallUnary(ctx, req)
 }
 
+// BulkReplayWebhooks calls hookly.v1.EdgeService.BulkReplayWebhooks.
+func (c *edgeServiceClient) BulkReplayWebhooks(ctx context.Context, req *connect.Request[v1.BulkReplayWebhooksRequest]) (*connect.Response[v1.BulkReplayWebhooksResponse], error) {
+	return c.bulkReplayWebhooks.CallUnary(ctx, req)
+}
+
+// CancelPendingReplays calls hookly.v1.EdgeService.CancelPendingReplays.
+func (c *edgeServiceClient) CancelPendingReplays(ctx context.Context, req *connect.Request[v1.CancelPendingReplaysRequest]) (*connect.Response[v1.CancelPendingReplaysResponse], error) {
+	return c.cancelPendingReplays.CallUnary(ctx, req)
+}
+
+// UndeleteWebhook calls hookly.v1.EdgeService.UndeleteWebhook.
+func (c *edgeServiceClient) UndeleteWebhook(ctx context.Context, req *connect.Request[v1.UndeleteWebhookRequest]) (*connect.Response[v1.UndeleteWebhookResponse], error) {
+	return c.undeleteWebhook.CallUnary(ctx, req)
+}
+
+// TailWebhooks calls hookly.v1.EdgeService.TailWebhooks.
+func (c *edgeServiceClient) TailWebhooks(ctx context.Context, req *connect.Request[v1.TailWebhooksRequest]) (*connect.ServerStreamForClient[v1.TailWebhooksResponse], error) {
+	return c.tailWebhooks.CallServerStream(ctx, req)
+}
+
 // GetStatus calls hookly.v1.EdgeService.GetStatus.
 func (c *edgeServiceClient) GetStatus(ctx context.Context, req *connect.Request[v1.GetStatusRequest]) (*connect.Response[v1.GetStatusResponse], error) {
 	return c.getStatus.CallUnary(ctx, req)
@@ -251,6 +675,31 @@ func (c *edgeServiceClient) GetSettings(ctx context.Context, req *connect.Reques
 	return c.getSettings.CallUnary(ctx, req)
 }
 
+// GetActivityFeed calls hookly.v1.EdgeService.GetActivityFeed.
+func (c *edgeServiceClient) GetActivityFeed(ctx context.Context, req *connect.Request[v1.GetActivityFeedRequest]) (*connect.Response[v1.GetActivityFeedResponse], error) {
+	return c.getActivityFeed.CallUnary(ctx, req)
+}
+
+// GetRegions calls hookly.v1.EdgeService.GetRegions.
+func (c *edgeServiceClient) GetRegions(ctx context.Context, req *connect.Request[v1.GetRegionsRequest]) (*connect.Response[v1.GetRegionsResponse], error) {
+	return c.getRegions.CallUnary(ctx, req)
+}
+
+// GetVersion calls hookly.v1.EdgeService.GetVersion.
+func (c *edgeServiceClient) GetVersion(ctx context.Context, req *connect.Request[v1.GetVersionRequest]) (*connect.Response[v1.GetVersionResponse], error) {
+	return c.getVersion.CallUnary(ctx, req)
+}
+
+// SendHubCommand calls hookly.v1.EdgeService.SendHubCommand.
+func (c *edgeServiceClient) SendHubCommand(ctx context.Context, req *connect.Request[v1.SendHubCommandRequest]) (*connect.Response[v1.SendHubCommandResponse], error) {
+	return c.sendHubCommand.CallUnary(ctx, req)
+}
+
+// GetCurrentUser calls hookly.v1.EdgeService.GetCurrentUser.
+func (c *edgeServiceClient) GetCurrentUser(ctx context.Context, req *connect.Request[v1.GetCurrentUserRequest]) (*connect.Response[v1.GetCurrentUserResponse], error) {
+	return c.getCurrentUser.CallUnary(ctx, req)
+}
+
 // GetUserSettings calls hookly.v1.EdgeService.GetUserSettings.
 func (c *edgeServiceClient) GetUserSettings(ctx context.Context, req *connect.Request[v1.GetUserSettingsRequest]) (*connect.Response[v1.GetUserSettingsResponse], error) {
 	return c.getUserSettings.CallUnary(ctx, req)
@@ -261,11 +710,86 @@ func (c *edgeServiceClient) UpdateUserSettings(ctx context.Context, req *connect
 	return c.updateUserSettings.CallUnary(ctx, req)
 }
 
+// SendTestEmail calls hookly.v1.EdgeService.SendTestEmail.
+func (c *edgeServiceClient) SendTestEmail(ctx context.Context, req *connect.Request[v1.SendTestEmailRequest]) (*connect.Response[v1.SendTestEmailResponse], error) {
+	return c.sendTestEmail.CallUnary(ctx, req)
+}
+
+// SendTestNotifyWebhook calls hookly.v1.EdgeService.SendTestNotifyWebhook.
+func (c *edgeServiceClient) SendTestNotifyWebhook(ctx context.Context, req *connect.Request[v1.SendTestNotifyWebhookRequest]) (*connect.Response[v1.SendTestNotifyWebhookResponse], error) {
+	return c.sendTestNotifyWebhook.CallUnary(ctx, req)
+}
+
+// ListApiTokens calls hookly.v1.EdgeService.ListApiTokens.
+func (c *edgeServiceClient) ListApiTokens(ctx context.Context, req *connect.Request[v1.ListApiTokensRequest]) (*connect.Response[v1.ListApiTokensResponse], error) {
+	return c.listApiTokens.CallUnary(ctx, req)
+}
+
+// CreateApiToken calls hookly.v1.EdgeService.CreateApiToken.
+func (c *edgeServiceClient) CreateApiToken(ctx context.Context, req *connect.Request[v1.CreateApiTokenRequest]) (*connect.Response[v1.CreateApiTokenResponse], error) {
+	return c.createApiToken.CallUnary(ctx, req)
+}
+
+// RotateApiToken calls hookly.v1.EdgeService.RotateApiToken.
+func (c *edgeServiceClient) RotateApiToken(ctx context.Context, req *connect.Request[v1.RotateApiTokenRequest]) (*connect.Response[v1.RotateApiTokenResponse], error) {
+	return c.rotateApiToken.CallUnary(ctx, req)
+}
+
+// RevokeApiToken calls hookly.v1.EdgeService.RevokeApiToken.
+func (c *edgeServiceClient) RevokeApiToken(ctx context.Context, req *connect.Request[v1.RevokeApiTokenRequest]) (*connect.Response[v1.RevokeApiTokenResponse], error) {
+	return c.revokeApiToken.CallUnary(ctx, req)
+}
+
+// ListOrgs calls hookly.v1.EdgeService.ListOrgs.
+func (c *edgeServiceClient) ListOrgs(ctx context.Context, req *connect.Request[v1.ListOrgsRequest]) (*connect.Response[v1.ListOrgsResponse], error) {
+	return c.listOrgs.CallUnary(ctx, req)
+}
+
+// CreateOrg calls hookly.v1.EdgeService.CreateOrg.
+func (c *edgeServiceClient) CreateOrg(ctx context.Context, req *connect.Request[v1.CreateOrgRequest]) (*connect.Response[v1.CreateOrgResponse], error) {
+	return c.createOrg.CallUnary(ctx, req)
+}
+
+// DeleteOrg calls hookly.v1.EdgeService.DeleteOrg.
+func (c *edgeServiceClient) DeleteOrg(ctx context.Context, req *connect.Request[v1.DeleteOrgRequest]) (*connect.Response[v1.DeleteOrgResponse], error) {
+	return c.deleteOrg.CallUnary(ctx, req)
+}
+
+// ListOrgMembers calls hookly.v1.EdgeService.ListOrgMembers.
+func (c *edgeServiceClient) ListOrgMembers(ctx context.Context, req *connect.Request[v1.ListOrgMembersRequest]) (*connect.Response[v1.ListOrgMembersResponse], error) {
+	return c.listOrgMembers.CallUnary(ctx, req)
+}
+
+// AddOrgMember calls hookly.v1.EdgeService.AddOrgMember.
+func (c *edgeServiceClient) AddOrgMember(ctx context.Context, req *connect.Request[v1.AddOrgMemberRequest]) (*connect.Response[v1.AddOrgMemberResponse], error) {
+	return c.addOrgMember.CallUnary(ctx, req)
+}
+
+// RemoveOrgMember calls hookly.v1.EdgeService.RemoveOrgMember.
+func (c *edgeServiceClient) RemoveOrgMember(ctx context.Context, req *connect.Request[v1.RemoveOrgMemberRequest]) (*connect.Response[v1.RemoveOrgMemberResponse], error) {
+	return c.removeOrgMember.CallUnary(ctx, req)
+}
+
+// ListAuditEvents calls hookly.v1.EdgeService.ListAuditEvents.
+func (c *edgeServiceClient) ListAuditEvents(ctx context.Context, req *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error) {
+	return c.listAuditEvents.CallUnary(ctx, req)
+}
+
 // GetSystemSettings calls hookly.v1.EdgeService.GetSystemSettings.
 func (c *edgeServiceClient) GetSystemSettings(ctx context.Context, req *connect.Request[v1.GetSystemSettingsRequest]) (*connect.Response[v1.GetSystemSettingsResponse], error) {
 	return c.getSystemSettings.CallUnary(ctx, req)
 }
 
+// RunMaintenance calls hookly.v1.EdgeService.RunMaintenance.
+func (c *edgeServiceClient) RunMaintenance(ctx context.Context, req *connect.Request[v1.RunMaintenanceRequest]) (*connect.Response[v1.RunMaintenanceResponse], error) {
+	return c.runMaintenance.CallUnary(ctx, req)
+}
+
+// SetLogLevel calls hookly.v1.EdgeService.SetLogLevel.
+func (c *edgeServiceClient) SetLogLevel(ctx context.Context, req *connect.Request[v1.SetLogLevelRequest]) (*connect.Response[v1.SetLogLevelResponse], error) {
+	return c.setLogLevel.CallUnary(ctx, req)
+}
+
 // EdgeServiceHandler is an implementation of the hookly.v1.EdgeService service.
 type EdgeServiceHandler interface {
 	// Endpoint management
@@ -274,18 +798,68 @@ type EdgeServiceHandler interface {
 	ListEndpoints(context.Context, *connect.Request[v1.ListEndpointsRequest]) (*connect.Response[v1.ListEndpointsResponse], error)
 	UpdateEndpoint(context.Context, *connect.Request[v1.UpdateEndpointRequest]) (*connect.Response[v1.UpdateEndpointResponse], error)
 	DeleteEndpoint(context.Context, *connect.Request[v1.DeleteEndpointRequest]) (*connect.Response[v1.DeleteEndpointResponse], error)
+	GetSetupInstructions(context.Context, *connect.Request[v1.GetSetupInstructionsRequest]) (*connect.Response[v1.GetSetupInstructionsResponse], error)
+	SetupTelegramWebhook(context.Context, *connect.Request[v1.SetupTelegramWebhookRequest]) (*connect.Response[v1.SetupTelegramWebhookResponse], error)
+	VerifyTelegramWebhook(context.Context, *connect.Request[v1.VerifyTelegramWebhookRequest]) (*connect.Response[v1.VerifyTelegramWebhookResponse], error)
+	GetEndpointStats(context.Context, *connect.Request[v1.GetEndpointStatsRequest]) (*connect.Response[v1.GetEndpointStatsResponse], error)
+	ListConnectionEvents(context.Context, *connect.Request[v1.ListConnectionEventsRequest]) (*connect.Response[v1.ListConnectionEventsResponse], error)
+	GenerateEndpointSecret(context.Context, *connect.Request[v1.GenerateEndpointSecretRequest]) (*connect.Response[v1.GenerateEndpointSecretResponse], error)
+	// Web session only; every reveal is audit-logged and rate limited
+	RevealEndpointSecret(context.Context, *connect.Request[v1.RevealEndpointSecretRequest]) (*connect.Response[v1.RevealEndpointSecretResponse], error)
 	// Webhook management
 	GetWebhook(context.Context, *connect.Request[v1.GetWebhookRequest]) (*connect.Response[v1.GetWebhookResponse], error)
+	GetWebhookPayload(context.Context, *connect.Request[v1.GetWebhookPayloadRequest]) (*connect.Response[v1.GetWebhookPayloadResponse], error)
 	ListWebhooks(context.Context, *connect.Request[v1.ListWebhooksRequest]) (*connect.Response[v1.ListWebhooksResponse], error)
 	ReplayWebhook(context.Context, *connect.Request[v1.ReplayWebhookRequest]) (*connect.Response[v1.ReplayWebhookResponse], error)
+	BulkReplayWebhooks(context.Context, *connect.Request[v1.BulkReplayWebhooksRequest]) (*connect.Response[v1.BulkReplayWebhooksResponse], error)
+	CancelPendingReplays(context.Context, *connect.Request[v1.CancelPendingReplaysRequest]) (*connect.Response[v1.CancelPendingReplaysResponse], error)
+	// Restores a webhook purged by retention cleanup, within the grace period
+	UndeleteWebhook(context.Context, *connect.Request[v1.UndeleteWebhookRequest]) (*connect.Response[v1.UndeleteWebhookResponse], error)
+	// Streams webhooks as they are received and change status
+	TailWebhooks(context.Context, *connect.Request[v1.TailWebhooksRequest], *connect.ServerStream[v1.TailWebhooksResponse]) error
 	// System status
 	GetStatus(context.Context, *connect.Request[v1.GetStatusRequest]) (*connect.Response[v1.GetStatusResponse], error)
 	GetSettings(context.Context, *connect.Request[v1.GetSettingsRequest]) (*connect.Response[v1.GetSettingsResponse], error)
+	GetActivityFeed(context.Context, *connect.Request[v1.GetActivityFeedRequest]) (*connect.Response[v1.GetActivityFeedResponse], error)
+	GetRegions(context.Context, *connect.Request[v1.GetRegionsRequest]) (*connect.Response[v1.GetRegionsResponse], error)
+	// Build info of the edge; needs no auth
+	GetVersion(context.Context, *connect.Request[v1.GetVersionRequest]) (*connect.Response[v1.GetVersionResponse], error)
+	// Hub management: runs a command on one of the user's connected hubs
+	SendHubCommand(context.Context, *connect.Request[v1.SendHubCommandRequest]) (*connect.Response[v1.SendHubCommandResponse], error)
 	// User settings
+	GetCurrentUser(context.Context, *connect.Request[v1.GetCurrentUserRequest]) (*connect.Response[v1.GetCurrentUserResponse], error)
 	GetUserSettings(context.Context, *connect.Request[v1.GetUserSettingsRequest]) (*connect.Response[v1.GetUserSettingsResponse], error)
 	UpdateUserSettings(context.Context, *connect.Request[v1.UpdateUserSettingsRequest]) (*connect.Response[v1.UpdateUserSettingsResponse], error)
+	// Sends a test email to the user's notification address
+	SendTestEmail(context.Context, *connect.Request[v1.SendTestEmailRequest]) (*connect.Response[v1.SendTestEmailResponse], error)
+	// Sends a test event to the user's notification webhook
+	SendTestNotifyWebhook(context.Context, *connect.Request[v1.SendTestNotifyWebhookRequest]) (*connect.Response[v1.SendTestNotifyWebhookResponse], error)
+	// API tokens; only admin tokens and web sessions can create, rotate or
+	// revoke them
+	ListApiTokens(context.Context, *connect.Request[v1.ListApiTokensRequest]) (*connect.Response[v1.ListApiTokensResponse], error)
+	CreateApiToken(context.Context, *connect.Request[v1.CreateApiTokenRequest]) (*connect.Response[v1.CreateApiTokenResponse], error)
+	// Replaces a token with a new one of the same name, scope and lifetime
+	RotateApiToken(context.Context, *connect.Request[v1.RotateApiTokenRequest]) (*connect.Response[v1.RotateApiTokenResponse], error)
+	RevokeApiToken(context.Context, *connect.Request[v1.RevokeApiTokenRequest]) (*connect.Response[v1.RevokeApiTokenResponse], error)
+	// Organizations, which share endpoints among their members. Endpoint
+	// calls act for an org when the Hookly-Org header names it.
+	ListOrgs(context.Context, *connect.Request[v1.ListOrgsRequest]) (*connect.Response[v1.ListOrgsResponse], error)
+	// Creates an org with the caller as its owner
+	CreateOrg(context.Context, *connect.Request[v1.CreateOrgRequest]) (*connect.Response[v1.CreateOrgResponse], error)
+	// Deletes an org with its endpoints and webhooks (owners only)
+	DeleteOrg(context.Context, *connect.Request[v1.DeleteOrgRequest]) (*connect.Response[v1.DeleteOrgResponse], error)
+	ListOrgMembers(context.Context, *connect.Request[v1.ListOrgMembersRequest]) (*connect.Response[v1.ListOrgMembersResponse], error)
+	// Adds a member, or changes a member's role (owners only)
+	AddOrgMember(context.Context, *connect.Request[v1.AddOrgMemberRequest]) (*connect.Response[v1.AddOrgMemberResponse], error)
+	// Removes a member; owners remove anyone, others only themselves
+	RemoveOrgMember(context.Context, *connect.Request[v1.RemoveOrgMemberRequest]) (*connect.Response[v1.RemoveOrgMemberResponse], error)
+	// Audit log of management actions: the caller's, and everyone's on the
+	// endpoints the call acts for
+	ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error)
 	// System settings (superuser only)
 	GetSystemSettings(context.Context, *connect.Request[v1.GetSystemSettingsRequest]) (*connect.Response[v1.GetSystemSettingsResponse], error)
+	RunMaintenance(context.Context, *connect.Request[v1.RunMaintenanceRequest]) (*connect.Response[v1.RunMaintenanceResponse], error)
+	SetLogLevel(context.Context, *connect.Request[v1.SetLogLevelRequest]) (*connect.Response[v1.SetLogLevelResponse], error)
 }
 
 // NewEdgeServiceHandler builds an HTTP handler from the service implementation. It returns the path
@@ -325,12 +899,60 @@ func NewEdgeServiceHandler(svc EdgeServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(edgeServiceMethods.ByName("DeleteEndpoint")),
 		connect.WithHandlerOptions(opts...),
 	)
+	edgeServiceGetSetupInstructionsHandler := connect.NewUnaryHandler(
+		EdgeServiceGetSetupInstructionsProcedure,
+		svc.GetSetupInstructions,
+		connect.WithSchema(edgeServiceMethods.ByName("GetSetupInstructions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceSetupTelegramWebhookHandler := connect.NewUnaryHandler(
+		EdgeServiceSetupTelegramWebhookProcedure,
+		svc.SetupTelegramWebhook,
+		connect.WithSchema(edgeServiceMethods.ByName("SetupTelegramWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceVerifyTelegramWebhookHandler := connect.NewUnaryHandler(
+		EdgeServiceVerifyTelegramWebhookProcedure,
+		svc.VerifyTelegramWebhook,
+		connect.WithSchema(edgeServiceMethods.ByName("VerifyTelegramWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceGetEndpointStatsHandler := connect.NewUnaryHandler(
+		EdgeServiceGetEndpointStatsProcedure,
+		svc.GetEndpointStats,
+		connect.WithSchema(edgeServiceMethods.ByName("GetEndpointStats")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceListConnectionEventsHandler := connect.NewUnaryHandler(
+		EdgeServiceListConnectionEventsProcedure,
+		svc.ListConnectionEvents,
+		connect.WithSchema(edgeServiceMethods.ByName("ListConnectionEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceGenerateEndpointSecretHandler := connect.NewUnaryHandler(
+		EdgeServiceGenerateEndpointSecretProcedure,
+		svc.GenerateEndpointSecret,
+		connect.WithSchema(edgeServiceMethods.ByName("GenerateEndpointSecret")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceRevealEndpointSecretHandler := connect.NewUnaryHandler(
+		EdgeServiceRevealEndpointSecretProcedure,
+		svc.RevealEndpointSecret,
+		connect.WithSchema(edgeServiceMethods.ByName("RevealEndpointSecret")),
+		connect.WithHandlerOptions(opts...),
+	)
 	edgeServiceGetWebhookHandler := connect.NewUnaryHandler(
 		EdgeServiceGetWebhookProcedure,
 		svc.GetWebhook,
 		connect.WithSchema(edgeServiceMethods.ByName("GetWebhook")),
 		connect.WithHandlerOptions(opts...),
 	)
+	edgeServiceGetWebhookPayloadHandler := connect.NewUnaryHandler(
+		EdgeServiceGetWebhookPayloadProcedure,
+		svc.GetWebhookPayload,
+		connect.WithSchema(edgeServiceMethods.ByName("GetWebhookPayload")),
+		connect.WithHandlerOptions(opts...),
+	)
 	edgeServiceListWebhooksHandler := connect.NewUnaryHandler(
 		EdgeServiceListWebhooksProcedure,
 		svc.ListWebhooks,
@@ -343,6 +965,30 @@ func NewEdgeServiceHandler(svc EdgeServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(edgeServiceMethods.ByName("ReplayWebhook")),
 		connect.WithHandlerOptions(opts...),
 	)
+	edgeServiceBulkReplayWebhooksHandler := connect.NewUnaryHandler(
+		EdgeServiceBulkReplayWebhooksProcedure,
+		svc.BulkReplayWebhooks,
+		connect.WithSchema(edgeServiceMethods.ByName("BulkReplayWebhooks")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceCancelPendingReplaysHandler := connect.NewUnaryHandler(
+		EdgeServiceCancelPendingReplaysProcedure,
+		svc.CancelPendingReplays,
+		connect.WithSchema(edgeServiceMethods.ByName("CancelPendingReplays")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceUndeleteWebhookHandler := connect.NewUnaryHandler(
+		EdgeServiceUndeleteWebhookProcedure,
+		svc.UndeleteWebhook,
+		connect.WithSchema(edgeServiceMethods.ByName("UndeleteWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceTailWebhooksHandler := connect.NewServerStreamHandler(
+		EdgeServiceTailWebhooksProcedure,
+		svc.TailWebhooks,
+		connect.WithSchema(edgeServiceMethods.ByName("TailWebhooks")),
+		connect.WithHandlerOptions(opts...),
+	)
 	edgeServiceGetStatusHandler := connect.NewUnaryHandler(
 		EdgeServiceGetStatusProcedure,
 		svc.GetStatus,
@@ -355,6 +1001,36 @@ func NewEdgeServiceHandler(svc EdgeServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(edgeServiceMethods.ByName("GetSettings")),
 		connect.WithHandlerOptions(opts...),
 	)
+	edgeServiceGetActivityFeedHandler := connect.NewUnaryHandler(
+		EdgeServiceGetActivityFeedProcedure,
+		svc.GetActivityFeed,
+		connect.WithSchema(edgeServiceMethods.ByName("GetActivityFeed")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceGetRegionsHandler := connect.NewUnaryHandler(
+		EdgeServiceGetRegionsProcedure,
+		svc.GetRegions,
+		connect.WithSchema(edgeServiceMethods.ByName("GetRegions")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceGetVersionHandler := connect.NewUnaryHandler(
+		EdgeServiceGetVersionProcedure,
+		svc.GetVersion,
+		connect.WithSchema(edgeServiceMethods.ByName("GetVersion")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceSendHubCommandHandler := connect.NewUnaryHandler(
+		EdgeServiceSendHubCommandProcedure,
+		svc.SendHubCommand,
+		connect.WithSchema(edgeServiceMethods.ByName("SendHubCommand")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceGetCurrentUserHandler := connect.NewUnaryHandler(
+		EdgeServiceGetCurrentUserProcedure,
+		svc.GetCurrentUser,
+		connect.WithSchema(edgeServiceMethods.ByName("GetCurrentUser")),
+		connect.WithHandlerOptions(opts...),
+	)
 	edgeServiceGetUserSettingsHandler := connect.NewUnaryHandler(
 		EdgeServiceGetUserSettingsProcedure,
 		svc.GetUserSettings,
@@ -367,12 +1043,102 @@ func NewEdgeServiceHandler(svc EdgeServiceHandler, opts ...connect.HandlerOption
 		connect.WithSchema(edgeServiceMethods.ByName("UpdateUserSettings")),
 		connect.WithHandlerOptions(opts...),
 	)
+	edgeServiceSendTestEmailHandler := connect.NewUnaryHandler(
+		EdgeServiceSendTestEmailProcedure,
+		svc.SendTestEmail,
+		connect.WithSchema(edgeServiceMethods.ByName("SendTestEmail")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceSendTestNotifyWebhookHandler := connect.NewUnaryHandler(
+		EdgeServiceSendTestNotifyWebhookProcedure,
+		svc.SendTestNotifyWebhook,
+		connect.WithSchema(edgeServiceMethods.ByName("SendTestNotifyWebhook")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceListApiTokensHandler := connect.NewUnaryHandler(
+		EdgeServiceListApiTokensProcedure,
+		svc.ListApiTokens,
+		connect.WithSchema(edgeServiceMethods.ByName("ListApiTokens")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceCreateApiTokenHandler := connect.NewUnaryHandler(
+		EdgeServiceCreateApiTokenProcedure,
+		svc.CreateApiToken,
+		connect.WithSchema(edgeServiceMethods.ByName("CreateApiToken")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceRotateApiTokenHandler := connect.NewUnaryHandler(
+		EdgeServiceRotateApiTokenProcedure,
+		svc.RotateApiToken,
+		connect.WithSchema(edgeServiceMethods.ByName("RotateApiToken")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceRevokeApiTokenHandler := connect.NewUnaryHandler(
+		EdgeServiceRevokeApiTokenProcedure,
+		svc.RevokeApiToken,
+		connect.WithSchema(edgeServiceMethods.ByName("RevokeApiToken")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceListOrgsHandler := connect.NewUnaryHandler(
+		EdgeServiceListOrgsProcedure,
+		svc.ListOrgs,
+		connect.WithSchema(edgeServiceMethods.ByName("ListOrgs")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceCreateOrgHandler := connect.NewUnaryHandler(
+		EdgeServiceCreateOrgProcedure,
+		svc.CreateOrg,
+		connect.WithSchema(edgeServiceMethods.ByName("CreateOrg")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceDeleteOrgHandler := connect.NewUnaryHandler(
+		EdgeServiceDeleteOrgProcedure,
+		svc.DeleteOrg,
+		connect.WithSchema(edgeServiceMethods.ByName("DeleteOrg")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceListOrgMembersHandler := connect.NewUnaryHandler(
+		EdgeServiceListOrgMembersProcedure,
+		svc.ListOrgMembers,
+		connect.WithSchema(edgeServiceMethods.ByName("ListOrgMembers")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceAddOrgMemberHandler := connect.NewUnaryHandler(
+		EdgeServiceAddOrgMemberProcedure,
+		svc.AddOrgMember,
+		connect.WithSchema(edgeServiceMethods.ByName("AddOrgMember")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceRemoveOrgMemberHandler := connect.NewUnaryHandler(
+		EdgeServiceRemoveOrgMemberProcedure,
+		svc.RemoveOrgMember,
+		connect.WithSchema(edgeServiceMethods.ByName("RemoveOrgMember")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceListAuditEventsHandler := connect.NewUnaryHandler(
+		EdgeServiceListAuditEventsProcedure,
+		svc.ListAuditEvents,
+		connect.WithSchema(edgeServiceMethods.ByName("ListAuditEvents")),
+		connect.WithHandlerOptions(opts...),
+	)
 	edgeServiceGetSystemSettingsHandler := connect.NewUnaryHandler(
 		EdgeServiceGetSystemSettingsProcedure,
 		svc.GetSystemSettings,
 		connect.WithSchema(edgeServiceMethods.ByName("GetSystemSettings")),
 		connect.WithHandlerOptions(opts...),
 	)
+	edgeServiceRunMaintenanceHandler := connect.NewUnaryHandler(
+		EdgeServiceRunMaintenanceProcedure,
+		svc.RunMaintenance,
+		connect.WithSchema(edgeServiceMethods.ByName("RunMaintenance")),
+		connect.WithHandlerOptions(opts...),
+	)
+	edgeServiceSetLogLevelHandler := connect.NewUnaryHandler(
+		EdgeServiceSetLogLevelProcedure,
+		svc.SetLogLevel,
+		connect.WithSchema(edgeServiceMethods.ByName("SetLogLevel")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/hookly.v1.EdgeService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case EdgeServiceCreateEndpointProcedure:
@@ -385,22 +1151,86 @@ func NewEdgeServiceHandler(svc EdgeServiceHandler, opts ...connect.HandlerOption
 			edgeServiceUpdateEndpointHandler.ServeHTTP(w, r)
 		case EdgeServiceDeleteEndpointProcedure:
 			edgeServiceDeleteEndpointHandler.ServeHTTP(w, r)
+		case EdgeServiceGetSetupInstructionsProcedure:
+			edgeServiceGetSetupInstructionsHandler.ServeHTTP(w, r)
+		case EdgeServiceSetupTelegramWebhookProcedure:
+			edgeServiceSetupTelegramWebhookHandler.ServeHTTP(w, r)
+		case EdgeServiceVerifyTelegramWebhookProcedure:
+			edgeServiceVerifyTelegramWebhookHandler.ServeHTTP(w, r)
+		case EdgeServiceGetEndpointStatsProcedure:
+			edgeServiceGetEndpointStatsHandler.ServeHTTP(w, r)
+		case EdgeServiceListConnectionEventsProcedure:
+			edgeServiceListConnectionEventsHandler.ServeHTTP(w, r)
+		case EdgeServiceGenerateEndpointSecretProcedure:
+			edgeServiceGenerateEndpointSecretHandler.ServeHTTP(w, r)
+		case EdgeServiceRevealEndpointSecretProcedure:
+			edgeServiceRevealEndpointSecretHandler.ServeHTTP(w, r)
 		case EdgeServiceGetWebhookProcedure:
 			edgeServiceGetWebhookHandler.ServeHTTP(w, r)
+		case EdgeServiceGetWebhookPayloadProcedure:
+			edgeServiceGetWebhookPayloadHandler.ServeHTTP(w, r)
 		case EdgeServiceListWebhooksProcedure:
 			edgeServiceListWebhooksHandler.ServeHTTP(w, r)
 		case EdgeServiceReplayWebhookProcedure:
 			edgeServiceReplayWebhookHandler.ServeHTTP(w, r)
+		case EdgeServiceBulkReplayWebhooksProcedure:
+			edgeServiceBulkReplayWebhooksHandler.ServeHTTP(w, r)
+		case EdgeServiceCancelPendingReplaysProcedure:
+			edgeServiceCancelPendingReplaysHandler.ServeHTTP(w, r)
+		case EdgeServiceUndeleteWebhookProcedure:
+			edgeServiceUndeleteWebhookHandler.ServeHTTP(w, r)
+		case EdgeServiceTailWebhooksProcedure:
+			edgeServiceTailWebhooksHandler.ServeHTTP(w, r)
 		case EdgeServiceGetStatusProcedure:
 			edgeServiceGetStatusHandler.ServeHTTP(w, r)
 		case EdgeServiceGetSettingsProcedure:
 			edgeServiceGetSettingsHandler.ServeHTTP(w, r)
+		case EdgeServiceGetActivityFeedProcedure:
+			edgeServiceGetActivityFeedHandler.ServeHTTP(w, r)
+		case EdgeServiceGetRegionsProcedure:
+			edgeServiceGetRegionsHandler.ServeHTTP(w, r)
+		case EdgeServiceGetVersionProcedure:
+			edgeServiceGetVersionHandler.ServeHTTP(w, r)
+		case EdgeServiceSendHubCommandProcedure:
+			edgeServiceSendHubCommandHandler.ServeHTTP(w, r)
+		case EdgeServiceGetCurrentUserProcedure:
+			edgeServiceGetCurrentUserHandler.ServeHTTP(w, r)
 		case EdgeServiceGetUserSettingsProcedure:
 			edgeServiceGetUserSettingsHandler.ServeHTTP(w, r)
 		case EdgeServiceUpdateUserSettingsProcedure:
 			edgeServiceUpdateUserSettingsHandler.ServeHTTP(w, r)
+		case EdgeServiceSendTestEmailProcedure:
+			edgeServiceSendTestEmailHandler.ServeHTTP(w, r)
+		case EdgeServiceSendTestNotifyWebhookProcedure:
+			edgeServiceSendTestNotifyWebhookHandler.ServeHTTP(w, r)
+		case EdgeServiceListApiTokensProcedure:
+			edgeServiceListApiTokensHandler.ServeHTTP(w, r)
+		case EdgeServiceCreateApiTokenProcedure:
+			edgeServiceCreateApiTokenHandler.ServeHTTP(w, r)
+		case EdgeServiceRotateApiTokenProcedure:
+			edgeServiceRotateApiTokenHandler.ServeHTTP(w, r)
+		case EdgeServiceRevokeApiTokenProcedure:
+			edgeServiceRevokeApiTokenHandler.ServeHTTP(w, r)
+		case EdgeServiceListOrgsProcedure:
+			edgeServiceListOrgsHandler.ServeHTTP(w, r)
+		case EdgeServiceCreateOrgProcedure:
+			edgeServiceCreateOrgHandler.ServeHTTP(w, r)
+		case EdgeServiceDeleteOrgProcedure:
+			edgeServiceDeleteOrgHandler.ServeHTTP(w, r)
+		case EdgeServiceListOrgMembersProcedure:
+			edgeServiceListOrgMembersHandler.ServeHTTP(w, r)
+		case EdgeServiceAddOrgMemberProcedure:
+			edgeServiceAddOrgMemberHandler.ServeHTTP(w, r)
+		case EdgeServiceRemoveOrgMemberProcedure:
+			edgeServiceRemoveOrgMemberHandler.ServeHTTP(w, r)
+		case EdgeServiceListAuditEventsProcedure:
+			edgeServiceListAuditEventsHandler.ServeHTTP(w, r)
 		case EdgeServiceGetSystemSettingsProcedure:
 			edgeServiceGetSystemSettingsHandler.ServeHTTP(w, r)
+		case EdgeServiceRunMaintenanceProcedure:
+			edgeServiceRunMaintenanceHandler.ServeHTTP(w, r)
+		case EdgeServiceSetLogLevelProcedure:
+			edgeServiceSetLogLevelHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -430,10 +1260,42 @@ func (UnimplementedEdgeServiceHandler) DeleteEndpoint(context.Context, *connect.
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.DeleteEndpoint is not implemented"))
 }
 
+func (UnimplementedEdgeServiceHandler) GetSetupInstructions(context.Context, *connect.Request[v1.GetSetupInstructionsRequest]) (*connect.Response[v1.GetSetupInstructionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.GetSetupInstructions is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) SetupTelegramWebhook(context.Context, *connect.Request[v1.SetupTelegramWebhookRequest]) (*connect.Response[v1.SetupTelegramWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.SetupTelegramWebhook is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) VerifyTelegramWebhook(context.Context, *connect.Request[v1.VerifyTelegramWebhookRequest]) (*connect.Response[v1.VerifyTelegramWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.VerifyTelegramWebhook is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) GetEndpointStats(context.Context, *connect.Request[v1.GetEndpointStatsRequest]) (*connect.Response[v1.GetEndpointStatsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.GetEndpointStats is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) ListConnectionEvents(context.Context, *connect.Request[v1.ListConnectionEventsRequest]) (*connect.Response[v1.ListConnectionEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.ListConnectionEvents is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) GenerateEndpointSecret(context.Context, *connect.Request[v1.GenerateEndpointSecretRequest]) (*connect.Response[v1.GenerateEndpointSecretResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.GenerateEndpointSecret is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) RevealEndpointSecret(context.Context, *connect.Request[v1.RevealEndpointSecretRequest]) (*connect.Response[v1.RevealEndpointSecretResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.RevealEndpointSecret is not implemented"))
+}
+
 func (UnimplementedEdgeServiceHandler) GetWebhook(context.Context, *connect.Request[v1.GetWebhookRequest]) (*connect.Response[v1.GetWebhookResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.GetWebhook is not implemented"))
 }
 
+func (UnimplementedEdgeServiceHandler) GetWebhookPayload(context.Context, *connect.Request[v1.GetWebhookPayloadRequest]) (*connect.Response[v1.GetWebhookPayloadResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.GetWebhookPayload is not implemented"))
+}
+
 func (UnimplementedEdgeServiceHandler) ListWebhooks(context.Context, *connect.Request[v1.ListWebhooksRequest]) (*connect.Response[v1.ListWebhooksResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.ListWebhooks is not implemented"))
 }
@@ -442,6 +1304,22 @@ func (UnimplementedEdgeServiceHandler) ReplayWebhook(context.Context, *connect.R
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.ReplayWebhook is not implemented"))
 }
 
+func (UnimplementedEdgeServiceHandler) BulkReplayWebhooks(context.Context, *connect.Request[v1.BulkReplayWebhooksRequest]) (*connect.Response[v1.BulkReplayWebhooksResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.BulkReplayWebhooks is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) CancelPendingReplays(context.Context, *connect.Request[v1.CancelPendingReplaysRequest]) (*connect.Response[v1.CancelPendingReplaysResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.CancelPendingReplays is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) UndeleteWebhook(context.Context, *connect.Request[v1.UndeleteWebhookRequest]) (*connect.Response[v1.UndeleteWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.UndeleteWebhook is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) TailWebhooks(context.Context, *connect.Request[v1.TailWebhooksRequest], *connect.ServerStream[v1.TailWebhooksResponse]) error {
+	return connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.TailWebhooks is not implemented"))
+}
+
 func (UnimplementedEdgeServiceHandler) GetStatus(context.Context, *connect.Request[v1.GetStatusRequest]) (*connect.Response[v1.GetStatusResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.GetStatus is not implemented"))
 }
@@ -450,6 +1328,26 @@ func (UnimplementedEdgeServiceHandler) GetSettings(context.Context, *connect.Req
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.GetSettings is not implemented"))
 }
 
+func (UnimplementedEdgeServiceHandler) GetActivityFeed(context.Context, *connect.Request[v1.GetActivityFeedRequest]) (*connect.Response[v1.GetActivityFeedResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.GetActivityFeed is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) GetRegions(context.Context, *connect.Request[v1.GetRegionsRequest]) (*connect.Response[v1.GetRegionsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.GetRegions is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) GetVersion(context.Context, *connect.Request[v1.GetVersionRequest]) (*connect.Response[v1.GetVersionResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.GetVersion is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) SendHubCommand(context.Context, *connect.Request[v1.SendHubCommandRequest]) (*connect.Response[v1.SendHubCommandResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.SendHubCommand is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) GetCurrentUser(context.Context, *connect.Request[v1.GetCurrentUserRequest]) (*connect.Response[v1.GetCurrentUserResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.GetCurrentUser is not implemented"))
+}
+
 func (UnimplementedEdgeServiceHandler) GetUserSettings(context.Context, *connect.Request[v1.GetUserSettingsRequest]) (*connect.Response[v1.GetUserSettingsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.GetUserSettings is not implemented"))
 }
@@ -458,6 +1356,66 @@ func (UnimplementedEdgeServiceHandler) UpdateUserSettings(context.Context, *conn
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.UpdateUserSettings is not implemented"))
 }
 
+func (UnimplementedEdgeServiceHandler) SendTestEmail(context.Context, *connect.Request[v1.SendTestEmailRequest]) (*connect.Response[v1.SendTestEmailResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.SendTestEmail is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) SendTestNotifyWebhook(context.Context, *connect.Request[v1.SendTestNotifyWebhookRequest]) (*connect.Response[v1.SendTestNotifyWebhookResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.SendTestNotifyWebhook is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) ListApiTokens(context.Context, *connect.Request[v1.ListApiTokensRequest]) (*connect.Response[v1.ListApiTokensResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.ListApiTokens is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) CreateApiToken(context.Context, *connect.Request[v1.CreateApiTokenRequest]) (*connect.Response[v1.CreateApiTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.CreateApiToken is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) RotateApiToken(context.Context, *connect.Request[v1.RotateApiTokenRequest]) (*connect.Response[v1.RotateApiTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.RotateApiToken is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) RevokeApiToken(context.Context, *connect.Request[v1.RevokeApiTokenRequest]) (*connect.Response[v1.RevokeApiTokenResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.RevokeApiToken is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) ListOrgs(context.Context, *connect.Request[v1.ListOrgsRequest]) (*connect.Response[v1.ListOrgsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.ListOrgs is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) CreateOrg(context.Context, *connect.Request[v1.CreateOrgRequest]) (*connect.Response[v1.CreateOrgResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.CreateOrg is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) DeleteOrg(context.Context, *connect.Request[v1.DeleteOrgRequest]) (*connect.Response[v1.DeleteOrgResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.DeleteOrg is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) ListOrgMembers(context.Context, *connect.Request[v1.ListOrgMembersRequest]) (*connect.Response[v1.ListOrgMembersResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.ListOrgMembers is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) AddOrgMember(context.Context, *connect.Request[v1.AddOrgMemberRequest]) (*connect.Response[v1.AddOrgMemberResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.AddOrgMember is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) RemoveOrgMember(context.Context, *connect.Request[v1.RemoveOrgMemberRequest]) (*connect.Response[v1.RemoveOrgMemberResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.RemoveOrgMember is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) ListAuditEvents(context.Context, *connect.Request[v1.ListAuditEventsRequest]) (*connect.Response[v1.ListAuditEventsResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.ListAuditEvents is not implemented"))
+}
+
 func (UnimplementedEdgeServiceHandler) GetSystemSettings(context.Context, *connect.Request[v1.GetSystemSettingsRequest]) (*connect.Response[v1.GetSystemSettingsResponse], error) {
 	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.GetSystemSettings is not implemented"))
 }
+
+func (UnimplementedEdgeServiceHandler) RunMaintenance(context.Context, *connect.Request[v1.RunMaintenanceRequest]) (*connect.Response[v1.RunMaintenanceResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.RunMaintenance is not implemented"))
+}
+
+func (UnimplementedEdgeServiceHandler) SetLogLevel(context.Context, *connect.Request[v1.SetLogLevelRequest]) (*connect.Response[v1.SetLogLevelResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.EdgeService.SetLogLevel is not implemented"))
+}
@@ -23,6 +23,8 @@ const _ = connect.IsAtLeastVersion1_13_0
 const (
 	// RelayServiceName is the fully-qualified name of the RelayService service.
 	RelayServiceName = "hookly.v1.RelayService"
+	// TunnelServiceName is the fully-qualified name of the TunnelService service.
+	TunnelServiceName = "hookly.v1.TunnelService"
 )
 
 // These constants are the fully-qualified names of the RPCs defined in this package. They're
@@ -35,6 +37,11 @@ const (
 const (
 	// RelayServiceStreamProcedure is the fully-qualified name of the RelayService's Stream RPC.
 	RelayServiceStreamProcedure = "/hookly.v1.RelayService/Stream"
+	// RelayServiceRegisterTunnelProcedure is the fully-qualified name of the RelayService's
+	// RegisterTunnel RPC.
+	RelayServiceRegisterTunnelProcedure = "/hookly.v1.RelayService/RegisterTunnel"
+	// TunnelServiceDeliverProcedure is the fully-qualified name of the TunnelService's Deliver RPC.
+	TunnelServiceDeliverProcedure = "/hookly.v1.TunnelService/Deliver"
 )
 
 // RelayServiceClient is a client for the hookly.v1.RelayService service.
@@ -43,6 +50,10 @@ type RelayServiceClient interface {
 	// Home-hub sends auth message, then receives webhooks.
 	// Home-hub sends delivery acks to report delivery status.
 	Stream(context.Context) *connect.BidiStreamForClient[v1.StreamRequest, v1.StreamResponse]
+	// RegisterTunnel leases delivery over a WireGuard or SSH reverse tunnel
+	// while the hub's stream is down. The hub renews the lease by calling it
+	// again; a connected stream always takes precedence.
+	RegisterTunnel(context.Context, *connect.Request[v1.RegisterTunnelRequest]) (*connect.Response[v1.RegisterTunnelResponse], error)
 }
 
 // NewRelayServiceClient constructs a client for the hookly.v1.RelayService service. By default, it
@@ -62,12 +73,19 @@ func NewRelayServiceClient(httpClient connect.HTTPClient, baseURL string, opts .
 			connect.WithSchema(relayServiceMethods.ByName("Stream")),
 			connect.WithClientOptions(opts...),
 		),
+		registerTunnel: connect.NewClient[v1.RegisterTunnelRequest, v1.RegisterTunnelResponse](
+			httpClient,
+			baseURL+RelayServiceRegisterTunnelProcedure,
+			connect.WithSchema(relayServiceMethods.ByName("RegisterTunnel")),
+			connect.WithClientOptions(opts...),
+		),
 	}
 }
 
 // relayServiceClient implements RelayServiceClient.
 type relayServiceClient struct {
-	stream *connect.Client[v1.StreamRequest, v1.StreamResponse]
+	stream         *connect.Client[v1.StreamRequest, v1.StreamResponse]
+	registerTunnel *connect.Client[v1.RegisterTunnelRequest, v1.RegisterTunnelResponse]
 }
 
 // Stream calls hookly.v1.RelayService.Stream.
@@ -75,12 +93,21 @@ func (c *relayServiceClient) Stream(ctx context.Context) *connect.BidiStreamForC
 	return c.stream.CallBidiStream(ctx)
 }
 
+// RegisterTunnel calls hookly.v1.RelayService.RegisterTunnel.
+func (c *relayServiceClient) RegisterTunnel(ctx context.Context, req *connect.Request[v1.RegisterTunnelRequest]) (*connect.Response[v1.RegisterTunnelResponse], error) {
+	return c.registerTunnel.CallUnary(ctx, req)
+}
+
 // RelayServiceHandler is an implementation of the hookly.v1.RelayService service.
 type RelayServiceHandler interface {
 	// Stream establishes a bidirectional stream.
 	// Home-hub sends auth message, then receives webhooks.
 	// Home-hub sends delivery acks to report delivery status.
 	Stream(context.Context, *connect.BidiStream[v1.StreamRequest, v1.StreamResponse]) error
+	// RegisterTunnel leases delivery over a WireGuard or SSH reverse tunnel
+	// while the hub's stream is down. The hub renews the lease by calling it
+	// again; a connected stream always takes precedence.
+	RegisterTunnel(context.Context, *connect.Request[v1.RegisterTunnelRequest]) (*connect.Response[v1.RegisterTunnelResponse], error)
 }
 
 // NewRelayServiceHandler builds an HTTP handler from the service implementation. It returns the
@@ -96,10 +123,18 @@ func NewRelayServiceHandler(svc RelayServiceHandler, opts ...connect.HandlerOpti
 		connect.WithSchema(relayServiceMethods.ByName("Stream")),
 		connect.WithHandlerOptions(opts...),
 	)
+	relayServiceRegisterTunnelHandler := connect.NewUnaryHandler(
+		RelayServiceRegisterTunnelProcedure,
+		svc.RegisterTunnel,
+		connect.WithSchema(relayServiceMethods.ByName("RegisterTunnel")),
+		connect.WithHandlerOptions(opts...),
+	)
 	return "/hookly.v1.RelayService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
 		case RelayServiceStreamProcedure:
 			relayServiceStreamHandler.ServeHTTP(w, r)
+		case RelayServiceRegisterTunnelProcedure:
+			relayServiceRegisterTunnelHandler.ServeHTTP(w, r)
 		default:
 			http.NotFound(w, r)
 		}
@@ -112,3 +147,77 @@ type UnimplementedRelayServiceHandler struct{}
 func (UnimplementedRelayServiceHandler) Stream(context.Context, *connect.BidiStream[v1.StreamRequest, v1.StreamResponse]) error {
 	return connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.RelayService.Stream is not implemented"))
 }
+
+func (UnimplementedRelayServiceHandler) RegisterTunnel(context.Context, *connect.Request[v1.RegisterTunnelRequest]) (*connect.Response[v1.RegisterTunnelResponse], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.RelayService.RegisterTunnel is not implemented"))
+}
+
+// TunnelServiceClient is a client for the hookly.v1.TunnelService service.
+type TunnelServiceClient interface {
+	Deliver(context.Context, *connect.Request[v1.WebhookEnvelope]) (*connect.Response[v1.DeliveryAck], error)
+}
+
+// NewTunnelServiceClient constructs a client for the hookly.v1.TunnelService service. By default,
+// it uses the Connect protocol with the binary Protobuf Codec, asks for gzipped responses, and
+// sends uncompressed requests. To use the gRPC or gRPC-Web protocols, supply the connect.WithGRPC()
+// or connect.WithGRPCWeb() options.
+//
+// The URL supplied here should be the base URL for the Connect or gRPC server (for example,
+// http://api.acme.com or https://acme.com/grpc).
+func NewTunnelServiceClient(httpClient connect.HTTPClient, baseURL string, opts ...connect.ClientOption) TunnelServiceClient {
+	baseURL = strings.TrimRight(baseURL, "/")
+	tunnelServiceMethods := v1.File_hookly_v1_relay_proto.Services().ByName("TunnelService").Methods()
+	return &tunnelServiceClient{
+		deliver: connect.NewClient[v1.WebhookEnvelope, v1.DeliveryAck](
+			httpClient,
+			baseURL+TunnelServiceDeliverProcedure,
+			connect.WithSchema(tunnelServiceMethods.ByName("Deliver")),
+			connect.WithClientOptions(opts...),
+		),
+	}
+}
+
+// tunnelServiceClient implements TunnelServiceClient.
+type tunnelServiceClient struct {
+	deliver *connect.Client[v1.WebhookEnvelope, v1.DeliveryAck]
+}
+
+// Deliver calls hookly.v1.TunnelService.Deliver.
+func (c *tunnelServiceClient) Deliver(ctx context.Context, req *connect.Request[v1.WebhookEnvelope]) (*connect.Response[v1.DeliveryAck], error) {
+	return c.deliver.CallUnary(ctx, req)
+}
+
+// TunnelServiceHandler is an implementation of the hookly.v1.TunnelService service.
+type TunnelServiceHandler interface {
+	Deliver(context.Context, *connect.Request[v1.WebhookEnvelope]) (*connect.Response[v1.DeliveryAck], error)
+}
+
+// NewTunnelServiceHandler builds an HTTP handler from the service implementation. It returns the
+// path on which to mount the handler and the handler itself.
+//
+// By default, handlers support the Connect, gRPC, and gRPC-Web protocols with the binary Protobuf
+// and JSON codecs. They also support gzip compression.
+func NewTunnelServiceHandler(svc TunnelServiceHandler, opts ...connect.HandlerOption) (string, http.Handler) {
+	tunnelServiceMethods := v1.File_hookly_v1_relay_proto.Services().ByName("TunnelService").Methods()
+	tunnelServiceDeliverHandler := connect.NewUnaryHandler(
+		TunnelServiceDeliverProcedure,
+		svc.Deliver,
+		connect.WithSchema(tunnelServiceMethods.ByName("Deliver")),
+		connect.WithHandlerOptions(opts...),
+	)
+	return "/hookly.v1.TunnelService/", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case TunnelServiceDeliverProcedure:
+			tunnelServiceDeliverHandler.ServeHTTP(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+}
+
+// UnimplementedTunnelServiceHandler returns CodeUnimplemented from all methods.
+type UnimplementedTunnelServiceHandler struct{}
+
+func (UnimplementedTunnelServiceHandler) Deliver(context.Context, *connect.Request[v1.WebhookEnvelope]) (*connect.Response[v1.DeliveryAck], error) {
+	return nil, connect.NewError(connect.CodeUnimplemented, errors.New("hookly.v1.TunnelService.Deliver is not implemented"))
+}
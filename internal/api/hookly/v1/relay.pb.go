@@ -30,6 +30,7 @@ type StreamRequest struct {
 	//	*StreamRequest_Connect
 	//	*StreamRequest_Ack
 	//	*StreamRequest_Heartbeat
+	//	*StreamRequest_CommandResult
 	Message       isStreamRequest_Message `protobuf_oneof:"message"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -99,6 +100,15 @@ func (x *StreamRequest) GetHeartbeat() *Heartbeat {
 	return nil
 }
 
+func (x *StreamRequest) GetCommandResult() *HubCommandResult {
+	if x != nil {
+		if x, ok := x.Message.(*StreamRequest_CommandResult); ok {
+			return x.CommandResult
+		}
+	}
+	return nil
+}
+
 type isStreamRequest_Message interface {
 	isStreamRequest_Message()
 }
@@ -115,12 +125,18 @@ type StreamRequest_Heartbeat struct {
 	Heartbeat *Heartbeat `protobuf:"bytes,3,opt,name=heartbeat,proto3,oneof"`
 }
 
+type StreamRequest_CommandResult struct {
+	CommandResult *HubCommandResult `protobuf:"bytes,4,opt,name=command_result,json=commandResult,proto3,oneof"`
+}
+
 func (*StreamRequest_Connect) isStreamRequest_Message() {}
 
 func (*StreamRequest_Ack) isStreamRequest_Message() {}
 
 func (*StreamRequest_Heartbeat) isStreamRequest_Message() {}
 
+func (*StreamRequest_CommandResult) isStreamRequest_Message() {}
+
 // Messages from edge to home-hub
 type StreamResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -129,6 +145,9 @@ type StreamResponse struct {
 	//	*StreamResponse_ConnectResponse
 	//	*StreamResponse_Webhook
 	//	*StreamResponse_Heartbeat
+	//	*StreamResponse_PayloadChunk
+	//	*StreamResponse_Maintenance
+	//	*StreamResponse_Command
 	Message       isStreamResponse_Message `protobuf_oneof:"message"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -198,6 +217,33 @@ func (x *StreamResponse) GetHeartbeat() *Heartbeat {
 	return nil
 }
 
+func (x *StreamResponse) GetPayloadChunk() *PayloadChunk {
+	if x != nil {
+		if x, ok := x.Message.(*StreamResponse_PayloadChunk); ok {
+			return x.PayloadChunk
+		}
+	}
+	return nil
+}
+
+func (x *StreamResponse) GetMaintenance() *Maintenance {
+	if x != nil {
+		if x, ok := x.Message.(*StreamResponse_Maintenance); ok {
+			return x.Maintenance
+		}
+	}
+	return nil
+}
+
+func (x *StreamResponse) GetCommand() *HubCommand {
+	if x != nil {
+		if x, ok := x.Message.(*StreamResponse_Command); ok {
+			return x.Command
+		}
+	}
+	return nil
+}
+
 type isStreamResponse_Message interface {
 	isStreamResponse_Message()
 }
@@ -214,18 +260,38 @@ type StreamResponse_Heartbeat struct {
 	Heartbeat *Heartbeat `protobuf:"bytes,3,opt,name=heartbeat,proto3,oneof"`
 }
 
+type StreamResponse_PayloadChunk struct {
+	PayloadChunk *PayloadChunk `protobuf:"bytes,4,opt,name=payload_chunk,json=payloadChunk,proto3,oneof"`
+}
+
+type StreamResponse_Maintenance struct {
+	Maintenance *Maintenance `protobuf:"bytes,5,opt,name=maintenance,proto3,oneof"`
+}
+
+type StreamResponse_Command struct {
+	Command *HubCommand `protobuf:"bytes,6,opt,name=command,proto3,oneof"`
+}
+
 func (*StreamResponse_ConnectResponse) isStreamResponse_Message() {}
 
 func (*StreamResponse_Webhook) isStreamResponse_Message() {}
 
 func (*StreamResponse_Heartbeat) isStreamResponse_Message() {}
 
+func (*StreamResponse_PayloadChunk) isStreamResponse_Message() {}
+
+func (*StreamResponse_Maintenance) isStreamResponse_Message() {}
+
+func (*StreamResponse_Command) isStreamResponse_Message() {}
+
 // Initial connection request with authentication
 type ConnectRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	HubId         string                 `protobuf:"bytes,1,opt,name=hub_id,json=hubId,proto3" json:"hub_id,omitempty"`
-	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`                                // Bearer token from CLI login
-	EndpointIds   []string               `protobuf:"bytes,3,rep,name=endpoint_ids,json=endpointIds,proto3" json:"endpoint_ids,omitempty"` // Endpoints this hub handles
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`                                   // Bearer token from CLI login
+	EndpointIds   []string               `protobuf:"bytes,3,rep,name=endpoint_ids,json=endpointIds,proto3" json:"endpoint_ids,omitempty"`    // Endpoints this hub handles
+	EventFilters  []*EventTypeFilter     `protobuf:"bytes,4,rep,name=event_filters,json=eventFilters,proto3" json:"event_filters,omitempty"` // Event types wanted per endpoint (none = all)
+	Paused        bool                   `protobuf:"varint,5,opt,name=paused,proto3" json:"paused,omitempty"`                                // Deliveries were paused by a command before reconnecting
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -281,18 +347,86 @@ func (x *ConnectRequest) GetEndpointIds() []string {
 	return nil
 }
 
-// Connection response
-type ConnectResponse struct {
+func (x *ConnectRequest) GetEventFilters() []*EventTypeFilter {
+	if x != nil {
+		return x.EventFilters
+	}
+	return nil
+}
+
+func (x *ConnectRequest) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+// EventTypeFilter restricts relayed webhooks for an endpoint to the listed event types.
+type EventTypeFilter struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Error         string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	EndpointId    string                 `protobuf:"bytes,1,opt,name=endpoint_id,json=endpointId,proto3" json:"endpoint_id,omitempty"`
+	EventTypes    []string               `protobuf:"bytes,2,rep,name=event_types,json=eventTypes,proto3" json:"event_types,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
+func (x *EventTypeFilter) Reset() {
+	*x = EventTypeFilter{}
+	mi := &file_hookly_v1_relay_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventTypeFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventTypeFilter) ProtoMessage() {}
+
+func (x *EventTypeFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_relay_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventTypeFilter.ProtoReflect.Descriptor instead.
+func (*EventTypeFilter) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_relay_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *EventTypeFilter) GetEndpointId() string {
+	if x != nil {
+		return x.EndpointId
+	}
+	return ""
+}
+
+func (x *EventTypeFilter) GetEventTypes() []string {
+	if x != nil {
+		return x.EventTypes
+	}
+	return nil
+}
+
+// Connection response
+type ConnectResponse struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Success           bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Error             string                 `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	RetryAfterSeconds int32                  `protobuf:"varint,3,opt,name=retry_after_seconds,json=retryAfterSeconds,proto3" json:"retry_after_seconds,omitempty"` // Minimum delay before reconnecting (0 = client default)
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
 func (x *ConnectResponse) Reset() {
 	*x = ConnectResponse{}
-	mi := &file_hookly_v1_relay_proto_msgTypes[3]
+	mi := &file_hookly_v1_relay_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -304,7 +438,7 @@ func (x *ConnectResponse) String() string {
 func (*ConnectResponse) ProtoMessage() {}
 
 func (x *ConnectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_relay_proto_msgTypes[3]
+	mi := &file_hookly_v1_relay_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -317,7 +451,7 @@ func (x *ConnectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectResponse.ProtoReflect.Descriptor instead.
 func (*ConnectResponse) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_relay_proto_rawDescGZIP(), []int{3}
+	return file_hookly_v1_relay_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ConnectResponse) GetSuccess() bool {
@@ -334,6 +468,139 @@ func (x *ConnectResponse) GetError() string {
 	return ""
 }
 
+func (x *ConnectResponse) GetRetryAfterSeconds() int32 {
+	if x != nil {
+		return x.RetryAfterSeconds
+	}
+	return 0
+}
+
+// Maintenance announces a planned edge restart. The edge closes the stream
+// right after sending it; the hub reconnects after reconnect_after_seconds,
+// to reconnect_url if set (a standby edge) and otherwise to the same edge.
+type Maintenance struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	ReconnectAfterSeconds int32                  `protobuf:"varint,1,opt,name=reconnect_after_seconds,json=reconnectAfterSeconds,proto3" json:"reconnect_after_seconds,omitempty"`
+	ReconnectUrl          string                 `protobuf:"bytes,2,opt,name=reconnect_url,json=reconnectUrl,proto3" json:"reconnect_url,omitempty"`
+	Reason                string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *Maintenance) Reset() {
+	*x = Maintenance{}
+	mi := &file_hookly_v1_relay_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Maintenance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Maintenance) ProtoMessage() {}
+
+func (x *Maintenance) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_relay_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Maintenance.ProtoReflect.Descriptor instead.
+func (*Maintenance) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_relay_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Maintenance) GetReconnectAfterSeconds() int32 {
+	if x != nil {
+		return x.ReconnectAfterSeconds
+	}
+	return 0
+}
+
+func (x *Maintenance) GetReconnectUrl() string {
+	if x != nil {
+		return x.ReconnectUrl
+	}
+	return ""
+}
+
+func (x *Maintenance) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// HubCommand is a remote management command. The hub answers with a
+// HubCommandResult carrying the same id.
+type HubCommand struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type  HubCommandType         `protobuf:"varint,2,opt,name=type,proto3,enum=hookly.v1.HubCommandType" json:"type,omitempty"`
+	// Log lines to send for HUB_COMMAND_TYPE_LOGS
+	Lines         int32 `protobuf:"varint,3,opt,name=lines,proto3" json:"lines,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HubCommand) Reset() {
+	*x = HubCommand{}
+	mi := &file_hookly_v1_relay_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HubCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HubCommand) ProtoMessage() {}
+
+func (x *HubCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_relay_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HubCommand.ProtoReflect.Descriptor instead.
+func (*HubCommand) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_relay_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *HubCommand) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HubCommand) GetType() HubCommandType {
+	if x != nil {
+		return x.Type
+	}
+	return HubCommandType_HUB_COMMAND_TYPE_UNSPECIFIED
+}
+
+func (x *HubCommand) GetLines() int32 {
+	if x != nil {
+		return x.Lines
+	}
+	return 0
+}
+
 // Heartbeat for connection health monitoring
 type Heartbeat struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -344,7 +611,7 @@ type Heartbeat struct {
 
 func (x *Heartbeat) Reset() {
 	*x = Heartbeat{}
-	mi := &file_hookly_v1_relay_proto_msgTypes[4]
+	mi := &file_hookly_v1_relay_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -356,7 +623,7 @@ func (x *Heartbeat) String() string {
 func (*Heartbeat) ProtoMessage() {}
 
 func (x *Heartbeat) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_relay_proto_msgTypes[4]
+	mi := &file_hookly_v1_relay_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -369,7 +636,7 @@ func (x *Heartbeat) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Heartbeat.ProtoReflect.Descriptor instead.
 func (*Heartbeat) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_relay_proto_rawDescGZIP(), []int{4}
+	return file_hookly_v1_relay_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Heartbeat) GetTimestamp() int64 {
@@ -389,13 +656,25 @@ type WebhookEnvelope struct {
 	Headers        map[string]string      `protobuf:"bytes,5,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	Payload        []byte                 `protobuf:"bytes,6,opt,name=payload,proto3" json:"payload,omitempty"`
 	Attempt        int32                  `protobuf:"varint,7,opt,name=attempt,proto3" json:"attempt,omitempty"`
-	unknownFields  protoimpl.UnknownFields
-	sizeCache      protoimpl.SizeCache
+	// Set for large payloads: payload is empty and follows as PayloadChunk
+	// messages on the same stream.
+	Chunked       bool       `protobuf:"varint,8,opt,name=chunked,proto3" json:"chunked,omitempty"`
+	PayloadSize   int64      `protobuf:"varint,9,opt,name=payload_size,json=payloadSize,proto3" json:"payload_size,omitempty"`
+	PayloadSha256 string     `protobuf:"bytes,10,opt,name=payload_sha256,json=payloadSha256,proto3" json:"payload_sha256,omitempty"` // Hex-encoded SHA-256 of the full payload
+	Transform     *Transform `protobuf:"bytes,11,opt,name=transform,proto3" json:"transform,omitempty"`                              // Applied before forwarding, unset if none
+	// Set when the endpoint fans out: the destinations still to deliver to,
+	// the endpoint's own with an empty id. Unset delivers to destination_url.
+	Destinations []*Destination `protobuf:"bytes,12,rep,name=destinations,proto3" json:"destinations,omitempty"`
+	// W3C trace context of the span that sent the webhook, so the hub's
+	// delivery spans join the webhook's trace.
+	Traceparent   string `protobuf:"bytes,13,opt,name=traceparent,proto3" json:"traceparent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *WebhookEnvelope) Reset() {
 	*x = WebhookEnvelope{}
-	mi := &file_hookly_v1_relay_proto_msgTypes[5]
+	mi := &file_hookly_v1_relay_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -407,7 +686,7 @@ func (x *WebhookEnvelope) String() string {
 func (*WebhookEnvelope) ProtoMessage() {}
 
 func (x *WebhookEnvelope) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_relay_proto_msgTypes[5]
+	mi := &file_hookly_v1_relay_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -420,7 +699,7 @@ func (x *WebhookEnvelope) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use WebhookEnvelope.ProtoReflect.Descriptor instead.
 func (*WebhookEnvelope) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_relay_proto_rawDescGZIP(), []int{5}
+	return file_hookly_v1_relay_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *WebhookEnvelope) GetId() string {
@@ -472,6 +751,118 @@ func (x *WebhookEnvelope) GetAttempt() int32 {
 	return 0
 }
 
+func (x *WebhookEnvelope) GetChunked() bool {
+	if x != nil {
+		return x.Chunked
+	}
+	return false
+}
+
+func (x *WebhookEnvelope) GetPayloadSize() int64 {
+	if x != nil {
+		return x.PayloadSize
+	}
+	return 0
+}
+
+func (x *WebhookEnvelope) GetPayloadSha256() string {
+	if x != nil {
+		return x.PayloadSha256
+	}
+	return ""
+}
+
+func (x *WebhookEnvelope) GetTransform() *Transform {
+	if x != nil {
+		return x.Transform
+	}
+	return nil
+}
+
+func (x *WebhookEnvelope) GetDestinations() []*Destination {
+	if x != nil {
+		return x.Destinations
+	}
+	return nil
+}
+
+func (x *WebhookEnvelope) GetTraceparent() string {
+	if x != nil {
+		return x.Traceparent
+	}
+	return ""
+}
+
+// PayloadChunk carries part of a chunked webhook payload. Chunks are sent in
+// order directly after their envelope.
+type PayloadChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WebhookId     string                 `protobuf:"bytes,1,opt,name=webhook_id,json=webhookId,proto3" json:"webhook_id,omitempty"`
+	Index         int32                  `protobuf:"varint,2,opt,name=index,proto3" json:"index,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	Last          bool                   `protobuf:"varint,4,opt,name=last,proto3" json:"last,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayloadChunk) Reset() {
+	*x = PayloadChunk{}
+	mi := &file_hookly_v1_relay_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayloadChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayloadChunk) ProtoMessage() {}
+
+func (x *PayloadChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_relay_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayloadChunk.ProtoReflect.Descriptor instead.
+func (*PayloadChunk) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_relay_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *PayloadChunk) GetWebhookId() string {
+	if x != nil {
+		return x.WebhookId
+	}
+	return ""
+}
+
+func (x *PayloadChunk) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *PayloadChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *PayloadChunk) GetLast() bool {
+	if x != nil {
+		return x.Last
+	}
+	return false
+}
+
 // Delivery acknowledgment from home-hub
 type DeliveryAck struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
@@ -480,13 +871,18 @@ type DeliveryAck struct {
 	StatusCode       int32                  `protobuf:"varint,3,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
 	ErrorMessage     string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
 	PermanentFailure bool                   `protobuf:"varint,5,opt,name=permanent_failure,json=permanentFailure,proto3" json:"permanent_failure,omitempty"` // true for 4xx, don't retry
-	unknownFields    protoimpl.UnknownFields
-	sizeCache        protoimpl.SizeCache
+	// One per destination of a fanned-out webhook. The fields above then
+	// summarize them: success if all succeeded, else the first failure.
+	Destinations []*DestinationResult `protobuf:"bytes,6,rep,name=destinations,proto3" json:"destinations,omitempty"`
+	// W3C trace context of the hub's delivery span, joined by the ACK span
+	Traceparent   string `protobuf:"bytes,7,opt,name=traceparent,proto3" json:"traceparent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *DeliveryAck) Reset() {
 	*x = DeliveryAck{}
-	mi := &file_hookly_v1_relay_proto_msgTypes[6]
+	mi := &file_hookly_v1_relay_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -498,7 +894,7 @@ func (x *DeliveryAck) String() string {
 func (*DeliveryAck) ProtoMessage() {}
 
 func (x *DeliveryAck) ProtoReflect() protoreflect.Message {
-	mi := &file_hookly_v1_relay_proto_msgTypes[6]
+	mi := &file_hookly_v1_relay_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -511,7 +907,7 @@ func (x *DeliveryAck) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeliveryAck.ProtoReflect.Descriptor instead.
 func (*DeliveryAck) Descriptor() ([]byte, []int) {
-	return file_hookly_v1_relay_proto_rawDescGZIP(), []int{6}
+	return file_hookly_v1_relay_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *DeliveryAck) GetWebhookId() string {
@@ -549,30 +945,255 @@ func (x *DeliveryAck) GetPermanentFailure() bool {
 	return false
 }
 
+func (x *DeliveryAck) GetDestinations() []*DestinationResult {
+	if x != nil {
+		return x.Destinations
+	}
+	return nil
+}
+
+func (x *DeliveryAck) GetTraceparent() string {
+	if x != nil {
+		return x.Traceparent
+	}
+	return ""
+}
+
+type DestinationResult struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	DestinationId    string                 `protobuf:"bytes,1,opt,name=destination_id,json=destinationId,proto3" json:"destination_id,omitempty"`
+	Success          bool                   `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	StatusCode       int32                  `protobuf:"varint,3,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	ErrorMessage     string                 `protobuf:"bytes,4,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	PermanentFailure bool                   `protobuf:"varint,5,opt,name=permanent_failure,json=permanentFailure,proto3" json:"permanent_failure,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DestinationResult) Reset() {
+	*x = DestinationResult{}
+	mi := &file_hookly_v1_relay_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DestinationResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DestinationResult) ProtoMessage() {}
+
+func (x *DestinationResult) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_relay_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DestinationResult.ProtoReflect.Descriptor instead.
+func (*DestinationResult) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_relay_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *DestinationResult) GetDestinationId() string {
+	if x != nil {
+		return x.DestinationId
+	}
+	return ""
+}
+
+func (x *DestinationResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DestinationResult) GetStatusCode() int32 {
+	if x != nil {
+		return x.StatusCode
+	}
+	return 0
+}
+
+func (x *DestinationResult) GetErrorMessage() string {
+	if x != nil {
+		return x.ErrorMessage
+	}
+	return ""
+}
+
+func (x *DestinationResult) GetPermanentFailure() bool {
+	if x != nil {
+		return x.PermanentFailure
+	}
+	return false
+}
+
+// Tunnel lease request, sent as a unary call so it works on networks that
+// drop long-lived streams
+type RegisterTunnelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Connect       *ConnectRequest        `protobuf:"bytes,1,opt,name=connect,proto3" json:"connect,omitempty"` // Same authentication and endpoints as the stream
+	Address       string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"` // ip:port the edge dials to reach the hub's tunnel listener
+	Secret        string                 `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`   // Bearer secret the edge presents to the listener
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterTunnelRequest) Reset() {
+	*x = RegisterTunnelRequest{}
+	mi := &file_hookly_v1_relay_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterTunnelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterTunnelRequest) ProtoMessage() {}
+
+func (x *RegisterTunnelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_relay_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterTunnelRequest.ProtoReflect.Descriptor instead.
+func (*RegisterTunnelRequest) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_relay_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RegisterTunnelRequest) GetConnect() *ConnectRequest {
+	if x != nil {
+		return x.Connect
+	}
+	return nil
+}
+
+func (x *RegisterTunnelRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RegisterTunnelRequest) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+type RegisterTunnelResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Active        bool                   `protobuf:"varint,1,opt,name=active,proto3" json:"active,omitempty"`                                 // False while the hub's stream is connected
+	LeaseSeconds  int32                  `protobuf:"varint,2,opt,name=lease_seconds,json=leaseSeconds,proto3" json:"lease_seconds,omitempty"` // Renew before the lease expires
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterTunnelResponse) Reset() {
+	*x = RegisterTunnelResponse{}
+	mi := &file_hookly_v1_relay_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterTunnelResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterTunnelResponse) ProtoMessage() {}
+
+func (x *RegisterTunnelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hookly_v1_relay_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterTunnelResponse.ProtoReflect.Descriptor instead.
+func (*RegisterTunnelResponse) Descriptor() ([]byte, []int) {
+	return file_hookly_v1_relay_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RegisterTunnelResponse) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *RegisterTunnelResponse) GetLeaseSeconds() int32 {
+	if x != nil {
+		return x.LeaseSeconds
+	}
+	return 0
+}
+
 var File_hookly_v1_relay_proto protoreflect.FileDescriptor
 
 const file_hookly_v1_relay_proto_rawDesc = "" +
 	"\n" +
-	"\x15hookly/v1/relay.proto\x12\thookly.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb3\x01\n" +
+	"\x15hookly/v1/relay.proto\x12\thookly.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16hookly/v1/common.proto\"\xf9\x01\n" +
 	"\rStreamRequest\x125\n" +
 	"\aconnect\x18\x01 \x01(\v2\x19.hookly.v1.ConnectRequestH\x00R\aconnect\x12*\n" +
 	"\x03ack\x18\x02 \x01(\v2\x16.hookly.v1.DeliveryAckH\x00R\x03ack\x124\n" +
-	"\theartbeat\x18\x03 \x01(\v2\x14.hookly.v1.HeartbeatH\x00R\theartbeatB\t\n" +
-	"\amessage\"\xd2\x01\n" +
+	"\theartbeat\x18\x03 \x01(\v2\x14.hookly.v1.HeartbeatH\x00R\theartbeat\x12D\n" +
+	"\x0ecommand_result\x18\x04 \x01(\v2\x1b.hookly.v1.HubCommandResultH\x00R\rcommandResultB\t\n" +
+	"\amessage\"\x81\x03\n" +
 	"\x0eStreamResponse\x12G\n" +
 	"\x10connect_response\x18\x01 \x01(\v2\x1a.hookly.v1.ConnectResponseH\x00R\x0fconnectResponse\x126\n" +
 	"\awebhook\x18\x02 \x01(\v2\x1a.hookly.v1.WebhookEnvelopeH\x00R\awebhook\x124\n" +
-	"\theartbeat\x18\x03 \x01(\v2\x14.hookly.v1.HeartbeatH\x00R\theartbeatB\t\n" +
-	"\amessage\"`\n" +
+	"\theartbeat\x18\x03 \x01(\v2\x14.hookly.v1.HeartbeatH\x00R\theartbeat\x12>\n" +
+	"\rpayload_chunk\x18\x04 \x01(\v2\x17.hookly.v1.PayloadChunkH\x00R\fpayloadChunk\x12:\n" +
+	"\vmaintenance\x18\x05 \x01(\v2\x16.hookly.v1.MaintenanceH\x00R\vmaintenance\x121\n" +
+	"\acommand\x18\x06 \x01(\v2\x15.hookly.v1.HubCommandH\x00R\acommandB\t\n" +
+	"\amessage\"\xb9\x01\n" +
 	"\x0eConnectRequest\x12\x15\n" +
 	"\x06hub_id\x18\x01 \x01(\tR\x05hubId\x12\x14\n" +
 	"\x05token\x18\x02 \x01(\tR\x05token\x12!\n" +
-	"\fendpoint_ids\x18\x03 \x03(\tR\vendpointIds\"A\n" +
+	"\fendpoint_ids\x18\x03 \x03(\tR\vendpointIds\x12?\n" +
+	"\revent_filters\x18\x04 \x03(\v2\x1a.hookly.v1.EventTypeFilterR\feventFilters\x12\x16\n" +
+	"\x06paused\x18\x05 \x01(\bR\x06paused\"S\n" +
+	"\x0fEventTypeFilter\x12\x1f\n" +
+	"\vendpoint_id\x18\x01 \x01(\tR\n" +
+	"endpointId\x12\x1f\n" +
+	"\vevent_types\x18\x02 \x03(\tR\n" +
+	"eventTypes\"q\n" +
 	"\x0fConnectResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x14\n" +
-	"\x05error\x18\x02 \x01(\tR\x05error\")\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\x12.\n" +
+	"\x13retry_after_seconds\x18\x03 \x01(\x05R\x11retryAfterSeconds\"\x82\x01\n" +
+	"\vMaintenance\x126\n" +
+	"\x17reconnect_after_seconds\x18\x01 \x01(\x05R\x15reconnectAfterSeconds\x12#\n" +
+	"\rreconnect_url\x18\x02 \x01(\tR\freconnectUrl\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"a\n" +
+	"\n" +
+	"HubCommand\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12-\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x19.hookly.v1.HubCommandTypeR\x04type\x12\x14\n" +
+	"\x05lines\x18\x03 \x01(\x05R\x05lines\")\n" +
 	"\tHeartbeat\x12\x1c\n" +
-	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\"\xdb\x02\n" +
+	"\ttimestamp\x18\x01 \x01(\x03R\ttimestamp\"\xd1\x04\n" +
 	"\x0fWebhookEnvelope\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vendpoint_id\x18\x02 \x01(\tR\n" +
@@ -582,10 +1203,23 @@ const file_hookly_v1_relay_proto_rawDesc = "" +
 	"receivedAt\x12A\n" +
 	"\aheaders\x18\x05 \x03(\v2'.hookly.v1.WebhookEnvelope.HeadersEntryR\aheaders\x12\x18\n" +
 	"\apayload\x18\x06 \x01(\fR\apayload\x12\x18\n" +
-	"\aattempt\x18\a \x01(\x05R\aattempt\x1a:\n" +
+	"\aattempt\x18\a \x01(\x05R\aattempt\x12\x18\n" +
+	"\achunked\x18\b \x01(\bR\achunked\x12!\n" +
+	"\fpayload_size\x18\t \x01(\x03R\vpayloadSize\x12%\n" +
+	"\x0epayload_sha256\x18\n" +
+	" \x01(\tR\rpayloadSha256\x122\n" +
+	"\ttransform\x18\v \x01(\v2\x14.hookly.v1.TransformR\ttransform\x12:\n" +
+	"\fdestinations\x18\f \x03(\v2\x16.hookly.v1.DestinationR\fdestinations\x12 \n" +
+	"\vtraceparent\x18\r \x01(\tR\vtraceparent\x1a:\n" +
 	"\fHeadersEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb9\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"k\n" +
+	"\fPayloadChunk\x12\x1d\n" +
+	"\n" +
+	"webhook_id\x18\x01 \x01(\tR\twebhookId\x12\x14\n" +
+	"\x05index\x18\x02 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\x12\x12\n" +
+	"\x04last\x18\x04 \x01(\bR\x04last\"\x9d\x02\n" +
 	"\vDeliveryAck\x12\x1d\n" +
 	"\n" +
 	"webhook_id\x18\x01 \x01(\tR\twebhookId\x12\x18\n" +
@@ -593,9 +1227,28 @@ const file_hookly_v1_relay_proto_rawDesc = "" +
 	"\vstatus_code\x18\x03 \x01(\x05R\n" +
 	"statusCode\x12#\n" +
 	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x12+\n" +
-	"\x11permanent_failure\x18\x05 \x01(\bR\x10permanentFailure2Q\n" +
+	"\x11permanent_failure\x18\x05 \x01(\bR\x10permanentFailure\x12@\n" +
+	"\fdestinations\x18\x06 \x03(\v2\x1c.hookly.v1.DestinationResultR\fdestinations\x12 \n" +
+	"\vtraceparent\x18\a \x01(\tR\vtraceparent\"\xc7\x01\n" +
+	"\x11DestinationResult\x12%\n" +
+	"\x0edestination_id\x18\x01 \x01(\tR\rdestinationId\x12\x18\n" +
+	"\asuccess\x18\x02 \x01(\bR\asuccess\x12\x1f\n" +
+	"\vstatus_code\x18\x03 \x01(\x05R\n" +
+	"statusCode\x12#\n" +
+	"\rerror_message\x18\x04 \x01(\tR\ferrorMessage\x12+\n" +
+	"\x11permanent_failure\x18\x05 \x01(\bR\x10permanentFailure\"~\n" +
+	"\x15RegisterTunnelRequest\x123\n" +
+	"\aconnect\x18\x01 \x01(\v2\x19.hookly.v1.ConnectRequestR\aconnect\x12\x18\n" +
+	"\aaddress\x18\x02 \x01(\tR\aaddress\x12\x16\n" +
+	"\x06secret\x18\x03 \x01(\tR\x06secret\"U\n" +
+	"\x16RegisterTunnelResponse\x12\x16\n" +
+	"\x06active\x18\x01 \x01(\bR\x06active\x12#\n" +
+	"\rlease_seconds\x18\x02 \x01(\x05R\fleaseSeconds2\xa8\x01\n" +
 	"\fRelayService\x12A\n" +
-	"\x06Stream\x12\x18.hookly.v1.StreamRequest\x1a\x19.hookly.v1.StreamResponse(\x010\x01B\x91\x01\n" +
+	"\x06Stream\x12\x18.hookly.v1.StreamRequest\x1a\x19.hookly.v1.StreamResponse(\x010\x01\x12U\n" +
+	"\x0eRegisterTunnel\x12 .hookly.v1.RegisterTunnelRequest\x1a!.hookly.v1.RegisterTunnelResponse2N\n" +
+	"\rTunnelService\x12=\n" +
+	"\aDeliver\x12\x1a.hookly.v1.WebhookEnvelope\x1a\x16.hookly.v1.DeliveryAckB\x91\x01\n" +
 	"\rcom.hookly.v1B\n" +
 	"RelayProtoP\x01Z/hooks.dx314.com/internal/api/hookly/v1;hooklyv1\xa2\x02\x03HXX\xaa\x02\tHookly.V1\xca\x02\tHookly\\V1\xe2\x02\x15Hookly\\V1\\GPBMetadata\xea\x02\n" +
 	"Hookly::V1b\x06proto3"
@@ -612,34 +1265,59 @@ func file_hookly_v1_relay_proto_rawDescGZIP() []byte {
 	return file_hookly_v1_relay_proto_rawDescData
 }
 
-var file_hookly_v1_relay_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_hookly_v1_relay_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_hookly_v1_relay_proto_goTypes = []any{
-	(*StreamRequest)(nil),         // 0: hookly.v1.StreamRequest
-	(*StreamResponse)(nil),        // 1: hookly.v1.StreamResponse
-	(*ConnectRequest)(nil),        // 2: hookly.v1.ConnectRequest
-	(*ConnectResponse)(nil),       // 3: hookly.v1.ConnectResponse
-	(*Heartbeat)(nil),             // 4: hookly.v1.Heartbeat
-	(*WebhookEnvelope)(nil),       // 5: hookly.v1.WebhookEnvelope
-	(*DeliveryAck)(nil),           // 6: hookly.v1.DeliveryAck
-	nil,                           // 7: hookly.v1.WebhookEnvelope.HeadersEntry
-	(*timestamppb.Timestamp)(nil), // 8: google.protobuf.Timestamp
+	(*StreamRequest)(nil),          // 0: hookly.v1.StreamRequest
+	(*StreamResponse)(nil),         // 1: hookly.v1.StreamResponse
+	(*ConnectRequest)(nil),         // 2: hookly.v1.ConnectRequest
+	(*EventTypeFilter)(nil),        // 3: hookly.v1.EventTypeFilter
+	(*ConnectResponse)(nil),        // 4: hookly.v1.ConnectResponse
+	(*Maintenance)(nil),            // 5: hookly.v1.Maintenance
+	(*HubCommand)(nil),             // 6: hookly.v1.HubCommand
+	(*Heartbeat)(nil),              // 7: hookly.v1.Heartbeat
+	(*WebhookEnvelope)(nil),        // 8: hookly.v1.WebhookEnvelope
+	(*PayloadChunk)(nil),           // 9: hookly.v1.PayloadChunk
+	(*DeliveryAck)(nil),            // 10: hookly.v1.DeliveryAck
+	(*DestinationResult)(nil),      // 11: hookly.v1.DestinationResult
+	(*RegisterTunnelRequest)(nil),  // 12: hookly.v1.RegisterTunnelRequest
+	(*RegisterTunnelResponse)(nil), // 13: hookly.v1.RegisterTunnelResponse
+	nil,                            // 14: hookly.v1.WebhookEnvelope.HeadersEntry
+	(*HubCommandResult)(nil),       // 15: hookly.v1.HubCommandResult
+	(HubCommandType)(0),            // 16: hookly.v1.HubCommandType
+	(*timestamppb.Timestamp)(nil),  // 17: google.protobuf.Timestamp
+	(*Transform)(nil),              // 18: hookly.v1.Transform
+	(*Destination)(nil),            // 19: hookly.v1.Destination
 }
 var file_hookly_v1_relay_proto_depIdxs = []int32{
-	2, // 0: hookly.v1.StreamRequest.connect:type_name -> hookly.v1.ConnectRequest
-	6, // 1: hookly.v1.StreamRequest.ack:type_name -> hookly.v1.DeliveryAck
-	4, // 2: hookly.v1.StreamRequest.heartbeat:type_name -> hookly.v1.Heartbeat
-	3, // 3: hookly.v1.StreamResponse.connect_response:type_name -> hookly.v1.ConnectResponse
-	5, // 4: hookly.v1.StreamResponse.webhook:type_name -> hookly.v1.WebhookEnvelope
-	4, // 5: hookly.v1.StreamResponse.heartbeat:type_name -> hookly.v1.Heartbeat
-	8, // 6: hookly.v1.WebhookEnvelope.received_at:type_name -> google.protobuf.Timestamp
-	7, // 7: hookly.v1.WebhookEnvelope.headers:type_name -> hookly.v1.WebhookEnvelope.HeadersEntry
-	0, // 8: hookly.v1.RelayService.Stream:input_type -> hookly.v1.StreamRequest
-	1, // 9: hookly.v1.RelayService.Stream:output_type -> hookly.v1.StreamResponse
-	9, // [9:10] is the sub-list for method output_type
-	8, // [8:9] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	2,  // 0: hookly.v1.StreamRequest.connect:type_name -> hookly.v1.ConnectRequest
+	10, // 1: hookly.v1.StreamRequest.ack:type_name -> hookly.v1.DeliveryAck
+	7,  // 2: hookly.v1.StreamRequest.heartbeat:type_name -> hookly.v1.Heartbeat
+	15, // 3: hookly.v1.StreamRequest.command_result:type_name -> hookly.v1.HubCommandResult
+	4,  // 4: hookly.v1.StreamResponse.connect_response:type_name -> hookly.v1.ConnectResponse
+	8,  // 5: hookly.v1.StreamResponse.webhook:type_name -> hookly.v1.WebhookEnvelope
+	7,  // 6: hookly.v1.StreamResponse.heartbeat:type_name -> hookly.v1.Heartbeat
+	9,  // 7: hookly.v1.StreamResponse.payload_chunk:type_name -> hookly.v1.PayloadChunk
+	5,  // 8: hookly.v1.StreamResponse.maintenance:type_name -> hookly.v1.Maintenance
+	6,  // 9: hookly.v1.StreamResponse.command:type_name -> hookly.v1.HubCommand
+	3,  // 10: hookly.v1.ConnectRequest.event_filters:type_name -> hookly.v1.EventTypeFilter
+	16, // 11: hookly.v1.HubCommand.type:type_name -> hookly.v1.HubCommandType
+	17, // 12: hookly.v1.WebhookEnvelope.received_at:type_name -> google.protobuf.Timestamp
+	14, // 13: hookly.v1.WebhookEnvelope.headers:type_name -> hookly.v1.WebhookEnvelope.HeadersEntry
+	18, // 14: hookly.v1.WebhookEnvelope.transform:type_name -> hookly.v1.Transform
+	19, // 15: hookly.v1.WebhookEnvelope.destinations:type_name -> hookly.v1.Destination
+	11, // 16: hookly.v1.DeliveryAck.destinations:type_name -> hookly.v1.DestinationResult
+	2,  // 17: hookly.v1.RegisterTunnelRequest.connect:type_name -> hookly.v1.ConnectRequest
+	0,  // 18: hookly.v1.RelayService.Stream:input_type -> hookly.v1.StreamRequest
+	12, // 19: hookly.v1.RelayService.RegisterTunnel:input_type -> hookly.v1.RegisterTunnelRequest
+	8,  // 20: hookly.v1.TunnelService.Deliver:input_type -> hookly.v1.WebhookEnvelope
+	1,  // 21: hookly.v1.RelayService.Stream:output_type -> hookly.v1.StreamResponse
+	13, // 22: hookly.v1.RelayService.RegisterTunnel:output_type -> hookly.v1.RegisterTunnelResponse
+	10, // 23: hookly.v1.TunnelService.Deliver:output_type -> hookly.v1.DeliveryAck
+	21, // [21:24] is the sub-list for method output_type
+	18, // [18:21] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_hookly_v1_relay_proto_init() }
@@ -647,15 +1325,20 @@ func file_hookly_v1_relay_proto_init() {
 	if File_hookly_v1_relay_proto != nil {
 		return
 	}
+	file_hookly_v1_common_proto_init()
 	file_hookly_v1_relay_proto_msgTypes[0].OneofWrappers = []any{
 		(*StreamRequest_Connect)(nil),
 		(*StreamRequest_Ack)(nil),
 		(*StreamRequest_Heartbeat)(nil),
+		(*StreamRequest_CommandResult)(nil),
 	}
 	file_hookly_v1_relay_proto_msgTypes[1].OneofWrappers = []any{
 		(*StreamResponse_ConnectResponse)(nil),
 		(*StreamResponse_Webhook)(nil),
 		(*StreamResponse_Heartbeat)(nil),
+		(*StreamResponse_PayloadChunk)(nil),
+		(*StreamResponse_Maintenance)(nil),
+		(*StreamResponse_Command)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -663,9 +1346,9 @@ func file_hookly_v1_relay_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hookly_v1_relay_proto_rawDesc), len(file_hookly_v1_relay_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   15,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
 		GoTypes:           file_hookly_v1_relay_proto_goTypes,
 		DependencyIndexes: file_hookly_v1_relay_proto_depIdxs,
@@ -0,0 +1,84 @@
+// Package audit records management actions — API calls that change
+// something, logins and token revocations — with who made them and from
+// where, for security reviews.
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+
+	"hooks.dx314.com/internal/db"
+)
+
+// Actions recorded outside EdgeService, whose actions are its method names.
+const (
+	ActionLogin        = "Login"        // Web sign-in through GitHub; Result is denied for users not allowed in
+	ActionLogout       = "Logout"       // Web sign-out
+	ActionAuthorizeCLI = "AuthorizeCLI" // 'hookly login' creating a token
+	ActionRevokeToken  = "RevokeApiToken"
+)
+
+// Results of an action other than an error code.
+const (
+	ResultOK     = "ok"
+	ResultDenied = "denied"
+)
+
+// Event is one recorded action.
+type Event struct {
+	UserID   string // Who acted
+	Username string
+	OwnerID  string // Whose endpoints were acted on; UserID if empty
+	TokenID  string // API token used, empty for web sessions
+	Action   string
+	Target   string // ID of what was acted on, if any
+	IP       string
+	Result   string // ResultOK if empty
+}
+
+// Recorder records events in the audit_log table.
+type Recorder struct {
+	queries *db.Queries
+}
+
+// NewRecorder creates a recorder.
+func NewRecorder(queries *db.Queries) *Recorder {
+	return &Recorder{queries: queries}
+}
+
+// Record records an event. Failures are logged: an action isn't failed
+// because it couldn't be recorded. It does nothing on a nil recorder.
+func (r *Recorder) Record(ctx context.Context, ev Event) {
+	if r == nil {
+		return
+	}
+	if ev.OwnerID == "" {
+		ev.OwnerID = ev.UserID
+	}
+	if ev.Result == "" {
+		ev.Result = ResultOK
+	}
+
+	id, err := gonanoid.New()
+	if err != nil {
+		slog.Error("failed to generate audit event id", "error", err)
+		return
+	}
+	// Recorded even when the request was cancelled once the action completed
+	if err := r.queries.RecordAuditEvent(context.WithoutCancel(ctx), db.RecordAuditEventParams{
+		ID:       id,
+		UserID:   ev.UserID,
+		Username: ev.Username,
+		OwnerID:  ev.OwnerID,
+		TokenID:  sql.NullString{String: ev.TokenID, Valid: ev.TokenID != ""},
+		Action:   ev.Action,
+		Target:   ev.Target,
+		Ip:       ev.IP,
+		Result:   ev.Result,
+	}); err != nil {
+		slog.Error("failed to record audit event", "action", ev.Action, "user_id", ev.UserID, "error", err)
+	}
+}
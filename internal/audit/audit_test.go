@@ -0,0 +1,52 @@
+package audit_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"hooks.dx314.com/internal/audit"
+	"hooks.dx314.com/internal/db"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+	queries := db.New(conn)
+	rec := audit.NewRecorder(queries)
+
+	rec.Record(ctx, audit.Event{UserID: "user-1", Username: "alice", Action: audit.ActionLogin, IP: "203.0.113.7"})
+	rec.Record(ctx, audit.Event{UserID: "user-1", Username: "alice", OwnerID: "org:org-1", TokenID: "tok-1", Action: "DeleteEndpoint", Target: "ep-1", Result: "permission_denied"})
+	rec.Record(ctx, audit.Event{UserID: "user-2", Username: "bob", OwnerID: "org:org-1", Action: "CreateEndpoint", Target: "ep-2"})
+
+	// The user's own actions, wherever they were
+	events, err := queries.ListAuditEvents(ctx, db.ListAuditEventsParams{OwnerID: "user-1", Limit: 10})
+	if err != nil {
+		t.Fatalf("list audit events: %v", err)
+	}
+	if len(events) != 2 || events[0].Action != "DeleteEndpoint" || events[1].Action != audit.ActionLogin {
+		t.Fatalf("got %+v, want DeleteEndpoint then Login", events)
+	}
+	if events[1].OwnerID != "user-1" || events[1].Result != audit.ResultOK || events[1].TokenID.Valid || events[1].Ip != "203.0.113.7" {
+		t.Errorf("login event = %+v", events[1])
+	}
+	if !events[0].TokenID.Valid || events[0].Result != "permission_denied" {
+		t.Errorf("delete event = %+v", events[0])
+	}
+
+	// Everyone's actions on the org's endpoints
+	events, err = queries.ListAuditEvents(ctx, db.ListAuditEventsParams{OwnerID: "org:org-1", Limit: 10})
+	if err != nil {
+		t.Fatalf("list audit events: %v", err)
+	}
+	if len(events) != 2 {
+		t.Errorf("expected 2 org events, got %d", len(events))
+	}
+
+	var nilRec *audit.Recorder
+	nilRec.Record(ctx, audit.Event{UserID: "user-1", Action: audit.ActionLogout})
+}
@@ -6,6 +6,8 @@ import (
 	"strings"
 	"sync"
 	"time"
+
+	"hooks.dx314.com/internal/clock"
 )
 
 // Authorizer handles user authorization checks.
@@ -17,6 +19,7 @@ type Authorizer struct {
 	// Cache for org membership checks
 	mu    sync.RWMutex
 	cache map[string]cacheEntry
+	clock clock.Clock
 }
 
 type cacheEntry struct {
@@ -54,9 +57,15 @@ func NewAuthorizer(github *GitHubClient, org string, allowedUsers []string) *Aut
 		org:          org,
 		allowedUsers: allowed,
 		cache:        make(map[string]cacheEntry),
+		clock:        clock.Real,
 	}
 }
 
+// SetClock sets the clock that expires cached membership checks.
+func (a *Authorizer) SetClock(c clock.Clock) {
+	a.clock = c
+}
+
 // IsAuthorized checks if the user is authorized to access the application.
 // Returns true if authorized, false otherwise.
 func (a *Authorizer) IsAuthorized(ctx context.Context, username string, accessToken string) bool {
@@ -94,7 +103,7 @@ func (a *Authorizer) checkOrgMembership(ctx context.Context, username, accessTok
 	entry, ok := a.cache[cacheKey]
 	a.mu.RUnlock()
 
-	if ok && time.Now().Before(entry.expiresAt) {
+	if ok && a.clock.Now().Before(entry.expiresAt) {
 		return entry.member, nil
 	}
 
@@ -108,7 +117,7 @@ func (a *Authorizer) checkOrgMembership(ctx context.Context, username, accessTok
 	a.mu.Lock()
 	a.cache[cacheKey] = cacheEntry{
 		member:    member,
-		expiresAt: time.Now().Add(cacheTTL),
+		expiresAt: a.clock.Now().Add(cacheTTL),
 	}
 	a.mu.Unlock()
 
@@ -5,10 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
+
+	"hooks.dx314.com/internal/audit"
 )
 
 // Handlers provides HTTP handlers for authentication.
@@ -17,6 +21,7 @@ type Handlers struct {
 	sessions   *SessionManager
 	authorizer *Authorizer
 	tokens     *TokenManager
+	audit      *audit.Recorder
 }
 
 // NewHandlers creates new authentication handlers.
@@ -29,6 +34,12 @@ func NewHandlers(github *GitHubClient, sessions *SessionManager, authorizer *Aut
 	}
 }
 
+// SetAuditRecorder records logins, logouts, CLI authorizations and token
+// revocations in the audit log.
+func (h *Handlers) SetAuditRecorder(r *audit.Recorder) {
+	h.audit = r
+}
+
 // Login redirects to GitHub for OAuth.
 // Supports optional return_to parameter to redirect after login.
 func (h *Handlers) Login(w http.ResponseWriter, r *http.Request) {
@@ -103,6 +114,13 @@ func (h *Handlers) Callback(w http.ResponseWriter, r *http.Request) {
 	// Check authorization
 	if !h.authorizer.IsAuthorized(ctx, user.Login, token.AccessToken) {
 		slog.Warn("user not authorized", "username", user.Login)
+		h.audit.Record(ctx, audit.Event{
+			UserID:   strconv.FormatInt(user.ID, 10),
+			Username: user.Login,
+			Action:   audit.ActionLogin,
+			IP:       clientIP(r),
+			Result:   audit.ResultDenied,
+		})
 		http.Error(w, "You are not authorized to access this application", http.StatusForbidden)
 		return
 	}
@@ -117,6 +135,7 @@ func (h *Handlers) Callback(w http.ResponseWriter, r *http.Request) {
 
 	h.sessions.SetSessionCookie(w, session)
 	slog.Info("user logged in", "username", user.Login, "user_id", user.ID)
+	h.audit.Record(ctx, audit.Event{UserID: session.UserID, Username: session.Username, Action: audit.ActionLogin, IP: clientIP(r)})
 
 	// Redirect to return_to or home
 	redirectURL := "/"
@@ -134,6 +153,7 @@ func (h *Handlers) Logout(w http.ResponseWriter, r *http.Request) {
 			slog.Error("failed to delete session", "error", err)
 		}
 		slog.Info("user logged out", "username", session.Username)
+		h.audit.Record(r.Context(), audit.Event{UserID: session.UserID, Username: session.Username, Action: audit.ActionLogout, IP: clientIP(r)})
 	}
 
 	h.sessions.ClearSessionCookie(w)
@@ -225,7 +245,7 @@ func (h *Handlers) CLIAuthorize(w http.ResponseWriter, r *http.Request) {
 	tokenName := fmt.Sprintf("CLI - %s", hostname)
 
 	// Create API token
-	apiToken, _, err := h.tokens.GenerateToken(ctx, session.UserID, session.Username, tokenName)
+	apiToken, tokenInfo, err := h.tokens.GenerateToken(ctx, session.UserID, session.Username, tokenName)
 	if err != nil {
 		slog.Error("failed to create API token", "error", err)
 		http.Error(w, "Failed to create API token", http.StatusInternalServerError)
@@ -233,6 +253,13 @@ func (h *Handlers) CLIAuthorize(w http.ResponseWriter, r *http.Request) {
 	}
 
 	slog.Info("CLI authorized", "username", session.Username, "user_id", session.UserID)
+	h.audit.Record(ctx, audit.Event{
+		UserID:   session.UserID,
+		Username: session.Username,
+		Action:   audit.ActionAuthorizeCLI,
+		Target:   tokenInfo.ID,
+		IP:       clientIP(r),
+	})
 
 	// Redirect to CLI callback with token
 	callbackURL := fmt.Sprintf("http://localhost:%s/callback?token=%s&state=%s&user_id=%s&username=%s",
@@ -291,7 +318,23 @@ func (h *Handlers) RevokeToken(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to revoke token", http.StatusInternalServerError)
 		return
 	}
+	h.audit.Record(ctx, audit.Event{
+		UserID:   session.UserID,
+		Username: session.Username,
+		Action:   audit.ActionRevokeToken,
+		Target:   tokenID,
+		IP:       clientIP(r),
+	})
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// clientIP returns the client IP of r for the audit log. The server's realIP
+// middleware has already resolved RemoteAddr through the trusted proxies;
+// outside it, RemoteAddr still has a port.
+func clientIP(r *http.Request) string {
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
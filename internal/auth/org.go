@@ -0,0 +1,91 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"hooks.dx314.com/internal/db"
+)
+
+// Org membership roles, stored in org_members.role.
+const (
+	RoleOwner  = "owner"  // Everything, including members and deleting the org
+	RoleMember = "member" // Manage the org's endpoints and relay its webhooks
+	RoleViewer = "viewer" // Read-only access to the org's endpoints and webhooks
+)
+
+// OrgHeader selects the org a request acts for. Without it, requests act
+// for the user's own endpoints.
+const OrgHeader = "Hookly-Org"
+
+// orgOwnerPrefix marks an org's ID stored as the owner of its endpoints in
+// endpoints.user_id, so queries scoped to an owner work for orgs unchanged.
+const orgOwnerPrefix = "org:"
+
+// ErrNotOrgMember is returned when a user isn't a member of an org.
+var ErrNotOrgMember = errors.New("not a member of this org")
+
+// ValidRole reports whether s is an org role.
+func ValidRole(s string) bool {
+	switch s {
+	case RoleOwner, RoleMember, RoleViewer:
+		return true
+	}
+	return false
+}
+
+// OrgOwnerID returns the owner ID of an org's endpoints.
+func OrgOwnerID(orgID string) string {
+	return orgOwnerPrefix + orgID
+}
+
+// OrgFromOwnerID returns the org ID of an endpoint owner ID, and whether the
+// owner is an org rather than a user.
+func OrgFromOwnerID(ownerID string) (string, bool) {
+	return strings.CutPrefix(ownerID, orgOwnerPrefix)
+}
+
+// RoleCanWrite reports whether an org role may change the org's endpoints
+// and relay its webhooks.
+func RoleCanWrite(role string) bool {
+	return role == RoleOwner || role == RoleMember
+}
+
+// OrgResolver looks up org memberships.
+type OrgResolver struct {
+	queries *db.Queries
+}
+
+// NewOrgResolver creates an org resolver.
+func NewOrgResolver(queries *db.Queries) *OrgResolver {
+	return &OrgResolver{queries: queries}
+}
+
+// Role returns a user's role in an org, or ErrNotOrgMember.
+func (r *OrgResolver) Role(ctx context.Context, userID, orgID string) (string, error) {
+	member, err := r.queries.GetOrgMember(ctx, db.GetOrgMemberParams{OrgID: orgID, UserID: userID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrNotOrgMember
+	}
+	if err != nil {
+		return "", err
+	}
+	return member.Role, nil
+}
+
+// Select sets the org a session acts for after checking the user's
+// membership. An empty orgID leaves the session acting for the user.
+func (r *OrgResolver) Select(ctx context.Context, session *Session, orgID string) error {
+	if orgID == "" {
+		return nil
+	}
+	role, err := r.Role(ctx, session.UserID, orgID)
+	if err != nil {
+		return err
+	}
+	session.OrgID = orgID
+	session.OrgRole = role
+	return nil
+}
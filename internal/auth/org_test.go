@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"hooks.dx314.com/internal/db"
+)
+
+func TestOrgResolver(t *testing.T) {
+	conn, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	defer conn.Close()
+	schema := `
+		CREATE TABLE org_members (
+			org_id TEXT NOT NULL,
+			user_id TEXT NOT NULL,
+			username TEXT NOT NULL DEFAULT '',
+			role TEXT NOT NULL,
+			created_at TEXT NOT NULL DEFAULT (datetime('now')),
+			PRIMARY KEY (org_id, user_id)
+		);
+	`
+	if _, err := conn.Exec(schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+	queries := db.New(conn)
+	ctx := context.Background()
+	if _, err := queries.UpsertOrgMember(ctx, db.UpsertOrgMemberParams{OrgID: "org-1", UserID: "12345", Username: "alice", Role: RoleViewer}); err != nil {
+		t.Fatalf("add member: %v", err)
+	}
+
+	resolver := NewOrgResolver(queries)
+	session := &Session{UserID: "12345"}
+	if err := resolver.Select(ctx, session, ""); err != nil || session.OwnerID() != "12345" {
+		t.Fatalf("no org: owner %q, %v", session.OwnerID(), err)
+	}
+	if err := resolver.Select(ctx, &Session{UserID: "67890"}, "org-1"); !errors.Is(err, ErrNotOrgMember) {
+		t.Errorf("expected ErrNotOrgMember for a non-member, got %v", err)
+	}
+	if err := resolver.Select(ctx, session, "org-1"); err != nil {
+		t.Fatalf("select org: %v", err)
+	}
+	if session.OwnerID() != "org:org-1" || session.OrgRole != RoleViewer {
+		t.Errorf("owner %q role %q, want org:org-1 viewer", session.OwnerID(), session.OrgRole)
+	}
+	if orgID, ok := OrgFromOwnerID(session.OwnerID()); !ok || orgID != "org-1" {
+		t.Errorf("OrgFromOwnerID = %q, %v", orgID, ok)
+	}
+	if _, ok := OrgFromOwnerID("12345"); ok {
+		t.Error("a user ID should not be an org owner ID")
+	}
+
+	// Viewers only read, even with a browser session or admin token
+	if !ScopeAllows(session, "/hookly.v1.EdgeService/ListEndpoints") || ScopeAllows(session, "/hookly.v1.EdgeService/DeleteEndpoint") {
+		t.Error("viewers should only read")
+	}
+	member := &Session{UserID: "12345", APIToken: true, Scope: ScopeAdmin, OrgID: "org-1", OrgRole: RoleMember}
+	if !ScopeAllows(member, "/hookly.v1.EdgeService/DeleteEndpoint") {
+		t.Error("members should write")
+	}
+}
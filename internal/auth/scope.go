@@ -0,0 +1,75 @@
+package auth
+
+import (
+	"net/http"
+	"strings"
+)
+
+// API token scopes, stored in api_tokens.scope.
+const (
+	ScopeAdmin = "admin" // Every API call and relay connections; tokens from 'hookly login'
+	ScopeRead  = "read"  // Read-only API calls, for dashboards and scripts
+	ScopeRelay = "relay" // Relay connections only, for hubs on servers
+)
+
+// readPrefixes are the method name prefixes of read-only EdgeService
+// procedures.
+var readPrefixes = []string{"Get", "List", "Tail"}
+
+// ValidScope reports whether s is a token scope.
+func ValidScope(s string) bool {
+	switch s {
+	case ScopeAdmin, ScopeRead, ScopeRelay:
+		return true
+	}
+	return false
+}
+
+// ScopeAllows reports whether a session may call a Connect procedure, such
+// as /hookly.v1.EdgeService/ListEndpoints. Procedures whose method starts
+// with Get, List or Tail are reads.
+func ScopeAllows(session *Session, procedure string) bool {
+	return scopeAllows(session, IsReadProcedure(procedure))
+}
+
+// IsReadProcedure reports whether a Connect procedure only reads: its method
+// starts with Get, List or Tail.
+func IsReadProcedure(procedure string) bool {
+	method := procedure[strings.LastIndex(procedure, "/")+1:]
+	for _, prefix := range readPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ScopeAllowsHTTP reports whether a session may make a plain HTTP request
+// with method. GET and HEAD requests are reads.
+func ScopeAllowsHTTP(session *Session, method string) bool {
+	return scopeAllows(session, method == http.MethodGet || method == http.MethodHead)
+}
+
+// scopeAllows lets browser sessions and admin tokens do anything, read
+// tokens read, and relay tokens, which only connect hubs, nothing. Org
+// viewers only read, whatever their credentials.
+func scopeAllows(session *Session, read bool) bool {
+	if session.OrgID != "" && !RoleCanWrite(session.OrgRole) && !read {
+		return false
+	}
+	if !session.APIToken {
+		return true
+	}
+	switch session.Scope {
+	case ScopeAdmin:
+		return true
+	case ScopeRead:
+		return read
+	}
+	return false
+}
+
+// ScopeAllowsRelay reports whether a token with scope may connect a hub.
+func ScopeAllowsRelay(scope string) bool {
+	return scope == ScopeAdmin || scope == ScopeRelay
+}
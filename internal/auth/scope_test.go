@@ -0,0 +1,37 @@
+package auth
+
+import "testing"
+
+func TestScopeAllows(t *testing.T) {
+	browser := &Session{UserID: "12345"}
+	admin := &Session{UserID: "12345", APIToken: true, Scope: ScopeAdmin}
+	read := &Session{UserID: "12345", APIToken: true, Scope: ScopeRead}
+	relay := &Session{UserID: "12345", APIToken: true, Scope: ScopeRelay}
+
+	tests := []struct {
+		session   *Session
+		procedure string
+		want      bool
+	}{
+		{browser, "/hookly.v1.EdgeService/DeleteEndpoint", true},
+		{admin, "/hookly.v1.EdgeService/DeleteEndpoint", true},
+		{read, "/hookly.v1.EdgeService/ListEndpoints", true},
+		{read, "/hookly.v1.EdgeService/GetWebhook", true},
+		{read, "/hookly.v1.EdgeService/TailWebhooks", true},
+		{read, "/hookly.v1.EdgeService/CreateApiToken", false},
+		{read, "/hookly.v1.EdgeService/ReplayWebhook", false},
+		{relay, "/hookly.v1.EdgeService/ListEndpoints", false},
+	}
+	for _, tt := range tests {
+		if got := ScopeAllows(tt.session, tt.procedure); got != tt.want {
+			t.Errorf("ScopeAllows(%q, %q) = %v, want %v", tt.session.Scope, tt.procedure, got, tt.want)
+		}
+	}
+
+	if !ScopeAllowsHTTP(read, "GET") || ScopeAllowsHTTP(read, "POST") || ScopeAllowsHTTP(relay, "GET") {
+		t.Error("ScopeAllowsHTTP: read tokens should only make GET requests and relay tokens none")
+	}
+	if !ScopeAllowsRelay(ScopeAdmin) || !ScopeAllowsRelay(ScopeRelay) || ScopeAllowsRelay(ScopeRead) {
+		t.Error("ScopeAllowsRelay: only admin and relay tokens should connect hubs")
+	}
+}
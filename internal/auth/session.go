@@ -34,6 +34,25 @@ type Session struct {
 	AvatarURL string
 	CreatedAt time.Time
 	ExpiresAt time.Time
+	// APIToken is set when the request authenticated with an API token
+	// instead of a browser session cookie.
+	APIToken bool
+	// Scope is the API token's scope, see ScopeAllows; empty for browser
+	// sessions, which can do everything.
+	Scope string
+	// OrgID and OrgRole are set when the request acts for an org, selected
+	// with OrgHeader.
+	OrgID   string
+	OrgRole string
+}
+
+// OwnerID returns the owner of the endpoints the session acts on: the org
+// selected for the request, or the user.
+func (s *Session) OwnerID() string {
+	if s.OrgID != "" {
+		return OrgOwnerID(s.OrgID)
+	}
+	return s.UserID
 }
 
 // SessionManager handles session creation and validation.
@@ -101,7 +120,10 @@ func (m *SessionManager) CreateSession(ctx context.Context, user *GitHubUser) (*
 		fmt.Printf("warning: failed to upsert user settings: %v\n", err)
 	}
 
-	expiresAt, _ := time.Parse("2006-01-02 15:04:05", dbSession.ExpiresAt)
+	expiresAt, err := db.ParseTime(dbSession.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("parse session expiry: %w", err)
+	}
 
 	return &Session{
 		ID:        dbSession.ID,
@@ -130,7 +152,10 @@ func (m *SessionManager) GetSession(ctx context.Context, sessionID string) (*Ses
 		return nil, fmt.Errorf("get session: %w", err)
 	}
 
-	expiresAt, _ := time.Parse("2006-01-02 15:04:05", dbSession.ExpiresAt)
+	expiresAt, err := db.ParseTime(dbSession.ExpiresAt)
+	if err != nil {
+		return nil, fmt.Errorf("parse session expiry: %w", err)
+	}
 
 	return &Session{
 		ID:        dbSession.ID,
@@ -9,11 +9,16 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"log/slog"
 	"strings"
+	"sync"
+	"time"
 
 	gonanoid "github.com/matoous/go-nanoid/v2"
 
+	"hooks.dx314.com/internal/clock"
 	"hooks.dx314.com/internal/db"
+	"hooks.dx314.com/internal/jobs"
 )
 
 const (
@@ -21,27 +26,74 @@ const (
 	TokenPrefix = "hk_"
 	// TokenByteLength is the length of the random bytes in a token.
 	TokenByteLength = 32
+
+	// JobTokenLastUsed is the job kind that records a token's last use.
+	JobTokenLastUsed = "token_last_used"
+
+	// lastUsedResolution is how stale last_used_at may get. A token's use
+	// is recorded at most this often, so busy API, MCP and relay clients
+	// don't cost a write per call.
+	lastUsedResolution = time.Minute
 )
 
 var (
-	ErrInvalidToken = errors.New("invalid token format")
-	ErrTokenRevoked = errors.New("token has been revoked")
+	ErrInvalidToken  = errors.New("invalid token format")
+	ErrTokenRevoked  = errors.New("token has been revoked")
 	ErrTokenNotFound = errors.New("token not found")
+	ErrTokenExpired  = errors.New("token has expired")
 )
 
 // TokenManager handles API token operations.
 type TokenManager struct {
 	queries *db.Queries
+	jobs    *jobs.Queue
+	clock   clock.Clock
+
+	mu       sync.Mutex
+	lastUsed map[string]time.Time // When each token's use was last recorded
 }
 
 // NewTokenManager creates a new TokenManager.
 func NewTokenManager(queries *db.Queries) *TokenManager {
-	return &TokenManager{queries: queries}
+	return &TokenManager{queries: queries, clock: clock.Real, lastUsed: make(map[string]time.Time)}
+}
+
+// SetClock sets the clock that tokens expire by.
+func (m *TokenManager) SetClock(c clock.Clock) {
+	m.clock = c
+}
+
+// SetJobQueue records last-used updates through the job queue instead of a
+// goroutine, so they are retried and not lost on shutdown.
+func (m *TokenManager) SetJobQueue(q *jobs.Queue) {
+	m.jobs = q
+	q.Register(JobTokenLastUsed, func(ctx context.Context, payload []byte) error {
+		job, err := jobs.Decode[tokenLastUsedJob](payload)
+		if err != nil {
+			return err
+		}
+		return m.queries.UpdateAPITokenLastUsed(ctx, job.TokenID)
+	})
+}
+
+type tokenLastUsedJob struct {
+	TokenID string `json:"token_id"`
 }
 
-// GenerateToken creates a new API token and stores its hash.
+// GenerateToken creates a new API token with full access that doesn't
+// expire, and stores its hash.
 // Returns the plaintext token (which should be shown to the user once) and the database record.
 func (m *TokenManager) GenerateToken(ctx context.Context, userID, username, name string) (string, *db.ApiToken, error) {
+	return m.GenerateScopedToken(ctx, userID, username, name, ScopeAdmin, time.Time{})
+}
+
+// GenerateScopedToken creates a new API token limited to scope, expiring at
+// expiresAt unless it is zero.
+func (m *TokenManager) GenerateScopedToken(ctx context.Context, userID, username, name, scope string, expiresAt time.Time) (string, *db.ApiToken, error) {
+	if !ValidScope(scope) {
+		return "", nil, fmt.Errorf("invalid scope %q", scope)
+	}
+
 	// Generate random bytes for token
 	tokenBytes := make([]byte, TokenByteLength)
 	if _, err := rand.Read(tokenBytes); err != nil {
@@ -61,13 +113,18 @@ func (m *TokenManager) GenerateToken(ctx context.Context, userID, username, name
 	}
 
 	// Store in database
-	token, err := m.queries.CreateAPIToken(ctx, db.CreateAPITokenParams{
+	params := db.CreateAPITokenParams{
 		ID:        id,
 		UserID:    userID,
 		Username:  username,
 		TokenHash: hash,
 		Name:      name,
-	})
+		Scope:     scope,
+	}
+	if !expiresAt.IsZero() {
+		params.ExpiresAt = sql.NullString{String: db.FormatTime(expiresAt), Valid: true}
+	}
+	token, err := m.queries.CreateAPIToken(ctx, params)
 	if err != nil {
 		return "", nil, fmt.Errorf("create token: %w", err)
 	}
@@ -76,7 +133,7 @@ func (m *TokenManager) GenerateToken(ctx context.Context, userID, username, name
 }
 
 // ValidateToken checks if a token is valid and returns the associated user info.
-// Also updates the last_used_at timestamp.
+// Also updates the last_used_at timestamp, at most once per lastUsedResolution.
 func (m *TokenManager) ValidateToken(ctx context.Context, plaintext string) (*db.ApiToken, error) {
 	if !strings.HasPrefix(plaintext, TokenPrefix) {
 		return nil, ErrInvalidToken
@@ -95,15 +152,90 @@ func (m *TokenManager) ValidateToken(ctx context.Context, plaintext string) (*db
 	if token.Revoked != 0 {
 		return nil, ErrTokenRevoked
 	}
+	if token.ExpiresAt.Valid {
+		// An unreadable expiry fails closed
+		expiresAt, err := db.ParseTime(token.ExpiresAt.String)
+		if err != nil || !m.clock.Now().Before(expiresAt) {
+			return nil, ErrTokenExpired
+		}
+	}
 
-	// Update last used (fire-and-forget, don't fail on error)
-	go func() {
-		_ = m.queries.UpdateAPITokenLastUsed(context.Background(), token.ID)
-	}()
+	// Update last used (don't fail validation on error)
+	if !m.shouldRecordUse(&token, m.clock.Now()) {
+		return &token, nil
+	}
+	if m.jobs != nil {
+		if err := m.jobs.Enqueue(ctx, JobTokenLastUsed, tokenLastUsedJob{TokenID: token.ID}); err != nil {
+			slog.Error("failed to enqueue token last used update", "token_id", token.ID, "error", err)
+		}
+	} else {
+		go func() {
+			_ = m.queries.UpdateAPITokenLastUsed(context.Background(), token.ID)
+		}()
+	}
 
 	return &token, nil
 }
 
+// shouldRecordUse reports whether a use of token at now should update its
+// last_used_at: when neither the stored time nor an update recorded since is
+// within lastUsedResolution of now.
+func (m *TokenManager) shouldRecordUse(token *db.ApiToken, now time.Time) bool {
+	if last, ok := db.ParseNullTime(token.LastUsedAt); ok && now.Sub(last) < lastUsedResolution {
+		return false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if last, ok := m.lastUsed[token.ID]; ok && now.Sub(last) < lastUsedResolution {
+		return false
+	}
+	if len(m.lastUsed) >= 1000 {
+		// Updates older than the resolution no longer skip anything
+		for id, last := range m.lastUsed {
+			if now.Sub(last) >= lastUsedResolution {
+				delete(m.lastUsed, id)
+			}
+		}
+	}
+	m.lastUsed[token.ID] = now
+	return true
+}
+
+// RotateToken replaces one of a user's tokens with a new one of the same
+// name and scope, and revokes it. A token that expires gets the same
+// lifetime again from now. Returns the new plaintext token and record.
+func (m *TokenManager) RotateToken(ctx context.Context, userID, tokenID string) (string, *db.ApiToken, error) {
+	old, err := m.queries.GetAPIToken(ctx, db.GetAPITokenParams{ID: tokenID, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", nil, ErrTokenNotFound
+		}
+		return "", nil, fmt.Errorf("get token: %w", err)
+	}
+	if old.Revoked != 0 {
+		return "", nil, ErrTokenRevoked
+	}
+
+	var expiresAt time.Time
+	if oldExpiry, ok := db.ParseNullTime(old.ExpiresAt); ok {
+		createdAt, err := db.ParseTime(old.CreatedAt)
+		if err != nil {
+			return "", nil, fmt.Errorf("parse token creation time: %w", err)
+		}
+		expiresAt = m.clock.Now().Add(oldExpiry.Sub(createdAt))
+	}
+
+	plaintext, token, err := m.GenerateScopedToken(ctx, userID, old.Username, old.Name, old.Scope, expiresAt)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := m.queries.RevokeAPIToken(ctx, old.ID); err != nil {
+		return "", nil, fmt.Errorf("revoke rotated token: %w", err)
+	}
+	return plaintext, token, nil
+}
+
 // RevokeToken revokes a specific token by ID.
 func (m *TokenManager) RevokeToken(ctx context.Context, tokenID string) error {
 	return m.queries.RevokeAPIToken(ctx, tokenID)
@@ -130,6 +262,7 @@ type TokenInfo struct {
 	TokenID  string
 	UserID   string
 	Username string
+	Scope    string
 }
 
 // ToSession converts TokenInfo to a Session for compatibility with existing code.
@@ -138,5 +271,7 @@ func (t *TokenInfo) ToSession() *Session {
 		ID:       t.TokenID,
 		UserID:   t.UserID,
 		Username: t.Username,
+		APIToken: true,
+		Scope:    t.Scope,
 	}
 }
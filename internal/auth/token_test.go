@@ -3,10 +3,13 @@ package auth
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"testing"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 
+	"hooks.dx314.com/internal/clock"
 	"hooks.dx314.com/internal/db"
 )
 
@@ -28,7 +31,9 @@ func setupTestDB(t *testing.T) (*db.Queries, func()) {
 			name TEXT NOT NULL,
 			created_at TEXT NOT NULL DEFAULT (datetime('now')),
 			last_used_at TEXT,
-			revoked INTEGER NOT NULL DEFAULT 0
+			revoked INTEGER NOT NULL DEFAULT 0,
+			scope TEXT NOT NULL DEFAULT 'admin',
+			expires_at TEXT
 		);
 		CREATE INDEX idx_api_tokens_hash ON api_tokens(token_hash);
 	`
@@ -221,6 +226,132 @@ func TestGetUserTokens(t *testing.T) {
 	}
 }
 
+func TestScopedTokens(t *testing.T) {
+	queries, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	mgr := NewTokenManager(queries)
+	ctx := context.Background()
+
+	plaintext, token, err := mgr.GenerateScopedToken(ctx, "12345", "testuser", "dashboard", ScopeRead, time.Time{})
+	if err != nil {
+		t.Fatalf("GenerateScopedToken: %v", err)
+	}
+	if token.Scope != ScopeRead || token.ExpiresAt.Valid {
+		t.Errorf("token: got scope %q, expires %v", token.Scope, token.ExpiresAt)
+	}
+	validated, err := mgr.ValidateToken(ctx, plaintext)
+	if err != nil {
+		t.Fatalf("ValidateToken: %v", err)
+	}
+	if validated.Scope != ScopeRead {
+		t.Errorf("validated scope: got %q, want %q", validated.Scope, ScopeRead)
+	}
+
+	_, token, err = mgr.GenerateToken(ctx, "12345", "testuser", "CLI")
+	if err != nil {
+		t.Fatalf("GenerateToken: %v", err)
+	}
+	if token.Scope != ScopeAdmin {
+		t.Errorf("GenerateToken scope: got %q, want %q", token.Scope, ScopeAdmin)
+	}
+
+	if _, _, err := mgr.GenerateScopedToken(ctx, "12345", "testuser", "bad", "write", time.Time{}); err == nil {
+		t.Error("GenerateScopedToken should reject an unknown scope")
+	}
+}
+
+func TestTokenExpiry(t *testing.T) {
+	queries, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clk := clock.NewFake(time.Now())
+	mgr := NewTokenManager(queries)
+	mgr.SetClock(clk)
+	ctx := context.Background()
+
+	plaintext, _, err := mgr.GenerateScopedToken(ctx, "12345", "testuser", "ci", ScopeRelay, clk.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("GenerateScopedToken: %v", err)
+	}
+	if _, err := mgr.ValidateToken(ctx, plaintext); err != nil {
+		t.Fatalf("ValidateToken before expiry: %v", err)
+	}
+
+	clk.Advance(time.Hour)
+	if _, err := mgr.ValidateToken(ctx, plaintext); !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("ValidateToken after expiry: got %v, want ErrTokenExpired", err)
+	}
+}
+
+func TestTokenLastUsedCoalesced(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC))
+	mgr := NewTokenManager(nil)
+	mgr.SetClock(clk)
+
+	token := &db.ApiToken{ID: "tok"}
+	if !mgr.shouldRecordUse(token, clk.Now()) {
+		t.Fatal("first use not recorded")
+	}
+	clk.Advance(30 * time.Second)
+	if mgr.shouldRecordUse(token, clk.Now()) {
+		t.Error("use recorded again within the resolution")
+	}
+	clk.Advance(30 * time.Second)
+	if !mgr.shouldRecordUse(token, clk.Now()) {
+		t.Error("use not recorded after the resolution")
+	}
+
+	// A recent stored last_used_at, e.g. from before a restart, skips too
+	stored := &db.ApiToken{ID: "other", LastUsedAt: sql.NullString{String: db.FormatTime(clk.Now().Add(-10 * time.Second)), Valid: true}}
+	if mgr.shouldRecordUse(stored, clk.Now()) {
+		t.Error("use recorded though last_used_at is recent")
+	}
+}
+
+func TestRotateToken(t *testing.T) {
+	queries, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	clk := clock.NewFake(time.Now())
+	mgr := NewTokenManager(queries)
+	mgr.SetClock(clk)
+	ctx := context.Background()
+
+	oldPlaintext, old, err := mgr.GenerateScopedToken(ctx, "12345", "testuser", "ci", ScopeRelay, clk.Now().Add(24*time.Hour))
+	if err != nil {
+		t.Fatalf("GenerateScopedToken: %v", err)
+	}
+
+	clk.Advance(12 * time.Hour)
+	plaintext, token, err := mgr.RotateToken(ctx, "12345", old.ID)
+	if err != nil {
+		t.Fatalf("RotateToken: %v", err)
+	}
+	if token.ID == old.ID || token.Name != "ci" || token.Scope != ScopeRelay {
+		t.Errorf("rotated token: got id %q name %q scope %q", token.ID, token.Name, token.Scope)
+	}
+	// The new token gets the old one's 24h lifetime from now
+	expiresAt, ok := db.ParseNullTime(token.ExpiresAt)
+	if want := clk.Now().Add(24 * time.Hour); !ok || expiresAt.Sub(want).Abs() > 2*time.Second {
+		t.Errorf("rotated token expires at %v, want about %v", expiresAt, want)
+	}
+
+	if _, err := mgr.ValidateToken(ctx, plaintext); err != nil {
+		t.Errorf("ValidateToken of rotated token: %v", err)
+	}
+	if _, err := mgr.ValidateToken(ctx, oldPlaintext); err == nil {
+		t.Error("old token should be revoked after rotation")
+	}
+
+	if _, _, err := mgr.RotateToken(ctx, "12345", old.ID); !errors.Is(err, ErrTokenRevoked) {
+		t.Errorf("RotateToken of revoked token: got %v, want ErrTokenRevoked", err)
+	}
+	if _, _, err := mgr.RotateToken(ctx, "67890", token.ID); !errors.Is(err, ErrTokenNotFound) {
+		t.Errorf("RotateToken of another user's token: got %v, want ErrTokenNotFound", err)
+	}
+}
+
 func TestHashToken(t *testing.T) {
 	// Same input should produce same hash
 	hash1 := hashToken("hk_test_token")
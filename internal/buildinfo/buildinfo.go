@@ -0,0 +1,147 @@
+// Package buildinfo identifies the running binary. Release builds set the
+// variables with ldflags:
+//
+//	go build -ldflags "-X hooks.dx314.com/internal/buildinfo.Version=1.2.0 \
+//	  -X hooks.dx314.com/internal/buildinfo.Commit=$(git rev-parse HEAD) \
+//	  -X hooks.dx314.com/internal/buildinfo.Date=$(date -u +%Y-%m-%dT%H:%M:%SZ)"
+//
+// Without them, Get falls back to what the Go toolchain embeds: the module
+// version for 'go install ...@v1.2.0', and the VCS revision and commit time
+// for builds from a checkout, which are versioned DevVersion.
+package buildinfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"runtime/debug"
+	"strconv"
+	"strings"
+)
+
+// Set with -ldflags -X; see the package comment.
+var (
+	Version = ""
+	Commit  = ""
+	Date    = "" // RFC3339
+)
+
+// DevVersion is the version of builds that don't set one.
+const DevVersion = "dev"
+
+// Info identifies a build.
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit,omitempty"`
+	Date      string `json:"date,omitempty"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build info of the running binary.
+func Get() Info {
+	info := Info{Version: Version, Commit: Commit, Date: Date}
+	if bi, ok := debug.ReadBuildInfo(); ok {
+		info.GoVersion = bi.GoVersion
+		if info.Version == "" && isRelease(bi.Main.Version) {
+			info.Version = strings.TrimPrefix(bi.Main.Version, "v")
+		}
+		for _, s := range bi.Settings {
+			switch {
+			case s.Key == "vcs.revision" && info.Commit == "":
+				info.Commit = s.Value
+			case s.Key == "vcs.time" && info.Date == "":
+				info.Date = s.Value
+			}
+		}
+	}
+	if info.Version == "" {
+		info.Version = DevVersion
+	}
+	return info
+}
+
+// pseudoVersion matches the timestamp and commit of Go pseudo-versions,
+// such as v0.0.0-20261014163202-4fa7ec4bd61b.
+var pseudoVersion = regexp.MustCompile(`\d{14}-[0-9a-f]{12}$`)
+
+// isRelease reports whether a module version names a release, rather than
+// a local build, which Go stamps with "(devel)" or a pseudo-version.
+func isRelease(v string) bool {
+	return v != "" && v != "(devel)" && !strings.Contains(v, "+dirty") && !pseudoVersion.MatchString(v)
+}
+
+// ServeHTTP serves the info as JSON, for /version.
+func (i Info) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(i)
+}
+
+// String formats the info for --version: the version with the short commit
+// and date, if known.
+func (i Info) String() string {
+	var extra []string
+	if i.Commit != "" {
+		extra = append(extra, ShortCommit(i.Commit))
+	}
+	if i.Date != "" {
+		extra = append(extra, i.Date)
+	}
+	if len(extra) == 0 {
+		return i.Version
+	}
+	return fmt.Sprintf("%s (%s)", i.Version, strings.Join(extra, ", "))
+}
+
+// ShortCommit abbreviates a commit hash to 7 characters.
+func ShortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
+
+// Compare compares two versions like 1.2.3 or v1.2.3, ignoring pre-release
+// and build suffixes. It returns -1, 0 or 1, and false if either isn't a
+// release version, such as DevVersion.
+func Compare(a, b string) (int, bool) {
+	va, ok := parse(a)
+	if !ok {
+		return 0, false
+	}
+	vb, ok := parse(b)
+	if !ok {
+		return 0, false
+	}
+	for i := range va {
+		switch {
+		case va[i] < vb[i]:
+			return -1, true
+		case va[i] > vb[i]:
+			return 1, true
+		}
+	}
+	return 0, true
+}
+
+// parse splits a version into its major, minor and patch numbers. Minor and
+// patch default to 0.
+func parse(v string) ([3]int, bool) {
+	var parts [3]int
+	v = strings.TrimPrefix(v, "v")
+	if i := strings.IndexAny(v, "-+"); i >= 0 {
+		v = v[:i]
+	}
+	fields := strings.Split(v, ".")
+	if len(fields) > 3 {
+		return parts, false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil || n < 0 {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
@@ -0,0 +1,67 @@
+package buildinfo
+
+import "testing"
+
+func TestCompare(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+		ok   bool
+	}{
+		{"1.2.3", "1.2.3", 0, true},
+		{"v1.2.3", "1.2.4", -1, true},
+		{"1.10.0", "1.9.9", 1, true},
+		{"2", "1.9", 1, true},
+		{"1.2.0-rc.1", "1.2.0", 0, true},
+		{"dev", "1.2.0", 0, false},
+		{"1.2.0", "", 0, false},
+		{"1.2.3.4", "1.2.3", 0, false},
+	} {
+		got, ok := Compare(tt.a, tt.b)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Compare(%q, %q) = %d, %v; want %d, %v", tt.a, tt.b, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestInfoString(t *testing.T) {
+	for _, tt := range []struct {
+		info Info
+		want string
+	}{
+		{Info{Version: "dev"}, "dev"},
+		{Info{Version: "1.2.0", Commit: "0123456789abcdef", Date: "2026-10-14T09:00:00Z"}, "1.2.0 (0123456, 2026-10-14T09:00:00Z)"},
+		{Info{Version: "1.2.0", Commit: "abc"}, "1.2.0 (abc)"},
+	} {
+		if got := tt.info.String(); got != tt.want {
+			t.Errorf("%+v: got %q, want %q", tt.info, got, tt.want)
+		}
+	}
+}
+
+func TestIsRelease(t *testing.T) {
+	for v, want := range map[string]bool{
+		"v1.2.0":                             true,
+		"v1.2.0-rc.1":                        true,
+		"(devel)":                            false,
+		"":                                   false,
+		"v0.0.0-20261014163202-4fa7ec4bd61b": false,
+		"v0.0.0-20261014163202-4fa7ec4bd61b+dirty":  false,
+		"v1.2.1-0.20261014163202-4fa7ec4bd61b":      false,
+		"v1.3.0-rc.1.0.20261014163202-4fa7ec4bd61b": false,
+	} {
+		if got := isRelease(v); got != want {
+			t.Errorf("isRelease(%q) = %v, want %v", v, got, want)
+		}
+	}
+}
+
+func TestGetDefaultsToDev(t *testing.T) {
+	if Version != "" {
+		t.Skip("built with a version")
+	}
+	// Test binaries have no module version
+	if got := Get().Version; got != DevVersion {
+		t.Errorf("version = %q, want %q", got, DevVersion)
+	}
+}
@@ -2,14 +2,20 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
 	"connectrpc.com/connect"
 
 	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/buildinfo"
+	"hooks.dx314.com/internal/telemetry"
 )
 
 func TestCredentialsManager(t *testing.T) {
@@ -133,6 +139,103 @@ func TestCredentialsEncryption(t *testing.T) {
 	}
 }
 
+func TestCredentialsForEdge(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", tempDir)
+
+	mgr, err := NewCredentialsManager()
+	if err != nil {
+		t.Fatalf("NewCredentialsManager: %v", err)
+	}
+
+	defaultCreds := &Credentials{
+		EdgeURL:   "https://hooks.dx314.com",
+		APIToken:  "hk_default_token",
+		Username:  "testuser",
+		CreatedAt: time.Now(),
+	}
+	if err := mgr.Save(defaultCreds); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	stagingCreds := &Credentials{
+		EdgeURL:   "https://staging.example.com:8443",
+		APIToken:  "hk_staging_token",
+		Username:  "testuser",
+		CreatedAt: time.Now(),
+	}
+	if err := mgr.SaveForEdge(stagingCreds); err != nil {
+		t.Fatalf("SaveForEdge: %v", err)
+	}
+
+	tests := []struct {
+		edgeURL   string
+		wantToken string
+	}{
+		{"https://hooks.dx314.com", "hk_default_token"},
+		{"https://HOOKS.dx314.com/", "hk_default_token"},
+		{"https://staging.example.com:8443", "hk_staging_token"},
+		{"https://other.example.com", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.edgeURL, func(t *testing.T) {
+			creds, err := mgr.LoadForEdge(tt.edgeURL)
+			if err != nil {
+				t.Fatalf("LoadForEdge: %v", err)
+			}
+			if tt.wantToken == "" {
+				if creds != nil {
+					t.Errorf("expected nil credentials, got %+v", creds)
+				}
+				return
+			}
+			if creds == nil {
+				t.Fatal("expected credentials, got nil")
+			}
+			if creds.APIToken != tt.wantToken {
+				t.Errorf("APIToken: got %q, want %q", creds.APIToken, tt.wantToken)
+			}
+		})
+	}
+
+	// Deleting per-edge credentials must keep the default credentials
+	if err := mgr.DeleteForEdge(stagingCreds.EdgeURL); err != nil {
+		t.Fatalf("DeleteForEdge: %v", err)
+	}
+	if creds, _ := mgr.LoadForEdge(stagingCreds.EdgeURL); creds != nil {
+		t.Errorf("expected staging credentials to be removed, got %+v", creds)
+	}
+	if creds, _ := mgr.Load(); creds == nil {
+		t.Error("default credentials were removed")
+	}
+}
+
+func TestCheckEdgeURL(t *testing.T) {
+	creds := &Credentials{EdgeURL: "https://hooks.dx314.com"}
+
+	tests := []struct {
+		name         string
+		edgeURL      string
+		wantMismatch bool
+	}{
+		{"identical", "https://hooks.dx314.com", false},
+		{"trailing slash", "https://hooks.dx314.com/", false},
+		{"case and default port", "HTTPS://Hooks.dx314.com:443", false},
+		{"different host", "https://hooks.example.com", true},
+		{"different scheme", "http://hooks.dx314.com", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := CheckEdgeURL(creds, tt.edgeURL)
+			if (err != nil) != tt.wantMismatch {
+				t.Errorf("CheckEdgeURL(%q) = %v, want mismatch %v", tt.edgeURL, err, tt.wantMismatch)
+			}
+		})
+	}
+}
+
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && (s[:len(substr)] == substr || contains(s[1:], substr)))
 }
@@ -201,3 +304,220 @@ func TestIntegrationClient(t *testing.T) {
 		t.Logf("  - %s (%s)", ep.Name, ep.Id)
 	}
 }
+
+func TestQueryJSON(t *testing.T) {
+	payload := []byte(`{"type":"invoice.paid","data":{"object":{"id":"in_1","lines":[{"amount":100},{"amount":250}]}},"a.b":true}`)
+
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{".", ""},
+		{".type", `["invoice.paid"]`},
+		{".data.object.id", `["in_1"]`},
+		{".data.object.lines[1].amount", `[250]`},
+		{".data.object.lines[-1].amount", `[250]`},
+		{".data.object.lines[].amount", `[100,250]`},
+		{".data.object | .id", `["in_1"]`},
+		{`.["a.b"]`, `[true]`},
+		{`."a.b"`, `[true]`},
+		{".missing.field", `[null]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			results, err := QueryJSON(payload, tt.expr)
+			if err != nil {
+				t.Fatalf("QueryJSON(%q): %v", tt.expr, err)
+			}
+			if tt.want == "" {
+				if len(results) != 1 {
+					t.Errorf("expected the whole document, got %d results", len(results))
+				}
+				return
+			}
+			got, _ := json.Marshal(results)
+			if string(got) != tt.want {
+				t.Errorf("QueryJSON(%q) = %s, want %s", tt.expr, got, tt.want)
+			}
+		})
+	}
+
+	for _, expr := range []string{"type", ".type[0]", ".data[", ".[abc]"} {
+		if _, err := QueryJSON(payload, expr); err == nil {
+			t.Errorf("QueryJSON(%q): expected error", expr)
+		}
+	}
+}
+
+func TestHighlightJSON(t *testing.T) {
+	pretty, ok := PrettyJSON([]byte(`{"key":"value","n":-1.5,"ok":true}`))
+	if !ok {
+		t.Fatal("PrettyJSON failed on valid JSON")
+	}
+
+	got := HighlightJSON(pretty)
+	for _, want := range []string{
+		jsonColorKey + `"key"` + jsonColorReset,
+		jsonColorString + `"value"` + jsonColorReset,
+		jsonColorNumber + `-1.5` + jsonColorReset,
+		jsonColorLiteral + `true` + jsonColorReset,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("highlighted output missing %q:\n%s", want, got)
+		}
+	}
+
+	if _, ok := PrettyJSON([]byte("not json")); ok {
+		t.Error("PrettyJSON should reject invalid JSON")
+	}
+}
+
+func TestSpinner(t *testing.T) {
+	// Not a terminal: nothing is drawn
+	var quiet strings.Builder
+	s := NewSpinner(&quiet, "Loading")
+	s.Start()
+	time.Sleep(2 * spinnerDelay)
+	s.Stop()
+	if quiet.Len() != 0 {
+		t.Errorf("spinner wrote to a non-terminal: %q", quiet.String())
+	}
+
+	var out strings.Builder
+	s = NewSpinner(&out, "Loading")
+	s.tty = true
+	s.delay = 0
+	s.Start()
+	time.Sleep(50 * time.Millisecond)
+	s.Stop()
+	got := out.String()
+	if !strings.Contains(got, spinnerFrames[0]+" Loading") {
+		t.Errorf("spinner output missing first frame: %q", got)
+	}
+	if !strings.HasSuffix(got, "\r\033[K") {
+		t.Errorf("spinner didn't clear its line: %q", got)
+	}
+
+	// A call that finishes before the delay draws nothing
+	var quick strings.Builder
+	s = NewSpinner(&quick, "Loading")
+	s.tty = true
+	s.Start()
+	s.Stop()
+	if quick.Len() != 0 {
+		t.Errorf("spinner drew for a quick call: %q", quick.String())
+	}
+
+	n, err := Spin(context.Background(), "Loading", func(ctx context.Context) (int, error) {
+		return 42, nil
+	})
+	if n != 42 || err != nil {
+		t.Errorf("Spin = %d, %v; want 42, nil", n, err)
+	}
+
+	// Cancelling the parent isn't an interrupt
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Spin(ctx, "Loading", func(ctx context.Context) (int, error) {
+		return 0, ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) || errors.Is(err, ErrInterrupted) {
+		t.Errorf("Spin with cancelled parent = %v; want context.Canceled", err)
+	}
+}
+
+func TestOutputStyle(t *testing.T) {
+	defer SetColorMode(ColorAuto)
+	defer SetASCII(false)
+
+	if _, err := ParseColorMode("sometimes"); err == nil {
+		t.Error("ParseColorMode should reject an unknown mode")
+	}
+	if m, err := ParseColorMode(""); err != nil || m != ColorAuto {
+		t.Errorf("ParseColorMode(\"\") = %q, %v; want auto", m, err)
+	}
+
+	// Auto mode colors terminals only
+	var out strings.Builder
+	if UseColor(&out) {
+		t.Error("auto mode colored a non-terminal")
+	}
+	SetColorMode(ColorAlways)
+	if !UseColor(&out) {
+		t.Error("always mode didn't color a non-terminal")
+	}
+	SetColorMode(ColorNever)
+	if UseColor(os.Stdout) {
+		t.Error("never mode colored output")
+	}
+
+	if got := Symbol("✓", "ok"); got != "✓" {
+		t.Errorf("Symbol = %q, want ✓", got)
+	}
+	SetASCII(true)
+	if got := Symbol("✓", "ok"); got != "ok" {
+		t.Errorf("Symbol in ASCII mode = %q, want ok", got)
+	}
+}
+
+func TestVersionCheck(t *testing.T) {
+	for _, tt := range []struct {
+		cli, edge          string
+		upgrade, edgeOlder bool
+	}{
+		{cli: "1.2.0", edge: "1.3.0", upgrade: true},
+		{cli: "1.3.0", edge: "1.2.9", edgeOlder: true},
+		{cli: "1.2.0", edge: "1.2.0"},
+		{cli: "dev", edge: "1.3.0"},
+		{cli: "1.2.0", edge: "dev"},
+	} {
+		v := VersionCheck{CLI: buildinfo.Info{Version: tt.cli}, Edge: buildinfo.Info{Version: tt.edge}}
+		if got := v.UpgradeAvailable(); got != tt.upgrade {
+			t.Errorf("cli %s, edge %s: UpgradeAvailable = %v", tt.cli, tt.edge, got)
+		}
+		if got := v.EdgeOlder(); got != tt.edgeOlder {
+			t.Errorf("cli %s, edge %s: EdgeOlder = %v", tt.cli, tt.edge, got)
+		}
+	}
+}
+
+func TestSendTelemetry(t *testing.T) {
+	t.Setenv(telemetry.EnvVar, "")
+	t.Setenv("DO_NOT_TRACK", "")
+	collector := telemetry.NewCollector()
+	edge := httptest.NewServer(collector)
+	defer edge.Close()
+
+	store := telemetry.NewStore(filepath.Join(t.TempDir(), telemetry.File))
+	build := buildinfo.Info{Version: "1.2.0"}
+	ctx := context.Background()
+
+	if sent, err := SendTelemetry(ctx, store, edge.URL, build); sent || err != nil {
+		t.Fatalf("sent while off: %v, %v", sent, err)
+	}
+
+	store.SetEnabled(true, time.Now())
+	store.RecordError("network")
+	if sent, err := SendTelemetry(ctx, store, edge.URL, build); !sent || err != nil {
+		t.Fatalf("not sent while on: %v, %v", sent, err)
+	}
+	if sent, _ := SendTelemetry(ctx, store, edge.URL, build); sent {
+		t.Error("sent twice within a day")
+	}
+	if state, _ := store.Load(); state.Errors != nil {
+		t.Errorf("errors kept after sending: %v", state.Errors)
+	}
+
+	var metrics strings.Builder
+	collector.WriteTo(&metrics)
+	if !strings.Contains(metrics.String(), `hookly_telemetry_command_errors_total{class="network"} 1`) {
+		t.Errorf("edge didn't count the report:\n%s", metrics.String())
+	}
+
+	t.Setenv("DO_NOT_TRACK", "1")
+	store.MarkSent(telemetry.Report{}, time.Now().Add(-telemetry.Interval))
+	if sent, _ := SendTelemetry(ctx, store, edge.URL, build); sent {
+		t.Error("sent with DO_NOT_TRACK=1")
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"connectrpc.com/connect"
 
 	"hooks.dx314.com/internal/api/hookly/v1/hooklyv1connect"
+	"hooks.dx314.com/internal/auth"
 )
 
 // Client provides an authenticated ConnectRPC client for the CLI.
@@ -15,9 +16,16 @@ type Client struct {
 
 // NewClient creates a new authenticated ConnectRPC client.
 func NewClient(edgeURL, token string) *Client {
+	return NewOrgClient(edgeURL, token, "")
+}
+
+// NewOrgClient creates a client whose calls act for an org's endpoints
+// instead of the user's. An empty orgID acts for the user, like NewClient.
+func NewOrgClient(edgeURL, token, orgID string) *Client {
 	httpClient := &http.Client{
 		Transport: &bearerAuthTransport{
 			token: token,
+			org:   orgID,
 			base:  http.DefaultTransport,
 		},
 	}
@@ -33,9 +41,11 @@ func NewClient(edgeURL, token string) *Client {
 	}
 }
 
-// bearerAuthTransport adds Bearer token authentication to HTTP requests.
+// bearerAuthTransport adds Bearer token authentication to HTTP requests,
+// and the org they act for if any.
 type bearerAuthTransport struct {
 	token string
+	org   string
 	base  http.RoundTripper
 }
 
@@ -43,5 +53,8 @@ func (t *bearerAuthTransport) RoundTrip(req *http.Request) (*http.Response, erro
 	// Clone the request to avoid mutating the original
 	clone := req.Clone(req.Context())
 	clone.Header.Set("Authorization", "Bearer "+t.token)
+	if t.org != "" {
+		clone.Header.Set(auth.OrgHeader, t.org)
+	}
 	return t.base.RoundTrip(clone)
 }
@@ -6,8 +6,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
 	"hooks.dx314.com/internal/crypto"
@@ -18,6 +20,8 @@ const (
 	ConfigDir = "hookly"
 	// CredentialsFile is the name of the credentials file.
 	CredentialsFile = "credentials.json"
+	// EdgeCredentialsDir holds credentials for additional edge servers, one file per host.
+	EdgeCredentialsDir = "edges"
 )
 
 // Credentials holds the stored authentication credentials.
@@ -27,6 +31,19 @@ type Credentials struct {
 	UserID    string    `json:"user_id"`
 	Username  string    `json:"username"`
 	CreatedAt time.Time `json:"created_at"`
+	// Region and RegionURL are the nearest region of a multi-region service,
+	// picked at login. Empty for single-region edges.
+	Region    string `json:"region,omitempty"`
+	RegionURL string `json:"region_url,omitempty"`
+}
+
+// ConnectURL returns the URL to reach the edge at: the nearest region's edge
+// if one was picked at login, otherwise EdgeURL.
+func (c *Credentials) ConnectURL() string {
+	if c.RegionURL != "" {
+		return c.RegionURL
+	}
+	return c.EdgeURL
 }
 
 // CredentialsManager handles loading and saving credentials.
@@ -57,8 +74,25 @@ func NewCredentialsManager() (*CredentialsManager, error) {
 // Load loads credentials from disk.
 // Returns nil if no credentials exist.
 func (m *CredentialsManager) Load() (*Credentials, error) {
-	path := filepath.Join(m.configDir, CredentialsFile)
+	return m.loadFile(filepath.Join(m.configDir, CredentialsFile))
+}
 
+// LoadForEdge loads the credentials to use for the given edge URL.
+// The default credentials are used when they belong to that edge, otherwise
+// the per-edge credentials file is consulted. Returns nil if neither exists.
+func (m *CredentialsManager) LoadForEdge(edgeURL string) (*Credentials, error) {
+	creds, err := m.Load()
+	if err != nil {
+		return nil, err
+	}
+	if creds != nil && SameEdge(creds.EdgeURL, edgeURL) {
+		return creds, nil
+	}
+	return m.loadFile(m.edgePath(edgeURL))
+}
+
+// loadFile loads and decrypts credentials from the given path.
+func (m *CredentialsManager) loadFile(path string) (*Credentials, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -89,8 +123,19 @@ func (m *CredentialsManager) Load() (*Credentials, error) {
 
 // Save saves credentials to disk.
 func (m *CredentialsManager) Save(creds *Credentials) error {
+	return m.saveFile(filepath.Join(m.configDir, CredentialsFile), creds)
+}
+
+// SaveForEdge saves credentials for an additional edge server without
+// replacing the default credentials.
+func (m *CredentialsManager) SaveForEdge(creds *Credentials) error {
+	return m.saveFile(m.edgePath(creds.EdgeURL), creds)
+}
+
+// saveFile encrypts and writes credentials to the given path.
+func (m *CredentialsManager) saveFile(path string, creds *Credentials) error {
 	// Ensure config directory exists
-	if err := os.MkdirAll(m.configDir, 0700); err != nil {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
 		return fmt.Errorf("create config dir: %w", err)
 	}
 
@@ -113,7 +158,6 @@ func (m *CredentialsManager) Save(creds *Credentials) error {
 		return fmt.Errorf("marshal credentials: %w", err)
 	}
 
-	path := filepath.Join(m.configDir, CredentialsFile)
 	if err := os.WriteFile(path, data, 0600); err != nil {
 		return fmt.Errorf("write credentials: %w", err)
 	}
@@ -130,11 +174,29 @@ func (m *CredentialsManager) Delete() error {
 	return nil
 }
 
+// DeleteForEdge removes the credentials used for the given edge URL.
+func (m *CredentialsManager) DeleteForEdge(edgeURL string) error {
+	creds, err := m.Load()
+	if err == nil && creds != nil && SameEdge(creds.EdgeURL, edgeURL) {
+		return m.Delete()
+	}
+	if err := os.Remove(m.edgePath(edgeURL)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("remove credentials: %w", err)
+	}
+	return nil
+}
+
 // Path returns the path to the credentials file.
 func (m *CredentialsManager) Path() string {
 	return filepath.Join(m.configDir, CredentialsFile)
 }
 
+// edgePath returns the path to the per-edge credentials file for an edge URL.
+func (m *CredentialsManager) edgePath(edgeURL string) string {
+	name := strings.NewReplacer(":", "_", "/", "_").Replace(edgeHost(edgeURL))
+	return filepath.Join(m.configDir, EdgeCredentialsDir, name+".json")
+}
+
 // storedCredentials is the on-disk format with encrypted token.
 type storedCredentials struct {
 	EdgeURL        string    `json:"edge_url"`
@@ -199,3 +261,57 @@ func deriveKey() ([]byte, error) {
 
 // ErrNotLoggedIn is returned when no credentials are found.
 var ErrNotLoggedIn = errors.New("not logged in")
+
+// EdgeMismatchError is returned when the edge URL in hookly.yaml does not
+// match the edge the stored credentials were issued by.
+type EdgeMismatchError struct {
+	ConfigEdgeURL      string
+	CredentialsEdgeURL string
+}
+
+func (e *EdgeMismatchError) Error() string {
+	return fmt.Sprintf("hookly.yaml edge_url %q does not match logged-in edge %q", e.ConfigEdgeURL, e.CredentialsEdgeURL)
+}
+
+// CheckEdgeURL verifies that credentials were issued by the configured edge.
+func CheckEdgeURL(creds *Credentials, configEdgeURL string) error {
+	if creds == nil || SameEdge(creds.EdgeURL, configEdgeURL) {
+		return nil
+	}
+	return &EdgeMismatchError{
+		ConfigEdgeURL:      configEdgeURL,
+		CredentialsEdgeURL: creds.EdgeURL,
+	}
+}
+
+// NormalizeEdgeURL returns a canonical form of an edge URL for comparison:
+// lowercase scheme and host, default ports and trailing slashes removed.
+func NormalizeEdgeURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return strings.TrimRight(strings.ToLower(raw), "/")
+	}
+
+	scheme := strings.ToLower(u.Scheme)
+	host := strings.ToLower(u.Host)
+	if (scheme == "https" && strings.HasSuffix(host, ":443")) || (scheme == "http" && strings.HasSuffix(host, ":80")) {
+		host = host[:strings.LastIndex(host, ":")]
+	}
+
+	return scheme + "://" + host + strings.TrimRight(u.Path, "/")
+}
+
+// SameEdge reports whether two edge URLs refer to the same edge server.
+func SameEdge(a, b string) bool {
+	return NormalizeEdgeURL(a) == NormalizeEdgeURL(b)
+}
+
+// edgeHost returns the host portion of an edge URL.
+func edgeHost(edgeURL string) string {
+	normalized := NormalizeEdgeURL(edgeURL)
+	if idx := strings.Index(normalized, "://"); idx >= 0 {
+		normalized = normalized[idx+3:]
+	}
+	return normalized
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+)
+
+// ANSI colors used for JSON syntax highlighting
+const (
+	jsonColorReset   = "\033[0m"
+	jsonColorKey     = "\033[36m" // cyan
+	jsonColorString  = "\033[32m" // green
+	jsonColorNumber  = "\033[33m" // yellow
+	jsonColorLiteral = "\033[35m" // magenta: true, false, null
+)
+
+// PrettyJSON indents a JSON document with two spaces. It returns false if
+// data is not valid JSON.
+func PrettyJSON(data []byte) (string, bool) {
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, bytes.TrimSpace(data), "", "  "); err != nil {
+		return "", false
+	}
+	return buf.String(), true
+}
+
+// HighlightJSON adds ANSI colors to formatted JSON: keys, strings, numbers
+// and literals. The input must be valid JSON, such as the output of PrettyJSON.
+func HighlightJSON(s string) string {
+	var sb strings.Builder
+	sb.Grow(len(s) * 2)
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '"':
+			end := i + 1
+			for end < len(s) && s[end] != '"' {
+				if s[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			end++ // closing quote
+			end = min(end, len(s))
+
+			// A string followed by ':' is an object key
+			color := jsonColorString
+			rest := strings.TrimLeft(s[end:], " ")
+			if strings.HasPrefix(rest, ":") {
+				color = jsonColorKey
+			}
+			sb.WriteString(color)
+			sb.WriteString(s[i:end])
+			sb.WriteString(jsonColorReset)
+			i = end
+
+		case c == '-' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(s) && strings.IndexByte("0123456789.eE+-", s[end]) >= 0 {
+				end++
+			}
+			sb.WriteString(jsonColorNumber)
+			sb.WriteString(s[i:end])
+			sb.WriteString(jsonColorReset)
+			i = end
+
+		case c == 't' || c == 'f' || c == 'n':
+			end := i + 1
+			for end < len(s) && s[end] >= 'a' && s[end] <= 'z' {
+				end++
+			}
+			sb.WriteString(jsonColorLiteral)
+			sb.WriteString(s[i:end])
+			sb.WriteString(jsonColorReset)
+			i = end
+
+		default:
+			sb.WriteByte(c)
+			i++
+		}
+	}
+	return sb.String()
+}
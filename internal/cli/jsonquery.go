@@ -0,0 +1,242 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// QueryJSON evaluates a jq-style path expression against a JSON document and
+// returns the matching values. Supported is the path subset of jq:
+//
+//	.                 the whole document
+//	.field .a.b       object fields
+//	."field" .["f"]   quoted object fields
+//	.[N]              array elements (negative indexes count from the end)
+//	.[]               all elements of an array or object
+//	a | b             apply b to every result of a
+//
+// Missing fields and out of range indexes yield null, as in jq.
+func QueryJSON(data []byte, expr string) ([]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("payload is not valid JSON: %w", err)
+	}
+
+	results := []any{doc}
+	for _, stage := range splitPipes(expr) {
+		steps, err := parsePath(strings.TrimSpace(stage))
+		if err != nil {
+			return nil, err
+		}
+		for _, step := range steps {
+			var next []any
+			for _, v := range results {
+				out, err := step.apply(v)
+				if err != nil {
+					return nil, err
+				}
+				next = append(next, out...)
+			}
+			results = next
+		}
+	}
+	return results, nil
+}
+
+// pathStep is a single field access, index or iteration.
+type pathStep struct {
+	field   string
+	index   int
+	isIndex bool
+	iterate bool
+}
+
+func (s pathStep) apply(v any) ([]any, error) {
+	switch {
+	case s.iterate:
+		switch t := v.(type) {
+		case []any:
+			return t, nil
+		case map[string]any:
+			// jq iterates objects in key order
+			keys := make([]string, 0, len(t))
+			for k := range t {
+				keys = append(keys, k)
+			}
+			slices.Sort(keys)
+			out := make([]any, len(keys))
+			for i, k := range keys {
+				out[i] = t[k]
+			}
+			return out, nil
+		default:
+			return nil, fmt.Errorf("cannot iterate over %s", jsonTypeName(v))
+		}
+
+	case s.isIndex:
+		switch t := v.(type) {
+		case nil:
+			return []any{nil}, nil
+		case []any:
+			i := s.index
+			if i < 0 {
+				i += len(t)
+			}
+			if i < 0 || i >= len(t) {
+				return []any{nil}, nil
+			}
+			return []any{t[i]}, nil
+		default:
+			return nil, fmt.Errorf("cannot index %s with a number", jsonTypeName(v))
+		}
+
+	default:
+		switch t := v.(type) {
+		case nil:
+			return []any{nil}, nil
+		case map[string]any:
+			return []any{t[s.field]}, nil
+		default:
+			return nil, fmt.Errorf("cannot index %s with %q", jsonTypeName(v), s.field)
+		}
+	}
+}
+
+// parsePath parses a single path expression such as .items[0].id.
+func parsePath(expr string) ([]pathStep, error) {
+	if !strings.HasPrefix(expr, ".") {
+		return nil, fmt.Errorf("invalid expression %q: must start with '.'", expr)
+	}
+
+	var steps []pathStep
+	i := 0
+	for i < len(expr) {
+		switch expr[i] {
+		case '.':
+			i++
+			if i >= len(expr) || expr[i] == '[' {
+				continue
+			}
+			if expr[i] == '"' {
+				field, n, err := parseQuoted(expr[i:])
+				if err != nil {
+					return nil, err
+				}
+				steps = append(steps, pathStep{field: field})
+				i += n
+				continue
+			}
+			start := i
+			for i < len(expr) && isIdentChar(expr[i]) {
+				i++
+			}
+			if start == i {
+				return nil, fmt.Errorf("invalid expression %q at offset %d", expr, start)
+			}
+			steps = append(steps, pathStep{field: expr[start:i]})
+
+		case '[':
+			end := strings.IndexByte(expr[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("invalid expression %q: missing ']'", expr)
+			}
+			inner := strings.TrimSpace(expr[i+1 : i+end])
+			switch {
+			case inner == "":
+				steps = append(steps, pathStep{iterate: true})
+				i += end + 1
+			case inner[0] == '"':
+				// Quoted keys may contain ']', so parse the string itself
+				field, n, err := parseQuoted(expr[i+1:])
+				if err != nil {
+					return nil, err
+				}
+				closing := i + 1 + n
+				if closing >= len(expr) || expr[closing] != ']' {
+					return nil, fmt.Errorf("invalid expression %q: missing ']'", expr)
+				}
+				steps = append(steps, pathStep{field: field})
+				i = closing + 1
+			default:
+				n, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid index %q in %q", inner, expr)
+				}
+				steps = append(steps, pathStep{index: n, isIndex: true})
+				i += end + 1
+			}
+
+		default:
+			return nil, fmt.Errorf("invalid expression %q at offset %d", expr, i)
+		}
+	}
+	return steps, nil
+}
+
+// parseQuoted parses a JSON string literal at the start of s and returns the
+// value and the number of bytes consumed.
+func parseQuoted(s string) (string, int, error) {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			v, err := strconv.Unquote(s[:i+1])
+			if err != nil {
+				return "", 0, fmt.Errorf("invalid string %s", s[:i+1])
+			}
+			return v, i + 1, nil
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated string in %q", s)
+}
+
+// splitPipes splits an expression on '|' outside of string literals.
+func splitPipes(expr string) []string {
+	var parts []string
+	inString := false
+	start := 0
+	for i := 0; i < len(expr); i++ {
+		switch expr[i] {
+		case '\\':
+			if inString {
+				i++
+			}
+		case '"':
+			inString = !inString
+		case '|':
+			if !inString {
+				parts = append(parts, expr[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, expr[start:])
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+func jsonTypeName(v any) string {
+	switch v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
@@ -129,6 +129,9 @@ func Login(ctx context.Context, edgeURL string) (*LoginResult, error) {
 		return nil, err
 	case <-ctx.Done():
 		server.Close()
+		if !errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, ctx.Err()
+		}
 		return nil, fmt.Errorf("login timed out after %v", CallbackTimeout)
 	}
 
@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/region"
+)
+
+// regionProbeTimeout bounds the health checks made to pick a region.
+const regionProbeTimeout = 10 * time.Second
+
+// NearestRegion asks the edge for the service's regions and probes the ones
+// it reports healthy from this machine, returning the fastest. It returns
+// false for single-region services.
+func NearestRegion(ctx context.Context, client *Client) (region.Status, bool, error) {
+	resp, err := client.Edge.GetRegions(ctx, connect.NewRequest(&hooklyv1.GetRegionsRequest{}))
+	if err != nil {
+		return region.Status{}, false, fmt.Errorf("get regions: %w", err)
+	}
+	if resp.Msg.CurrentRegion == "" {
+		return region.Status{}, false, nil
+	}
+
+	var candidates []region.Region
+	for _, r := range resp.Msg.Regions {
+		if r.Healthy {
+			candidates = append(candidates, region.Region{Name: r.Name, URL: r.Url})
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, regionProbeTimeout)
+	defer cancel()
+	nearest, ok := region.Nearest(region.ProbeAll(ctx, http.DefaultClient, candidates))
+	if !ok {
+		return region.Status{}, false, errors.New("no region reachable from this machine")
+	}
+	return nearest, true, nil
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"golang.org/x/term"
+)
+
+// ErrInterrupted is returned by Spin when Ctrl-C cancelled the call.
+var ErrInterrupted = errors.New("interrupted")
+
+// spinnerDelay is how long a call runs before its spinner shows, so quick
+// calls don't flicker.
+const spinnerDelay = 150 * time.Millisecond
+
+var (
+	spinnerFrames      = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+	spinnerFramesASCII = []string{"|", "/", "-", "\\"}
+)
+
+// Spinner shows a message with an animated spinner on one line while a
+// command waits. It draws nothing unless it writes to a terminal, so piped
+// and scripted output stays clean.
+type Spinner struct {
+	w     io.Writer
+	msg   string
+	tty   bool
+	delay time.Duration
+
+	once sync.Once
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewSpinner creates a spinner showing msg on w, usually os.Stderr.
+func NewSpinner(w io.Writer, msg string) *Spinner {
+	f, ok := w.(*os.File)
+	return &Spinner{
+		w:     w,
+		msg:   msg,
+		tty:   ok && term.IsTerminal(int(f.Fd())),
+		delay: spinnerDelay,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// Start shows the spinner until Stop.
+func (s *Spinner) Start() {
+	if !s.tty {
+		close(s.done)
+		return
+	}
+	go s.run()
+}
+
+// Stop removes the spinner, leaving the line empty for what follows.
+func (s *Spinner) Stop() {
+	s.once.Do(func() { close(s.stop) })
+	<-s.done
+}
+
+func (s *Spinner) run() {
+	defer close(s.done)
+
+	select {
+	case <-s.stop:
+		return
+	case <-time.After(s.delay):
+	}
+
+	frames := spinnerFrames
+	if asciiOnly {
+		frames = spinnerFramesASCII
+	}
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for i := 0; ; i++ {
+		fmt.Fprintf(s.w, "\r\033[K%s %s", frames[i%len(frames)], s.msg)
+		select {
+		case <-s.stop:
+			fmt.Fprint(s.w, "\r\033[K")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Spin runs fn with a spinner showing msg on stderr. Ctrl-C cancels the
+// context passed to fn, and Spin then returns ErrInterrupted rather than
+// the error the cancelled call failed with.
+func Spin[T any](parent context.Context, msg string, fn func(context.Context) (T, error)) (T, error) {
+	ctx, stop := signal.NotifyContext(parent, os.Interrupt)
+	defer stop()
+
+	spinner := NewSpinner(os.Stderr, msg)
+	spinner.Start()
+	result, err := fn(ctx)
+	spinner.Stop()
+
+	// Cancelled without the parent being cancelled: by the signal
+	if err != nil && ctx.Err() != nil && parent.Err() == nil {
+		return result, ErrInterrupted
+	}
+	return result, err
+}
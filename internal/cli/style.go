@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/term"
+)
+
+// ColorMode is when the CLI colors its output.
+type ColorMode string
+
+const (
+	ColorAuto   ColorMode = "auto"   // On terminals, unless NO_COLOR is set or TERM is dumb
+	ColorAlways ColorMode = "always" // Even when piped
+	ColorNever  ColorMode = "never"
+)
+
+// The output style of the process, set once from flags at startup.
+var (
+	colorMode = ColorAuto
+	asciiOnly bool
+)
+
+// ParseColorMode parses a --color or HOOKLY_COLOR value. Empty is auto.
+func ParseColorMode(s string) (ColorMode, error) {
+	switch m := ColorMode(s); m {
+	case "":
+		return ColorAuto, nil
+	case ColorAuto, ColorAlways, ColorNever:
+		return m, nil
+	}
+	return "", fmt.Errorf("invalid color mode %q: use auto, always or never", s)
+}
+
+// SetColorMode sets when UseColor colors output.
+func SetColorMode(m ColorMode) {
+	colorMode = m
+}
+
+// UseColor reports whether output written to w should be colored. In auto
+// mode it is when w is a terminal, NO_COLOR (https://no-color.org) isn't set
+// and TERM isn't dumb.
+func UseColor(w io.Writer) bool {
+	switch colorMode {
+	case ColorAlways:
+		return true
+	case ColorNever:
+		return false
+	}
+	if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// SetASCII replaces the Unicode symbols of the CLI's output, such as ✓ and
+// the spinner, with ASCII ones for terminals and screen readers that
+// mangle them.
+func SetASCII(on bool) {
+	asciiOnly = on
+}
+
+// Symbol returns unicode, or ascii after SetASCII.
+func Symbol(unicode, ascii string) string {
+	if asciiOnly {
+		return ascii
+	}
+	return unicode
+}
@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"hooks.dx314.com/internal/buildinfo"
+	"hooks.dx314.com/internal/telemetry"
+)
+
+// telemetryTimeout bounds sending a report, so a slow edge delays the CLI
+// by at most this much once a day.
+const telemetryTimeout = 2 * time.Second
+
+// TelemetryStore returns the store of the telemetry setting, next to the
+// credentials.
+func TelemetryStore() (*telemetry.Store, error) {
+	configDir, err := getConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return telemetry.NewStore(filepath.Join(configDir, telemetry.File)), nil
+}
+
+// TelemetryReport returns the report this CLI would send next.
+func TelemetryReport(state telemetry.State, build buildinfo.Info) telemetry.Report {
+	return state.Report(build, runtime.GOOS, runtime.GOARCH)
+}
+
+// SendTelemetry sends a report to the edge if telemetry is on, not turned
+// off by the environment, and the last report is a day old. It reports
+// whether one was sent.
+func SendTelemetry(ctx context.Context, store *telemetry.Store, edgeURL string, build buildinfo.Info) (bool, error) {
+	if telemetry.Disabled(os.Getenv) {
+		return false, nil
+	}
+	state, err := store.Load()
+	if err != nil || !state.Due(time.Now()) {
+		return false, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, telemetryTimeout)
+	defer cancel()
+	report := TelemetryReport(state, build)
+	if err := telemetry.Send(ctx, http.DefaultClient, edgeURL, report); err != nil {
+		return false, err
+	}
+	return true, store.MarkSent(report, time.Now())
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"connectrpc.com/connect"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/buildinfo"
+)
+
+// versionCheckTimeout bounds asking the edge for its version, so status
+// stays quick when the edge is unreachable.
+const versionCheckTimeout = 3 * time.Second
+
+// UpgradeCommand installs the latest CLI.
+const UpgradeCommand = "go install hooks.dx314.com/hookly@latest"
+
+// VersionCheck compares this CLI's build with the edge's.
+type VersionCheck struct {
+	CLI  buildinfo.Info
+	Edge buildinfo.Info
+}
+
+// CheckVersion asks the edge for its build info. The call needs no auth.
+func CheckVersion(ctx context.Context, client *Client, cli buildinfo.Info) (VersionCheck, error) {
+	ctx, cancel := context.WithTimeout(ctx, versionCheckTimeout)
+	defer cancel()
+	resp, err := client.Edge.GetVersion(ctx, connect.NewRequest(&hooklyv1.GetVersionRequest{}))
+	if err != nil {
+		return VersionCheck{CLI: cli}, fmt.Errorf("get version: %w", err)
+	}
+	return VersionCheck{
+		CLI: cli,
+		Edge: buildinfo.Info{
+			Version:   resp.Msg.Version,
+			Commit:    resp.Msg.Commit,
+			Date:      resp.Msg.BuildDate,
+			GoVersion: resp.Msg.GoVersion,
+		},
+	}, nil
+}
+
+// UpgradeAvailable reports whether the edge runs a newer release than the
+// CLI. The edge is upgraded with each release, so its version is the latest
+// the CLI can get. Development builds are never compared.
+func (v VersionCheck) UpgradeAvailable() bool {
+	c, ok := buildinfo.Compare(v.CLI.Version, v.Edge.Version)
+	return ok && c < 0
+}
+
+// EdgeOlder reports whether the CLI is a newer release than the edge, whose
+// API may lack what the CLI needs.
+func (v VersionCheck) EdgeOlder() bool {
+	c, ok := buildinfo.Compare(v.CLI.Version, v.Edge.Version)
+	return ok && c > 0
+}
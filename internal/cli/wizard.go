@@ -25,7 +25,9 @@ func RunWizard(client *Client, creds *Credentials) (*WizardConfig, error) {
 	fmt.Printf("Logged in as %s (%s)\n\n", creds.Username, creds.EdgeURL)
 
 	// List endpoints
-	resp, err := client.Edge.ListEndpoints(context.Background(), connect.NewRequest(&hooklyv1.ListEndpointsRequest{}))
+	resp, err := Spin(context.Background(), "Loading endpoints", func(ctx context.Context) (*connect.Response[hooklyv1.ListEndpointsResponse], error) {
+		return client.Edge.ListEndpoints(ctx, connect.NewRequest(&hooklyv1.ListEndpointsRequest{}))
+	})
 	if err != nil {
 		return nil, fmt.Errorf("list endpoints: %w", err)
 	}
@@ -45,7 +47,11 @@ func RunWizard(client *Client, creds *Credentials) (*WizardConfig, error) {
 	// Display endpoints
 	fmt.Println("Available endpoints:")
 	for i, ep := range endpoints {
-		fmt.Printf("  %d. %s (%s)\n", i+1, ep.Name, ep.Id)
+		status := ""
+		if ep.FirstEventAt == nil {
+			status = " - waiting for first webhook"
+		}
+		fmt.Printf("  %d. %s (%s)%s\n", i+1, ep.Name, ep.Id, status)
 	}
 	fmt.Println()
 
@@ -109,6 +115,10 @@ var signatureFormats = []struct {
 	{"Stripe", hooklyv1.ProviderType_PROVIDER_TYPE_STRIPE},
 	{"GitHub", hooklyv1.ProviderType_PROVIDER_TYPE_GITHUB},
 	{"Telegram", hooklyv1.ProviderType_PROVIDER_TYPE_TELEGRAM},
+	{"Slack", hooklyv1.ProviderType_PROVIDER_TYPE_SLACK},
+	{"Shopify", hooklyv1.ProviderType_PROVIDER_TYPE_SHOPIFY},
+	{"Square", hooklyv1.ProviderType_PROVIDER_TYPE_SQUARE},
+	{"PayPal", hooklyv1.ProviderType_PROVIDER_TYPE_PAYPAL},
 	{"Generic (HMAC-SHA256)", hooklyv1.ProviderType_PROVIDER_TYPE_GENERIC},
 }
 
@@ -150,17 +160,29 @@ func createEndpointWizard(client *Client) (*hooklyv1.Endpoint, error) {
 		providerType = signatureFormats[formatIndex].value
 	}
 
+	// Ask about first event notification
+	fmt.Print("\nNotify me when the first webhook arrives? (Y/n): ")
+	notifyInput := strings.ToLower(readLine())
+	notifyFirstEvent := notifyInput != "n" && notifyInput != "no"
+
 	// Create endpoint
-	fmt.Println("\nCreating endpoint...")
-	createResp, err := client.Edge.CreateEndpoint(context.Background(), connect.NewRequest(&hooklyv1.CreateEndpointRequest{
-		Name:           name,
-		ProviderType:   providerType,
-		DestinationUrl: destinationURL,
-	}))
+	fmt.Println()
+	createResp, err := Spin(context.Background(), "Creating endpoint", func(ctx context.Context) (*connect.Response[hooklyv1.CreateEndpointResponse], error) {
+		return client.Edge.CreateEndpoint(ctx, connect.NewRequest(&hooklyv1.CreateEndpointRequest{
+			Name:             name,
+			ProviderType:     providerType,
+			DestinationUrl:   destinationURL,
+			NotifyFirstEvent: notifyFirstEvent,
+		}))
+	})
 	if err != nil {
 		return nil, fmt.Errorf("create endpoint: %w", err)
 	}
 
-	fmt.Printf("Created endpoint: %s (%s)\n\n", createResp.Msg.Endpoint.Name, createResp.Msg.Endpoint.Id)
+	fmt.Printf("Created endpoint: %s (%s)\n", createResp.Msg.Endpoint.Name, createResp.Msg.Endpoint.Id)
+	if notifyFirstEvent {
+		fmt.Println("You'll be notified when the first webhook arrives.")
+	}
+	fmt.Println()
 	return createResp.Msg.Endpoint, nil
 }
@@ -0,0 +1,65 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeTicker(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := NewFake(start)
+	ticker := f.NewTicker(time.Minute)
+
+	f.Advance(59 * time.Second)
+	select {
+	case <-ticker.C():
+		t.Fatal("ticked early")
+	default:
+	}
+
+	f.Advance(time.Second)
+	if got := <-ticker.C(); !got.Equal(start.Add(time.Minute)) {
+		t.Errorf("tick = %v, want %v", got, start.Add(time.Minute))
+	}
+
+	// Ticks the reader missed are dropped, not queued
+	f.Advance(5 * time.Minute)
+	<-ticker.C()
+	select {
+	case <-ticker.C():
+		t.Error("missed ticks were queued")
+	default:
+	}
+
+	ticker.Stop()
+	f.Advance(time.Hour)
+	select {
+	case <-ticker.C():
+		t.Error("stopped ticker fired")
+	default:
+	}
+	if got := f.Now(); !got.Equal(start.Add(66 * time.Minute)) {
+		t.Errorf("Now = %v", got)
+	}
+}
+
+func TestWaitForTickers(t *testing.T) {
+	f := NewFake(time.Now())
+	done := make(chan struct{})
+	go func() {
+		f.WaitForTickers(1)
+		close(done)
+	}()
+	f.NewTicker(time.Second)
+	<-done
+}
+
+func TestOr(t *testing.T) {
+	if Or(nil) != Real {
+		t.Error("Or(nil) is not the real clock")
+	}
+	f := NewFake(time.Now())
+	if Or(f) != Clock(f) {
+		t.Error("Or replaced a set clock")
+	}
+}
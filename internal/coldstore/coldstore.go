@@ -0,0 +1,136 @@
+// Package coldstore exports webhooks to cold storage before retention deletes
+// them, for an audit trail that outlives the database.
+//
+// Each export is an NDJSON index of webhook records, one per line, under
+// webhooks/YYYY/MM/DD/, and a blob per payload under payloads/YYYY/MM/DD/
+// named after the webhook ID, dated by when the webhook was received.
+// Payloads are written before the index, so every indexed payload exists.
+package coldstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"hooks.dx314.com/internal/clock"
+)
+
+// Store writes objects to cold storage.
+type Store interface {
+	// Put writes an object, replacing any with the same key. Keys are
+	// slash-separated paths.
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+}
+
+// Open returns the store for a COLD_STORAGE_URL: s3://bucket/prefix, or a
+// directory as file:///path or a plain path. S3 settings come from getenv.
+func Open(rawURL string, getenv func(string) string) (Store, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid URL: %w", err)
+	}
+	switch u.Scheme {
+	case "s3":
+		return newS3Store(u.Host, strings.Trim(u.Path, "/"), getenv)
+	case "file":
+		return newDirStore(u.Path)
+	case "":
+		return newDirStore(rawURL)
+	default:
+		return nil, fmt.Errorf("unsupported scheme %q: use s3:// or a directory", u.Scheme)
+	}
+}
+
+// Record is an exported webhook, a line of the index.
+type Record struct {
+	ID            string            `json:"id"`
+	EndpointID    string            `json:"endpoint_id"`
+	Status        string            `json:"status"`
+	ReceivedAt    string            `json:"received_at"`
+	EventType     string            `json:"event_type,omitempty"`
+	DeliveryID    string            `json:"delivery_id,omitempty"`
+	SourceIP      string            `json:"source_ip,omitempty"`
+	Headers       map[string]string `json:"headers"`
+	Attempts      int64             `json:"attempts"`
+	LastAttemptAt string            `json:"last_attempt_at,omitempty"`
+	DeliveredAt   string            `json:"delivered_at,omitempty"`
+	ErrorMessage  string            `json:"error_message,omitempty"`
+	ReplayCount   int64             `json:"replay_count,omitempty"`
+	TraceID       string            `json:"trace_id,omitempty"`
+	History       []Transition      `json:"history,omitempty"`
+
+	// Payload is stored as a separate blob, at PayloadKey
+	Payload       []byte `json:"-"`
+	PayloadKey    string `json:"payload_key"`
+	PayloadSize   int    `json:"payload_size"`
+	PayloadSHA256 string `json:"payload_sha256"`
+}
+
+// Transition is a status change of an exported webhook.
+type Transition struct {
+	From   string `json:"from,omitempty"`
+	To     string `json:"to"`
+	Reason string `json:"reason,omitempty"`
+	At     string `json:"at"`
+	By     string `json:"by,omitempty"`
+}
+
+// Exporter writes batches of webhooks to a store.
+type Exporter struct {
+	store Store
+	clock clock.Clock
+}
+
+// NewExporter creates an exporter writing to store.
+func NewExporter(store Store, c clock.Clock) *Exporter {
+	return &Exporter{store: store, clock: clock.Or(c)}
+}
+
+// Export writes the payloads of records, then their index. Once it returns
+// nil the records are safe to delete.
+func (e *Exporter) Export(ctx context.Context, records []Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+
+	var index bytes.Buffer
+	for i := range records {
+		r := &records[i]
+		sum := sha256.Sum256(r.Payload)
+		r.PayloadKey = path.Join("payloads", datePath(r.ReceivedAt), r.ID)
+		r.PayloadSize = len(r.Payload)
+		r.PayloadSHA256 = hex.EncodeToString(sum[:])
+		if err := e.store.Put(ctx, r.PayloadKey, r.Payload, "application/octet-stream"); err != nil {
+			return fmt.Errorf("put payload of %s: %w", r.ID, err)
+		}
+
+		line, err := json.Marshal(r)
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", r.ID, err)
+		}
+		index.Write(line)
+		index.WriteByte('\n')
+	}
+
+	now := e.clock.Now().UTC()
+	key := path.Join("webhooks", now.Format("2006/01/02"), now.Format("20060102T150405Z")+"-"+records[0].ID+".ndjson")
+	if err := e.store.Put(ctx, key, index.Bytes(), "application/x-ndjson"); err != nil {
+		return fmt.Errorf("put index: %w", err)
+	}
+	return nil
+}
+
+// datePath turns an SQLite datetime into YYYY/MM/DD, or "undated" if it
+// isn't one.
+func datePath(datetime string) string {
+	if len(datetime) < 10 || datetime[4] != '-' || datetime[7] != '-' {
+		return "undated"
+	}
+	return strings.ReplaceAll(datetime[:10], "-", "/")
+}
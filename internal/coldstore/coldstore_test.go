@@ -0,0 +1,153 @@
+package coldstore
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"hooks.dx314.com/internal/clock"
+)
+
+func envMap(m map[string]string) func(string) string {
+	return func(k string) string { return m[k] }
+}
+
+func testRecords() []Record {
+	return []Record{
+		{ID: "wh-1", EndpointID: "ep-1", Status: "delivered", ReceivedAt: "2026-03-01 10:00:00", Headers: map[string]string{"Content-Type": "application/json"}, Payload: []byte(`{"n":1}`)},
+		{ID: "wh-2", EndpointID: "ep-1", Status: "failed", ReceivedAt: "2026-03-02 23:59:59", ErrorMessage: "HTTP 400", Payload: []byte("a=b"),
+			History: []Transition{{To: "pending", At: "2026-03-02 23:59:59"}, {From: "pending", To: "failed", Reason: "HTTP 400", At: "2026-03-03 00:00:01"}}},
+	}
+}
+
+func TestExportToDirectory(t *testing.T) {
+	dir := t.TempDir()
+	store, err := Open("file://"+dir, envMap(nil))
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	now := time.Date(2026, 3, 10, 4, 5, 6, 0, time.UTC)
+	if err := NewExporter(store, clock.NewFake(now)).Export(context.Background(), testRecords()); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	payload, err := os.ReadFile(filepath.Join(dir, "payloads", "2026", "03", "02", "wh-2"))
+	if err != nil || string(payload) != "a=b" {
+		t.Errorf("payload = %q, %v", payload, err)
+	}
+
+	f, err := os.Open(filepath.Join(dir, "webhooks", "2026", "03", "10", "20260310T040506Z-wh-1.ndjson"))
+	if err != nil {
+		t.Fatalf("open index: %v", err)
+	}
+	defer f.Close()
+	var lines []map[string]any
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var line map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+			t.Fatalf("index line %q: %v", scanner.Text(), err)
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) != 2 {
+		t.Fatalf("index has %d lines, want 2", len(lines))
+	}
+	if lines[0]["payload_key"] != "payloads/2026/03/01/wh-1" || lines[0]["payload_size"] != 7.0 || lines[0]["payload"] != nil {
+		t.Errorf("first record = %v", lines[0])
+	}
+	if history, _ := lines[1]["history"].([]any); len(history) != 2 {
+		t.Errorf("second record history = %v", lines[1]["history"])
+	}
+
+	// No temporary files are left behind
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if strings.HasPrefix(d.Name(), ".tmp-") {
+			t.Errorf("left %s", path)
+		}
+		return err
+	})
+}
+
+func TestExportToS3(t *testing.T) {
+	var mu sync.Mutex
+	objects := make(map[string][]byte)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPut ||
+			!strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/s3/aws4_request") ||
+			r.Header.Get("X-Amz-Content-Sha256") == "" {
+			http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+			return
+		}
+		mu.Lock()
+		objects[r.URL.Path] = body
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	env := envMap(map[string]string{
+		"COLD_STORAGE_S3_ENDPOINT": srv.URL,
+		"AWS_REGION":               "eu-west-1",
+		"AWS_ACCESS_KEY_ID":        "AKID",
+		"AWS_SECRET_ACCESS_KEY":    "secret",
+	})
+	store, err := Open("s3://audit/hookly/", env)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if err := NewExporter(store, nil).Export(context.Background(), testRecords()); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	if !bytes.Equal(objects["/audit/hookly/payloads/2026/03/01/wh-1"], []byte(`{"n":1}`)) {
+		t.Errorf("objects = %v", objects)
+	}
+	if len(objects) != 3 {
+		t.Errorf("uploaded %d objects, want 2 payloads and an index", len(objects))
+	}
+
+	// Upload errors are returned
+	denied, _ := Open("s3://audit", envMap(map[string]string{
+		"COLD_STORAGE_S3_ENDPOINT": srv.URL,
+		"AWS_DEFAULT_REGION":       "us-east-1",
+		"AWS_ACCESS_KEY_ID":        "AKID",
+		"AWS_SECRET_ACCESS_KEY":    "secret",
+	}))
+	if err := NewExporter(denied, nil).Export(context.Background(), testRecords()); err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("denied upload: err = %v", err)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	if s, err := Open("/var/lib/hookly/archive", envMap(nil)); err != nil || s.(*dirStore).root != "/var/lib/hookly/archive" {
+		t.Errorf("plain path: %v, %v", s, err)
+	}
+	if s, err := Open("s3://audit/hookly", envMap(map[string]string{
+		"AWS_REGION": "eu-west-1", "AWS_ACCESS_KEY_ID": "AKID", "AWS_SECRET_ACCESS_KEY": "secret",
+	})); err != nil || s.(*s3Store).baseURL != "https://audit.s3.eu-west-1.amazonaws.com" {
+		t.Errorf("s3 bucket: %v, %v", s, err)
+	}
+
+	for rawURL, want := range map[string]string{
+		"gs://bucket":    "unsupported scheme",
+		"http://example": "unsupported scheme",
+		"s3://bucket":    "AWS_REGION",
+		"s3:///prefix":   "bucket is required",
+		"file://":        "directory is required",
+	} {
+		_, err := Open(rawURL, envMap(nil))
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Open(%q) = %v, want error containing %q", rawURL, err, want)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package coldstore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// dirStore writes objects as files under a directory, for a mounted volume
+// or a directory that another tool syncs elsewhere.
+type dirStore struct {
+	root string
+}
+
+func newDirStore(root string) (*dirStore, error) {
+	if root == "" {
+		return nil, errors.New("directory is required")
+	}
+	return &dirStore{root: root}, nil
+}
+
+// Put writes the file through a temporary one, so a crash never leaves a
+// partial object.
+func (s *dirStore) Put(_ context.Context, key string, data []byte, _ string) error {
+	name := filepath.Join(s.root, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(name), 0o750); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
@@ -0,0 +1,101 @@
+package coldstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"hooks.dx314.com/internal/kms"
+)
+
+// s3RequestTimeout bounds each upload.
+const s3RequestTimeout = time.Minute
+
+// s3Store writes objects to an S3 bucket, or an S3-compatible service at
+// COLD_STORAGE_S3_ENDPOINT. Credentials come from the standard AWS_*
+// environment variables.
+type s3Store struct {
+	baseURL      string // Bucket URL, without a trailing slash
+	prefix       string
+	region       string
+	accessKeyID  string
+	secretKey    string
+	sessionToken string
+	client       *http.Client
+	now          func() time.Time
+}
+
+func newS3Store(bucket, prefix string, getenv func(string) string) (*s3Store, error) {
+	s := &s3Store{
+		prefix:       prefix,
+		region:       getenv("AWS_REGION"),
+		accessKeyID:  getenv("AWS_ACCESS_KEY_ID"),
+		secretKey:    getenv("AWS_SECRET_ACCESS_KEY"),
+		sessionToken: getenv("AWS_SESSION_TOKEN"),
+		client:       &http.Client{Timeout: s3RequestTimeout},
+		now:          time.Now,
+	}
+	if s.region == "" {
+		s.region = getenv("AWS_DEFAULT_REGION")
+	}
+
+	switch {
+	case bucket == "":
+		return nil, errors.New("bucket is required, as in s3://bucket/prefix")
+	case s.region == "":
+		return nil, errors.New("AWS_REGION is required for an S3 bucket")
+	case s.accessKeyID == "" || s.secretKey == "":
+		return nil, errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required for an S3 bucket")
+	}
+
+	// A custom endpoint, such as MinIO's, is addressed path-style
+	if endpoint := getenv("COLD_STORAGE_S3_ENDPOINT"); endpoint != "" {
+		u, err := url.Parse(endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("COLD_STORAGE_S3_ENDPOINT %q must be an http or https URL", endpoint)
+		}
+		s.baseURL = strings.TrimSuffix(endpoint, "/") + "/" + url.PathEscape(bucket)
+	} else {
+		s.baseURL = fmt.Sprintf("https://%s.s3.%s.amazonaws.com", bucket, s.region)
+	}
+	return s, nil
+}
+
+// Put uploads the object with PutObject.
+func (s *s3Store) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	segments := strings.Split(path.Join(s.prefix, key), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.baseURL+"/"+strings.Join(segments, "/"), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sum[:]))
+	if s.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", s.sessionToken)
+	}
+	kms.SignV4(req, data, s.accessKeyID, s.secretKey, s.region, "s3", s.now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return fmt.Errorf("s3 put %s: HTTP %d: %s", key, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
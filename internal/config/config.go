@@ -2,33 +2,131 @@
 package config
 
 import (
-	"errors"
 	"fmt"
+	"log/slog"
+	"net"
+	"net/url"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
+	"hooks.dx314.com/internal/coldstore"
 	"hooks.dx314.com/internal/crypto"
+	"hooks.dx314.com/internal/errreport"
+	"hooks.dx314.com/internal/id"
+	"hooks.dx314.com/internal/kms"
+	"hooks.dx314.com/internal/logging"
+	"hooks.dx314.com/internal/notify"
+	"hooks.dx314.com/internal/region"
+	"hooks.dx314.com/internal/tracing"
+	"hooks.dx314.com/internal/webhook"
 
 	"github.com/joho/godotenv"
 )
 
 // Config holds all application configuration.
 type Config struct {
-	DatabasePath       string
-	EncryptionKey      []byte
-	Port               int
-	BaseURL            string
-	GitHubClientID     string
-	GitHubClientSecret string
-	GitHubOrg          string
-	GitHubAllowedUsers []string
-	TelegramBotToken   string
-	TelegramChatID     string
+	DatabasePath         string
+	DatabaseReadPath     string // API list, search and stats reads use this file if set
+	EncryptionKey        []byte // Nil until unwrapped when EncryptionKeySource isn't "env"
+	EncryptionKeySource  string
+	EncryptionKeyWrapped string
+	Port                 int
+	BaseURL              string
+	WebhookPathPrefix    string // Where webhooks are received, such as /h/
+	EndpointIDFormat     id.Format
+	WebhookIDFormat      id.Format
+	GitHubClientID       string
+	GitHubClientSecret   string
+	GitHubOrg            string
+	GitHubAllowedUsers   []string
+	TelegramBotToken     string
+	TelegramChatID       string
+	DiscordWebhookURL    string
+	SMTP                 notify.SMTPConfig // Also sends per-user email; unset if incomplete
+	SMTPTo               string            // System email notifications go here if set
+	NotifyWebhookURL     string            // System notification webhook; unset without a secret
+	NotifyWebhookSecret  string
+
+	// Multi-region: the region of this edge and the edges of every region.
+	// Both are empty on a single-region edge.
+	Region  string
+	Regions []region.Region
+
+	// Relay keepalives
+	RelayHeartbeatInterval time.Duration
+	RelayStaleTimeout      time.Duration // Hubs silent for longer are dropped
+
+	// Planned restarts: on shutdown hubs are told to reconnect after
+	// MaintenanceReconnectAfter, to MaintenanceReconnectURL if set
+	MaintenanceReconnectAfter time.Duration
+	MaintenanceReconnectURL   string
+
+	// Proxies whose forwarding headers (CF-Connecting-IP, X-Forwarded-For)
+	// are believed; empty means client IPs are the connection address
+	TrustedProxies []*net.IPNet
+
+	// Networks hub tunnel addresses may be in; empty disables tunnel delivery
+	TunnelAllowedNets []*net.IPNet
+
+	// Ingestion guards
+	IngestRequireJSON    bool             // Reject non-JSON bodies on endpoints of JSON providers
+	IngestBannedPatterns []*regexp.Regexp // Reject payloads matching any of these
+	IngestDailyLimitMB   int              // Payload MB stored per endpoint per UTC day (0 disables)
+	IngestRateLimit      int              // Requests per endpoint per minute; endpoints may override it (0 disables)
+	IngestIPRateLimit    int              // Requests per source IP per minute across endpoints (0 disables)
+
+	// Replay safety
+	ReplayRateLimit        int // replays per endpoint per minute (0 disables)
+	ReplayConfirmThreshold int // pending replays above which confirmation is required (0 disables)
+
+	// Maintenance schedule and retention
+	SchedulerInterval   time.Duration
+	DeadLetterAge       time.Duration // pending webhooks older than this become dead letters
+	DeliveredRetention  time.Duration
+	FailedRetention     time.Duration // counted from the last attempt
+	DeadLetterRetention time.Duration
+	SkippedRetention    time.Duration // counted from receipt
+	ActivityRetention   time.Duration
+	AuditRetention      time.Duration
+	// RetentionGrace is how long webhooks past retention can be undeleted
+	// before they are deleted
+	RetentionGrace time.Duration
+	// EndpointArchiveAfter mutes endpoints without webhooks for this long (0 disables)
+	EndpointArchiveAfter time.Duration
+	// ColdStorageURL is where webhooks are exported before retention deletes
+	// them, an s3:// URL or a directory (see internal/coldstore); unset
+	// deletes them outright
+	ColdStorageURL string
+
+	// Logging
+	LogLevel      slog.Level
+	LogFormat     string
+	LogFile       string // Log to this file instead of stdout if set
+	LogMaxSizeMB  int
+	LogMaxBackups int
+	LogSample     time.Duration // Summarize per-webhook records over this interval; 0 logs each
+
+	// Error reporting (optional)
+	SentryDSN         string
+	SentryEnvironment string
+
+	// Tracing (optional), from the standard OTEL_* variables
+	Tracing tracing.Options
+
+	// Database instrumentation
+	SlowQueryThreshold time.Duration // Queries at least this slow are logged; 0 disables
+	MetricsAddr        string        // Serve /metrics on this address if set
+	EndpointCacheTTL   time.Duration // How long endpoint lookups are cached; 0 disables
+
+	problems []Problem // Found while loading, reported by Validate
 }
 
 // Load loads configuration from environment variables.
-// Optionally loads from .env file if present.
+// Optionally loads from .env file if present. Invalid values are reported by
+// Validate rather than Load, so that every problem is reported at once.
 func Load() (*Config, error) {
 	// Load .env file if present (ignore errors)
 	_ = godotenv.Load()
@@ -37,19 +135,51 @@ func Load() (*Config, error) {
 
 	// Required fields
 	cfg.DatabasePath = getEnv("DATABASE_PATH", "./hookly.db")
-
-	keyHex := os.Getenv("ENCRYPTION_KEY")
-	if keyHex == "" {
-		return nil, errors.New("ENCRYPTION_KEY is required")
+	if v := os.Getenv("DATABASE_READ_URL"); v != "" {
+		path, err := parseDatabaseURL(v)
+		if err != nil {
+			cfg.problems = append(cfg.problems, Problem{Key: "DATABASE_READ_URL", Message: err.Error() + "; reads use the primary connection"})
+		} else {
+			cfg.DatabaseReadPath = path
+		}
 	}
-	key, err := crypto.ParseKey(keyHex)
-	if err != nil {
-		return nil, fmt.Errorf("invalid ENCRYPTION_KEY: %w", err)
+
+	// The key is either given directly or wrapped by a KMS (see internal/kms)
+	cfg.EncryptionKeySource = getEnv("ENCRYPTION_KEY_SOURCE", kms.SourceEnv)
+	switch cfg.EncryptionKeySource {
+	case kms.SourceEnv:
+		keyHex := os.Getenv("ENCRYPTION_KEY")
+		if keyHex == "" {
+			cfg.fatal("ENCRYPTION_KEY", "required (generate one with: openssl rand -hex 32)")
+			break
+		}
+		key, err := crypto.ParseKey(keyHex)
+		if err != nil {
+			cfg.fatal("ENCRYPTION_KEY", fmt.Sprintf("%v: expected 64 hex characters (32 bytes), got %d characters", err, len(keyHex)))
+			break
+		}
+		cfg.EncryptionKey = key
+	case kms.SourceVault, kms.SourceAWSKMS, kms.SourceGCPKMS:
+		cfg.EncryptionKeyWrapped = os.Getenv("ENCRYPTION_KEY_WRAPPED")
+		if cfg.EncryptionKeyWrapped == "" {
+			cfg.fatal("ENCRYPTION_KEY_WRAPPED", fmt.Sprintf("required with ENCRYPTION_KEY_SOURCE=%s", cfg.EncryptionKeySource))
+		}
+	default:
+		cfg.fatal("ENCRYPTION_KEY_SOURCE", fmt.Sprintf("unknown source %q (valid: %s, %s, %s, %s)",
+			cfg.EncryptionKeySource, kms.SourceEnv, kms.SourceVault, kms.SourceAWSKMS, kms.SourceGCPKMS))
 	}
-	cfg.EncryptionKey = key
 
-	cfg.Port = getEnvInt("PORT", 8080)
+	cfg.Port = cfg.getEnvInt("PORT", 8080)
 	cfg.BaseURL = getEnv("BASE_URL", "http://localhost:8080")
+	cfg.WebhookPathPrefix = webhook.DefaultPathPrefix
+	if v := os.Getenv("WEBHOOK_PATH_PREFIX"); v != "" {
+		prefix, err := webhook.CleanPathPrefix(v)
+		if err != nil {
+			cfg.problems = append(cfg.problems, Problem{Key: "WEBHOOK_PATH_PREFIX", Message: err.Error() + "; using " + webhook.DefaultPathPrefix})
+		} else {
+			cfg.WebhookPathPrefix = prefix
+		}
+	}
 
 	// GitHub OAuth (optional)
 	cfg.GitHubClientID = os.Getenv("GITHUB_CLIENT_ID")
@@ -66,9 +196,415 @@ func Load() (*Config, error) {
 	cfg.TelegramBotToken = os.Getenv("TELEGRAM_BOT_TOKEN")
 	cfg.TelegramChatID = os.Getenv("TELEGRAM_CHAT_ID")
 
+	// Discord notifications (optional)
+	if v := os.Getenv("DISCORD_WEBHOOK_URL"); v != "" {
+		if err := notify.ValidateDiscordWebhookURL(v); err != nil {
+			cfg.problems = append(cfg.problems, Problem{Key: "DISCORD_WEBHOOK_URL", Message: err.Error() + "; system Discord notifications are disabled"})
+		} else {
+			cfg.DiscordWebhookURL = v
+		}
+	}
+
+	// Notification webhook (optional)
+	if v := os.Getenv("NOTIFY_WEBHOOK_URL"); v != "" {
+		secret := os.Getenv("NOTIFY_WEBHOOK_SECRET")
+		switch err := notify.ValidateWebhookURL(v); {
+		case err != nil:
+			cfg.problems = append(cfg.problems, Problem{Key: "NOTIFY_WEBHOOK_URL", Message: err.Error() + "; the system notification webhook is disabled"})
+		case secret == "":
+			cfg.problems = append(cfg.problems, Problem{Key: "NOTIFY_WEBHOOK_SECRET", Message: "required with NOTIFY_WEBHOOK_URL to sign events; the system notification webhook is disabled"})
+		default:
+			cfg.NotifyWebhookURL = v
+			cfg.NotifyWebhookSecret = secret
+		}
+	}
+
+	// Email notifications (optional); the server also sends users' email
+	smtp := notify.SMTPConfig{
+		Host:     os.Getenv("SMTP_HOST"),
+		Port:     cfg.getEnvInt("SMTP_PORT", 587),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+		Security: notify.SMTPStartTLS,
+	}
+	if smtp.Port == 465 {
+		smtp.Security = notify.SMTPTLS
+	}
+	smtp.Security = getEnv("SMTP_SECURITY", smtp.Security)
+	smtpTo := os.Getenv("SMTP_TO")
+	if smtp.Host != "" || smtp.From != "" || smtpTo != "" {
+		var key, msg string
+		switch {
+		case smtp.Host == "":
+			key, msg = "SMTP_HOST", "required with SMTP_FROM and SMTP_TO"
+		case smtp.From == "":
+			key, msg = "SMTP_FROM", "required with SMTP_HOST"
+		case notify.ValidateEmailAddress(smtp.From) != nil:
+			key, msg = "SMTP_FROM", notify.ValidateEmailAddress(smtp.From).Error()
+		case smtp.Security != notify.SMTPStartTLS && smtp.Security != notify.SMTPTLS && smtp.Security != notify.SMTPNone:
+			key, msg = "SMTP_SECURITY", fmt.Sprintf("unsupported mode %q (valid: starttls, tls, none)", smtp.Security)
+		case smtpTo != "" && notify.ValidateEmailAddress(smtpTo) != nil:
+			key, msg = "SMTP_TO", notify.ValidateEmailAddress(smtpTo).Error()
+		}
+		if key != "" {
+			cfg.problems = append(cfg.problems, Problem{Key: key, Message: msg + "; email notifications are disabled"})
+		} else {
+			cfg.SMTP = smtp
+			cfg.SMTPTo = smtpTo
+		}
+	}
+
+	// Multi-region (optional)
+	cfg.Region = os.Getenv("REGION")
+	if spec := os.Getenv("EDGE_REGIONS"); spec != "" {
+		regions, err := region.ParseList(spec)
+		if err != nil {
+			cfg.problems = append(cfg.problems, Problem{Key: "EDGE_REGIONS", Message: err.Error() + "; region hints are disabled"})
+		} else {
+			cfg.Regions = regions
+		}
+	}
+
+	// Relay keepalives
+	cfg.RelayHeartbeatInterval = cfg.getEnvDurationIn("RELAY_HEARTBEAT_INTERVAL", 15*time.Second, time.Second, 5*time.Minute)
+	cfg.RelayStaleTimeout = cfg.getEnvDurationIn("RELAY_STALE_TIMEOUT", 60*time.Second, 30*time.Second, 30*time.Minute)
+
+	// Maintenance announcements
+	cfg.MaintenanceReconnectAfter = cfg.getEnvDurationIn("MAINTENANCE_RECONNECT_AFTER", 5*time.Second, 0, 10*time.Minute)
+	if v := os.Getenv("MAINTENANCE_RECONNECT_URL"); v != "" {
+		if u, err := url.Parse(v); err != nil || u.Scheme != "https" || u.Host == "" {
+			cfg.problems = append(cfg.problems, Problem{Key: "MAINTENANCE_RECONNECT_URL", Message: fmt.Sprintf("%q must be an https:// URL such as https://standby.hooks.example.com; hubs reconnect to this edge", v)})
+		} else {
+			cfg.MaintenanceReconnectURL = strings.TrimSuffix(v, "/")
+		}
+	}
+
+	// Trusted proxies (optional)
+	if spec := os.Getenv("TRUSTED_PROXIES"); spec != "" {
+		nets, err := parseNets(spec)
+		if err != nil {
+			cfg.problems = append(cfg.problems, Problem{Key: "TRUSTED_PROXIES", Message: err.Error() + "; forwarding headers are ignored"})
+		} else {
+			cfg.TrustedProxies = nets
+		}
+	}
+
+	// Tunnel delivery (optional)
+	if spec := os.Getenv("TUNNEL_ALLOWED_NETS"); spec != "" {
+		nets, err := parseNets(spec)
+		if err != nil {
+			cfg.problems = append(cfg.problems, Problem{Key: "TUNNEL_ALLOWED_NETS", Message: err.Error() + "; tunnel delivery is disabled"})
+		} else {
+			cfg.TunnelAllowedNets = nets
+		}
+	}
+
+	// Logging
+	cfg.LogLevel = cfg.logLevel()
+	cfg.LogFormat = getEnv("LOG_FORMAT", logging.FormatText)
+	if cfg.LogFormat != logging.FormatText && cfg.LogFormat != logging.FormatJSON {
+		cfg.problems = append(cfg.problems, Problem{Key: "LOG_FORMAT", Message: fmt.Sprintf("unknown format %q (valid: %s, %s), using %s", cfg.LogFormat, logging.FormatText, logging.FormatJSON, logging.FormatText)})
+		cfg.LogFormat = logging.FormatText
+	}
+	cfg.LogFile = os.Getenv("LOG_FILE")
+	cfg.LogMaxSizeMB = cfg.getEnvInt("LOG_MAX_SIZE_MB", 100)
+	cfg.LogMaxBackups = cfg.getEnvInt("LOG_MAX_BACKUPS", 5)
+	cfg.LogSample = time.Minute
+	if os.Getenv("LOG_SAMPLE_INTERVAL") == "0" {
+		cfg.LogSample = 0
+	} else {
+		cfg.LogSample = cfg.getEnvDuration("LOG_SAMPLE_INTERVAL", cfg.LogSample)
+	}
+
+	// Error reporting (optional)
+	cfg.SentryDSN = os.Getenv("SENTRY_DSN")
+	cfg.SentryEnvironment = getEnv("SENTRY_ENVIRONMENT", "production")
+
+	// Tracing (optional)
+	if opts, err := tracing.OptionsFromEnv(os.Getenv, "hookly-edge"); err != nil {
+		cfg.problems = append(cfg.problems, Problem{Key: "OTEL_EXPORTER_OTLP_ENDPOINT", Message: err.Error() + "; spans are not exported"})
+	} else {
+		cfg.Tracing = opts
+	}
+
+	// Database instrumentation
+	cfg.SlowQueryThreshold = 250 * time.Millisecond
+	if os.Getenv("DB_SLOW_QUERY_THRESHOLD") == "0" {
+		cfg.SlowQueryThreshold = 0
+	} else {
+		cfg.SlowQueryThreshold = cfg.getEnvDuration("DB_SLOW_QUERY_THRESHOLD", cfg.SlowQueryThreshold)
+	}
+	cfg.MetricsAddr = os.Getenv("METRICS_ADDR")
+	cfg.EndpointCacheTTL = 5 * time.Second
+	if os.Getenv("ENDPOINT_CACHE_TTL") == "0" {
+		cfg.EndpointCacheTTL = 0
+	} else {
+		cfg.EndpointCacheTTL = cfg.getEnvDuration("ENDPOINT_CACHE_TTL", cfg.EndpointCacheTTL)
+	}
+
+	// Ingestion guards (optional)
+	cfg.IngestRequireJSON = os.Getenv("INGEST_REQUIRE_JSON") == "true"
+	if spec := os.Getenv("INGEST_BANNED_PATTERNS"); spec != "" {
+		patterns, err := parsePatterns(spec)
+		if err != nil {
+			cfg.problems = append(cfg.problems, Problem{Key: "INGEST_BANNED_PATTERNS", Message: err.Error() + "; no payloads are rejected by pattern"})
+		} else {
+			cfg.IngestBannedPatterns = patterns
+		}
+	}
+	cfg.loadIDFormats()
+	cfg.IngestDailyLimitMB = cfg.getEnvInt("INGEST_DAILY_LIMIT_MB", 0)
+	cfg.IngestRateLimit = cfg.getEnvInt("INGEST_RATE_LIMIT", 0)
+	cfg.IngestIPRateLimit = cfg.getEnvInt("INGEST_IP_RATE_LIMIT", 0)
+
+	// Replay safety
+	cfg.ReplayRateLimit = cfg.getEnvInt("REPLAY_RATE_LIMIT", 30)
+	cfg.ReplayConfirmThreshold = cfg.getEnvInt("REPLAY_CONFIRM_THRESHOLD", 20)
+
+	// Maintenance schedule and retention
+	cfg.SchedulerInterval = cfg.getEnvDuration("SCHEDULER_INTERVAL", time.Hour)
+	cfg.DeadLetterAge = cfg.getEnvDuration("DEAD_LETTER_AGE", 7*24*time.Hour)
+	cfg.DeliveredRetention = cfg.getEnvDuration("DELIVERED_RETENTION", 7*24*time.Hour)
+	cfg.FailedRetention = cfg.getEnvDuration("FAILED_RETENTION", 7*24*time.Hour)
+	cfg.DeadLetterRetention = cfg.getEnvDuration("DEAD_LETTER_RETENTION", 14*24*time.Hour)
+	cfg.SkippedRetention = cfg.getEnvDuration("SKIPPED_RETENTION", 7*24*time.Hour)
+	cfg.ActivityRetention = cfg.getEnvDuration("ACTIVITY_RETENTION", 7*24*time.Hour)
+	cfg.AuditRetention = cfg.getEnvDuration("AUDIT_RETENTION", 365*24*time.Hour)
+	cfg.RetentionGrace = cfg.getEnvDuration("RETENTION_GRACE", 72*time.Hour)
+	cfg.EndpointArchiveAfter = cfg.getEnvDuration("ENDPOINT_ARCHIVE_AFTER", 0)
+	cfg.ColdStorageURL = os.Getenv("COLD_STORAGE_URL")
+	if d := cfg.EndpointArchiveAfter; d > 0 && d < minEndpointArchiveAfter {
+		cfg.problems = append(cfg.problems, Problem{Key: "ENDPOINT_ARCHIVE_AFTER", Message: fmt.Sprintf("%v is shorter than %v; archiving is disabled", d, minEndpointArchiveAfter)})
+		cfg.EndpointArchiveAfter = 0
+	}
+
 	return cfg, nil
 }
 
+// minEndpointArchiveAfter keeps a typo such as "7h" for "7d" from archiving
+// every endpoint that is quiet overnight.
+const minEndpointArchiveAfter = 24 * time.Hour
+
+// Problem is a configuration mistake found by Validate.
+type Problem struct {
+	Key     string // Environment variable at fault
+	Message string
+	// Fatal problems stop the edge even with --allow-degraded. The others
+	// leave it running with a feature disabled or a default in place.
+	Fatal bool
+}
+
+func (p Problem) String() string {
+	return p.Key + ": " + p.Message
+}
+
+// ValidationError reports every problem found in the configuration.
+type ValidationError struct {
+	Problems []Problem
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Problems))
+	for i, p := range e.Problems {
+		msgs[i] = p.String()
+	}
+	return fmt.Sprintf("%d configuration problem(s): %s", len(e.Problems), strings.Join(msgs, "; "))
+}
+
+// Fatal reports whether any problem stops the edge from starting at all.
+func (e *ValidationError) Fatal() bool {
+	for _, p := range e.Problems {
+		if p.Fatal {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the configuration for missing, invalid and half-configured
+// settings. It returns a *ValidationError listing all of them, or nil.
+func (c *Config) Validate() error {
+	problems := append([]Problem(nil), c.problems...)
+	add := func(key, msg string) {
+		problems = append(problems, Problem{Key: key, Message: msg})
+	}
+
+	if c.Port < 1 || c.Port > 65535 {
+		problems = append(problems, Problem{Key: "PORT", Message: fmt.Sprintf("%d is not a valid port", c.Port), Fatal: true})
+	}
+
+	if u, err := url.Parse(c.BaseURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		add("BASE_URL", fmt.Sprintf("%q must be an absolute http:// or https:// URL such as https://hooks.example.com", c.BaseURL))
+	} else if strings.HasSuffix(c.BaseURL, "/") {
+		add("BASE_URL", fmt.Sprintf("%q must not end with a slash", c.BaseURL))
+	}
+
+	switch {
+	case c.GitHubClientID != "" && c.GitHubClientSecret == "":
+		add("GITHUB_CLIENT_SECRET", "required with GITHUB_CLIENT_ID; without it auth and the relay service are disabled")
+	case c.GitHubClientID == "" && c.GitHubClientSecret != "":
+		add("GITHUB_CLIENT_ID", "required with GITHUB_CLIENT_SECRET; without it auth and the relay service are disabled")
+	case !c.GitHubAuthEnabled():
+		add("GITHUB_CLIENT_ID", "not set: the API runs without auth and the relay service is disabled, so hubs can't connect")
+	}
+	if !c.GitHubAuthEnabled() && (c.GitHubOrg != "" || len(c.GitHubAllowedUsers) > 0) {
+		add("GITHUB_ORG", "GITHUB_ORG and GITHUB_ALLOWED_USERS are ignored without GitHub auth")
+	}
+
+	switch {
+	case c.TelegramBotToken != "" && c.TelegramChatID == "":
+		add("TELEGRAM_CHAT_ID", "required with TELEGRAM_BOT_TOKEN; system notifications are disabled")
+	case c.TelegramBotToken == "" && c.TelegramChatID != "":
+		add("TELEGRAM_BOT_TOKEN", "required with TELEGRAM_CHAT_ID; system notifications are disabled")
+	}
+
+	if c.Region == "" && len(c.Regions) > 0 {
+		add("REGION", "required with EDGE_REGIONS; region hints are disabled")
+	}
+
+	if c.SentryDSN != "" {
+		if err := errreport.CheckDSN(c.SentryDSN); err != nil {
+			add("SENTRY_DSN", err.Error()+"; error reporting is disabled")
+		}
+	}
+
+	if c.LogMaxSizeMB < 0 {
+		add("LOG_MAX_SIZE_MB", "must not be negative (0 disables rotation)")
+	}
+	if c.LogMaxBackups < 0 {
+		add("LOG_MAX_BACKUPS", "must not be negative")
+	}
+
+	if c.MetricsAddr != "" {
+		if _, _, err := net.SplitHostPort(c.MetricsAddr); err != nil {
+			add("METRICS_ADDR", fmt.Sprintf("%q is not a host:port address such as 127.0.0.1:9090; metrics are disabled", c.MetricsAddr))
+		}
+	}
+
+	if c.IngestDailyLimitMB < 0 {
+		add("INGEST_DAILY_LIMIT_MB", "must not be negative (0 disables)")
+	}
+
+	if c.ReplayRateLimit < 0 {
+		add("REPLAY_RATE_LIMIT", "must not be negative (0 disables)")
+	}
+	if c.ReplayConfirmThreshold < 0 {
+		add("REPLAY_CONFIRM_THRESHOLD", "must not be negative (0 disables)")
+	}
+
+	// Fatal: running without it would delete webhooks that should be kept
+	if c.ColdStorageURL != "" {
+		if _, err := coldstore.Open(c.ColdStorageURL, os.Getenv); err != nil {
+			problems = append(problems, Problem{Key: "COLD_STORAGE_URL", Message: err.Error(), Fatal: true})
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: problems}
+}
+
+// LogOptions returns the logger options.
+func (c *Config) LogOptions() logging.Options {
+	return logging.Options{
+		Level:          c.LogLevel,
+		Format:         c.LogFormat,
+		File:           c.LogFile,
+		MaxSizeMB:      c.LogMaxSizeMB,
+		MaxBackups:     c.LogMaxBackups,
+		SampleInterval: c.LogSample,
+	}
+}
+
+// ReloadLogLevel reads LOG_LEVEL again, from the .env file if it sets it and
+// from the environment otherwise, so the level can be changed on SIGHUP.
+func ReloadLogLevel() (slog.Level, error) {
+	val := os.Getenv("LOG_LEVEL")
+	if env, err := godotenv.Read(); err == nil && env["LOG_LEVEL"] != "" {
+		val = env["LOG_LEVEL"]
+	}
+	if val == "" {
+		return slog.LevelInfo, nil
+	}
+	return logging.ParseLevel(val)
+}
+
+func (c *Config) logLevel() slog.Level {
+	val := os.Getenv("LOG_LEVEL")
+	if val == "" {
+		return slog.LevelInfo
+	}
+	level, err := logging.ParseLevel(val)
+	if err != nil {
+		c.problems = append(c.problems, Problem{Key: "LOG_LEVEL", Message: err.Error() + ", using info"})
+	}
+	return level
+}
+
+// fatal records a problem that stops the edge from starting.
+func (c *Config) fatal(key, msg string) {
+	c.problems = append(c.problems, Problem{Key: key, Message: msg, Fatal: true})
+}
+
+// parseDatabaseURL returns the SQLite file of a database URL such as
+// sqlite:///data/hookly.db. Only SQLite is supported.
+func parseDatabaseURL(v string) (string, error) {
+	scheme, rest, ok := strings.Cut(v, ":")
+	switch {
+	case !ok:
+		return "", fmt.Errorf("%q must be a URL such as sqlite:///data/hookly.db", v)
+	case scheme != "sqlite" && scheme != "file":
+		return "", fmt.Errorf("unsupported database %q (valid: sqlite)", scheme)
+	}
+	path := strings.TrimPrefix(rest, "//")
+	if path == "" {
+		return "", fmt.Errorf("%q has no file path", v)
+	}
+	return path, nil
+}
+
+// parseNets parses a comma-separated list of CIDRs such as "127.0.0.1/32,10.8.0.0/24".
+func parseNets(spec string) ([]*net.IPNet, error) {
+	var nets []*net.IPNet
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		_, n, err := net.ParseCIDR(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid network %q: expected a CIDR such as 127.0.0.1/32", part)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
+
+// parsePatterns parses a comma-separated list of regular expressions. A
+// comma inside an expression is written as \x2c.
+func parsePatterns(spec string) ([]*regexp.Regexp, error) {
+	var patterns []*regexp.Regexp
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		re, err := regexp.Compile(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %v", part, err)
+		}
+		patterns = append(patterns, re)
+	}
+	return patterns, nil
+}
+
+// RegionsEnabled returns true if this edge is part of a multi-region service.
+func (c *Config) RegionsEnabled() bool {
+	return c.Region != "" && len(c.Regions) > 0
+}
+
 // GitHubAuthEnabled returns true if GitHub OAuth is configured.
 func (c *Config) GitHubAuthEnabled() bool {
 	return c.GitHubClientID != "" && c.GitHubClientSecret != ""
@@ -79,6 +615,48 @@ func (c *Config) TelegramEnabled() bool {
 	return c.TelegramBotToken != "" && c.TelegramChatID != ""
 }
 
+// EmailEnabled returns true if system email notifications are configured.
+func (c *Config) EmailEnabled() bool {
+	return c.SMTP.Enabled() && c.SMTPTo != ""
+}
+
+// DiscordEnabled returns true if Discord notifications are configured.
+func (c *Config) DiscordEnabled() bool {
+	return c.DiscordWebhookURL != ""
+}
+
+// NotifyWebhookEnabled returns true if the system notification webhook is
+// configured.
+func (c *Config) NotifyWebhookEnabled() bool {
+	return c.NotifyWebhookURL != ""
+}
+
+// loadIDFormats reads the formats of new endpoint and webhook IDs. Invalid
+// settings are recorded as problems and fall back to the defaults.
+func (c *Config) loadIDFormats() {
+	alphabet := getEnv("ID_ALPHABET", id.DefaultAlphabet)
+	if err := (id.Format{Alphabet: alphabet, Length: 255}).Validate(0); err != nil {
+		c.problems = append(c.problems, Problem{Key: "ID_ALPHABET", Message: err.Error() + "; using the default"})
+		alphabet = id.DefaultAlphabet
+	}
+	formats := []struct {
+		key     string
+		format  *id.Format
+		length  int
+		minBits float64
+	}{
+		{"ENDPOINT_ID_LENGTH", &c.EndpointIDFormat, id.EndpointIDLength, id.MinEndpointIDBits},
+		{"WEBHOOK_ID_LENGTH", &c.WebhookIDFormat, id.WebhookIDLength, id.MinWebhookIDBits},
+	}
+	for _, f := range formats {
+		*f.format = id.Format{Alphabet: alphabet, Length: c.getEnvInt(f.key, f.length)}
+		if err := f.format.Validate(f.minBits); err != nil {
+			c.problems = append(c.problems, Problem{Key: f.key, Message: err.Error() + fmt.Sprintf("; using %d", f.length)})
+			*f.format = id.Format{Alphabet: id.DefaultAlphabet, Length: f.length}
+		}
+	}
+}
+
 func getEnv(key, defaultVal string) string {
 	if val := os.Getenv(key); val != "" {
 		return val
@@ -86,11 +664,44 @@ func getEnv(key, defaultVal string) string {
 	return defaultVal
 }
 
-func getEnvInt(key string, defaultVal int) int {
-	if val := os.Getenv(key); val != "" {
-		if i, err := strconv.Atoi(val); err == nil {
-			return i
-		}
+// getEnvInt parses an integer. Invalid values are recorded as problems and
+// fall back to the default.
+func (c *Config) getEnvInt(key string, defaultVal int) int {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal
 	}
-	return defaultVal
+	i, err := strconv.Atoi(val)
+	if err != nil {
+		c.problems = append(c.problems, Problem{Key: key, Message: fmt.Sprintf("%q is not an integer, using %d", val, defaultVal)})
+		return defaultVal
+	}
+	return i
+}
+
+// getEnvDuration parses a Go duration such as "90m" or "168h". Invalid or
+// non-positive values are recorded as problems and fall back to the default.
+func (c *Config) getEnvDuration(key string, defaultVal time.Duration) time.Duration {
+	val := os.Getenv(key)
+	if val == "" {
+		return defaultVal
+	}
+	d, err := time.ParseDuration(val)
+	if err != nil || d <= 0 {
+		c.problems = append(c.problems, Problem{Key: key, Message: fmt.Sprintf("%q is not a positive duration such as 90m or 168h, using %s", val, defaultVal)})
+		return defaultVal
+	}
+	return d
+}
+
+// getEnvDurationIn is getEnvDuration for values that must be within lo and
+// hi. Values outside the bounds are recorded as problems and fall back to the
+// default.
+func (c *Config) getEnvDurationIn(key string, defaultVal, lo, hi time.Duration) time.Duration {
+	d := c.getEnvDuration(key, defaultVal)
+	if d < lo || d > hi {
+		c.problems = append(c.problems, Problem{Key: key, Message: fmt.Sprintf("%v is outside %v to %v, using %v", d, lo, hi, defaultVal)})
+		return defaultVal
+	}
+	return d
 }
@@ -0,0 +1,458 @@
+package config
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"hooks.dx314.com/internal/notify"
+)
+
+const testKey = "0000000000000000000000000000000000000000000000000000000000000000"
+
+// loadProblems loads the config from env and returns its problems by key.
+func loadProblems(t *testing.T, env map[string]string) map[string]Problem {
+	t.Helper()
+	for _, key := range []string{
+		"ENCRYPTION_KEY", "ENCRYPTION_KEY_SOURCE", "ENCRYPTION_KEY_WRAPPED", "PORT", "BASE_URL",
+		"GITHUB_CLIENT_ID", "GITHUB_CLIENT_SECRET", "GITHUB_ORG", "GITHUB_ALLOWED_USERS",
+		"TELEGRAM_BOT_TOKEN", "TELEGRAM_CHAT_ID", "SCHEDULER_INTERVAL", "RELAY_STALE_TIMEOUT",
+		"INGEST_BANNED_PATTERNS", "ENDPOINT_ARCHIVE_AFTER", "MAINTENANCE_RECONNECT_URL",
+		"COLD_STORAGE_URL", "AWS_REGION", "AWS_DEFAULT_REGION",
+		"OTEL_EXPORTER_OTLP_ENDPOINT", "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "OTEL_EXPORTER_OTLP_HEADERS",
+		"WEBHOOK_PATH_PREFIX", "ID_ALPHABET", "ENDPOINT_ID_LENGTH", "WEBHOOK_ID_LENGTH", "DISCORD_WEBHOOK_URL",
+		"DATABASE_READ_URL", "SMTP_HOST", "SMTP_PORT", "SMTP_FROM", "SMTP_TO", "SMTP_SECURITY",
+		"NOTIFY_WEBHOOK_URL", "NOTIFY_WEBHOOK_SECRET",
+	} {
+		t.Setenv(key, env[key])
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	err = cfg.Validate()
+	if err == nil {
+		return nil
+	}
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("validate returned %T, want *ValidationError", err)
+	}
+	problems := make(map[string]Problem)
+	for _, p := range verr.Problems {
+		problems[p.Key] = p
+	}
+	return problems
+}
+
+func TestValidateComplete(t *testing.T) {
+	problems := loadProblems(t, map[string]string{
+		"ENCRYPTION_KEY":       testKey,
+		"BASE_URL":             "https://hooks.example.com",
+		"GITHUB_CLIENT_ID":     "id",
+		"GITHUB_CLIENT_SECRET": "secret",
+	})
+	if problems != nil {
+		t.Errorf("unexpected problems: %v", problems)
+	}
+}
+
+func TestValidateReportsAllProblems(t *testing.T) {
+	problems := loadProblems(t, map[string]string{
+		"ENCRYPTION_KEY":         "abcd",
+		"BASE_URL":               "hooks.example.com",
+		"TELEGRAM_BOT_TOKEN":     "token",
+		"SCHEDULER_INTERVAL":     "hourly",
+		"INGEST_BANNED_PATTERNS": `^MZ,(unclosed`,
+	})
+
+	tests := []struct {
+		key   string
+		fatal bool
+		want  string
+	}{
+		{"ENCRYPTION_KEY", true, "got 4 characters"},
+		{"BASE_URL", false, "absolute http"},
+		{"TELEGRAM_CHAT_ID", false, "required with TELEGRAM_BOT_TOKEN"},
+		{"GITHUB_CLIENT_ID", false, "relay service is disabled"},
+		{"SCHEDULER_INTERVAL", false, "using 1h0m0s"},
+		{"INGEST_BANNED_PATTERNS", false, `"(unclosed"`},
+	}
+	for _, tt := range tests {
+		p, ok := problems[tt.key]
+		if !ok {
+			t.Errorf("%s: no problem reported", tt.key)
+			continue
+		}
+		if p.Fatal != tt.fatal {
+			t.Errorf("%s: fatal = %v, want %v", tt.key, p.Fatal, tt.fatal)
+		}
+		if !strings.Contains(p.Message, tt.want) {
+			t.Errorf("%s: message %q doesn't mention %q", tt.key, p.Message, tt.want)
+		}
+	}
+	if len(problems) != len(tests) {
+		t.Errorf("got %d problems, want %d: %v", len(problems), len(tests), problems)
+	}
+}
+
+func TestValidateHalfConfiguredAuth(t *testing.T) {
+	problems := loadProblems(t, map[string]string{
+		"ENCRYPTION_KEY":   testKey,
+		"GITHUB_CLIENT_ID": "id",
+		"GITHUB_ORG":       "acme",
+	})
+	if _, ok := problems["GITHUB_CLIENT_SECRET"]; !ok {
+		t.Error("missing GITHUB_CLIENT_SECRET not reported")
+	}
+	if _, ok := problems["GITHUB_ORG"]; !ok {
+		t.Error("ignored GITHUB_ORG not reported")
+	}
+}
+
+func TestEndpointArchiveAfter(t *testing.T) {
+	t.Setenv("ENDPOINT_ARCHIVE_AFTER", "")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.EndpointArchiveAfter != 0 {
+		t.Errorf("default = %v, want disabled", cfg.EndpointArchiveAfter)
+	}
+
+	problems := loadProblems(t, map[string]string{
+		"ENCRYPTION_KEY":         testKey,
+		"BASE_URL":               "https://hooks.example.com",
+		"GITHUB_CLIENT_ID":       "id",
+		"GITHUB_CLIENT_SECRET":   "secret",
+		"ENDPOINT_ARCHIVE_AFTER": "7h",
+	})
+	if p, ok := problems["ENDPOINT_ARCHIVE_AFTER"]; !ok || !strings.Contains(p.Message, "archiving is disabled") {
+		t.Errorf("short ENDPOINT_ARCHIVE_AFTER: got %+v", p)
+	}
+}
+
+func TestColdStorageURL(t *testing.T) {
+	complete := map[string]string{
+		"ENCRYPTION_KEY":       testKey,
+		"BASE_URL":             "https://hooks.example.com",
+		"GITHUB_CLIENT_ID":     "id",
+		"GITHUB_CLIENT_SECRET": "secret",
+	}
+
+	complete["COLD_STORAGE_URL"] = t.TempDir()
+	if problems := loadProblems(t, complete); problems != nil {
+		t.Errorf("directory: unexpected problems %v", problems)
+	}
+
+	// Retention would delete what should have been exported
+	complete["COLD_STORAGE_URL"] = "s3://audit"
+	p, ok := loadProblems(t, complete)["COLD_STORAGE_URL"]
+	if !ok || !p.Fatal || !strings.Contains(p.Message, "AWS_REGION") {
+		t.Errorf("s3 without a region: got %+v", p)
+	}
+}
+
+func TestTracing(t *testing.T) {
+	complete := map[string]string{
+		"ENCRYPTION_KEY":              testKey,
+		"BASE_URL":                    "https://hooks.example.com",
+		"GITHUB_CLIENT_ID":            "id",
+		"GITHUB_CLIENT_SECRET":        "secret",
+		"OTEL_EXPORTER_OTLP_ENDPOINT": "http://localhost:4318",
+	}
+	if problems := loadProblems(t, complete); problems != nil {
+		t.Errorf("valid endpoint: unexpected problems %v", problems)
+	}
+
+	// A bad collector URL only disables export
+	complete["OTEL_EXPORTER_OTLP_ENDPOINT"] = "localhost:4318"
+	p, ok := loadProblems(t, complete)["OTEL_EXPORTER_OTLP_ENDPOINT"]
+	if !ok || p.Fatal {
+		t.Errorf("invalid endpoint: got %+v", p)
+	}
+}
+
+func TestMaintenanceReconnectURL(t *testing.T) {
+	t.Setenv("MAINTENANCE_RECONNECT_URL", "https://standby.hooks.example.com/")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.MaintenanceReconnectURL != "https://standby.hooks.example.com" {
+		t.Errorf("MaintenanceReconnectURL = %q", cfg.MaintenanceReconnectURL)
+	}
+
+	problems := loadProblems(t, map[string]string{
+		"ENCRYPTION_KEY":            testKey,
+		"BASE_URL":                  "https://hooks.example.com",
+		"GITHUB_CLIENT_ID":          "id",
+		"GITHUB_CLIENT_SECRET":      "secret",
+		"MAINTENANCE_RECONNECT_URL": "http://standby:8080",
+	})
+	if _, ok := problems["MAINTENANCE_RECONNECT_URL"]; !ok {
+		t.Error("expected a problem for a plain http standby URL")
+	}
+}
+
+func TestWebhookPathPrefix(t *testing.T) {
+	t.Setenv("WEBHOOK_PATH_PREFIX", "hooks/in")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.WebhookPathPrefix != "/hooks/in/" {
+		t.Errorf("WebhookPathPrefix = %q", cfg.WebhookPathPrefix)
+	}
+
+	problems := loadProblems(t, map[string]string{
+		"ENCRYPTION_KEY":       testKey,
+		"BASE_URL":             "https://hooks.example.com",
+		"GITHUB_CLIENT_ID":     "id",
+		"GITHUB_CLIENT_SECRET": "secret",
+		"WEBHOOK_PATH_PREFIX":  "/api/",
+	})
+	if _, ok := problems["WEBHOOK_PATH_PREFIX"]; !ok {
+		t.Error("expected a problem for a prefix the API is served under")
+	}
+}
+
+func TestDiscordWebhookURL(t *testing.T) {
+	t.Setenv("DISCORD_WEBHOOK_URL", "https://discord.com/api/webhooks/123/token")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !cfg.DiscordEnabled() {
+		t.Error("Discord not enabled")
+	}
+
+	problems := loadProblems(t, map[string]string{
+		"ENCRYPTION_KEY":       testKey,
+		"BASE_URL":             "https://hooks.example.com",
+		"GITHUB_CLIENT_ID":     "id",
+		"GITHUB_CLIENT_SECRET": "secret",
+		"DISCORD_WEBHOOK_URL":  "https://example.com/api/webhooks/123/token",
+	})
+	if p, ok := problems["DISCORD_WEBHOOK_URL"]; !ok || strings.Contains(p.Message, "/123/") {
+		t.Errorf("problem for a URL on another host = %q, %v", p.Message, ok)
+	}
+}
+
+func TestNotifyWebhook(t *testing.T) {
+	t.Setenv("NOTIFY_WEBHOOK_URL", "https://alerts.example.com/hookly")
+	t.Setenv("NOTIFY_WEBHOOK_SECRET", "s3cret")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !cfg.NotifyWebhookEnabled() || cfg.NotifyWebhookSecret != "s3cret" {
+		t.Errorf("notification webhook = %q, %q", cfg.NotifyWebhookURL, cfg.NotifyWebhookSecret)
+	}
+
+	problems := loadProblems(t, map[string]string{
+		"ENCRYPTION_KEY":       testKey,
+		"BASE_URL":             "https://hooks.example.com",
+		"GITHUB_CLIENT_ID":     "id",
+		"GITHUB_CLIENT_SECRET": "secret",
+		"NOTIFY_WEBHOOK_URL":   "https://alerts.example.com/hookly",
+	})
+	if p, ok := problems["NOTIFY_WEBHOOK_SECRET"]; !ok || p.Fatal {
+		t.Errorf("problem for a webhook without a secret = %+v, %v; want a non-fatal problem", p, ok)
+	}
+}
+
+func TestDatabaseReadURL(t *testing.T) {
+	t.Setenv("DATABASE_READ_URL", "sqlite:///data/replica.db")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.DatabaseReadPath != "/data/replica.db" {
+		t.Errorf("DatabaseReadPath = %q", cfg.DatabaseReadPath)
+	}
+
+	problems := loadProblems(t, map[string]string{
+		"ENCRYPTION_KEY":       testKey,
+		"BASE_URL":             "https://hooks.example.com",
+		"GITHUB_CLIENT_ID":     "id",
+		"GITHUB_CLIENT_SECRET": "secret",
+		"DATABASE_READ_URL":    "postgres://replica/hookly",
+	})
+	if p, ok := problems["DATABASE_READ_URL"]; !ok || p.Fatal {
+		t.Errorf("problem for a Postgres replica = %+v, %v; want a non-fatal problem", p, ok)
+	}
+}
+
+func TestParseDatabaseURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    string
+		wantErr bool
+	}{
+		{"sqlite:///data/hookly.db", "/data/hookly.db", false},
+		{"sqlite:hookly.db", "hookly.db", false},
+		{"file:./hookly.db", "./hookly.db", false},
+		{"postgres://hookly@db:5432/hookly", "", true},
+		{"mysql://db/hookly", "", true},
+		{"sqlite://", "", true},
+		{"/data/hookly.db", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := parseDatabaseURL(tt.url)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("parseDatabaseURL(%q) = %q, %v; want %q, error %v", tt.url, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSMTP(t *testing.T) {
+	t.Setenv("SMTP_HOST", "smtp.example.com")
+	t.Setenv("SMTP_PORT", "465")
+	t.Setenv("SMTP_FROM", "Hookly <hookly@example.com>")
+	t.Setenv("SMTP_TO", "ops@example.com")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if !cfg.EmailEnabled() || cfg.SMTP.Security != notify.SMTPTLS {
+		t.Errorf("SMTP = %+v, enabled %v; want implicit TLS on port 465", cfg.SMTP, cfg.EmailEnabled())
+	}
+
+	base := map[string]string{
+		"ENCRYPTION_KEY":       testKey,
+		"BASE_URL":             "https://hooks.example.com",
+		"GITHUB_CLIENT_ID":     "id",
+		"GITHUB_CLIENT_SECRET": "secret",
+	}
+	for key, env := range map[string]map[string]string{
+		"SMTP_HOST":     {"SMTP_TO": "ops@example.com"},
+		"SMTP_FROM":     {"SMTP_HOST": "smtp.example.com", "SMTP_FROM": "not an address"},
+		"SMTP_SECURITY": {"SMTP_HOST": "smtp.example.com", "SMTP_FROM": "hookly@example.com", "SMTP_SECURITY": "ssl"},
+	} {
+		for k, v := range base {
+			env[k] = v
+		}
+		if _, ok := loadProblems(t, env)[key]; !ok {
+			t.Errorf("no %s problem for %v", key, env)
+		}
+	}
+}
+
+func TestIDFormats(t *testing.T) {
+	t.Setenv("ID_ALPHABET", "0123456789abcdef")
+	t.Setenv("ENDPOINT_ID_LENGTH", "40")
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+	if cfg.EndpointIDFormat.Length != 40 || cfg.EndpointIDFormat.Alphabet != "0123456789abcdef" || cfg.WebhookIDFormat.Length != 21 {
+		t.Errorf("formats = %+v, %+v", cfg.EndpointIDFormat, cfg.WebhookIDFormat)
+	}
+
+	// Endpoint IDs are capabilities: too short to be secret falls back
+	problems := loadProblems(t, map[string]string{
+		"ENCRYPTION_KEY":       testKey,
+		"BASE_URL":             "https://hooks.example.com",
+		"GITHUB_CLIENT_ID":     "id",
+		"GITHUB_CLIENT_SECRET": "secret",
+		"ENDPOINT_ID_LENGTH":   "16",
+		"ID_ALPHABET":          "abc/",
+	})
+	for _, key := range []string{"ENDPOINT_ID_LENGTH", "ID_ALPHABET"} {
+		if _, ok := problems[key]; !ok {
+			t.Errorf("expected a problem for %s", key)
+		}
+	}
+}
+
+func TestKeepaliveBounds(t *testing.T) {
+	problems := loadProblems(t, map[string]string{
+		"ENCRYPTION_KEY":       testKey,
+		"BASE_URL":             "https://hooks.example.com",
+		"GITHUB_CLIENT_ID":     "id",
+		"GITHUB_CLIENT_SECRET": "secret",
+		"RELAY_STALE_TIMEOUT":  "5s",
+	})
+	if p, ok := problems["RELAY_STALE_TIMEOUT"]; !ok || !strings.Contains(p.Message, "using 1m0s") {
+		t.Errorf("out of bounds RELAY_STALE_TIMEOUT: got %+v", p)
+	}
+
+	hub := &HooklyConfig{EdgeURL: "https://hooks.example.com", Endpoints: []EndpointConfig{{ID: "ep_1"}}}
+	if err := hub.Validate(); err != nil {
+		t.Fatalf("default keepalive: %v", err)
+	}
+	if got := hub.Keepalive.Heartbeat(); got != DefaultHeartbeatInterval {
+		t.Errorf("default heartbeat = %v", got)
+	}
+
+	for _, k := range []KeepaliveConfig{
+		{HeartbeatInterval: time.Minute},
+		{PingTimeout: 100 * time.Millisecond},
+		{ReadIdleTimeout: 5 * time.Second, PingTimeout: 10 * time.Second},
+	} {
+		hub.Keepalive = k
+		if err := hub.Validate(); err == nil {
+			t.Errorf("keepalive %+v accepted", k)
+		}
+	}
+
+	hub.Keepalive = KeepaliveConfig{HeartbeatInterval: 5 * time.Second, ReadIdleTimeout: 10 * time.Second}
+	if err := hub.Validate(); err != nil {
+		t.Errorf("valid keepalive rejected: %v", err)
+	}
+}
+
+func TestRouteValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		route   RouteConfig
+		wantErr bool
+	}{
+		{"prefix", RouteConfig{Field: "type", Prefix: "invoice.", Destination: "http://localhost:4000/billing"}, false},
+		{"equals", RouteConfig{Field: "/data/object/status", Equals: "paid", Destination: "https://billing.internal/hooks"}, false},
+		{"no field", RouteConfig{Prefix: "invoice.", Destination: "http://localhost:4000"}, true},
+		{"no matcher", RouteConfig{Field: "type", Destination: "http://localhost:4000"}, true},
+		{"both matchers", RouteConfig{Field: "type", Equals: "a", Prefix: "a", Destination: "http://localhost:4000"}, true},
+		{"no destination", RouteConfig{Field: "type", Prefix: "invoice."}, true},
+		{"not http", RouteConfig{Field: "type", Prefix: "invoice.", Destination: "localhost:4000"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := &HooklyConfig{EdgeURL: "https://hooks.example.com", Endpoints: []EndpointConfig{{ID: "ep_1", Routes: []RouteConfig{tt.route}}}}
+			if err := hub.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func FuzzParseHooklyYAML(f *testing.F) {
+	f.Add([]byte(ExampleYAML()))
+	f.Add([]byte("edge_url: https://hooks.example.com\nendpoints:\n  - id: ep_1\n    event_types: [\"\"]\n"))
+	f.Add([]byte("edge_url: x\nendpoints: [{id: a}]\nkeepalive: {heartbeat_interval: 99999999999h}\n"))
+	f.Add([]byte("edge_url: x\nendpoints: [{id: a}]\ntunnel: {listen: \"[::1\"}\n"))
+	f.Add([]byte("a: &a [*a, *a]\n"))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		cfg, err := ParseHooklyYAML(data)
+		if err != nil {
+			return
+		}
+		if cfg.EdgeURL == "" || len(cfg.Endpoints) == 0 {
+			t.Errorf("invalid config accepted: %+v", cfg)
+		}
+		// Accessors on a validated config must not panic
+		cfg.EndpointIDs()
+		cfg.EventTypeFilters()
+		cfg.GetRoutes(cfg.Endpoints[0].ID)
+		cfg.Keepalive.Heartbeat()
+		if cfg.Tunnel != nil {
+			cfg.Tunnel.Address()
+		}
+	})
+}
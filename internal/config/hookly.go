@@ -3,10 +3,15 @@ package config
 import (
 	"errors"
 	"fmt"
+	"net"
+	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"hooks.dx314.com/internal/errreport"
 )
 
 // HooklyConfig holds configuration for the hookly CLI.
@@ -14,14 +19,134 @@ type HooklyConfig struct {
 	EdgeURL   string           `yaml:"edge_url"`
 	HubID     string           `yaml:"hub_id,omitempty"` // Optional, auto-generated from hostname if empty
 	Endpoints []EndpointConfig `yaml:"endpoints"`
+	// MetricsAddr is the listen address of the local status server exposing
+	// OpenMetrics on /metrics, e.g. 127.0.0.1:9464. Disabled if empty.
+	MetricsAddr string `yaml:"metrics_addr,omitempty"`
+	// Tunnel enables delivery over a WireGuard or SSH reverse tunnel to the
+	// edge while the relay stream is down. Disabled if nil.
+	Tunnel *TunnelConfig `yaml:"tunnel,omitempty"`
+	// SentryDSN enables reporting panics and errors to Sentry. Disabled if empty.
+	SentryDSN string `yaml:"sentry_dsn,omitempty"`
+	// Keepalive tunes how the relay stream detects dead connections.
+	Keepalive KeepaliveConfig `yaml:"keepalive,omitempty"`
+	// ShareLogs lets the account owner fetch this hub's recent log lines
+	// from the edge, for support. Off by default.
+	ShareLogs bool `yaml:"share_logs,omitempty"`
 	// Token is loaded from credentials, not from YAML
 	Token string `yaml:"-"`
 }
 
 // EndpointConfig defines an endpoint this hub handles.
 type EndpointConfig struct {
-	ID          string `yaml:"id"`
-	Destination string `yaml:"destination,omitempty"` // Optional override
+	ID          string   `yaml:"id"`
+	Destination string   `yaml:"destination,omitempty"` // Optional override
+	EventTypes  []string `yaml:"event_types,omitempty"` // Optional, relay only these event types
+	// Routes send webhooks whose payload matches a rule to another
+	// destination. The first matching route wins; webhooks matching none go
+	// to Destination, or the edge-configured destination.
+	Routes []RouteConfig `yaml:"routes,omitempty"`
+}
+
+// RouteConfig routes webhooks by a field of their JSON payload. Exactly one of
+// Equals and Prefix is set.
+type RouteConfig struct {
+	// Field is the payload field compared, in dot notation ("type",
+	// "data.object.status") or as a JSON pointer ("/data/object/status").
+	Field       string `yaml:"field"`
+	Equals      string `yaml:"equals,omitempty"`
+	Prefix      string `yaml:"prefix,omitempty"`
+	Destination string `yaml:"destination"`
+}
+
+// TunnelConfig configures the hub's tunnel delivery listener. The tunnel
+// itself (wg-quick, ssh -R) is set up outside hookly.
+type TunnelConfig struct {
+	// Listen is the local address of the delivery listener, e.g. 127.0.0.1:9465.
+	Listen string `yaml:"listen"`
+	// EdgeAddress is the ip:port the edge dials to reach the listener through
+	// the tunnel, e.g. the hub's WireGuard address. Defaults to Listen, which
+	// suits "ssh -R 9465:127.0.0.1:9465 edge".
+	EdgeAddress string `yaml:"edge_address,omitempty"`
+}
+
+// Address returns the address the edge dials to reach the listener.
+func (t *TunnelConfig) Address() string {
+	if t.EdgeAddress != "" {
+		return t.EdgeAddress
+	}
+	return t.Listen
+}
+
+// Relay keepalive defaults and the bounds accepted in hookly.yaml. The hub's
+// heartbeat must stay well under the edge's stale timeout (60s by default), or
+// the edge drops the connection between heartbeats.
+const (
+	DefaultHeartbeatInterval = 15 * time.Second
+	DefaultReadIdleTimeout   = 15 * time.Second
+	DefaultPingTimeout       = 5 * time.Second
+
+	minHeartbeatInterval = time.Second
+	maxHeartbeatInterval = 45 * time.Second
+	minReadIdleTimeout   = 5 * time.Second
+	maxReadIdleTimeout   = 10 * time.Minute
+	minPingTimeout       = time.Second
+	maxPingTimeout       = time.Minute
+)
+
+// KeepaliveConfig tunes the relay stream's keepalives, for NATs and ISPs that
+// drop idle connections sooner than the defaults allow for. Zero fields keep
+// the defaults.
+type KeepaliveConfig struct {
+	// HeartbeatInterval is how often the hub sends a heartbeat to the edge.
+	HeartbeatInterval time.Duration `yaml:"heartbeat_interval,omitempty"`
+	// ReadIdleTimeout is how long the connection may be idle before the hub
+	// sends an HTTP/2 ping.
+	ReadIdleTimeout time.Duration `yaml:"read_idle_timeout,omitempty"`
+	// PingTimeout is how long the hub waits for a ping response before
+	// closing the connection.
+	PingTimeout time.Duration `yaml:"ping_timeout,omitempty"`
+}
+
+// Heartbeat returns the heartbeat interval, or the default.
+func (k KeepaliveConfig) Heartbeat() time.Duration {
+	return orDefault(k.HeartbeatInterval, DefaultHeartbeatInterval)
+}
+
+// ReadIdle returns the HTTP/2 read idle timeout, or the default.
+func (k KeepaliveConfig) ReadIdle() time.Duration {
+	return orDefault(k.ReadIdleTimeout, DefaultReadIdleTimeout)
+}
+
+// Ping returns the HTTP/2 ping timeout, or the default.
+func (k KeepaliveConfig) Ping() time.Duration {
+	return orDefault(k.PingTimeout, DefaultPingTimeout)
+}
+
+func (k KeepaliveConfig) validate() error {
+	for _, f := range []struct {
+		name     string
+		val      time.Duration
+		min, max time.Duration
+	}{
+		{"heartbeat_interval", k.HeartbeatInterval, minHeartbeatInterval, maxHeartbeatInterval},
+		{"read_idle_timeout", k.ReadIdleTimeout, minReadIdleTimeout, maxReadIdleTimeout},
+		{"ping_timeout", k.PingTimeout, minPingTimeout, maxPingTimeout},
+	} {
+		if f.val != 0 && (f.val < f.min || f.val > f.max) {
+			return fmt.Errorf("keepalive.%s: %v is outside %v to %v", f.name, f.val, f.min, f.max)
+		}
+	}
+	if k.Ping() >= k.ReadIdle() {
+		return fmt.Errorf("keepalive.ping_timeout: %v must be shorter than read_idle_timeout (%v)", k.Ping(), k.ReadIdle())
+	}
+	return nil
+}
+
+func orDefault(d, def time.Duration) time.Duration {
+	if d == 0 {
+		return def
+	}
+	return d
 }
 
 // LoadHooklyYAML loads configuration from a YAML file.
@@ -30,7 +155,11 @@ func LoadHooklyYAML(path string) (*HooklyConfig, error) {
 	if err != nil {
 		return nil, fmt.Errorf("read config file: %w", err)
 	}
+	return ParseHooklyYAML(data)
+}
 
+// ParseHooklyYAML parses and validates hookly.yaml content.
+func ParseHooklyYAML(data []byte) (*HooklyConfig, error) {
 	var cfg HooklyConfig
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("parse config file: %w", err)
@@ -56,8 +185,52 @@ func (c *HooklyConfig) Validate() error {
 		if ep.ID == "" {
 			return fmt.Errorf("endpoint %d: id is required", i)
 		}
+		for _, et := range ep.EventTypes {
+			if strings.TrimSpace(et) == "" {
+				return fmt.Errorf("endpoint %s: event_types must not contain empty values", ep.ID)
+			}
+		}
+		for j, r := range ep.Routes {
+			if err := r.validate(); err != nil {
+				return fmt.Errorf("endpoint %s: routes[%d]: %w", ep.ID, j, err)
+			}
+		}
+	}
+
+	if c.SentryDSN != "" {
+		if err := errreport.CheckDSN(c.SentryDSN); err != nil {
+			return fmt.Errorf("sentry_dsn: %w", err)
+		}
+	}
+
+	if err := c.Keepalive.validate(); err != nil {
+		return err
 	}
 
+	if c.Tunnel != nil {
+		if _, _, err := net.SplitHostPort(c.Tunnel.Listen); err != nil {
+			return fmt.Errorf("tunnel.listen: %q is not a host:port address", c.Tunnel.Listen)
+		}
+		host, _, err := net.SplitHostPort(c.Tunnel.Address())
+		if err != nil || net.ParseIP(host) == nil {
+			return fmt.Errorf("tunnel.edge_address: %q must be an ip:port address", c.Tunnel.Address())
+		}
+	}
+
+	return nil
+}
+
+func (r RouteConfig) validate() error {
+	if r.Field == "" {
+		return errors.New("field is required")
+	}
+	if (r.Equals == "") == (r.Prefix == "") {
+		return errors.New("set exactly one of equals and prefix")
+	}
+	u, err := url.Parse(r.Destination)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("destination %q must be an http:// or https:// URL", r.Destination)
+	}
 	return nil
 }
 
@@ -91,9 +264,21 @@ func (c *HooklyConfig) EndpointIDs() []string {
 	return ids
 }
 
+// EventTypeFilters returns the event types wanted for each endpoint that
+// declares event_types. Endpoints without a filter receive all event types.
+func (c *HooklyConfig) EventTypeFilters() map[string][]string {
+	filters := make(map[string][]string)
+	for _, ep := range c.Endpoints {
+		if len(ep.EventTypes) > 0 {
+			filters[ep.ID] = ep.EventTypes
+		}
+	}
+	return filters
+}
+
 // GetDestination returns the destination URL for an endpoint.
 // If the endpoint has a destination override, it's returned.
-// Otherwise, defaultDest is returned.
+// Otherwise, defaultDest is returned. Routes are not considered.
 func (c *HooklyConfig) GetDestination(endpointID, defaultDest string) string {
 	for _, ep := range c.Endpoints {
 		if ep.ID == endpointID && ep.Destination != "" {
@@ -103,17 +288,50 @@ func (c *HooklyConfig) GetDestination(endpointID, defaultDest string) string {
 	return defaultDest
 }
 
+// GetRoutes returns the payload routes of an endpoint.
+func (c *HooklyConfig) GetRoutes(endpointID string) []RouteConfig {
+	for _, ep := range c.Endpoints {
+		if ep.ID == endpointID {
+			return ep.Routes
+		}
+	}
+	return nil
+}
+
 // ExampleYAML returns an example hookly.yaml configuration.
 func ExampleYAML() string {
 	return `# Hookly configuration
 edge_url: "https://hooks.example.com"
 # hub_id is optional - auto-generated from hostname if not set
 # hub_id: "myapp-dev"
+# metrics_addr is optional - serves OpenMetrics for Prometheus at /metrics
+# metrics_addr: "127.0.0.1:9464"
+# tunnel is optional - delivery over a WireGuard or SSH tunnel when the relay
+# stream is down (e.g. ssh -N -R 9465:127.0.0.1:9465 you@hooks.example.com)
+# tunnel:
+#   listen: "127.0.0.1:9465"
+#   edge_address: "10.8.0.2:9465"  # only if the edge reaches the hub elsewhere
+# sentry_dsn is optional - reports crashes and errors to your Sentry project
+# sentry_dsn: "https://<key>@o0.ingest.sentry.io/0"
+# keepalive is optional - shorten these if your NAT drops idle connections
+# keepalive:
+#   heartbeat_interval: 10s
+#   read_idle_timeout: 10s
+#   ping_timeout: 5s
 
 endpoints:
   - id: "ep_abc123"
     destination: "http://localhost:3000/webhooks/stripe"
   - id: "ep_def456"
     # Uses edge-configured destination (no override)
+    # Only relay these event types; others are marked skipped on the edge
+    event_types: ["push", "pull_request"]
+  - id: "ep_ghi789"
+    destination: "http://localhost:3000/webhooks"
+    # Payload routes are optional; the first match wins, others go to destination
+    routes:
+      - field: "type"
+        prefix: "invoice."
+        destination: "http://localhost:4000/billing/webhooks"
 `
 }
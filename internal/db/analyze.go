@@ -0,0 +1,121 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// planCheck is a hot query whose plan Analyze reviews, with representative
+// arguments and the index it should use. Arguments don't change SQLite's plan
+// but must bind.
+type planCheck struct {
+	name  string
+	query string
+	args  []any
+	index string // A trailing * accepts any index with the prefix
+}
+
+// hotQueries are the webhooks queries that degrade first as the table grows:
+// the list page and its filters, the dispatcher's pending scan and its check
+// of every webhook stored, the maintenance sweeps and the queue depth metric read on every scrape.
+var hotQueries = []planCheck{
+	// Any of the indexes leading with endpoint_id serves the user's endpoints
+	{"ListWebhooks", listWebhooks, []any{"user", nil, nil, nil, 0, 0, 50}, "idx_webhooks_endpoint_*"},
+	{"ListWebhooks by endpoint", listWebhooks, []any{"user", "endpoint", nil, nil, 0, 0, 50}, "idx_webhooks_endpoint_*"},
+	{"ListWebhooks by status", listWebhooks, []any{"user", nil, "failed", nil, 0, 0, 50}, "idx_webhooks_endpoint_*"},
+	{"CountWebhooks", countWebhooks, []any{"user", "endpoint", nil, nil, 0}, "idx_webhooks_endpoint_*"},
+	{"GetPendingWebhooks", getPendingWebhooks, []any{100}, "idx_webhooks_endpoint_status_received"},
+	{"GetDispatchableWebhook", getDispatchableWebhook, []any{"webhook"}, "idx_webhooks_endpoint_status_received"},
+	{"MarkDeadLetter", markDeadLetter, []any{7 * 24 * 3600}, "idx_webhooks_status_received"},
+	{"PurgeDeliveredWebhooks", purgeDeliveredWebhooks, []any{7 * 24 * 3600}, "idx_webhooks_status_delivered"},
+	{"PurgeFailedWebhooks", purgeFailedWebhooks, []any{7 * 24 * 3600}, "idx_webhooks_status_last_attempt"},
+	{"PurgeDeadLetterWebhooks", purgeDeadLetterWebhooks, []any{14 * 24 * 3600}, "idx_webhooks_status_received"},
+	{"PurgeSkippedWebhooks", purgeSkippedWebhooks, []any{7 * 24 * 3600}, "idx_webhooks_status_received"},
+	{"DeletePurgedWebhooks", deletePurgedWebhooks, []any{3 * 24 * 3600}, "idx_webhooks_purged"},
+	{"ListExpiredWebhooks", listExpiredWebhooks, []any{7 * 24 * 3600, 7 * 24 * 3600, 14 * 24 * 3600, 7 * 24 * 3600, 500}, "idx_webhooks_status_*"},
+	{"CountWebhooksByStatus", countWebhooksByStatus, nil, "idx_webhooks_status*"},
+}
+
+// QueryPlan is the plan of one hot query and what is wrong with it.
+type QueryPlan struct {
+	Query    string
+	Plan     []string // EXPLAIN QUERY PLAN details, indented by depth
+	Warnings []string
+}
+
+// Analyze runs EXPLAIN QUERY PLAN over the hot webhooks queries and warns
+// about full scans of the webhooks table and queries not using their index,
+// usually because a migration adding it hasn't been applied.
+func Analyze(ctx context.Context, conn *sql.DB) ([]QueryPlan, error) {
+	plans := make([]QueryPlan, 0, len(hotQueries))
+	for _, check := range hotQueries {
+		plan, err := explain(ctx, conn, check.query, check.args)
+		if err != nil {
+			return nil, fmt.Errorf("explain %s: %w", check.name, err)
+		}
+		plans = append(plans, QueryPlan{Query: check.name, Plan: plan, Warnings: planWarnings(plan, check.index)})
+	}
+	return plans, nil
+}
+
+func explain(ctx context.Context, conn *sql.DB, query string, args []any) ([]string, error) {
+	rows, err := conn.QueryContext(ctx, "EXPLAIN QUERY PLAN "+query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	depth := make(map[int64]int) // Node id to depth
+	var plan []string
+	for rows.Next() {
+		var id, parent, notUsed int64
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return nil, err
+		}
+		d := 0
+		if parent != 0 {
+			d = depth[parent] + 1
+		}
+		depth[id] = d
+		plan = append(plan, strings.Repeat("  ", d)+detail)
+	}
+	return plan, rows.Err()
+}
+
+// planWarnings flags plan steps that read every webhook and a missing use of
+// the expected index.
+func planWarnings(plan []string, index string) []string {
+	var warnings []string
+	want := "INDEX " + index + " "
+	if prefix, ok := strings.CutSuffix(index, "*"); ok {
+		want = "INDEX " + prefix
+	}
+	used := false
+	for _, step := range plan {
+		step = strings.TrimSpace(step)
+		if isWebhooksScan(step) && !strings.Contains(step, "INDEX") {
+			warnings = append(warnings, "full table scan: "+step)
+		}
+		if strings.Contains(step, want) {
+			used = true
+		}
+	}
+	if !used {
+		warnings = append(warnings, "missing index: does not use "+index)
+	}
+	return warnings
+}
+
+// isWebhooksScan reports whether a plan step scans the webhooks table, which
+// the queries alias as w or w2 or leave unaliased.
+func isWebhooksScan(step string) bool {
+	table, ok := strings.CutPrefix(step, "SCAN ")
+	if !ok {
+		return false
+	}
+	table, _, _ = strings.Cut(table, " ")
+	return table == "webhooks" || table == "w" || table == "w2"
+}
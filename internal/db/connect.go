@@ -10,9 +10,22 @@ import (
 
 // Open opens a SQLite database connection and runs migrations.
 func Open(ctx context.Context, path string) (*sql.DB, error) {
-	db, err := sql.Open("sqlite3", path+"?_foreign_keys=on&_journal_mode=WAL")
-	if err != nil {
-		return nil, fmt.Errorf("open database: %w", err)
+	return OpenInstrumented(ctx, path, nil)
+}
+
+// OpenInstrumented is Open with every query recorded in metrics. A nil
+// metrics records nothing.
+func OpenInstrumented(ctx context.Context, path string, metrics *QueryMetrics) (*sql.DB, error) {
+	dsn := path + "?_foreign_keys=on&_journal_mode=WAL"
+	var db *sql.DB
+	if metrics != nil {
+		db = sql.OpenDB(&instrumentedConnector{dsn: dsn, metrics: metrics})
+	} else {
+		var err error
+		db, err = sql.Open("sqlite3", dsn)
+		if err != nil {
+			return nil, fmt.Errorf("open database: %w", err)
+		}
 	}
 
 	// Set connection pool settings for SQLite
@@ -33,3 +46,38 @@ func Open(ctx context.Context, path string) (*sql.DB, error) {
 
 	return db, nil
 }
+
+// readConns is the size of a read-only pool. Readers don't block each other
+// or the writer in WAL mode.
+const readConns = 4
+
+// OpenReadOnly opens a pool of read-only connections to a database that Open
+// has already migrated, such as the edge's own file or a replica of it, so
+// heavy reads don't queue behind writes for the single writer connection.
+func OpenReadOnly(ctx context.Context, path string, metrics *QueryMetrics) (*sql.DB, error) {
+	dsn := "file:" + path + "?mode=ro&_foreign_keys=on&_query_only=true"
+	var db *sql.DB
+	if metrics != nil {
+		db = sql.OpenDB(&instrumentedConnector{dsn: dsn, metrics: metrics})
+	} else {
+		var err error
+		db, err = sql.Open("sqlite3", dsn)
+		if err != nil {
+			return nil, fmt.Errorf("open database: %w", err)
+		}
+	}
+	db.SetMaxOpenConns(readConns)
+	db.SetMaxIdleConns(readConns)
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("ping database: %w", err)
+	}
+	// A replica that hasn't been migrated would fail on every query instead
+	var version int64
+	if err := db.QueryRowContext(ctx, "SELECT MAX(version_id) FROM goose_db_version").Scan(&version); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("check schema: %w", err)
+	}
+	return db, nil
+}
@@ -2,10 +2,18 @@ package db_test
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/pressly/goose/v3"
+
+	"hooks.dx314.com/internal/clock"
 	"hooks.dx314.com/internal/crypto"
 	"hooks.dx314.com/internal/db"
 )
@@ -92,3 +100,1385 @@ func TestEncryption(t *testing.T) {
 		t.Errorf("expected %s, got %s", plaintext, string(decrypted))
 	}
 }
+
+func TestActivityEvents(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+
+	queries := db.New(conn)
+
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-activity",
+		UserID:         "user-1",
+		Name:           "Activity Endpoint",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+
+	// Deliveries within the same hour roll up into one row
+	for i := 0; i < 3; i++ {
+		if err := queries.RecordDeliveryActivity(ctx, db.RecordDeliveryActivityParams{
+			ID:         "del_ep-activity_bucket",
+			UserID:     "user-1",
+			EndpointID: sql.NullString{String: "ep-activity", Valid: true},
+		}); err != nil {
+			t.Fatalf("record delivery activity: %v", err)
+		}
+	}
+
+	if err := queries.RecordHubActivity(ctx, db.RecordHubActivityParams{
+		ID:     "hub-event-1",
+		UserID: "user-1",
+		Kind:   "hub_connected",
+		HubID:  sql.NullString{String: "laptop", Valid: true},
+	}); err != nil {
+		t.Fatalf("record hub activity: %v", err)
+	}
+
+	events, err := queries.ListActivityEvents(ctx, db.ListActivityEventsParams{
+		UserID: "user-1",
+		Since:  "1970-01-01 00:00:00",
+		Limit:  10,
+	})
+	if err != nil {
+		t.Fatalf("list activity events: %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	var deliveries *db.ListActivityEventsRow
+	for i := range events {
+		if events[i].Kind == "deliveries" {
+			deliveries = &events[i]
+		}
+	}
+	if deliveries == nil {
+		t.Fatal("missing deliveries event")
+	}
+	if deliveries.Count != 3 {
+		t.Errorf("expected rollup count 3, got %d", deliveries.Count)
+	}
+	if deliveries.EndpointName != "Activity Endpoint" {
+		t.Errorf("expected endpoint name, got %q", deliveries.EndpointName)
+	}
+
+	// Other users see nothing
+	other, err := queries.ListActivityEvents(ctx, db.ListActivityEventsParams{
+		UserID: "user-2",
+		Since:  "1970-01-01 00:00:00",
+		Limit:  10,
+	})
+	if err != nil {
+		t.Fatalf("list activity events: %v", err)
+	}
+	if len(other) != 0 {
+		t.Errorf("expected no events for other user, got %d", len(other))
+	}
+}
+
+func TestConnectionEvents(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+
+	queries := db.New(conn)
+
+	for _, id := range []string{"ep-1", "ep-2"} {
+		if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+			ID:             id,
+			UserID:         "user-1",
+			Name:           "Endpoint " + id,
+			ProviderType:   "generic",
+			DestinationUrl: "http://localhost:8080/hook",
+		}); err != nil {
+			t.Fatalf("create endpoint: %v", err)
+		}
+	}
+
+	for i, ev := range []db.RecordConnectionEventParams{
+		{EndpointID: "ep-1", HubID: "laptop", Event: "connected", Transport: "stream"},
+		{EndpointID: "ep-2", HubID: "laptop", Event: "connected", Transport: "stream"},
+		{EndpointID: "ep-1", HubID: "laptop", Event: "disconnected", Transport: "stream"},
+	} {
+		ev.ID = fmt.Sprintf("conn-%d", i)
+		if err := queries.RecordConnectionEvent(ctx, ev); err != nil {
+			t.Fatalf("record connection event: %v", err)
+		}
+	}
+
+	// Newest first, even within the same second
+	events, err := queries.ListConnectionEvents(ctx, db.ListConnectionEventsParams{
+		UserID:     "user-1",
+		EndpointID: sql.NullString{String: "ep-1", Valid: true},
+		Limit:      10,
+	})
+	if err != nil {
+		t.Fatalf("list connection events: %v", err)
+	}
+	if len(events) != 2 || events[0].Event != "disconnected" || events[1].Event != "connected" {
+		t.Fatalf("got %+v, want ep-1 disconnected then connected", events)
+	}
+	if events[0].EndpointName != "Endpoint ep-1" {
+		t.Errorf("endpoint name = %q", events[0].EndpointName)
+	}
+
+	all, err := queries.ListConnectionEvents(ctx, db.ListConnectionEventsParams{UserID: "user-1", Limit: 10})
+	if err != nil {
+		t.Fatalf("list connection events: %v", err)
+	}
+	if len(all) != 3 {
+		t.Errorf("expected 3 events for all endpoints, got %d", len(all))
+	}
+
+	// Other users see nothing
+	other, err := queries.ListConnectionEvents(ctx, db.ListConnectionEventsParams{UserID: "user-2", Limit: 10})
+	if err != nil {
+		t.Fatalf("list connection events: %v", err)
+	}
+	if len(other) != 0 {
+		t.Errorf("expected no events for other user, got %d", len(other))
+	}
+
+	deleted, err := queries.DeleteOldConnectionEvents(ctx, 3600)
+	if err != nil {
+		t.Fatalf("delete old connection events: %v", err)
+	}
+	if deleted != 0 {
+		t.Errorf("deleted %d recent events, want 0", deleted)
+	}
+}
+
+func TestMarkFirstEvent(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+
+	queries := db.New(conn)
+
+	endpoint, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:               "ep-first",
+		UserID:           "user-1",
+		Name:             "First Event Endpoint",
+		ProviderType:     "generic",
+		DestinationUrl:   "http://localhost:8080/hook",
+		NotifyFirstEvent: 1,
+	})
+	if err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+	if endpoint.FirstEventAt.Valid {
+		t.Error("new endpoint should be waiting for its first event")
+	}
+
+	notify, err := queries.MarkFirstEvent(ctx, "ep-first")
+	if err != nil {
+		t.Fatalf("mark first event: %v", err)
+	}
+	if notify != 1 {
+		t.Errorf("notify_first_event: got %d, want 1", notify)
+	}
+
+	// Only the first webhook is reported
+	if _, err := queries.MarkFirstEvent(ctx, "ep-first"); err != sql.ErrNoRows {
+		t.Errorf("second mark: got %v, want sql.ErrNoRows", err)
+	}
+
+	updated, err := queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		ID:               "ep-first",
+		UserID:           "user-1",
+		NotifyFirstEvent: sql.NullInt64{Int64: 0, Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("update endpoint: %v", err)
+	}
+	if updated.NotifyFirstEvent != 0 || !updated.FirstEventAt.Valid {
+		t.Errorf("unexpected endpoint after update: %+v", updated)
+	}
+}
+
+func TestEventTypeFilterAndCounts(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+
+	queries := db.New(conn)
+
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-events",
+		UserID:         "user-1",
+		Name:           "Event Types",
+		ProviderType:   "github",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+
+	for i, eventType := range []string{"push", "push", "issues", ""} {
+		if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{
+			ID:             fmt.Sprintf("wh-%d", i),
+			EndpointID:     "ep-events",
+			Headers:        "{}",
+			Payload:        []byte("{}"),
+			SignatureValid: 1,
+			EventType:      sql.NullString{String: eventType, Valid: eventType != ""},
+		}); err != nil {
+			t.Fatalf("create webhook: %v", err)
+		}
+	}
+
+	pushes, err := queries.ListWebhooks(ctx, db.ListWebhooksParams{
+		UserID:    "user-1",
+		EventType: "push",
+		Limit:     10,
+	})
+	if err != nil {
+		t.Fatalf("list webhooks: %v", err)
+	}
+	if len(pushes) != 2 {
+		t.Errorf("push webhooks: got %d, want 2", len(pushes))
+	}
+
+	count, err := queries.CountWebhooks(ctx, db.CountWebhooksParams{
+		UserID:    "user-1",
+		EventType: "issues",
+	})
+	if err != nil {
+		t.Fatalf("count webhooks: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("issues count: got %d, want 1", count)
+	}
+
+	counts, err := queries.GetEventTypeCounts(ctx, db.GetEventTypeCountsParams{
+		UserID:     "user-1",
+		EndpointID: "ep-events",
+	})
+	if err != nil {
+		t.Fatalf("get event type counts: %v", err)
+	}
+	if len(counts) != 3 || counts[0].EventType != "push" || counts[0].Count != 2 {
+		t.Errorf("unexpected counts: %+v", counts)
+	}
+}
+
+func TestListEndpointsFilters(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+
+	queries := db.New(conn)
+
+	for i, ep := range []struct{ id, name, provider string }{
+		{"ep-1", "Stripe Payments", "stripe"},
+		{"ep-2", "GitHub CI", "github"},
+		{"ep-3", "stripe_100%", "stripe"},
+	} {
+		if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+			ID:             ep.id,
+			UserID:         "user-1",
+			Name:           ep.name,
+			ProviderType:   ep.provider,
+			DestinationUrl: "http://localhost:8080/hook",
+		}); err != nil {
+			t.Fatalf("create endpoint: %v", err)
+		}
+		if _, err := conn.ExecContext(ctx, "UPDATE endpoints SET created_at = datetime('now', ?) WHERE id = ?", fmt.Sprintf("-%d days", 6-2*i), ep.id); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{ID: "ep-2", UserID: "user-1", Muted: sql.NullInt64{Int64: 1, Valid: true}}); err != nil {
+		t.Fatalf("mute endpoint: %v", err)
+	}
+	if err := queries.MarkEndpointReceived(ctx, "ep-1"); err != nil {
+		t.Fatalf("mark received: %v", err)
+	}
+	if _, err := conn.ExecContext(ctx, "UPDATE endpoints SET last_webhook_received_at = datetime('now', '-10 days') WHERE id = 'ep-2'"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		params db.ListEndpointsParams
+		want   []string
+	}{
+		{"newest first", db.ListEndpointsParams{}, []string{"ep-3", "ep-2", "ep-1"}},
+		{"oldest first", db.ListEndpointsParams{Sort: "created_asc"}, []string{"ep-1", "ep-2", "ep-3"}},
+		{"last received", db.ListEndpointsParams{Sort: "last_received"}, []string{"ep-1", "ep-2", "ep-3"}},
+		{"search is case-insensitive", db.ListEndpointsParams{Search: db.EscapeLike("stripe")}, []string{"ep-3", "ep-1"}},
+		{"search wildcards are literal", db.ListEndpointsParams{Search: db.EscapeLike("_100%")}, []string{"ep-3"}},
+		{"search without match", db.ListEndpointsParams{Search: db.EscapeLike("gitlab")}, nil},
+		{"provider", db.ListEndpointsParams{ProviderType: "github"}, []string{"ep-2"}},
+		{"unmuted", db.ListEndpointsParams{Muted: int64(0)}, []string{"ep-3", "ep-1"}},
+		{"muted", db.ListEndpointsParams{Muted: int64(1)}, []string{"ep-2"}},
+		{"inactive", db.ListEndpointsParams{InactiveDays: int64(3)}, []string{"ep-2"}},
+		{"inactive counts from creation", db.ListEndpointsParams{InactiveDays: int64(1)}, []string{"ep-3", "ep-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params := tt.params
+			params.UserID = "user-1"
+			params.Limit = 10
+			endpoints, err := queries.ListEndpoints(ctx, params)
+			if err != nil {
+				t.Fatalf("list endpoints: %v", err)
+			}
+			var got []string
+			for _, ep := range endpoints {
+				got = append(got, ep.ID)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+
+			count, err := queries.CountEndpoints(ctx, db.CountEndpointsParams{
+				UserID:       "user-1",
+				Search:       params.Search,
+				ProviderType: params.ProviderType,
+				Muted:        params.Muted,
+				InactiveDays: params.InactiveDays,
+			})
+			if err != nil {
+				t.Fatalf("count endpoints: %v", err)
+			}
+			if count != int64(len(tt.want)) {
+				t.Errorf("count = %d, want %d", count, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestEndpointSLO(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+
+	queries := db.New(conn)
+
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-slo",
+		UserID:         "user-1",
+		Name:           "SLO Endpoint",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+
+	endpoints, err := queries.ListSLOEndpoints(ctx)
+	if err != nil {
+		t.Fatalf("list slo endpoints: %v", err)
+	}
+	if len(endpoints) != 0 {
+		t.Errorf("endpoints without an SLO should not be listed: %+v", endpoints)
+	}
+
+	updated, err := queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		ID:        "ep-slo",
+		UserID:    "user-1",
+		SloTarget: sql.NullFloat64{Float64: 99, Valid: true},
+	})
+	if err != nil {
+		t.Fatalf("update endpoint: %v", err)
+	}
+	if updated.SloTarget != 99 || updated.SloLatencySeconds != 60 || updated.SloWindowHours != 24 {
+		t.Errorf("unexpected slo after update: %+v", updated)
+	}
+
+	// received_at offset, delivered_at offset (empty if not delivered), status
+	webhooks := []struct {
+		received, delivered, status string
+	}{
+		{"-10 minutes", "-599 seconds", "delivered"}, // met
+		{"-10 minutes", "-5 minutes", "delivered"},   // too slow
+		{"-10 minutes", "", "pending"},               // past the latency target
+		{"-10 seconds", "", "pending"},               // can still meet it
+		{"-10 minutes", "", "skipped"},               // never relayed
+		{"-30 hours", "-30 hours", "delivered"},      // outside the window
+	}
+	for i, wh := range webhooks {
+		id := fmt.Sprintf("wh-slo-%d", i)
+		if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{
+			ID:             id,
+			EndpointID:     "ep-slo",
+			Headers:        "{}",
+			Payload:        []byte("{}"),
+			SignatureValid: 1,
+		}); err != nil {
+			t.Fatalf("create webhook: %v", err)
+		}
+		delivered := sql.NullString{String: wh.delivered, Valid: wh.delivered != ""}
+		if _, err := conn.ExecContext(ctx, `UPDATE webhooks
+			SET status = ?, received_at = datetime('now', ?),
+				delivered_at = CASE WHEN ? IS NULL THEN NULL ELSE datetime('now', ?) END
+			WHERE id = ?`, wh.status, wh.received, delivered, delivered, id); err != nil {
+			t.Fatalf("update webhook: %v", err)
+		}
+	}
+
+	stats, err := queries.GetEndpointSLOStats(ctx, db.GetEndpointSLOStatsParams{
+		LatencySeconds: 60,
+		EndpointID:     "ep-slo",
+		WindowHours:    24,
+	})
+	if err != nil {
+		t.Fatalf("get slo stats: %v", err)
+	}
+	if stats.Total != 3 || stats.Met != 1 {
+		t.Errorf("slo stats: got %+v, want total 3, met 1", stats)
+	}
+
+	// Breaches are recorded once until cleared
+	for i, want := range []int64{1, 0} {
+		n, err := queries.SetEndpointSLOBreached(ctx, "ep-slo")
+		if err != nil {
+			t.Fatalf("set slo breached: %v", err)
+		}
+		if n != want {
+			t.Errorf("set slo breached #%d: got %d rows, want %d", i+1, n, want)
+		}
+	}
+	n, err := queries.ClearEndpointSLOBreached(ctx, "ep-slo")
+	if err != nil {
+		t.Fatalf("clear slo breached: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("clear slo breached: got %d rows, want 1", n)
+	}
+}
+
+func TestSecretReveals(t *testing.T) {
+	ctx := context.Background()
+
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+
+	queries := db.New(conn)
+
+	for i := range 3 {
+		if err := queries.RecordSecretReveal(ctx, db.RecordSecretRevealParams{
+			ID:         fmt.Sprintf("rev-%d", i),
+			UserID:     "user-1",
+			EndpointID: "ep-1",
+			IpAddress:  "192.0.2.1",
+			UserAgent:  "test",
+		}); err != nil {
+			t.Fatalf("record secret reveal: %v", err)
+		}
+	}
+
+	// Reveals older than an hour no longer count towards the limit
+	if _, err := conn.ExecContext(ctx, `UPDATE secret_reveals SET revealed_at = datetime('now', '-2 hours') WHERE id = 'rev-0'`); err != nil {
+		t.Fatalf("age reveal: %v", err)
+	}
+
+	count, err := queries.CountRecentSecretReveals(ctx, "user-1")
+	if err != nil {
+		t.Fatalf("count secret reveals: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("recent reveals: got %d, want 2", count)
+	}
+}
+
+func TestQueryMetrics(t *testing.T) {
+	ctx := context.Background()
+	metrics := db.NewQueryMetrics(0)
+	conn, err := db.OpenInstrumented(ctx, filepath.Join(t.TempDir(), "test.db"), metrics)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+
+	queries := db.New(conn)
+	for _, id := range []string{"ep_1", "ep_2"} {
+		if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+			ID:             id,
+			UserID:         "user_1",
+			Name:           id,
+			ProviderType:   "generic",
+			DestinationUrl: "http://localhost:3000",
+		}); err != nil {
+			t.Fatalf("create endpoint: %v", err)
+		}
+	}
+	if _, err := queries.ListEndpoints(ctx, db.ListEndpointsParams{UserID: "user_1", Limit: 10}); err != nil {
+		t.Fatalf("list endpoints: %v", err)
+	}
+
+	stats := make(map[string]db.QueryStat)
+	for _, st := range metrics.Stats() {
+		stats[st.Name] = st
+	}
+	if st := stats["CreateEndpoint"]; st.Calls != 2 || st.Rows != 2 {
+		t.Errorf("CreateEndpoint stats = %+v, want 2 calls returning 2 rows", st)
+	}
+	if st := stats["ListEndpoints"]; st.Calls != 1 || st.Rows != 2 || st.Errors != 0 {
+		t.Errorf("ListEndpoints stats = %+v, want 1 call returning 2 rows", st)
+	}
+
+	var out strings.Builder
+	metrics.WriteTo(&out)
+	if !strings.Contains(out.String(), `hookly_db_queries_total{query="ListEndpoints"} 1`) {
+		t.Errorf("metrics missing ListEndpoints:\n%s", out.String())
+	}
+}
+
+func TestAnalyze(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+
+	plans, err := db.Analyze(ctx, conn)
+	if err != nil {
+		t.Fatalf("analyze: %v", err)
+	}
+	for _, p := range plans {
+		if len(p.Warnings) > 0 || len(p.Plan) == 0 {
+			t.Errorf("%s: plan %q, warnings %q", p.Query, p.Plan, p.Warnings)
+		}
+	}
+
+	// Without its index the dispatcher's per-endpoint lookup is flagged
+	if _, err := conn.ExecContext(ctx, "DROP INDEX idx_webhooks_endpoint_status_received"); err != nil {
+		t.Fatal(err)
+	}
+	plans, err = db.Analyze(ctx, conn)
+	if err != nil {
+		t.Fatalf("analyze: %v", err)
+	}
+	for _, p := range plans {
+		if p.Query == "GetPendingWebhooks" && len(p.Warnings) == 0 {
+			t.Errorf("missing index not reported: %q", p.Plan)
+		}
+	}
+}
+
+func TestProviderMigrations(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+	queries := db.New(conn)
+
+	for _, provider := range []string{"slack", "shopify", "square", "paypal"} {
+		if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+			ID:             "ep-" + provider,
+			UserID:         "user-1",
+			Name:           provider,
+			ProviderType:   provider,
+			DestinationUrl: "http://localhost:8080/" + provider,
+		}); err != nil {
+			t.Fatalf("create %s endpoint: %v", provider, err)
+		}
+	}
+	if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{
+		ID:         "wh-1",
+		EndpointID: "ep-slack",
+		Headers:    "{}",
+		Payload:    []byte(`{}`),
+	}); err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+
+	// Recreating the endpoints table must not cascade to their webhooks
+	if err := goose.DownToContext(ctx, conn, "migrations", 25); err != nil {
+		t.Fatalf("migrate down: %v", err)
+	}
+	if err := db.Migrate(ctx, conn); err != nil {
+		t.Fatalf("migrate up: %v", err)
+	}
+	if _, err := queries.GetWebhookWithEndpointByID(ctx, "wh-1"); err != nil {
+		t.Errorf("webhook lost in the migration: %v", err)
+	}
+	for _, id := range []string{"ep-slack", "ep-shopify", "ep-square", "ep-paypal"} {
+		ep, err := queries.GetEndpointByID(ctx, id)
+		if err != nil || ep.ProviderType != "generic" {
+			t.Errorf("%s after down and up: %v, %v", id, ep.ProviderType, err)
+		}
+	}
+
+	var fk int
+	if err := conn.QueryRowContext(ctx, "PRAGMA foreign_keys").Scan(&fk); err != nil || fk != 1 {
+		t.Errorf("foreign keys left off: %d, %v", fk, err)
+	}
+}
+
+func TestMarkWebhookAcknowledgedDuplicate(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+	queries := db.New(conn)
+
+	for _, id := range []string{"ep-opted-in", "ep-default"} {
+		if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+			ID:             id,
+			UserID:         "user-1",
+			Name:           id,
+			ProviderType:   "generic",
+			DestinationUrl: "http://localhost:8080/hook",
+		}); err != nil {
+			t.Fatalf("create endpoint: %v", err)
+		}
+		if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{
+			ID:         "wh-" + id,
+			EndpointID: id,
+			Headers:    "{}",
+			Payload:    []byte(`{}`),
+		}); err != nil {
+			t.Fatalf("create webhook: %v", err)
+		}
+	}
+	if _, err := queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		ID:                  "ep-opted-in",
+		UserID:              "user-1",
+		ConflictAsDuplicate: sql.NullInt64{Int64: 1, Valid: true},
+	}); err != nil {
+		t.Fatalf("update endpoint: %v", err)
+	}
+
+	ack := func(id string) (db.Webhook, error) {
+		return queries.MarkWebhookAcknowledgedDuplicate(ctx, db.MarkWebhookAcknowledgedDuplicateParams{
+			ErrorMessage: sql.NullString{String: "HTTP 409", Valid: true},
+			ID:           id,
+		})
+	}
+
+	wh, err := ack("wh-ep-opted-in")
+	if err != nil {
+		t.Fatalf("acknowledge duplicate: %v", err)
+	}
+	if wh.Status != "acknowledged_duplicate" || !wh.DeliveredAt.Valid || wh.Attempts != 1 {
+		t.Errorf("webhook = %s, delivered %v, %d attempts", wh.Status, wh.DeliveredAt, wh.Attempts)
+	}
+	// Only pending webhooks, and only on endpoints that opted in
+	if _, err := ack("wh-ep-opted-in"); err != sql.ErrNoRows {
+		t.Errorf("second ack: err = %v, want no rows", err)
+	}
+	if _, err := ack("wh-ep-default"); err != sql.ErrNoRows {
+		t.Errorf("endpoint without opt-in: err = %v, want no rows", err)
+	}
+
+	history, err := queries.ListWebhookStatusHistory(ctx, "wh-ep-opted-in")
+	if err != nil {
+		t.Fatalf("list history: %v", err)
+	}
+	if last := history[len(history)-1]; last.ToStatus != "acknowledged_duplicate" || last.Reason.String != "HTTP 409" {
+		t.Errorf("history ends with %s (%q)", last.ToStatus, last.Reason.String)
+	}
+
+	// Acknowledged duplicates are cleaned up with delivered webhooks
+	if _, err := conn.ExecContext(ctx, "UPDATE webhooks SET delivered_at = datetime('now', '-2 days') WHERE id = 'wh-ep-opted-in'"); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := queries.PurgeDeliveredWebhooks(ctx, 86400); err != nil || n != 1 {
+		t.Errorf("cleanup purged %d, %v", n, err)
+	}
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+	queries := db.New(conn)
+
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-fan",
+		UserID:         "user-1",
+		Name:           "fan",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+	if _, err := queries.CreateEndpointDestination(ctx, db.CreateEndpointDestinationParams{ID: "dst-audit", EndpointID: "ep-fan", Url: "http://audit/hook"}); err != nil {
+		t.Fatalf("create destination: %v", err)
+	}
+	if _, err := queries.CreateEndpointDestination(ctx, db.CreateEndpointDestinationParams{ID: "dst-dup", EndpointID: "ep-fan", Url: "http://audit/hook"}); err == nil {
+		t.Error("creating a destination twice: want error")
+	}
+	if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{
+		ID:         "wh-fan",
+		EndpointID: "ep-fan",
+		Headers:    "{}",
+		Payload:    []byte(`{}`),
+	}); err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+
+	record := func(destinationID, status string, code int64) {
+		t.Helper()
+		if err := queries.RecordWebhookDelivery(ctx, db.RecordWebhookDeliveryParams{
+			WebhookID:     "wh-fan",
+			DestinationID: destinationID,
+			Status:        status,
+			StatusCode:    sql.NullInt64{Int64: code, Valid: code != 0},
+		}); err != nil {
+			t.Fatalf("record delivery: %v", err)
+		}
+	}
+	record("", "delivered", 200)
+	record("dst-audit", "pending", 503)
+	record("dst-audit", "delivered", 200)
+
+	deliveries, err := queries.ListWebhookDeliveries(ctx, "wh-fan")
+	if err != nil {
+		t.Fatalf("list deliveries: %v", err)
+	}
+	if len(deliveries) != 2 || deliveries[0].DestinationID != "" || deliveries[1].DestinationID != "dst-audit" {
+		t.Fatalf("deliveries = %+v, want the endpoint's then dst-audit", deliveries)
+	}
+	if d := deliveries[1]; d.Status != "delivered" || d.Attempts != 2 || d.StatusCode.Int64 != 200 || !d.DeliveredAt.Valid {
+		t.Errorf("dst-audit = %s after %d attempts, HTTP %d, delivered %v", d.Status, d.Attempts, d.StatusCode.Int64, d.DeliveredAt)
+	}
+
+	// A replay starts over at every destination
+	if _, err := queries.MarkWebhookDelivered(ctx, "wh-fan"); err != nil {
+		t.Fatalf("mark delivered: %v", err)
+	}
+	if _, err := queries.ResetWebhookForReplay(ctx, db.ResetWebhookForReplayParams{ID: "wh-fan", UserID: "user-1"}); err != nil {
+		t.Fatalf("replay: %v", err)
+	}
+	if deliveries, _ := queries.ListWebhookDeliveries(ctx, "wh-fan"); len(deliveries) != 0 {
+		t.Errorf("deliveries after replay = %+v, want none", deliveries)
+	}
+
+	if err := queries.DeleteEndpointDestination(ctx, db.DeleteEndpointDestinationParams{ID: "dst-audit", EndpointID: "ep-fan"}); err != nil {
+		t.Fatalf("delete destination: %v", err)
+	}
+	if destinations, _ := queries.ListEndpointDestinations(ctx, "ep-fan"); len(destinations) != 0 {
+		t.Errorf("destinations after delete = %+v", destinations)
+	}
+}
+
+func TestWebhookRetrySchedule(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+	queries := db.New(conn)
+
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-1",
+		UserID:         "user-1",
+		Name:           "retries",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+	if _, err := queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		ID:               "ep-1",
+		UserID:           "user-1",
+		RetryMaxAttempts: sql.NullInt64{Int64: 3, Valid: true},
+		RetryJitter:      sql.NullFloat64{Float64: 0.5, Valid: true},
+	}); err != nil {
+		t.Fatalf("update endpoint: %v", err)
+	}
+	if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{
+		ID:         "wh-1",
+		EndpointID: "ep-1",
+		Headers:    "{}",
+		Payload:    []byte(`{}`),
+	}); err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+
+	pending := func() int {
+		rows, err := queries.GetPendingWebhooks(ctx, 10)
+		if err != nil {
+			t.Fatalf("get pending: %v", err)
+		}
+		return len(rows)
+	}
+	if n := pending(); n != 1 {
+		t.Fatalf("new webhook: %d pending, want 1", n)
+	}
+
+	// Not handed out again until the retry is due
+	wh, err := queries.RecordWebhookAttempt(ctx, db.RecordWebhookAttemptParams{
+		RetryDelaySeconds: 60,
+		ErrorMessage:      sql.NullString{String: "HTTP 503", Valid: true},
+		ID:                "wh-1",
+	})
+	if err != nil {
+		t.Fatalf("record attempt: %v", err)
+	}
+	if !wh.NextAttemptAt.Valid || wh.Attempts != 1 {
+		t.Errorf("after attempt: next %v, %d attempts", wh.NextAttemptAt, wh.Attempts)
+	}
+	if n := pending(); n != 0 {
+		t.Errorf("retry scheduled in a minute: %d pending, want 0", n)
+	}
+	if _, err := queries.RecordWebhookAttempt(ctx, db.RecordWebhookAttemptParams{ID: "wh-1"}); err != nil {
+		t.Fatalf("record attempt: %v", err)
+	}
+	if n := pending(); n != 1 {
+		t.Errorf("retry due now: %d pending, want 1", n)
+	}
+
+	policy, err := queries.GetWebhookRetryPolicy(ctx, "wh-1")
+	if err != nil {
+		t.Fatalf("get retry policy: %v", err)
+	}
+	if policy.Attempts != 2 || policy.RetryMaxAttempts != 3 || policy.RetryJitter != 0.5 || policy.RetryBackoffBaseSeconds != 0 {
+		t.Errorf("retry policy = %+v", policy)
+	}
+}
+
+func TestDispatchLease(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+	queries := db.New(conn)
+
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-1",
+		UserID:         "user-1",
+		Name:           "fast path",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+	for _, id := range []string{"wh-1", "wh-2"} {
+		if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{
+			ID:         id,
+			EndpointID: "ep-1",
+			Headers:    "{}",
+			Payload:    []byte(`{}`),
+		}); err != nil {
+			t.Fatalf("create webhook: %v", err)
+		}
+	}
+	if _, err := conn.Exec(`UPDATE webhooks SET received_at = datetime('now', '-1 minute') WHERE id = 'wh-1'`); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the oldest pending webhook of an endpoint is dispatched
+	if _, err := queries.GetDispatchableWebhook(ctx, "wh-2"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("webhook behind another: %v, want no rows", err)
+	}
+	wh, err := queries.GetDispatchableWebhook(ctx, "wh-1")
+	if err != nil || wh.DestinationUrl != "http://localhost:8080/hook" {
+		t.Fatalf("oldest webhook: %+v, %v", wh, err)
+	}
+
+	// A dispatched webhook waits out its lease, holding back the endpoint
+	if err := queries.MarkWebhookDispatched(ctx, db.MarkWebhookDispatchedParams{LeaseSeconds: 30, ID: "wh-1"}); err != nil {
+		t.Fatalf("mark dispatched: %v", err)
+	}
+	if _, err := queries.GetDispatchableWebhook(ctx, "wh-1"); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("dispatched webhook: %v, want no rows", err)
+	}
+	if rows, err := queries.GetPendingWebhooks(ctx, 10); err != nil || len(rows) != 0 {
+		t.Errorf("pending during the lease: %d, %v", len(rows), err)
+	}
+	if err := queries.MarkWebhookDispatched(ctx, db.MarkWebhookDispatchedParams{ID: "wh-1"}); err != nil {
+		t.Fatalf("release: %v", err)
+	}
+	if rows, err := queries.GetPendingWebhooks(ctx, 10); err != nil || len(rows) != 1 || rows[0].ID != "wh-1" {
+		t.Errorf("pending after release: %+v, %v", rows, err)
+	}
+}
+
+func TestEndpointCache(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+	queries := db.New(conn)
+	cache := db.NewEndpointCache(queries, time.Hour)
+
+	if _, err := cache.Get(ctx, "ep-1"); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("missing endpoint: %v, want no rows", err)
+	}
+	// Not found isn't cached, so a new endpoint is found at once
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-1",
+		UserID:         "user-1",
+		Name:           "cached",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+	if ep, err := cache.Get(ctx, "ep-1"); err != nil || ep.Name != "cached" {
+		t.Fatalf("new endpoint: %+v, %v", ep, err)
+	}
+
+	// Changes are served from the cache until invalidated
+	if _, err := conn.Exec(`UPDATE endpoints SET muted = 1 WHERE id = 'ep-1'`); err != nil {
+		t.Fatal(err)
+	}
+	if ep, _ := cache.Get(ctx, "ep-1"); ep.Muted != 0 {
+		t.Error("endpoint read again before invalidation")
+	}
+	cache.Invalidate("ep-1")
+	if ep, _ := cache.Get(ctx, "ep-1"); ep.Muted != 1 {
+		t.Error("invalidated endpoint served from the cache")
+	}
+
+	// Entries expire after the TTL
+	fake := clock.NewFake(time.Now())
+	cache = db.NewEndpointCache(queries, time.Minute)
+	cache.SetClock(fake)
+	cache.Get(ctx, "ep-1")
+	if _, err := conn.Exec(`UPDATE endpoints SET muted = 0 WHERE id = 'ep-1'`); err != nil {
+		t.Fatal(err)
+	}
+	fake.Advance(59 * time.Second)
+	if ep, _ := cache.Get(ctx, "ep-1"); ep.Muted != 1 {
+		t.Error("endpoint read again within the TTL")
+	}
+	fake.Advance(time.Second)
+	if ep, _ := cache.Get(ctx, "ep-1"); ep.Muted != 0 {
+		t.Error("expired endpoint served from the cache")
+	}
+
+	var nilCache *db.EndpointCache
+	nilCache.Invalidate("ep-1")
+}
+
+func TestWithTx(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+	queries := db.New(conn)
+	create := func(q *db.Queries, id string) error {
+		_, err := q.CreateEndpoint(ctx, db.CreateEndpointParams{
+			ID:             id,
+			UserID:         "user-1",
+			Name:           id,
+			ProviderType:   "generic",
+			DestinationUrl: "http://localhost:8080/hook",
+		})
+		return err
+	}
+	exists := func(id string) bool {
+		_, err := queries.GetEndpointByID(ctx, id)
+		return err == nil
+	}
+
+	// A failure rolls back what was written before it
+	failed := errors.New("failed")
+	err = db.WithTx(ctx, queries, func(q *db.Queries) error {
+		if err := create(q, "ep-rolled-back"); err != nil {
+			return err
+		}
+		return failed
+	})
+	if !errors.Is(err, failed) || exists("ep-rolled-back") {
+		t.Errorf("failed transaction: err = %v, endpoint kept %v", err, exists("ep-rolled-back"))
+	}
+
+	// Nested calls run in the outer transaction
+	err = db.WithTx(ctx, queries, func(q *db.Queries) error {
+		if err := create(q, "ep-1"); err != nil {
+			return err
+		}
+		return db.WithTx(ctx, q, func(q *db.Queries) error { return create(q, "ep-2") })
+	})
+	if err != nil || !exists("ep-1") || !exists("ep-2") {
+		t.Errorf("committed transaction: err = %v, endpoints %v %v", err, exists("ep-1"), exists("ep-2"))
+	}
+}
+
+func TestOpenReadOnly(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "test.db")
+	if _, err := db.OpenReadOnly(ctx, path, nil); err == nil {
+		t.Error("opened a database that doesn't exist")
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("read-only open created the database: %v", err)
+	}
+
+	conn, err := db.Open(ctx, path)
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+	readConn, err := db.OpenReadOnly(ctx, path, nil)
+	if err != nil {
+		t.Fatalf("open read-only: %v", err)
+	}
+	defer readConn.Close()
+	reads := db.New(readConn)
+
+	// Writes are visible to the reader, which can't write itself
+	if _, err := db.New(conn).CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-1",
+		UserID:         "user-1",
+		Name:           "replica",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+	if n, err := reads.CountAllEndpoints(ctx); err != nil || n != 1 {
+		t.Errorf("endpoints read = %d, %v", n, err)
+	}
+	if err := reads.DeleteEndpoint(ctx, db.DeleteEndpointParams{ID: "ep-1", UserID: "user-1"}); err == nil {
+		t.Error("read-only connection deleted an endpoint")
+	}
+}
+
+func TestPurgeAndUndelete(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+	queries := db.New(conn)
+
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-1",
+		UserID:         "user-1",
+		Name:           "retention",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+	for _, id := range []string{"wh-old", "wh-new"} {
+		if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{ID: id, EndpointID: "ep-1", Headers: "{}", Payload: []byte(`{}`)}); err != nil {
+			t.Fatalf("create webhook: %v", err)
+		}
+		if _, err := queries.MarkWebhookDelivered(ctx, id); err != nil {
+			t.Fatalf("mark delivered: %v", err)
+		}
+	}
+	if _, err := conn.ExecContext(ctx, "UPDATE webhooks SET delivered_at = datetime('now', '-2 days') WHERE id = 'wh-old'"); err != nil {
+		t.Fatal(err)
+	}
+
+	if n, err := queries.PurgeDeliveredWebhooks(ctx, 86400); err != nil || n != 1 {
+		t.Fatalf("purged %d, %v; want 1", n, err)
+	}
+	if n, err := queries.PurgeDeliveredWebhooks(ctx, 86400); err != nil || n != 0 {
+		t.Errorf("purged %d again, %v", n, err)
+	}
+	if _, err := queries.GetWebhook(ctx, db.GetWebhookParams{ID: "wh-old", UserID: "user-1"}); err != sql.ErrNoRows {
+		t.Errorf("get purged webhook: err = %v, want no rows", err)
+	}
+	list := func(purged int64) int64 {
+		n, err := queries.CountWebhooks(ctx, db.CountWebhooksParams{UserID: "user-1", Purged: purged})
+		if err != nil {
+			t.Fatalf("count webhooks: %v", err)
+		}
+		return n
+	}
+	if live, purged := list(0), list(1); live != 1 || purged != 1 {
+		t.Errorf("listed %d live and %d purged, want 1 and 1", live, purged)
+	}
+
+	// Only the owner can undelete, and only purged webhooks
+	if _, err := queries.UndeleteWebhook(ctx, db.UndeleteWebhookParams{ID: "wh-old", UserID: "user-2"}); err != sql.ErrNoRows {
+		t.Errorf("undelete by another user: err = %v, want no rows", err)
+	}
+	if _, err := queries.UndeleteWebhook(ctx, db.UndeleteWebhookParams{ID: "wh-new", UserID: "user-1"}); err != sql.ErrNoRows {
+		t.Errorf("undelete a live webhook: err = %v, want no rows", err)
+	}
+	wh, err := queries.UndeleteWebhook(ctx, db.UndeleteWebhookParams{ID: "wh-old", UserID: "user-1"})
+	if err != nil {
+		t.Fatalf("undelete: %v", err)
+	}
+	if wh.PurgedAt.Valid || !wh.UndeletedAt.Valid || wh.Status != "delivered" {
+		t.Errorf("undeleted webhook: purged %v, undeleted %v, status %s", wh.PurgedAt, wh.UndeletedAt, wh.Status)
+	}
+	// Undeleting restarts retention
+	if n, err := queries.PurgeDeliveredWebhooks(ctx, 86400); err != nil || n != 0 {
+		t.Errorf("purged %d undeleted webhooks, %v", n, err)
+	}
+
+	// Purged webhooks are deleted once the grace period has passed
+	if _, err := conn.ExecContext(ctx, "UPDATE webhooks SET purged_at = datetime('now', '-4 days') WHERE id = 'wh-old'"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.ExecContext(ctx, "UPDATE webhooks SET purged_at = datetime('now') WHERE id = 'wh-new'"); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := queries.DeletePurgedWebhooks(ctx, 3*86400); err != nil || n != 1 {
+		t.Errorf("deleted %d, %v; want 1", n, err)
+	}
+	if _, err := queries.UndeleteWebhook(ctx, db.UndeleteWebhookParams{ID: "wh-old", UserID: "user-1"}); err != sql.ErrNoRows {
+		t.Errorf("undelete after the grace period: err = %v, want no rows", err)
+	}
+}
+
+func TestCancelledReplaysPurged(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+	queries := db.New(conn)
+
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-1",
+		UserID:         "user-1",
+		Name:           "replays",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+	if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{ID: "wh-1", EndpointID: "ep-1", Headers: "{}", Payload: []byte(`{}`)}); err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+	if _, err := conn.ExecContext(ctx, "UPDATE webhooks SET replayed_at = datetime('now') WHERE id = 'wh-1'"); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := queries.CancelPendingReplays(ctx, db.CancelPendingReplaysParams{UserID: "user-1"}); err != nil || n != 1 {
+		t.Fatalf("cancelled %d, %v; want 1", n, err)
+	}
+
+	// Failed retention counts from the cancel
+	if n, err := queries.PurgeFailedWebhooks(ctx, 86400); err != nil || n != 0 {
+		t.Errorf("purged %d just cancelled, %v", n, err)
+	}
+	if _, err := conn.ExecContext(ctx, "UPDATE webhooks SET last_attempt_at = datetime(last_attempt_at, '-2 days')"); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := queries.PurgeFailedWebhooks(ctx, 86400); err != nil || n != 1 {
+		t.Errorf("purged %d cancelled replays, %v; want 1", n, err)
+	}
+}
+
+func TestTimestamps(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+
+	// Stored timestamps are UTC whatever the local zone
+	var now string
+	if err := conn.QueryRowContext(ctx, "SELECT datetime('now')").Scan(&now); err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := db.ParseTime(now)
+	if err != nil {
+		t.Fatalf("parse %q: %v", now, err)
+	}
+	if d := time.Since(parsed); d < -time.Second || d > 5*time.Second || parsed.Location() != time.UTC {
+		t.Errorf("datetime('now') parsed as %v", parsed)
+	}
+
+	// Times formatted from Go compare with datetime() as text
+	local := time.Now().In(time.FixedZone("UTC+10", 10*60*60)).Add(-time.Minute)
+	var before bool
+	if err := conn.QueryRowContext(ctx, "SELECT ? < datetime('now')", db.FormatTime(local)).Scan(&before); err != nil {
+		t.Fatal(err)
+	}
+	if !before {
+		t.Errorf("%s is not before datetime('now')", db.FormatTime(local))
+	}
+
+	want := time.Date(2026, 3, 1, 12, 30, 0, 0, time.UTC)
+	for _, s := range []string{"2026-03-01 12:30:00", "2026-03-01 12:30:00.000", "2026-03-01T12:30:00Z", "2026-03-01T14:30:00+02:00"} {
+		if got, err := db.ParseTime(s); err != nil || !got.Equal(want) || got.Location() != time.UTC {
+			t.Errorf("ParseTime(%q) = %v, %v", s, got, err)
+		}
+	}
+	if _, err := db.ParseTime("yesterday"); err == nil {
+		t.Error("invalid timestamp parsed")
+	}
+	if _, ok := db.ParseNullTime(sql.NullString{}); ok {
+		t.Error("NULL parsed")
+	}
+	if got := db.RFC3339("2026-03-01 12:30:00"); got != "2026-03-01T12:30:00Z" {
+		t.Errorf("RFC3339 = %q", got)
+	}
+}
+
+func TestInsertWithID(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+	queries := db.New(conn)
+
+	createEndpoint := func(id string) (db.Endpoint, error) {
+		return queries.CreateEndpoint(ctx, db.CreateEndpointParams{ID: id, Name: id, ProviderType: "generic", DestinationUrl: "http://localhost"})
+	}
+	if _, err := createEndpoint("taken"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = createEndpoint("taken")
+	if !db.IsPrimaryKeyConflict(err) {
+		t.Fatalf("duplicate insert error %v is not a primary key conflict", err)
+	}
+
+	// A taken ID is replaced by the next one
+	ids := []string{"taken", "free"}
+	next := func() string {
+		id := ids[0]
+		ids = ids[1:]
+		return id
+	}
+	endpoint, err := db.InsertWithID(next, createEndpoint)
+	if err != nil || endpoint.ID != "free" {
+		t.Fatalf("inserted %q, %v", endpoint.ID, err)
+	}
+
+	// Only for so long
+	if _, err := db.InsertWithID(func() string { return "taken" }, createEndpoint); !db.IsPrimaryKeyConflict(err) {
+		t.Errorf("inserting only taken IDs returned %v", err)
+	}
+	// Other errors aren't retried
+	calls := 0
+	_, err = db.InsertWithID(func() string { calls++; return "" }, func(string) (int, error) { return 0, sql.ErrConnDone })
+	if err != sql.ErrConnDone || calls != 1 {
+		t.Errorf("other error: %v after %d calls", err, calls)
+	}
+}
+
+func TestOrgs(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+	queries := db.New(conn)
+
+	if _, err := queries.CreateOrg(ctx, db.CreateOrgParams{ID: "org-1", Name: "acme", CreatedBy: "user-1"}); err != nil {
+		t.Fatalf("create org: %v", err)
+	}
+	for _, m := range []db.UpsertOrgMemberParams{
+		{OrgID: "org-1", UserID: "user-1", Username: "alice", Role: "owner"},
+		{OrgID: "org-1", UserID: "user-2", Username: "bob", Role: "viewer"},
+		{OrgID: "org-1", UserID: "user-2", Username: "bob", Role: "member"},
+	} {
+		if _, err := queries.UpsertOrgMember(ctx, m); err != nil {
+			t.Fatalf("upsert member: %v", err)
+		}
+	}
+	if _, err := queries.UpsertOrgMember(ctx, db.UpsertOrgMemberParams{OrgID: "org-1", UserID: "user-3", Role: "admin"}); err == nil {
+		t.Error("expected invalid role to be rejected")
+	}
+
+	// Upserting an existing member changes the role
+	member, err := queries.GetOrgMember(ctx, db.GetOrgMemberParams{OrgID: "org-1", UserID: "user-2"})
+	if err != nil {
+		t.Fatalf("get member: %v", err)
+	}
+	if member.Role != "member" {
+		t.Errorf("role = %q, want member", member.Role)
+	}
+	members, err := queries.ListOrgMembers(ctx, "org-1")
+	if err != nil {
+		t.Fatalf("list members: %v", err)
+	}
+	if len(members) != 2 {
+		t.Errorf("expected 2 members, got %d", len(members))
+	}
+
+	orgs, err := queries.ListUserOrgs(ctx, "user-2")
+	if err != nil {
+		t.Fatalf("list user orgs: %v", err)
+	}
+	if len(orgs) != 1 || orgs[0].Name != "acme" || orgs[0].Role != "member" {
+		t.Errorf("got %+v, want acme as member", orgs)
+	}
+
+	owners, err := queries.CountOrgOwners(ctx, "org-1")
+	if err != nil {
+		t.Fatalf("count owners: %v", err)
+	}
+	if owners != 1 {
+		t.Errorf("owners = %d, want 1", owners)
+	}
+
+	removed, err := queries.RemoveOrgMember(ctx, db.RemoveOrgMemberParams{OrgID: "org-1", UserID: "user-2"})
+	if err != nil || removed != 1 {
+		t.Fatalf("remove member: %d, %v", removed, err)
+	}
+
+	// Org endpoints are stored with the org's owner ID
+	for _, ep := range []db.CreateEndpointParams{
+		{ID: "ep-org", UserID: "org:org-1"},
+		{ID: "ep-user", UserID: "user-1"},
+	} {
+		ep.Name, ep.ProviderType, ep.DestinationUrl = ep.ID, "generic", "http://localhost:8080/hook"
+		if _, err := queries.CreateEndpoint(ctx, ep); err != nil {
+			t.Fatalf("create endpoint: %v", err)
+		}
+	}
+	deleted, err := queries.DeleteEndpointsByOwner(ctx, "org:org-1")
+	if err != nil {
+		t.Fatalf("delete org endpoints: %v", err)
+	}
+	if len(deleted) != 1 || deleted[0] != "ep-org" {
+		t.Errorf("deleted %v, want [ep-org]", deleted)
+	}
+	if _, err := queries.GetEndpointByID(ctx, "ep-user"); err != nil {
+		t.Errorf("user endpoint should remain: %v", err)
+	}
+
+	// Deleting the org removes its members
+	if err := queries.DeleteOrg(ctx, "org-1"); err != nil {
+		t.Fatalf("delete org: %v", err)
+	}
+	if _, err := queries.GetOrgMember(ctx, db.GetOrgMemberParams{OrgID: "org-1", UserID: "user-1"}); !errors.Is(err, sql.ErrNoRows) {
+		t.Errorf("expected member to be deleted with the org, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package db
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"hooks.dx314.com/internal/clock"
+)
+
+// endpointCacheSize bounds the endpoints an EndpointCache holds.
+const endpointCacheSize = 10000
+
+// EndpointCache caches GetEndpointByID for ingestion and stream connects,
+// which look an endpoint up on every request. Entries expire after a TTL
+// and are dropped by Invalidate when the endpoint changes; the TTL bounds how
+// stale a change made without Invalidate, such as one by another process
+// sharing the database, can be.
+type EndpointCache struct {
+	queries *Queries
+	ttl     time.Duration
+	clock   clock.Clock
+
+	mu         sync.Mutex
+	entries    map[string]endpointCacheEntry
+	generation uint64 // Incremented by Invalidate, so lookups racing it aren't cached
+}
+
+type endpointCacheEntry struct {
+	endpoint GetEndpointByIDRow
+	expires  time.Time
+}
+
+// NewEndpointCache creates a cache in front of queries keeping endpoints for
+// ttl.
+func NewEndpointCache(queries *Queries, ttl time.Duration) *EndpointCache {
+	return &EndpointCache{
+		queries: queries,
+		ttl:     ttl,
+		clock:   clock.Real,
+		entries: make(map[string]endpointCacheEntry),
+	}
+}
+
+// SetClock sets the clock that expires entries. It must be called before
+// the cache is used.
+func (c *EndpointCache) SetClock(clk clock.Clock) {
+	c.clock = clk
+}
+
+// Get returns an endpoint like GetEndpointByID. Endpoints that aren't found
+// aren't cached, so a new endpoint is found at once.
+func (c *EndpointCache) Get(ctx context.Context, id string) (GetEndpointByIDRow, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[id]
+	generation := c.generation
+	c.mu.Unlock()
+	if ok && c.clock.Now().Before(entry.expires) {
+		return entry.endpoint, nil
+	}
+
+	endpoint, err := c.queries.GetEndpointByID(ctx, id)
+	if err != nil {
+		return endpoint, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// The endpoint may have changed since it was read
+	if c.generation != generation {
+		return endpoint, nil
+	}
+	if len(c.entries) >= endpointCacheSize {
+		c.evict()
+	}
+	c.entries[id] = endpointCacheEntry{endpoint: endpoint, expires: c.clock.Now().Add(c.ttl)}
+	return endpoint, nil
+}
+
+// Invalidate drops an endpoint after it was updated or deleted. It does
+// nothing on a nil cache.
+func (c *EndpointCache) Invalidate(id string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, id)
+	c.generation++
+}
+
+// evict drops expired entries, or an arbitrary one if none has expired.
+func (c *EndpointCache) evict() {
+	now := c.clock.Now()
+	for id, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, id)
+		}
+	}
+	if len(c.entries) < endpointCacheSize {
+		return
+	}
+	for id := range c.entries {
+		delete(c.entries, id)
+		return
+	}
+}
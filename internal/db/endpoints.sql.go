@@ -11,21 +11,98 @@ import (
 	"strings"
 )
 
+const archiveInactiveEndpoints = `-- name: ArchiveInactiveEndpoints :many
+UPDATE endpoints
+SET muted = 1,
+    archived_at = datetime('now'),
+    updated_at = datetime('now')
+WHERE muted = 0
+  AND honeypot = 0
+  AND COALESCE(last_webhook_received_at, created_at) < datetime('now', '-' || CAST(?1 AS INTEGER) || ' seconds')
+RETURNING id, name, last_webhook_received_at
+`
+
+type ArchiveInactiveEndpointsRow struct {
+	ID                    string         `json:"id"`
+	Name                  string         `json:"name"`
+	LastWebhookReceivedAt sql.NullString `json:"last_webhook_received_at"`
+}
+
+// System query: mutes endpoints without a webhook for age_seconds, counting
+// from creation if none was received. Honeypots are meant to stay idle.
+func (q *Queries) ArchiveInactiveEndpoints(ctx context.Context, ageSeconds int64) ([]ArchiveInactiveEndpointsRow, error) {
+	rows, err := q.db.QueryContext(ctx, archiveInactiveEndpoints, ageSeconds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ArchiveInactiveEndpointsRow{}
+	for rows.Next() {
+		var i ArchiveInactiveEndpointsRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.LastWebhookReceivedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const clearEndpointSLOBreached = `-- name: ClearEndpointSLOBreached :execrows
+UPDATE endpoints
+SET slo_breached_at = NULL
+WHERE id = ? AND slo_breached_at IS NOT NULL
+`
+
+// System query: clears the breach once the SLO is met again.
+func (q *Queries) ClearEndpointSLOBreached(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, clearEndpointSLOBreached, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const countEndpoints = `-- name: CountEndpoints :one
-SELECT COUNT(*) FROM endpoints WHERE user_id = ?
+SELECT COUNT(*) FROM endpoints
+WHERE user_id = ?1
+  AND (?2 IS NULL OR name LIKE '%' || ?2 || '%' ESCAPE '\')
+  AND (?3 IS NULL OR provider_type = ?3)
+  AND (?4 IS NULL OR muted = ?4)
+  AND (?5 IS NULL OR COALESCE(last_webhook_received_at, created_at) < datetime('now', '-' || ?5 || ' days'))
 `
 
-func (q *Queries) CountEndpoints(ctx context.Context, userID string) (int64, error) {
-	row := q.db.QueryRowContext(ctx, countEndpoints, userID)
+type CountEndpointsParams struct {
+	UserID       string      `json:"user_id"`
+	Search       interface{} `json:"search"`
+	ProviderType interface{} `json:"provider_type"`
+	Muted        interface{} `json:"muted"`
+	InactiveDays interface{} `json:"inactive_days"`
+}
+
+// Counts the endpoints ListEndpoints returns for the same filters.
+func (q *Queries) CountEndpoints(ctx context.Context, arg CountEndpointsParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countEndpoints,
+		arg.UserID,
+		arg.Search,
+		arg.ProviderType,
+		arg.Muted,
+		arg.InactiveDays,
+	)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
 }
 
 const createEndpoint = `-- name: CreateEndpoint :one
-INSERT INTO endpoints (id, user_id, name, provider_type, signature_secret_encrypted, verification_config_encrypted, destination_url, muted, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, 0, datetime('now'), datetime('now'))
-RETURNING id, user_id, name, provider_type, signature_secret_encrypted, verification_config_encrypted, destination_url, muted, created_at, updated_at
+INSERT INTO endpoints (id, user_id, name, provider_type, signature_secret_encrypted, verification_config_encrypted, destination_url, muted, notify_first_event, home_region, ingest_auth_encrypted, honeypot, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, 0, ?, ?, ?, ?, datetime('now'), datetime('now'))
+RETURNING id, user_id, name, provider_type, signature_secret_encrypted, verification_config_encrypted, destination_url, muted, created_at, updated_at, notify_first_event, first_event_at, telegram_bot_token_encrypted, slo_target, slo_latency_seconds, slo_window_hours, slo_breached_at, reject_duplicates, home_region, ingest_auth_encrypted, honeypot, last_webhook_received_at, last_delivered_at, archived_at, conflict_as_duplicate, rate_limit_per_minute, transform, answer_probes, ingest_response, retry_max_attempts, retry_backoff_base_seconds, retry_max_interval_seconds, retry_jitter, max_payload_bytes, allowed_content_types
 `
 
 type CreateEndpointParams struct {
@@ -36,6 +113,10 @@ type CreateEndpointParams struct {
 	SignatureSecretEncrypted    []byte `json:"signature_secret_encrypted"`
 	VerificationConfigEncrypted []byte `json:"verification_config_encrypted"`
 	DestinationUrl              string `json:"destination_url"`
+	NotifyFirstEvent            int64  `json:"notify_first_event"`
+	HomeRegion                  string `json:"home_region"`
+	IngestAuthEncrypted         []byte `json:"ingest_auth_encrypted"`
+	Honeypot                    int64  `json:"honeypot"`
 }
 
 func (q *Queries) CreateEndpoint(ctx context.Context, arg CreateEndpointParams) (Endpoint, error) {
@@ -47,6 +128,10 @@ func (q *Queries) CreateEndpoint(ctx context.Context, arg CreateEndpointParams)
 		arg.SignatureSecretEncrypted,
 		arg.VerificationConfigEncrypted,
 		arg.DestinationUrl,
+		arg.NotifyFirstEvent,
+		arg.HomeRegion,
+		arg.IngestAuthEncrypted,
+		arg.Honeypot,
 	)
 	var i Endpoint
 	err := row.Scan(
@@ -60,6 +145,31 @@ func (q *Queries) CreateEndpoint(ctx context.Context, arg CreateEndpointParams)
 		&i.Muted,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.NotifyFirstEvent,
+		&i.FirstEventAt,
+		&i.TelegramBotTokenEncrypted,
+		&i.SloTarget,
+		&i.SloLatencySeconds,
+		&i.SloWindowHours,
+		&i.SloBreachedAt,
+		&i.RejectDuplicates,
+		&i.HomeRegion,
+		&i.IngestAuthEncrypted,
+		&i.Honeypot,
+		&i.LastWebhookReceivedAt,
+		&i.LastDeliveredAt,
+		&i.ArchivedAt,
+		&i.ConflictAsDuplicate,
+		&i.RateLimitPerMinute,
+		&i.Transform,
+		&i.AnswerProbes,
+		&i.IngestResponse,
+		&i.RetryMaxAttempts,
+		&i.RetryBackoffBaseSeconds,
+		&i.RetryMaxIntervalSeconds,
+		&i.RetryJitter,
+		&i.MaxPayloadBytes,
+		&i.AllowedContentTypes,
 	)
 	return i, err
 }
@@ -79,7 +189,7 @@ func (q *Queries) DeleteEndpoint(ctx context.Context, arg DeleteEndpointParams)
 }
 
 const getEndpoint = `-- name: GetEndpoint :one
-SELECT id, user_id, name, provider_type, signature_secret_encrypted, verification_config_encrypted, destination_url, muted, created_at, updated_at FROM endpoints WHERE id = ? AND user_id = ?
+SELECT id, user_id, name, provider_type, signature_secret_encrypted, verification_config_encrypted, destination_url, muted, created_at, updated_at, notify_first_event, first_event_at, telegram_bot_token_encrypted, slo_target, slo_latency_seconds, slo_window_hours, slo_breached_at, reject_duplicates, home_region, ingest_auth_encrypted, honeypot, last_webhook_received_at, last_delivered_at, archived_at, conflict_as_duplicate, rate_limit_per_minute, transform, answer_probes, ingest_response, retry_max_attempts, retry_backoff_base_seconds, retry_max_interval_seconds, retry_jitter, max_payload_bytes, allowed_content_types FROM endpoints WHERE id = ? AND user_id = ?
 `
 
 type GetEndpointParams struct {
@@ -101,25 +211,58 @@ func (q *Queries) GetEndpoint(ctx context.Context, arg GetEndpointParams) (Endpo
 		&i.Muted,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.NotifyFirstEvent,
+		&i.FirstEventAt,
+		&i.TelegramBotTokenEncrypted,
+		&i.SloTarget,
+		&i.SloLatencySeconds,
+		&i.SloWindowHours,
+		&i.SloBreachedAt,
+		&i.RejectDuplicates,
+		&i.HomeRegion,
+		&i.IngestAuthEncrypted,
+		&i.Honeypot,
+		&i.LastWebhookReceivedAt,
+		&i.LastDeliveredAt,
+		&i.ArchivedAt,
+		&i.ConflictAsDuplicate,
+		&i.RateLimitPerMinute,
+		&i.Transform,
+		&i.AnswerProbes,
+		&i.IngestResponse,
+		&i.RetryMaxAttempts,
+		&i.RetryBackoffBaseSeconds,
+		&i.RetryMaxIntervalSeconds,
+		&i.RetryJitter,
+		&i.MaxPayloadBytes,
+		&i.AllowedContentTypes,
 	)
 	return i, err
 }
 
 const getEndpointByID = `-- name: GetEndpointByID :one
-SELECT id, user_id, name, provider_type, signature_secret_encrypted, verification_config_encrypted, destination_url, muted
+SELECT id, user_id, name, provider_type, signature_secret_encrypted, verification_config_encrypted, destination_url, muted, reject_duplicates, ingest_auth_encrypted, honeypot, rate_limit_per_minute, answer_probes, ingest_response, max_payload_bytes, allowed_content_types
 FROM endpoints
 WHERE id = ?
 `
 
 type GetEndpointByIDRow struct {
-	ID                          string `json:"id"`
-	UserID                      string `json:"user_id"`
-	Name                        string `json:"name"`
-	ProviderType                string `json:"provider_type"`
-	SignatureSecretEncrypted    []byte `json:"signature_secret_encrypted"`
-	VerificationConfigEncrypted []byte `json:"verification_config_encrypted"`
-	DestinationUrl              string `json:"destination_url"`
-	Muted                       int64  `json:"muted"`
+	ID                          string         `json:"id"`
+	UserID                      string         `json:"user_id"`
+	Name                        string         `json:"name"`
+	ProviderType                string         `json:"provider_type"`
+	SignatureSecretEncrypted    []byte         `json:"signature_secret_encrypted"`
+	VerificationConfigEncrypted []byte         `json:"verification_config_encrypted"`
+	DestinationUrl              string         `json:"destination_url"`
+	Muted                       int64          `json:"muted"`
+	RejectDuplicates            int64          `json:"reject_duplicates"`
+	IngestAuthEncrypted         []byte         `json:"ingest_auth_encrypted"`
+	Honeypot                    int64          `json:"honeypot"`
+	RateLimitPerMinute          int64          `json:"rate_limit_per_minute"`
+	AnswerProbes                int64          `json:"answer_probes"`
+	IngestResponse              sql.NullString `json:"ingest_response"`
+	MaxPayloadBytes             int64          `json:"max_payload_bytes"`
+	AllowedContentTypes         string         `json:"allowed_content_types"`
 }
 
 // Public query for webhook ingestion and relay auth - no user_id filter
@@ -135,6 +278,14 @@ func (q *Queries) GetEndpointByID(ctx context.Context, id string) (GetEndpointBy
 		&i.VerificationConfigEncrypted,
 		&i.DestinationUrl,
 		&i.Muted,
+		&i.RejectDuplicates,
+		&i.IngestAuthEncrypted,
+		&i.Honeypot,
+		&i.RateLimitPerMinute,
+		&i.AnswerProbes,
+		&i.IngestResponse,
+		&i.MaxPayloadBytes,
+		&i.AllowedContentTypes,
 	)
 	return i, err
 }
@@ -189,17 +340,44 @@ func (q *Queries) GetEndpointsByIDs(ctx context.Context, arg GetEndpointsByIDsPa
 }
 
 const listEndpoints = `-- name: ListEndpoints :many
-SELECT id, user_id, name, provider_type, signature_secret_encrypted, verification_config_encrypted, destination_url, muted, created_at, updated_at FROM endpoints WHERE user_id = ? ORDER BY created_at DESC LIMIT ? OFFSET ?
+SELECT id, user_id, name, provider_type, signature_secret_encrypted, verification_config_encrypted, destination_url, muted, created_at, updated_at, notify_first_event, first_event_at, telegram_bot_token_encrypted, slo_target, slo_latency_seconds, slo_window_hours, slo_breached_at, reject_duplicates, home_region, ingest_auth_encrypted, honeypot, last_webhook_received_at, last_delivered_at, archived_at, conflict_as_duplicate, rate_limit_per_minute, transform, answer_probes, ingest_response, retry_max_attempts, retry_backoff_base_seconds, retry_max_interval_seconds, retry_jitter, max_payload_bytes, allowed_content_types FROM endpoints
+WHERE user_id = ?1
+  AND (?2 IS NULL OR name LIKE '%' || ?2 || '%' ESCAPE '\')
+  AND (?3 IS NULL OR provider_type = ?3)
+  AND (?4 IS NULL OR muted = ?4)
+  AND (?5 IS NULL OR COALESCE(last_webhook_received_at, created_at) < datetime('now', '-' || ?5 || ' days'))
+ORDER BY
+  CASE WHEN ?6 = 'last_received' THEN last_webhook_received_at END DESC,
+  CASE WHEN ?6 = 'created_asc' THEN created_at END ASC,
+  created_at DESC
+LIMIT ?8 OFFSET ?7
 `
 
 type ListEndpointsParams struct {
-	UserID string `json:"user_id"`
-	Limit  int64  `json:"limit"`
-	Offset int64  `json:"offset"`
+	UserID       string      `json:"user_id"`
+	Search       interface{} `json:"search"`
+	ProviderType interface{} `json:"provider_type"`
+	Muted        interface{} `json:"muted"`
+	InactiveDays interface{} `json:"inactive_days"`
+	Sort         interface{} `json:"sort"`
+	Offset       int64       `json:"offset"`
+	Limit        int64       `json:"limit"`
 }
 
+// Filters are ignored when NULL. search is a LIKE pattern fragment with \ as escape.
+// inactive_days keeps endpoints without a webhook for that many days since creation.
+// sort: created_asc, last_received (never received last), or newest first.
 func (q *Queries) ListEndpoints(ctx context.Context, arg ListEndpointsParams) ([]Endpoint, error) {
-	rows, err := q.db.QueryContext(ctx, listEndpoints, arg.UserID, arg.Limit, arg.Offset)
+	rows, err := q.db.QueryContext(ctx, listEndpoints,
+		arg.UserID,
+		arg.Search,
+		arg.ProviderType,
+		arg.Muted,
+		arg.InactiveDays,
+		arg.Sort,
+		arg.Offset,
+		arg.Limit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -218,6 +396,31 @@ func (q *Queries) ListEndpoints(ctx context.Context, arg ListEndpointsParams) ([
 			&i.Muted,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.NotifyFirstEvent,
+			&i.FirstEventAt,
+			&i.TelegramBotTokenEncrypted,
+			&i.SloTarget,
+			&i.SloLatencySeconds,
+			&i.SloWindowHours,
+			&i.SloBreachedAt,
+			&i.RejectDuplicates,
+			&i.HomeRegion,
+			&i.IngestAuthEncrypted,
+			&i.Honeypot,
+			&i.LastWebhookReceivedAt,
+			&i.LastDeliveredAt,
+			&i.ArchivedAt,
+			&i.ConflictAsDuplicate,
+			&i.RateLimitPerMinute,
+			&i.Transform,
+			&i.AnswerProbes,
+			&i.IngestResponse,
+			&i.RetryMaxAttempts,
+			&i.RetryBackoffBaseSeconds,
+			&i.RetryMaxIntervalSeconds,
+			&i.RetryJitter,
+			&i.MaxPayloadBytes,
+			&i.AllowedContentTypes,
 		); err != nil {
 			return nil, err
 		}
@@ -232,26 +435,233 @@ func (q *Queries) ListEndpoints(ctx context.Context, arg ListEndpointsParams) ([
 	return items, nil
 }
 
-const updateEndpoint = `-- name: UpdateEndpoint :one
+const listSLOEndpoints = `-- name: ListSLOEndpoints :many
+SELECT id, user_id, name, destination_url, slo_target, slo_latency_seconds, slo_window_hours, slo_breached_at
+FROM endpoints
+WHERE slo_target > 0
+`
+
+type ListSLOEndpointsRow struct {
+	ID                string         `json:"id"`
+	UserID            string         `json:"user_id"`
+	Name              string         `json:"name"`
+	DestinationUrl    string         `json:"destination_url"`
+	SloTarget         float64        `json:"slo_target"`
+	SloLatencySeconds int64          `json:"slo_latency_seconds"`
+	SloWindowHours    int64          `json:"slo_window_hours"`
+	SloBreachedAt     sql.NullString `json:"slo_breached_at"`
+}
+
+// System query: endpoints with an SLO configured (no user filter)
+func (q *Queries) ListSLOEndpoints(ctx context.Context) ([]ListSLOEndpointsRow, error) {
+	rows, err := q.db.QueryContext(ctx, listSLOEndpoints)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListSLOEndpointsRow{}
+	for rows.Next() {
+		var i ListSLOEndpointsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.DestinationUrl,
+			&i.SloTarget,
+			&i.SloLatencySeconds,
+			&i.SloWindowHours,
+			&i.SloBreachedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markEndpointDelivered = `-- name: MarkEndpointDelivered :exec
+UPDATE endpoints SET last_delivered_at = datetime('now') WHERE id = ?
+`
+
+// System query: records a delivery acknowledged by the hub.
+func (q *Queries) MarkEndpointDelivered(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, markEndpointDelivered, id)
+	return err
+}
+
+const markEndpointReceived = `-- name: MarkEndpointReceived :exec
+UPDATE endpoints SET last_webhook_received_at = datetime('now') WHERE id = ?
+`
+
+// System query: records a webhook stored for the endpoint.
+func (q *Queries) MarkEndpointReceived(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, markEndpointReceived, id)
+	return err
+}
+
+const markFirstEvent = `-- name: MarkFirstEvent :one
+UPDATE endpoints
+SET first_event_at = datetime('now')
+WHERE id = ? AND first_event_at IS NULL
+RETURNING notify_first_event
+`
+
+// System query: records the first webhook for an endpoint. Returns no rows if
+// the endpoint already received a webhook.
+func (q *Queries) MarkFirstEvent(ctx context.Context, id string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, markFirstEvent, id)
+	var notify_first_event int64
+	err := row.Scan(&notify_first_event)
+	return notify_first_event, err
+}
+
+const setEndpointIngestAuth = `-- name: SetEndpointIngestAuth :exec
+UPDATE endpoints
+SET ingest_auth_encrypted = ?,
+    updated_at = datetime('now')
+WHERE id = ? AND user_id = ?
+`
+
+type SetEndpointIngestAuthParams struct {
+	IngestAuthEncrypted []byte `json:"ingest_auth_encrypted"`
+	ID                  string `json:"id"`
+	UserID              string `json:"user_id"`
+}
+
+// Sets or clears (NULL) the ingestion credentials
+func (q *Queries) SetEndpointIngestAuth(ctx context.Context, arg SetEndpointIngestAuthParams) error {
+	_, err := q.db.ExecContext(ctx, setEndpointIngestAuth, arg.IngestAuthEncrypted, arg.ID, arg.UserID)
+	return err
+}
+
+const setEndpointTransform = `-- name: SetEndpointTransform :exec
+UPDATE endpoints
+SET transform = ?,
+    updated_at = datetime('now')
+WHERE id = ? AND user_id = ?
+`
+
+type SetEndpointTransformParams struct {
+	Transform sql.NullString `json:"transform"`
+	ID        string         `json:"id"`
+	UserID    string         `json:"user_id"`
+}
+
+// Sets or clears (NULL) the rules the hub applies before forwarding
+func (q *Queries) SetEndpointTransform(ctx context.Context, arg SetEndpointTransformParams) error {
+	_, err := q.db.ExecContext(ctx, setEndpointTransform, arg.Transform, arg.ID, arg.UserID)
+	return err
+}
+
+const setEndpointIngestResponse = `-- name: SetEndpointIngestResponse :exec
 UPDATE endpoints
-SET name = COALESCE(?3, name),
-    signature_secret_encrypted = COALESCE(?4, signature_secret_encrypted),
-    verification_config_encrypted = COALESCE(?5, verification_config_encrypted),
-    destination_url = COALESCE(?6, destination_url),
-    muted = COALESCE(?7, muted),
+SET ingest_response = ?,
     updated_at = datetime('now')
 WHERE id = ? AND user_id = ?
-RETURNING id, user_id, name, provider_type, signature_secret_encrypted, verification_config_encrypted, destination_url, muted, created_at, updated_at
+`
+
+type SetEndpointIngestResponseParams struct {
+	IngestResponse sql.NullString `json:"ingest_response"`
+	ID             string         `json:"id"`
+	UserID         string         `json:"user_id"`
+}
+
+// Sets or clears (NULL) the response sent once a webhook is stored
+func (q *Queries) SetEndpointIngestResponse(ctx context.Context, arg SetEndpointIngestResponseParams) error {
+	_, err := q.db.ExecContext(ctx, setEndpointIngestResponse, arg.IngestResponse, arg.ID, arg.UserID)
+	return err
+}
+
+const setEndpointSLOBreached = `-- name: SetEndpointSLOBreached :execrows
+UPDATE endpoints
+SET slo_breached_at = datetime('now')
+WHERE id = ? AND slo_breached_at IS NULL
+`
+
+// System query: marks the SLO breached. Affects no rows if already breached.
+func (q *Queries) SetEndpointSLOBreached(ctx context.Context, id string) (int64, error) {
+	result, err := q.db.ExecContext(ctx, setEndpointSLOBreached, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const setEndpointTelegramBotToken = `-- name: SetEndpointTelegramBotToken :exec
+UPDATE endpoints
+SET telegram_bot_token_encrypted = ?,
+    updated_at = datetime('now')
+WHERE id = ? AND user_id = ?
+`
+
+type SetEndpointTelegramBotTokenParams struct {
+	TelegramBotTokenEncrypted []byte `json:"telegram_bot_token_encrypted"`
+	ID                        string `json:"id"`
+	UserID                    string `json:"user_id"`
+}
+
+func (q *Queries) SetEndpointTelegramBotToken(ctx context.Context, arg SetEndpointTelegramBotTokenParams) error {
+	_, err := q.db.ExecContext(ctx, setEndpointTelegramBotToken, arg.TelegramBotTokenEncrypted, arg.ID, arg.UserID)
+	return err
+}
+
+const updateEndpoint = `-- name: UpdateEndpoint :one
+UPDATE endpoints
+SET name = COALESCE(?1, name),
+    signature_secret_encrypted = COALESCE(?2, signature_secret_encrypted),
+    verification_config_encrypted = COALESCE(?3, verification_config_encrypted),
+    destination_url = COALESCE(?4, destination_url),
+    muted = COALESCE(?5, muted),
+    archived_at = CASE WHEN ?5 = 0 THEN NULL ELSE archived_at END,
+    notify_first_event = COALESCE(?6, notify_first_event),
+    slo_target = COALESCE(?7, slo_target),
+    slo_latency_seconds = COALESCE(?8, slo_latency_seconds),
+    slo_window_hours = COALESCE(?9, slo_window_hours),
+    reject_duplicates = COALESCE(?10, reject_duplicates),
+    honeypot = COALESCE(?11, honeypot),
+    conflict_as_duplicate = COALESCE(?12, conflict_as_duplicate),
+    rate_limit_per_minute = COALESCE(?13, rate_limit_per_minute),
+    answer_probes = COALESCE(?14, answer_probes),
+    retry_max_attempts = COALESCE(?15, retry_max_attempts),
+    retry_backoff_base_seconds = COALESCE(?16, retry_backoff_base_seconds),
+    retry_max_interval_seconds = COALESCE(?17, retry_max_interval_seconds),
+    retry_jitter = COALESCE(?18, retry_jitter),
+    max_payload_bytes = COALESCE(?19, max_payload_bytes),
+    allowed_content_types = COALESCE(?20, allowed_content_types),
+    updated_at = datetime('now')
+WHERE id = ?21 AND user_id = ?22
+RETURNING id, user_id, name, provider_type, signature_secret_encrypted, verification_config_encrypted, destination_url, muted, created_at, updated_at, notify_first_event, first_event_at, telegram_bot_token_encrypted, slo_target, slo_latency_seconds, slo_window_hours, slo_breached_at, reject_duplicates, home_region, ingest_auth_encrypted, honeypot, last_webhook_received_at, last_delivered_at, archived_at, conflict_as_duplicate, rate_limit_per_minute, transform, answer_probes, ingest_response, retry_max_attempts, retry_backoff_base_seconds, retry_max_interval_seconds, retry_jitter, max_payload_bytes, allowed_content_types
 `
 
 type UpdateEndpointParams struct {
-	Name                        sql.NullString `json:"name"`
-	SignatureSecretEncrypted    []byte         `json:"signature_secret_encrypted"`
-	VerificationConfigEncrypted []byte         `json:"verification_config_encrypted"`
-	DestinationUrl              sql.NullString `json:"destination_url"`
-	Muted                       sql.NullInt64  `json:"muted"`
-	ID                          string         `json:"id"`
-	UserID                      string         `json:"user_id"`
+	Name                        sql.NullString  `json:"name"`
+	SignatureSecretEncrypted    []byte          `json:"signature_secret_encrypted"`
+	VerificationConfigEncrypted []byte          `json:"verification_config_encrypted"`
+	DestinationUrl              sql.NullString  `json:"destination_url"`
+	Muted                       sql.NullInt64   `json:"muted"`
+	NotifyFirstEvent            sql.NullInt64   `json:"notify_first_event"`
+	SloTarget                   sql.NullFloat64 `json:"slo_target"`
+	SloLatencySeconds           sql.NullInt64   `json:"slo_latency_seconds"`
+	SloWindowHours              sql.NullInt64   `json:"slo_window_hours"`
+	RejectDuplicates            sql.NullInt64   `json:"reject_duplicates"`
+	Honeypot                    sql.NullInt64   `json:"honeypot"`
+	ConflictAsDuplicate         sql.NullInt64   `json:"conflict_as_duplicate"`
+	RateLimitPerMinute          sql.NullInt64   `json:"rate_limit_per_minute"`
+	AnswerProbes                sql.NullInt64   `json:"answer_probes"`
+	RetryMaxAttempts            sql.NullInt64   `json:"retry_max_attempts"`
+	RetryBackoffBaseSeconds     sql.NullInt64   `json:"retry_backoff_base_seconds"`
+	RetryMaxIntervalSeconds     sql.NullInt64   `json:"retry_max_interval_seconds"`
+	RetryJitter                 sql.NullFloat64 `json:"retry_jitter"`
+	MaxPayloadBytes             sql.NullInt64   `json:"max_payload_bytes"`
+	AllowedContentTypes         sql.NullString  `json:"allowed_content_types"`
+	ID                          string          `json:"id"`
+	UserID                      string          `json:"user_id"`
 }
 
 func (q *Queries) UpdateEndpoint(ctx context.Context, arg UpdateEndpointParams) (Endpoint, error) {
@@ -261,6 +671,21 @@ func (q *Queries) UpdateEndpoint(ctx context.Context, arg UpdateEndpointParams)
 		arg.VerificationConfigEncrypted,
 		arg.DestinationUrl,
 		arg.Muted,
+		arg.NotifyFirstEvent,
+		arg.SloTarget,
+		arg.SloLatencySeconds,
+		arg.SloWindowHours,
+		arg.RejectDuplicates,
+		arg.Honeypot,
+		arg.ConflictAsDuplicate,
+		arg.RateLimitPerMinute,
+		arg.AnswerProbes,
+		arg.RetryMaxAttempts,
+		arg.RetryBackoffBaseSeconds,
+		arg.RetryMaxIntervalSeconds,
+		arg.RetryJitter,
+		arg.MaxPayloadBytes,
+		arg.AllowedContentTypes,
 		arg.ID,
 		arg.UserID,
 	)
@@ -276,6 +701,31 @@ func (q *Queries) UpdateEndpoint(ctx context.Context, arg UpdateEndpointParams)
 		&i.Muted,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.NotifyFirstEvent,
+		&i.FirstEventAt,
+		&i.TelegramBotTokenEncrypted,
+		&i.SloTarget,
+		&i.SloLatencySeconds,
+		&i.SloWindowHours,
+		&i.SloBreachedAt,
+		&i.RejectDuplicates,
+		&i.HomeRegion,
+		&i.IngestAuthEncrypted,
+		&i.Honeypot,
+		&i.LastWebhookReceivedAt,
+		&i.LastDeliveredAt,
+		&i.ArchivedAt,
+		&i.ConflictAsDuplicate,
+		&i.RateLimitPerMinute,
+		&i.Transform,
+		&i.AnswerProbes,
+		&i.IngestResponse,
+		&i.RetryMaxAttempts,
+		&i.RetryBackoffBaseSeconds,
+		&i.RetryMaxIntervalSeconds,
+		&i.RetryJitter,
+		&i.MaxPayloadBytes,
+		&i.AllowedContentTypes,
 	)
 	return i, err
 }
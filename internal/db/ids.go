@@ -0,0 +1,35 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// idAttempts bounds the IDs InsertWithID tries.
+const idAttempts = 3
+
+// IsPrimaryKeyConflict reports whether err is an insert failing because a
+// row with its primary key already exists.
+func IsPrimaryKeyConflict(err error) bool {
+	var serr sqlite3.Error
+	return errors.As(err, &serr) && serr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey
+}
+
+// InsertWithID inserts a row with an ID from newID. If the ID is already
+// taken, which short configured IDs make possible, it tries again with
+// another instead of failing or, worse, being mistaken for the existing row.
+func InsertWithID[T any](newID func() string, insert func(id string) (T, error)) (T, error) {
+	for attempt := 1; ; attempt++ {
+		row, err := insert(newID())
+		if !IsPrimaryKeyConflict(err) {
+			return row, err
+		}
+		slog.Warn("generated ID already taken, trying another", "attempt", attempt)
+		if attempt == idAttempts {
+			return row, fmt.Errorf("%d generated IDs were all taken: %w", attempt, err)
+		}
+	}
+}
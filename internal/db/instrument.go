@@ -0,0 +1,280 @@
+package db
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-sqlite3"
+)
+
+// queryLatencyBuckets are the upper bounds, in seconds, of the query duration
+// histogram buckets.
+var queryLatencyBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+// QueryMetrics records the duration and row count of every query, keyed by
+// its sqlc name, and logs queries slower than a threshold. A query's duration
+// runs until its rows are closed, so it includes reading the results.
+type QueryMetrics struct {
+	slowThreshold time.Duration // 0 disables the slow query log
+
+	mu    sync.Mutex
+	stats map[string]*queryStats
+}
+
+type queryStats struct {
+	calls   uint64
+	errors  uint64
+	rows    uint64 // Rows returned by queries, rows affected by statements
+	buckets []uint64
+	seconds float64
+	slowest time.Duration
+}
+
+// QueryStat is a snapshot of one query's metrics.
+type QueryStat struct {
+	Name    string
+	Calls   uint64
+	Errors  uint64
+	Rows    uint64
+	Total   time.Duration
+	Slowest time.Duration
+}
+
+// NewQueryMetrics creates an empty registry. Queries taking at least
+// slowThreshold are logged; zero disables the log.
+func NewQueryMetrics(slowThreshold time.Duration) *QueryMetrics {
+	return &QueryMetrics{slowThreshold: slowThreshold, stats: make(map[string]*queryStats)}
+}
+
+// queryName returns the sqlc name of a query ("-- name: GetWebhook :one"),
+// or "other" for statements not generated by sqlc such as migrations.
+func queryName(query string) string {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(query), "-- name: ")
+	if !ok {
+		return "other"
+	}
+	name, _, _ := strings.Cut(rest, " ")
+	return name
+}
+
+func (m *QueryMetrics) observe(query string, d time.Duration, rows int64, err error) {
+	name := queryName(query)
+	failed := err != nil && err != sql.ErrNoRows && err != io.EOF
+
+	m.mu.Lock()
+	st, ok := m.stats[name]
+	if !ok {
+		st = &queryStats{buckets: make([]uint64, len(queryLatencyBuckets))}
+		m.stats[name] = st
+	}
+	st.calls++
+	if failed {
+		st.errors++
+	}
+	if rows > 0 {
+		st.rows += uint64(rows)
+	}
+	seconds := d.Seconds()
+	for i, le := range queryLatencyBuckets {
+		if seconds <= le {
+			st.buckets[i]++
+		}
+	}
+	st.seconds += seconds
+	st.slowest = max(st.slowest, d)
+	m.mu.Unlock()
+
+	if m.slowThreshold > 0 && d >= m.slowThreshold {
+		slog.Warn("slow query", "query", name, "duration", d.String(), "rows", rows, "threshold", m.slowThreshold.String())
+	}
+}
+
+// Stats returns a snapshot of every query's metrics, slowest total first.
+func (m *QueryMetrics) Stats() []QueryStat {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]QueryStat, 0, len(m.stats))
+	for name, st := range m.stats {
+		out = append(out, QueryStat{
+			Name:    name,
+			Calls:   st.calls,
+			Errors:  st.errors,
+			Rows:    st.rows,
+			Total:   time.Duration(st.seconds * float64(time.Second)),
+			Slowest: st.slowest,
+		})
+	}
+	slices.SortFunc(out, func(a, b QueryStat) int {
+		return cmp.Or(cmp.Compare(b.Total, a.Total), strings.Compare(a.Name, b.Name))
+	})
+	return out
+}
+
+// WriteTo writes the metrics in the OpenMetrics text format, without the
+// trailing # EOF so they can be combined with other metrics.
+func (m *QueryMetrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	names := make([]string, 0, len(m.stats))
+	for name := range m.stats {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var b strings.Builder
+	b.WriteString("# TYPE hookly_db_queries counter\n")
+	b.WriteString("# HELP hookly_db_queries Database queries run, by sqlc query name.\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "hookly_db_queries_total{query=%q} %d\n", name, m.stats[name].calls)
+	}
+	b.WriteString("# TYPE hookly_db_query_errors counter\n")
+	b.WriteString("# HELP hookly_db_query_errors Database queries that failed, by sqlc query name.\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "hookly_db_query_errors_total{query=%q} %d\n", name, m.stats[name].errors)
+	}
+	b.WriteString("# TYPE hookly_db_query_rows counter\n")
+	b.WriteString("# HELP hookly_db_query_rows Rows returned or affected, by sqlc query name.\n")
+	for _, name := range names {
+		fmt.Fprintf(&b, "hookly_db_query_rows_total{query=%q} %d\n", name, m.stats[name].rows)
+	}
+	b.WriteString("# TYPE hookly_db_query_duration_seconds histogram\n")
+	b.WriteString("# UNIT hookly_db_query_duration_seconds seconds\n")
+	b.WriteString("# HELP hookly_db_query_duration_seconds Time from running a query to closing its rows.\n")
+	for _, name := range names {
+		st := m.stats[name]
+		for i, le := range queryLatencyBuckets {
+			fmt.Fprintf(&b, "hookly_db_query_duration_seconds_bucket{query=%q,le=\"%s\"} %d\n", name, strconv.FormatFloat(le, 'g', -1, 64), st.buckets[i])
+		}
+		fmt.Fprintf(&b, "hookly_db_query_duration_seconds_bucket{query=%q,le=\"+Inf\"} %d\n", name, st.calls)
+		fmt.Fprintf(&b, "hookly_db_query_duration_seconds_sum{query=%q} %s\n", name, strconv.FormatFloat(st.seconds, 'g', -1, 64))
+		fmt.Fprintf(&b, "hookly_db_query_duration_seconds_count{query=%q} %d\n", name, st.calls)
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// ServeHTTP serves the metrics in the OpenMetrics text format.
+func (m *QueryMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/openmetrics-text; version=1.0.0; charset=utf-8")
+	m.WriteTo(w)
+	io.WriteString(w, "# EOF\n")
+}
+
+// instrumentedConnector opens SQLite connections that report to metrics.
+type instrumentedConnector struct {
+	dsn     string
+	metrics *QueryMetrics
+}
+
+func (c *instrumentedConnector) Connect(context.Context) (driver.Conn, error) {
+	conn, err := c.Driver().Open(c.dsn)
+	if err != nil {
+		return nil, err
+	}
+	return &instrumentedConn{Conn: conn, metrics: c.metrics}, nil
+}
+
+func (c *instrumentedConnector) Driver() driver.Driver {
+	return &sqlite3.SQLiteDriver{}
+}
+
+// instrumentedConn times queries and statements run on the connection.
+// Prepared statements are not timed; sqlc doesn't prepare them.
+type instrumentedConn struct {
+	driver.Conn
+	metrics *QueryMetrics
+}
+
+func (c *instrumentedConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil {
+		c.metrics.observe(query, time.Since(start), 0, err)
+		return nil, err
+	}
+	return &instrumentedRows{Rows: rows, metrics: c.metrics, query: query, start: start}, nil
+}
+
+func (c *instrumentedConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	start := time.Now()
+	res, err := execer.ExecContext(ctx, query, args)
+	var affected int64
+	if err == nil {
+		affected, _ = res.RowsAffected()
+	}
+	c.metrics.observe(query, time.Since(start), affected, err)
+	return res, err
+}
+
+func (c *instrumentedConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	if p, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		return p.PrepareContext(ctx, query)
+	}
+	return c.Conn.Prepare(query)
+}
+
+func (c *instrumentedConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	if b, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return b.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *instrumentedConn) Ping(ctx context.Context) error {
+	if p, ok := c.Conn.(driver.Pinger); ok {
+		return p.Ping(ctx)
+	}
+	return nil
+}
+
+// instrumentedRows counts rows and records the query when closed.
+type instrumentedRows struct {
+	driver.Rows
+	metrics *QueryMetrics
+	query   string
+	start   time.Time
+	n       int64
+	err     error
+	closed  bool
+}
+
+func (r *instrumentedRows) Next(dest []driver.Value) error {
+	err := r.Rows.Next(dest)
+	if err == nil {
+		r.n++
+	} else if err != io.EOF {
+		r.err = err
+	}
+	return err
+}
+
+func (r *instrumentedRows) Close() error {
+	err := r.Rows.Close()
+	if !r.closed {
+		r.closed = true
+		r.metrics.observe(r.query, time.Since(r.start), r.n, r.err)
+	}
+	return err
+}
@@ -0,0 +1,12 @@
+package db
+
+import "strings"
+
+// likeEscaper escapes LIKE wildcards for patterns using ESCAPE '\'.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// EscapeLike escapes s for use as a literal in a LIKE pattern with
+// ESCAPE '\', such as the search filter of ListEndpoints.
+func EscapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Add activity_events table for the UI activity feed.
+-- Deliveries are rolled up per endpoint per hour to keep the feed compact.
+
+CREATE TABLE IF NOT EXISTS activity_events (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('deliveries', 'hub_connected', 'hub_disconnected')),
+    endpoint_id TEXT,
+    hub_id TEXT,
+    count INTEGER NOT NULL DEFAULT 1,
+    occurred_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_activity_events_user_updated ON activity_events(user_id, updated_at DESC);
+
+-- +goose Down
+DROP TABLE IF EXISTS activity_events;
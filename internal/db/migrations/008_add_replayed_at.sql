@@ -0,0 +1,10 @@
+-- +goose Up
+-- Track webhooks queued by a replay so they can be throttled and cancelled.
+
+ALTER TABLE webhooks ADD COLUMN replayed_at TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_replay_pending ON webhooks(endpoint_id, status) WHERE replayed_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_webhooks_replay_pending;
+ALTER TABLE webhooks DROP COLUMN replayed_at;
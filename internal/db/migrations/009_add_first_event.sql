@@ -0,0 +1,13 @@
+-- +goose Up
+-- Track when an endpoint receives its first webhook, with an opt-in notification.
+
+ALTER TABLE endpoints ADD COLUMN notify_first_event INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE endpoints ADD COLUMN first_event_at TEXT;
+
+-- Endpoints that already received webhooks are not waiting for a first event
+UPDATE endpoints
+SET first_event_at = (SELECT MIN(w.received_at) FROM webhooks w WHERE w.endpoint_id = endpoints.id);
+
+-- +goose Down
+ALTER TABLE endpoints DROP COLUMN first_event_at;
+ALTER TABLE endpoints DROP COLUMN notify_first_event;
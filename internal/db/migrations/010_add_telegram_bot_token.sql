@@ -0,0 +1,7 @@
+-- +goose Up
+-- Store the bot token of telegram endpoints so setWebhook can be called on the user's behalf.
+
+ALTER TABLE endpoints ADD COLUMN telegram_bot_token_encrypted BLOB;
+
+-- +goose Down
+ALTER TABLE endpoints DROP COLUMN telegram_bot_token_encrypted;
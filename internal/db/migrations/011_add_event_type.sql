@@ -0,0 +1,10 @@
+-- +goose Up
+-- Store the provider event type so webhooks can be filtered and counted by type.
+
+ALTER TABLE webhooks ADD COLUMN event_type TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_endpoint_event_type ON webhooks(endpoint_id, event_type);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_webhooks_endpoint_event_type;
+ALTER TABLE webhooks DROP COLUMN event_type;
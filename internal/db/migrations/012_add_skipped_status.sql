@@ -0,0 +1,67 @@
+-- +goose Up
+-- Add the 'skipped' webhook status for event types a hub did not subscribe to.
+
+-- SQLite can't alter CHECK constraints, so we recreate the table
+CREATE TABLE webhooks_new (
+    id TEXT PRIMARY KEY,
+    endpoint_id TEXT NOT NULL,
+    received_at TEXT NOT NULL DEFAULT (datetime('now')),
+    headers TEXT NOT NULL,
+    payload BLOB NOT NULL,
+    signature_valid INTEGER NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed', 'dead_letter', 'skipped')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_attempt_at TEXT,
+    delivered_at TEXT,
+    error_message TEXT,
+    notification_sent INTEGER NOT NULL DEFAULT 0,
+    replayed_at TEXT,
+    event_type TEXT,
+    FOREIGN KEY (endpoint_id) REFERENCES endpoints(id) ON DELETE CASCADE
+);
+
+INSERT INTO webhooks_new SELECT * FROM webhooks;
+
+DROP TABLE webhooks;
+ALTER TABLE webhooks_new RENAME TO webhooks;
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_endpoint_id ON webhooks(endpoint_id);
+CREATE INDEX IF NOT EXISTS idx_webhooks_status ON webhooks(status);
+CREATE INDEX IF NOT EXISTS idx_webhooks_received_at ON webhooks(received_at);
+CREATE INDEX IF NOT EXISTS idx_webhooks_status_received ON webhooks(status, received_at);
+CREATE INDEX IF NOT EXISTS idx_webhooks_replay_pending ON webhooks(endpoint_id, status) WHERE replayed_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_webhooks_endpoint_event_type ON webhooks(endpoint_id, event_type);
+
+-- +goose Down
+-- Skipped webhooks were never delivered; keep them as failed
+UPDATE webhooks SET status = 'failed' WHERE status = 'skipped';
+
+CREATE TABLE webhooks_new (
+    id TEXT PRIMARY KEY,
+    endpoint_id TEXT NOT NULL,
+    received_at TEXT NOT NULL DEFAULT (datetime('now')),
+    headers TEXT NOT NULL,
+    payload BLOB NOT NULL,
+    signature_valid INTEGER NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed', 'dead_letter')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_attempt_at TEXT,
+    delivered_at TEXT,
+    error_message TEXT,
+    notification_sent INTEGER NOT NULL DEFAULT 0,
+    replayed_at TEXT,
+    event_type TEXT,
+    FOREIGN KEY (endpoint_id) REFERENCES endpoints(id) ON DELETE CASCADE
+);
+
+INSERT INTO webhooks_new SELECT * FROM webhooks;
+
+DROP TABLE webhooks;
+ALTER TABLE webhooks_new RENAME TO webhooks;
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_endpoint_id ON webhooks(endpoint_id);
+CREATE INDEX IF NOT EXISTS idx_webhooks_status ON webhooks(status);
+CREATE INDEX IF NOT EXISTS idx_webhooks_received_at ON webhooks(received_at);
+CREATE INDEX IF NOT EXISTS idx_webhooks_status_received ON webhooks(status, received_at);
+CREATE INDEX IF NOT EXISTS idx_webhooks_replay_pending ON webhooks(endpoint_id, status) WHERE replayed_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_webhooks_endpoint_event_type ON webhooks(endpoint_id, event_type);
@@ -0,0 +1,14 @@
+-- +goose Up
+-- Per-endpoint delivery SLO: slo_target percent of webhooks delivered within
+-- slo_latency_seconds over the last slo_window_hours.
+
+ALTER TABLE endpoints ADD COLUMN slo_target REAL NOT NULL DEFAULT 0;
+ALTER TABLE endpoints ADD COLUMN slo_latency_seconds INTEGER NOT NULL DEFAULT 60;
+ALTER TABLE endpoints ADD COLUMN slo_window_hours INTEGER NOT NULL DEFAULT 24;
+ALTER TABLE endpoints ADD COLUMN slo_breached_at TEXT;
+
+-- +goose Down
+ALTER TABLE endpoints DROP COLUMN slo_breached_at;
+ALTER TABLE endpoints DROP COLUMN slo_window_hours;
+ALTER TABLE endpoints DROP COLUMN slo_latency_seconds;
+ALTER TABLE endpoints DROP COLUMN slo_target;
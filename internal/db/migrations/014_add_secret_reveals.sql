@@ -0,0 +1,17 @@
+-- +goose Up
+-- Audit log of signature secret reveals, also used to rate limit them.
+
+CREATE TABLE IF NOT EXISTS secret_reveals (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    endpoint_id TEXT NOT NULL,
+    ip_address TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    revealed_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_secret_reveals_user_revealed ON secret_reveals(user_id, revealed_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_secret_reveals_user_revealed;
+DROP TABLE IF EXISTS secret_reveals;
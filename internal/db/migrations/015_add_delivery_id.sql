@@ -0,0 +1,15 @@
+-- +goose Up
+-- Provider delivery IDs (X-GitHub-Delivery, Stripe event id, Svix webhook-id)
+-- so re-deliveries can be detected even when the body changed.
+
+ALTER TABLE webhooks ADD COLUMN delivery_id TEXT;
+ALTER TABLE webhooks ADD COLUMN duplicate_of TEXT;
+ALTER TABLE endpoints ADD COLUMN reject_duplicates INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_webhooks_endpoint_delivery_id ON webhooks(endpoint_id, delivery_id) WHERE delivery_id IS NOT NULL;
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_webhooks_endpoint_delivery_id;
+ALTER TABLE endpoints DROP COLUMN reject_duplicates;
+ALTER TABLE webhooks DROP COLUMN duplicate_of;
+ALTER TABLE webhooks DROP COLUMN delivery_id;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Region of the edge an endpoint was created on, so its webhook URL points at
+-- that region when several edges share one service.
+
+ALTER TABLE endpoints ADD COLUMN home_region TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE endpoints DROP COLUMN home_region;
@@ -0,0 +1,7 @@
+-- +goose Up
+-- Client IP the webhook was received from, resolved through trusted proxies.
+
+ALTER TABLE webhooks ADD COLUMN source_ip TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE webhooks DROP COLUMN source_ip;
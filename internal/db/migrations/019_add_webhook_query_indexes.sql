@@ -0,0 +1,13 @@
+-- +goose Up
+-- Composite indexes for the hot webhooks queries, found with `migrate analyze`.
+
+-- Dispatcher: oldest pending webhook per endpoint
+CREATE INDEX IF NOT EXISTS idx_webhooks_endpoint_status_received ON webhooks(endpoint_id, status, received_at);
+-- Retention sweeps
+CREATE INDEX IF NOT EXISTS idx_webhooks_status_delivered ON webhooks(status, delivered_at);
+CREATE INDEX IF NOT EXISTS idx_webhooks_status_last_attempt ON webhooks(status, last_attempt_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_webhooks_status_last_attempt;
+DROP INDEX IF EXISTS idx_webhooks_status_delivered;
+DROP INDEX IF EXISTS idx_webhooks_endpoint_status_received;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Credentials required on an endpoint's ingestion URL (basic auth or a static
+-- header token), as encrypted JSON. NULL leaves the URL open.
+
+ALTER TABLE endpoints ADD COLUMN ingest_auth_encrypted BLOB;
+
+-- +goose Down
+ALTER TABLE endpoints DROP COLUMN ingest_auth_encrypted;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Honeypot endpoints never relay: every hit is stored and alerts the owner,
+-- to detect leaked or scanned endpoint URLs and canary tokens.
+
+ALTER TABLE endpoints ADD COLUMN honeypot INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE endpoints DROP COLUMN honeypot;
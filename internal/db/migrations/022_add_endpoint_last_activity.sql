@@ -0,0 +1,14 @@
+-- +goose Up
+-- Last activity per endpoint, so stale or abandoned endpoints show at a glance
+-- without scanning webhooks. Backfilled from the webhooks still stored.
+
+ALTER TABLE endpoints ADD COLUMN last_webhook_received_at TEXT;
+ALTER TABLE endpoints ADD COLUMN last_delivered_at TEXT;
+
+UPDATE endpoints SET
+    last_webhook_received_at = (SELECT MAX(received_at) FROM webhooks WHERE endpoint_id = endpoints.id),
+    last_delivered_at = (SELECT MAX(delivered_at) FROM webhooks WHERE endpoint_id = endpoints.id);
+
+-- +goose Down
+ALTER TABLE endpoints DROP COLUMN last_delivered_at;
+ALTER TABLE endpoints DROP COLUMN last_webhook_received_at;
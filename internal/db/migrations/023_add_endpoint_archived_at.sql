@@ -0,0 +1,7 @@
+-- +goose Up
+-- When an endpoint was muted automatically for inactivity. Unmuting clears it.
+
+ALTER TABLE endpoints ADD COLUMN archived_at TEXT;
+
+-- +goose Down
+ALTER TABLE endpoints DROP COLUMN archived_at;
@@ -0,0 +1,61 @@
+-- +goose Up
+-- Record every webhook status change, and reject changes the webhook state
+-- machine doesn't allow (internal/webhook/status.go). Migrations that
+-- recreate the webhooks table must create the triggers again.
+
+CREATE TABLE IF NOT EXISTS webhook_status_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    webhook_id TEXT NOT NULL,
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    reason TEXT,
+    changed_at TEXT NOT NULL DEFAULT (datetime('now')),
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_status_history_webhook ON webhook_status_history(webhook_id, id);
+
+-- +goose StatementBegin
+CREATE TRIGGER webhook_status_check
+BEFORE UPDATE OF status ON webhooks
+WHEN OLD.status != NEW.status
+  AND OLD.status || '>' || NEW.status NOT IN (
+    'pending>delivered', 'pending>failed', 'pending>skipped', 'pending>dead_letter',
+    'delivered>pending', 'failed>pending', 'dead_letter>pending', 'skipped>pending'
+  )
+BEGIN
+    SELECT RAISE(ABORT, 'invalid webhook status transition');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER webhook_status_stored
+AFTER INSERT ON webhooks
+BEGIN
+    INSERT INTO webhook_status_history (webhook_id, from_status, to_status, changed_at)
+    VALUES (NEW.id, NULL, NEW.status, NEW.received_at);
+END;
+-- +goose StatementEnd
+
+-- A replay is recorded even if the webhook was still pending
+-- +goose StatementBegin
+CREATE TRIGGER webhook_status_changed
+AFTER UPDATE OF status ON webhooks
+WHEN OLD.status != NEW.status OR NEW.replayed_at IS NOT OLD.replayed_at
+BEGIN
+    INSERT INTO webhook_status_history (webhook_id, from_status, to_status, reason)
+    VALUES (
+        NEW.id,
+        OLD.status,
+        NEW.status,
+        CASE WHEN NEW.replayed_at IS NOT OLD.replayed_at THEN 'replayed' ELSE NEW.error_message END
+    );
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS webhook_status_changed;
+DROP TRIGGER IF EXISTS webhook_status_stored;
+DROP TRIGGER IF EXISTS webhook_status_check;
+DROP INDEX IF EXISTS idx_webhook_status_history_webhook;
+DROP TABLE IF EXISTS webhook_status_history;
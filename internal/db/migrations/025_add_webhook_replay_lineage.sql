@@ -0,0 +1,49 @@
+-- +goose Up
+-- Record who replayed a webhook and how often. Each replay's entry in the
+-- status history names who made it.
+
+ALTER TABLE webhooks ADD COLUMN replayed_by TEXT;
+ALTER TABLE webhooks ADD COLUMN replay_count INTEGER NOT NULL DEFAULT 0;
+UPDATE webhooks SET replay_count = 1 WHERE replayed_at IS NOT NULL;
+
+ALTER TABLE webhook_status_history ADD COLUMN changed_by TEXT;
+
+DROP TRIGGER IF EXISTS webhook_status_changed;
+
+-- +goose StatementBegin
+CREATE TRIGGER webhook_status_changed
+AFTER UPDATE OF status ON webhooks
+WHEN OLD.status != NEW.status OR NEW.replay_count != OLD.replay_count
+BEGIN
+    INSERT INTO webhook_status_history (webhook_id, from_status, to_status, reason, changed_by)
+    VALUES (
+        NEW.id,
+        OLD.status,
+        NEW.status,
+        CASE WHEN NEW.replay_count != OLD.replay_count THEN 'replayed' ELSE NEW.error_message END,
+        CASE WHEN NEW.replay_count != OLD.replay_count THEN NEW.replayed_by END
+    );
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS webhook_status_changed;
+
+-- +goose StatementBegin
+CREATE TRIGGER webhook_status_changed
+AFTER UPDATE OF status ON webhooks
+WHEN OLD.status != NEW.status OR NEW.replayed_at IS NOT OLD.replayed_at
+BEGIN
+    INSERT INTO webhook_status_history (webhook_id, from_status, to_status, reason)
+    VALUES (
+        NEW.id,
+        OLD.status,
+        NEW.status,
+        CASE WHEN NEW.replayed_at IS NOT OLD.replayed_at THEN 'replayed' ELSE NEW.error_message END
+    );
+END;
+-- +goose StatementEnd
+
+ALTER TABLE webhook_status_history DROP COLUMN changed_by;
+ALTER TABLE webhooks DROP COLUMN replay_count;
+ALTER TABLE webhooks DROP COLUMN replayed_by;
@@ -0,0 +1,92 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- Add the 'slack' provider type.
+
+-- SQLite can't alter CHECK constraints, so we recreate the table. Dropping
+-- the old table would cascade-delete every webhook unless foreign keys are
+-- off, and that can't be changed inside goose's transaction.
+PRAGMA foreign_keys = OFF;
+BEGIN;
+
+CREATE TABLE endpoints_new (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    provider_type TEXT NOT NULL CHECK (provider_type IN ('stripe', 'github', 'telegram', 'slack', 'generic', 'custom')),
+    signature_secret_encrypted BLOB,
+    verification_config_encrypted BLOB,
+    destination_url TEXT NOT NULL,
+    muted INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now')),
+    notify_first_event INTEGER NOT NULL DEFAULT 0,
+    first_event_at TEXT,
+    telegram_bot_token_encrypted BLOB,
+    slo_target REAL NOT NULL DEFAULT 0,
+    slo_latency_seconds INTEGER NOT NULL DEFAULT 60,
+    slo_window_hours INTEGER NOT NULL DEFAULT 24,
+    slo_breached_at TEXT,
+    reject_duplicates INTEGER NOT NULL DEFAULT 0,
+    home_region TEXT NOT NULL DEFAULT '',
+    ingest_auth_encrypted BLOB,
+    honeypot INTEGER NOT NULL DEFAULT 0,
+    last_webhook_received_at TEXT,
+    last_delivered_at TEXT,
+    archived_at TEXT
+);
+
+INSERT INTO endpoints_new SELECT * FROM endpoints;
+
+DROP TABLE endpoints;
+ALTER TABLE endpoints_new RENAME TO endpoints;
+
+CREATE INDEX idx_endpoints_user_id ON endpoints(user_id);
+CREATE INDEX idx_endpoints_user_created ON endpoints(user_id, created_at DESC);
+
+COMMIT;
+PRAGMA foreign_keys = ON;
+
+-- +goose Down
+PRAGMA foreign_keys = OFF;
+BEGIN;
+
+-- Slack endpoints become generic; their signatures will fail verification
+UPDATE endpoints SET provider_type = 'generic' WHERE provider_type = 'slack';
+
+CREATE TABLE endpoints_new (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    provider_type TEXT NOT NULL CHECK (provider_type IN ('stripe', 'github', 'telegram', 'generic', 'custom')),
+    signature_secret_encrypted BLOB,
+    verification_config_encrypted BLOB,
+    destination_url TEXT NOT NULL,
+    muted INTEGER NOT NULL DEFAULT 0,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now')),
+    notify_first_event INTEGER NOT NULL DEFAULT 0,
+    first_event_at TEXT,
+    telegram_bot_token_encrypted BLOB,
+    slo_target REAL NOT NULL DEFAULT 0,
+    slo_latency_seconds INTEGER NOT NULL DEFAULT 60,
+    slo_window_hours INTEGER NOT NULL DEFAULT 24,
+    slo_breached_at TEXT,
+    reject_duplicates INTEGER NOT NULL DEFAULT 0,
+    home_region TEXT NOT NULL DEFAULT '',
+    ingest_auth_encrypted BLOB,
+    honeypot INTEGER NOT NULL DEFAULT 0,
+    last_webhook_received_at TEXT,
+    last_delivered_at TEXT,
+    archived_at TEXT
+);
+
+INSERT INTO endpoints_new SELECT * FROM endpoints;
+
+DROP TABLE endpoints;
+ALTER TABLE endpoints_new RENAME TO endpoints;
+
+CREATE INDEX idx_endpoints_user_id ON endpoints(user_id);
+CREATE INDEX idx_endpoints_user_created ON endpoints(user_id, created_at DESC);
+
+COMMIT;
+PRAGMA foreign_keys = ON;
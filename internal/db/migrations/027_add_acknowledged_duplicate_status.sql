@@ -0,0 +1,186 @@
+-- +goose NO TRANSACTION
+-- +goose Up
+-- Add the 'acknowledged_duplicate' webhook status, for webhooks the
+-- destination answered with 409 Conflict because it had already processed
+-- them, on endpoints that opt in with conflict_as_duplicate.
+
+ALTER TABLE endpoints ADD COLUMN conflict_as_duplicate INTEGER NOT NULL DEFAULT 0;
+
+-- SQLite can't alter CHECK constraints, so we recreate the table, with
+-- foreign keys off so the status history isn't cascade-deleted (see 026),
+-- and the status triggers of migrations 024 and 025 with the new transitions.
+PRAGMA foreign_keys = OFF;
+BEGIN;
+
+CREATE TABLE webhooks_new (
+    id TEXT PRIMARY KEY,
+    endpoint_id TEXT NOT NULL,
+    received_at TEXT NOT NULL DEFAULT (datetime('now')),
+    headers TEXT NOT NULL,
+    payload BLOB NOT NULL,
+    signature_valid INTEGER NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed', 'dead_letter', 'skipped', 'acknowledged_duplicate')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_attempt_at TEXT,
+    delivered_at TEXT,
+    error_message TEXT,
+    notification_sent INTEGER NOT NULL DEFAULT 0,
+    replayed_at TEXT,
+    event_type TEXT,
+    delivery_id TEXT,
+    duplicate_of TEXT,
+    source_ip TEXT NOT NULL DEFAULT '',
+    replayed_by TEXT,
+    replay_count INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (endpoint_id) REFERENCES endpoints(id) ON DELETE CASCADE
+);
+
+INSERT INTO webhooks_new SELECT * FROM webhooks;
+
+-- Dropping the table drops its triggers too
+DROP TABLE webhooks;
+ALTER TABLE webhooks_new RENAME TO webhooks;
+
+CREATE INDEX idx_webhooks_endpoint_id ON webhooks(endpoint_id);
+CREATE INDEX idx_webhooks_status ON webhooks(status);
+CREATE INDEX idx_webhooks_received_at ON webhooks(received_at);
+CREATE INDEX idx_webhooks_status_received ON webhooks(status, received_at);
+CREATE INDEX idx_webhooks_replay_pending ON webhooks(endpoint_id, status) WHERE replayed_at IS NOT NULL;
+CREATE INDEX idx_webhooks_endpoint_event_type ON webhooks(endpoint_id, event_type);
+CREATE INDEX idx_webhooks_endpoint_delivery_id ON webhooks(endpoint_id, delivery_id) WHERE delivery_id IS NOT NULL;
+CREATE INDEX idx_webhooks_endpoint_status_received ON webhooks(endpoint_id, status, received_at);
+CREATE INDEX idx_webhooks_status_delivered ON webhooks(status, delivered_at);
+CREATE INDEX idx_webhooks_status_last_attempt ON webhooks(status, last_attempt_at);
+
+-- +goose StatementBegin
+CREATE TRIGGER webhook_status_check
+BEFORE UPDATE OF status ON webhooks
+WHEN OLD.status != NEW.status
+  AND OLD.status || '>' || NEW.status NOT IN (
+    'pending>delivered', 'pending>failed', 'pending>skipped', 'pending>dead_letter', 'pending>acknowledged_duplicate',
+    'delivered>pending', 'failed>pending', 'dead_letter>pending', 'skipped>pending',
+    'acknowledged_duplicate>pending'
+  )
+BEGIN
+    SELECT RAISE(ABORT, 'invalid webhook status transition');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER webhook_status_stored
+AFTER INSERT ON webhooks
+BEGIN
+    INSERT INTO webhook_status_history (webhook_id, from_status, to_status, changed_at)
+    VALUES (NEW.id, NULL, NEW.status, NEW.received_at);
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER webhook_status_changed
+AFTER UPDATE OF status ON webhooks
+WHEN OLD.status != NEW.status OR NEW.replay_count != OLD.replay_count
+BEGIN
+    INSERT INTO webhook_status_history (webhook_id, from_status, to_status, reason, changed_by)
+    VALUES (
+        NEW.id,
+        OLD.status,
+        NEW.status,
+        CASE WHEN NEW.replay_count != OLD.replay_count THEN 'replayed' ELSE NEW.error_message END,
+        CASE WHEN NEW.replay_count != OLD.replay_count THEN NEW.replayed_by END
+    );
+END;
+-- +goose StatementEnd
+
+COMMIT;
+PRAGMA foreign_keys = ON;
+
+-- +goose Down
+-- The destination has these webhooks; keep them as delivered
+DROP TRIGGER IF EXISTS webhook_status_check;
+UPDATE webhooks SET status = 'delivered' WHERE status = 'acknowledged_duplicate';
+
+PRAGMA foreign_keys = OFF;
+BEGIN;
+
+CREATE TABLE webhooks_new (
+    id TEXT PRIMARY KEY,
+    endpoint_id TEXT NOT NULL,
+    received_at TEXT NOT NULL DEFAULT (datetime('now')),
+    headers TEXT NOT NULL,
+    payload BLOB NOT NULL,
+    signature_valid INTEGER NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed', 'dead_letter', 'skipped')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_attempt_at TEXT,
+    delivered_at TEXT,
+    error_message TEXT,
+    notification_sent INTEGER NOT NULL DEFAULT 0,
+    replayed_at TEXT,
+    event_type TEXT,
+    delivery_id TEXT,
+    duplicate_of TEXT,
+    source_ip TEXT NOT NULL DEFAULT '',
+    replayed_by TEXT,
+    replay_count INTEGER NOT NULL DEFAULT 0,
+    FOREIGN KEY (endpoint_id) REFERENCES endpoints(id) ON DELETE CASCADE
+);
+
+INSERT INTO webhooks_new SELECT * FROM webhooks;
+
+-- Dropping the table drops its triggers too
+DROP TABLE webhooks;
+ALTER TABLE webhooks_new RENAME TO webhooks;
+
+CREATE INDEX idx_webhooks_endpoint_id ON webhooks(endpoint_id);
+CREATE INDEX idx_webhooks_status ON webhooks(status);
+CREATE INDEX idx_webhooks_received_at ON webhooks(received_at);
+CREATE INDEX idx_webhooks_status_received ON webhooks(status, received_at);
+CREATE INDEX idx_webhooks_replay_pending ON webhooks(endpoint_id, status) WHERE replayed_at IS NOT NULL;
+CREATE INDEX idx_webhooks_endpoint_event_type ON webhooks(endpoint_id, event_type);
+CREATE INDEX idx_webhooks_endpoint_delivery_id ON webhooks(endpoint_id, delivery_id) WHERE delivery_id IS NOT NULL;
+CREATE INDEX idx_webhooks_endpoint_status_received ON webhooks(endpoint_id, status, received_at);
+CREATE INDEX idx_webhooks_status_delivered ON webhooks(status, delivered_at);
+CREATE INDEX idx_webhooks_status_last_attempt ON webhooks(status, last_attempt_at);
+
+-- +goose StatementBegin
+CREATE TRIGGER webhook_status_check
+BEFORE UPDATE OF status ON webhooks
+WHEN OLD.status != NEW.status
+  AND OLD.status || '>' || NEW.status NOT IN (
+    'pending>delivered', 'pending>failed', 'pending>skipped', 'pending>dead_letter',
+    'delivered>pending', 'failed>pending', 'dead_letter>pending', 'skipped>pending'
+  )
+BEGIN
+    SELECT RAISE(ABORT, 'invalid webhook status transition');
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER webhook_status_stored
+AFTER INSERT ON webhooks
+BEGIN
+    INSERT INTO webhook_status_history (webhook_id, from_status, to_status, changed_at)
+    VALUES (NEW.id, NULL, NEW.status, NEW.received_at);
+END;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+CREATE TRIGGER webhook_status_changed
+AFTER UPDATE OF status ON webhooks
+WHEN OLD.status != NEW.status OR NEW.replay_count != OLD.replay_count
+BEGIN
+    INSERT INTO webhook_status_history (webhook_id, from_status, to_status, reason, changed_by)
+    VALUES (
+        NEW.id,
+        OLD.status,
+        NEW.status,
+        CASE WHEN NEW.replay_count != OLD.replay_count THEN 'replayed' ELSE NEW.error_message END,
+        CASE WHEN NEW.replay_count != OLD.replay_count THEN NEW.replayed_by END
+    );
+END;
+-- +goose StatementEnd
+
+COMMIT;
+PRAGMA foreign_keys = ON;
+
+ALTER TABLE endpoints DROP COLUMN conflict_as_duplicate;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Per-endpoint ingestion rate limit in requests per minute, overriding the
+-- edge's INGEST_RATE_LIMIT. 0 uses the edge's limit.
+
+ALTER TABLE endpoints ADD COLUMN rate_limit_per_minute INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE endpoints DROP COLUMN rate_limit_per_minute;
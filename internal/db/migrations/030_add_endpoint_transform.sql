@@ -0,0 +1,9 @@
+-- +goose Up
+-- Rules the hub applies to a webhook before forwarding it, as JSON: a path
+-- to extract, a body template and static headers. NULL forwards webhooks as
+-- received.
+
+ALTER TABLE endpoints ADD COLUMN transform TEXT;
+
+-- +goose Down
+ALTER TABLE endpoints DROP COLUMN transform;
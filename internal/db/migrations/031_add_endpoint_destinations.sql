@@ -0,0 +1,42 @@
+-- +goose Up
+-- Destinations an endpoint fans out to besides its destination_url, and the
+-- delivery of each webhook to each destination. The endpoint's own
+-- destination is recorded with destination_id ''. Migrations that recreate
+-- the webhooks table must create the trigger again.
+
+CREATE TABLE IF NOT EXISTS endpoint_destinations (
+    id TEXT PRIMARY KEY,
+    endpoint_id TEXT NOT NULL,
+    url TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    UNIQUE (endpoint_id, url),
+    FOREIGN KEY (endpoint_id) REFERENCES endpoints(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    webhook_id TEXT NOT NULL,
+    destination_id TEXT NOT NULL,
+    status TEXT NOT NULL CHECK (status IN ('pending', 'delivered', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    status_code INTEGER,
+    error_message TEXT,
+    last_attempt_at TEXT,
+    delivered_at TEXT,
+    PRIMARY KEY (webhook_id, destination_id),
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE
+);
+
+-- A replay delivers to every destination again
+-- +goose StatementBegin
+CREATE TRIGGER webhook_deliveries_replayed
+AFTER UPDATE OF replay_count ON webhooks
+WHEN NEW.replay_count != OLD.replay_count
+BEGIN
+    DELETE FROM webhook_deliveries WHERE webhook_id = NEW.id;
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP TRIGGER IF EXISTS webhook_deliveries_replayed;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TABLE IF EXISTS endpoint_destinations;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Answer HEAD probes and OPTIONS preflights at the ingestion URL with 204
+-- instead of 405. On by default: providers probe URLs before saving them.
+
+ALTER TABLE endpoints ADD COLUMN answer_probes INTEGER NOT NULL DEFAULT 1;
+
+-- +goose Down
+ALTER TABLE endpoints DROP COLUMN answer_probes;
@@ -0,0 +1,8 @@
+-- +goose Up
+-- Response ingestion sends once a webhook is stored, as JSON
+-- {"status_code", "body", "content_type"}. NULL answers 200 with no body.
+
+ALTER TABLE endpoints ADD COLUMN ingest_response TEXT;
+
+-- +goose Down
+ALTER TABLE endpoints DROP COLUMN ingest_response;
@@ -0,0 +1,26 @@
+-- +goose Up
+-- Per-endpoint retry policy for transient delivery failures. 0 keeps the
+-- default for each: no attempt limit, a 1s backoff base, a 1h max interval
+-- and no jitter.
+--
+-- The backoff was computed from attempts when fetching pending webhooks. It
+-- now depends on the policy and jitter, so the ack records when the webhook
+-- is next due instead; webhooks already waiting keep their retry time.
+
+ALTER TABLE endpoints ADD COLUMN retry_max_attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE endpoints ADD COLUMN retry_backoff_base_seconds INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE endpoints ADD COLUMN retry_max_interval_seconds INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE endpoints ADD COLUMN retry_jitter REAL NOT NULL DEFAULT 0;
+
+ALTER TABLE webhooks ADD COLUMN next_attempt_at TEXT;
+
+UPDATE webhooks
+SET next_attempt_at = datetime(last_attempt_at, '+' || MIN(1 << attempts, 3600) || ' seconds')
+WHERE status = 'pending' AND last_attempt_at IS NOT NULL;
+
+-- +goose Down
+ALTER TABLE webhooks DROP COLUMN next_attempt_at;
+ALTER TABLE endpoints DROP COLUMN retry_jitter;
+ALTER TABLE endpoints DROP COLUMN retry_max_interval_seconds;
+ALTER TABLE endpoints DROP COLUMN retry_backoff_base_seconds;
+ALTER TABLE endpoints DROP COLUMN retry_max_attempts;
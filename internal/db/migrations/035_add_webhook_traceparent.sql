@@ -0,0 +1,8 @@
+-- +goose Up
+-- Trace context of each webhook's ingestion span, so the spans of its
+-- delivery join the same trace. NULL for webhooks received before tracing.
+
+ALTER TABLE webhooks ADD COLUMN traceparent TEXT;
+
+-- +goose Down
+ALTER TABLE webhooks DROP COLUMN traceparent;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Cleanup purges webhooks past retention before deleting them: purged_at
+-- hides a webhook, and it is deleted once the grace period has passed.
+-- undeleted_at restarts the retention of a webhook restored within the
+-- grace period, so the next cleanup doesn't purge it again.
+
+ALTER TABLE webhooks ADD COLUMN purged_at TEXT;
+ALTER TABLE webhooks ADD COLUMN undeleted_at TEXT;
+
+CREATE INDEX idx_webhooks_purged ON webhooks(purged_at) WHERE purged_at IS NOT NULL;
+
+-- +goose Down
+DROP INDEX idx_webhooks_purged;
+ALTER TABLE webhooks DROP COLUMN undeleted_at;
+ALTER TABLE webhooks DROP COLUMN purged_at;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Per-endpoint payload limits: the largest payload accepted, in bytes (0 uses
+-- the edge's 100 MB limit), and the content types accepted, as a
+-- comma-separated list of media types ('' accepts any).
+
+ALTER TABLE endpoints ADD COLUMN max_payload_bytes INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE endpoints ADD COLUMN allowed_content_types TEXT NOT NULL DEFAULT '';
+
+-- +goose Down
+ALTER TABLE endpoints DROP COLUMN allowed_content_types;
+ALTER TABLE endpoints DROP COLUMN max_payload_bytes;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Per-user Discord notifications: the channel webhook URL, encrypted like the
+-- Telegram bot token since it holds the webhook's token.
+
+ALTER TABLE user_settings ADD COLUMN discord_webhook_url_encrypted BLOB;
+ALTER TABLE user_settings ADD COLUMN discord_enabled INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE user_settings DROP COLUMN discord_enabled;
+ALTER TABLE user_settings DROP COLUMN discord_webhook_url_encrypted;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Per-user email notifications, sent through the edge's SMTP server.
+
+ALTER TABLE user_settings ADD COLUMN email_address TEXT;
+ALTER TABLE user_settings ADD COLUMN email_enabled INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE user_settings DROP COLUMN email_enabled;
+ALTER TABLE user_settings DROP COLUMN email_address;
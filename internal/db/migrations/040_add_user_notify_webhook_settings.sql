@@ -0,0 +1,12 @@
+-- +goose Up
+-- Per-user notification webhooks: JSON events POSTed to a URL of the user's,
+-- signed with their secret. Both are encrypted like endpoint secrets.
+
+ALTER TABLE user_settings ADD COLUMN notify_webhook_url_encrypted BLOB;
+ALTER TABLE user_settings ADD COLUMN notify_webhook_secret_encrypted BLOB;
+ALTER TABLE user_settings ADD COLUMN notify_webhook_enabled INTEGER NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE user_settings DROP COLUMN notify_webhook_enabled;
+ALTER TABLE user_settings DROP COLUMN notify_webhook_secret_encrypted;
+ALTER TABLE user_settings DROP COLUMN notify_webhook_url_encrypted;
@@ -0,0 +1,21 @@
+-- +goose Up
+-- Per-endpoint history of hubs connecting and disconnecting, so users can see
+-- when webhooks started queuing.
+
+CREATE TABLE IF NOT EXISTS connection_events (
+    id TEXT PRIMARY KEY,
+    endpoint_id TEXT NOT NULL,
+    hub_id TEXT NOT NULL,
+    event TEXT NOT NULL CHECK (event IN ('connected', 'disconnected')),
+    transport TEXT NOT NULL DEFAULT '',
+    occurred_at TEXT NOT NULL DEFAULT (datetime('now')),
+    FOREIGN KEY (endpoint_id) REFERENCES endpoints(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_connection_events_endpoint_occurred ON connection_events(endpoint_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_connection_events_occurred ON connection_events(occurred_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_connection_events_occurred;
+DROP INDEX IF EXISTS idx_connection_events_endpoint_occurred;
+DROP TABLE IF EXISTS connection_events;
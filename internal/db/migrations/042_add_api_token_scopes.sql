@@ -0,0 +1,10 @@
+-- +goose Up
+-- Token scopes limit what an API token can call, and tokens can expire.
+-- Existing tokens keep full access and never expire.
+
+ALTER TABLE api_tokens ADD COLUMN scope TEXT NOT NULL DEFAULT 'admin' CHECK (scope IN ('admin', 'read', 'relay'));
+ALTER TABLE api_tokens ADD COLUMN expires_at TEXT;
+
+-- +goose Down
+ALTER TABLE api_tokens DROP COLUMN expires_at;
+ALTER TABLE api_tokens DROP COLUMN scope;
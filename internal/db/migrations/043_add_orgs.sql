@@ -0,0 +1,28 @@
+-- +goose Up
+-- Organizations share endpoints between users. An org's endpoints are stored
+-- with user_id 'org:' || id, so every query scoped to an owner works for orgs
+-- unchanged; org_members says who may act as that owner.
+
+CREATE TABLE IF NOT EXISTS orgs (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_by TEXT NOT NULL,  -- GitHub user ID
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE TABLE IF NOT EXISTS org_members (
+    org_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,  -- GitHub user ID
+    username TEXT NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('owner', 'member', 'viewer')),
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (org_id, user_id),
+    FOREIGN KEY (org_id) REFERENCES orgs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_members_user_id ON org_members(user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_org_members_user_id;
+DROP TABLE IF EXISTS org_members;
+DROP TABLE IF EXISTS orgs;
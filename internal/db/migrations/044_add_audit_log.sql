@@ -0,0 +1,27 @@
+-- +goose Up
+-- Management actions (API calls that change something, logins, token
+-- revocations) with who made them and from where, for security reviews.
+-- owner_id is whose endpoints were acted on: the user, or an org's owner ID.
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,  -- GitHub user ID of who acted
+    username TEXT NOT NULL,
+    owner_id TEXT NOT NULL,
+    token_id TEXT,  -- API token used, NULL for web sessions
+    action TEXT NOT NULL,  -- e.g. CreateEndpoint, Login
+    target TEXT NOT NULL DEFAULT '',  -- ID of the endpoint, webhook, token or org acted on
+    ip TEXT NOT NULL DEFAULT '',
+    result TEXT NOT NULL DEFAULT 'ok',  -- ok, or why it failed, e.g. permission_denied
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_owner_created ON audit_log(owner_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_user_created ON audit_log(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created ON audit_log(created_at);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_audit_log_created;
+DROP INDEX IF EXISTS idx_audit_log_user_created;
+DROP INDEX IF EXISTS idx_audit_log_owner_created;
+DROP TABLE IF EXISTS audit_log;
@@ -0,0 +1,10 @@
+-- +goose Up
+-- Cancelled replays were marked failed without a last attempt, which failed
+-- webhooks' retention counts from, so they were never purged. Count theirs
+-- from the replay.
+
+UPDATE webhooks SET last_attempt_at = COALESCE(replayed_at, received_at)
+WHERE status = 'failed' AND last_attempt_at IS NULL;
+
+-- +goose Down
+-- Nothing to undo: the backfilled times stay valid.
@@ -8,6 +8,17 @@ import (
 	"database/sql"
 )
 
+type ActivityEvent struct {
+	ID         string         `json:"id"`
+	UserID     string         `json:"user_id"`
+	Kind       string         `json:"kind"`
+	EndpointID sql.NullString `json:"endpoint_id"`
+	HubID      sql.NullString `json:"hub_id"`
+	Count      int64          `json:"count"`
+	OccurredAt string         `json:"occurred_at"`
+	UpdatedAt  string         `json:"updated_at"`
+}
+
 type ApiToken struct {
 	ID         string         `json:"id"`
 	UserID     string         `json:"user_id"`
@@ -17,19 +28,111 @@ type ApiToken struct {
 	CreatedAt  string         `json:"created_at"`
 	LastUsedAt sql.NullString `json:"last_used_at"`
 	Revoked    int64          `json:"revoked"`
+	Scope      string         `json:"scope"`
+	ExpiresAt  sql.NullString `json:"expires_at"`
+}
+
+type AuditLog struct {
+	ID        string         `json:"id"`
+	UserID    string         `json:"user_id"`
+	Username  string         `json:"username"`
+	OwnerID   string         `json:"owner_id"`
+	TokenID   sql.NullString `json:"token_id"`
+	Action    string         `json:"action"`
+	Target    string         `json:"target"`
+	Ip        string         `json:"ip"`
+	Result    string         `json:"result"`
+	CreatedAt string         `json:"created_at"`
+}
+
+type ConnectionEvent struct {
+	ID         string `json:"id"`
+	EndpointID string `json:"endpoint_id"`
+	HubID      string `json:"hub_id"`
+	Event      string `json:"event"`
+	Transport  string `json:"transport"`
+	OccurredAt string `json:"occurred_at"`
 }
 
 type Endpoint struct {
-	ID                          string `json:"id"`
-	UserID                      string `json:"user_id"`
-	Name                        string `json:"name"`
-	ProviderType                string `json:"provider_type"`
-	SignatureSecretEncrypted    []byte `json:"signature_secret_encrypted"`
-	VerificationConfigEncrypted []byte `json:"verification_config_encrypted"`
-	DestinationUrl              string `json:"destination_url"`
-	Muted                       int64  `json:"muted"`
-	CreatedAt                   string `json:"created_at"`
-	UpdatedAt                   string `json:"updated_at"`
+	ID                          string         `json:"id"`
+	UserID                      string         `json:"user_id"`
+	Name                        string         `json:"name"`
+	ProviderType                string         `json:"provider_type"`
+	SignatureSecretEncrypted    []byte         `json:"signature_secret_encrypted"`
+	VerificationConfigEncrypted []byte         `json:"verification_config_encrypted"`
+	DestinationUrl              string         `json:"destination_url"`
+	Muted                       int64          `json:"muted"`
+	CreatedAt                   string         `json:"created_at"`
+	UpdatedAt                   string         `json:"updated_at"`
+	NotifyFirstEvent            int64          `json:"notify_first_event"`
+	FirstEventAt                sql.NullString `json:"first_event_at"`
+	TelegramBotTokenEncrypted   []byte         `json:"telegram_bot_token_encrypted"`
+	SloTarget                   float64        `json:"slo_target"`
+	SloLatencySeconds           int64          `json:"slo_latency_seconds"`
+	SloWindowHours              int64          `json:"slo_window_hours"`
+	SloBreachedAt               sql.NullString `json:"slo_breached_at"`
+	RejectDuplicates            int64          `json:"reject_duplicates"`
+	HomeRegion                  string         `json:"home_region"`
+	IngestAuthEncrypted         []byte         `json:"ingest_auth_encrypted"`
+	Honeypot                    int64          `json:"honeypot"`
+	LastWebhookReceivedAt       sql.NullString `json:"last_webhook_received_at"`
+	LastDeliveredAt             sql.NullString `json:"last_delivered_at"`
+	ArchivedAt                  sql.NullString `json:"archived_at"`
+	ConflictAsDuplicate         int64          `json:"conflict_as_duplicate"`
+	RateLimitPerMinute          int64          `json:"rate_limit_per_minute"`
+	Transform                   sql.NullString `json:"transform"`
+	AnswerProbes                int64          `json:"answer_probes"`
+	IngestResponse              sql.NullString `json:"ingest_response"`
+	RetryMaxAttempts            int64          `json:"retry_max_attempts"`
+	RetryBackoffBaseSeconds     int64          `json:"retry_backoff_base_seconds"`
+	RetryMaxIntervalSeconds     int64          `json:"retry_max_interval_seconds"`
+	RetryJitter                 float64        `json:"retry_jitter"`
+	MaxPayloadBytes             int64          `json:"max_payload_bytes"`
+	AllowedContentTypes         string         `json:"allowed_content_types"`
+}
+
+type EndpointDestination struct {
+	ID         string `json:"id"`
+	EndpointID string `json:"endpoint_id"`
+	Url        string `json:"url"`
+	CreatedAt  string `json:"created_at"`
+}
+
+type Job struct {
+	ID        string         `json:"id"`
+	Kind      string         `json:"kind"`
+	Payload   string         `json:"payload"`
+	Status    string         `json:"status"`
+	Attempts  int64          `json:"attempts"`
+	LastError sql.NullString `json:"last_error"`
+	RunAt     string         `json:"run_at"`
+	CreatedAt string         `json:"created_at"`
+	UpdatedAt string         `json:"updated_at"`
+}
+
+type Org struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	CreatedBy string `json:"created_by"`
+	CreatedAt string `json:"created_at"`
+}
+
+type OrgMember struct {
+	OrgID     string `json:"org_id"`
+	UserID    string `json:"user_id"`
+	Username  string `json:"username"`
+	Role      string `json:"role"`
+	CreatedAt string `json:"created_at"`
+}
+
+type SecretReveal struct {
+	ID         string `json:"id"`
+	UserID     string `json:"user_id"`
+	EndpointID string `json:"endpoint_id"`
+	IpAddress  string `json:"ip_address"`
+	UserAgent  string `json:"user_agent"`
+	RevealedAt string `json:"revealed_at"`
 }
 
 type Session struct {
@@ -42,19 +145,26 @@ type Session struct {
 }
 
 type UserSetting struct {
-	UserID                    string         `json:"user_id"`
-	Username                  string         `json:"username"`
-	GithubName                sql.NullString `json:"github_name"`
-	GithubEmail               sql.NullString `json:"github_email"`
-	GithubProfileUrl          sql.NullString `json:"github_profile_url"`
-	AvatarUrl                 sql.NullString `json:"avatar_url"`
-	TelegramBotTokenEncrypted []byte         `json:"telegram_bot_token_encrypted"`
-	TelegramChatID            sql.NullString `json:"telegram_chat_id"`
-	TelegramEnabled           int64          `json:"telegram_enabled"`
-	ThemePreference           string         `json:"theme_preference"`
-	CreatedAt                 string         `json:"created_at"`
-	UpdatedAt                 string         `json:"updated_at"`
-	LastLoginAt               string         `json:"last_login_at"`
+	UserID                       string         `json:"user_id"`
+	Username                     string         `json:"username"`
+	GithubName                   sql.NullString `json:"github_name"`
+	GithubEmail                  sql.NullString `json:"github_email"`
+	GithubProfileUrl             sql.NullString `json:"github_profile_url"`
+	AvatarUrl                    sql.NullString `json:"avatar_url"`
+	TelegramBotTokenEncrypted    []byte         `json:"telegram_bot_token_encrypted"`
+	TelegramChatID               sql.NullString `json:"telegram_chat_id"`
+	TelegramEnabled              int64          `json:"telegram_enabled"`
+	ThemePreference              string         `json:"theme_preference"`
+	CreatedAt                    string         `json:"created_at"`
+	UpdatedAt                    string         `json:"updated_at"`
+	LastLoginAt                  string         `json:"last_login_at"`
+	DiscordWebhookUrlEncrypted   []byte         `json:"discord_webhook_url_encrypted"`
+	DiscordEnabled               int64          `json:"discord_enabled"`
+	EmailAddress                 sql.NullString `json:"email_address"`
+	EmailEnabled                 int64          `json:"email_enabled"`
+	NotifyWebhookUrlEncrypted    []byte         `json:"notify_webhook_url_encrypted"`
+	NotifyWebhookSecretEncrypted []byte         `json:"notify_webhook_secret_encrypted"`
+	NotifyWebhookEnabled         int64          `json:"notify_webhook_enabled"`
 }
 
 type Webhook struct {
@@ -70,4 +180,36 @@ type Webhook struct {
 	DeliveredAt      sql.NullString `json:"delivered_at"`
 	ErrorMessage     sql.NullString `json:"error_message"`
 	NotificationSent int64          `json:"notification_sent"`
+	ReplayedAt       sql.NullString `json:"replayed_at"`
+	EventType        sql.NullString `json:"event_type"`
+	DeliveryID       sql.NullString `json:"delivery_id"`
+	DuplicateOf      sql.NullString `json:"duplicate_of"`
+	SourceIp         string         `json:"source_ip"`
+	ReplayedBy       sql.NullString `json:"replayed_by"`
+	ReplayCount      int64          `json:"replay_count"`
+	NextAttemptAt    sql.NullString `json:"next_attempt_at"`
+	Traceparent      sql.NullString `json:"traceparent"`
+	PurgedAt         sql.NullString `json:"purged_at"`
+	UndeletedAt      sql.NullString `json:"undeleted_at"`
+}
+
+type WebhookDelivery struct {
+	WebhookID     string         `json:"webhook_id"`
+	DestinationID string         `json:"destination_id"`
+	Status        string         `json:"status"`
+	Attempts      int64          `json:"attempts"`
+	StatusCode    sql.NullInt64  `json:"status_code"`
+	ErrorMessage  sql.NullString `json:"error_message"`
+	LastAttemptAt sql.NullString `json:"last_attempt_at"`
+	DeliveredAt   sql.NullString `json:"delivered_at"`
+}
+
+type WebhookStatusHistory struct {
+	ID         int64          `json:"id"`
+	WebhookID  string         `json:"webhook_id"`
+	FromStatus sql.NullString `json:"from_status"`
+	ToStatus   string         `json:"to_status"`
+	Reason     sql.NullString `json:"reason"`
+	ChangedAt  string         `json:"changed_at"`
+	ChangedBy  sql.NullString `json:"changed_by"`
 }
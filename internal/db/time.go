@@ -0,0 +1,58 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// TimeLayout is how timestamps are stored: the format of SQLite's
+// datetime('now'), always UTC. Queries compare timestamps as text against
+// datetime(), so values written from Go must use it too, see FormatTime.
+const TimeLayout = "2006-01-02 15:04:05"
+
+// parseLayouts are the formats ParseTime accepts: the stored format, with
+// fractional seconds from datetime('now', 'subsec'), and RFC3339 as SQLite's
+// date functions also accept it. Layouts without a zone are UTC.
+var parseLayouts = []string{
+	TimeLayout,
+	"2006-01-02 15:04:05.999999999",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+}
+
+// ParseTime parses a stored timestamp as UTC.
+func ParseTime(s string) (time.Time, error) {
+	for _, layout := range parseLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.UTC); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+}
+
+// ParseNullTime parses a nullable stored timestamp. It reports false if the
+// value is NULL or invalid.
+func ParseNullTime(s sql.NullString) (time.Time, bool) {
+	if !s.Valid {
+		return time.Time{}, false
+	}
+	t, err := ParseTime(s.String)
+	return t, err == nil
+}
+
+// FormatTime formats t in UTC for comparison with stored timestamps.
+func FormatTime(t time.Time) string {
+	return t.UTC().Format(TimeLayout)
+}
+
+// RFC3339 reformats a stored timestamp as RFC3339 in UTC, for output where
+// readers shouldn't have to know its zone. Invalid values are returned as
+// they are.
+func RFC3339(s string) string {
+	t, err := ParseTime(s)
+	if err != nil {
+		return s
+	}
+	return t.Format(time.RFC3339)
+}
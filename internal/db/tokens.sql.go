@@ -7,20 +7,23 @@ package db
 
 import (
 	"context"
+	"database/sql"
 )
 
 const createAPIToken = `-- name: CreateAPIToken :one
-INSERT INTO api_tokens (id, user_id, username, token_hash, name)
-VALUES (?, ?, ?, ?, ?)
-RETURNING id, user_id, username, token_hash, name, created_at, last_used_at, revoked
+INSERT INTO api_tokens (id, user_id, username, token_hash, name, scope, expires_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
+RETURNING id, user_id, username, token_hash, name, created_at, last_used_at, revoked, scope, expires_at
 `
 
 type CreateAPITokenParams struct {
-	ID        string `json:"id"`
-	UserID    string `json:"user_id"`
-	Username  string `json:"username"`
-	TokenHash string `json:"token_hash"`
-	Name      string `json:"name"`
+	ID        string         `json:"id"`
+	UserID    string         `json:"user_id"`
+	Username  string         `json:"username"`
+	TokenHash string         `json:"token_hash"`
+	Name      string         `json:"name"`
+	Scope     string         `json:"scope"`
+	ExpiresAt sql.NullString `json:"expires_at"`
 }
 
 func (q *Queries) CreateAPIToken(ctx context.Context, arg CreateAPITokenParams) (ApiToken, error) {
@@ -30,6 +33,8 @@ func (q *Queries) CreateAPIToken(ctx context.Context, arg CreateAPITokenParams)
 		arg.Username,
 		arg.TokenHash,
 		arg.Name,
+		arg.Scope,
+		arg.ExpiresAt,
 	)
 	var i ApiToken
 	err := row.Scan(
@@ -41,14 +46,16 @@ func (q *Queries) CreateAPIToken(ctx context.Context, arg CreateAPITokenParams)
 		&i.CreatedAt,
 		&i.LastUsedAt,
 		&i.Revoked,
+		&i.Scope,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
 
 const deleteRevokedAPITokens = `-- name: DeleteRevokedAPITokens :execrows
 DELETE FROM api_tokens
-WHERE revoked = 1
-  AND (last_used_at IS NULL OR last_used_at < datetime('now', '-30 days'))
+WHERE (revoked = 1 AND (last_used_at IS NULL OR last_used_at < datetime('now', '-30 days')))
+   OR expires_at < datetime('now', '-30 days')
 `
 
 func (q *Queries) DeleteRevokedAPITokens(ctx context.Context) (int64, error) {
@@ -59,8 +66,37 @@ func (q *Queries) DeleteRevokedAPITokens(ctx context.Context) (int64, error) {
 	return result.RowsAffected()
 }
 
+const getAPIToken = `-- name: GetAPIToken :one
+SELECT id, user_id, username, token_hash, name, created_at, last_used_at, revoked, scope, expires_at FROM api_tokens
+WHERE id = ?
+  AND user_id = ?
+`
+
+type GetAPITokenParams struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+}
+
+func (q *Queries) GetAPIToken(ctx context.Context, arg GetAPITokenParams) (ApiToken, error) {
+	row := q.db.QueryRowContext(ctx, getAPIToken, arg.ID, arg.UserID)
+	var i ApiToken
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Username,
+		&i.TokenHash,
+		&i.Name,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.Revoked,
+		&i.Scope,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
 const getAPITokenByHash = `-- name: GetAPITokenByHash :one
-SELECT id, user_id, username, token_hash, name, created_at, last_used_at, revoked FROM api_tokens
+SELECT id, user_id, username, token_hash, name, created_at, last_used_at, revoked, scope, expires_at FROM api_tokens
 WHERE token_hash = ?
   AND revoked = 0
 `
@@ -77,12 +113,14 @@ func (q *Queries) GetAPITokenByHash(ctx context.Context, tokenHash string) (ApiT
 		&i.CreatedAt,
 		&i.LastUsedAt,
 		&i.Revoked,
+		&i.Scope,
+		&i.ExpiresAt,
 	)
 	return i, err
 }
 
 const getAPITokensByUser = `-- name: GetAPITokensByUser :many
-SELECT id, user_id, username, token_hash, name, created_at, last_used_at, revoked FROM api_tokens
+SELECT id, user_id, username, token_hash, name, created_at, last_used_at, revoked, scope, expires_at FROM api_tokens
 WHERE user_id = ?
 ORDER BY created_at DESC
 `
@@ -105,6 +143,8 @@ func (q *Queries) GetAPITokensByUser(ctx context.Context, userID string) ([]ApiT
 			&i.CreatedAt,
 			&i.LastUsedAt,
 			&i.Revoked,
+			&i.Scope,
+			&i.ExpiresAt,
 		); err != nil {
 			return nil, err
 		}
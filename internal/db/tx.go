@@ -0,0 +1,36 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// WithTx runs fn with queries in one transaction, committed if fn returns nil
+// and rolled back otherwise. Queries already in a transaction run fn in it.
+// fn must only use the queries it is given: the writer has one connection,
+// which the transaction holds.
+func WithTx(ctx context.Context, queries *Queries, fn func(*Queries) error) error {
+	switch conn := queries.db.(type) {
+	case *sql.Tx:
+		return fn(queries)
+	case *sql.DB:
+		tx, err := conn.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("begin transaction: %w", err)
+		}
+		if err := fn(queries.WithTx(tx)); err != nil {
+			if rbErr := tx.Rollback(); rbErr != nil {
+				return errors.Join(err, fmt.Errorf("roll back: %w", rbErr))
+			}
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return fmt.Errorf("commit: %w", err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("queries on a %T can't start a transaction", conn)
+	}
+}
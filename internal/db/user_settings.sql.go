@@ -32,39 +32,60 @@ func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
 	return count, err
 }
 
-const getEndpointOwnerTelegramConfig = `-- name: GetEndpointOwnerTelegramConfig :one
+const getEndpointOwnerNotificationConfig = `-- name: GetEndpointOwnerNotificationConfig :one
 SELECT
     us.user_id,
     us.telegram_bot_token_encrypted,
     us.telegram_chat_id,
-    us.telegram_enabled
+    us.telegram_enabled,
+    us.discord_webhook_url_encrypted,
+    us.discord_enabled,
+    us.email_address,
+    us.email_enabled,
+    us.notify_webhook_url_encrypted,
+    us.notify_webhook_secret_encrypted,
+    us.notify_webhook_enabled
 FROM endpoints e
 JOIN user_settings us ON e.user_id = us.user_id
 WHERE e.id = ?
 `
 
-type GetEndpointOwnerTelegramConfigRow struct {
-	UserID                    string         `json:"user_id"`
-	TelegramBotTokenEncrypted []byte         `json:"telegram_bot_token_encrypted"`
-	TelegramChatID            sql.NullString `json:"telegram_chat_id"`
-	TelegramEnabled           int64          `json:"telegram_enabled"`
+type GetEndpointOwnerNotificationConfigRow struct {
+	UserID                       string         `json:"user_id"`
+	TelegramBotTokenEncrypted    []byte         `json:"telegram_bot_token_encrypted"`
+	TelegramChatID               sql.NullString `json:"telegram_chat_id"`
+	TelegramEnabled              int64          `json:"telegram_enabled"`
+	DiscordWebhookUrlEncrypted   []byte         `json:"discord_webhook_url_encrypted"`
+	DiscordEnabled               int64          `json:"discord_enabled"`
+	EmailAddress                 sql.NullString `json:"email_address"`
+	EmailEnabled                 int64          `json:"email_enabled"`
+	NotifyWebhookUrlEncrypted    []byte         `json:"notify_webhook_url_encrypted"`
+	NotifyWebhookSecretEncrypted []byte         `json:"notify_webhook_secret_encrypted"`
+	NotifyWebhookEnabled         int64          `json:"notify_webhook_enabled"`
 }
 
-// Get the endpoint owner's Telegram configuration for sending notifications
-func (q *Queries) GetEndpointOwnerTelegramConfig(ctx context.Context, id string) (GetEndpointOwnerTelegramConfigRow, error) {
-	row := q.db.QueryRowContext(ctx, getEndpointOwnerTelegramConfig, id)
-	var i GetEndpointOwnerTelegramConfigRow
+// Get the endpoint owner's Telegram, Discord, email and webhook configuration for sending notifications
+func (q *Queries) GetEndpointOwnerNotificationConfig(ctx context.Context, id string) (GetEndpointOwnerNotificationConfigRow, error) {
+	row := q.db.QueryRowContext(ctx, getEndpointOwnerNotificationConfig, id)
+	var i GetEndpointOwnerNotificationConfigRow
 	err := row.Scan(
 		&i.UserID,
 		&i.TelegramBotTokenEncrypted,
 		&i.TelegramChatID,
 		&i.TelegramEnabled,
+		&i.DiscordWebhookUrlEncrypted,
+		&i.DiscordEnabled,
+		&i.EmailAddress,
+		&i.EmailEnabled,
+		&i.NotifyWebhookUrlEncrypted,
+		&i.NotifyWebhookSecretEncrypted,
+		&i.NotifyWebhookEnabled,
 	)
 	return i, err
 }
 
 const getUserSettings = `-- name: GetUserSettings :one
-SELECT user_id, username, github_name, github_email, github_profile_url, avatar_url, telegram_bot_token_encrypted, telegram_chat_id, telegram_enabled, theme_preference, created_at, updated_at, last_login_at FROM user_settings WHERE user_id = ?
+SELECT user_id, username, github_name, github_email, github_profile_url, avatar_url, telegram_bot_token_encrypted, telegram_chat_id, telegram_enabled, theme_preference, created_at, updated_at, last_login_at, discord_webhook_url_encrypted, discord_enabled, email_address, email_enabled, notify_webhook_url_encrypted, notify_webhook_secret_encrypted, notify_webhook_enabled FROM user_settings WHERE user_id = ?
 `
 
 func (q *Queries) GetUserSettings(ctx context.Context, userID string) (UserSetting, error) {
@@ -84,12 +105,19 @@ func (q *Queries) GetUserSettings(ctx context.Context, userID string) (UserSetti
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.DiscordWebhookUrlEncrypted,
+		&i.DiscordEnabled,
+		&i.EmailAddress,
+		&i.EmailEnabled,
+		&i.NotifyWebhookUrlEncrypted,
+		&i.NotifyWebhookSecretEncrypted,
+		&i.NotifyWebhookEnabled,
 	)
 	return i, err
 }
 
 const getUserSettingsByUsername = `-- name: GetUserSettingsByUsername :one
-SELECT user_id, username, github_name, github_email, github_profile_url, avatar_url, telegram_bot_token_encrypted, telegram_chat_id, telegram_enabled, theme_preference, created_at, updated_at, last_login_at FROM user_settings WHERE username = ?
+SELECT user_id, username, github_name, github_email, github_profile_url, avatar_url, telegram_bot_token_encrypted, telegram_chat_id, telegram_enabled, theme_preference, created_at, updated_at, last_login_at, discord_webhook_url_encrypted, discord_enabled, email_address, email_enabled, notify_webhook_url_encrypted, notify_webhook_secret_encrypted, notify_webhook_enabled FROM user_settings WHERE username = ?
 `
 
 func (q *Queries) GetUserSettingsByUsername(ctx context.Context, username string) (UserSetting, error) {
@@ -109,6 +137,149 @@ func (q *Queries) GetUserSettingsByUsername(ctx context.Context, username string
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.DiscordWebhookUrlEncrypted,
+		&i.DiscordEnabled,
+		&i.EmailAddress,
+		&i.EmailEnabled,
+		&i.NotifyWebhookUrlEncrypted,
+		&i.NotifyWebhookSecretEncrypted,
+		&i.NotifyWebhookEnabled,
+	)
+	return i, err
+}
+
+const updateUserDiscordSettings = `-- name: UpdateUserDiscordSettings :one
+UPDATE user_settings
+SET discord_webhook_url_encrypted = ?,
+    discord_enabled = ?,
+    updated_at = datetime('now')
+WHERE user_id = ?
+RETURNING user_id, username, github_name, github_email, github_profile_url, avatar_url, telegram_bot_token_encrypted, telegram_chat_id, telegram_enabled, theme_preference, created_at, updated_at, last_login_at, discord_webhook_url_encrypted, discord_enabled, email_address, email_enabled, notify_webhook_url_encrypted, notify_webhook_secret_encrypted, notify_webhook_enabled
+`
+
+type UpdateUserDiscordSettingsParams struct {
+	DiscordWebhookUrlEncrypted []byte `json:"discord_webhook_url_encrypted"`
+	DiscordEnabled             int64  `json:"discord_enabled"`
+	UserID                     string `json:"user_id"`
+}
+
+func (q *Queries) UpdateUserDiscordSettings(ctx context.Context, arg UpdateUserDiscordSettingsParams) (UserSetting, error) {
+	row := q.db.QueryRowContext(ctx, updateUserDiscordSettings, arg.DiscordWebhookUrlEncrypted, arg.DiscordEnabled, arg.UserID)
+	var i UserSetting
+	err := row.Scan(
+		&i.UserID,
+		&i.Username,
+		&i.GithubName,
+		&i.GithubEmail,
+		&i.GithubProfileUrl,
+		&i.AvatarUrl,
+		&i.TelegramBotTokenEncrypted,
+		&i.TelegramChatID,
+		&i.TelegramEnabled,
+		&i.ThemePreference,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastLoginAt,
+		&i.DiscordWebhookUrlEncrypted,
+		&i.DiscordEnabled,
+		&i.EmailAddress,
+		&i.EmailEnabled,
+		&i.NotifyWebhookUrlEncrypted,
+		&i.NotifyWebhookSecretEncrypted,
+		&i.NotifyWebhookEnabled,
+	)
+	return i, err
+}
+
+const updateUserEmailSettings = `-- name: UpdateUserEmailSettings :one
+UPDATE user_settings
+SET email_address = ?,
+    email_enabled = ?,
+    updated_at = datetime('now')
+WHERE user_id = ?
+RETURNING user_id, username, github_name, github_email, github_profile_url, avatar_url, telegram_bot_token_encrypted, telegram_chat_id, telegram_enabled, theme_preference, created_at, updated_at, last_login_at, discord_webhook_url_encrypted, discord_enabled, email_address, email_enabled, notify_webhook_url_encrypted, notify_webhook_secret_encrypted, notify_webhook_enabled
+`
+
+type UpdateUserEmailSettingsParams struct {
+	EmailAddress sql.NullString `json:"email_address"`
+	EmailEnabled int64          `json:"email_enabled"`
+	UserID       string         `json:"user_id"`
+}
+
+func (q *Queries) UpdateUserEmailSettings(ctx context.Context, arg UpdateUserEmailSettingsParams) (UserSetting, error) {
+	row := q.db.QueryRowContext(ctx, updateUserEmailSettings, arg.EmailAddress, arg.EmailEnabled, arg.UserID)
+	var i UserSetting
+	err := row.Scan(
+		&i.UserID,
+		&i.Username,
+		&i.GithubName,
+		&i.GithubEmail,
+		&i.GithubProfileUrl,
+		&i.AvatarUrl,
+		&i.TelegramBotTokenEncrypted,
+		&i.TelegramChatID,
+		&i.TelegramEnabled,
+		&i.ThemePreference,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastLoginAt,
+		&i.DiscordWebhookUrlEncrypted,
+		&i.DiscordEnabled,
+		&i.EmailAddress,
+		&i.EmailEnabled,
+		&i.NotifyWebhookUrlEncrypted,
+		&i.NotifyWebhookSecretEncrypted,
+		&i.NotifyWebhookEnabled,
+	)
+	return i, err
+}
+
+const updateUserNotifyWebhookSettings = `-- name: UpdateUserNotifyWebhookSettings :one
+UPDATE user_settings
+SET notify_webhook_url_encrypted = ?,
+    notify_webhook_secret_encrypted = ?,
+    notify_webhook_enabled = ?,
+    updated_at = datetime('now')
+WHERE user_id = ?
+RETURNING user_id, username, github_name, github_email, github_profile_url, avatar_url, telegram_bot_token_encrypted, telegram_chat_id, telegram_enabled, theme_preference, created_at, updated_at, last_login_at, discord_webhook_url_encrypted, discord_enabled, email_address, email_enabled, notify_webhook_url_encrypted, notify_webhook_secret_encrypted, notify_webhook_enabled
+`
+
+type UpdateUserNotifyWebhookSettingsParams struct {
+	NotifyWebhookUrlEncrypted    []byte `json:"notify_webhook_url_encrypted"`
+	NotifyWebhookSecretEncrypted []byte `json:"notify_webhook_secret_encrypted"`
+	NotifyWebhookEnabled         int64  `json:"notify_webhook_enabled"`
+	UserID                       string `json:"user_id"`
+}
+
+func (q *Queries) UpdateUserNotifyWebhookSettings(ctx context.Context, arg UpdateUserNotifyWebhookSettingsParams) (UserSetting, error) {
+	row := q.db.QueryRowContext(ctx, updateUserNotifyWebhookSettings,
+		arg.NotifyWebhookUrlEncrypted,
+		arg.NotifyWebhookSecretEncrypted,
+		arg.NotifyWebhookEnabled,
+		arg.UserID,
+	)
+	var i UserSetting
+	err := row.Scan(
+		&i.UserID,
+		&i.Username,
+		&i.GithubName,
+		&i.GithubEmail,
+		&i.GithubProfileUrl,
+		&i.AvatarUrl,
+		&i.TelegramBotTokenEncrypted,
+		&i.TelegramChatID,
+		&i.TelegramEnabled,
+		&i.ThemePreference,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.LastLoginAt,
+		&i.DiscordWebhookUrlEncrypted,
+		&i.DiscordEnabled,
+		&i.EmailAddress,
+		&i.EmailEnabled,
+		&i.NotifyWebhookUrlEncrypted,
+		&i.NotifyWebhookSecretEncrypted,
+		&i.NotifyWebhookEnabled,
 	)
 	return i, err
 }
@@ -120,7 +291,7 @@ SET telegram_bot_token_encrypted = ?,
     telegram_enabled = ?,
     updated_at = datetime('now')
 WHERE user_id = ?
-RETURNING user_id, username, github_name, github_email, github_profile_url, avatar_url, telegram_bot_token_encrypted, telegram_chat_id, telegram_enabled, theme_preference, created_at, updated_at, last_login_at
+RETURNING user_id, username, github_name, github_email, github_profile_url, avatar_url, telegram_bot_token_encrypted, telegram_chat_id, telegram_enabled, theme_preference, created_at, updated_at, last_login_at, discord_webhook_url_encrypted, discord_enabled, email_address, email_enabled, notify_webhook_url_encrypted, notify_webhook_secret_encrypted, notify_webhook_enabled
 `
 
 type UpdateUserTelegramSettingsParams struct {
@@ -152,6 +323,13 @@ func (q *Queries) UpdateUserTelegramSettings(ctx context.Context, arg UpdateUser
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.DiscordWebhookUrlEncrypted,
+		&i.DiscordEnabled,
+		&i.EmailAddress,
+		&i.EmailEnabled,
+		&i.NotifyWebhookUrlEncrypted,
+		&i.NotifyWebhookSecretEncrypted,
+		&i.NotifyWebhookEnabled,
 	)
 	return i, err
 }
@@ -161,7 +339,7 @@ UPDATE user_settings
 SET theme_preference = ?,
     updated_at = datetime('now')
 WHERE user_id = ?
-RETURNING user_id, username, github_name, github_email, github_profile_url, avatar_url, telegram_bot_token_encrypted, telegram_chat_id, telegram_enabled, theme_preference, created_at, updated_at, last_login_at
+RETURNING user_id, username, github_name, github_email, github_profile_url, avatar_url, telegram_bot_token_encrypted, telegram_chat_id, telegram_enabled, theme_preference, created_at, updated_at, last_login_at, discord_webhook_url_encrypted, discord_enabled, email_address, email_enabled, notify_webhook_url_encrypted, notify_webhook_secret_encrypted, notify_webhook_enabled
 `
 
 type UpdateUserThemeParams struct {
@@ -186,6 +364,13 @@ func (q *Queries) UpdateUserTheme(ctx context.Context, arg UpdateUserThemeParams
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.DiscordWebhookUrlEncrypted,
+		&i.DiscordEnabled,
+		&i.EmailAddress,
+		&i.EmailEnabled,
+		&i.NotifyWebhookUrlEncrypted,
+		&i.NotifyWebhookSecretEncrypted,
+		&i.NotifyWebhookEnabled,
 	)
 	return i, err
 }
@@ -209,7 +394,7 @@ ON CONFLICT(user_id) DO UPDATE SET
     avatar_url = excluded.avatar_url,
     last_login_at = datetime('now'),
     updated_at = datetime('now')
-RETURNING user_id, username, github_name, github_email, github_profile_url, avatar_url, telegram_bot_token_encrypted, telegram_chat_id, telegram_enabled, theme_preference, created_at, updated_at, last_login_at
+RETURNING user_id, username, github_name, github_email, github_profile_url, avatar_url, telegram_bot_token_encrypted, telegram_chat_id, telegram_enabled, theme_preference, created_at, updated_at, last_login_at, discord_webhook_url_encrypted, discord_enabled, email_address, email_enabled, notify_webhook_url_encrypted, notify_webhook_secret_encrypted, notify_webhook_enabled
 `
 
 type UpsertUserSettingsParams struct {
@@ -245,6 +430,13 @@ func (q *Queries) UpsertUserSettings(ctx context.Context, arg UpsertUserSettings
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.LastLoginAt,
+		&i.DiscordWebhookUrlEncrypted,
+		&i.DiscordEnabled,
+		&i.EmailAddress,
+		&i.EmailEnabled,
+		&i.NotifyWebhookUrlEncrypted,
+		&i.NotifyWebhookSecretEncrypted,
+		&i.NotifyWebhookEnabled,
 	)
 	return i, err
 }
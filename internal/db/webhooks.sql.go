@@ -10,42 +10,228 @@ import (
 	"database/sql"
 )
 
+const bulkResetWebhooksForReplay = `-- name: BulkResetWebhooksForReplay :execrows
+UPDATE webhooks
+SET status = 'pending',
+    attempts = 0,
+    last_attempt_at = NULL,
+    next_attempt_at = NULL,
+    delivered_at = NULL,
+    error_message = NULL,
+    notification_sent = 0,
+    replayed_at = datetime('now'),
+    replayed_by = ?1,
+    replay_count = replay_count + 1
+WHERE id IN (
+    SELECT w.id FROM webhooks w
+    JOIN endpoints e ON w.endpoint_id = e.id
+    WHERE e.user_id = ?2
+      AND e.honeypot = 0
+      AND w.purged_at IS NULL
+      AND w.status = ?3
+      AND (?4 IS NULL OR w.endpoint_id = ?4)
+      AND (?5 IS NULL OR w.received_at >= ?5)
+      AND (?6 IS NULL OR w.received_at < ?6)
+    ORDER BY w.received_at
+    LIMIT ?7
+)
+`
+
+type BulkResetWebhooksForReplayParams struct {
+	ReplayedBy     sql.NullString `json:"replayed_by"`
+	UserID         string         `json:"user_id"`
+	Status         string         `json:"status"`
+	EndpointID     interface{}    `json:"endpoint_id"`
+	ReceivedAfter  interface{}    `json:"received_after"`
+	ReceivedBefore interface{}    `json:"received_before"`
+	MaxCount       int64          `json:"max_count"`
+}
+
+// User-facing query: resets up to max_count of the user's webhooks with a
+// status, oldest first, for re-delivery like ResetWebhookForReplay. One
+// statement, so all of them are reset or none. Honeypot hits and purged
+// webhooks are never replayed.
+func (q *Queries) BulkResetWebhooksForReplay(ctx context.Context, arg BulkResetWebhooksForReplayParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, bulkResetWebhooksForReplay,
+		arg.ReplayedBy,
+		arg.UserID,
+		arg.Status,
+		arg.EndpointID,
+		arg.ReceivedAfter,
+		arg.ReceivedBefore,
+		arg.MaxCount,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const cancelPendingReplays = `-- name: CancelPendingReplays :execrows
+UPDATE webhooks
+SET status = 'failed',
+    error_message = 'replay cancelled',
+    last_attempt_at = datetime('now')
+WHERE status = 'pending'
+  AND replayed_at IS NOT NULL
+  AND endpoint_id IN (SELECT e.id FROM endpoints e WHERE e.user_id = ?1)
+  AND (?2 IS NULL OR endpoint_id = ?2)
+`
+
+type CancelPendingReplaysParams struct {
+	UserID     string      `json:"user_id"`
+	EndpointID interface{} `json:"endpoint_id"`
+}
+
+// User-facing query: cancels queued replays, optionally for a single endpoint
+func (q *Queries) CancelPendingReplays(ctx context.Context, arg CancelPendingReplaysParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, cancelPendingReplays, arg.UserID, arg.EndpointID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const countBulkReplayable = `-- name: CountBulkReplayable :one
+SELECT COUNT(*) FROM webhooks w
+JOIN endpoints e ON w.endpoint_id = e.id
+WHERE e.user_id = ?1
+  AND e.honeypot = 0
+  AND w.purged_at IS NULL
+  AND w.status = ?2
+  AND (?3 IS NULL OR w.endpoint_id = ?3)
+  AND (?4 IS NULL OR w.received_at >= ?4)
+  AND (?5 IS NULL OR w.received_at < ?5)
+`
+
+type CountBulkReplayableParams struct {
+	UserID         string      `json:"user_id"`
+	Status         string      `json:"status"`
+	EndpointID     interface{} `json:"endpoint_id"`
+	ReceivedAfter  interface{} `json:"received_after"`
+	ReceivedBefore interface{} `json:"received_before"`
+}
+
+// User-facing query: counts the webhooks BulkResetWebhooksForReplay would
+// reset without its limit
+func (q *Queries) CountBulkReplayable(ctx context.Context, arg CountBulkReplayableParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countBulkReplayable,
+		arg.UserID,
+		arg.Status,
+		arg.EndpointID,
+		arg.ReceivedAfter,
+		arg.ReceivedBefore,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countPendingReplays = `-- name: CountPendingReplays :one
+SELECT COUNT(*) FROM webhooks
+WHERE endpoint_id = ?
+  AND status = 'pending'
+  AND replayed_at IS NOT NULL
+`
+
+// System query: counts replayed webhooks still waiting for delivery on an endpoint
+func (q *Queries) CountPendingReplays(ctx context.Context, endpointID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPendingReplays, endpointID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countWebhooks = `-- name: CountWebhooks :one
 SELECT COUNT(*) FROM webhooks w
 JOIN endpoints e ON w.endpoint_id = e.id
 WHERE e.user_id = ?1
   AND (?2 IS NULL OR w.endpoint_id = ?2)
   AND (?3 IS NULL OR w.status = ?3)
+  AND (?4 IS NULL OR w.event_type = ?4)
+  AND (w.purged_at IS NOT NULL) = ?5
 `
 
 type CountWebhooksParams struct {
 	UserID     string      `json:"user_id"`
 	EndpointID interface{} `json:"endpoint_id"`
 	Status     interface{} `json:"status"`
+	EventType  interface{} `json:"event_type"`
+	Purged     int64       `json:"purged"`
 }
 
 // User-facing query: counts webhooks owned by user
 func (q *Queries) CountWebhooks(ctx context.Context, arg CountWebhooksParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, countWebhooks, arg.UserID, arg.EndpointID, arg.Status)
+	row := q.db.QueryRowContext(ctx, countWebhooks,
+		arg.UserID,
+		arg.EndpointID,
+		arg.Status,
+		arg.EventType,
+		arg.Purged,
+	)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
 }
 
+const countWebhooksByStatus = `-- name: CountWebhooksByStatus :many
+SELECT status, COUNT(*) AS count
+FROM webhooks
+WHERE purged_at IS NULL
+GROUP BY status
+ORDER BY status
+`
+
+type CountWebhooksByStatusRow struct {
+	Status string `json:"status"`
+	Count  int64  `json:"count"`
+}
+
+// System query: counts all webhooks by status, for the queue depth metric
+func (q *Queries) CountWebhooksByStatus(ctx context.Context) ([]CountWebhooksByStatusRow, error) {
+	rows, err := q.db.QueryContext(ctx, countWebhooksByStatus)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CountWebhooksByStatusRow
+	for rows.Next() {
+		var i CountWebhooksByStatusRow
+		if err := rows.Scan(&i.Status, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createWebhook = `-- name: CreateWebhook :one
-INSERT INTO webhooks (id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts)
-VALUES (?, ?, datetime('now'), ?, ?, ?, 'pending', 0)
-RETURNING id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts, last_attempt_at, delivered_at, error_message, notification_sent
+INSERT INTO webhooks (id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts, event_type, delivery_id, duplicate_of, source_ip, traceparent)
+VALUES (?, ?, datetime('now'), ?, ?, ?, COALESCE(?, 'pending'), 0, ?, ?, ?, ?, ?)
+RETURNING id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts, last_attempt_at, delivered_at, error_message, notification_sent, replayed_at, event_type, delivery_id, duplicate_of, source_ip, replayed_by, replay_count, next_attempt_at, traceparent, purged_at, undeleted_at
 `
 
 type CreateWebhookParams struct {
-	ID             string `json:"id"`
-	EndpointID     string `json:"endpoint_id"`
-	Headers        string `json:"headers"`
-	Payload        []byte `json:"payload"`
-	SignatureValid int64  `json:"signature_valid"`
+	ID             string         `json:"id"`
+	EndpointID     string         `json:"endpoint_id"`
+	Headers        string         `json:"headers"`
+	Payload        []byte         `json:"payload"`
+	SignatureValid int64          `json:"signature_valid"`
+	Status         sql.NullString `json:"status"`
+	EventType      sql.NullString `json:"event_type"`
+	DeliveryID     sql.NullString `json:"delivery_id"`
+	DuplicateOf    sql.NullString `json:"duplicate_of"`
+	SourceIp       string         `json:"source_ip"`
+	Traceparent    sql.NullString `json:"traceparent"`
 }
 
+// Public query for webhook ingestion. Status defaults to pending.
 func (q *Queries) CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error) {
 	row := q.db.QueryRowContext(ctx, createWebhook,
 		arg.ID,
@@ -53,6 +239,12 @@ func (q *Queries) CreateWebhook(ctx context.Context, arg CreateWebhookParams) (W
 		arg.Headers,
 		arg.Payload,
 		arg.SignatureValid,
+		arg.Status,
+		arg.EventType,
+		arg.DeliveryID,
+		arg.DuplicateOf,
+		arg.SourceIp,
+		arg.Traceparent,
 	)
 	var i Webhook
 	err := row.Scan(
@@ -68,60 +260,67 @@ func (q *Queries) CreateWebhook(ctx context.Context, arg CreateWebhookParams) (W
 		&i.DeliveredAt,
 		&i.ErrorMessage,
 		&i.NotificationSent,
+		&i.ReplayedAt,
+		&i.EventType,
+		&i.DeliveryID,
+		&i.DuplicateOf,
+		&i.SourceIp,
+		&i.ReplayedBy,
+		&i.ReplayCount,
+		&i.NextAttemptAt,
+		&i.Traceparent,
+		&i.PurgedAt,
+		&i.UndeletedAt,
 	)
 	return i, err
 }
 
-const deleteDeadLetterWebhooks = `-- name: DeleteDeadLetterWebhooks :execrows
+const deletePurgedWebhooks = `-- name: DeletePurgedWebhooks :execrows
 DELETE FROM webhooks
-WHERE status = 'dead_letter'
-  AND received_at < datetime('now', '-14 days')
+WHERE purged_at IS NOT NULL
+  AND purged_at < datetime('now', '-' || CAST(?1 AS INTEGER) || ' seconds')
 `
 
-// System query: cleanup old dead letter webhooks (no user filter)
-func (q *Queries) DeleteDeadLetterWebhooks(ctx context.Context) (int64, error) {
-	result, err := q.db.ExecContext(ctx, deleteDeadLetterWebhooks)
+// System query: deletes webhooks purged longer ago than the grace period (no user filter)
+func (q *Queries) DeletePurgedWebhooks(ctx context.Context, graceSeconds int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deletePurgedWebhooks, graceSeconds)
 	if err != nil {
 		return 0, err
 	}
 	return result.RowsAffected()
 }
 
-const deleteDeliveredWebhooks = `-- name: DeleteDeliveredWebhooks :execrows
-DELETE FROM webhooks
-WHERE status = 'delivered'
-  AND delivered_at < datetime('now', '-7 days')
+const findWebhookByDeliveryID = `-- name: FindWebhookByDeliveryID :one
+SELECT id FROM webhooks
+WHERE endpoint_id = ?1
+  AND delivery_id = ?2
+  AND duplicate_of IS NULL
+  AND received_at >= datetime('now', '-' || CAST(?3 AS INTEGER) || ' hours')
+ORDER BY received_at
+LIMIT 1
 `
 
-// System query: cleanup old delivered webhooks (no user filter)
-func (q *Queries) DeleteDeliveredWebhooks(ctx context.Context) (int64, error) {
-	result, err := q.db.ExecContext(ctx, deleteDeliveredWebhooks)
-	if err != nil {
-		return 0, err
-	}
-	return result.RowsAffected()
+type FindWebhookByDeliveryIDParams struct {
+	EndpointID  string         `json:"endpoint_id"`
+	DeliveryID  sql.NullString `json:"delivery_id"`
+	WindowHours int64          `json:"window_hours"`
 }
 
-const deleteFailedWebhooks = `-- name: DeleteFailedWebhooks :execrows
-DELETE FROM webhooks
-WHERE status = 'failed'
-  AND last_attempt_at < datetime('now', '-7 days')
-`
-
-// System query: cleanup old failed webhooks (no user filter)
-func (q *Queries) DeleteFailedWebhooks(ctx context.Context) (int64, error) {
-	result, err := q.db.ExecContext(ctx, deleteFailedWebhooks)
-	if err != nil {
-		return 0, err
-	}
-	return result.RowsAffected()
+// Public query for webhook ingestion: the first webhook received for an
+// endpoint with a provider delivery ID in the last window_hours.
+func (q *Queries) FindWebhookByDeliveryID(ctx context.Context, arg FindWebhookByDeliveryIDParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, findWebhookByDeliveryID, arg.EndpointID, arg.DeliveryID, arg.WindowHours)
+	var id string
+	err := row.Scan(&id)
+	return id, err
 }
 
 const getDeadLetterWebhooks = `-- name: GetDeadLetterWebhooks :many
-SELECT w.id, w.endpoint_id, w.received_at, w.headers, w.payload, w.signature_valid, w.status, w.attempts, w.last_attempt_at, w.delivered_at, w.error_message, w.notification_sent, e.name as endpoint_name, e.destination_url, e.provider_type
+SELECT w.id, w.endpoint_id, w.received_at, w.headers, w.payload, w.signature_valid, w.status, w.attempts, w.last_attempt_at, w.delivered_at, w.error_message, w.notification_sent, w.replayed_at, w.event_type, w.delivery_id, w.duplicate_of, w.source_ip, w.replayed_by, w.replay_count, w.next_attempt_at, w.traceparent, w.purged_at, w.undeleted_at, e.name as endpoint_name, e.destination_url, e.provider_type
 FROM webhooks w
 JOIN endpoints e ON w.endpoint_id = e.id
 WHERE w.status = 'dead_letter'
+  AND w.purged_at IS NULL
 ORDER BY w.received_at DESC
 LIMIT ?
 `
@@ -139,6 +338,17 @@ type GetDeadLetterWebhooksRow struct {
 	DeliveredAt      sql.NullString `json:"delivered_at"`
 	ErrorMessage     sql.NullString `json:"error_message"`
 	NotificationSent int64          `json:"notification_sent"`
+	ReplayedAt       sql.NullString `json:"replayed_at"`
+	EventType        sql.NullString `json:"event_type"`
+	DeliveryID       sql.NullString `json:"delivery_id"`
+	DuplicateOf      sql.NullString `json:"duplicate_of"`
+	SourceIp         string         `json:"source_ip"`
+	ReplayedBy       sql.NullString `json:"replayed_by"`
+	ReplayCount      int64          `json:"replay_count"`
+	NextAttemptAt    sql.NullString `json:"next_attempt_at"`
+	Traceparent      sql.NullString `json:"traceparent"`
+	PurgedAt         sql.NullString `json:"purged_at"`
+	UndeletedAt      sql.NullString `json:"undeleted_at"`
 	EndpointName     string         `json:"endpoint_name"`
 	DestinationUrl   string         `json:"destination_url"`
 	ProviderType     string         `json:"provider_type"`
@@ -167,6 +377,17 @@ func (q *Queries) GetDeadLetterWebhooks(ctx context.Context, limit int64) ([]Get
 			&i.DeliveredAt,
 			&i.ErrorMessage,
 			&i.NotificationSent,
+			&i.ReplayedAt,
+			&i.EventType,
+			&i.DeliveryID,
+			&i.DuplicateOf,
+			&i.SourceIp,
+			&i.ReplayedBy,
+			&i.ReplayCount,
+			&i.NextAttemptAt,
+			&i.Traceparent,
+			&i.PurgedAt,
+			&i.UndeletedAt,
 			&i.EndpointName,
 			&i.DestinationUrl,
 			&i.ProviderType,
@@ -184,17 +405,201 @@ func (q *Queries) GetDeadLetterWebhooks(ctx context.Context, limit int64) ([]Get
 	return items, nil
 }
 
+const getDispatchableWebhook = `-- name: GetDispatchableWebhook :one
+SELECT w.id, w.endpoint_id, w.received_at, w.headers, w.payload, w.signature_valid, w.status, w.attempts, w.last_attempt_at, w.delivered_at, w.error_message, w.notification_sent, w.replayed_at, w.event_type, w.delivery_id, w.duplicate_of, w.source_ip, w.replayed_by, w.replay_count, w.next_attempt_at, w.traceparent, w.purged_at, w.undeleted_at, e.destination_url, e.provider_type, e.transform
+FROM webhooks w
+JOIN endpoints e ON w.endpoint_id = e.id
+WHERE w.id = ?
+  AND w.status = 'pending'
+  AND e.muted = 0
+  AND w.next_attempt_at IS NULL
+  AND w.received_at = (
+    SELECT MIN(w2.received_at)
+    FROM webhooks w2
+    WHERE w2.endpoint_id = w.endpoint_id
+      AND w2.status = 'pending'
+  )
+`
+
+type GetDispatchableWebhookRow struct {
+	ID               string         `json:"id"`
+	EndpointID       string         `json:"endpoint_id"`
+	ReceivedAt       string         `json:"received_at"`
+	Headers          string         `json:"headers"`
+	Payload          []byte         `json:"payload"`
+	SignatureValid   int64          `json:"signature_valid"`
+	Status           string         `json:"status"`
+	Attempts         int64          `json:"attempts"`
+	LastAttemptAt    sql.NullString `json:"last_attempt_at"`
+	DeliveredAt      sql.NullString `json:"delivered_at"`
+	ErrorMessage     sql.NullString `json:"error_message"`
+	NotificationSent int64          `json:"notification_sent"`
+	ReplayedAt       sql.NullString `json:"replayed_at"`
+	EventType        sql.NullString `json:"event_type"`
+	DeliveryID       sql.NullString `json:"delivery_id"`
+	DuplicateOf      sql.NullString `json:"duplicate_of"`
+	SourceIp         string         `json:"source_ip"`
+	ReplayedBy       sql.NullString `json:"replayed_by"`
+	ReplayCount      int64          `json:"replay_count"`
+	NextAttemptAt    sql.NullString `json:"next_attempt_at"`
+	Traceparent      sql.NullString `json:"traceparent"`
+	PurgedAt         sql.NullString `json:"purged_at"`
+	UndeletedAt      sql.NullString `json:"undeleted_at"`
+	DestinationUrl   string         `json:"destination_url"`
+	ProviderType     string         `json:"provider_type"`
+	Transform        sql.NullString `json:"transform"`
+}
+
+// System query: a just-stored webhook, if it is the next to dispatch for its endpoint (no user filter)
+func (q *Queries) GetDispatchableWebhook(ctx context.Context, id string) (GetDispatchableWebhookRow, error) {
+	row := q.db.QueryRowContext(ctx, getDispatchableWebhook, id)
+	var i GetDispatchableWebhookRow
+	err := row.Scan(
+		&i.ID,
+		&i.EndpointID,
+		&i.ReceivedAt,
+		&i.Headers,
+		&i.Payload,
+		&i.SignatureValid,
+		&i.Status,
+		&i.Attempts,
+		&i.LastAttemptAt,
+		&i.DeliveredAt,
+		&i.ErrorMessage,
+		&i.NotificationSent,
+		&i.ReplayedAt,
+		&i.EventType,
+		&i.DeliveryID,
+		&i.DuplicateOf,
+		&i.SourceIp,
+		&i.ReplayedBy,
+		&i.ReplayCount,
+		&i.NextAttemptAt,
+		&i.Traceparent,
+		&i.PurgedAt,
+		&i.UndeletedAt,
+		&i.DestinationUrl,
+		&i.ProviderType,
+		&i.Transform,
+	)
+	return i, err
+}
+
+const getEndpointBytesToday = `-- name: GetEndpointBytesToday :one
+SELECT CAST(COALESCE(SUM(LENGTH(payload)), 0) AS INTEGER) AS bytes
+FROM webhooks
+WHERE endpoint_id = ?1
+  AND received_at >= date('now')
+`
+
+// Public query for webhook ingestion: payload bytes stored for an endpoint
+// since the start of the UTC day.
+func (q *Queries) GetEndpointBytesToday(ctx context.Context, endpointID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getEndpointBytesToday, endpointID)
+	var bytes int64
+	err := row.Scan(&bytes)
+	return bytes, err
+}
+
+const getEndpointSLOStats = `-- name: GetEndpointSLOStats :one
+SELECT
+    COUNT(*) AS total,
+    COUNT(CASE WHEN w.status IN ('delivered', 'acknowledged_duplicate')
+        AND (julianday(w.delivered_at) - julianday(w.received_at)) * 86400 <= CAST(?1 AS INTEGER)
+        THEN 1 END) AS met
+FROM webhooks w
+WHERE w.endpoint_id = ?2
+  AND w.status != 'skipped'
+  AND w.received_at >= datetime('now', '-' || CAST(?3 AS INTEGER) || ' hours')
+  AND NOT (w.status = 'pending'
+    AND w.received_at > datetime('now', '-' || CAST(?1 AS INTEGER) || ' seconds'))
+`
+
+type GetEndpointSLOStatsParams struct {
+	LatencySeconds int64  `json:"latency_seconds"`
+	EndpointID     string `json:"endpoint_id"`
+	WindowHours    int64  `json:"window_hours"`
+}
+
+type GetEndpointSLOStatsRow struct {
+	Total int64 `json:"total"`
+	Met   int64 `json:"met"`
+}
+
+// System query: webhooks received in the SLO window and how many were delivered
+// within the latency target. Pending webhooks younger than the latency target
+// can still meet it and are not counted; skipped webhooks are never relayed.
+func (q *Queries) GetEndpointSLOStats(ctx context.Context, arg GetEndpointSLOStatsParams) (GetEndpointSLOStatsRow, error) {
+	row := q.db.QueryRowContext(ctx, getEndpointSLOStats, arg.LatencySeconds, arg.EndpointID, arg.WindowHours)
+	var i GetEndpointSLOStatsRow
+	err := row.Scan(&i.Total, &i.Met)
+	return i, err
+}
+
+const getEventTypeCounts = `-- name: GetEventTypeCounts :many
+SELECT COALESCE(w.event_type, '') AS event_type, COUNT(*) AS count
+FROM webhooks w
+JOIN endpoints e ON w.endpoint_id = e.id
+WHERE e.user_id = ? AND w.endpoint_id = ?
+  AND w.purged_at IS NULL
+GROUP BY w.event_type
+ORDER BY count DESC
+`
+
+type GetEventTypeCountsParams struct {
+	UserID     string `json:"user_id"`
+	EndpointID string `json:"endpoint_id"`
+}
+
+type GetEventTypeCountsRow struct {
+	EventType string `json:"event_type"`
+	Count     int64  `json:"count"`
+}
+
+// User-facing query: webhook counts per event type for an endpoint
+func (q *Queries) GetEventTypeCounts(ctx context.Context, arg GetEventTypeCountsParams) ([]GetEventTypeCountsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getEventTypeCounts, arg.UserID, arg.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetEventTypeCountsRow{}
+	for rows.Next() {
+		var i GetEventTypeCountsRow
+		if err := rows.Scan(&i.EventType, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLastStatusChangeID = `-- name: GetLastStatusChangeID :one
+SELECT CAST(COALESCE(MAX(id), 0) AS INTEGER) AS id FROM webhook_status_history
+`
+
+// System query: the newest status history row, where a tail starts.
+func (q *Queries) GetLastStatusChangeID(ctx context.Context) (int64, error) {
+	row := q.db.QueryRowContext(ctx, getLastStatusChangeID)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
 const getPendingWebhooks = `-- name: GetPendingWebhooks :many
-SELECT w.id, w.endpoint_id, w.received_at, w.headers, w.payload, w.signature_valid, w.status, w.attempts, w.last_attempt_at, w.delivered_at, w.error_message, w.notification_sent, e.destination_url, e.provider_type
+SELECT w.id, w.endpoint_id, w.received_at, w.headers, w.payload, w.signature_valid, w.status, w.attempts, w.last_attempt_at, w.delivered_at, w.error_message, w.notification_sent, w.replayed_at, w.event_type, w.delivery_id, w.duplicate_of, w.source_ip, w.replayed_by, w.replay_count, w.next_attempt_at, w.traceparent, w.purged_at, w.undeleted_at, e.destination_url, e.provider_type, e.transform
 FROM webhooks w
 JOIN endpoints e ON w.endpoint_id = e.id
 WHERE w.status = 'pending'
   AND e.muted = 0
-  -- Respect backoff: either never attempted, or backoff delay has passed
-  AND (
-    w.last_attempt_at IS NULL
-    OR datetime(w.last_attempt_at, '+' || MIN(1 << w.attempts, 3600) || ' seconds') <= datetime('now')
-  )
+  -- Respect backoff: either never attempted, or the retry is due
+  AND (w.next_attempt_at IS NULL OR w.next_attempt_at <= datetime('now'))
   -- In-order delivery: only the oldest pending webhook per endpoint
   AND w.received_at = (
     SELECT MIN(w2.received_at)
@@ -219,8 +624,20 @@ type GetPendingWebhooksRow struct {
 	DeliveredAt      sql.NullString `json:"delivered_at"`
 	ErrorMessage     sql.NullString `json:"error_message"`
 	NotificationSent int64          `json:"notification_sent"`
+	ReplayedAt       sql.NullString `json:"replayed_at"`
+	EventType        sql.NullString `json:"event_type"`
+	DeliveryID       sql.NullString `json:"delivery_id"`
+	DuplicateOf      sql.NullString `json:"duplicate_of"`
+	SourceIp         string         `json:"source_ip"`
+	ReplayedBy       sql.NullString `json:"replayed_by"`
+	ReplayCount      int64          `json:"replay_count"`
+	NextAttemptAt    sql.NullString `json:"next_attempt_at"`
+	Traceparent      sql.NullString `json:"traceparent"`
+	PurgedAt         sql.NullString `json:"purged_at"`
+	UndeletedAt      sql.NullString `json:"undeleted_at"`
 	DestinationUrl   string         `json:"destination_url"`
 	ProviderType     string         `json:"provider_type"`
+	Transform        sql.NullString `json:"transform"`
 }
 
 // System query: gets all pending webhooks for dispatch (no user filter)
@@ -246,8 +663,20 @@ func (q *Queries) GetPendingWebhooks(ctx context.Context, limit int64) ([]GetPen
 			&i.DeliveredAt,
 			&i.ErrorMessage,
 			&i.NotificationSent,
+			&i.ReplayedAt,
+			&i.EventType,
+			&i.DeliveryID,
+			&i.DuplicateOf,
+			&i.SourceIp,
+			&i.ReplayedBy,
+			&i.ReplayCount,
+			&i.NextAttemptAt,
+			&i.Traceparent,
+			&i.PurgedAt,
+			&i.UndeletedAt,
 			&i.DestinationUrl,
 			&i.ProviderType,
+			&i.Transform,
 		); err != nil {
 			return nil, err
 		}
@@ -270,6 +699,7 @@ SELECT
 FROM webhooks w
 JOIN endpoints e ON w.endpoint_id = e.id
 WHERE e.user_id = ?
+  AND w.purged_at IS NULL
 `
 
 type GetQueueStatsRow struct {
@@ -287,11 +717,12 @@ func (q *Queries) GetQueueStats(ctx context.Context, userID string) (GetQueueSta
 }
 
 const getUnnotifiedDeadLetters = `-- name: GetUnnotifiedDeadLetters :many
-SELECT w.id, w.endpoint_id, w.received_at, w.headers, w.payload, w.signature_valid, w.status, w.attempts, w.last_attempt_at, w.delivered_at, w.error_message, w.notification_sent, e.name as endpoint_name, e.destination_url as endpoint_destination_url
+SELECT w.id, w.endpoint_id, w.received_at, w.headers, w.payload, w.signature_valid, w.status, w.attempts, w.last_attempt_at, w.delivered_at, w.error_message, w.notification_sent, w.replayed_at, w.event_type, w.delivery_id, w.duplicate_of, w.source_ip, w.replayed_by, w.replay_count, w.next_attempt_at, w.traceparent, w.purged_at, w.undeleted_at, e.name as endpoint_name, e.destination_url as endpoint_destination_url
 FROM webhooks w
 JOIN endpoints e ON w.endpoint_id = e.id
 WHERE w.status = 'dead_letter'
   AND w.notification_sent = 0
+  AND w.purged_at IS NULL
 ORDER BY w.received_at DESC
 LIMIT ?
 `
@@ -309,6 +740,17 @@ type GetUnnotifiedDeadLettersRow struct {
 	DeliveredAt            sql.NullString `json:"delivered_at"`
 	ErrorMessage           sql.NullString `json:"error_message"`
 	NotificationSent       int64          `json:"notification_sent"`
+	ReplayedAt             sql.NullString `json:"replayed_at"`
+	EventType              sql.NullString `json:"event_type"`
+	DeliveryID             sql.NullString `json:"delivery_id"`
+	DuplicateOf            sql.NullString `json:"duplicate_of"`
+	SourceIp               string         `json:"source_ip"`
+	ReplayedBy             sql.NullString `json:"replayed_by"`
+	ReplayCount            int64          `json:"replay_count"`
+	NextAttemptAt          sql.NullString `json:"next_attempt_at"`
+	Traceparent            sql.NullString `json:"traceparent"`
+	PurgedAt               sql.NullString `json:"purged_at"`
+	UndeletedAt            sql.NullString `json:"undeleted_at"`
 	EndpointName           string         `json:"endpoint_name"`
 	EndpointDestinationUrl string         `json:"endpoint_destination_url"`
 }
@@ -336,6 +778,17 @@ func (q *Queries) GetUnnotifiedDeadLetters(ctx context.Context, limit int64) ([]
 			&i.DeliveredAt,
 			&i.ErrorMessage,
 			&i.NotificationSent,
+			&i.ReplayedAt,
+			&i.EventType,
+			&i.DeliveryID,
+			&i.DuplicateOf,
+			&i.SourceIp,
+			&i.ReplayedBy,
+			&i.ReplayCount,
+			&i.NextAttemptAt,
+			&i.Traceparent,
+			&i.PurgedAt,
+			&i.UndeletedAt,
 			&i.EndpointName,
 			&i.EndpointDestinationUrl,
 		); err != nil {
@@ -353,9 +806,10 @@ func (q *Queries) GetUnnotifiedDeadLetters(ctx context.Context, limit int64) ([]
 }
 
 const getWebhook = `-- name: GetWebhook :one
-SELECT w.id, w.endpoint_id, w.received_at, w.headers, w.payload, w.signature_valid, w.status, w.attempts, w.last_attempt_at, w.delivered_at, w.error_message, w.notification_sent FROM webhooks w
+SELECT w.id, w.endpoint_id, w.received_at, w.headers, w.payload, w.signature_valid, w.status, w.attempts, w.last_attempt_at, w.delivered_at, w.error_message, w.notification_sent, w.replayed_at, w.event_type, w.delivery_id, w.duplicate_of, w.source_ip, w.replayed_by, w.replay_count, w.next_attempt_at, w.traceparent, w.purged_at, w.undeleted_at FROM webhooks w
 JOIN endpoints e ON w.endpoint_id = e.id
 WHERE w.id = ? AND e.user_id = ?
+  AND w.purged_at IS NULL
 `
 
 type GetWebhookParams struct {
@@ -380,15 +834,56 @@ func (q *Queries) GetWebhook(ctx context.Context, arg GetWebhookParams) (Webhook
 		&i.DeliveredAt,
 		&i.ErrorMessage,
 		&i.NotificationSent,
+		&i.ReplayedAt,
+		&i.EventType,
+		&i.DeliveryID,
+		&i.DuplicateOf,
+		&i.SourceIp,
+		&i.ReplayedBy,
+		&i.ReplayCount,
+		&i.NextAttemptAt,
+		&i.Traceparent,
+		&i.PurgedAt,
+		&i.UndeletedAt,
+	)
+	return i, err
+}
+
+const getWebhookRetryPolicy = `-- name: GetWebhookRetryPolicy :one
+SELECT w.attempts, e.retry_max_attempts, e.retry_backoff_base_seconds, e.retry_max_interval_seconds, e.retry_jitter
+FROM webhooks w
+JOIN endpoints e ON w.endpoint_id = e.id
+WHERE w.id = ?
+`
+
+type GetWebhookRetryPolicyRow struct {
+	Attempts                int64   `json:"attempts"`
+	RetryMaxAttempts        int64   `json:"retry_max_attempts"`
+	RetryBackoffBaseSeconds int64   `json:"retry_backoff_base_seconds"`
+	RetryMaxIntervalSeconds int64   `json:"retry_max_interval_seconds"`
+	RetryJitter             float64 `json:"retry_jitter"`
+}
+
+// System query: a webhook's attempts and its endpoint's retry policy (no user filter)
+func (q *Queries) GetWebhookRetryPolicy(ctx context.Context, id string) (GetWebhookRetryPolicyRow, error) {
+	row := q.db.QueryRowContext(ctx, getWebhookRetryPolicy, id)
+	var i GetWebhookRetryPolicyRow
+	err := row.Scan(
+		&i.Attempts,
+		&i.RetryMaxAttempts,
+		&i.RetryBackoffBaseSeconds,
+		&i.RetryMaxIntervalSeconds,
+		&i.RetryJitter,
 	)
 	return i, err
 }
 
 const getWebhookWithEndpoint = `-- name: GetWebhookWithEndpoint :one
-SELECT w.id, w.endpoint_id, w.received_at, w.headers, w.payload, w.signature_valid, w.status, w.attempts, w.last_attempt_at, w.delivered_at, w.error_message, w.notification_sent, e.name as endpoint_name, e.destination_url as endpoint_destination_url
+SELECT w.id, w.endpoint_id, w.received_at, w.headers, w.payload, w.signature_valid, w.status, w.attempts, w.last_attempt_at, w.delivered_at, w.error_message, w.notification_sent, w.replayed_at, w.event_type, w.delivery_id, w.duplicate_of, w.source_ip, w.replayed_by, w.replay_count, w.next_attempt_at, w.traceparent, w.purged_at, w.undeleted_at, e.name as endpoint_name, e.destination_url as endpoint_destination_url
 FROM webhooks w
 JOIN endpoints e ON w.endpoint_id = e.id
 WHERE w.id = ? AND e.user_id = ?
+  AND w.purged_at IS NULL
 `
 
 type GetWebhookWithEndpointParams struct {
@@ -409,6 +904,17 @@ type GetWebhookWithEndpointRow struct {
 	DeliveredAt            sql.NullString `json:"delivered_at"`
 	ErrorMessage           sql.NullString `json:"error_message"`
 	NotificationSent       int64          `json:"notification_sent"`
+	ReplayedAt             sql.NullString `json:"replayed_at"`
+	EventType              sql.NullString `json:"event_type"`
+	DeliveryID             sql.NullString `json:"delivery_id"`
+	DuplicateOf            sql.NullString `json:"duplicate_of"`
+	SourceIp               string         `json:"source_ip"`
+	ReplayedBy             sql.NullString `json:"replayed_by"`
+	ReplayCount            int64          `json:"replay_count"`
+	NextAttemptAt          sql.NullString `json:"next_attempt_at"`
+	Traceparent            sql.NullString `json:"traceparent"`
+	PurgedAt               sql.NullString `json:"purged_at"`
+	UndeletedAt            sql.NullString `json:"undeleted_at"`
 	EndpointName           string         `json:"endpoint_name"`
 	EndpointDestinationUrl string         `json:"endpoint_destination_url"`
 }
@@ -430,6 +936,17 @@ func (q *Queries) GetWebhookWithEndpoint(ctx context.Context, arg GetWebhookWith
 		&i.DeliveredAt,
 		&i.ErrorMessage,
 		&i.NotificationSent,
+		&i.ReplayedAt,
+		&i.EventType,
+		&i.DeliveryID,
+		&i.DuplicateOf,
+		&i.SourceIp,
+		&i.ReplayedBy,
+		&i.ReplayCount,
+		&i.NextAttemptAt,
+		&i.Traceparent,
+		&i.PurgedAt,
+		&i.UndeletedAt,
 		&i.EndpointName,
 		&i.EndpointDestinationUrl,
 	)
@@ -437,7 +954,7 @@ func (q *Queries) GetWebhookWithEndpoint(ctx context.Context, arg GetWebhookWith
 }
 
 const getWebhookWithEndpointByID = `-- name: GetWebhookWithEndpointByID :one
-SELECT w.id, w.endpoint_id, w.received_at, w.headers, w.payload, w.signature_valid, w.status, w.attempts, w.last_attempt_at, w.delivered_at, w.error_message, w.notification_sent, e.name as endpoint_name, e.destination_url as endpoint_destination_url
+SELECT w.id, w.endpoint_id, w.received_at, w.headers, w.payload, w.signature_valid, w.status, w.attempts, w.last_attempt_at, w.delivered_at, w.error_message, w.notification_sent, w.replayed_at, w.event_type, w.delivery_id, w.duplicate_of, w.source_ip, w.replayed_by, w.replay_count, w.next_attempt_at, w.traceparent, w.purged_at, w.undeleted_at, e.name as endpoint_name, e.destination_url as endpoint_destination_url
 FROM webhooks w
 JOIN endpoints e ON w.endpoint_id = e.id
 WHERE w.id = ?
@@ -456,6 +973,17 @@ type GetWebhookWithEndpointByIDRow struct {
 	DeliveredAt            sql.NullString `json:"delivered_at"`
 	ErrorMessage           sql.NullString `json:"error_message"`
 	NotificationSent       int64          `json:"notification_sent"`
+	ReplayedAt             sql.NullString `json:"replayed_at"`
+	EventType              sql.NullString `json:"event_type"`
+	DeliveryID             sql.NullString `json:"delivery_id"`
+	DuplicateOf            sql.NullString `json:"duplicate_of"`
+	SourceIp               string         `json:"source_ip"`
+	ReplayedBy             sql.NullString `json:"replayed_by"`
+	ReplayCount            int64          `json:"replay_count"`
+	NextAttemptAt          sql.NullString `json:"next_attempt_at"`
+	Traceparent            sql.NullString `json:"traceparent"`
+	PurgedAt               sql.NullString `json:"purged_at"`
+	UndeletedAt            sql.NullString `json:"undeleted_at"`
 	EndpointName           string         `json:"endpoint_name"`
 	EndpointDestinationUrl string         `json:"endpoint_destination_url"`
 }
@@ -477,26 +1005,216 @@ func (q *Queries) GetWebhookWithEndpointByID(ctx context.Context, id string) (Ge
 		&i.DeliveredAt,
 		&i.ErrorMessage,
 		&i.NotificationSent,
+		&i.ReplayedAt,
+		&i.EventType,
+		&i.DeliveryID,
+		&i.DuplicateOf,
+		&i.SourceIp,
+		&i.ReplayedBy,
+		&i.ReplayCount,
+		&i.NextAttemptAt,
+		&i.Traceparent,
+		&i.PurgedAt,
+		&i.UndeletedAt,
 		&i.EndpointName,
 		&i.EndpointDestinationUrl,
 	)
 	return i, err
 }
 
+const listExpiredWebhooks = `-- name: ListExpiredWebhooks :many
+SELECT id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts, last_attempt_at, delivered_at, error_message, notification_sent, replayed_at, event_type, delivery_id, duplicate_of, source_ip, replayed_by, replay_count, next_attempt_at, traceparent, purged_at, undeleted_at FROM webhooks
+WHERE purged_at IS NULL
+  AND ((status IN ('delivered', 'acknowledged_duplicate')
+      AND delivered_at < datetime('now', '-' || CAST(?1 AS INTEGER) || ' seconds')
+      AND (undeleted_at IS NULL OR undeleted_at < datetime('now', '-' || CAST(?1 AS INTEGER) || ' seconds')))
+    OR (status = 'failed'
+      AND last_attempt_at < datetime('now', '-' || CAST(?2 AS INTEGER) || ' seconds')
+      AND (undeleted_at IS NULL OR undeleted_at < datetime('now', '-' || CAST(?2 AS INTEGER) || ' seconds')))
+    OR (status = 'dead_letter'
+      AND received_at < datetime('now', '-' || CAST(?3 AS INTEGER) || ' seconds')
+      AND (undeleted_at IS NULL OR undeleted_at < datetime('now', '-' || CAST(?3 AS INTEGER) || ' seconds')))
+    OR (status = 'skipped'
+      AND received_at < datetime('now', '-' || CAST(?4 AS INTEGER) || ' seconds')
+      AND (undeleted_at IS NULL OR undeleted_at < datetime('now', '-' || CAST(?4 AS INTEGER) || ' seconds'))))
+LIMIT ?5
+`
+
+type ListExpiredWebhooksParams struct {
+	DeliveredAgeSeconds  int64 `json:"delivered_age_seconds"`
+	FailedAgeSeconds     int64 `json:"failed_age_seconds"`
+	DeadLetterAgeSeconds int64 `json:"dead_letter_age_seconds"`
+	SkippedAgeSeconds    int64 `json:"skipped_age_seconds"`
+	Limit                int64 `json:"limit"`
+}
+
+// System query: webhooks past retention, exported to cold storage before they are purged (no user filter)
+func (q *Queries) ListExpiredWebhooks(ctx context.Context, arg ListExpiredWebhooksParams) ([]Webhook, error) {
+	rows, err := q.db.QueryContext(ctx, listExpiredWebhooks,
+		arg.DeliveredAgeSeconds,
+		arg.FailedAgeSeconds,
+		arg.DeadLetterAgeSeconds,
+		arg.SkippedAgeSeconds,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Webhook{}
+	for rows.Next() {
+		var i Webhook
+		if err := rows.Scan(
+			&i.ID,
+			&i.EndpointID,
+			&i.ReceivedAt,
+			&i.Headers,
+			&i.Payload,
+			&i.SignatureValid,
+			&i.Status,
+			&i.Attempts,
+			&i.LastAttemptAt,
+			&i.DeliveredAt,
+			&i.ErrorMessage,
+			&i.NotificationSent,
+			&i.ReplayedAt,
+			&i.EventType,
+			&i.DeliveryID,
+			&i.DuplicateOf,
+			&i.SourceIp,
+			&i.ReplayedBy,
+			&i.ReplayCount,
+			&i.NextAttemptAt,
+			&i.Traceparent,
+			&i.PurgedAt,
+			&i.UndeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStatusChangesAfter = `-- name: ListStatusChangesAfter :many
+SELECT h.id, h.webhook_id, h.from_status, h.to_status, h.reason, h.changed_at, h.changed_by
+FROM webhook_status_history h
+JOIN webhooks w ON w.id = h.webhook_id
+JOIN endpoints e ON e.id = w.endpoint_id
+WHERE h.id > ?1
+  AND e.user_id = ?2
+  AND (?3 IS NULL OR w.endpoint_id = ?3)
+ORDER BY h.id ASC
+LIMIT ?4
+`
+
+type ListStatusChangesAfterParams struct {
+	AfterID    int64       `json:"after_id"`
+	UserID     string      `json:"user_id"`
+	EndpointID interface{} `json:"endpoint_id"`
+	Limit      int64       `json:"limit"`
+}
+
+// Status changes of the user's webhooks after a history row, oldest first, for tailing.
+// endpoint_id is ignored when NULL.
+func (q *Queries) ListStatusChangesAfter(ctx context.Context, arg ListStatusChangesAfterParams) ([]WebhookStatusHistory, error) {
+	rows, err := q.db.QueryContext(ctx, listStatusChangesAfter,
+		arg.AfterID,
+		arg.UserID,
+		arg.EndpointID,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookStatusHistory{}
+	for rows.Next() {
+		var i WebhookStatusHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Reason,
+			&i.ChangedAt,
+			&i.ChangedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWebhookStatusHistory = `-- name: ListWebhookStatusHistory :many
+SELECT id, webhook_id, from_status, to_status, reason, changed_at, changed_by FROM webhook_status_history
+WHERE webhook_id = ?
+ORDER BY id ASC
+`
+
+// System query: status changes of a webhook, oldest first. Callers check ownership of the webhook.
+func (q *Queries) ListWebhookStatusHistory(ctx context.Context, webhookID string) ([]WebhookStatusHistory, error) {
+	rows, err := q.db.QueryContext(ctx, listWebhookStatusHistory, webhookID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WebhookStatusHistory{}
+	for rows.Next() {
+		var i WebhookStatusHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Reason,
+			&i.ChangedAt,
+			&i.ChangedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listWebhooks = `-- name: ListWebhooks :many
-SELECT w.id, w.endpoint_id, w.received_at, w.headers, w.payload, w.signature_valid, w.status, w.attempts, w.last_attempt_at, w.delivered_at, w.error_message, w.notification_sent FROM webhooks w
+SELECT w.id, w.endpoint_id, w.received_at, w.headers, w.payload, w.signature_valid, w.status, w.attempts, w.last_attempt_at, w.delivered_at, w.error_message, w.notification_sent, w.replayed_at, w.event_type, w.delivery_id, w.duplicate_of, w.source_ip, w.replayed_by, w.replay_count, w.next_attempt_at, w.traceparent, w.purged_at, w.undeleted_at FROM webhooks w
 JOIN endpoints e ON w.endpoint_id = e.id
 WHERE e.user_id = ?1
   AND (?2 IS NULL OR w.endpoint_id = ?2)
   AND (?3 IS NULL OR w.status = ?3)
+  AND (?4 IS NULL OR w.event_type = ?4)
+  AND (w.purged_at IS NOT NULL) = ?5
 ORDER BY w.received_at DESC
-LIMIT ?5 OFFSET ?4
+LIMIT ?7 OFFSET ?6
 `
 
 type ListWebhooksParams struct {
 	UserID     string      `json:"user_id"`
 	EndpointID interface{} `json:"endpoint_id"`
 	Status     interface{} `json:"status"`
+	EventType  interface{} `json:"event_type"`
+	Purged     int64       `json:"purged"`
 	Offset     int64       `json:"offset"`
 	Limit      int64       `json:"limit"`
 }
@@ -507,6 +1225,8 @@ func (q *Queries) ListWebhooks(ctx context.Context, arg ListWebhooksParams) ([]W
 		arg.UserID,
 		arg.EndpointID,
 		arg.Status,
+		arg.EventType,
+		arg.Purged,
 		arg.Offset,
 		arg.Limit,
 	)
@@ -530,6 +1250,17 @@ func (q *Queries) ListWebhooks(ctx context.Context, arg ListWebhooksParams) ([]W
 			&i.DeliveredAt,
 			&i.ErrorMessage,
 			&i.NotificationSent,
+			&i.ReplayedAt,
+			&i.EventType,
+			&i.DeliveryID,
+			&i.DuplicateOf,
+			&i.SourceIp,
+			&i.ReplayedBy,
+			&i.ReplayCount,
+			&i.NextAttemptAt,
+			&i.Traceparent,
+			&i.PurgedAt,
+			&i.UndeletedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -548,12 +1279,12 @@ const markDeadLetter = `-- name: MarkDeadLetter :execrows
 UPDATE webhooks
 SET status = 'dead_letter'
 WHERE status = 'pending'
-  AND received_at < datetime('now', '-7 days')
+  AND received_at < datetime('now', '-' || CAST(?1 AS INTEGER) || ' seconds')
 `
 
-// System query: marks old pending webhooks as dead_letter (no user filter)
-func (q *Queries) MarkDeadLetter(ctx context.Context) (int64, error) {
-	result, err := q.db.ExecContext(ctx, markDeadLetter)
+// System query: marks pending webhooks older than age_seconds as dead_letter (no user filter)
+func (q *Queries) MarkDeadLetter(ctx context.Context, ageSeconds int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markDeadLetter, ageSeconds)
 	if err != nil {
 		return 0, err
 	}
@@ -572,6 +1303,57 @@ func (q *Queries) MarkNotificationSent(ctx context.Context, id string) error {
 	return err
 }
 
+const markWebhookAcknowledgedDuplicate = `-- name: MarkWebhookAcknowledgedDuplicate :one
+UPDATE webhooks
+SET status = 'acknowledged_duplicate',
+    attempts = attempts + 1,
+    last_attempt_at = datetime('now'),
+    delivered_at = datetime('now'),
+    error_message = ?1
+WHERE id = ?2
+  AND status = 'pending'
+  AND endpoint_id IN (SELECT id FROM endpoints WHERE conflict_as_duplicate = 1)
+RETURNING id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts, last_attempt_at, delivered_at, error_message, notification_sent, replayed_at, event_type, delivery_id, duplicate_of, source_ip, replayed_by, replay_count, next_attempt_at, traceparent, purged_at, undeleted_at
+`
+
+type MarkWebhookAcknowledgedDuplicateParams struct {
+	ErrorMessage sql.NullString `json:"error_message"`
+	ID           string         `json:"id"`
+}
+
+// System query: records a 409 from the destination as already processed, if
+// the webhook's endpoint opted in. Returns no rows otherwise.
+func (q *Queries) MarkWebhookAcknowledgedDuplicate(ctx context.Context, arg MarkWebhookAcknowledgedDuplicateParams) (Webhook, error) {
+	row := q.db.QueryRowContext(ctx, markWebhookAcknowledgedDuplicate, arg.ErrorMessage, arg.ID)
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.EndpointID,
+		&i.ReceivedAt,
+		&i.Headers,
+		&i.Payload,
+		&i.SignatureValid,
+		&i.Status,
+		&i.Attempts,
+		&i.LastAttemptAt,
+		&i.DeliveredAt,
+		&i.ErrorMessage,
+		&i.NotificationSent,
+		&i.ReplayedAt,
+		&i.EventType,
+		&i.DeliveryID,
+		&i.DuplicateOf,
+		&i.SourceIp,
+		&i.ReplayedBy,
+		&i.ReplayCount,
+		&i.NextAttemptAt,
+		&i.Traceparent,
+		&i.PurgedAt,
+		&i.UndeletedAt,
+	)
+	return i, err
+}
+
 const markWebhookDelivered = `-- name: MarkWebhookDelivered :one
 UPDATE webhooks
 SET status = 'delivered',
@@ -580,7 +1362,7 @@ SET status = 'delivered',
     delivered_at = datetime('now'),
     error_message = NULL
 WHERE id = ?
-RETURNING id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts, last_attempt_at, delivered_at, error_message, notification_sent
+RETURNING id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts, last_attempt_at, delivered_at, error_message, notification_sent, replayed_at, event_type, delivery_id, duplicate_of, source_ip, replayed_by, replay_count, next_attempt_at, traceparent, purged_at, undeleted_at
 `
 
 // System query: no user filter (called by background dispatcher)
@@ -600,10 +1382,38 @@ func (q *Queries) MarkWebhookDelivered(ctx context.Context, id string) (Webhook,
 		&i.DeliveredAt,
 		&i.ErrorMessage,
 		&i.NotificationSent,
+		&i.ReplayedAt,
+		&i.EventType,
+		&i.DeliveryID,
+		&i.DuplicateOf,
+		&i.SourceIp,
+		&i.ReplayedBy,
+		&i.ReplayCount,
+		&i.NextAttemptAt,
+		&i.Traceparent,
+		&i.PurgedAt,
+		&i.UndeletedAt,
 	)
 	return i, err
 }
 
+const markWebhookDispatched = `-- name: MarkWebhookDispatched :exec
+UPDATE webhooks
+SET next_attempt_at = datetime('now', '+' || CAST(?1 AS INTEGER) || ' seconds')
+WHERE id = ?2 AND status = 'pending'
+`
+
+type MarkWebhookDispatchedParams struct {
+	LeaseSeconds int64  `json:"lease_seconds"`
+	ID           string `json:"id"`
+}
+
+// System query: holds a pending webhook back from dispatch for lease_seconds, while its ack is awaited (no user filter)
+func (q *Queries) MarkWebhookDispatched(ctx context.Context, arg MarkWebhookDispatchedParams) error {
+	_, err := q.db.ExecContext(ctx, markWebhookDispatched, arg.LeaseSeconds, arg.ID)
+	return err
+}
+
 const markWebhookFailed = `-- name: MarkWebhookFailed :one
 UPDATE webhooks
 SET status = 'failed',
@@ -611,7 +1421,7 @@ SET status = 'failed',
     last_attempt_at = datetime('now'),
     error_message = ?
 WHERE id = ?
-RETURNING id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts, last_attempt_at, delivered_at, error_message, notification_sent
+RETURNING id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts, last_attempt_at, delivered_at, error_message, notification_sent, replayed_at, event_type, delivery_id, duplicate_of, source_ip, replayed_by, replay_count, next_attempt_at, traceparent, purged_at, undeleted_at
 `
 
 type MarkWebhookFailedParams struct {
@@ -636,27 +1446,144 @@ func (q *Queries) MarkWebhookFailed(ctx context.Context, arg MarkWebhookFailedPa
 		&i.DeliveredAt,
 		&i.ErrorMessage,
 		&i.NotificationSent,
+		&i.ReplayedAt,
+		&i.EventType,
+		&i.DeliveryID,
+		&i.DuplicateOf,
+		&i.SourceIp,
+		&i.ReplayedBy,
+		&i.ReplayCount,
+		&i.NextAttemptAt,
+		&i.Traceparent,
+		&i.PurgedAt,
+		&i.UndeletedAt,
 	)
 	return i, err
 }
 
+const markWebhookSkipped = `-- name: MarkWebhookSkipped :exec
+UPDATE webhooks
+SET status = 'skipped'
+WHERE id = ? AND status = 'pending'
+`
+
+// System query: marks a pending webhook whose event type the hub doesn't want (no user filter)
+func (q *Queries) MarkWebhookSkipped(ctx context.Context, id string) error {
+	_, err := q.db.ExecContext(ctx, markWebhookSkipped, id)
+	return err
+}
+
+const purgeDeadLetterWebhooks = `-- name: PurgeDeadLetterWebhooks :execrows
+UPDATE webhooks
+SET purged_at = datetime('now')
+WHERE status = 'dead_letter'
+  AND received_at < datetime('now', '-' || CAST(?1 AS INTEGER) || ' seconds')
+  AND purged_at IS NULL
+  AND (undeleted_at IS NULL OR undeleted_at < datetime('now', '-' || CAST(?1 AS INTEGER) || ' seconds'))
+`
+
+// System query: purges old dead letter webhooks, deleted after the grace period (no user filter)
+func (q *Queries) PurgeDeadLetterWebhooks(ctx context.Context, ageSeconds int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeDeadLetterWebhooks, ageSeconds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeDeliveredWebhooks = `-- name: PurgeDeliveredWebhooks :execrows
+UPDATE webhooks
+SET purged_at = datetime('now')
+WHERE status IN ('delivered', 'acknowledged_duplicate')
+  AND delivered_at < datetime('now', '-' || CAST(?1 AS INTEGER) || ' seconds')
+  AND purged_at IS NULL
+  AND (undeleted_at IS NULL OR undeleted_at < datetime('now', '-' || CAST(?1 AS INTEGER) || ' seconds'))
+`
+
+// System query: purges old delivered and acknowledged duplicate webhooks, deleted after the grace period (no user filter)
+func (q *Queries) PurgeDeliveredWebhooks(ctx context.Context, ageSeconds int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeDeliveredWebhooks, ageSeconds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeSkippedWebhooks = `-- name: PurgeSkippedWebhooks :execrows
+UPDATE webhooks
+SET purged_at = datetime('now')
+WHERE status = 'skipped'
+  AND received_at < datetime('now', '-' || CAST(?1 AS INTEGER) || ' seconds')
+  AND purged_at IS NULL
+  AND (undeleted_at IS NULL OR undeleted_at < datetime('now', '-' || CAST(?1 AS INTEGER) || ' seconds'))
+`
+
+// System query: purges old skipped webhooks, including honeypot hits, deleted after the grace period (no user filter)
+func (q *Queries) PurgeSkippedWebhooks(ctx context.Context, ageSeconds int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeSkippedWebhooks, ageSeconds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeExportedWebhook = `-- name: PurgeExportedWebhook :execrows
+UPDATE webhooks
+SET purged_at = datetime('now')
+WHERE id = ? AND status = ? AND purged_at IS NULL
+`
+
+type PurgeExportedWebhookParams struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+// System query: purges an exported webhook unless its status changed since, e.g. by a replay (no user filter)
+func (q *Queries) PurgeExportedWebhook(ctx context.Context, arg PurgeExportedWebhookParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeExportedWebhook, arg.ID, arg.Status)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const purgeFailedWebhooks = `-- name: PurgeFailedWebhooks :execrows
+UPDATE webhooks
+SET purged_at = datetime('now')
+WHERE status = 'failed'
+  AND last_attempt_at < datetime('now', '-' || CAST(?1 AS INTEGER) || ' seconds')
+  AND purged_at IS NULL
+  AND (undeleted_at IS NULL OR undeleted_at < datetime('now', '-' || CAST(?1 AS INTEGER) || ' seconds'))
+`
+
+// System query: purges old failed webhooks, deleted after the grace period (no user filter)
+func (q *Queries) PurgeFailedWebhooks(ctx context.Context, ageSeconds int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeFailedWebhooks, ageSeconds)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const recordWebhookAttempt = `-- name: RecordWebhookAttempt :one
 UPDATE webhooks
 SET attempts = attempts + 1,
     last_attempt_at = datetime('now'),
-    error_message = ?
-WHERE id = ?
-RETURNING id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts, last_attempt_at, delivered_at, error_message, notification_sent
+    next_attempt_at = datetime('now', '+' || CAST(?1 AS INTEGER) || ' seconds'),
+    error_message = ?2
+WHERE id = ?3
+RETURNING id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts, last_attempt_at, delivered_at, error_message, notification_sent, replayed_at, event_type, delivery_id, duplicate_of, source_ip, replayed_by, replay_count, next_attempt_at, traceparent, purged_at, undeleted_at
 `
 
 type RecordWebhookAttemptParams struct {
-	ErrorMessage sql.NullString `json:"error_message"`
-	ID           string         `json:"id"`
+	RetryDelaySeconds int64          `json:"retry_delay_seconds"`
+	ErrorMessage      sql.NullString `json:"error_message"`
+	ID                string         `json:"id"`
 }
 
 // System query: no user filter (called by background dispatcher)
 func (q *Queries) RecordWebhookAttempt(ctx context.Context, arg RecordWebhookAttemptParams) (Webhook, error) {
-	row := q.db.QueryRowContext(ctx, recordWebhookAttempt, arg.ErrorMessage, arg.ID)
+	row := q.db.QueryRowContext(ctx, recordWebhookAttempt, arg.RetryDelaySeconds, arg.ErrorMessage, arg.ID)
 	var i Webhook
 	err := row.Scan(
 		&i.ID,
@@ -671,6 +1598,17 @@ func (q *Queries) RecordWebhookAttempt(ctx context.Context, arg RecordWebhookAtt
 		&i.DeliveredAt,
 		&i.ErrorMessage,
 		&i.NotificationSent,
+		&i.ReplayedAt,
+		&i.EventType,
+		&i.DeliveryID,
+		&i.DuplicateOf,
+		&i.SourceIp,
+		&i.ReplayedBy,
+		&i.ReplayCount,
+		&i.NextAttemptAt,
+		&i.Traceparent,
+		&i.PurgedAt,
+		&i.UndeletedAt,
 	)
 	return i, err
 }
@@ -680,22 +1618,75 @@ UPDATE webhooks
 SET status = 'pending',
     attempts = 0,
     last_attempt_at = NULL,
+    next_attempt_at = NULL,
     delivered_at = NULL,
     error_message = NULL,
-    notification_sent = 0
+    notification_sent = 0,
+    replayed_at = datetime('now'),
+    replayed_by = ?,
+    replay_count = replay_count + 1
 WHERE webhooks.id = ?
+  AND webhooks.purged_at IS NULL
   AND webhooks.endpoint_id IN (SELECT e.id FROM endpoints e WHERE e.user_id = ?)
-RETURNING id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts, last_attempt_at, delivered_at, error_message, notification_sent
+RETURNING id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts, last_attempt_at, delivered_at, error_message, notification_sent, replayed_at, event_type, delivery_id, duplicate_of, source_ip, replayed_by, replay_count, next_attempt_at, traceparent, purged_at, undeleted_at
 `
 
 type ResetWebhookForReplayParams struct {
-	ID     string `json:"id"`
-	UserID string `json:"user_id"`
+	ReplayedBy sql.NullString `json:"replayed_by"`
+	ID         string         `json:"id"`
+	UserID     string         `json:"user_id"`
 }
 
 // User-facing query: validates endpoint ownership via subquery
 func (q *Queries) ResetWebhookForReplay(ctx context.Context, arg ResetWebhookForReplayParams) (Webhook, error) {
-	row := q.db.QueryRowContext(ctx, resetWebhookForReplay, arg.ID, arg.UserID)
+	row := q.db.QueryRowContext(ctx, resetWebhookForReplay, arg.ReplayedBy, arg.ID, arg.UserID)
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.EndpointID,
+		&i.ReceivedAt,
+		&i.Headers,
+		&i.Payload,
+		&i.SignatureValid,
+		&i.Status,
+		&i.Attempts,
+		&i.LastAttemptAt,
+		&i.DeliveredAt,
+		&i.ErrorMessage,
+		&i.NotificationSent,
+		&i.ReplayedAt,
+		&i.EventType,
+		&i.DeliveryID,
+		&i.DuplicateOf,
+		&i.SourceIp,
+		&i.ReplayedBy,
+		&i.ReplayCount,
+		&i.NextAttemptAt,
+		&i.Traceparent,
+		&i.PurgedAt,
+		&i.UndeletedAt,
+	)
+	return i, err
+}
+
+const undeleteWebhook = `-- name: UndeleteWebhook :one
+UPDATE webhooks
+SET purged_at = NULL,
+    undeleted_at = datetime('now')
+WHERE webhooks.id = ?
+  AND webhooks.purged_at IS NOT NULL
+  AND webhooks.endpoint_id IN (SELECT e.id FROM endpoints e WHERE e.user_id = ?)
+RETURNING id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts, last_attempt_at, delivered_at, error_message, notification_sent, replayed_at, event_type, delivery_id, duplicate_of, source_ip, replayed_by, replay_count, next_attempt_at, traceparent, purged_at, undeleted_at
+`
+
+type UndeleteWebhookParams struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+}
+
+// User-facing query: restores a purged webhook within the grace period, validates ownership via subquery
+func (q *Queries) UndeleteWebhook(ctx context.Context, arg UndeleteWebhookParams) (Webhook, error) {
+	row := q.db.QueryRowContext(ctx, undeleteWebhook, arg.ID, arg.UserID)
 	var i Webhook
 	err := row.Scan(
 		&i.ID,
@@ -710,6 +1701,17 @@ func (q *Queries) ResetWebhookForReplay(ctx context.Context, arg ResetWebhookFor
 		&i.DeliveredAt,
 		&i.ErrorMessage,
 		&i.NotificationSent,
+		&i.ReplayedAt,
+		&i.EventType,
+		&i.DeliveryID,
+		&i.DuplicateOf,
+		&i.SourceIp,
+		&i.ReplayedBy,
+		&i.ReplayCount,
+		&i.NextAttemptAt,
+		&i.Traceparent,
+		&i.PurgedAt,
+		&i.UndeletedAt,
 	)
 	return i, err
 }
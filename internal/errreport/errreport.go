@@ -0,0 +1,241 @@
+// Package errreport reports panics and error-level logs to Sentry, so crashes
+// on servers and user machines are seen instead of lost in a journal. It
+// speaks Sentry's envelope API directly; any service accepting that API (for
+// example GlitchTip) works too.
+//
+// All methods are safe to call on a nil *Reporter, which reports nothing.
+package errreport
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// queueSize bounds the events waiting to be sent; more are dropped.
+	queueSize = 100
+	// sendTimeout bounds a single upload.
+	sendTimeout = 10 * time.Second
+)
+
+// Options tag every event.
+type Options struct {
+	Release     string // e.g. hookly@0.1.0
+	Environment string // e.g. production
+}
+
+// Reporter sends events to Sentry in the background.
+type Reporter struct {
+	dsn        string
+	endpoint   string
+	authHeader string
+	opts       Options
+	serverName string
+	client     *http.Client
+
+	events    chan []byte
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// New creates a reporter for a Sentry DSN such as
+// https://<key>@o123.ingest.sentry.io/456.
+func New(dsn string, opts Options) (*Reporter, error) {
+	endpoint, key, err := parseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	hostname, _ := os.Hostname()
+	r := &Reporter{
+		dsn:        dsn,
+		endpoint:   endpoint,
+		authHeader: fmt.Sprintf("Sentry sentry_version=7, sentry_key=%s, sentry_client=hookly-errreport/1.0", key),
+		opts:       opts,
+		serverName: hostname,
+		client:     &http.Client{Timeout: sendTimeout},
+		events:     make(chan []byte, queueSize),
+		done:       make(chan struct{}),
+	}
+	go r.run()
+	return r, nil
+}
+
+// Install creates a reporter for dsn and wraps the default slog handler so
+// error logs are reported. It returns nil, reporting nothing, if dsn is empty
+// or invalid.
+func Install(dsn string, opts Options) *Reporter {
+	if dsn == "" {
+		return nil
+	}
+	r, err := New(dsn, opts)
+	if err != nil {
+		slog.Warn("error reporting disabled", "error", err)
+		return nil
+	}
+	slog.SetDefault(slog.New(r.Handler(slog.Default().Handler())))
+	return r
+}
+
+// CheckDSN reports whether dsn is a valid Sentry DSN.
+func CheckDSN(dsn string) error {
+	_, _, err := parseDSN(dsn)
+	return err
+}
+
+// parseDSN returns the envelope endpoint and public key of a DSN.
+func parseDSN(dsn string) (endpoint, key string, err error) {
+	u, err := url.Parse(dsn)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", "", fmt.Errorf("invalid DSN: expected https://<key>@<host>/<project>")
+	}
+	if u.User == nil || u.User.Username() == "" {
+		return "", "", fmt.Errorf("invalid DSN: missing public key")
+	}
+	path := strings.TrimSuffix(u.Path, "/")
+	i := strings.LastIndex(path, "/")
+	if i < 0 || path[i+1:] == "" {
+		return "", "", fmt.Errorf("invalid DSN: missing project ID")
+	}
+	prefix, project := path[:i], path[i+1:]
+	return fmt.Sprintf("%s://%s%s/api/%s/envelope/", u.Scheme, u.Host, prefix, project), u.User.Username(), nil
+}
+
+// CaptureError reports an error with extra context.
+func (r *Reporter) CaptureError(msg string, extra map[string]any) {
+	if r == nil {
+		return
+	}
+	r.enqueue("error", msg, extra)
+}
+
+// CapturePanic reports a recovered panic with the stack of the goroutine
+// that panicked. Call it from the deferred function that recovered.
+func (r *Reporter) CapturePanic(v any) {
+	if r == nil {
+		return
+	}
+	r.enqueue("fatal", fmt.Sprintf("panic: %v", v), map[string]any{"stack": string(debug.Stack())})
+}
+
+// Recover reports a panic and then resumes panicking, so the process still
+// crashes as it would without reporting. Use it as the first deferred call of
+// main and of long-running goroutines:
+//
+//	defer reporter.Recover()
+func (r *Reporter) Recover() {
+	if r == nil {
+		return
+	}
+	if v := recover(); v != nil {
+		r.CapturePanic(v)
+		r.Close(5 * time.Second)
+		panic(v)
+	}
+}
+
+// Close sends queued events, waiting at most timeout, and stops the reporter.
+func (r *Reporter) Close(timeout time.Duration) {
+	if r == nil {
+		return
+	}
+	r.closeOnce.Do(func() { close(r.events) })
+	select {
+	case <-r.done:
+	case <-time.After(timeout):
+	}
+}
+
+// enqueue builds an event and queues it, dropping it if the queue is full or
+// the reporter is closed.
+func (r *Reporter) enqueue(level, msg string, extra map[string]any) {
+	envelope, err := r.envelope(level, msg, extra)
+	if err != nil {
+		return
+	}
+	defer func() { recover() }() // Send on a closed queue
+	select {
+	case r.events <- envelope:
+	default:
+	}
+}
+
+// envelope encodes an event in Sentry's envelope format: a header line, an
+// item header line and the event.
+func (r *Reporter) envelope(level, msg string, extra map[string]any) ([]byte, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+	eventID := hex.EncodeToString(id)
+	now := time.Now().UTC().Format(time.RFC3339Nano)
+
+	event, err := json.Marshal(map[string]any{
+		"event_id":    eventID,
+		"timestamp":   now,
+		"platform":    "go",
+		"level":       level,
+		"logger":      "slog",
+		"release":     r.opts.Release,
+		"environment": r.opts.Environment,
+		"server_name": r.serverName,
+		"message":     map[string]string{"formatted": msg},
+		"extra":       extra,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	header, _ := json.Marshal(map[string]string{"event_id": eventID, "sent_at": now, "dsn": r.dsn})
+	item, _ := json.Marshal(map[string]any{"type": "event", "length": len(event)})
+	buf.Write(header)
+	buf.WriteByte('\n')
+	buf.Write(item)
+	buf.WriteByte('\n')
+	buf.Write(event)
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func (r *Reporter) run() {
+	defer close(r.done)
+	for envelope := range r.events {
+		if err := r.send(envelope); err != nil {
+			// Warn rather than Error, or the failure would be reported again
+			slog.Warn("error report not sent", "error", err)
+		}
+	}
+}
+
+func (r *Reporter) send(envelope []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.endpoint, bytes.NewReader(envelope))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-sentry-envelope")
+	req.Header.Set("X-Sentry-Auth", r.authHeader)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("sentry returned %d", resp.StatusCode)
+	}
+	return nil
+}
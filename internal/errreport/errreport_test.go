@@ -0,0 +1,98 @@
+package errreport
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseDSN(t *testing.T) {
+	endpoint, key, err := parseDSN("https://abc123@o1.ingest.sentry.io/42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if endpoint != "https://o1.ingest.sentry.io/api/42/envelope/" || key != "abc123" {
+		t.Errorf("endpoint = %q, key = %q", endpoint, key)
+	}
+
+	endpoint, _, err = parseDSN("http://k@glitchtip.local/sentry/7")
+	if err != nil || endpoint != "http://glitchtip.local/sentry/api/7/envelope/" {
+		t.Errorf("path prefix: endpoint = %q, err = %v", endpoint, err)
+	}
+
+	for _, dsn := range []string{"", "https://o1.ingest.sentry.io/42", "https://k@o1.ingest.sentry.io/", "ftp://k@host/1"} {
+		if err := CheckDSN(dsn); err == nil {
+			t.Errorf("CheckDSN(%q) succeeded", dsn)
+		}
+	}
+}
+
+func TestHandlerReportsErrors(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		events []map[string]any
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.Header.Get("X-Sentry-Auth"), "sentry_key=key") {
+			t.Errorf("auth header = %q", r.Header.Get("X-Sentry-Auth"))
+		}
+		body, _ := io.ReadAll(r.Body)
+		lines := bytes.Split(bytes.TrimSpace(body), []byte("\n"))
+		if len(lines) != 3 {
+			t.Errorf("envelope has %d lines, want 3", len(lines))
+			return
+		}
+		var event map[string]any
+		if err := json.Unmarshal(lines[2], &event); err != nil {
+			t.Errorf("decode event: %v", err)
+		}
+		mu.Lock()
+		events = append(events, event)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	r, err := New(strings.Replace(srv.URL, "http://", "http://key@", 1)+"/1", Options{Release: "hookly@test"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var logs bytes.Buffer
+	logger := slog.New(r.Handler(slog.NewTextHandler(&logs, nil))).With("hub_id", "hub-1")
+	logger.Info("not reported")
+	logger.Error("delivery failed", "error", errors.New("connection refused"))
+	r.Close(5 * time.Second)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(events) != 1 {
+		t.Fatalf("got %d events, want 1", len(events))
+	}
+	ev := events[0]
+	extra, _ := ev["extra"].(map[string]any)
+	if ev["release"] != "hookly@test" || ev["level"] != "error" ||
+		extra["hub_id"] != "hub-1" || extra["error"] != "connection refused" {
+		t.Errorf("event = %v", ev)
+	}
+	if !strings.Contains(logs.String(), "not reported") || !strings.Contains(logs.String(), "delivery failed") {
+		t.Errorf("records not passed on:\n%s", logs.String())
+	}
+}
+
+func TestNilReporter(t *testing.T) {
+	var r *Reporter
+	r.CaptureError("ignored", nil)
+	r.Close(time.Millisecond)
+	h := slog.NewTextHandler(io.Discard, nil)
+	if r.Handler(h) != slog.Handler(h) {
+		t.Error("nil reporter wrapped the handler")
+	}
+}
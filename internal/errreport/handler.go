@@ -0,0 +1,66 @@
+package errreport
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Handler wraps a slog handler so error-level records are also reported.
+// Records are always passed on to next.
+func (r *Reporter) Handler(next slog.Handler) slog.Handler {
+	if r == nil {
+		return next
+	}
+	return &handler{next: next, reporter: r}
+}
+
+type handler struct {
+	next     slog.Handler
+	reporter *Reporter
+	attrs    []slog.Attr // From WithAttrs, keys prefixed with their groups
+	group    string      // Current group prefix, e.g. "request."
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= slog.LevelError || h.next.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, rec slog.Record) error {
+	if rec.Level >= slog.LevelError {
+		extra := make(map[string]any, len(h.attrs)+rec.NumAttrs())
+		for _, a := range h.attrs {
+			extra[a.Key] = a.Value.Resolve().Any()
+		}
+		rec.Attrs(func(a slog.Attr) bool {
+			extra[h.group+a.Key] = a.Value.Resolve().Any()
+			return true
+		})
+		// Errors don't marshal to JSON, so send their text
+		for k, v := range extra {
+			if err, ok := v.(error); ok {
+				extra[k] = err.Error()
+			}
+		}
+		h.reporter.CaptureError(rec.Message, extra)
+	}
+	if !h.next.Enabled(ctx, rec.Level) {
+		return nil
+	}
+	return h.next.Handle(ctx, rec)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	prefixed := make([]slog.Attr, len(h.attrs), len(h.attrs)+len(attrs))
+	copy(prefixed, h.attrs)
+	for _, a := range attrs {
+		prefixed = append(prefixed, slog.Attr{Key: h.group + a.Key, Value: a.Value})
+	}
+	return &handler{next: h.next.WithAttrs(attrs), reporter: h.reporter, attrs: prefixed, group: h.group}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &handler{next: h.next.WithGroup(name), reporter: h.reporter, attrs: h.attrs, group: h.group + name + "."}
+}
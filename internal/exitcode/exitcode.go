@@ -0,0 +1,85 @@
+// Package exitcode defines the exit codes of the hookly CLI, so wrapper
+// scripts and service managers can tell why the relay stopped without
+// parsing its output.
+package exitcode
+
+import (
+	"errors"
+	"net"
+
+	"hooks.dx314.com/internal/relay"
+)
+
+// Exit codes. 2 is left to shells and usage errors.
+const (
+	OK          = 0
+	Error       = 1   // Any other failure
+	Auth        = 3   // Not logged in, or the token is invalid, expired or revoked
+	Config      = 4   // hookly.yaml is missing or invalid
+	Endpoint    = 5   // An endpoint doesn't exist, belongs to another user, or none is configured
+	Network     = 6   // A network failure retrying can't fix, such as a listen address in use
+	Interrupted = 130 // Ctrl-C, as shells report it
+)
+
+// exitError is an error that exits with a code.
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+func (e *exitError) Unwrap() error { return e.err }
+
+// With marks err to exit with code. It returns nil if err is nil.
+func With(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: code, err: err}
+}
+
+// Code returns the exit code for err: the code it was marked With, or the
+// class of a relay error.
+func Code(err error) int {
+	if err == nil {
+		return OK
+	}
+	var exit *exitError
+	if errors.As(err, &exit) {
+		return exit.code
+	}
+	switch {
+	case errors.Is(err, relay.ErrTokenInvalid), errors.Is(err, relay.ErrTokenRevoked):
+		return Auth
+	case errors.Is(err, relay.ErrEndpointNotFound), errors.Is(err, relay.ErrEndpointForbidden), errors.Is(err, relay.ErrNoEndpoints):
+		return Endpoint
+	}
+	// The relay retries failed connections, so a network error that stops
+	// it is one it can't retry, like failing to listen
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return Network
+	}
+	return Error
+}
+
+// Name returns the class of an exit code as a short name, such as auth, for
+// telemetry. Unknown codes are "error".
+func Name(code int) string {
+	switch code {
+	case OK:
+		return "ok"
+	case Auth:
+		return "auth"
+	case Config:
+		return "config"
+	case Endpoint:
+		return "endpoint"
+	case Network:
+		return "network"
+	case Interrupted:
+		return "interrupted"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,48 @@
+package exitcode
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+	"testing"
+
+	"hooks.dx314.com/internal/relay"
+	"hooks.dx314.com/internal/telemetry"
+)
+
+func TestCode(t *testing.T) {
+	_, listenErr := net.Listen("tcp", "256.0.0.1:0")
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, OK},
+		{"other", errors.New("boom"), Error},
+		{"marked", With(Config, errors.New("bad yaml")), Config},
+		{"marked wrapped", fmt.Errorf("load: %w", With(Auth, errors.New("not logged in"))), Auth},
+		{"token revoked", fmt.Errorf("relay error: %w", relay.ErrTokenRevoked), Auth},
+		{"endpoint not found", relay.ErrEndpointNotFound, Endpoint},
+		{"no endpoints", relay.ErrNoEndpoints, Endpoint},
+		{"listen", fmt.Errorf("status server: %w", listenErr), Network},
+	}
+	for _, tt := range tests {
+		if got := Code(tt.err); got != tt.want {
+			t.Errorf("%s: Code(%v) = %d, want %d", tt.name, tt.err, got, tt.want)
+		}
+	}
+
+	if With(Config, nil) != nil {
+		t.Error("With(code, nil) should be nil")
+	}
+}
+
+func TestNameIsTelemetryClass(t *testing.T) {
+	for _, code := range []int{Error, Auth, Config, Endpoint, Network, Interrupted, 42} {
+		if name := Name(code); !slices.Contains(telemetry.ErrorClasses, name) {
+			t.Errorf("Name(%d) = %q is not a telemetry error class", code, name)
+		}
+	}
+}
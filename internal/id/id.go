@@ -1,14 +1,125 @@
 // Package id provides centralized ID generation for hookly.
+//
+// Endpoint IDs are the secret part of webhook URLs: anyone who knows one can
+// send webhooks to it, so they must not be guessable. Their format can be
+// changed with Configure, but never below MinEndpointIDBits of entropy.
 package id
 
-import gonanoid "github.com/matoous/go-nanoid/v2"
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
 
-// EndpointIDLength is the length of endpoint IDs.
+	gonanoid "github.com/matoous/go-nanoid/v2"
+)
+
+// DefaultAlphabet is the URL-safe alphabet of nanoid.
+const DefaultAlphabet = "_-0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+
+// EndpointIDLength is the default length of endpoint IDs.
 // 64 characters with 64-char alphabet provides ~384 bits of entropy.
 const EndpointIDLength = 64
 
+// WebhookIDLength is the default length of webhook IDs, ~126 bits of entropy.
+const WebhookIDLength = 21
+
+// Minimum entropy of configured formats. Webhook IDs are only used behind
+// auth, so they only need to be unique.
+const (
+	MinEndpointIDBits = 128
+	MinWebhookIDBits  = 64
+)
+
+// Format is how IDs are generated: Length characters from Alphabet.
+type Format struct {
+	Alphabet string
+	Length   int
+}
+
+// Bits is the entropy of an ID of the format.
+func (f Format) Bits() float64 {
+	return float64(f.Length) * math.Log2(float64(len(f.Alphabet)))
+}
+
+// Validate checks that the alphabet is URL-safe without repeated characters
+// and that IDs have at least minBits of entropy.
+func (f Format) Validate(minBits float64) error {
+	if len(f.Alphabet) < 2 {
+		return fmt.Errorf("alphabet needs at least 2 characters")
+	}
+	for i, c := range f.Alphabet {
+		if !strings.ContainsRune(DefaultAlphabet, c) {
+			return fmt.Errorf("alphabet character %q is not one of %s", c, DefaultAlphabet)
+		}
+		if strings.IndexRune(f.Alphabet, c) != i {
+			return fmt.Errorf("alphabet repeats %q", c)
+		}
+	}
+	if f.Length < 1 || f.Length > 255 {
+		return fmt.Errorf("length %d must be between 1 and 255", f.Length)
+	}
+	if bits := f.Bits(); bits < minBits {
+		return fmt.Errorf("%d characters from a %d-character alphabet give %.0f bits of entropy, at least %.0f are needed", f.Length, len(f.Alphabet), bits, minBits)
+	}
+	return nil
+}
+
+// Matches reports whether s could be an ID of the format.
+func (f Format) Matches(s string) bool {
+	if len(s) != f.Length {
+		return false
+	}
+	for _, c := range s {
+		if !strings.ContainsRune(f.Alphabet, c) {
+			return false
+		}
+	}
+	return true
+}
+
+func (f Format) generate() string {
+	id, _ := gonanoid.Generate(f.Alphabet, f.Length) // Only fails on invalid formats
+	return id
+}
+
+var (
+	mu             sync.RWMutex
+	endpointFormat = Format{Alphabet: DefaultAlphabet, Length: EndpointIDLength}
+	webhookFormat  = Format{Alphabet: DefaultAlphabet, Length: WebhookIDLength}
+)
+
+// Configure sets the formats of new endpoint and webhook IDs; existing IDs
+// keep working. Call it at startup, before IDs are generated.
+func Configure(endpoint, webhook Format) error {
+	if err := endpoint.Validate(MinEndpointIDBits); err != nil {
+		return fmt.Errorf("endpoint IDs: %w", err)
+	}
+	if err := webhook.Validate(MinWebhookIDBits); err != nil {
+		return fmt.Errorf("webhook IDs: %w", err)
+	}
+	mu.Lock()
+	endpointFormat, webhookFormat = endpoint, webhook
+	mu.Unlock()
+	return nil
+}
+
+// EndpointFormat returns the format of new endpoint IDs.
+func EndpointFormat() Format {
+	mu.RLock()
+	defer mu.RUnlock()
+	return endpointFormat
+}
+
 // NewEndpointID generates a new endpoint ID with maximum security.
 func NewEndpointID() string {
-	id, _ := gonanoid.New(EndpointIDLength)
-	return id
+	return EndpointFormat().generate()
+}
+
+// NewWebhookID generates a new webhook ID.
+func NewWebhookID() string {
+	mu.RLock()
+	f := webhookFormat
+	mu.RUnlock()
+	return f.generate()
 }
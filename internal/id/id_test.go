@@ -1,6 +1,9 @@
 package id
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestNewEndpointID(t *testing.T) {
 	id := NewEndpointID()
@@ -19,3 +22,38 @@ func TestNewEndpointIDUnique(t *testing.T) {
 		ids[id] = true
 	}
 }
+
+func TestConfigure(t *testing.T) {
+	t.Cleanup(func() {
+		Configure(Format{DefaultAlphabet, EndpointIDLength}, Format{DefaultAlphabet, WebhookIDLength})
+	})
+
+	hex := "0123456789abcdef"
+	if err := Configure(Format{hex, 40}, Format{hex, 16}); err != nil {
+		t.Fatalf("configure: %v", err)
+	}
+	if id := NewEndpointID(); len(id) != 40 || strings.Trim(id, hex) != "" || !EndpointFormat().Matches(id) {
+		t.Errorf("endpoint ID %q", id)
+	}
+	if id := NewWebhookID(); len(id) != 16 || strings.Trim(id, hex) != "" {
+		t.Errorf("webhook ID %q", id)
+	}
+	if EndpointFormat().Matches("abc") || EndpointFormat().Matches(strings.Repeat("g", 40)) {
+		t.Error("Matches accepted an ID of another format")
+	}
+
+	for _, tc := range []struct{ endpoint, webhook Format }{
+		{Format{hex, 31}, Format{hex, 16}},                // 124 bits
+		{Format{hex, 40}, Format{hex, 15}},                // 60 bits
+		{Format{"ab/", 200}, Format{hex, 16}},             // Not URL-safe
+		{Format{"0123456789" + "0", 64}, Format{hex, 16}}, // Repeated character
+		{Format{DefaultAlphabet, 300}, Format{hex, 16}},
+	} {
+		if err := Configure(tc.endpoint, tc.webhook); err == nil {
+			t.Errorf("Configure(%+v, %+v) succeeded", tc.endpoint, tc.webhook)
+		}
+	}
+	if EndpointFormat().Length != 40 {
+		t.Error("a rejected format was applied")
+	}
+}
@@ -0,0 +1,261 @@
+// Package jobs provides a small persistent job queue for background side
+// effects such as notifications. Jobs are stored in the database, so they
+// survive restarts, and failed runs are retried with backoff.
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+
+	"hooks.dx314.com/internal/db"
+)
+
+const (
+	// PollInterval is how often the worker checks for due jobs when it
+	// isn't woken by an enqueue.
+	PollInterval = 5 * time.Second
+	// MaxAttempts is how many times a job runs before it is marked failed.
+	MaxAttempts = 8
+
+	batchSize    = 50
+	runTimeout   = time.Minute
+	baseDelay    = 10 * time.Second
+	maxDelay     = time.Hour
+	statusFailed = "failed"
+)
+
+// Outcome is the result of a run of a job.
+type Outcome struct {
+	At  time.Time
+	Err error // Nil if the job succeeded
+}
+
+// Handler runs a job with its JSON payload. An error schedules a retry.
+type Handler func(ctx context.Context, payload []byte) error
+
+// Queue stores jobs and runs them with the handler registered for their kind.
+type Queue struct {
+	queries *db.Queries
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+	outcomes map[string]Outcome // Last run by kind, see LastOutcome
+
+	wake chan struct{}
+}
+
+// New creates a job queue.
+func New(queries *db.Queries) *Queue {
+	return &Queue{
+		queries:  queries,
+		handlers: make(map[string]Handler),
+		outcomes: make(map[string]Outcome),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Register sets the handler for a job kind.
+func (q *Queue) Register(kind string, h Handler) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.handlers[kind] = h
+}
+
+// Enqueue stores a job to run as soon as the worker picks it up. The payload
+// is encoded as JSON.
+func (q *Queue) Enqueue(ctx context.Context, kind string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("encode %s job: %w", kind, err)
+	}
+	id, err := gonanoid.New()
+	if err != nil {
+		return err
+	}
+	if err := q.queries.EnqueueJob(ctx, db.EnqueueJobParams{
+		ID:      id,
+		Kind:    kind,
+		Payload: string(data),
+	}); err != nil {
+		return fmt.Errorf("enqueue %s job: %w", kind, err)
+	}
+
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Run processes jobs until ctx is cancelled. Jobs left running by a previous
+// process are requeued first.
+func (q *Queue) Run(ctx context.Context) error {
+	if n, err := q.queries.ResetRunningJobs(ctx); err != nil {
+		slog.Error("failed to requeue interrupted jobs", "error", err)
+	} else if n > 0 {
+		slog.Info("requeued interrupted jobs", "count", n)
+	}
+
+	ticker := time.NewTicker(PollInterval)
+	defer ticker.Stop()
+
+	for {
+		q.RunDue(ctx)
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		case <-q.wake:
+		}
+	}
+}
+
+// RunDue runs the jobs that are currently due and returns how many ran.
+func (q *Queue) RunDue(ctx context.Context) int {
+	due, err := q.queries.GetDueJobs(ctx, batchSize)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("failed to get due jobs", "error", err)
+		}
+		return 0
+	}
+
+	ran := 0
+	for _, job := range due {
+		if ctx.Err() != nil {
+			break
+		}
+		claimed, err := q.queries.ClaimJob(ctx, job.ID)
+		if err != nil {
+			slog.Error("failed to claim job", "job_id", job.ID, "error", err)
+			continue
+		}
+		if claimed == 0 {
+			continue
+		}
+		q.runJob(ctx, job.ID, job.Kind, job.Payload, int(job.Attempts)+1)
+		ran++
+	}
+	return ran
+}
+
+// runJob runs a claimed job and records the outcome.
+func (q *Queue) runJob(ctx context.Context, id, kind, payload string, attempt int) {
+	q.mu.RLock()
+	handler := q.handlers[kind]
+	q.mu.RUnlock()
+
+	var err error
+	if handler == nil {
+		err = fmt.Errorf("no handler registered for job kind %q", kind)
+	} else {
+		runCtx, cancel := context.WithTimeout(ctx, runTimeout)
+		err = runHandler(runCtx, handler, []byte(payload))
+		cancel()
+	}
+	q.mu.Lock()
+	q.outcomes[kind] = Outcome{At: time.Now(), Err: err}
+	q.mu.Unlock()
+
+	// Record the outcome even if ctx was cancelled mid-run
+	recordCtx := context.WithoutCancel(ctx)
+	if err == nil {
+		if err := q.queries.CompleteJob(recordCtx, id); err != nil {
+			slog.Error("failed to complete job", "job_id", id, "error", err)
+		}
+		return
+	}
+
+	lastError := sql.NullString{String: err.Error(), Valid: true}
+	if attempt >= MaxAttempts {
+		slog.Error("job failed permanently", "job_id", id, "kind", kind, "attempts", attempt, "error", err)
+		if err := q.queries.FailJob(recordCtx, db.FailJobParams{LastError: lastError, ID: id}); err != nil {
+			slog.Error("failed to mark job failed", "job_id", id, "error", err)
+		}
+		return
+	}
+
+	delay := retryDelay(attempt)
+	slog.Warn("job failed, will retry", "job_id", id, "kind", kind, "attempt", attempt, "retry_in", delay, "error", err)
+	if err := q.queries.RetryJob(recordCtx, db.RetryJobParams{
+		LastError:    lastError,
+		DelaySeconds: int64(delay / time.Second),
+		ID:           id,
+	}); err != nil {
+		slog.Error("failed to reschedule job", "job_id", id, "error", err)
+	}
+}
+
+// runHandler calls a handler, turning a panic into an error so one bad job
+// can't stop the worker.
+func runHandler(ctx context.Context, h Handler, payload []byte) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return h(ctx, payload)
+}
+
+// retryDelay is the backoff before the retry that follows attempt n.
+func retryDelay(attempt int) time.Duration {
+	delay := baseDelay << (attempt - 1)
+	if delay <= 0 || delay > maxDelay {
+		return maxDelay
+	}
+	return delay
+}
+
+// LastOutcome returns the outcome of the last run of a kind of job by this
+// queue, and false if none ran since it was created.
+func (q *Queue) LastOutcome(kind string) (Outcome, bool) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	o, ok := q.outcomes[kind]
+	return o, ok
+}
+
+// Stats counts jobs by kind and status.
+type Stats map[string]map[string]int64
+
+// Stats returns the number of stored jobs by kind and status.
+func (q *Queue) Stats(ctx context.Context) (Stats, error) {
+	rows, err := q.queries.GetJobStats(ctx)
+	if err != nil {
+		return nil, err
+	}
+	stats := make(Stats)
+	for _, row := range rows {
+		if stats[row.Kind] == nil {
+			stats[row.Kind] = make(map[string]int64)
+		}
+		stats[row.Kind][row.Status] = row.Count
+	}
+	return stats, nil
+}
+
+// Failed returns the number of jobs that exhausted their attempts.
+func (s Stats) Failed() int64 {
+	var n int64
+	for _, byStatus := range s {
+		n += byStatus[statusFailed]
+	}
+	return n
+}
+
+// Decode is a helper for handlers that decodes a job payload into T.
+func Decode[T any](payload []byte) (T, error) {
+	var v T
+	if err := json.Unmarshal(payload, &v); err != nil {
+		return v, fmt.Errorf("decode job payload: %w", err)
+	}
+	return v, nil
+}
@@ -0,0 +1,172 @@
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"hooks.dx314.com/internal/db"
+)
+
+func setupQueue(t *testing.T) (*Queue, *sql.DB) {
+	t.Helper()
+	conn, err := db.Open(context.Background(), filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return New(db.New(conn)), conn
+}
+
+// makeDue moves every pending job's run_at into the past, skipping backoff.
+func makeDue(t *testing.T, conn *sql.DB) {
+	t.Helper()
+	if _, err := conn.Exec(`UPDATE jobs SET run_at = datetime('now', '-1 second') WHERE status = 'pending'`); err != nil {
+		t.Fatalf("make jobs due: %v", err)
+	}
+}
+
+func TestQueueRunsJobs(t *testing.T) {
+	ctx := context.Background()
+	q, _ := setupQueue(t)
+
+	type payload struct {
+		Name string `json:"name"`
+	}
+	var got []string
+	q.Register("greet", func(_ context.Context, data []byte) error {
+		p, err := Decode[payload](data)
+		if err != nil {
+			return err
+		}
+		got = append(got, p.Name)
+		return nil
+	})
+
+	for _, name := range []string{"a", "b"} {
+		if err := q.Enqueue(ctx, "greet", payload{Name: name}); err != nil {
+			t.Fatalf("enqueue: %v", err)
+		}
+	}
+
+	if ran := q.RunDue(ctx); ran != 2 {
+		t.Fatalf("ran %d jobs, want 2", ran)
+	}
+	if len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("handled %v", got)
+	}
+	if ran := q.RunDue(ctx); ran != 0 {
+		t.Errorf("completed jobs ran again: %d", ran)
+	}
+
+	stats, err := q.Stats(ctx)
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if stats["greet"]["done"] != 2 {
+		t.Errorf("stats = %v", stats)
+	}
+	if o, ok := q.LastOutcome("greet"); !ok || o.Err != nil {
+		t.Errorf("last outcome = %+v, %v; want a success", o, ok)
+	}
+	if _, ok := q.LastOutcome("other"); ok {
+		t.Errorf("outcome for a kind that never ran")
+	}
+}
+
+func TestQueueRetriesThenFails(t *testing.T) {
+	ctx := context.Background()
+	q, conn := setupQueue(t)
+
+	calls := 0
+	q.Register("flaky", func(context.Context, []byte) error {
+		calls++
+		return errors.New("destination down")
+	})
+	if err := q.Enqueue(ctx, "flaky", nil); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+
+	q.RunDue(ctx)
+	// The retry waits for its backoff
+	if ran := q.RunDue(ctx); ran != 0 {
+		t.Fatalf("retry ran before its backoff")
+	}
+
+	for i := 1; i < MaxAttempts; i++ {
+		makeDue(t, conn)
+		q.RunDue(ctx)
+	}
+	if calls != MaxAttempts {
+		t.Errorf("handler called %d times, want %d", calls, MaxAttempts)
+	}
+
+	stats, err := q.Stats(ctx)
+	if err != nil {
+		t.Fatalf("stats: %v", err)
+	}
+	if stats.Failed() != 1 {
+		t.Errorf("failed = %d, want 1 (stats %v)", stats.Failed(), stats)
+	}
+	if o, _ := q.LastOutcome("flaky"); o.Err == nil {
+		t.Errorf("last outcome has no error")
+	}
+	makeDue(t, conn)
+	if ran := q.RunDue(ctx); ran != 0 {
+		t.Errorf("failed job ran again")
+	}
+}
+
+func TestQueueRecoversPanicsAndInterruptedJobs(t *testing.T) {
+	ctx := context.Background()
+	q, conn := setupQueue(t)
+
+	q.Register("panics", func(context.Context, []byte) error { panic("boom") })
+	if err := q.Enqueue(ctx, "panics", nil); err != nil {
+		t.Fatalf("enqueue: %v", err)
+	}
+	q.RunDue(ctx)
+
+	var lastError string
+	if err := conn.QueryRow(`SELECT last_error FROM jobs`).Scan(&lastError); err != nil {
+		t.Fatalf("query job: %v", err)
+	}
+	if lastError != "panic: boom" {
+		t.Errorf("last_error = %q", lastError)
+	}
+
+	// A job left running by a crashed process is picked up again by Run
+	if _, err := conn.Exec(`UPDATE jobs SET status = 'running', run_at = datetime('now', '-1 second')`); err != nil {
+		t.Fatalf("mark running: %v", err)
+	}
+	done := make(chan struct{})
+	q.Register("panics", func(context.Context, []byte) error {
+		close(done)
+		return nil
+	})
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go q.Run(runCtx)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("interrupted job was not requeued")
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	if d := retryDelay(1); d != baseDelay {
+		t.Errorf("retryDelay(1) = %v, want %v", d, baseDelay)
+	}
+	if d := retryDelay(2); d != 2*baseDelay {
+		t.Errorf("retryDelay(2) = %v, want %v", d, 2*baseDelay)
+	}
+	if d := retryDelay(64); d != maxDelay {
+		t.Errorf("retryDelay(64) = %v, want %v", d, maxDelay)
+	}
+}
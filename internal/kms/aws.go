@@ -0,0 +1,179 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// awsProvider unwraps data keys with AWS KMS Decrypt, e.g. the CiphertextBlob
+// of `aws kms generate-data-key --key-spec AES_256`. Credentials come from the
+// standard AWS_* environment variables.
+type awsProvider struct {
+	endpoint     string
+	region       string
+	keyID        string
+	accessKeyID  string
+	secretKey    string
+	sessionToken string
+	client       *http.Client
+	now          func() time.Time
+}
+
+func newAWSProvider(getenv func(string) string) (*awsProvider, error) {
+	p := &awsProvider{
+		endpoint:     getenv("AWS_KMS_ENDPOINT"),
+		region:       getenv("AWS_REGION"),
+		keyID:        getenv("AWS_KMS_KEY_ID"),
+		accessKeyID:  getenv("AWS_ACCESS_KEY_ID"),
+		secretKey:    getenv("AWS_SECRET_ACCESS_KEY"),
+		sessionToken: getenv("AWS_SESSION_TOKEN"),
+		client:       newHTTPClient(),
+		now:          time.Now,
+	}
+	if p.region == "" {
+		p.region = getenv("AWS_DEFAULT_REGION")
+	}
+
+	switch {
+	case p.region == "":
+		return nil, errors.New("AWS_REGION is required for the awskms key source")
+	case p.accessKeyID == "" || p.secretKey == "":
+		return nil, errors.New("AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY are required for the awskms key source")
+	}
+	if p.endpoint == "" {
+		p.endpoint = fmt.Sprintf("https://kms.%s.amazonaws.com/", p.region)
+	}
+	return p, nil
+}
+
+// Decrypt calls KMS Decrypt with a base64 CiphertextBlob.
+func (p *awsProvider) Decrypt(ctx context.Context, wrapped string) ([]byte, error) {
+	params := map[string]string{"CiphertextBlob": wrapped}
+	if p.keyID != "" {
+		params["KeyId"] = p.keyID
+	}
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService.Decrypt")
+	if p.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", p.sessionToken)
+	}
+	SignV4(req, body, p.accessKeyID, p.secretKey, p.region, "kms", p.now())
+
+	var resp struct {
+		Plaintext string `json:"Plaintext"`
+	}
+	if err := doJSON(ctx, p.client, req, &resp); err != nil {
+		return nil, fmt.Errorf("aws kms decrypt: %w", err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(resp.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("aws kms decrypt: invalid plaintext: %w", err)
+	}
+	return key, nil
+}
+
+// SignV4 signs req with AWS Signature Version 4. All headers set on req
+// before signing are signed. internal/coldstore also signs S3 requests with it.
+func SignV4(req *http.Request, body []byte, accessKeyID, secretKey, region, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	bodyHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		path,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hex.EncodeToString(bodyHash[:]),
+	}, "\n")
+
+	scope := date + "/" + region + "/" + service + "/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signature := hex.EncodeToString(hmacSHA256(signingKeyV4(secretKey, date, region, service), stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKeyID, scope, signedHeaders, signature))
+}
+
+// signingKeyV4 derives the SigV4 signing key for a date (YYYYMMDD), region and service.
+func signingKeyV4(secretKey, date, region, service string) []byte {
+	key := hmacSHA256([]byte("AWS4"+secretKey), date)
+	key = hmacSHA256(key, region)
+	key = hmacSHA256(key, service)
+	return hmacSHA256(key, "aws4_request")
+}
+
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		vs := append([]string(nil), values[k]...)
+		sort.Strings(vs)
+		for _, v := range vs {
+			parts = append(parts, awsEscape(k)+"="+awsEscape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// awsEscape percent-encodes everything except unreserved characters.
+func awsEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
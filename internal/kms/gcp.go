@@ -0,0 +1,100 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	gcpKMSEndpoint   = "https://cloudkms.googleapis.com"
+	gcpMetadataToken = "http://metadata.google.internal/computeMetadata/v1/instance/service-accounts/default/token"
+)
+
+// gcpProvider unwraps data keys with Cloud KMS decrypt. The access token is
+// GOOGLE_OAUTH_ACCESS_TOKEN if set, otherwise the service account token from
+// the GCE/Cloud Run metadata server.
+type gcpProvider struct {
+	endpoint    string
+	keyName     string
+	accessToken string
+	metadataURL string
+	client      *http.Client
+}
+
+func newGCPProvider(getenv func(string) string) (*gcpProvider, error) {
+	p := &gcpProvider{
+		endpoint:    strings.TrimSuffix(getenv("GCP_KMS_ENDPOINT"), "/"),
+		keyName:     getenv("GCP_KMS_KEY"),
+		accessToken: getenv("GOOGLE_OAUTH_ACCESS_TOKEN"),
+		metadataURL: gcpMetadataToken,
+		client:      newHTTPClient(),
+	}
+	if p.endpoint == "" {
+		p.endpoint = gcpKMSEndpoint
+	}
+	if p.keyName == "" {
+		return nil, errors.New("GCP_KMS_KEY (projects/.../locations/.../keyRings/.../cryptoKeys/...) is required for the gcpkms key source")
+	}
+	return p, nil
+}
+
+// Decrypt calls cryptoKeys.decrypt with a base64 ciphertext.
+func (p *gcpProvider) Decrypt(ctx context.Context, wrapped string) ([]byte, error) {
+	token, err := p.token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("gcp access token: %w", err)
+	}
+
+	body, err := json.Marshal(map[string]string{"ciphertext": wrapped})
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/v1/%s:decrypt", p.endpoint, p.keyName), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	var resp struct {
+		Plaintext string `json:"plaintext"`
+	}
+	if err := doJSON(ctx, p.client, req, &resp); err != nil {
+		return nil, fmt.Errorf("gcp kms decrypt: %w", err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(resp.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("gcp kms decrypt: invalid plaintext: %w", err)
+	}
+	return key, nil
+}
+
+func (p *gcpProvider) token(ctx context.Context) (string, error) {
+	if p.accessToken != "" {
+		return p.accessToken, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, p.metadataURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	var resp struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := doJSON(ctx, p.client, req, &resp); err != nil {
+		return "", fmt.Errorf("metadata server: %w", err)
+	}
+	if resp.AccessToken == "" {
+		return "", errors.New("metadata server returned no access token")
+	}
+	return resp.AccessToken, nil
+}
@@ -0,0 +1,106 @@
+// Package kms unwraps the edge encryption key with a key management service.
+//
+// With envelope encryption the data key that encrypts secrets in the database
+// is itself encrypted ("wrapped") by a master key that never leaves the KMS.
+// Only the wrapped key is configured; the plaintext data key exists solely in
+// process memory.
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Key sources for ENCRYPTION_KEY_SOURCE.
+const (
+	SourceEnv    = "env"    // Raw hex key in ENCRYPTION_KEY
+	SourceVault  = "vault"  // HashiCorp Vault transit
+	SourceAWSKMS = "awskms" // AWS KMS
+	SourceGCPKMS = "gcpkms" // Google Cloud KMS
+)
+
+// dataKeyLength is the AES-256 data key length.
+const dataKeyLength = 32
+
+// requestTimeout bounds each call to a KMS.
+const requestTimeout = 15 * time.Second
+
+// Provider decrypts a wrapped data key.
+type Provider interface {
+	Decrypt(ctx context.Context, wrapped string) ([]byte, error)
+}
+
+// Renewer is implemented by providers whose credentials need renewing while
+// the process runs. Renew blocks until ctx is cancelled.
+type Renewer interface {
+	Renew(ctx context.Context)
+}
+
+// NewProvider creates the provider for a key source, reading its settings
+// through getenv (usually os.Getenv).
+func NewProvider(source string, getenv func(string) string) (Provider, error) {
+	switch source {
+	case SourceVault:
+		return newVaultProvider(getenv)
+	case SourceAWSKMS:
+		return newAWSProvider(getenv)
+	case SourceGCPKMS:
+		return newGCPProvider(getenv)
+	default:
+		return nil, fmt.Errorf("unknown encryption key source %q (valid: %s, %s, %s, %s)", source, SourceEnv, SourceVault, SourceAWSKMS, SourceGCPKMS)
+	}
+}
+
+// LoadDataKey unwraps the data key. The result is cached by the caller for
+// the lifetime of the process, so the KMS is only called at startup.
+func LoadDataKey(ctx context.Context, p Provider, wrapped string) ([]byte, error) {
+	if wrapped == "" {
+		return nil, fmt.Errorf("ENCRYPTION_KEY_WRAPPED is required")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	key, err := p.Decrypt(ctx, wrapped)
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key: %w", err)
+	}
+	if len(key) != dataKeyLength {
+		return nil, fmt.Errorf("unwrapped data key is %d bytes, expected %d", len(key), dataKeyLength)
+	}
+	return key, nil
+}
+
+// doJSON sends a request and decodes a JSON response into out.
+// Non-2xx responses are returned as errors including the response body.
+func doJSON(ctx context.Context, client *http.Client, req *http.Request, out any) error {
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return nil
+}
+
+func newHTTPClient() *http.Client {
+	return &http.Client{Timeout: requestTimeout}
+}
@@ -0,0 +1,179 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testDataKey = bytes.Repeat([]byte{0x42}, dataKeyLength)
+
+func envMap(m map[string]string) func(string) string {
+	return func(k string) string { return m[k] }
+}
+
+func TestVaultProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/transit/decrypt/hookly" || r.Header.Get("X-Vault-Token") != "s.token" {
+			http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+			return
+		}
+		var req struct {
+			Ciphertext string `json:"ciphertext"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if req.Ciphertext != "vault:v1:wrapped" {
+			http.Error(w, `{"errors":["bad ciphertext"]}`, http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": map[string]string{"plaintext": base64.StdEncoding.EncodeToString(testDataKey)},
+		})
+	}))
+	defer srv.Close()
+
+	p, err := NewProvider(SourceVault, envMap(map[string]string{
+		"VAULT_ADDR":        srv.URL,
+		"VAULT_TOKEN":       "s.token",
+		"VAULT_TRANSIT_KEY": "hookly",
+	}))
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	key, err := LoadDataKey(context.Background(), p, "vault:v1:wrapped")
+	if err != nil {
+		t.Fatalf("LoadDataKey: %v", err)
+	}
+	if !bytes.Equal(key, testDataKey) {
+		t.Errorf("key = %x", key)
+	}
+
+	if _, err := LoadDataKey(context.Background(), p, "vault:v1:other"); err == nil || !strings.Contains(err.Error(), "bad ciphertext") {
+		t.Errorf("expected vault error, got %v", err)
+	}
+	if _, ok := p.(Renewer); !ok {
+		t.Error("vault provider should renew its token")
+	}
+}
+
+func TestAWSProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if r.Header.Get("X-Amz-Target") != "TrentService.Decrypt" ||
+			!strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") ||
+			!strings.Contains(auth, "/eu-west-1/kms/aws4_request") {
+			http.Error(w, `{"message":"bad request"}`, http.StatusBadRequest)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"Plaintext": base64.StdEncoding.EncodeToString(testDataKey),
+		})
+	}))
+	defer srv.Close()
+
+	p, err := NewProvider(SourceAWSKMS, envMap(map[string]string{
+		"AWS_KMS_ENDPOINT":      srv.URL,
+		"AWS_DEFAULT_REGION":    "eu-west-1",
+		"AWS_ACCESS_KEY_ID":     "AKID",
+		"AWS_SECRET_ACCESS_KEY": "secret",
+	}))
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	key, err := LoadDataKey(context.Background(), p, "d3JhcHBlZA==")
+	if err != nil {
+		t.Fatalf("LoadDataKey: %v", err)
+	}
+	if !bytes.Equal(key, testDataKey) {
+		t.Errorf("key = %x", key)
+	}
+}
+
+func TestSigningKeyV4(t *testing.T) {
+	// Example from the AWS documentation on deriving a signing key
+	key := signingKeyV4("wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "20120215", "us-east-1", "iam")
+	if got, want := hex.EncodeToString(key), "f4780e2d9f65fa895f9c67b32ce1baf0b0d8a43505a000a1a9e090d414db404d"; got != want {
+		t.Errorf("signing key = %s, want %s", got, want)
+	}
+}
+
+func TestSignV4(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodPost, "https://kms.us-east-1.amazonaws.com/", nil)
+	req.Header.Set("X-Amz-Target", "TrentService.Decrypt")
+	SignV4(req, []byte("{}"), "AKID", "secret", "us-east-1", "kms", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	if got := req.Header.Get("X-Amz-Date"); got != "20150830T123600Z" {
+		t.Errorf("X-Amz-Date = %q", got)
+	}
+	auth := req.Header.Get("Authorization")
+	for _, want := range []string{
+		"AWS4-HMAC-SHA256 Credential=AKID/20150830/us-east-1/kms/aws4_request, ",
+		"SignedHeaders=host;x-amz-date;x-amz-target, ",
+		"Signature=",
+	} {
+		if !strings.Contains(auth, want) {
+			t.Errorf("Authorization %q missing %q", auth, want)
+		}
+	}
+}
+
+func TestGCPProvider(t *testing.T) {
+	const keyName = "projects/p/locations/global/keyRings/r/cryptoKeys/k"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/"+keyName+":decrypt" || r.Header.Get("Authorization") != "Bearer ya29.token" {
+			http.Error(w, `{"error":{"message":"denied"}}`, http.StatusForbidden)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{
+			"plaintext": base64.StdEncoding.EncodeToString(testDataKey),
+		})
+	}))
+	defer srv.Close()
+
+	p, err := NewProvider(SourceGCPKMS, envMap(map[string]string{
+		"GCP_KMS_ENDPOINT":          srv.URL,
+		"GCP_KMS_KEY":               keyName,
+		"GOOGLE_OAUTH_ACCESS_TOKEN": "ya29.token",
+	}))
+	if err != nil {
+		t.Fatalf("NewProvider: %v", err)
+	}
+
+	key, err := LoadDataKey(context.Background(), p, "d3JhcHBlZA==")
+	if err != nil {
+		t.Fatalf("LoadDataKey: %v", err)
+	}
+	if !bytes.Equal(key, testDataKey) {
+		t.Errorf("key = %x", key)
+	}
+}
+
+func TestProviderConfigErrors(t *testing.T) {
+	for _, source := range []string{SourceVault, SourceAWSKMS, SourceGCPKMS, "plaintext"} {
+		if _, err := NewProvider(source, envMap(nil)); err == nil {
+			t.Errorf("NewProvider(%q) without settings should fail", source)
+		}
+	}
+}
+
+type staticProvider []byte
+
+func (s staticProvider) Decrypt(context.Context, string) ([]byte, error) { return s, nil }
+
+func TestLoadDataKeyLength(t *testing.T) {
+	if _, err := LoadDataKey(context.Background(), staticProvider(make([]byte, 16)), "wrapped"); err == nil {
+		t.Error("a 16 byte key should be rejected")
+	}
+	if _, err := LoadDataKey(context.Background(), staticProvider(testDataKey), ""); err == nil {
+		t.Error("an empty wrapped key should be rejected")
+	}
+}
@@ -0,0 +1,128 @@
+package kms
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Vault token renewal timing.
+const (
+	minRenewInterval   = time.Minute
+	renewRetryInterval = time.Minute
+)
+
+// vaultProvider unwraps data keys with the Vault transit secrets engine, e.g.
+// one created with `vault write -f transit/datakey/wrapped/hookly`.
+type vaultProvider struct {
+	addr      string
+	token     string
+	namespace string
+	mount     string
+	key       string
+	client    *http.Client
+}
+
+func newVaultProvider(getenv func(string) string) (*vaultProvider, error) {
+	p := &vaultProvider{
+		addr:      strings.TrimSuffix(getenv("VAULT_ADDR"), "/"),
+		token:     getenv("VAULT_TOKEN"),
+		namespace: getenv("VAULT_NAMESPACE"),
+		mount:     strings.Trim(getenv("VAULT_TRANSIT_MOUNT"), "/"),
+		key:       getenv("VAULT_TRANSIT_KEY"),
+		client:    newHTTPClient(),
+	}
+	if p.mount == "" {
+		p.mount = "transit"
+	}
+
+	switch {
+	case p.addr == "":
+		return nil, errors.New("VAULT_ADDR is required for the vault key source")
+	case p.token == "":
+		return nil, errors.New("VAULT_TOKEN is required for the vault key source")
+	case p.key == "":
+		return nil, errors.New("VAULT_TRANSIT_KEY is required for the vault key source")
+	}
+	return p, nil
+}
+
+// Decrypt decrypts a "vault:v1:..." transit ciphertext.
+func (p *vaultProvider) Decrypt(ctx context.Context, wrapped string) ([]byte, error) {
+	var resp struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	path := fmt.Sprintf("/v1/%s/decrypt/%s", p.mount, p.key)
+	if err := p.call(ctx, path, map[string]string{"ciphertext": wrapped}, &resp); err != nil {
+		return nil, fmt.Errorf("vault transit decrypt: %w", err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("vault transit decrypt: invalid plaintext: %w", err)
+	}
+	return key, nil
+}
+
+// Renew keeps the Vault token alive by renewing it at half its TTL. It stops
+// when the token is not renewable or ctx is cancelled.
+func (p *vaultProvider) Renew(ctx context.Context) {
+	for {
+		var resp struct {
+			Auth struct {
+				LeaseDuration int  `json:"lease_duration"`
+				Renewable     bool `json:"renewable"`
+			} `json:"auth"`
+		}
+
+		wait := renewRetryInterval
+		if err := p.call(ctx, "/v1/auth/token/renew-self", map[string]string{}, &resp); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Warn("failed to renew vault token", "error", err)
+		} else {
+			if !resp.Auth.Renewable || resp.Auth.LeaseDuration == 0 {
+				slog.Debug("vault token is not renewable, stopping renewal")
+				return
+			}
+			wait = max(time.Duration(resp.Auth.LeaseDuration)*time.Second/2, minRenewInterval)
+			slog.Debug("renewed vault token", "ttl", time.Duration(resp.Auth.LeaseDuration)*time.Second)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (p *vaultProvider) call(ctx context.Context, path string, body any, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequest(http.MethodPost, p.addr+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", p.token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	reqCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	return doJSON(reqCtx, p.client, req, out)
+}
@@ -0,0 +1,239 @@
+// Package listen runs a local stand-in for the edge, to develop against
+// webhooks without one: webhooks are received by the edge's ingestion
+// handler into a local SQLite database, then forwarded to a destination with
+// the edge's forwarder and retry policy. `hookly listen` serves it.
+package listen
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	mathrand "math/rand/v2"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"hooks.dx314.com/internal/db"
+	"hooks.dx314.com/internal/webhook"
+)
+
+// userID owns the local endpoints.
+const userID = "local"
+
+// DefaultPollInterval is how often pending webhooks are looked for by default.
+const DefaultPollInterval = 500 * time.Millisecond
+
+// pollBatchSize bounds the webhooks forwarded per poll.
+const pollBatchSize = 50
+
+// providerTypes are the providers whose signatures can be verified locally.
+// Custom verification needs a configuration the edge UI creates.
+var providerTypes = map[string]bool{"stripe": true, "github": true, "telegram": true, "slack": true, "shopify": true, "square": true, "paypal": true, "generic": true}
+
+// Options configure a Listener.
+type Options struct {
+	// Destination is the URL webhooks are forwarded to.
+	Destination string
+	// ProviderType picks signature verification and event type detection,
+	// generic by default. It is also the local endpoint's ID.
+	ProviderType string
+	// Secret verifies signatures. Webhooks aren't verified if empty.
+	Secret string
+	// PollInterval is how often pending webhooks are forwarded; 0 for
+	// DefaultPollInterval.
+	PollInterval time.Duration
+}
+
+// Listener receives webhooks into a local database and forwards them.
+type Listener struct {
+	queries    *db.Queries
+	opts       Options
+	endpointID string
+	handler    *webhook.Handler
+	forwarder  *webhook.Forwarder
+}
+
+// New creates a listener storing webhooks with queries, and creates or
+// updates its local endpoint.
+func New(ctx context.Context, queries *db.Queries, opts Options) (*Listener, error) {
+	if opts.ProviderType == "" {
+		opts.ProviderType = "generic"
+	}
+	if !providerTypes[opts.ProviderType] {
+		return nil, fmt.Errorf("unsupported provider %q", opts.ProviderType)
+	}
+	u, err := url.Parse(opts.Destination)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("destination %q must be an http or https URL", opts.Destination)
+	}
+	if opts.PollInterval <= 0 {
+		opts.PollInterval = DefaultPollInterval
+	}
+
+	// The secret only has to last this run, so its key does too
+	key := make([]byte, 32)
+	rand.Read(key)
+	secrets := db.NewSecretManager(key)
+
+	l := &Listener{
+		queries:    queries,
+		opts:       opts,
+		endpointID: opts.ProviderType,
+		handler:    webhook.NewHandler(queries, secrets, nil),
+		forwarder:  webhook.NewForwarder(),
+	}
+	if err := l.setupEndpoint(ctx, secrets); err != nil {
+		return nil, fmt.Errorf("set up local endpoint: %w", err)
+	}
+	return l, nil
+}
+
+// setupEndpoint creates the local endpoint, or points an existing one at the
+// destination and secret of this run.
+func (l *Listener) setupEndpoint(ctx context.Context, secrets *db.SecretManager) error {
+	encrypted := []byte{} // Not NULL, which would keep the previous run's secret
+	if l.opts.Secret != "" {
+		var err error
+		if encrypted, err = secrets.EncryptSecret(l.opts.Secret); err != nil {
+			return err
+		}
+	}
+
+	_, err := l.queries.GetEndpointByID(ctx, l.endpointID)
+	if errors.Is(err, sql.ErrNoRows) {
+		_, err = l.queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+			ID:                       l.endpointID,
+			UserID:                   userID,
+			Name:                     "local " + l.opts.ProviderType,
+			ProviderType:             l.opts.ProviderType,
+			SignatureSecretEncrypted: encrypted,
+			DestinationUrl:           l.opts.Destination,
+		})
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	_, err = l.queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		SignatureSecretEncrypted: encrypted,
+		DestinationUrl:           sql.NullString{String: l.opts.Destination, Valid: true},
+		Muted:                    sql.NullInt64{Int64: 0, Valid: true},
+		ID:                       l.endpointID,
+		UserID:                   userID,
+	})
+	return err
+}
+
+// Path is where webhooks are received, like /h/<endpoint id> on the edge.
+func (l *Listener) Path() string {
+	return webhook.DefaultPathPrefix + l.endpointID
+}
+
+// Handler returns the ingestion handler, serving Path.
+func (l *Listener) Handler() http.Handler {
+	r := chi.NewRouter()
+	r.HandleFunc(webhook.Route(webhook.DefaultPathPrefix), l.handler.ServeHTTP)
+	return r
+}
+
+// Run forwards pending webhooks until ctx is cancelled.
+func (l *Listener) Run(ctx context.Context) error {
+	ticker := time.NewTicker(l.opts.PollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := l.forwardPending(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("failed to forward pending webhooks", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// forwardPending forwards the webhooks that are due and returns how many
+// were attempted.
+func (l *Listener) forwardPending(ctx context.Context) (int, error) {
+	pending, err := l.queries.GetPendingWebhooks(ctx, pollBatchSize)
+	if err != nil {
+		return 0, err
+	}
+	for _, wh := range pending {
+		if err := l.forward(ctx, wh); err != nil {
+			return 0, err
+		}
+	}
+	return len(pending), nil
+}
+
+// forward sends a webhook to the destination and records the outcome as the
+// edge does for a hub's ack: delivered, failed on a permanent error, and
+// otherwise retried after the endpoint's backoff.
+func (l *Listener) forward(ctx context.Context, wh db.GetPendingWebhooksRow) error {
+	var headers map[string]string
+	if err := json.Unmarshal([]byte(wh.Headers), &headers); err != nil {
+		slog.Warn("webhook has invalid headers, forwarding without them", "webhook_id", wh.ID, "error", err)
+	}
+
+	attempt := int(wh.Attempts) + 1
+	result := l.forwarder.Forward(ctx, wh.DestinationUrl, headers, wh.Payload, wh.ID, attempt)
+	if ctx.Err() != nil {
+		return nil // Interrupted, not failed; retried on the next run
+	}
+
+	var err error
+	switch {
+	case result.Success:
+		_, err = l.queries.MarkWebhookDelivered(ctx, wh.ID)
+	case result.PermanentFailure:
+		_, err = l.markFailed(ctx, wh.ID, result.Error)
+	default:
+		err = l.retryOrFail(ctx, wh.ID, attempt, result.Error)
+	}
+	return err
+}
+
+// retryOrFail schedules a retry after the endpoint's backoff, or fails the
+// webhook if that was its last attempt.
+func (l *Listener) retryOrFail(ctx context.Context, id string, attempt int, message string) error {
+	row, err := l.queries.GetWebhookRetryPolicy(ctx, id)
+	if err != nil {
+		return err
+	}
+	policy := webhook.RetryPolicy{
+		MaxAttempts: int(row.RetryMaxAttempts),
+		BackoffBase: time.Duration(row.RetryBackoffBaseSeconds) * time.Second,
+		MaxInterval: time.Duration(row.RetryMaxIntervalSeconds) * time.Second,
+		Jitter:      row.RetryJitter,
+	}
+	if policy.Exhausted(attempt) {
+		slog.Info("webhook failed: out of retry attempts", "webhook_id", id, "attempts", attempt)
+		_, err := l.markFailed(ctx, id, fmt.Sprintf("gave up after %d attempts: %s", attempt, message))
+		return err
+	}
+
+	delay := policy.Delay(int(row.Attempts), mathrand.Float64())
+	if _, err := l.queries.RecordWebhookAttempt(ctx, db.RecordWebhookAttemptParams{
+		RetryDelaySeconds: int64((delay + time.Second - 1) / time.Second),
+		ErrorMessage:      sql.NullString{String: message, Valid: message != ""},
+		ID:                id,
+	}); err != nil {
+		return err
+	}
+	slog.Info("webhook will be retried after backoff", "webhook_id", id, "retry_in", delay.Round(time.Second))
+	return nil
+}
+
+func (l *Listener) markFailed(ctx context.Context, id, message string) (db.Webhook, error) {
+	return l.queries.MarkWebhookFailed(ctx, db.MarkWebhookFailedParams{
+		ErrorMessage: sql.NullString{String: message, Valid: message != ""},
+		ID:           id,
+	})
+}
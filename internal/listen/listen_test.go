@@ -0,0 +1,124 @@
+package listen
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"hooks.dx314.com/internal/db"
+	"hooks.dx314.com/internal/webhook"
+)
+
+func TestListener(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "listen.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	queries := db.New(conn)
+
+	var (
+		mu       sync.Mutex
+		received []string
+		status   = http.StatusServiceUnavailable
+	)
+	dest := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		received = append(received, r.Header.Get("X-GitHub-Event"))
+		w.WriteHeader(status)
+	}))
+	defer dest.Close()
+
+	l, err := New(ctx, queries, Options{Destination: dest.URL, ProviderType: "github", Secret: "s3cret"})
+	if err != nil {
+		t.Fatalf("new listener: %v", err)
+	}
+	srv := httptest.NewServer(l.Handler())
+	defer srv.Close()
+
+	payload := `{"action":"opened"}`
+	req, _ := http.NewRequest(http.MethodPost, srv.URL+l.Path(), strings.NewReader(payload))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", "pull_request")
+	req.Header.Set("X-Hub-Signature-256", webhook.ComputeGitHubSignature([]byte(payload), "s3cret"))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("ingest status = %d", resp.StatusCode)
+	}
+
+	// A transient failure is retried after the backoff
+	if n, err := l.forwardPending(ctx); err != nil || n != 1 {
+		t.Fatalf("forwarded %d, %v", n, err)
+	}
+	wh, err := queries.GetWebhookRetryPolicy(ctx, webhookID(t, queries))
+	if err != nil || wh.Attempts != 1 {
+		t.Fatalf("after a 503: %+v, %v", wh, err)
+	}
+	if n, _ := l.forwardPending(ctx); n != 0 {
+		t.Errorf("forwarded %d before the retry was due", n)
+	}
+	if _, err := conn.Exec(`UPDATE webhooks SET next_attempt_at = NULL`); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	status = http.StatusOK
+	mu.Unlock()
+	if n, err := l.forwardPending(ctx); err != nil || n != 1 {
+		t.Fatalf("forwarded %d, %v", n, err)
+	}
+	webhooks, err := queries.ListWebhooks(ctx, db.ListWebhooksParams{UserID: userID, Limit: 10})
+	if err != nil {
+		t.Fatalf("list webhooks: %v", err)
+	}
+	if len(webhooks) != 1 || webhooks[0].Status != "delivered" || webhooks[0].Attempts != 2 || webhooks[0].SignatureValid != 1 || webhooks[0].EventType.String != "pull_request" {
+		t.Fatalf("webhooks = %+v", webhooks)
+	}
+	mu.Lock()
+	if len(received) != 2 || received[0] != "pull_request" {
+		t.Errorf("destination received %v", received)
+	}
+	mu.Unlock()
+
+	// A later run without a secret doesn't verify with the old one
+	l, err = New(ctx, queries, Options{Destination: dest.URL, ProviderType: "github"})
+	if err != nil {
+		t.Fatalf("new listener: %v", err)
+	}
+	endpoint, err := queries.GetEndpointByID(ctx, "github")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(endpoint.SignatureSecretEncrypted) != 0 {
+		t.Error("secret of the previous run kept")
+	}
+}
+
+func webhookID(t *testing.T, queries *db.Queries) string {
+	t.Helper()
+	webhooks, err := queries.ListWebhooks(context.Background(), db.ListWebhooksParams{UserID: userID, Limit: 1})
+	if err != nil || len(webhooks) == 0 {
+		t.Fatalf("list webhooks: %v", err)
+	}
+	return webhooks[0].ID
+}
+
+func TestNewValidatesOptions(t *testing.T) {
+	for _, opts := range []Options{
+		{Destination: "localhost:3000"},
+		{Destination: "http://localhost:3000", ProviderType: "custom"},
+	} {
+		if _, err := New(context.Background(), nil, opts); err == nil {
+			t.Errorf("New(%+v) succeeded", opts)
+		}
+	}
+}
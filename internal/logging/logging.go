@@ -0,0 +1,105 @@
+// Package logging sets up the edge gateway's structured logger: level,
+// text or JSON format, optional output to a size-rotated file, and sampling
+// of per-webhook records. The level can be changed while running. The rotated file and fan-out handler are also
+// used by the relay client for its own log file.
+package logging
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"time"
+)
+
+// Formats accepted by Options.Format.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Options configures the logger.
+type Options struct {
+	Level      slog.Level
+	Format     string // FormatText or FormatJSON
+	File       string // Log to this file instead of stdout if set
+	MaxSizeMB  int    // Rotate the file at this size (0 disables rotation)
+	MaxBackups int    // Rotated files to keep
+	// SampleInterval summarizes records marked by SampleBy over this
+	// interval; 0 logs each of them
+	SampleInterval time.Duration
+}
+
+// Logger is the installed default logger.
+type Logger struct {
+	level   *slog.LevelVar
+	file    *rotatingFile // nil when logging to stdout
+	sampler *Sampler
+}
+
+// ParseLevel parses debug, info, warn or error.
+func ParseLevel(s string) (slog.Level, error) {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(strings.TrimSpace(s))); err != nil {
+		return slog.LevelInfo, fmt.Errorf("unknown log level %q (valid: debug, info, warn, error)", s)
+	}
+	return level, nil
+}
+
+// Setup creates a logger from opts and installs it as the slog default.
+func Setup(opts Options) (*Logger, error) {
+	l := &Logger{level: new(slog.LevelVar)}
+	l.level.Set(opts.Level)
+
+	var out io.Writer = os.Stdout
+	if opts.File != "" {
+		f, err := openRotating(opts.File, int64(opts.MaxSizeMB)<<20, opts.MaxBackups)
+		if err != nil {
+			return nil, err
+		}
+		l.file = f
+		out = f
+	}
+
+	handlerOpts := &slog.HandlerOptions{Level: l.level}
+	var handler slog.Handler
+	switch opts.Format {
+	case FormatJSON:
+		handler = slog.NewJSONHandler(out, handlerOpts)
+	case FormatText, "":
+		handler = slog.NewTextHandler(out, handlerOpts)
+	default:
+		l.Close()
+		return nil, fmt.Errorf("unknown log format %q (valid: %s, %s)", opts.Format, FormatText, FormatJSON)
+	}
+
+	l.sampler = NewSampler(handler, opts.SampleInterval)
+	slog.SetDefault(slog.New(l.sampler))
+	return l, nil
+}
+
+// LevelVar returns the level, which can be changed while running.
+func (l *Logger) LevelVar() *slog.LevelVar {
+	return l.level
+}
+
+// Reopen reopens the log file, for use after an external tool moved it.
+// It does nothing when logging to stdout.
+func (l *Logger) Reopen() error {
+	if l.file == nil {
+		return nil
+	}
+	return l.file.reopen()
+}
+
+// Close logs the last sampling summaries and closes the log file, if any.
+func (l *Logger) Close() error {
+	if l.sampler != nil {
+		l.sampler.Close()
+	}
+	if l.file == nil {
+		return nil
+	}
+	return l.file.Close()
+}
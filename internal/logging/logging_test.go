@@ -0,0 +1,155 @@
+package logging
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseLevel(t *testing.T) {
+	for s, want := range map[string]slog.Level{
+		"debug": slog.LevelDebug,
+		"INFO":  slog.LevelInfo,
+		"warn":  slog.LevelWarn,
+		"error": slog.LevelError,
+	} {
+		if got, err := ParseLevel(s); err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v", s, got, err, want)
+		}
+	}
+	if _, err := ParseLevel("verbose"); err == nil {
+		t.Error("ParseLevel(verbose) succeeded")
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "edge.log")
+	f, err := openRotating(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := f.Write([]byte(line)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name, want := range map[string]string{
+		path:        "fourth\n",
+		path + ".1": "third\n",
+		path + ".2": "second\n",
+	} {
+		got, err := os.ReadFile(name)
+		if err != nil {
+			t.Fatalf("read %s: %v", filepath.Base(name), err)
+		}
+		if string(got) != want {
+			t.Errorf("%s = %q, want %q", filepath.Base(name), got, want)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("kept more than 2 backups")
+	}
+}
+
+func TestSetupLevelVar(t *testing.T) {
+	defer slog.SetDefault(slog.Default())
+
+	path := filepath.Join(t.TempDir(), "edge.log")
+	l, err := Setup(Options{Level: slog.LevelInfo, Format: FormatJSON, File: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	slog.Debug("hidden")
+	l.LevelVar().Set(slog.LevelDebug)
+	slog.Debug("shown")
+
+	got, _ := os.ReadFile(path)
+	if strings.Contains(string(got), "hidden") || !strings.Contains(string(got), `"msg":"shown"`) {
+		t.Errorf("log file:\n%s", got)
+	}
+
+	if _, err := Setup(Options{Format: "xml"}); err == nil {
+		t.Error("unknown format accepted")
+	}
+}
+
+func TestFanout(t *testing.T) {
+	var text, json strings.Builder
+	logger := slog.New(Fanout(
+		slog.NewTextHandler(&text, &slog.HandlerOptions{Level: slog.LevelWarn}),
+		slog.NewJSONHandler(&json, &slog.HandlerOptions{Level: slog.LevelDebug}),
+	)).With("hub_id", "hub-1")
+
+	logger.Debug("connecting")
+	logger.WithGroup("tunnel").Warn("reconnecting", "attempt", 2)
+
+	if strings.Contains(text.String(), "msg=connecting") || !strings.Contains(text.String(), "tunnel.attempt=2") {
+		t.Errorf("text handler got:\n%s", text.String())
+	}
+	if strings.Count(json.String(), `"hub_id":"hub-1"`) != 2 || !strings.Contains(json.String(), `"tunnel":{"attempt":2}`) {
+		t.Errorf("json handler got:\n%s", json.String())
+	}
+}
+
+func TestRecent(t *testing.T) {
+	r := NewRecent(3)
+	logger := slog.New(r.Handler(slog.LevelInfo))
+
+	logger.Debug("hidden")
+	for i := range 5 {
+		logger.Info("delivered", "n", i)
+	}
+	logger.Warn("big", "payload", strings.Repeat("x", 2*maxRecentLineLen))
+
+	lines := r.Lines(10)
+	if len(lines) != 3 {
+		t.Fatalf("got %d lines, want 3: %q", len(lines), lines)
+	}
+	if !strings.Contains(lines[0], "n=3") || !strings.Contains(lines[1], "n=4") {
+		t.Errorf("lines not oldest first: %q", lines)
+	}
+	if !strings.HasSuffix(lines[2], "…") || len(lines[2]) > maxRecentLineLen+len("…") {
+		t.Errorf("long line kept as %d bytes", len(lines[2]))
+	}
+	if last := r.Lines(1); len(last) != 1 || last[0] != lines[2] {
+		t.Errorf("Lines(1) = %q", last)
+	}
+}
+
+func TestSampler(t *testing.T) {
+	var out strings.Builder
+	level := new(slog.LevelVar)
+	sampler := NewSampler(slog.NewTextHandler(&out, &slog.HandlerOptions{Level: level}), time.Hour)
+	logger := slog.New(sampler)
+
+	for i := range 3 {
+		logger.Info("webhook received", SampleBy("endpoint_id", "ep_a"), "n", i)
+	}
+	logger.Info("webhook received", SampleBy("endpoint_id", "ep_b"), "n", 3)
+	logger.Info("connected")
+
+	level.Set(slog.LevelDebug)
+	logger.Info("webhook received", SampleBy("endpoint_id", "ep_a"), "n", 4)
+	sampler.Close()
+
+	got := out.String()
+	for _, want := range []string{
+		"n=0", "n=3", "n=4", "msg=connected",
+		`msg="webhook received (summary)" endpoint_id=ep_a count=3 window=1h0m0s`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("log lacks %q:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "n=1") || strings.Contains(got, "sample=") || strings.Contains(got, "endpoint_id=ep_b count") {
+		t.Errorf("log:\n%s", got)
+	}
+}
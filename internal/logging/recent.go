@@ -0,0 +1,67 @@
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"sync"
+)
+
+// maxRecentLineLen caps a kept line, so a record with a huge attribute
+// can't fill the buffer.
+const maxRecentLineLen = 1024
+
+// Recent keeps the last lines written to it, for a hub to send its recent
+// logs to the edge on request. Write it through Handler.
+type Recent struct {
+	mu    sync.Mutex
+	lines []string
+	next  int // Index the next line is written to once the buffer is full
+}
+
+// NewRecent creates a buffer keeping the last size lines.
+func NewRecent(size int) *Recent {
+	return &Recent{lines: make([]string, 0, size)}
+}
+
+// Handler returns a text handler writing records at level and above to r.
+func (r *Recent) Handler(level slog.Leveler) slog.Handler {
+	return slog.NewTextHandler(r, &slog.HandlerOptions{Level: level})
+}
+
+// Write implements io.Writer. The text handler writes each record with a
+// single call.
+func (r *Recent) Write(p []byte) (int, error) {
+	n := len(p)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, line := range bytes.Split(bytes.TrimSuffix(p, []byte("\n")), []byte("\n")) {
+		if len(line) > maxRecentLineLen {
+			line = append(line[:maxRecentLineLen:maxRecentLineLen], "…"...)
+		}
+		r.add(string(line))
+	}
+	return n, nil
+}
+
+func (r *Recent) add(line string) {
+	if len(r.lines) < cap(r.lines) {
+		r.lines = append(r.lines, line)
+		return
+	}
+	if len(r.lines) == 0 {
+		return
+	}
+	r.lines[r.next] = line
+	r.next = (r.next + 1) % len(r.lines)
+}
+
+// Lines returns up to the last n lines, oldest first.
+func (r *Recent) Lines(n int) []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ordered := append(append([]string(nil), r.lines[r.next:]...), r.lines[:r.next]...)
+	if n >= 0 && n < len(ordered) {
+		ordered = ordered[len(ordered)-n:]
+	}
+	return ordered
+}
@@ -0,0 +1,176 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// sampleAttrKey marks a record for sampling. The sampler removes it.
+const sampleAttrKey = "sample"
+
+// sampleMarker groups sampled records. It logs as its value where records
+// aren't passed through a Sampler.
+type sampleMarker struct {
+	attr slog.Attr
+}
+
+func (m sampleMarker) LogValue() slog.Value {
+	return m.attr.Value
+}
+
+// SampleBy marks a high-volume record, such as one per webhook, for sampling
+// by a Sampler: records with the same message and key=value are logged once
+// per interval and summarized with a count.
+//
+//	slog.Info("webhook received", logging.SampleBy("endpoint_id", id), "webhook_id", whID)
+func SampleBy(key string, value any) slog.Attr {
+	return slog.Any(sampleAttrKey, sampleMarker{attr: slog.Any(key, value)})
+}
+
+// Sampler is a handler that samples records marked by SampleBy, so a burst
+// of webhooks doesn't flood the log. The first record of each group in an
+// interval is logged in full; at the end of the interval a summary counts
+// the group's records. Every record is logged in full when the next handler
+// is enabled for debug, or when the interval is 0.
+type Sampler struct {
+	next slog.Handler
+	s    *sampling
+}
+
+// sampling is the state shared by a Sampler and the handlers derived from it
+// with WithAttrs and WithGroup.
+type sampling struct {
+	out      slog.Handler // Summaries, without the attributes of derived handlers
+	interval time.Duration
+
+	mu     sync.Mutex
+	groups map[sampleGroup]*sampleCount
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+type sampleGroup struct {
+	msg   string
+	key   string
+	value string
+}
+
+type sampleCount struct {
+	level slog.Level
+	attr  slog.Attr
+	count int
+}
+
+// NewSampler wraps next, summarizing sampled records over interval. Close
+// logs the last summaries.
+func NewSampler(next slog.Handler, interval time.Duration) *Sampler {
+	s := &sampling{
+		out:      next,
+		interval: interval,
+		groups:   make(map[sampleGroup]*sampleCount),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	if interval > 0 {
+		go s.run()
+	} else {
+		close(s.done)
+	}
+	return &Sampler{next: next, s: s}
+}
+
+// Close stops the sampler, logging the summaries of the current interval.
+func (h *Sampler) Close() {
+	h.s.once.Do(func() { close(h.s.stop) })
+	<-h.s.done
+}
+
+func (h *Sampler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *Sampler) Handle(ctx context.Context, r slog.Record) error {
+	var marker *sampleMarker
+	r.Attrs(func(a slog.Attr) bool {
+		if m, ok := a.Value.Any().(sampleMarker); ok && a.Key == sampleAttrKey {
+			marker = &m
+			return false
+		}
+		return true
+	})
+	if marker == nil {
+		return h.next.Handle(ctx, r)
+	}
+
+	stripped := slog.NewRecord(r.Time, r.Level, r.Message, r.PC)
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key != sampleAttrKey {
+			stripped.AddAttrs(a)
+		}
+		return true
+	})
+	if h.s.interval <= 0 || h.next.Enabled(ctx, slog.LevelDebug) {
+		return h.next.Handle(ctx, stripped)
+	}
+
+	group := sampleGroup{msg: r.Message, key: marker.attr.Key, value: marker.attr.Value.String()}
+	h.s.mu.Lock()
+	c, seen := h.s.groups[group]
+	if !seen {
+		c = &sampleCount{level: r.Level, attr: marker.attr}
+		h.s.groups[group] = c
+	}
+	c.count++
+	h.s.mu.Unlock()
+	if seen {
+		return nil
+	}
+	return h.next.Handle(ctx, stripped)
+}
+
+func (h *Sampler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &Sampler{next: h.next.WithAttrs(attrs), s: h.s}
+}
+
+func (h *Sampler) WithGroup(name string) slog.Handler {
+	return &Sampler{next: h.next.WithGroup(name), s: h.s}
+}
+
+func (s *sampling) run() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			s.flush()
+			return
+		case <-ticker.C:
+			s.flush()
+		}
+	}
+}
+
+// flush logs a summary of each group with more than the one record logged
+// in full, and starts a new interval.
+func (s *sampling) flush() {
+	s.mu.Lock()
+	groups := s.groups
+	s.groups = make(map[sampleGroup]*sampleCount)
+	s.mu.Unlock()
+
+	ctx := context.Background()
+	now := time.Now()
+	for group, c := range groups {
+		if c.count < 2 || !s.out.Enabled(ctx, c.level) {
+			continue
+		}
+		r := slog.NewRecord(now, c.level, group.msg+" (summary)", 0)
+		r.AddAttrs(c.attr, slog.Int("count", c.count), slog.Duration("window", s.interval))
+		s.out.Handle(ctx, r)
+	}
+}
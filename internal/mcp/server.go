@@ -7,6 +7,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -14,6 +16,17 @@ import (
 
 	"hooks.dx314.com/internal/db"
 	"hooks.dx314.com/internal/id"
+	"hooks.dx314.com/internal/tracing"
+	"hooks.dx314.com/internal/webhook"
+)
+
+// Result caps, so a large account can't flood the agent's context. Lists
+// are paged with a cursor, the offset of the next page.
+const (
+	defaultListLimit   = 50
+	maxListLimit       = 200
+	maxPayloadListSize = 20       // List limit when payload previews are included
+	maxPayloadSize     = 64 << 10 // Full payload returned by hookly_get_webhook
 )
 
 // Server is the MCP server for Hookly.
@@ -21,17 +34,23 @@ type Server struct {
 	mcpServer     *server.MCPServer
 	queries       *db.Queries
 	secretManager *db.SecretManager
+	replayGuard   *webhook.ReplayGuard
 	baseURL       string
+	pathPrefix    string // Where the edge receives webhooks, see SetWebhookPathPrefix
 	userID        string
+	username      string // Recorded as who replayed webhooks
 }
 
 // NewServer creates a new Hookly MCP server.
-func NewServer(queries *db.Queries, secretManager *db.SecretManager, baseURL, userID string) *Server {
+func NewServer(queries *db.Queries, secretManager *db.SecretManager, baseURL, userID, username string) *Server {
 	s := &Server{
 		queries:       queries,
 		secretManager: secretManager,
+		replayGuard:   webhook.NewReplayGuard(queries, webhook.DefaultReplayRateLimit, webhook.DefaultReplayConfirmThreshold),
 		baseURL:       baseURL,
+		pathPrefix:    webhook.DefaultPathPrefix,
 		userID:        userID,
+		username:      username,
 	}
 
 	// Create MCP server
@@ -39,14 +58,43 @@ func NewServer(queries *db.Queries, secretManager *db.SecretManager, baseURL, us
 		"hookly",
 		"1.0.0",
 		server.WithToolCapabilities(false),
+		server.WithResourceCapabilities(false, false),
 	)
 
 	// Register tools
 	s.registerTools()
+	s.mcpServer.AddResource(mcp.NewResource(summaryURI, "Hookly summary",
+		mcp.WithResourceDescription("Briefing on queue depth, recent failures, SLO breaches and disconnected hubs"),
+		mcp.WithMIMEType("text/plain"),
+	), s.readSummary)
 
 	return s
 }
 
+// SetWebhookPathPrefix sets the path prefix the edge receives webhooks under,
+// for the webhook URLs of endpoints. Call it before ServeStdio.
+func (s *Server) SetWebhookPathPrefix(prefix string) {
+	s.pathPrefix = prefix
+}
+
+// SetReadOnly removes the tools that change anything (creating, deleting,
+// muting and configuring endpoints, replays), so an agent can observe but not
+// act. Call it before ServeStdio.
+func (s *Server) SetReadOnly() {
+	var mutating []string
+	for _, tool := range defineTools() {
+		if !isReadOnly(tool) {
+			mutating = append(mutating, tool.Name)
+		}
+	}
+	s.mcpServer.DeleteTools(mutating...)
+}
+
+// isReadOnly reports whether the tool is annotated as changing nothing.
+func isReadOnly(tool mcp.Tool) bool {
+	return tool.Annotations.ReadOnlyHint != nil && *tool.Annotations.ReadOnlyHint
+}
+
 // ServeStdio runs the MCP server on stdio.
 func (s *Server) ServeStdio() error {
 	return server.ServeStdio(s.mcpServer)
@@ -56,15 +104,20 @@ func (s *Server) registerTools() {
 	tools := defineTools()
 
 	handlers := map[string]server.ToolHandlerFunc{
-		"hookly_list_endpoints":  s.handleListEndpoints,
-		"hookly_get_endpoint":    s.handleGetEndpoint,
-		"hookly_create_endpoint": s.handleCreateEndpoint,
-		"hookly_delete_endpoint": s.handleDeleteEndpoint,
-		"hookly_mute_endpoint":   s.handleMuteEndpoint,
-		"hookly_list_webhooks":   s.handleListWebhooks,
-		"hookly_get_webhook":     s.handleGetWebhook,
-		"hookly_replay_webhook":  s.handleReplayWebhook,
-		"hookly_get_status":      s.handleGetStatus,
+		"hookly_list_endpoints":     s.handleListEndpoints,
+		"hookly_get_endpoint":       s.handleGetEndpoint,
+		"hookly_create_endpoint":    s.handleCreateEndpoint,
+		"hookly_delete_endpoint":    s.handleDeleteEndpoint,
+		"hookly_mute_endpoint":      s.handleMuteEndpoint,
+		"hookly_set_retry_policy":   s.handleSetRetryPolicy,
+		"hookly_set_payload_limits": s.handleSetPayloadLimits,
+		"hookly_list_webhooks":      s.handleListWebhooks,
+		"hookly_get_webhook":        s.handleGetWebhook,
+		"hookly_replay_webhook":     s.handleReplayWebhook,
+		"hookly_bulk_replay":        s.handleBulkReplay,
+		"hookly_cancel_replays":     s.handleCancelReplays,
+		"hookly_get_status":         s.handleGetStatus,
+		"hookly_summary":            s.handleSummary,
 	}
 
 	for _, tool := range tools {
@@ -74,41 +127,112 @@ func (s *Server) registerTools() {
 	}
 }
 
+// listPage reads the limit and cursor of a list tool, with the limit capped at
+// maxLimit.
+func listPage(req mcp.CallToolRequest, maxLimit int) (limit, offset int64, err error) {
+	limit = int64(min(mcp.ParseInt(req, "limit", defaultListLimit), maxLimit))
+	if limit <= 0 {
+		return 0, 0, errors.New("limit must be positive")
+	}
+	if cursor := mcp.ParseString(req, "cursor", ""); cursor != "" {
+		offset, err = strconv.ParseInt(cursor, 10, 64)
+		if err != nil || offset < 0 {
+			return 0, 0, errors.New("invalid cursor; pass next_cursor from the previous page")
+		}
+	}
+	return limit, offset, nil
+}
+
+// listResult returns a page of a list under key. One item more than the
+// limit is fetched to tell whether there is a next page.
+func listResult[T any](key string, items []T, limit, offset int64) *mcp.CallToolResult {
+	result := map[string]any{}
+	if int64(len(items)) > limit {
+		items = items[:limit]
+		result["next_cursor"] = strconv.FormatInt(offset+limit, 10)
+	}
+	result[key] = items
+	data, _ := json.MarshalIndent(result, "", "  ")
+	return mcp.NewToolResultText(string(data))
+}
+
 func (s *Server) handleListEndpoints(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	endpoints, err := s.queries.ListEndpoints(ctx, db.ListEndpointsParams{
+	limit, offset, err := listPage(req, maxListLimit)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+	params := db.ListEndpointsParams{
 		UserID: s.userID,
-		Limit:  1000,
-		Offset: 0,
-	})
+		Limit:  limit + 1,
+		Offset: offset,
+	}
+	if search := mcp.ParseString(req, "search", ""); search != "" {
+		params.Search = db.EscapeLike(search)
+	}
+	if providerType := mcp.ParseString(req, "provider_type", ""); providerType != "" {
+		params.ProviderType = providerType
+	}
+	if _, ok := req.GetArguments()["muted"]; ok {
+		muted := int64(0)
+		if mcp.ParseBoolean(req, "muted", false) {
+			muted = 1
+		}
+		params.Muted = muted
+	}
+	if days := mcp.ParseInt(req, "inactive_days", 0); days > 0 {
+		params.InactiveDays = int64(days)
+	}
+	switch sort := mcp.ParseString(req, "sort", ""); sort {
+	case "", "newest":
+	case "oldest":
+		params.Sort = "created_asc"
+	case "last_received":
+		params.Sort = "last_received"
+	default:
+		return mcp.NewToolResultError("sort must be one of: newest, oldest, last_received"), nil
+	}
+
+	endpoints, err := s.queries.ListEndpoints(ctx, params)
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to list endpoints: %v", err)), nil
 	}
 
 	type endpointResult struct {
-		ID             string `json:"id"`
-		Name           string `json:"name"`
-		ProviderType   string `json:"provider_type"`
-		DestinationURL string `json:"destination_url"`
-		Muted          bool   `json:"muted"`
-		WebhookURL     string `json:"webhook_url"`
-		CreatedAt      string `json:"created_at"`
+		ID                   string `json:"id"`
+		Name                 string `json:"name"`
+		ProviderType         string `json:"provider_type"`
+		DestinationURL       string `json:"destination_url"`
+		Muted                bool   `json:"muted"`
+		WebhookURL           string `json:"webhook_url"`
+		CreatedAt            string `json:"created_at"`
+		FirstEventAt         string `json:"first_event_at,omitempty"`
+		WaitingForFirstEvent bool   `json:"waiting_for_first_event"`
+		LastReceivedAt       string `json:"last_webhook_received_at,omitempty"`
+		LastDeliveredAt      string `json:"last_delivered_at,omitempty"`
+		ArchivedAt           string `json:"archived_at,omitempty"`
+		Honeypot             bool   `json:"honeypot,omitempty"`
 	}
 
 	results := make([]endpointResult, len(endpoints))
 	for i, e := range endpoints {
 		results[i] = endpointResult{
-			ID:             e.ID,
-			Name:           e.Name,
-			ProviderType:   e.ProviderType,
-			DestinationURL: e.DestinationUrl,
-			Muted:          e.Muted != 0,
-			WebhookURL:     fmt.Sprintf("%s/h/%s", s.baseURL, e.ID),
-			CreatedAt:      e.CreatedAt,
+			ID:                   e.ID,
+			Name:                 e.Name,
+			ProviderType:         e.ProviderType,
+			DestinationURL:       e.DestinationUrl,
+			Muted:                e.Muted != 0,
+			WebhookURL:           webhook.WebhookURL(s.baseURL, s.pathPrefix, e.ID),
+			CreatedAt:            db.RFC3339(e.CreatedAt),
+			FirstEventAt:         db.RFC3339(e.FirstEventAt.String),
+			WaitingForFirstEvent: !e.FirstEventAt.Valid,
+			LastReceivedAt:       db.RFC3339(e.LastWebhookReceivedAt.String),
+			LastDeliveredAt:      db.RFC3339(e.LastDeliveredAt.String),
+			ArchivedAt:           db.RFC3339(e.ArchivedAt.String),
+			Honeypot:             e.Honeypot != 0,
 		}
 	}
 
-	data, _ := json.MarshalIndent(results, "", "  ")
-	return mcp.NewToolResultText(string(data)), nil
+	return listResult("endpoints", results, limit, offset), nil
 }
 
 func (s *Server) handleGetEndpoint(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -129,14 +253,40 @@ func (s *Server) handleGetEndpoint(ctx context.Context, req mcp.CallToolRequest)
 	}
 
 	result := map[string]any{
-		"id":              endpoint.ID,
-		"name":            endpoint.Name,
-		"provider_type":   endpoint.ProviderType,
-		"destination_url": endpoint.DestinationUrl,
-		"muted":           endpoint.Muted != 0,
-		"webhook_url":     fmt.Sprintf("%s/h/%s", s.baseURL, endpoint.ID),
-		"created_at":      endpoint.CreatedAt,
-		"updated_at":      endpoint.UpdatedAt,
+		"id":                      endpoint.ID,
+		"name":                    endpoint.Name,
+		"provider_type":           endpoint.ProviderType,
+		"destination_url":         endpoint.DestinationUrl,
+		"muted":                   endpoint.Muted != 0,
+		"webhook_url":             webhook.WebhookURL(s.baseURL, s.pathPrefix, endpoint.ID),
+		"created_at":              db.RFC3339(endpoint.CreatedAt),
+		"updated_at":              db.RFC3339(endpoint.UpdatedAt),
+		"notify_first_event":      endpoint.NotifyFirstEvent != 0,
+		"waiting_for_first_event": !endpoint.FirstEventAt.Valid,
+		"honeypot":                endpoint.Honeypot != 0,
+	}
+	if endpoint.FirstEventAt.Valid {
+		result["first_event_at"] = db.RFC3339(endpoint.FirstEventAt.String)
+	}
+	if endpoint.LastWebhookReceivedAt.Valid {
+		result["last_webhook_received_at"] = db.RFC3339(endpoint.LastWebhookReceivedAt.String)
+	}
+	if endpoint.LastDeliveredAt.Valid {
+		result["last_delivered_at"] = db.RFC3339(endpoint.LastDeliveredAt.String)
+	}
+	if endpoint.ArchivedAt.Valid {
+		result["archived_at"] = db.RFC3339(endpoint.ArchivedAt.String)
+	}
+	result["retry_policy"] = map[string]any{
+		"max_attempts":         endpoint.RetryMaxAttempts,
+		"backoff_base_seconds": endpoint.RetryBackoffBaseSeconds,
+		"max_interval_seconds": endpoint.RetryMaxIntervalSeconds,
+		"jitter":               endpoint.RetryJitter,
+	}
+	limits := webhook.ParsePayloadLimits(endpoint.MaxPayloadBytes, endpoint.AllowedContentTypes)
+	result["payload_limits"] = map[string]any{
+		"max_bytes":     limits.MaxBytes,
+		"content_types": limits.ContentTypes,
 	}
 
 	data, _ := json.MarshalIndent(result, "", "  ")
@@ -148,15 +298,17 @@ func (s *Server) handleCreateEndpoint(ctx context.Context, req mcp.CallToolReque
 	providerType := mcp.ParseString(req, "provider_type", "")
 	signatureSecret := mcp.ParseString(req, "signature_secret", "")
 	destinationURL := mcp.ParseString(req, "destination_url", "")
+	honeypot := mcp.ParseBoolean(req, "honeypot", false)
 
-	if name == "" || providerType == "" || signatureSecret == "" || destinationURL == "" {
+	// Honeypots never relay, so they need no destination
+	if name == "" || providerType == "" || signatureSecret == "" || (destinationURL == "" && !honeypot) {
 		return mcp.NewToolResultError("name, provider_type, signature_secret, and destination_url are required"), nil
 	}
 
 	// Validate provider type
-	validTypes := map[string]bool{"stripe": true, "github": true, "telegram": true, "generic": true, "custom": true}
+	validTypes := map[string]bool{"stripe": true, "github": true, "telegram": true, "slack": true, "shopify": true, "square": true, "paypal": true, "generic": true, "custom": true}
 	if !validTypes[providerType] {
-		return mcp.NewToolResultError("provider_type must be one of: stripe, github, telegram, generic, custom"), nil
+		return mcp.NewToolResultError("provider_type must be one of: stripe, github, telegram, slack, shopify, square, paypal, generic, custom"), nil
 	}
 
 	// Handle custom verification config
@@ -210,8 +362,14 @@ func (s *Server) handleCreateEndpoint(ctx context.Context, req mcp.CallToolReque
 		}
 	}
 
-	// Generate ID
-	endpointID := id.NewEndpointID()
+	notifyFirst := int64(0)
+	if mcp.ParseBoolean(req, "notify_first_event", false) {
+		notifyFirst = 1
+	}
+	isHoneypot := int64(0)
+	if honeypot {
+		isHoneypot = 1
+	}
 
 	// Encrypt secret
 	encrypted, err := s.secretManager.EncryptSecret(signatureSecret)
@@ -220,14 +378,19 @@ func (s *Server) handleCreateEndpoint(ctx context.Context, req mcp.CallToolReque
 	}
 
 	// Create endpoint
-	endpoint, err := s.queries.CreateEndpoint(ctx, db.CreateEndpointParams{
-		ID:                          endpointID,
+	params := db.CreateEndpointParams{
 		UserID:                      s.userID,
 		Name:                        name,
 		ProviderType:                providerType,
 		SignatureSecretEncrypted:    encrypted,
 		VerificationConfigEncrypted: encryptedVerificationConfig,
 		DestinationUrl:              destinationURL,
+		NotifyFirstEvent:            notifyFirst,
+		Honeypot:                    isHoneypot,
+	}
+	endpoint, err := db.InsertWithID(id.NewEndpointID, func(endpointID string) (db.Endpoint, error) {
+		params.ID = endpointID
+		return s.queries.CreateEndpoint(ctx, params)
 	})
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to create endpoint: %v", err)), nil
@@ -238,8 +401,9 @@ func (s *Server) handleCreateEndpoint(ctx context.Context, req mcp.CallToolReque
 		"name":            endpoint.Name,
 		"provider_type":   endpoint.ProviderType,
 		"destination_url": endpoint.DestinationUrl,
-		"webhook_url":     fmt.Sprintf("%s/h/%s", s.baseURL, endpoint.ID),
-		"created_at":      endpoint.CreatedAt,
+		"webhook_url":     webhook.WebhookURL(s.baseURL, s.pathPrefix, endpoint.ID),
+		"created_at":      db.RFC3339(endpoint.CreatedAt),
+		"honeypot":        honeypot,
 	}
 
 	data, _ := json.MarshalIndent(result, "", "  ")
@@ -308,25 +472,118 @@ func (s *Server) handleMuteEndpoint(ctx context.Context, req mcp.CallToolRequest
 	return mcp.NewToolResultText(fmt.Sprintf("Endpoint %s (%s) is now %s", endpoint.Name, endpoint.ID, status)), nil
 }
 
+func (s *Server) handleSetRetryPolicy(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	endpointID := mcp.ParseString(req, "endpoint_id", "")
+	if endpointID == "" {
+		return mcp.NewToolResultError("endpoint_id is required"), nil
+	}
+
+	policy := webhook.RetryPolicy{
+		MaxAttempts: mcp.ParseInt(req, "max_attempts", 0),
+		BackoffBase: time.Duration(mcp.ParseInt64(req, "backoff_base_seconds", 0)) * time.Second,
+		MaxInterval: time.Duration(mcp.ParseInt64(req, "max_interval_seconds", 0)) * time.Second,
+		Jitter:      mcp.ParseFloat64(req, "jitter", 0),
+	}
+	if err := policy.Validate(); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid retry policy: %v", err)), nil
+	}
+
+	endpoint, err := s.queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		ID:                      endpointID,
+		UserID:                  s.userID,
+		RetryMaxAttempts:        sql.NullInt64{Int64: int64(policy.MaxAttempts), Valid: true},
+		RetryBackoffBaseSeconds: sql.NullInt64{Int64: int64(policy.BackoffBase / time.Second), Valid: true},
+		RetryMaxIntervalSeconds: sql.NullInt64{Int64: int64(policy.MaxInterval / time.Second), Valid: true},
+		RetryJitter:             sql.NullFloat64{Float64: policy.Jitter, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return mcp.NewToolResultError("Endpoint not found"), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update endpoint: %v", err)), nil
+	}
+
+	attempts := "unlimited attempts"
+	if policy.MaxAttempts > 0 {
+		attempts = fmt.Sprintf("at most %d attempts", policy.MaxAttempts)
+	}
+	maxInterval := policy.MaxInterval
+	if maxInterval == 0 {
+		maxInterval = webhook.MaxRetryDelay
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Endpoint %s (%s) now retries with %s, waiting %s doubling up to %s, jitter %.2f",
+		endpoint.Name, endpoint.ID, attempts, policy.Delay(0, 0), maxInterval, policy.Jitter)), nil
+}
+
+func (s *Server) handleSetPayloadLimits(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	endpointID := mcp.ParseString(req, "endpoint_id", "")
+	if endpointID == "" {
+		return mcp.NewToolResultError("endpoint_id is required"), nil
+	}
+
+	limits := webhook.ParsePayloadLimits(mcp.ParseInt64(req, "max_bytes", 0), mcp.ParseString(req, "content_types", ""))
+	if err := limits.Validate(); err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Invalid payload limits: %v", err)), nil
+	}
+
+	endpoint, err := s.queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		ID:                  endpointID,
+		UserID:              s.userID,
+		MaxPayloadBytes:     sql.NullInt64{Int64: limits.MaxBytes, Valid: true},
+		AllowedContentTypes: sql.NullString{String: limits.ContentTypeList(), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return mcp.NewToolResultError("Endpoint not found"), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to update endpoint: %v", err)), nil
+	}
+
+	maxBytes := limits.MaxBytes
+	if maxBytes == 0 {
+		maxBytes = webhook.MaxPayloadSize
+	}
+	contentTypes := "any content type"
+	if len(limits.ContentTypes) > 0 {
+		contentTypes = strings.Join(limits.ContentTypes, ", ")
+	}
+	return mcp.NewToolResultText(fmt.Sprintf("Endpoint %s (%s) now accepts payloads up to %d bytes of %s",
+		endpoint.Name, endpoint.ID, maxBytes, contentTypes)), nil
+}
+
 func (s *Server) handleListWebhooks(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	endpointID := mcp.ParseString(req, "endpoint_id", "")
 	status := mcp.ParseString(req, "status", "")
-	limit := mcp.ParseInt(req, "limit", 50)
+	eventType := mcp.ParseString(req, "event_type", "")
+	includePayload := mcp.ParseBoolean(req, "include_payload", false)
 
-	var endpointIDVal, statusVal interface{}
+	maxLimit := maxListLimit
+	if includePayload {
+		maxLimit = maxPayloadListSize
+	}
+	limit, offset, err := listPage(req, maxLimit)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	var endpointIDVal, statusVal, eventTypeVal interface{}
 	if endpointID != "" {
 		endpointIDVal = endpointID
 	}
 	if status != "" {
 		statusVal = status
 	}
+	if eventType != "" {
+		eventTypeVal = eventType
+	}
 
 	webhooks, err := s.queries.ListWebhooks(ctx, db.ListWebhooksParams{
 		UserID:     s.userID,
 		EndpointID: endpointIDVal,
 		Status:     statusVal,
-		Limit:      int64(limit),
-		Offset:     0,
+		EventType:  eventTypeVal,
+		Limit:      limit + 1,
+		Offset:     offset,
 	})
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to list webhooks: %v", err)), nil
@@ -336,12 +593,19 @@ func (s *Server) handleListWebhooks(ctx context.Context, req mcp.CallToolRequest
 		ID            string `json:"id"`
 		EndpointID    string `json:"endpoint_id"`
 		Status        string `json:"status"`
+		EventType     string `json:"event_type,omitempty"`
 		Attempts      int64  `json:"attempts"`
 		SignatureOK   bool   `json:"signature_valid"`
 		ReceivedAt    string `json:"received_at"`
 		LastAttemptAt string `json:"last_attempt_at,omitempty"`
 		DeliveredAt   string `json:"delivered_at,omitempty"`
 		ErrorMessage  string `json:"error_message,omitempty"`
+		DuplicateOf   string `json:"duplicate_of,omitempty"`
+		SourceIP      string `json:"source_ip,omitempty"`
+		TraceID       string `json:"trace_id,omitempty"`
+		PayloadSize   int    `json:"payload_size"`
+		Payload       string `json:"payload_preview,omitempty"`
+		Truncated     bool   `json:"payload_truncated,omitempty"`
 	}
 
 	results := make([]webhookResult, len(webhooks))
@@ -350,15 +614,24 @@ func (s *Server) handleListWebhooks(ctx context.Context, req mcp.CallToolRequest
 			ID:          w.ID,
 			EndpointID:  w.EndpointID,
 			Status:      w.Status,
+			EventType:   w.EventType.String,
 			Attempts:    w.Attempts,
 			SignatureOK: w.SignatureValid != 0,
-			ReceivedAt:  w.ReceivedAt,
+			ReceivedAt:  db.RFC3339(w.ReceivedAt),
+			DuplicateOf: w.DuplicateOf.String,
+			SourceIP:    w.SourceIp,
+			TraceID:     tracing.TraceIDFromTraceparent(w.Traceparent.String),
+			PayloadSize: len(w.Payload),
+		}
+		if includePayload {
+			preview, truncated := webhook.PayloadPreview(w.Payload)
+			r.Payload, r.Truncated = string(preview), truncated
 		}
 		if w.LastAttemptAt.Valid {
-			r.LastAttemptAt = w.LastAttemptAt.String
+			r.LastAttemptAt = db.RFC3339(w.LastAttemptAt.String)
 		}
 		if w.DeliveredAt.Valid {
-			r.DeliveredAt = w.DeliveredAt.String
+			r.DeliveredAt = db.RFC3339(w.DeliveredAt.String)
 		}
 		if w.ErrorMessage.Valid {
 			r.ErrorMessage = w.ErrorMessage.String
@@ -366,8 +639,7 @@ func (s *Server) handleListWebhooks(ctx context.Context, req mcp.CallToolRequest
 		results[i] = r
 	}
 
-	data, _ := json.MarshalIndent(results, "", "  ")
-	return mcp.NewToolResultText(string(data)), nil
+	return listResult("webhooks", results, limit, offset), nil
 }
 
 func (s *Server) handleGetWebhook(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -376,7 +648,7 @@ func (s *Server) handleGetWebhook(ctx context.Context, req mcp.CallToolRequest)
 		return mcp.NewToolResultError("webhook_id is required"), nil
 	}
 
-	webhook, err := s.queries.GetWebhook(ctx, db.GetWebhookParams{
+	wh, err := s.queries.GetWebhook(ctx, db.GetWebhookParams{
 		ID:     webhookID,
 		UserID: s.userID,
 	})
@@ -389,28 +661,74 @@ func (s *Server) handleGetWebhook(ctx context.Context, req mcp.CallToolRequest)
 
 	// Parse headers
 	var headers map[string]string
-	json.Unmarshal([]byte(webhook.Headers), &headers)
+	json.Unmarshal([]byte(wh.Headers), &headers)
 
 	result := map[string]any{
-		"id":              webhook.ID,
-		"endpoint_id":     webhook.EndpointID,
-		"status":          webhook.Status,
-		"attempts":        webhook.Attempts,
-		"signature_valid": webhook.SignatureValid != 0,
-		"received_at":     webhook.ReceivedAt,
+		"id":              wh.ID,
+		"endpoint_id":     wh.EndpointID,
+		"status":          wh.Status,
+		"event_type":      wh.EventType.String,
+		"attempts":        wh.Attempts,
+		"signature_valid": wh.SignatureValid != 0,
+		"received_at":     db.RFC3339(wh.ReceivedAt),
 		"headers":         headers,
-		"payload":         string(webhook.Payload),
-		"payload_base64":  base64.StdEncoding.EncodeToString(webhook.Payload),
+		"payload_size":    len(wh.Payload),
 	}
 
-	if webhook.LastAttemptAt.Valid {
-		result["last_attempt_at"] = webhook.LastAttemptAt.String
-	}
-	if webhook.DeliveredAt.Valid {
-		result["delivered_at"] = webhook.DeliveredAt.String
-	}
-	if webhook.ErrorMessage.Valid {
-		result["error_message"] = webhook.ErrorMessage.String
+	payload := wh.Payload
+	if path := mcp.ParseString(req, "json_path", ""); path != "" {
+		payload, err = webhook.ExtractJSONPath(payload, path)
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+		result["json_path"] = path
+	}
+	if !mcp.ParseBoolean(req, "include_payload", false) {
+		var truncated bool
+		payload, truncated = webhook.PayloadPreview(payload)
+		result["payload_truncated"] = truncated
+	} else if len(payload) > maxPayloadSize {
+		// Too large to read whole; json_path picks out the part needed
+		payload = payload[:maxPayloadSize]
+		result["payload_truncated"] = true
+	}
+	result["payload"] = string(payload)
+	result["payload_base64"] = base64.StdEncoding.EncodeToString(payload)
+
+	if wh.LastAttemptAt.Valid {
+		result["last_attempt_at"] = db.RFC3339(wh.LastAttemptAt.String)
+	}
+	if wh.DeliveredAt.Valid {
+		result["delivered_at"] = db.RFC3339(wh.DeliveredAt.String)
+	}
+	if wh.ErrorMessage.Valid {
+		result["error_message"] = wh.ErrorMessage.String
+	}
+	if wh.ReplayedAt.Valid {
+		result["replayed_at"] = db.RFC3339(wh.ReplayedAt.String)
+		result["replayed_by"] = wh.ReplayedBy.String
+		result["replay_count"] = wh.ReplayCount
+	}
+	if traceID := tracing.TraceIDFromTraceparent(wh.Traceparent.String); traceID != "" {
+		result["trace_id"] = traceID
+	}
+
+	if history, err := s.queries.ListWebhookStatusHistory(ctx, wh.ID); err == nil && len(history) > 0 {
+		changes := make([]map[string]any, len(history))
+		for i, h := range history {
+			change := map[string]any{"to": h.ToStatus, "at": db.RFC3339(h.ChangedAt)}
+			if h.FromStatus.Valid {
+				change["from"] = h.FromStatus.String
+			}
+			if h.Reason.Valid {
+				change["reason"] = h.Reason.String
+			}
+			if h.ChangedBy.Valid {
+				change["by"] = h.ChangedBy.String
+			}
+			changes[i] = change
+		}
+		result["status_history"] = changes
 	}
 
 	data, _ := json.MarshalIndent(result, "", "  ")
@@ -423,18 +741,77 @@ func (s *Server) handleReplayWebhook(ctx context.Context, req mcp.CallToolReques
 		return mcp.NewToolResultError("webhook_id is required"), nil
 	}
 
-	webhook, err := s.queries.ResetWebhookForReplay(ctx, db.ResetWebhookForReplayParams{
-		ID:     webhookID,
-		UserID: s.userID,
-	})
+	confirmToken := mcp.ParseString(req, "confirm_token", "")
+
+	wh, err := s.replayGuard.Replay(ctx, s.userID, webhookID, confirmToken, s.username+" (MCP)")
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		var confirmErr *webhook.ConfirmationRequiredError
+		switch {
+		case errors.As(err, &confirmErr):
+			return mcp.NewToolResultError(fmt.Sprintf("Endpoint already has %d pending replays. Call again with confirm_token %q to replay anyway.", confirmErr.Pending, confirmErr.Token)), nil
+		case errors.Is(err, webhook.ErrReplayRateLimited):
+			return mcp.NewToolResultError("Replay rate limit exceeded for this endpoint, try again in a minute"), nil
+		case errors.Is(err, webhook.ErrReplayHoneypot):
+			return mcp.NewToolResultError("Webhooks of honeypot endpoints are never relayed and can't be replayed"), nil
+		case errors.Is(err, sql.ErrNoRows):
 			return mcp.NewToolResultError("Webhook not found"), nil
 		}
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to replay webhook: %v", err)), nil
 	}
 
-	return mcp.NewToolResultText(fmt.Sprintf("Webhook %s reset for replay (status: %s, attempts: %d)", webhook.ID, webhook.Status, webhook.Attempts)), nil
+	return mcp.NewToolResultText(fmt.Sprintf("Webhook %s reset for replay (status: %s, attempts: %d)", wh.ID, wh.Status, wh.Attempts)), nil
+}
+
+func (s *Server) handleBulkReplay(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	filter := webhook.BulkReplayFilter{
+		EndpointID: mcp.ParseString(req, "endpoint_id", ""),
+		Status:     mcp.ParseString(req, "status", ""),
+		MaxCount:   mcp.ParseInt(req, "max_count", 0),
+	}
+	for _, bound := range []struct {
+		arg string
+		t   *time.Time
+	}{
+		{"received_after", &filter.ReceivedAfter},
+		{"received_before", &filter.ReceivedBefore},
+	} {
+		if v := mcp.ParseString(req, bound.arg, ""); v != "" {
+			t, err := time.Parse(time.RFC3339, v)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("%s must be an RFC3339 time such as 2026-01-02T15:04:05Z: %v", bound.arg, err)), nil
+			}
+			*bound.t = t
+		}
+	}
+	if err := filter.Validate(); err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	confirmToken := mcp.ParseString(req, "confirm_token", "")
+	replayed, err := s.replayGuard.BulkReplay(ctx, s.userID, filter, confirmToken, s.username+" (MCP)")
+	if err != nil {
+		var confirmErr *webhook.ConfirmationRequiredError
+		switch {
+		case errors.As(err, &confirmErr):
+			return mcp.NewToolResultError(fmt.Sprintf("%d webhooks would be replayed. Call again with the same arguments and confirm_token %q to replay them anyway.", confirmErr.Matching, confirmErr.Token)), nil
+		case errors.Is(err, webhook.ErrReplayRateLimited):
+			return mcp.NewToolResultError("Replay rate limit exceeded, try again in a minute"), nil
+		}
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to replay webhooks: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Reset %d %s webhooks for replay", replayed, filter.Status)), nil
+}
+
+func (s *Server) handleCancelReplays(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	endpointID := mcp.ParseString(req, "endpoint_id", "")
+
+	cancelled, err := s.replayGuard.CancelPending(ctx, s.userID, endpointID)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to cancel replays: %v", err)), nil
+	}
+
+	return mcp.NewToolResultText(fmt.Sprintf("Cancelled %d pending replays", cancelled)), nil
 }
 
 func (s *Server) handleGetStatus(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -443,7 +820,7 @@ func (s *Server) handleGetStatus(ctx context.Context, req mcp.CallToolRequest) (
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to get stats: %v", err)), nil
 	}
 
-	endpointCount, err := s.queries.CountEndpoints(ctx, s.userID)
+	endpointCount, err := s.queries.CountEndpoints(ctx, db.CountEndpointsParams{UserID: s.userID})
 	if err != nil {
 		return mcp.NewToolResultError(fmt.Sprintf("Failed to count endpoints: %v", err)), nil
 	}
@@ -0,0 +1,179 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"hooks.dx314.com/internal/db"
+)
+
+// summaryURI is the resource holding the same briefing as hookly_summary.
+const summaryURI = "hookly://summary"
+
+// Bounds of the summary, which is meant to be read in one go.
+const (
+	summaryFailures  = 5              // Recent failed and dead-lettered webhooks listed
+	summaryHubWindow = 24 * time.Hour // How far back disconnects are looked for
+)
+
+func (s *Server) handleSummary(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	text, err := s.summary(ctx)
+	if err != nil {
+		return mcp.NewToolResultError(fmt.Sprintf("Failed to build summary: %v", err)), nil
+	}
+	return mcp.NewToolResultText(text), nil
+}
+
+func (s *Server) readSummary(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	text, err := s.summary(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return []mcp.ResourceContents{mcp.TextResourceContents{
+		URI:      summaryURI,
+		MIMEType: "text/plain",
+		Text:     text,
+	}}, nil
+}
+
+// summary composes the queue, recent failures, SLO breaches and disconnected
+// hubs into a short briefing, with the problems first.
+func (s *Server) summary(ctx context.Context) (string, error) {
+	stats, err := s.queries.GetQueueStats(ctx, s.userID)
+	if err != nil {
+		return "", fmt.Errorf("queue stats: %w", err)
+	}
+	endpoints, err := s.queries.ListEndpoints(ctx, db.ListEndpointsParams{UserID: s.userID, Limit: 1000})
+	if err != nil {
+		return "", fmt.Errorf("list endpoints: %w", err)
+	}
+	names := make(map[string]string, len(endpoints))
+	for _, e := range endpoints {
+		names[e.ID] = e.Name
+	}
+
+	var failures []db.Webhook
+	for _, status := range []string{"dead_letter", "failed"} {
+		webhooks, err := s.queries.ListWebhooks(ctx, db.ListWebhooksParams{
+			UserID: s.userID,
+			Status: status,
+			Limit:  summaryFailures,
+		})
+		if err != nil {
+			return "", fmt.Errorf("list %s webhooks: %w", status, err)
+		}
+		failures = append(failures, webhooks...)
+	}
+
+	hubs, err := s.disconnectedHubs(ctx)
+	if err != nil {
+		return "", fmt.Errorf("hub activity: %w", err)
+	}
+
+	pending, failed, dead := int64(stats.PendingCount.Float64), int64(stats.FailedCount.Float64), int64(stats.DeadLetterCount.Float64)
+
+	var problems []string
+	if dead > 0 {
+		problems = append(problems, fmt.Sprintf("%d dead-lettered (won't be retried until replayed)", dead))
+	}
+	if failed > 0 {
+		problems = append(problems, fmt.Sprintf("%d failing and being retried", failed))
+	}
+	var breached []string
+	for _, e := range endpoints {
+		if e.SloBreachedAt.Valid {
+			breached = append(breached, fmt.Sprintf("%s (%s) since %s", e.Name, e.ID, db.RFC3339(e.SloBreachedAt.String)))
+		}
+	}
+	if len(breached) > 0 {
+		problems = append(problems, fmt.Sprintf("%d endpoint(s) breaching their SLO", len(breached)))
+	}
+	if len(hubs) > 0 {
+		problems = append(problems, fmt.Sprintf("%d hub(s) disconnected", len(hubs)))
+	}
+
+	var b strings.Builder
+	if len(problems) == 0 {
+		b.WriteString("All clear: no failing deliveries, SLO breaches or disconnected hubs.\n")
+	} else {
+		fmt.Fprintf(&b, "Needs attention: %s.\n", strings.Join(problems, ", "))
+	}
+	fmt.Fprintf(&b, "\nQueue: %d pending, %d failed, %d dead letter across %d endpoints.\n", pending, failed, dead, len(endpoints))
+
+	if len(failures) > 0 {
+		b.WriteString("\nRecent failures:\n")
+		for _, w := range failures {
+			name := names[w.EndpointID]
+			if name == "" {
+				name = w.EndpointID
+			}
+			fmt.Fprintf(&b, "- %s %s on %s after %d attempts", w.ID, w.Status, name, w.Attempts)
+			if w.LastAttemptAt.Valid {
+				fmt.Fprintf(&b, ", last at %s", db.RFC3339(w.LastAttemptAt.String))
+			}
+			if w.ErrorMessage.Valid && w.ErrorMessage.String != "" {
+				fmt.Fprintf(&b, ": %s", w.ErrorMessage.String)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if len(breached) > 0 {
+		b.WriteString("\nSLO breaches:\n")
+		for _, e := range breached {
+			fmt.Fprintf(&b, "- %s\n", e)
+		}
+	}
+
+	if len(hubs) > 0 {
+		b.WriteString("\nHubs disconnected in the last day and not back:\n")
+		for _, h := range hubs {
+			fmt.Fprintf(&b, "- %s since %s\n", h.id, h.at)
+		}
+	}
+
+	if len(failures) > 0 {
+		b.WriteString("\nUse hookly_get_webhook for a failure's history, and hookly_replay_webhook once the destination is fixed.\n")
+	}
+	return b.String(), nil
+}
+
+type disconnectedHub struct {
+	id string
+	at string
+}
+
+// disconnectedHubs returns the hubs whose last connection event in the hub
+// window is a disconnect, newest first. The MCP server has no view of the
+// edge's live connections, so this is read from the activity feed.
+func (s *Server) disconnectedHubs(ctx context.Context) ([]disconnectedHub, error) {
+	events, err := s.queries.ListActivityEvents(ctx, db.ListActivityEventsParams{
+		UserID: s.userID,
+		Since:  db.FormatTime(time.Now().Add(-summaryHubWindow)),
+		Limit:  1000,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var hubs []disconnectedHub
+	// Events are newest first, so the first one per hub is its current state
+	for _, e := range events {
+		if !e.HubID.Valid || seen[e.HubID.String] {
+			continue
+		}
+		switch e.Kind {
+		case "hub_connected":
+			seen[e.HubID.String] = true
+		case "hub_disconnected":
+			seen[e.HubID.String] = true
+			hubs = append(hubs, disconnectedHub{id: e.HubID.String, at: db.RFC3339(e.UpdatedAt)})
+		}
+	}
+	return hubs, nil
+}
@@ -5,22 +5,34 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
-// Define all 9 tools for the Hookly MCP server.
+// Define all 14 tools for the Hookly MCP server. Tools that change nothing
+// are annotated read-only; they are the only ones a read-only server offers.
 func defineTools() []mcp.Tool {
 	return []mcp.Tool{
 		mcp.NewTool("hookly_list_endpoints",
-			mcp.WithDescription("List all webhook endpoints"),
+			mcp.WithDescription("List webhook endpoints with optional filters, a page at a time; pass next_cursor as cursor for the next page"),
+			mcp.WithReadOnlyHintAnnotation(true),
+			mcp.WithString("search", mcp.Description("Filter by case-insensitive substring of the endpoint name")),
+			mcp.WithString("provider_type", mcp.Description("Filter by provider type: stripe, github, telegram, slack, shopify, square, paypal, generic, or custom")),
+			mcp.WithBoolean("muted", mcp.Description("Only muted (true) or unmuted (false) endpoints")),
+			mcp.WithNumber("inactive_days", mcp.Description("Only endpoints without a webhook for this many days (stale or abandoned)")),
+			mcp.WithString("sort", mcp.Description("Sort order: newest (default), oldest, or last_received")),
+			mcp.WithNumber("limit", mcp.Description("Maximum number of endpoints to return (default 50, at most 200)")),
+			mcp.WithString("cursor", mcp.Description("next_cursor from the previous page, to get the next one")),
 		),
 		mcp.NewTool("hookly_get_endpoint",
 			mcp.WithDescription("Get details of a specific endpoint"),
+			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithString("endpoint_id", mcp.Required(), mcp.Description("The endpoint ID")),
 		),
 		mcp.NewTool("hookly_create_endpoint",
 			mcp.WithDescription("Create a new webhook endpoint"),
 			mcp.WithString("name", mcp.Required(), mcp.Description("Endpoint name")),
-			mcp.WithString("provider_type", mcp.Required(), mcp.Description("Provider type: stripe, github, telegram, generic, or custom")),
+			mcp.WithString("provider_type", mcp.Required(), mcp.Description("Provider type: stripe, github, telegram, slack, shopify, square, paypal, generic, or custom")),
 			mcp.WithString("signature_secret", mcp.Required(), mcp.Description("Secret for signature verification")),
-			mcp.WithString("destination_url", mcp.Required(), mcp.Description("URL to forward webhooks to")),
+			mcp.WithString("destination_url", mcp.Description("URL to forward webhooks to (required unless honeypot)")),
+			mcp.WithBoolean("notify_first_event", mcp.Description("Send a notification when the first webhook arrives")),
+			mcp.WithBoolean("honeypot", mcp.Description("Never relay; alert on every request to the URL, to detect leaked URLs")),
 			// Custom verification config (required when provider_type is 'custom')
 			mcp.WithString("verification_method", mcp.Description("For custom provider: static, hmac_sha256, hmac_sha1, or timestamped_hmac")),
 			mcp.WithString("signature_header", mcp.Description("For custom provider: header containing the signature (e.g., X-Signature)")),
@@ -37,22 +49,62 @@ func defineTools() []mcp.Tool {
 			mcp.WithString("endpoint_id", mcp.Required(), mcp.Description("The endpoint ID")),
 			mcp.WithBoolean("muted", mcp.Required(), mcp.Description("Whether to mute (true) or unmute (false)")),
 		),
+		mcp.NewTool("hookly_set_retry_policy",
+			mcp.WithDescription("Set how an endpoint's webhooks are retried after transient delivery failures; omitted fields revert to the default"),
+			mcp.WithString("endpoint_id", mcp.Required(), mcp.Description("The endpoint ID")),
+			mcp.WithNumber("max_attempts", mcp.Description("Attempts before a webhook fails (default 0, unlimited)")),
+			mcp.WithNumber("backoff_base_seconds", mcp.Description("Delay before the first retry, doubled after each (default 1)")),
+			mcp.WithNumber("max_interval_seconds", mcp.Description("Longest delay between retries (default 3600)")),
+			mcp.WithNumber("jitter", mcp.Description("Fraction of each delay randomly taken off, from 0 (default) to 1")),
+		),
+		mcp.NewTool("hookly_set_payload_limits",
+			mcp.WithDescription("Limit the size and content types of webhooks an endpoint accepts, rejected with 413 and 415; omitted fields remove the limit"),
+			mcp.WithString("endpoint_id", mcp.Required(), mcp.Description("The endpoint ID")),
+			mcp.WithNumber("max_bytes", mcp.Description("Largest payload accepted, in bytes (default 0, the edge's 100 MB)")),
+			mcp.WithString("content_types", mcp.Description("Comma-separated media types accepted, type/* for any subtype, e.g. application/json,text/* (default: any)")),
+		),
 		mcp.NewTool("hookly_list_webhooks",
-			mcp.WithDescription("List webhooks with optional filters"),
+			mcp.WithDescription("List webhooks with optional filters, newest first and a page at a time; payloads are left out unless include_payload is set"),
+			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithString("endpoint_id", mcp.Description("Filter by endpoint ID")),
-			mcp.WithString("status", mcp.Description("Filter by status: pending, delivered, failed, dead_letter")),
-			mcp.WithNumber("limit", mcp.Description("Maximum number of webhooks to return (default 50)")),
+			mcp.WithString("status", mcp.Description("Filter by status: pending, delivered, failed, dead_letter, skipped, acknowledged_duplicate")),
+			mcp.WithString("event_type", mcp.Description("Filter by provider event type (e.g. push, payment_intent.succeeded)")),
+			mcp.WithNumber("limit", mcp.Description("Maximum number of webhooks to return (default 50, at most 200, or 20 with include_payload)")),
+			mcp.WithString("cursor", mcp.Description("next_cursor from the previous page, to get the next one")),
+			mcp.WithBoolean("include_payload", mcp.Description("Include the first 4 KB of each payload (default false; use hookly_get_webhook for one payload)")),
 		),
 		mcp.NewTool("hookly_get_webhook",
-			mcp.WithDescription("Get full webhook details including payload"),
+			mcp.WithDescription("Get webhook details with a payload preview"),
+			mcp.WithReadOnlyHintAnnotation(true),
 			mcp.WithString("webhook_id", mcp.Required(), mcp.Description("The webhook ID")),
+			mcp.WithBoolean("include_payload", mcp.Description("Return the full payload, up to 64 KB, instead of the first 4 KB (default false)")),
+			mcp.WithString("json_path", mcp.Description("Return only the JSON value at this path, as a JSON pointer (/data/object/id) or dot notation (data.items[0].id)")),
 		),
 		mcp.NewTool("hookly_replay_webhook",
 			mcp.WithDescription("Replay a webhook for re-delivery"),
 			mcp.WithString("webhook_id", mcp.Required(), mcp.Description("The webhook ID to replay")),
+			mcp.WithString("confirm_token", mcp.Description("Confirmation token returned when many replays are already pending")),
+		),
+		mcp.NewTool("hookly_bulk_replay",
+			mcp.WithDescription("Replay webhooks in bulk for re-delivery, the oldest first: dead letters by default, filtered by endpoint, status and time received"),
+			mcp.WithString("endpoint_id", mcp.Description("Only replay webhooks of this endpoint")),
+			mcp.WithString("status", mcp.Description("Status of the webhooks to replay: dead_letter (default), failed, delivered, acknowledged_duplicate, or skipped")),
+			mcp.WithString("received_after", mcp.Description("Only webhooks received at or after this RFC3339 time")),
+			mcp.WithString("received_before", mcp.Description("Only webhooks received before this RFC3339 time")),
+			mcp.WithNumber("max_count", mcp.Description("Maximum number of webhooks to replay (default 100, at most 1000)")),
+			mcp.WithString("confirm_token", mcp.Description("Confirmation token returned when many webhooks match, valid for the same arguments")),
+		),
+		mcp.NewTool("hookly_cancel_replays",
+			mcp.WithDescription("Cancel all pending replays, optionally for a single endpoint"),
+			mcp.WithString("endpoint_id", mcp.Description("Only cancel replays for this endpoint")),
 		),
 		mcp.NewTool("hookly_get_status",
 			mcp.WithDescription("Get system status including queue depth"),
+			mcp.WithReadOnlyHintAnnotation(true),
+		),
+		mcp.NewTool("hookly_summary",
+			mcp.WithDescription("Briefing on what's wrong with your webhooks: queue depth, recent failures with their errors, SLO breaches and disconnected hubs"),
+			mcp.WithReadOnlyHintAnnotation(true),
 		),
 	}
 }
@@ -0,0 +1,238 @@
+// Package metrics records edge gateway activity and exposes it in the
+// OpenMetrics text format for Prometheus.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ContentType is the content type of the OpenMetrics text format.
+const ContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// Ingestion results besides the ingestion error codes.
+const (
+	ResultStored           = "stored"
+	ResultInvalidSignature = "invalid_signature" // Stored, but the signature didn't verify
+	ResultHoneypot         = "honeypot"
+)
+
+// ACK outcomes reported by hubs.
+const (
+	AckDelivered = "delivered"
+	AckFailed    = "failed"    // Permanent failure, not retried
+	AckRetry     = "retry"     // Transient failure, retried after backoff
+	AckDuplicate = "duplicate" // Already processed by the destination (409)
+)
+
+// deliveryLatencyBuckets are the upper bounds, in seconds, of the delivery
+// latency histogram buckets. Deliveries queued while no hub is connected
+// land in the upper buckets.
+var deliveryLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300, 900, 3600}
+
+// gaugeTimeout bounds reading the queue depth during a scrape.
+const gaugeTimeout = 5 * time.Second
+
+// Metrics records edge gateway activity. Counters are updated as webhooks
+// are received and acknowledged; gauges are read from their source on every
+// scrape. The methods do nothing on a nil *Metrics, so metrics are optional.
+type Metrics struct {
+	mu           sync.Mutex
+	received     map[string]uint64 // By ingestion result
+	acks         map[string]uint64 // By ACK outcome
+	buckets      []uint64          // Cumulative counts per deliveryLatencyBuckets
+	latencySum   float64
+	latencyN     uint64
+	deadLettered uint64
+	unknownIDs   map[string]uint64 // Requests for missing endpoints, by ID format
+
+	queueDepth    func(context.Context) (map[string]int64, error)
+	connectedHubs func() int
+}
+
+// New creates an empty metrics registry.
+func New() *Metrics {
+	return &Metrics{
+		received:   make(map[string]uint64),
+		acks:       make(map[string]uint64),
+		unknownIDs: make(map[string]uint64),
+		buckets:    make([]uint64, len(deliveryLatencyBuckets)),
+	}
+}
+
+// SetQueueDepth sets the function returning the number of stored webhooks
+// by status, read on every scrape.
+func (m *Metrics) SetQueueDepth(fn func(context.Context) (map[string]int64, error)) {
+	m.mu.Lock()
+	m.queueDepth = fn
+	m.mu.Unlock()
+}
+
+// SetConnectedHubs sets the function returning the number of connected hubs.
+func (m *Metrics) SetConnectedHubs(fn func() int) {
+	m.mu.Lock()
+	m.connectedHubs = fn
+	m.mu.Unlock()
+}
+
+// WebhookReceived records the result of an ingestion request: one of the
+// Result constants or an ingestion error code.
+func (m *Metrics) WebhookReceived(result string) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	m.received[result]++
+	m.mu.Unlock()
+}
+
+// UnknownEndpoint records a webhook sent to an endpoint ID that doesn't
+// exist. wellFormed is whether the ID has the format of generated IDs: those
+// are more likely guesses than a scanner's or a stale provider's requests.
+func (m *Metrics) UnknownEndpoint(wellFormed bool) {
+	if m == nil {
+		return
+	}
+	format := "malformed"
+	if wellFormed {
+		format = "well_formed"
+	}
+	m.mu.Lock()
+	m.unknownIDs[format]++
+	m.mu.Unlock()
+}
+
+// Ack records a hub's ACK. latency is the time from receiving the webhook to
+// its delivery and only recorded for AckDelivered.
+func (m *Metrics) Ack(outcome string, latency time.Duration) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.acks[outcome]++
+	if outcome != AckDelivered {
+		return
+	}
+	seconds := max(latency.Seconds(), 0)
+	for i, le := range deliveryLatencyBuckets {
+		if seconds <= le {
+			m.buckets[i]++
+		}
+	}
+	m.latencySum += seconds
+	m.latencyN++
+}
+
+// DeadLettered records webhooks moved to the dead letter queue.
+func (m *Metrics) DeadLettered(n int64) {
+	if m == nil || n <= 0 {
+		return
+	}
+	m.mu.Lock()
+	m.deadLettered += uint64(n)
+	m.mu.Unlock()
+}
+
+// WriteTo writes the metrics in the OpenMetrics text format, without the
+// trailing # EOF so they can be combined with other metrics.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), gaugeTimeout)
+	defer cancel()
+
+	// Read the gauges first: the queue depth runs a query
+	m.mu.Lock()
+	queueDepth, connectedHubs := m.queueDepth, m.connectedHubs
+	m.mu.Unlock()
+	var depth map[string]int64
+	if queueDepth != nil {
+		var err error
+		if depth, err = queueDepth(ctx); err != nil {
+			slog.Error("failed to read queue depth for metrics", "error", err)
+		}
+	}
+
+	var b strings.Builder
+	if depth != nil {
+		b.WriteString("# TYPE hookly_webhooks gauge\n")
+		b.WriteString("# HELP hookly_webhooks Stored webhooks by status; pending is the delivery queue.\n")
+		for _, status := range sortedKeys(depth) {
+			fmt.Fprintf(&b, "hookly_webhooks{status=%q} %d\n", status, depth[status])
+		}
+	}
+	if connectedHubs != nil {
+		b.WriteString("# TYPE hookly_connected_hubs gauge\n")
+		b.WriteString("# HELP hookly_connected_hubs Hubs connected to the edge.\n")
+		fmt.Fprintf(&b, "hookly_connected_hubs %d\n", connectedHubs())
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b.WriteString("# TYPE hookly_webhooks_received counter\n")
+	b.WriteString("# HELP hookly_webhooks_received Ingestion requests by result: stored, invalid_signature, honeypot or the error code.\n")
+	for _, result := range sortedKeys(m.received) {
+		fmt.Fprintf(&b, "hookly_webhooks_received_total{result=%q} %d\n", result, m.received[result])
+	}
+
+	b.WriteString("# TYPE hookly_unknown_endpoint_requests counter\n")
+	b.WriteString("# HELP hookly_unknown_endpoint_requests Ingestion requests for endpoint IDs that don't exist, by whether the ID is well_formed or malformed. A rise in well_formed ones suggests IDs are being guessed.\n")
+	for _, format := range sortedKeys(m.unknownIDs) {
+		fmt.Fprintf(&b, "hookly_unknown_endpoint_requests_total{id=%q} %d\n", format, m.unknownIDs[format])
+	}
+
+	b.WriteString("# TYPE hookly_delivery_acks counter\n")
+	b.WriteString("# HELP hookly_delivery_acks Delivery ACKs from hubs by outcome: delivered, failed or retry.\n")
+	for _, outcome := range sortedKeys(m.acks) {
+		fmt.Fprintf(&b, "hookly_delivery_acks_total{outcome=%q} %d\n", outcome, m.acks[outcome])
+	}
+
+	b.WriteString("# TYPE hookly_delivery_latency_seconds histogram\n")
+	b.WriteString("# UNIT hookly_delivery_latency_seconds seconds\n")
+	b.WriteString("# HELP hookly_delivery_latency_seconds Time from receiving a webhook to the hub acknowledging its delivery.\n")
+	for i, le := range deliveryLatencyBuckets {
+		fmt.Fprintf(&b, "hookly_delivery_latency_seconds_bucket{le=\"%s\"} %d\n", strconv.FormatFloat(le, 'g', -1, 64), m.buckets[i])
+	}
+	fmt.Fprintf(&b, "hookly_delivery_latency_seconds_bucket{le=\"+Inf\"} %d\n", m.latencyN)
+	fmt.Fprintf(&b, "hookly_delivery_latency_seconds_sum %s\n", strconv.FormatFloat(m.latencySum, 'g', -1, 64))
+	fmt.Fprintf(&b, "hookly_delivery_latency_seconds_count %d\n", m.latencyN)
+
+	b.WriteString("# TYPE hookly_webhooks_dead_lettered counter\n")
+	b.WriteString("# HELP hookly_webhooks_dead_lettered Webhooks moved to the dead letter queue.\n")
+	fmt.Fprintf(&b, "hookly_webhooks_dead_lettered_total %d\n", m.deadLettered)
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// Handler serves the combined metrics of sources, each written without a
+// trailing # EOF, in the OpenMetrics text format.
+func Handler(sources ...io.WriterTo) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		for _, src := range sources {
+			if _, err := src.WriteTo(w); err != nil {
+				return
+			}
+		}
+		io.WriteString(w, "# EOF\n")
+	})
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
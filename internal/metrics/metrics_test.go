@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsWriteTo(t *testing.T) {
+	m := New()
+	m.WebhookReceived(ResultStored)
+	m.WebhookReceived(ResultStored)
+	m.WebhookReceived("muted")
+	m.Ack(AckDelivered, 80*time.Millisecond)
+	m.Ack(AckDelivered, 20*time.Minute)
+	m.Ack(AckRetry, 0)
+	m.DeadLettered(3)
+	m.UnknownEndpoint(true)
+	m.UnknownEndpoint(false)
+	m.UnknownEndpoint(true)
+	m.SetConnectedHubs(func() int { return 2 })
+	m.SetQueueDepth(func(context.Context) (map[string]int64, error) {
+		return map[string]int64{"pending": 5, "dead_letter": 3}, nil
+	})
+
+	var sb strings.Builder
+	if _, err := m.WriteTo(&sb); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	out := sb.String()
+
+	for _, want := range []string{
+		`hookly_webhooks{status="dead_letter"} 3` + "\n",
+		`hookly_webhooks{status="pending"} 5` + "\n",
+		"hookly_connected_hubs 2\n",
+		`hookly_webhooks_received_total{result="muted"} 1` + "\n",
+		`hookly_webhooks_received_total{result="stored"} 2` + "\n",
+		`hookly_delivery_acks_total{outcome="delivered"} 2` + "\n",
+		`hookly_delivery_acks_total{outcome="retry"} 1` + "\n",
+		`hookly_delivery_latency_seconds_bucket{le="0.1"} 1` + "\n",
+		`hookly_delivery_latency_seconds_bucket{le="900"} 1` + "\n",
+		`hookly_delivery_latency_seconds_bucket{le="3600"} 2` + "\n",
+		`hookly_delivery_latency_seconds_bucket{le="+Inf"} 2` + "\n",
+		"hookly_delivery_latency_seconds_count 2\n",
+		"hookly_webhooks_dead_lettered_total 3\n",
+		`hookly_unknown_endpoint_requests_total{id="malformed"} 1` + "\n",
+		`hookly_unknown_endpoint_requests_total{id="well_formed"} 2` + "\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "# EOF") {
+		t.Error("WriteTo must not write # EOF")
+	}
+}
+
+func TestMetricsGaugeErrors(t *testing.T) {
+	m := New()
+	m.SetQueueDepth(func(context.Context) (map[string]int64, error) {
+		return nil, errors.New("database is locked")
+	})
+
+	var sb strings.Builder
+	if _, err := m.WriteTo(&sb); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	// A failed gauge is left out rather than failing the scrape
+	if out := sb.String(); strings.Contains(out, "hookly_webhooks{") || !strings.Contains(out, "hookly_webhooks_dead_lettered_total 0\n") {
+		t.Errorf("output:\n%s", out)
+	}
+}
+
+func TestNilMetrics(t *testing.T) {
+	var m *Metrics
+	m.WebhookReceived(ResultStored)
+	m.Ack(AckDelivered, time.Second)
+	m.DeadLettered(1)
+}
+
+func TestHandler(t *testing.T) {
+	m := New()
+	m.WebhookReceived(ResultStored)
+	other := New()
+	other.DeadLettered(1)
+
+	rec := httptest.NewRecorder()
+	Handler(m, other).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	out := rec.Body.String()
+	if !strings.Contains(out, `hookly_webhooks_received_total{result="stored"} 1`) || !strings.Contains(out, "hookly_webhooks_dead_lettered_total 1\n") {
+		t.Errorf("missing metrics of a source:\n%s", out)
+	}
+	if !strings.HasSuffix(out, "# EOF\n") || strings.Count(out, "# EOF") != 1 {
+		t.Error("exposition must end with a single # EOF")
+	}
+}
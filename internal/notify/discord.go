@@ -0,0 +1,333 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Embed colors, as RGB integers.
+const (
+	discordColorRed    = 0xE74C3C
+	discordColorOrange = 0xE67E22
+	discordColorGreen  = 0x2ECC71
+	discordColorGray   = 0x95A5A6
+)
+
+// DiscordNotifier sends notifications to a Discord channel through one of its
+// webhooks.
+type DiscordNotifier struct {
+	webhookURL string
+	baseURL    string // For webhook detail links
+	client     *http.Client
+}
+
+// NewDiscordNotifier creates a new Discord notifier posting to webhookURL,
+// such as https://discord.com/api/webhooks/<id>/<token>.
+func NewDiscordNotifier(webhookURL, baseURL string) *DiscordNotifier {
+	return &DiscordNotifier{
+		webhookURL: webhookURL,
+		baseURL:    baseURL,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// ValidateDiscordWebhookURL checks that u is a Discord webhook URL, so
+// notifications can't be pointed at other hosts. Errors leave out the URL,
+// which holds the webhook's token.
+func ValidateDiscordWebhookURL(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil || parsed.Scheme != "https" {
+		return errors.New("not an https URL")
+	}
+	switch parsed.Hostname() {
+	case "discord.com", "discordapp.com", "canary.discord.com", "ptb.discord.com":
+	default:
+		return fmt.Errorf("%q is not a Discord host", parsed.Hostname())
+	}
+	if !strings.HasPrefix(parsed.Path, "/api/webhooks/") {
+		return errors.New("not a Discord webhook URL: expected https://discord.com/api/webhooks/<id>/<token>")
+	}
+	return nil
+}
+
+// NotifyDeliveryFailure sends a notification when a webhook fails permanently.
+func (d *DiscordNotifier) NotifyDeliveryFailure(ctx context.Context, info WebhookInfo) error {
+	embed := discordEmbed{
+		Title: "🚨 Webhook Delivery Failed",
+		Description: fmt.Sprintf("Endpoint: %s\nWebhook ID: `%s`\nAttempts: %d\nError: %s",
+			discordEscape(info.EndpointName),
+			info.ID,
+			info.Attempts,
+			discordEscape(info.Error),
+		),
+		URL:   d.baseURL + "/webhooks/" + info.ID,
+		Color: discordColorRed,
+	}
+
+	if err := d.send(ctx, embed); err != nil {
+		slog.Error("failed to send delivery failure notification",
+			"webhook_id", info.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent delivery failure notification",
+		"webhook_id", info.ID,
+		"endpoint", info.EndpointName,
+	)
+	return nil
+}
+
+// NotifyDeadLetter sends a notification when a webhook becomes a dead letter.
+func (d *DiscordNotifier) NotifyDeadLetter(ctx context.Context, info WebhookInfo) error {
+	embed := discordEmbed{
+		Title: "⚠️ Webhook Dead Letter",
+		Description: fmt.Sprintf("Endpoint: %s\nWebhook ID: `%s`\nReceived: %s\n\nWebhook exceeded 7-day delivery window.",
+			discordEscape(info.EndpointName),
+			info.ID,
+			info.ReceivedAt.Format("2006-01-02 15:04:05 UTC"),
+		),
+		URL:   d.baseURL + "/webhooks/" + info.ID,
+		Color: discordColorOrange,
+	}
+
+	if err := d.send(ctx, embed); err != nil {
+		slog.Error("failed to send dead letter notification",
+			"webhook_id", info.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent dead letter notification",
+		"webhook_id", info.ID,
+		"endpoint", info.EndpointName,
+	)
+	return nil
+}
+
+// NotifyFirstEvent sends a notification when an endpoint receives its first webhook.
+func (d *DiscordNotifier) NotifyFirstEvent(ctx context.Context, info WebhookInfo) error {
+	embed := discordEmbed{
+		Title: "✅ First Webhook Received",
+		Description: fmt.Sprintf("Endpoint: %s\nWebhook ID: `%s`\nReceived: %s\n\nThe provider is configured correctly.",
+			discordEscape(info.EndpointName),
+			info.ID,
+			info.ReceivedAt.Format("2006-01-02 15:04:05 UTC"),
+		),
+		URL:   d.baseURL + "/webhooks/" + info.ID,
+		Color: discordColorGreen,
+	}
+
+	if err := d.send(ctx, embed); err != nil {
+		slog.Error("failed to send first event notification",
+			"webhook_id", info.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent first event notification",
+		"webhook_id", info.ID,
+		"endpoint", info.EndpointName,
+	)
+	return nil
+}
+
+// NotifySLOBreach sends a notification when an endpoint's delivery SLO is breached.
+func (d *DiscordNotifier) NotifySLOBreach(ctx context.Context, info SLOInfo) error {
+	embed := discordEmbed{
+		Title: "📉 Delivery SLO Breached",
+		Description: fmt.Sprintf("Endpoint: %s\nDestination: %s\nCompliance: %.2f%% (target %.2f%%)\nDelivered within %s: %d of %d in the last %s",
+			discordEscape(info.EndpointName),
+			discordEscape(info.DestinationURL),
+			info.Compliance,
+			info.Target,
+			info.Latency,
+			info.Met,
+			info.Total,
+			info.Window,
+		),
+		URL:   d.baseURL + "/endpoints/" + info.EndpointID,
+		Color: discordColorOrange,
+	}
+
+	if err := d.send(ctx, embed); err != nil {
+		slog.Error("failed to send slo breach notification",
+			"endpoint_id", info.EndpointID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent slo breach notification",
+		"endpoint_id", info.EndpointID,
+		"endpoint", info.EndpointName,
+	)
+	return nil
+}
+
+// NotifyHoneypotHit sends a notification when a honeypot endpoint receives a request.
+func (d *DiscordNotifier) NotifyHoneypotHit(ctx context.Context, info HoneypotInfo) error {
+	names := make([]string, 0, len(info.Headers))
+	for name := range info.Headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	var headers strings.Builder
+	for i, name := range names {
+		if i == honeypotMaxHeaders {
+			fmt.Fprintf(&headers, "... %d more\n", len(names)-i)
+			break
+		}
+		value := info.Headers[name]
+		if len(value) > honeypotMaxHeaderValue {
+			value = value[:honeypotMaxHeaderValue] + "..."
+		}
+		fmt.Fprintf(&headers, "%s: %s\n", name, value)
+	}
+
+	embed := discordEmbed{
+		Title: "🪤 Honeypot Endpoint Hit",
+		Description: fmt.Sprintf("Endpoint: %s\nRequest: %s from `%s`\nReceived: %s\nPayload: %d bytes\n```\n%s```",
+			discordEscape(info.EndpointName),
+			discordEscape(info.Method),
+			strings.ReplaceAll(info.SourceIP, "`", ""),
+			info.ReceivedAt.Format("2006-01-02 15:04:05 UTC"),
+			info.PayloadSize,
+			// The headers can't end the code block
+			strings.ReplaceAll(headers.String(), "```", "'''"),
+		),
+		URL:   d.baseURL + "/webhooks/" + info.WebhookID,
+		Color: discordColorRed,
+	}
+
+	if err := d.send(ctx, embed); err != nil {
+		slog.Error("failed to send honeypot notification",
+			"endpoint_id", info.EndpointID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent honeypot notification",
+		"endpoint_id", info.EndpointID,
+		"source_ip", info.SourceIP,
+	)
+	return nil
+}
+
+// NotifyEndpointArchived sends a notification when an inactive endpoint is muted.
+func (d *DiscordNotifier) NotifyEndpointArchived(ctx context.Context, info ArchiveInfo) error {
+	last := "never"
+	if !info.LastWebhookAt.IsZero() {
+		last = info.LastWebhookAt.Format("2006-01-02 15:04:05 UTC")
+	}
+
+	embed := discordEmbed{
+		Title: "🗄 Endpoint Archived",
+		Description: fmt.Sprintf("Endpoint: %s\nLast webhook: %s\nNo webhooks for %s, so the endpoint was muted. Unmute it to resume relaying.",
+			discordEscape(info.EndpointName),
+			last,
+			info.InactiveFor,
+		),
+		URL:   d.baseURL + "/endpoints/" + info.EndpointID,
+		Color: discordColorGray,
+	}
+
+	if err := d.send(ctx, embed); err != nil {
+		slog.Error("failed to send archive notification",
+			"endpoint_id", info.EndpointID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent archive notification",
+		"endpoint_id", info.EndpointID,
+		"endpoint", info.EndpointName,
+	)
+	return nil
+}
+
+// discordEmbed is a message embed. The title links to url; the description
+// is at most 4096 characters of Discord markdown.
+type discordEmbed struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	URL         string `json:"url,omitempty"`
+	Color       int    `json:"color"`
+}
+
+type discordRequest struct {
+	Embeds []discordEmbed `json:"embeds"`
+	// Names in messages can't mention @everyone or roles
+	AllowedMentions struct {
+		Parse []string `json:"parse"`
+	} `json:"allowed_mentions"`
+}
+
+type discordErrorResponse struct {
+	Message string `json:"message"`
+}
+
+// discordMarkdown replaces the characters Discord reads as markdown with
+// their escaped form.
+var discordMarkdown = strings.NewReplacer(
+	`\`, `\\`, "*", `\*`, "_", `\_`, "~", `\~`, "`", "\\`", "|", `\|`, ">", `\>`, "#", `\#`, "[", `\[`, "]", `\]`,
+)
+
+func discordEscape(s string) string {
+	return discordMarkdown.Replace(s)
+}
+
+func (d *DiscordNotifier) send(ctx context.Context, embed discordEmbed) error {
+	msg := discordRequest{Embeds: []discordEmbed{embed}}
+	msg.AllowedMentions.Parse = []string{}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		// Without the URL, which holds the webhook's token
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Discord answers 204 without a body unless asked to wait for the message
+	if resp.StatusCode >= 300 {
+		var result discordErrorResponse
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		if json.Unmarshal(data, &result) != nil || result.Message == "" {
+			result.Message = http.StatusText(resp.StatusCode)
+		}
+		return fmt.Errorf("discord error: %d %s", resp.StatusCode, result.Message)
+	}
+
+	return nil
+}
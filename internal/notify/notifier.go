@@ -3,6 +3,7 @@ package notify
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
@@ -17,6 +18,57 @@ type WebhookInfo struct {
 	ReceivedAt     time.Time
 }
 
+// SLOInfo contains information about an endpoint's SLO breach for notifications.
+type SLOInfo struct {
+	EndpointID     string
+	EndpointName   string
+	DestinationURL string
+	Target         float64
+	Compliance     float64
+	Latency        time.Duration
+	Window         time.Duration
+	Total          int64
+	Met            int64
+}
+
+// HoneypotInfo describes a hit on a honeypot endpoint.
+type HoneypotInfo struct {
+	WebhookID    string
+	EndpointID   string
+	EndpointName string
+	Method       string
+	SourceIP     string
+	Headers      map[string]string
+	PayloadSize  int
+	ReceivedAt   time.Time
+}
+
+// ArchiveInfo describes an endpoint muted automatically for inactivity.
+type ArchiveInfo struct {
+	EndpointID    string
+	EndpointName  string
+	LastWebhookAt time.Time // Zero if the endpoint never received a webhook
+	InactiveFor   time.Duration
+}
+
+// DisconnectInfo describes an endpoint left without a connected hub.
+type DisconnectInfo struct {
+	EndpointID     string
+	EndpointName   string
+	HubID          string // The hub that was last connected
+	DisconnectedAt time.Time
+}
+
+// ReconnectInfo describes an endpoint whose hub came back after its
+// disconnect was reported.
+type ReconnectInfo struct {
+	EndpointID     string
+	EndpointName   string
+	HubID          string // The hub that connected
+	DisconnectedAt time.Time
+	ReconnectedAt  time.Time
+}
+
 // Notifier sends notifications for webhook events.
 type Notifier interface {
 	// NotifyDeliveryFailure sends a notification when a webhook fails permanently (4xx).
@@ -24,6 +76,31 @@ type Notifier interface {
 
 	// NotifyDeadLetter sends a notification when a webhook becomes a dead letter.
 	NotifyDeadLetter(ctx context.Context, info WebhookInfo) error
+
+	// NotifyFirstEvent sends a notification when an endpoint receives its first webhook.
+	NotifyFirstEvent(ctx context.Context, info WebhookInfo) error
+
+	// NotifySLOBreach sends a notification when an endpoint's delivery SLO is breached.
+	NotifySLOBreach(ctx context.Context, info SLOInfo) error
+
+	// NotifyHoneypotHit sends a notification when a honeypot endpoint receives a request.
+	NotifyHoneypotHit(ctx context.Context, info HoneypotInfo) error
+
+	// NotifyEndpointArchived sends a notification when an inactive endpoint is muted.
+	NotifyEndpointArchived(ctx context.Context, info ArchiveInfo) error
+}
+
+// ConnectionNotifier is implemented by notifiers that also report endpoints
+// left without a hub, and their hub coming back. Chat channels don't: a hub
+// on a laptop disconnects whenever it sleeps.
+type ConnectionNotifier interface {
+	// NotifyEndpointDisconnected sends a notification when an endpoint's last
+	// hub disconnects.
+	NotifyEndpointDisconnected(ctx context.Context, info DisconnectInfo) error
+
+	// NotifyEndpointReconnected sends a notification when a hub connects for
+	// an endpoint whose disconnect was reported.
+	NotifyEndpointReconnected(ctx context.Context, info ReconnectInfo) error
 }
 
 // NopNotifier is a no-op notifier that does nothing.
@@ -39,3 +116,89 @@ func (NopNotifier) NotifyDeliveryFailure(context.Context, WebhookInfo) error {
 func (NopNotifier) NotifyDeadLetter(context.Context, WebhookInfo) error {
 	return nil
 }
+
+// NotifyFirstEvent does nothing.
+func (NopNotifier) NotifyFirstEvent(context.Context, WebhookInfo) error {
+	return nil
+}
+
+// NotifySLOBreach does nothing.
+func (NopNotifier) NotifySLOBreach(context.Context, SLOInfo) error {
+	return nil
+}
+
+// NotifyHoneypotHit does nothing.
+func (NopNotifier) NotifyHoneypotHit(context.Context, HoneypotInfo) error {
+	return nil
+}
+
+// NotifyEndpointArchived does nothing.
+func (NopNotifier) NotifyEndpointArchived(context.Context, ArchiveInfo) error {
+	return nil
+}
+
+// MultiNotifier sends every notification with each of its notifiers, for
+// more than one channel. It returns their errors joined.
+type MultiNotifier []Notifier
+
+// NotifyDeliveryFailure notifies every channel.
+func (m MultiNotifier) NotifyDeliveryFailure(ctx context.Context, info WebhookInfo) error {
+	return m.each(func(n Notifier) error { return n.NotifyDeliveryFailure(ctx, info) })
+}
+
+// NotifyDeadLetter notifies every channel.
+func (m MultiNotifier) NotifyDeadLetter(ctx context.Context, info WebhookInfo) error {
+	return m.each(func(n Notifier) error { return n.NotifyDeadLetter(ctx, info) })
+}
+
+// NotifyFirstEvent notifies every channel.
+func (m MultiNotifier) NotifyFirstEvent(ctx context.Context, info WebhookInfo) error {
+	return m.each(func(n Notifier) error { return n.NotifyFirstEvent(ctx, info) })
+}
+
+// NotifySLOBreach notifies every channel.
+func (m MultiNotifier) NotifySLOBreach(ctx context.Context, info SLOInfo) error {
+	return m.each(func(n Notifier) error { return n.NotifySLOBreach(ctx, info) })
+}
+
+// NotifyHoneypotHit notifies every channel.
+func (m MultiNotifier) NotifyHoneypotHit(ctx context.Context, info HoneypotInfo) error {
+	return m.each(func(n Notifier) error { return n.NotifyHoneypotHit(ctx, info) })
+}
+
+// NotifyEndpointArchived notifies every channel.
+func (m MultiNotifier) NotifyEndpointArchived(ctx context.Context, info ArchiveInfo) error {
+	return m.each(func(n Notifier) error { return n.NotifyEndpointArchived(ctx, info) })
+}
+
+// NotifyEndpointDisconnected notifies every channel that reports
+// disconnects.
+func (m MultiNotifier) NotifyEndpointDisconnected(ctx context.Context, info DisconnectInfo) error {
+	return m.each(func(n Notifier) error {
+		if c, ok := n.(ConnectionNotifier); ok {
+			return c.NotifyEndpointDisconnected(ctx, info)
+		}
+		return nil
+	})
+}
+
+// NotifyEndpointReconnected notifies every channel that reports
+// disconnects.
+func (m MultiNotifier) NotifyEndpointReconnected(ctx context.Context, info ReconnectInfo) error {
+	return m.each(func(n Notifier) error {
+		if c, ok := n.(ConnectionNotifier); ok {
+			return c.NotifyEndpointReconnected(ctx, info)
+		}
+		return nil
+	})
+}
+
+func (m MultiNotifier) each(notify func(Notifier) error) error {
+	var errs []error
+	for _, n := range m {
+		if err := notify(n); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
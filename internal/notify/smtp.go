@@ -0,0 +1,440 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"log/slog"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"slices"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+)
+
+// SMTP connection security modes.
+const (
+	SMTPStartTLS = "starttls" // Upgrade a plain connection, usually on port 587
+	SMTPTLS      = "tls"      // TLS from the start, usually on port 465
+	SMTPNone     = "none"     // No encryption, for a relay on localhost
+)
+
+// smtpTimeout bounds sending one email, from dialing to QUIT.
+const smtpTimeout = 30 * time.Second
+
+// SMTPConfig is the mail server notifications are sent through.
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // No authentication if empty
+	Password string
+	From     string // Such as "Hookly <hookly@example.com>"
+	Security string // SMTPStartTLS, SMTPTLS or SMTPNone
+}
+
+// Enabled reports whether a server and sender are configured.
+func (c SMTPConfig) Enabled() bool {
+	return c.Host != "" && c.From != ""
+}
+
+// ValidateEmailAddress checks that addr is a single email address, such as
+// ops@example.com or "Ops <ops@example.com>".
+func ValidateEmailAddress(addr string) error {
+	if _, err := mail.ParseAddress(addr); err != nil {
+		return fmt.Errorf("invalid email address %q", addr)
+	}
+	return nil
+}
+
+//go:embed templates/email.html templates/email.txt
+var emailTemplates embed.FS
+
+var (
+	emailHTML = htmltemplate.Must(htmltemplate.ParseFS(emailTemplates, "templates/email.html"))
+	emailText = texttemplate.Must(texttemplate.ParseFS(emailTemplates, "templates/email.txt"))
+)
+
+// emailData fills the email templates.
+type emailData struct {
+	Title    string
+	Rows     []emailRow
+	Note     string
+	Code     string // Preformatted, such as request headers
+	LinkURL  string
+	LinkText string
+}
+
+type emailRow struct {
+	Label string
+	Value string
+}
+
+// SMTPNotifier sends notifications by email.
+type SMTPNotifier struct {
+	cfg     SMTPConfig
+	to      string
+	baseURL string // For webhook detail links
+}
+
+// NewSMTPNotifier creates a new email notifier sending to the address to
+// through the server of cfg.
+func NewSMTPNotifier(cfg SMTPConfig, to, baseURL string) *SMTPNotifier {
+	return &SMTPNotifier{
+		cfg:     cfg,
+		to:      to,
+		baseURL: baseURL,
+	}
+}
+
+// NotifyDeliveryFailure sends a notification when a webhook fails permanently.
+func (s *SMTPNotifier) NotifyDeliveryFailure(ctx context.Context, info WebhookInfo) error {
+	data := emailData{
+		Title: "Webhook Delivery Failed",
+		Rows: []emailRow{
+			{"Endpoint", info.EndpointName},
+			{"Webhook ID", info.ID},
+			{"Attempts", strconv.Itoa(info.Attempts)},
+			{"Error", info.Error},
+		},
+		LinkURL:  s.baseURL + "/webhooks/" + info.ID,
+		LinkText: "View Details",
+	}
+
+	if err := s.send(ctx, "Webhook delivery failed: "+info.EndpointName, data); err != nil {
+		slog.Error("failed to send delivery failure notification",
+			"webhook_id", info.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent delivery failure notification",
+		"webhook_id", info.ID,
+		"endpoint", info.EndpointName,
+	)
+	return nil
+}
+
+// NotifyDeadLetter sends a notification when a webhook becomes a dead letter.
+func (s *SMTPNotifier) NotifyDeadLetter(ctx context.Context, info WebhookInfo) error {
+	data := emailData{
+		Title: "Webhook Dead Letter",
+		Rows: []emailRow{
+			{"Endpoint", info.EndpointName},
+			{"Webhook ID", info.ID},
+			{"Received", info.ReceivedAt.Format("2006-01-02 15:04:05 UTC")},
+		},
+		Note:     "Webhook exceeded 7-day delivery window.",
+		LinkURL:  s.baseURL + "/webhooks/" + info.ID,
+		LinkText: "View Details",
+	}
+
+	if err := s.send(ctx, "Webhook dead letter: "+info.EndpointName, data); err != nil {
+		slog.Error("failed to send dead letter notification",
+			"webhook_id", info.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent dead letter notification",
+		"webhook_id", info.ID,
+		"endpoint", info.EndpointName,
+	)
+	return nil
+}
+
+// NotifyFirstEvent sends a notification when an endpoint receives its first webhook.
+func (s *SMTPNotifier) NotifyFirstEvent(ctx context.Context, info WebhookInfo) error {
+	data := emailData{
+		Title: "First Webhook Received",
+		Rows: []emailRow{
+			{"Endpoint", info.EndpointName},
+			{"Webhook ID", info.ID},
+			{"Received", info.ReceivedAt.Format("2006-01-02 15:04:05 UTC")},
+		},
+		Note:     "The provider is configured correctly.",
+		LinkURL:  s.baseURL + "/webhooks/" + info.ID,
+		LinkText: "View Webhook",
+	}
+
+	if err := s.send(ctx, "First webhook received: "+info.EndpointName, data); err != nil {
+		slog.Error("failed to send first event notification",
+			"webhook_id", info.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent first event notification",
+		"webhook_id", info.ID,
+		"endpoint", info.EndpointName,
+	)
+	return nil
+}
+
+// NotifySLOBreach sends a notification when an endpoint's delivery SLO is breached.
+func (s *SMTPNotifier) NotifySLOBreach(ctx context.Context, info SLOInfo) error {
+	data := emailData{
+		Title: "Delivery SLO Breached",
+		Rows: []emailRow{
+			{"Endpoint", info.EndpointName},
+			{"Destination", info.DestinationURL},
+			{"Compliance", fmt.Sprintf("%.2f%% (target %.2f%%)", info.Compliance, info.Target)},
+			{"Delivered within " + info.Latency.String(), fmt.Sprintf("%d of %d in the last %s", info.Met, info.Total, info.Window)},
+		},
+		LinkURL:  s.baseURL + "/endpoints/" + info.EndpointID,
+		LinkText: "View Endpoint",
+	}
+
+	if err := s.send(ctx, "Delivery SLO breached: "+info.EndpointName, data); err != nil {
+		slog.Error("failed to send slo breach notification",
+			"endpoint_id", info.EndpointID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent slo breach notification",
+		"endpoint_id", info.EndpointID,
+		"endpoint", info.EndpointName,
+	)
+	return nil
+}
+
+// NotifyHoneypotHit sends a notification when a honeypot endpoint receives a request.
+func (s *SMTPNotifier) NotifyHoneypotHit(ctx context.Context, info HoneypotInfo) error {
+	names := make([]string, 0, len(info.Headers))
+	for name := range info.Headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	var headers strings.Builder
+	for i, name := range names {
+		if i == honeypotMaxHeaders {
+			fmt.Fprintf(&headers, "... %d more\n", len(names)-i)
+			break
+		}
+		value := info.Headers[name]
+		if len(value) > honeypotMaxHeaderValue {
+			value = value[:honeypotMaxHeaderValue] + "..."
+		}
+		fmt.Fprintf(&headers, "%s: %s\n", name, value)
+	}
+
+	data := emailData{
+		Title: "Honeypot Endpoint Hit",
+		Rows: []emailRow{
+			{"Endpoint", info.EndpointName},
+			{"Request", info.Method + " from " + info.SourceIP},
+			{"Received", info.ReceivedAt.Format("2006-01-02 15:04:05 UTC")},
+			{"Payload", fmt.Sprintf("%d bytes", info.PayloadSize)},
+		},
+		Code:     headers.String(),
+		LinkURL:  s.baseURL + "/webhooks/" + info.WebhookID,
+		LinkText: "View Request",
+	}
+
+	if err := s.send(ctx, "Honeypot endpoint hit: "+info.EndpointName, data); err != nil {
+		slog.Error("failed to send honeypot notification",
+			"endpoint_id", info.EndpointID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent honeypot notification",
+		"endpoint_id", info.EndpointID,
+		"source_ip", info.SourceIP,
+	)
+	return nil
+}
+
+// NotifyEndpointArchived sends a notification when an inactive endpoint is muted.
+func (s *SMTPNotifier) NotifyEndpointArchived(ctx context.Context, info ArchiveInfo) error {
+	last := "never"
+	if !info.LastWebhookAt.IsZero() {
+		last = info.LastWebhookAt.Format("2006-01-02 15:04:05 UTC")
+	}
+
+	data := emailData{
+		Title: "Endpoint Archived",
+		Rows: []emailRow{
+			{"Endpoint", info.EndpointName},
+			{"Last webhook", last},
+		},
+		Note:     fmt.Sprintf("No webhooks for %s, so the endpoint was muted. Unmute it to resume relaying.", info.InactiveFor),
+		LinkURL:  s.baseURL + "/endpoints/" + info.EndpointID,
+		LinkText: "View Endpoint",
+	}
+
+	if err := s.send(ctx, "Endpoint archived: "+info.EndpointName, data); err != nil {
+		slog.Error("failed to send archive notification",
+			"endpoint_id", info.EndpointID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent archive notification",
+		"endpoint_id", info.EndpointID,
+		"endpoint", info.EndpointName,
+	)
+	return nil
+}
+
+// SendTest sends an email confirming that notifications reach the address.
+func (s *SMTPNotifier) SendTest(ctx context.Context) error {
+	return s.send(ctx, "Hookly test notification", emailData{
+		Title:    "Test Notification",
+		Note:     "Email notifications are working. Failed and dead-lettered webhooks will be reported to this address.",
+		LinkURL:  s.baseURL + "/settings",
+		LinkText: "Notification Settings",
+	})
+}
+
+func (s *SMTPNotifier) send(ctx context.Context, subject string, data emailData) error {
+	from, err := mail.ParseAddress(s.cfg.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender: %w", err)
+	}
+	to, err := mail.ParseAddress(s.to)
+	if err != nil {
+		return fmt.Errorf("invalid recipient: %w", err)
+	}
+	msg, err := s.message(from, to, subject, data)
+	if err != nil {
+		return fmt.Errorf("build message: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, smtpTimeout)
+	defer cancel()
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+	deadline, _ := ctx.Deadline()
+	conn.SetDeadline(deadline)
+
+	c, err := smtp.NewClient(conn, s.cfg.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("connect: %w", err)
+	}
+	defer c.Close()
+
+	if s.cfg.Security == SMTPStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			return errors.New("server doesn't support STARTTLS")
+		}
+		if err := c.StartTLS(&tls.Config{ServerName: s.cfg.Host}); err != nil {
+			return fmt.Errorf("starttls: %w", err)
+		}
+	}
+	if s.cfg.Username != "" {
+		// PlainAuth refuses to send the password unencrypted, except to localhost
+		if err := c.Auth(smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)); err != nil {
+			return fmt.Errorf("auth: %w", err)
+		}
+	}
+	if err := c.Mail(from.Address); err != nil {
+		return fmt.Errorf("mail from: %w", err)
+	}
+	if err := c.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("rcpt to: %w", err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return fmt.Errorf("data: %w", err)
+	}
+	if _, err := w.Write(msg); err != nil {
+		return fmt.Errorf("data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("data: %w", err)
+	}
+	return c.Quit()
+}
+
+func (s *SMTPNotifier) dial(ctx context.Context) (net.Conn, error) {
+	addr := net.JoinHostPort(s.cfg.Host, strconv.Itoa(s.cfg.Port))
+	if s.cfg.Security == SMTPTLS {
+		d := &tls.Dialer{Config: &tls.Config{ServerName: s.cfg.Host}}
+		return d.DialContext(ctx, "tcp", addr)
+	}
+	var d net.Dialer
+	return d.DialContext(ctx, "tcp", addr)
+}
+
+// message renders data as a multipart email with HTML and plain text bodies.
+func (s *SMTPNotifier) message(from, to *mail.Address, subject string, data emailData) ([]byte, error) {
+	var html, text bytes.Buffer
+	if err := emailHTML.Execute(&html, data); err != nil {
+		return nil, err
+	}
+	if err := emailText.Execute(&text, data); err != nil {
+		return nil, err
+	}
+
+	// Names in the subject can't add headers
+	subject = strings.Map(func(r rune) rune {
+		if r == '\r' || r == '\n' {
+			return ' '
+		}
+		return r
+	}, subject)
+
+	// The writer only writes on the first part, after the headers
+	var msg bytes.Buffer
+	body := multipart.NewWriter(&msg)
+	fmt.Fprintf(&msg, "From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMessage-ID: %s\r\nMIME-Version: 1.0\r\nContent-Type: multipart/alternative; boundary=%q\r\n\r\n",
+		from, to, mime.QEncoding.Encode("utf-8", subject), time.Now().Format(time.RFC1123Z), messageID(from.Address), body.Boundary())
+
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=utf-8", text.Bytes()},
+		{"text/html; charset=utf-8", html.Bytes()},
+	} {
+		w, err := body.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write(part.content); err != nil {
+			return nil, err
+		}
+		if err := qp.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	return msg.Bytes(), nil
+}
+
+// messageID returns a unique Message-ID in the sender's domain.
+func messageID(from string) string {
+	b := make([]byte, 12)
+	rand.Read(b)
+	_, domain, _ := strings.Cut(from, "@")
+	return "<" + hex.EncodeToString(b) + "@" + domain + ">"
+}
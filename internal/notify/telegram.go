@@ -8,6 +8,8 @@ import (
 	"html"
 	"log/slog"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -101,6 +103,178 @@ Webhook exceeded 7-day delivery window.
 	return nil
 }
 
+// NotifyFirstEvent sends a notification when an endpoint receives its first webhook.
+func (t *TelegramNotifier) NotifyFirstEvent(ctx context.Context, info WebhookInfo) error {
+	message := fmt.Sprintf(
+		`✅ <b>First Webhook Received</b>
+
+Endpoint: %s
+Webhook ID: <code>%s</code>
+Received: %s
+
+The provider is configured correctly.
+
+<a href="%s/webhooks/%s">View Details</a>`,
+		html.EscapeString(info.EndpointName),
+		html.EscapeString(info.ID),
+		info.ReceivedAt.Format("2006-01-02 15:04:05 UTC"),
+		t.baseURL,
+		info.ID,
+	)
+
+	if err := t.sendMessage(ctx, message); err != nil {
+		slog.Error("failed to send first event notification",
+			"webhook_id", info.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent first event notification",
+		"webhook_id", info.ID,
+		"endpoint", info.EndpointName,
+	)
+	return nil
+}
+
+// NotifySLOBreach sends a notification when an endpoint's delivery SLO is breached.
+func (t *TelegramNotifier) NotifySLOBreach(ctx context.Context, info SLOInfo) error {
+	message := fmt.Sprintf(
+		`📉 <b>Delivery SLO Breached</b>
+
+Endpoint: %s
+Destination: %s
+Compliance: %.2f%% (target %.2f%%)
+Delivered within %s: %d of %d in the last %s
+
+<a href="%s/endpoints/%s">View Endpoint</a>`,
+		html.EscapeString(info.EndpointName),
+		html.EscapeString(info.DestinationURL),
+		info.Compliance,
+		info.Target,
+		info.Latency,
+		info.Met,
+		info.Total,
+		info.Window,
+		t.baseURL,
+		info.EndpointID,
+	)
+
+	if err := t.sendMessage(ctx, message); err != nil {
+		slog.Error("failed to send slo breach notification",
+			"endpoint_id", info.EndpointID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent slo breach notification",
+		"endpoint_id", info.EndpointID,
+		"endpoint", info.EndpointName,
+	)
+	return nil
+}
+
+// Limits that keep a honeypot alert within Telegram's 4096 character message
+// limit and Discord's embed description limit, the same, since the headers
+// are chosen by whoever hit the endpoint.
+const (
+	honeypotMaxHeaders     = 20
+	honeypotMaxHeaderValue = 120
+)
+
+// NotifyHoneypotHit sends a notification when a honeypot endpoint receives a request.
+func (t *TelegramNotifier) NotifyHoneypotHit(ctx context.Context, info HoneypotInfo) error {
+	names := make([]string, 0, len(info.Headers))
+	for name := range info.Headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	var headers strings.Builder
+	for i, name := range names {
+		if i == honeypotMaxHeaders {
+			fmt.Fprintf(&headers, "... %d more\n", len(names)-i)
+			break
+		}
+		value := info.Headers[name]
+		if len(value) > honeypotMaxHeaderValue {
+			value = value[:honeypotMaxHeaderValue] + "..."
+		}
+		fmt.Fprintf(&headers, "%s: %s\n", name, value)
+	}
+
+	message := fmt.Sprintf(
+		`🪤 <b>Honeypot Endpoint Hit</b>
+
+Endpoint: %s
+Request: %s from <code>%s</code>
+Received: %s
+Payload: %d bytes
+
+<pre>%s</pre>
+<a href="%s/webhooks/%s">View Details</a>`,
+		html.EscapeString(info.EndpointName),
+		html.EscapeString(info.Method),
+		html.EscapeString(info.SourceIP),
+		info.ReceivedAt.Format("2006-01-02 15:04:05 UTC"),
+		info.PayloadSize,
+		html.EscapeString(headers.String()),
+		t.baseURL,
+		info.WebhookID,
+	)
+
+	if err := t.sendMessage(ctx, message); err != nil {
+		slog.Error("failed to send honeypot notification",
+			"endpoint_id", info.EndpointID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent honeypot notification",
+		"endpoint_id", info.EndpointID,
+		"source_ip", info.SourceIP,
+	)
+	return nil
+}
+
+// NotifyEndpointArchived sends a notification when an inactive endpoint is muted.
+func (t *TelegramNotifier) NotifyEndpointArchived(ctx context.Context, info ArchiveInfo) error {
+	last := "never"
+	if !info.LastWebhookAt.IsZero() {
+		last = info.LastWebhookAt.Format("2006-01-02 15:04:05 UTC")
+	}
+
+	message := fmt.Sprintf(
+		`🗄 <b>Endpoint Archived</b>
+
+Endpoint: %s
+Last webhook: %s
+No webhooks for %s, so the endpoint was muted. Unmute it to resume relaying.
+
+<a href="%s/endpoints/%s">View Endpoint</a>`,
+		html.EscapeString(info.EndpointName),
+		last,
+		info.InactiveFor,
+		t.baseURL,
+		info.EndpointID,
+	)
+
+	if err := t.sendMessage(ctx, message); err != nil {
+		slog.Error("failed to send archive notification",
+			"endpoint_id", info.EndpointID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent archive notification",
+		"endpoint_id", info.EndpointID,
+		"endpoint", info.EndpointName,
+	)
+	return nil
+}
+
 type telegramRequest struct {
 	ChatID    string `json:"chat_id"`
 	Text      string `json:"text"`
@@ -14,13 +14,15 @@ type SecretManager interface {
 	DecryptSecret(encrypted []byte) (string, error)
 }
 
-// UserNotifier is a notifier that checks per-user Telegram config first,
-// then falls back to a global notifier.
+// UserNotifier is a notifier that checks the endpoint owner's Telegram,
+// Discord, email and webhook config first, then falls back to a global
+// notifier.
 type UserNotifier struct {
 	queries       *db.Queries
 	secretManager SecretManager
 	globalConfig  Notifier
 	baseURL       string
+	smtp          SMTPConfig // Users' email isn't sent unless enabled
 }
 
 // NewUserNotifier creates a new user notifier.
@@ -37,50 +39,126 @@ func NewUserNotifier(queries *db.Queries, secretManager SecretManager, globalCon
 	}
 }
 
+// SetSMTP sets the mail server that sends users' email notifications.
+func (u *UserNotifier) SetSMTP(cfg SMTPConfig) {
+	u.smtp = cfg
+}
+
 // NotifyDeliveryFailure sends a notification when a webhook fails permanently.
-// It first checks for per-user Telegram config, then falls back to global.
+// It first checks for per-user channels, then falls back to global.
 func (u *UserNotifier) NotifyDeliveryFailure(ctx context.Context, info WebhookInfo) error {
 	notifier := u.getNotifierForEndpoint(ctx, info.EndpointID)
 	return notifier.NotifyDeliveryFailure(ctx, info)
 }
 
 // NotifyDeadLetter sends a notification when a webhook becomes a dead letter.
-// It first checks for per-user Telegram config, then falls back to global.
+// It first checks for per-user channels, then falls back to global.
 func (u *UserNotifier) NotifyDeadLetter(ctx context.Context, info WebhookInfo) error {
 	notifier := u.getNotifierForEndpoint(ctx, info.EndpointID)
 	return notifier.NotifyDeadLetter(ctx, info)
 }
 
-// getNotifierForEndpoint returns the appropriate notifier for an endpoint.
-// It checks if the endpoint owner has Telegram configured and enabled.
+// NotifyFirstEvent sends a notification when an endpoint receives its first webhook.
+// It first checks for per-user channels, then falls back to global.
+func (u *UserNotifier) NotifyFirstEvent(ctx context.Context, info WebhookInfo) error {
+	notifier := u.getNotifierForEndpoint(ctx, info.EndpointID)
+	return notifier.NotifyFirstEvent(ctx, info)
+}
+
+// NotifySLOBreach sends a notification when an endpoint's delivery SLO is breached.
+// It first checks for per-user channels, then falls back to global.
+func (u *UserNotifier) NotifySLOBreach(ctx context.Context, info SLOInfo) error {
+	notifier := u.getNotifierForEndpoint(ctx, info.EndpointID)
+	return notifier.NotifySLOBreach(ctx, info)
+}
+
+// NotifyHoneypotHit sends a notification when a honeypot endpoint receives a request.
+// It first checks for per-user channels, then falls back to global.
+func (u *UserNotifier) NotifyHoneypotHit(ctx context.Context, info HoneypotInfo) error {
+	notifier := u.getNotifierForEndpoint(ctx, info.EndpointID)
+	return notifier.NotifyHoneypotHit(ctx, info)
+}
+
+// NotifyEndpointArchived sends a notification when an inactive endpoint is muted.
+// It first checks for per-user channels, then falls back to global.
+func (u *UserNotifier) NotifyEndpointArchived(ctx context.Context, info ArchiveInfo) error {
+	notifier := u.getNotifierForEndpoint(ctx, info.EndpointID)
+	return notifier.NotifyEndpointArchived(ctx, info)
+}
+
+// NotifyEndpointDisconnected sends a notification when an endpoint's last hub
+// disconnects, to the channels that report disconnects.
+// It first checks for per-user channels, then falls back to global.
+func (u *UserNotifier) NotifyEndpointDisconnected(ctx context.Context, info DisconnectInfo) error {
+	notifier := u.getNotifierForEndpoint(ctx, info.EndpointID)
+	if c, ok := notifier.(ConnectionNotifier); ok {
+		return c.NotifyEndpointDisconnected(ctx, info)
+	}
+	return nil
+}
+
+// NotifyEndpointReconnected sends a notification when a hub comes back for
+// an endpoint whose disconnect was reported, to the same channels.
+// It first checks for per-user channels, then falls back to global.
+func (u *UserNotifier) NotifyEndpointReconnected(ctx context.Context, info ReconnectInfo) error {
+	notifier := u.getNotifierForEndpoint(ctx, info.EndpointID)
+	if c, ok := notifier.(ConnectionNotifier); ok {
+		return c.NotifyEndpointReconnected(ctx, info)
+	}
+	return nil
+}
+
+// getNotifierForEndpoint returns the appropriate notifier for an endpoint:
+// the channels its owner configured and enabled, or the global notifier.
 func (u *UserNotifier) getNotifierForEndpoint(ctx context.Context, endpointID string) Notifier {
-	// Try to get user's Telegram config via the endpoint
-	config, err := u.queries.GetEndpointOwnerTelegramConfig(ctx, endpointID)
+	config, err := u.queries.GetEndpointOwnerNotificationConfig(ctx, endpointID)
 	if err != nil {
 		if !errors.Is(err, sql.ErrNoRows) {
-			slog.Debug("failed to get endpoint owner telegram config", "endpoint_id", endpointID, "error", err)
+			slog.Debug("failed to get endpoint owner notification config", "endpoint_id", endpointID, "error", err)
 		}
 		// Fall back to global notifier
 		return u.globalConfig
 	}
 
-	// Check if user has Telegram enabled with valid config
-	if config.TelegramEnabled == 0 || len(config.TelegramBotTokenEncrypted) == 0 || !config.TelegramChatID.Valid {
-		// User hasn't configured Telegram, use global
-		return u.globalConfig
+	var notifiers MultiNotifier
+	if config.TelegramEnabled != 0 && len(config.TelegramBotTokenEncrypted) > 0 && config.TelegramChatID.Valid {
+		botToken, err := u.secretManager.DecryptSecret(config.TelegramBotTokenEncrypted)
+		if err != nil {
+			slog.Error("failed to decrypt user telegram token", "user_id", config.UserID, "error", err)
+		} else {
+			notifiers = append(notifiers, NewTelegramNotifier(botToken, config.TelegramChatID.String, u.baseURL))
+		}
+	}
+	if config.DiscordEnabled != 0 && len(config.DiscordWebhookUrlEncrypted) > 0 {
+		webhookURL, err := u.secretManager.DecryptSecret(config.DiscordWebhookUrlEncrypted)
+		if err != nil {
+			slog.Error("failed to decrypt user discord webhook url", "user_id", config.UserID, "error", err)
+		} else {
+			notifiers = append(notifiers, NewDiscordNotifier(webhookURL, u.baseURL))
+		}
+	}
+	if u.smtp.Enabled() && config.EmailEnabled != 0 && config.EmailAddress.Valid {
+		notifiers = append(notifiers, NewSMTPNotifier(u.smtp, config.EmailAddress.String, u.baseURL))
+	}
+	if config.NotifyWebhookEnabled != 0 && len(config.NotifyWebhookUrlEncrypted) > 0 && len(config.NotifyWebhookSecretEncrypted) > 0 {
+		webhookURL, err := u.secretManager.DecryptSecret(config.NotifyWebhookUrlEncrypted)
+		if err != nil {
+			slog.Error("failed to decrypt user notification webhook url", "user_id", config.UserID, "error", err)
+		} else if secret, err := u.secretManager.DecryptSecret(config.NotifyWebhookSecretEncrypted); err != nil {
+			slog.Error("failed to decrypt user notification webhook secret", "user_id", config.UserID, "error", err)
+		} else {
+			notifiers = append(notifiers, NewUserWebhookNotifier(webhookURL, secret, u.baseURL))
+		}
 	}
 
-	// Decrypt the bot token
-	botToken, err := u.secretManager.DecryptSecret(config.TelegramBotTokenEncrypted)
-	if err != nil {
-		slog.Error("failed to decrypt user telegram token", "user_id", config.UserID, "error", err)
+	switch len(notifiers) {
+	case 0:
+		// User hasn't configured a channel, use global
 		return u.globalConfig
+	case 1:
+		slog.Debug("using per-user notifier", "user_id", config.UserID, "endpoint_id", endpointID)
+		return notifiers[0]
 	}
-
-	// Create a new TelegramNotifier for this user
-	slog.Debug("using per-user telegram notifier",
-		"user_id", config.UserID,
-		"endpoint_id", endpointID,
-	)
-	return NewTelegramNotifier(botToken, config.TelegramChatID.String, u.baseURL)
+	slog.Debug("using per-user notifiers", "user_id", config.UserID, "endpoint_id", endpointID, "channels", len(notifiers))
+	return notifiers
 }
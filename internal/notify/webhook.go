@@ -0,0 +1,351 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// Event types of notification webhooks, sent as the payload's type and in
+// the X-Hookly-Event header.
+const (
+	EventDeliveryFailed       = "delivery_failed"
+	EventDeadLetter           = "dead_letter"
+	EventFirstEvent           = "first_event"
+	EventSLOBreached          = "slo_breached"
+	EventHoneypotHit          = "honeypot_hit"
+	EventEndpointArchived     = "endpoint_archived"
+	EventEndpointDisconnected = "endpoint_disconnected"
+	EventEndpointReconnected  = "endpoint_reconnected"
+	EventTest                 = "test" // Sent from the settings page
+)
+
+// WebhookNotifier POSTs notifications as JSON events to a URL, signed with
+// HMAC-SHA256 of the body in X-Webhook-Signature as sha256=<hex>, as
+// Hookly's generic provider verifies. Unlike chat channels it also reports
+// disconnects, see ConnectionNotifier.
+type WebhookNotifier struct {
+	webhookURL string
+	secret     string
+	baseURL    string // For links to webhooks and endpoints
+	client     *http.Client
+}
+
+// NewWebhookNotifier creates a notifier posting events to webhookURL, signed
+// with secret.
+func NewWebhookNotifier(webhookURL, secret, baseURL string) *WebhookNotifier {
+	return &WebhookNotifier{
+		webhookURL: webhookURL,
+		secret:     secret,
+		baseURL:    baseURL,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// NewUserWebhookNotifier creates a notifier for a URL set by a user, which
+// can't reach the edge's own network.
+func NewUserWebhookNotifier(webhookURL, secret, baseURL string) *WebhookNotifier {
+	w := NewWebhookNotifier(webhookURL, secret, baseURL)
+	dialer := &net.Dialer{Timeout: 5 * time.Second, Control: publicOnly}
+	w.client.Transport = &http.Transport{
+		Proxy:               http.ProxyFromEnvironment,
+		DialContext:         dialer.DialContext,
+		TLSHandshakeTimeout: 5 * time.Second,
+	}
+	return w
+}
+
+// publicOnly refuses connections to loopback, private and link-local
+// addresses. It runs for each address dialed, so a name resolving to one or a
+// redirect can't get around it.
+func publicOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return fmt.Errorf("%s is not a public address", addr)
+	}
+	return nil
+}
+
+// ValidateWebhookURL checks that u is an http or https URL. Errors leave out
+// the URL, which may hold a token.
+func ValidateWebhookURL(u string) error {
+	parsed, err := url.Parse(u)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return errors.New("not an http or https URL")
+	}
+	return nil
+}
+
+// NotifyDeliveryFailure sends a delivery_failed event.
+func (w *WebhookNotifier) NotifyDeliveryFailure(ctx context.Context, info WebhookInfo) error {
+	return w.send(ctx, newWebhookEvent(EventDeliveryFailed, w.webhookData(info)))
+}
+
+// NotifyDeadLetter sends a dead_letter event.
+func (w *WebhookNotifier) NotifyDeadLetter(ctx context.Context, info WebhookInfo) error {
+	return w.send(ctx, newWebhookEvent(EventDeadLetter, w.webhookData(info)))
+}
+
+// NotifyFirstEvent sends a first_event event.
+func (w *WebhookNotifier) NotifyFirstEvent(ctx context.Context, info WebhookInfo) error {
+	return w.send(ctx, newWebhookEvent(EventFirstEvent, w.webhookData(info)))
+}
+
+// NotifySLOBreach sends an slo_breached event.
+func (w *WebhookNotifier) NotifySLOBreach(ctx context.Context, info SLOInfo) error {
+	return w.send(ctx, newWebhookEvent(EventSLOBreached, sloEventData{
+		EndpointID:     info.EndpointID,
+		EndpointName:   info.EndpointName,
+		DestinationURL: info.DestinationURL,
+		Target:         info.Target,
+		Compliance:     info.Compliance,
+		LatencySeconds: info.Latency.Seconds(),
+		WindowSeconds:  info.Window.Seconds(),
+		Total:          info.Total,
+		Met:            info.Met,
+		URL:            w.baseURL + "/endpoints/" + info.EndpointID,
+	}))
+}
+
+// NotifyHoneypotHit sends a honeypot_hit event.
+func (w *WebhookNotifier) NotifyHoneypotHit(ctx context.Context, info HoneypotInfo) error {
+	return w.send(ctx, newWebhookEvent(EventHoneypotHit, honeypotEventData{
+		WebhookID:    info.WebhookID,
+		EndpointID:   info.EndpointID,
+		EndpointName: info.EndpointName,
+		Method:       info.Method,
+		SourceIP:     info.SourceIP,
+		Headers:      info.Headers,
+		PayloadSize:  info.PayloadSize,
+		ReceivedAt:   info.ReceivedAt,
+		URL:          w.baseURL + "/webhooks/" + info.WebhookID,
+	}))
+}
+
+// NotifyEndpointArchived sends an endpoint_archived event.
+func (w *WebhookNotifier) NotifyEndpointArchived(ctx context.Context, info ArchiveInfo) error {
+	data := archiveEventData{
+		EndpointID:      info.EndpointID,
+		EndpointName:    info.EndpointName,
+		InactiveSeconds: info.InactiveFor.Seconds(),
+		URL:             w.baseURL + "/endpoints/" + info.EndpointID,
+	}
+	if !info.LastWebhookAt.IsZero() {
+		data.LastWebhookAt = &info.LastWebhookAt
+	}
+	return w.send(ctx, newWebhookEvent(EventEndpointArchived, data))
+}
+
+// NotifyEndpointDisconnected sends an endpoint_disconnected event.
+func (w *WebhookNotifier) NotifyEndpointDisconnected(ctx context.Context, info DisconnectInfo) error {
+	return w.send(ctx, newWebhookEvent(EventEndpointDisconnected, disconnectEventData{
+		EndpointID:     info.EndpointID,
+		EndpointName:   info.EndpointName,
+		HubID:          info.HubID,
+		DisconnectedAt: info.DisconnectedAt,
+		URL:            w.baseURL + "/endpoints/" + info.EndpointID,
+	}))
+}
+
+// NotifyEndpointReconnected sends an endpoint_reconnected event.
+func (w *WebhookNotifier) NotifyEndpointReconnected(ctx context.Context, info ReconnectInfo) error {
+	return w.send(ctx, newWebhookEvent(EventEndpointReconnected, reconnectEventData{
+		EndpointID:      info.EndpointID,
+		EndpointName:    info.EndpointName,
+		HubID:           info.HubID,
+		DisconnectedAt:  info.DisconnectedAt,
+		ReconnectedAt:   info.ReconnectedAt,
+		DowntimeSeconds: info.ReconnectedAt.Sub(info.DisconnectedAt).Seconds(),
+		URL:             w.baseURL + "/endpoints/" + info.EndpointID,
+	}))
+}
+
+// webhookEvent is the body of a notification webhook.
+type webhookEvent struct {
+	ID        string    `json:"id"` // Also in X-Hookly-Delivery, for receivers to drop duplicates
+	Type      string    `json:"type"`
+	CreatedAt time.Time `json:"created_at"`
+	Data      any       `json:"data"`
+}
+
+type webhookEventData struct {
+	WebhookID    string    `json:"webhook_id"`
+	EndpointID   string    `json:"endpoint_id"`
+	EndpointName string    `json:"endpoint_name"`
+	Attempts     int       `json:"attempts,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	ReceivedAt   time.Time `json:"received_at"`
+	URL          string    `json:"url"`
+}
+
+type sloEventData struct {
+	EndpointID     string  `json:"endpoint_id"`
+	EndpointName   string  `json:"endpoint_name"`
+	DestinationURL string  `json:"destination_url"`
+	Target         float64 `json:"target"`
+	Compliance     float64 `json:"compliance"`
+	LatencySeconds float64 `json:"latency_seconds"`
+	WindowSeconds  float64 `json:"window_seconds"`
+	Total          int64   `json:"total"`
+	Met            int64   `json:"met"`
+	URL            string  `json:"url"`
+}
+
+type honeypotEventData struct {
+	WebhookID    string            `json:"webhook_id"`
+	EndpointID   string            `json:"endpoint_id"`
+	EndpointName string            `json:"endpoint_name"`
+	Method       string            `json:"method"`
+	SourceIP     string            `json:"source_ip"`
+	Headers      map[string]string `json:"headers"`
+	PayloadSize  int               `json:"payload_size"`
+	ReceivedAt   time.Time         `json:"received_at"`
+	URL          string            `json:"url"`
+}
+
+type archiveEventData struct {
+	EndpointID      string     `json:"endpoint_id"`
+	EndpointName    string     `json:"endpoint_name"`
+	LastWebhookAt   *time.Time `json:"last_webhook_at"` // null if it never received one
+	InactiveSeconds float64    `json:"inactive_seconds"`
+	URL             string     `json:"url"`
+}
+
+type testEventData struct {
+	Message string `json:"message"`
+	URL     string `json:"url"`
+}
+
+type disconnectEventData struct {
+	EndpointID     string    `json:"endpoint_id"`
+	EndpointName   string    `json:"endpoint_name"`
+	HubID          string    `json:"hub_id"`
+	DisconnectedAt time.Time `json:"disconnected_at"`
+	URL            string    `json:"url"`
+}
+
+type reconnectEventData struct {
+	EndpointID      string    `json:"endpoint_id"`
+	EndpointName    string    `json:"endpoint_name"`
+	HubID           string    `json:"hub_id"`
+	DisconnectedAt  time.Time `json:"disconnected_at"`
+	ReconnectedAt   time.Time `json:"reconnected_at"`
+	DowntimeSeconds float64   `json:"downtime_seconds"`
+	URL             string    `json:"url"`
+}
+
+func (w *WebhookNotifier) webhookData(info WebhookInfo) webhookEventData {
+	return webhookEventData{
+		WebhookID:    info.ID,
+		EndpointID:   info.EndpointID,
+		EndpointName: info.EndpointName,
+		Attempts:     info.Attempts,
+		Error:        info.Error,
+		ReceivedAt:   info.ReceivedAt,
+		URL:          w.baseURL + "/webhooks/" + info.ID,
+	}
+}
+
+// SignWebhookPayload returns the X-Webhook-Signature of a notification
+// webhook's body.
+func SignWebhookPayload(body []byte, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SendTest sends a test event confirming that notifications reach the URL,
+// and returns its ID.
+func (w *WebhookNotifier) SendTest(ctx context.Context) (string, error) {
+	event := newWebhookEvent(EventTest, testEventData{
+		Message: "Notifications from Hookly reach this URL.",
+		URL:     w.baseURL + "/settings",
+	})
+	return event.ID, w.send(ctx, event)
+}
+
+func newWebhookEvent(eventType string, data any) webhookEvent {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return webhookEvent{
+		ID:        "evt_" + hex.EncodeToString(id),
+		Type:      eventType,
+		CreatedAt: time.Now().UTC(),
+		Data:      data,
+	}
+}
+
+func (w *WebhookNotifier) send(ctx context.Context, event webhookEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("marshal event: %w", err)
+	}
+
+	if err := w.post(ctx, event, body); err != nil {
+		slog.Error("failed to send notification webhook",
+			"event", event.Type,
+			"event_id", event.ID,
+			"error", err,
+		)
+		return err
+	}
+
+	slog.Info("sent notification webhook",
+		"event", event.Type,
+		"event_id", event.ID,
+	)
+	return nil
+}
+
+func (w *WebhookNotifier) post(ctx context.Context, event webhookEvent, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return errors.New("create request: invalid URL")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Hookly-Notifications")
+	req.Header.Set("X-Hookly-Event", event.Type)
+	req.Header.Set("X-Hookly-Delivery", event.ID)
+	req.Header.Set("X-Webhook-Signature", SignWebhookPayload(body, w.secret))
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		// Without the URL, which may hold a token
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("send request: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification webhook answered %d %s", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+// Package region describes the regions a hookly service runs edges in and
+// checks their health. Edges in every region serve the same accounts, so a
+// hub can connect to whichever region is nearest.
+package region
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// probeTimeout bounds a single health probe.
+	probeTimeout = 5 * time.Second
+	// cacheTTL is how long the edge reuses health results, so GetRegions
+	// doesn't probe every peer on every call.
+	cacheTTL = 30 * time.Second
+)
+
+// Region is a named edge location.
+type Region struct {
+	Name string
+	URL  string // Base URL of the region's edge, e.g. https://eu.hooks.example.com
+}
+
+// ParseList parses a region list such as
+// "us-east=https://us.hooks.example.com,eu-west=https://eu.hooks.example.com".
+func ParseList(spec string) ([]Region, error) {
+	var regions []Region
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, rawURL, ok := strings.Cut(part, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid region %q: expected name=url", part)
+		}
+		u, err := url.Parse(rawURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, fmt.Errorf("invalid region %s: %q is not an http(s) URL", name, rawURL)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("region %s listed twice", name)
+		}
+		seen[name] = true
+		regions = append(regions, Region{Name: name, URL: strings.TrimSuffix(rawURL, "/")})
+	}
+	return regions, nil
+}
+
+// Status is the health of a region as seen from the prober.
+type Status struct {
+	Region
+	Healthy   bool
+	Latency   time.Duration // Round trip of the health check
+	Error     string        // Why the region is unhealthy
+	CheckedAt time.Time
+}
+
+// Probe checks a region's /health endpoint and measures its round trip.
+func Probe(ctx context.Context, client *http.Client, r Region) Status {
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	st := Status{Region: r, CheckedAt: time.Now()}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.URL+"/health", nil)
+	if err != nil {
+		st.Error = err.Error()
+		return st
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	st.Latency = time.Since(start)
+	if err != nil {
+		st.Error = err.Error()
+		return st
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		st.Error = fmt.Sprintf("health check returned %d", resp.StatusCode)
+		return st
+	}
+	st.Healthy = true
+	return st
+}
+
+// ProbeAll probes regions concurrently. The result is in the order of regions.
+func ProbeAll(ctx context.Context, client *http.Client, regions []Region) []Status {
+	statuses := make([]Status, len(regions))
+	var wg sync.WaitGroup
+	for i, r := range regions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i] = Probe(ctx, client, r)
+		}()
+	}
+	wg.Wait()
+	return statuses
+}
+
+// Nearest returns the healthy region with the lowest latency.
+func Nearest(statuses []Status) (Status, bool) {
+	var best Status
+	found := false
+	for _, st := range statuses {
+		if st.Healthy && (!found || st.Latency < best.Latency) {
+			best, found = st, true
+		}
+	}
+	return best, found
+}
+
+// Checker reports the health of the service's regions from an edge.
+type Checker struct {
+	current Region
+	regions []Region
+	client  *http.Client
+
+	mu        sync.Mutex
+	statuses  []Status
+	checkedAt time.Time
+}
+
+// NewChecker creates a checker for an edge in region current. The current
+// region is added to regions if it isn't listed.
+func NewChecker(current Region, regions []Region) *Checker {
+	all := []Region{current}
+	for _, r := range regions {
+		if r.Name != current.Name {
+			all = append(all, r)
+		}
+	}
+	return &Checker{
+		current: current,
+		regions: all,
+		client:  &http.Client{Timeout: probeTimeout},
+	}
+}
+
+// Current returns the region of this edge.
+func (c *Checker) Current() Region {
+	return c.current
+}
+
+// URL returns the base URL of a region.
+func (c *Checker) URL(name string) (string, bool) {
+	for _, r := range c.regions {
+		if r.Name == name {
+			return r.URL, true
+		}
+	}
+	return "", false
+}
+
+// Check returns the health of every region, current first. The current
+// region is healthy by definition; peers are probed at most once per cacheTTL.
+func (c *Checker) Check(ctx context.Context) []Status {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.statuses != nil && time.Since(c.checkedAt) < cacheTTL {
+		return append([]Status(nil), c.statuses...)
+	}
+
+	now := time.Now()
+	statuses := append([]Status{{Region: c.current, Healthy: true, CheckedAt: now}},
+		ProbeAll(ctx, c.client, c.regions[1:])...)
+	c.statuses = statuses
+	c.checkedAt = now
+	return append([]Status(nil), statuses...)
+}
@@ -0,0 +1,91 @@
+package region
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestParseList(t *testing.T) {
+	regions, err := ParseList("us-east=https://us.hooks.example.com/, eu-west=https://eu.hooks.example.com")
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	want := []Region{
+		{Name: "us-east", URL: "https://us.hooks.example.com"},
+		{Name: "eu-west", URL: "https://eu.hooks.example.com"},
+	}
+	if len(regions) != len(want) {
+		t.Fatalf("got %v, want %v", regions, want)
+	}
+	for i := range want {
+		if regions[i] != want[i] {
+			t.Errorf("region %d = %v, want %v", i, regions[i], want[i])
+		}
+	}
+
+	for _, spec := range []string{
+		"us-east",
+		"=https://us.hooks.example.com",
+		"us-east=hooks.example.com",
+		"us-east=https://a.example.com,us-east=https://b.example.com",
+	} {
+		if _, err := ParseList(spec); err == nil {
+			t.Errorf("ParseList(%q) succeeded, want error", spec)
+		}
+	}
+}
+
+func TestCheckerAndNearest(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer healthy.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer down.Close()
+
+	c := NewChecker(Region{Name: "us-east", URL: "https://us.hooks.example.com"}, []Region{
+		{Name: "us-east", URL: "https://us.hooks.example.com"},
+		{Name: "eu-west", URL: healthy.URL},
+		{Name: "ap-south", URL: down.URL},
+	})
+
+	statuses := c.Check(context.Background())
+	if len(statuses) != 3 {
+		t.Fatalf("got %d statuses, want 3 (current listed once)", len(statuses))
+	}
+	byName := make(map[string]Status)
+	for _, st := range statuses {
+		byName[st.Name] = st
+	}
+	if !byName["us-east"].Healthy || !byName["eu-west"].Healthy {
+		t.Errorf("healthy regions reported down: %+v", statuses)
+	}
+	if st := byName["ap-south"]; st.Healthy || st.Error == "" {
+		t.Errorf("ap-south = %+v, want unhealthy with an error", st)
+	}
+
+	if u, ok := c.URL("eu-west"); !ok || u != healthy.URL {
+		t.Errorf("URL(eu-west) = %q, %v", u, ok)
+	}
+
+	nearest, ok := Nearest([]Status{
+		{Region: Region{Name: "slow"}, Healthy: true, Latency: 80 * time.Millisecond},
+		{Region: Region{Name: "fast-but-down"}, Latency: time.Millisecond},
+		{Region: Region{Name: "fast"}, Healthy: true, Latency: 20 * time.Millisecond},
+	})
+	if !ok || nearest.Name != "fast" {
+		t.Errorf("nearest = %v, %v; want fast", nearest.Name, ok)
+	}
+	if _, ok := Nearest([]Status{{Region: Region{Name: "down"}}}); ok {
+		t.Error("nearest found with no healthy region")
+	}
+}
@@ -0,0 +1,61 @@
+package relay
+
+import (
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+
+	"hooks.dx314.com/internal/db"
+)
+
+// Activity event kinds stored in activity_events.kind.
+const (
+	activityDeliveries      = "deliveries"
+	activityHubConnected    = "hub_connected"
+	activityHubDisconnected = "hub_disconnected"
+)
+
+// recordHubActivity stores a hub connect/disconnect event for the activity feed.
+// Failures are logged and otherwise ignored - the feed is best-effort.
+func (h *Handler) recordHubActivity(ctx context.Context, userID, hubID, kind string) {
+	id, err := gonanoid.New()
+	if err != nil {
+		slog.Error("failed to generate activity id", "error", err)
+		return
+	}
+
+	if err := h.queries.RecordHubActivity(ctx, db.RecordHubActivityParams{
+		ID:     id,
+		UserID: userID,
+		Kind:   kind,
+		HubID:  sql.NullString{String: hubID, Valid: true},
+	}); err != nil {
+		slog.Error("failed to record hub activity", "hub_id", hubID, "kind", kind, "error", err)
+	}
+}
+
+// recordDeliveryActivity increments the hourly delivery count for an endpoint
+// and records its last delivery. The activity belongs to the endpoint's
+// owner, which for an org's endpoint isn't the hub's user.
+func (h *Handler) recordDeliveryActivity(ctx context.Context, userID, endpointID string) {
+	if err := h.queries.MarkEndpointDelivered(ctx, endpointID); err != nil {
+		slog.Error("failed to record endpoint delivery", "endpoint_id", endpointID, "error", err)
+	}
+	if ep, err := h.endpoint(ctx, endpointID); err == nil {
+		userID = ep.UserID
+	}
+
+	// One row per endpoint per hour keeps the feed compact
+	id := "del_" + endpointID + "_" + time.Now().UTC().Format("2006010215")
+
+	if err := h.queries.RecordDeliveryActivity(ctx, db.RecordDeliveryActivityParams{
+		ID:         id,
+		UserID:     userID,
+		EndpointID: sql.NullString{String: endpointID, Valid: true},
+	}); err != nil {
+		slog.Error("failed to record delivery activity", "endpoint_id", endpointID, "error", err)
+	}
+}
@@ -0,0 +1,127 @@
+package relay
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+)
+
+// defaultChaosMaxDelay is the longest injected delay when max_delay isn't set.
+const defaultChaosMaxDelay = 10 * time.Second
+
+// Chaos injects faults into webhook delivery to exercise the edge's retry,
+// backoff, dead-letter and notification paths. Fractions are per delivery:
+//
+//	delay      delay the delivery by up to MaxDelay
+//	fail       ACK a transient failure without forwarding (the edge retries)
+//	nack       ACK a permanent failure without forwarding (the edge gives up)
+//
+// It is meant for staging environments only.
+type Chaos struct {
+	Delay    float64
+	Fail     float64
+	Nack     float64
+	MaxDelay time.Duration
+
+	rand func() float64
+}
+
+// ParseChaos parses a chaos spec such as "fail=0.1,nack=0.02,delay=0.2,max_delay=5s".
+func ParseChaos(spec string) (*Chaos, error) {
+	c := &Chaos{MaxDelay: defaultChaosMaxDelay}
+
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid chaos option %q: expected key=value", part)
+		}
+
+		if key == "max_delay" {
+			d, err := time.ParseDuration(value)
+			if err != nil || d <= 0 {
+				return nil, fmt.Errorf("invalid chaos max_delay %q", value)
+			}
+			c.MaxDelay = d
+			continue
+		}
+
+		var target *float64
+		switch key {
+		case "delay":
+			target = &c.Delay
+		case "fail":
+			target = &c.Fail
+		case "nack":
+			target = &c.Nack
+		default:
+			return nil, fmt.Errorf("unknown chaos option %q (valid: delay, fail, nack, max_delay)", key)
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || f < 0 || f > 1 {
+			return nil, fmt.Errorf("invalid chaos %s %q: must be a fraction between 0 and 1", key, value)
+		}
+		*target = f
+	}
+
+	if c.Fail+c.Nack > 1 {
+		return nil, fmt.Errorf("chaos fail and nack fractions add up to more than 1")
+	}
+	if c.Delay == 0 && c.Fail == 0 && c.Nack == 0 {
+		return nil, fmt.Errorf("chaos spec %q injects no faults", spec)
+	}
+	return c, nil
+}
+
+// String describes the configured faults.
+func (c *Chaos) String() string {
+	return fmt.Sprintf("delay=%g,fail=%g,nack=%g,max_delay=%s", c.Delay, c.Fail, c.Nack, c.MaxDelay)
+}
+
+// intercept applies chaos to a delivery. It may sleep first, and returns the
+// ACK to send instead of forwarding, or nil to forward normally.
+func (c *Chaos) intercept(ctx context.Context, webhookID string) *hooklyv1.DeliveryAck {
+	roll := c.rand
+	if roll == nil {
+		roll = rand.Float64
+	}
+
+	if roll() < c.Delay {
+		delay := time.Duration(roll() * float64(c.MaxDelay))
+		slog.Warn("chaos: delaying delivery", "webhook_id", webhookID, "delay", delay)
+		select {
+		case <-ctx.Done():
+		case <-time.After(delay):
+		}
+	}
+
+	r := roll()
+	switch {
+	case r < c.Fail:
+		slog.Warn("chaos: failing delivery", "webhook_id", webhookID)
+		return &hooklyv1.DeliveryAck{
+			WebhookId:    webhookID,
+			StatusCode:   http.StatusServiceUnavailable,
+			ErrorMessage: "chaos: injected transient failure",
+		}
+	case r < c.Fail+c.Nack:
+		slog.Warn("chaos: rejecting delivery", "webhook_id", webhookID)
+		return &hooklyv1.DeliveryAck{
+			WebhookId:        webhookID,
+			StatusCode:       http.StatusBadRequest,
+			ErrorMessage:     "chaos: injected permanent failure",
+			PermanentFailure: true,
+		}
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+package relay
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestParseChaos(t *testing.T) {
+	c, err := ParseChaos("fail=0.1, nack=0.02,delay=0.5,max_delay=2s")
+	if err != nil {
+		t.Fatalf("ParseChaos: %v", err)
+	}
+	if c.Fail != 0.1 || c.Nack != 0.02 || c.Delay != 0.5 || c.MaxDelay != 2*time.Second {
+		t.Errorf("unexpected chaos: %+v", c)
+	}
+
+	c, err = ParseChaos("fail=1")
+	if err != nil {
+		t.Fatalf("ParseChaos: %v", err)
+	}
+	if c.MaxDelay != defaultChaosMaxDelay {
+		t.Errorf("MaxDelay = %s, want default %s", c.MaxDelay, defaultChaosMaxDelay)
+	}
+
+	for _, spec := range []string{
+		"",
+		"fail",
+		"fail=2",
+		"fail=-0.1",
+		"fail=0.6,nack=0.6",
+		"drop=0.1",
+		"delay=0.1,max_delay=soon",
+		"delay=0",
+	} {
+		if _, err := ParseChaos(spec); err == nil {
+			t.Errorf("ParseChaos(%q) should fail", spec)
+		}
+	}
+}
+
+func TestChaosIntercept(t *testing.T) {
+	tests := []struct {
+		name      string
+		roll      float64
+		wantAck   bool
+		permanent bool
+	}{
+		{"fail", 0.05, true, false},
+		{"nack", 0.15, true, true},
+		{"forward", 0.5, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Chaos{Fail: 0.1, Nack: 0.1, rand: func() float64 { return tt.roll }}
+			ack := c.intercept(context.Background(), "wh-1")
+			if (ack != nil) != tt.wantAck {
+				t.Fatalf("ack = %+v, want ack: %v", ack, tt.wantAck)
+			}
+			if ack == nil {
+				return
+			}
+			if ack.Success || ack.PermanentFailure != tt.permanent || ack.WebhookId != "wh-1" {
+				t.Errorf("unexpected ack: %+v", ack)
+			}
+		})
+	}
+}
+
+func TestChaosDelayHonorsContext(t *testing.T) {
+	c := &Chaos{Delay: 1, MaxDelay: time.Hour, rand: func() float64 { return 0.5 }}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	if ack := c.intercept(ctx, "wh-1"); ack != nil {
+		t.Errorf("delay-only chaos should forward, got %+v", ack)
+	}
+	if time.Since(start) > time.Second {
+		t.Error("delay should stop when the context is cancelled")
+	}
+}
@@ -0,0 +1,140 @@
+package relay
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/webhook"
+)
+
+// payloadChunkSize is the maximum payload size sent in a single stream
+// message. Larger payloads are split into chunks of this size so one big
+// webhook doesn't exceed message limits or stall heartbeats.
+const payloadChunkSize = 256 << 10
+
+// streamMessages returns the stream messages for a webhook envelope. Payloads
+// up to payloadChunkSize are sent inline; larger ones are sent as an envelope
+// without payload followed by PayloadChunk messages.
+func streamMessages(envelope *hooklyv1.WebhookEnvelope) []*hooklyv1.StreamResponse {
+	if len(envelope.Payload) <= payloadChunkSize {
+		return []*hooklyv1.StreamResponse{{
+			Message: &hooklyv1.StreamResponse_Webhook{Webhook: envelope},
+		}}
+	}
+
+	payload := envelope.Payload
+	sum := sha256.Sum256(payload)
+	header := &hooklyv1.WebhookEnvelope{
+		Id:             envelope.Id,
+		EndpointId:     envelope.EndpointId,
+		DestinationUrl: envelope.DestinationUrl,
+		ReceivedAt:     envelope.ReceivedAt,
+		Headers:        envelope.Headers,
+		Attempt:        envelope.Attempt,
+		Transform:      envelope.Transform,
+		Destinations:   envelope.Destinations,
+		Chunked:        true,
+		PayloadSize:    int64(len(payload)),
+		PayloadSha256:  hex.EncodeToString(sum[:]),
+	}
+
+	msgs := make([]*hooklyv1.StreamResponse, 0, 1+(len(payload)+payloadChunkSize-1)/payloadChunkSize)
+	msgs = append(msgs, &hooklyv1.StreamResponse{
+		Message: &hooklyv1.StreamResponse_Webhook{Webhook: header},
+	})
+	for i := 0; len(payload) > 0; i++ {
+		n := min(len(payload), payloadChunkSize)
+		msgs = append(msgs, &hooklyv1.StreamResponse{
+			Message: &hooklyv1.StreamResponse_PayloadChunk{
+				PayloadChunk: &hooklyv1.PayloadChunk{
+					WebhookId: envelope.Id,
+					Index:     int32(i),
+					Data:      payload[:n],
+					Last:      n == len(payload),
+				},
+			},
+		})
+		payload = payload[n:]
+	}
+	return msgs
+}
+
+// payloadAssembler reassembles a chunked payload on the hub. Chunks for one
+// webhook arrive in order before the next envelope, so only one transfer is
+// in progress at a time.
+type payloadAssembler struct {
+	envelope *hooklyv1.WebhookEnvelope
+	buf      []byte
+	next     int32
+}
+
+// start begins reassembly for a chunked envelope, discarding any incomplete
+// transfer. It returns the ID of the discarded webhook, if any, and an error
+// if the envelope declares a size the edge would never send, in which case
+// no transfer is in progress.
+func (a *payloadAssembler) start(envelope *hooklyv1.WebhookEnvelope) (discarded string, err error) {
+	if a.envelope != nil {
+		discarded = a.envelope.Id
+	}
+	if envelope.PayloadSize < 0 || envelope.PayloadSize > webhook.MaxPayloadSize {
+		a.reset()
+		return discarded, fmt.Errorf("declared payload size of %d bytes is outside 0 to %d", envelope.PayloadSize, webhook.MaxPayloadSize)
+	}
+	a.envelope = envelope
+	a.buf = make([]byte, 0, envelope.PayloadSize)
+	a.next = 0
+	return discarded, nil
+}
+
+// add appends a chunk. When the last chunk arrives the checksum is verified
+// and the complete envelope is returned; until then it returns nil.
+func (a *payloadAssembler) add(chunk *hooklyv1.PayloadChunk) (*hooklyv1.WebhookEnvelope, error) {
+	envelope := a.envelope
+	if envelope == nil || envelope.Id != chunk.WebhookId {
+		return nil, fmt.Errorf("unexpected payload chunk for webhook %s", chunk.WebhookId)
+	}
+
+	if chunk.Index != a.next {
+		a.reset()
+		return nil, fmt.Errorf("payload chunk %d out of order, expected %d", chunk.Index, a.next)
+	}
+	if int64(len(a.buf)+len(chunk.Data)) > envelope.PayloadSize {
+		a.reset()
+		return nil, fmt.Errorf("payload exceeds declared size of %d bytes", envelope.PayloadSize)
+	}
+	a.buf = append(a.buf, chunk.Data...)
+	a.next++
+
+	if !chunk.Last {
+		return nil, nil
+	}
+
+	payload := a.buf
+	a.reset()
+	if int64(len(payload)) != envelope.PayloadSize {
+		return nil, fmt.Errorf("payload is %d bytes, expected %d", len(payload), envelope.PayloadSize)
+	}
+	sum := sha256.Sum256(payload)
+	if hex.EncodeToString(sum[:]) != envelope.PayloadSha256 {
+		return nil, fmt.Errorf("payload checksum mismatch")
+	}
+
+	envelope.Payload = payload
+	return envelope, nil
+}
+
+// pendingID returns the ID of the webhook being reassembled, or "".
+func (a *payloadAssembler) pendingID() string {
+	if a.envelope == nil {
+		return ""
+	}
+	return a.envelope.Id
+}
+
+func (a *payloadAssembler) reset() {
+	a.envelope = nil
+	a.buf = nil
+	a.next = 0
+}
@@ -0,0 +1,111 @@
+package relay
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/webhook"
+)
+
+func TestStreamMessagesInline(t *testing.T) {
+	envelope := &hooklyv1.WebhookEnvelope{Id: "wh-small", Payload: []byte(`{"ok":true}`)}
+
+	msgs := streamMessages(envelope)
+	if len(msgs) != 1 || msgs[0].GetWebhook() != envelope {
+		t.Fatalf("small payload should be sent inline, got %d messages", len(msgs))
+	}
+}
+
+func TestChunkedPayloadRoundTrip(t *testing.T) {
+	payload := bytes.Repeat([]byte("0123456789"), payloadChunkSize/4)
+	envelope := &hooklyv1.WebhookEnvelope{Id: "wh-big", EndpointId: "ep-1", Payload: payload}
+
+	msgs := streamMessages(envelope)
+	if len(msgs) != 4 {
+		t.Fatalf("expected envelope and 3 chunks, got %d messages", len(msgs))
+	}
+
+	header := msgs[0].GetWebhook()
+	if !header.Chunked || len(header.Payload) != 0 || header.PayloadSize != int64(len(payload)) {
+		t.Fatalf("unexpected header envelope: chunked=%v payload=%d size=%d", header.Chunked, len(header.Payload), header.PayloadSize)
+	}
+
+	var a payloadAssembler
+	a.start(header)
+
+	var got *hooklyv1.WebhookEnvelope
+	for i, msg := range msgs[1:] {
+		env, err := a.add(msg.GetPayloadChunk())
+		if err != nil {
+			t.Fatalf("chunk %d: %v", i, err)
+		}
+		if env != nil {
+			got = env
+		}
+	}
+
+	if got == nil {
+		t.Fatal("payload was not reassembled")
+	}
+	if !bytes.Equal(got.Payload, payload) {
+		t.Error("reassembled payload differs from original")
+	}
+	if a.pendingID() != "" {
+		t.Error("assembler should be idle after the last chunk")
+	}
+}
+
+func TestChunkedPayloadErrors(t *testing.T) {
+	payload := bytes.Repeat([]byte("x"), payloadChunkSize*2+1)
+	msgs := streamMessages(&hooklyv1.WebhookEnvelope{Id: "wh-big", Payload: payload})
+
+	t.Run("out of order", func(t *testing.T) {
+		var a payloadAssembler
+		a.start(msgs[0].GetWebhook())
+		if _, err := a.add(msgs[2].GetPayloadChunk()); err == nil {
+			t.Error("expected error for out of order chunk")
+		}
+		if a.pendingID() != "" {
+			t.Error("failed transfer should be discarded")
+		}
+	})
+
+	t.Run("checksum mismatch", func(t *testing.T) {
+		header := msgs[0].GetWebhook()
+		header.PayloadSha256 = "bad"
+
+		var a payloadAssembler
+		a.start(header)
+		var err error
+		for _, msg := range msgs[1:] {
+			if _, err = a.add(msg.GetPayloadChunk()); err != nil {
+				break
+			}
+		}
+		if err == nil {
+			t.Error("expected checksum error")
+		}
+	})
+
+	t.Run("interrupted", func(t *testing.T) {
+		var a payloadAssembler
+		a.start(msgs[0].GetWebhook())
+		if discarded, _ := a.start(&hooklyv1.WebhookEnvelope{Id: "wh-next", Chunked: true}); discarded != "wh-big" {
+			t.Errorf("discarded: got %q, want wh-big", discarded)
+		}
+	})
+
+	t.Run("declared size", func(t *testing.T) {
+		for _, size := range []int64{-1, webhook.MaxPayloadSize + 1, math.MaxInt64} {
+			var a payloadAssembler
+			if _, err := a.start(&hooklyv1.WebhookEnvelope{Id: "wh-bad", Chunked: true, PayloadSize: size}); err == nil {
+				t.Errorf("size %d: expected error", size)
+			}
+			if a.pendingID() != "" {
+				t.Errorf("size %d: transfer started", size)
+			}
+		}
+	})
+}
@@ -7,9 +7,14 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
+	"math/rand/v2"
 	"net"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"connectrpc.com/connect"
@@ -17,14 +22,20 @@ import (
 
 	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
 	"hooks.dx314.com/internal/api/hookly/v1/hooklyv1connect"
+	"hooks.dx314.com/internal/clock"
 	"hooks.dx314.com/internal/config"
+	"hooks.dx314.com/internal/logging"
+	"hooks.dx314.com/internal/tracing"
 	"hooks.dx314.com/internal/webhook"
 )
 
 const (
-	initialBackoff  = 1 * time.Second
-	maxBackoff      = 60 * time.Second
-	clientHeartbeat = 15 * time.Second
+	initialBackoff = 1 * time.Second
+	maxBackoff     = 60 * time.Second
+	// backoffJitter is the fraction of the backoff randomised in either direction.
+	backoffJitter = 0.2
+	// minStableUptime is how long a connection must stay up before backoff resets.
+	minStableUptime = 30 * time.Second
 )
 
 // Connection error types - permanent errors should not be retried
@@ -34,12 +45,52 @@ var (
 	ErrEndpointNotFound  = errors.New("endpoint not found")
 	ErrEndpointForbidden = errors.New("endpoint access denied")
 	ErrNoEndpoints       = errors.New("no endpoints configured")
+
+	errClosedByServer = errors.New("connection closed by server")
 )
 
+// retryHintError carries a server-provided minimum reconnect delay.
+type retryHintError struct {
+	err        error
+	retryAfter time.Duration
+}
+
+func (e *retryHintError) Error() string { return e.err.Error() }
+func (e *retryHintError) Unwrap() error { return e.err }
+
+// MaintenanceError ends a connection the edge closed for a planned restart.
+// It isn't a failure: the client reconnects after ReconnectAfter, to
+// ReconnectURL if set, without backing off.
+type MaintenanceError struct {
+	ReconnectAfter time.Duration
+	ReconnectURL   string // Standby edge, empty to reconnect to the same edge
+	Reason         string
+}
+
+func (e *MaintenanceError) Error() string {
+	if e.Reason == "" {
+		return "edge maintenance"
+	}
+	return "edge maintenance: " + e.Reason
+}
+
 // Client connects to the edge relay service and handles webhooks.
 type Client struct {
 	config    *config.HooklyConfig
 	forwarder *webhook.Forwarder
+	chaos     *Chaos // Fault injection for testing, nil in normal operation
+	metrics   *Metrics
+	tracer    *tracing.Tracer
+	clock     clock.Clock
+
+	mu         sync.Mutex
+	state      StateEvent
+	listeners  []StateListener
+	edgeURL    string // Edge to connect to; a maintenance announcement can move it to a standby
+	reloader   func() (*config.HooklyConfig, error)
+	recentLogs *logging.Recent // Read by logs commands
+
+	paused atomic.Bool // Deliveries paused by a command from the edge
 }
 
 // NewClient creates a new relay client from HooklyConfig.
@@ -47,14 +98,62 @@ func NewClient(cfg *config.HooklyConfig) *Client {
 	return &Client{
 		config:    cfg,
 		forwarder: webhook.NewForwarder(),
+		metrics:   NewMetrics(),
+		clock:     clock.Real,
+		edgeURL:   cfg.EdgeURL,
 	}
 }
 
+// SetClock sets the clock that paces heartbeats and reconnect backoff and
+// dates state changes. Must be called before Run.
+func (c *Client) SetClock(clk clock.Clock) {
+	c.clock = clk
+}
+
+// Metrics returns the client's metrics registry.
+func (c *Client) Metrics() *Metrics {
+	return c.metrics
+}
+
+// SetChaos enables fault injection for deliveries. Must be called before Run.
+func (c *Client) SetChaos(chaos *Chaos) {
+	c.chaos = chaos
+}
+
+// SetTracer exports delivery spans with t. Must be called before Run.
+func (c *Client) SetTracer(t *tracing.Tracer) {
+	c.tracer = t
+}
+
 // Run connects to the edge and processes webhooks until context is cancelled.
 // Automatically reconnects on disconnect with exponential backoff.
 // Returns immediately on permanent errors (auth issues, endpoint not found).
+//
+// Progress is reported as state events, see OnStateChange.
+//
+// If the config sets a metrics address, a local status server exposing
+// OpenMetrics on /metrics runs for the lifetime of Run. If it configures a
+// tunnel, webhooks are also accepted over the tunnel while the stream is down.
 func (c *Client) Run(ctx context.Context) error {
+	if c.cfg().MetricsAddr != "" {
+		if err := serveStatus(ctx, c.cfg().MetricsAddr, c.metrics); err != nil {
+			return err
+		}
+	}
+	if c.cfg().Tunnel != nil {
+		secret, err := newTunnelSecret()
+		if err != nil {
+			return fmt.Errorf("tunnel secret: %w", err)
+		}
+		if err := serveTunnel(ctx, c.cfg().Tunnel.Listen, &tunnelServer{secret: secret, deliver: c.deliver}); err != nil {
+			return err
+		}
+		go c.maintainTunnel(ctx, secret)
+	}
+
 	backoff := initialBackoff
+	attempt := 0
+	reconnecting := false
 
 	for {
 		select {
@@ -63,36 +162,122 @@ func (c *Client) Run(ctx context.Context) error {
 		default:
 		}
 
-		slog.Info("connecting to edge", "url", c.config.EdgeURL, "hub_id", c.config.GetHubID())
+		attempt++
+		if reconnecting {
+			c.metrics.incReconnects()
+		}
+		reconnecting = true
+		slog.Info("connecting to edge", "url", c.currentEdgeURL(), "edge_host", c.edgeHost(), "hub_id", c.cfg().GetHubID())
+		c.setState(StateEvent{State: StateConnecting, Attempt: attempt})
 
 		err := c.connect(ctx)
-		if err != nil {
-			if errors.Is(err, context.Canceled) {
+		if errors.Is(err, context.Canceled) {
+			return err
+		}
+
+		// Check for permanent errors that shouldn't be retried
+		if err != nil && isPermanentError(err) {
+			c.setState(StateEvent{State: StateFatal, Err: err})
+			return err
+		}
+
+		// Reconnect right away with the new endpoints
+		if errors.Is(err, errReloaded) {
+			backoff = initialBackoff
+			attempt = 0
+			reconnecting = false
+			continue
+		}
+
+		// A planned restart: wait as told, then connect as if for the first time
+		var maintenance *MaintenanceError
+		if errors.As(err, &maintenance) {
+			c.followMaintenance(maintenance)
+			backoff = initialBackoff
+			attempt = 0
+			reconnecting = false
+			if err := c.backOff(ctx, maintenance.ReconnectAfter, err); err != nil {
 				return err
 			}
+			continue
+		}
 
-			// Check for permanent errors that shouldn't be retried
-			if isPermanentError(err) {
-				slog.Error("connection failed (not retrying)", "error", err)
-				return err
+		// Only a connection that stayed up long enough resets the backoff,
+		// so one that drops right after connecting can't cause a reconnect storm.
+		if c.connectedUptime() >= minStableUptime {
+			backoff = initialBackoff
+			attempt = 0
+			if err == nil {
+				// Clean close of a stable connection - reconnect immediately
+				continue
 			}
+		}
+		if err == nil {
+			err = errClosedByServer
+		}
 
-			slog.Warn("connection failed, will retry", "error", err, "retry_in", backoff)
+		delay := jitter(backoff)
+		var hint *retryHintError
+		if errors.As(err, &hint) && hint.retryAfter > delay {
+			delay = hint.retryAfter
+		}
 
-			select {
-			case <-ctx.Done():
-				return ctx.Err()
-			case <-time.After(backoff):
-			}
+		if err := c.backOff(ctx, delay, err); err != nil {
+			return err
+		}
+
+		// Increase backoff
+		backoff = backoff * 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// connectedUptime returns how long the last connection was up, or zero if
+// the last attempt never reached the connected state.
+func (c *Client) connectedUptime() time.Duration {
+	ev := c.State()
+	if ev.State != StateConnected {
+		return 0
+	}
+	return c.clock.Now().Sub(ev.At)
+}
 
-			// Increase backoff
-			backoff = backoff * 2
-			if backoff > maxBackoff {
-				backoff = maxBackoff
+// jitter randomises d by up to backoffJitter in either direction.
+func jitter(d time.Duration) time.Duration {
+	spread := float64(d) * backoffJitter
+	return d + time.Duration(spread*(2*rand.Float64()-1))
+}
+
+// backOff waits for the given delay, emitting a countdown event every second.
+func (c *Client) backOff(ctx context.Context, delay time.Duration, cause error) error {
+	if delay <= 0 {
+		return nil
+	}
+	retryAt := c.clock.Now().Add(delay)
+	c.setState(StateEvent{State: StateBackingOff, Err: cause, RetryIn: delay, RetryAt: retryAt})
+
+	interval := min(delay, time.Second)
+	ticker := c.clock.NewTicker(interval)
+	defer func() { ticker.Stop() }()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C():
+			remaining := retryAt.Sub(c.clock.Now())
+			if remaining <= 0 {
+				return nil
 			}
-		} else {
-			// Connection was clean, reset backoff
-			backoff = initialBackoff
+			// The last tick lands on retryAt
+			if remaining < interval {
+				ticker.Stop()
+				interval = remaining
+				ticker = c.clock.NewTicker(interval)
+			}
+			c.setState(StateEvent{State: StateBackingOff, Err: cause, RetryIn: remaining, RetryAt: retryAt})
 		}
 	}
 }
@@ -103,7 +288,8 @@ func isPermanentError(err error) bool {
 		errors.Is(err, ErrTokenRevoked) ||
 		errors.Is(err, ErrEndpointNotFound) ||
 		errors.Is(err, ErrEndpointForbidden) ||
-		errors.Is(err, ErrNoEndpoints) {
+		errors.Is(err, ErrNoEndpoints) ||
+		errors.Is(err, ErrDisconnectedByEdge) {
 		return true
 	}
 	return false
@@ -124,7 +310,7 @@ func (c *Client) connect(ctx context.Context) error {
 				slog.Debug("TLS dial starting", "network", network, "addr", addr)
 				dialer := &net.Dialer{
 					Timeout:   30 * time.Second,
-					KeepAlive: 15 * time.Second,
+					KeepAlive: c.cfg().Keepalive.Heartbeat(),
 				}
 				conn, err := tls.DialWithDialer(dialer, network, addr, cfg)
 				if err != nil {
@@ -134,59 +320,67 @@ func (c *Client) connect(ctx context.Context) error {
 				}
 				return conn, err
 			},
-			ReadIdleTimeout: 15 * time.Second,
-			PingTimeout:     5 * time.Second,
+			ReadIdleTimeout: c.cfg().Keepalive.ReadIdle(),
+			PingTimeout:     c.cfg().Keepalive.Ping(),
 		},
 	}
 
 	// Create ConnectRPC client
 	client := hooklyv1connect.NewRelayServiceClient(
 		httpClient,
-		c.config.EdgeURL,
+		c.currentEdgeURL(),
 	)
 
 	// Open bidirectional stream
 	stream := client.Stream(ctx)
+	c.setState(StateEvent{State: StateAuthenticating})
 
 	// Send authentication message with bearer token
-	hubID := c.config.GetHubID()
-	slog.Debug("sending auth message", "hub_id", hubID, "endpoints", len(c.config.EndpointIDs()))
+	hubID := c.cfg().GetHubID()
+	slog.Debug("sending auth message", "hub_id", hubID, "endpoints", len(c.cfg().EndpointIDs()))
 
 	if err := stream.Send(&hooklyv1.StreamRequest{
 		Message: &hooklyv1.StreamRequest_Connect{
 			Connect: &hooklyv1.ConnectRequest{
-				HubId:       hubID,
-				Token:       c.config.Token,
-				EndpointIds: c.config.EndpointIDs(),
+				HubId:        hubID,
+				Token:        c.cfg().Token,
+				EndpointIds:  c.cfg().EndpointIDs(),
+				EventFilters: eventTypeFilters(c.cfg()),
+				Paused:       c.paused.Load(),
 			},
 		},
 	}); err != nil {
-		return err
+		return fmt.Errorf("connect to edge %s: %w", c.edgeHost(), err)
 	}
 
 	// Wait for auth response
-	slog.Debug("waiting for auth response")
+	slog.Debug("waiting for auth response", "edge_host", c.edgeHost())
 	resp, err := stream.Receive()
 	if err != nil {
-		return fmt.Errorf("connect to edge: %w", err)
+		return fmt.Errorf("connect to edge %s: %w", c.edgeHost(), err)
 	}
 
 	authResp := resp.GetConnectResponse()
 	if authResp == nil {
-		return errors.New("unexpected response from server")
+		return fmt.Errorf("unexpected response from edge %s", c.edgeHost())
 	}
 	if !authResp.Success {
-		slog.Debug("auth failed", "error", authResp.Error)
-		return parseConnectError(authResp.Error)
+		slog.Debug("auth failed", "edge_host", c.edgeHost(), "error", authResp.Error, "retry_after_seconds", authResp.RetryAfterSeconds)
+		err := fmt.Errorf("edge %s: %w", c.edgeHost(), parseConnectError(authResp.Error))
+		if authResp.RetryAfterSeconds > 0 {
+			return &retryHintError{err: err, retryAfter: time.Duration(authResp.RetryAfterSeconds) * time.Second}
+		}
+		return err
 	}
 
 	slog.Debug("auth succeeded")
-	slog.Info("connected to edge", "endpoints", c.config.EndpointIDs())
+	c.setState(StateEvent{State: StateConnected})
+	slog.Info("connected to edge", "edge_host", c.edgeHost(), "endpoints", c.cfg().EndpointIDs())
 
 	// Start heartbeat sender
 	heartbeatDone := make(chan struct{})
 	go func() {
-		ticker := time.NewTicker(clientHeartbeat)
+		ticker := c.clock.NewTicker(c.cfg().Keepalive.Heartbeat())
 		defer ticker.Stop()
 		for {
 			select {
@@ -194,12 +388,12 @@ func (c *Client) connect(ctx context.Context) error {
 				return
 			case <-ctx.Done():
 				return
-			case <-ticker.C:
+			case <-ticker.C():
 				slog.Debug("sending heartbeat")
 				if err := stream.Send(&hooklyv1.StreamRequest{
 					Message: &hooklyv1.StreamRequest_Heartbeat{
 						Heartbeat: &hooklyv1.Heartbeat{
-							Timestamp: time.Now().Unix(),
+							Timestamp: c.clock.Now().Unix(),
 						},
 					},
 				}); err != nil {
@@ -214,11 +408,12 @@ func (c *Client) connect(ctx context.Context) error {
 
 	// Process messages
 	slog.Debug("entering message loop")
+	var assembler payloadAssembler
 	for {
 		msg, err := stream.Receive()
 		if err != nil {
 			if errors.Is(err, io.EOF) {
-				slog.Info("connection closed by server")
+				slog.Info("connection closed by server", "uptime", c.connectedUptime().Round(time.Second))
 				return nil
 			}
 			slog.Debug("stream receive error", "error", err)
@@ -227,10 +422,46 @@ func (c *Client) connect(ctx context.Context) error {
 
 		switch m := msg.Message.(type) {
 		case *hooklyv1.StreamResponse_Webhook:
-			slog.Debug("received webhook message", "webhook_id", m.Webhook.Id)
+			slog.Debug("received webhook message", "webhook_id", m.Webhook.Id, "chunked", m.Webhook.Chunked)
+			if m.Webhook.Chunked {
+				discarded, err := assembler.start(m.Webhook)
+				if discarded != "" {
+					c.sendAck(stream, transferFailedAck(discarded, "payload transfer interrupted"))
+				}
+				if err != nil {
+					slog.Warn("rejected chunked payload", "webhook_id", m.Webhook.Id, "error", err)
+					c.sendAck(stream, transferFailedAck(m.Webhook.Id, err.Error()))
+				}
+				continue
+			}
 			c.handleWebhook(ctx, stream, m.Webhook)
+		case *hooklyv1.StreamResponse_PayloadChunk:
+			pending := assembler.pendingID()
+			envelope, err := assembler.add(m.PayloadChunk)
+			if err != nil {
+				slog.Warn("failed to reassemble payload", "webhook_id", m.PayloadChunk.WebhookId, "error", err)
+				if pending == m.PayloadChunk.WebhookId {
+					c.sendAck(stream, transferFailedAck(pending, err.Error()))
+				}
+				continue
+			}
+			if envelope != nil {
+				c.handleWebhook(ctx, stream, envelope)
+			}
 		case *hooklyv1.StreamResponse_Heartbeat:
 			slog.Debug("heartbeat from edge", "timestamp", m.Heartbeat.Timestamp)
+		case *hooklyv1.StreamResponse_Command:
+			result, end := c.runCommand(m.Command)
+			c.sendCommandResult(stream, result)
+			if end != nil {
+				return end
+			}
+		case *hooklyv1.StreamResponse_Maintenance:
+			return &MaintenanceError{
+				ReconnectAfter: time.Duration(m.Maintenance.ReconnectAfterSeconds) * time.Second,
+				ReconnectURL:   m.Maintenance.ReconnectUrl,
+				Reason:         m.Maintenance.Reason,
+			}
 		default:
 			slog.Debug("received unknown message type")
 		}
@@ -238,42 +469,232 @@ func (c *Client) connect(ctx context.Context) error {
 }
 
 func (c *Client) handleWebhook(ctx context.Context, stream *connect.BidiStreamForClient[hooklyv1.StreamRequest, hooklyv1.StreamResponse], envelope *hooklyv1.WebhookEnvelope) {
-	// Get destination URL, allowing local override
-	destinationURL := c.config.GetDestination(envelope.EndpointId, envelope.DestinationUrl)
+	c.sendAck(stream, c.deliver(ctx, envelope))
+}
+
+// deliver forwards a webhook to its destination and returns the ack for the
+// edge. Webhooks arrive over the stream or, when configured, the tunnel.
+func (c *Client) deliver(ctx context.Context, envelope *hooklyv1.WebhookEnvelope) (ack *hooklyv1.DeliveryAck) {
+	span := c.tracer.StartFromTraceparent("webhook.deliver", tracing.KindConsumer, envelope.Traceparent)
+	span.SetAttribute("hookly.webhook_id", envelope.Id)
+	span.SetAttribute("hookly.endpoint_id", envelope.EndpointId)
+	span.SetAttribute("hookly.attempt", int(envelope.Attempt))
+	defer func() {
+		if !ack.Success {
+			span.SetError(errors.New(ack.ErrorMessage))
+		}
+		// The edge's ACK span joins the trace it sent
+		if envelope.Traceparent != "" {
+			ack.Traceparent = span.Traceparent()
+		}
+		span.End()
+	}()
+
+	// Get destination URL, allowing local override and payload routes
+	cfg := c.cfg()
+	destinationURL := routeDestination(cfg.GetRoutes(envelope.EndpointId), envelope.Payload)
+	if destinationURL == "" {
+		destinationURL = cfg.GetDestination(envelope.EndpointId, envelope.DestinationUrl)
+	}
 
 	slog.Info("received webhook",
+		logging.SampleBy("endpoint_id", envelope.EndpointId),
 		"webhook_id", envelope.Id,
 		"endpoint_id", envelope.EndpointId,
 		"destination", destinationURL,
 		"attempt", envelope.Attempt,
 	)
 
+	// The edge stops dispatching once paused; this covers webhooks in flight
+	if c.paused.Load() {
+		slog.Info("webhook arrived while paused, edge will retry", "webhook_id", envelope.Id)
+		return pausedAck(envelope.Id)
+	}
+
+	if c.chaos != nil {
+		if ack := c.chaos.intercept(ctx, envelope.Id); ack != nil {
+			return ack
+		}
+	}
+
+	headers, payload := envelope.Headers, envelope.Payload
+	if t := envelope.Transform; t != nil {
+		var err error
+		transform := &webhook.Transform{Extract: t.Extract, Template: t.Template, Headers: t.Headers}
+		if headers, payload, err = transform.Apply(headers, payload); err != nil {
+			slog.Warn("webhook transform failed", "webhook_id", envelope.Id, "error", err)
+			return &hooklyv1.DeliveryAck{
+				WebhookId:        envelope.Id,
+				ErrorMessage:     err.Error(),
+				PermanentFailure: true,
+			}
+		}
+	}
+
+	if len(envelope.Destinations) > 0 {
+		return c.fanOut(ctx, span.Context(), envelope, destinationURL, headers, payload)
+	}
+
 	// Forward webhook
+	result := c.forward(ctx, span.Context(), destinationURL, headers, payload, envelope)
+	return &hooklyv1.DeliveryAck{
+		WebhookId:        envelope.Id,
+		Success:          result.Success,
+		StatusCode:       int32(result.StatusCode),
+		ErrorMessage:     result.Error,
+		PermanentFailure: result.PermanentFailure,
+	}
+}
+
+// forward sends a webhook to one destination in a span of its own, a child
+// of parent. The destination gets the span's context as its traceparent
+// header, replacing the one the provider sent.
+func (c *Client) forward(ctx context.Context, parent tracing.SpanContext, destinationURL string, headers map[string]string, payload []byte, envelope *hooklyv1.WebhookEnvelope) webhook.ForwardResult {
+	span := c.tracer.Start("webhook.forward", tracing.KindClient, parent)
+	if u, err := url.Parse(destinationURL); err == nil {
+		span.SetAttribute("server.address", u.Host)
+	}
+	headers = maps.Clone(headers)
+	if headers == nil {
+		headers = make(map[string]string, 1)
+	}
+	for name := range headers {
+		if strings.EqualFold(name, "traceparent") {
+			delete(headers, name)
+		}
+	}
+	headers["Traceparent"] = span.Traceparent()
+
+	start := time.Now()
 	result := c.forwarder.Forward(
 		ctx,
 		destinationURL,
-		envelope.Headers,
-		envelope.Payload,
+		headers,
+		payload,
 		envelope.Id,
 		int(envelope.Attempt),
 	)
+	c.metrics.observeForward(result.Success, result.StatusCode, time.Since(start))
 
-	// Send ACK
-	ack := &hooklyv1.DeliveryAck{
-		WebhookId:        envelope.Id,
-		Success:          result.Success,
-		StatusCode:       int32(result.StatusCode),
-		ErrorMessage:     result.Error,
-		PermanentFailure: result.PermanentFailure,
+	if result.StatusCode != 0 {
+		span.SetAttribute("http.response.status_code", result.StatusCode)
+	}
+	if !result.Success {
+		span.SetError(errors.New(result.Error))
 	}
+	span.End()
+	return result
+}
+
+// fanOut forwards a webhook to each of the envelope's destinations at once
+// and reports each result. The endpoint's own destination, with an empty ID,
+// is destinationURL so local overrides and routes apply to it.
+func (c *Client) fanOut(ctx context.Context, parent tracing.SpanContext, envelope *hooklyv1.WebhookEnvelope, destinationURL string, headers map[string]string, payload []byte) *hooklyv1.DeliveryAck {
+	results := make([]*hooklyv1.DestinationResult, len(envelope.Destinations))
+	urls := make([]string, len(envelope.Destinations))
+	var wg sync.WaitGroup
+	for i, dst := range envelope.Destinations {
+		urls[i] = dst.Url
+		if dst.Id == "" {
+			urls[i] = destinationURL
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result := c.forward(ctx, parent, urls[i], headers, payload, envelope)
+			results[i] = &hooklyv1.DestinationResult{
+				DestinationId:    dst.Id,
+				Success:          result.Success,
+				StatusCode:       int32(result.StatusCode),
+				ErrorMessage:     result.Error,
+				PermanentFailure: result.PermanentFailure,
+			}
+		}()
+	}
+	wg.Wait()
 
+	ack := &hooklyv1.DeliveryAck{WebhookId: envelope.Id, Success: true, Destinations: results}
+	for i, r := range results {
+		if r.Success {
+			continue
+		}
+		if ack.Success {
+			ack.Success = false
+			ack.StatusCode = r.StatusCode
+			ack.ErrorMessage = fmt.Sprintf("%s: %s", urls[i], r.ErrorMessage)
+			ack.PermanentFailure = true
+		}
+		ack.PermanentFailure = ack.PermanentFailure && r.PermanentFailure
+	}
+	return ack
+}
+
+func (c *Client) sendAck(stream *connect.BidiStreamForClient[hooklyv1.StreamRequest, hooklyv1.StreamResponse], ack *hooklyv1.DeliveryAck) {
 	if err := stream.Send(&hooklyv1.StreamRequest{
 		Message: &hooklyv1.StreamRequest_Ack{
 			Ack: ack,
 		},
 	}); err != nil {
-		slog.Error("failed to send ACK", "webhook_id", envelope.Id, "error", err)
+		slog.Error("failed to send ACK", "webhook_id", ack.WebhookId, "error", err)
+	}
+}
+
+// transferFailedAck reports a payload that didn't arrive intact. It is a
+// transient failure so the edge retries the webhook.
+func transferFailedAck(webhookID, reason string) *hooklyv1.DeliveryAck {
+	return &hooklyv1.DeliveryAck{
+		WebhookId:    webhookID,
+		ErrorMessage: "payload transfer failed: " + reason,
+	}
+}
+
+// currentEdgeURL returns the URL of the edge the client connects to.
+func (c *Client) currentEdgeURL() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.edgeURL
+}
+
+// followMaintenance switches to the standby edge of a maintenance
+// announcement. The standby is used until the next announcement or until
+// hookly restarts; a URL that isn't https is ignored.
+func (c *Client) followMaintenance(m *MaintenanceError) {
+	if m.ReconnectURL == "" {
+		return
+	}
+	u, err := url.Parse(m.ReconnectURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		slog.Warn("ignoring invalid standby edge URL from maintenance announcement", "url", m.ReconnectURL)
+		return
+	}
+	c.mu.Lock()
+	c.edgeURL = strings.TrimSuffix(m.ReconnectURL, "/")
+	c.mu.Unlock()
+}
+
+// edgeHost returns the host of the current edge URL for diagnostics.
+func (c *Client) edgeHost() string {
+	edgeURL := c.currentEdgeURL()
+	u, err := url.Parse(edgeURL)
+	if err != nil || u.Host == "" {
+		return edgeURL
+	}
+	return u.Host
+}
+
+// eventTypeFilters converts the per-endpoint event_types from hookly.yaml to
+// connect request filters.
+func eventTypeFilters(cfg *config.HooklyConfig) []*hooklyv1.EventTypeFilter {
+	var filters []*hooklyv1.EventTypeFilter
+	for _, ep := range cfg.Endpoints {
+		if len(ep.EventTypes) > 0 {
+			filters = append(filters, &hooklyv1.EventTypeFilter{
+				EndpointId: ep.ID,
+				EventTypes: ep.EventTypes,
+			})
+		}
 	}
+	return filters
 }
 
 // parseConnectError parses the server error string and returns a typed error.
@@ -291,7 +712,7 @@ func parseConnectError(serverError string) error {
 	switch code {
 	case "TOKEN_MISSING":
 		return fmt.Errorf("%w: %s", ErrTokenInvalid, message)
-	case "TOKEN_INVALID":
+	case "TOKEN_INVALID", "TOKEN_EXPIRED", "TOKEN_SCOPE":
 		return fmt.Errorf("%w: %s", ErrTokenInvalid, message)
 	case "TOKEN_REVOKED":
 		return fmt.Errorf("%w: %s", ErrTokenRevoked, message)
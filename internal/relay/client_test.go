@@ -0,0 +1,70 @@
+package relay
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"hooks.dx314.com/internal/config"
+)
+
+func TestJitter(t *testing.T) {
+	base := 10 * time.Second
+	low := time.Duration(float64(base) * (1 - backoffJitter))
+	high := time.Duration(float64(base) * (1 + backoffJitter))
+
+	seen := map[time.Duration]bool{}
+	for i := 0; i < 100; i++ {
+		d := jitter(base)
+		if d < low || d > high {
+			t.Fatalf("jitter(%v) = %v, want within [%v, %v]", base, d, low, high)
+		}
+		seen[d] = true
+	}
+	if len(seen) < 2 {
+		t.Error("jitter returned the same delay every time")
+	}
+}
+
+func TestRetryHintError(t *testing.T) {
+	err := &retryHintError{err: parseConnectError("AUTH_FAILED: authentication failed"), retryAfter: 30 * time.Second}
+
+	var hint *retryHintError
+	if !errors.As(err, &hint) || hint.retryAfter != 30*time.Second {
+		t.Fatalf("expected retry hint of 30s, got %v", err)
+	}
+	if isPermanentError(err) {
+		t.Error("AUTH_FAILED with retry hint should not be permanent")
+	}
+
+	wrapped := &retryHintError{err: parseConnectError("TOKEN_REVOKED: revoked"), retryAfter: time.Second}
+	if !errors.Is(wrapped, ErrTokenRevoked) {
+		t.Error("retry hint should unwrap to the underlying error")
+	}
+}
+
+func TestFollowMaintenance(t *testing.T) {
+	c := NewClient(&config.HooklyConfig{EdgeURL: "https://hooks.example.com"})
+
+	c.followMaintenance(&MaintenanceError{ReconnectAfter: 5 * time.Second})
+	if got := c.currentEdgeURL(); got != "https://hooks.example.com" {
+		t.Errorf("without a standby: edge = %q", got)
+	}
+
+	c.followMaintenance(&MaintenanceError{ReconnectURL: "http://standby.example.com"})
+	if got := c.currentEdgeURL(); got != "https://hooks.example.com" {
+		t.Errorf("plain http standby was followed: edge = %q", got)
+	}
+
+	c.followMaintenance(&MaintenanceError{ReconnectURL: "https://standby.example.com/"})
+	if got := c.currentEdgeURL(); got != "https://standby.example.com" {
+		t.Errorf("edge = %q, want the standby", got)
+	}
+	if got := c.edgeHost(); got != "standby.example.com" {
+		t.Errorf("edgeHost = %q", got)
+	}
+
+	if isPermanentError(&MaintenanceError{}) {
+		t.Error("maintenance must not be a permanent error")
+	}
+}
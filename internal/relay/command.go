@@ -0,0 +1,332 @@
+package relay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"connectrpc.com/connect"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/config"
+	"hooks.dx314.com/internal/logging"
+)
+
+// Hub commands let the owner manage a headless hub from the edge: the edge
+// sends a HubCommand on the stream and waits for the HubCommandResult with
+// the same id. This file holds both sides.
+
+var (
+	// ErrHubCommandsUnsupported is returned for a hub delivering over a
+	// tunnel, which has no stream to carry commands.
+	ErrHubCommandsUnsupported = errors.New("hub commands need the relay stream; the hub is connected over a tunnel")
+	// ErrHubDisconnected is returned when the hub's stream closes before it
+	// answers a command.
+	ErrHubDisconnected = errors.New("hub disconnected before answering")
+	// ErrDisconnectedByEdge is returned by Run when the edge told the hub to
+	// disconnect. It is permanent: the hub stays disconnected until restarted.
+	ErrDisconnectedByEdge = errors.New("disconnected by a command from the edge")
+
+	// errLogsNotShared answers a logs command on a hub without share_logs.
+	errLogsNotShared = errors.New("this hub doesn't share its logs; set share_logs: true in hookly.yaml to allow it")
+
+	// errReloaded ends a connection after a config reload, so the client
+	// reconnects with the new endpoints.
+	errReloaded = errors.New("config reloaded")
+)
+
+// Bounds of the log lines a logs command returns.
+const (
+	DefaultLogLines = 100
+	MaxLogLines     = 1000
+)
+
+// hubCommands tracks the commands sent to one hub connection.
+type hubCommands struct {
+	ch     chan *hooklyv1.HubCommand
+	closed chan struct{} // Closed when the stream ends
+
+	mu        sync.Mutex
+	pending   map[string]chan *hooklyv1.HubCommandResult
+	closeOnce sync.Once
+}
+
+func newHubCommands() *hubCommands {
+	return &hubCommands{
+		ch:      make(chan *hooklyv1.HubCommand, 8),
+		closed:  make(chan struct{}),
+		pending: make(map[string]chan *hooklyv1.HubCommandResult),
+	}
+}
+
+// resolve hands a hub's result to the command waiting for it. Results for
+// commands that timed out are dropped.
+func (c *hubCommands) resolve(result *hooklyv1.HubCommandResult) {
+	c.mu.Lock()
+	ch, ok := c.pending[result.Id]
+	c.mu.Unlock()
+	if !ok {
+		slog.Debug("dropping result for unknown hub command", "command_id", result.Id)
+		return
+	}
+	select {
+	case ch <- result:
+	default:
+	}
+}
+
+func (c *hubCommands) close() {
+	c.closeOnce.Do(func() { close(c.closed) })
+}
+
+// Command sends cmd to the hub, assigning its ID, and waits for the result
+// until ctx is done. A successful pause or resume also pauses or resumes
+// dispatching to the hub.
+func (c *HubConnection) Command(ctx context.Context, cmd *hooklyv1.HubCommand) (*hooklyv1.HubCommandResult, error) {
+	if c.transport != TransportStream {
+		return nil, ErrHubCommandsUnsupported
+	}
+	id, err := gonanoid.New()
+	if err != nil {
+		return nil, err
+	}
+	cmd.Id = id
+
+	resultCh := make(chan *hooklyv1.HubCommandResult, 1)
+	c.commands.mu.Lock()
+	c.commands.pending[id] = resultCh
+	c.commands.mu.Unlock()
+	defer func() {
+		c.commands.mu.Lock()
+		delete(c.commands.pending, id)
+		c.commands.mu.Unlock()
+	}()
+
+	select {
+	case c.commands.ch <- cmd:
+	case <-c.commands.closed:
+		return nil, ErrHubDisconnected
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	var result *hooklyv1.HubCommandResult
+	select {
+	case result = <-resultCh:
+	case <-c.commands.closed:
+		// A hub told to disconnect answers right before closing its stream
+		select {
+		case result = <-resultCh:
+		default:
+			return nil, ErrHubDisconnected
+		}
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	if result.Success {
+		switch cmd.Type {
+		case hooklyv1.HubCommandType_HUB_COMMAND_TYPE_PAUSE:
+			c.paused.Store(true)
+		case hooklyv1.HubCommandType_HUB_COMMAND_TYPE_RESUME:
+			c.paused.Store(false)
+		}
+	}
+	return result, nil
+}
+
+// Hub side
+
+// SetReloader sets how a reload command re-reads the configuration. Without
+// one, reload commands fail. Only the endpoints, hub ID, keepalives and log
+// sharing are taken from the new configuration; the edge, token, metrics
+// address and tunnel need a restart.
+func (c *Client) SetReloader(fn func() (*config.HooklyConfig, error)) {
+	c.mu.Lock()
+	c.reloader = fn
+	c.mu.Unlock()
+}
+
+// SetRecentLogs sets the buffer a logs command reads from. Logs are only
+// sent if the configuration sets share_logs.
+func (c *Client) SetRecentLogs(r *logging.Recent) {
+	c.mu.Lock()
+	c.recentLogs = r
+	c.mu.Unlock()
+}
+
+// cfg returns the current configuration, which a reload command replaces.
+func (c *Client) cfg() *config.HooklyConfig {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.config
+}
+
+// runCommand carries out a command from the edge. A non-nil error ends the
+// connection once the result is sent.
+func (c *Client) runCommand(cmd *hooklyv1.HubCommand) (*hooklyv1.HubCommandResult, error) {
+	slog.Info("received command from edge", "command", cmd.Type.String(), "command_id", cmd.Id)
+	result := &hooklyv1.HubCommandResult{Id: cmd.Id, Success: true}
+
+	switch cmd.Type {
+	case hooklyv1.HubCommandType_HUB_COMMAND_TYPE_RELOAD_CONFIG:
+		n, err := c.reload()
+		if err != nil {
+			slog.Warn("config reload failed", "error", err)
+			return failedResult(cmd.Id, err.Error()), nil
+		}
+		result.Output = fmt.Sprintf("reloaded %d endpoints; reconnecting", n)
+		return result, errReloaded
+
+	case hooklyv1.HubCommandType_HUB_COMMAND_TYPE_PAUSE:
+		c.paused.Store(true)
+		result.Output = "deliveries paused"
+
+	case hooklyv1.HubCommandType_HUB_COMMAND_TYPE_RESUME:
+		c.paused.Store(false)
+		result.Output = "deliveries resumed"
+
+	case hooklyv1.HubCommandType_HUB_COMMAND_TYPE_DIAGNOSTICS:
+		data, err := json.MarshalIndent(c.diagnostics(), "", "  ")
+		if err != nil {
+			return failedResult(cmd.Id, err.Error()), nil
+		}
+		result.Output = string(data)
+
+	case hooklyv1.HubCommandType_HUB_COMMAND_TYPE_LOGS:
+		lines, err := c.recentLines(int(cmd.Lines))
+		if err != nil {
+			return failedResult(cmd.Id, err.Error()), nil
+		}
+		slog.Info("sending recent logs to edge", "lines", len(lines))
+		result.Output = strings.Join(lines, "\n")
+
+	case hooklyv1.HubCommandType_HUB_COMMAND_TYPE_DISCONNECT:
+		result.Output = "disconnecting"
+		return result, ErrDisconnectedByEdge
+
+	default:
+		return failedResult(cmd.Id, "unsupported command "+cmd.Type.String()), nil
+	}
+	return result, nil
+}
+
+func failedResult(id, msg string) *hooklyv1.HubCommandResult {
+	return &hooklyv1.HubCommandResult{Id: id, Error: msg}
+}
+
+// reload re-reads the configuration, keeping the settings bound at start,
+// and returns the number of endpoints.
+func (c *Client) reload() (int, error) {
+	c.mu.Lock()
+	reloader := c.reloader
+	c.mu.Unlock()
+	if reloader == nil {
+		return 0, errors.New("this hub can't reload its config")
+	}
+
+	loaded, err := reloader()
+	if err != nil {
+		return 0, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	next := *c.config
+	next.HubID = loaded.HubID
+	next.Endpoints = loaded.Endpoints
+	next.Keepalive = loaded.Keepalive
+	next.ShareLogs = loaded.ShareLogs
+	c.config = &next
+	slog.Info("config reloaded", "endpoints", len(next.Endpoints))
+	return len(next.Endpoints), nil
+}
+
+// recentLines returns up to n recent log lines, DefaultLogLines if n is 0,
+// if the owner allowed sharing them.
+func (c *Client) recentLines(n int) ([]string, error) {
+	c.mu.Lock()
+	recent, share := c.recentLogs, c.config.ShareLogs
+	c.mu.Unlock()
+	if !share {
+		return nil, errLogsNotShared
+	}
+	if recent == nil {
+		return nil, errors.New("this hub doesn't keep recent logs")
+	}
+	if n <= 0 {
+		n = DefaultLogLines
+	}
+	return recent.Lines(min(n, MaxLogLines)), nil
+}
+
+// hubDiagnostics is the report of a diagnostics command.
+type hubDiagnostics struct {
+	HubID        string                  `json:"hub_id"`
+	EdgeURL      string                  `json:"edge_url"`
+	State        string                  `json:"state"`
+	ConnectedFor string                  `json:"connected_for,omitempty"`
+	Paused       bool                    `json:"paused"`
+	Endpoints    []config.EndpointConfig `json:"endpoints"`
+	Tunnel       bool                    `json:"tunnel"`
+	ShareLogs    bool                    `json:"share_logs"`
+	Forwarded    uint64                  `json:"forwarded"`
+	Failed       uint64                  `json:"failed"`
+	Reconnects   uint64                  `json:"reconnects"`
+	GoVersion    string                  `json:"go_version"`
+	Platform     string                  `json:"platform"`
+	Goroutines   int                     `json:"goroutines"`
+}
+
+func (c *Client) diagnostics() hubDiagnostics {
+	cfg := c.cfg()
+	state := c.State()
+	d := hubDiagnostics{
+		HubID:      cfg.GetHubID(),
+		EdgeURL:    c.currentEdgeURL(),
+		State:      state.State.String(),
+		Paused:     c.paused.Load(),
+		Endpoints:  cfg.Endpoints,
+		Tunnel:     cfg.Tunnel != nil,
+		ShareLogs:  cfg.ShareLogs,
+		GoVersion:  runtime.Version(),
+		Platform:   runtime.GOOS + "/" + runtime.GOARCH,
+		Goroutines: runtime.NumGoroutine(),
+	}
+	if state.State == StateConnected {
+		d.ConnectedFor = c.clock.Now().Sub(state.At).Round(time.Second).String()
+	}
+
+	c.metrics.mu.Lock()
+	d.Forwarded = c.metrics.forwarded
+	for _, n := range c.metrics.failures {
+		d.Failed += n
+	}
+	d.Reconnects = c.metrics.reconnects
+	c.metrics.mu.Unlock()
+	return d
+}
+
+// pausedAck answers a webhook that arrived while deliveries are paused. It is
+// a transient failure, so the edge delivers it after the hub resumes.
+func pausedAck(webhookID string) *hooklyv1.DeliveryAck {
+	return &hooklyv1.DeliveryAck{
+		WebhookId:    webhookID,
+		ErrorMessage: "hub paused",
+	}
+}
+
+func (c *Client) sendCommandResult(stream *connect.BidiStreamForClient[hooklyv1.StreamRequest, hooklyv1.StreamResponse], result *hooklyv1.HubCommandResult) {
+	if err := stream.Send(&hooklyv1.StreamRequest{
+		Message: &hooklyv1.StreamRequest_CommandResult{CommandResult: result},
+	}); err != nil {
+		slog.Error("failed to send command result", "command_id", result.Id, "error", err)
+	}
+}
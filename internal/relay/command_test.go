@@ -0,0 +1,181 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/config"
+	"hooks.dx314.com/internal/logging"
+)
+
+// answer plays the hub's stream: it takes the next command and resolves it
+// with the given success.
+func answer(t *testing.T, conn *HubConnection, success bool) {
+	t.Helper()
+	select {
+	case cmd := <-conn.commands.ch:
+		conn.commands.resolve(&hooklyv1.HubCommandResult{Id: cmd.Id, Success: success})
+	case <-time.After(time.Second):
+		t.Error("no command sent")
+	}
+}
+
+func TestHubConnectionCommand(t *testing.T) {
+	m := NewConnectionManager()
+	conn := m.AddConnection("hub-1", "user-1", []string{"ep-1"}, nil)
+	ctx := context.Background()
+
+	go answer(t, conn, true)
+	result, err := conn.Command(ctx, &hooklyv1.HubCommand{Type: hooklyv1.HubCommandType_HUB_COMMAND_TYPE_PAUSE})
+	if err != nil || !result.Success {
+		t.Fatalf("Command() = %v, %v", result, err)
+	}
+	if !conn.Paused() {
+		t.Error("hub not paused after a successful pause")
+	}
+
+	// A failed resume leaves the hub paused
+	go answer(t, conn, false)
+	if _, err := conn.Command(ctx, &hooklyv1.HubCommand{Type: hooklyv1.HubCommandType_HUB_COMMAND_TYPE_RESUME}); err != nil {
+		t.Fatal(err)
+	}
+	if !conn.Paused() {
+		t.Error("hub resumed after a failed resume")
+	}
+
+	// A hub that never answers
+	timeout, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := conn.Command(timeout, &hooklyv1.HubCommand{Type: hooklyv1.HubCommandType_HUB_COMMAND_TYPE_DIAGNOSTICS}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("unanswered command: err = %v, want deadline exceeded", err)
+	}
+	<-conn.commands.ch
+
+	conn.commands.close()
+	if _, err := conn.Command(ctx, &hooklyv1.HubCommand{Type: hooklyv1.HubCommandType_HUB_COMMAND_TYPE_DIAGNOSTICS}); !errors.Is(err, ErrHubDisconnected) {
+		t.Errorf("closed stream: err = %v, want ErrHubDisconnected", err)
+	}
+
+	tunnel := m.addConnection("hub-tunnel", "user-1", TransportTunnel, []string{"ep-2"}, nil)
+	if _, err := tunnel.Command(ctx, &hooklyv1.HubCommand{Type: hooklyv1.HubCommandType_HUB_COMMAND_TYPE_PAUSE}); !errors.Is(err, ErrHubCommandsUnsupported) {
+		t.Errorf("tunnel hub: err = %v, want ErrHubCommandsUnsupported", err)
+	}
+}
+
+func TestRunCommand(t *testing.T) {
+	c := NewClient(&config.HooklyConfig{
+		EdgeURL:   "https://hooks.example.com",
+		HubID:     "hub-1",
+		Endpoints: []config.EndpointConfig{{ID: "ep-1"}},
+	})
+	run := func(typ hooklyv1.HubCommandType) (*hooklyv1.HubCommandResult, error) {
+		return c.runCommand(&hooklyv1.HubCommand{Id: "cmd-1", Type: typ})
+	}
+
+	if result, err := run(hooklyv1.HubCommandType_HUB_COMMAND_TYPE_PAUSE); err != nil || !result.Success || !c.paused.Load() {
+		t.Errorf("pause: %v, %v, paused %v", result, err, c.paused.Load())
+	}
+	if ack := c.deliver(context.Background(), &hooklyv1.WebhookEnvelope{Id: "wh-1"}); ack.Success || ack.PermanentFailure {
+		t.Errorf("delivery while paused = %v, want a transient failure", ack)
+	}
+	if _, err := run(hooklyv1.HubCommandType_HUB_COMMAND_TYPE_RESUME); err != nil || c.paused.Load() {
+		t.Errorf("resume: %v, paused %v", err, c.paused.Load())
+	}
+
+	result, err := run(hooklyv1.HubCommandType_HUB_COMMAND_TYPE_DIAGNOSTICS)
+	if err != nil || !result.Success || !strings.Contains(result.Output, `"hub_id": "hub-1"`) {
+		t.Errorf("diagnostics: %v, %v", result, err)
+	}
+
+	// Reloading without a reloader fails but keeps the connection
+	if result, err := run(hooklyv1.HubCommandType_HUB_COMMAND_TYPE_RELOAD_CONFIG); err != nil || result.Success || result.Error == "" {
+		t.Errorf("reload without reloader: %v, %v", result, err)
+	}
+
+	if result, err := run(hooklyv1.HubCommandType_HUB_COMMAND_TYPE_DISCONNECT); !errors.Is(err, ErrDisconnectedByEdge) || !result.Success {
+		t.Errorf("disconnect: %v, %v", result, err)
+	}
+	if !isPermanentError(ErrDisconnectedByEdge) {
+		t.Error("a disconnect command must stop the client")
+	}
+}
+
+func TestReload(t *testing.T) {
+	c := NewClient(&config.HooklyConfig{
+		EdgeURL:   "https://hooks.example.com",
+		Token:     "hk_token",
+		HubID:     "hub-1",
+		Endpoints: []config.EndpointConfig{{ID: "ep-1"}},
+	})
+	c.SetReloader(func() (*config.HooklyConfig, error) {
+		return &config.HooklyConfig{
+			EdgeURL:   "https://other.example.com",
+			HubID:     "hub-2",
+			Endpoints: []config.EndpointConfig{{ID: "ep-1"}, {ID: "ep-2"}},
+		}, nil
+	})
+
+	result, err := c.runCommand(&hooklyv1.HubCommand{Id: "cmd-1", Type: hooklyv1.HubCommandType_HUB_COMMAND_TYPE_RELOAD_CONFIG})
+	if !errors.Is(err, errReloaded) || !result.Success {
+		t.Fatalf("reload: %v, %v", result, err)
+	}
+
+	cfg := c.cfg()
+	if cfg.HubID != "hub-2" || len(cfg.Endpoints) != 2 {
+		t.Errorf("reloaded hub %q with %d endpoints", cfg.HubID, len(cfg.Endpoints))
+	}
+	// Settings bound at start are kept
+	if cfg.EdgeURL != "https://hooks.example.com" || cfg.Token != "hk_token" {
+		t.Errorf("reload changed edge %q, token %q", cfg.EdgeURL, cfg.Token)
+	}
+
+	c.SetReloader(func() (*config.HooklyConfig, error) { return nil, errors.New("hookly.yaml: bad yaml") })
+	result, err = c.runCommand(&hooklyv1.HubCommand{Id: "cmd-2", Type: hooklyv1.HubCommandType_HUB_COMMAND_TYPE_RELOAD_CONFIG})
+	if err != nil || result.Success || !strings.Contains(result.Error, "bad yaml") {
+		t.Errorf("failed reload: %v, %v", result, err)
+	}
+	if c.cfg().HubID != "hub-2" {
+		t.Error("failed reload replaced the config")
+	}
+}
+
+func TestLogsCommand(t *testing.T) {
+	cfg := &config.HooklyConfig{EdgeURL: "https://hooks.example.com", HubID: "hub-1"}
+	c := NewClient(cfg)
+	recent := logging.NewRecent(MaxLogLines)
+	logger := slog.New(recent.Handler(slog.LevelInfo))
+	for i := range 150 {
+		logger.Info("delivered", "n", i)
+	}
+	c.SetRecentLogs(recent)
+	logs := func(lines int32) *hooklyv1.HubCommandResult {
+		result, err := c.runCommand(&hooklyv1.HubCommand{Id: "cmd-1", Type: hooklyv1.HubCommandType_HUB_COMMAND_TYPE_LOGS, Lines: lines})
+		if err != nil {
+			t.Fatalf("logs command ended the connection: %v", err)
+		}
+		return result
+	}
+
+	// Sharing needs the owner's consent in hookly.yaml
+	if result := logs(10); result.Success || !strings.Contains(result.Error, "share_logs") {
+		t.Errorf("logs without consent: %v", result)
+	}
+
+	cfg.ShareLogs = true
+	result := logs(10)
+	if !result.Success {
+		t.Fatalf("logs: %v", result)
+	}
+	lines := strings.Split(result.Output, "\n")
+	if len(lines) != 10 || !strings.Contains(lines[9], "n=149") {
+		t.Errorf("got %d lines ending %q", len(lines), lines[len(lines)-1])
+	}
+	if n := strings.Count(logs(0).Output, "\n") + 1; n != DefaultLogLines {
+		t.Errorf("default returned %d lines, want %d", n, DefaultLogLines)
+	}
+}
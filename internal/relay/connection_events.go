@@ -0,0 +1,151 @@
+package relay
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+
+	"hooks.dx314.com/internal/db"
+	"hooks.dx314.com/internal/notify"
+)
+
+// disconnectGrace is how long an endpoint must stay without a hub before its
+// owner is notified, so a hub reconnecting after a network blip or an edge
+// restart isn't reported.
+const disconnectGrace = time.Minute
+
+// handleConnectionEvent records a hub connecting or disconnecting in the
+// activity feed and each endpoint's connection history, and notifies the
+// owners of endpoints left without a hub or getting theirs back. It is the
+// connection manager's event handler.
+func (h *Handler) handleConnectionEvent(ev ConnectionEvent) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	kind := activityHubConnected
+	if ev.Type == ConnectionEventDisconnected {
+		kind = activityHubDisconnected
+	}
+	h.recordHubActivity(ctx, ev.UserID, ev.HubID, kind)
+	for _, epID := range ev.EndpointIDs {
+		h.recordConnectionEvent(ctx, epID, ev)
+	}
+
+	n, ok := h.notifier.(notify.ConnectionNotifier)
+	if !ok {
+		return
+	}
+	switch ev.Type {
+	case ConnectionEventConnected:
+		h.notifyReconnected(n, ev)
+	case ConnectionEventDisconnected:
+		h.notifyDisconnected(n, ev)
+	}
+}
+
+// recordConnectionEvent adds an event to an endpoint's connection history.
+// Failures are logged and otherwise ignored, like the activity feed's.
+func (h *Handler) recordConnectionEvent(ctx context.Context, endpointID string, ev ConnectionEvent) {
+	id, err := gonanoid.New()
+	if err != nil {
+		slog.Error("failed to generate connection event id", "error", err)
+		return
+	}
+
+	if err := h.queries.RecordConnectionEvent(ctx, db.RecordConnectionEventParams{
+		ID:         id,
+		EndpointID: endpointID,
+		HubID:      ev.HubID,
+		Event:      ev.Type,
+		Transport:  ev.Transport,
+	}); err != nil {
+		slog.Error("failed to record connection event", "endpoint_id", endpointID, "hub_id", ev.HubID, "event", ev.Type, "error", err)
+	}
+}
+
+// notifyDisconnected notifies the owners of a hub's endpoints that are still
+// without a hub after disconnectGrace.
+func (h *Handler) notifyDisconnected(n notify.ConnectionNotifier, ev ConnectionEvent) {
+	time.AfterFunc(disconnectGrace, func() {
+		for _, epID := range ev.EndpointIDs {
+			if h.manager.GetHubForEndpoint(epID) != nil {
+				continue
+			}
+			h.sendDisconnectNotification(n, notify.DisconnectInfo{
+				EndpointID:     epID,
+				HubID:          ev.HubID,
+				DisconnectedAt: ev.At,
+			})
+		}
+	})
+}
+
+// notifyReconnected notifies the owners of a hub's endpoints whose
+// disconnect was reported that they are connected again.
+func (h *Handler) notifyReconnected(n notify.ConnectionNotifier, ev ConnectionEvent) {
+	h.reportedMu.Lock()
+	var infos []notify.ReconnectInfo
+	for _, epID := range ev.EndpointIDs {
+		if disconnectedAt, ok := h.reported[epID]; ok {
+			delete(h.reported, epID)
+			infos = append(infos, notify.ReconnectInfo{
+				EndpointID:     epID,
+				HubID:          ev.HubID,
+				DisconnectedAt: disconnectedAt,
+				ReconnectedAt:  ev.At,
+			})
+		}
+	}
+	h.reportedMu.Unlock()
+
+	if len(infos) == 0 {
+		return
+	}
+	// Not on the connecting hub's goroutine
+	go func() {
+		for _, info := range infos {
+			h.sendReconnectNotification(n, info)
+		}
+	}()
+}
+
+func (h *Handler) sendDisconnectNotification(n notify.ConnectionNotifier, info notify.DisconnectInfo) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	// Deleted endpoints aren't reported
+	endpoint, err := h.endpoint(ctx, info.EndpointID)
+	if err != nil {
+		slog.Debug("skipped disconnect notification", "endpoint_id", info.EndpointID, "error", err)
+		return
+	}
+	info.EndpointName = endpoint.Name
+
+	// Marked first, so a hub connecting while this is sent is reported too
+	h.reportedMu.Lock()
+	h.reported[info.EndpointID] = info.DisconnectedAt
+	h.reportedMu.Unlock()
+	if err := n.NotifyEndpointDisconnected(ctx, info); err != nil {
+		slog.Warn("failed to send disconnect notification", "endpoint_id", info.EndpointID, "error", err)
+		h.reportedMu.Lock()
+		if h.reported[info.EndpointID].Equal(info.DisconnectedAt) {
+			delete(h.reported, info.EndpointID)
+		}
+		h.reportedMu.Unlock()
+	}
+}
+
+func (h *Handler) sendReconnectNotification(n notify.ConnectionNotifier, info notify.ReconnectInfo) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	endpoint, err := h.endpoint(ctx, info.EndpointID)
+	if err != nil {
+		slog.Debug("skipped reconnect notification", "endpoint_id", info.EndpointID, "error", err)
+		return
+	}
+	info.EndpointName = endpoint.Name
+	if err := n.NotifyEndpointReconnected(ctx, info); err != nil {
+		slog.Warn("failed to send reconnect notification", "endpoint_id", info.EndpointID, "error", err)
+	}
+}
@@ -2,12 +2,20 @@ package relay
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"runtime/debug"
+	"sync"
 	"time"
 
 	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/clock"
 	"hooks.dx314.com/internal/db"
+	"hooks.dx314.com/internal/tracing"
+	"hooks.dx314.com/internal/webhook"
 
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
@@ -15,12 +23,21 @@ import (
 const (
 	dispatchInterval = 1 * time.Second
 	batchSize        = 100
+	// dispatchLease is how long a webhook sent at ingestion waits for its
+	// ack before the dispatcher sends it again.
+	dispatchLease = 30 * time.Second
 )
 
 // Dispatcher watches for pending webhooks and sends them to the appropriate home-hub.
 type Dispatcher struct {
 	queries *db.Queries
 	manager *ConnectionManager
+	clock   clock.Clock
+	tracer  *tracing.Tracer
+
+	mu      sync.Mutex
+	lastRun time.Time // See LastRun
+	lastErr error
 }
 
 // NewDispatcher creates a new webhook dispatcher.
@@ -28,28 +45,66 @@ func NewDispatcher(queries *db.Queries, manager *ConnectionManager) *Dispatcher
 	return &Dispatcher{
 		queries: queries,
 		manager: manager,
+		clock:   clock.Real,
 	}
 }
 
+// SetClock sets the clock that paces dispatch. It must be called before Run.
+func (d *Dispatcher) SetClock(c clock.Clock) {
+	d.clock = c
+}
+
+// SetTracer exports dispatch spans with t. It must be called before Run.
+func (d *Dispatcher) SetTracer(t *tracing.Tracer) {
+	d.tracer = t
+}
+
 // Run starts the dispatcher loop. Blocks until context is cancelled.
 func (d *Dispatcher) Run(ctx context.Context) error {
-	ticker := time.NewTicker(dispatchInterval)
+	ticker := d.clock.NewTicker(dispatchInterval)
 	defer ticker.Stop()
+	d.mu.Lock()
+	d.lastRun = d.clock.Now()
+	d.mu.Unlock()
 
 	for {
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
-		case <-ticker.C:
+		case <-ticker.C():
+			var err error
 			if d.manager.IsAnyConnected() {
-				if err := d.dispatch(ctx); err != nil {
+				if err = d.dispatchOnce(ctx); err != nil {
 					slog.Error("dispatch error", "error", err)
 				}
 			}
+			d.mu.Lock()
+			d.lastRun, d.lastErr = d.clock.Now(), err
+			d.mu.Unlock()
 		}
 	}
 }
 
+// LastRun returns when the dispatcher loop last ran, or started if it hasn't
+// yet, zero if Run wasn't called, and the error of that run's dispatch.
+func (d *Dispatcher) LastRun() (time.Time, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.lastRun, d.lastErr
+}
+
+// dispatchOnce runs one dispatch, turning a panic into an error so the
+// dispatcher keeps running.
+func (d *Dispatcher) dispatchOnce(ctx context.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("panic in dispatcher", "panic", r, "stack", string(debug.Stack()))
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return d.dispatch(ctx)
+}
+
 func (d *Dispatcher) dispatch(ctx context.Context) error {
 	// Get pending webhooks
 	webhooks, err := d.queries.GetPendingWebhooks(ctx, batchSize)
@@ -60,49 +115,167 @@ func (d *Dispatcher) dispatch(ctx context.Context) error {
 	for _, wh := range webhooks {
 		// Look up which hub handles this endpoint
 		conn := d.manager.GetHubForEndpoint(wh.EndpointID)
-		if conn == nil {
-			// No hub registered for this endpoint, skip
+		if conn == nil || conn.Paused() {
+			// No hub registered for this endpoint, or it paused deliveries
 			continue
 		}
+		d.send(ctx, conn, wh)
+	}
 
-		// Parse headers JSON
-		var headers map[string]string
-		if err := json.Unmarshal([]byte(wh.Headers), &headers); err != nil {
-			slog.Warn("failed to parse headers", "webhook_id", wh.ID, "error", err)
-			headers = make(map[string]string)
-		}
+	return nil
+}
 
-		// Parse received_at timestamp
-		receivedAt, err := time.Parse("2006-01-02 15:04:05", wh.ReceivedAt)
-		if err != nil {
-			receivedAt = time.Now()
+// DispatchNow sends a webhook just stored by the ingestion handler to its hub
+// right away, instead of at the next dispatch. It does nothing unless the
+// hub is connected and the webhook is the next to deliver for its endpoint,
+// which keeps delivery in order. The webhook is marked dispatched so the
+// dispatcher doesn't send it again while its ack is awaited.
+func (d *Dispatcher) DispatchNow(ctx context.Context, endpointID, webhookID string) {
+	conn := d.manager.GetHubForEndpoint(endpointID)
+	if conn == nil || conn.Paused() {
+		return
+	}
+	wh, err := d.queries.GetDispatchableWebhook(ctx, webhookID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			slog.Error("failed to get webhook for dispatch", "webhook_id", webhookID, "error", err)
 		}
+		return
+	}
+	// Marked before sending, so a fast ack's retry delay isn't overwritten
+	if err := d.markDispatched(ctx, webhookID, dispatchLease); err != nil {
+		slog.Error("failed to mark webhook dispatched", "webhook_id", webhookID, "error", err)
+		return
+	}
+	if !d.send(ctx, conn, db.GetPendingWebhooksRow(wh)) {
+		// Left to the dispatcher
+		if err := d.markDispatched(ctx, webhookID, 0); err != nil {
+			slog.Error("failed to release webhook for dispatch", "webhook_id", webhookID, "error", err)
+		}
+	}
+}
+
+func (d *Dispatcher) markDispatched(ctx context.Context, webhookID string, lease time.Duration) error {
+	return d.queries.MarkWebhookDispatched(ctx, db.MarkWebhookDispatchedParams{
+		LeaseSeconds: int64(lease / time.Second),
+		ID:           webhookID,
+	})
+}
 
-		envelope := &hooklyv1.WebhookEnvelope{
-			Id:             wh.ID,
-			EndpointId:     wh.EndpointID,
-			DestinationUrl: wh.DestinationUrl,
-			ReceivedAt:     timestamppb.New(receivedAt),
-			Headers:        headers,
-			Payload:        wh.Payload,
-			Attempt:        int32(wh.Attempts) + 1,
+// send queues a pending webhook for delivery by conn, or marks it skipped if
+// the hub doesn't want its event type. It returns whether it was queued.
+func (d *Dispatcher) send(ctx context.Context, conn *HubConnection, wh db.GetPendingWebhooksRow) bool {
+	// Event types the hub didn't subscribe to stay on the edge as skipped
+	if !conn.WantsEventType(wh.EndpointID, wh.EventType.String) {
+		if err := d.queries.MarkWebhookSkipped(ctx, wh.ID); err != nil {
+			slog.Error("failed to mark webhook skipped", "webhook_id", wh.ID, "error", err)
+			return false
 		}
+		slog.Debug("skipped webhook not wanted by hub",
+			"webhook_id", wh.ID,
+			"endpoint_id", wh.EndpointID,
+			"event_type", wh.EventType.String,
+			"hub_id", conn.HubID(),
+		)
+		return false
+	}
 
-		if !conn.Send(envelope) {
-			slog.Warn("failed to queue webhook for delivery",
-				"webhook_id", wh.ID,
-				"hub_id", conn.HubID(),
-			)
-			continue
+	// Parse headers JSON
+	var headers map[string]string
+	if err := json.Unmarshal([]byte(wh.Headers), &headers); err != nil {
+		slog.Warn("failed to parse headers", "webhook_id", wh.ID, "error", err)
+		headers = make(map[string]string)
+	}
+
+	// Parse received_at timestamp
+	receivedAt, err := db.ParseTime(wh.ReceivedAt)
+	if err != nil {
+		slog.Warn("webhook has an invalid received_at", "webhook_id", wh.ID, "error", err)
+		receivedAt = d.clock.Now()
+	}
+
+	envelope := &hooklyv1.WebhookEnvelope{
+		Id:             wh.ID,
+		EndpointId:     wh.EndpointID,
+		DestinationUrl: wh.DestinationUrl,
+		ReceivedAt:     timestamppb.New(receivedAt),
+		Headers:        headers,
+		Payload:        wh.Payload,
+		Attempt:        int32(wh.Attempts) + 1,
+	}
+	if wh.Transform.Valid {
+		var t webhook.Transform
+		if err := json.Unmarshal([]byte(wh.Transform.String), &t); err != nil {
+			slog.Warn("failed to parse transform", "webhook_id", wh.ID, "error", err)
+		} else {
+			envelope.Transform = &hooklyv1.Transform{Extract: t.Extract, Template: t.Template, Headers: t.Headers}
 		}
+	}
+	destinations, fansOut, err := d.fanOut(ctx, wh.ID, wh.EndpointID, wh.DestinationUrl)
+	if err != nil {
+		slog.Error("failed to list destinations", "webhook_id", wh.ID, "error", err)
+		return false
+	}
+	if fansOut && len(destinations) == 0 {
+		// Every destination is done; the ack being handled settles the webhook
+		return false
+	}
+	envelope.Destinations = destinations
 
-		slog.Debug("queued webhook for delivery",
+	// A child of the ingestion span, or a new trace for webhooks stored
+	// before tracing
+	span := d.tracer.StartFromTraceparent("webhook.dispatch", tracing.KindProducer, wh.Traceparent.String)
+	span.SetAttribute("hookly.webhook_id", wh.ID)
+	span.SetAttribute("hookly.hub_id", conn.HubID())
+	span.SetAttribute("hookly.attempt", int(envelope.Attempt))
+	envelope.Traceparent = span.Traceparent()
+	queued := conn.Send(envelope)
+	if !queued {
+		span.SetError(errors.New("hub webhook buffer is full"))
+	}
+	span.End()
+	if !queued {
+		slog.Warn("failed to queue webhook for delivery",
 			"webhook_id", wh.ID,
-			"endpoint_id", wh.EndpointID,
 			"hub_id", conn.HubID(),
-			"attempt", envelope.Attempt,
 		)
+		return false
 	}
 
-	return nil
+	slog.Debug("queued webhook for delivery",
+		"webhook_id", wh.ID,
+		"endpoint_id", wh.EndpointID,
+		"hub_id", conn.HubID(),
+		"attempt", envelope.Attempt,
+	)
+	return true
+}
+
+// fanOut returns the destinations a webhook still has to be delivered to, and
+// false if its endpoint has no additional destinations.
+func (d *Dispatcher) fanOut(ctx context.Context, webhookID, endpointID, destinationURL string) ([]*hooklyv1.Destination, bool, error) {
+	extra, err := d.queries.ListEndpointDestinations(ctx, endpointID)
+	if err != nil || len(extra) == 0 {
+		return nil, false, err
+	}
+	deliveries, err := d.queries.ListWebhookDeliveries(ctx, webhookID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// Destinations that delivered or failed permanently are done
+	done := make(map[string]bool, len(deliveries))
+	for _, del := range deliveries {
+		done[del.DestinationID] = del.Status != "pending"
+	}
+	var destinations []*hooklyv1.Destination
+	if !done[""] {
+		destinations = append(destinations, &hooklyv1.Destination{Url: destinationURL})
+	}
+	for _, dst := range extra {
+		if !done[dst.ID] {
+			destinations = append(destinations, &hooklyv1.Destination{Id: dst.ID, Url: dst.Url})
+		}
+	}
+	return destinations, true, nil
 }
@@ -4,29 +4,68 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"sync"
 	"time"
 
 	"connectrpc.com/connect"
 
 	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
 	"hooks.dx314.com/internal/auth"
+	"hooks.dx314.com/internal/clock"
 	"hooks.dx314.com/internal/db"
+	"hooks.dx314.com/internal/jobs"
+	"hooks.dx314.com/internal/logging"
+	"hooks.dx314.com/internal/metrics"
 	"hooks.dx314.com/internal/notify"
+	"hooks.dx314.com/internal/tracing"
+	"hooks.dx314.com/internal/webhook"
 )
 
 const (
-	heartbeatInterval = 15 * time.Second
-	staleTimeout      = 60 * time.Second
+	// Keepalive defaults, see SetKeepalive
+	defaultHeartbeatInterval = 15 * time.Second
+	defaultStaleTimeout      = 60 * time.Second
+	// retryAfterHint is the backoff hint sent to clients when a connect fails
+	// for a transient server-side reason.
+	retryAfterHint = 30 * time.Second
+
+	// JobFailureNotification is the job kind that notifies about a
+	// permanently failed delivery.
+	JobFailureNotification = "failure_notification"
 )
 
 // Handler implements the RelayService.
 type Handler struct {
 	tokenMgr *auth.TokenManager
+	orgs     *auth.OrgResolver
 	manager  *ConnectionManager
 	queries  *db.Queries
 	notifier notify.Notifier
+	jobs     *jobs.Queue
+	metrics  *metrics.Metrics
+	tracer   *tracing.Tracer
+	clock    clock.Clock
+	jitter   func() float64 // Picks retry jitter, from 0 to 1
+
+	endpoints *db.EndpointCache // Endpoints checked on connect; nil reads the database
+
+	heartbeatInterval time.Duration // How often the edge sends heartbeats
+	staleTimeout      time.Duration // Silence after which a hub is dropped
+
+	tunnelNets []*net.IPNet // Networks tunnel addresses may be in; nil disables tunnels
+	tunnelsMu  sync.Mutex
+	tunnels    map[string]*edgeTunnel // hubID → active tunnel
+
+	reportedMu sync.Mutex
+	reported   map[string]time.Time // endpointID → disconnect notified of, until a hub connects
 }
 
 // NewHandler creates a new relay handler.
@@ -34,12 +73,103 @@ func NewHandler(tokenMgr *auth.TokenManager, manager *ConnectionManager, queries
 	if notifier == nil {
 		notifier = notify.NopNotifier{}
 	}
-	return &Handler{
+	h := &Handler{
 		tokenMgr: tokenMgr,
+		orgs:     auth.NewOrgResolver(queries),
 		manager:  manager,
 		queries:  queries,
 		notifier: notifier,
+		clock:    clock.Real,
+		jitter:   rand.Float64,
+		tunnels:  make(map[string]*edgeTunnel),
+		reported: make(map[string]time.Time),
+
+		heartbeatInterval: defaultHeartbeatInterval,
+		staleTimeout:      defaultStaleTimeout,
+	}
+	manager.SetEventHandler(h.handleConnectionEvent)
+	return h
+}
+
+// SetClock sets the clock that paces heartbeats and times deliveries. It
+// must be called before serving streams.
+func (h *Handler) SetClock(c clock.Clock) {
+	h.clock = c
+}
+
+// SetJitter sets the source of retry jitter, which returns values from 0 to
+// 1. The default is random.
+func (h *Handler) SetJitter(fn func() float64) {
+	h.jitter = fn
+}
+
+// SetKeepalive overrides the heartbeat interval and the stale timeout after
+// which a silent hub is dropped. Zero values keep the defaults.
+func (h *Handler) SetKeepalive(heartbeat, stale time.Duration) {
+	if heartbeat > 0 {
+		h.heartbeatInterval = heartbeat
 	}
+	if stale > 0 {
+		h.staleTimeout = stale
+	}
+}
+
+// SetMetrics records ACK outcomes and delivery latency in m.
+func (h *Handler) SetMetrics(m *metrics.Metrics) {
+	h.metrics = m
+}
+
+// SetTracer exports stream send and ACK spans with t.
+func (h *Handler) SetTracer(t *tracing.Tracer) {
+	h.tracer = t
+}
+
+// SetEndpointCache checks the endpoints of connecting hubs through c.
+func (h *Handler) SetEndpointCache(c *db.EndpointCache) {
+	h.endpoints = c
+}
+
+// SetJobQueue sends failure notifications through the job queue instead of a
+// goroutine, so they are retried and not lost on shutdown.
+func (h *Handler) SetJobQueue(q *jobs.Queue) {
+	h.jobs = q
+	q.Register(JobFailureNotification, func(ctx context.Context, payload []byte) error {
+		job, err := jobs.Decode[failureNotificationJob](payload)
+		if err != nil {
+			return err
+		}
+		return h.sendFailureNotification(ctx, job.WebhookID, job.ErrorMessage)
+	})
+}
+
+type failureNotificationJob struct {
+	WebhookID    string `json:"webhook_id"`
+	ErrorMessage string `json:"error_message"`
+}
+
+// AnnounceMaintenance tells every hub connected over the stream that the
+// edge is about to restart and closes their streams. Hubs reconnect after
+// reconnectAfter, to reconnectURL if set. It returns the number of hubs told,
+// once their streams are closed or ctx is done.
+func (h *Handler) AnnounceMaintenance(ctx context.Context, reconnectAfter time.Duration, reconnectURL, reason string) int {
+	announced := h.manager.AnnounceMaintenance(&hooklyv1.Maintenance{
+		ReconnectAfterSeconds: int32(reconnectAfter / time.Second),
+		ReconnectUrl:          reconnectURL,
+		Reason:                reason,
+	})
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+	for _, conn := range announced {
+		for h.manager.connection(conn.hubID) == conn {
+			select {
+			case <-ctx.Done():
+				return len(announced)
+			case <-ticker.C:
+			}
+		}
+	}
+	return len(announced)
 }
 
 // Stream handles the bidirectional streaming connection from home-hub.
@@ -55,41 +185,12 @@ func (h *Handler) Stream(ctx context.Context, stream *connect.BidiStream[hooklyv
 		return connect.NewError(connect.CodeUnauthenticated, errors.New("first message must be connect request"))
 	}
 
-	// Validate bearer token
-	if connectReq.Token == "" {
-		return h.sendConnectError(stream, connect.CodeUnauthenticated, "TOKEN_MISSING", "no token provided - run 'hookly login' first")
-	}
-
-	token, err := h.tokenMgr.ValidateToken(ctx, connectReq.Token)
-	if err != nil {
-		slog.Warn("relay auth failed", "hub_id", connectReq.HubId, "error", err)
-		if errors.Is(err, auth.ErrTokenNotFound) || errors.Is(err, auth.ErrInvalidToken) {
-			return h.sendConnectError(stream, connect.CodeUnauthenticated, "TOKEN_INVALID", "invalid token - run 'hookly login' to re-authenticate")
-		}
-		if errors.Is(err, auth.ErrTokenRevoked) {
-			return h.sendConnectError(stream, connect.CodeUnauthenticated, "TOKEN_REVOKED", "token has been revoked - run 'hookly login' to re-authenticate")
-		}
-		return h.sendConnectError(stream, connect.CodeUnauthenticated, "AUTH_FAILED", "authentication failed")
-	}
-
-	// Verify user owns the requested endpoints
-	endpointIDs := connectReq.EndpointIds
-	if len(endpointIDs) == 0 {
-		return h.sendConnectError(stream, connect.CodeInvalidArgument, "NO_ENDPOINTS", "no endpoints specified in hookly.yaml")
-	}
-
-	for _, epID := range endpointIDs {
-		ep, err := h.queries.GetEndpointByID(ctx, epID)
-		if err != nil {
-			slog.Warn("endpoint not found", "endpoint_id", epID, "user_id", token.UserID)
-			return h.sendConnectError(stream, connect.CodeNotFound, "ENDPOINT_NOT_FOUND",
-				"endpoint '"+epID+"' does not exist - check your hookly.yaml or run 'hookly init' to reconfigure")
-		}
-		if ep.UserID != token.UserID {
-			slog.Warn("endpoint ownership mismatch", "endpoint_id", epID, "user_id", token.UserID, "owner", ep.UserID)
-			return h.sendConnectError(stream, connect.CodePermissionDenied, "ENDPOINT_ACCESS_DENIED",
-				"you don't have access to endpoint '"+epID+"' - it belongs to another user")
+	userID, eventTypes, cerr := h.authorize(ctx, connectReq)
+	if cerr != nil {
+		if cerr.retryAfter > 0 {
+			return h.sendRetryableConnectError(stream, cerr.code, cerr.errorCode, cerr.message, cerr.retryAfter)
 		}
+		return h.sendConnectError(stream, cerr.code, cerr.errorCode, cerr.message)
 	}
 
 	// Send success response
@@ -106,16 +207,25 @@ func (h *Handler) Stream(ctx context.Context, stream *connect.BidiStream[hooklyv
 	hubID := connectReq.HubId
 
 	// Register connection with endpoints
-	conn := h.manager.AddConnection(hubID, endpointIDs)
-	defer h.manager.RemoveConnection(hubID)
+	conn := h.manager.AddConnection(hubID, userID, connectReq.EndpointIds, eventTypes)
+	conn.paused.Store(connectReq.Paused)
+	defer h.manager.removeIfCurrent(conn)
+	defer conn.commands.close()
 
 	// Create channels for coordination
 	errCh := make(chan error, 2)
 	doneCh := make(chan struct{})
 	defer close(doneCh)
 
-	// Start receiver goroutine (handles ACKs and heartbeats from home-hub)
+	// Start receiver goroutine (handles ACKs and heartbeats from home-hub).
+	// A panic closes this hub's stream instead of crashing the edge.
 	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				slog.Error("panic in hub stream receiver, closing stream", "hub_id", hubID, "panic", r, "stack", string(debug.Stack()))
+				errCh <- connect.NewError(connect.CodeInternal, errors.New("internal error"))
+			}
+		}()
 		for {
 			select {
 			case <-doneCh:
@@ -135,19 +245,21 @@ func (h *Handler) Stream(ctx context.Context, stream *connect.BidiStream[hooklyv
 
 			switch m := msg.Message.(type) {
 			case *hooklyv1.StreamRequest_Ack:
-				h.handleAck(ctx, m.Ack)
+				h.processAck(ctx, userID, m.Ack)
 			case *hooklyv1.StreamRequest_Heartbeat:
 				h.manager.UpdateHeartbeat(hubID)
+			case *hooklyv1.StreamRequest_CommandResult:
+				conn.commands.resolve(m.CommandResult)
 			}
 		}
 	}()
 
 	// Start heartbeat sender
-	heartbeatTicker := time.NewTicker(heartbeatInterval)
+	heartbeatTicker := h.clock.NewTicker(h.heartbeatInterval)
 	defer heartbeatTicker.Stop()
 
 	// Start stale connection checker
-	staleTicker := time.NewTicker(10 * time.Second)
+	staleTicker := h.clock.NewTicker(10 * time.Second)
 	defer staleTicker.Stop()
 
 	// Main loop: send webhooks and heartbeats
@@ -161,27 +273,51 @@ func (h *Handler) Stream(ctx context.Context, stream *connect.BidiStream[hooklyv
 			return err
 
 		case webhook := <-sendCh:
+			// The hub's delivery spans are children of the send
+			span := h.tracer.StartFromTraceparent("relay.send", tracing.KindProducer, webhook.Traceparent)
+			span.SetAttribute("hookly.webhook_id", webhook.Id)
+			span.SetAttribute("hookly.hub_id", hubID)
+			webhook.Traceparent = span.Traceparent()
+			// Large payloads are split into chunks sent right after the envelope
+			for _, msg := range streamMessages(webhook) {
+				if err := stream.Send(msg); err != nil {
+					span.SetError(err)
+					span.End()
+					return err
+				}
+			}
+			span.End()
+
+		case cmd := <-conn.commands.ch:
 			if err := stream.Send(&hooklyv1.StreamResponse{
-				Message: &hooklyv1.StreamResponse_Webhook{
-					Webhook: webhook,
-				},
+				Message: &hooklyv1.StreamResponse_Command{Command: cmd},
+			}); err != nil {
+				return err
+			}
+
+		case notice := <-conn.MaintenanceCh():
+			// Close cleanly so the hub reconnects as told instead of backing off
+			if err := stream.Send(&hooklyv1.StreamResponse{
+				Message: &hooklyv1.StreamResponse_Maintenance{Maintenance: notice},
 			}); err != nil {
 				return err
 			}
+			slog.Info("announced maintenance to hub", "hub_id", hubID, "reconnect_url", notice.ReconnectUrl)
+			return nil
 
-		case <-heartbeatTicker.C:
+		case <-heartbeatTicker.C():
 			if err := stream.Send(&hooklyv1.StreamResponse{
 				Message: &hooklyv1.StreamResponse_Heartbeat{
 					Heartbeat: &hooklyv1.Heartbeat{
-						Timestamp: time.Now().Unix(),
+						Timestamp: h.clock.Now().Unix(),
 					},
 				},
 			}); err != nil {
 				return err
 			}
 
-		case <-staleTicker.C:
-			if h.manager.IsStale(hubID, staleTimeout) {
+		case <-staleTicker.C():
+			if h.manager.IsStale(hubID, h.staleTimeout) {
 				slog.Warn("connection stale, closing", "hub_id", hubID)
 				return connect.NewError(connect.CodeDeadlineExceeded, errors.New("connection stale"))
 			}
@@ -189,17 +325,164 @@ func (h *Handler) Stream(ctx context.Context, stream *connect.BidiStream[hooklyv
 	}
 }
 
-func (h *Handler) handleAck(ctx context.Context, ack *hooklyv1.DeliveryAck) {
+// connectError is a rejected connect request. errorCode is a short
+// machine-readable code, message is human-readable.
+type connectError struct {
+	code       connect.Code
+	errorCode  string
+	message    string
+	retryAfter time.Duration // Backoff hint for transient failures, 0 if none
+}
+
+func (e *connectError) err() error {
+	return connect.NewError(e.code, errors.New(e.errorCode+": "+e.message))
+}
+
+// authorize validates a hub's token and checks that the token's user owns the
+// requested endpoints, or is an owner or member of the orgs owning them. It
+// returns the user ID and the event type filters by endpoint.
+func (h *Handler) authorize(ctx context.Context, req *hooklyv1.ConnectRequest) (string, map[string][]string, *connectError) {
+	// Validate bearer token
+	if req.Token == "" {
+		return "", nil, &connectError{code: connect.CodeUnauthenticated, errorCode: "TOKEN_MISSING", message: "no token provided - run 'hookly login' first"}
+	}
+
+	token, err := h.tokenMgr.ValidateToken(ctx, req.Token)
+	if err != nil {
+		slog.Warn("relay auth failed", "hub_id", req.HubId, "error", err)
+		if errors.Is(err, auth.ErrTokenNotFound) || errors.Is(err, auth.ErrInvalidToken) {
+			return "", nil, &connectError{code: connect.CodeUnauthenticated, errorCode: "TOKEN_INVALID", message: "invalid token - run 'hookly login' to re-authenticate"}
+		}
+		if errors.Is(err, auth.ErrTokenRevoked) {
+			return "", nil, &connectError{code: connect.CodeUnauthenticated, errorCode: "TOKEN_REVOKED", message: "token has been revoked - run 'hookly login' to re-authenticate"}
+		}
+		if errors.Is(err, auth.ErrTokenExpired) {
+			return "", nil, &connectError{code: connect.CodeUnauthenticated, errorCode: "TOKEN_EXPIRED", message: "token has expired - run 'hookly login' or create a new token with 'hookly token create'"}
+		}
+		return "", nil, &connectError{code: connect.CodeUnavailable, errorCode: "AUTH_FAILED", message: "authentication failed", retryAfter: retryAfterHint}
+	}
+
+	if !auth.ScopeAllowsRelay(token.Scope) {
+		slog.Warn("relay auth failed", "hub_id", req.HubId, "token_id", token.ID, "scope", token.Scope)
+		return "", nil, &connectError{code: connect.CodePermissionDenied, errorCode: "TOKEN_SCOPE", message: "a " + token.Scope + " token can't connect a hub - use a relay or admin token"}
+	}
+
+	// Verify user owns the requested endpoints
+	endpointIDs := req.EndpointIds
+	if len(endpointIDs) == 0 {
+		return "", nil, &connectError{code: connect.CodeInvalidArgument, errorCode: "NO_ENDPOINTS", message: "no endpoints specified in hookly.yaml"}
+	}
+
+	for _, epID := range endpointIDs {
+		ep, err := h.endpoint(ctx, epID)
+		if err != nil {
+			slog.Warn("endpoint not found", "endpoint_id", epID, "user_id", token.UserID)
+			return "", nil, &connectError{code: connect.CodeNotFound, errorCode: "ENDPOINT_NOT_FOUND",
+				message: "endpoint '" + epID + "' does not exist - check your hookly.yaml or run 'hookly init' to reconfigure"}
+		}
+		if cerr := h.authorizeEndpoint(ctx, token.UserID, ep); cerr != nil {
+			return "", nil, cerr
+		}
+	}
+
+	// Event type filters only apply to endpoints the hub handles
+	eventTypes := make(map[string][]string)
+	for _, f := range req.EventFilters {
+		if !slices.Contains(endpointIDs, f.EndpointId) {
+			return "", nil, &connectError{code: connect.CodeInvalidArgument, errorCode: "INVALID_EVENT_FILTER",
+				message: "event_types set for endpoint '" + f.EndpointId + "' which is not listed in hookly.yaml"}
+		}
+		if len(f.EventTypes) > 0 {
+			eventTypes[f.EndpointId] = f.EventTypes
+		}
+	}
+
+	return token.UserID, eventTypes, nil
+}
+
+// authorizeEndpoint checks that a user may relay an endpoint's webhooks:
+// the user owns it, or it belongs to an org where the user isn't a viewer.
+func (h *Handler) authorizeEndpoint(ctx context.Context, userID string, ep db.GetEndpointByIDRow) *connectError {
+	if ep.UserID == userID {
+		return nil
+	}
+	if orgID, ok := auth.OrgFromOwnerID(ep.UserID); ok {
+		role, err := h.orgs.Role(ctx, userID, orgID)
+		if err == nil && auth.RoleCanWrite(role) {
+			return nil
+		}
+		if err != nil && !errors.Is(err, auth.ErrNotOrgMember) {
+			slog.Error("failed to look up org membership", "org_id", orgID, "user_id", userID, "error", err)
+			return &connectError{code: connect.CodeUnavailable, errorCode: "AUTH_FAILED", message: "authentication failed", retryAfter: retryAfterHint}
+		}
+		if err == nil {
+			slog.Warn("org viewer tried to relay endpoint", "endpoint_id", ep.ID, "user_id", userID, "org_id", orgID)
+			return &connectError{code: connect.CodePermissionDenied, errorCode: "ENDPOINT_ACCESS_DENIED",
+				message: "you can't relay endpoint '" + ep.ID + "' - org viewers have read-only access"}
+		}
+	}
+	slog.Warn("endpoint ownership mismatch", "endpoint_id", ep.ID, "user_id", userID, "owner", ep.UserID)
+	return &connectError{code: connect.CodePermissionDenied, errorCode: "ENDPOINT_ACCESS_DENIED",
+		message: "you don't have access to endpoint '" + ep.ID + "' - it belongs to another user"}
+}
+
+// endpoint looks an endpoint up, through the cache if there is one.
+func (h *Handler) endpoint(ctx context.Context, id string) (db.GetEndpointByIDRow, error) {
+	if h.endpoints != nil {
+		return h.endpoints.Get(ctx, id)
+	}
+	return h.queries.GetEndpointByID(ctx, id)
+}
+
+// processAck handles an ack, recovering from a panic so one bad webhook
+// doesn't close the hub's stream.
+func (h *Handler) processAck(ctx context.Context, userID string, ack *hooklyv1.DeliveryAck) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("panic handling ack", "webhook_id", ack.GetWebhookId(), "panic", r, "stack", string(debug.Stack()))
+		}
+	}()
+	h.handleAck(ctx, userID, ack)
+}
+
+func (h *Handler) handleAck(ctx context.Context, userID string, ack *hooklyv1.DeliveryAck) {
 	slog.Info("received delivery ack",
+		logging.SampleBy("user_id", userID),
 		"webhook_id", ack.WebhookId,
 		"success", ack.Success,
 		"status_code", ack.StatusCode,
 	)
 
+	// Older hubs send no context, and a span of its own would only start a
+	// trace with nothing in it
+	var span *tracing.Span
+	if ack.Traceparent != "" {
+		span = h.tracer.StartFromTraceparent("webhook.ack", tracing.KindConsumer, ack.Traceparent)
+		span.SetAttribute("hookly.webhook_id", ack.WebhookId)
+		span.SetAttribute("hookly.success", ack.Success)
+		span.SetAttribute("http.response.status_code", int(ack.StatusCode))
+		defer span.End()
+	}
+
+	if len(ack.Destinations) > 0 {
+		if err := h.settleFanOut(ctx, ack); err != nil {
+			span.SetError(err)
+			slog.Error("failed to record destination results", "webhook_id", ack.WebhookId, "error", err)
+			return
+		}
+	} else if !ack.Success && ack.StatusCode == http.StatusConflict && h.ackDuplicate(ctx, userID, ack) {
+		return
+	}
+
 	var err error
 	if ack.Success {
 		// Successfully delivered
-		_, err = h.queries.MarkWebhookDelivered(ctx, ack.WebhookId)
+		var wh db.Webhook
+		wh, err = h.queries.MarkWebhookDelivered(ctx, ack.WebhookId)
+		if err == nil {
+			h.recordDeliveryActivity(ctx, userID, wh.EndpointID)
+			h.metrics.Ack(metrics.AckDelivered, h.deliveryLatency(wh))
+		}
 	} else if ack.PermanentFailure {
 		// Permanent failure (4xx) - stop retrying
 		_, err = h.queries.MarkWebhookFailed(ctx, db.MarkWebhookFailedParams{
@@ -207,41 +490,183 @@ func (h *Handler) handleAck(ctx context.Context, ack *hooklyv1.DeliveryAck) {
 			ID:           ack.WebhookId,
 		})
 		if err == nil {
-			// Send failure notification (fire and forget)
-			go h.sendFailureNotification(ctx, ack.WebhookId, ack.ErrorMessage)
+			h.metrics.Ack(metrics.AckFailed, 0)
+			h.queueFailureNotification(ctx, ack.WebhookId, ack.ErrorMessage)
 		}
 	} else {
-		// Transient failure (5xx or network error) - stay pending for retry
-		_, err = h.queries.RecordWebhookAttempt(ctx, db.RecordWebhookAttemptParams{
-			ErrorMessage: stringToNullString(ack.ErrorMessage),
+		// Transient failure (5xx or network error) - stay pending for retry,
+		// unless the endpoint's retry policy is out of attempts
+		err = h.retryOrFail(ctx, ack)
+	}
+
+	if webhook.IsInvalidTransition(err) {
+		// A late ack for a webhook that was dead-lettered or cancelled meanwhile
+		slog.Warn("ignoring ack for webhook that is no longer pending", "webhook_id", ack.WebhookId)
+	} else if err != nil {
+		span.SetError(err)
+		slog.Error("failed to update webhook status", "webhook_id", ack.WebhookId, "error", err)
+	}
+}
+
+// retryOrFail records a transient failure: the webhook is retried after the
+// backoff of its endpoint's retry policy, or failed if that was its last
+// attempt.
+func (h *Handler) retryOrFail(ctx context.Context, ack *hooklyv1.DeliveryAck) error {
+	row, err := h.queries.GetWebhookRetryPolicy(ctx, ack.WebhookId)
+	if err != nil {
+		return err
+	}
+	policy := webhook.RetryPolicy{
+		MaxAttempts: int(row.RetryMaxAttempts),
+		BackoffBase: time.Duration(row.RetryBackoffBaseSeconds) * time.Second,
+		MaxInterval: time.Duration(row.RetryMaxIntervalSeconds) * time.Second,
+		Jitter:      row.RetryJitter,
+	}
+
+	if attempts := int(row.Attempts) + 1; policy.Exhausted(attempts) {
+		message := fmt.Sprintf("gave up after %d attempts: %s", attempts, ack.ErrorMessage)
+		if _, err := h.queries.MarkWebhookFailed(ctx, db.MarkWebhookFailedParams{
+			ErrorMessage: stringToNullString(message),
 			ID:           ack.WebhookId,
-		})
-		slog.Info("webhook will be retried after backoff",
-			"webhook_id", ack.WebhookId,
-			"error", ack.ErrorMessage,
-		)
+		}); err != nil {
+			return err
+		}
+		slog.Info("webhook failed: out of retry attempts", "webhook_id", ack.WebhookId, "attempts", attempts, "error", ack.ErrorMessage)
+		h.metrics.Ack(metrics.AckFailed, 0)
+		h.queueFailureNotification(ctx, ack.WebhookId, message)
+		return nil
 	}
 
+	delay := policy.Delay(int(row.Attempts), h.jitter())
+	if _, err := h.queries.RecordWebhookAttempt(ctx, db.RecordWebhookAttemptParams{
+		RetryDelaySeconds: int64((delay + time.Second - 1) / time.Second),
+		ErrorMessage:      stringToNullString(ack.ErrorMessage),
+		ID:                ack.WebhookId,
+	}); err != nil {
+		return err
+	}
+	h.metrics.Ack(metrics.AckRetry, 0)
+	slog.Info("webhook will be retried after backoff",
+		"webhook_id", ack.WebhookId,
+		"retry_in", delay.Round(time.Second),
+		"error", ack.ErrorMessage,
+	)
+	return nil
+}
+
+// settleFanOut records the result for each destination of a fanned-out
+// webhook and sets the ack's outcome from all of the webhook's deliveries:
+// delivered once every destination delivered, retried while any destination
+// can still succeed, and failed otherwise.
+func (h *Handler) settleFanOut(ctx context.Context, ack *hooklyv1.DeliveryAck) error {
+	for _, r := range ack.Destinations {
+		status := "pending"
+		if r.Success {
+			status = "delivered"
+		} else if r.PermanentFailure {
+			status = "failed"
+		}
+		if err := h.queries.RecordWebhookDelivery(ctx, db.RecordWebhookDeliveryParams{
+			WebhookID:     ack.WebhookId,
+			DestinationID: r.DestinationId,
+			Status:        status,
+			StatusCode:    sql.NullInt64{Int64: int64(r.StatusCode), Valid: r.StatusCode != 0},
+			ErrorMessage:  stringToNullString(r.ErrorMessage),
+		}); err != nil {
+			return err
+		}
+	}
+
+	deliveries, err := h.queries.ListWebhookDeliveries(ctx, ack.WebhookId)
 	if err != nil {
-		slog.Error("failed to update webhook status", "webhook_id", ack.WebhookId, "error", err)
+		return err
+	}
+	var retrying, failed bool
+	for _, d := range deliveries {
+		retrying = retrying || d.Status == "pending"
+		if d.Status == "failed" && !failed {
+			failed = true
+			if ack.ErrorMessage == "" {
+				// Failed in an earlier attempt
+				ack.ErrorMessage = d.ErrorMessage.String
+			}
+		}
+	}
+	ack.Success = !retrying && !failed
+	ack.PermanentFailure = !retrying && failed
+	if ack.Success {
+		ack.ErrorMessage = ""
+	}
+	return nil
+}
+
+// ackDuplicate records a 409 Conflict from the destination as
+// acknowledged_duplicate, for endpoints that opted in: the destination
+// already processed the webhook, typically during a bulk replay. It returns
+// false if the ack should be handled like any other, which retries a 409.
+func (h *Handler) ackDuplicate(ctx context.Context, userID string, ack *hooklyv1.DeliveryAck) bool {
+	wh, err := h.queries.MarkWebhookAcknowledgedDuplicate(ctx, db.MarkWebhookAcknowledgedDuplicateParams{
+		ErrorMessage: stringToNullString(ack.ErrorMessage),
+		ID:           ack.WebhookId,
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return false
+	}
+	if err != nil {
+		slog.Error("failed to record acknowledged duplicate", "webhook_id", ack.WebhookId, "error", err)
+		return false
+	}
+	slog.Info("destination had already processed webhook", "webhook_id", ack.WebhookId)
+	h.recordDeliveryActivity(ctx, userID, wh.EndpointID)
+	h.metrics.Ack(metrics.AckDuplicate, 0)
+	return true
+}
+
+// deliveryLatency returns the time since the webhook was received, or since
+// its last replay so replays of old webhooks don't skew the histogram.
+func (h *Handler) deliveryLatency(wh db.Webhook) time.Duration {
+	queuedAt := wh.ReceivedAt
+	if wh.ReplayedAt.Valid {
+		queuedAt = wh.ReplayedAt.String
+	}
+	t, err := db.ParseTime(queuedAt)
+	if err != nil {
+		return 0
+	}
+	return h.clock.Now().Sub(t)
+}
+
+// queueFailureNotification sends a failure notification in the background.
+func (h *Handler) queueFailureNotification(ctx context.Context, webhookID, errorMsg string) {
+	if h.jobs == nil {
+		go h.sendFailureNotification(context.WithoutCancel(ctx), webhookID, errorMsg)
+		return
+	}
+	if err := h.jobs.Enqueue(ctx, JobFailureNotification, failureNotificationJob{
+		WebhookID:    webhookID,
+		ErrorMessage: errorMsg,
+	}); err != nil {
+		slog.Error("failed to enqueue failure notification", "webhook_id", webhookID, "error", err)
 	}
 }
 
-func (h *Handler) sendFailureNotification(ctx context.Context, webhookID, errorMsg string) {
+func (h *Handler) sendFailureNotification(ctx context.Context, webhookID, errorMsg string) error {
 	// Get webhook with endpoint info (system query, no user filter)
 	row, err := h.queries.GetWebhookWithEndpointByID(ctx, webhookID)
 	if err != nil {
 		slog.Error("failed to get webhook for notification", "webhook_id", webhookID, "error", err)
-		return
+		return err
 	}
 
 	// Check if already notified
 	if row.NotificationSent != 0 {
-		return
+		return nil
 	}
 
-	// Parse received_at time
-	receivedAt, _ := time.Parse("2006-01-02 15:04:05", row.ReceivedAt)
+	receivedAt, err := db.ParseTime(row.ReceivedAt)
+	if err != nil {
+		slog.Warn("webhook has an invalid received_at", "webhook_id", row.ID, "error", err)
+	}
 
 	info := notify.WebhookInfo{
 		ID:             row.ID,
@@ -254,14 +679,14 @@ func (h *Handler) sendFailureNotification(ctx context.Context, webhookID, errorM
 	}
 
 	if err := h.notifier.NotifyDeliveryFailure(ctx, info); err != nil {
-		// Log but don't fail - notification is best-effort
-		return
+		return err
 	}
 
 	// Mark as notified
 	if err := h.queries.MarkNotificationSent(ctx, webhookID); err != nil {
 		slog.Error("failed to mark notification sent", "webhook_id", webhookID, "error", err)
 	}
+	return nil
 }
 
 func stringToNullString(s string) sql.NullString {
@@ -284,3 +709,18 @@ func (h *Handler) sendConnectError(stream *connect.BidiStream[hooklyv1.StreamReq
 	})
 	return connect.NewError(code, errors.New(errorCode+": "+message))
 }
+
+// sendRetryableConnectError sends an error response with a backoff hint so the
+// client waits at least retryAfter before reconnecting.
+func (h *Handler) sendRetryableConnectError(stream *connect.BidiStream[hooklyv1.StreamRequest, hooklyv1.StreamResponse], code connect.Code, errorCode, message string, retryAfter time.Duration) error {
+	_ = stream.Send(&hooklyv1.StreamResponse{
+		Message: &hooklyv1.StreamResponse_ConnectResponse{
+			ConnectResponse: &hooklyv1.ConnectResponse{
+				Success:           false,
+				Error:             errorCode + ": " + message,
+				RetryAfterSeconds: int32(retryAfter / time.Second),
+			},
+		},
+	})
+	return connect.NewError(code, errors.New(errorCode+": "+message))
+}
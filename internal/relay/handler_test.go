@@ -0,0 +1,27 @@
+package relay
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"hooks.dx314.com/internal/clock"
+	"hooks.dx314.com/internal/db"
+)
+
+func TestDeliveryLatency(t *testing.T) {
+	received := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	clk := clock.NewFake(received.Add(90 * time.Second))
+	h := &Handler{clock: clk}
+
+	wh := db.Webhook{ReceivedAt: db.FormatTime(received)}
+	if got := h.deliveryLatency(wh); got != 90*time.Second {
+		t.Errorf("latency = %v, want 90s", got)
+	}
+
+	// Replays are timed from the replay
+	wh.ReplayedAt = sql.NullString{String: db.FormatTime(received.Add(time.Minute)), Valid: true}
+	if got := h.deliveryLatency(wh); got != 30*time.Second {
+		t.Errorf("replay latency = %v, want 30s", got)
+	}
+}
@@ -2,10 +2,14 @@ package relay
 
 import (
 	"log/slog"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/clock"
 )
 
 // ConnectionManager manages multiple home-hub connections with endpoint routing.
@@ -13,14 +17,46 @@ type ConnectionManager struct {
 	mu          sync.RWMutex
 	connections map[string]*HubConnection  // hubID → connection
 	endpoints   map[string]string          // endpointID → hubID (routing table)
+	onEvent     func(ConnectionEvent)
+	clock       clock.Clock
 }
 
+// Connection lifecycle event types, see SetEventHandler.
+const (
+	ConnectionEventConnected    = "connected"
+	ConnectionEventDisconnected = "disconnected"
+)
+
+// ConnectionEvent reports a hub connecting or disconnecting. A hub
+// reconnecting replaces its connection with only a connected event.
+type ConnectionEvent struct {
+	Type        string
+	HubID       string
+	UserID      string
+	Transport   string
+	EndpointIDs []string
+	At          time.Time
+}
+
+// Transports a hub can receive webhooks over.
+const (
+	TransportStream = "stream" // The Connect bidirectional stream
+	TransportTunnel = "tunnel" // Unary deliveries over a WireGuard or SSH tunnel, see tunnel.go
+)
+
 // HubConnection represents a single hub's connection state.
 type HubConnection struct {
 	hubID         string
+	userID        string // Owner of the hub's token
+	transport     string
 	endpointIDs   []string
+	eventTypes    map[string]map[string]struct{} // endpointID → wanted event types
+	connectedAt   time.Time
 	lastHeartbeat time.Time
 	sendCh        chan *hooklyv1.WebhookEnvelope
+	maintenanceCh chan *hooklyv1.Maintenance // Buffered; the stream sends it and closes
+	paused        atomic.Bool                // Deliveries paused by a hub command
+	commands      *hubCommands
 }
 
 // NewConnectionManager creates a new connection manager.
@@ -28,12 +64,58 @@ func NewConnectionManager() *ConnectionManager {
 	return &ConnectionManager{
 		connections: make(map[string]*HubConnection),
 		endpoints:   make(map[string]string),
+		clock:       clock.Real,
 	}
 }
 
+// SetClock sets the clock used for connection times and heartbeat
+// staleness. Must be called before any hub registers.
+func (m *ConnectionManager) SetClock(clk clock.Clock) {
+	m.clock = clk
+}
+
+// SetEventHandler sets a function called with every connection event. It is
+// called outside the manager's lock, on the goroutine that connected or
+// removed the hub, so it may query the manager.
+func (m *ConnectionManager) SetEventHandler(fn func(ConnectionEvent)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onEvent = fn
+}
+
+// emit calls the event handler, if any, for conn.
+func (m *ConnectionManager) emit(eventType string, conn *HubConnection, at time.Time) {
+	m.mu.RLock()
+	fn := m.onEvent
+	m.mu.RUnlock()
+	if fn == nil {
+		return
+	}
+	fn(ConnectionEvent{
+		Type:        eventType,
+		HubID:       conn.hubID,
+		UserID:      conn.userID,
+		Transport:   conn.transport,
+		EndpointIDs: conn.endpointIDs,
+		At:          at,
+	})
+}
+
 // AddConnection registers a new hub connection with its endpoints.
+// eventTypes optionally restricts the event types relayed for an endpoint;
+// endpoints without an entry receive all event types.
 // Returns the HubConnection for sending webhooks.
-func (m *ConnectionManager) AddConnection(hubID string, endpointIDs []string) *HubConnection {
+func (m *ConnectionManager) AddConnection(hubID, userID string, endpointIDs []string, eventTypes map[string][]string) *HubConnection {
+	return m.addConnection(hubID, userID, TransportStream, endpointIDs, eventTypes)
+}
+
+func (m *ConnectionManager) addConnection(hubID, userID, transport string, endpointIDs []string, eventTypes map[string][]string) *HubConnection {
+	conn := m.register(hubID, userID, transport, endpointIDs, eventTypes)
+	m.emit(ConnectionEventConnected, conn, conn.connectedAt)
+	return conn
+}
+
+func (m *ConnectionManager) register(hubID, userID, transport string, endpointIDs []string, eventTypes map[string][]string) *HubConnection {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -45,11 +127,18 @@ func (m *ConnectionManager) AddConnection(hubID string, endpointIDs []string) *H
 		close(old.sendCh)
 	}
 
+	now := m.clock.Now()
 	conn := &HubConnection{
 		hubID:         hubID,
+		userID:        userID,
+		transport:     transport,
 		endpointIDs:   endpointIDs,
-		lastHeartbeat: time.Now(),
+		eventTypes:    make(map[string]map[string]struct{}),
+		connectedAt:   now,
+		lastHeartbeat: now,
 		sendCh:        make(chan *hooklyv1.WebhookEnvelope, 1000),
+		maintenanceCh: make(chan *hooklyv1.Maintenance, 1),
+		commands:      newHubCommands(),
 	}
 
 	m.connections[hubID] = conn
@@ -59,9 +148,19 @@ func (m *ConnectionManager) AddConnection(hubID string, endpointIDs []string) *H
 		m.endpoints[epID] = hubID
 	}
 
+	for epID, types := range eventTypes {
+		wanted := make(map[string]struct{}, len(types))
+		for _, t := range types {
+			wanted[t] = struct{}{}
+		}
+		conn.eventTypes[epID] = wanted
+	}
+
 	slog.Info("hub connected",
 		"hub_id", hubID,
+		"transport", transport,
 		"endpoints", endpointIDs,
+		"event_filters", len(conn.eventTypes),
 		"total_hubs", len(m.connections),
 	)
 
@@ -71,11 +170,19 @@ func (m *ConnectionManager) AddConnection(hubID string, endpointIDs []string) *H
 // RemoveConnection removes a hub and its endpoint mappings.
 func (m *ConnectionManager) RemoveConnection(hubID string) {
 	m.mu.Lock()
-	defer m.mu.Unlock()
+	conn := m.removeLocked(hubID)
+	m.mu.Unlock()
+	if conn != nil {
+		m.emit(ConnectionEventDisconnected, conn, m.clock.Now())
+	}
+}
 
+// removeLocked removes a hub and returns its connection, or nil if it
+// wasn't connected.
+func (m *ConnectionManager) removeLocked(hubID string) *HubConnection {
 	conn, exists := m.connections[hubID]
 	if !exists {
-		return
+		return nil
 	}
 
 	// Remove endpoint mappings
@@ -89,6 +196,28 @@ func (m *ConnectionManager) RemoveConnection(hubID string) {
 		"hub_id", hubID,
 		"total_hubs", len(m.connections),
 	)
+	return conn
+}
+
+// removeIfCurrent removes a hub's connection unless it was already replaced by
+// a newer one.
+func (m *ConnectionManager) removeIfCurrent(conn *HubConnection) {
+	m.mu.Lock()
+	removed := m.connections[conn.hubID] == conn
+	if removed {
+		m.removeLocked(conn.hubID)
+	}
+	m.mu.Unlock()
+	if removed {
+		m.emit(ConnectionEventDisconnected, conn, m.clock.Now())
+	}
+}
+
+// connection returns a hub's current connection, or nil.
+func (m *ConnectionManager) connection(hubID string) *HubConnection {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.connections[hubID]
 }
 
 // GetHubForEndpoint returns the connection for the hub handling this endpoint.
@@ -112,6 +241,72 @@ func (m *ConnectionManager) IsAnyConnected() bool {
 	return len(m.connections) > 0
 }
 
+// HubCount returns the number of connected hubs.
+func (m *ConnectionManager) HubCount() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return len(m.connections)
+}
+
+// AnnounceMaintenance queues a maintenance announcement for every hub
+// connected over the stream and returns their connections. Tunnel hubs
+// reconnect their stream on their own once the edge is back.
+func (m *ConnectionManager) AnnounceMaintenance(notice *hooklyv1.Maintenance) []*HubConnection {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var announced []*HubConnection
+	for _, conn := range m.connections {
+		if conn.transport != TransportStream {
+			continue
+		}
+		select {
+		case conn.maintenanceCh <- notice:
+			announced = append(announced, conn)
+		default: // Already announced
+		}
+	}
+	return announced
+}
+
+// Hub returns a hub's current connection, or nil if it isn't connected.
+func (m *ConnectionManager) Hub(hubID string) *HubConnection {
+	return m.connection(hubID)
+}
+
+// HubInfo describes a connected hub.
+type HubInfo struct {
+	HubID         string
+	Transport     string
+	EndpointIDs   []string
+	ConnectedAt   time.Time
+	LastHeartbeat time.Time
+	Paused        bool
+}
+
+// Hubs returns the hubs connected with a token of userID, by hub ID.
+func (m *ConnectionManager) Hubs(userID string) []HubInfo {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var hubs []HubInfo
+	for _, conn := range m.connections {
+		if conn.userID != userID {
+			continue
+		}
+		hubs = append(hubs, HubInfo{
+			HubID:         conn.hubID,
+			Transport:     conn.transport,
+			EndpointIDs:   conn.endpointIDs,
+			ConnectedAt:   conn.connectedAt,
+			LastHeartbeat: conn.lastHeartbeat,
+			Paused:        conn.paused.Load(),
+		})
+	}
+	slices.SortFunc(hubs, func(a, b HubInfo) int { return strings.Compare(a.HubID, b.HubID) })
+	return hubs
+}
+
 // ConnectedEndpointIDs returns all endpoint IDs that have active relay connections.
 func (m *ConnectionManager) ConnectedEndpointIDs() []string {
 	m.mu.RLock()
@@ -130,7 +325,7 @@ func (m *ConnectionManager) UpdateHeartbeat(hubID string) {
 	defer m.mu.Unlock()
 
 	if conn, exists := m.connections[hubID]; exists {
-		conn.lastHeartbeat = time.Now()
+		conn.lastHeartbeat = m.clock.Now()
 	}
 }
 
@@ -143,7 +338,7 @@ func (m *ConnectionManager) IsStale(hubID string, timeout time.Duration) bool {
 	if !exists {
 		return false
 	}
-	return time.Since(conn.lastHeartbeat) > timeout
+	return m.clock.Now().Sub(conn.lastHeartbeat) > timeout
 }
 
 // Send queues a webhook for delivery to a specific hub.
@@ -161,11 +356,43 @@ func (c *HubConnection) Send(webhook *hooklyv1.WebhookEnvelope) bool {
 	}
 }
 
+// WantsEventType reports whether the hub subscribed to the event type for the
+// endpoint. Endpoints without an event type filter accept every webhook.
+func (c *HubConnection) WantsEventType(endpointID, eventType string) bool {
+	wanted, ok := c.eventTypes[endpointID]
+	if !ok {
+		return true
+	}
+	_, ok = wanted[eventType]
+	return ok
+}
+
 // SendCh returns the channel for sending webhooks to this hub.
 func (c *HubConnection) SendCh() <-chan *hooklyv1.WebhookEnvelope {
 	return c.sendCh
 }
 
+// MaintenanceCh returns the channel of maintenance announcements for this hub.
+func (c *HubConnection) MaintenanceCh() <-chan *hooklyv1.Maintenance {
+	return c.maintenanceCh
+}
+
+// UserID returns the owner of the hub's token.
+func (c *HubConnection) UserID() string {
+	return c.userID
+}
+
+// Paused reports whether deliveries to the hub are paused. Webhooks for a
+// paused hub stay pending on the edge.
+func (c *HubConnection) Paused() bool {
+	return c.paused.Load()
+}
+
+// Transport returns how the hub receives webhooks, TransportStream or TransportTunnel.
+func (c *HubConnection) Transport() string {
+	return c.transport
+}
+
 // HubID returns the hub's identifier.
 func (c *HubConnection) HubID() string {
 	return c.hubID
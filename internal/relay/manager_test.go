@@ -0,0 +1,115 @@
+package relay
+
+import (
+	"testing"
+	"time"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/clock"
+)
+
+func TestWantsEventType(t *testing.T) {
+	m := NewConnectionManager()
+	conn := m.AddConnection("hub-1", "user-1", []string{"ep-filtered", "ep-all"}, map[string][]string{
+		"ep-filtered": {"push", "pull_request"},
+	})
+
+	tests := []struct {
+		endpointID string
+		eventType  string
+		want       bool
+	}{
+		{"ep-filtered", "push", true},
+		{"ep-filtered", "pull_request", true},
+		{"ep-filtered", "issues", false},
+		{"ep-filtered", "", false},
+		{"ep-all", "issues", true},
+		{"ep-all", "", true},
+	}
+
+	for _, tt := range tests {
+		if got := conn.WantsEventType(tt.endpointID, tt.eventType); got != tt.want {
+			t.Errorf("WantsEventType(%q, %q) = %v, want %v", tt.endpointID, tt.eventType, got, tt.want)
+		}
+	}
+}
+
+func TestAnnounceMaintenance(t *testing.T) {
+	m := NewConnectionManager()
+	stream := m.AddConnection("hub-stream", "user-1", []string{"ep-1"}, nil)
+	m.addConnection("hub-tunnel", "user-1", TransportTunnel, []string{"ep-2"}, nil)
+
+	notice := &hooklyv1.Maintenance{ReconnectAfterSeconds: 5, ReconnectUrl: "https://standby.example.com"}
+	announced := m.AnnounceMaintenance(notice)
+	if len(announced) != 1 || announced[0] != stream {
+		t.Fatalf("announced to %d hubs, want only the stream hub", len(announced))
+	}
+	select {
+	case got := <-stream.MaintenanceCh():
+		if got != notice {
+			t.Errorf("got %v, want %v", got, notice)
+		}
+	default:
+		t.Fatal("stream hub has no announcement queued")
+	}
+
+	// A hub whose stream hasn't taken the announcement yet isn't told twice
+	m.AnnounceMaintenance(notice)
+	if again := m.AnnounceMaintenance(notice); len(again) != 0 {
+		t.Errorf("announced twice to %d hubs", len(again))
+	}
+}
+
+func TestConnectionEvents(t *testing.T) {
+	m := NewConnectionManager()
+	var events []ConnectionEvent
+	m.SetEventHandler(func(ev ConnectionEvent) {
+		// Called outside the lock
+		m.GetHubForEndpoint("ep-1")
+		events = append(events, ev)
+	})
+
+	first := m.AddConnection("hub-1", "user-1", []string{"ep-1"}, nil)
+	// Reconnecting replaces the connection without a disconnect
+	second := m.AddConnection("hub-1", "user-1", []string{"ep-1"}, nil)
+	m.removeIfCurrent(first)
+	m.removeIfCurrent(second)
+	m.RemoveConnection("hub-1")
+
+	want := []string{ConnectionEventConnected, ConnectionEventConnected, ConnectionEventDisconnected}
+	if len(events) != len(want) {
+		t.Fatalf("got %d events, want %d: %+v", len(events), len(want), events)
+	}
+	for i, ev := range events {
+		if ev.Type != want[i] {
+			t.Errorf("event %d is %s, want %s", i, ev.Type, want[i])
+		}
+		if ev.HubID != "hub-1" || ev.UserID != "user-1" || ev.Transport != TransportStream || len(ev.EndpointIDs) != 1 || ev.At.IsZero() {
+			t.Errorf("event %d = %+v", i, ev)
+		}
+	}
+}
+
+func TestIsStale(t *testing.T) {
+	m := NewConnectionManager()
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	m.SetClock(clk)
+	m.AddConnection("hub-1", "user-1", []string{"ep-1"}, nil)
+
+	clk.Advance(30 * time.Second)
+	if m.IsStale("hub-1", time.Minute) {
+		t.Error("stale 30s after connecting")
+	}
+	clk.Advance(31 * time.Second)
+	if !m.IsStale("hub-1", time.Minute) {
+		t.Error("not stale 61s after connecting")
+	}
+
+	m.UpdateHeartbeat("hub-1")
+	if m.IsStale("hub-1", time.Minute) {
+		t.Error("stale right after a heartbeat")
+	}
+	if m.IsStale("hub-2", time.Minute) {
+		t.Error("unknown hub reported stale")
+	}
+}
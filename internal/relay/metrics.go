@@ -0,0 +1,165 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// openMetricsContentType is the content type of the OpenMetrics text format.
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// forwardLatencyBuckets are the upper bounds, in seconds, of the forward
+// latency histogram buckets.
+var forwardLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Metrics records relay client activity for OpenMetrics exposition.
+type Metrics struct {
+	mu         sync.Mutex
+	forwarded  uint64
+	failures   map[int]uint64 // By destination status code, 0 when no response
+	buckets    []uint64       // Cumulative counts per forwardLatencyBuckets
+	latencySum float64
+	latencyN   uint64
+	reconnects uint64
+}
+
+// NewMetrics creates an empty metrics registry.
+func NewMetrics() *Metrics {
+	return &Metrics{
+		failures: make(map[int]uint64),
+		buckets:  make([]uint64, len(forwardLatencyBuckets)),
+	}
+}
+
+// observeForward records the outcome and duration of a forward to the destination.
+func (m *Metrics) observeForward(success bool, statusCode int, d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if success {
+		m.forwarded++
+	} else {
+		m.failures[statusCode]++
+	}
+
+	seconds := d.Seconds()
+	for i, le := range forwardLatencyBuckets {
+		if seconds <= le {
+			m.buckets[i]++
+		}
+	}
+	m.latencySum += seconds
+	m.latencyN++
+}
+
+// incReconnects records a reconnect to the edge.
+func (m *Metrics) incReconnects() {
+	m.mu.Lock()
+	m.reconnects++
+	m.mu.Unlock()
+}
+
+// WriteTo writes the metrics in the OpenMetrics text format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	cw := &countingWriter{w: w}
+
+	fmt.Fprintln(cw, "# TYPE hookly_relay_forwarded counter")
+	fmt.Fprintln(cw, "# HELP hookly_relay_forwarded Webhooks forwarded to their destination successfully.")
+	fmt.Fprintf(cw, "hookly_relay_forwarded_total %d\n", m.forwarded)
+
+	fmt.Fprintln(cw, "# TYPE hookly_relay_forward_failures counter")
+	fmt.Fprintln(cw, "# HELP hookly_relay_forward_failures Failed forwards by destination status code, 0 if the destination did not respond.")
+	codes := make([]int, 0, len(m.failures))
+	for code := range m.failures {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	for _, code := range codes {
+		fmt.Fprintf(cw, "hookly_relay_forward_failures_total{status_code=\"%d\"} %d\n", code, m.failures[code])
+	}
+
+	fmt.Fprintln(cw, "# TYPE hookly_relay_forward_duration_seconds histogram")
+	fmt.Fprintln(cw, "# UNIT hookly_relay_forward_duration_seconds seconds")
+	fmt.Fprintln(cw, "# HELP hookly_relay_forward_duration_seconds Time taken to forward a webhook to its destination.")
+	for i, le := range forwardLatencyBuckets {
+		fmt.Fprintf(cw, "hookly_relay_forward_duration_seconds_bucket{le=\"%s\"} %d\n", strconv.FormatFloat(le, 'g', -1, 64), m.buckets[i])
+	}
+	fmt.Fprintf(cw, "hookly_relay_forward_duration_seconds_bucket{le=\"+Inf\"} %d\n", m.latencyN)
+	fmt.Fprintf(cw, "hookly_relay_forward_duration_seconds_sum %s\n", strconv.FormatFloat(m.latencySum, 'g', -1, 64))
+	fmt.Fprintf(cw, "hookly_relay_forward_duration_seconds_count %d\n", m.latencyN)
+
+	fmt.Fprintln(cw, "# TYPE hookly_relay_reconnects counter")
+	fmt.Fprintln(cw, "# HELP hookly_relay_reconnects Reconnects to the edge after the first connection attempt.")
+	fmt.Fprintf(cw, "hookly_relay_reconnects_total %d\n", m.reconnects)
+
+	fmt.Fprintln(cw, "# EOF")
+	return cw.n, cw.err
+}
+
+// ServeHTTP serves the metrics in the OpenMetrics text format.
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", openMetricsContentType)
+	m.WriteTo(w)
+}
+
+// serveStatus runs the local status server on addr until ctx is cancelled.
+// It exposes /metrics and a /healthz liveness check.
+func serveStatus(ctx context.Context, addr string, metrics *Metrics) error {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics)
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.Write([]byte("ok\n"))
+	})
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("status server: %w", err)
+	}
+
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	slog.Info("status server listening", "addr", ln.Addr().String())
+	go func() {
+		if err := srv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("status server error", "error", err)
+		}
+	}()
+	return nil
+}
+
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
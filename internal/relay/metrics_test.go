@@ -0,0 +1,75 @@
+package relay
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMetricsWriteTo(t *testing.T) {
+	m := NewMetrics()
+	m.observeForward(true, 200, 20*time.Millisecond)
+	m.observeForward(false, 503, 2*time.Second)
+	m.observeForward(false, 503, 3*time.Second)
+	m.observeForward(false, 0, 40*time.Second)
+	m.incReconnects()
+
+	var sb strings.Builder
+	if _, err := m.WriteTo(&sb); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	out := sb.String()
+
+	for _, want := range []string{
+		"hookly_relay_forwarded_total 1\n",
+		`hookly_relay_forward_failures_total{status_code="0"} 1` + "\n",
+		`hookly_relay_forward_failures_total{status_code="503"} 2` + "\n",
+		`hookly_relay_forward_duration_seconds_bucket{le="0.025"} 1` + "\n",
+		`hookly_relay_forward_duration_seconds_bucket{le="5"} 3` + "\n",
+		`hookly_relay_forward_duration_seconds_bucket{le="30"} 3` + "\n",
+		`hookly_relay_forward_duration_seconds_bucket{le="+Inf"} 4` + "\n",
+		"hookly_relay_forward_duration_seconds_count 4\n",
+		"hookly_relay_reconnects_total 1\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+	if !strings.HasSuffix(out, "# EOF\n") {
+		t.Error("exposition must end with # EOF")
+	}
+}
+
+func TestServeStatus(t *testing.T) {
+	// Reserve a free port
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := serveStatus(ctx, addr, NewMetrics()); err != nil {
+		t.Fatalf("serveStatus: %v", err)
+	}
+
+	resp, err := http.Get("http://" + addr + "/metrics")
+	if err != nil {
+		t.Fatalf("get metrics: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if ct := resp.Header.Get("Content-Type"); ct != openMetricsContentType {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if !strings.Contains(string(body), "hookly_relay_forwarded_total 0") {
+		t.Errorf("unexpected body:\n%s", body)
+	}
+}
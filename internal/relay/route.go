@@ -0,0 +1,38 @@
+package relay
+
+import (
+	"encoding/json"
+	"strings"
+
+	"hooks.dx314.com/internal/config"
+	"hooks.dx314.com/internal/webhook"
+)
+
+// routeDestination returns the destination of the first route matching the
+// payload, or "" if none matches. Payloads that aren't JSON match no route.
+func routeDestination(routes []config.RouteConfig, payload []byte) string {
+	for _, r := range routes {
+		value, ok := routeField(payload, r.Field)
+		if !ok {
+			continue
+		}
+		if (r.Equals != "" && value == r.Equals) || (r.Prefix != "" && strings.HasPrefix(value, r.Prefix)) {
+			return r.Destination
+		}
+	}
+	return ""
+}
+
+// routeField returns a payload field as text: strings unquoted, other values
+// as their JSON.
+func routeField(payload []byte, field string) (string, bool) {
+	raw, err := webhook.ExtractJSONPath(payload, field)
+	if err != nil {
+		return "", false
+	}
+	var s string
+	if json.Unmarshal(raw, &s) == nil {
+		return s, true
+	}
+	return string(raw), true
+}
@@ -0,0 +1,34 @@
+package relay
+
+import (
+	"testing"
+
+	"hooks.dx314.com/internal/config"
+)
+
+func TestRouteDestination(t *testing.T) {
+	routes := []config.RouteConfig{
+		{Field: "type", Prefix: "invoice.", Destination: "http://billing"},
+		{Field: "data.object.livemode", Equals: "false", Destination: "http://sandbox"},
+		{Field: "/data/object/status", Equals: "paid", Destination: "http://paid"},
+	}
+	tests := []struct {
+		name    string
+		payload string
+		want    string
+	}{
+		{"prefix", `{"type":"invoice.paid","data":{"object":{"status":"paid"}}}`, "http://billing"},
+		{"first match wins", `{"type":"invoice.created","data":{"object":{"livemode":false}}}`, "http://billing"},
+		{"non-string value", `{"type":"charge.succeeded","data":{"object":{"livemode":false}}}`, "http://sandbox"},
+		{"json pointer", `{"type":"charge.succeeded","data":{"object":{"livemode":true,"status":"paid"}}}`, "http://paid"},
+		{"no match", `{"type":"customer.created"}`, ""},
+		{"not json", `type=invoice.paid`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := routeDestination(routes, []byte(tt.payload)); got != tt.want {
+				t.Errorf("routeDestination() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package relay
+
+import (
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// State is the connection state of the relay client.
+type State int
+
+const (
+	// StateIdle is the state before Run is called.
+	StateIdle State = iota
+	// StateConnecting means the client is opening a stream to the edge.
+	StateConnecting
+	// StateAuthenticating means the stream is open and the client is waiting for the connect response.
+	StateAuthenticating
+	// StateConnected means the client is authenticated and receiving webhooks.
+	StateConnected
+	// StateBackingOff means the last attempt failed and the client is waiting to retry.
+	StateBackingOff
+	// StateFatal means the client stopped because of a permanent error.
+	StateFatal
+)
+
+// String returns the lowercase name of the state.
+func (s State) String() string {
+	switch s {
+	case StateIdle:
+		return "idle"
+	case StateConnecting:
+		return "connecting"
+	case StateAuthenticating:
+		return "authenticating"
+	case StateConnected:
+		return "connected"
+	case StateBackingOff:
+		return "backing_off"
+	case StateFatal:
+		return "fatal"
+	default:
+		return "unknown"
+	}
+}
+
+// StateEvent describes a state change of the relay client.
+// While backing off, an event is also emitted every second with the
+// remaining RetryIn so consumers can render a countdown.
+type StateEvent struct {
+	State    State
+	Previous State
+	At       time.Time
+	// Attempt is the number of consecutive connection attempts, starting at 1.
+	Attempt int
+	// Err is the error that caused the transition, if any.
+	Err error
+	// RetryIn is the remaining time until the next attempt (StateBackingOff only).
+	RetryIn time.Duration
+	// RetryAt is when the next attempt starts (StateBackingOff only).
+	RetryAt time.Time
+}
+
+// Countdown reports whether the event is a countdown tick rather than a transition.
+func (e StateEvent) Countdown() bool {
+	return e.State == e.Previous
+}
+
+// StateListener receives state events. Listeners are called synchronously
+// from the client goroutine and must not block.
+type StateListener func(StateEvent)
+
+// OnStateChange registers a listener for state events.
+func (c *Client) OnStateChange(fn StateListener) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.listeners = append(c.listeners, fn)
+}
+
+// State returns the most recent state event.
+func (c *Client) State() StateEvent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.state
+}
+
+// setState records a state event and notifies listeners.
+func (c *Client) setState(ev StateEvent) {
+	c.mu.Lock()
+	ev.Previous = c.state.State
+	ev.At = c.clock.Now()
+	if ev.Attempt == 0 {
+		ev.Attempt = c.state.Attempt
+	}
+	c.state = ev
+	listeners := make([]StateListener, len(c.listeners))
+	copy(listeners, c.listeners)
+	c.mu.Unlock()
+
+	logStateEvent(ev)
+	for _, fn := range listeners {
+		fn(ev)
+	}
+}
+
+// logStateEvent writes state transitions to the logger.
+func logStateEvent(ev StateEvent) {
+	if ev.Countdown() {
+		slog.Debug("waiting to reconnect", "retry_in", ev.RetryIn.Round(time.Second))
+		return
+	}
+
+	// A planned edge restart isn't a failure
+	var maintenance *MaintenanceError
+	if ev.State == StateBackingOff && errors.As(ev.Err, &maintenance) {
+		slog.Info("edge restarting for maintenance, will reconnect", "reason", maintenance.Reason, "reconnect_url", maintenance.ReconnectURL, "retry_in", ev.RetryIn)
+		return
+	}
+
+	switch ev.State {
+	case StateBackingOff:
+		slog.Warn("connection failed, will retry", "error", ev.Err, "retry_in", ev.RetryIn, "attempt", ev.Attempt)
+	case StateFatal:
+		slog.Error("connection failed (not retrying)", "error", ev.Err)
+	default:
+		slog.Debug("relay state changed", "from", ev.Previous, "to", ev.State, "attempt", ev.Attempt)
+	}
+}
@@ -0,0 +1,81 @@
+package relay
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"hooks.dx314.com/internal/clock"
+	"hooks.dx314.com/internal/config"
+)
+
+func TestStateEvents(t *testing.T) {
+	c := NewClient(&config.HooklyConfig{EdgeURL: "https://hooks.example.com"})
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	c.SetClock(clk)
+
+	events := make(chan StateEvent, 8)
+	c.OnStateChange(func(ev StateEvent) {
+		events <- ev
+	})
+
+	c.setState(StateEvent{State: StateConnecting, Attempt: 1})
+	c.setState(StateEvent{State: StateAuthenticating})
+	<-events
+	authenticating := <-events
+	if authenticating.Previous != StateConnecting || authenticating.State != StateAuthenticating {
+		t.Errorf("transition: got %s -> %s", authenticating.Previous, authenticating.State)
+	}
+	if authenticating.Attempt != 1 {
+		t.Errorf("attempt should carry over, got %d", authenticating.Attempt)
+	}
+
+	cause := errors.New("boom")
+	done := make(chan error, 1)
+	go func() {
+		done <- c.backOff(context.Background(), 1500*time.Millisecond, cause)
+	}()
+
+	backingOff := <-events
+	if backingOff.State != StateBackingOff || backingOff.Countdown() {
+		t.Errorf("expected backing_off transition, got %+v", backingOff)
+	}
+	if !errors.Is(backingOff.Err, cause) {
+		t.Errorf("Err: got %v, want %v", backingOff.Err, cause)
+	}
+	if want := clk.Now().Add(1500 * time.Millisecond); !backingOff.RetryAt.Equal(want) {
+		t.Errorf("RetryAt: got %v, want %v", backingOff.RetryAt, want)
+	}
+
+	clk.WaitForTickers(1)
+	clk.Advance(time.Second)
+	tick := <-events
+	if !tick.Countdown() {
+		t.Errorf("expected countdown tick, got %+v", tick)
+	}
+	if tick.RetryIn != 500*time.Millisecond {
+		t.Errorf("RetryIn: got %v, want 500ms", tick.RetryIn)
+	}
+
+	// The tick was sent after switching to a ticker for the last 500ms
+	clk.Advance(500 * time.Millisecond)
+	if err := <-done; err != nil {
+		t.Fatalf("backOff: %v", err)
+	}
+
+	if got := c.State().State; got != StateBackingOff {
+		t.Errorf("State: got %s, want %s", got, StateBackingOff)
+	}
+}
+
+func TestBackOffCancel(t *testing.T) {
+	c := NewClient(&config.HooklyConfig{EdgeURL: "https://hooks.example.com"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := c.backOff(ctx, time.Minute, errors.New("boom")); !errors.Is(err, context.Canceled) {
+		t.Errorf("backOff: got %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,323 @@
+package relay
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"connectrpc.com/connect"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/api/hookly/v1/hooklyv1connect"
+)
+
+// Tunnel delivery is a fallback for networks that drop long-lived HTTP/2
+// streams but let WireGuard or SSH through. The hub runs the tunnel itself
+// and serves TunnelService on a listener the edge can reach through it. While
+// its stream is down the hub leases the tunnel with RegisterTunnel, a unary
+// call, and the edge delivers each webhook with a unary Deliver call.
+const (
+	// tunnelLeaseTTL is how long a tunnel stays in use without a renewal.
+	tunnelLeaseTTL = 90 * time.Second
+	// tunnelRenewInterval is how often the hub renews its lease.
+	tunnelRenewInterval = 30 * time.Second
+	// tunnelDeliverTimeout bounds a delivery, which includes the hub's
+	// forward to the destination.
+	tunnelDeliverTimeout = 60 * time.Second
+	// tunnelDedupWindow is how long delivered attempts are remembered, so
+	// webhooks re-queued while a delivery is in flight aren't sent twice.
+	tunnelDedupWindow = 10 * time.Minute
+	// minTunnelSecretLen is the minimum length of the hub's bearer secret.
+	minTunnelSecretLen = 32
+)
+
+// edgeTunnel is a hub's leased tunnel on the edge.
+type edgeTunnel struct {
+	conn    *HubConnection
+	address string
+	secret  string
+}
+
+// SetTunnelAllowedNets enables tunnel delivery to addresses within nets, such
+// as 127.0.0.1/32 for SSH reverse tunnels or the WireGuard subnet. Other
+// addresses are refused so hubs can't point the edge at internal services.
+func (h *Handler) SetTunnelAllowedNets(nets []*net.IPNet) {
+	h.tunnelNets = nets
+}
+
+// RegisterTunnel leases tunnel delivery for a hub whose stream is down.
+func (h *Handler) RegisterTunnel(ctx context.Context, req *connect.Request[hooklyv1.RegisterTunnelRequest]) (*connect.Response[hooklyv1.RegisterTunnelResponse], error) {
+	if len(h.tunnelNets) == 0 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("TUNNEL_DISABLED: tunnel delivery is not enabled on this edge"))
+	}
+
+	connectReq := req.Msg.Connect
+	if connectReq == nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("connect is required"))
+	}
+	userID, eventTypes, cerr := h.authorize(ctx, connectReq)
+	if cerr != nil {
+		return nil, cerr.err()
+	}
+
+	if err := checkTunnelAddress(req.Msg.Address, h.tunnelNets); err != nil {
+		slog.Warn("tunnel address refused", "hub_id", connectReq.HubId, "address", req.Msg.Address, "error", err)
+		return nil, connect.NewError(connect.CodePermissionDenied, fmt.Errorf("TUNNEL_ADDRESS_DENIED: %w", err))
+	}
+	if len(req.Msg.Secret) < minTunnelSecretLen {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("secret must be at least %d characters", minTunnelSecretLen))
+	}
+
+	hubID := connectReq.HubId
+	lease := &hooklyv1.RegisterTunnelResponse{LeaseSeconds: int32(tunnelLeaseTTL / time.Second)}
+
+	// A connected stream takes precedence
+	current := h.manager.connection(hubID)
+	if current != nil && current.Transport() == TransportStream {
+		return connect.NewResponse(lease), nil
+	}
+
+	h.tunnelsMu.Lock()
+	defer h.tunnelsMu.Unlock()
+
+	if t := h.tunnels[hubID]; t != nil && t.conn == current && t.address == req.Msg.Address && t.secret == req.Msg.Secret {
+		h.manager.UpdateHeartbeat(hubID)
+		current.paused.Store(connectReq.Paused)
+		lease.Active = true
+		return connect.NewResponse(lease), nil
+	}
+
+	t := &edgeTunnel{
+		conn:    h.manager.addConnection(hubID, userID, TransportTunnel, connectReq.EndpointIds, eventTypes),
+		address: req.Msg.Address,
+		secret:  req.Msg.Secret,
+	}
+	t.conn.paused.Store(connectReq.Paused)
+	h.tunnels[hubID] = t
+	go h.runTunnel(t, userID)
+
+	lease.Active = true
+	return connect.NewResponse(lease), nil
+}
+
+// runTunnel delivers a hub's webhooks over its tunnel until the lease
+// expires or the hub reconnects.
+func (h *Handler) runTunnel(t *edgeTunnel, userID string) {
+	ctx := context.Background()
+	hubID := t.conn.HubID()
+	slog.Info("tunnel delivery started", "hub_id", hubID, "address", t.address)
+	defer func() {
+		// Runs after the cleanup below, so a panic only ends this tunnel
+		if r := recover(); r != nil {
+			slog.Error("panic in tunnel delivery, closing tunnel", "hub_id", hubID, "panic", r, "stack", string(debug.Stack()))
+		}
+	}()
+
+	defer func() {
+		h.manager.removeIfCurrent(t.conn)
+		h.tunnelsMu.Lock()
+		if h.tunnels[hubID] == t {
+			delete(h.tunnels, hubID)
+		}
+		h.tunnelsMu.Unlock()
+	}()
+
+	client := hooklyv1connect.NewTunnelServiceClient(
+		&http.Client{Timeout: tunnelDeliverTimeout},
+		"http://"+t.address,
+	)
+	delivered := make(map[string]time.Time) // webhookID/attempt → when
+
+	staleTicker := time.NewTicker(10 * time.Second)
+	defer staleTicker.Stop()
+
+	sendCh := t.conn.SendCh()
+	for {
+		select {
+		case envelope, ok := <-sendCh:
+			if !ok {
+				slog.Info("tunnel delivery stopped, hub reconnected", "hub_id", hubID)
+				return
+			}
+			key := envelope.Id + "/" + strconv.Itoa(int(envelope.Attempt))
+			if _, dup := delivered[key]; dup {
+				continue
+			}
+			delivered[key] = time.Now()
+			h.processAck(ctx, userID, deliverOverTunnel(ctx, client, t.secret, envelope))
+
+		case <-staleTicker.C:
+			if h.manager.IsStale(hubID, tunnelLeaseTTL) {
+				slog.Warn("tunnel lease expired", "hub_id", hubID)
+				return
+			}
+			for key, at := range delivered {
+				if time.Since(at) > tunnelDedupWindow {
+					delete(delivered, key)
+				}
+			}
+		}
+	}
+}
+
+// deliverOverTunnel sends a webhook to the hub's tunnel listener. Failing to
+// reach the hub is a transient failure, so the webhook is retried.
+func deliverOverTunnel(ctx context.Context, client hooklyv1connect.TunnelServiceClient, secret string, envelope *hooklyv1.WebhookEnvelope) *hooklyv1.DeliveryAck {
+	req := connect.NewRequest(envelope)
+	req.Header().Set("Authorization", "Bearer "+secret)
+	resp, err := client.Deliver(ctx, req)
+	if err != nil {
+		slog.Warn("tunnel delivery failed", "webhook_id", envelope.Id, "error", err)
+		return &hooklyv1.DeliveryAck{
+			WebhookId:    envelope.Id,
+			ErrorMessage: "tunnel delivery failed: " + err.Error(),
+		}
+	}
+	ack := resp.Msg
+	ack.WebhookId = envelope.Id
+	return ack
+}
+
+// checkTunnelAddress checks that addr is an ip:port within nets. Host names
+// are refused so the check can't be bypassed with DNS.
+func checkTunnelAddress(addr string, nets []*net.IPNet) error {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("%q is not an ip:port address", addr)
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return fmt.Errorf("%q is not an IP address", host)
+	}
+	if p, err := strconv.Atoi(port); err != nil || p < 1 || p > 65535 {
+		return fmt.Errorf("%q is not a valid port", port)
+	}
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s is not in the edge's allowed tunnel networks", ip)
+}
+
+// tunnelServer serves TunnelService on the hub.
+type tunnelServer struct {
+	secret  string
+	deliver func(context.Context, *hooklyv1.WebhookEnvelope) *hooklyv1.DeliveryAck
+}
+
+// Deliver forwards a webhook received over the tunnel.
+func (s *tunnelServer) Deliver(ctx context.Context, req *connect.Request[hooklyv1.WebhookEnvelope]) (*connect.Response[hooklyv1.DeliveryAck], error) {
+	auth := req.Header().Get("Authorization")
+	if subtle.ConstantTimeCompare([]byte(auth), []byte("Bearer "+s.secret)) != 1 {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("invalid tunnel secret"))
+	}
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id is required"))
+	}
+	slog.Debug("received webhook over tunnel", "webhook_id", req.Msg.Id)
+	return connect.NewResponse(s.deliver(ctx, req.Msg)), nil
+}
+
+// serveTunnel runs the hub's tunnel listener on addr until ctx is cancelled.
+func serveTunnel(ctx context.Context, addr string, srv *tunnelServer) error {
+	mux := http.NewServeMux()
+	path, handler := hooklyv1connect.NewTunnelServiceHandler(srv)
+	mux.Handle(path, handler)
+
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("tunnel listener: %w", err)
+	}
+
+	httpSrv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		httpSrv.Shutdown(shutdownCtx)
+	}()
+
+	slog.Info("tunnel listener started", "addr", ln.Addr().String())
+	go func() {
+		if err := httpSrv.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			slog.Error("tunnel listener error", "error", err)
+		}
+	}()
+	return nil
+}
+
+// newTunnelSecret generates the bearer secret the edge presents to the
+// tunnel listener. It changes on every start of the hub.
+func newTunnelSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// maintainTunnel leases tunnel delivery from the edge while the stream is
+// down, renewing the lease until ctx is cancelled.
+func (c *Client) maintainTunnel(ctx context.Context, secret string) {
+	address := c.cfg().Tunnel.Address()
+
+	ticker := time.NewTicker(tunnelRenewInterval)
+	defer ticker.Stop()
+
+	active := false
+	var lastErr string
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		// The edge prefers the stream, and the lease lapses once it's back
+		if c.State().State == StateConnected {
+			active = false
+			continue
+		}
+
+		// Follows the client to a standby edge after a maintenance announcement
+		client := hooklyv1connect.NewRelayServiceClient(http.DefaultClient, c.currentEdgeURL())
+		resp, err := client.RegisterTunnel(ctx, connect.NewRequest(&hooklyv1.RegisterTunnelRequest{
+			Connect: &hooklyv1.ConnectRequest{
+				HubId:        c.cfg().GetHubID(),
+				Token:        c.cfg().Token,
+				EndpointIds:  c.cfg().EndpointIDs(),
+				EventFilters: eventTypeFilters(c.cfg()),
+				Paused:       c.paused.Load(),
+			},
+			Address: address,
+			Secret:  secret,
+		}))
+		if err != nil {
+			if ctx.Err() == nil && err.Error() != lastErr {
+				slog.Warn("tunnel registration failed", "address", address, "error", err)
+			}
+			lastErr = err.Error()
+			active = false
+			continue
+		}
+		lastErr = ""
+
+		if resp.Msg.Active && !active {
+			slog.Info("delivering over tunnel while the stream is down", "address", address)
+		}
+		active = resp.Msg.Active
+	}
+}
@@ -0,0 +1,95 @@
+package relay
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/api/hookly/v1/hooklyv1connect"
+)
+
+func TestCheckTunnelAddress(t *testing.T) {
+	_, loopback, _ := net.ParseCIDR("127.0.0.1/32")
+	_, wg, _ := net.ParseCIDR("10.8.0.0/24")
+	nets := []*net.IPNet{loopback, wg}
+
+	tests := []struct {
+		addr string
+		ok   bool
+	}{
+		{"127.0.0.1:9443", true},
+		{"10.8.0.2:9443", true},
+		{"10.9.0.2:9443", false},
+		{"169.254.169.254:80", false},
+		{"localhost:9443", false},
+		{"127.0.0.1", false},
+		{"127.0.0.1:0", false},
+	}
+	for _, tt := range tests {
+		if err := checkTunnelAddress(tt.addr, nets); (err == nil) != tt.ok {
+			t.Errorf("checkTunnelAddress(%q) = %v, want ok=%v", tt.addr, err, tt.ok)
+		}
+	}
+}
+
+func TestTunnelDelivery(t *testing.T) {
+	// Reserve a free port
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := ln.Addr().String()
+	ln.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	secret := strings.Repeat("s", minTunnelSecretLen)
+	srv := &tunnelServer{
+		secret: secret,
+		deliver: func(_ context.Context, env *hooklyv1.WebhookEnvelope) *hooklyv1.DeliveryAck {
+			return &hooklyv1.DeliveryAck{WebhookId: env.Id, Success: true, StatusCode: 204}
+		},
+	}
+	if err := serveTunnel(ctx, addr, srv); err != nil {
+		t.Fatalf("serveTunnel: %v", err)
+	}
+
+	client := hooklyv1connect.NewTunnelServiceClient(http.DefaultClient, "http://"+addr)
+	envelope := &hooklyv1.WebhookEnvelope{Id: "wh-1", EndpointId: "ep-1"}
+
+	ack := deliverOverTunnel(ctx, client, "wrong", envelope)
+	if ack.Success || !strings.Contains(ack.ErrorMessage, "tunnel delivery failed") {
+		t.Errorf("wrong secret: ack = %+v, want transient failure", ack)
+	}
+	if ack.PermanentFailure {
+		t.Error("wrong secret: failure must be transient so the webhook is retried")
+	}
+
+	ack = deliverOverTunnel(ctx, client, secret, envelope)
+	if !ack.Success || ack.StatusCode != 204 || ack.WebhookId != "wh-1" {
+		t.Errorf("ack = %+v, want success for wh-1", ack)
+	}
+}
+
+func TestRemoveIfCurrent(t *testing.T) {
+	m := NewConnectionManager()
+	tunnel := m.addConnection("hub-1", "user-1", TransportTunnel, []string{"ep-1"}, nil)
+	stream := m.AddConnection("hub-1", "user-1", []string{"ep-1"}, nil)
+
+	// The replaced tunnel must not remove the stream that replaced it
+	m.removeIfCurrent(tunnel)
+	if got := m.GetHubForEndpoint("ep-1"); got != stream {
+		t.Fatalf("endpoint routed to %v after removing a replaced connection, want the stream", got)
+	}
+	if stream.Transport() != TransportStream {
+		t.Errorf("transport = %q, want %q", stream.Transport(), TransportStream)
+	}
+
+	m.removeIfCurrent(stream)
+	if got := m.GetHubForEndpoint("ep-1"); got != nil {
+		t.Errorf("endpoint still routed after removing the current connection")
+	}
+}
@@ -0,0 +1,111 @@
+// Package release verifies the signatures of hookly release artifacts.
+// Releases are signed with cosign's key-pair signing,
+//
+//	cosign sign-blob --key cosign.key --output-signature hookly-linux-amd64.sig hookly-linux-amd64
+//
+// an ECDSA P-256 signature of the artifact's SHA-256, base64 encoded. The
+// public key of the release key is embedded in the binary, so a hookly that
+// is already trusted can check a download before it is installed.
+package release
+
+import (
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	_ "embed"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// SignatureSuffix is appended to an artifact's name to name its signature.
+const SignatureSuffix = ".sig"
+
+// ErrBadSignature is returned when a signature doesn't match the artifact
+// and key.
+var ErrBadSignature = errors.New("signature does not match")
+
+// ErrNoPublicKey is returned by PublicKey when cosign.pub holds no key yet.
+var ErrNoPublicKey = errors.New("this build has no release key")
+
+// publicKeyPEM is the public half of the key CI signs releases with
+// (secrets.COSIGN_KEY), committed by a maintainer.
+//
+//go:embed cosign.pub
+var publicKeyPEM []byte
+
+// PublicKey returns the embedded release key.
+func PublicKey() (*ecdsa.PublicKey, error) {
+	if block, _ := pem.Decode(publicKeyPEM); block == nil {
+		return nil, ErrNoPublicKey
+	}
+	key, err := ParsePublicKey(publicKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("embedded release key: %w", err)
+	}
+	return key, nil
+}
+
+// ParsePublicKey parses a PEM encoded ECDSA public key, like cosign.pub.
+func ParsePublicKey(data []byte) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("no PEM public key found")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+	key, ok := pub.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %T, want ECDSA", pub)
+	}
+	return key, nil
+}
+
+// Result describes a verified artifact.
+type Result struct {
+	SHA256 string // Hex digest of the artifact
+}
+
+// Verify checks that sig, as written by cosign sign-blob, signs the
+// artifact read from r with key. It returns ErrBadSignature if it doesn't.
+func Verify(r io.Reader, sig []byte, key *ecdsa.PublicKey) (Result, error) {
+	der, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sig)))
+	if err != nil {
+		return Result{}, fmt.Errorf("decode signature: %w", err)
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, r); err != nil {
+		return Result{}, fmt.Errorf("read artifact: %w", err)
+	}
+	digest := h.Sum(nil)
+	result := Result{SHA256: hex.EncodeToString(digest)}
+	if !ecdsa.VerifyASN1(key, digest, der) {
+		return result, ErrBadSignature
+	}
+	return result, nil
+}
+
+// VerifyFile checks the artifact at path against the signature at sigPath,
+// by default path+SignatureSuffix.
+func VerifyFile(path, sigPath string, key *ecdsa.PublicKey) (Result, error) {
+	if sigPath == "" {
+		sigPath = path + SignatureSuffix
+	}
+	sig, err := os.ReadFile(sigPath)
+	if err != nil {
+		return Result{}, fmt.Errorf("read signature: %w", err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return Result{}, err
+	}
+	defer f.Close()
+	return Verify(f, sig, key)
+}
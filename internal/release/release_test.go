@@ -0,0 +1,94 @@
+package release
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// sign signs data like cosign sign-blob.
+func sign(t *testing.T, key *ecdsa.PrivateKey, data []byte) []byte {
+	t.Helper()
+	digest := sha256.Sum256(data)
+	der, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+	return []byte(base64.StdEncoding.EncodeToString(der) + "\n")
+}
+
+func TestVerify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	artifact := []byte("hookly binary")
+	sig := sign(t, key, artifact)
+
+	result, err := Verify(bytes.NewReader(artifact), sig, &key.PublicKey)
+	if err != nil {
+		t.Fatalf("verify: %v", err)
+	}
+	if want := sha256.Sum256(artifact); result.SHA256 != hex.EncodeToString(want[:]) {
+		t.Errorf("sha256 = %s", result.SHA256)
+	}
+
+	if _, err := Verify(bytes.NewReader([]byte("tampered")), sig, &key.PublicKey); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("tampered artifact: err = %v, want ErrBadSignature", err)
+	}
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if _, err := Verify(bytes.NewReader(artifact), sig, &other.PublicKey); !errors.Is(err, ErrBadSignature) {
+		t.Errorf("other key: err = %v, want ErrBadSignature", err)
+	}
+	if _, err := Verify(bytes.NewReader(artifact), []byte("not base64!"), &key.PublicKey); err == nil || errors.Is(err, ErrBadSignature) {
+		t.Errorf("malformed signature: err = %v", err)
+	}
+}
+
+func TestVerifyFile(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	dir := t.TempDir()
+	path := filepath.Join(dir, "hookly-linux-amd64")
+	artifact := []byte("hookly binary")
+	if err := os.WriteFile(path, artifact, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyFile(path, "", &key.PublicKey); err == nil {
+		t.Errorf("verified without a signature file")
+	}
+	if err := os.WriteFile(path+SignatureSuffix, sign(t, key, artifact), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyFile(path, "", &key.PublicKey); err != nil {
+		t.Errorf("verify: %v", err)
+	}
+}
+
+func TestParsePublicKey(t *testing.T) {
+	// cosign.pub holds either a valid key or the note that there is none yet
+	if _, err := PublicKey(); err != nil && !errors.Is(err, ErrNoPublicKey) {
+		t.Fatalf("embedded key: %v", err)
+	}
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ParsePublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	if err != nil || !parsed.Equal(&key.PublicKey) {
+		t.Errorf("parse: %v", err)
+	}
+	if _, err := ParsePublicKey([]byte("not a key")); err == nil {
+		t.Errorf("parsed garbage")
+	}
+}
@@ -0,0 +1,135 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"hooks.dx314.com/internal/audit"
+	"hooks.dx314.com/internal/auth"
+)
+
+// auditTargetFields are the request fields naming what a call acts on, in
+// the order they are looked for.
+var auditTargetFields = []protoreflect.Name{"id", "endpoint_id", "webhook_id", "org_id", "hub_id"}
+
+// AuditInterceptor records the authenticated unary calls that aren't reads
+// (see auth.IsReadProcedure) in the audit log. It must run inside
+// AuthInterceptor, which puts the session in the context and records the
+// calls it denies itself (see AuthInterceptor.SetAuditRecorder).
+type AuditInterceptor struct {
+	recorder *audit.Recorder
+}
+
+// NewAuditInterceptor creates an interceptor recording to recorder.
+func NewAuditInterceptor(recorder *audit.Recorder) *AuditInterceptor {
+	return &AuditInterceptor{recorder: recorder}
+}
+
+// WrapUnary implements connect.Interceptor.
+func (i *AuditInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
+	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		procedure := req.Spec().Procedure
+		session := auth.GetSessionFromContext(ctx)
+		if session == nil || auth.IsReadProcedure(procedure) {
+			return next(ctx, req)
+		}
+
+		resp, err := next(ctx, req)
+
+		ev := callEvent(session, req)
+		// Created resources are named in the response
+		if ev.Target == "" && err == nil && resp != nil {
+			ev.Target = auditResponseTarget(resp.Any())
+		}
+		if err != nil {
+			ev.Result = auditResult(err)
+		}
+		i.recorder.Record(ctx, ev)
+		return resp, err
+	}
+}
+
+// WrapStreamingClient implements connect.Interceptor.
+func (i *AuditInterceptor) WrapStreamingClient(next connect.StreamingClientFunc) connect.StreamingClientFunc {
+	return next
+}
+
+// WrapStreamingHandler implements connect.Interceptor. Streaming calls only
+// read.
+func (i *AuditInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc) connect.StreamingHandlerFunc {
+	return next
+}
+
+// callEvent returns the audit event of a call made with session.
+func callEvent(session *auth.Session, req connect.AnyRequest) audit.Event {
+	procedure := req.Spec().Procedure
+	ev := audit.Event{
+		UserID:   session.UserID,
+		Username: session.Username,
+		OwnerID:  session.OwnerID(),
+		Action:   procedure[strings.LastIndex(procedure, "/")+1:],
+		Target:   auditTarget(req.Any()),
+		IP:       hostOnly(req.Peer().Addr),
+	}
+	if session.APIToken {
+		ev.TokenID = session.ID
+	}
+	return ev
+}
+
+// auditTarget returns the ID a request acts on: the first of
+// auditTargetFields that is set.
+func auditTarget(msg any) string {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return ""
+	}
+	r := m.ProtoReflect()
+	fields := r.Descriptor().Fields()
+	for _, name := range auditTargetFields {
+		fd := fields.ByName(name)
+		if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+			continue
+		}
+		if v := r.Get(fd).String(); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// auditResponseTarget returns the ID of the resource in a response, such as
+// the endpoint CreateEndpoint returns.
+func auditResponseTarget(msg any) string {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return ""
+	}
+	r := m.ProtoReflect()
+	fields := r.Descriptor().Fields()
+	for j := 0; j < fields.Len(); j++ {
+		fd := fields.Get(j)
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() || !r.Has(fd) {
+			continue
+		}
+		if id := auditTarget(r.Get(fd).Message().Interface()); id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// auditResult describes why a call failed: its Connect code, such as
+// permission_denied.
+func auditResult(err error) string {
+	var cerr *connect.Error
+	if errors.As(err, &cerr) {
+		return cerr.Code().String()
+	}
+	return connect.CodeUnknown.String()
+}
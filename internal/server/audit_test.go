@@ -0,0 +1,87 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/api/hookly/v1/hooklyv1connect"
+	"hooks.dx314.com/internal/audit"
+	"hooks.dx314.com/internal/auth"
+	"hooks.dx314.com/internal/db"
+)
+
+func TestAuditTarget(t *testing.T) {
+	if got := auditTarget(&hooklyv1.DeleteEndpointRequest{Id: "ep-1"}); got != "ep-1" {
+		t.Errorf("DeleteEndpoint target = %q, want ep-1", got)
+	}
+	if got := auditTarget(&hooklyv1.GenerateEndpointSecretRequest{EndpointId: "ep-1"}); got != "ep-1" {
+		t.Errorf("GenerateEndpointSecret target = %q, want ep-1", got)
+	}
+	if got := auditTarget(&hooklyv1.CreateEndpointRequest{Name: "stripe"}); got != "" {
+		t.Errorf("CreateEndpoint target = %q, want none", got)
+	}
+
+	resp := &hooklyv1.CreateEndpointResponse{Endpoint: &hooklyv1.Endpoint{Id: "ep-2"}}
+	if got := auditResponseTarget(resp); got != "ep-2" {
+		t.Errorf("CreateEndpoint response target = %q, want ep-2", got)
+	}
+	if got := auditResponseTarget(&hooklyv1.CreateEndpointResponse{}); got != "" {
+		t.Errorf("empty response target = %q, want none", got)
+	}
+
+	if got := auditResult(connect.NewError(connect.CodePermissionDenied, errors.New("no"))); got != "permission_denied" {
+		t.Errorf("result = %q, want permission_denied", got)
+	}
+}
+
+func TestAuditScopeDenied(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	queries := db.New(conn)
+	tokens := auth.NewTokenManager(queries)
+	token, apiToken, err := tokens.GenerateScopedToken(ctx, "user-1", "octocat", "dashboard", auth.ScopeRead, time.Time{})
+	if err != nil {
+		t.Fatalf("generate token: %v", err)
+	}
+
+	recorder := audit.NewRecorder(queries)
+	authInterceptor := NewAuthInterceptor(nil, tokens)
+	authInterceptor.SetAuditRecorder(recorder)
+	path, handler := hooklyv1connect.NewEdgeServiceHandler(hooklyv1connect.UnimplementedEdgeServiceHandler{},
+		connect.WithInterceptors(authInterceptor, NewAuditInterceptor(recorder)))
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	client := hooklyv1connect.NewEdgeServiceClient(srv.Client(), srv.URL)
+	req := connect.NewRequest(&hooklyv1.DeleteEndpointRequest{Id: "ep-1"})
+	req.Header().Set("Authorization", "Bearer "+token)
+	if _, err := client.DeleteEndpoint(ctx, req); connect.CodeOf(err) != connect.CodePermissionDenied {
+		t.Fatalf("delete with a read token: err = %v, want permission denied", err)
+	}
+
+	events, err := queries.ListAuditEvents(ctx, db.ListAuditEventsParams{OwnerID: "user-1", Limit: 10})
+	if err != nil {
+		t.Fatalf("list audit events: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("%d audit events, want 1", len(events))
+	}
+	ev := events[0]
+	if ev.Action != "DeleteEndpoint" || ev.Target != "ep-1" || ev.Result != "permission_denied" || ev.TokenID.String != apiToken.ID {
+		t.Errorf("audit event %+v, want a denied DeleteEndpoint of ep-1 with the token", ev)
+	}
+}
@@ -3,19 +3,29 @@ package server
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"strings"
 
 	"connectrpc.com/connect"
 
+	"hooks.dx314.com/internal/api/hookly/v1/hooklyv1connect"
+	"hooks.dx314.com/internal/audit"
 	"hooks.dx314.com/internal/auth"
 )
 
+// publicProcedures are the procedures callable without credentials.
+var publicProcedures = map[string]bool{
+	hooklyv1connect.EdgeServiceGetVersionProcedure: true,
+}
+
 // AuthInterceptor validates session cookies or Bearer tokens for ConnectRPC handlers.
 type AuthInterceptor struct {
 	sessions *auth.SessionManager
 	tokens   *auth.TokenManager
+	orgs     *auth.OrgResolver
+	audit    *audit.Recorder
 }
 
 // NewAuthInterceptor creates a new auth interceptor.
@@ -23,13 +33,39 @@ func NewAuthInterceptor(sessions *auth.SessionManager, tokens *auth.TokenManager
 	return &AuthInterceptor{sessions: sessions, tokens: tokens}
 }
 
+// SetOrgResolver lets requests act for an org selected with auth.OrgHeader.
+// Without it the header is rejected.
+func (i *AuthInterceptor) SetOrgResolver(orgs *auth.OrgResolver) {
+	i.orgs = orgs
+}
+
+// SetAuditRecorder records the unary calls denied by a token scope or org
+// role in the audit log, reads included. They never reach AuditInterceptor.
+func (i *AuthInterceptor) SetAuditRecorder(r *audit.Recorder) {
+	i.audit = r
+}
+
 // WrapUnary implements connect.Interceptor.
 func (i *AuthInterceptor) WrapUnary(next connect.UnaryFunc) connect.UnaryFunc {
 	return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
+		if publicProcedures[req.Spec().Procedure] {
+			return next(ctx, req)
+		}
 		ctx, err := i.authenticate(ctx, req.Header())
 		if err != nil {
 			return nil, connect.NewError(connect.CodeUnauthenticated, err)
 		}
+		if err := i.selectOrg(ctx, req.Header()); err != nil {
+			if connect.CodeOf(err) == connect.CodePermissionDenied {
+				i.auditDenied(ctx, req, err)
+			}
+			return nil, err
+		}
+		if !auth.ScopeAllows(auth.GetSessionFromContext(ctx), req.Spec().Procedure) {
+			err := errScopeDenied(ctx)
+			i.auditDenied(ctx, req, err)
+			return nil, err
+		}
 		return next(ctx, req)
 	}
 }
@@ -46,10 +82,37 @@ func (i *AuthInterceptor) WrapStreamingHandler(next connect.StreamingHandlerFunc
 		if err != nil {
 			return connect.NewError(connect.CodeUnauthenticated, err)
 		}
+		if err := i.selectOrg(ctx, conn.RequestHeader()); err != nil {
+			return err
+		}
+		if !auth.ScopeAllows(auth.GetSessionFromContext(ctx), conn.Spec().Procedure) {
+			return errScopeDenied(ctx)
+		}
 		return next(ctx, conn)
 	}
 }
 
+// Middleware authenticates plain HTTP handlers the same way, responding 401
+// without valid credentials.
+func (i *AuthInterceptor) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := i.authenticate(r.Context(), r.Header)
+		if err != nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if err := i.selectOrg(ctx, r.Header); err != nil {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		if !auth.ScopeAllowsHTTP(auth.GetSessionFromContext(ctx), r.Method) {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 // authenticate extracts and validates credentials from headers.
 // It checks Bearer token first (for CLI), then falls back to session cookie (for web UI).
 func (i *AuthInterceptor) authenticate(ctx context.Context, headers http.Header) (context.Context, error) {
@@ -72,7 +135,7 @@ func (i *AuthInterceptor) authenticateWithToken(ctx context.Context, token strin
 
 	apiToken, err := i.tokens.ValidateToken(ctx, token)
 	if err != nil {
-		if errors.Is(err, auth.ErrTokenNotFound) || errors.Is(err, auth.ErrTokenRevoked) || errors.Is(err, auth.ErrInvalidToken) {
+		if errors.Is(err, auth.ErrTokenNotFound) || errors.Is(err, auth.ErrTokenRevoked) || errors.Is(err, auth.ErrTokenExpired) || errors.Is(err, auth.ErrInvalidToken) {
 			return nil, errors.New("invalid or expired token")
 		}
 		slog.Error("auth interceptor: failed to validate token", "error", err)
@@ -84,11 +147,55 @@ func (i *AuthInterceptor) authenticateWithToken(ctx context.Context, token strin
 		ID:       apiToken.ID,
 		UserID:   apiToken.UserID,
 		Username: apiToken.Username,
+		APIToken: true,
+		Scope:    apiToken.Scope,
 	}
 
 	return auth.ContextWithSession(ctx, session), nil
 }
 
+// selectOrg makes the authenticated session act for the org in
+// auth.OrgHeader, if any, after checking the user is a member.
+func (i *AuthInterceptor) selectOrg(ctx context.Context, headers http.Header) error {
+	orgID := headers.Get(auth.OrgHeader)
+	if orgID == "" {
+		return nil
+	}
+	if i.orgs == nil {
+		return connect.NewError(connect.CodeUnimplemented, errors.New("organizations are not enabled"))
+	}
+	err := i.orgs.Select(ctx, auth.GetSessionFromContext(ctx), orgID)
+	if errors.Is(err, auth.ErrNotOrgMember) {
+		return connect.NewError(connect.CodePermissionDenied, err)
+	}
+	if err != nil {
+		slog.Error("auth interceptor: failed to look up org membership", "error", err)
+		return connect.NewError(connect.CodeInternal, errors.New("authentication failed"))
+	}
+	return nil
+}
+
+// auditDenied records a call of an authenticated session that was denied
+// with err.
+func (i *AuthInterceptor) auditDenied(ctx context.Context, req connect.AnyRequest, err error) {
+	if i.audit == nil {
+		return
+	}
+	ev := callEvent(auth.GetSessionFromContext(ctx), req)
+	ev.Result = auditResult(err)
+	i.audit.Record(ctx, ev)
+}
+
+// errScopeDenied is returned for calls the session's token scope or org
+// role doesn't allow.
+func errScopeDenied(ctx context.Context) error {
+	session := auth.GetSessionFromContext(ctx)
+	if session.OrgID != "" && !auth.RoleCanWrite(session.OrgRole) {
+		return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("this call is not allowed for an org %s", session.OrgRole))
+	}
+	return connect.NewError(connect.CodePermissionDenied, fmt.Errorf("this call is not allowed for a %s token", session.Scope))
+}
+
 // authenticateWithCookie validates a session cookie.
 func (i *AuthInterceptor) authenticateWithCookie(ctx context.Context, headers http.Header) (context.Context, error) {
 	// Parse cookie header
@@ -3,11 +3,19 @@ package server
 import (
 	"log/slog"
 	"net/http"
+	"runtime/debug"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5/middleware"
+
+	"hooks.dx314.com/internal/telemetry"
 )
 
+// anonymousPaths are logged without the client IP and request ID, which
+// would tie anonymous usage reports to their senders.
+var anonymousPaths = map[string]bool{telemetry.Path: true}
+
 // LoggingMiddleware logs HTTP requests using slog.
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -15,26 +23,65 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
 
 		defer func() {
-			slog.Info("http request",
+			attrs := []any{
 				"method", r.Method,
 				"path", r.URL.Path,
 				"status", ww.Status(),
 				"bytes", ww.BytesWritten(),
 				"duration", time.Since(start).String(),
+			}
+			if !anonymousPaths[r.URL.Path] {
+				attrs = append(attrs, "client_ip", ClientIP(r), "request_id", middleware.GetReqID(r.Context()))
+			}
+			slog.Info("http request", attrs...)
+		}()
+
+		next.ServeHTTP(ww, r)
+	})
+}
+
+// RecoverMiddleware turns a panic in a handler into a 500 response, logging
+// the panic with the request ID and stack. http.ErrAbortHandler is re-raised
+// so net/http can abort the response as intended.
+func RecoverMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			v := recover()
+			if v == nil {
+				return
+			}
+			if v == http.ErrAbortHandler {
+				panic(v)
+			}
+			slog.Error("panic recovered",
+				"panic", v,
+				"method", r.Method,
+				"path", r.URL.Path,
 				"request_id", middleware.GetReqID(r.Context()),
+				"stack", string(debug.Stack()),
 			)
+			if r.Header.Get("Connection") != "Upgrade" {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
 		}()
 
-		next.ServeHTTP(ww, r)
+		next.ServeHTTP(w, r)
 	})
 }
 
-// CORSMiddleware adds CORS headers for the API.
-func CORSMiddleware(next http.Handler) http.Handler {
+// CORSMiddleware adds CORS headers for the API. Webhook ingestion under
+// ingestPrefix is left alone: whether it answers preflights is set per
+// endpoint.
+func CORSMiddleware(ingestPrefix string, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, ingestPrefix) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		w.Header().Set("Access-Control-Allow-Origin", "*")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-CSRF-Token, Connect-Protocol-Version")
+		w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-CSRF-Token, Connect-Protocol-Version, Hookly-Org")
 		w.Header().Set("Access-Control-Expose-Headers", "Link")
 		w.Header().Set("Access-Control-Max-Age", "300")
 
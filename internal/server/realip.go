@@ -0,0 +1,104 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+)
+
+type clientIPKey struct{}
+
+// SetTrustedProxies sets the proxies, such as Cloudflare or a local Caddy,
+// whose forwarding headers are believed. Requests from anywhere else keep
+// their connection address. Must be called before Start.
+func (s *Server) SetTrustedProxies(nets []*net.IPNet) {
+	s.trustedProxies = nets
+}
+
+// realIP resolves the client IP of each request through the trusted proxies.
+// The result replaces r.RemoteAddr and is available from ClientIP.
+func (s *Server) realIP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip := resolveClientIP(r, s.trustedProxies)
+		r.RemoteAddr = ip
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientIPKey{}, ip)))
+	})
+}
+
+// ClientIP returns the client IP of a request as resolved through the trusted
+// proxies, or the connection address outside the server's middleware.
+func ClientIP(r *http.Request) string {
+	if ip, ok := r.Context().Value(clientIPKey{}).(string); ok {
+		return ip
+	}
+	return hostOnly(r.RemoteAddr)
+}
+
+// resolveClientIP returns the IP of the client that sent r. Forwarding
+// headers are only used when the connection comes from a trusted proxy:
+// CF-Connecting-IP first, then the rightmost X-Forwarded-For entry that isn't
+// itself a trusted proxy, then X-Real-IP.
+func resolveClientIP(r *http.Request, trusted []*net.IPNet) string {
+	peer := hostOnly(r.RemoteAddr)
+	if !inNets(peer, trusted) {
+		return peer
+	}
+
+	if ip := parseIP(r.Header.Get("CF-Connecting-IP")); ip != "" {
+		return ip
+	}
+
+	var hops []string
+	for _, v := range r.Header.Values("X-Forwarded-For") {
+		for _, hop := range strings.Split(v, ",") {
+			if ip := parseIP(hop); ip != "" {
+				hops = append(hops, ip)
+			}
+		}
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		if !inNets(hops[i], trusted) {
+			return hops[i]
+		}
+	}
+	if len(hops) > 0 {
+		// Every hop is a trusted proxy, so the first one is the client
+		return hops[0]
+	}
+
+	if ip := parseIP(r.Header.Get("X-Real-IP")); ip != "" {
+		return ip
+	}
+	return peer
+}
+
+// hostOnly strips the port from a host:port address.
+func hostOnly(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
+
+// parseIP returns the canonical form of an IP in a header value, or "".
+func parseIP(s string) string {
+	ip := net.ParseIP(strings.TrimSpace(s))
+	if ip == nil {
+		return ""
+	}
+	return ip.String()
+}
+
+func inNets(addr string, nets []*net.IPNet) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package server
+
+import (
+	"net"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestResolveClientIP(t *testing.T) {
+	_, cloudflare, _ := net.ParseCIDR("173.245.48.0/20")
+	_, local, _ := net.ParseCIDR("10.0.0.0/8")
+	trusted := []*net.IPNet{cloudflare, local}
+
+	tests := []struct {
+		name    string
+		remote  string
+		headers map[string]string
+		want    string
+	}{
+		{"direct", "203.0.113.7:5123", nil, "203.0.113.7"},
+		{"untrusted peer spoofing headers", "203.0.113.7:5123", map[string]string{
+			"CF-Connecting-IP": "1.2.3.4",
+			"X-Forwarded-For":  "1.2.3.4",
+		}, "203.0.113.7"},
+		{"cloudflare", "173.245.48.10:443", map[string]string{"CF-Connecting-IP": "198.51.100.2"}, "198.51.100.2"},
+		{"forwarded for chain", "10.0.0.2:443", map[string]string{
+			// The client prepended a fake hop; the trusted proxies appended the rest
+			"X-Forwarded-For": "1.2.3.4, 198.51.100.2, 173.245.48.10",
+		}, "198.51.100.2"},
+		{"real ip", "10.0.0.2:443", map[string]string{"X-Real-IP": "198.51.100.3"}, "198.51.100.3"},
+		{"invalid headers", "10.0.0.2:443", map[string]string{"CF-Connecting-IP": "nope", "X-Forwarded-For": "also nope"}, "10.0.0.2"},
+		{"ipv6", "[2001:db8::1]:443", nil, "2001:db8::1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/h/ep_1", nil)
+			r.RemoteAddr = tt.remote
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			if got := resolveClientIP(r, trusted); got != tt.want {
+				t.Errorf("client IP = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"net"
 	"net/http"
 	"time"
 
@@ -16,35 +17,48 @@ import (
 
 // Server wraps the HTTP server with graceful shutdown.
 type Server struct {
-	server *http.Server
-	router chi.Router
+	server         *http.Server
+	router         chi.Router
+	trustedProxies []*net.IPNet // See SetTrustedProxies
+	ingestPrefix   string       // See SetIngestPathPrefix
 }
 
 // New creates a new server with the given options.
 func New(addr string) *Server {
 	r := chi.NewRouter()
+	s := &Server{router: r, ingestPrefix: "/h/"}
 
 	// Middleware
 	r.Use(middleware.RequestID)
-	r.Use(middleware.RealIP)
+	r.Use(s.realIP)
 	r.Use(LoggingMiddleware)
-	r.Use(middleware.Recoverer)
-	r.Use(CORSMiddleware)
+	r.Use(RecoverMiddleware)
+	r.Use(s.cors)
 
-	s := &Server{
-		server: &http.Server{
-			Addr:    addr,
-			Handler: h2c.NewHandler(r, &http2.Server{}),
-			// No read/write timeouts for streaming connections
-			// Timeouts are handled at the application level (heartbeats)
-			IdleTimeout: 120 * time.Second,
-		},
-		router: r,
+	s.server = &http.Server{
+		Addr:    addr,
+		Handler: h2c.NewHandler(r, &http2.Server{}),
+		// No read/write timeouts for streaming connections
+		// Timeouts are handled at the application level (heartbeats)
+		IdleTimeout: 120 * time.Second,
 	}
 
 	return s
 }
 
+// SetIngestPathPrefix sets the path prefix webhooks are received under, /h/
+// by default, which CORS headers are left to the handler for. Must be called
+// before Start.
+func (s *Server) SetIngestPathPrefix(prefix string) {
+	s.ingestPrefix = prefix
+}
+
+func (s *Server) cors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		CORSMiddleware(s.ingestPrefix, next).ServeHTTP(w, r)
+	})
+}
+
 // Router returns the chi router for adding routes.
 func (s *Server) Router() chi.Router {
 	return s.router
@@ -2,9 +2,14 @@ package service
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"strings"
+
+	"hooks.dx314.com/internal/logging"
 )
 
 // ServiceConfig holds configuration for the service.
@@ -13,8 +18,32 @@ type ServiceConfig struct {
 	WorkingDir  string // Working directory for the service
 	LogPath     string // Path for log output (macOS only)
 	UserService bool   // Install as user service (no sudo)
+	Release     string // Version reported with errors, e.g. hookly@0.1.0
+	// RecentLogs keeps the log lines a logs command from the edge reads
+	RecentLogs *logging.Recent
+
+	// Unit customization, written into the service definition at install
+	RunAs   string            // User to run as (system services only)
+	Env     map[string]string // Extra environment variables
+	Nice    int               // CPU priority from -20 (highest) to 19; 0 keeps the default
+	IOClass string            // I/O scheduling class: "", "best-effort", "idle" or "realtime"
+	Restart string            // "always" (default), "on-failure" or "never"
+	After   []string          // systemd units to start after, e.g. network-online.target
+
+	// NoAutostart installs without starting at boot or login (systemd only)
+	NoAutostart bool
 }
 
+// Restart policies.
+const (
+	RestartAlways    = "always"
+	RestartOnFailure = "on-failure"
+	RestartNever     = "never"
+)
+
+// envKeyPattern matches valid environment variable names.
+var envKeyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 // DefaultServiceConfig returns platform-appropriate default configuration.
 func DefaultServiceConfig(userService bool) *ServiceConfig {
 	cfg := &ServiceConfig{
@@ -103,6 +132,47 @@ func (c *ServiceConfig) ValidateForInstall() error {
 		return errors.New("cannot install service from temporary location\n\nInstall hookly first with: go install hooks.dx314.com/hookly@latest")
 	}
 
+	return c.validateUnitOptions()
+}
+
+// validateUnitOptions checks the unit customization options.
+func (c *ServiceConfig) validateUnitOptions() error {
+	if c.RunAs != "" && c.UserService {
+		return errors.New("--run-as is only supported for system services; user services run as you")
+	}
+	for key, value := range c.Env {
+		if !envKeyPattern.MatchString(key) {
+			return fmt.Errorf("invalid environment variable name %q", key)
+		}
+		if strings.ContainsAny(value, "\n\r") {
+			return fmt.Errorf("environment variable %s must not contain newlines", key)
+		}
+	}
+	if c.Nice < -20 || c.Nice > 19 {
+		return fmt.Errorf("nice must be between -20 and 19, got %d", c.Nice)
+	}
+	if c.UserService && (c.Nice < 0 || c.IOClass == "realtime") {
+		return errors.New("raising priority (negative nice, realtime I/O) requires a system service")
+	}
+	switch c.IOClass {
+	case "", "best-effort", "idle", "realtime":
+	default:
+		return fmt.Errorf("invalid I/O class %q: expected best-effort, idle or realtime", c.IOClass)
+	}
+	switch c.Restart {
+	case "", RestartAlways, RestartOnFailure, RestartNever:
+	default:
+		return fmt.Errorf("invalid restart policy %q: expected always, on-failure or never", c.Restart)
+	}
+	if c.NoAutostart && runtime.GOOS == "darwin" {
+		// launchd starts KeepAlive jobs whenever they are loaded
+		return errors.New("--enable=false is not supported by launchd")
+	}
+	for _, unit := range c.After {
+		if unit == "" || strings.ContainsAny(unit, " \t\n") {
+			return fmt.Errorf("invalid unit name %q", unit)
+		}
+	}
 	return nil
 }
 
@@ -123,7 +193,7 @@ func isTemporaryPath(path string) bool {
 	return false
 }
 
-// contains checks if s contains substr (simple implementation to avoid strings import).
+// contains checks if s contains substr.
 func contains(s, substr string) bool {
 	for i := 0; i <= len(s)-len(substr); i++ {
 		if s[i:i+len(substr)] == substr {
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+
+	"hooks.dx314.com/internal/clock"
+)
+
+const (
+	// crashLoopThreshold starts within crashLoopWindow put the service in a
+	// cool-down.
+	crashLoopThreshold = 5
+	crashLoopWindow    = 10 * time.Minute
+	// crashLoopCooldown is the retry interval while cooling down.
+	crashLoopCooldown = 15 * time.Minute
+	// restartDelay is the pause before restarting a relay that stopped.
+	restartDelay = 10 * time.Second
+)
+
+// crashLoop detects a relay that keeps failing, for example on an invalid
+// config, so the service retries every crashLoopCooldown instead of in a
+// tight loop. Starts are persisted, so restarts by the service manager after
+// a crash count too. A run lasting crashLoopWindow clears the history.
+type crashLoop struct {
+	path  string // State file; empty keeps the state in memory only
+	clock clock.Clock
+	state crashLoopState
+}
+
+type crashLoopState struct {
+	Starts      []time.Time `json:"starts"`
+	CoolingDown bool        `json:"cooling_down"`
+}
+
+// crashLoopPath returns the state file used by the service, in the running
+// user's cache directory.
+func crashLoopPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "hookly", "service-starts.json")
+}
+
+// loadCrashLoop reads the persisted start history from path. A missing or
+// unreadable file starts a fresh history.
+func loadCrashLoop(path string, c clock.Clock) *crashLoop {
+	l := &crashLoop{path: path, clock: clock.Or(c)}
+	if path == "" {
+		return l
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		json.Unmarshal(data, &l.state)
+	}
+	return l
+}
+
+// recent returns the starts within crashLoopWindow.
+func (l *crashLoop) recent() int {
+	cutoff := l.clock.Now().Add(-crashLoopWindow)
+	n := 0
+	for _, t := range l.state.Starts {
+		if t.After(cutoff) {
+			n++
+		}
+	}
+	return n
+}
+
+// coolingDown reports whether the next start should wait crashLoopCooldown.
+// Entering the cool-down sticks until a run lasts crashLoopWindow, so each
+// attempt while cooling down is a single retry rather than another burst.
+func (l *crashLoop) coolingDown() bool {
+	if !l.state.CoolingDown && l.recent() >= crashLoopThreshold {
+		l.state.CoolingDown = true
+		l.save()
+	}
+	return l.state.CoolingDown
+}
+
+// recordStart records a start, dropping starts outside the window.
+func (l *crashLoop) recordStart() {
+	cutoff := l.clock.Now().Add(-crashLoopWindow)
+	starts := l.state.Starts[:0]
+	for _, t := range l.state.Starts {
+		if t.After(cutoff) {
+			starts = append(starts, t)
+		}
+	}
+	l.state.Starts = append(starts, l.clock.Now())
+	l.save()
+}
+
+// forgetStart removes the last start, for a run stopped on purpose.
+func (l *crashLoop) forgetStart() {
+	if n := len(l.state.Starts); n > 0 {
+		l.state.Starts = l.state.Starts[:n-1]
+		l.save()
+	}
+}
+
+// reset clears the history after a stable run.
+func (l *crashLoop) reset() {
+	l.state = crashLoopState{}
+	l.save()
+}
+
+func (l *crashLoop) save() {
+	if l.path == "" {
+		return
+	}
+	data, _ := json.Marshal(l.state)
+	err := os.MkdirAll(filepath.Dir(l.path), 0o700)
+	if err == nil {
+		err = os.WriteFile(l.path, data, 0o600)
+	}
+	if err != nil {
+		slog.Debug("failed to save service start history", "path", l.path, "error", err)
+	}
+}
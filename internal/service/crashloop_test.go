@@ -0,0 +1,69 @@
+package service
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"hooks.dx314.com/internal/clock"
+)
+
+func TestCrashLoop(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hookly", "service-starts.json")
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	l := loadCrashLoop(path, clk)
+	for i := 0; i < crashLoopThreshold-1; i++ {
+		l.recordStart()
+		clk.Advance(restartDelay)
+	}
+	if l.coolingDown() {
+		t.Fatalf("cooling down after %d starts", crashLoopThreshold-1)
+	}
+
+	// Starts are persisted, so a restarted process sees them
+	l = loadCrashLoop(path, clk)
+	l.recordStart()
+	if !l.coolingDown() {
+		t.Fatalf("not cooling down after %d starts", crashLoopThreshold)
+	}
+
+	// The cool-down sticks once the starts age out of the window
+	clk.Advance(crashLoopCooldown)
+	if l.recent() != 0 || !loadCrashLoop(path, clk).coolingDown() {
+		t.Error("cool-down ended without a stable run")
+	}
+
+	// A stable run clears it
+	l.reset()
+	if loadCrashLoop(path, clk).coolingDown() {
+		t.Error("still cooling down after reset")
+	}
+}
+
+func TestCrashLoopWindow(t *testing.T) {
+	clk := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	l := loadCrashLoop("", clk)
+
+	// Starts spread wider than the window never trip the detector
+	for i := 0; i < 3*crashLoopThreshold; i++ {
+		l.recordStart()
+		if l.coolingDown() {
+			t.Fatalf("cooling down after start %d", i+1)
+		}
+		clk.Advance(crashLoopWindow / (crashLoopThreshold - 1))
+	}
+	if len(l.state.Starts) >= crashLoopThreshold {
+		t.Errorf("kept %d starts, want old ones dropped", len(l.state.Starts))
+	}
+
+	// Stopping on purpose doesn't count as a start
+	l = loadCrashLoop("", clk)
+	for i := 0; i < crashLoopThreshold; i++ {
+		l.recordStart()
+		l.forgetStart()
+	}
+	if l.coolingDown() {
+		t.Error("cooling down after deliberate restarts")
+	}
+}
@@ -0,0 +1,274 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// ErrNotInstalled is returned when no service definition is installed.
+var ErrNotInstalled = errors.New("service not installed")
+
+// InstalledCommand is the command an installed service definition runs.
+type InstalledCommand struct {
+	UnitPath   string // Service definition file (systemd unit or launchd plist)
+	Path       string // Executable
+	Args       []string
+	ConfigPath string // Value of --config, empty if not set
+}
+
+// ExecDrift describes an installed service whose executable is not the
+// running hookly binary, typically because a package manager moved it.
+type ExecDrift struct {
+	Installed string // Executable in the service definition
+	Current   string // Running executable
+	Missing   bool   // The installed executable no longer exists
+}
+
+func (d *ExecDrift) String() string {
+	if d.Missing {
+		return fmt.Sprintf("service runs %s, which no longer exists (current binary: %s)", d.Installed, d.Current)
+	}
+	return fmt.Sprintf("service runs %s, not the current binary %s", d.Installed, d.Current)
+}
+
+// UnitPath returns the path of the service definition for the platform.
+func UnitPath(userService bool) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil && userService {
+		return "", err
+	}
+	switch runtime.GOOS {
+	case "linux":
+		if userService {
+			return filepath.Join(home, ".config", "systemd", "user", serviceName+".service"), nil
+		}
+		return "/etc/systemd/system/" + serviceName + ".service", nil
+	case "darwin":
+		if userService {
+			return filepath.Join(home, "Library", "LaunchAgents", serviceName+".plist"), nil
+		}
+		return "/Library/LaunchDaemons/" + serviceName + ".plist", nil
+	default:
+		return "", fmt.Errorf("service definitions are not inspectable on %s", runtime.GOOS)
+	}
+}
+
+// ReadInstalledCommand reads the command from the installed service
+// definition. It returns ErrNotInstalled if there is none.
+func ReadInstalledCommand(userService bool) (*InstalledCommand, error) {
+	unitPath, err := UnitPath(userService)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(unitPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotInstalled
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var argv []string
+	if strings.HasSuffix(unitPath, ".plist") {
+		argv, err = parseLaunchdArgs(string(data))
+	} else {
+		argv, err = parseSystemdExecStart(string(data))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", unitPath, err)
+	}
+
+	cmd := &InstalledCommand{UnitPath: unitPath, Path: argv[0], Args: argv[1:]}
+	for i, arg := range cmd.Args {
+		if arg == "--config" && i+1 < len(cmd.Args) {
+			cmd.ConfigPath = cmd.Args[i+1]
+		} else if v, ok := strings.CutPrefix(arg, "--config="); ok {
+			cmd.ConfigPath = v
+		}
+	}
+	return cmd, nil
+}
+
+// CheckExecutable compares the installed service's executable with the
+// running one. It returns nil if they are the same file, including through a
+// symlink such as Homebrew's bin/hookly.
+func CheckExecutable(userService bool) (*ExecDrift, error) {
+	installed, err := ReadInstalledCommand(userService)
+	if err != nil {
+		return nil, err
+	}
+	return installed.Drift()
+}
+
+// Drift compares the command's executable with the running one. It returns
+// nil if they are the same file.
+func (c *InstalledCommand) Drift() (*ExecDrift, error) {
+	current, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("determine executable path: %w", err)
+	}
+	return execDrift(c.Path, current), nil
+}
+
+// execDrift returns the drift between an installed and the current
+// executable, or nil if they resolve to the same file.
+func execDrift(installed, current string) *ExecDrift {
+	installedInfo, err := os.Stat(installed)
+	if err != nil {
+		return &ExecDrift{Installed: installed, Current: current, Missing: true}
+	}
+	if currentInfo, err := os.Stat(current); err == nil && os.SameFile(installedInfo, currentInfo) {
+		return nil
+	}
+	return &ExecDrift{Installed: installed, Current: current}
+}
+
+// Repair rewrites the installed service definition to run the current
+// executable with cfg.ConfigPath, keeping every other setting, and reloads
+// the service manager. The service should be stopped first.
+func Repair(cfg *ServiceConfig, installed *InstalledCommand) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("determine executable path: %w", err)
+	}
+	argv := []string{exe, "--service-mode", "--config", cfg.ConfigPath}
+
+	data, err := os.ReadFile(installed.UnitPath)
+	if err != nil {
+		return err
+	}
+	var rewritten string
+	if strings.HasSuffix(installed.UnitPath, ".plist") {
+		rewritten, err = rewriteLaunchdArgs(string(data), argv)
+	} else {
+		rewritten, err = rewriteSystemdExecStart(string(data), argv)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", installed.UnitPath, err)
+	}
+	if err := os.WriteFile(installed.UnitPath, []byte(rewritten), 0o644); err != nil {
+		return err
+	}
+
+	// launchd rereads the plist when the service is loaded again on start
+	if runtime.GOOS == "linux" {
+		args := []string{"daemon-reload"}
+		if cfg.UserService {
+			args = append(args, "--user")
+		}
+		if out, err := exec.Command("systemctl", args...).CombinedOutput(); err != nil {
+			return fmt.Errorf("systemctl daemon-reload: %w: %s", err, strings.TrimSpace(string(out)))
+		}
+	}
+	return nil
+}
+
+// rewriteSystemdExecStart replaces the command of a unit, in the format
+// parseSystemdExecStart reads.
+func rewriteSystemdExecStart(unit string, argv []string) (string, error) {
+	path := strings.ReplaceAll(argv[0], " ", `\x20`)
+	var b strings.Builder
+	b.WriteString("ExecStart=" + path)
+	for _, arg := range argv[1:] {
+		b.WriteString(` "` + strings.ReplaceAll(arg, `"`, `\"`) + `"`)
+	}
+
+	lines := strings.Split(unit, "\n")
+	found := false
+	for i, l := range lines {
+		switch {
+		case strings.HasPrefix(strings.TrimSpace(l), "ExecStart="):
+			lines[i] = b.String()
+			found = true
+		case strings.HasPrefix(strings.TrimSpace(l), "ConditionFileIsExecutable="):
+			lines[i] = "ConditionFileIsExecutable=" + path
+		}
+	}
+	if !found {
+		return "", errors.New("no ExecStart line")
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// rewriteLaunchdArgs replaces the ProgramArguments of a launchd plist.
+func rewriteLaunchdArgs(plist string, argv []string) (string, error) {
+	loc := launchdProgramArgs.FindStringSubmatchIndex(plist)
+	if loc == nil {
+		return "", errors.New("no ProgramArguments")
+	}
+	var b strings.Builder
+	for _, arg := range argv {
+		b.WriteString("\n\t\t<string>" + html.EscapeString(arg) + "</string>")
+	}
+	b.WriteString("\n\t")
+	return plist[:loc[2]] + b.String() + plist[loc[3]:], nil
+}
+
+// parseSystemdExecStart returns the argv of a unit's ExecStart line, as
+// written by the service library: the path with spaces escaped as \x20,
+// then double-quoted arguments.
+func parseSystemdExecStart(unit string) ([]string, error) {
+	var line string
+	for _, l := range strings.Split(unit, "\n") {
+		if v, ok := strings.CutPrefix(strings.TrimSpace(l), "ExecStart="); ok {
+			line = v
+			break
+		}
+	}
+	if line == "" {
+		return nil, errors.New("no ExecStart line")
+	}
+
+	path, rest, _ := strings.Cut(line, " ")
+	argv := []string{strings.ReplaceAll(path, `\x20`, " ")}
+	for rest = strings.TrimSpace(rest); rest != ""; rest = strings.TrimSpace(rest) {
+		if rest[0] != '"' {
+			arg, after, _ := strings.Cut(rest, " ")
+			argv = append(argv, arg)
+			rest = after
+			continue
+		}
+		var b strings.Builder
+		i := 1
+		for ; i < len(rest) && rest[i] != '"'; i++ {
+			if rest[i] == '\\' && i+1 < len(rest) {
+				i++
+			}
+			b.WriteByte(rest[i])
+		}
+		if i >= len(rest) {
+			return nil, errors.New("unterminated quote in ExecStart")
+		}
+		argv = append(argv, b.String())
+		rest = rest[i+1:]
+	}
+	return argv, nil
+}
+
+var (
+	launchdProgramArgs = regexp.MustCompile(`(?s)<key>ProgramArguments</key>\s*<array>(.*?)</array>`)
+	launchdString      = regexp.MustCompile(`(?s)<string>(.*?)</string>`)
+)
+
+// parseLaunchdArgs returns the ProgramArguments of a launchd plist.
+func parseLaunchdArgs(plist string) ([]string, error) {
+	m := launchdProgramArgs.FindStringSubmatch(plist)
+	if m == nil {
+		return nil, errors.New("no ProgramArguments")
+	}
+	var argv []string
+	for _, s := range launchdString.FindAllStringSubmatch(m[1], -1) {
+		argv = append(argv, html.UnescapeString(s[1]))
+	}
+	if len(argv) == 0 {
+		return nil, errors.New("empty ProgramArguments")
+	}
+	return argv, nil
+}
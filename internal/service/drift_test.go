@@ -0,0 +1,124 @@
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestParseSystemdExecStart(t *testing.T) {
+	unit := `[Unit]
+Description=Webhook relay client
+ConditionFileIsExecutable=/opt/my\x20tools/hookly
+
+[Service]
+StartLimitInterval=5
+ExecStart=/opt/my\x20tools/hookly "--service-mode" "--config" "/home/me/hookly \"prod\".yaml"
+Restart=always
+`
+	argv, err := parseSystemdExecStart(unit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/opt/my tools/hookly", "--service-mode", "--config", `/home/me/hookly "prod".yaml`}
+	if !slices.Equal(argv, want) {
+		t.Errorf("argv = %q, want %q", argv, want)
+	}
+
+	if _, err := parseSystemdExecStart("[Service]\nRestart=always\n"); err == nil {
+		t.Error("expected error for unit without ExecStart")
+	}
+	if _, err := parseSystemdExecStart(`ExecStart=/bin/hookly "--config`); err == nil {
+		t.Error("expected error for unterminated quote")
+	}
+}
+
+func TestParseLaunchdArgs(t *testing.T) {
+	plist := `<?xml version="1.0" encoding="UTF-8"?>
+<plist version="1.0">
+<dict>
+	<key>Label</key>
+	<string>hookly</string>
+	<key>ProgramArguments</key>
+	<array>
+		<string>/opt/homebrew/Cellar/hookly/0.1.0/bin/hookly</string>
+		<string>--service-mode</string>
+		<string>--config</string>
+		<string>/Users/me/R&amp;D/hookly.yaml</string>
+	</array>
+	<key>KeepAlive</key>
+	<true/>
+</dict>
+</plist>`
+	argv, err := parseLaunchdArgs(plist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"/opt/homebrew/Cellar/hookly/0.1.0/bin/hookly", "--service-mode", "--config", "/Users/me/R&D/hookly.yaml"}
+	if !slices.Equal(argv, want) {
+		t.Errorf("argv = %q, want %q", argv, want)
+	}
+
+	if _, err := parseLaunchdArgs("<plist><dict></dict></plist>"); err == nil {
+		t.Error("expected error for plist without ProgramArguments")
+	}
+}
+
+func TestExecDrift(t *testing.T) {
+	dir := t.TempDir()
+	current := filepath.Join(dir, "Cellar", "hookly")
+	other := filepath.Join(dir, "other-hookly")
+	link := filepath.Join(dir, "bin-hookly")
+	os.MkdirAll(filepath.Dir(current), 0o755)
+	for _, p := range []string{current, other} {
+		if err := os.WriteFile(p, []byte("#!/bin/sh\n"), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(current, link); err != nil {
+		t.Fatal(err)
+	}
+
+	if d := execDrift(current, current); d != nil {
+		t.Errorf("same path: drift = %v", d)
+	}
+	if d := execDrift(link, current); d != nil {
+		t.Errorf("symlink to current: drift = %v", d)
+	}
+	if d := execDrift(other, current); d == nil || d.Missing {
+		t.Errorf("different binary: drift = %+v", d)
+	}
+	if d := execDrift(filepath.Join(dir, "Cellar", "0.0.9", "hookly"), current); d == nil || !d.Missing {
+		t.Errorf("moved binary: drift = %+v", d)
+	}
+}
+
+func TestRewriteCommand(t *testing.T) {
+	argv := []string{"/opt/homebrew/Cellar/hookly/0.2.0/bin/hookly", "--service-mode", "--config", "/Users/me/my hookly.yaml"}
+
+	unit := "[Unit]\nConditionFileIsExecutable=/old/hookly\n\n[Service]\nExecStart=/old/hookly \"--service-mode\"\nNice=5\n"
+	rewritten, err := rewriteSystemdExecStart(unit, argv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := parseSystemdExecStart(rewritten); !slices.Equal(got, argv) {
+		t.Errorf("systemd argv = %q, want %q", got, argv)
+	}
+	if !strings.Contains(rewritten, "ConditionFileIsExecutable="+argv[0]+"\n") || !strings.Contains(rewritten, "Nice=5\n") {
+		t.Errorf("rewritten unit:\n%s", rewritten)
+	}
+
+	plist := "<dict>\n\t<key>ProgramArguments</key>\n\t<array>\n\t\t<string>/old/hookly</string>\n\t</array>\n\t<key>Nice</key>\n</dict>"
+	rewritten, err = rewriteLaunchdArgs(plist, argv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := parseLaunchdArgs(rewritten); !slices.Equal(got, argv) {
+		t.Errorf("launchd argv = %q, want %q", got, argv)
+	}
+	if !strings.Contains(rewritten, "<key>Nice</key>") {
+		t.Errorf("rewritten plist:\n%s", rewritten)
+	}
+}
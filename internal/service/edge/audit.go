@@ -0,0 +1,67 @@
+package edge
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"connectrpc.com/connect"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/auth"
+	"hooks.dx314.com/internal/db"
+)
+
+// ListAuditEvents lists recorded management actions, newest first: the
+// user's own, and everyone's on the endpoints the request acts for, which
+// for an org are its members'.
+func (s *Service) ListAuditEvents(ctx context.Context, req *connect.Request[hooklyv1.ListAuditEventsRequest]) (*connect.Response[hooklyv1.ListAuditEventsResponse], error) {
+	ownerID, err := getOwnerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int64(50)
+	if req.Msg.Limit > 0 && req.Msg.Limit <= 500 {
+		limit = int64(req.Msg.Limit)
+	}
+	params := db.ListAuditEventsParams{
+		OwnerID: ownerID,
+		Limit:   limit,
+	}
+	if req.Msg.Action != nil {
+		params.Action = sql.NullString{String: *req.Msg.Action, Valid: true}
+	}
+	if req.Msg.Since != nil {
+		params.Since = sql.NullString{String: db.FormatTime(req.Msg.Since.AsTime()), Valid: true}
+	}
+
+	events, err := s.reads.ListAuditEvents(ctx, params)
+	if err != nil {
+		slog.Error("failed to list audit events", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list audit events"))
+	}
+
+	resp := &hooklyv1.ListAuditEventsResponse{Events: make([]*hooklyv1.AuditEvent, len(events))}
+	for i := range events {
+		resp.Events[i] = dbAuditEventToProto(&events[i])
+	}
+	return connect.NewResponse(resp), nil
+}
+
+func dbAuditEventToProto(ev *db.AuditLog) *hooklyv1.AuditEvent {
+	orgID, _ := auth.OrgFromOwnerID(ev.OwnerID)
+	return &hooklyv1.AuditEvent{
+		Id:         ev.ID,
+		UserId:     ev.UserID,
+		Username:   ev.Username,
+		TokenId:    ev.TokenID.String,
+		Action:     ev.Action,
+		Target:     ev.Target,
+		Ip:         ev.Ip,
+		Result:     ev.Result,
+		OccurredAt: sqlTimestamp(ev.CreatedAt),
+		OrgId:      orgID,
+	}
+}
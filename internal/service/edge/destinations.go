@@ -0,0 +1,122 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"slices"
+	"strings"
+
+	"connectrpc.com/connect"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/db"
+)
+
+// maxDestinations bounds the additional destinations of an endpoint.
+const maxDestinations = 10
+
+// parseDestinations validates the URLs of a destination list, dropping blanks
+// and repeats.
+func parseDestinations(list *hooklyv1.DestinationList) ([]string, error) {
+	var urls []string
+	for _, raw := range list.Urls {
+		raw = strings.TrimSpace(raw)
+		if raw == "" || slices.Contains(urls, raw) {
+			continue
+		}
+		u, err := url.Parse(raw)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("destination %q must be an http or https URL", raw))
+		}
+		urls = append(urls, raw)
+	}
+	if len(urls) > maxDestinations {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("at most %d additional destinations", maxDestinations))
+	}
+	return urls, nil
+}
+
+// setDestinations makes urls the endpoint's additional destinations. Kept
+// destinations keep their ID, so their deliveries stay tracked. The caller
+// checks ownership of the endpoint.
+func setDestinations(ctx context.Context, queries *db.Queries, endpointID string, urls []string) error {
+	existing, err := queries.ListEndpointDestinations(ctx, endpointID)
+	if err != nil {
+		return err
+	}
+	for _, d := range existing {
+		if !slices.Contains(urls, d.Url) {
+			if err := queries.DeleteEndpointDestination(ctx, db.DeleteEndpointDestinationParams{ID: d.ID, EndpointID: endpointID}); err != nil {
+				return err
+			}
+		}
+	}
+	for _, u := range urls {
+		if slices.ContainsFunc(existing, func(d db.EndpointDestination) bool { return d.Url == u }) {
+			continue
+		}
+		id, err := gonanoid.New()
+		if err != nil {
+			return err
+		}
+		if _, err := queries.CreateEndpointDestination(ctx, db.CreateEndpointDestinationParams{ID: id, EndpointID: endpointID, Url: u}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withDestinations adds the endpoint's additional destinations to ep. They
+// are left out of endpoint lists.
+func (s *Service) withDestinations(ctx context.Context, ep *hooklyv1.Endpoint) (*hooklyv1.Endpoint, error) {
+	destinations, err := s.queries.ListEndpointDestinations(ctx, ep.Id)
+	if err != nil {
+		slog.Error("failed to list destinations", "error", err, "id", ep.Id)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get endpoint"))
+	}
+	for _, d := range destinations {
+		ep.Destinations = append(ep.Destinations, &hooklyv1.Destination{Id: d.ID, Url: d.Url})
+	}
+	return ep, nil
+}
+
+// webhookDeliveries returns the delivery of a fanned-out webhook to each
+// destination, the endpoint's own first. Destinations removed since show
+// without a URL.
+func (s *Service) webhookDeliveries(ctx context.Context, wh *db.Webhook) ([]*hooklyv1.DestinationDelivery, error) {
+	deliveries, err := s.queries.ListWebhookDeliveries(ctx, wh.ID)
+	if err != nil || len(deliveries) == 0 {
+		return nil, err
+	}
+	endpoint, err := s.queries.GetEndpointByID(ctx, wh.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	destinations, err := s.queries.ListEndpointDestinations(ctx, wh.EndpointID)
+	if err != nil {
+		return nil, err
+	}
+	urls := map[string]string{"": endpoint.DestinationUrl}
+	for _, d := range destinations {
+		urls[d.ID] = d.Url
+	}
+
+	result := make([]*hooklyv1.DestinationDelivery, len(deliveries))
+	for i, d := range deliveries {
+		result[i] = &hooklyv1.DestinationDelivery{
+			DestinationId: d.DestinationID,
+			Url:           urls[d.DestinationID],
+			Status:        mapStringToWebhookStatus(d.Status),
+			Attempts:      int32(d.Attempts),
+			StatusCode:    int32(d.StatusCode.Int64),
+			ErrorMessage:  d.ErrorMessage.String,
+			LastAttemptAt: sqlNullTimestamp(d.LastAttemptAt),
+			DeliveredAt:   sqlNullTimestamp(d.DeliveredAt),
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/relay"
+)
+
+// hubCommandTimeout bounds the wait for a hub to answer a command.
+const hubCommandTimeout = 30 * time.Second
+
+// SendHubCommand runs a remote management command on one of the user's
+// connected hubs and returns its answer.
+func (s *Service) SendHubCommand(ctx context.Context, req *connect.Request[hooklyv1.SendHubCommandRequest]) (*connect.Response[hooklyv1.SendHubCommandResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Msg.HubId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("hub_id is required"))
+	}
+	if req.Msg.Command == hooklyv1.HubCommandType_HUB_COMMAND_TYPE_UNSPECIFIED {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("command is required"))
+	}
+	if req.Msg.Lines < 0 || req.Msg.Lines > relay.MaxLogLines {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("lines must be between 0 and %d", relay.MaxLogLines))
+	}
+
+	// Another user's hub is reported as not connected
+	conn := s.connMgr.Hub(req.Msg.HubId)
+	if conn == nil || conn.UserID() != userID {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("hub not connected"))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, hubCommandTimeout)
+	defer cancel()
+	result, err := conn.Command(ctx, &hooklyv1.HubCommand{Type: req.Msg.Command, Lines: req.Msg.Lines})
+	switch {
+	case errors.Is(err, relay.ErrHubCommandsUnsupported):
+		return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+	case errors.Is(err, relay.ErrHubDisconnected):
+		return nil, connect.NewError(connect.CodeUnavailable, err)
+	case errors.Is(err, context.DeadlineExceeded):
+		return nil, connect.NewError(connect.CodeDeadlineExceeded, errors.New("hub did not answer in time"))
+	case err != nil:
+		slog.Error("failed to send hub command", "hub_id", req.Msg.HubId, "command", req.Msg.Command.String(), "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to send command"))
+	}
+
+	slog.Info("hub command sent",
+		"hub_id", req.Msg.HubId,
+		"command", req.Msg.Command.String(),
+		"success", result.Success,
+		"user_id", userID,
+	)
+
+	return connect.NewResponse(&hooklyv1.SendHubCommandResponse{Result: result}), nil
+}
+
+// hubsToProto converts connected hubs for the status response.
+func hubsToProto(hubs []relay.HubInfo) []*hooklyv1.ConnectedHub {
+	out := make([]*hooklyv1.ConnectedHub, len(hubs))
+	for i, hub := range hubs {
+		out[i] = &hooklyv1.ConnectedHub{
+			HubId:           hub.HubID,
+			Transport:       hub.Transport,
+			EndpointIds:     hub.EndpointIDs,
+			ConnectedAt:     timestamppb.New(hub.ConnectedAt),
+			LastHeartbeatAt: timestamppb.New(hub.LastHeartbeat),
+			Paused:          hub.Paused,
+		}
+	}
+	return out
+}
@@ -0,0 +1,303 @@
+package edge
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"slices"
+
+	"connectrpc.com/connect"
+	gonanoid "github.com/matoous/go-nanoid/v2"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/auth"
+	"hooks.dx314.com/internal/db"
+)
+
+// ListOrgs lists the orgs the current user belongs to.
+func (s *Service) ListOrgs(ctx context.Context, _ *connect.Request[hooklyv1.ListOrgsRequest]) (*connect.Response[hooklyv1.ListOrgsResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orgs, err := s.queries.ListUserOrgs(ctx, userID)
+	if err != nil {
+		slog.Error("failed to list orgs", "error", err, "user_id", userID)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list orgs"))
+	}
+
+	resp := &hooklyv1.ListOrgsResponse{}
+	for _, o := range orgs {
+		resp.Orgs = append(resp.Orgs, &hooklyv1.Org{
+			Id:        o.ID,
+			Name:      o.Name,
+			CreatedAt: sqlTimestamp(o.CreatedAt),
+			Role:      mapStringToOrgRole(o.Role),
+		})
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// CreateOrg creates an org with the current user as its owner.
+func (s *Service) CreateOrg(ctx context.Context, req *connect.Request[hooklyv1.CreateOrgRequest]) (*connect.Response[hooklyv1.CreateOrgResponse], error) {
+	session := auth.GetSessionFromContext(ctx)
+	if session == nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+	if req.Msg.Name == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("name is required"))
+	}
+
+	id, err := gonanoid.New()
+	if err != nil {
+		slog.Error("failed to generate org id", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to create org"))
+	}
+	org, err := s.queries.CreateOrg(ctx, db.CreateOrgParams{ID: id, Name: req.Msg.Name, CreatedBy: session.UserID})
+	if err != nil {
+		slog.Error("failed to create org", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to create org"))
+	}
+	if _, err := s.queries.UpsertOrgMember(ctx, db.UpsertOrgMemberParams{
+		OrgID:    org.ID,
+		UserID:   session.UserID,
+		Username: session.Username,
+		Role:     auth.RoleOwner,
+	}); err != nil {
+		slog.Error("failed to add org owner", "error", err, "org_id", org.ID)
+		// An org without an owner couldn't be managed or deleted
+		if err := s.queries.DeleteOrg(ctx, org.ID); err != nil {
+			slog.Error("failed to delete org without owner", "error", err, "org_id", org.ID)
+		}
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to create org"))
+	}
+
+	slog.Info("org created", "org_id", org.ID, "user_id", session.UserID)
+	return connect.NewResponse(&hooklyv1.CreateOrgResponse{
+		Org: &hooklyv1.Org{
+			Id:        org.ID,
+			Name:      org.Name,
+			CreatedAt: sqlTimestamp(org.CreatedAt),
+			Role:      hooklyv1.OrgRole_ORG_ROLE_OWNER,
+		},
+	}), nil
+}
+
+// DeleteOrg deletes an org with its endpoints and their webhooks. Only
+// owners can delete an org.
+func (s *Service) DeleteOrg(ctx context.Context, req *connect.Request[hooklyv1.DeleteOrgRequest]) (*connect.Response[hooklyv1.DeleteOrgResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireOrgRole(ctx, req.Msg.Id, userID, auth.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	// Endpoints reference their owner by ID only, so they go first
+	endpointIDs, err := s.queries.DeleteEndpointsByOwner(ctx, auth.OrgOwnerID(req.Msg.Id))
+	for _, id := range endpointIDs {
+		s.endpoints.Invalidate(id)
+	}
+	if err != nil {
+		slog.Error("failed to delete org endpoints", "error", err, "org_id", req.Msg.Id)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to delete org"))
+	}
+	if err := s.queries.DeleteOrg(ctx, req.Msg.Id); err != nil {
+		slog.Error("failed to delete org", "error", err, "org_id", req.Msg.Id)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to delete org"))
+	}
+
+	slog.Info("org deleted", "org_id", req.Msg.Id, "user_id", userID, "endpoints", len(endpointIDs))
+	return connect.NewResponse(&hooklyv1.DeleteOrgResponse{EndpointsDeleted: int64(len(endpointIDs))}), nil
+}
+
+// ListOrgMembers lists an org's members, for any member.
+func (s *Service) ListOrgMembers(ctx context.Context, req *connect.Request[hooklyv1.ListOrgMembersRequest]) (*connect.Response[hooklyv1.ListOrgMembersResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireOrgRole(ctx, req.Msg.OrgId, userID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.queries.ListOrgMembers(ctx, req.Msg.OrgId)
+	if err != nil {
+		slog.Error("failed to list org members", "error", err, "org_id", req.Msg.OrgId)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list members"))
+	}
+
+	resp := &hooklyv1.ListOrgMembersResponse{}
+	for i := range members {
+		resp.Members = append(resp.Members, dbOrgMemberToProto(&members[i]))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// AddOrgMember adds a user to an org by GitHub username, or changes a
+// member's role. Only owners can add members, and the last owner can't be
+// demoted.
+func (s *Service) AddOrgMember(ctx context.Context, req *connect.Request[hooklyv1.AddOrgMemberRequest]) (*connect.Response[hooklyv1.AddOrgMemberResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireOrgRole(ctx, req.Msg.OrgId, userID, auth.RoleOwner); err != nil {
+		return nil, err
+	}
+	if req.Msg.Username == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("username is required"))
+	}
+	role := mapOrgRoleToString(req.Msg.Role)
+	if role == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("role is required"))
+	}
+
+	// Users are known once they have signed in
+	user, err := s.queries.GetUserSettingsByUsername(ctx, req.Msg.Username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("user not found - they must sign in to hookly once first"))
+	}
+	if err != nil {
+		slog.Error("failed to look up user", "error", err, "username", req.Msg.Username)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to add member"))
+	}
+
+	if role != auth.RoleOwner {
+		if err := s.keepOrgOwner(ctx, req.Msg.OrgId, user.UserID); err != nil {
+			return nil, err
+		}
+	}
+
+	member, err := s.queries.UpsertOrgMember(ctx, db.UpsertOrgMemberParams{
+		OrgID:    req.Msg.OrgId,
+		UserID:   user.UserID,
+		Username: user.Username,
+		Role:     role,
+	})
+	if err != nil {
+		slog.Error("failed to add org member", "error", err, "org_id", req.Msg.OrgId)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to add member"))
+	}
+
+	slog.Info("org member added", "org_id", req.Msg.OrgId, "member_id", user.UserID, "role", role, "user_id", userID)
+	return connect.NewResponse(&hooklyv1.AddOrgMemberResponse{Member: dbOrgMemberToProto(&member)}), nil
+}
+
+// RemoveOrgMember removes a member from an org. Owners can remove anyone
+// and other members only themselves; the last owner can't leave.
+func (s *Service) RemoveOrgMember(ctx context.Context, req *connect.Request[hooklyv1.RemoveOrgMemberRequest]) (*connect.Response[hooklyv1.RemoveOrgMemberResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if req.Msg.UserId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("user_id is required"))
+	}
+	if req.Msg.UserId == userID {
+		err = s.requireOrgRole(ctx, req.Msg.OrgId, userID)
+	} else {
+		err = s.requireOrgRole(ctx, req.Msg.OrgId, userID, auth.RoleOwner)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.keepOrgOwner(ctx, req.Msg.OrgId, req.Msg.UserId); err != nil {
+		return nil, err
+	}
+
+	removed, err := s.queries.RemoveOrgMember(ctx, db.RemoveOrgMemberParams{OrgID: req.Msg.OrgId, UserID: req.Msg.UserId})
+	if err != nil {
+		slog.Error("failed to remove org member", "error", err, "org_id", req.Msg.OrgId)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to remove member"))
+	}
+	if removed == 0 {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("member not found"))
+	}
+
+	slog.Info("org member removed", "org_id", req.Msg.OrgId, "member_id", req.Msg.UserId, "user_id", userID)
+	return connect.NewResponse(&hooklyv1.RemoveOrgMemberResponse{}), nil
+}
+
+// requireOrgRole checks that a user is a member of an org, with one of
+// roles if any are given. Orgs the user isn't a member of are reported as
+// not found.
+func (s *Service) requireOrgRole(ctx context.Context, orgID, userID string, roles ...string) error {
+	if orgID == "" {
+		return connect.NewError(connect.CodeInvalidArgument, errors.New("org id is required"))
+	}
+	member, err := s.queries.GetOrgMember(ctx, db.GetOrgMemberParams{OrgID: orgID, UserID: userID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return connect.NewError(connect.CodeNotFound, errors.New("org not found"))
+	}
+	if err != nil {
+		slog.Error("failed to get org member", "error", err, "org_id", orgID)
+		return connect.NewError(connect.CodeInternal, errors.New("failed to check org membership"))
+	}
+	if len(roles) > 0 && !slices.Contains(roles, member.Role) {
+		return connect.NewError(connect.CodePermissionDenied, errors.New("only org owners can do this"))
+	}
+	return nil
+}
+
+// keepOrgOwner refuses to remove or demote an org's last owner.
+func (s *Service) keepOrgOwner(ctx context.Context, orgID, userID string) error {
+	member, err := s.queries.GetOrgMember(ctx, db.GetOrgMemberParams{OrgID: orgID, UserID: userID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		slog.Error("failed to get org member", "error", err, "org_id", orgID)
+		return connect.NewError(connect.CodeInternal, errors.New("failed to check org owners"))
+	}
+	if member.Role != auth.RoleOwner {
+		return nil
+	}
+	owners, err := s.queries.CountOrgOwners(ctx, orgID)
+	if err != nil {
+		slog.Error("failed to count org owners", "error", err, "org_id", orgID)
+		return connect.NewError(connect.CodeInternal, errors.New("failed to check org owners"))
+	}
+	if owners <= 1 {
+		return connect.NewError(connect.CodeFailedPrecondition, errors.New("an org needs an owner - add another owner first, or delete the org"))
+	}
+	return nil
+}
+
+func dbOrgMemberToProto(m *db.OrgMember) *hooklyv1.OrgMember {
+	return &hooklyv1.OrgMember{
+		UserId:    m.UserID,
+		Username:  m.Username,
+		Role:      mapStringToOrgRole(m.Role),
+		CreatedAt: sqlTimestamp(m.CreatedAt),
+	}
+}
+
+func mapOrgRoleToString(role hooklyv1.OrgRole) string {
+	switch role {
+	case hooklyv1.OrgRole_ORG_ROLE_OWNER:
+		return auth.RoleOwner
+	case hooklyv1.OrgRole_ORG_ROLE_MEMBER:
+		return auth.RoleMember
+	case hooklyv1.OrgRole_ORG_ROLE_VIEWER:
+		return auth.RoleViewer
+	default:
+		return ""
+	}
+}
+
+func mapStringToOrgRole(s string) hooklyv1.OrgRole {
+	switch s {
+	case auth.RoleOwner:
+		return hooklyv1.OrgRole_ORG_ROLE_OWNER
+	case auth.RoleMember:
+		return hooklyv1.OrgRole_ORG_ROLE_MEMBER
+	case auth.RoleViewer:
+		return hooklyv1.OrgRole_ORG_ROLE_VIEWER
+	default:
+		return hooklyv1.OrgRole_ORG_ROLE_UNSPECIFIED
+	}
+}
@@ -2,46 +2,108 @@
 package edge
 
 import (
+	"cmp"
 	"context"
 	"database/sql"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"log/slog"
+	"maps"
+	"net"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"connectrpc.com/connect"
+	gonanoid "github.com/matoous/go-nanoid/v2"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
 	"hooks.dx314.com/internal/auth"
+	"hooks.dx314.com/internal/buildinfo"
 	"hooks.dx314.com/internal/config"
 	"hooks.dx314.com/internal/db"
 	"hooks.dx314.com/internal/id"
+	"hooks.dx314.com/internal/logging"
+	"hooks.dx314.com/internal/notify"
+	"hooks.dx314.com/internal/region"
 	"hooks.dx314.com/internal/relay"
+	"hooks.dx314.com/internal/tracing"
+	"hooks.dx314.com/internal/webhook"
 )
 
 // Service implements the EdgeService.
 type Service struct {
 	queries       *db.Queries
+	reads         *db.Queries // List, search and stats queries; queries unless SetReadQueries
+	endpoints     *db.EndpointCache
 	secretManager *db.SecretManager
 	connMgr       *relay.ConnectionManager
+	replayGuard   *webhook.ReplayGuard
+	telegram      *webhook.TelegramClient
 	cfg           *config.Config
+	scheduler     *webhook.Scheduler
+	regions       *region.Checker
+	logLevel      *slog.LevelVar
+	rateLimiter   *webhook.RateLimiter
+	tokens        *auth.TokenManager
 }
 
 // New creates a new EdgeService.
 func New(queries *db.Queries, secretManager *db.SecretManager, connMgr *relay.ConnectionManager, cfg *config.Config) *Service {
 	return &Service{
 		queries:       queries,
+		reads:         queries,
 		secretManager: secretManager,
 		connMgr:       connMgr,
+		replayGuard:   webhook.NewReplayGuard(queries, cfg.ReplayRateLimit, cfg.ReplayConfirmThreshold),
+		telegram:      webhook.NewTelegramClient(),
 		cfg:           cfg,
 	}
 }
 
-// generateID creates a new endpoint ID with maximum security.
-func (s *Service) generateID() string {
-	return id.NewEndpointID()
+// SetReadQueries serves the list, search and stats queries of the UI from a
+// read-only connection, so they don't wait on the writer that ingestion uses.
+// They may lag writes by as much as the connection's database does.
+func (s *Service) SetReadQueries(reads *db.Queries) {
+	s.reads = reads
+}
+
+// SetEndpointCache sets the endpoint cache of ingestion, which endpoint
+// changes invalidate.
+func (s *Service) SetEndpointCache(c *db.EndpointCache) {
+	s.endpoints = c
+}
+
+// SetScheduler sets the maintenance scheduler reported by GetStatus and run
+// by RunMaintenance.
+func (s *Service) SetScheduler(scheduler *webhook.Scheduler) {
+	s.scheduler = scheduler
+}
+
+// SetRegionChecker enables multi-region support: GetRegions reports region
+// health and endpoints homed in another region get that region's webhook URL.
+func (s *Service) SetRegionChecker(regions *region.Checker) {
+	s.regions = regions
+}
+
+// SetRateLimiter sets the ingestion rate limiter whose rejections GetStatus
+// reports.
+func (s *Service) SetRateLimiter(l *webhook.RateLimiter) {
+	s.rateLimiter = l
+}
+
+// SetTokenManager enables the API token RPCs. Without it they fail as
+// Unimplemented, as when GitHub auth is off.
+func (s *Service) SetTokenManager(m *auth.TokenManager) {
+	s.tokens = m
+}
+
+// SetLogLevelVar lets superusers change the edge's log level with SetLogLevel.
+func (s *Service) SetLogLevelVar(level *slog.LevelVar) {
+	s.logLevel = level
 }
 
 // getUserID extracts the user ID from the auth context.
@@ -54,9 +116,33 @@ func getUserID(ctx context.Context) (string, error) {
 	return session.UserID, nil
 }
 
+// getOwnerID returns the owner of the endpoints the request acts on: the
+// user, or the org selected with auth.OrgHeader, whose ID endpoints store in
+// place of a user ID. Returns the same errors as getUserID.
+func getOwnerID(ctx context.Context) (string, error) {
+	session := auth.GetSessionFromContext(ctx)
+	if session == nil {
+		return "", connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+	return session.OwnerID(), nil
+}
+
+// replayedBy describes who is making the request for a webhook's history:
+// the username, noting requests made with an API token.
+func replayedBy(ctx context.Context) string {
+	session := auth.GetSessionFromContext(ctx)
+	if session == nil {
+		return ""
+	}
+	if session.APIToken {
+		return session.Username + " (API token)"
+	}
+	return session.Username
+}
+
 // CreateEndpoint creates a new webhook endpoint.
 func (s *Service) CreateEndpoint(ctx context.Context, req *connect.Request[hooklyv1.CreateEndpointRequest]) (*connect.Response[hooklyv1.CreateEndpointResponse], error) {
-	userID, err := getUserID(ctx)
+	userID, err := getOwnerID(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -67,13 +153,10 @@ func (s *Service) CreateEndpoint(ctx context.Context, req *connect.Request[hookl
 	if msg.Name == "" {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("name is required"))
 	}
-	if msg.DestinationUrl == "" {
+	if msg.DestinationUrl == "" && !msg.Honeypot {
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("destination_url is required"))
 	}
 
-	// Generate ID
-	id := s.generateID()
-
 	// Encrypt signature secret if provided
 	var encryptedSecret []byte
 	if msg.SignatureSecret != "" {
@@ -118,32 +201,47 @@ func (s *Service) CreateEndpoint(ctx context.Context, req *connect.Request[hookl
 		}
 	}
 
+	var encryptedIngestAuth []byte
+	if msg.IngestAuth != nil {
+		encryptedIngestAuth, err = s.encryptIngestAuth(msg.IngestAuth)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create in database
-	endpoint, err := s.queries.CreateEndpoint(ctx, db.CreateEndpointParams{
-		ID:                            id,
-		UserID:                        userID,
-		Name:                          msg.Name,
-		ProviderType:                  providerType,
-		SignatureSecretEncrypted:      encryptedSecret,
-		VerificationConfigEncrypted:   encryptedVerificationConfig,
-		DestinationUrl:                msg.DestinationUrl,
+	params := db.CreateEndpointParams{
+		UserID:                      userID,
+		Name:                        msg.Name,
+		ProviderType:                providerType,
+		SignatureSecretEncrypted:    encryptedSecret,
+		VerificationConfigEncrypted: encryptedVerificationConfig,
+		DestinationUrl:              msg.DestinationUrl,
+		NotifyFirstEvent:            boolToInt64(msg.NotifyFirstEvent),
+		HomeRegion:                  s.cfg.Region,
+		IngestAuthEncrypted:         encryptedIngestAuth,
+		Honeypot:                    boolToInt64(msg.Honeypot),
+	}
+	endpoint, err := db.InsertWithID(id.NewEndpointID, func(endpointID string) (db.Endpoint, error) {
+		params.ID = endpointID
+		return s.queries.CreateEndpoint(ctx, params)
 	})
 	if err != nil {
 		slog.Error("failed to create endpoint", "error", err)
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to create endpoint"))
 	}
 
-	slog.Info("endpoint created", "id", id, "name", msg.Name, "user_id", userID)
+	slog.Info("endpoint created", "id", endpoint.ID, "name", msg.Name, "user_id", userID)
 
 	return connect.NewResponse(&hooklyv1.CreateEndpointResponse{
 		Endpoint:   s.dbEndpointToProto(&endpoint),
-		WebhookUrl: s.webhookURL(id),
+		WebhookUrl: s.webhookURL(&endpoint),
 	}), nil
 }
 
 // GetEndpoint retrieves an endpoint by ID.
 func (s *Service) GetEndpoint(ctx context.Context, req *connect.Request[hooklyv1.GetEndpointRequest]) (*connect.Response[hooklyv1.GetEndpointResponse], error) {
-	userID, err := getUserID(ctx)
+	userID, err := getOwnerID(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -164,15 +262,19 @@ func (s *Service) GetEndpoint(ctx context.Context, req *connect.Request[hooklyv1
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get endpoint"))
 	}
 
+	protoEp, err := s.withDestinations(ctx, s.dbEndpointToProto(&endpoint))
+	if err != nil {
+		return nil, err
+	}
 	return connect.NewResponse(&hooklyv1.GetEndpointResponse{
-		Endpoint:   s.dbEndpointToProto(&endpoint),
-		WebhookUrl: s.webhookURL(endpoint.ID),
+		Endpoint:   protoEp,
+		WebhookUrl: s.webhookURL(&endpoint),
 	}), nil
 }
 
-// ListEndpoints lists all endpoints with pagination.
+// ListEndpoints lists endpoints matching the request's filters with pagination.
 func (s *Service) ListEndpoints(ctx context.Context, req *connect.Request[hooklyv1.ListEndpointsRequest]) (*connect.Response[hooklyv1.ListEndpointsResponse], error) {
-	userID, err := getUserID(ctx)
+	userID, err := getOwnerID(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -193,10 +295,39 @@ func (s *Service) ListEndpoints(ctx context.Context, req *connect.Request[hookly
 		}
 	}
 
-	endpoints, err := s.queries.ListEndpoints(ctx, db.ListEndpointsParams{
-		UserID: userID,
-		Limit:  pageSize + 1, // Fetch one extra to check if there's a next page
-		Offset: offset,
+	// Build filters
+	var search, providerType, muted, inactiveDays, sortBy interface{}
+	if req.Msg.Search != "" {
+		search = db.EscapeLike(req.Msg.Search)
+	}
+	if req.Msg.ProviderType != hooklyv1.ProviderType_PROVIDER_TYPE_UNSPECIFIED {
+		providerType = mapProviderTypeToString(req.Msg.ProviderType)
+	}
+	if req.Msg.Muted != nil {
+		muted = boolToInt64(*req.Msg.Muted)
+	}
+	if req.Msg.InactiveDays < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("inactive_days must not be negative"))
+	}
+	if req.Msg.InactiveDays > 0 {
+		inactiveDays = int64(req.Msg.InactiveDays)
+	}
+	switch req.Msg.Sort {
+	case hooklyv1.EndpointSort_ENDPOINT_SORT_CREATED_ASC:
+		sortBy = "created_asc"
+	case hooklyv1.EndpointSort_ENDPOINT_SORT_LAST_RECEIVED:
+		sortBy = "last_received"
+	}
+
+	endpoints, err := s.reads.ListEndpoints(ctx, db.ListEndpointsParams{
+		UserID:       userID,
+		Search:       search,
+		ProviderType: providerType,
+		Muted:        muted,
+		InactiveDays: inactiveDays,
+		Sort:         sortBy,
+		Limit:        pageSize + 1, // Fetch one extra to check if there's a next page
+		Offset:       offset,
 	})
 	if err != nil {
 		slog.Error("failed to list endpoints", "error", err)
@@ -204,7 +335,13 @@ func (s *Service) ListEndpoints(ctx context.Context, req *connect.Request[hookly
 	}
 
 	// Get total count
-	totalCount, err := s.queries.CountEndpoints(ctx, userID)
+	totalCount, err := s.reads.CountEndpoints(ctx, db.CountEndpointsParams{
+		UserID:       userID,
+		Search:       search,
+		ProviderType: providerType,
+		Muted:        muted,
+		InactiveDays: inactiveDays,
+	})
 	if err != nil {
 		slog.Error("failed to count endpoints", "error", err)
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to count endpoints"))
@@ -233,11 +370,10 @@ func (s *Service) ListEndpoints(ctx context.Context, req *connect.Request[hookly
 
 // UpdateEndpoint updates an existing endpoint.
 func (s *Service) UpdateEndpoint(ctx context.Context, req *connect.Request[hooklyv1.UpdateEndpointRequest]) (*connect.Response[hooklyv1.UpdateEndpointResponse], error) {
-	userID, err := getUserID(ctx)
+	userID, err := getOwnerID(ctx)
 	if err != nil {
 		return nil, err
 	}
-
 	msg := req.Msg
 
 	if msg.Id == "" {
@@ -263,6 +399,45 @@ func (s *Service) UpdateEndpoint(ctx context.Context, req *connect.Request[hookl
 		}
 		params.Muted = sql.NullInt64{Int64: muted, Valid: true}
 	}
+	if msg.NotifyFirstEvent != nil {
+		params.NotifyFirstEvent = sql.NullInt64{Int64: boolToInt64(*msg.NotifyFirstEvent), Valid: true}
+	}
+	if msg.SloTarget != nil {
+		if *msg.SloTarget < 0 || *msg.SloTarget > 100 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("slo_target must be between 0 and 100"))
+		}
+		params.SloTarget = sql.NullFloat64{Float64: *msg.SloTarget, Valid: true}
+	}
+	if msg.SloLatencySeconds != nil {
+		if *msg.SloLatencySeconds <= 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("slo_latency_seconds must be positive"))
+		}
+		params.SloLatencySeconds = sql.NullInt64{Int64: int64(*msg.SloLatencySeconds), Valid: true}
+	}
+	if msg.SloWindowHours != nil {
+		if *msg.SloWindowHours <= 0 || *msg.SloWindowHours > maxSLOWindowHours {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("slo_window_hours must be between 1 and %d", maxSLOWindowHours))
+		}
+		params.SloWindowHours = sql.NullInt64{Int64: int64(*msg.SloWindowHours), Valid: true}
+	}
+	if msg.RejectDuplicates != nil {
+		params.RejectDuplicates = sql.NullInt64{Int64: boolToInt64(*msg.RejectDuplicates), Valid: true}
+	}
+	if msg.Honeypot != nil {
+		params.Honeypot = sql.NullInt64{Int64: boolToInt64(*msg.Honeypot), Valid: true}
+	}
+	if msg.ConflictAsDuplicate != nil {
+		params.ConflictAsDuplicate = sql.NullInt64{Int64: boolToInt64(*msg.ConflictAsDuplicate), Valid: true}
+	}
+	if msg.AnswerProbes != nil {
+		params.AnswerProbes = sql.NullInt64{Int64: boolToInt64(*msg.AnswerProbes), Valid: true}
+	}
+	if msg.RateLimitPerMinute != nil {
+		if *msg.RateLimitPerMinute < 0 {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("rate_limit_per_minute must not be negative"))
+		}
+		params.RateLimitPerMinute = sql.NullInt64{Int64: int64(*msg.RateLimitPerMinute), Valid: true}
+	}
 	if msg.SignatureSecret != nil {
 		encryptedSecret, err := s.secretManager.EncryptSecret(*msg.SignatureSecret)
 		if err != nil {
@@ -298,25 +473,140 @@ func (s *Service) UpdateEndpoint(ctx context.Context, req *connect.Request[hookl
 		params.VerificationConfigEncrypted = encryptedConfig
 	}
 
-	endpoint, err := s.queries.UpdateEndpoint(ctx, params)
+	// Checked before anything is written
+	var transform sql.NullString
+	if msg.Transform != nil {
+		if transform, err = transformToJSON(msg.Transform); err != nil {
+			return nil, err
+		}
+	}
+
+	var ingestResponse sql.NullString
+	if msg.IngestResponse != nil {
+		if ingestResponse, err = ingestResponseToJSON(msg.IngestResponse); err != nil {
+			return nil, err
+		}
+	}
+
+	if msg.RetryPolicy != nil {
+		policy := protoRetryPolicyToInternal(msg.RetryPolicy)
+		if err := policy.Validate(); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid retry policy: %w", err))
+		}
+		params.RetryMaxAttempts = sql.NullInt64{Int64: int64(policy.MaxAttempts), Valid: true}
+		params.RetryBackoffBaseSeconds = sql.NullInt64{Int64: int64(policy.BackoffBase / time.Second), Valid: true}
+		params.RetryMaxIntervalSeconds = sql.NullInt64{Int64: int64(policy.MaxInterval / time.Second), Valid: true}
+		params.RetryJitter = sql.NullFloat64{Float64: policy.Jitter, Valid: true}
+	}
+
+	if msg.PayloadLimits != nil {
+		limits := webhook.PayloadLimits{
+			MaxBytes:     msg.PayloadLimits.MaxBytes,
+			ContentTypes: msg.PayloadLimits.ContentTypes,
+		}
+		if err := limits.Validate(); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid payload limits: %w", err))
+		}
+		params.MaxPayloadBytes = sql.NullInt64{Int64: limits.MaxBytes, Valid: true}
+		params.AllowedContentTypes = sql.NullString{String: limits.ContentTypeList(), Valid: true}
+	}
+
+	var destinations []string
+	if msg.Destinations != nil {
+		if destinations, err = parseDestinations(msg.Destinations); err != nil {
+			return nil, err
+		}
+	}
+
+	var encryptedIngestAuth []byte
+	if msg.IngestAuth != nil {
+		if encryptedIngestAuth, err = s.encryptIngestAuth(msg.IngestAuth); err != nil {
+			return nil, err
+		}
+	}
+
+	// Every change is written, or none
+	var endpoint db.Endpoint
+	errNotFound := connect.NewError(connect.CodeNotFound, errors.New("endpoint not found"))
+	errUpdate := connect.NewError(connect.CodeInternal, errors.New("failed to update endpoint"))
+	err = db.WithTx(ctx, s.queries, func(q *db.Queries) error {
+		// Set separately: the update can't clear a column
+		if msg.IngestAuth != nil {
+			if err := q.SetEndpointIngestAuth(ctx, db.SetEndpointIngestAuthParams{
+				IngestAuthEncrypted: encryptedIngestAuth,
+				ID:                  msg.Id,
+				UserID:              userID,
+			}); err != nil {
+				slog.Error("failed to set ingest auth", "error", err, "id", msg.Id)
+				return errUpdate
+			}
+		}
+
+		if msg.Transform != nil {
+			if err := q.SetEndpointTransform(ctx, db.SetEndpointTransformParams{
+				Transform: transform,
+				ID:        msg.Id,
+				UserID:    userID,
+			}); err != nil {
+				slog.Error("failed to set transform", "error", err, "id", msg.Id)
+				return errUpdate
+			}
+		}
+
+		if msg.IngestResponse != nil {
+			if err := q.SetEndpointIngestResponse(ctx, db.SetEndpointIngestResponseParams{
+				IngestResponse: ingestResponse,
+				ID:             msg.Id,
+				UserID:         userID,
+			}); err != nil {
+				slog.Error("failed to set ingest response", "error", err, "id", msg.Id)
+				return errUpdate
+			}
+		}
+
+		var err error
+		endpoint, err = q.UpdateEndpoint(ctx, params)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return errNotFound
+			}
+			slog.Error("failed to update endpoint", "error", err, "id", msg.Id)
+			return errUpdate
+		}
+
+		// After the update, which checked ownership
+		if msg.Destinations != nil {
+			if err := setDestinations(ctx, q, msg.Id, destinations); err != nil {
+				slog.Error("failed to set destinations", "error", err, "id", msg.Id)
+				return errUpdate
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return nil, connect.NewError(connect.CodeNotFound, errors.New("endpoint not found"))
+		if connect.CodeOf(err) == connect.CodeUnknown {
+			slog.Error("failed to update endpoint", "error", err, "id", msg.Id)
+			return nil, errUpdate
 		}
-		slog.Error("failed to update endpoint", "error", err, "id", msg.Id)
-		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to update endpoint"))
+		return nil, err
 	}
+	// Only once committed, so no lookup caches the endpoint as it was
+	s.endpoints.Invalidate(msg.Id)
 
 	slog.Info("endpoint updated", "id", msg.Id)
 
+	protoEp, err := s.withDestinations(ctx, s.dbEndpointToProto(&endpoint))
+	if err != nil {
+		return nil, err
+	}
 	return connect.NewResponse(&hooklyv1.UpdateEndpointResponse{
-		Endpoint: s.dbEndpointToProto(&endpoint),
+		Endpoint: protoEp,
 	}), nil
 }
 
 // DeleteEndpoint deletes an endpoint.
 func (s *Service) DeleteEndpoint(ctx context.Context, req *connect.Request[hooklyv1.DeleteEndpointRequest]) (*connect.Response[hooklyv1.DeleteEndpointResponse], error) {
-	userID, err := getUserID(ctx)
+	userID, err := getOwnerID(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -339,6 +629,7 @@ func (s *Service) DeleteEndpoint(ctx context.Context, req *connect.Request[hookl
 	}
 
 	// Delete endpoint (webhooks cascade delete via FK)
+	defer s.endpoints.Invalidate(req.Msg.Id)
 	if err := s.queries.DeleteEndpoint(ctx, db.DeleteEndpointParams{
 		ID:     req.Msg.Id,
 		UserID: userID,
@@ -352,9 +643,337 @@ func (s *Service) DeleteEndpoint(ctx context.Context, req *connect.Request[hookl
 	return connect.NewResponse(&hooklyv1.DeleteEndpointResponse{}), nil
 }
 
+// GetSetupInstructions renders provider-specific setup steps for an endpoint.
+func (s *Service) GetSetupInstructions(ctx context.Context, req *connect.Request[hooklyv1.GetSetupInstructionsRequest]) (*connect.Response[hooklyv1.GetSetupInstructionsResponse], error) {
+	userID, err := getOwnerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Msg.EndpointId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("endpoint_id is required"))
+	}
+
+	endpoint, err := s.queries.GetEndpoint(ctx, db.GetEndpointParams{
+		ID:     req.Msg.EndpointId,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("endpoint not found"))
+		}
+		slog.Error("failed to get endpoint", "error", err, "id", req.Msg.EndpointId)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get endpoint"))
+	}
+
+	data := webhook.InstructionsData{
+		EndpointName: endpoint.Name,
+		WebhookURL:   s.webhookURL(&endpoint),
+		HasSecret:    len(endpoint.SignatureSecretEncrypted) > 0,
+	}
+	if endpoint.ProviderType == "custom" && len(endpoint.VerificationConfigEncrypted) > 0 {
+		decrypted, err := s.secretManager.DecryptSecret(endpoint.VerificationConfigEncrypted)
+		if err == nil {
+			data.Verification, _ = webhook.ParseVerificationConfig([]byte(decrypted))
+		}
+	}
+
+	instructions, err := webhook.RenderSetupInstructions(endpoint.ProviderType, data)
+	if err != nil {
+		slog.Error("failed to render setup instructions", "error", err, "id", endpoint.ID)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to render setup instructions"))
+	}
+
+	return connect.NewResponse(&hooklyv1.GetSetupInstructionsResponse{
+		WebhookUrl:   data.WebhookURL,
+		ProviderType: mapStringToProviderType(endpoint.ProviderType),
+		Instructions: instructions,
+	}), nil
+}
+
+// maxSLOWindowHours limits the SLO window to the delivered webhook retention
+// period, since older webhooks are no longer around to count.
+const maxSLOWindowHours = 7 * 24
+
+// GetEndpointStats returns the per-event-type breakdown of an endpoint's
+// webhooks and its delivery SLO compliance.
+func (s *Service) GetEndpointStats(ctx context.Context, req *connect.Request[hooklyv1.GetEndpointStatsRequest]) (*connect.Response[hooklyv1.GetEndpointStatsResponse], error) {
+	userID, err := getOwnerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Msg.EndpointId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("endpoint_id is required"))
+	}
+
+	endpoint, err := s.queries.GetEndpoint(ctx, db.GetEndpointParams{
+		ID:     req.Msg.EndpointId,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("endpoint not found"))
+		}
+		slog.Error("failed to get endpoint", "error", err, "id", req.Msg.EndpointId)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get endpoint"))
+	}
+
+	counts, err := s.reads.GetEventTypeCounts(ctx, db.GetEventTypeCountsParams{
+		UserID:     userID,
+		EndpointID: req.Msg.EndpointId,
+	})
+	if err != nil {
+		slog.Error("failed to get event type counts", "error", err, "id", req.Msg.EndpointId)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get endpoint stats"))
+	}
+
+	eventTypes := make([]*hooklyv1.EventTypeCount, len(counts))
+	for i, c := range counts {
+		eventTypes[i] = &hooklyv1.EventTypeCount{
+			EventType: c.EventType,
+			Count:     c.Count,
+		}
+	}
+
+	resp := &hooklyv1.GetEndpointStatsResponse{
+		EventTypes: eventTypes,
+	}
+
+	if endpoint.SloTarget > 0 {
+		status, err := webhook.ComputeSLO(ctx, s.reads, endpoint.ID, endpoint.SloTarget, endpoint.SloLatencySeconds, endpoint.SloWindowHours)
+		if err != nil {
+			slog.Error("failed to compute slo", "error", err, "id", endpoint.ID)
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get endpoint stats"))
+		}
+		resp.Slo = &hooklyv1.SLOCompliance{
+			Target:         status.Target,
+			LatencySeconds: int32(endpoint.SloLatencySeconds),
+			WindowHours:    int32(endpoint.SloWindowHours),
+			Total:          status.Total,
+			Met:            status.Met,
+			Compliance:     status.Compliance(),
+			Breached:       status.Breached(),
+		}
+	}
+
+	return connect.NewResponse(resp), nil
+}
+
+// GenerateEndpointSecret generates a strong signature secret in the endpoint
+// provider's format and stores it encrypted, replacing the current secret.
+// The secret is only returned by this call.
+func (s *Service) GenerateEndpointSecret(ctx context.Context, req *connect.Request[hooklyv1.GenerateEndpointSecretRequest]) (*connect.Response[hooklyv1.GenerateEndpointSecretResponse], error) {
+	userID, err := getOwnerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Msg.EndpointId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("endpoint_id is required"))
+	}
+
+	endpoint, err := s.queries.GetEndpoint(ctx, db.GetEndpointParams{
+		ID:     req.Msg.EndpointId,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("endpoint not found"))
+		}
+		slog.Error("failed to get endpoint", "error", err, "id", req.Msg.EndpointId)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get endpoint"))
+	}
+
+	secret, err := webhook.GenerateSecret(endpoint.ProviderType)
+	if err != nil {
+		slog.Error("failed to generate secret", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to generate secret"))
+	}
+	encrypted, err := s.secretManager.EncryptSecret(secret)
+	if err != nil {
+		slog.Error("failed to encrypt secret", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to encrypt secret"))
+	}
+	defer s.endpoints.Invalidate(endpoint.ID)
+	if _, err := s.queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		SignatureSecretEncrypted: encrypted,
+		ID:                       endpoint.ID,
+		UserID:                   userID,
+	}); err != nil {
+		slog.Error("failed to store secret", "error", err, "id", endpoint.ID)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to store secret"))
+	}
+
+	slog.Info("generated endpoint secret", "endpoint_id", endpoint.ID, "provider", endpoint.ProviderType)
+
+	return connect.NewResponse(&hooklyv1.GenerateEndpointSecretResponse{
+		Secret: secret,
+	}), nil
+}
+
+// maxSecretRevealsPerHour limits how many signature secrets a user can reveal
+// per hour, so a hijacked session can't quietly dump every secret.
+const maxSecretRevealsPerHour = 5
+
+// RevealEndpointSecret returns an endpoint's signature secret so it can be
+// configured at the provider again. It requires a web session (API tokens are
+// refused), is rate limited per user and every reveal is audit-logged. The
+// secret is re-encrypted with a fresh nonce on each reveal.
+func (s *Service) RevealEndpointSecret(ctx context.Context, req *connect.Request[hooklyv1.RevealEndpointSecretRequest]) (*connect.Response[hooklyv1.RevealEndpointSecretResponse], error) {
+	ownerID, err := getOwnerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// Reveals are limited and recorded per user, also inside an org
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if auth.GetSessionFromContext(ctx).APIToken {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("revealing secrets requires a web session, API tokens are not allowed"))
+	}
+
+	if req.Msg.EndpointId == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("endpoint_id is required"))
+	}
+
+	endpoint, err := s.queries.GetEndpoint(ctx, db.GetEndpointParams{
+		ID:     req.Msg.EndpointId,
+		UserID: ownerID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("endpoint not found"))
+		}
+		slog.Error("failed to get endpoint", "error", err, "id", req.Msg.EndpointId)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get endpoint"))
+	}
+	if len(endpoint.SignatureSecretEncrypted) == 0 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("endpoint has no signature secret"))
+	}
+
+	recent, err := s.queries.CountRecentSecretReveals(ctx, userID)
+	if err != nil {
+		slog.Error("failed to count secret reveals", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to reveal secret"))
+	}
+	if recent >= maxSecretRevealsPerHour {
+		return nil, connect.NewError(connect.CodeResourceExhausted, fmt.Errorf("at most %d secrets can be revealed per hour, try again later", maxSecretRevealsPerHour))
+	}
+
+	secret, err := s.secretManager.DecryptSecret(endpoint.SignatureSecretEncrypted)
+	if err != nil {
+		slog.Error("failed to decrypt secret", "error", err, "id", endpoint.ID)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to decrypt secret"))
+	}
+
+	// Never reveal without an audit record
+	auditID, err := gonanoid.New()
+	if err != nil {
+		slog.Error("failed to generate audit id", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to reveal secret"))
+	}
+	ipAddress := req.Peer().Addr
+	if host, _, err := net.SplitHostPort(ipAddress); err == nil {
+		ipAddress = host
+	}
+	if err := s.queries.RecordSecretReveal(ctx, db.RecordSecretRevealParams{
+		ID:         auditID,
+		UserID:     userID,
+		EndpointID: endpoint.ID,
+		IpAddress:  ipAddress,
+		UserAgent:  req.Header().Get("User-Agent"),
+	}); err != nil {
+		slog.Error("failed to record secret reveal", "error", err, "id", endpoint.ID)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to reveal secret"))
+	}
+
+	// Re-encrypt so the stored ciphertext changes with every read
+	reencrypted, err := s.secretManager.EncryptSecret(secret)
+	if err != nil {
+		slog.Error("failed to encrypt secret", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to encrypt secret"))
+	}
+	if _, err := s.queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		SignatureSecretEncrypted: reencrypted,
+		ID:                       endpoint.ID,
+		UserID:                   ownerID,
+	}); err != nil {
+		slog.Error("failed to store secret", "error", err, "id", endpoint.ID)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to store secret"))
+	}
+
+	slog.Warn("endpoint secret revealed",
+		"endpoint_id", endpoint.ID,
+		"user_id", userID,
+		"ip", ipAddress,
+		"audit_id", auditID,
+	)
+
+	return connect.NewResponse(&hooklyv1.RevealEndpointSecretResponse{
+		Secret: secret,
+	}), nil
+}
+
 // GetWebhook retrieves a webhook by ID.
 func (s *Service) GetWebhook(ctx context.Context, req *connect.Request[hooklyv1.GetWebhookRequest]) (*connect.Response[hooklyv1.GetWebhookResponse], error) {
-	userID, err := getUserID(ctx)
+	userID, err := getOwnerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id is required"))
+	}
+
+	wh, err := s.queries.GetWebhook(ctx, db.GetWebhookParams{
+		ID:     req.Msg.Id,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("webhook not found"))
+		}
+		slog.Error("failed to get webhook", "error", err, "id", req.Msg.Id)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get webhook"))
+	}
+
+	history, err := s.queries.ListWebhookStatusHistory(ctx, wh.ID)
+	if err != nil {
+		slog.Error("failed to get webhook status history", "error", err, "id", wh.ID)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get webhook"))
+	}
+
+	deliveries, err := s.webhookDeliveries(ctx, &wh)
+	if err != nil {
+		slog.Error("failed to get webhook deliveries", "error", err, "id", wh.ID)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get webhook"))
+	}
+
+	includePayload := req.Msg.IncludePayload == nil || *req.Msg.IncludePayload
+	if req.Msg.JsonPath == nil {
+		proto := dbWebhookToProto(&wh, includePayload)
+		proto.StatusHistory = dbStatusHistoryToProto(history)
+		return connect.NewResponse(&hooklyv1.GetWebhookResponse{Webhook: proto, Deliveries: deliveries}), nil
+	}
+
+	value, err := webhook.ExtractJSONPath(wh.Payload, *req.Msg.JsonPath)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+	proto := dbWebhookToProto(&wh, false)
+	proto.PayloadPreview, proto.PayloadTruncated = webhook.PayloadPreview(value)
+	if includePayload {
+		proto.Payload = value
+	}
+	proto.StatusHistory = dbStatusHistoryToProto(history)
+	return connect.NewResponse(&hooklyv1.GetWebhookResponse{Webhook: proto, Deliveries: deliveries}), nil
+}
+
+// GetWebhookPayload returns the full payload of a webhook.
+func (s *Service) GetWebhookPayload(ctx context.Context, req *connect.Request[hooklyv1.GetWebhookPayloadRequest]) (*connect.Response[hooklyv1.GetWebhookPayloadResponse], error) {
+	userID, err := getOwnerID(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -375,14 +994,14 @@ func (s *Service) GetWebhook(ctx context.Context, req *connect.Request[hooklyv1.
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get webhook"))
 	}
 
-	return connect.NewResponse(&hooklyv1.GetWebhookResponse{
-		Webhook: dbWebhookToProto(&webhook),
+	return connect.NewResponse(&hooklyv1.GetWebhookPayloadResponse{
+		Payload: webhook.Payload,
 	}), nil
 }
 
 // ListWebhooks lists webhooks with filters and pagination.
 func (s *Service) ListWebhooks(ctx context.Context, req *connect.Request[hooklyv1.ListWebhooksRequest]) (*connect.Response[hooklyv1.ListWebhooksResponse], error) {
-	userID, err := getUserID(ctx)
+	userID, err := getOwnerID(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -416,10 +1035,17 @@ func (s *Service) ListWebhooks(ctx context.Context, req *connect.Request[hooklyv
 		status = mapWebhookStatusToString(*msg.Status)
 	}
 
-	webhooks, err := s.queries.ListWebhooks(ctx, db.ListWebhooksParams{
+	var eventType interface{}
+	if msg.EventType != nil && *msg.EventType != "" {
+		eventType = *msg.EventType
+	}
+
+	webhooks, err := s.reads.ListWebhooks(ctx, db.ListWebhooksParams{
 		UserID:     userID,
 		EndpointID: endpointID,
 		Status:     status,
+		EventType:  eventType,
+		Purged:     boolToInt64(msg.Purged),
 		Limit:      pageSize + 1,
 		Offset:     offset,
 	})
@@ -429,10 +1055,12 @@ func (s *Service) ListWebhooks(ctx context.Context, req *connect.Request[hooklyv
 	}
 
 	// Get total count with filters
-	totalCount, err := s.queries.CountWebhooks(ctx, db.CountWebhooksParams{
+	totalCount, err := s.reads.CountWebhooks(ctx, db.CountWebhooksParams{
 		UserID:     userID,
 		EndpointID: endpointID,
 		Status:     status,
+		EventType:  eventType,
+		Purged:     boolToInt64(msg.Purged),
 	})
 	if err != nil {
 		slog.Error("failed to count webhooks", "error", err)
@@ -446,9 +1074,10 @@ func (s *Service) ListWebhooks(ctx context.Context, req *connect.Request[hooklyv
 		nextPageToken = strconv.FormatInt(offset+pageSize, 10)
 	}
 
+	includePayload := msg.IncludePayload == nil || *msg.IncludePayload
 	protoWebhooks := make([]*hooklyv1.Webhook, len(webhooks))
 	for i, wh := range webhooks {
-		protoWebhooks[i] = dbWebhookToProto(&wh)
+		protoWebhooks[i] = s.withPurgeExpiry(dbWebhookToProto(&wh, includePayload))
 	}
 
 	return connect.NewResponse(&hooklyv1.ListWebhooksResponse{
@@ -462,7 +1091,7 @@ func (s *Service) ListWebhooks(ctx context.Context, req *connect.Request[hooklyv
 
 // ReplayWebhook resets a webhook for re-delivery.
 func (s *Service) ReplayWebhook(ctx context.Context, req *connect.Request[hooklyv1.ReplayWebhookRequest]) (*connect.Response[hooklyv1.ReplayWebhookResponse], error) {
-	userID, err := getUserID(ctx)
+	userID, err := getOwnerID(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -471,33 +1100,143 @@ func (s *Service) ReplayWebhook(ctx context.Context, req *connect.Request[hookly
 		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id is required"))
 	}
 
-	webhook, err := s.queries.ResetWebhookForReplay(ctx, db.ResetWebhookForReplayParams{
-		ID:     req.Msg.Id,
-		UserID: userID,
-	})
+	wh, err := s.replayGuard.Replay(ctx, userID, req.Msg.Id, req.Msg.ConfirmToken, replayedBy(ctx))
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
+		var confirmErr *webhook.ConfirmationRequiredError
+		switch {
+		case errors.As(err, &confirmErr):
+			slog.Warn("replay requires confirmation", "id", req.Msg.Id, "pending", confirmErr.Pending)
+			return connect.NewResponse(&hooklyv1.ReplayWebhookResponse{
+				ConfirmationRequired: true,
+				ConfirmationToken:    confirmErr.Token,
+				PendingReplays:       int32(confirmErr.Pending),
+			}), nil
+		case errors.Is(err, webhook.ErrReplayRateLimited):
+			return nil, connect.NewError(connect.CodeResourceExhausted, errors.New("too many replays for this endpoint, try again in a minute"))
+		case errors.Is(err, webhook.ErrReplayHoneypot):
+			return nil, connect.NewError(connect.CodeFailedPrecondition, err)
+		case errors.Is(err, sql.ErrNoRows):
 			return nil, connect.NewError(connect.CodeNotFound, errors.New("webhook not found"))
 		}
 		slog.Error("failed to replay webhook", "error", err, "id", req.Msg.Id)
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to replay webhook"))
 	}
 
-	slog.Info("webhook replayed", "id", req.Msg.Id)
+	slog.Info("webhook replayed", "id", req.Msg.Id, "by", wh.ReplayedBy.String, "replay_count", wh.ReplayCount)
 
 	return connect.NewResponse(&hooklyv1.ReplayWebhookResponse{
-		Webhook: dbWebhookToProto(&webhook),
+		Webhook: dbWebhookToProto(&wh, false),
+	}), nil
+}
+
+// BulkReplayWebhooks resets the webhooks matching the filters for
+// re-delivery, dead letters by default.
+func (s *Service) BulkReplayWebhooks(ctx context.Context, req *connect.Request[hooklyv1.BulkReplayWebhooksRequest]) (*connect.Response[hooklyv1.BulkReplayWebhooksResponse], error) {
+	userID, err := getOwnerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := req.Msg
+	filter := webhook.BulkReplayFilter{
+		EndpointID: msg.GetEndpointId(),
+		MaxCount:   int(msg.MaxCount),
+	}
+	if msg.Status != hooklyv1.WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED {
+		filter.Status = mapWebhookStatusToString(msg.Status)
+	}
+	if msg.ReceivedAfter != nil {
+		filter.ReceivedAfter = msg.ReceivedAfter.AsTime()
+	}
+	if msg.ReceivedBefore != nil {
+		filter.ReceivedBefore = msg.ReceivedBefore.AsTime()
+	}
+	if err := filter.Validate(); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, err)
+	}
+
+	replayed, err := s.replayGuard.BulkReplay(ctx, userID, filter, msg.ConfirmToken, replayedBy(ctx))
+	if err != nil {
+		var confirmErr *webhook.ConfirmationRequiredError
+		switch {
+		case errors.As(err, &confirmErr):
+			slog.Warn("bulk replay requires confirmation", "endpoint_id", filter.EndpointID, "matching", confirmErr.Matching)
+			return connect.NewResponse(&hooklyv1.BulkReplayWebhooksResponse{
+				ConfirmationRequired: true,
+				ConfirmationToken:    confirmErr.Token,
+				MatchingCount:        int32(confirmErr.Matching),
+			}), nil
+		case errors.Is(err, webhook.ErrReplayRateLimited):
+			return nil, connect.NewError(connect.CodeResourceExhausted, errors.New("too many replays, try again in a minute"))
+		}
+		slog.Error("failed to bulk replay webhooks", "error", err, "endpoint_id", filter.EndpointID)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to replay webhooks"))
+	}
+
+	slog.Info("webhooks replayed in bulk", "endpoint_id", filter.EndpointID, "status", filter.Status, "count", replayed, "by", replayedBy(ctx))
+
+	return connect.NewResponse(&hooklyv1.BulkReplayWebhooksResponse{
+		ReplayedCount: int32(replayed),
+	}), nil
+}
+
+// UndeleteWebhook restores a webhook purged by retention cleanup, until the
+// grace period ends and it is deleted. It keeps its status, and retention
+// counts again from now.
+func (s *Service) UndeleteWebhook(ctx context.Context, req *connect.Request[hooklyv1.UndeleteWebhookRequest]) (*connect.Response[hooklyv1.UndeleteWebhookResponse], error) {
+	userID, err := getOwnerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Msg.Id == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("id is required"))
+	}
+
+	wh, err := s.queries.UndeleteWebhook(ctx, db.UndeleteWebhookParams{ID: req.Msg.Id, UserID: userID})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("purged webhook not found"))
+		}
+		slog.Error("failed to undelete webhook", "error", err, "id", req.Msg.Id)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to undelete webhook"))
+	}
+
+	slog.Info("webhook undeleted", "id", wh.ID, "by", replayedBy(ctx))
+
+	return connect.NewResponse(&hooklyv1.UndeleteWebhookResponse{
+		Webhook: dbWebhookToProto(&wh, false),
+	}), nil
+}
+
+// CancelPendingReplays marks all queued replays as failed.
+func (s *Service) CancelPendingReplays(ctx context.Context, req *connect.Request[hooklyv1.CancelPendingReplaysRequest]) (*connect.Response[hooklyv1.CancelPendingReplaysResponse], error) {
+	userID, err := getOwnerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelled, err := s.replayGuard.CancelPending(ctx, userID, req.Msg.GetEndpointId())
+	if err != nil {
+		slog.Error("failed to cancel pending replays", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to cancel pending replays"))
+	}
+
+	slog.Warn("pending replays cancelled", "endpoint_id", req.Msg.GetEndpointId(), "count", cancelled)
+
+	return connect.NewResponse(&hooklyv1.CancelPendingReplaysResponse{
+		CancelledCount: int32(cancelled),
 	}), nil
 }
 
 // GetStatus returns system status.
 func (s *Service) GetStatus(ctx context.Context, _ *connect.Request[hooklyv1.GetStatusRequest]) (*connect.Response[hooklyv1.GetStatusResponse], error) {
-	userID, err := getUserID(ctx)
+	userID, err := getOwnerID(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	stats, err := s.queries.GetQueueStats(ctx, userID)
+	stats, err := s.reads.GetQueueStats(ctx, userID)
 	if err != nil {
 		slog.Error("failed to get queue stats", "error", err)
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get status"))
@@ -545,6 +1284,13 @@ func (s *Service) GetStatus(ctx context.Context, _ *connect.Request[hooklyv1.Get
 		FailedCount:        failedCount,
 		DeadLetterCount:    deadLetterCount,
 		ConnectedEndpoints: connectedEndpoints,
+		ConnectedHubs:      hubsToProto(s.connMgr.Hubs(userID)),
+	}
+	status.RateLimitedEndpoints = s.rateLimitedEndpoints(ctx, userID)
+	if s.scheduler != nil {
+		for _, job := range s.scheduler.JobStatuses() {
+			status.MaintenanceJobs = append(status.MaintenanceJobs, maintenanceJobToProto(job))
+		}
 	}
 
 	return connect.NewResponse(&hooklyv1.GetStatusResponse{
@@ -552,6 +1298,175 @@ func (s *Service) GetStatus(ctx context.Context, _ *connect.Request[hooklyv1.Get
 	}), nil
 }
 
+// rateLimitedEndpoints returns the user's endpoints that had webhooks
+// rejected by the rate limiter, most rejected first.
+func (s *Service) rateLimitedEndpoints(ctx context.Context, userID string) []*hooklyv1.RateLimitedEndpoint {
+	if s.rateLimiter == nil {
+		return nil
+	}
+	rejected := s.rateLimiter.Rejected()
+	if len(rejected) == 0 {
+		return nil
+	}
+
+	// Only the user's own endpoints are returned
+	endpoints, err := s.queries.GetEndpointsByIDs(ctx, db.GetEndpointsByIDsParams{
+		UserID: userID,
+		Ids:    slices.Collect(maps.Keys(rejected)),
+	})
+	if err != nil {
+		slog.Error("failed to get rate limited endpoints", "error", err)
+		return nil
+	}
+	limited := make([]*hooklyv1.RateLimitedEndpoint, len(endpoints))
+	for i, ep := range endpoints {
+		limited[i] = &hooklyv1.RateLimitedEndpoint{
+			Id:            ep.ID,
+			Name:          ep.Name,
+			RejectedCount: rejected[ep.ID],
+		}
+	}
+	slices.SortFunc(limited, func(a, b *hooklyv1.RateLimitedEndpoint) int {
+		return cmp.Compare(b.RejectedCount, a.RejectedCount)
+	})
+	return limited
+}
+
+// GetRegions reports the health of the service's regions, so clients can
+// pick the nearest healthy one.
+func (s *Service) GetRegions(ctx context.Context, _ *connect.Request[hooklyv1.GetRegionsRequest]) (*connect.Response[hooklyv1.GetRegionsResponse], error) {
+	if _, err := getOwnerID(ctx); err != nil {
+		return nil, err
+	}
+
+	// Single-region services report just this edge
+	if s.regions == nil {
+		return connect.NewResponse(&hooklyv1.GetRegionsResponse{
+			Regions: []*hooklyv1.Region{{
+				Url:       s.cfg.BaseURL,
+				Healthy:   true,
+				CheckedAt: timestamppb.Now(),
+				Current:   true,
+			}},
+		}), nil
+	}
+
+	current := s.regions.Current().Name
+	statuses := s.regions.Check(ctx)
+	regions := make([]*hooklyv1.Region, len(statuses))
+	for i, st := range statuses {
+		regions[i] = &hooklyv1.Region{
+			Name:      st.Name,
+			Url:       st.URL,
+			Healthy:   st.Healthy,
+			LatencyMs: st.Latency.Milliseconds(),
+			Error:     st.Error,
+			CheckedAt: timestamppb.New(st.CheckedAt),
+			Current:   st.Name == current,
+		}
+	}
+
+	return connect.NewResponse(&hooklyv1.GetRegionsResponse{
+		CurrentRegion: current,
+		Regions:       regions,
+	}), nil
+}
+
+// GetVersion returns the edge's build info. It needs no auth, so clients can
+// compare versions before logging in.
+func (s *Service) GetVersion(_ context.Context, _ *connect.Request[hooklyv1.GetVersionRequest]) (*connect.Response[hooklyv1.GetVersionResponse], error) {
+	build := buildinfo.Get()
+	return connect.NewResponse(&hooklyv1.GetVersionResponse{
+		Version:   build.Version,
+		Commit:    build.Commit,
+		BuildDate: build.Date,
+		GoVersion: build.GoVersion,
+	}), nil
+}
+
+// GetActivityFeed returns recent deliveries and hub connection events.
+func (s *Service) GetActivityFeed(ctx context.Context, req *connect.Request[hooklyv1.GetActivityFeedRequest]) (*connect.Response[hooklyv1.GetActivityFeedResponse], error) {
+	userID, err := getOwnerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int64(50)
+	if req.Msg.Limit > 0 && req.Msg.Limit <= 200 {
+		limit = int64(req.Msg.Limit)
+	}
+	sinceHours := 24
+	if req.Msg.SinceHours > 0 {
+		sinceHours = int(req.Msg.SinceHours)
+	}
+	since := time.Now().UTC().Add(-time.Duration(sinceHours) * time.Hour)
+
+	events, err := s.reads.ListActivityEvents(ctx, db.ListActivityEventsParams{
+		UserID: userID,
+		Since:  db.FormatTime(since),
+		Limit:  limit,
+	})
+	if err != nil {
+		slog.Error("failed to list activity events", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get activity feed"))
+	}
+
+	items := make([]*hooklyv1.ActivityItem, len(events))
+	for i, ev := range events {
+		items[i] = dbActivityEventToProto(&ev)
+	}
+
+	return connect.NewResponse(&hooklyv1.GetActivityFeedResponse{
+		Items: items,
+	}), nil
+}
+
+// ListConnectionEvents returns when hubs connected and disconnected for the
+// user's endpoints, or one of them.
+func (s *Service) ListConnectionEvents(ctx context.Context, req *connect.Request[hooklyv1.ListConnectionEventsRequest]) (*connect.Response[hooklyv1.ListConnectionEventsResponse], error) {
+	userID, err := getOwnerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int64(50)
+	if req.Msg.Limit > 0 && req.Msg.Limit <= 500 {
+		limit = int64(req.Msg.Limit)
+	}
+	params := db.ListConnectionEventsParams{
+		UserID: userID,
+		Limit:  limit,
+	}
+	if req.Msg.EndpointId != nil {
+		if _, err := s.queries.GetEndpoint(ctx, db.GetEndpointParams{
+			ID:     *req.Msg.EndpointId,
+			UserID: userID,
+		}); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, connect.NewError(connect.CodeNotFound, errors.New("endpoint not found"))
+			}
+			slog.Error("failed to get endpoint", "error", err, "id", *req.Msg.EndpointId)
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get endpoint"))
+		}
+		params.EndpointID = sql.NullString{String: *req.Msg.EndpointId, Valid: true}
+	}
+
+	events, err := s.reads.ListConnectionEvents(ctx, params)
+	if err != nil {
+		slog.Error("failed to list connection events", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list connection events"))
+	}
+
+	protoEvents := make([]*hooklyv1.ConnectionEvent, len(events))
+	for i, ev := range events {
+		protoEvents[i] = dbConnectionEventToProto(&ev)
+	}
+
+	return connect.NewResponse(&hooklyv1.ListConnectionEventsResponse{
+		Events: protoEvents,
+	}), nil
+}
+
 // GetSettings returns system settings and user info.
 func (s *Service) GetSettings(ctx context.Context, _ *connect.Request[hooklyv1.GetSettingsRequest]) (*connect.Response[hooklyv1.GetSettingsResponse], error) {
 	session := auth.GetSessionFromContext(ctx)
@@ -566,16 +1481,44 @@ func (s *Service) GetSettings(ctx context.Context, _ *connect.Request[hooklyv1.G
 		themePreference = mapStringToThemePreference(userSettings.ThemePreference)
 	}
 
-	return connect.NewResponse(&hooklyv1.GetSettingsResponse{
-		BaseUrl:                      s.cfg.BaseURL,
-		GithubAuthEnabled:            s.cfg.GitHubAuthEnabled(),
-		TelegramNotificationsEnabled: s.cfg.TelegramEnabled(),
-		UserId:                       session.UserID,
-		Username:                     session.Username,
-		AvatarUrl:                    session.AvatarURL,
-		ThemePreference:              themePreference,
-		IsSuperuser:                  auth.IsSuperuser(session.Username),
-	}), nil
+	return connect.NewResponse(&hooklyv1.GetSettingsResponse{
+		BaseUrl:                      s.cfg.BaseURL,
+		GithubAuthEnabled:            s.cfg.GitHubAuthEnabled(),
+		TelegramNotificationsEnabled: s.cfg.TelegramEnabled(),
+		UserId:                       session.UserID,
+		Username:                     session.Username,
+		AvatarUrl:                    session.AvatarURL,
+		ThemePreference:              themePreference,
+		IsSuperuser:                  auth.IsSuperuser(session.Username),
+		DiscordNotificationsEnabled:  s.cfg.DiscordEnabled(),
+		EmailAvailable:               s.cfg.SMTP.Enabled(),
+	}), nil
+}
+
+// GetCurrentUser returns the current user's profile and, when the request
+// authenticated with an API token, that token's metadata.
+func (s *Service) GetCurrentUser(ctx context.Context, _ *connect.Request[hooklyv1.GetCurrentUserRequest]) (*connect.Response[hooklyv1.GetCurrentUserResponse], error) {
+	session := auth.GetSessionFromContext(ctx)
+	if session == nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	settings, err := s.userSettings(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	resp := &hooklyv1.GetCurrentUserResponse{User: settings}
+
+	if session.APIToken {
+		token, err := s.queries.GetAPIToken(ctx, db.GetAPITokenParams{ID: session.ID, UserID: session.UserID})
+		if err != nil {
+			slog.Error("failed to get api token", "error", err, "token_id", session.ID)
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get token"))
+		}
+		resp.Token = dbAPITokenToProto(&token)
+	}
+
+	return connect.NewResponse(resp), nil
 }
 
 // GetUserSettings returns the current user's settings.
@@ -585,27 +1528,31 @@ func (s *Service) GetUserSettings(ctx context.Context, _ *connect.Request[hookly
 		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
 	}
 
+	settings, err := s.userSettings(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&hooklyv1.GetUserSettingsResponse{Settings: settings}), nil
+}
+
+// userSettings returns the session user's settings, or defaults if they
+// have none yet.
+func (s *Service) userSettings(ctx context.Context, session *auth.Session) (*hooklyv1.UserSettings, error) {
 	settings, err := s.queries.GetUserSettings(ctx, session.UserID)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			// Return default settings if none exist
-			return connect.NewResponse(&hooklyv1.GetUserSettingsResponse{
-				Settings: &hooklyv1.UserSettings{
-					UserId:          session.UserID,
-					Username:        session.Username,
-					AvatarUrl:       session.AvatarURL,
-					ThemePreference: hooklyv1.ThemePreference_THEME_PREFERENCE_SYSTEM,
-					IsSuperuser:     auth.IsSuperuser(session.Username),
-				},
-			}), nil
+			return &hooklyv1.UserSettings{
+				UserId:          session.UserID,
+				Username:        session.Username,
+				AvatarUrl:       session.AvatarURL,
+				ThemePreference: hooklyv1.ThemePreference_THEME_PREFERENCE_SYSTEM,
+				IsSuperuser:     auth.IsSuperuser(session.Username),
+			}, nil
 		}
 		slog.Error("failed to get user settings", "error", err, "user_id", session.UserID)
 		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user settings"))
 	}
-
-	return connect.NewResponse(&hooklyv1.GetUserSettingsResponse{
-		Settings: dbUserSettingsToProto(&settings, auth.IsSuperuser(session.Username)),
-	}), nil
+	return dbUserSettingsToProto(&settings, auth.IsSuperuser(session.Username)), nil
 }
 
 // UpdateUserSettings updates the current user's settings.
@@ -618,6 +1565,22 @@ func (s *Service) UpdateUserSettings(ctx context.Context, req *connect.Request[h
 	msg := req.Msg
 	var settings db.UserSetting
 	var err error
+	// Checked first, so an invalid URL doesn't leave the other settings half-updated
+	if msg.DiscordWebhookUrl != nil && *msg.DiscordWebhookUrl != "" {
+		if err := notify.ValidateDiscordWebhookURL(*msg.DiscordWebhookUrl); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("discord webhook url: %w", err))
+		}
+	}
+	if msg.EmailAddress != nil && *msg.EmailAddress != "" {
+		if err := notify.ValidateEmailAddress(*msg.EmailAddress); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+	}
+	if msg.NotifyWebhookUrl != nil && *msg.NotifyWebhookUrl != "" {
+		if err := notify.ValidateWebhookURL(*msg.NotifyWebhookUrl); err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("notification webhook url: %w", err))
+		}
+	}
 
 	// Ensure user settings row exists (for users who logged in before migration)
 	_, err = s.queries.GetUserSettings(ctx, session.UserID)
@@ -691,6 +1654,125 @@ func (s *Service) UpdateUserSettings(ctx context.Context, req *connect.Request[h
 		slog.Info("user telegram settings updated", "user_id", session.UserID)
 	}
 
+	// Handle Discord settings update
+	if msg.DiscordWebhookUrl != nil || msg.DiscordEnabled != nil {
+		current, err := s.queries.GetUserSettings(ctx, session.UserID)
+		if err != nil {
+			slog.Error("failed to get user settings for update", "error", err, "user_id", session.UserID)
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user settings"))
+		}
+
+		params := db.UpdateUserDiscordSettingsParams{
+			UserID:                     session.UserID,
+			DiscordWebhookUrlEncrypted: current.DiscordWebhookUrlEncrypted,
+			DiscordEnabled:             current.DiscordEnabled,
+		}
+
+		// Update the webhook URL if provided
+		if msg.DiscordWebhookUrl != nil && *msg.DiscordWebhookUrl != "" {
+			encryptedURL, err := s.secretManager.EncryptSecret(*msg.DiscordWebhookUrl)
+			if err != nil {
+				slog.Error("failed to encrypt discord webhook url", "error", err)
+				return nil, connect.NewError(connect.CodeInternal, errors.New("failed to encrypt discord webhook url"))
+			}
+			params.DiscordWebhookUrlEncrypted = encryptedURL
+		}
+
+		if msg.DiscordEnabled != nil {
+			if *msg.DiscordEnabled {
+				params.DiscordEnabled = 1
+			} else {
+				params.DiscordEnabled = 0
+			}
+		}
+
+		settings, err = s.queries.UpdateUserDiscordSettings(ctx, params)
+		if err != nil {
+			slog.Error("failed to update discord settings", "error", err, "user_id", session.UserID)
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to update discord settings"))
+		}
+
+		slog.Info("user discord settings updated", "user_id", session.UserID)
+	}
+
+	// Handle email settings update
+	if msg.EmailAddress != nil || msg.EmailEnabled != nil {
+		current, err := s.queries.GetUserSettings(ctx, session.UserID)
+		if err != nil {
+			slog.Error("failed to get user settings for update", "error", err, "user_id", session.UserID)
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user settings"))
+		}
+
+		params := db.UpdateUserEmailSettingsParams{
+			UserID:       session.UserID,
+			EmailAddress: current.EmailAddress,
+			EmailEnabled: current.EmailEnabled,
+		}
+		if msg.EmailAddress != nil {
+			params.EmailAddress = sql.NullString{String: *msg.EmailAddress, Valid: *msg.EmailAddress != ""}
+		}
+		if msg.EmailEnabled != nil {
+			params.EmailEnabled = boolToInt64(*msg.EmailEnabled)
+		}
+
+		settings, err = s.queries.UpdateUserEmailSettings(ctx, params)
+		if err != nil {
+			slog.Error("failed to update email settings", "error", err, "user_id", session.UserID)
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to update email settings"))
+		}
+
+		slog.Info("user email settings updated", "user_id", session.UserID)
+	}
+
+	// Handle notification webhook settings update
+	if msg.NotifyWebhookUrl != nil || msg.NotifyWebhookSecret != nil || msg.NotifyWebhookEnabled != nil {
+		current, err := s.queries.GetUserSettings(ctx, session.UserID)
+		if err != nil {
+			slog.Error("failed to get user settings for update", "error", err, "user_id", session.UserID)
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user settings"))
+		}
+
+		params := db.UpdateUserNotifyWebhookSettingsParams{
+			UserID:                       session.UserID,
+			NotifyWebhookUrlEncrypted:    current.NotifyWebhookUrlEncrypted,
+			NotifyWebhookSecretEncrypted: current.NotifyWebhookSecretEncrypted,
+			NotifyWebhookEnabled:         current.NotifyWebhookEnabled,
+		}
+		for _, field := range []struct {
+			value     *string
+			encrypted *[]byte
+			name      string
+		}{
+			{msg.NotifyWebhookUrl, &params.NotifyWebhookUrlEncrypted, "notification webhook url"},
+			{msg.NotifyWebhookSecret, &params.NotifyWebhookSecretEncrypted, "notification webhook secret"},
+		} {
+			if field.value == nil || *field.value == "" {
+				continue
+			}
+			encrypted, err := s.secretManager.EncryptSecret(*field.value)
+			if err != nil {
+				slog.Error("failed to encrypt "+field.name, "error", err)
+				return nil, connect.NewError(connect.CodeInternal, errors.New("failed to encrypt "+field.name))
+			}
+			*field.encrypted = encrypted
+		}
+		if msg.NotifyWebhookEnabled != nil {
+			params.NotifyWebhookEnabled = boolToInt64(*msg.NotifyWebhookEnabled)
+		}
+		// Events are always signed
+		if params.NotifyWebhookEnabled != 0 && (len(params.NotifyWebhookUrlEncrypted) == 0 || len(params.NotifyWebhookSecretEncrypted) == 0) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("a notification webhook needs a URL and a signing secret"))
+		}
+
+		settings, err = s.queries.UpdateUserNotifyWebhookSettings(ctx, params)
+		if err != nil {
+			slog.Error("failed to update notification webhook settings", "error", err, "user_id", session.UserID)
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to update notification webhook settings"))
+		}
+
+		slog.Info("user notification webhook settings updated", "user_id", session.UserID)
+	}
+
 	// Handle theme preference update
 	if msg.ThemePreference != nil && *msg.ThemePreference != hooklyv1.ThemePreference_THEME_PREFERENCE_UNSPECIFIED {
 		themeStr := mapThemePreferenceToString(*msg.ThemePreference)
@@ -720,6 +1802,74 @@ func (s *Service) UpdateUserSettings(ctx context.Context, req *connect.Request[h
 	}), nil
 }
 
+// SendTestEmail sends a test email to the current user's notification address,
+// to check it before relying on it.
+func (s *Service) SendTestEmail(ctx context.Context, _ *connect.Request[hooklyv1.SendTestEmailRequest]) (*connect.Response[hooklyv1.SendTestEmailResponse], error) {
+	session := auth.GetSessionFromContext(ctx)
+	if session == nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+	if !s.cfg.SMTP.Enabled() {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("email isn't configured on this edge"))
+	}
+
+	settings, err := s.queries.GetUserSettings(ctx, session.UserID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.Error("failed to get user settings", "error", err, "user_id", session.UserID)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user settings"))
+	}
+	if !settings.EmailAddress.Valid {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("no email address saved"))
+	}
+
+	if err := notify.NewSMTPNotifier(s.cfg.SMTP, settings.EmailAddress.String, s.cfg.BaseURL).SendTest(ctx); err != nil {
+		slog.Warn("failed to send test email", "error", err, "user_id", session.UserID)
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("send test email: %w", err))
+	}
+	slog.Info("sent test email", "user_id", session.UserID)
+
+	return connect.NewResponse(&hooklyv1.SendTestEmailResponse{
+		EmailAddress: settings.EmailAddress.String,
+	}), nil
+}
+
+// SendTestNotifyWebhook sends a test event to the user's notification
+// webhook.
+func (s *Service) SendTestNotifyWebhook(ctx context.Context, _ *connect.Request[hooklyv1.SendTestNotifyWebhookRequest]) (*connect.Response[hooklyv1.SendTestNotifyWebhookResponse], error) {
+	session := auth.GetSessionFromContext(ctx)
+	if session == nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	settings, err := s.queries.GetUserSettings(ctx, session.UserID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		slog.Error("failed to get user settings", "error", err, "user_id", session.UserID)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to get user settings"))
+	}
+	if len(settings.NotifyWebhookUrlEncrypted) == 0 || len(settings.NotifyWebhookSecretEncrypted) == 0 {
+		return nil, connect.NewError(connect.CodeFailedPrecondition, errors.New("no notification webhook saved"))
+	}
+	webhookURL, err := s.secretManager.DecryptSecret(settings.NotifyWebhookUrlEncrypted)
+	if err != nil {
+		slog.Error("failed to decrypt notification webhook url", "error", err, "user_id", session.UserID)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to decrypt notification webhook"))
+	}
+	secret, err := s.secretManager.DecryptSecret(settings.NotifyWebhookSecretEncrypted)
+	if err != nil {
+		slog.Error("failed to decrypt notification webhook secret", "error", err, "user_id", session.UserID)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to decrypt notification webhook"))
+	}
+
+	eventID, err := notify.NewUserWebhookNotifier(webhookURL, secret, s.cfg.BaseURL).SendTest(ctx)
+	if err != nil {
+		return nil, connect.NewError(connect.CodeUnavailable, fmt.Errorf("send test event: %w", err))
+	}
+
+	return connect.NewResponse(&hooklyv1.SendTestNotifyWebhookResponse{
+		EventId: eventID,
+	}), nil
+}
+
 // GetSystemSettings returns system-wide settings (superuser only).
 func (s *Service) GetSystemSettings(ctx context.Context, _ *connect.Request[hooklyv1.GetSystemSettingsRequest]) (*connect.Response[hooklyv1.GetSystemSettingsResponse], error) {
 	session := auth.GetSessionFromContext(ctx)
@@ -746,35 +1896,188 @@ func (s *Service) GetSystemSettings(ctx context.Context, _ *connect.Request[hook
 
 	return connect.NewResponse(&hooklyv1.GetSystemSettingsResponse{
 		Settings: &hooklyv1.SystemSettings{
-			BaseUrl:               s.cfg.BaseURL,
-			GithubOrg:             s.cfg.GitHubOrg,
-			GithubAllowedUsers:    s.cfg.GitHubAllowedUsers,
-			SystemTelegramEnabled: s.cfg.TelegramEnabled(),
-			TotalUsers:            int32(totalUsers),
-			TotalEndpoints:        int32(totalEndpoints),
+			BaseUrl:                    s.cfg.BaseURL,
+			GithubOrg:                  s.cfg.GitHubOrg,
+			GithubAllowedUsers:         s.cfg.GitHubAllowedUsers,
+			SystemTelegramEnabled:      s.cfg.TelegramEnabled(),
+			TotalUsers:                 int32(totalUsers),
+			TotalEndpoints:             int32(totalEndpoints),
+			SystemDiscordEnabled:       s.cfg.DiscordEnabled(),
+			SystemEmailEnabled:         s.cfg.EmailEnabled(),
+			SystemNotifyWebhookEnabled: s.cfg.NotifyWebhookEnabled(),
 		},
 	}), nil
 }
 
-// webhookURL generates the webhook URL for an endpoint.
-func (s *Service) webhookURL(endpointID string) string {
-	return s.cfg.BaseURL + "/h/" + endpointID
+// RunMaintenance runs a scheduler maintenance job now (superuser only).
+func (s *Service) RunMaintenance(ctx context.Context, req *connect.Request[hooklyv1.RunMaintenanceRequest]) (*connect.Response[hooklyv1.RunMaintenanceResponse], error) {
+	session := auth.GetSessionFromContext(ctx)
+	if session == nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	if !auth.IsSuperuser(session.Username) {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("superuser access required"))
+	}
+
+	if s.scheduler == nil {
+		return nil, connect.NewError(connect.CodeUnavailable, errors.New("scheduler not running"))
+	}
+
+	name := req.Msg.Job
+	slog.Info("maintenance job requested", "job", name, "username", session.Username)
+	if err := s.scheduler.RunJob(ctx, name); err != nil {
+		if errors.Is(err, webhook.ErrUnknownJob) {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		// The failure is recorded in the job status returned below
+		slog.Error("maintenance job failed", "job", name, "error", err)
+	}
+
+	for _, job := range s.scheduler.JobStatuses() {
+		if job.Name == name {
+			return connect.NewResponse(&hooklyv1.RunMaintenanceResponse{
+				Job: maintenanceJobToProto(job),
+			}), nil
+		}
+	}
+	return nil, connect.NewError(connect.CodeInternal, errors.New("job status not found"))
+}
+
+// SetLogLevel changes the edge's log level until the next restart or SIGHUP
+// (superuser only).
+func (s *Service) SetLogLevel(ctx context.Context, req *connect.Request[hooklyv1.SetLogLevelRequest]) (*connect.Response[hooklyv1.SetLogLevelResponse], error) {
+	session := auth.GetSessionFromContext(ctx)
+	if session == nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+
+	if !auth.IsSuperuser(session.Username) {
+		return nil, connect.NewError(connect.CodePermissionDenied, errors.New("superuser access required"))
+	}
+
+	if s.logLevel == nil {
+		return nil, connect.NewError(connect.CodeUnavailable, errors.New("log level is not adjustable"))
+	}
+
+	previous := s.logLevel.Level()
+	if req.Msg.Level != "" {
+		level, err := logging.ParseLevel(req.Msg.Level)
+		if err != nil {
+			return nil, connect.NewError(connect.CodeInvalidArgument, err)
+		}
+		s.logLevel.Set(level)
+		slog.Warn("log level changed", "from", previous, "to", level, "username", session.Username)
+	}
+
+	return connect.NewResponse(&hooklyv1.SetLogLevelResponse{
+		Level:         strings.ToLower(s.logLevel.Level().String()),
+		PreviousLevel: strings.ToLower(previous.String()),
+	}), nil
+}
+
+// maintenanceJobToProto converts a scheduler job status to a proto message.
+func maintenanceJobToProto(job webhook.JobStatus) *hooklyv1.MaintenanceJob {
+	pb := &hooklyv1.MaintenanceJob{
+		Name:           job.Name,
+		LastDurationMs: job.LastDuration.Milliseconds(),
+		LastError:      job.LastError,
+	}
+	if !job.LastRun.IsZero() {
+		pb.LastRunAt = timestamppb.New(job.LastRun)
+	}
+	if !job.NextRun.IsZero() {
+		pb.NextRunAt = timestamppb.New(job.NextRun)
+	}
+	return pb
+}
+
+// webhookURL generates the webhook URL for an endpoint, on its home region's
+// edge when that is known.
+func (s *Service) webhookURL(ep *db.Endpoint) string {
+	baseURL := s.cfg.BaseURL
+	if s.regions != nil && ep.HomeRegion != "" {
+		if u, ok := s.regions.URL(ep.HomeRegion); ok {
+			baseURL = u
+		}
+	}
+	return webhook.WebhookURL(baseURL, s.cfg.WebhookPathPrefix, ep.ID)
 }
 
 // Helper functions
 
-func (s *Service) dbEndpointToProto(ep *db.Endpoint) *hooklyv1.Endpoint {
-	createdAt, _ := time.Parse("2006-01-02 15:04:05", ep.CreatedAt)
-	updatedAt, _ := time.Parse("2006-01-02 15:04:05", ep.UpdatedAt)
+// sqlTimestamp converts a stored timestamp, nil if empty or invalid.
+func sqlTimestamp(s string) *timestamppb.Timestamp {
+	t, err := db.ParseTime(s)
+	if err != nil {
+		if s != "" {
+			slog.Warn("invalid timestamp in database", "value", s)
+		}
+		return nil
+	}
+	return timestamppb.New(t)
+}
 
+// sqlNullTimestamp converts a nullable stored timestamp, nil if NULL or
+// invalid.
+func sqlNullTimestamp(s sql.NullString) *timestamppb.Timestamp {
+	if !s.Valid {
+		return nil
+	}
+	return sqlTimestamp(s.String)
+}
+
+// boolToInt64 converts a bool to the INTEGER representation used by SQLite.
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func (s *Service) dbEndpointToProto(ep *db.Endpoint) *hooklyv1.Endpoint {
 	protoEp := &hooklyv1.Endpoint{
-		Id:             ep.ID,
-		Name:           ep.Name,
-		ProviderType:   mapStringToProviderType(ep.ProviderType),
-		DestinationUrl: ep.DestinationUrl,
-		Muted:          ep.Muted != 0,
-		CreatedAt:      timestamppb.New(createdAt),
-		UpdatedAt:      timestamppb.New(updatedAt),
+		Id:                  ep.ID,
+		Name:                ep.Name,
+		ProviderType:        mapStringToProviderType(ep.ProviderType),
+		DestinationUrl:      ep.DestinationUrl,
+		WebhookUrl:          s.webhookURL(ep),
+		Muted:               ep.Muted != 0,
+		CreatedAt:           sqlTimestamp(ep.CreatedAt),
+		UpdatedAt:           sqlTimestamp(ep.UpdatedAt),
+		NotifyFirstEvent:    ep.NotifyFirstEvent != 0,
+		HasTelegramBotToken: len(ep.TelegramBotTokenEncrypted) > 0,
+		SloTarget:           ep.SloTarget,
+		SloLatencySeconds:   int32(ep.SloLatencySeconds),
+		SloWindowHours:      int32(ep.SloWindowHours),
+		RejectDuplicates:    ep.RejectDuplicates != 0,
+		HomeRegion:          ep.HomeRegion,
+		Honeypot:            ep.Honeypot != 0,
+		ConflictAsDuplicate: ep.ConflictAsDuplicate != 0,
+		RateLimitPerMinute:  int32(ep.RateLimitPerMinute),
+		AnswerProbes:        ep.AnswerProbes != 0,
+	}
+
+	protoEp.FirstEventAt = sqlNullTimestamp(ep.FirstEventAt)
+	protoEp.LastWebhookReceivedAt = sqlNullTimestamp(ep.LastWebhookReceivedAt)
+	protoEp.LastDeliveredAt = sqlNullTimestamp(ep.LastDeliveredAt)
+	protoEp.ArchivedAt = sqlNullTimestamp(ep.ArchivedAt)
+
+	if ep.RetryMaxAttempts != 0 || ep.RetryBackoffBaseSeconds != 0 || ep.RetryMaxIntervalSeconds != 0 || ep.RetryJitter != 0 {
+		protoEp.RetryPolicy = &hooklyv1.RetryPolicy{
+			MaxAttempts:        int32(ep.RetryMaxAttempts),
+			BackoffBaseSeconds: int32(ep.RetryBackoffBaseSeconds),
+			MaxIntervalSeconds: int32(ep.RetryMaxIntervalSeconds),
+			Jitter:             ep.RetryJitter,
+		}
+	}
+
+	if ep.MaxPayloadBytes != 0 || ep.AllowedContentTypes != "" {
+		limits := webhook.ParsePayloadLimits(ep.MaxPayloadBytes, ep.AllowedContentTypes)
+		protoEp.PayloadLimits = &hooklyv1.PayloadLimits{
+			MaxBytes:     limits.MaxBytes,
+			ContentTypes: limits.ContentTypes,
+		}
 	}
 
 	// Decrypt and include verification config for custom provider type
@@ -788,20 +2091,71 @@ func (s *Service) dbEndpointToProto(ep *db.Endpoint) *hooklyv1.Endpoint {
 		}
 	}
 
+	if len(ep.IngestAuthEncrypted) > 0 {
+		decrypted, err := s.secretManager.DecryptSecret(ep.IngestAuthEncrypted)
+		if err == nil {
+			if a, err := webhook.ParseIngestAuth([]byte(decrypted)); err == nil {
+				protoEp.IngestAuth = &hooklyv1.IngestAuth{
+					Method:   mapStringToIngestAuthMethod(a.Method),
+					Username: a.Username,
+					Header:   a.Header,
+				}
+			}
+		}
+	}
+
+	if ep.IngestResponse.Valid {
+		r := webhook.ParseIngestResponse(ep.IngestResponse.String)
+		protoEp.IngestResponse = &hooklyv1.IngestResponse{
+			StatusCode:  int32(r.StatusCode),
+			Body:        r.Body,
+			ContentType: r.ContentType,
+		}
+	}
+
+	if ep.Transform.Valid {
+		var t webhook.Transform
+		if json.Unmarshal([]byte(ep.Transform.String), &t) == nil {
+			protoEp.Transform = &hooklyv1.Transform{
+				Extract:  t.Extract,
+				Template: t.Template,
+				Headers:  t.Headers,
+			}
+		}
+	}
+
 	return protoEp
 }
 
-func dbWebhookToProto(wh *db.Webhook) *hooklyv1.Webhook {
-	receivedAt, _ := time.Parse("2006-01-02 15:04:05", wh.ReceivedAt)
+// dbWebhookToProto converts a webhook. The payload preview is always set; the
+// full payload only if includePayload is true.
+func dbWebhookToProto(wh *db.Webhook, includePayload bool) *hooklyv1.Webhook {
+	preview, truncated := webhook.PayloadPreview(wh.Payload)
 
 	proto := &hooklyv1.Webhook{
-		Id:             wh.ID,
-		EndpointId:     wh.EndpointID,
-		ReceivedAt:     timestamppb.New(receivedAt),
-		Payload:        wh.Payload,
-		SignatureValid: wh.SignatureValid != 0,
-		Status:         mapStringToWebhookStatus(wh.Status),
-		Attempts:       int32(wh.Attempts),
+		Id:               wh.ID,
+		EndpointId:       wh.EndpointID,
+		ReceivedAt:       sqlTimestamp(wh.ReceivedAt),
+		LastAttemptAt:    sqlNullTimestamp(wh.LastAttemptAt),
+		DeliveredAt:      sqlNullTimestamp(wh.DeliveredAt),
+		ReplayedAt:       sqlNullTimestamp(wh.ReplayedAt),
+		PurgedAt:         sqlNullTimestamp(wh.PurgedAt),
+		SignatureValid:   wh.SignatureValid != 0,
+		Status:           mapStringToWebhookStatus(wh.Status),
+		Attempts:         int32(wh.Attempts),
+		EventType:        wh.EventType.String,
+		PayloadPreview:   preview,
+		PayloadSize:      int64(len(wh.Payload)),
+		PayloadTruncated: truncated,
+		DeliveryId:       wh.DeliveryID.String,
+		DuplicateOf:      wh.DuplicateOf.String,
+		SourceIp:         wh.SourceIp,
+		ReplayedBy:       wh.ReplayedBy.String,
+		ReplayCount:      int32(wh.ReplayCount),
+		TraceId:          tracing.TraceIDFromTraceparent(wh.Traceparent.String),
+	}
+	if includePayload {
+		proto.Payload = wh.Payload
 	}
 
 	// Parse headers JSON
@@ -812,15 +2166,6 @@ func dbWebhookToProto(wh *db.Webhook) *hooklyv1.Webhook {
 		}
 	}
 
-	// Optional timestamps
-	if wh.LastAttemptAt.Valid {
-		t, _ := time.Parse("2006-01-02 15:04:05", wh.LastAttemptAt.String)
-		proto.LastAttemptAt = timestamppb.New(t)
-	}
-	if wh.DeliveredAt.Valid {
-		t, _ := time.Parse("2006-01-02 15:04:05", wh.DeliveredAt.String)
-		proto.DeliveredAt = timestamppb.New(t)
-	}
 	if wh.ErrorMessage.Valid {
 		proto.ErrorMessage = wh.ErrorMessage.String
 	}
@@ -828,6 +2173,64 @@ func dbWebhookToProto(wh *db.Webhook) *hooklyv1.Webhook {
 	return proto
 }
 
+// withPurgeExpiry sets when a purged webhook is deleted, after the retention
+// grace period.
+func (s *Service) withPurgeExpiry(wh *hooklyv1.Webhook) *hooklyv1.Webhook {
+	if wh.PurgedAt != nil {
+		grace := s.cfg.RetentionGrace
+		if grace <= 0 {
+			grace = webhook.PurgeGrace
+		}
+		wh.PurgeExpiresAt = timestamppb.New(wh.PurgedAt.AsTime().Add(grace))
+	}
+	return wh
+}
+
+func dbActivityEventToProto(ev *db.ListActivityEventsRow) *hooklyv1.ActivityItem {
+	return &hooklyv1.ActivityItem{
+		Id:           ev.ID,
+		Kind:         mapStringToActivityKind(ev.Kind),
+		EndpointId:   ev.EndpointID.String,
+		EndpointName: ev.EndpointName,
+		HubId:        ev.HubID.String,
+		Count:        int32(ev.Count),
+		OccurredAt:   sqlTimestamp(ev.OccurredAt),
+		UpdatedAt:    sqlTimestamp(ev.UpdatedAt),
+	}
+}
+
+func dbConnectionEventToProto(ev *db.ListConnectionEventsRow) *hooklyv1.ConnectionEvent {
+	eventType := hooklyv1.ConnectionEventType_CONNECTION_EVENT_TYPE_UNSPECIFIED
+	switch ev.Event {
+	case "connected":
+		eventType = hooklyv1.ConnectionEventType_CONNECTION_EVENT_TYPE_CONNECTED
+	case "disconnected":
+		eventType = hooklyv1.ConnectionEventType_CONNECTION_EVENT_TYPE_DISCONNECTED
+	}
+	return &hooklyv1.ConnectionEvent{
+		Id:           ev.ID,
+		EndpointId:   ev.EndpointID,
+		EndpointName: ev.EndpointName,
+		HubId:        ev.HubID,
+		Type:         eventType,
+		Transport:    ev.Transport,
+		OccurredAt:   sqlTimestamp(ev.OccurredAt),
+	}
+}
+
+func mapStringToActivityKind(s string) hooklyv1.ActivityKind {
+	switch s {
+	case "deliveries":
+		return hooklyv1.ActivityKind_ACTIVITY_KIND_DELIVERIES
+	case "hub_connected":
+		return hooklyv1.ActivityKind_ACTIVITY_KIND_HUB_CONNECTED
+	case "hub_disconnected":
+		return hooklyv1.ActivityKind_ACTIVITY_KIND_HUB_DISCONNECTED
+	default:
+		return hooklyv1.ActivityKind_ACTIVITY_KIND_UNSPECIFIED
+	}
+}
+
 func mapProviderTypeToString(pt hooklyv1.ProviderType) string {
 	switch pt {
 	case hooklyv1.ProviderType_PROVIDER_TYPE_STRIPE:
@@ -836,6 +2239,14 @@ func mapProviderTypeToString(pt hooklyv1.ProviderType) string {
 		return "github"
 	case hooklyv1.ProviderType_PROVIDER_TYPE_TELEGRAM:
 		return "telegram"
+	case hooklyv1.ProviderType_PROVIDER_TYPE_SLACK:
+		return "slack"
+	case hooklyv1.ProviderType_PROVIDER_TYPE_SHOPIFY:
+		return "shopify"
+	case hooklyv1.ProviderType_PROVIDER_TYPE_SQUARE:
+		return "square"
+	case hooklyv1.ProviderType_PROVIDER_TYPE_PAYPAL:
+		return "paypal"
 	case hooklyv1.ProviderType_PROVIDER_TYPE_GENERIC:
 		return "generic"
 	case hooklyv1.ProviderType_PROVIDER_TYPE_CUSTOM:
@@ -853,6 +2264,14 @@ func mapStringToProviderType(s string) hooklyv1.ProviderType {
 		return hooklyv1.ProviderType_PROVIDER_TYPE_GITHUB
 	case "telegram":
 		return hooklyv1.ProviderType_PROVIDER_TYPE_TELEGRAM
+	case "slack":
+		return hooklyv1.ProviderType_PROVIDER_TYPE_SLACK
+	case "shopify":
+		return hooklyv1.ProviderType_PROVIDER_TYPE_SHOPIFY
+	case "square":
+		return hooklyv1.ProviderType_PROVIDER_TYPE_SQUARE
+	case "paypal":
+		return hooklyv1.ProviderType_PROVIDER_TYPE_PAYPAL
 	case "generic":
 		return hooklyv1.ProviderType_PROVIDER_TYPE_GENERIC
 	case "custom":
@@ -862,6 +2281,20 @@ func mapStringToProviderType(s string) hooklyv1.ProviderType {
 	}
 }
 
+func dbStatusHistoryToProto(history []db.WebhookStatusHistory) []*hooklyv1.WebhookStatusChange {
+	changes := make([]*hooklyv1.WebhookStatusChange, len(history))
+	for i, h := range history {
+		changes[i] = &hooklyv1.WebhookStatusChange{
+			FromStatus: mapStringToWebhookStatus(h.FromStatus.String),
+			ToStatus:   mapStringToWebhookStatus(h.ToStatus),
+			Reason:     h.Reason.String,
+			ChangedAt:  sqlTimestamp(h.ChangedAt),
+			ChangedBy:  h.ChangedBy.String,
+		}
+	}
+	return changes
+}
+
 func mapWebhookStatusToString(s hooklyv1.WebhookStatus) string {
 	switch s {
 	case hooklyv1.WebhookStatus_WEBHOOK_STATUS_PENDING:
@@ -872,6 +2305,10 @@ func mapWebhookStatusToString(s hooklyv1.WebhookStatus) string {
 		return "failed"
 	case hooklyv1.WebhookStatus_WEBHOOK_STATUS_DEAD_LETTER:
 		return "dead_letter"
+	case hooklyv1.WebhookStatus_WEBHOOK_STATUS_SKIPPED:
+		return "skipped"
+	case hooklyv1.WebhookStatus_WEBHOOK_STATUS_ACKNOWLEDGED_DUPLICATE:
+		return "acknowledged_duplicate"
 	default:
 		return ""
 	}
@@ -887,11 +2324,66 @@ func mapStringToWebhookStatus(s string) hooklyv1.WebhookStatus {
 		return hooklyv1.WebhookStatus_WEBHOOK_STATUS_FAILED
 	case "dead_letter":
 		return hooklyv1.WebhookStatus_WEBHOOK_STATUS_DEAD_LETTER
+	case "skipped":
+		return hooklyv1.WebhookStatus_WEBHOOK_STATUS_SKIPPED
+	case "acknowledged_duplicate":
+		return hooklyv1.WebhookStatus_WEBHOOK_STATUS_ACKNOWLEDGED_DUPLICATE
 	default:
 		return hooklyv1.WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED
 	}
 }
 
+// encryptIngestAuth validates and encrypts ingestion credentials. It returns
+// nil for method unspecified, which leaves the URL open.
+func (s *Service) encryptIngestAuth(a *hooklyv1.IngestAuth) ([]byte, error) {
+	if a.Method == hooklyv1.IngestAuthMethod_INGEST_AUTH_METHOD_UNSPECIFIED {
+		return nil, nil
+	}
+	cfg := &webhook.IngestAuth{
+		Method:   mapIngestAuthMethodToString(a.Method),
+		Username: a.Username,
+		Header:   a.Header,
+		Secret:   a.Secret,
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("ingest_auth: %w", err))
+	}
+
+	configJSON, err := json.Marshal(cfg)
+	if err != nil {
+		slog.Error("failed to serialize ingest auth", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to serialize ingest auth"))
+	}
+	encrypted, err := s.secretManager.EncryptSecret(string(configJSON))
+	if err != nil {
+		slog.Error("failed to encrypt ingest auth", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to encrypt ingest auth"))
+	}
+	return encrypted, nil
+}
+
+func mapIngestAuthMethodToString(m hooklyv1.IngestAuthMethod) string {
+	switch m {
+	case hooklyv1.IngestAuthMethod_INGEST_AUTH_METHOD_BASIC:
+		return webhook.IngestAuthBasic
+	case hooklyv1.IngestAuthMethod_INGEST_AUTH_METHOD_HEADER:
+		return webhook.IngestAuthHeader
+	default:
+		return ""
+	}
+}
+
+func mapStringToIngestAuthMethod(s string) hooklyv1.IngestAuthMethod {
+	switch s {
+	case webhook.IngestAuthBasic:
+		return hooklyv1.IngestAuthMethod_INGEST_AUTH_METHOD_BASIC
+	case webhook.IngestAuthHeader:
+		return hooklyv1.IngestAuthMethod_INGEST_AUTH_METHOD_HEADER
+	default:
+		return hooklyv1.IngestAuthMethod_INGEST_AUTH_METHOD_UNSPECIFIED
+	}
+}
+
 // internalVerificationConfig matches the webhook.VerificationConfig struct for JSON serialization.
 type internalVerificationConfig struct {
 	Method             string `json:"method"`
@@ -901,6 +2393,57 @@ type internalVerificationConfig struct {
 	TimestampTolerance int64  `json:"timestamp_tolerance,omitempty"`
 }
 
+// transformToJSON validates a transform and serializes it for storage. An
+// empty transform is stored as NULL.
+func transformToJSON(t *hooklyv1.Transform) (sql.NullString, error) {
+	transform := &webhook.Transform{
+		Extract:  strings.TrimSpace(t.Extract),
+		Template: t.Template,
+		Headers:  t.Headers,
+	}
+	if transform.IsZero() {
+		return sql.NullString{}, nil
+	}
+	if err := transform.Validate(); err != nil {
+		return sql.NullString{}, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid transform: %w", err))
+	}
+	data, err := json.Marshal(transform)
+	if err != nil {
+		return sql.NullString{}, connect.NewError(connect.CodeInternal, errors.New("failed to serialize transform"))
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+// ingestResponseToJSON validates an ingest response and serializes it for
+// storage. The default response is stored as NULL.
+func ingestResponseToJSON(r *hooklyv1.IngestResponse) (sql.NullString, error) {
+	response := &webhook.IngestResponse{
+		StatusCode:  int(r.StatusCode),
+		Body:        r.Body,
+		ContentType: strings.TrimSpace(r.ContentType),
+	}
+	if response.IsZero() {
+		return sql.NullString{}, nil
+	}
+	if err := response.Validate(); err != nil {
+		return sql.NullString{}, connect.NewError(connect.CodeInvalidArgument, fmt.Errorf("invalid ingest response: %w", err))
+	}
+	data, err := json.Marshal(response)
+	if err != nil {
+		return sql.NullString{}, connect.NewError(connect.CodeInternal, errors.New("failed to serialize ingest response"))
+	}
+	return sql.NullString{String: string(data), Valid: true}, nil
+}
+
+func protoRetryPolicyToInternal(p *hooklyv1.RetryPolicy) webhook.RetryPolicy {
+	return webhook.RetryPolicy{
+		MaxAttempts: int(p.MaxAttempts),
+		BackoffBase: time.Duration(p.BackoffBaseSeconds) * time.Second,
+		MaxInterval: time.Duration(p.MaxIntervalSeconds) * time.Second,
+		Jitter:      p.Jitter,
+	}
+}
+
 func protoVerificationConfigToInternal(cfg *hooklyv1.VerificationConfig) *internalVerificationConfig {
 	if cfg == nil {
 		return nil
@@ -992,24 +2535,38 @@ func mapStringToThemePreference(s string) hooklyv1.ThemePreference {
 }
 
 func dbUserSettingsToProto(s *db.UserSetting, isSuperuser bool) *hooklyv1.UserSettings {
-	createdAt, _ := time.Parse("2006-01-02 15:04:05", s.CreatedAt)
-	updatedAt, _ := time.Parse("2006-01-02 15:04:05", s.UpdatedAt)
-	lastLoginAt, _ := time.Parse("2006-01-02 15:04:05", s.LastLoginAt)
-
 	return &hooklyv1.UserSettings{
-		UserId:             s.UserID,
-		Username:           s.Username,
-		GithubName:         s.GithubName.String,
-		GithubEmail:        s.GithubEmail.String,
-		GithubProfileUrl:   s.GithubProfileUrl.String,
-		AvatarUrl:          s.AvatarUrl.String,
-		TelegramConfigured: len(s.TelegramBotTokenEncrypted) > 0,
-		TelegramChatId:     s.TelegramChatID.String,
-		TelegramEnabled:    s.TelegramEnabled != 0,
-		ThemePreference:    mapStringToThemePreference(s.ThemePreference),
-		IsSuperuser:        isSuperuser,
-		CreatedAt:          timestamppb.New(createdAt),
-		UpdatedAt:          timestamppb.New(updatedAt),
-		LastLoginAt:        timestamppb.New(lastLoginAt),
+		UserId:                  s.UserID,
+		Username:                s.Username,
+		GithubName:              s.GithubName.String,
+		GithubEmail:             s.GithubEmail.String,
+		GithubProfileUrl:        s.GithubProfileUrl.String,
+		AvatarUrl:               s.AvatarUrl.String,
+		TelegramConfigured:      len(s.TelegramBotTokenEncrypted) > 0,
+		TelegramChatId:          s.TelegramChatID.String,
+		TelegramEnabled:         s.TelegramEnabled != 0,
+		ThemePreference:         mapStringToThemePreference(s.ThemePreference),
+		IsSuperuser:             isSuperuser,
+		CreatedAt:               sqlTimestamp(s.CreatedAt),
+		UpdatedAt:               sqlTimestamp(s.UpdatedAt),
+		LastLoginAt:             sqlTimestamp(s.LastLoginAt),
+		DiscordConfigured:       len(s.DiscordWebhookUrlEncrypted) > 0,
+		DiscordEnabled:          s.DiscordEnabled != 0,
+		EmailAddress:            s.EmailAddress.String,
+		EmailEnabled:            s.EmailEnabled != 0,
+		NotifyWebhookConfigured: len(s.NotifyWebhookUrlEncrypted) > 0 && len(s.NotifyWebhookSecretEncrypted) > 0,
+		NotifyWebhookEnabled:    s.NotifyWebhookEnabled != 0,
+	}
+}
+
+func dbAPITokenToProto(t *db.ApiToken) *hooklyv1.ApiToken {
+	return &hooklyv1.ApiToken{
+		Id:         t.ID,
+		Name:       t.Name,
+		CreatedAt:  sqlTimestamp(t.CreatedAt),
+		LastUsedAt: sqlNullTimestamp(t.LastUsedAt),
+		Scope:      mapStringToTokenScope(t.Scope),
+		ExpiresAt:  sqlNullTimestamp(t.ExpiresAt),
+		Expired:    tokenExpired(t, time.Now()),
 	}
 }
@@ -0,0 +1,114 @@
+package edge
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"connectrpc.com/connect"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/auth"
+	"hooks.dx314.com/internal/config"
+	"hooks.dx314.com/internal/db"
+)
+
+func TestRevealEndpointSecretInOrg(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	queries := db.New(conn)
+	secrets := db.NewSecretManager(make([]byte, 32))
+
+	encrypted, err := secrets.EncryptSecret("whsec_test")
+	if err != nil {
+		t.Fatalf("encrypt secret: %v", err)
+	}
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:                       "ep-1",
+		UserID:                   auth.OrgOwnerID("org-1"),
+		Name:                     "ep-1",
+		ProviderType:             "generic",
+		SignatureSecretEncrypted: encrypted,
+		DestinationUrl:           "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+
+	s := New(queries, secrets, nil, &config.Config{})
+	ctx = auth.ContextWithSession(ctx, &auth.Session{UserID: "user-1", OrgID: "org-1", OrgRole: auth.RoleMember})
+	resp, err := s.RevealEndpointSecret(ctx, connect.NewRequest(&hooklyv1.RevealEndpointSecretRequest{EndpointId: "ep-1"}))
+	if err != nil {
+		t.Fatalf("reveal secret: %v", err)
+	}
+	if resp.Msg.Secret != "whsec_test" {
+		t.Errorf("secret %q, want whsec_test", resp.Msg.Secret)
+	}
+
+	// The reveal is recorded, and limited, for the member, not the org
+	var userID string
+	if err := conn.QueryRow(`SELECT user_id FROM secret_reveals WHERE endpoint_id = 'ep-1'`).Scan(&userID); err != nil {
+		t.Fatalf("read secret reveal: %v", err)
+	}
+	if userID != "user-1" {
+		t.Errorf("reveal recorded for %q, want user-1", userID)
+	}
+	if n, err := queries.CountRecentSecretReveals(ctx, "user-1"); err != nil || n != 1 {
+		t.Errorf("recent reveals for user-1: %d, %v; want 1", n, err)
+	}
+}
+
+func TestUpdateEndpoint(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	queries := db.New(conn)
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-1",
+		UserID:         "user-1",
+		Name:           "before",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+
+	s := New(queries, db.NewSecretManager(make([]byte, 32)), nil, &config.Config{})
+	cache := db.NewEndpointCache(queries, time.Hour)
+	s.SetEndpointCache(cache)
+	if _, err := cache.Get(ctx, "ep-1"); err != nil {
+		t.Fatalf("cache endpoint: %v", err)
+	}
+
+	ctx = auth.ContextWithSession(ctx, &auth.Session{UserID: "user-1"})
+	name := "after"
+	req := &hooklyv1.UpdateEndpointRequest{
+		Id:             "ep-1",
+		Name:           &name,
+		IngestResponse: &hooklyv1.IngestResponse{StatusCode: 204},
+		Destinations:   &hooklyv1.DestinationList{Urls: []string{"http://localhost:8081/hook"}},
+	}
+	if _, err := s.UpdateEndpoint(ctx, connect.NewRequest(req)); err != nil {
+		t.Fatalf("update endpoint: %v", err)
+	}
+	ep, err := cache.Get(ctx, "ep-1")
+	if err != nil || ep.Name != "after" || !ep.IngestResponse.Valid {
+		t.Errorf("cached endpoint after the update: %+v, %v", ep, err)
+	}
+	if dests, err := queries.ListEndpointDestinations(ctx, "ep-1"); err != nil || len(dests) != 1 {
+		t.Errorf("destinations: %+v, %v", dests, err)
+	}
+
+	// Another owner's endpoint isn't changed at all
+	ctx = auth.ContextWithSession(context.Background(), &auth.Session{UserID: "user-2"})
+	if _, err := s.UpdateEndpoint(ctx, connect.NewRequest(req)); connect.CodeOf(err) != connect.CodeNotFound {
+		t.Errorf("update another owner's endpoint: err = %v, want not found", err)
+	}
+}
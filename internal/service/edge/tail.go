@@ -0,0 +1,105 @@
+package edge
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+
+	"connectrpc.com/connect"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/db"
+)
+
+const (
+	// tailPollInterval is how often a tail reads new status changes.
+	tailPollInterval = time.Second
+	// tailBatchSize bounds the status changes read per poll.
+	tailBatchSize = 100
+)
+
+// TailWebhooks streams the user's webhooks as they are received and change
+// status, starting from now. Changes are read from the status history, so
+// webhooks received by other edge instances sharing the database are
+// included.
+func (s *Service) TailWebhooks(ctx context.Context, req *connect.Request[hooklyv1.TailWebhooksRequest], stream *connect.ServerStream[hooklyv1.TailWebhooksResponse]) error {
+	userID, err := getOwnerID(ctx)
+	if err != nil {
+		return err
+	}
+
+	var endpointID interface{}
+	if req.Msg.EndpointId != nil {
+		if _, err := s.queries.GetEndpoint(ctx, db.GetEndpointParams{ID: *req.Msg.EndpointId, UserID: userID}); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return connect.NewError(connect.CodeNotFound, errors.New("endpoint not found"))
+			}
+			slog.Error("failed to get endpoint", "error", err, "id", *req.Msg.EndpointId)
+			return connect.NewError(connect.CodeInternal, errors.New("failed to tail webhooks"))
+		}
+		endpointID = *req.Msg.EndpointId
+	}
+
+	statuses := make(map[string]bool, len(req.Msg.Statuses))
+	for _, st := range req.Msg.Statuses {
+		if st == hooklyv1.WebhookStatus_WEBHOOK_STATUS_UNSPECIFIED {
+			return connect.NewError(connect.CodeInvalidArgument, errors.New("statuses must not contain unspecified"))
+		}
+		statuses[mapWebhookStatusToString(st)] = true
+	}
+
+	after, err := s.queries.GetLastStatusChangeID(ctx)
+	if err != nil {
+		slog.Error("failed to get last status change", "error", err)
+		return connect.NewError(connect.CodeInternal, errors.New("failed to tail webhooks"))
+	}
+
+	ticker := time.NewTicker(tailPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		changes, err := s.queries.ListStatusChangesAfter(ctx, db.ListStatusChangesAfterParams{
+			AfterID:    after,
+			UserID:     userID,
+			EndpointID: endpointID,
+			Limit:      tailBatchSize,
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			slog.Error("failed to list status changes", "error", err)
+			return connect.NewError(connect.CodeInternal, errors.New("failed to tail webhooks"))
+		}
+
+		for _, change := range changes {
+			after = change.ID
+			if len(statuses) > 0 && !statuses[change.ToStatus] {
+				continue
+			}
+
+			wh, err := s.queries.GetWebhook(ctx, db.GetWebhookParams{ID: change.WebhookID, UserID: userID})
+			if errors.Is(err, sql.ErrNoRows) {
+				continue // Deleted since the change
+			}
+			if err != nil {
+				slog.Error("failed to get webhook", "error", err, "id", change.WebhookID)
+				return connect.NewError(connect.CodeInternal, errors.New("failed to tail webhooks"))
+			}
+
+			if err := stream.Send(&hooklyv1.TailWebhooksResponse{
+				Webhook: dbWebhookToProto(&wh, false),
+				Change:  dbStatusHistoryToProto([]db.WebhookStatusHistory{change})[0],
+			}); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package edge
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/db"
+	"hooks.dx314.com/internal/webhook"
+)
+
+// SetupTelegramWebhook registers a telegram endpoint with Telegram's setWebhook
+// API and verifies the registration with getWebhookInfo.
+func (s *Service) SetupTelegramWebhook(ctx context.Context, req *connect.Request[hooklyv1.SetupTelegramWebhookRequest]) (*connect.Response[hooklyv1.SetupTelegramWebhookResponse], error) {
+	userID, err := getOwnerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := s.getTelegramEndpoint(ctx, userID, req.Msg.EndpointId)
+	if err != nil {
+		return nil, err
+	}
+
+	botToken := req.Msg.BotToken
+	if botToken == "" {
+		botToken, err = s.storedBotToken(&endpoint)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Telegram echoes the secret token in X-Telegram-Bot-Api-Secret-Token,
+	// which is what the telegram verifier checks against the signature secret.
+	secretToken, err := s.telegramSecretToken(ctx, &endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	webhookURL := s.webhookURL(&endpoint)
+	if err := s.telegram.SetWebhook(ctx, botToken, webhookURL, secretToken); err != nil {
+		return nil, telegramError(err, endpoint.ID)
+	}
+
+	if req.Msg.BotToken != "" {
+		encrypted, err := s.secretManager.EncryptSecret(req.Msg.BotToken)
+		if err != nil {
+			slog.Error("failed to encrypt bot token", "error", err)
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to encrypt bot token"))
+		}
+		if err := s.queries.SetEndpointTelegramBotToken(ctx, db.SetEndpointTelegramBotTokenParams{
+			TelegramBotTokenEncrypted: encrypted,
+			ID:                        endpoint.ID,
+			UserID:                    userID,
+		}); err != nil {
+			slog.Error("failed to store bot token", "error", err, "id", endpoint.ID)
+			return nil, connect.NewError(connect.CodeInternal, errors.New("failed to store bot token"))
+		}
+	}
+
+	slog.Info("telegram webhook registered", "endpoint_id", endpoint.ID)
+
+	status, err := s.telegramWebhookStatus(ctx, botToken, webhookURL, endpoint.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&hooklyv1.SetupTelegramWebhookResponse{
+		Status: status,
+	}), nil
+}
+
+// VerifyTelegramWebhook checks that Telegram's webhook registration points at the endpoint.
+func (s *Service) VerifyTelegramWebhook(ctx context.Context, req *connect.Request[hooklyv1.VerifyTelegramWebhookRequest]) (*connect.Response[hooklyv1.VerifyTelegramWebhookResponse], error) {
+	userID, err := getOwnerID(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := s.getTelegramEndpoint(ctx, userID, req.Msg.EndpointId)
+	if err != nil {
+		return nil, err
+	}
+
+	botToken, err := s.storedBotToken(&endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := s.telegramWebhookStatus(ctx, botToken, s.webhookURL(&endpoint), endpoint.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return connect.NewResponse(&hooklyv1.VerifyTelegramWebhookResponse{
+		Status: status,
+	}), nil
+}
+
+// getTelegramEndpoint loads an endpoint and checks that it is a telegram endpoint.
+func (s *Service) getTelegramEndpoint(ctx context.Context, userID, endpointID string) (db.Endpoint, error) {
+	if endpointID == "" {
+		return db.Endpoint{}, connect.NewError(connect.CodeInvalidArgument, errors.New("endpoint_id is required"))
+	}
+
+	endpoint, err := s.queries.GetEndpoint(ctx, db.GetEndpointParams{
+		ID:     endpointID,
+		UserID: userID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return db.Endpoint{}, connect.NewError(connect.CodeNotFound, errors.New("endpoint not found"))
+		}
+		slog.Error("failed to get endpoint", "error", err, "id", endpointID)
+		return db.Endpoint{}, connect.NewError(connect.CodeInternal, errors.New("failed to get endpoint"))
+	}
+
+	if endpoint.ProviderType != "telegram" {
+		return db.Endpoint{}, connect.NewError(connect.CodeFailedPrecondition, errors.New("endpoint is not a telegram endpoint"))
+	}
+	return endpoint, nil
+}
+
+// storedBotToken decrypts the bot token stored for an endpoint.
+func (s *Service) storedBotToken(endpoint *db.Endpoint) (string, error) {
+	if len(endpoint.TelegramBotTokenEncrypted) == 0 {
+		return "", connect.NewError(connect.CodeInvalidArgument, errors.New("bot_token is required"))
+	}
+	botToken, err := s.secretManager.DecryptSecret(endpoint.TelegramBotTokenEncrypted)
+	if err != nil {
+		slog.Error("failed to decrypt bot token", "error", err, "id", endpoint.ID)
+		return "", connect.NewError(connect.CodeInternal, errors.New("failed to decrypt bot token"))
+	}
+	return botToken, nil
+}
+
+// telegramSecretToken returns the endpoint's signature secret for use as the
+// Telegram secret token, generating and storing one if none is configured.
+func (s *Service) telegramSecretToken(ctx context.Context, endpoint *db.Endpoint) (string, error) {
+	if len(endpoint.SignatureSecretEncrypted) > 0 {
+		secret, err := s.secretManager.DecryptSecret(endpoint.SignatureSecretEncrypted)
+		if err != nil {
+			slog.Error("failed to decrypt secret", "error", err, "id", endpoint.ID)
+			return "", connect.NewError(connect.CodeInternal, errors.New("failed to decrypt secret"))
+		}
+		if !webhook.ValidTelegramSecretToken(secret) {
+			return "", connect.NewError(connect.CodeFailedPrecondition, errors.New("signature secret must be 1-256 characters of A-Z, a-z, 0-9, _ or - to be used as a Telegram secret token"))
+		}
+		return secret, nil
+	}
+
+	secret, err := webhook.GenerateSecret("telegram")
+	if err != nil {
+		slog.Error("failed to generate secret", "error", err)
+		return "", connect.NewError(connect.CodeInternal, errors.New("failed to generate secret"))
+	}
+	encrypted, err := s.secretManager.EncryptSecret(secret)
+	if err != nil {
+		slog.Error("failed to encrypt secret", "error", err)
+		return "", connect.NewError(connect.CodeInternal, errors.New("failed to encrypt secret"))
+	}
+	defer s.endpoints.Invalidate(endpoint.ID)
+	if _, err := s.queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		SignatureSecretEncrypted: encrypted,
+		ID:                       endpoint.ID,
+		UserID:                   endpoint.UserID,
+	}); err != nil {
+		slog.Error("failed to store secret", "error", err, "id", endpoint.ID)
+		return "", connect.NewError(connect.CodeInternal, errors.New("failed to store secret"))
+	}
+
+	slog.Info("generated telegram secret token", "endpoint_id", endpoint.ID)
+	return secret, nil
+}
+
+// telegramWebhookStatus fetches getWebhookInfo and compares it with the endpoint URL.
+func (s *Service) telegramWebhookStatus(ctx context.Context, botToken, webhookURL, endpointID string) (*hooklyv1.TelegramWebhookStatus, error) {
+	info, err := s.telegram.GetWebhookInfo(ctx, botToken)
+	if err != nil {
+		return nil, telegramError(err, endpointID)
+	}
+
+	status := &hooklyv1.TelegramWebhookStatus{
+		Url:                info.URL,
+		Matches:            info.URL == webhookURL,
+		PendingUpdateCount: int32(info.PendingUpdateCount),
+		LastErrorMessage:   info.LastErrorMessage,
+	}
+	if at := info.LastErrorAt(); !at.IsZero() {
+		status.LastErrorAt = timestamppb.New(at)
+	}
+	return status, nil
+}
+
+// telegramError maps Telegram client errors to connect errors.
+func telegramError(err error, endpointID string) error {
+	var apiErr *webhook.TelegramAPIError
+	if errors.As(err, &apiErr) {
+		return connect.NewError(connect.CodeFailedPrecondition, apiErr)
+	}
+	slog.Error("telegram request failed", "error", err, "endpoint_id", endpointID)
+	return connect.NewError(connect.CodeUnavailable, errors.New("failed to reach telegram"))
+}
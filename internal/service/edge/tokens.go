@@ -0,0 +1,169 @@
+package edge
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"connectrpc.com/connect"
+
+	hooklyv1 "hooks.dx314.com/internal/api/hookly/v1"
+	"hooks.dx314.com/internal/auth"
+	"hooks.dx314.com/internal/db"
+)
+
+// ListApiTokens lists the current user's tokens that aren't revoked,
+// including expired ones.
+func (s *Service) ListApiTokens(ctx context.Context, _ *connect.Request[hooklyv1.ListApiTokensRequest]) (*connect.Response[hooklyv1.ListApiTokensResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.tokens == nil {
+		return nil, errTokensUnavailable
+	}
+
+	tokens, err := s.tokens.GetUserTokens(ctx, userID)
+	if err != nil {
+		slog.Error("failed to list api tokens", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to list tokens"))
+	}
+
+	resp := &hooklyv1.ListApiTokensResponse{}
+	for i := range tokens {
+		if tokens[i].Revoked != 0 {
+			continue
+		}
+		resp.Tokens = append(resp.Tokens, dbAPITokenToProto(&tokens[i]))
+	}
+	return connect.NewResponse(resp), nil
+}
+
+// CreateApiToken creates a token for the current user. The plaintext token
+// is only in the response.
+func (s *Service) CreateApiToken(ctx context.Context, req *connect.Request[hooklyv1.CreateApiTokenRequest]) (*connect.Response[hooklyv1.CreateApiTokenResponse], error) {
+	session := auth.GetSessionFromContext(ctx)
+	if session == nil {
+		return nil, connect.NewError(connect.CodeUnauthenticated, errors.New("authentication required"))
+	}
+	if s.tokens == nil {
+		return nil, errTokensUnavailable
+	}
+
+	if req.Msg.Name == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("name is required"))
+	}
+	scope := mapTokenScopeToString(req.Msg.Scope)
+	if scope == "" {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("scope is required"))
+	}
+	if req.Msg.ExpiresInSeconds < 0 {
+		return nil, connect.NewError(connect.CodeInvalidArgument, errors.New("expires_in_seconds must not be negative"))
+	}
+	var expiresAt time.Time
+	if req.Msg.ExpiresInSeconds > 0 {
+		expiresAt = time.Now().Add(time.Duration(req.Msg.ExpiresInSeconds) * time.Second)
+	}
+
+	plaintext, token, err := s.tokens.GenerateScopedToken(ctx, session.UserID, session.Username, req.Msg.Name, scope, expiresAt)
+	if err != nil {
+		slog.Error("failed to create api token", "error", err)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to create token"))
+	}
+
+	slog.Info("api token created", "token_id", token.ID, "user_id", session.UserID, "scope", scope)
+	return connect.NewResponse(&hooklyv1.CreateApiTokenResponse{
+		Token:    plaintext,
+		ApiToken: dbAPITokenToProto(token),
+	}), nil
+}
+
+// RotateApiToken replaces one of the current user's tokens with a new one
+// and revokes it.
+func (s *Service) RotateApiToken(ctx context.Context, req *connect.Request[hooklyv1.RotateApiTokenRequest]) (*connect.Response[hooklyv1.RotateApiTokenResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.tokens == nil {
+		return nil, errTokensUnavailable
+	}
+
+	plaintext, token, err := s.tokens.RotateToken(ctx, userID, req.Msg.Id)
+	if err != nil {
+		if errors.Is(err, auth.ErrTokenNotFound) || errors.Is(err, auth.ErrTokenRevoked) {
+			return nil, connect.NewError(connect.CodeNotFound, errors.New("token not found"))
+		}
+		slog.Error("failed to rotate api token", "error", err, "token_id", req.Msg.Id)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to rotate token"))
+	}
+
+	slog.Info("api token rotated", "token_id", req.Msg.Id, "new_token_id", token.ID, "user_id", userID)
+	return connect.NewResponse(&hooklyv1.RotateApiTokenResponse{
+		Token:    plaintext,
+		ApiToken: dbAPITokenToProto(token),
+	}), nil
+}
+
+// RevokeApiToken revokes one of the current user's tokens, which may be the
+// one making the request.
+func (s *Service) RevokeApiToken(ctx context.Context, req *connect.Request[hooklyv1.RevokeApiTokenRequest]) (*connect.Response[hooklyv1.RevokeApiTokenResponse], error) {
+	userID, err := getUserID(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if s.tokens == nil {
+		return nil, errTokensUnavailable
+	}
+
+	// Only revoke the user's own token
+	if _, err := s.queries.GetAPIToken(ctx, db.GetAPITokenParams{ID: req.Msg.Id, UserID: userID}); err != nil {
+		return nil, connect.NewError(connect.CodeNotFound, errors.New("token not found"))
+	}
+	if err := s.tokens.RevokeToken(ctx, req.Msg.Id); err != nil {
+		slog.Error("failed to revoke api token", "error", err, "token_id", req.Msg.Id)
+		return nil, connect.NewError(connect.CodeInternal, errors.New("failed to revoke token"))
+	}
+
+	slog.Info("api token revoked", "token_id", req.Msg.Id, "user_id", userID)
+	return connect.NewResponse(&hooklyv1.RevokeApiTokenResponse{}), nil
+}
+
+var errTokensUnavailable = connect.NewError(connect.CodeUnimplemented, errors.New("api tokens require GitHub authentication"))
+
+// tokenExpired reports whether a token has expired at now. An unreadable
+// expiry counts as expired, as in TokenManager.ValidateToken.
+func tokenExpired(t *db.ApiToken, now time.Time) bool {
+	if !t.ExpiresAt.Valid {
+		return false
+	}
+	expiresAt, err := db.ParseTime(t.ExpiresAt.String)
+	return err != nil || !now.Before(expiresAt)
+}
+
+func mapTokenScopeToString(scope hooklyv1.TokenScope) string {
+	switch scope {
+	case hooklyv1.TokenScope_TOKEN_SCOPE_ADMIN:
+		return auth.ScopeAdmin
+	case hooklyv1.TokenScope_TOKEN_SCOPE_READ:
+		return auth.ScopeRead
+	case hooklyv1.TokenScope_TOKEN_SCOPE_RELAY:
+		return auth.ScopeRelay
+	default:
+		return ""
+	}
+}
+
+func mapStringToTokenScope(s string) hooklyv1.TokenScope {
+	switch s {
+	case auth.ScopeAdmin:
+		return hooklyv1.TokenScope_TOKEN_SCOPE_ADMIN
+	case auth.ScopeRead:
+		return hooklyv1.TokenScope_TOKEN_SCOPE_READ
+	case auth.ScopeRelay:
+		return hooklyv1.TokenScope_TOKEN_SCOPE_RELAY
+	default:
+		return hooklyv1.TokenScope_TOKEN_SCOPE_UNSPECIFIED
+	}
+}
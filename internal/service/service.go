@@ -3,15 +3,25 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
+	"os/exec"
+	"runtime/debug"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/kardianos/service"
 
+	"hooks.dx314.com/internal/clock"
 	"hooks.dx314.com/internal/config"
+	"hooks.dx314.com/internal/errreport"
+	"hooks.dx314.com/internal/exitcode"
+	"hooks.dx314.com/internal/logging"
 	"hooks.dx314.com/internal/relay"
+	"hooks.dx314.com/internal/tracing"
 )
 
 const (
@@ -23,9 +33,14 @@ const (
 
 // Program implements service.Interface for the hookly relay.
 type Program struct {
-	cfg    *ServiceConfig
-	cancel context.CancelFunc
-	wg     sync.WaitGroup
+	cfg      *ServiceConfig
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	reporter *errreport.Reporter
+	tracer   *tracing.Tracer
+	crashes  *crashLoop
+	clock    clock.Clock
+	exit     func(code int) // os.Exit unless set
 }
 
 // Start is called when the service is started.
@@ -33,34 +48,130 @@ type Program struct {
 func (p *Program) Start(s service.Service) error {
 	slog.Info("service starting", "config", p.cfg.ConfigPath)
 
-	// Load hookly config
-	hooklyCfg, err := config.LoadHooklyYAML(p.cfg.ConfigPath)
-	if err != nil {
-		return err
-	}
-
 	ctx, cancel := context.WithCancel(context.Background())
 	p.cancel = cancel
+	p.clock = clock.Or(p.clock)
+	if p.crashes == nil {
+		p.crashes = loadCrashLoop(crashLoopPath(), p.clock)
+	}
 
-	// Create relay client
-	client := relay.NewClient(hooklyCfg)
-
-	// Start relay in goroutine (must not block)
+	// Failures are retried here rather than by exiting, which would have
+	// the service manager restart hookly in a tight loop
 	p.wg.Add(1)
 	go func() {
 		defer p.wg.Done()
-		if err := client.Run(ctx); err != nil && err != context.Canceled {
-			slog.Error("relay error", "error", err)
+		p.supervise(ctx)
+	}()
+	return nil
+}
+
+// supervise runs the relay until ctx is cancelled, restarting it when it
+// stops and cooling down when it keeps failing.
+func (p *Program) supervise(ctx context.Context) {
+	for {
+		if p.crashes.coolingDown() {
+			slog.Error("service is crash looping, retrying less often",
+				"starts", p.crashes.recent(),
+				"window", crashLoopWindow.String(),
+				"retry_in", crashLoopCooldown.String(),
+			)
+			if !sleep(ctx, p.clock, crashLoopCooldown) {
+				return
+			}
+		}
+
+		p.crashes.recordStart()
+		err := p.run(ctx)
+		if ctx.Err() != nil {
+			p.crashes.forgetStart()
+			return
+		}
+		if errors.Is(err, relay.ErrDisconnectedByEdge) {
+			// Stay disconnected until the service is restarted
+			p.crashes.forgetStart()
+			slog.Warn("relay disconnected by a command from the edge; restart the service to reconnect")
+			<-ctx.Done()
+			return
+		}
+		if code := exitcode.Code(err); code == exitcode.Auth {
+			// No retry can fix the token; exit so the service manager shows why
+			slog.Error("relay authentication failed, exiting; run 'hookly login' and restart the service", "error", err, "exit_code", code)
+			p.tracer.Close(shutdownTimeout)
+			p.reporter.Close(shutdownTimeout)
+			if p.exit == nil {
+				p.exit = os.Exit
+			}
+			p.exit(code)
+			return
+		}
+		slog.Error("relay stopped, restarting", "error", err, "retry_in", restartDelay.String())
+		if !sleep(ctx, p.clock, restartDelay) {
+			return
+		}
+	}
+}
+
+// run loads the config and runs the relay until it stops.
+func (p *Program) run(ctx context.Context) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.reporter.CapturePanic(r)
+			slog.Error("panic", "panic", r, "stack", string(debug.Stack()))
+			err = fmt.Errorf("panic: %v", r)
 		}
 	}()
 
+	// Load hookly config
+	hooklyCfg, err := config.LoadHooklyYAML(p.cfg.ConfigPath)
+	if err != nil {
+		return err
+	}
+
+	if p.reporter == nil {
+		p.reporter = errreport.Install(hooklyCfg.SentryDSN, errreport.Options{Release: p.cfg.Release})
+	}
+	if p.tracer == nil {
+		p.tracer = tracing.FromEnv(os.Getenv, "hookly-hub", p.cfg.Release)
+	}
+
 	slog.Info("service started",
 		"edge_url", hooklyCfg.EdgeURL,
 		"hub_id", hooklyCfg.HubID,
 		"endpoints", len(hooklyCfg.Endpoints),
 	)
 
-	return nil
+	// A run that lasts the window proves the config works, even if the
+	// process is later killed
+	stable := p.clock.NewTicker(crashLoopWindow)
+	defer stable.Stop()
+	done := make(chan error, 1)
+	client := relay.NewClient(hooklyCfg)
+	client.SetReloader(func() (*config.HooklyConfig, error) {
+		return config.LoadHooklyYAML(p.cfg.ConfigPath)
+	})
+	client.SetRecentLogs(p.cfg.RecentLogs)
+	client.SetTracer(p.tracer)
+	go func() { done <- client.Run(ctx) }()
+	for {
+		select {
+		case <-stable.C():
+			p.crashes.reset()
+		case err := <-done:
+			return err
+		}
+	}
+}
+
+// sleep waits for d, returning false if ctx is cancelled first.
+func sleep(ctx context.Context, c clock.Clock, d time.Duration) bool {
+	t := c.NewTicker(d)
+	defer t.Stop()
+	select {
+	case <-t.C():
+		return true
+	case <-ctx.Done():
+		return false
+	}
 }
 
 // Stop is called when the service is stopped.
@@ -84,6 +195,8 @@ func (p *Program) Stop(s service.Service) error {
 	case <-time.After(shutdownTimeout):
 		slog.Warn("service shutdown timed out")
 	}
+	p.tracer.Close(shutdownTimeout)
+	p.reporter.Close(shutdownTimeout)
 
 	return nil
 }
@@ -93,8 +206,11 @@ func NewService(cfg *ServiceConfig) (service.Service, error) {
 	prg := &Program{cfg: cfg}
 
 	options := make(service.KeyValue)
-	options["KeepAlive"] = true
+	options["KeepAlive"] = cfg.Restart != RestartNever
 	options["RunAtLoad"] = true
+	options["Restart"] = systemdRestart(cfg.Restart)
+	options["SystemdScript"] = systemdUnit(cfg)
+	options["LaunchdConfig"] = launchdPlist(cfg)
 
 	// For user services on macOS, set UserService option
 	if cfg.UserService {
@@ -102,11 +218,14 @@ func NewService(cfg *ServiceConfig) (service.Service, error) {
 	}
 
 	svcConfig := &service.Config{
-		Name:        serviceName,
-		DisplayName: serviceDisplayName,
-		Description: serviceDescription,
-		Arguments:   []string{"--service-mode", "--config", cfg.ConfigPath},
-		Option:      options,
+		Name:         serviceName,
+		DisplayName:  serviceDisplayName,
+		Description:  serviceDescription,
+		UserName:     cfg.RunAs,
+		Arguments:    []string{"--service-mode", "--config", cfg.ConfigPath},
+		Dependencies: systemdDependencies(cfg),
+		EnvVars:      cfg.Env,
+		Option:       options,
 	}
 
 	// Set working directory if specified
@@ -118,14 +237,19 @@ func NewService(cfg *ServiceConfig) (service.Service, error) {
 }
 
 // RunServiceMode runs hookly in service mode (called by service manager).
-func RunServiceMode(configPath string) error {
+// release tags error reports.
+func RunServiceMode(configPath, release string) error {
 	// Setup logging for service mode
-	slog.SetDefault(slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
-		Level: slog.LevelInfo,
-	})))
+	recent := logging.NewRecent(relay.MaxLogLines)
+	slog.SetDefault(slog.New(logging.Fanout(
+		slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}),
+		recent.Handler(slog.LevelInfo),
+	)))
 
 	cfg := &ServiceConfig{
 		ConfigPath: configPath,
+		Release:    release,
+		RecentLogs: recent,
 	}
 
 	svc, err := NewService(cfg)
@@ -145,7 +269,13 @@ func ControlService(cfg *ServiceConfig, action string) error {
 
 	switch action {
 	case "install":
-		return svc.Install()
+		if err := svc.Install(); err != nil {
+			return err
+		}
+		if cfg.NoAutostart {
+			return disableAutostart(cfg)
+		}
+		return nil
 	case "uninstall":
 		return svc.Uninstall()
 	case "start":
@@ -159,6 +289,19 @@ func ControlService(cfg *ServiceConfig, action string) error {
 	}
 }
 
+// disableAutostart keeps an installed systemd unit from starting at boot or
+// login; the service library always enables it.
+func disableAutostart(cfg *ServiceConfig) error {
+	args := []string{"disable", serviceName + ".service"}
+	if cfg.UserService {
+		args = append(args, "--user")
+	}
+	if out, err := exec.Command("systemctl", args...).CombinedOutput(); err != nil {
+		return fmt.Errorf("systemctl disable: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
 // GetServiceStatus returns the current service status.
 func GetServiceStatus(cfg *ServiceConfig) (service.Status, error) {
 	svc, err := NewService(cfg)
@@ -97,19 +97,20 @@ endpoints: []
 		t.Fatalf("failed to write test config: %v", err)
 	}
 
-	t.Run("start fails with invalid config", func(t *testing.T) {
+	t.Run("start keeps running with invalid config", func(t *testing.T) {
 		cfg := &ServiceConfig{
 			ConfigPath: configPath,
 		}
 
-		prg := &Program{cfg: cfg}
+		prg := &Program{cfg: cfg, crashes: loadCrashLoop("", nil)}
 
-		// Start should fail because config is invalid (no endpoints)
-		err := prg.Start(nil)
-		if err == nil {
-			// If it didn't fail, make sure to stop it
-			prg.Stop(nil)
-			t.Error("expected error for invalid config")
+		// Start must not fail, or the service manager would restart hookly
+		// in a tight loop; the relay is retried in the background instead
+		if err := prg.Start(nil); err != nil {
+			t.Fatalf("Start returned error: %v", err)
+		}
+		if err := prg.Stop(nil); err != nil {
+			t.Errorf("Stop returned unexpected error: %v", err)
 		}
 	})
 
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"hooks.dx314.com/internal/exitcode"
+)
+
+// The service definitions below are the service library's defaults with
+// hookly's unit options added. The library renders them with its own data
+// (path, arguments, user, environment), so options it has no field for are
+// formatted into the template text.
+
+// systemdUnit returns the systemd unit template for cfg.
+func systemdUnit(cfg *ServiceConfig) string {
+	var extra strings.Builder
+	if cfg.Nice != 0 {
+		fmt.Fprintf(&extra, "Nice=%d\n", cfg.Nice)
+	}
+	if cfg.IOClass != "" {
+		fmt.Fprintf(&extra, "IOSchedulingClass=%s\n", cfg.IOClass)
+	}
+
+	// User units have no multi-user.target; default.target starts them on login
+	wantedBy := "multi-user.target"
+	if cfg.UserService {
+		wantedBy = "default.target"
+	}
+
+	return `[Unit]
+Description={{.Description}}
+ConditionFileIsExecutable={{.Path|cmdEscape}}
+{{range .Dependencies}}{{.}}
+{{end}}
+[Service]
+StartLimitInterval=5
+StartLimitBurst=10
+ExecStart={{.Path|cmdEscape}}{{range .Arguments}} {{.|cmd}}{{end}}
+{{if .WorkingDirectory}}WorkingDirectory={{.WorkingDirectory|cmdEscape}}
+{{end}}{{if .UserName}}User={{.UserName}}
+{{end}}{{if .Restart}}Restart={{.Restart}}
+{{end}}RestartSec=120
+RestartPreventExitStatus=` + fmt.Sprint(exitcode.Auth) + `
+` + extra.String() + `EnvironmentFile=-/etc/sysconfig/{{.Name}}
+{{range $k, $v := .EnvVars}}Environment={{printf "%s=%s" $k $v | cmd}}
+{{end}}
+[Install]
+WantedBy=` + wantedBy + "\n"
+}
+
+// systemdDependencies returns the [Unit] lines ordering the service after
+// cfg.After. network-online.target is only reached if something wants it.
+func systemdDependencies(cfg *ServiceConfig) []string {
+	var deps []string
+	for _, unit := range cfg.After {
+		if unit == "network-online.target" {
+			deps = append(deps, "Wants="+unit)
+		}
+		deps = append(deps, "After="+unit)
+	}
+	return deps
+}
+
+// systemdRestart returns the systemd Restart= value for a policy.
+func systemdRestart(policy string) string {
+	switch policy {
+	case RestartOnFailure:
+		return "on-failure"
+	case RestartNever:
+		return "no"
+	default:
+		return "always"
+	}
+}
+
+// launchdPlist returns the launchd property list template for cfg.
+func launchdPlist(cfg *ServiceConfig) string {
+	var keepAlive string
+	switch cfg.Restart {
+	case RestartOnFailure:
+		keepAlive = "<dict>\n\t\t<key>SuccessfulExit</key>\n\t\t<false/>\n\t</dict>"
+	case RestartNever:
+		keepAlive = "<false/>"
+	default:
+		keepAlive = "<true/>"
+	}
+
+	var extra strings.Builder
+	if cfg.Nice != 0 {
+		fmt.Fprintf(&extra, "\n\t<key>Nice</key>\n\t<integer>%d</integer>", cfg.Nice)
+	}
+	if cfg.IOClass == "idle" {
+		extra.WriteString("\n\t<key>LowPriorityIO</key>\n\t<true/>")
+	}
+
+	return `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE plist PUBLIC "-//Apple//DTD PLIST 1.0//EN" "http://www.apple.com/DTDs/PropertyList-1.0.dtd">
+<plist version="1.0">
+<dict>
+	<key>Disabled</key>
+	<false/>
+	{{- if .EnvVars}}
+	<key>EnvironmentVariables</key>
+	<dict>
+		{{- range $k, $v := .EnvVars}}
+		<key>{{html $k}}</key>
+		<string>{{html $v}}</string>
+		{{- end}}
+	</dict>
+	{{- end}}
+	<key>KeepAlive</key>
+	` + keepAlive + `
+	<key>Label</key>
+	<string>{{html .Name}}</string>` + extra.String() + `
+	<key>ProgramArguments</key>
+	<array>
+		<string>{{html .Path}}</string>
+		{{- range .Config.Arguments}}
+		<string>{{html .}}</string>
+		{{- end}}
+	</array>
+	<key>RunAtLoad</key>
+	<{{bool .RunAtLoad}}/>
+	<key>SessionCreate</key>
+	<{{bool .SessionCreate}}/>
+	{{- if .StandardErrorPath}}
+	<key>StandardErrorPath</key>
+	<string>{{html .StandardErrorPath}}</string>
+	{{- end}}
+	{{- if .StandardOutPath}}
+	<key>StandardOutPath</key>
+	<string>{{html .StandardOutPath}}</string>
+	{{- end}}
+	{{- if .UserName}}
+	<key>UserName</key>
+	<string>{{html .UserName}}</string>
+	{{- end}}
+	{{- if .WorkingDirectory}}
+	<key>WorkingDirectory</key>
+	<string>{{html .WorkingDirectory}}</string>
+	{{- end}}
+</dict>
+</plist>
+`
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"text/template"
+
+	"github.com/kardianos/service"
+)
+
+// render executes a unit template with the functions and data the service
+// library uses.
+func render(t *testing.T, tmpl string, cfg *ServiceConfig) string {
+	t.Helper()
+	funcs := template.FuncMap{
+		"cmd":       func(s string) string { return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"` },
+		"cmdEscape": func(s string) string { return strings.ReplaceAll(s, " ", `\x20`) },
+		"bool": func(v bool) string {
+			if v {
+				return "true"
+			}
+			return "false"
+		},
+	}
+	data := struct {
+		*service.Config
+		Path              string
+		Restart           string
+		RunAtLoad         bool
+		SessionCreate     bool
+		StandardOutPath   string
+		StandardErrorPath string
+	}{
+		Config: &service.Config{
+			Name:         serviceName,
+			Description:  serviceDescription,
+			UserName:     cfg.RunAs,
+			Arguments:    []string{"--service-mode", "--config", cfg.ConfigPath},
+			Dependencies: systemdDependencies(cfg),
+			EnvVars:      cfg.Env,
+		},
+		Path:      "/usr/local/bin/hookly",
+		Restart:   systemdRestart(cfg.Restart),
+		RunAtLoad: true,
+	}
+	var b strings.Builder
+	if err := template.Must(template.New("").Funcs(funcs).Parse(tmpl)).Execute(&b, data); err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	return b.String()
+}
+
+func TestSystemdUnit(t *testing.T) {
+	cfg := &ServiceConfig{
+		ConfigPath: "/etc/hookly/hookly.yaml",
+		RunAs:      "hookly",
+		Env:        map[string]string{"HTTPS_PROXY": "http://proxy:3128", "GREETING": `say "hi"`},
+		Nice:       5,
+		IOClass:    "idle",
+		Restart:    RestartOnFailure,
+		After:      []string{"network-online.target", "docker.service"},
+	}
+	unit := render(t, systemdUnit(cfg), cfg)
+
+	for _, line := range []string{
+		"Wants=network-online.target",
+		"After=network-online.target",
+		"After=docker.service",
+		"User=hookly",
+		"Restart=on-failure",
+		"RestartPreventExitStatus=3",
+		"Nice=5",
+		"IOSchedulingClass=idle",
+		`Environment="HTTPS_PROXY=http://proxy:3128"`,
+		`Environment="GREETING=say \"hi\""`,
+		"WantedBy=multi-user.target",
+	} {
+		if !strings.Contains(unit, line+"\n") {
+			t.Errorf("unit missing %q:\n%s", line, unit)
+		}
+	}
+
+	argv, err := parseSystemdExecStart(unit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"/usr/local/bin/hookly", "--service-mode", "--config", "/etc/hookly/hookly.yaml"}; !slices.Equal(argv, want) {
+		t.Errorf("argv = %q, want %q", argv, want)
+	}
+
+	// Defaults: no scheduling lines, user units start on login
+	user := render(t, systemdUnit(&ServiceConfig{UserService: true}), &ServiceConfig{})
+	if strings.Contains(user, "Nice=") || strings.Contains(user, "IOSchedulingClass=") || strings.Contains(user, "User=") {
+		t.Errorf("default unit has customization:\n%s", user)
+	}
+	if !strings.Contains(user, "Restart=always\n") || !strings.Contains(user, "WantedBy=default.target\n") {
+		t.Errorf("user unit:\n%s", user)
+	}
+}
+
+func TestLaunchdPlist(t *testing.T) {
+	cfg := &ServiceConfig{
+		ConfigPath: "/Users/me/R&D/hookly.yaml",
+		Env:        map[string]string{"HTTPS_PROXY": "http://proxy:3128"},
+		Nice:       10,
+		IOClass:    "idle",
+		Restart:    RestartOnFailure,
+	}
+	plist := render(t, launchdPlist(cfg), cfg)
+
+	for _, want := range []string{
+		"<key>SuccessfulExit</key>\n\t\t<false/>",
+		"<key>Nice</key>\n\t<integer>10</integer>",
+		"<key>LowPriorityIO</key>\n\t<true/>",
+		"<key>HTTPS_PROXY</key>\n\t\t<string>http://proxy:3128</string>",
+	} {
+		if !strings.Contains(plist, want) {
+			t.Errorf("plist missing %q:\n%s", want, plist)
+		}
+	}
+
+	argv, err := parseLaunchdArgs(plist)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if argv[len(argv)-1] != cfg.ConfigPath {
+		t.Errorf("config arg = %q, want %q", argv[len(argv)-1], cfg.ConfigPath)
+	}
+
+	never := render(t, launchdPlist(&ServiceConfig{Restart: RestartNever}), &ServiceConfig{})
+	if !strings.Contains(never, "<key>KeepAlive</key>\n\t<false/>") || strings.Contains(never, "Nice") {
+		t.Errorf("restart never plist:\n%s", never)
+	}
+}
+
+func TestValidateUnitOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     ServiceConfig
+		wantErr bool
+	}{
+		{"defaults", ServiceConfig{}, false},
+		{"all options", ServiceConfig{RunAs: "hookly", Env: map[string]string{"A_1": "x,y"}, Nice: -5, IOClass: "realtime", Restart: RestartNever, After: []string{"network-online.target"}}, false},
+		{"run-as user service", ServiceConfig{UserService: true, RunAs: "root"}, true},
+		{"bad env key", ServiceConfig{Env: map[string]string{"1BAD": "x"}}, true},
+		{"env newline", ServiceConfig{Env: map[string]string{"A": "x\ny"}}, true},
+		{"nice range", ServiceConfig{Nice: 20}, true},
+		{"user negative nice", ServiceConfig{UserService: true, Nice: -1}, true},
+		{"user positive nice", ServiceConfig{UserService: true, Nice: 10, IOClass: "idle"}, false},
+		{"bad io class", ServiceConfig{IOClass: "low"}, true},
+		{"bad restart", ServiceConfig{Restart: "sometimes"}, true},
+		{"bad unit", ServiceConfig{After: []string{"network online"}}, true},
+		{"no autostart", ServiceConfig{NoAutostart: true}, runtime.GOOS == "darwin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.validateUnitOptions()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateUnitOptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
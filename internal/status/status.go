@@ -0,0 +1,146 @@
+// Package status serves /statusz, a JSON health report of the edge gateway
+// for external status pages: the health of each component, build info,
+// uptime and queue counts. It reports aggregates only, never per-user data,
+// so it is served without auth.
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"hooks.dx314.com/internal/buildinfo"
+)
+
+// checkTimeout bounds each check and reading the queue counts.
+const checkTimeout = 5 * time.Second
+
+// State is the health of a component or of the whole edge.
+type State string
+
+const (
+	StateOK       State = "ok"
+	StateDegraded State = "degraded" // Working, but with recent failures
+	StateDown     State = "down"
+)
+
+// severity orders states from best to worst.
+func (s State) severity() int {
+	switch s {
+	case StateOK:
+		return 0
+	case StateDegraded:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// Component is the health of one component. Detail is shown publicly, so it
+// must not contain user data such as error messages about an endpoint.
+type Component struct {
+	Status State  `json:"status"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// Check reports the health of a component.
+type Check func(ctx context.Context) Component
+
+// Report is the /statusz response.
+type Report struct {
+	Status        State                `json:"status"` // The worst component state
+	Components    map[string]Component `json:"components"`
+	Build         buildinfo.Info       `json:"build"`
+	StartedAt     time.Time            `json:"started_at"`
+	UptimeSeconds int64                `json:"uptime_seconds"`
+	// Stored webhooks by status across all users; omitted if they couldn't
+	// be counted.
+	Queue map[string]int64 `json:"queue,omitempty"`
+}
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Handler serves the status report.
+type Handler struct {
+	build   buildinfo.Info
+	started time.Time
+	checks  []namedCheck
+	queue   func(context.Context) (map[string]int64, error)
+}
+
+// NewHandler creates a handler reporting build, with uptime counted from
+// now.
+func NewHandler(build buildinfo.Info) *Handler {
+	return &Handler{build: build, started: time.Now()}
+}
+
+// AddCheck adds a component to the report. It must be called before the
+// handler serves requests.
+func (h *Handler) AddCheck(name string, check Check) {
+	h.checks = append(h.checks, namedCheck{name: name, check: check})
+}
+
+// SetQueueCounts sets the function returning the number of stored webhooks
+// by status. It must be called before the handler serves requests.
+func (h *Handler) SetQueueCounts(fn func(context.Context) (map[string]int64, error)) {
+	h.queue = fn
+}
+
+// Report runs the checks, concurrently, and returns the report.
+func (h *Handler) Report(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	report := Report{
+		Status:        StateOK,
+		Components:    make(map[string]Component, len(h.checks)),
+		Build:         h.build,
+		StartedAt:     h.started.UTC(),
+		UptimeSeconds: int64(time.Since(h.started) / time.Second),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, c := range h.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			component := c.check(ctx)
+			mu.Lock()
+			defer mu.Unlock()
+			report.Components[c.name] = component
+			if component.Status.severity() > report.Status.severity() {
+				report.Status = component.Status
+			}
+		}()
+	}
+
+	if h.queue != nil {
+		counts, err := h.queue(ctx)
+		if err != nil {
+			slog.Warn("status: failed to count webhooks", "error", err)
+		} else {
+			report.Queue = counts
+		}
+	}
+	wg.Wait()
+	return report
+}
+
+// ServeHTTP writes the report as JSON, with status 503 if a component is
+// down so simple uptime probes notice too.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := h.Report(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if report.Status == StateDown {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,76 @@
+package status
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"hooks.dx314.com/internal/buildinfo"
+)
+
+func get(t *testing.T, h *Handler) (int, Report) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/statusz", nil))
+	var report Report
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode report: %v (%s)", err, rec.Body.String())
+	}
+	return rec.Code, report
+}
+
+func TestHandler(t *testing.T) {
+	h := NewHandler(buildinfo.Info{Version: "1.2.3", GoVersion: "go1.24.0"})
+	h.AddCheck("db", func(context.Context) Component { return Component{Status: StateOK} })
+	h.SetQueueCounts(func(context.Context) (map[string]int64, error) {
+		return map[string]int64{"pending": 4, "delivered": 10}, nil
+	})
+	code, report := get(t, h)
+	if code != http.StatusOK || report.Status != StateOK {
+		t.Errorf("status = %d %q, want 200 ok", code, report.Status)
+	}
+	if report.Build.Version != "1.2.3" || report.Build.GoVersion == "" {
+		t.Errorf("build = %+v", report.Build)
+	}
+	if report.Queue["pending"] != 4 {
+		t.Errorf("queue = %v", report.Queue)
+	}
+	if report.StartedAt.IsZero() {
+		t.Errorf("no start time")
+	}
+}
+
+func TestHandlerWorstState(t *testing.T) {
+	for _, tt := range []struct {
+		states []State
+		want   State
+		code   int
+	}{
+		{states: nil, want: StateOK, code: http.StatusOK},
+		{states: []State{StateOK, StateDegraded}, want: StateDegraded, code: http.StatusOK},
+		{states: []State{StateDown, StateDegraded, StateOK}, want: StateDown, code: http.StatusServiceUnavailable},
+	} {
+		h := NewHandler(buildinfo.Get())
+		for i, s := range tt.states {
+			h.AddCheck(string(rune('a'+i)), func(context.Context) Component {
+				return Component{Status: s, Detail: "detail"}
+			})
+		}
+		h.SetQueueCounts(func(context.Context) (map[string]int64, error) {
+			return nil, errors.New("database is locked")
+		})
+		code, report := get(t, h)
+		if code != tt.code || report.Status != tt.want {
+			t.Errorf("%v: got %d %q, want %d %q", tt.states, code, report.Status, tt.code, tt.want)
+		}
+		if len(report.Components) != len(tt.states) {
+			t.Errorf("%v: components = %v", tt.states, report.Components)
+		}
+		if report.Queue != nil {
+			t.Errorf("queue reported after an error: %v", report.Queue)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package telemetry
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// maxReportSize bounds a report body.
+const maxReportSize = 4 << 10
+
+// maxLabelValues caps the distinct versions and platforms counted, so a
+// flood of made-up reports can't grow the metrics without bound. Further
+// values are counted as "other".
+const maxLabelValues = 50
+
+// Collector counts the reports the edge receives and exposes the counts in
+// the OpenMetrics text format, next to the gateway metrics. It doesn't
+// keep reports, or log them or who sent them.
+type Collector struct {
+	mu        sync.Mutex
+	versions  map[string]uint64 // Reports by CLI version
+	platforms map[string]uint64 // Reports by os/arch
+	endpoints map[string]uint64 // Reports by endpoint bucket
+	errors    map[string]uint64 // Failed commands by error class
+}
+
+// NewCollector creates a collector with no reports.
+func NewCollector() *Collector {
+	return &Collector{
+		versions:  make(map[string]uint64),
+		platforms: make(map[string]uint64),
+		endpoints: make(map[string]uint64),
+		errors:    make(map[string]uint64),
+	}
+}
+
+// ServeHTTP receives a report, answering 204 once it is counted.
+func (c *Collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	var report Report
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxReportSize))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&report); err != nil {
+		http.Error(w, "invalid report", http.StatusBadRequest)
+		return
+	}
+	if err := report.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	c.Record(report)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Record counts a validated report.
+func (c *Collector) Record(r Report) {
+	version := r.Version
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i] // Pre-releases count with their release
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	countCapped(c.versions, strings.TrimPrefix(version, "v"))
+	countCapped(c.platforms, r.OS+"/"+r.Arch)
+	c.endpoints[r.Endpoints]++
+	for class, n := range r.Errors {
+		c.errors[class] += uint64(n)
+	}
+}
+
+// countCapped counts key, or "other" once m holds maxLabelValues keys.
+func countCapped(m map[string]uint64, key string) {
+	if _, ok := m[key]; !ok && len(m) >= maxLabelValues {
+		key = "other"
+	}
+	m[key]++
+}
+
+// WriteTo writes the counts in the OpenMetrics text format, without the
+// trailing # EOF, for metrics.Handler.
+func (c *Collector) WriteTo(w io.Writer) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var b strings.Builder
+	b.WriteString("# TYPE hookly_telemetry_reports counter\n")
+	b.WriteString("# HELP hookly_telemetry_reports Usage reports from CLIs that opted in, by version.\n")
+	for _, v := range sortedKeys(c.versions) {
+		fmt.Fprintf(&b, "hookly_telemetry_reports_total{version=%q} %d\n", v, c.versions[v])
+	}
+
+	b.WriteString("# TYPE hookly_telemetry_platform_reports counter\n")
+	b.WriteString("# HELP hookly_telemetry_platform_reports Usage reports by CLI os/arch.\n")
+	for _, p := range sortedKeys(c.platforms) {
+		fmt.Fprintf(&b, "hookly_telemetry_platform_reports_total{platform=%q} %d\n", p, c.platforms[p])
+	}
+
+	b.WriteString("# TYPE hookly_telemetry_endpoint_reports counter\n")
+	b.WriteString("# HELP hookly_telemetry_endpoint_reports Usage reports by the number of endpoints the relay runs with.\n")
+	for _, e := range EndpointBuckets {
+		fmt.Fprintf(&b, "hookly_telemetry_endpoint_reports_total{endpoints=%q} %d\n", e, c.endpoints[e])
+	}
+
+	b.WriteString("# TYPE hookly_telemetry_command_errors counter\n")
+	b.WriteString("# HELP hookly_telemetry_command_errors Failed CLI commands reported, by error class.\n")
+	for _, class := range ErrorClasses {
+		fmt.Fprintf(&b, "hookly_telemetry_command_errors_total{class=%q} %d\n", class, c.errors[class])
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+func sortedKeys(m map[string]uint64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,55 @@
+package telemetry
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCollectorServeHTTP(t *testing.T) {
+	c := NewCollector()
+	for _, tt := range []struct {
+		name   string
+		method string
+		body   string
+		want   int
+	}{
+		{"report", http.MethodPost, `{"version":"1.2.0-rc.1","os":"linux","arch":"amd64","endpoints":"0","errors":{"auth":2}}`, http.StatusNoContent},
+		{"extra field", http.MethodPost, `{"version":"1.2.0","os":"linux","arch":"amd64","endpoints":"0","hostname":"build-box"}`, http.StatusBadRequest},
+		{"invalid", http.MethodPost, `{"version":"1.2.0","os":"linux","arch":"amd64","endpoints":"12"}`, http.StatusBadRequest},
+		{"not json", http.MethodPost, `version=1.2.0`, http.StatusBadRequest},
+		{"get", http.MethodGet, "", http.StatusMethodNotAllowed},
+	} {
+		rec := httptest.NewRecorder()
+		c.ServeHTTP(rec, httptest.NewRequest(tt.method, Path, strings.NewReader(tt.body)))
+		if rec.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, rec.Code, tt.want)
+		}
+	}
+
+	var out strings.Builder
+	c.WriteTo(&out)
+	for _, want := range []string{
+		`hookly_telemetry_reports_total{version="1.2.0"} 1`,
+		`hookly_telemetry_platform_reports_total{platform="linux/amd64"} 1`,
+		`hookly_telemetry_endpoint_reports_total{endpoints="0"} 1`,
+		`hookly_telemetry_command_errors_total{class="auth"} 2`,
+		`hookly_telemetry_command_errors_total{class="network"} 0`,
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("metrics missing %q:\n%s", want, out.String())
+		}
+	}
+}
+
+func TestCollectorCapsLabels(t *testing.T) {
+	c := NewCollector()
+	for i := range maxLabelValues + 10 {
+		c.Record(Report{Version: fmt.Sprintf("1.%d.0", i), OS: "linux", Arch: "amd64", Endpoints: "1"})
+	}
+	if len(c.versions) != maxLabelValues+1 || c.versions["other"] != 10 {
+		t.Errorf("%d versions, %d other; want %d and 10", len(c.versions), c.versions["other"], maxLabelValues+1)
+	}
+}
@@ -0,0 +1,281 @@
+// Package telemetry reports anonymous usage counters from the CLI to the
+// edge, for users who opt in with 'hookly telemetry on'. It is off until
+// then.
+//
+// A report holds only coarse counters: the CLI version, OS and
+// architecture, a bucket of the configured endpoint count, and how many
+// commands failed with each error class since the last report. It never
+// holds webhook payloads, endpoint IDs or URLs, hostnames, user or token
+// names, or any ID that would tie reports from one machine together. The
+// edge counts reports in its metrics and keeps nothing else.
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"hooks.dx314.com/internal/buildinfo"
+)
+
+const (
+	// File is the name of the telemetry settings file in the config dir.
+	File = "telemetry.json"
+	// Path is where the edge receives reports.
+	Path = "/telemetry"
+	// Interval is the least time between two reports.
+	Interval = 24 * time.Hour
+	// EnvVar disables telemetry when set to off, false or 0, whatever the
+	// setting, so it can be kept off on CI machines and in images.
+	EnvVar = "HOOKLY_TELEMETRY"
+)
+
+// maxErrorCount caps each error count in a report; the counters only need
+// to tell rare from common.
+const maxErrorCount = 1000
+
+// EndpointBuckets are the endpoint count buckets reports use.
+var EndpointBuckets = []string{"0", "1", "2-5", "6-20", "21-100", "100+"}
+
+// ErrorClasses are the error classes reports count, named after the CLI's
+// exit codes.
+var ErrorClasses = []string{"error", "auth", "config", "endpoint", "network", "interrupted"}
+
+// Report is everything a report sends.
+type Report struct {
+	Version   string         `json:"version"`
+	OS        string         `json:"os"`
+	Arch      string         `json:"arch"`
+	Endpoints string         `json:"endpoints"`        // One of EndpointBuckets
+	Errors    map[string]int `json:"errors,omitempty"` // Failed commands by error class
+}
+
+// EndpointBucket returns the bucket of an endpoint count.
+func EndpointBucket(n int) string {
+	switch {
+	case n <= 0:
+		return "0"
+	case n == 1:
+		return "1"
+	case n <= 5:
+		return "2-5"
+	case n <= 20:
+		return "6-20"
+	case n <= 100:
+		return "21-100"
+	default:
+		return "100+"
+	}
+}
+
+// Validate checks that a report holds nothing but the fields and values
+// reports are documented to hold.
+func (r Report) Validate() error {
+	if r.Version != buildinfo.DevVersion {
+		if _, ok := buildinfo.Compare(r.Version, r.Version); !ok {
+			return fmt.Errorf("invalid version %q", r.Version)
+		}
+	}
+	if !validName(r.OS) || !validName(r.Arch) {
+		return fmt.Errorf("invalid platform %q/%q", r.OS, r.Arch)
+	}
+	if !slices.Contains(EndpointBuckets, r.Endpoints) {
+		return fmt.Errorf("invalid endpoint bucket %q", r.Endpoints)
+	}
+	for class, n := range r.Errors {
+		if !slices.Contains(ErrorClasses, class) {
+			return fmt.Errorf("invalid error class %q", class)
+		}
+		if n < 0 || n > maxErrorCount {
+			return fmt.Errorf("invalid %s error count %d", class, n)
+		}
+	}
+	return nil
+}
+
+// validName reports whether s looks like a GOOS or GOARCH value.
+func validName(s string) bool {
+	if s == "" || len(s) > 16 {
+		return false
+	}
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Disabled reports whether the environment turns telemetry off: EnvVar set
+// to off, false or 0, or DO_NOT_TRACK set to 1.
+func Disabled(getenv func(string) string) bool {
+	switch strings.ToLower(getenv(EnvVar)) {
+	case "off", "false", "0":
+		return true
+	}
+	return getenv("DO_NOT_TRACK") == "1"
+}
+
+// State is the telemetry setting and the counters of the next report.
+type State struct {
+	Enabled   bool           `json:"enabled"`
+	DecidedAt time.Time      `json:"decided_at,omitzero"` // When Enabled was last set
+	LastSent  time.Time      `json:"last_sent,omitzero"`
+	Endpoints int            `json:"endpoints"`        // Endpoint count the relay last ran with
+	Errors    map[string]int `json:"errors,omitempty"` // Failed commands since LastSent
+}
+
+// Due reports whether a report should be sent at now.
+func (s State) Due(now time.Time) bool {
+	return s.Enabled && now.Sub(s.LastSent) >= Interval
+}
+
+// Report returns the report the state would send from build.
+func (s State) Report(build buildinfo.Info, goos, goarch string) Report {
+	return Report{
+		Version:   build.Version,
+		OS:        goos,
+		Arch:      goarch,
+		Endpoints: EndpointBucket(s.Endpoints),
+		Errors:    s.Errors,
+	}
+}
+
+// Store keeps the state in a file.
+type Store struct {
+	path string
+}
+
+// NewStore creates a store for the settings file at path.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Path returns the path of the settings file.
+func (s *Store) Path() string {
+	return s.path
+}
+
+// Load reads the state. A missing file is telemetry that was never turned
+// on.
+func (s *Store) Load() (State, error) {
+	var state State
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, fmt.Errorf("read telemetry settings: %w", err)
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("parse telemetry settings: %w", err)
+	}
+	return state, nil
+}
+
+// Save writes the state.
+func (s *Store) Save(state State) error {
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return fmt.Errorf("create config dir: %w", err)
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.path, data, 0600); err != nil {
+		return fmt.Errorf("write telemetry settings: %w", err)
+	}
+	return nil
+}
+
+// SetEnabled turns telemetry on or off. Turning it off discards the
+// counters, so nothing recorded is sent if it is turned on again.
+func (s *Store) SetEnabled(enabled bool, now time.Time) error {
+	state, err := s.Load()
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		state = State{}
+	}
+	state.Enabled = enabled
+	state.DecidedAt = now
+	return s.Save(state)
+}
+
+// RecordError counts a failed command. It records nothing while telemetry
+// is off.
+func (s *Store) RecordError(class string) error {
+	return s.update(func(state *State) {
+		if state.Errors == nil {
+			state.Errors = make(map[string]int)
+		}
+		state.Errors[class] = min(state.Errors[class]+1, maxErrorCount)
+	})
+}
+
+// RecordEndpoints records the number of endpoints the relay runs with. It
+// records nothing while telemetry is off.
+func (s *Store) RecordEndpoints(n int) error {
+	return s.update(func(state *State) {
+		state.Endpoints = n
+	})
+}
+
+// MarkSent records that r was sent at now, taking its errors off the
+// counts. Errors recorded while it was sent are kept for the next report.
+func (s *Store) MarkSent(r Report, now time.Time) error {
+	return s.update(func(state *State) {
+		state.LastSent = now
+		for class, n := range r.Errors {
+			if left := state.Errors[class] - n; left > 0 {
+				state.Errors[class] = left
+			} else {
+				delete(state.Errors, class)
+			}
+		}
+		if len(state.Errors) == 0 {
+			state.Errors = nil
+		}
+	})
+}
+
+// update applies fn to the state if telemetry is on.
+func (s *Store) update(fn func(*State)) error {
+	state, err := s.Load()
+	if err != nil || !state.Enabled {
+		return err
+	}
+	fn(&state)
+	return s.Save(state)
+}
+
+// Send posts a report to the edge at edgeURL.
+func Send(ctx context.Context, client *http.Client, edgeURL string, r Report) error {
+	body, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(edgeURL, "/")+Path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send telemetry: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNoContent {
+		return fmt.Errorf("send telemetry: status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package telemetry
+
+import (
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"hooks.dx314.com/internal/buildinfo"
+)
+
+func TestEndpointBucket(t *testing.T) {
+	for n, want := range map[int]string{0: "0", 1: "1", 2: "2-5", 5: "2-5", 6: "6-20", 20: "6-20", 21: "21-100", 100: "21-100", 101: "100+"} {
+		if got := EndpointBucket(n); got != want {
+			t.Errorf("EndpointBucket(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestReportValidate(t *testing.T) {
+	valid := Report{Version: "1.2.0", OS: "linux", Arch: "amd64", Endpoints: "2-5", Errors: map[string]int{"network": 3}}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("valid report: %v", err)
+	}
+
+	for name, mutate := range map[string]func(*Report){
+		"version":      func(r *Report) { r.Version = "my-laptop" },
+		"os":           func(r *Report) { r.OS = "Alice's Mac" },
+		"bucket":       func(r *Report) { r.Endpoints = "7" },
+		"error class":  func(r *Report) { r.Errors = map[string]int{"stripe webhook ep_123": 1} },
+		"error count":  func(r *Report) { r.Errors = map[string]int{"auth": -1} },
+		"empty arch":   func(r *Report) { r.Arch = "" },
+		"dev accepted": nil,
+	} {
+		r := valid
+		if mutate == nil {
+			r.Version = buildinfo.DevVersion
+			if err := r.Validate(); err != nil {
+				t.Errorf("%s: %v", name, err)
+			}
+			continue
+		}
+		mutate(&r)
+		if err := r.Validate(); err == nil {
+			t.Errorf("%s: invalid report accepted: %+v", name, r)
+		}
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	for env, want := range map[string]bool{
+		"":               false,
+		EnvVar + "=off":  true,
+		EnvVar + "=0":    true,
+		EnvVar + "=on":   false,
+		"DO_NOT_TRACK=1": true,
+	} {
+		getenv := func(key string) string {
+			if k, v, ok := strings.Cut(env, "="); ok && k == key {
+				return v
+			}
+			return ""
+		}
+		if got := Disabled(getenv); got != want {
+			t.Errorf("Disabled(%q) = %v, want %v", env, got, want)
+		}
+	}
+}
+
+func TestStoreRecordsOnlyWhenEnabled(t *testing.T) {
+	s := NewStore(filepath.Join(t.TempDir(), "hookly", File))
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+
+	// Off by default: nothing is recorded, or even written
+	if err := s.RecordError("auth"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RecordEndpoints(3); err != nil {
+		t.Fatal(err)
+	}
+	if state, err := s.Load(); err != nil || state.Enabled || state.Errors != nil || state.Endpoints != 0 {
+		t.Fatalf("state while off: %+v, %v", state, err)
+	}
+
+	if err := s.SetEnabled(true, now); err != nil {
+		t.Fatal(err)
+	}
+	s.RecordError("auth")
+	s.RecordError("auth")
+	s.RecordEndpoints(3)
+	state, err := s.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !state.Due(now) || state.Errors["auth"] != 2 {
+		t.Fatalf("state while on: %+v", state)
+	}
+	report := state.Report(buildinfo.Info{Version: "1.2.0", Commit: "abc"}, "linux", "arm64")
+	if report.Endpoints != "2-5" || report.Version != "1.2.0" || report.Errors["auth"] != 2 {
+		t.Errorf("report = %+v", report)
+	}
+
+	// An error recorded while the report was sent is kept for the next
+	s.RecordError("network")
+	if err := s.MarkSent(report, now); err != nil {
+		t.Fatal(err)
+	}
+	state, _ = s.Load()
+	if state.Due(now.Add(Interval-time.Second)) || !state.Due(now.Add(Interval)) {
+		t.Errorf("due after sending: %+v", state)
+	}
+	if len(state.Errors) != 1 || state.Errors["network"] != 1 {
+		t.Errorf("errors after sending: %v, want only the later network error", state.Errors)
+	}
+
+	// Turning off discards what was recorded
+	s.RecordError("network")
+	if err := s.SetEnabled(false, now); err != nil {
+		t.Fatal(err)
+	}
+	if state, _ := s.Load(); state.Enabled || state.Errors != nil || state.Endpoints != 0 || !state.LastSent.IsZero() {
+		t.Errorf("state after turning off: %+v", state)
+	}
+}
+
+func TestSend(t *testing.T) {
+	c := NewCollector()
+	srv := httptest.NewServer(c)
+	defer srv.Close()
+
+	report := Report{Version: "1.2.0", OS: "darwin", Arch: "arm64", Endpoints: "1", Errors: map[string]int{"config": 1}}
+	if err := Send(t.Context(), srv.Client(), srv.URL+"/", report); err != nil {
+		t.Fatalf("send: %v", err)
+	}
+	if c.versions["1.2.0"] != 1 || c.errors["config"] != 1 {
+		t.Errorf("collector counts: %+v %+v", c.versions, c.errors)
+	}
+
+	report.Endpoints = "lots"
+	if err := Send(t.Context(), srv.Client(), srv.URL, report); err == nil {
+		t.Error("invalid report sent")
+	}
+}
@@ -0,0 +1,337 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// queueSize bounds the spans waiting to be exported; more are dropped.
+	queueSize = 2048
+	// batchSize is the most spans exported in one request.
+	batchSize = 256
+	// flushInterval is how long an incomplete batch waits before export.
+	flushInterval = 5 * time.Second
+	// sendTimeout bounds a single export.
+	sendTimeout = 10 * time.Second
+)
+
+// Options configure span export.
+type Options struct {
+	// Endpoint is the OTLP/HTTP traces URL, such as
+	// http://localhost:4318/v1/traces. Spans aren't exported if empty.
+	Endpoint string
+	// Headers are sent with every export, for example an API key.
+	Headers map[string]string
+	// ServiceName and ServiceVersion identify the process in traces.
+	ServiceName    string
+	ServiceVersion string
+}
+
+// OptionsFromEnv reads the standard OpenTelemetry exporter variables:
+// OTEL_EXPORTER_OTLP_TRACES_ENDPOINT, or OTEL_EXPORTER_OTLP_ENDPOINT with
+// /v1/traces appended; OTEL_EXPORTER_OTLP_TRACES_HEADERS or
+// OTEL_EXPORTER_OTLP_HEADERS as comma-separated key=value pairs; and
+// OTEL_SERVICE_NAME, which overrides serviceName.
+func OptionsFromEnv(getenv func(string) string, serviceName string) (Options, error) {
+	opts := Options{ServiceName: serviceName}
+	if v := getenv("OTEL_SERVICE_NAME"); v != "" {
+		opts.ServiceName = v
+	}
+	opts.Endpoint = getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")
+	if opts.Endpoint == "" {
+		if base := getenv("OTEL_EXPORTER_OTLP_ENDPOINT"); base != "" {
+			opts.Endpoint = strings.TrimSuffix(base, "/") + "/v1/traces"
+		}
+	}
+	if opts.Endpoint != "" {
+		if err := checkEndpoint(opts.Endpoint); err != nil {
+			return Options{}, err
+		}
+	}
+	headers := getenv("OTEL_EXPORTER_OTLP_TRACES_HEADERS")
+	if headers == "" {
+		headers = getenv("OTEL_EXPORTER_OTLP_HEADERS")
+	}
+	var err error
+	if opts.Headers, err = parseHeaders(headers); err != nil {
+		return Options{}, err
+	}
+	return opts, nil
+}
+
+func checkEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid OTLP endpoint %q: expected an http or https URL", endpoint)
+	}
+	return nil
+}
+
+// parseHeaders parses key=value pairs separated by commas, with URL-encoded
+// values.
+func parseHeaders(s string) (map[string]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	headers := make(map[string]string)
+	for pair := range strings.SplitSeq(s, ",") {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid OTLP header %q: expected key=value", strings.TrimSpace(pair))
+		}
+		if decoded, err := url.QueryUnescape(strings.TrimSpace(value)); err == nil {
+			value = decoded
+		}
+		headers[key] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// Tracer exports ended spans in batches in the background.
+type Tracer struct {
+	opts   Options
+	client *http.Client
+
+	spans     chan *Span
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// New creates a tracer exporting to opts.Endpoint. It returns nil, a tracer
+// that exports nothing, if the endpoint is empty.
+func New(opts Options) (*Tracer, error) {
+	if opts.Endpoint == "" {
+		return nil, nil
+	}
+	if err := checkEndpoint(opts.Endpoint); err != nil {
+		return nil, err
+	}
+	if opts.ServiceName == "" {
+		opts.ServiceName = "hookly"
+	}
+	t := &Tracer{
+		opts:   opts,
+		client: &http.Client{Timeout: sendTimeout},
+		spans:  make(chan *Span, queueSize),
+		done:   make(chan struct{}),
+	}
+	go t.run()
+	return t, nil
+}
+
+// FromEnv creates a tracer configured by the OTEL_* variables, see
+// OptionsFromEnv. It returns nil, exporting nothing, if they are unset or
+// invalid.
+func FromEnv(getenv func(string) string, serviceName, serviceVersion string) *Tracer {
+	opts, err := OptionsFromEnv(getenv, serviceName)
+	if err != nil {
+		slog.Warn("tracing disabled", "error", err)
+		return nil
+	}
+	opts.ServiceVersion = serviceVersion
+	t, _ := New(opts) // Options are already checked
+	return t
+}
+
+// Close exports queued spans, waiting at most timeout, and stops the tracer.
+func (t *Tracer) Close(timeout time.Duration) {
+	if t == nil {
+		return
+	}
+	t.closeOnce.Do(func() { close(t.spans) })
+	select {
+	case <-t.done:
+	case <-time.After(timeout):
+	}
+}
+
+// enqueue queues a span, dropping it if the queue is full or the tracer is
+// closed.
+func (t *Tracer) enqueue(s *Span) {
+	if t == nil {
+		return
+	}
+	defer func() { recover() }() // Send on a closed queue
+	select {
+	case t.spans <- s:
+	default:
+	}
+}
+
+func (t *Tracer) run() {
+	defer close(t.done)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	var batch []*Span
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		if err := t.send(batch); err != nil {
+			slog.Warn("spans not exported", "error", err, "spans", len(batch))
+		}
+		batch = nil
+	}
+	for {
+		select {
+		case s, ok := <-t.spans:
+			if !ok {
+				flush()
+				return
+			}
+			batch = append(batch, s)
+			if len(batch) >= batchSize {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (t *Tracer) send(batch []*Span) error {
+	body, err := json.Marshal(t.request(batch))
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.opts.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range t.opts.Headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("collector returned %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// The OTLP JSON encoding of an export request. IDs are hex and times are
+// nanoseconds as decimal strings.
+type (
+	exportRequest struct {
+		ResourceSpans []resourceSpans `json:"resourceSpans"`
+	}
+	resourceSpans struct {
+		Resource   resource     `json:"resource"`
+		ScopeSpans []scopeSpans `json:"scopeSpans"`
+	}
+	resource struct {
+		Attributes []keyValue `json:"attributes"`
+	}
+	scopeSpans struct {
+		Scope scope      `json:"scope"`
+		Spans []spanJSON `json:"spans"`
+	}
+	scope struct {
+		Name string `json:"name"`
+	}
+	spanJSON struct {
+		TraceID           string     `json:"traceId"`
+		SpanID            string     `json:"spanId"`
+		ParentSpanID      string     `json:"parentSpanId,omitempty"`
+		Name              string     `json:"name"`
+		Kind              Kind       `json:"kind"`
+		StartTimeUnixNano string     `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+		Attributes        []keyValue `json:"attributes,omitempty"`
+		Status            *status    `json:"status,omitempty"`
+	}
+	status struct {
+		Code    int    `json:"code"` // 2 is error
+		Message string `json:"message,omitempty"`
+	}
+	keyValue struct {
+		Key   string   `json:"key"`
+		Value anyValue `json:"value"`
+	}
+	anyValue struct {
+		StringValue *string  `json:"stringValue,omitempty"`
+		IntValue    *string  `json:"intValue,omitempty"`
+		BoolValue   *bool    `json:"boolValue,omitempty"`
+		DoubleValue *float64 `json:"doubleValue,omitempty"`
+	}
+)
+
+func (t *Tracer) request(batch []*Span) exportRequest {
+	attrs := []keyValue{stringAttr("service.name", t.opts.ServiceName)}
+	if t.opts.ServiceVersion != "" {
+		attrs = append(attrs, stringAttr("service.version", t.opts.ServiceVersion))
+	}
+	spans := make([]spanJSON, len(batch))
+	for i, s := range batch {
+		spans[i] = s.json()
+	}
+	return exportRequest{ResourceSpans: []resourceSpans{{
+		Resource:   resource{Attributes: attrs},
+		ScopeSpans: []scopeSpans{{Scope: scope{Name: "hooks.dx314.com/internal/tracing"}, Spans: spans}},
+	}}}
+}
+
+func (s *Span) json() spanJSON {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := spanJSON{
+		TraceID:           s.sc.TraceID.String(),
+		SpanID:            s.sc.SpanID.String(),
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+	}
+	if s.parent.IsValid() {
+		out.ParentSpanID = s.parent.String()
+	}
+	for _, a := range s.attrs {
+		out.Attributes = append(out.Attributes, keyValue{Key: a.key, Value: toAnyValue(a.value)})
+	}
+	if s.errMsg != "" {
+		out.Status = &status{Code: 2, Message: s.errMsg}
+	}
+	return out
+}
+
+func stringAttr(key, value string) keyValue {
+	return keyValue{Key: key, Value: anyValue{StringValue: &value}}
+}
+
+func toAnyValue(v any) anyValue {
+	switch v := v.(type) {
+	case int:
+		s := strconv.Itoa(v)
+		return anyValue{IntValue: &s}
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		return anyValue{IntValue: &s}
+	case bool:
+		return anyValue{BoolValue: &v}
+	case float64:
+		return anyValue{DoubleValue: &v}
+	case string:
+		return anyValue{StringValue: &v}
+	}
+	s := fmt.Sprint(v)
+	return anyValue{StringValue: &s}
+}
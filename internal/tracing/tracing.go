@@ -0,0 +1,225 @@
+// Package tracing records OpenTelemetry spans across the life of a webhook,
+// from ingestion on the edge through the relay to delivery on the hub, so a
+// slow delivery can be followed from one process to the other. It exports
+// spans over OTLP/HTTP in the JSON encoding, which any OpenTelemetry
+// collector (and Jaeger, Tempo or Honeycomb directly) accepts. Trace context
+// crosses processes as a W3C traceparent.
+//
+// Spans get IDs whether or not they are exported, so trace IDs can be
+// stored and shown even with tracing off. All methods are safe to call on a
+// nil *Tracer, which exports nothing, and on a nil *Span.
+package tracing
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TraceID identifies a trace.
+type TraceID [16]byte
+
+// IsValid reports whether the ID is not all zeros.
+func (t TraceID) IsValid() bool { return t != TraceID{} }
+
+func (t TraceID) String() string { return hex.EncodeToString(t[:]) }
+
+// SpanID identifies a span within a trace.
+type SpanID [8]byte
+
+// IsValid reports whether the ID is not all zeros.
+func (s SpanID) IsValid() bool { return s != SpanID{} }
+
+func (s SpanID) String() string { return hex.EncodeToString(s[:]) }
+
+// SpanContext is what a child span needs from its parent. The zero value
+// starts a new trace.
+type SpanContext struct {
+	TraceID TraceID
+	SpanID  SpanID
+}
+
+// IsValid reports whether both IDs are set.
+func (sc SpanContext) IsValid() bool { return sc.TraceID.IsValid() && sc.SpanID.IsValid() }
+
+// Traceparent formats the context as a W3C traceparent header value, or
+// returns "" if it is invalid. Spans are always sampled.
+func (sc SpanContext) Traceparent() string {
+	if !sc.IsValid() {
+		return ""
+	}
+	return "00-" + sc.TraceID.String() + "-" + sc.SpanID.String() + "-01"
+}
+
+// ParseTraceparent parses a W3C traceparent header value such as
+// 00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01.
+func ParseTraceparent(s string) (SpanContext, error) {
+	var sc SpanContext
+	s = strings.TrimSpace(s)
+	// Later versions may append fields, but must keep these
+	if len(s) < 55 || (len(s) > 55 && (s[:2] == "00" || s[55] != '-')) {
+		return sc, errors.New("invalid traceparent length")
+	}
+	if s[2] != '-' || s[35] != '-' || s[52] != '-' {
+		return sc, errors.New("invalid traceparent format")
+	}
+	if !isLowerHex(s[:2]) || s[:2] == "ff" {
+		return sc, fmt.Errorf("invalid traceparent version %q", s[:2])
+	}
+	if !isLowerHex(s[3:35]) || !isLowerHex(s[36:52]) || !isLowerHex(s[53:55]) {
+		return sc, errors.New("traceparent fields must be lowercase hex")
+	}
+	hex.Decode(sc.TraceID[:], []byte(s[3:35]))
+	hex.Decode(sc.SpanID[:], []byte(s[36:52]))
+	if !sc.IsValid() {
+		return SpanContext{}, errors.New("traceparent has an all-zero ID")
+	}
+	return sc, nil
+}
+
+func isLowerHex(s string) bool {
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// TraceIDFromTraceparent returns the trace ID of a stored traceparent as hex,
+// or "" if there is none.
+func TraceIDFromTraceparent(s string) string {
+	sc, err := ParseTraceparent(s)
+	if err != nil {
+		return ""
+	}
+	return sc.TraceID.String()
+}
+
+// Kind is the OpenTelemetry span kind.
+type Kind int
+
+// Span kinds, with their OTLP values.
+const (
+	KindInternal Kind = 1
+	KindServer   Kind = 2 // Handles a request, such as a webhook POST
+	KindClient   Kind = 3 // Makes a request, such as forwarding a webhook
+	KindProducer Kind = 4 // Queues a message, such as sending over the relay stream
+	KindConsumer Kind = 5 // Handles a queued message
+)
+
+// attribute is a span attribute. Values are strings, ints, bools or floats.
+type attribute struct {
+	key   string
+	value any
+}
+
+// Span is an operation within a trace. End it once, when the operation is done.
+type Span struct {
+	tracer *Tracer
+	name   string
+	kind   Kind
+	sc     SpanContext
+	parent SpanID
+	start  time.Time
+
+	mu     sync.Mutex
+	end    time.Time
+	attrs  []attribute
+	errMsg string
+	ended  bool
+}
+
+// Start starts a span as a child of parent, or as the root of a new trace if
+// parent is invalid.
+func (t *Tracer) Start(name string, kind Kind, parent SpanContext) *Span {
+	s := &Span{tracer: t, name: name, kind: kind, start: time.Now()}
+	if parent.IsValid() {
+		s.sc.TraceID = parent.TraceID
+		s.parent = parent.SpanID
+	} else {
+		randomID(s.sc.TraceID[:])
+	}
+	randomID(s.sc.SpanID[:])
+	return s
+}
+
+// StartFromTraceparent starts a span as a child of a traceparent, or as the
+// root of a new trace if traceparent is empty or invalid.
+func (t *Tracer) StartFromTraceparent(name string, kind Kind, traceparent string) *Span {
+	parent, _ := ParseTraceparent(traceparent)
+	return t.Start(name, kind, parent)
+}
+
+// randomID fills id with random bytes, never all zeros.
+func randomID(id []byte) {
+	for {
+		rand.Read(id)
+		for _, b := range id {
+			if b != 0 {
+				return
+			}
+		}
+	}
+}
+
+// Context returns the span's context, to start children with.
+func (s *Span) Context() SpanContext {
+	if s == nil {
+		return SpanContext{}
+	}
+	return s.sc
+}
+
+// Traceparent returns the span's context as a traceparent header value.
+func (s *Span) Traceparent() string {
+	return s.Context().Traceparent()
+}
+
+// SetAttribute records an attribute of the operation. Values other than
+// strings, ints, bools and floats are recorded as their %v formatting.
+func (s *Span) SetAttribute(key string, value any) {
+	if s == nil {
+		return
+	}
+	switch value.(type) {
+	case string, int, int64, bool, float64:
+	default:
+		value = fmt.Sprint(value)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attrs = append(s.attrs, attribute{key, value})
+}
+
+// SetError marks the operation as failed. A nil error does nothing.
+func (s *Span) SetError(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.errMsg = err.Error(); s.errMsg == "" {
+		s.errMsg = "error"
+	}
+}
+
+// End ends the span and queues it for export. Only the first call counts.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.ended {
+		s.mu.Unlock()
+		return
+	}
+	s.ended = true
+	s.end = time.Now()
+	s.mu.Unlock()
+	s.tracer.enqueue(s)
+}
@@ -0,0 +1,180 @@
+package tracing
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestParseTraceparent(t *testing.T) {
+	const valid = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, err := ParseTraceparent(valid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID.String() != "00f067aa0ba902b7" {
+		t.Errorf("parsed %s, %s", sc.TraceID, sc.SpanID)
+	}
+	if got := sc.Traceparent(); got != valid {
+		t.Errorf("Traceparent() = %q, want %q", got, valid)
+	}
+
+	// Later versions may append fields
+	if _, err := ParseTraceparent("cc-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra"); err != nil {
+		t.Errorf("future version: %v", err)
+	}
+
+	for _, s := range []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00_4bf92f3577b34da6a3ce929d0e0e4736_00f067aa0ba902b7_01",
+	} {
+		if _, err := ParseTraceparent(s); err == nil {
+			t.Errorf("ParseTraceparent(%q) succeeded", s)
+		}
+	}
+
+	if got := TraceIDFromTraceparent(valid); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("TraceIDFromTraceparent = %q", got)
+	}
+	if got := TraceIDFromTraceparent(""); got != "" {
+		t.Errorf("TraceIDFromTraceparent(\"\") = %q", got)
+	}
+}
+
+func TestStartJoinsParent(t *testing.T) {
+	var tracer *Tracer // Exports nothing, but spans still get IDs
+	root := tracer.Start("webhook.ingest", KindServer, SpanContext{})
+	if !root.Context().IsValid() {
+		t.Fatal("root span has no IDs")
+	}
+	child := tracer.StartFromTraceparent("webhook.dispatch", KindProducer, root.Traceparent())
+	if child.Context().TraceID != root.Context().TraceID {
+		t.Error("child started a new trace")
+	}
+	if child.parent != root.Context().SpanID || child.Context().SpanID == root.Context().SpanID {
+		t.Errorf("child parent = %s, span = %s", child.parent, child.Context().SpanID)
+	}
+	if other := tracer.StartFromTraceparent("webhook.ingest", KindServer, "garbage"); other.Context().TraceID == root.Context().TraceID {
+		t.Error("invalid traceparent joined an existing trace")
+	}
+	child.End()
+	root.End()
+
+	var span *Span
+	span.SetAttribute("key", "value")
+	span.End()
+	if span.Traceparent() != "" {
+		t.Error("nil span has a traceparent")
+	}
+}
+
+func TestExport(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []exportRequest
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("request to %s with content type %q", r.URL.Path, r.Header.Get("Content-Type"))
+		}
+		if r.Header.Get("X-Api-Key") != "secret key" {
+			t.Errorf("X-Api-Key = %q", r.Header.Get("X-Api-Key"))
+		}
+		var req exportRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode: %v", err)
+		}
+		mu.Lock()
+		requests = append(requests, req)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	env := map[string]string{
+		"OTEL_EXPORTER_OTLP_ENDPOINT": srv.URL + "/",
+		"OTEL_EXPORTER_OTLP_HEADERS":  "X-Api-Key=secret%20key",
+	}
+	opts, err := OptionsFromEnv(func(key string) string { return env[key] }, "hookly-edge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracer, err := New(opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	root := tracer.Start("webhook.ingest", KindServer, SpanContext{})
+	root.SetAttribute("hookly.endpoint_id", "ep_1")
+	root.SetAttribute("http.response.status_code", 200)
+	child := tracer.Start("webhook.forward", KindClient, root.Context())
+	child.SetError(errors.New("connection refused"))
+	child.End()
+	root.End()
+	tracer.Close(5 * time.Second)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(requests) != 1 {
+		t.Fatalf("got %d export requests, want 1", len(requests))
+	}
+	rs := requests[0].ResourceSpans[0]
+	if attr := rs.Resource.Attributes[0]; attr.Key != "service.name" || *attr.Value.StringValue != "hookly-edge" {
+		t.Errorf("resource attribute = %+v", attr)
+	}
+	spans := rs.ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	forward, ingest := spans[0], spans[1]
+	if forward.TraceID != ingest.TraceID || forward.ParentSpanID != ingest.SpanID || ingest.ParentSpanID != "" {
+		t.Errorf("spans not linked: %+v, %+v", forward, ingest)
+	}
+	if forward.Status == nil || forward.Status.Code != 2 || forward.Status.Message != "connection refused" {
+		t.Errorf("forward status = %+v", forward.Status)
+	}
+	if len(ingest.Attributes) != 2 || *ingest.Attributes[1].Value.IntValue != "200" {
+		t.Errorf("ingest attributes = %+v", ingest.Attributes)
+	}
+}
+
+func TestOptionsFromEnv(t *testing.T) {
+	env := map[string]string{
+		"OTEL_EXPORTER_OTLP_ENDPOINT":        "http://collector:4318",
+		"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "https://api.honeycomb.io/v1/traces",
+		"OTEL_SERVICE_NAME":                  "edge-eu",
+	}
+	opts, err := OptionsFromEnv(func(key string) string { return env[key] }, "hookly-edge")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Endpoint != "https://api.honeycomb.io/v1/traces" || opts.ServiceName != "edge-eu" {
+		t.Errorf("opts = %+v", opts)
+	}
+
+	opts, err = OptionsFromEnv(func(string) string { return "" }, "hookly-hub")
+	if err != nil || opts.Endpoint != "" {
+		t.Errorf("unset: opts = %+v, err = %v", opts, err)
+	}
+	if tracer, err := New(opts); tracer != nil || err != nil {
+		t.Errorf("New without an endpoint = %v, %v", tracer, err)
+	}
+
+	for _, bad := range []map[string]string{
+		{"OTEL_EXPORTER_OTLP_ENDPOINT": "collector:4318"},
+		{"OTEL_EXPORTER_OTLP_HEADERS": "api-key"},
+	} {
+		if _, err := OptionsFromEnv(func(key string) string { return bad[key] }, "hookly-edge"); err == nil {
+			t.Errorf("OptionsFromEnv(%v) succeeded", bad)
+		}
+	}
+}
@@ -1,16 +1,88 @@
 package webhook
 
-import "time"
+import (
+	"fmt"
+	"time"
+
+	"hooks.dx314.com/internal/clock"
+)
 
 // MaxRetryDelay is the maximum delay between retries (1 hour).
 const MaxRetryDelay = time.Hour
 
+// Bounds of a RetryPolicy, checked by Validate.
+const (
+	MaxRetryAttempts    = 1000
+	MaxRetryBackoffBase = time.Hour
+	MaxRetryInterval    = 24 * time.Hour
+)
+
+// RetryPolicy is how a webhook is retried after transient delivery failures.
+// Delays double from BackoffBase up to MaxInterval, and Jitter shortens each
+// by up to that fraction at random, so webhooks failing together don't retry
+// together. The zero value is the default: 1s doubling up to an hour, without
+// jitter or an attempt limit. Pending webhooks are dead-lettered by age
+// either way.
+type RetryPolicy struct {
+	MaxAttempts int           // Attempts before the webhook fails; 0 for no limit
+	BackoffBase time.Duration // First delay; 0 for a second
+	MaxInterval time.Duration // Longest delay; 0 for MaxRetryDelay
+	Jitter      float64       // 0 to 1
+}
+
+// Validate checks that the policy is within bounds.
+func (p RetryPolicy) Validate() error {
+	switch {
+	case p.MaxAttempts < 0 || p.MaxAttempts > MaxRetryAttempts:
+		return fmt.Errorf("max attempts must be between 0 and %d", MaxRetryAttempts)
+	case p.BackoffBase < 0 || p.BackoffBase > MaxRetryBackoffBase:
+		return fmt.Errorf("backoff base must be between 0 and %s", MaxRetryBackoffBase)
+	case p.MaxInterval < 0 || p.MaxInterval > MaxRetryInterval:
+		return fmt.Errorf("max interval must be between 0 and %s", MaxRetryInterval)
+	case p.MaxInterval > 0 && p.MaxInterval < p.base():
+		return fmt.Errorf("max interval is shorter than the backoff base")
+	case p.Jitter < 0 || p.Jitter > 1:
+		return fmt.Errorf("jitter must be between 0 and 1")
+	}
+	return nil
+}
+
+func (p RetryPolicy) base() time.Duration {
+	if p.BackoffBase <= 0 {
+		return time.Second
+	}
+	return p.BackoffBase
+}
+
+func (p RetryPolicy) maxInterval() time.Duration {
+	if p.MaxInterval <= 0 {
+		return MaxRetryDelay
+	}
+	return p.MaxInterval
+}
+
+// Delay returns how long to wait before retrying a webhook after attempts
+// earlier failed attempts, BackoffBase for the first retry. r, in [0, 1),
+// picks the jitter.
+func (p RetryPolicy) Delay(attempts int, r float64) time.Duration {
+	delay, limit := p.base(), p.maxInterval()
+	for i := 0; i < attempts && delay < limit; i++ {
+		delay *= 2
+	}
+	delay = min(delay, limit)
+	return delay - time.Duration(float64(delay)*p.Jitter*r)
+}
+
+// Exhausted reports whether a webhook that failed attempts times is out of
+// attempts.
+func (p RetryPolicy) Exhausted(attempts int) bool {
+	return p.MaxAttempts > 0 && attempts >= p.MaxAttempts
+}
+
 // NextRetryDelay calculates the next retry delay using exponential backoff.
 // Returns: 1s, 2s, 4s, 8s, 16s, 32s, 64s, 128s, 256s, 512s, 1024s, 2048s, max 1 hour.
 func NextRetryDelay(attempts int) time.Duration {
-	attempts = max(0, min(attempts, 12))
-	delay := time.Second * time.Duration(1<<attempts)
-	return min(delay, MaxRetryDelay)
+	return RetryPolicy{}.Delay(attempts, 0)
 }
 
 // NextRetryTime calculates when a webhook should next be retried.
@@ -20,5 +92,10 @@ func NextRetryTime(lastAttempt time.Time, attempts int) time.Time {
 
 // ShouldRetry returns true if enough time has passed since the last attempt.
 func ShouldRetry(lastAttempt time.Time, attempts int) bool {
-	return time.Now().After(NextRetryTime(lastAttempt, attempts))
+	return ShouldRetryAt(clock.Real, lastAttempt, attempts)
+}
+
+// ShouldRetryAt is ShouldRetry against the given clock.
+func ShouldRetryAt(c clock.Clock, lastAttempt time.Time, attempts int) bool {
+	return c.Now().After(NextRetryTime(lastAttempt, attempts))
 }
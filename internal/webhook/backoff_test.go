@@ -3,6 +3,8 @@ package webhook
 import (
 	"testing"
 	"time"
+
+	"hooks.dx314.com/internal/clock"
 )
 
 func TestNextRetryDelay(t *testing.T) {
@@ -44,6 +46,63 @@ func TestNextRetryDelay_Negative(t *testing.T) {
 	}
 }
 
+func TestRetryPolicyDelay(t *testing.T) {
+	p := RetryPolicy{BackoffBase: 10 * time.Second, MaxInterval: time.Minute}
+	for attempts, want := range []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute, time.Minute} {
+		if got := p.Delay(attempts, 0.5); got != want {
+			t.Errorf("Delay(%d) = %v, want %v", attempts, got, want)
+		}
+	}
+	if got := p.Delay(1<<30, 0); got != time.Minute {
+		t.Errorf("Delay(huge) = %v, want the max interval", got)
+	}
+
+	p.Jitter = 0.5
+	if got := p.Delay(1, 0); got != 20*time.Second {
+		t.Errorf("Delay with r = 0: %v, want 20s", got)
+	}
+	if got := p.Delay(1, 0.5); got != 15*time.Second {
+		t.Errorf("Delay with r = 0.5: %v, want 15s", got)
+	}
+}
+
+func TestRetryPolicyExhausted(t *testing.T) {
+	if (RetryPolicy{}).Exhausted(1 << 20) {
+		t.Error("default policy ran out of attempts")
+	}
+	p := RetryPolicy{MaxAttempts: 3}
+	if p.Exhausted(2) || !p.Exhausted(3) {
+		t.Errorf("Exhausted(2) = %v, Exhausted(3) = %v; want false, true", p.Exhausted(2), p.Exhausted(3))
+	}
+}
+
+func TestRetryPolicyValidate(t *testing.T) {
+	valid := []RetryPolicy{
+		{},
+		{MaxAttempts: 5, BackoffBase: time.Minute, MaxInterval: time.Hour, Jitter: 0.2},
+		{BackoffBase: 30 * time.Second, MaxInterval: 12 * time.Hour},
+	}
+	for _, p := range valid {
+		if err := p.Validate(); err != nil {
+			t.Errorf("Validate(%+v): %v", p, err)
+		}
+	}
+
+	invalid := []RetryPolicy{
+		{MaxAttempts: -1},
+		{MaxAttempts: MaxRetryAttempts + 1},
+		{BackoffBase: 2 * time.Hour},
+		{MaxInterval: 48 * time.Hour},
+		{BackoffBase: time.Minute, MaxInterval: time.Second},
+		{Jitter: 1.5},
+	}
+	for _, p := range invalid {
+		if err := p.Validate(); err == nil {
+			t.Errorf("Validate(%+v): want error", p)
+		}
+	}
+}
+
 func TestShouldRetry(t *testing.T) {
 	now := time.Now()
 
@@ -88,3 +147,23 @@ func TestShouldRetry(t *testing.T) {
 		})
 	}
 }
+
+func TestShouldRetryAtBoundary(t *testing.T) {
+	lastAttempt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c := clock.NewFake(lastAttempt)
+
+	// Third attempt backs off 4s; the retry is due strictly after that
+	for _, step := range []struct {
+		advance time.Duration
+		want    bool
+	}{
+		{3 * time.Second, false},
+		{time.Second, false},
+		{time.Nanosecond, true},
+	} {
+		c.Advance(step.advance)
+		if got := ShouldRetryAt(c, lastAttempt, 2); got != step.want {
+			t.Errorf("at +%v: ShouldRetryAt() = %v, want %v", c.Now().Sub(lastAttempt), got, step.want)
+		}
+	}
+}
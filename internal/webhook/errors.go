@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// ErrorCode is a machine-readable ingestion error code. Codes are part of
+// the public API: providers and monitors match on them, so never rename one.
+type ErrorCode string
+
+// Ingestion error codes returned by /h/{endpointID} (see Route).
+const (
+	ErrCodeEndpointNotFound  ErrorCode = "endpoint_not_found"
+	ErrCodeMuted             ErrorCode = "muted"
+	ErrCodeUnauthorized      ErrorCode = "unauthorized"
+	ErrCodePayloadTooLarge   ErrorCode = "payload_too_large"
+	ErrCodeUnsupportedType   ErrorCode = "unsupported_content_type"
+	ErrCodePayloadRejected   ErrorCode = "payload_rejected"
+	ErrCodeQuotaExceeded     ErrorCode = "quota_exceeded"
+	ErrCodeRateLimited       ErrorCode = "rate_limited"
+	ErrCodeDuplicateDelivery ErrorCode = "duplicate_delivery"
+	ErrCodeMethodNotAllowed  ErrorCode = "method_not_allowed"
+	ErrCodeBadRequest        ErrorCode = "bad_request"
+	ErrCodeInternal          ErrorCode = "internal_error"
+)
+
+// ErrorResponse is the JSON body of an ingestion error:
+//
+//	{"error": {"code": "endpoint_not_found", "message": "...", "request_id": "..."}}
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail describes an ingestion error.
+type ErrorDetail struct {
+	Code      ErrorCode `json:"code"`
+	Message   string    `json:"message"`
+	RequestID string    `json:"request_id,omitempty"`
+}
+
+// writeError writes a structured JSON error response.
+func writeError(w http.ResponseWriter, r *http.Request, status int, code ErrorCode, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(ErrorResponse{Error: ErrorDetail{
+		Code:      code,
+		Message:   message,
+		RequestID: middleware.GetReqID(r.Context()),
+	}})
+}
@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// maxEventTypeLength caps stored event types so a malformed payload can't
+// bloat the index.
+const maxEventTypeLength = 128
+
+// ExtractEventType returns the provider event type of a webhook, or "" if it
+// can't be determined:
+//   - stripe: the "type" field of the event (e.g. payment_intent.succeeded)
+//   - github: the X-GitHub-Event header (e.g. push)
+//   - telegram: the update kind (e.g. message, callback_query)
+//   - slack: the Events API event type (e.g. app_mention)
+//   - shopify: the X-Shopify-Topic header (e.g. orders/create)
+//   - square: the "type" field of the event (e.g. payment.updated)
+//   - paypal: the "event_type" field of the event (e.g. PAYMENT.CAPTURE.COMPLETED)
+//   - generic/custom: the X-Event-Type or X-Webhook-Event header, falling
+//     back to a "type" or "event" field in a JSON payload
+func ExtractEventType(providerType string, headers map[string]string, payload []byte) string {
+	var eventType string
+	switch providerType {
+	case "stripe":
+		eventType = jsonStringField(payload, "type")
+	case "github":
+		eventType = headerValue(headers, "X-GitHub-Event")
+	case "telegram":
+		eventType = telegramUpdateType(payload)
+	case "slack":
+		eventType = slackEventType(payload)
+	case "shopify":
+		eventType = headerValue(headers, "X-Shopify-Topic")
+	case "square":
+		eventType = jsonStringField(payload, "type")
+	case "paypal":
+		eventType = jsonStringField(payload, "event_type")
+	default:
+		eventType = headerValue(headers, "X-Event-Type")
+		if eventType == "" {
+			eventType = headerValue(headers, "X-Webhook-Event")
+		}
+		if eventType == "" {
+			eventType = jsonStringField(payload, "type", "event")
+		}
+	}
+
+	eventType = strings.TrimSpace(eventType)
+	if len(eventType) > maxEventTypeLength {
+		eventType = eventType[:maxEventTypeLength]
+	}
+	return eventType
+}
+
+// maxDeliveryIDLength caps stored delivery IDs for the same reason.
+const maxDeliveryIDLength = 128
+
+// ExtractDeliveryID returns the provider's unique ID for a delivery, or "" if
+// there is none. Providers send the same ID when they retry, even if the body
+// changed (Stripe, for instance, re-renders the event):
+//   - github: the X-GitHub-Delivery header
+//   - stripe: the event "id" field (evt_...)
+//   - slack: the Events API "event_id" field (Ev...)
+//   - shopify: the X-Shopify-Webhook-Id header
+//   - square: the "event_id" field
+//   - paypal: the event "id" field (WH-...)
+//   - any provider sending Standard Webhooks / Svix headers: webhook-id or svix-id
+func ExtractDeliveryID(providerType string, headers map[string]string, payload []byte) string {
+	var id string
+	switch providerType {
+	case "github":
+		id = headerValue(headers, "X-GitHub-Delivery")
+	case "stripe":
+		id = jsonStringField(payload, "id")
+	case "slack":
+		id = jsonStringField(payload, "event_id")
+	case "shopify":
+		id = headerValue(headers, "X-Shopify-Webhook-Id")
+	case "square":
+		id = jsonStringField(payload, "event_id")
+	case "paypal":
+		id = jsonStringField(payload, "id")
+	}
+	if id == "" {
+		id = headerValue(headers, "Webhook-Id")
+	}
+	if id == "" {
+		id = headerValue(headers, "Svix-Id")
+	}
+
+	id = strings.TrimSpace(id)
+	if len(id) > maxDeliveryIDLength {
+		return ""
+	}
+	return id
+}
+
+// headerValue looks up a header in the canonicalized header map.
+func headerValue(headers map[string]string, name string) string {
+	if v, ok := headers[http.CanonicalHeaderKey(name)]; ok {
+		return v
+	}
+	return headers[name]
+}
+
+// jsonStringField returns the first of the named top-level fields that holds
+// a string in a JSON object payload.
+func jsonStringField(payload []byte, names ...string) string {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &obj); err != nil {
+		return ""
+	}
+	for _, name := range names {
+		var v string
+		if raw, ok := obj[name]; ok && json.Unmarshal(raw, &v) == nil && v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// telegramUpdateType returns the kind of a Telegram update, which is the one
+// optional field set besides update_id.
+func telegramUpdateType(payload []byte) string {
+	var obj map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &obj); err != nil {
+		return ""
+	}
+	for key := range obj {
+		if key != "update_id" {
+			return key
+		}
+	}
+	return ""
+}
@@ -0,0 +1,185 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractEventType(t *testing.T) {
+	tests := []struct {
+		name         string
+		providerType string
+		headers      map[string]string
+		payload      string
+		want         string
+	}{
+		{
+			name:         "stripe type field",
+			providerType: "stripe",
+			payload:      `{"id":"evt_1","type":"payment_intent.succeeded"}`,
+			want:         "payment_intent.succeeded",
+		},
+		{
+			name:         "github header",
+			providerType: "github",
+			headers:      map[string]string{"X-Github-Event": "push"},
+			payload:      `{"ref":"refs/heads/main"}`,
+			want:         "push",
+		},
+		{
+			name:         "telegram update kind",
+			providerType: "telegram",
+			payload:      `{"update_id":1,"callback_query":{"id":"1"}}`,
+			want:         "callback_query",
+		},
+		{
+			name:         "slack event callback",
+			providerType: "slack",
+			payload:      `{"type":"event_callback","event":{"type":"app_mention"}}`,
+			want:         "app_mention",
+		},
+		{
+			name:         "shopify topic header",
+			providerType: "shopify",
+			headers:      map[string]string{"X-Shopify-Topic": "orders/create"},
+			payload:      `{"id":820982911946154508}`,
+			want:         "orders/create",
+		},
+		{
+			name:         "square type field",
+			providerType: "square",
+			payload:      `{"merchant_id":"6SSW7HV8K2ST5","type":"payment.updated","event_id":"13b867cf-db3d-4b1c-90b6-2f32a9d78124"}`,
+			want:         "payment.updated",
+		},
+		{
+			name:         "paypal event_type field",
+			providerType: "paypal",
+			payload:      `{"id":"WH-2WR32451HC0233532-67976317FL4543714","event_type":"PAYMENT.CAPTURE.COMPLETED"}`,
+			want:         "PAYMENT.CAPTURE.COMPLETED",
+		},
+		{
+			name:         "generic header",
+			providerType: "generic",
+			headers:      map[string]string{"X-Event-Type": "order.created"},
+			payload:      `{"type":"ignored"}`,
+			want:         "order.created",
+		},
+		{
+			name:         "generic json fallback",
+			providerType: "generic",
+			payload:      `{"event":"user.deleted"}`,
+			want:         "user.deleted",
+		},
+		{
+			name:         "custom non-json payload",
+			providerType: "custom",
+			payload:      `not json`,
+			want:         "",
+		},
+		{
+			name:         "stripe type not a string",
+			providerType: "stripe",
+			payload:      `{"type":42}`,
+			want:         "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractEventType(tt.providerType, tt.headers, []byte(tt.payload))
+			if got != tt.want {
+				t.Errorf("ExtractEventType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExtractEventTypeTruncates(t *testing.T) {
+	long := strings.Repeat("a", maxEventTypeLength+10)
+	got := ExtractEventType("github", map[string]string{"X-Github-Event": long}, nil)
+	if len(got) != maxEventTypeLength {
+		t.Errorf("len = %d, want %d", len(got), maxEventTypeLength)
+	}
+}
+
+func TestExtractDeliveryID(t *testing.T) {
+	tests := []struct {
+		name         string
+		providerType string
+		headers      map[string]string
+		payload      string
+		want         string
+	}{
+		{
+			name:         "github header",
+			providerType: "github",
+			headers:      map[string]string{"X-Github-Delivery": "72d3162e-cc78-11e3-81ab-4c9367dc0958"},
+			want:         "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+		},
+		{
+			name:         "stripe event id",
+			providerType: "stripe",
+			payload:      `{"id":"evt_1","type":"charge.succeeded"}`,
+			want:         "evt_1",
+		},
+		{
+			name:         "slack event id",
+			providerType: "slack",
+			payload:      `{"type":"event_callback","event_id":"Ev08MFMKH6"}`,
+			want:         "Ev08MFMKH6",
+		},
+		{
+			name:         "shopify webhook id",
+			providerType: "shopify",
+			headers:      map[string]string{"X-Shopify-Webhook-Id": "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043"},
+			payload:      `{"id":820982911946154508}`,
+			want:         "b54557e4-bdd9-4b37-8a5f-bf7d70bcd043",
+		},
+		{
+			name:         "square event id",
+			providerType: "square",
+			payload:      `{"type":"payment.updated","event_id":"13b867cf-db3d-4b1c-90b6-2f32a9d78124"}`,
+			want:         "13b867cf-db3d-4b1c-90b6-2f32a9d78124",
+		},
+		{
+			name:         "paypal event id",
+			providerType: "paypal",
+			headers:      map[string]string{"Paypal-Transmission-Id": "69cd13f0-d67a-11e5-baa3-778b53f4ae55"},
+			payload:      `{"id":"WH-2WR32451HC0233532-67976317FL4543714","event_type":"PAYMENT.CAPTURE.COMPLETED"}`,
+			want:         "WH-2WR32451HC0233532-67976317FL4543714",
+		},
+		{
+			name:         "standard webhooks header",
+			providerType: "generic",
+			headers:      map[string]string{"Webhook-Id": "msg_2"},
+			want:         "msg_2",
+		},
+		{
+			name:         "svix header",
+			providerType: "custom",
+			headers:      map[string]string{"Svix-Id": "msg_3"},
+			want:         "msg_3",
+		},
+		{
+			name:         "generic payload id is not a delivery id",
+			providerType: "generic",
+			payload:      `{"id":"order_1"}`,
+			want:         "",
+		},
+		{
+			name:         "oversized id ignored",
+			providerType: "github",
+			headers:      map[string]string{"X-Github-Delivery": strings.Repeat("a", maxDeliveryIDLength+1)},
+			want:         "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractDeliveryID(tt.providerType, tt.headers, []byte(tt.payload))
+			if got != tt.want {
+				t.Errorf("ExtractDeliveryID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -9,6 +9,8 @@ import (
 	"net/http"
 	"strings"
 	"time"
+
+	"hooks.dx314.com/internal/logging"
 )
 
 // Forwarder forwards webhooks to destination URLs.
@@ -97,6 +99,7 @@ func (f *Forwarder) Forward(ctx context.Context, destinationURL string, headers
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		result.Success = true
 		slog.Info("webhook delivered",
+			logging.SampleBy("destination", destinationURL),
 			"webhook_id", webhookID,
 			"status", resp.StatusCode,
 		)
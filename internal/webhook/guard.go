@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"mime"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Guards are ingestion limits for a public edge, whose endpoint URLs will
+// eventually be found and used as a data drop. The zero value disables them.
+type Guards struct {
+	// RequireJSON rejects bodies that aren't sent as JSON on endpoints of
+	// providers that only send JSON.
+	RequireJSON bool
+	// BannedPatterns rejects payloads matching any of the expressions.
+	BannedPatterns []*regexp.Regexp
+	// DailyBytes caps the payload bytes stored per endpoint per UTC day;
+	// 0 disables the cap.
+	DailyBytes int64
+}
+
+// jsonProviders are the provider types whose webhooks are always JSON.
+// Generic and custom endpoints may receive anything.
+var jsonProviders = map[string]bool{
+	"stripe":   true,
+	"github":   true,
+	"telegram": true,
+}
+
+// acceptsContentType reports whether a request with the Content-Type header
+// contentType may be stored for an endpoint of providerType.
+func (g *Guards) acceptsContentType(providerType, contentType string) bool {
+	if !g.RequireJSON || !jsonProviders[providerType] {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// bannedPattern returns the first banned pattern matching payload, or nil.
+func (g *Guards) bannedPattern(payload []byte) *regexp.Regexp {
+	for _, re := range g.BannedPatterns {
+		if re.Match(payload) {
+			return re
+		}
+	}
+	return nil
+}
+
+// untilNextDay returns the time from now to the next UTC midnight, when the
+// daily byte cap resets.
+func untilNextDay(now time.Time) time.Duration {
+	now = now.UTC()
+	return now.Truncate(24 * time.Hour).Add(24 * time.Hour).Sub(now)
+}
@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAcceptsContentType(t *testing.T) {
+	guards := &Guards{RequireJSON: true}
+	tests := []struct {
+		provider    string
+		contentType string
+		want        bool
+	}{
+		{"stripe", "application/json", true},
+		{"github", "application/json; charset=utf-8", true},
+		{"telegram", "application/vnd.api+json", true},
+		{"github", "application/x-www-form-urlencoded", false},
+		{"stripe", "", false},
+		{"stripe", "application/json;;", false},
+		{"generic", "text/plain", true},
+		{"custom", "", true},
+	}
+	for _, tt := range tests {
+		if got := guards.acceptsContentType(tt.provider, tt.contentType); got != tt.want {
+			t.Errorf("acceptsContentType(%q, %q) = %v, want %v", tt.provider, tt.contentType, got, tt.want)
+		}
+	}
+
+	if !(&Guards{}).acceptsContentType("stripe", "text/plain") {
+		t.Error("disabled guard rejected a content type")
+	}
+}
+
+func TestUntilNextDay(t *testing.T) {
+	now := time.Date(2026, 3, 14, 23, 30, 0, 0, time.UTC)
+	if got := untilNextDay(now); got != 30*time.Minute {
+		t.Errorf("untilNextDay(23:30 UTC) = %v, want 30m", got)
+	}
+	// Midnight UTC, not local midnight
+	local := time.Date(2026, 3, 14, 20, 0, 0, 0, time.FixedZone("UTC-2", -2*3600))
+	if got := untilNextDay(local); got != 2*time.Hour {
+		t.Errorf("untilNextDay(20:00 UTC-2) = %v, want 2h", got)
+	}
+}
@@ -2,77 +2,337 @@ package webhook
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"hooks.dx314.com/internal/clock"
 	"hooks.dx314.com/internal/db"
+	"hooks.dx314.com/internal/id"
+	"hooks.dx314.com/internal/jobs"
+	"hooks.dx314.com/internal/logging"
+	"hooks.dx314.com/internal/metrics"
+	"hooks.dx314.com/internal/notify"
+	"hooks.dx314.com/internal/server"
+	"hooks.dx314.com/internal/tracing"
 
 	"github.com/go-chi/chi/v5"
-	gonanoid "github.com/matoous/go-nanoid/v2"
 )
 
-const maxPayloadSize = 100 * 1024 * 1024 // 100MB
+// MaxPayloadSize is the largest payload the edge accepts, in bytes, and the
+// most an endpoint's PayloadLimits can allow.
+const MaxPayloadSize = 100 * 1024 * 1024 // 100MB
+
+// duplicateWindowHours is how far back re-deliveries of a provider delivery
+// ID are detected. Stripe retries for up to three days.
+const duplicateWindowHours = 72
+
+// webhookMeta is what ingestion learns about a webhook besides its content.
+type webhookMeta struct {
+	eventType   string
+	deliveryID  string
+	duplicateOf string // ID of an earlier webhook with the same delivery ID
+	sourceIP    string // Client IP, resolved through trusted proxies
+	status      string // Initial status; empty means pending
+	traceparent string // Context of the ingestion span
+}
+
+// JobFirstEventNotification is the job kind that sends the opt-in first
+// event notification.
+const JobFirstEventNotification = "first_event_notification"
+
+// JobHoneypotAlert is the job kind that alerts on a honeypot endpoint hit.
+const JobHoneypotAlert = "honeypot_alert"
+
+// honeypotMaxPayload is how much of a honeypot hit's body is stored. Hits
+// are only evidence, so a larger body is cut and marked with
+// honeypotTruncatedHeader.
+const honeypotMaxPayload = 64 << 10
+
+// honeypotTruncatedHeader is added to the stored headers of a honeypot hit
+// whose body was cut to honeypotMaxPayload.
+const honeypotTruncatedHeader = "X-Hookly-Truncated"
+
+// honeypotAlertInterval is the least time between alerts for one honeypot,
+// so a scanner hammering it doesn't flood the owner. Every hit is stored.
+const honeypotAlertInterval = time.Minute
+
+// Dispatcher sends stored webhooks to their hub.
+type Dispatcher interface {
+	// DispatchNow sends a just-stored webhook if its hub is connected, and
+	// otherwise leaves it to the background dispatch.
+	DispatchNow(ctx context.Context, endpointID, webhookID string)
+}
 
 // Handler handles webhook ingestion.
 type Handler struct {
 	queries       *db.Queries
 	secretManager *db.SecretManager
+	notifier      notify.Notifier
+	jobs          *jobs.Queue
+	clock         clock.Clock
+	guards        Guards
+	rateLimiter   *RateLimiter
+	metrics       *metrics.Metrics
+	tracer        *tracing.Tracer
+	dispatcher    Dispatcher
+	endpoints     *db.EndpointCache
+	baseURL       string
+
+	mu              sync.Mutex
+	honeypotAlerted map[string]time.Time // Last alert per honeypot endpoint
 }
 
 // NewHandler creates a new webhook handler.
-func NewHandler(queries *db.Queries, secretManager *db.SecretManager) *Handler {
+func NewHandler(queries *db.Queries, secretManager *db.SecretManager, notifier notify.Notifier) *Handler {
+	if notifier == nil {
+		notifier = notify.NopNotifier{}
+	}
 	return &Handler{
-		queries:       queries,
-		secretManager: secretManager,
+		queries:         queries,
+		secretManager:   secretManager,
+		notifier:        notifier,
+		clock:           clock.Real,
+		honeypotAlerted: make(map[string]time.Time),
 	}
 }
 
-// ServeHTTP handles incoming webhooks at POST /h/{endpoint-id}
-func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
-		return
-	}
+// SetClock sets the clock used for signature timestamps and receive times.
+func (h *Handler) SetClock(c clock.Clock) {
+	h.clock = c
+}
+
+// SetBaseURL sets the edge's public URL, which Square signs along with the
+// body.
+func (h *Handler) SetBaseURL(u string) {
+	h.baseURL = strings.TrimSuffix(u, "/")
+}
+
+// SetGuards sets the ingestion guards applied to every endpoint.
+func (h *Handler) SetGuards(g Guards) {
+	h.guards = g
+}
+
+// SetRateLimiter rate limits ingestion with l.
+func (h *Handler) SetRateLimiter(l *RateLimiter) {
+	h.rateLimiter = l
+}
+
+// SetMetrics records ingestion results in m.
+func (h *Handler) SetMetrics(m *metrics.Metrics) {
+	h.metrics = m
+}
 
+// SetTracer exports ingestion spans with t.
+func (h *Handler) SetTracer(t *tracing.Tracer) {
+	h.tracer = t
+}
+
+// SetDispatcher relays webhooks to connected hubs as soon as they are stored,
+// instead of waiting for the next background dispatch.
+func (h *Handler) SetDispatcher(d Dispatcher) {
+	h.dispatcher = d
+}
+
+// SetEndpointCache looks endpoints up through c instead of the database on
+// every request.
+func (h *Handler) SetEndpointCache(c *db.EndpointCache) {
+	h.endpoints = c
+}
+
+// SetJobQueue sends first event notifications through the job queue instead
+// of a goroutine, so they are retried and not lost on shutdown.
+func (h *Handler) SetJobQueue(q *jobs.Queue) {
+	h.jobs = q
+	q.Register(JobFirstEventNotification, func(ctx context.Context, payload []byte) error {
+		info, err := jobs.Decode[notify.WebhookInfo](payload)
+		if err != nil {
+			return err
+		}
+		return h.notifier.NotifyFirstEvent(ctx, info)
+	})
+	q.Register(JobHoneypotAlert, func(ctx context.Context, payload []byte) error {
+		info, err := jobs.Decode[notify.HoneypotInfo](payload)
+		if err != nil {
+			return err
+		}
+		return h.notifier.NotifyHoneypotHit(ctx, info)
+	})
+}
+
+// ServeHTTP handles incoming webhooks at POST /h/{endpoint-id}, or under the
+// configured path prefix, see Route. HEAD and OPTIONS are answered with 204
+// on endpoints that answer probes; other methods are only accepted by
+// honeypot endpoints.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	endpointID := chi.URLParam(r, "endpointID")
 	if endpointID == "" {
-		http.Error(w, "Endpoint ID required", http.StatusBadRequest)
+		h.writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest, "endpoint ID is required")
 		return
 	}
 
+	// The ingestion span joins the sender's trace if it sent one. Its
+	// context is stored with the webhook, for the delivery spans to join.
+	span := h.tracer.StartFromTraceparent("webhook.ingest", tracing.KindServer, r.Header.Get("Traceparent"))
+	span.SetAttribute("hookly.endpoint_id", endpointID)
+	sw := &statusWriter{ResponseWriter: w}
+	w = sw
+	defer func() {
+		span.SetAttribute("http.response.status_code", sw.code())
+		span.End()
+	}()
+
 	ctx := r.Context()
 
 	// Look up endpoint
-	endpoint, err := h.queries.GetEndpointByID(ctx, endpointID)
+	endpoint, err := h.endpoint(ctx, endpointID)
 	if err != nil {
-		slog.Debug("endpoint not found", "endpoint_id", endpointID, "error", err)
-		http.Error(w, "Not found", http.StatusNotFound)
+		slog.Debug("endpoint not found", "endpoint_id", endpointID, "source_ip", server.ClientIP(r), "error", err)
+		h.metrics.UnknownEndpoint(id.EndpointFormat().Matches(endpointID))
+		h.writeError(w, r, http.StatusNotFound, ErrCodeEndpointNotFound, "no endpoint with this ID")
 		return
 	}
 
-	// Check if muted
+	// Check if muted. Respond 200 so providers don't retry or disable the
+	// endpoint, but say why the webhook was dropped.
 	if endpoint.Muted != 0 {
 		slog.Debug("endpoint is muted, ignoring webhook", "endpoint_id", endpointID)
-		w.WriteHeader(http.StatusOK)
+		h.writeError(w, r, http.StatusOK, ErrCodeMuted, "endpoint is muted; the webhook was discarded")
+		return
+	}
+
+	// Honeypot hits are rate limited like webhooks, so they can't fill the
+	// database
+	if endpoint.Honeypot != 0 {
+		if !h.rateLimited(w, r, endpoint) {
+			h.serveHoneypot(w, r, endpoint)
+		}
 		return
 	}
 
-	// Read payload with size limit
-	r.Body = http.MaxBytesReader(w, r.Body, maxPayloadSize)
+	if endpoint.AnswerProbes != 0 {
+		if r.Method == http.MethodHead || r.Method == http.MethodOptions {
+			serveProbe(w, r)
+			return
+		}
+		// Lets a dashboard's test button read the response
+		if r.Header.Get("Origin") != "" {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		}
+	}
+
+	if r.Method != http.MethodPost {
+		if endpoint.AnswerProbes != 0 {
+			w.Header().Set("Allow", probeAllow)
+		} else {
+			w.Header().Set("Allow", http.MethodPost)
+		}
+		h.writeError(w, r, http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed, "webhooks must be sent with POST")
+		return
+	}
+
+	// Rate limits come before ingestion credentials, so they can't be
+	// guessed at full speed either
+	if h.rateLimited(w, r, endpoint) {
+		return
+	}
+
+	// Ingestion credentials gate the endpoint before anything is read or stored
+	var ingestAuth *IngestAuth
+	if len(endpoint.IngestAuthEncrypted) > 0 {
+		if ingestAuth = h.checkIngestAuth(w, r, endpoint); ingestAuth == nil {
+			return
+		}
+	}
+
+	if !h.guards.acceptsContentType(endpoint.ProviderType, r.Header.Get("Content-Type")) {
+		slog.Warn("webhook rejected: not JSON",
+			"endpoint_id", endpointID,
+			"content_type", r.Header.Get("Content-Type"),
+			"source_ip", server.ClientIP(r),
+		)
+		h.writeError(w, r, http.StatusUnsupportedMediaType, ErrCodeUnsupportedType, endpoint.ProviderType+" endpoints only accept application/json")
+		return
+	}
+
+	limits := ParsePayloadLimits(endpoint.MaxPayloadBytes, endpoint.AllowedContentTypes)
+	if !limits.allowsContentType(r.Header.Get("Content-Type")) {
+		slog.Warn("webhook rejected: content type not allowed",
+			"endpoint_id", endpointID,
+			"content_type", r.Header.Get("Content-Type"),
+			"source_ip", server.ClientIP(r),
+		)
+		h.writeError(w, r, http.StatusUnsupportedMediaType, ErrCodeUnsupportedType, "this endpoint only accepts "+strings.Join(limits.ContentTypes, ", "))
+		return
+	}
+
+	// Read payload with size limit. A declared length over it is refused
+	// without reading the body.
+	maxBytes := limits.maxBytes()
+	if r.ContentLength > maxBytes {
+		slog.Warn("webhook rejected: payload too large", "endpoint_id", endpointID, "content_length", r.ContentLength, "limit", maxBytes)
+		h.writeError(w, r, http.StatusRequestEntityTooLarge, ErrCodePayloadTooLarge, fmt.Sprintf("payload exceeds the %d byte limit", maxBytes))
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
 	payload, err := io.ReadAll(r.Body)
 	if err != nil {
-		slog.Warn("failed to read payload", "error", err)
-		http.Error(w, "Payload too large", http.StatusRequestEntityTooLarge)
+		slog.Warn("failed to read payload", "endpoint_id", endpointID, "error", err)
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			h.writeError(w, r, http.StatusRequestEntityTooLarge, ErrCodePayloadTooLarge, fmt.Sprintf("payload exceeds the %d byte limit", maxBytes))
+		} else {
+			h.writeError(w, r, http.StatusBadRequest, ErrCodeBadRequest, "failed to read payload")
+		}
 		return
 	}
 
-	// Extract headers
-	headers := make(map[string]string)
-	for name, values := range r.Header {
-		if len(values) > 0 {
-			headers[name] = values[0]
+	if !h.checkGuards(w, r, endpointID, payload) {
+		return
+	}
+
+	headers := requestHeaders(r)
+	// The credential only gates the URL: never store or relay it
+	if ingestAuth != nil {
+		delete(headers, ingestAuth.CredentialHeader())
+	}
+
+	meta := webhookMeta{
+		eventType:   ExtractEventType(endpoint.ProviderType, headers, payload),
+		deliveryID:  ExtractDeliveryID(endpoint.ProviderType, headers, payload),
+		sourceIP:    server.ClientIP(r),
+		traceparent: span.Traceparent(),
+	}
+
+	// Detect re-deliveries by provider delivery ID. Providers resend the same
+	// ID with a changed body, so this is independent of the payload.
+	if meta.deliveryID != "" {
+		originalID, err := h.queries.FindWebhookByDeliveryID(ctx, db.FindWebhookByDeliveryIDParams{
+			EndpointID:  endpointID,
+			DeliveryID:  sql.NullString{String: meta.deliveryID, Valid: true},
+			WindowHours: duplicateWindowHours,
+		})
+		switch {
+		case err == nil && endpoint.RejectDuplicates != 0:
+			slog.Info("duplicate delivery rejected",
+				"endpoint_id", endpointID,
+				"delivery_id", meta.deliveryID,
+				"original_webhook_id", originalID,
+			)
+			h.writeError(w, r, http.StatusOK, ErrCodeDuplicateDelivery, "delivery "+meta.deliveryID+" was already received as webhook "+originalID)
+			return
+		case err == nil:
+			meta.duplicateOf = originalID
+		case !errors.Is(err, sql.ErrNoRows):
+			slog.Error("failed to look up delivery ID", "endpoint_id", endpointID, "error", err)
 		}
 	}
 
@@ -83,8 +343,8 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			slog.Error("failed to decrypt secret", "endpoint_id", endpointID, "error", err)
 			// Still store webhook but mark as invalid
-			h.storeWebhook(ctx, endpointID, headers, payload, false)
-			w.WriteHeader(http.StatusOK)
+			h.storeWebhook(ctx, endpointID, headers, payload, meta, false)
+			ParseIngestResponse(endpoint.IngestResponse.String).write(w)
 			return
 		}
 
@@ -93,31 +353,53 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			// Custom provider requires verification config
 			if len(endpoint.VerificationConfigEncrypted) == 0 {
 				slog.Error("custom endpoint missing verification config", "endpoint_id", endpointID)
-				h.storeWebhook(ctx, endpointID, headers, payload, false)
-				w.WriteHeader(http.StatusOK)
+				h.storeWebhook(ctx, endpointID, headers, payload, meta, false)
+				ParseIngestResponse(endpoint.IngestResponse.String).write(w)
 				return
 			}
 			configJSON, err := h.secretManager.DecryptSecret(endpoint.VerificationConfigEncrypted)
 			if err != nil {
 				slog.Error("failed to decrypt verification config", "endpoint_id", endpointID, "error", err)
-				h.storeWebhook(ctx, endpointID, headers, payload, false)
-				w.WriteHeader(http.StatusOK)
+				h.storeWebhook(ctx, endpointID, headers, payload, meta, false)
+				ParseIngestResponse(endpoint.IngestResponse.String).write(w)
 				return
 			}
 			cfg, err := ParseVerificationConfig([]byte(configJSON))
 			if err != nil {
 				slog.Error("failed to parse verification config", "endpoint_id", endpointID, "error", err)
-				h.storeWebhook(ctx, endpointID, headers, payload, false)
-				w.WriteHeader(http.StatusOK)
+				h.storeWebhook(ctx, endpointID, headers, payload, meta, false)
+				ParseIngestResponse(endpoint.IngestResponse.String).write(w)
 				return
 			}
-			verifier = NewCustomVerifier(cfg)
+			custom := NewCustomVerifier(cfg)
+			custom.Clock = h.clock
+			verifier = custom
 		} else {
 			verifier = NewVerifier(endpoint.ProviderType)
+			switch v := verifier.(type) {
+			case *StripeVerifier:
+				v.Clock = h.clock
+			case *SlackVerifier:
+				v.Clock = h.clock
+			case *SquareVerifier:
+				v.NotificationURLs = h.notificationURLs(r)
+			}
 		}
 		signatureValid = verifier.Verify(payload, headers, secret)
 	}
 
+	// Slack's URL handshake is answered here; only a signed one, so the URL
+	// can't be enabled by someone without the signing secret
+	if endpoint.ProviderType == "slack" && signatureValid && len(endpoint.SignatureSecretEncrypted) > 0 {
+		if challenge := slackChallenge(payload); challenge != "" {
+			slog.Info("answered slack url verification", "endpoint_id", endpointID)
+			w.Header().Set("Content-Type", "text/plain")
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, challenge)
+			return
+		}
+	}
+
 	if !signatureValid {
 		slog.Warn("webhook signature verification failed",
 			"endpoint_id", endpointID,
@@ -126,29 +408,329 @@ func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Store webhook
-	webhookID, err := h.storeWebhook(ctx, endpointID, headers, payload, signatureValid)
+	webhookID, err := h.storeWebhook(ctx, endpointID, headers, payload, meta, signatureValid)
 	if err != nil {
+		span.SetError(err)
 		slog.Error("failed to store webhook", "error", err)
-		http.Error(w, "Internal error", http.StatusInternalServerError)
+		h.writeError(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to store webhook")
 		return
 	}
 
+	span.SetAttribute("hookly.webhook_id", webhookID)
 	slog.Info("webhook received",
+		logging.SampleBy("endpoint_id", endpointID),
 		"webhook_id", webhookID,
 		"endpoint_id", endpointID,
 		"signature_valid", signatureValid,
+		"event_type", meta.eventType,
 		"payload_size", len(payload),
 	)
+	if meta.duplicateOf != "" {
+		slog.Info("webhook is a re-delivery",
+			"webhook_id", webhookID,
+			"delivery_id", meta.deliveryID,
+			"original_webhook_id", meta.duplicateOf,
+		)
+	}
+
+	h.checkFirstEvent(ctx, endpoint, webhookID)
+	if h.dispatcher != nil {
+		h.dispatcher.DispatchNow(ctx, endpointID, webhookID)
+	}
 
-	w.WriteHeader(http.StatusOK)
+	// Stored: answer as the provider expects
+	ParseIngestResponse(endpoint.IngestResponse.String).write(w)
 }
 
-func (h *Handler) storeWebhook(ctx context.Context, endpointID string, headers map[string]string, payload []byte, signatureValid bool) (string, error) {
-	webhookID, err := gonanoid.New()
+// statusWriter records the status code of a response, for the ingestion span.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (w *statusWriter) WriteHeader(code int) {
+	if w.status == 0 {
+		w.status = code
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *statusWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *statusWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// code returns the status sent, 200 if nothing was written.
+func (w *statusWriter) code() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+	return w.status
+}
+
+// probeAllow is the Allow header of endpoints that answer probes.
+const probeAllow = "POST, HEAD, OPTIONS"
+
+// serveProbe answers a HEAD probe or an OPTIONS request, a CORS preflight if
+// it has an Origin, with 204. Nothing is stored and no limits apply: the
+// request has no body and preflights never carry credentials.
+func serveProbe(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Allow", probeAllow)
+	if r.Method == http.MethodOptions && r.Header.Get("Origin") != "" {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", http.MethodPost)
+		if requested := r.Header.Get("Access-Control-Request-Headers"); requested != "" {
+			w.Header().Set("Access-Control-Allow-Headers", requested)
+			w.Header().Add("Vary", "Access-Control-Request-Headers")
+		}
+		w.Header().Set("Access-Control-Max-Age", "86400")
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// rateLimited answers 429 and reports true when a request to endpoint is
+// over its rate limits.
+func (h *Handler) rateLimited(w http.ResponseWriter, r *http.Request, endpoint db.GetEndpointByIDRow) bool {
+	if h.rateLimiter == nil {
+		return false
+	}
+	ok, wait := h.rateLimiter.Allow(endpoint.ID, server.ClientIP(r), int(endpoint.RateLimitPerMinute))
+	if ok {
+		return false
+	}
+	slog.Warn("webhook rejected: rate limited",
+		"endpoint_id", endpoint.ID,
+		"source_ip", server.ClientIP(r),
+	)
+	w.Header().Set("Retry-After", strconv.Itoa(int(wait.Seconds())+1))
+	h.writeError(w, r, http.StatusTooManyRequests, ErrCodeRateLimited, "too many webhooks for this endpoint or from this address; retry later")
+	return true
+}
+
+// serveHoneypot stores a hit on a honeypot endpoint as skipped, so it is
+// never relayed, and alerts the owner. It answers like a working endpoint so
+// whoever found the URL learns nothing.
+func (h *Handler) serveHoneypot(w http.ResponseWriter, r *http.Request, endpoint db.GetEndpointByIDRow) {
+	ctx := r.Context()
+
+	// A body over the limit is still a hit: keep what fits
+	payload, _ := io.ReadAll(io.LimitReader(r.Body, honeypotMaxPayload+1))
+	headers := requestHeaders(r)
+	if len(payload) > honeypotMaxPayload {
+		payload = payload[:honeypotMaxPayload]
+		headers[honeypotTruncatedHeader] = strconv.Itoa(honeypotMaxPayload)
+	}
+	meta := webhookMeta{sourceIP: server.ClientIP(r), status: "skipped"}
+
+	webhookID, err := h.storeWebhook(ctx, endpoint.ID, headers, payload, meta, false)
 	if err != nil {
-		return "", err
+		slog.Error("failed to store honeypot hit", "endpoint_id", endpoint.ID, "error", err)
+	}
+	slog.Warn("honeypot endpoint hit",
+		"endpoint_id", endpoint.ID,
+		"webhook_id", webhookID,
+		"method", r.Method,
+		"source_ip", meta.sourceIP,
+		"user_agent", r.UserAgent(),
+	)
+
+	if h.shouldAlertHoneypot(endpoint.ID) {
+		info := notify.HoneypotInfo{
+			WebhookID:    webhookID,
+			EndpointID:   endpoint.ID,
+			EndpointName: endpoint.Name,
+			Method:       r.Method,
+			SourceIP:     meta.sourceIP,
+			Headers:      headers,
+			PayloadSize:  len(payload),
+			ReceivedAt:   h.clock.Now().UTC(),
+		}
+		if h.jobs != nil {
+			if err := h.jobs.Enqueue(ctx, JobHoneypotAlert, info); err != nil {
+				slog.Error("failed to enqueue honeypot alert", "endpoint_id", endpoint.ID, "error", err)
+			}
+		} else {
+			go func() {
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				defer cancel()
+				_ = h.notifier.NotifyHoneypotHit(ctx, info)
+			}()
+		}
+	}
+
+	ParseIngestResponse(endpoint.IngestResponse.String).write(w)
+}
+
+// shouldAlertHoneypot reports whether a hit on the honeypot should alert,
+// at most once per honeypotAlertInterval.
+func (h *Handler) shouldAlertHoneypot(endpointID string) bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	now := h.clock.Now()
+	if last, ok := h.honeypotAlerted[endpointID]; ok && now.Sub(last) < honeypotAlertInterval {
+		return false
+	}
+	for id, last := range h.honeypotAlerted {
+		if now.Sub(last) >= honeypotAlertInterval {
+			delete(h.honeypotAlerted, id)
+		}
+	}
+	h.honeypotAlerted[endpointID] = now
+	return true
+}
+
+// notificationURLs returns the URLs a webhook may have been sent to: the
+// request path under the edge's base URL, and under the host the request
+// arrived at, which is what a tunnel exposes for hookly listen.
+func (h *Handler) notificationURLs(r *http.Request) []string {
+	var urls []string
+	if h.baseURL != "" {
+		urls = append(urls, h.baseURL+r.URL.RequestURI())
+	}
+	urls = append(urls, "https://"+r.Host+r.URL.RequestURI())
+	if r.TLS == nil {
+		urls = append(urls, "http://"+r.Host+r.URL.RequestURI())
+	}
+	return urls
+}
+
+// requestHeaders returns the first value of each request header.
+func requestHeaders(r *http.Request) map[string]string {
+	headers := make(map[string]string, len(r.Header))
+	for name, values := range r.Header {
+		if len(values) > 0 {
+			headers[name] = values[0]
+		}
 	}
+	return headers
+}
 
+// checkIngestAuth checks the endpoint's ingestion credentials. It returns the
+// configuration if they match, and otherwise writes the error response and
+// returns nil.
+func (h *Handler) checkIngestAuth(w http.ResponseWriter, r *http.Request, endpoint db.GetEndpointByIDRow) *IngestAuth {
+	decrypted, err := h.secretManager.DecryptSecret(endpoint.IngestAuthEncrypted)
+	if err != nil {
+		slog.Error("failed to decrypt ingest auth", "endpoint_id", endpoint.ID, "error", err)
+		h.writeError(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to check credentials")
+		return nil
+	}
+	ingestAuth, err := ParseIngestAuth([]byte(decrypted))
+	if err != nil {
+		slog.Error("failed to parse ingest auth", "endpoint_id", endpoint.ID, "error", err)
+		h.writeError(w, r, http.StatusInternalServerError, ErrCodeInternal, "failed to check credentials")
+		return nil
+	}
+	if ingestAuth.Check(r) {
+		return ingestAuth
+	}
+
+	slog.Warn("webhook rejected: missing or wrong credentials",
+		"endpoint_id", endpoint.ID,
+		"method", ingestAuth.Method,
+		"source_ip", server.ClientIP(r),
+	)
+	if ingestAuth.Method == IngestAuthBasic {
+		w.Header().Set("WWW-Authenticate", `Basic realm="hookly"`)
+	}
+	h.writeError(w, r, http.StatusUnauthorized, ErrCodeUnauthorized, "missing or wrong credentials for this endpoint")
+	return nil
+}
+
+// checkGuards applies the payload guards, writing the error response and
+// returning false if the webhook must not be stored.
+func (h *Handler) checkGuards(w http.ResponseWriter, r *http.Request, endpointID string, payload []byte) bool {
+	if re := h.guards.bannedPattern(payload); re != nil {
+		slog.Warn("webhook rejected: banned pattern",
+			"endpoint_id", endpointID,
+			"pattern", re.String(),
+			"source_ip", server.ClientIP(r),
+		)
+		h.writeError(w, r, http.StatusUnprocessableEntity, ErrCodePayloadRejected, "payload matches a banned pattern")
+		return false
+	}
+
+	if h.guards.DailyBytes > 0 {
+		stored, err := h.queries.GetEndpointBytesToday(r.Context(), endpointID)
+		if err != nil {
+			// Fail open: losing webhooks is worse than exceeding the cap
+			slog.Error("failed to get stored bytes", "endpoint_id", endpointID, "error", err)
+		} else if stored+int64(len(payload)) > h.guards.DailyBytes {
+			slog.Warn("webhook rejected: daily quota exceeded",
+				"endpoint_id", endpointID,
+				"stored_bytes", stored,
+				"payload_size", len(payload),
+			)
+			retry := untilNextDay(h.clock.Now())
+			w.Header().Set("Retry-After", strconv.Itoa(int(retry.Seconds())+1))
+			h.writeError(w, r, http.StatusTooManyRequests, ErrCodeQuotaExceeded, fmt.Sprintf("endpoint stored its %d byte daily quota; it resets at 00:00 UTC", h.guards.DailyBytes))
+			return false
+		}
+	}
+	return true
+}
+
+// endpoint looks an endpoint up, through the cache if there is one.
+func (h *Handler) endpoint(ctx context.Context, id string) (db.GetEndpointByIDRow, error) {
+	if h.endpoints != nil {
+		return h.endpoints.Get(ctx, id)
+	}
+	return h.queries.GetEndpointByID(ctx, id)
+}
+
+// checkFirstEvent records the first webhook for an endpoint and sends the
+// opt-in first event notification.
+func (h *Handler) checkFirstEvent(ctx context.Context, endpoint db.GetEndpointByIDRow, webhookID string) {
+	notifyFirst, err := h.queries.MarkFirstEvent(ctx, endpoint.ID)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			slog.Error("failed to mark first event", "endpoint_id", endpoint.ID, "error", err)
+		}
+		return
+	}
+
+	slog.Info("first webhook received for endpoint", "endpoint_id", endpoint.ID)
+	if notifyFirst == 0 {
+		return
+	}
+
+	info := notify.WebhookInfo{
+		ID:             webhookID,
+		EndpointID:     endpoint.ID,
+		EndpointName:   endpoint.Name,
+		DestinationURL: endpoint.DestinationUrl,
+		ReceivedAt:     h.clock.Now().UTC(),
+	}
+
+	// Send in the background so the provider gets a fast response
+	if h.jobs != nil {
+		if err := h.jobs.Enqueue(ctx, JobFirstEventNotification, info); err != nil {
+			slog.Error("failed to enqueue first event notification", "endpoint_id", endpoint.ID, "error", err)
+		}
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_ = h.notifier.NotifyFirstEvent(ctx, info)
+	}()
+}
+
+// writeError writes a structured JSON error response and records the
+// rejection in the metrics.
+func (h *Handler) writeError(w http.ResponseWriter, r *http.Request, status int, code ErrorCode, message string) {
+	h.metrics.WebhookReceived(string(code))
+	writeError(w, r, status, code, message)
+}
+
+func (h *Handler) storeWebhook(ctx context.Context, endpointID string, headers map[string]string, payload []byte, meta webhookMeta, signatureValid bool) (string, error) {
 	headersJSON, err := json.Marshal(headers)
 	if err != nil {
 		return "", err
@@ -159,16 +741,36 @@ func (h *Handler) storeWebhook(ctx context.Context, endpointID string, headers m
 		sigValid = 1
 	}
 
-	_, err = h.queries.CreateWebhook(ctx, db.CreateWebhookParams{
-		ID:             webhookID,
+	params := db.CreateWebhookParams{
 		EndpointID:     endpointID,
 		Headers:        string(headersJSON),
 		Payload:        payload,
 		SignatureValid: sigValid,
+		Status:         sql.NullString{String: meta.status, Valid: meta.status != ""},
+		EventType:      sql.NullString{String: meta.eventType, Valid: meta.eventType != ""},
+		DeliveryID:     sql.NullString{String: meta.deliveryID, Valid: meta.deliveryID != ""},
+		DuplicateOf:    sql.NullString{String: meta.duplicateOf, Valid: meta.duplicateOf != ""},
+		SourceIp:       meta.sourceIP,
+		Traceparent:    sql.NullString{String: meta.traceparent, Valid: meta.traceparent != ""},
+	}
+	wh, err := db.InsertWithID(id.NewWebhookID, func(webhookID string) (db.Webhook, error) {
+		params.ID = webhookID
+		return h.queries.CreateWebhook(ctx, params)
 	})
 	if err != nil {
 		return "", err
 	}
+	if err := h.queries.MarkEndpointReceived(ctx, endpointID); err != nil {
+		slog.Error("failed to record endpoint activity", "endpoint_id", endpointID, "error", err)
+	}
 
-	return webhookID, nil
+	switch {
+	case meta.status == "skipped":
+		h.metrics.WebhookReceived(metrics.ResultHoneypot)
+	case !signatureValid:
+		h.metrics.WebhookReceived(metrics.ResultInvalidSignature)
+	default:
+		h.metrics.WebhookReceived(metrics.ResultStored)
+	}
+	return wh.ID, nil
 }
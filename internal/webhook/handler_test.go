@@ -0,0 +1,796 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+
+	"hooks.dx314.com/internal/clock"
+	"hooks.dx314.com/internal/db"
+	"hooks.dx314.com/internal/tracing"
+)
+
+// setupHandlerTest returns a router serving the ingestion handler, with an
+// active endpoint "ep-active" and a muted endpoint "ep-muted".
+func setupHandlerTest(t *testing.T) (http.Handler, *db.Queries) {
+	return setupGuardedHandlerTest(t, Guards{})
+}
+
+// setupGuardedHandlerTest is setupHandlerTest with ingestion guards, and a
+// Stripe endpoint "ep-stripe".
+func setupGuardedHandlerTest(t *testing.T, guards Guards) (http.Handler, *db.Queries) {
+	t.Helper()
+	ctx := context.Background()
+
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	queries := db.New(conn)
+	for id, provider := range map[string]string{"ep-active": "generic", "ep-muted": "generic", "ep-stripe": "stripe"} {
+		if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+			ID:             id,
+			UserID:         "user-1",
+			Name:           id,
+			ProviderType:   provider,
+			DestinationUrl: "http://localhost:8080/hook",
+		}); err != nil {
+			t.Fatalf("create endpoint: %v", err)
+		}
+	}
+	if _, err := queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		ID:     "ep-muted",
+		UserID: "user-1",
+		Muted:  sql.NullInt64{Int64: 1, Valid: true},
+	}); err != nil {
+		t.Fatalf("mute endpoint: %v", err)
+	}
+
+	h := NewHandler(queries, db.NewSecretManager(make([]byte, 32)), nil)
+	h.SetGuards(guards)
+	r := chi.NewRouter()
+	r.Use(middleware.RequestID)
+	r.HandleFunc("/h/{endpointID}", h.ServeHTTP)
+	return r, queries
+}
+
+func TestHandlerErrors(t *testing.T) {
+	router, _ := setupHandlerTest(t)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		code   ErrorCode
+	}{
+		{"unknown endpoint", http.MethodPost, "/h/missing", "{}", http.StatusNotFound, ErrCodeEndpointNotFound},
+		{"muted", http.MethodPost, "/h/ep-muted", "{}", http.StatusOK, ErrCodeMuted},
+		{"wrong method", http.MethodGet, "/h/ep-active", "", http.StatusMethodNotAllowed, ErrCodeMethodNotAllowed},
+		{"too large", http.MethodPost, "/h/ep-active", strings.Repeat("x", MaxPayloadSize+1), http.StatusRequestEntityTooLarge, ErrCodePayloadTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body)))
+
+			if rec.Code != tt.status {
+				t.Errorf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+				t.Errorf("Content-Type = %q", ct)
+			}
+			var resp ErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode body %q: %v", rec.Body.String(), err)
+			}
+			if resp.Error.Code != tt.code {
+				t.Errorf("code = %q, want %q", resp.Error.Code, tt.code)
+			}
+			if resp.Error.Message == "" || resp.Error.RequestID == "" {
+				t.Errorf("missing message or request ID: %+v", resp.Error)
+			}
+		})
+	}
+}
+
+func TestHandlerProbes(t *testing.T) {
+	router, queries := setupHandlerTest(t)
+	ctx := context.Background()
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/h/ep-active", nil))
+	if rec.Code != http.StatusNoContent || rec.Header().Get("Allow") != probeAllow {
+		t.Errorf("HEAD: status %d, Allow %q", rec.Code, rec.Header().Get("Allow"))
+	}
+
+	req := httptest.NewRequest(http.MethodOptions, "/h/ep-active", nil)
+	req.Header.Set("Origin", "https://dashboard.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "content-type, x-signature")
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("preflight: status %d", rec.Code)
+	}
+	for name, want := range map[string]string{
+		"Access-Control-Allow-Origin":  "*",
+		"Access-Control-Allow-Methods": "POST",
+		"Access-Control-Allow-Headers": "content-type, x-signature",
+	} {
+		if got := rec.Header().Get(name); got != want {
+			t.Errorf("preflight %s = %q, want %q", name, got, want)
+		}
+	}
+
+	count, err := queries.CountWebhooks(ctx, db.CountWebhooksParams{UserID: "user-1", EndpointID: "ep-active"})
+	if err != nil || count != 0 {
+		t.Errorf("probes stored %d webhooks, err %v", count, err)
+	}
+
+	if _, err := queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		ID:           "ep-active",
+		UserID:       "user-1",
+		AnswerProbes: sql.NullInt64{Int64: 0, Valid: true},
+	}); err != nil {
+		t.Fatalf("update endpoint: %v", err)
+	}
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodHead, "/h/ep-active", nil))
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != "POST" {
+		t.Errorf("HEAD with probes off: status %d, Allow %q", rec.Code, rec.Header().Get("Allow"))
+	}
+}
+
+func TestHandlerStoresWebhook(t *testing.T) {
+	router, queries := setupHandlerTest(t)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/h/ep-active", strings.NewReader(`{"type":"ping"}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	count, err := queries.CountWebhooks(context.Background(), db.CountWebhooksParams{
+		UserID:     "user-1",
+		EndpointID: "ep-active",
+	})
+	if err != nil {
+		t.Fatalf("count webhooks: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("stored %d webhooks, want 1", count)
+	}
+
+	endpoint, err := queries.GetEndpoint(context.Background(), db.GetEndpointParams{ID: "ep-active", UserID: "user-1"})
+	if err != nil {
+		t.Fatalf("get endpoint: %v", err)
+	}
+	if !endpoint.LastWebhookReceivedAt.Valid || endpoint.LastDeliveredAt.Valid {
+		t.Errorf("last activity = %v, %v; want received only", endpoint.LastWebhookReceivedAt, endpoint.LastDeliveredAt)
+	}
+}
+
+func TestHandlerIngestResponse(t *testing.T) {
+	router, queries := setupHandlerTest(t)
+	if err := queries.SetEndpointIngestResponse(context.Background(), db.SetEndpointIngestResponseParams{
+		IngestResponse: sql.NullString{String: `{"status_code": 202, "body": "{\"received\":true}", "content_type": "application/json"}`, Valid: true},
+		ID:             "ep-active",
+		UserID:         "user-1",
+	}); err != nil {
+		t.Fatalf("set ingest response: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/h/ep-active", strings.NewReader(`{"type":"ping"}`)))
+	if rec.Code != http.StatusAccepted || rec.Body.String() != `{"received":true}` || rec.Header().Get("Content-Type") != "application/json" {
+		t.Errorf("response = %d %q (%s)", rec.Code, rec.Body.String(), rec.Header().Get("Content-Type"))
+	}
+
+	// Errors are still reported as usual
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/h/ep-active", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("PUT: status %d", rec.Code)
+	}
+}
+
+func TestHandlerTraceparent(t *testing.T) {
+	router, queries := setupHandlerTest(t)
+
+	// A sender's trace is joined, anything else starts a new one
+	const sent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	for _, header := range []string{sent, "", "not-a-traceparent"} {
+		req := httptest.NewRequest(http.MethodPost, "/h/ep-active", strings.NewReader(`{"type":"ping"}`))
+		if header != "" {
+			req.Header.Set("Traceparent", header)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, body %q", rec.Code, rec.Body.String())
+		}
+	}
+
+	pending, err := queries.GetPendingWebhooks(context.Background(), 10)
+	if err != nil {
+		t.Fatalf("get pending webhooks: %v", err)
+	}
+	if len(pending) != 3 {
+		t.Fatalf("stored %d webhooks, want 3", len(pending))
+	}
+	traces := make(map[string]bool)
+	joined := 0
+	for _, wh := range pending {
+		sc, err := tracing.ParseTraceparent(wh.Traceparent.String)
+		if err != nil {
+			t.Fatalf("stored traceparent %q: %v", wh.Traceparent.String, err)
+		}
+		if wh.Traceparent.String == sent {
+			t.Error("stored the sender's span instead of the ingestion span")
+		}
+		if sc.TraceID.String() == "4bf92f3577b34da6a3ce929d0e0e4736" {
+			joined++
+		}
+		traces[sc.TraceID.String()] = true
+	}
+	if joined != 1 || len(traces) != 3 {
+		t.Errorf("joined %d traces, %d distinct; want 1 and 3", joined, len(traces))
+	}
+}
+
+func TestHandlerDuplicateDelivery(t *testing.T) {
+	ctx := context.Background()
+	router, queries := setupHandlerTest(t)
+
+	send := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/h/ep-active", strings.NewReader(body))
+		req.Header.Set("Webhook-Id", "msg_1")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Flagged by default: both are stored, the second points at the first
+	send(`{"v":1}`)
+	if rec := send(`{"v":2}`); rec.Code != http.StatusOK || rec.Body.Len() != 0 {
+		t.Fatalf("re-delivery: status %d, body %q", rec.Code, rec.Body.String())
+	}
+	webhooks, err := queries.ListWebhooks(ctx, db.ListWebhooksParams{UserID: "user-1", EndpointID: "ep-active", Limit: 10})
+	if err != nil {
+		t.Fatalf("list webhooks: %v", err)
+	}
+	if len(webhooks) != 2 {
+		t.Fatalf("stored %d webhooks, want 2", len(webhooks))
+	}
+	var original, duplicate db.Webhook
+	for _, wh := range webhooks {
+		if wh.DuplicateOf.Valid {
+			duplicate = wh
+		} else {
+			original = wh
+		}
+	}
+	if duplicate.DuplicateOf.String != original.ID || duplicate.DeliveryID.String != "msg_1" {
+		t.Errorf("duplicate_of = %q, delivery_id = %q; original %q", duplicate.DuplicateOf.String, duplicate.DeliveryID.String, original.ID)
+	}
+
+	// Rejected when the endpoint opts in
+	if _, err := queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		ID:               "ep-active",
+		UserID:           "user-1",
+		RejectDuplicates: sql.NullInt64{Int64: 1, Valid: true},
+	}); err != nil {
+		t.Fatalf("update endpoint: %v", err)
+	}
+	rec := send(`{"v":3}`)
+	var resp ErrorResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil || resp.Error.Code != ErrCodeDuplicateDelivery {
+		t.Fatalf("rejected re-delivery: status %d, body %q", rec.Code, rec.Body.String())
+	}
+	count, err := queries.CountWebhooks(ctx, db.CountWebhooksParams{UserID: "user-1", EndpointID: "ep-active"})
+	if err != nil {
+		t.Fatalf("count webhooks: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("stored %d webhooks after rejection, want 2", count)
+	}
+}
+
+func TestHandlerRateLimit(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+	queries := db.New(conn)
+	for _, id := range []string{"ep-default", "ep-own"} {
+		if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+			ID:             id,
+			UserID:         "user-1",
+			Name:           id,
+			ProviderType:   "generic",
+			DestinationUrl: "http://localhost:8080/hook",
+		}); err != nil {
+			t.Fatalf("create endpoint: %v", err)
+		}
+	}
+	if _, err := queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		ID:                 "ep-own",
+		UserID:             "user-1",
+		RateLimitPerMinute: sql.NullInt64{Int64: 3, Valid: true},
+	}); err != nil {
+		t.Fatalf("update endpoint: %v", err)
+	}
+
+	h := NewHandler(queries, db.NewSecretManager(make([]byte, 32)), nil)
+	limiter := NewRateLimiter(RateLimits{PerEndpoint: 1}, clock.NewFake(time.Now()))
+	h.SetRateLimiter(limiter)
+	r := chi.NewRouter()
+	r.HandleFunc("/h/{endpointID}", h.ServeHTTP)
+	send := func(endpointID string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/h/"+endpointID, strings.NewReader("{}")))
+		return rec
+	}
+
+	if rec := send("ep-default"); rec.Code != http.StatusOK {
+		t.Fatalf("first webhook: status %d", rec.Code)
+	}
+	rec := send("ep-default")
+	var resp ErrorResponse
+	json.Unmarshal(rec.Body.Bytes(), &resp)
+	if rec.Code != http.StatusTooManyRequests || resp.Error.Code != ErrCodeRateLimited {
+		t.Fatalf("over the limit: status %d, code %q", rec.Code, resp.Error.Code)
+	}
+	if rec.Header().Get("Retry-After") != "61" {
+		t.Errorf("Retry-After = %q, want 61", rec.Header().Get("Retry-After"))
+	}
+
+	// The endpoint's own limit overrides the edge's
+	for i := range 3 {
+		if rec := send("ep-own"); rec.Code != http.StatusOK {
+			t.Fatalf("ep-own webhook %d: status %d", i+1, rec.Code)
+		}
+	}
+	if rec := send("ep-own"); rec.Code != http.StatusTooManyRequests {
+		t.Errorf("ep-own over its limit: status %d", rec.Code)
+	}
+
+	if got := limiter.Rejected(); got["ep-default"] != 1 || got["ep-own"] != 1 {
+		t.Errorf("Rejected() = %v", got)
+	}
+}
+
+func TestHandlerGuards(t *testing.T) {
+	router, queries := setupGuardedHandlerTest(t, Guards{
+		RequireJSON:    true,
+		BannedPatterns: []*regexp.Regexp{regexp.MustCompile(`^MZ`)},
+		DailyBytes:     20,
+	})
+
+	send := func(endpointID, contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/h/"+endpointID, strings.NewReader(body))
+		req.Header.Set("Content-Type", contentType)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	expect := func(rec *httptest.ResponseRecorder, status int, code ErrorCode) {
+		t.Helper()
+		var resp ErrorResponse
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		if rec.Code != status || resp.Error.Code != code {
+			t.Errorf("status %d, code %q, want %d %q", rec.Code, resp.Error.Code, status, code)
+		}
+	}
+
+	// Only endpoints of JSON providers require JSON
+	expect(send("ep-stripe", "text/plain", `{"a":1}`), http.StatusUnsupportedMediaType, ErrCodeUnsupportedType)
+	expect(send("ep-stripe", "application/json; charset=utf-8", `{"a":1}`), http.StatusOK, "")
+	expect(send("ep-active", "text/plain", "hello"), http.StatusOK, "")
+
+	expect(send("ep-active", "application/octet-stream", "MZ\x90\x00"), http.StatusUnprocessableEntity, ErrCodePayloadRejected)
+
+	// 5 bytes stored on ep-active today; 16 more exceed the 20 byte quota
+	rec := send("ep-active", "application/json", `{"data":"12345"}`)
+	expect(rec, http.StatusTooManyRequests, ErrCodeQuotaExceeded)
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("quota response has no Retry-After")
+	}
+	expect(send("ep-active", "application/json", "{}"), http.StatusOK, "")
+
+	count, err := queries.CountWebhooks(context.Background(), db.CountWebhooksParams{UserID: "user-1", EndpointID: "ep-active"})
+	if err != nil {
+		t.Fatalf("count webhooks: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("stored %d webhooks, want 2", count)
+	}
+}
+
+func TestHandlerIngestAuth(t *testing.T) {
+	ctx := context.Background()
+	router, queries := setupHandlerTest(t)
+
+	secrets := db.NewSecretManager(make([]byte, 32))
+	encrypted, err := secrets.EncryptSecret(`{"method":"header","header":"X-Hook-Token","secret":"tok"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := queries.SetEndpointIngestAuth(ctx, db.SetEndpointIngestAuthParams{
+		IngestAuthEncrypted: encrypted,
+		ID:                  "ep-active",
+		UserID:              "user-1",
+	}); err != nil {
+		t.Fatalf("set ingest auth: %v", err)
+	}
+
+	send := func(token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/h/ep-active", strings.NewReader(`{"type":"ping"}`))
+		if token != "" {
+			req.Header.Set("X-Hook-Token", token)
+		}
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for _, token := range []string{"", "wrong"} {
+		rec := send(token)
+		var resp ErrorResponse
+		json.Unmarshal(rec.Body.Bytes(), &resp)
+		if rec.Code != http.StatusUnauthorized || resp.Error.Code != ErrCodeUnauthorized {
+			t.Errorf("token %q: status %d, code %q", token, rec.Code, resp.Error.Code)
+		}
+	}
+
+	if rec := send("tok"); rec.Code != http.StatusOK {
+		t.Fatalf("valid token: status %d, body %q", rec.Code, rec.Body.String())
+	}
+	webhooks, err := queries.ListWebhooks(ctx, db.ListWebhooksParams{UserID: "user-1", EndpointID: "ep-active", Limit: 10})
+	if err != nil {
+		t.Fatalf("list webhooks: %v", err)
+	}
+	if len(webhooks) != 1 {
+		t.Fatalf("stored %d webhooks, want 1", len(webhooks))
+	}
+	if strings.Contains(webhooks[0].Headers, "X-Hook-Token") {
+		t.Errorf("credential header stored: %s", webhooks[0].Headers)
+	}
+}
+
+func TestHandlerSlackChallenge(t *testing.T) {
+	ctx := context.Background()
+	router, queries := setupHandlerTest(t)
+
+	encrypted, err := db.NewSecretManager(make([]byte, 32)).EncryptSecret("slack_secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:                       "ep-slack",
+		UserID:                   "user-1",
+		Name:                     "ep-slack",
+		ProviderType:             "slack",
+		SignatureSecretEncrypted: encrypted,
+		DestinationUrl:           "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+
+	send := func(secret string) *httptest.ResponseRecorder {
+		payload := []byte(`{"token":"t","challenge":"ch4llenge","type":"url_verification"}`)
+		ts := time.Now().Unix()
+		req := httptest.NewRequest(http.MethodPost, "/h/ep-slack", strings.NewReader(string(payload)))
+		req.Header.Set("X-Slack-Request-Timestamp", strconv.FormatInt(ts, 10))
+		req.Header.Set("X-Slack-Signature", ComputeSlackSignature(payload, secret, ts))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := send("slack_secret"); rec.Code != http.StatusOK || rec.Body.String() != "ch4llenge" {
+		t.Errorf("signed challenge: status %d, body %q", rec.Code, rec.Body.String())
+	}
+	// An unsigned handshake is stored like any other webhook, unanswered
+	if rec := send("wrong"); rec.Body.String() == "ch4llenge" {
+		t.Error("challenge answered without a valid signature")
+	}
+	count, err := queries.CountWebhooks(ctx, db.CountWebhooksParams{UserID: "user-1", EndpointID: "ep-slack"})
+	if err != nil {
+		t.Fatalf("count webhooks: %v", err)
+	}
+	if count != 1 {
+		t.Errorf("stored %d webhooks, want only the unsigned one", count)
+	}
+}
+
+func TestHandlerSquareSignature(t *testing.T) {
+	ctx := context.Background()
+	router, queries := setupHandlerTest(t)
+
+	encrypted, err := db.NewSecretManager(make([]byte, 32)).EncryptSecret("square_key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:                       "ep-square",
+		UserID:                   "user-1",
+		Name:                     "ep-square",
+		ProviderType:             "square",
+		SignatureSecretEncrypted: encrypted,
+		DestinationUrl:           "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+
+	// Square signs the URL it was configured with, which is the request's
+	// under the host it arrived at
+	payload := []byte(`{"type":"payment.updated","event_id":"ev-1"}`)
+	for _, url := range []string{"https://example.com/h/ep-square", "http://example.com/h/ep-square", "https://other.example.com/h/ep-square"} {
+		req := httptest.NewRequest(http.MethodPost, "/h/ep-square", strings.NewReader(string(payload)))
+		req.Header.Set("X-Square-Hmacsha256-Signature", ComputeSquareSignature(payload, "square_key", url))
+		router.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	webhooks, err := queries.ListWebhooks(ctx, db.ListWebhooksParams{UserID: "user-1", EndpointID: "ep-square", Limit: 10})
+	if err != nil {
+		t.Fatalf("list webhooks: %v", err)
+	}
+	var valid int
+	for _, wh := range webhooks {
+		valid += int(wh.SignatureValid)
+	}
+	if len(webhooks) != 3 || valid != 2 {
+		t.Errorf("stored %d webhooks with %d valid signatures, want 3 with 2", len(webhooks), valid)
+	}
+}
+
+func TestHandlerHoneypot(t *testing.T) {
+	ctx := context.Background()
+	router, queries := setupHandlerTest(t)
+	if _, err := queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		ID:       "ep-active",
+		UserID:   "user-1",
+		Honeypot: sql.NullInt64{Int64: 1, Valid: true},
+	}); err != nil {
+		t.Fatalf("update endpoint: %v", err)
+	}
+
+	// Any method is a hit, answered like a working endpoint
+	for _, method := range []string{http.MethodGet, http.MethodPost} {
+		req := httptest.NewRequest(method, "/h/ep-active", strings.NewReader(`{"probe":true}`))
+		req.Header.Set("User-Agent", "scanner/1.0")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Errorf("%s: status %d, body %q", method, rec.Code, rec.Body.String())
+		}
+	}
+
+	webhooks, err := queries.ListWebhooks(ctx, db.ListWebhooksParams{UserID: "user-1", EndpointID: "ep-active", Limit: 10})
+	if err != nil {
+		t.Fatalf("list webhooks: %v", err)
+	}
+	if len(webhooks) != 2 {
+		t.Fatalf("stored %d hits, want 2", len(webhooks))
+	}
+	for _, wh := range webhooks {
+		if wh.Status != "skipped" {
+			t.Errorf("hit %s: status %q, want skipped", wh.ID, wh.Status)
+		}
+		if !strings.Contains(wh.Headers, "scanner/1.0") {
+			t.Errorf("hit %s: headers not stored: %s", wh.ID, wh.Headers)
+		}
+	}
+}
+
+func TestHandlerHoneypotLimits(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+	queries := db.New(conn)
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-trap",
+		UserID:         "user-1",
+		Name:           "trap",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+	if _, err := queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		ID:       "ep-trap",
+		UserID:   "user-1",
+		Honeypot: sql.NullInt64{Int64: 1, Valid: true},
+	}); err != nil {
+		t.Fatalf("update endpoint: %v", err)
+	}
+
+	h := NewHandler(queries, db.NewSecretManager(make([]byte, 32)), nil)
+	h.SetRateLimiter(NewRateLimiter(RateLimits{PerEndpoint: 5}, clock.NewFake(time.Now())))
+	r := chi.NewRouter()
+	r.HandleFunc("/h/{endpointID}", h.ServeHTTP)
+
+	// A flood is rate limited like any endpoint, and large bodies are cut
+	body := strings.Repeat("x", honeypotMaxPayload*2)
+	limited := 0
+	for range 20 {
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/h/ep-trap", strings.NewReader(body)))
+		if rec.Code == http.StatusTooManyRequests {
+			limited++
+		}
+	}
+	if limited != 15 {
+		t.Errorf("%d of 20 hits rate limited, want 15", limited)
+	}
+
+	webhooks, err := queries.ListWebhooks(ctx, db.ListWebhooksParams{UserID: "user-1", EndpointID: "ep-trap", Limit: 50})
+	if err != nil {
+		t.Fatalf("list webhooks: %v", err)
+	}
+	if len(webhooks) != 5 {
+		t.Fatalf("stored %d hits, want 5", len(webhooks))
+	}
+	wh, err := queries.GetWebhook(ctx, db.GetWebhookParams{ID: webhooks[0].ID, UserID: "user-1"})
+	if err != nil {
+		t.Fatalf("get webhook: %v", err)
+	}
+	if len(wh.Payload) != honeypotMaxPayload || !strings.Contains(wh.Headers, honeypotTruncatedHeader) {
+		t.Errorf("stored %d bytes, headers %s; want %d bytes marked truncated", len(wh.Payload), wh.Headers, honeypotMaxPayload)
+	}
+}
+
+func TestShouldAlertHoneypot(t *testing.T) {
+	c := clock.NewFake(time.Unix(1700000000, 0))
+	h := NewHandler(nil, nil, nil)
+	h.SetClock(c)
+
+	if !h.shouldAlertHoneypot("ep-1") {
+		t.Error("first hit should alert")
+	}
+	if h.shouldAlertHoneypot("ep-1") {
+		t.Error("second hit within the interval should not alert")
+	}
+	if !h.shouldAlertHoneypot("ep-2") {
+		t.Error("hit on another honeypot should alert")
+	}
+
+	c.Advance(honeypotAlertInterval)
+	if !h.shouldAlertHoneypot("ep-1") {
+		t.Error("hit after the interval should alert")
+	}
+}
+
+func TestHandlerPayloadLimits(t *testing.T) {
+	router, queries := setupHandlerTest(t)
+	ctx := context.Background()
+	if _, err := queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		ID:                  "ep-active",
+		UserID:              "user-1",
+		MaxPayloadBytes:     sql.NullInt64{Int64: 16, Valid: true},
+		AllowedContentTypes: sql.NullString{String: "application/json,text/*", Valid: true},
+	}); err != nil {
+		t.Fatalf("update endpoint: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		status      int
+		code        ErrorCode
+	}{
+		{"allowed", "application/json; charset=utf-8", `{"ok":true}`, http.StatusOK, ""},
+		{"wildcard", "text/plain", "hello", http.StatusOK, ""},
+		{"other type", "application/xml", "<ok/>", http.StatusUnsupportedMediaType, ErrCodeUnsupportedType},
+		{"no type", "", "{}", http.StatusUnsupportedMediaType, ErrCodeUnsupportedType},
+		{"too large", "application/json", `{"padding":"xxxxxxxx"}`, http.StatusRequestEntityTooLarge, ErrCodePayloadTooLarge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/h/ep-active", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.status, rec.Body.String())
+			}
+			if tt.code != "" {
+				var resp ErrorResponse
+				json.Unmarshal(rec.Body.Bytes(), &resp)
+				if resp.Error.Code != tt.code {
+					t.Errorf("code = %q, want %q", resp.Error.Code, tt.code)
+				}
+			}
+		})
+	}
+
+	// A body without a declared length is cut off at the limit too
+	req := httptest.NewRequest(http.MethodPost, "/h/ep-active", io.MultiReader(strings.NewReader(`{"padding":"`), strings.NewReader(`xxxxxxxx"}`)))
+	req.ContentLength = -1
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("chunked body over the limit: status %d", rec.Code)
+	}
+}
+
+// recordingDispatcher records the webhooks it was asked to dispatch.
+type recordingDispatcher struct {
+	dispatched []string
+}
+
+func (d *recordingDispatcher) DispatchNow(_ context.Context, endpointID, webhookID string) {
+	d.dispatched = append(d.dispatched, endpointID+"/"+webhookID)
+}
+
+func TestHandlerDispatchesNow(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	queries := db.New(conn)
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-active",
+		UserID:         "user-1",
+		Name:           "ep-active",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+
+	dispatcher := &recordingDispatcher{}
+	h := NewHandler(queries, db.NewSecretManager(make([]byte, 32)), nil)
+	h.SetDispatcher(dispatcher)
+	r := chi.NewRouter()
+	r.HandleFunc("/h/{endpointID}", h.ServeHTTP)
+
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/h/ep-active", strings.NewReader(`{}`)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %q", rec.Code, rec.Body.String())
+	}
+	webhooks, err := queries.ListWebhooks(ctx, db.ListWebhooksParams{UserID: "user-1", Limit: 1})
+	if err != nil || len(webhooks) != 1 {
+		t.Fatalf("list webhooks: %d, %v", len(webhooks), err)
+	}
+	if want := "ep-active/" + webhooks[0].ID; len(dispatcher.dispatched) != 1 || dispatcher.dispatched[0] != want {
+		t.Errorf("dispatched %v, want [%s]", dispatcher.dispatched, want)
+	}
+
+	// Rejected webhooks aren't dispatched
+	rec = httptest.NewRecorder()
+	r.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/h/ep-unknown", strings.NewReader(`{}`)))
+	if rec.Code != http.StatusNotFound || len(dispatcher.dispatched) != 1 {
+		t.Errorf("unknown endpoint: status %d, dispatched %v", rec.Code, dispatcher.dispatched)
+	}
+}
@@ -0,0 +1,77 @@
+package webhook
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIngestAuthValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		auth    IngestAuth
+		wantErr bool
+	}{
+		{"basic", IngestAuth{Method: IngestAuthBasic, Username: "stripe", Secret: "pw"}, false},
+		{"basic without user", IngestAuth{Method: IngestAuthBasic, Secret: "pw"}, true},
+		{"basic user with colon", IngestAuth{Method: IngestAuthBasic, Username: "a:b", Secret: "pw"}, true},
+		{"header default", IngestAuth{Method: IngestAuthHeader, Secret: "tok"}, false},
+		{"custom header", IngestAuth{Method: IngestAuthHeader, Header: "X-Hook-Token", Secret: "tok"}, false},
+		{"bad header name", IngestAuth{Method: IngestAuthHeader, Header: "X Token", Secret: "tok"}, true},
+		{"no secret", IngestAuth{Method: IngestAuthHeader}, true},
+		{"unknown method", IngestAuth{Method: "mtls", Secret: "x"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.auth.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestIngestAuthCheck(t *testing.T) {
+	basic := &IngestAuth{Method: IngestAuthBasic, Username: "stripe", Secret: "s3cret"}
+	bearer := &IngestAuth{Method: IngestAuthHeader, Secret: "tok"}
+	custom := &IngestAuth{Method: IngestAuthHeader, Header: "x-hook-token", Secret: "tok"}
+
+	tests := []struct {
+		name   string
+		auth   *IngestAuth
+		header string
+		value  string
+		want   bool
+	}{
+		{"basic ok", basic, "", "", true}, // Set with SetBasicAuth below
+		{"basic missing", basic, "X-Other", "x", false},
+		{"bearer", bearer, "Authorization", "Bearer tok", true},
+		{"bearer lowercase", bearer, "Authorization", "bearer tok", true},
+		{"bare token", bearer, "Authorization", "tok", true},
+		{"wrong token", bearer, "Authorization", "Bearer toke", false},
+		{"empty", bearer, "Authorization", "Bearer ", false},
+		{"custom header", custom, "X-Hook-Token", "tok", true},
+		{"custom header keeps bearer", custom, "X-Hook-Token", "Bearer tok", false},
+		{"custom header not authorization", custom, "Authorization", "Bearer tok", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/h/ep", nil)
+			if tt.header == "" {
+				r.SetBasicAuth("stripe", "s3cret")
+			} else {
+				r.Header.Set(tt.header, tt.value)
+			}
+			if got := tt.auth.Check(r); got != tt.want {
+				t.Errorf("Check() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	r := httptest.NewRequest("POST", "/h/ep", nil)
+	r.SetBasicAuth("other", "s3cret")
+	if basic.Check(r) {
+		t.Error("wrong user name accepted")
+	}
+	if got := custom.CredentialHeader(); got != "X-Hook-Token" {
+		t.Errorf("CredentialHeader() = %q", got)
+	}
+}
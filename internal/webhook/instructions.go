@@ -0,0 +1,45 @@
+package webhook
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"text/template"
+)
+
+// SecretPlaceholder is shown in setup instructions in place of the signature secret.
+const SecretPlaceholder = "<SIGNATURE_SECRET>"
+
+//go:embed instructions/*.tmpl
+var instructionFS embed.FS
+
+var instructionTemplates = template.Must(template.ParseFS(instructionFS, "instructions/*.tmpl"))
+
+// InstructionsData is the input for rendering setup instructions.
+type InstructionsData struct {
+	EndpointName string
+	WebhookURL   string
+	// HasSecret is true if the endpoint has a signature secret configured.
+	HasSecret bool
+	// Verification is the custom verification config (custom provider only).
+	Verification *VerificationConfig
+}
+
+// SecretPlaceholder returns the placeholder used for the signature secret.
+func (InstructionsData) SecretPlaceholder() string {
+	return SecretPlaceholder
+}
+
+// RenderSetupInstructions renders the provider-specific setup steps for an endpoint.
+func RenderSetupInstructions(providerType string, data InstructionsData) (string, error) {
+	tmpl := instructionTemplates.Lookup(providerType + ".tmpl")
+	if tmpl == nil {
+		return "", fmt.Errorf("no setup instructions for provider %q", providerType)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("render %s instructions: %w", providerType, err)
+	}
+	return buf.String(), nil
+}
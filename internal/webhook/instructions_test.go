@@ -0,0 +1,68 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderSetupInstructions(t *testing.T) {
+	const url = "https://hooks.example.com/h/ep_abc123"
+
+	tests := []struct {
+		provider string
+		want     []string
+	}{
+		{"stripe", []string{url, "Developers > Webhooks", "Stripe-Signature"}},
+		{"github", []string{url, "Settings > Webhooks", "X-Hub-Signature-256"}},
+		{"telegram", []string{"setWebhook", "url=" + url, "secret_token=" + SecretPlaceholder}},
+		{"slack", []string{url, "Event Subscriptions", "Signing Secret"}},
+		{"shopify", []string{url, "Create webhook", "X-Shopify-Hmac-Sha256"}},
+		{"square", []string{url, "Add subscription", "x-square-hmacsha256-signature"}},
+		{"paypal", []string{url, "Webhook ID", "PAYPAL-TRANSMISSION-SIG"}},
+		{"generic", []string{url, "X-Webhook-Signature"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			out, err := RenderSetupInstructions(tt.provider, InstructionsData{
+				EndpointName: "Test",
+				WebhookURL:   url,
+			})
+			if err != nil {
+				t.Fatalf("render: %v", err)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(out, w) {
+					t.Errorf("instructions missing %q:\n%s", w, out)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderSetupInstructionsCustom(t *testing.T) {
+	out, err := RenderSetupInstructions("custom", InstructionsData{
+		EndpointName: "Custom",
+		WebhookURL:   "https://hooks.example.com/h/ep_custom",
+		HasSecret:    true,
+		Verification: &VerificationConfig{
+			Method:          MethodTimestampedHMAC,
+			SignatureHeader: "X-Signature",
+			TimestampHeader: "X-Timestamp",
+		},
+	})
+	if err != nil {
+		t.Fatalf("render: %v", err)
+	}
+	for _, w := range []string{"X-Signature", "X-Timestamp", "<timestamp>.<body>"} {
+		if !strings.Contains(out, w) {
+			t.Errorf("instructions missing %q:\n%s", w, out)
+		}
+	}
+}
+
+func TestRenderSetupInstructionsUnknownProvider(t *testing.T) {
+	if _, err := RenderSetupInstructions("unknown", InstructionsData{}); err == nil {
+		t.Error("expected error for unknown provider")
+	}
+}
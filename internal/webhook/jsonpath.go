@@ -0,0 +1,128 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrPayloadNotJSON is returned when extracting a path from a payload that
+// isn't JSON.
+var ErrPayloadNotJSON = errors.New("payload is not JSON")
+
+// PathNotFoundError is returned when a JSON path doesn't exist in a payload.
+type PathNotFoundError struct {
+	Path string // The path up to and including the missing segment
+}
+
+func (e *PathNotFoundError) Error() string {
+	return fmt.Sprintf("path %q not found in payload", e.Path)
+}
+
+// ExtractJSONPath returns the JSON value at path in payload, byte for byte as
+// it appears there. The path is either a JSON pointer (RFC 6901, e.g.
+// "/data/object/items/0/id") or dot notation with bracketed array indexes
+// (e.g. "data.object.items[0].id"). An empty path returns the whole payload.
+func ExtractJSONPath(payload []byte, path string) ([]byte, error) {
+	if !json.Valid(payload) {
+		return nil, ErrPayloadNotJSON
+	}
+	segments, err := parseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	value := json.RawMessage(bytes.TrimSpace(payload))
+	for i, seg := range segments {
+		next, ok := jsonChild(value, seg)
+		if !ok {
+			return nil, &PathNotFoundError{Path: formatJSONPointer(segments[:i+1])}
+		}
+		value = next
+	}
+	return value, nil
+}
+
+// jsonChild returns the member of an object or the element of an array named
+// by seg.
+func jsonChild(value json.RawMessage, seg string) (json.RawMessage, bool) {
+	switch {
+	case len(value) > 0 && value[0] == '{':
+		var obj map[string]json.RawMessage
+		if err := json.Unmarshal(value, &obj); err != nil {
+			return nil, false
+		}
+		child, ok := obj[seg]
+		return child, ok
+	case len(value) > 0 && value[0] == '[':
+		// RFC 6901 indexes are plain decimals: no sign or leading zeros
+		if seg == "" || (len(seg) > 1 && seg[0] == '0') || strings.TrimLeft(seg, "0123456789") != "" {
+			return nil, false
+		}
+		idx, err := strconv.Atoi(seg)
+		if err != nil {
+			return nil, false
+		}
+		var arr []json.RawMessage
+		if err := json.Unmarshal(value, &arr); err != nil || idx >= len(arr) {
+			return nil, false
+		}
+		return arr[idx], true
+	default:
+		return nil, false
+	}
+}
+
+// parseJSONPath splits a JSON pointer or dot-notation path into segments.
+func parseJSONPath(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if path[0] == '/' {
+		segments := strings.Split(path[1:], "/")
+		for i, seg := range segments {
+			// ~1 first, so "~01" becomes "~1" rather than "/"
+			segments[i] = strings.ReplaceAll(strings.ReplaceAll(seg, "~1", "/"), "~0", "~")
+		}
+		return segments, nil
+	}
+
+	var segments []string
+	for _, part := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		if key == "" && (rest == "" || len(segments) > 0) {
+			return nil, fmt.Errorf("invalid path %q: empty segment", path)
+		}
+		if key != "" {
+			segments = append(segments, key)
+		}
+		for rest != "" {
+			idx, after, ok := strings.Cut(rest, "]")
+			if !ok || idx == "" {
+				return nil, fmt.Errorf("invalid path %q: unterminated index", path)
+			}
+			segments = append(segments, idx)
+			if after == "" {
+				break
+			}
+			if after[0] != '[' {
+				return nil, fmt.Errorf("invalid path %q: unexpected %q after index", path, after)
+			}
+			rest = after[1:]
+		}
+	}
+	return segments, nil
+}
+
+// formatJSONPointer formats segments as a JSON pointer.
+func formatJSONPointer(segments []string) string {
+	var b strings.Builder
+	for _, seg := range segments {
+		b.WriteByte('/')
+		b.WriteString(strings.ReplaceAll(strings.ReplaceAll(seg, "~", "~0"), "/", "~1"))
+	}
+	return b.String()
+}
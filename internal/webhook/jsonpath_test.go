@@ -0,0 +1,84 @@
+package webhook
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestExtractJSONPath(t *testing.T) {
+	payload := []byte(`{
+		"type": "invoice.paid",
+		"data": {"object": {"id": "in_1", "lines": [{"amount": 100}, {"amount": 250, "meta": {"b": 2, "a": 1}}]}},
+		"a/b": {"m~n": true},
+		"": "empty key"
+	}`)
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/type", `"invoice.paid"`},
+		{"type", `"invoice.paid"`},
+		{"/data/object/id", `"in_1"`},
+		{"data.object.id", `"in_1"`},
+		{"/data/object/lines/1/amount", `250`},
+		{"data.object.lines[1].amount", `250`},
+		{"data.object.lines[1].meta", `{"b": 2, "a": 1}`}, // Key order and spacing kept
+		{"/a~1b/m~0n", `true`},
+		{"/", `"empty key"`},
+	}
+	for _, tt := range tests {
+		got, err := ExtractJSONPath(payload, tt.path)
+		if err != nil {
+			t.Errorf("ExtractJSONPath(%q): %v", tt.path, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("ExtractJSONPath(%q) = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+
+	if whole, err := ExtractJSONPath(payload, ""); err != nil || string(whole) != string(bytes.TrimSpace(payload)) {
+		t.Errorf("empty path: got %s, %v, want the whole payload", whole, err)
+	}
+
+	arr, err := ExtractJSONPath([]byte(`[{"id": 1}, {"id": 2}]`), "[1].id")
+	if err != nil || string(arr) != "2" {
+		t.Errorf("root array: got %s, %v", arr, err)
+	}
+}
+
+func TestExtractJSONPathErrors(t *testing.T) {
+	payload := []byte(`{"data": {"items": [1, 2]}, "n": 5}`)
+
+	if _, err := ExtractJSONPath([]byte("a=1&b=2"), "/a"); !errors.Is(err, ErrPayloadNotJSON) {
+		t.Errorf("form payload: err = %v, want ErrPayloadNotJSON", err)
+	}
+
+	notFound := map[string]string{
+		"/missing":         "/missing",
+		"data.missing.x":   "/data/missing",
+		"/data/items/2":    "/data/items/2",
+		"/data/items/01":   "/data/items/01",
+		"/data/items/-":    "/data/items/-",
+		"data.items[-1]":   "/data/items/-1",
+		"/n/x":             "/n/x",
+		"data.items[0].id": "/data/items/0/id",
+	}
+	for path, want := range notFound {
+		_, err := ExtractJSONPath(payload, path)
+		var pnf *PathNotFoundError
+		if !errors.As(err, &pnf) || pnf.Path != want {
+			t.Errorf("ExtractJSONPath(%q): err = %v, want not found at %s", path, err, want)
+		}
+	}
+
+	for _, path := range []string{".data", "data..items", "data.items[0", "data.items[]", "data.items[0]x"} {
+		_, err := ExtractJSONPath(payload, path)
+		var pnf *PathNotFoundError
+		if err == nil || errors.As(err, &pnf) {
+			t.Errorf("ExtractJSONPath(%q): err = %v, want invalid path", path, err)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// maxContentTypes bounds the content types an endpoint can allow.
+const maxContentTypes = 20
+
+// PayloadLimits restrict the webhooks an endpoint accepts, for endpoints
+// whose provider only sends small payloads of a known type. The zero value
+// accepts any content type up to MaxPayloadSize.
+type PayloadLimits struct {
+	// MaxBytes is the largest payload accepted; 0 for MaxPayloadSize.
+	MaxBytes int64
+	// ContentTypes are the media types accepted, such as application/json.
+	// A type/* entry accepts any subtype. Empty accepts any content type.
+	ContentTypes []string
+}
+
+// ParsePayloadLimits decodes stored limits: the content types are a
+// comma-separated list.
+func ParsePayloadLimits(maxBytes int64, contentTypes string) PayloadLimits {
+	l := PayloadLimits{MaxBytes: maxBytes}
+	for ct := range strings.SplitSeq(contentTypes, ",") {
+		if ct = strings.TrimSpace(ct); ct != "" {
+			l.ContentTypes = append(l.ContentTypes, ct)
+		}
+	}
+	return l
+}
+
+// Validate checks the limits and normalizes the content types to lowercase
+// media types without parameters.
+func (l *PayloadLimits) Validate() error {
+	if l.MaxBytes < 0 || l.MaxBytes > MaxPayloadSize {
+		return fmt.Errorf("max payload size must be between 0 and %d bytes", MaxPayloadSize)
+	}
+	if len(l.ContentTypes) > maxContentTypes {
+		return fmt.Errorf("at most %d content types can be allowed", maxContentTypes)
+	}
+	for i, ct := range l.ContentTypes {
+		mediaType, _, err := mime.ParseMediaType(ct)
+		if err != nil || !strings.Contains(mediaType, "/") || strings.HasPrefix(mediaType, "*") {
+			return fmt.Errorf("invalid content type %q: expected a media type such as application/json or text/*", ct)
+		}
+		l.ContentTypes[i] = mediaType
+	}
+	return nil
+}
+
+// maxBytes returns the largest payload accepted.
+func (l PayloadLimits) maxBytes() int64 {
+	if l.MaxBytes <= 0 {
+		return MaxPayloadSize
+	}
+	return min(l.MaxBytes, MaxPayloadSize)
+}
+
+// allowsContentType reports whether a request with the Content-Type header
+// contentType is accepted. Without one, only limits that allow any type
+// accept it.
+func (l PayloadLimits) allowsContentType(contentType string) bool {
+	if len(l.ContentTypes) == 0 {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range l.ContentTypes {
+		if family, ok := strings.CutSuffix(allowed, "/*"); ok {
+			if strings.HasPrefix(mediaType, family+"/") {
+				return true
+			}
+		} else if mediaType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// ContentTypeList formats the content types as stored, comma-separated.
+func (l PayloadLimits) ContentTypeList() string {
+	return strings.Join(l.ContentTypes, ",")
+}
@@ -0,0 +1,68 @@
+package webhook
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPayloadLimitsValidate(t *testing.T) {
+	l := ParsePayloadLimits(1024, " Application/JSON; charset=utf-8, text/*,")
+	if err := l.Validate(); err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if want := []string{"application/json", "text/*"}; !slices.Equal(l.ContentTypes, want) {
+		t.Errorf("content types = %q, want %q", l.ContentTypes, want)
+	}
+	if got := l.ContentTypeList(); got != "application/json,text/*" {
+		t.Errorf("ContentTypeList() = %q", got)
+	}
+
+	invalid := []struct {
+		limits PayloadLimits
+		want   string
+	}{
+		{PayloadLimits{MaxBytes: -1}, "between"},
+		{PayloadLimits{MaxBytes: MaxPayloadSize + 1}, "between"},
+		{PayloadLimits{ContentTypes: []string{"json"}}, "invalid content type"},
+		{PayloadLimits{ContentTypes: []string{"*/*"}}, "invalid content type"},
+		{PayloadLimits{ContentTypes: make([]string, maxContentTypes+1)}, "at most"},
+	}
+	for _, tt := range invalid {
+		err := tt.limits.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Validate(%+v) = %v, want error containing %q", tt.limits, err, tt.want)
+		}
+	}
+}
+
+func TestPayloadLimitsAllowsContentType(t *testing.T) {
+	if !(PayloadLimits{}).allowsContentType("") {
+		t.Error("no content types should allow any")
+	}
+
+	l := PayloadLimits{ContentTypes: []string{"application/json", "text/*"}}
+	for ct, want := range map[string]bool{
+		"application/json":                  true,
+		"application/json; charset=utf-8":   true,
+		"APPLICATION/JSON":                  true,
+		"text/plain":                        true,
+		"text/csv; header=present":          true,
+		"application/x-www-form-urlencoded": false,
+		"application/vnd.api+json":          false,
+		"textual/plain":                     false,
+		"":                                  false,
+		"not a type;":                       false,
+	} {
+		if got := l.allowsContentType(ct); got != want {
+			t.Errorf("allowsContentType(%q) = %v, want %v", ct, got, want)
+		}
+	}
+
+	if got := (PayloadLimits{}).maxBytes(); got != MaxPayloadSize {
+		t.Errorf("default maxBytes() = %d", got)
+	}
+	if got := (PayloadLimits{MaxBytes: 10}).maxBytes(); got != 10 {
+		t.Errorf("maxBytes() = %d, want 10", got)
+	}
+}
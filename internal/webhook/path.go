@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultPathPrefix is where webhooks are received by default, as
+// /h/<endpoint id>.
+const DefaultPathPrefix = "/h/"
+
+// reservedPathSegments are the first path segments the edge already serves:
+// its API, auth and health routes and the pages of the UI.
+var reservedPathSegments = map[string]bool{
+	"api": true, "auth": true, "health": true, "metrics": true, "_app": true,
+	"cli": true, "endpoints": true, "login": true, "settings": true, "webhooks": true,
+}
+
+// CleanPathPrefix checks a webhook URL path prefix such as /hooks/ or
+// /in/webhooks/, adding missing leading and trailing slashes. Segments may
+// use letters, digits, '-' and '_', and the first can't be one the edge
+// already serves, like api or webhooks.
+func CleanPathPrefix(prefix string) (string, error) {
+	trimmed := strings.Trim(strings.TrimSpace(prefix), "/")
+	if trimmed == "" {
+		return "", fmt.Errorf("%q has no path segment: webhooks can't be received at the root", prefix)
+	}
+	segments := strings.Split(trimmed, "/")
+	for _, seg := range segments {
+		if seg == "" || strings.Trim(seg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_") != "" {
+			return "", fmt.Errorf("%q: path segments may only contain letters, digits, '-' and '_'", prefix)
+		}
+	}
+	if reservedPathSegments[strings.ToLower(segments[0])] {
+		return "", fmt.Errorf("%q: /%s/ is already served by the edge, pick another such as /hooks/", prefix, segments[0])
+	}
+	return "/" + trimmed + "/", nil
+}
+
+// Route is the router pattern of the ingestion handler under a path prefix,
+// with the endpoint ID as the endpointID parameter.
+func Route(prefix string) string {
+	return prefix + "{endpointID}"
+}
+
+// WebhookURL is the URL providers send an endpoint's webhooks to, on the edge
+// at baseURL receiving them under prefix, DefaultPathPrefix if empty.
+func WebhookURL(baseURL, prefix, endpointID string) string {
+	if prefix == "" {
+		prefix = DefaultPathPrefix
+	}
+	return strings.TrimSuffix(baseURL, "/") + prefix + endpointID
+}
@@ -0,0 +1,33 @@
+package webhook
+
+import "testing"
+
+func TestCleanPathPrefix(t *testing.T) {
+	for in, want := range map[string]string{
+		"/h/":           "/h/",
+		"webhooks-in":   "/webhooks-in/",
+		"/hooks/in":     "/hooks/in/",
+		" /in/github/ ": "/in/github/",
+	} {
+		if got, err := CleanPathPrefix(in); err != nil || got != want {
+			t.Errorf("CleanPathPrefix(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "/", "/a//b/", "/hooks.v1/", "/a b/", "/webhooks/", "/API/in/", "/_app/"} {
+		if got, err := CleanPathPrefix(in); err == nil {
+			t.Errorf("CleanPathPrefix(%q) = %q, want an error", in, got)
+		}
+	}
+}
+
+func TestWebhookURL(t *testing.T) {
+	if got := WebhookURL("https://hooks.example.com", "/in/", "abc"); got != "https://hooks.example.com/in/abc" {
+		t.Errorf("WebhookURL = %q", got)
+	}
+	if got := WebhookURL("http://localhost:8080/", "", "abc"); got != "http://localhost:8080/h/abc" {
+		t.Errorf("WebhookURL with the default prefix = %q", got)
+	}
+	if got := Route(DefaultPathPrefix); got != "/h/{endpointID}" {
+		t.Errorf("Route = %q", got)
+	}
+}
@@ -0,0 +1,152 @@
+package webhook
+
+import (
+	"bytes"
+	"compress/gzip"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+
+	"hooks.dx314.com/internal/auth"
+	"hooks.dx314.com/internal/db"
+)
+
+// gzipMinSize is the smallest payload worth compressing.
+const gzipMinSize = 1 << 10
+
+// PayloadHandler serves a webhook's raw payload as a download, with range
+// requests and gzip, so large payloads don't have to fit a protobuf message.
+// It expects the session in the request context and the webhook ID in the
+// webhookID route parameter.
+type PayloadHandler struct {
+	queries *db.Queries
+}
+
+// NewPayloadHandler creates a payload download handler.
+func NewPayloadHandler(queries *db.Queries) *PayloadHandler {
+	return &PayloadHandler{queries: queries}
+}
+
+func (h *PayloadHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	session := auth.GetSessionFromContext(r.Context())
+	if session == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id := chi.URLParam(r, "webhookID")
+	wh, err := h.queries.GetWebhook(r.Context(), db.GetWebhookParams{ID: id, UserID: session.OwnerID()})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			http.Error(w, "Webhook not found", http.StatusNotFound)
+			return
+		}
+		slog.Error("failed to get webhook payload", "id", id, "error", err)
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	contentType := payloadContentType(wh.Headers, wh.Payload)
+	header := w.Header()
+	header.Set("Content-Type", contentType)
+	header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s%s"`, wh.ID, payloadExtension(contentType)))
+	// Payloads are sender-controlled: never let a browser render them as a page
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Content-Security-Policy", "sandbox; default-src 'none'")
+	header.Set("Cache-Control", "private, max-age=3600") // Payloads never change
+	header.Set("Vary", "Accept-Encoding")
+
+	modtime, _ := db.ParseTime(wh.ReceivedAt) // No Last-Modified if invalid
+
+	if !acceptsGzip(r) || r.Header.Get("Range") != "" || !compressible(contentType, len(wh.Payload)) {
+		header.Set("ETag", strconv.Quote(wh.ID))
+		http.ServeContent(w, r, "", modtime, bytes.NewReader(wh.Payload))
+		return
+	}
+
+	// Compressed responses can't serve ranges, which are of the identity encoding
+	etag := strconv.Quote(wh.ID + ".gz")
+	header.Set("ETag", etag)
+	if match := r.Header.Get("If-None-Match"); match != "" && (match == "*" || strings.Contains(match, etag)) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	header.Set("Content-Encoding", "gzip")
+	if !modtime.IsZero() {
+		header.Set("Last-Modified", modtime.UTC().Format(http.TimeFormat))
+	}
+	if r.Method == http.MethodHead {
+		return
+	}
+	gz := gzip.NewWriter(w)
+	if _, err := gz.Write(wh.Payload); err != nil {
+		slog.Debug("payload download interrupted", "id", id, "error", err)
+		return
+	}
+	gz.Close()
+}
+
+// payloadContentType returns the Content-Type the payload was sent with, or
+// one sniffed from its content.
+func payloadContentType(headersJSON string, payload []byte) string {
+	var headers map[string]string
+	json.Unmarshal([]byte(headersJSON), &headers)
+	if ct := getHeader(headers, "Content-Type"); ct != "" {
+		if _, _, err := mime.ParseMediaType(ct); err == nil {
+			return ct
+		}
+	}
+	if json.Valid(payload) {
+		return "application/json"
+	}
+	return http.DetectContentType(payload)
+}
+
+// payloadExtension returns a file extension for a content type.
+func payloadExtension(contentType string) string {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		return ".json"
+	case mediaType == "application/xml" || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml"):
+		return ".xml"
+	case mediaType == "application/x-www-form-urlencoded" || strings.HasPrefix(mediaType, "text/"):
+		return ".txt"
+	default:
+		return ".bin"
+	}
+}
+
+// acceptsGzip reports whether the client accepts gzip content encoding.
+func acceptsGzip(r *http.Request) bool {
+	for _, part := range strings.Split(r.Header.Get("Accept-Encoding"), ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if strings.EqualFold(strings.TrimSpace(coding), "gzip") {
+			return strings.ReplaceAll(params, " ", "") != "q=0"
+		}
+	}
+	return false
+}
+
+// compressible reports whether a payload is worth compressing.
+func compressible(contentType string, size int) bool {
+	if size < gzipMinSize {
+		return false
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case strings.HasPrefix(mediaType, "image/"), strings.HasPrefix(mediaType, "video/"), strings.HasPrefix(mediaType, "audio/"):
+		return false
+	case mediaType == "application/zip", mediaType == "application/gzip", mediaType == "application/x-gzip":
+		return false
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package webhook
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+
+	"hooks.dx314.com/internal/auth"
+	"hooks.dx314.com/internal/db"
+)
+
+func TestPayloadHandler(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	defer conn.Close()
+
+	queries := db.New(conn)
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-1",
+		UserID:         "user-1",
+		Name:           "ep-1",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+	payload := []byte(`{"items":"` + strings.Repeat("x", 4096) + `"}`)
+	if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{
+		ID:         "wh-1",
+		EndpointID: "ep-1",
+		Headers:    `{"content-type":"application/json; charset=utf-8"}`,
+		Payload:    payload,
+	}); err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+
+	r := chi.NewRouter()
+	r.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			session := &auth.Session{UserID: req.Header.Get("X-Test-User")}
+			next.ServeHTTP(w, req.WithContext(auth.ContextWithSession(req.Context(), session)))
+		})
+	})
+	r.Get("/api/webhooks/{webhookID}/payload", NewPayloadHandler(queries).ServeHTTP)
+
+	get := func(user string, headers map[string]string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/webhooks/wh-1/payload", nil)
+		req.Header.Set("X-Test-User", user)
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		rec := httptest.NewRecorder()
+		r.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := get("user-1", nil)
+	if rec.Code != http.StatusOK || !bytes.Equal(rec.Body.Bytes(), payload) {
+		t.Fatalf("full download: status %d, %d bytes", rec.Code, rec.Body.Len())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	if cd := rec.Header().Get("Content-Disposition"); cd != `attachment; filename="wh-1.json"` {
+		t.Errorf("Content-Disposition = %q", cd)
+	}
+
+	rec = get("user-1", map[string]string{"Range": "bytes=0-8"})
+	if rec.Code != http.StatusPartialContent || rec.Body.String() != `{"items":` {
+		t.Errorf("range: status %d, body %q", rec.Code, rec.Body.String())
+	}
+
+	rec = get("user-1", map[string]string{"Accept-Encoding": "br, gzip"})
+	if rec.Header().Get("Content-Encoding") != "gzip" {
+		t.Fatalf("gzip not used: %v", rec.Header())
+	}
+	zr, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := io.ReadAll(zr); !bytes.Equal(got, payload) {
+		t.Errorf("gzip body is %d bytes, want %d", len(got), len(payload))
+	}
+
+	if rec := get("user-2", nil); rec.Code != http.StatusNotFound {
+		t.Errorf("other user: status %d, want 404", rec.Code)
+	}
+}
+
+func TestPayloadContentType(t *testing.T) {
+	tests := []struct {
+		headers string
+		payload string
+		want    string
+		ext     string
+	}{
+		{`{"Content-Type":"application/xml"}`, "<a/>", "application/xml", ".xml"},
+		{`{}`, `{"a":1}`, "application/json", ".json"},
+		{`{"Content-Type":"not a type;;"}`, "a=1&b=2", "text/plain; charset=utf-8", ".txt"},
+		{`{}`, "\x00\x01\x02", "application/octet-stream", ".bin"},
+	}
+	for _, tt := range tests {
+		got := payloadContentType(tt.headers, []byte(tt.payload))
+		if got != tt.want || payloadExtension(got) != tt.ext {
+			t.Errorf("payloadContentType(%s, %q) = %q (%s), want %q (%s)", tt.headers, tt.payload, got, payloadExtension(got), tt.want, tt.ext)
+		}
+	}
+}
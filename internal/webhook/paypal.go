@@ -0,0 +1,203 @@
+package webhook
+
+import (
+	"context"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"hooks.dx314.com/internal/clock"
+)
+
+// payPalDomain is the domain PayPal's signing certificates are served from
+// and issued to.
+const payPalDomain = ".paypal.com"
+
+// maxPayPalCertSize bounds a downloaded certificate chain.
+const maxPayPalCertSize = 64 << 10
+
+// PayPalVerifier verifies PayPal webhook signatures. PayPal signs
+// transmission ID|transmission time|webhook ID|CRC32 of the body with the
+// certificate at PAYPAL-CERT-URL; the endpoint's secret is the webhook's
+// ID, from the PayPal developer dashboard.
+//
+// PayPal resends a webhook with its original transmission time, so unlike
+// Stripe and Slack the timestamp isn't held to a tolerance.
+type PayPalVerifier struct {
+	Certs *PayPalCerts // Fetches signing certificates; nil means a shared cache
+}
+
+func (v *PayPalVerifier) Verify(payload []byte, headers map[string]string, secret string) bool {
+	transmissionID := getHeader(headers, "Paypal-Transmission-Id")
+	transmissionTime := getHeader(headers, "Paypal-Transmission-Time")
+	sig := getHeader(headers, "Paypal-Transmission-Sig")
+	certURL := getHeader(headers, "Paypal-Cert-Url")
+	if transmissionID == "" || transmissionTime == "" || sig == "" || certURL == "" || secret == "" {
+		return false
+	}
+	if algo := getHeader(headers, "Paypal-Auth-Algo"); algo != "" && algo != "SHA256withRSA" {
+		return false
+	}
+
+	sigBytes, err := base64.StdEncoding.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+
+	certs := v.Certs
+	if certs == nil {
+		certs = defaultPayPalCerts
+	}
+	cert, err := certs.Get(context.Background(), certURL)
+	if err != nil {
+		return false
+	}
+	pub, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return false
+	}
+
+	digest := sha256.Sum256([]byte(payPalMessage(transmissionID, transmissionTime, secret, payload)))
+	return rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest[:], sigBytes) == nil
+}
+
+// payPalMessage is what PayPal signs for a webhook.
+func payPalMessage(transmissionID, transmissionTime, webhookID string, payload []byte) string {
+	return fmt.Sprintf("%s|%s|%s|%d", transmissionID, transmissionTime, webhookID, crc32.ChecksumIEEE(payload))
+}
+
+// defaultPayPalCerts is shared by verifiers without their own cache, so a
+// certificate is fetched once per edge rather than once per webhook.
+var defaultPayPalCerts = NewPayPalCerts()
+
+// PayPalCerts fetches PayPal's signing certificates and caches them until
+// they expire. Only certificates served by and issued to paypal.com, and
+// chaining to a trusted root, are returned; the cert URL is sent with the
+// webhook, so it can't be trusted by itself.
+type PayPalCerts struct {
+	client *http.Client
+	roots  *x509.CertPool // nil means the system roots
+	clock  clock.Clock
+
+	mu    sync.Mutex
+	certs map[string]*x509.Certificate
+}
+
+// NewPayPalCerts creates an empty certificate cache.
+func NewPayPalCerts() *PayPalCerts {
+	return &PayPalCerts{
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		clock: clock.Real,
+		certs: make(map[string]*x509.Certificate),
+	}
+}
+
+// Get returns the verified signing certificate at certURL.
+func (c *PayPalCerts) Get(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	now := c.clock.Now()
+
+	c.mu.Lock()
+	cert, ok := c.certs[certURL]
+	c.mu.Unlock()
+	if ok && now.Before(cert.NotAfter) {
+		return cert, nil
+	}
+
+	u, err := url.Parse(certURL)
+	if err != nil || u.Scheme != "https" || !strings.HasSuffix(strings.ToLower(u.Hostname()), payPalDomain) {
+		return nil, fmt.Errorf("cert url %q is not a paypal.com https url", certURL)
+	}
+
+	cert, err = c.fetch(ctx, u.String(), now)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	c.certs[certURL] = cert
+	c.mu.Unlock()
+	return cert, nil
+}
+
+// fetch downloads the certificate chain at certURL and verifies its leaf.
+func (c *PayPalCerts) fetch(ctx context.Context, certURL string, now time.Time) (*x509.Certificate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch paypal cert: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch paypal cert: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxPayPalCertSize))
+	if err != nil {
+		return nil, fmt.Errorf("fetch paypal cert: %w", err)
+	}
+
+	var chain []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("parse paypal cert: %w", err)
+		}
+		chain = append(chain, cert)
+	}
+	if len(chain) == 0 {
+		return nil, errors.New("no certificate in paypal cert response")
+	}
+
+	leaf := chain[0]
+	intermediates := x509.NewCertPool()
+	for _, cert := range chain[1:] {
+		intermediates.AddCert(cert)
+	}
+	if _, err := leaf.Verify(x509.VerifyOptions{
+		Roots:         c.roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return nil, fmt.Errorf("verify paypal cert: %w", err)
+	}
+	if !issuedToPayPal(leaf) {
+		return nil, fmt.Errorf("paypal cert issued to %q", leaf.Subject.CommonName)
+	}
+	return leaf, nil
+}
+
+// issuedToPayPal reports whether a certificate names a paypal.com host.
+func issuedToPayPal(cert *x509.Certificate) bool {
+	names := append([]string{cert.Subject.CommonName}, cert.DNSNames...)
+	for _, name := range names {
+		if strings.HasSuffix(strings.ToLower(name), payPalDomain) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,240 @@
+package webhook
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"maps"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"hooks.dx314.com/internal/clock"
+)
+
+const testPayPalCertURL = "https://api.sandbox.paypal.com/v1/notifications/certs/CERT-360caa42-fca2a594-a5cafa77"
+
+// payPalTestCA issues signing certificates for tests.
+type payPalTestCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func newPayPalTestCA(t *testing.T) *payPalTestCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test Root CA"},
+		NotBefore:             time.Unix(1700000000, 0).Add(-time.Hour),
+		NotAfter:              time.Unix(1700000000, 0).Add(365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+	return &payPalTestCA{cert: cert, key: key}
+}
+
+// issue returns a PEM signing certificate for name and its key.
+func (ca *payPalTestCA) issue(t *testing.T, name string, notAfter time.Time) ([]byte, *rsa.PrivateKey) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Unix(1700000000, 0).Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), key
+}
+
+// newTestPayPalCerts returns a cache trusting ca that fetches every URL
+// from a test server serving certPEM, and the number of fetches.
+func newTestPayPalCerts(t *testing.T, ca *payPalTestCA, certPEM []byte, c clock.Clock) (*PayPalCerts, *atomic.Int32) {
+	t.Helper()
+	var fetches atomic.Int32
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches.Add(1)
+		w.Write(certPEM)
+	}))
+	t.Cleanup(srv.Close)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	certs := NewPayPalCerts()
+	certs.roots = roots
+	certs.clock = c
+	certs.client = &http.Client{Transport: &http.Transport{
+		// Serves paypal.com URLs from the test server
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return (&net.Dialer{}).DialContext(ctx, network, srv.Listener.Addr().String())
+		},
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	}}
+	return certs, &fetches
+}
+
+func signPayPal(t *testing.T, key *rsa.PrivateKey, payload []byte, webhookID string) map[string]string {
+	t.Helper()
+	id, ts := "69cd13f0-d67a-11e5-baa3-778b53f4ae55", "2016-02-18T20:01:35Z"
+	digest := sha256.Sum256([]byte(payPalMessage(id, ts, webhookID, payload)))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return map[string]string{
+		"Paypal-Transmission-Id":   id,
+		"Paypal-Transmission-Time": ts,
+		"Paypal-Transmission-Sig":  base64.StdEncoding.EncodeToString(sig),
+		"Paypal-Cert-Url":          testPayPalCertURL,
+		"Paypal-Auth-Algo":         "SHA256withRSA",
+	}
+}
+
+func TestPayPalVerifier(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	ca := newPayPalTestCA(t)
+	certPEM, key := ca.issue(t, "messageverificationcerts.sandbox.paypal.com", now.Add(24*time.Hour))
+	certs, fetches := newTestPayPalCerts(t, ca, certPEM, clock.NewFake(now))
+	v := &PayPalVerifier{Certs: certs}
+
+	const webhookID = "1JE4291016473214C"
+	payload := []byte(`{"id":"WH-2WR32451HC0233532-67976317FL4543714","event_type":"PAYMENT.CAPTURE.COMPLETED"}`)
+	headers := signPayPal(t, key, payload, webhookID)
+
+	if !v.Verify(payload, headers, webhookID) {
+		t.Fatal("expected valid signature to pass")
+	}
+	if v.Verify(payload, headers, "WRONGWEBHOOKID") {
+		t.Error("expected wrong webhook ID to fail")
+	}
+	if v.Verify([]byte(`{"id":"WH-1"}`), headers, webhookID) {
+		t.Error("expected modified body to fail")
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Errorf("certificate fetched %d times, want 1", n)
+	}
+
+	for _, header := range []string{"Paypal-Transmission-Id", "Paypal-Transmission-Time", "Paypal-Transmission-Sig", "Paypal-Cert-Url"} {
+		missing := make(map[string]string)
+		for k, val := range headers {
+			if k != header {
+				missing[k] = val
+			}
+		}
+		if v.Verify(payload, missing, webhookID) {
+			t.Errorf("expected missing %s to fail", header)
+		}
+	}
+
+	sha1 := maps.Clone(headers)
+	sha1["Paypal-Auth-Algo"] = "SHA1withRSA"
+	if v.Verify(payload, sha1, webhookID) {
+		t.Error("expected unsupported algorithm to fail")
+	}
+}
+
+func TestPayPalVerifierCertURL(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	ca := newPayPalTestCA(t)
+	certPEM, key := ca.issue(t, "messageverificationcerts.paypal.com", now.Add(24*time.Hour))
+	certs, fetches := newTestPayPalCerts(t, ca, certPEM, clock.NewFake(now))
+	v := &PayPalVerifier{Certs: certs}
+
+	payload := []byte(`{"id":"WH-1"}`)
+	for _, certURL := range []string{
+		"http://api.paypal.com/v1/notifications/certs/CERT-1",
+		"https://api.paypal.com.example.com/CERT-1",
+		"https://example.com/api.paypal.com/CERT-1",
+		"https://paypal.com@example.com/CERT-1",
+		"/v1/notifications/certs/CERT-1",
+	} {
+		headers := signPayPal(t, key, payload, "WH")
+		headers["Paypal-Cert-Url"] = certURL
+		if v.Verify(payload, headers, "WH") {
+			t.Errorf("cert url %q accepted", certURL)
+		}
+	}
+	if n := fetches.Load(); n != 0 {
+		t.Errorf("fetched %d certificates from untrusted urls", n)
+	}
+}
+
+func TestPayPalCertsRejectsUntrustedCerts(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	ca := newPayPalTestCA(t)
+
+	for name, certPEM := range map[string][]byte{
+		"other name": first(ca.issue(t, "messageverificationcerts.example.com", now.Add(time.Hour))),
+		"expired":    first(ca.issue(t, "messageverificationcerts.paypal.com", now.Add(-time.Minute))),
+		"other ca":   first(newPayPalTestCA(t).issue(t, "messageverificationcerts.paypal.com", now.Add(time.Hour))),
+		"not pem":    []byte("<html>not found</html>"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			certs, _ := newTestPayPalCerts(t, ca, certPEM, clock.NewFake(now))
+			if _, err := certs.Get(context.Background(), testPayPalCertURL); err == nil {
+				t.Error("certificate accepted")
+			}
+		})
+	}
+}
+
+func TestPayPalCertsExpire(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	c := clock.NewFake(now)
+	ca := newPayPalTestCA(t)
+	certPEM, _ := ca.issue(t, "messageverificationcerts.paypal.com", now.Add(time.Hour))
+	certs, fetches := newTestPayPalCerts(t, ca, certPEM, c)
+
+	for range 2 {
+		if _, err := certs.Get(context.Background(), testPayPalCertURL); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := fetches.Load(); n != 1 {
+		t.Fatalf("fetched %d times, want 1 while cached", n)
+	}
+
+	// Past NotAfter the cached certificate is dropped, and the expired
+	// download fails verification
+	c.Advance(2 * time.Hour)
+	if _, err := certs.Get(context.Background(), testPayPalCertURL); err == nil {
+		t.Error("expired certificate returned")
+	}
+	if n := fetches.Load(); n != 2 {
+		t.Errorf("fetched %d times, want a refetch after expiry", n)
+	}
+}
+
+func first[A, B any](a A, _ B) A {
+	return a
+}
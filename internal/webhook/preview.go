@@ -0,0 +1,23 @@
+package webhook
+
+import "unicode/utf8"
+
+// PayloadPreviewSize is the number of payload bytes returned as a preview by
+// APIs that don't ship full payloads.
+const PayloadPreviewSize = 4 << 10
+
+// PayloadPreview returns the first PayloadPreviewSize bytes of a payload and
+// whether it was truncated. Text payloads are cut on a rune boundary.
+func PayloadPreview(payload []byte) ([]byte, bool) {
+	if len(payload) <= PayloadPreviewSize {
+		return payload, false
+	}
+
+	preview := payload[:PayloadPreviewSize]
+	if utf8.Valid(payload[:min(len(payload), PayloadPreviewSize+utf8.UTFMax)]) {
+		for len(preview) > 0 && !utf8.RuneStart(payload[len(preview)]) {
+			preview = preview[:len(preview)-1]
+		}
+	}
+	return preview, true
+}
@@ -0,0 +1,26 @@
+package webhook
+
+import (
+	"bytes"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestPayloadPreview(t *testing.T) {
+	small := []byte(`{"id":1}`)
+	if preview, truncated := PayloadPreview(small); truncated || !bytes.Equal(preview, small) {
+		t.Errorf("small payload: got truncated=%v len=%d", truncated, len(preview))
+	}
+
+	large := bytes.Repeat([]byte("a"), PayloadPreviewSize*2)
+	if preview, truncated := PayloadPreview(large); !truncated || len(preview) != PayloadPreviewSize {
+		t.Errorf("large payload: got truncated=%v len=%d", truncated, len(preview))
+	}
+
+	// A multi-byte rune straddling the limit is dropped rather than split
+	text := append(bytes.Repeat([]byte("a"), PayloadPreviewSize-1), []byte("é and more")...)
+	preview, truncated := PayloadPreview(text)
+	if !truncated || !utf8.Valid(preview) || len(preview) != PayloadPreviewSize-1 {
+		t.Errorf("rune boundary: got truncated=%v valid=%v len=%d", truncated, utf8.Valid(preview), len(preview))
+	}
+}
@@ -0,0 +1,151 @@
+package webhook
+
+import (
+	"maps"
+	"math"
+	"sync"
+	"time"
+
+	"hooks.dx314.com/internal/clock"
+)
+
+// RateLimits are the ingestion rate limits, in requests per minute. Each is
+// a token bucket holding a minute's worth of requests, so a provider may
+// burst after a quiet spell. The zero value disables them.
+type RateLimits struct {
+	// PerEndpoint limits each endpoint; an endpoint's own limit overrides it.
+	PerEndpoint int
+	// PerIP limits each source IP across all endpoints.
+	PerIP int
+}
+
+// rateLimitPruneInterval is how often buckets that have refilled are dropped,
+// so scanners cycling through addresses don't grow the maps without bound.
+const rateLimitPruneInterval = time.Minute
+
+// bucket is a token bucket refilled at perMinute tokens a minute up to
+// perMinute.
+type bucket struct {
+	tokens    float64
+	perMinute int
+	updated   time.Time
+}
+
+// refill adds the tokens earned since the last update. perMinute may have
+// changed since, when an endpoint's limit is edited.
+func (b *bucket) refill(now time.Time, perMinute int) {
+	b.perMinute = perMinute
+	if elapsed := now.Sub(b.updated).Minutes(); elapsed > 0 {
+		b.tokens += elapsed * float64(perMinute)
+		b.updated = now
+	}
+	b.tokens = math.Min(b.tokens, float64(perMinute))
+}
+
+// wait returns how long until the bucket has a token.
+func (b *bucket) wait() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	return time.Duration((1 - b.tokens) / float64(b.perMinute) * float64(time.Minute))
+}
+
+// full reports whether the bucket has refilled, so dropping it changes nothing.
+func (b *bucket) full(now time.Time) bool {
+	return b.tokens+now.Sub(b.updated).Minutes()*float64(b.perMinute) >= float64(b.perMinute)
+}
+
+// RateLimiter applies RateLimits to webhook ingestion and counts the
+// requests it rejected per endpoint. State is in memory, per edge.
+type RateLimiter struct {
+	limits RateLimits
+	clock  clock.Clock
+
+	mu        sync.Mutex
+	endpoints map[string]*bucket
+	ips       map[string]*bucket
+	rejected  map[string]uint64 // Requests rejected per endpoint since start
+	pruned    time.Time
+}
+
+// NewRateLimiter creates a rate limiter with the given default limits.
+func NewRateLimiter(limits RateLimits, c clock.Clock) *RateLimiter {
+	return &RateLimiter{
+		limits:    limits,
+		clock:     clock.Or(c),
+		endpoints: make(map[string]*bucket),
+		ips:       make(map[string]*bucket),
+		rejected:  make(map[string]uint64),
+	}
+}
+
+// Allow takes a request to endpointID from ip, with the endpoint's own limit
+// (0 for the default). If either limit is exhausted it takes nothing and
+// returns false and how long until the request would be allowed.
+func (l *RateLimiter) Allow(endpointID, ip string, endpointLimit int) (bool, time.Duration) {
+	if endpointLimit <= 0 {
+		endpointLimit = l.limits.PerEndpoint
+	}
+	if endpointLimit <= 0 && l.limits.PerIP <= 0 {
+		return true, 0
+	}
+
+	now := l.clock.Now()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.prune(now)
+
+	var taken []*bucket
+	var wait time.Duration
+	if endpointLimit > 0 {
+		b := l.bucket(l.endpoints, endpointID, endpointLimit, now)
+		wait = max(wait, b.wait())
+		taken = append(taken, b)
+	}
+	if l.limits.PerIP > 0 && ip != "" {
+		b := l.bucket(l.ips, ip, l.limits.PerIP, now)
+		wait = max(wait, b.wait())
+		taken = append(taken, b)
+	}
+	if wait > 0 {
+		l.rejected[endpointID]++
+		return false, wait
+	}
+	for _, b := range taken {
+		b.tokens--
+	}
+	return true, 0
+}
+
+// bucket returns the refilled bucket for key, creating a full one.
+func (l *RateLimiter) bucket(buckets map[string]*bucket, key string, perMinute int, now time.Time) *bucket {
+	b, ok := buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(perMinute), perMinute: perMinute, updated: now}
+		buckets[key] = b
+	}
+	b.refill(now, perMinute)
+	return b
+}
+
+func (l *RateLimiter) prune(now time.Time) {
+	if now.Sub(l.pruned) < rateLimitPruneInterval {
+		return
+	}
+	l.pruned = now
+	for _, buckets := range []map[string]*bucket{l.endpoints, l.ips} {
+		for key, b := range buckets {
+			if b.full(now) {
+				delete(buckets, key)
+			}
+		}
+	}
+}
+
+// Rejected returns the number of requests rejected per endpoint since the
+// edge started. Endpoints without rejections are left out.
+func (l *RateLimiter) Rejected() map[string]uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return maps.Clone(l.rejected)
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"hooks.dx314.com/internal/clock"
+)
+
+func TestRateLimiter(t *testing.T) {
+	c := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	l := NewRateLimiter(RateLimits{PerEndpoint: 3, PerIP: 5}, c)
+
+	// A minute's worth may burst
+	for i := range 3 {
+		if ok, _ := l.Allow("ep-1", "203.0.113.1", 0); !ok {
+			t.Fatalf("request %d rejected within the burst", i+1)
+		}
+	}
+	ok, wait := l.Allow("ep-1", "203.0.113.1", 0)
+	if ok || wait <= 0 || wait > 20*time.Second {
+		t.Fatalf("over the limit: ok %v, wait %v, want a 20s wait", ok, wait)
+	}
+
+	// Tokens refill at the rate: one every 20 seconds
+	c.Advance(20 * time.Second)
+	if ok, _ := l.Allow("ep-1", "203.0.113.1", 0); !ok {
+		t.Error("rejected after a token refilled")
+	}
+
+	// The IP limit spans endpoints, and a rejected request takes nothing
+	for i := range 2 {
+		if ok, _ := l.Allow("ep-2", "203.0.113.1", 0); !ok {
+			t.Fatalf("ep-2 request %d rejected", i+1)
+		}
+	}
+	if ok, _ := l.Allow("ep-2", "203.0.113.1", 0); ok {
+		t.Error("IP over its limit allowed")
+	}
+	if ok, _ := l.Allow("ep-2", "198.51.100.7", 0); !ok {
+		t.Error("another IP rejected though ep-2 has a token left")
+	}
+
+	// An endpoint's own limit overrides the default
+	for i := range 10 {
+		if ok, _ := l.Allow("ep-3", "", 10); !ok {
+			t.Fatalf("ep-3 request %d rejected under its own limit", i+1)
+		}
+	}
+	if ok, _ := l.Allow("ep-3", "", 10); ok {
+		t.Error("ep-3 allowed over its own limit")
+	}
+
+	got := l.Rejected()
+	if got["ep-1"] != 1 || got["ep-2"] != 1 || got["ep-3"] != 1 || len(got) != 3 {
+		t.Errorf("Rejected() = %v", got)
+	}
+}
+
+func TestRateLimiterDisabled(t *testing.T) {
+	l := NewRateLimiter(RateLimits{}, nil)
+	for range 1000 {
+		if ok, _ := l.Allow("ep-1", "203.0.113.1", 0); !ok {
+			t.Fatal("rejected with limits disabled")
+		}
+	}
+	if ok, _ := l.Allow("ep-2", "203.0.113.1", 1); !ok {
+		t.Fatal("first request over an endpoint limit rejected")
+	}
+	if ok, _ := l.Allow("ep-2", "203.0.113.1", 1); ok {
+		t.Error("an endpoint's own limit applies without a default")
+	}
+}
+
+func TestRateLimiterPrune(t *testing.T) {
+	c := clock.NewFake(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	l := NewRateLimiter(RateLimits{PerIP: 60}, c)
+	for i := range 100 {
+		l.Allow("ep-1", "203.0.113."+strconv.Itoa(i), 0)
+	}
+	c.Advance(2 * time.Minute)
+	l.Allow("ep-1", "198.51.100.1", 0)
+	if n := len(l.ips); n != 1 {
+		t.Errorf("%d IP buckets kept after they refilled, want 1", n)
+	}
+}
@@ -0,0 +1,349 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	gonanoid "github.com/matoous/go-nanoid/v2"
+
+	"hooks.dx314.com/internal/clock"
+	"hooks.dx314.com/internal/db"
+)
+
+const (
+	// DefaultReplayRateLimit is the default number of replays allowed per endpoint per minute.
+	DefaultReplayRateLimit = 30
+	// DefaultReplayConfirmThreshold is the default number of pending replays on an
+	// endpoint above which further replays require a confirmation token.
+	DefaultReplayConfirmThreshold = 20
+
+	// replayWindow is the sliding window used for replay rate limiting.
+	replayWindow = time.Minute
+	// confirmTokenTTL is how long a confirmation token stays valid.
+	confirmTokenTTL = 5 * time.Minute
+
+	// DefaultBulkReplayCount is how many webhooks a bulk replay resets at
+	// most by default.
+	DefaultBulkReplayCount = 100
+	// MaxBulkReplayCount is the most webhooks a bulk replay can reset.
+	MaxBulkReplayCount = 1000
+)
+
+// ErrReplayRateLimited is returned when an endpoint exceeded its replay rate.
+var ErrReplayRateLimited = errors.New("replay rate limit exceeded")
+
+// ErrReplayHoneypot is returned for webhooks of honeypot endpoints, which are
+// never relayed.
+var ErrReplayHoneypot = errors.New("honeypot webhooks can't be replayed")
+
+// ConfirmationRequiredError is returned when an endpoint already has many
+// replays queued, or a bulk replay would queue many. The replay must be
+// retried with Token to proceed.
+type ConfirmationRequiredError struct {
+	Token     string
+	Pending   int64
+	Matching  int64 // Webhooks a bulk replay would reset
+	Threshold int
+}
+
+func (e *ConfirmationRequiredError) Error() string {
+	if e.Matching > 0 {
+		return fmt.Sprintf("%d webhooks would be replayed (threshold %d), confirmation required", e.Matching, e.Threshold)
+	}
+	return fmt.Sprintf("%d replays already pending (threshold %d), confirmation required", e.Pending, e.Threshold)
+}
+
+// confirmGrant is what a confirmation token confirms: replays of the user's
+// on an endpoint, or one bulk replay (see bulkScope) of at most matching
+// webhooks.
+type confirmGrant struct {
+	userID   string
+	scope    string
+	matching int64
+	expires  time.Time
+}
+
+// ReplayGuard throttles webhook replays per endpoint and requires explicit
+// confirmation before queueing large replay backlogs.
+type ReplayGuard struct {
+	queries          *db.Queries
+	ratePerMinute    int
+	confirmThreshold int
+
+	mu     sync.Mutex
+	recent map[string][]time.Time
+	tokens map[string]confirmGrant
+	clock  clock.Clock
+}
+
+// NewReplayGuard creates a replay guard. A rate or threshold of zero or less
+// disables the corresponding check.
+func NewReplayGuard(queries *db.Queries, ratePerMinute, confirmThreshold int) *ReplayGuard {
+	return &ReplayGuard{
+		queries:          queries,
+		ratePerMinute:    ratePerMinute,
+		confirmThreshold: confirmThreshold,
+		recent:           make(map[string][]time.Time),
+		tokens:           make(map[string]confirmGrant),
+		clock:            clock.Real,
+	}
+}
+
+// SetClock sets the clock that rate limits replays and expires
+// confirmation tokens.
+func (g *ReplayGuard) SetClock(c clock.Clock) {
+	g.clock = c
+}
+
+// Replay resets a webhook for re-delivery after applying the rate limit and
+// confirmation checks. by names who replayed it in the webhook's history, for
+// example a username. Returns sql.ErrNoRows if the webhook does not exist.
+func (g *ReplayGuard) Replay(ctx context.Context, userID, webhookID, confirmToken, by string) (db.Webhook, error) {
+	webhook, err := g.queries.GetWebhook(ctx, db.GetWebhookParams{
+		ID:     webhookID,
+		UserID: userID,
+	})
+	if err != nil {
+		return db.Webhook{}, err
+	}
+
+	endpoint, err := g.queries.GetEndpointByID(ctx, webhook.EndpointID)
+	if err != nil {
+		return db.Webhook{}, err
+	}
+	if endpoint.Honeypot != 0 {
+		return db.Webhook{}, ErrReplayHoneypot
+	}
+
+	if g.confirmThreshold > 0 && !g.validToken(confirmToken, userID, webhook.EndpointID, 0) {
+		pending, err := g.queries.CountPendingReplays(ctx, webhook.EndpointID)
+		if err != nil {
+			return db.Webhook{}, err
+		}
+		if pending >= int64(g.confirmThreshold) {
+			token, err := g.issueToken(userID, webhook.EndpointID, 0)
+			if err != nil {
+				return db.Webhook{}, err
+			}
+			return db.Webhook{}, &ConfirmationRequiredError{
+				Token:     token,
+				Pending:   pending,
+				Threshold: g.confirmThreshold,
+			}
+		}
+	}
+
+	if !g.allow(webhook.EndpointID) {
+		return db.Webhook{}, ErrReplayRateLimited
+	}
+
+	return g.queries.ResetWebhookForReplay(ctx, db.ResetWebhookForReplayParams{
+		ReplayedBy: sql.NullString{String: by, Valid: by != ""},
+		ID:         webhookID,
+		UserID:     userID,
+	})
+}
+
+// BulkReplayFilter selects the webhooks of a bulk replay.
+type BulkReplayFilter struct {
+	// EndpointID limits the replay to one endpoint; all of the user's if empty.
+	EndpointID string
+	// Status is the status of the webhooks to replay, StatusDeadLetter if
+	// empty. Pending webhooks are already queued, so they can't be replayed
+	// in bulk. Skipped ones can, for event types a hub now subscribes to;
+	// those of honeypot endpoints are never replayed.
+	Status string
+	// ReceivedAfter and ReceivedBefore bound when the webhooks were received,
+	// including ReceivedAfter and excluding ReceivedBefore. Zero is unbounded.
+	ReceivedAfter  time.Time
+	ReceivedBefore time.Time
+	// MaxCount is the most webhooks to replay, the oldest first:
+	// DefaultBulkReplayCount if 0, at most MaxBulkReplayCount.
+	MaxCount int
+}
+
+// Validate checks the filter and fills in the defaults.
+func (f *BulkReplayFilter) Validate() error {
+	switch f.Status {
+	case "":
+		f.Status = StatusDeadLetter
+	case StatusDelivered, StatusFailed, StatusDeadLetter, StatusAcknowledgedDuplicate, StatusSkipped:
+	default:
+		return fmt.Errorf("webhooks with status %q can't be replayed in bulk", f.Status)
+	}
+	if !f.ReceivedAfter.IsZero() && !f.ReceivedBefore.IsZero() && !f.ReceivedAfter.Before(f.ReceivedBefore) {
+		return errors.New("the time range is empty: received_after must be before received_before")
+	}
+	switch {
+	case f.MaxCount == 0:
+		f.MaxCount = DefaultBulkReplayCount
+	case f.MaxCount < 0 || f.MaxCount > MaxBulkReplayCount:
+		return fmt.Errorf("max count must be between 1 and %d", MaxBulkReplayCount)
+	}
+	return nil
+}
+
+// bulkScope identifies a validated filter's bulk replay for its
+// confirmation token.
+func bulkScope(f BulkReplayFilter) string {
+	bound := func(t time.Time) string {
+		if t.IsZero() {
+			return ""
+		}
+		return t.UTC().Format(time.RFC3339Nano)
+	}
+	return fmt.Sprintf("bulk\x00%s\x00%s\x00%s\x00%s\x00%d", f.EndpointID, f.Status, bound(f.ReceivedAfter), bound(f.ReceivedBefore), f.MaxCount)
+}
+
+// BulkReplay resets the oldest webhooks matching filter for re-delivery, at
+// most filter.MaxCount of them, and returns how many it reset. Queueing as
+// many as the confirmation threshold takes a confirmation token, like
+// Replay, and each bulk replay counts once towards the user's rate limit.
+// A token only confirms the same filter, and no more webhooks than it
+// counted.
+func (g *ReplayGuard) BulkReplay(ctx context.Context, userID string, filter BulkReplayFilter, confirmToken, by string) (int64, error) {
+	if err := filter.Validate(); err != nil {
+		return 0, err
+	}
+	var endpointFilter, after, before interface{}
+	if filter.EndpointID != "" {
+		endpointFilter = filter.EndpointID
+	}
+	if !filter.ReceivedAfter.IsZero() {
+		after = db.FormatTime(filter.ReceivedAfter)
+	}
+	if !filter.ReceivedBefore.IsZero() {
+		before = db.FormatTime(filter.ReceivedBefore)
+	}
+
+	matching, err := g.queries.CountBulkReplayable(ctx, db.CountBulkReplayableParams{
+		UserID:         userID,
+		Status:         filter.Status,
+		EndpointID:     endpointFilter,
+		ReceivedAfter:  after,
+		ReceivedBefore: before,
+	})
+	if err != nil {
+		return 0, err
+	}
+	matching = min(matching, int64(filter.MaxCount))
+	if matching == 0 {
+		return 0, nil
+	}
+
+	scope := bulkScope(filter)
+	if g.confirmThreshold > 0 && matching >= int64(g.confirmThreshold) && !g.validToken(confirmToken, userID, scope, matching) {
+		token, err := g.issueToken(userID, scope, matching)
+		if err != nil {
+			return 0, err
+		}
+		return 0, &ConfirmationRequiredError{
+			Token:     token,
+			Matching:  matching,
+			Threshold: g.confirmThreshold,
+		}
+	}
+
+	if !g.allow("bulk:" + userID) {
+		return 0, ErrReplayRateLimited
+	}
+
+	return g.queries.BulkResetWebhooksForReplay(ctx, db.BulkResetWebhooksForReplayParams{
+		ReplayedBy:     sql.NullString{String: by, Valid: by != ""},
+		UserID:         userID,
+		Status:         filter.Status,
+		EndpointID:     endpointFilter,
+		ReceivedAfter:  after,
+		ReceivedBefore: before,
+		MaxCount:       int64(filter.MaxCount),
+	})
+}
+
+// CancelPending marks all queued replays as failed. If endpointID is empty,
+// replays on all of the user's endpoints are cancelled.
+func (g *ReplayGuard) CancelPending(ctx context.Context, userID, endpointID string) (int64, error) {
+	var endpointFilter interface{}
+	if endpointID != "" {
+		endpointFilter = endpointID
+	}
+	return g.queries.CancelPendingReplays(ctx, db.CancelPendingReplaysParams{
+		UserID:     userID,
+		EndpointID: endpointFilter,
+	})
+}
+
+// allow records a replay for the endpoint, or a user's bulk replay, if it is
+// within the rate limit.
+func (g *ReplayGuard) allow(endpointID string) bool {
+	if g.ratePerMinute <= 0 {
+		return true
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.clock.Now()
+	cutoff := now.Add(-replayWindow)
+	times := g.recent[endpointID]
+	i := 0
+	for i < len(times) && !times[i].After(cutoff) {
+		i++
+	}
+	times = times[i:]
+
+	if len(times) >= g.ratePerMinute {
+		g.recent[endpointID] = times
+		return false
+	}
+	g.recent[endpointID] = append(times, now)
+	return true
+}
+
+// issueToken creates a confirmation token bound to the user, scope (an
+// endpoint ID or bulkScope) and the matching webhooks it was shown.
+func (g *ReplayGuard) issueToken(userID, scope string, matching int64) (string, error) {
+	token, err := gonanoid.New()
+	if err != nil {
+		return "", fmt.Errorf("generate confirmation token: %w", err)
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.clock.Now()
+	for t, grant := range g.tokens {
+		if now.After(grant.expires) {
+			delete(g.tokens, t)
+		}
+	}
+	g.tokens[token] = confirmGrant{
+		userID:   userID,
+		scope:    scope,
+		matching: matching,
+		expires:  now.Add(confirmTokenTTL),
+	}
+	return token, nil
+}
+
+// validToken reports whether token is a valid confirmation for the user and
+// scope, of no more than matching webhooks. Tokens stay valid until they
+// expire so a batch of replays can be confirmed once.
+func (g *ReplayGuard) validToken(token, userID, scope string, matching int64) bool {
+	if token == "" {
+		return false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	grant, ok := g.tokens[token]
+	if !ok || g.clock.Now().After(grant.expires) {
+		delete(g.tokens, token)
+		return false
+	}
+	return grant.userID == userID && grant.scope == scope && matching <= grant.matching
+}
@@ -0,0 +1,268 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"hooks.dx314.com/internal/clock"
+	"hooks.dx314.com/internal/db"
+)
+
+func setupReplayTest(t *testing.T) *db.Queries {
+	t.Helper()
+	ctx := context.Background()
+
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	queries := db.New(conn)
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-replay",
+		UserID:         "user-1",
+		Name:           "Replay Endpoint",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+
+	for i := 1; i <= 4; i++ {
+		id := fmt.Sprintf("wh-%d", i)
+		if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{
+			ID:         id,
+			EndpointID: "ep-replay",
+			Headers:    "{}",
+			Payload:    []byte("{}"),
+		}); err != nil {
+			t.Fatalf("create webhook: %v", err)
+		}
+		if _, err := queries.MarkWebhookDelivered(ctx, id); err != nil {
+			t.Fatalf("mark delivered: %v", err)
+		}
+	}
+
+	return queries
+}
+
+func TestReplayGuardConfirmation(t *testing.T) {
+	ctx := context.Background()
+	queries := setupReplayTest(t)
+	g := NewReplayGuard(queries, 0, 2)
+
+	for _, id := range []string{"wh-1", "wh-2"} {
+		if _, err := g.Replay(ctx, "user-1", id, "", "alice"); err != nil {
+			t.Fatalf("replay %s: %v", id, err)
+		}
+	}
+
+	_, err := g.Replay(ctx, "user-1", "wh-3", "", "alice")
+	var confirmErr *ConfirmationRequiredError
+	if !errors.As(err, &confirmErr) {
+		t.Fatalf("expected ConfirmationRequiredError, got %v", err)
+	}
+	if confirmErr.Pending != 2 || confirmErr.Token == "" {
+		t.Errorf("unexpected confirmation: %+v", confirmErr)
+	}
+
+	// Token is bound to the user that requested it
+	if _, err := g.Replay(ctx, "user-2", "wh-3", confirmErr.Token, "alice"); err == nil {
+		t.Error("expected replay by another user to fail")
+	}
+
+	for _, id := range []string{"wh-3", "wh-4"} {
+		wh, err := g.Replay(ctx, "user-1", id, confirmErr.Token, "alice")
+		if err != nil {
+			t.Fatalf("confirmed replay %s: %v", id, err)
+		}
+		if wh.Status != "pending" || !wh.ReplayedAt.Valid || wh.ReplayedBy.String != "alice" || wh.ReplayCount != 1 {
+			t.Errorf("replay %s: status %s, replayed_at %v by %v, count %d", id, wh.Status, wh.ReplayedAt, wh.ReplayedBy, wh.ReplayCount)
+		}
+	}
+
+	if _, err := g.Replay(ctx, "user-1", "wh-1", "bogus", "alice"); !errors.As(err, &confirmErr) {
+		t.Errorf("expected invalid token to require confirmation, got %v", err)
+	}
+}
+
+func TestReplayGuardRateLimit(t *testing.T) {
+	ctx := context.Background()
+	queries := setupReplayTest(t)
+	g := NewReplayGuard(queries, 2, 0)
+	clk := clock.NewFake(time.Now())
+	g.SetClock(clk)
+
+	for _, id := range []string{"wh-1", "wh-2"} {
+		if _, err := g.Replay(ctx, "user-1", id, "", "alice"); err != nil {
+			t.Fatalf("replay %s: %v", id, err)
+		}
+	}
+	if _, err := g.Replay(ctx, "user-1", "wh-3", "", "alice"); !errors.Is(err, ErrReplayRateLimited) {
+		t.Fatalf("expected ErrReplayRateLimited, got %v", err)
+	}
+
+	// Slide the window forward
+	clk.Advance(replayWindow + time.Second)
+	if _, err := g.Replay(ctx, "user-1", "wh-3", "", "alice"); err != nil {
+		t.Fatalf("replay after window: %v", err)
+	}
+}
+
+func TestReplayGuardCancelPending(t *testing.T) {
+	ctx := context.Background()
+	queries := setupReplayTest(t)
+	g := NewReplayGuard(queries, 0, 0)
+
+	for _, id := range []string{"wh-1", "wh-2", "wh-3"} {
+		if _, err := g.Replay(ctx, "user-1", id, "", "alice"); err != nil {
+			t.Fatalf("replay %s: %v", id, err)
+		}
+	}
+
+	if n, err := g.CancelPending(ctx, "user-2", ""); err != nil || n != 0 {
+		t.Errorf("cancel for other user: got %d, %v", n, err)
+	}
+
+	n, err := g.CancelPending(ctx, "user-1", "ep-replay")
+	if err != nil {
+		t.Fatalf("cancel pending: %v", err)
+	}
+	if n != 3 {
+		t.Errorf("cancelled: got %d, want 3", n)
+	}
+
+	pending, err := queries.CountPendingReplays(ctx, "ep-replay")
+	if err != nil {
+		t.Fatalf("count pending replays: %v", err)
+	}
+	if pending != 0 {
+		t.Errorf("pending replays: got %d, want 0", pending)
+	}
+}
+
+func TestReplayGuardHoneypot(t *testing.T) {
+	ctx := context.Background()
+	queries := setupReplayTest(t)
+	if _, err := queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		ID:       "ep-replay",
+		UserID:   "user-1",
+		Honeypot: sql.NullInt64{Int64: 1, Valid: true},
+	}); err != nil {
+		t.Fatalf("update endpoint: %v", err)
+	}
+
+	g := NewReplayGuard(queries, 0, 0)
+	if _, err := g.Replay(ctx, "user-1", "wh-1", "", "alice"); !errors.Is(err, ErrReplayHoneypot) {
+		t.Fatalf("expected ErrReplayHoneypot, got %v", err)
+	}
+}
+
+func TestReplayGuardBulkReplay(t *testing.T) {
+	ctx := context.Background()
+	queries := setupReplayTest(t)
+	g := NewReplayGuard(queries, 0, 3)
+
+	delivered := BulkReplayFilter{EndpointID: "ep-replay", Status: StatusDelivered}
+	if n, err := g.BulkReplay(ctx, "user-2", delivered, "", "mallory"); err != nil || n != 0 {
+		t.Errorf("bulk replay for other user: got %d, %v", n, err)
+	}
+	if n, err := g.BulkReplay(ctx, "user-1", BulkReplayFilter{}, "", "alice"); err != nil || n != 0 {
+		t.Errorf("bulk replay of dead letters: got %d, %v", n, err)
+	}
+	if n, err := g.BulkReplay(ctx, "user-1", BulkReplayFilter{ReceivedBefore: time.Now().Add(-time.Hour), Status: StatusDelivered}, "", "alice"); err != nil || n != 0 {
+		t.Errorf("bulk replay before the webhooks: got %d, %v", n, err)
+	}
+
+	// Two stay under the confirmation threshold
+	limited := delivered
+	limited.MaxCount = 2
+	n, err := g.BulkReplay(ctx, "user-1", limited, "", "alice")
+	if err != nil || n != 2 {
+		t.Fatalf("bulk replay of 2: got %d, %v", n, err)
+	}
+	wh, err := queries.GetWebhook(ctx, db.GetWebhookParams{ID: "wh-1", UserID: "user-1"})
+	if err != nil || wh.Status != StatusPending || wh.ReplayedBy.String != "alice" || wh.ReplayCount != 1 {
+		t.Errorf("oldest webhook after bulk replay: %+v, %v", wh, err)
+	}
+
+	// Mark them delivered again: four match, which needs confirmation
+	for _, id := range []string{"wh-1", "wh-2"} {
+		if _, err := queries.MarkWebhookDelivered(ctx, id); err != nil {
+			t.Fatalf("mark delivered: %v", err)
+		}
+	}
+	_, err = g.BulkReplay(ctx, "user-1", delivered, "", "alice")
+	var confirmErr *ConfirmationRequiredError
+	if !errors.As(err, &confirmErr) || confirmErr.Matching != 4 {
+		t.Fatalf("expected confirmation for 4 webhooks, got %v", err)
+	}
+	// The token only confirms the filter it was issued for, and no more
+	// webhooks than were counted
+	for _, f := range []BulkReplayFilter{
+		{Status: StatusDelivered},
+		{EndpointID: "ep-replay", Status: StatusDelivered, ReceivedBefore: time.Now().Add(time.Hour)},
+		{EndpointID: "ep-replay", Status: StatusDelivered, ReceivedAfter: time.Now().Add(-time.Hour)},
+	} {
+		if _, err := g.BulkReplay(ctx, "user-1", f, confirmErr.Token, "alice"); !errors.As(err, new(*ConfirmationRequiredError)) {
+			t.Errorf("BulkReplay(%+v) with another filter's token: got %v, want confirmation", f, err)
+		}
+	}
+	if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{ID: "wh-5", EndpointID: "ep-replay", Headers: "{}", Payload: []byte("{}")}); err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+	if _, err := queries.MarkWebhookDelivered(ctx, "wh-5"); err != nil {
+		t.Fatalf("mark delivered: %v", err)
+	}
+	if _, err := g.BulkReplay(ctx, "user-1", delivered, confirmErr.Token, "alice"); !errors.As(err, &confirmErr) || confirmErr.Matching != 5 {
+		t.Fatalf("bulk replay after more matched: got %v, want confirmation for 5", err)
+	}
+	if n, err := g.BulkReplay(ctx, "user-1", delivered, confirmErr.Token, "alice"); err != nil || n != 5 {
+		t.Fatalf("confirmed bulk replay: got %d, %v", n, err)
+	}
+	if pending, _ := queries.CountPendingReplays(ctx, "ep-replay"); pending != 5 {
+		t.Errorf("pending replays: got %d, want 5", pending)
+	}
+
+	// Webhooks skipped because no hub wanted their event type can be replayed
+	if err := queries.MarkWebhookSkipped(ctx, "wh-1"); err != nil {
+		t.Fatalf("mark skipped: %v", err)
+	}
+	skipped := BulkReplayFilter{Status: StatusSkipped}
+	if n, err := NewReplayGuard(queries, 0, 0).BulkReplay(ctx, "user-1", skipped, "", "alice"); err != nil || n != 1 {
+		t.Errorf("bulk replay of skipped webhooks: got %d, %v", n, err)
+	}
+
+	for _, f := range []BulkReplayFilter{
+		{Status: StatusPending},
+		{MaxCount: MaxBulkReplayCount + 1},
+		{ReceivedAfter: time.Now(), ReceivedBefore: time.Now().Add(-time.Hour)},
+	} {
+		if _, err := g.BulkReplay(ctx, "user-1", f, "", "alice"); err == nil {
+			t.Errorf("BulkReplay(%+v) succeeded", f)
+		}
+	}
+}
+
+func TestReplayGuardBulkReplayHoneypot(t *testing.T) {
+	ctx := context.Background()
+	queries := setupReplayTest(t)
+	if _, err := queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{
+		ID:       "ep-replay",
+		UserID:   "user-1",
+		Honeypot: sql.NullInt64{Int64: 1, Valid: true},
+	}); err != nil {
+		t.Fatalf("update endpoint: %v", err)
+	}
+
+	g := NewReplayGuard(queries, 0, 0)
+	if n, err := g.BulkReplay(ctx, "user-1", BulkReplayFilter{Status: StatusDelivered}, "", "alice"); err != nil || n != 0 {
+		t.Errorf("bulk replay on a honeypot: got %d, %v", n, err)
+	}
+}
@@ -0,0 +1,82 @@
+package webhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+)
+
+// MaxIngestResponseBody bounds the body of an IngestResponse, in bytes.
+const MaxIngestResponseBody = 4 << 10
+
+// IngestResponse is what ingestion answers once a webhook is stored, for
+// providers that expect a particular status or body. The zero value answers
+// 200 with no body.
+type IngestResponse struct {
+	StatusCode  int    `json:"status_code,omitempty"` // 0 for 200
+	Body        string `json:"body,omitempty"`
+	ContentType string `json:"content_type,omitempty"`
+}
+
+// IsZero reports whether the response is the default one.
+func (r *IngestResponse) IsZero() bool {
+	return r == nil || (r.StatusCode == 0 && r.Body == "" && r.ContentType == "")
+}
+
+// Validate checks that the response can be sent. Only 2xx statuses are
+// allowed: the webhook is stored, and anything else makes providers resend
+// it.
+func (r *IngestResponse) Validate() error {
+	if r.StatusCode != 0 && (r.StatusCode < 200 || r.StatusCode > 299) {
+		return fmt.Errorf("status code %d is not a 2xx status", r.StatusCode)
+	}
+	if r.StatusCode == http.StatusNoContent && r.Body != "" {
+		return fmt.Errorf("a 204 response can't have a body")
+	}
+	if len(r.Body) > MaxIngestResponseBody {
+		return fmt.Errorf("body is longer than %d bytes", MaxIngestResponseBody)
+	}
+	if r.ContentType != "" {
+		if r.Body == "" {
+			return fmt.Errorf("content type set without a body")
+		}
+		if _, _, err := mime.ParseMediaType(r.ContentType); err != nil {
+			return fmt.Errorf("invalid content type %q", r.ContentType)
+		}
+	}
+	return nil
+}
+
+// ParseIngestResponse decodes a stored response. An empty or invalid one is
+// the default response, so a bad row never fails ingestion.
+func ParseIngestResponse(data string) IngestResponse {
+	var r IngestResponse
+	if data != "" {
+		if err := json.Unmarshal([]byte(data), &r); err != nil {
+			slog.Error("invalid ingest response, answering 200", "error", err)
+			return IngestResponse{}
+		}
+	}
+	return r
+}
+
+// write sends the response.
+func (r IngestResponse) write(w http.ResponseWriter) {
+	status := r.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+	if r.Body != "" {
+		contentType := r.ContentType
+		if contentType == "" {
+			contentType = "text/plain; charset=utf-8"
+		}
+		w.Header().Set("Content-Type", contentType)
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+	}
+	w.WriteHeader(status)
+	io.WriteString(w, r.Body)
+}
@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIngestResponseValidate(t *testing.T) {
+	valid := []IngestResponse{
+		{},
+		{StatusCode: http.StatusNoContent},
+		{StatusCode: http.StatusAccepted, Body: `{"ok":true}`, ContentType: "application/json"},
+		{Body: "ok"},
+	}
+	for _, r := range valid {
+		if err := r.Validate(); err != nil {
+			t.Errorf("Validate(%+v): %v", r, err)
+		}
+	}
+
+	invalid := []struct {
+		response IngestResponse
+		want     string
+	}{
+		{IngestResponse{StatusCode: http.StatusBadRequest}, "not a 2xx"},
+		{IngestResponse{StatusCode: 100}, "not a 2xx"},
+		{IngestResponse{StatusCode: http.StatusNoContent, Body: "x"}, "204"},
+		{IngestResponse{Body: strings.Repeat("x", MaxIngestResponseBody+1)}, "longer"},
+		{IngestResponse{ContentType: "text/plain"}, "without a body"},
+		{IngestResponse{Body: "x", ContentType: "text/"}, "invalid content type"},
+	}
+	for _, tt := range invalid {
+		err := tt.response.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Validate(%+v) = %v, want error containing %q", tt.response, err, tt.want)
+		}
+	}
+}
+
+func TestIngestResponseWrite(t *testing.T) {
+	tests := []struct {
+		stored      string
+		status      int
+		body        string
+		contentType string
+	}{
+		{"", http.StatusOK, "", ""},
+		{"not json", http.StatusOK, "", ""},
+		{`{"status_code": 204}`, http.StatusNoContent, "", ""},
+		{`{"body": "ok"}`, http.StatusOK, "ok", "text/plain; charset=utf-8"},
+		{`{"status_code": 202, "body": "{}", "content_type": "application/json"}`, http.StatusAccepted, "{}", "application/json"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		ParseIngestResponse(tt.stored).write(rec)
+		if rec.Code != tt.status || rec.Body.String() != tt.body || rec.Header().Get("Content-Type") != tt.contentType {
+			t.Errorf("%q: got %d %q (%s), want %d %q (%s)", tt.stored,
+				rec.Code, rec.Body.String(), rec.Header().Get("Content-Type"),
+				tt.status, tt.body, tt.contentType)
+		}
+	}
+}
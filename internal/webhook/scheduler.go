@@ -2,35 +2,168 @@ package webhook
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
+	"runtime/debug"
 	"sync"
 	"time"
 
+	"hooks.dx314.com/internal/clock"
+	"hooks.dx314.com/internal/coldstore"
 	"hooks.dx314.com/internal/db"
+	"hooks.dx314.com/internal/jobs"
+	"hooks.dx314.com/internal/tracing"
 )
 
 const (
-	// JobInterval is how often background jobs run.
+	// JobInterval is how often background jobs run by default.
 	JobInterval = time.Hour
-	// DeadLetterAge is how long before pending webhooks become dead letters.
+	// DeadLetterAge is how long before pending webhooks become dead letters by default.
 	DeadLetterAge = 7 * 24 * time.Hour
+
+	// Default retention of webhooks, activity and audit events.
+	DeliveredRetention  = 7 * 24 * time.Hour
+	FailedRetention     = 7 * 24 * time.Hour // From the last attempt
+	DeadLetterRetention = 14 * 24 * time.Hour
+	SkippedRetention    = 7 * 24 * time.Hour // From receipt
+	ActivityRetention   = 7 * 24 * time.Hour
+	AuditRetention      = 365 * 24 * time.Hour
+
+	// PurgeGrace is how long webhooks purged by cleanup can be undeleted
+	// before they are deleted, by default.
+	PurgeGrace = 72 * time.Hour
+)
+
+// Maintenance jobs, in the order they run.
+const (
+	MaintenanceDeadLetters = "dead_letters" // Mark old pending webhooks as dead letters
+	MaintenanceSLO         = "slo"          // Check delivery SLOs
+	MaintenanceArchive     = "archive"      // Mute endpoints inactive for ArchiveAfter
+	MaintenanceCleanup     = "cleanup"      // Purge (or export and purge) webhooks past retention, delete purged webhooks, activity, audit events and jobs
+	MaintenanceJobs        = "jobs"         // Report background jobs that failed permanently
 )
 
+// MaintenanceJobNames lists the maintenance jobs in the order they run.
+var MaintenanceJobNames = []string{MaintenanceDeadLetters, MaintenanceSLO, MaintenanceArchive, MaintenanceCleanup, MaintenanceJobs}
+
+// exportBatchSize is how many webhooks past retention are exported to cold
+// storage, then purged, at a time.
+const exportBatchSize = 500
+
+// ErrUnknownJob is returned by RunJob for a job name that doesn't exist.
+var ErrUnknownJob = errors.New("unknown maintenance job")
+
+// SchedulerConfig controls how often maintenance runs and how long webhooks
+// are kept.
+type SchedulerConfig struct {
+	Interval            time.Duration
+	DeadLetterAge       time.Duration
+	DeliveredRetention  time.Duration
+	FailedRetention     time.Duration
+	DeadLetterRetention time.Duration
+	SkippedRetention    time.Duration
+	ActivityRetention   time.Duration
+	AuditRetention      time.Duration
+	// PurgeGrace is how long webhooks past retention stay purged, hidden but
+	// able to be undeleted, before cleanup deletes them.
+	PurgeGrace time.Duration
+	// ArchiveAfter mutes endpoints that received no webhook for this long.
+	// Zero, the default, disables archiving.
+	ArchiveAfter time.Duration
+}
+
+// DefaultSchedulerConfig returns the default schedule and retention.
+func DefaultSchedulerConfig() SchedulerConfig {
+	return SchedulerConfig{
+		Interval:            JobInterval,
+		DeadLetterAge:       DeadLetterAge,
+		DeliveredRetention:  DeliveredRetention,
+		FailedRetention:     FailedRetention,
+		DeadLetterRetention: DeadLetterRetention,
+		SkippedRetention:    SkippedRetention,
+		ActivityRetention:   ActivityRetention,
+		AuditRetention:      AuditRetention,
+		PurgeGrace:          PurgeGrace,
+	}
+}
+
+// JobStatus describes the last and next run of a maintenance job.
+type JobStatus struct {
+	Name         string
+	LastRun      time.Time // Zero if the job hasn't run yet
+	LastDuration time.Duration
+	LastError    string
+	NextRun      time.Time // Zero if the scheduler isn't running
+}
+
 // Scheduler runs background maintenance jobs for webhooks.
 type Scheduler struct {
-	queries *db.Queries
+	queries      *db.Queries
+	cfg          SchedulerConfig
 	onDeadLetter func(count int64) // Callback when webhooks are dead-lettered
+	onSLOBreach  func(endpoint db.ListSLOEndpointsRow, status SLOStatus)
+	onArchive    func(endpoint db.ArchiveInactiveEndpointsRow)
+	jobs         *jobs.Queue
+	exporter     *coldstore.Exporter // Exports webhooks before cleanup deletes them, if set
+	clock        clock.Clock
 
 	mu       sync.Mutex
 	running  bool
 	cancelFn context.CancelFunc
+	lastRuns map[string]JobStatus
+	nextRun  time.Time
+
+	runMu sync.Mutex // Serializes job runs, scheduled or manual
 }
 
-// NewScheduler creates a new webhook scheduler.
+// NewScheduler creates a new webhook scheduler with the default config.
 func NewScheduler(queries *db.Queries) *Scheduler {
 	return &Scheduler{
-		queries: queries,
+		queries:  queries,
+		cfg:      DefaultSchedulerConfig(),
+		lastRuns: make(map[string]JobStatus),
+		clock:    clock.Real,
+	}
+}
+
+// SetClock sets the clock that schedules and times jobs. It must be called
+// before Start.
+func (s *Scheduler) SetClock(c clock.Clock) {
+	s.clock = c
+}
+
+// SetConfig sets the schedule and retention. Zero fields keep their defaults,
+// except ArchiveAfter. It must be called before Start.
+func (s *Scheduler) SetConfig(cfg SchedulerConfig) {
+	def := DefaultSchedulerConfig()
+	for _, f := range []struct{ v, d *time.Duration }{
+		{&cfg.Interval, &def.Interval},
+		{&cfg.DeadLetterAge, &def.DeadLetterAge},
+		{&cfg.DeliveredRetention, &def.DeliveredRetention},
+		{&cfg.FailedRetention, &def.FailedRetention},
+		{&cfg.DeadLetterRetention, &def.DeadLetterRetention},
+		{&cfg.SkippedRetention, &def.SkippedRetention},
+		{&cfg.ActivityRetention, &def.ActivityRetention},
+		{&cfg.AuditRetention, &def.AuditRetention},
+		{&cfg.PurgeGrace, &def.PurgeGrace},
+	} {
+		if *f.v <= 0 {
+			*f.v = *f.d
+		}
 	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfg = cfg
+}
+
+// Config returns the scheduler's schedule and retention.
+func (s *Scheduler) Config() SchedulerConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg
 }
 
 // SetDeadLetterCallback sets a callback to be invoked when webhooks are dead-lettered.
@@ -40,6 +173,37 @@ func (s *Scheduler) SetDeadLetterCallback(fn func(count int64)) {
 	s.onDeadLetter = fn
 }
 
+// SetSLOBreachCallback sets a callback to be invoked when an endpoint's
+// delivery SLO becomes breached. It is called once per breach.
+func (s *Scheduler) SetSLOBreachCallback(fn func(endpoint db.ListSLOEndpointsRow, status SLOStatus)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onSLOBreach = fn
+}
+
+// SetArchiveCallback sets a callback to be invoked for each endpoint muted
+// for inactivity.
+func (s *Scheduler) SetArchiveCallback(fn func(endpoint db.ArchiveInactiveEndpointsRow)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.onArchive = fn
+}
+
+// SetJobQueue sets the job queue whose worker runs alongside the scheduler.
+func (s *Scheduler) SetJobQueue(q *jobs.Queue) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.jobs = q
+}
+
+// SetExporter makes cleanup export webhooks past retention to cold storage
+// before purging them. A webhook is only purged once its export succeeded.
+func (s *Scheduler) SetExporter(e *coldstore.Exporter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.exporter = e
+}
+
 // Start begins the background scheduler. Blocks until context is cancelled.
 func (s *Scheduler) Start(ctx context.Context) error {
 	s.mu.Lock()
@@ -50,28 +214,85 @@ func (s *Scheduler) Start(ctx context.Context) error {
 	s.running = true
 
 	ctx, s.cancelFn = context.WithCancel(ctx)
+	queue := s.jobs
 	s.mu.Unlock()
 
+	if queue != nil {
+		go queue.Run(ctx)
+	}
+
 	defer func() {
 		s.mu.Lock()
 		s.running = false
 		s.mu.Unlock()
 	}()
 
+	interval := s.Config().Interval
+
 	// Run immediately on startup
 	s.runJobs(ctx)
+	s.setNextRun(s.clock.Now().Add(interval))
 
-	ticker := time.NewTicker(JobInterval)
+	ticker := s.clock.NewTicker(interval)
 	defer ticker.Stop()
 
 	for {
 		select {
 		case <-ctx.Done():
+			s.setNextRun(time.Time{})
 			return ctx.Err()
-		case <-ticker.C:
+		case <-ticker.C():
 			s.runJobs(ctx)
+			s.setNextRun(s.clock.Now().Add(interval))
+		}
+	}
+}
+
+func (s *Scheduler) setNextRun(t time.Time) {
+	s.mu.Lock()
+	s.nextRun = t
+	s.mu.Unlock()
+}
+
+// RunJob runs a single maintenance job now, waiting for a scheduled run in
+// progress to finish first. It returns ErrUnknownJob for an unknown name.
+func (s *Scheduler) RunJob(ctx context.Context, name string) error {
+	if !isMaintenanceJob(name) {
+		return fmt.Errorf("%w %q", ErrUnknownJob, name)
+	}
+	slog.Info("running maintenance job on demand", "job", name)
+	return s.runJob(ctx, name)
+}
+
+// JobStatuses returns the last and next run of each maintenance job.
+func (s *Scheduler) JobStatuses() []JobStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	statuses := make([]JobStatus, len(MaintenanceJobNames))
+	for i, name := range MaintenanceJobNames {
+		st := s.lastRuns[name]
+		st.Name = name
+		st.NextRun = s.nextRun
+		statuses[i] = st
+	}
+	return statuses
+}
+
+func isMaintenanceJob(name string) bool {
+	for _, n := range MaintenanceJobNames {
+		if n == name {
+			return true
 		}
 	}
+	return false
+}
+
+// Running reports whether Start is running.
+func (s *Scheduler) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
 }
 
 // Stop gracefully stops the scheduler.
@@ -86,19 +307,80 @@ func (s *Scheduler) Stop() {
 func (s *Scheduler) runJobs(ctx context.Context) {
 	slog.Debug("running webhook maintenance jobs")
 
-	// Process dead letters
-	s.processDeadLetters(ctx)
+	for _, name := range MaintenanceJobNames {
+		s.runJob(ctx, name)
+	}
+}
 
-	// Run retention cleanup
-	s.runCleanup(ctx)
+// execJob runs the body of a maintenance job, turning a panic into an error so
+// one failing job can't stop the scheduler.
+func (s *Scheduler) execJob(ctx context.Context, name string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("maintenance job panicked", "job", name, "panic", r, "stack", string(debug.Stack()))
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	switch name {
+	case MaintenanceDeadLetters:
+		return s.processDeadLetters(ctx)
+	case MaintenanceSLO:
+		return s.checkSLOs(ctx)
+	case MaintenanceArchive:
+		return s.archiveInactive(ctx)
+	case MaintenanceCleanup:
+		return s.runCleanup(ctx)
+	case MaintenanceJobs:
+		return s.checkJobs(ctx)
+	}
+	return nil
+}
+
+// runJob runs a maintenance job and records when it ran and how it went.
+func (s *Scheduler) runJob(ctx context.Context, name string) error {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	start := s.clock.Now()
+	err := s.execJob(ctx, name)
+
+	st := JobStatus{Name: name, LastRun: start, LastDuration: s.clock.Now().Sub(start)}
+	if err != nil {
+		st.LastError = err.Error()
+	}
+	s.mu.Lock()
+	s.lastRuns[name] = st
+	s.mu.Unlock()
+	return err
+}
+
+// checkJobs logs jobs that failed permanently, so lost side effects are visible.
+func (s *Scheduler) checkJobs(ctx context.Context) error {
+	s.mu.Lock()
+	queue := s.jobs
+	s.mu.Unlock()
+	if queue == nil {
+		return nil
+	}
+
+	stats, err := queue.Stats(ctx)
+	if err != nil {
+		slog.Error("failed to get job stats", "error", err)
+		return err
+	}
+	if failed := stats.Failed(); failed > 0 {
+		slog.Warn("background jobs failed permanently", "count", failed, "stats", stats)
+	}
+	return nil
 }
 
 // processDeadLetters marks old pending webhooks as dead letters.
-func (s *Scheduler) processDeadLetters(ctx context.Context) {
-	count, err := s.queries.MarkDeadLetter(ctx)
+func (s *Scheduler) processDeadLetters(ctx context.Context) error {
+	count, err := s.queries.MarkDeadLetter(ctx, seconds(s.Config().DeadLetterAge))
 	if err != nil {
 		slog.Error("failed to mark dead letters", "error", err)
-		return
+		return err
 	}
 
 	if count > 0 {
@@ -112,31 +394,228 @@ func (s *Scheduler) processDeadLetters(ctx context.Context) {
 			callback(count)
 		}
 	}
+	return nil
 }
 
-// runCleanup deletes old webhooks per retention policy.
-func (s *Scheduler) runCleanup(ctx context.Context) {
-	// Delete old delivered webhooks (7 days)
-	delivered, err := s.queries.DeleteDeliveredWebhooks(ctx)
+// checkSLOs computes SLO compliance for endpoints with an SLO and records
+// breach transitions, so each breach is alerted once until it recovers.
+func (s *Scheduler) checkSLOs(ctx context.Context) error {
+	endpoints, err := s.queries.ListSLOEndpoints(ctx)
 	if err != nil {
-		slog.Error("failed to delete delivered webhooks", "error", err)
-	} else if delivered > 0 {
-		slog.Info("deleted old delivered webhooks", "count", delivered)
+		slog.Error("failed to list slo endpoints", "error", err)
+		return err
+	}
+
+	for _, ep := range endpoints {
+		status, err := ComputeSLO(ctx, s.queries, ep.ID, ep.SloTarget, ep.SloLatencySeconds, ep.SloWindowHours)
+		if err != nil {
+			slog.Error("failed to compute slo", "error", err, "endpoint_id", ep.ID)
+			continue
+		}
+
+		if !status.Breached() {
+			recovered, err := s.queries.ClearEndpointSLOBreached(ctx, ep.ID)
+			if err != nil {
+				slog.Error("failed to clear slo breach", "error", err, "endpoint_id", ep.ID)
+			} else if recovered > 0 {
+				slog.Info("endpoint slo recovered", "endpoint_id", ep.ID, "compliance", status.Compliance())
+			}
+			continue
+		}
+
+		breached, err := s.queries.SetEndpointSLOBreached(ctx, ep.ID)
+		if err != nil {
+			slog.Error("failed to mark slo breach", "error", err, "endpoint_id", ep.ID)
+			continue
+		}
+		if breached == 0 {
+			// Already alerted for this breach
+			continue
+		}
+
+		slog.Warn("endpoint slo breached",
+			"endpoint_id", ep.ID,
+			"compliance", status.Compliance(),
+			"target", status.Target,
+		)
+
+		s.mu.Lock()
+		callback := s.onSLOBreach
+		s.mu.Unlock()
+
+		if callback != nil {
+			callback(ep, status)
+		}
 	}
+	return nil
+}
 
-	// Delete old failed webhooks (7 days from last attempt)
-	failed, err := s.queries.DeleteFailedWebhooks(ctx)
+// archiveInactive mutes endpoints that received no webhook for ArchiveAfter,
+// so forgotten ingestion URLs stop relaying.
+func (s *Scheduler) archiveInactive(ctx context.Context) error {
+	age := s.Config().ArchiveAfter
+	if age <= 0 {
+		return nil
+	}
+
+	archived, err := s.queries.ArchiveInactiveEndpoints(ctx, seconds(age))
 	if err != nil {
-		slog.Error("failed to delete failed webhooks", "error", err)
-	} else if failed > 0 {
-		slog.Info("deleted old failed webhooks", "count", failed)
+		slog.Error("failed to archive inactive endpoints", "error", err)
+		return err
 	}
 
-	// Delete old dead letter webhooks (14 days)
-	deadLetter, err := s.queries.DeleteDeadLetterWebhooks(ctx)
+	s.mu.Lock()
+	callback := s.onArchive
+	s.mu.Unlock()
+
+	for _, ep := range archived {
+		slog.Info("archived inactive endpoint", "endpoint_id", ep.ID, "last_webhook_received_at", ep.LastWebhookReceivedAt.String)
+		if callback != nil {
+			callback(ep)
+		}
+	}
+	return nil
+}
+
+// runCleanup purges old webhooks per retention policy, exporting them first
+// if an exporter is set, and deletes those purged longer ago than the grace
+// period. It runs every step and returns the first error.
+func (s *Scheduler) runCleanup(ctx context.Context) error {
+	cfg := s.Config()
+	s.mu.Lock()
+	exporter := s.exporter
+	s.mu.Unlock()
+
+	type cleanupStep struct {
+		verb string // purge or delete
+		what string
+		run  func(context.Context, int64) (int64, error)
+		age  time.Duration
+	}
+	steps := []cleanupStep{
+		{"delete", "purged webhooks", s.queries.DeletePurgedWebhooks, cfg.PurgeGrace},
+		{"delete", "old activity events", s.queries.DeleteOldActivityEvents, cfg.ActivityRetention},
+		{"delete", "old connection events", s.queries.DeleteOldConnectionEvents, cfg.ActivityRetention},
+		{"delete", "old audit events", s.queries.DeleteOldAuditEvents, cfg.AuditRetention},
+	}
+	if exporter == nil {
+		steps = append([]cleanupStep{
+			{"purge", "old delivered webhooks", s.queries.PurgeDeliveredWebhooks, cfg.DeliveredRetention},
+			{"purge", "old failed webhooks", s.queries.PurgeFailedWebhooks, cfg.FailedRetention},
+			{"purge", "old dead letter webhooks", s.queries.PurgeDeadLetterWebhooks, cfg.DeadLetterRetention},
+			{"purge", "old skipped webhooks", s.queries.PurgeSkippedWebhooks, cfg.SkippedRetention},
+		}, steps...)
+	}
+
+	var firstErr error
+	if exporter != nil {
+		count, err := s.exportExpired(ctx, cfg, exporter)
+		if err != nil {
+			slog.Error("failed to export old webhooks", "error", err, "purged", count)
+			firstErr = err
+		} else if count > 0 {
+			slog.Info("exported and purged old webhooks", "count", count)
+		}
+	}
+	for _, step := range steps {
+		count, err := step.run(ctx, seconds(step.age))
+		if err != nil {
+			slog.Error("failed to "+step.verb+" "+step.what, "error", err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		} else if count > 0 {
+			slog.Info(step.verb+"d "+step.what, "count", count)
+		}
+	}
+
+	// Delete finished jobs (7 days)
+	jobsDeleted, err := s.queries.DeleteOldJobs(ctx)
 	if err != nil {
-		slog.Error("failed to delete dead letter webhooks", "error", err)
-	} else if deadLetter > 0 {
-		slog.Info("deleted old dead letter webhooks", "count", deadLetter)
+		slog.Error("failed to delete old jobs", "error", err)
+		if firstErr == nil {
+			firstErr = err
+		}
+	} else if jobsDeleted > 0 {
+		slog.Info("deleted old jobs", "count", jobsDeleted)
+	}
+	return firstErr
+}
+
+// exportExpired exports the webhooks past retention to cold storage a batch
+// at a time, purging each batch once it is stored, and returns how many were
+// purged. Webhooks of a batch that failed to export stay for the next run.
+func (s *Scheduler) exportExpired(ctx context.Context, cfg SchedulerConfig, exporter *coldstore.Exporter) (int64, error) {
+	var purged int64
+	for {
+		batch, err := s.queries.ListExpiredWebhooks(ctx, db.ListExpiredWebhooksParams{
+			DeliveredAgeSeconds:  seconds(cfg.DeliveredRetention),
+			FailedAgeSeconds:     seconds(cfg.FailedRetention),
+			DeadLetterAgeSeconds: seconds(cfg.DeadLetterRetention),
+			SkippedAgeSeconds:    seconds(cfg.SkippedRetention),
+			Limit:                exportBatchSize,
+		})
+		if err != nil || len(batch) == 0 {
+			return purged, err
+		}
+
+		records := make([]coldstore.Record, len(batch))
+		for i, wh := range batch {
+			history, err := s.queries.ListWebhookStatusHistory(ctx, wh.ID)
+			if err != nil {
+				return purged, err
+			}
+			records[i] = exportRecord(wh, history)
+		}
+		if err := exporter.Export(ctx, records); err != nil {
+			return purged, err
+		}
+
+		// A webhook replayed since it was listed is kept
+		for _, wh := range batch {
+			n, err := s.queries.PurgeExportedWebhook(ctx, db.PurgeExportedWebhookParams{ID: wh.ID, Status: wh.Status})
+			if err != nil {
+				return purged, err
+			}
+			purged += n
+		}
+		if len(batch) < exportBatchSize {
+			return purged, nil
+		}
 	}
 }
+
+// exportRecord converts a webhook and its status history for export.
+func exportRecord(wh db.Webhook, history []db.WebhookStatusHistory) coldstore.Record {
+	r := coldstore.Record{
+		ID:            wh.ID,
+		EndpointID:    wh.EndpointID,
+		Status:        wh.Status,
+		ReceivedAt:    db.RFC3339(wh.ReceivedAt),
+		EventType:     wh.EventType.String,
+		DeliveryID:    wh.DeliveryID.String,
+		SourceIP:      wh.SourceIp,
+		Attempts:      wh.Attempts,
+		LastAttemptAt: db.RFC3339(wh.LastAttemptAt.String),
+		DeliveredAt:   db.RFC3339(wh.DeliveredAt.String),
+		ErrorMessage:  wh.ErrorMessage.String,
+		ReplayCount:   wh.ReplayCount,
+		TraceID:       tracing.TraceIDFromTraceparent(wh.Traceparent.String),
+		Payload:       wh.Payload,
+	}
+	_ = json.Unmarshal([]byte(wh.Headers), &r.Headers)
+	for _, h := range history {
+		r.History = append(r.History, coldstore.Transition{
+			From:   h.FromStatus.String,
+			To:     h.ToStatus,
+			Reason: h.Reason.String,
+			At:     db.RFC3339(h.ChangedAt),
+			By:     h.ChangedBy.String,
+		})
+	}
+	return r
+}
+
+func seconds(d time.Duration) int64 {
+	return int64(d / time.Second)
+}
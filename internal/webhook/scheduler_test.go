@@ -0,0 +1,362 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"hooks.dx314.com/internal/coldstore"
+	"hooks.dx314.com/internal/db"
+)
+
+func TestSchedulerRunJob(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	queries := db.New(conn)
+
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-1",
+		UserID:         "user-1",
+		Name:           "ep-1",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+	for _, id := range []string{"wh-old", "wh-new"} {
+		if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{
+			ID:         id,
+			EndpointID: "ep-1",
+			Headers:    "{}",
+			Payload:    []byte("{}"),
+		}); err != nil {
+			t.Fatalf("create webhook: %v", err)
+		}
+	}
+	if _, err := conn.Exec(`UPDATE webhooks SET received_at = datetime('now', '-2 hours') WHERE id = 'wh-old'`); err != nil {
+		t.Fatalf("backdate webhook: %v", err)
+	}
+
+	s := NewScheduler(queries)
+	s.SetConfig(SchedulerConfig{DeadLetterAge: time.Hour})
+	if got := s.Config().DeliveredRetention; got != DeliveredRetention {
+		t.Errorf("unset retention = %v, want default %v", got, DeliveredRetention)
+	}
+
+	var deadLettered int64
+	s.SetDeadLetterCallback(func(count int64) { deadLettered = count })
+	if err := s.RunJob(ctx, MaintenanceDeadLetters); err != nil {
+		t.Fatalf("run dead letters: %v", err)
+	}
+	if deadLettered != 1 {
+		t.Errorf("dead lettered %d webhooks, want 1", deadLettered)
+	}
+
+	if err := s.RunJob(ctx, "nope"); !errors.Is(err, ErrUnknownJob) {
+		t.Errorf("unknown job error = %v, want ErrUnknownJob", err)
+	}
+
+	statuses := s.JobStatuses()
+	if len(statuses) != len(MaintenanceJobNames) {
+		t.Fatalf("got %d statuses, want %d", len(statuses), len(MaintenanceJobNames))
+	}
+	for _, st := range statuses {
+		ran := !st.LastRun.IsZero()
+		if want := st.Name == MaintenanceDeadLetters; ran != want {
+			t.Errorf("%s: ran = %v, want %v", st.Name, ran, want)
+		}
+		if st.LastError != "" {
+			t.Errorf("%s: last error %q", st.Name, st.LastError)
+		}
+		if !st.NextRun.IsZero() {
+			t.Errorf("%s: next run set while scheduler stopped", st.Name)
+		}
+	}
+}
+
+func TestSchedulerArchiveInactive(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	queries := db.New(conn)
+
+	for _, ep := range []struct {
+		id       string
+		honeypot int64
+	}{
+		{"ep-idle", 0},
+		{"ep-active", 0},
+		{"ep-honeypot", 1},
+	} {
+		if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+			ID:             ep.id,
+			UserID:         "user-1",
+			Name:           ep.id,
+			ProviderType:   "generic",
+			DestinationUrl: "http://localhost:8080/hook",
+			Honeypot:       ep.honeypot,
+		}); err != nil {
+			t.Fatalf("create endpoint: %v", err)
+		}
+	}
+	if _, err := conn.Exec(`UPDATE endpoints SET created_at = datetime('now', '-10 days')`); err != nil {
+		t.Fatalf("backdate endpoints: %v", err)
+	}
+	if err := queries.MarkEndpointReceived(ctx, "ep-active"); err != nil {
+		t.Fatalf("mark received: %v", err)
+	}
+
+	s := NewScheduler(queries)
+	var archived []string
+	s.SetArchiveCallback(func(ep db.ArchiveInactiveEndpointsRow) { archived = append(archived, ep.ID) })
+
+	// Disabled by default
+	if err := s.RunJob(ctx, MaintenanceArchive); err != nil {
+		t.Fatalf("run archive: %v", err)
+	}
+	if len(archived) != 0 {
+		t.Fatalf("archived %v with archiving disabled", archived)
+	}
+
+	s.SetConfig(SchedulerConfig{ArchiveAfter: 7 * 24 * time.Hour})
+	for range 2 {
+		if err := s.RunJob(ctx, MaintenanceArchive); err != nil {
+			t.Fatalf("run archive: %v", err)
+		}
+	}
+	if len(archived) != 1 || archived[0] != "ep-idle" {
+		t.Fatalf("archived %v, want [ep-idle] once", archived)
+	}
+
+	ep, err := queries.GetEndpoint(ctx, db.GetEndpointParams{ID: "ep-idle", UserID: "user-1"})
+	if err != nil {
+		t.Fatalf("get endpoint: %v", err)
+	}
+	if ep.Muted != 1 || !ep.ArchivedAt.Valid {
+		t.Errorf("muted = %d, archived_at = %v, want muted and archived", ep.Muted, ep.ArchivedAt)
+	}
+
+	// Unmuting clears the archive
+	ep, err = queries.UpdateEndpoint(ctx, db.UpdateEndpointParams{ID: "ep-idle", UserID: "user-1", Muted: sql.NullInt64{Int64: 0, Valid: true}})
+	if err != nil {
+		t.Fatalf("update endpoint: %v", err)
+	}
+	if ep.Muted != 0 || ep.ArchivedAt.Valid {
+		t.Errorf("after unmute: muted = %d, archived_at = %v", ep.Muted, ep.ArchivedAt)
+	}
+}
+
+// failingStore is a cold storage that is down.
+type failingStore struct{}
+
+func (failingStore) Put(context.Context, string, []byte, string) error {
+	return errors.New("bucket unavailable")
+}
+
+func TestSchedulerExportsBeforeCleanup(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	queries := db.New(conn)
+
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-1",
+		UserID:         "user-1",
+		Name:           "ep-1",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+	for _, id := range []string{"wh-delivered", "wh-failed", "wh-recent"} {
+		if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{
+			ID:         id,
+			EndpointID: "ep-1",
+			Headers:    `{"Content-Type":"application/json"}`,
+			Payload:    []byte(`{"id":"` + id + `"}`),
+		}); err != nil {
+			t.Fatalf("create webhook: %v", err)
+		}
+	}
+	for _, stmt := range []string{
+		`UPDATE webhooks SET status = 'delivered', delivered_at = datetime('now', '-10 days') WHERE id = 'wh-delivered'`,
+		`UPDATE webhooks SET status = 'failed', last_attempt_at = datetime('now', '-10 days') WHERE id = 'wh-failed'`,
+		`UPDATE webhooks SET status = 'delivered', delivered_at = datetime('now') WHERE id = 'wh-recent'`,
+	} {
+		if _, err := conn.Exec(stmt); err != nil {
+			t.Fatalf("backdate webhooks: %v", err)
+		}
+	}
+	remaining := func() int {
+		var n int
+		if err := conn.QueryRow(`SELECT COUNT(*) FROM webhooks WHERE purged_at IS NULL`).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	// Nothing is purged while the export fails
+	s := NewScheduler(queries)
+	s.SetExporter(coldstore.NewExporter(failingStore{}, nil))
+	if err := s.RunJob(ctx, MaintenanceCleanup); err == nil {
+		t.Error("cleanup with a failing export: want error")
+	}
+	if n := remaining(); n != 3 {
+		t.Errorf("%d webhooks left after a failed export, want 3", n)
+	}
+
+	dir := t.TempDir()
+	store, err := coldstore.Open(dir, nil)
+	if err != nil {
+		t.Fatalf("open store: %v", err)
+	}
+	s.SetExporter(coldstore.NewExporter(store, nil))
+	if err := s.RunJob(ctx, MaintenanceCleanup); err != nil {
+		t.Fatalf("run cleanup: %v", err)
+	}
+	if n := remaining(); n != 1 {
+		t.Errorf("%d webhooks left, want only the recent one", n)
+	}
+	payloads, _ := filepath.Glob(filepath.Join(dir, "payloads", "*", "*", "*", "*"))
+	indexes, _ := filepath.Glob(filepath.Join(dir, "webhooks", "*", "*", "*", "*.ndjson"))
+	if len(payloads) != 2 || len(indexes) != 1 {
+		t.Errorf("exported payloads %v, indexes %v", payloads, indexes)
+	}
+	if len(indexes) == 1 {
+		if data, _ := os.ReadFile(indexes[0]); len(data) == 0 {
+			t.Error("empty index")
+		}
+	}
+}
+
+func TestSchedulerPurgesBeforeDeleting(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	queries := db.New(conn)
+
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-1",
+		UserID:         "user-1",
+		Name:           "ep-1",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+	if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{ID: "wh-1", EndpointID: "ep-1", Headers: "{}", Payload: []byte(`{}`)}); err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+	if _, err := conn.Exec(`UPDATE webhooks SET status = 'delivered', delivered_at = datetime('now', '-10 days') WHERE id = 'wh-1'`); err != nil {
+		t.Fatalf("backdate webhook: %v", err)
+	}
+	state := func() (rows int, purged bool) {
+		var purgedAt sql.NullString
+		err := conn.QueryRow(`SELECT COUNT(*), MAX(purged_at) FROM webhooks`).Scan(&rows, &purgedAt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return rows, purgedAt.Valid
+	}
+
+	s := NewScheduler(queries)
+	if err := s.RunJob(ctx, MaintenanceCleanup); err != nil {
+		t.Fatalf("run cleanup: %v", err)
+	}
+	if rows, purged := state(); rows != 1 || !purged {
+		t.Fatalf("after cleanup: %d rows, purged %v; want the webhook kept, purged", rows, purged)
+	}
+
+	// Kept for the grace period, then deleted
+	if _, err := conn.Exec(`UPDATE webhooks SET purged_at = datetime('now', '-71 hours')`); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RunJob(ctx, MaintenanceCleanup); err != nil {
+		t.Fatalf("run cleanup: %v", err)
+	}
+	if rows, _ := state(); rows != 1 {
+		t.Errorf("deleted within the grace period: %d rows", rows)
+	}
+	if _, err := conn.Exec(`UPDATE webhooks SET purged_at = datetime('now', '-73 hours')`); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RunJob(ctx, MaintenanceCleanup); err != nil {
+		t.Fatalf("run cleanup: %v", err)
+	}
+	if rows, _ := state(); rows != 0 {
+		t.Errorf("after the grace period: %d rows, want 0", rows)
+	}
+}
+
+func TestSchedulerPurgesSkipped(t *testing.T) {
+	ctx := context.Background()
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	queries := db.New(conn)
+
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-1",
+		UserID:         "user-1",
+		Name:           "ep-1",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+	for _, id := range []string{"wh-old", "wh-recent"} {
+		if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{ID: id, EndpointID: "ep-1", Headers: "{}", Payload: []byte(`{}`), Status: sql.NullString{String: "skipped", Valid: true}}); err != nil {
+			t.Fatalf("create webhook: %v", err)
+		}
+	}
+	if _, err := conn.Exec(`UPDATE webhooks SET received_at = datetime('now', '-8 days') WHERE id = 'wh-old'`); err != nil {
+		t.Fatalf("backdate webhook: %v", err)
+	}
+
+	s := NewScheduler(queries)
+	if err := s.RunJob(ctx, MaintenanceCleanup); err != nil {
+		t.Fatalf("run cleanup: %v", err)
+	}
+	if _, err := conn.Exec(`UPDATE webhooks SET purged_at = datetime('now', '-73 hours') WHERE purged_at IS NOT NULL`); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RunJob(ctx, MaintenanceCleanup); err != nil {
+		t.Fatalf("run cleanup: %v", err)
+	}
+
+	var ids []string
+	rows, err := conn.Query(`SELECT id FROM webhooks`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) != 1 || ids[0] != "wh-recent" {
+		t.Errorf("webhooks left %v, want only wh-recent", ids)
+	}
+}
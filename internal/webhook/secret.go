@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	gonanoid "github.com/matoous/go-nanoid/v2"
+)
+
+const (
+	// secretAlphabet is used for generated secrets. Alphanumeric only, so the
+	// secrets survive copy-paste into any provider settings page.
+	secretAlphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// stripeSecretPrefix is the prefix of Stripe webhook signing secrets.
+	stripeSecretPrefix = "whsec_"
+	// stripeSecretLength is the length of a Stripe secret after the prefix.
+	stripeSecretLength = 32
+	// telegramSecretLength is the length of generated Telegram secret tokens.
+	telegramSecretLength = 48
+	// defaultSecretLength gives about 256 bits of entropy.
+	defaultSecretLength = 43
+)
+
+// GenerateSecret returns a cryptographically strong signature secret in the
+// format the provider expects. Stripe secrets carry the whsec_ prefix and
+// Telegram secrets only use characters allowed in a secret_token.
+func GenerateSecret(providerType string) (string, error) {
+	switch providerType {
+	case "stripe":
+		s, err := gonanoid.Generate(secretAlphabet, stripeSecretLength)
+		if err != nil {
+			return "", err
+		}
+		return stripeSecretPrefix + s, nil
+	case "telegram":
+		// The default nanoid alphabet (A-Z, a-z, 0-9, _ and -) is exactly
+		// what Telegram accepts.
+		return gonanoid.New(telegramSecretLength)
+	default:
+		return gonanoid.Generate(secretAlphabet, defaultSecretLength)
+	}
+}
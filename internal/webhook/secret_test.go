@@ -0,0 +1,40 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateSecret(t *testing.T) {
+	tests := []struct {
+		provider string
+		prefix   string
+		length   int
+	}{
+		{"stripe", "whsec_", 38},
+		{"telegram", "", 48},
+		{"github", "", 43},
+		{"generic", "", 43},
+		{"custom", "", 43},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.provider, func(t *testing.T) {
+			secret, err := GenerateSecret(tt.provider)
+			if err != nil {
+				t.Fatalf("GenerateSecret: %v", err)
+			}
+			if !strings.HasPrefix(secret, tt.prefix) || len(secret) != tt.length {
+				t.Errorf("GenerateSecret(%q) = %q, want prefix %q and length %d", tt.provider, secret, tt.prefix, tt.length)
+			}
+			if tt.provider == "telegram" && !ValidTelegramSecretToken(secret) {
+				t.Errorf("%q is not a valid telegram secret token", secret)
+			}
+
+			other, _ := GenerateSecret(tt.provider)
+			if other == secret {
+				t.Error("generated secrets should differ")
+			}
+		})
+	}
+}
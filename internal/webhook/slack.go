@@ -0,0 +1,39 @@
+package webhook
+
+import (
+	"encoding/json"
+)
+
+// slackEvent is the part of a Slack Events API request hookly reads.
+type slackEvent struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Event     struct {
+		Type string `json:"type"`
+	} `json:"event"`
+}
+
+// slackEventType returns the type of a Slack event: the inner event's type
+// for an event_callback (e.g. app_mention), otherwise the request type.
+// Slash commands and interactivity are form-encoded and have none.
+func slackEventType(payload []byte) string {
+	var ev slackEvent
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		return ""
+	}
+	if ev.Type == "event_callback" && ev.Event.Type != "" {
+		return ev.Event.Type
+	}
+	return ev.Type
+}
+
+// slackChallenge returns the challenge of a Slack url_verification request,
+// or "" for any other request. Slack only enables the Events API URL once
+// the challenge is echoed back, which a relayed webhook can't do in time.
+func slackChallenge(payload []byte) string {
+	var ev slackEvent
+	if err := json.Unmarshal(payload, &ev); err != nil || ev.Type != "url_verification" {
+		return ""
+	}
+	return ev.Challenge
+}
@@ -0,0 +1,29 @@
+package webhook
+
+import "testing"
+
+func TestSlackEventType(t *testing.T) {
+	tests := []struct {
+		payload string
+		want    string
+	}{
+		{`{"type":"event_callback","event_id":"Ev1","event":{"type":"app_mention"}}`, "app_mention"},
+		{`{"type":"url_verification","challenge":"abc"}`, "url_verification"},
+		{`{"type":"event_callback"}`, "event_callback"},
+		{"command=%2Fdeploy&text=prod", ""},
+	}
+	for _, tt := range tests {
+		if got := slackEventType([]byte(tt.payload)); got != tt.want {
+			t.Errorf("slackEventType(%s) = %q, want %q", tt.payload, got, tt.want)
+		}
+	}
+}
+
+func TestSlackChallenge(t *testing.T) {
+	if got := slackChallenge([]byte(`{"token":"t","challenge":"3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P","type":"url_verification"}`)); got != "3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P" {
+		t.Errorf("challenge = %q", got)
+	}
+	if got := slackChallenge([]byte(`{"type":"event_callback","challenge":"x"}`)); got != "" {
+		t.Errorf("event callback answered as a challenge: %q", got)
+	}
+}
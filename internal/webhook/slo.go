@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"hooks.dx314.com/internal/db"
+)
+
+// SLOStatus is an endpoint's compliance with its delivery SLO: Target percent
+// of webhooks delivered within Latency over the last Window.
+type SLOStatus struct {
+	Target  float64
+	Latency time.Duration
+	Window  time.Duration
+	// Total is the number of webhooks counted in the window, Met how many of
+	// them were delivered within Latency.
+	Total int64
+	Met   int64
+}
+
+// Compliance returns the percent of webhooks that met the latency target.
+// An endpoint with no webhooks in the window is fully compliant.
+func (s SLOStatus) Compliance() float64 {
+	if s.Total == 0 {
+		return 100
+	}
+	return float64(s.Met) / float64(s.Total) * 100
+}
+
+// Breached reports whether compliance is below the target.
+func (s SLOStatus) Breached() bool {
+	return s.Total > 0 && s.Compliance() < s.Target
+}
+
+// ComputeSLO computes an endpoint's SLO compliance from its webhooks.
+func ComputeSLO(ctx context.Context, queries *db.Queries, endpointID string, target float64, latencySeconds, windowHours int64) (SLOStatus, error) {
+	stats, err := queries.GetEndpointSLOStats(ctx, db.GetEndpointSLOStatsParams{
+		LatencySeconds: latencySeconds,
+		EndpointID:     endpointID,
+		WindowHours:    windowHours,
+	})
+	if err != nil {
+		return SLOStatus{}, err
+	}
+	return SLOStatus{
+		Target:  target,
+		Latency: time.Duration(latencySeconds) * time.Second,
+		Window:  time.Duration(windowHours) * time.Hour,
+		Total:   stats.Total,
+		Met:     stats.Met,
+	}, nil
+}
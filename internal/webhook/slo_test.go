@@ -0,0 +1,28 @@
+package webhook
+
+import "testing"
+
+func TestSLOStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     SLOStatus
+		compliance float64
+		breached   bool
+	}{
+		{"no webhooks", SLOStatus{Target: 99}, 100, false},
+		{"all met", SLOStatus{Target: 99, Total: 10, Met: 10}, 100, false},
+		{"at target", SLOStatus{Target: 90, Total: 10, Met: 9}, 90, false},
+		{"below target", SLOStatus{Target: 99, Total: 10, Met: 9}, 90, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.status.Compliance(); got != tt.compliance {
+				t.Errorf("Compliance() = %v, want %v", got, tt.compliance)
+			}
+			if got := tt.status.Breached(); got != tt.breached {
+				t.Errorf("Breached() = %v, want %v", got, tt.breached)
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package webhook
+
+import (
+	"slices"
+	"strings"
+)
+
+// Webhook statuses, as stored in the webhooks table.
+const (
+	StatusPending    = "pending"
+	StatusDelivered  = "delivered"
+	StatusFailed     = "failed"
+	StatusDeadLetter = "dead_letter"
+	StatusSkipped    = "skipped"
+	// StatusAcknowledgedDuplicate is a webhook the destination answered with
+	// 409 Conflict, having processed it before, on an endpoint that opted in.
+	StatusAcknowledgedDuplicate = "acknowledged_duplicate"
+)
+
+// statusTransitions is the webhook state machine: the statuses each status
+// may change to. Webhooks are stored as pending, or as skipped if they are
+// never relayed, and any finished webhook can be replayed back to pending.
+// A trigger on the webhooks table enforces the same transitions (migrations
+// 024 and 027), so a query making any other change fails.
+var statusTransitions = map[string][]string{
+	StatusPending:               {StatusDelivered, StatusFailed, StatusSkipped, StatusDeadLetter, StatusAcknowledgedDuplicate},
+	StatusDelivered:             {StatusPending},
+	StatusFailed:                {StatusPending},
+	StatusDeadLetter:            {StatusPending},
+	StatusSkipped:               {StatusPending},
+	StatusAcknowledgedDuplicate: {StatusPending},
+}
+
+// CanTransition reports whether a webhook may change from one status to
+// another. Keeping the same status is always allowed.
+func CanTransition(from, to string) bool {
+	return from == to || slices.Contains(statusTransitions[from], to)
+}
+
+// IsInvalidTransition reports whether err is the database rejecting a status
+// change the state machine doesn't allow.
+func IsInvalidTransition(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "invalid webhook status transition")
+}
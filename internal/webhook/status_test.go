@@ -0,0 +1,179 @@
+package webhook
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"hooks.dx314.com/internal/db"
+)
+
+func setupStatusTest(t *testing.T) (*sql.DB, *db.Queries) {
+	t.Helper()
+	ctx := context.Background()
+
+	conn, err := db.Open(ctx, filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	queries := db.New(conn)
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-1",
+		UserID:         "user-1",
+		Name:           "ep-1",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+	return conn, queries
+}
+
+// The trigger on the webhooks table must agree with CanTransition.
+func TestStatusTransitionsEnforced(t *testing.T) {
+	ctx := context.Background()
+	conn, queries := setupStatusTest(t)
+
+	statuses := []string{StatusPending, StatusDelivered, StatusFailed, StatusDeadLetter, StatusSkipped, StatusAcknowledgedDuplicate}
+	for _, from := range statuses {
+		for _, to := range statuses {
+			id := fmt.Sprintf("wh-%s-%s", from, to)
+			if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{
+				ID:         id,
+				EndpointID: "ep-1",
+				Headers:    "{}",
+				Payload:    []byte("{}"),
+				Status:     sql.NullString{String: from, Valid: true},
+			}); err != nil {
+				t.Fatalf("create webhook: %v", err)
+			}
+
+			_, err := conn.Exec(`UPDATE webhooks SET status = ? WHERE id = ?`, to, id)
+			if allowed := CanTransition(from, to); allowed != (err == nil) {
+				t.Errorf("%s -> %s: allowed = %v, update error = %v", from, to, allowed, err)
+			}
+			if err != nil && !IsInvalidTransition(err) {
+				t.Errorf("%s -> %s: error %v is not an invalid transition", from, to, err)
+			}
+		}
+	}
+}
+
+func TestStatusHistory(t *testing.T) {
+	ctx := context.Background()
+	_, queries := setupStatusTest(t)
+
+	if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{
+		ID:         "wh-1",
+		EndpointID: "ep-1",
+		Headers:    "{}",
+		Payload:    []byte("{}"),
+	}); err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+	steps := []func() error{
+		func() error {
+			// Retries keep the webhook pending and aren't status changes
+			_, err := queries.RecordWebhookAttempt(ctx, db.RecordWebhookAttemptParams{ErrorMessage: sql.NullString{String: "502", Valid: true}, ID: "wh-1"})
+			return err
+		},
+		func() error {
+			_, err := queries.MarkWebhookFailed(ctx, db.MarkWebhookFailedParams{ErrorMessage: sql.NullString{String: "410 gone", Valid: true}, ID: "wh-1"})
+			return err
+		},
+		func() error {
+			_, err := queries.ResetWebhookForReplay(ctx, db.ResetWebhookForReplayParams{ReplayedBy: sql.NullString{String: "alice", Valid: true}, ID: "wh-1", UserID: "user-1"})
+			return err
+		},
+		func() error {
+			_, err := queries.MarkWebhookDelivered(ctx, "wh-1")
+			return err
+		},
+	}
+	for i, step := range steps {
+		if err := step(); err != nil {
+			t.Fatalf("step %d: %v", i, err)
+		}
+	}
+
+	// A late failure ack for a delivered webhook is rejected
+	if _, err := queries.MarkWebhookFailed(ctx, db.MarkWebhookFailedParams{ID: "wh-1"}); !IsInvalidTransition(err) {
+		t.Errorf("failing a delivered webhook: error = %v, want invalid transition", err)
+	}
+
+	history, err := queries.ListWebhookStatusHistory(ctx, "wh-1")
+	if err != nil {
+		t.Fatalf("list history: %v", err)
+	}
+	var got []string
+	for _, h := range history {
+		got = append(got, fmt.Sprintf("%s>%s:%s:%s", h.FromStatus.String, h.ToStatus, h.Reason.String, h.ChangedBy.String))
+	}
+	want := []string{
+		">pending::",
+		"pending>failed:410 gone:",
+		"failed>pending:replayed:alice",
+		"pending>delivered::",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("history = %q, want %q", got, want)
+	}
+	if history[0].FromStatus.Valid {
+		t.Error("first entry has a from status")
+	}
+}
+
+func TestListStatusChangesAfter(t *testing.T) {
+	ctx := context.Background()
+	_, queries := setupStatusTest(t)
+	if _, err := queries.CreateEndpoint(ctx, db.CreateEndpointParams{
+		ID:             "ep-other",
+		UserID:         "user-2",
+		Name:           "ep-other",
+		ProviderType:   "generic",
+		DestinationUrl: "http://localhost:8080/hook",
+	}); err != nil {
+		t.Fatalf("create endpoint: %v", err)
+	}
+
+	create := func(id, endpointID string) {
+		t.Helper()
+		if _, err := queries.CreateWebhook(ctx, db.CreateWebhookParams{ID: id, EndpointID: endpointID, Headers: "{}", Payload: []byte("{}")}); err != nil {
+			t.Fatalf("create webhook: %v", err)
+		}
+	}
+	create("wh-old", "ep-1")
+	start, err := queries.GetLastStatusChangeID(ctx)
+	if err != nil || start == 0 {
+		t.Fatalf("GetLastStatusChangeID() = %d, %v", start, err)
+	}
+
+	create("wh-1", "ep-1")
+	create("wh-other", "ep-other")
+	if _, err := queries.MarkWebhookDelivered(ctx, "wh-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	changes, err := queries.ListStatusChangesAfter(ctx, db.ListStatusChangesAfterParams{AfterID: start, UserID: "user-1", Limit: 10})
+	if err != nil {
+		t.Fatalf("list changes: %v", err)
+	}
+	var got []string
+	for _, ch := range changes {
+		got = append(got, ch.WebhookID+":"+ch.ToStatus)
+	}
+	// Only changes after the start, and not another user's
+	if want := []string{"wh-1:pending", "wh-1:delivered"}; !slices.Equal(got, want) {
+		t.Errorf("changes = %q, want %q", got, want)
+	}
+
+	changes, err = queries.ListStatusChangesAfter(ctx, db.ListStatusChangesAfterParams{AfterID: changes[0].ID, UserID: "user-1", EndpointID: "ep-other", Limit: 10})
+	if err != nil || len(changes) != 0 {
+		t.Errorf("another user's endpoint: %d changes, %v", len(changes), err)
+	}
+}
@@ -0,0 +1,142 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const telegramAPIURL = "https://api.telegram.org"
+
+// TelegramAPIError is returned when the Telegram Bot API rejects a request.
+type TelegramAPIError struct {
+	Method      string
+	Description string
+}
+
+func (e *TelegramAPIError) Error() string {
+	return fmt.Sprintf("telegram %s: %s", e.Method, e.Description)
+}
+
+// TelegramWebhookInfo is the result of Telegram's getWebhookInfo.
+type TelegramWebhookInfo struct {
+	URL                string `json:"url"`
+	PendingUpdateCount int    `json:"pending_update_count"`
+	LastErrorDate      int64  `json:"last_error_date,omitempty"`
+	LastErrorMessage   string `json:"last_error_message,omitempty"`
+}
+
+// LastErrorAt returns the time of the last delivery error, or the zero time.
+func (i *TelegramWebhookInfo) LastErrorAt() time.Time {
+	if i.LastErrorDate == 0 {
+		return time.Time{}
+	}
+	return time.Unix(i.LastErrorDate, 0).UTC()
+}
+
+// TelegramClient registers telegram endpoints with the Telegram Bot API.
+type TelegramClient struct {
+	baseURL string
+	client  *http.Client
+}
+
+// NewTelegramClient creates a new Telegram Bot API client.
+func NewTelegramClient() *TelegramClient {
+	return &TelegramClient{
+		baseURL: telegramAPIURL,
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// SetWebhook points the bot's webhook at url. Telegram sends secretToken in
+// the X-Telegram-Bot-Api-Secret-Token header of every update.
+func (c *TelegramClient) SetWebhook(ctx context.Context, botToken, url, secretToken string) error {
+	params := map[string]string{"url": url}
+	if secretToken != "" {
+		params["secret_token"] = secretToken
+	}
+	return c.call(ctx, botToken, "setWebhook", params, nil)
+}
+
+// GetWebhookInfo returns the bot's current webhook registration.
+func (c *TelegramClient) GetWebhookInfo(ctx context.Context, botToken string) (*TelegramWebhookInfo, error) {
+	var info TelegramWebhookInfo
+	if err := c.call(ctx, botToken, "getWebhookInfo", nil, &info); err != nil {
+		return nil, err
+	}
+	return &info, nil
+}
+
+type telegramResponse struct {
+	OK          bool            `json:"ok"`
+	Description string          `json:"description,omitempty"`
+	Result      json.RawMessage `json:"result,omitempty"`
+}
+
+func (c *TelegramClient) call(ctx context.Context, botToken, method string, params map[string]string, result any) error {
+	if botToken == "" {
+		return errors.New("bot token is required")
+	}
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/bot%s/%s", c.baseURL, botToken, method)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %s", redactToken(err, botToken))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		// The request URL contains the bot token, keep it out of the error
+		return fmt.Errorf("send request: %s", redactToken(err, botToken))
+	}
+	defer resp.Body.Close()
+
+	var tr telegramResponse
+	if err := json.NewDecoder(resp.Body).Decode(&tr); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	if !tr.OK {
+		return &TelegramAPIError{Method: method, Description: tr.Description}
+	}
+
+	if result != nil {
+		if err := json.Unmarshal(tr.Result, result); err != nil {
+			return fmt.Errorf("decode result: %w", err)
+		}
+	}
+	return nil
+}
+
+// redactToken returns the error message with the bot token removed.
+func redactToken(err error, botToken string) string {
+	return strings.ReplaceAll(err.Error(), botToken, "<bot-token>")
+}
+
+// ValidTelegramSecretToken reports whether s can be used as a Telegram
+// secret_token (1-256 characters of A-Z, a-z, 0-9, _ and -).
+func ValidTelegramSecretToken(s string) bool {
+	if len(s) == 0 || len(s) > 256 {
+		return false
+	}
+	for _, r := range s {
+		switch {
+		case r >= 'A' && r <= 'Z', r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,98 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestTelegramClient(t *testing.T) {
+	const botToken = "123456:test-token"
+
+	var registered map[string]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bot" + botToken + "/setWebhook":
+			json.NewDecoder(r.Body).Decode(&registered)
+			w.Write([]byte(`{"ok":true,"result":true}`))
+		case "/bot" + botToken + "/getWebhookInfo":
+			json.NewEncoder(w).Encode(map[string]any{
+				"ok": true,
+				"result": map[string]any{
+					"url":                  registered["url"],
+					"pending_update_count": 2,
+					"last_error_date":      1700000000,
+					"last_error_message":   "Wrong response from the webhook: 502 Bad Gateway",
+				},
+			})
+		default:
+			w.Write([]byte(`{"ok":false,"description":"Unauthorized"}`))
+		}
+	}))
+	defer srv.Close()
+
+	c := NewTelegramClient()
+	c.baseURL = srv.URL
+	ctx := context.Background()
+
+	if err := c.SetWebhook(ctx, botToken, "https://hooks.example.com/h/ep_tg", "secret_token-1"); err != nil {
+		t.Fatalf("SetWebhook: %v", err)
+	}
+	if registered["url"] != "https://hooks.example.com/h/ep_tg" || registered["secret_token"] != "secret_token-1" {
+		t.Errorf("unexpected setWebhook params: %v", registered)
+	}
+
+	info, err := c.GetWebhookInfo(ctx, botToken)
+	if err != nil {
+		t.Fatalf("GetWebhookInfo: %v", err)
+	}
+	if info.URL != "https://hooks.example.com/h/ep_tg" || info.PendingUpdateCount != 2 {
+		t.Errorf("unexpected webhook info: %+v", info)
+	}
+	if info.LastErrorAt().Unix() != 1700000000 {
+		t.Errorf("LastErrorAt: got %v", info.LastErrorAt())
+	}
+
+	_, err = c.GetWebhookInfo(ctx, "bad:token")
+	var apiErr *TelegramAPIError
+	if !errors.As(err, &apiErr) || apiErr.Description != "Unauthorized" {
+		t.Errorf("expected TelegramAPIError, got %v", err)
+	}
+}
+
+func TestTelegramClientRedactsToken(t *testing.T) {
+	const botToken = "123456:secret-bot-token"
+
+	c := NewTelegramClient()
+	c.baseURL = "http://127.0.0.1:1"
+
+	err := c.SetWebhook(context.Background(), botToken, "https://hooks.example.com/h/ep_tg", "")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if strings.Contains(err.Error(), botToken) {
+		t.Errorf("error leaks bot token: %v", err)
+	}
+}
+
+func TestValidTelegramSecretToken(t *testing.T) {
+	tests := []struct {
+		token string
+		want  bool
+	}{
+		{"abc_DEF-123", true},
+		{"", false},
+		{"has space", false},
+		{"whsec+abc", false},
+		{strings.Repeat("a", 257), false},
+	}
+	for _, tt := range tests {
+		if got := ValidTelegramSecretToken(tt.token); got != tt.want {
+			t.Errorf("ValidTelegramSecretToken(%q) = %v, want %v", tt.token, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"strings"
+	"text/template"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+// Bounds of a transform, checked by Validate.
+const (
+	MaxTransformTemplate = 16 << 10 // Template length in bytes
+	MaxTransformHeaders  = 32
+)
+
+// Transform rewrites a webhook before the hub forwards it. Extract replaces
+// the body with the JSON value at a path, then Template replaces it with the
+// rendered template, then Headers are set over the received headers. The
+// zero value forwards webhooks unchanged.
+//
+// Templates are Go text/template, executed with the body decoded as JSON
+// (.Body, nil if it isn't JSON), the body as text (.Raw) and the received
+// headers (.Headers). A missing field fails the webhook; use index for
+// optional ones. The json function renders a value as JSON.
+type Transform struct {
+	Extract  string            `json:"extract,omitempty"`
+	Template string            `json:"template,omitempty"`
+	Headers  map[string]string `json:"headers,omitempty"`
+}
+
+// transformData is what a transform template is executed with.
+type transformData struct {
+	Body    any
+	Raw     string
+	Headers map[string]string
+}
+
+var transformFuncs = template.FuncMap{
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// IsZero reports whether the transform changes nothing.
+func (t *Transform) IsZero() bool {
+	return t == nil || (t.Extract == "" && t.Template == "" && len(t.Headers) == 0)
+}
+
+// Validate checks that the path and template parse and that the headers can
+// be sent. Headers the forwarder drops or sets itself are rejected rather
+// than ignored.
+func (t *Transform) Validate() error {
+	if t.Extract != "" {
+		if _, err := parseJSONPath(t.Extract); err != nil {
+			return fmt.Errorf("extract: %w", err)
+		}
+	}
+	if len(t.Template) > MaxTransformTemplate {
+		return fmt.Errorf("template is longer than %d bytes", MaxTransformTemplate)
+	}
+	if _, err := t.parseTemplate(); err != nil {
+		return err
+	}
+	if len(t.Headers) > MaxTransformHeaders {
+		return fmt.Errorf("more than %d headers", MaxTransformHeaders)
+	}
+	for name, value := range t.Headers {
+		if !httpguts.ValidHeaderFieldName(name) {
+			return fmt.Errorf("invalid header name %q", name)
+		}
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return fmt.Errorf("invalid value for header %s", name)
+		}
+		if !shouldForwardHeader(name) || strings.HasPrefix(strings.ToLower(name), "x-hookly-") {
+			return fmt.Errorf("header %s can't be set", name)
+		}
+	}
+	return nil
+}
+
+func (t *Transform) parseTemplate() (*template.Template, error) {
+	if t.Template == "" {
+		return nil, nil
+	}
+	tmpl, err := template.New("body").Funcs(transformFuncs).Option("missingkey=error").Parse(t.Template)
+	if err != nil {
+		return nil, fmt.Errorf("template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// Apply returns the headers and payload to forward. The inputs aren't
+// modified. An error means the webhook can't be transformed, which retrying
+// won't change.
+func (t *Transform) Apply(headers map[string]string, payload []byte) (map[string]string, []byte, error) {
+	if t.IsZero() {
+		return headers, payload, nil
+	}
+
+	if t.Extract != "" {
+		value, err := ExtractJSONPath(payload, t.Extract)
+		if err != nil {
+			return nil, nil, fmt.Errorf("transform extract: %w", err)
+		}
+		payload = value
+	}
+
+	tmpl, err := t.parseTemplate()
+	if err != nil {
+		return nil, nil, fmt.Errorf("transform %w", err)
+	}
+	if tmpl != nil {
+		data := transformData{Raw: string(payload), Headers: headers}
+		if json.Valid(payload) {
+			_ = json.Unmarshal(payload, &data.Body)
+		}
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, nil, fmt.Errorf("transform %w", err)
+		}
+		payload = buf.Bytes()
+	}
+
+	out := maps.Clone(headers)
+	if out == nil {
+		out = make(map[string]string, len(t.Headers))
+	}
+	for name, value := range t.Headers {
+		// Received header names keep the provider's case
+		for received := range out {
+			if strings.EqualFold(received, name) {
+				delete(out, received)
+			}
+		}
+		out[name] = value
+	}
+	return out, payload, nil
+}
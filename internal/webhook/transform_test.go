@@ -0,0 +1,133 @@
+package webhook
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTransformApply(t *testing.T) {
+	payload := []byte(`{"type": "invoice.paid", "data": {"object": {"id": "in_1", "amount": 250}}}`)
+	headers := map[string]string{"Content-Type": "application/json", "X-Source": "stripe"}
+
+	tests := []struct {
+		name        string
+		transform   Transform
+		wantBody    string
+		wantHeaders map[string]string
+	}{
+		{
+			name:        "zero value",
+			wantBody:    string(payload),
+			wantHeaders: headers,
+		},
+		{
+			name:        "extract",
+			transform:   Transform{Extract: "data.object"},
+			wantBody:    `{"id": "in_1", "amount": 250}`,
+			wantHeaders: headers,
+		},
+		{
+			name:        "template",
+			transform:   Transform{Template: `{"text": "{{.Body.type}} {{.Body.data.object.id}} from {{index .Headers "X-Source"}}"}`},
+			wantBody:    `{"text": "invoice.paid in_1 from stripe"}`,
+			wantHeaders: headers,
+		},
+		{
+			name:        "template after extract",
+			transform:   Transform{Extract: "/data/object", Template: `{"invoice": {{json .Body.id}}, "missing": {{json (index .Body "due")}}}`},
+			wantBody:    `{"invoice": "in_1", "missing": null}`,
+			wantHeaders: headers,
+		},
+		{
+			name:      "headers",
+			transform: Transform{Headers: map[string]string{"content-type": "text/plain", "X-Env": "staging"}},
+			wantBody:  string(payload),
+			wantHeaders: map[string]string{
+				"content-type": "text/plain",
+				"X-Source":     "stripe",
+				"X-Env":        "staging",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotHeaders, gotBody, err := tt.transform.Apply(headers, payload)
+			if err != nil {
+				t.Fatalf("Apply: %v", err)
+			}
+			if string(gotBody) != tt.wantBody {
+				t.Errorf("body = %s, want %s", gotBody, tt.wantBody)
+			}
+			if len(gotHeaders) != len(tt.wantHeaders) {
+				t.Errorf("headers = %v, want %v", gotHeaders, tt.wantHeaders)
+			}
+			for name, want := range tt.wantHeaders {
+				if gotHeaders[name] != want {
+					t.Errorf("header %s = %q, want %q", name, gotHeaders[name], want)
+				}
+			}
+		})
+	}
+
+	if headers["X-Env"] != "" || len(headers) != 2 {
+		t.Errorf("Apply modified the received headers: %v", headers)
+	}
+}
+
+func TestTransformApplyErrors(t *testing.T) {
+	payload := []byte(`{"type": "invoice.paid"}`)
+
+	_, _, err := (&Transform{Extract: "data.object"}).Apply(nil, payload)
+	var notFound *PathNotFoundError
+	if !errors.As(err, &notFound) {
+		t.Errorf("extracting a missing path: err = %v, want PathNotFoundError", err)
+	}
+
+	if _, _, err := (&Transform{Extract: "type"}).Apply(nil, []byte("a=b")); !errors.Is(err, ErrPayloadNotJSON) {
+		t.Errorf("extracting from a form body: err = %v, want ErrPayloadNotJSON", err)
+	}
+
+	if _, _, err := (&Transform{Template: "{{.Body.data.id}}"}).Apply(nil, payload); err == nil {
+		t.Error("template with a missing field: want error")
+	}
+
+	// Non-JSON bodies are only available as .Raw
+	_, body, err := (&Transform{Template: "{{.Body}}|{{.Raw}}"}).Apply(nil, []byte("a=b"))
+	if err != nil || string(body) != "<no value>|a=b" {
+		t.Errorf("template over a form body = %q, %v", body, err)
+	}
+}
+
+func TestTransformValidate(t *testing.T) {
+	valid := []Transform{
+		{},
+		{Extract: "/data/object"},
+		{Template: `{"id": {{json .Body.id}}}`},
+		{Headers: map[string]string{"Content-Type": "text/plain", "X-Env": "prod"}},
+	}
+	for _, tr := range valid {
+		if err := tr.Validate(); err != nil {
+			t.Errorf("Validate(%+v): %v", tr, err)
+		}
+	}
+
+	invalid := []struct {
+		transform Transform
+		want      string
+	}{
+		{Transform{Extract: "data..id"}, "extract"},
+		{Transform{Template: "{{.Body"}, "template"},
+		{Transform{Template: strings.Repeat("a", MaxTransformTemplate+1)}, "longer"},
+		{Transform{Headers: map[string]string{"Bad Name": "x"}}, "invalid header name"},
+		{Transform{Headers: map[string]string{"X-Env": "a\nb"}}, "invalid value"},
+		{Transform{Headers: map[string]string{"Host": "example.com"}}, "can't be set"},
+		{Transform{Headers: map[string]string{"X-Hookly-Attempt": "1"}}, "can't be set"},
+	}
+	for _, tt := range invalid {
+		err := tt.transform.Validate()
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("Validate(%+v) = %v, want error containing %q", tt.transform, err, tt.want)
+		}
+	}
+}
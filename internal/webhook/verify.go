@@ -6,12 +6,14 @@ import (
 	"crypto/sha1"
 	"crypto/sha256"
 	"crypto/subtle"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strconv"
 	"strings"
-	"time"
+
+	"hooks.dx314.com/internal/clock"
 )
 
 // Verifier verifies webhook signatures.
@@ -31,6 +33,14 @@ func NewVerifier(providerType string) Verifier {
 		return &GitHubVerifier{}
 	case "telegram":
 		return &TelegramVerifier{}
+	case "slack":
+		return &SlackVerifier{}
+	case "shopify":
+		return &ShopifyVerifier{}
+	case "square":
+		return &SquareVerifier{}
+	case "paypal":
+		return &PayPalVerifier{}
 	case "generic":
 		return &GenericVerifier{}
 	case "custom":
@@ -43,7 +53,9 @@ func NewVerifier(providerType string) Verifier {
 
 // StripeVerifier verifies Stripe webhook signatures.
 // Format: Stripe-Signature: t=1492774577,v1=5257a869...
-type StripeVerifier struct{}
+type StripeVerifier struct {
+	Clock clock.Clock // Checks the timestamp tolerance; nil means the system clock
+}
 
 func (v *StripeVerifier) Verify(payload []byte, headers map[string]string, secret string) bool {
 	sig := getHeader(headers, "Stripe-Signature")
@@ -81,7 +93,7 @@ func (v *StripeVerifier) Verify(payload []byte, headers map[string]string, secre
 	if err != nil {
 		return false
 	}
-	if time.Now().Unix()-ts > 300 { // 5 minutes
+	if !withinTolerance(clock.Or(v.Clock).Now().Unix(), ts, 300) { // 5 minutes
 		return false
 	}
 
@@ -142,6 +154,90 @@ func (v *TelegramVerifier) Verify(payload []byte, headers map[string]string, sec
 	return subtle.ConstantTimeCompare([]byte(token), []byte(secret)) == 1
 }
 
+// SlackVerifier verifies Slack request signatures.
+// Format: X-Slack-Signature: v0=hmac of v0:timestamp:body, with the timestamp
+// in X-Slack-Request-Timestamp
+type SlackVerifier struct {
+	Clock clock.Clock // Checks the timestamp tolerance; nil means the system clock
+}
+
+func (v *SlackVerifier) Verify(payload []byte, headers map[string]string, secret string) bool {
+	sig := getHeader(headers, "X-Slack-Signature")
+	timestamp := getHeader(headers, "X-Slack-Request-Timestamp")
+	if sig == "" || timestamp == "" {
+		return false
+	}
+
+	if !strings.HasPrefix(sig, "v0=") {
+		return false
+	}
+	sigBytes, err := hex.DecodeString(strings.TrimPrefix(sig, "v0="))
+	if err != nil {
+		return false
+	}
+
+	// Slack rejects requests older than 5 minutes; so do we
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	if !withinTolerance(clock.Or(v.Clock).Now().Unix(), ts, 300) {
+		return false
+	}
+
+	expected := computeHMACSHA256([]byte("v0:"+timestamp+":"+string(payload)), []byte(secret))
+	return subtle.ConstantTimeCompare(expected, sigBytes) == 1
+}
+
+// ShopifyVerifier verifies Shopify webhook signatures.
+// Format: X-Shopify-Hmac-Sha256: base64 hmac of the body
+type ShopifyVerifier struct{}
+
+func (v *ShopifyVerifier) Verify(payload []byte, headers map[string]string, secret string) bool {
+	sig := getHeader(headers, "X-Shopify-Hmac-Sha256")
+	if sig == "" {
+		return false
+	}
+
+	sigBytes, err := base64.StdEncoding.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+
+	expected := computeHMACSHA256(payload, []byte(secret))
+	return subtle.ConstantTimeCompare(expected, sigBytes) == 1
+}
+
+// SquareVerifier verifies Square webhook signatures.
+// Format: X-Square-Hmacsha256-Signature: base64 hmac of the notification URL
+// followed by the body
+type SquareVerifier struct {
+	// NotificationURLs are the URLs the webhook may have been sent to, as
+	// registered in Square. Proxies and tunnels can change the URL the edge
+	// sees, so any of them is accepted.
+	NotificationURLs []string
+}
+
+func (v *SquareVerifier) Verify(payload []byte, headers map[string]string, secret string) bool {
+	sig := getHeader(headers, "X-Square-Hmacsha256-Signature")
+	if sig == "" {
+		return false
+	}
+
+	sigBytes, err := base64.StdEncoding.DecodeString(sig)
+	if err != nil {
+		return false
+	}
+
+	for _, u := range v.NotificationURLs {
+		expected := computeHMACSHA256([]byte(u+string(payload)), []byte(secret))
+		if subtle.ConstantTimeCompare(expected, sigBytes) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
 // GenericVerifier verifies generic webhook signatures.
 // Format: X-Webhook-Signature: sha256=...
 type GenericVerifier struct{}
@@ -220,12 +316,16 @@ func ParseVerificationConfig(data []byte) (*VerificationConfig, error) {
 	if cfg.Method == MethodTimestampedHMAC && cfg.TimestampHeader == "" {
 		return nil, fmt.Errorf("timestamp_header is required for timestamped_hmac method")
 	}
+	if cfg.TimestampTolerance < 0 {
+		return nil, fmt.Errorf("timestamp_tolerance must not be negative")
+	}
 	return &cfg, nil
 }
 
 // CustomVerifier verifies webhooks using custom configuration.
 type CustomVerifier struct {
 	Config *VerificationConfig
+	Clock  clock.Clock // Checks the timestamp tolerance; nil means the system clock
 }
 
 // NewCustomVerifier creates a verifier with the given config.
@@ -284,7 +384,7 @@ func (v *CustomVerifier) Verify(payload []byte, headers map[string]string, secre
 		if tolerance == 0 {
 			tolerance = 300 // default 5 minutes
 		}
-		if time.Now().Unix()-ts > tolerance {
+		if !withinTolerance(clock.Or(v.Clock).Now().Unix(), ts, tolerance) {
 			return false
 		}
 		signedPayload := timestamp + "." + string(payload)
@@ -301,6 +401,20 @@ func (v *CustomVerifier) Verify(payload []byte, headers map[string]string, secre
 }
 
 // computeHMACSHA1 computes HMAC-SHA1.
+// withinTolerance reports whether the unix timestamp ts is within tolerance
+// seconds of now. Future timestamps are held to the same limit, or a signed
+// request dated far ahead could be replayed indefinitely. Timestamps before
+// 1970 are rejected, which keeps the subtraction from overflowing.
+func withinTolerance(now, ts, tolerance int64) bool {
+	if ts <= 0 {
+		return false
+	}
+	if ts > now {
+		return ts-now <= tolerance
+	}
+	return now-ts <= tolerance
+}
+
 func computeHMACSHA1(message, key []byte) []byte {
 	mac := hmac.New(sha1.New, key)
 	mac.Write(message)
@@ -342,3 +456,19 @@ func ComputeGitHubSignature(payload []byte, secret string) string {
 	sig := computeHMACSHA256(payload, []byte(secret))
 	return "sha256=" + hex.EncodeToString(sig)
 }
+
+// ComputeSlackSignature generates a Slack signature for testing.
+func ComputeSlackSignature(payload []byte, secret string, timestamp int64) string {
+	sig := computeHMACSHA256([]byte(fmt.Sprintf("v0:%d:%s", timestamp, payload)), []byte(secret))
+	return "v0=" + hex.EncodeToString(sig)
+}
+
+// ComputeShopifySignature generates a Shopify signature for testing.
+func ComputeShopifySignature(payload []byte, secret string) string {
+	return base64.StdEncoding.EncodeToString(computeHMACSHA256(payload, []byte(secret)))
+}
+
+// ComputeSquareSignature generates a Square signature for testing.
+func ComputeSquareSignature(payload []byte, secret, notificationURL string) string {
+	return base64.StdEncoding.EncodeToString(computeHMACSHA256([]byte(notificationURL+string(payload)), []byte(secret)))
+}
@@ -1,8 +1,13 @@
 package webhook
 
 import (
+	"encoding/hex"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
+
+	"hooks.dx314.com/internal/clock"
 )
 
 func TestStripeVerifier(t *testing.T) {
@@ -38,6 +43,29 @@ func TestStripeVerifier(t *testing.T) {
 	}
 }
 
+func TestStripeVerifierTolerance(t *testing.T) {
+	signed := time.Unix(1700000000, 0)
+	c := clock.NewFake(signed)
+	v := &StripeVerifier{Clock: c}
+	secret := "whsec_test_secret"
+	payload := []byte(`{"type":"charge.succeeded"}`)
+	headers := map[string]string{"Stripe-Signature": ComputeStripeSignature(payload, secret, signed.Unix())}
+
+	c.Advance(300 * time.Second)
+	if !v.Verify(payload, headers, secret) {
+		t.Error("signature rejected at the 5 minute limit")
+	}
+	c.Advance(time.Second)
+	if v.Verify(payload, headers, secret) {
+		t.Error("signature accepted past the 5 minute limit")
+	}
+	// A timestamp far in the future would otherwise never expire
+	future := map[string]string{"Stripe-Signature": ComputeStripeSignature(payload, secret, c.Now().Unix()+301)}
+	if v.Verify(payload, future, secret) {
+		t.Error("signature dated past the 5 minute limit ahead accepted")
+	}
+}
+
 func TestGitHubVerifier(t *testing.T) {
 	v := &GitHubVerifier{}
 	secret := "github_secret"
@@ -85,6 +113,110 @@ func TestTelegramVerifier(t *testing.T) {
 	}
 }
 
+func TestSlackVerifier(t *testing.T) {
+	// The example from Slack's "Verifying requests from Slack" guide
+	secret := "8f742231b10e8888abcd99yyyzzz85a5"
+	payload := []byte("token=xyzz0WbapA4vBCDEFasx0q6G&team_id=T1DC2JH3J&team_domain=testteamnow&channel_id=G8PSS9T3V&channel_name=foobar&user_id=U2CERLKJA&user_name=roadrunner&command=%2Fwebhook-collect&text=&response_url=https%3A%2F%2Fhooks.slack.com%2Fcommands%2FT1DC2JH3J%2F397700885554%2F96rGlfmibIGlgcZRskXaIFfN&trigger_id=398738663015.47445629121.803a0bc887a14d10d2c447fce8b6703c")
+	headers := map[string]string{
+		"X-Slack-Request-Timestamp": "1531420618",
+		"X-Slack-Signature":         "v0=a2114d57b48eac39b9ad189dd8316235a7b4a8d21a10bd27519666489c69b503",
+	}
+	c := clock.NewFake(time.Unix(1531420618, 0))
+	v := &SlackVerifier{Clock: c}
+
+	if !v.Verify(payload, headers, secret) {
+		t.Error("expected Slack's example signature to pass")
+	}
+	if got := ComputeSlackSignature(payload, secret, 1531420618); got != headers["X-Slack-Signature"] {
+		t.Errorf("ComputeSlackSignature = %s", got)
+	}
+	if v.Verify(payload, headers, "wrong_secret") {
+		t.Error("expected wrong secret to fail")
+	}
+	if v.Verify(append(payload, '&'), headers, secret) {
+		t.Error("expected modified body to fail")
+	}
+
+	// The timestamp is signed, so it can't be swapped for a fresh one
+	c.Advance(301 * time.Second)
+	if v.Verify(payload, headers, secret) {
+		t.Error("expected old timestamp to fail")
+	}
+	fresh := map[string]string{
+		"X-Slack-Request-Timestamp": strconv.FormatInt(c.Now().Unix(), 10),
+		"X-Slack-Signature":         headers["X-Slack-Signature"],
+	}
+	if v.Verify(payload, fresh, secret) {
+		t.Error("expected replaced timestamp to fail")
+	}
+
+	if v.Verify(payload, map[string]string{"X-Slack-Signature": headers["X-Slack-Signature"]}, secret) {
+		t.Error("expected missing timestamp to fail")
+	}
+}
+
+func TestShopifyVerifier(t *testing.T) {
+	v := &ShopifyVerifier{}
+	secret := "shpss_test_secret"
+	payload := []byte(`{"id":820982911946154508,"email":"jon@example.com"}`)
+
+	// Shopify sends the raw HMAC base64-encoded, not hex
+	headers := map[string]string{"X-Shopify-Hmac-Sha256": "LnwdZPLBbdjkBwPD/lDL1/rYEGby/GHPtL1aAH6Dxjc="}
+	if !v.Verify(payload, headers, secret) {
+		t.Error("expected valid signature to pass")
+	}
+	if got := ComputeShopifySignature(payload, secret); got != headers["X-Shopify-Hmac-Sha256"] {
+		t.Errorf("ComputeShopifySignature = %s", got)
+	}
+	if v.Verify(payload, headers, "wrong_secret") {
+		t.Error("expected wrong secret to fail")
+	}
+	if v.Verify([]byte(`{"id":1}`), headers, secret) {
+		t.Error("expected modified body to fail")
+	}
+
+	hexSig := map[string]string{"X-Shopify-Hmac-Sha256": hex.EncodeToString(computeHMACSHA256(payload, []byte(secret)))}
+	if v.Verify(payload, hexSig, secret) {
+		t.Error("expected hex signature to fail")
+	}
+	if v.Verify(payload, map[string]string{}, secret) {
+		t.Error("expected missing signature to fail")
+	}
+}
+
+func TestSquareVerifier(t *testing.T) {
+	secret := "asdf1234"
+	payload := []byte(`{"hello":"world"}`)
+	const url = "https://example.com/webhook"
+
+	// Square's own example; the URL is signed along with the body
+	headers := map[string]string{"X-Square-Hmacsha256-Signature": "2kRE5qRU2tR+tBGlDwMEw2avJ7QM4ikPYD/PJ3bd9Og="}
+	v := &SquareVerifier{NotificationURLs: []string{"http://example.com/webhook", url}}
+	if !v.Verify(payload, headers, secret) {
+		t.Error("expected valid signature to pass")
+	}
+	if got := ComputeSquareSignature(payload, secret, url); got != headers["X-Square-Hmacsha256-Signature"] {
+		t.Errorf("ComputeSquareSignature = %s", got)
+	}
+	if v.Verify(payload, headers, "wrong_secret") {
+		t.Error("expected wrong secret to fail")
+	}
+	if v.Verify([]byte(`{"hello":"there"}`), headers, secret) {
+		t.Error("expected modified body to fail")
+	}
+
+	other := &SquareVerifier{NotificationURLs: []string{"https://example.com/other"}}
+	if other.Verify(payload, headers, secret) {
+		t.Error("expected signature for another URL to fail")
+	}
+	if (&SquareVerifier{}).Verify(payload, headers, secret) {
+		t.Error("expected no notification URL to fail")
+	}
+	if v.Verify(payload, map[string]string{}, secret) {
+		t.Error("expected missing signature to fail")
+	}
+}
+
 func TestGenericVerifier(t *testing.T) {
 	v := &GenericVerifier{}
 	secret := "generic_secret"
@@ -112,6 +244,10 @@ func TestNewVerifier(t *testing.T) {
 		{"stripe", "*webhook.StripeVerifier"},
 		{"github", "*webhook.GitHubVerifier"},
 		{"telegram", "*webhook.TelegramVerifier"},
+		{"slack", "*webhook.SlackVerifier"},
+		{"shopify", "*webhook.ShopifyVerifier"},
+		{"square", "*webhook.SquareVerifier"},
+		{"paypal", "*webhook.PayPalVerifier"},
 		{"generic", "*webhook.GenericVerifier"},
 		{"unknown", "*webhook.GenericVerifier"}, // defaults to generic
 	}
@@ -131,6 +267,22 @@ func TestNewVerifier(t *testing.T) {
 			if tt.providerType != "telegram" {
 				t.Errorf("expected %s verifier for %s", tt.expected, tt.providerType)
 			}
+		case *SlackVerifier:
+			if tt.providerType != "slack" {
+				t.Errorf("expected %s verifier for %s", tt.expected, tt.providerType)
+			}
+		case *ShopifyVerifier:
+			if tt.providerType != "shopify" {
+				t.Errorf("expected %s verifier for %s", tt.expected, tt.providerType)
+			}
+		case *SquareVerifier:
+			if tt.providerType != "square" {
+				t.Errorf("expected %s verifier for %s", tt.expected, tt.providerType)
+			}
+		case *PayPalVerifier:
+			if tt.providerType != "paypal" {
+				t.Errorf("expected %s verifier for %s", tt.expected, tt.providerType)
+			}
 		case *GenericVerifier:
 			if tt.providerType != "generic" && tt.providerType != "unknown" {
 				t.Errorf("expected %s verifier for %s", tt.expected, tt.providerType)
@@ -138,3 +290,52 @@ func TestNewVerifier(t *testing.T) {
 		}
 	}
 }
+
+func FuzzStripeVerifier(f *testing.F) {
+	secret := "whsec_test_secret"
+	payload := []byte(`{"type":"charge.succeeded"}`)
+	f.Add(ComputeStripeSignature(payload, secret, 1700000000))
+	f.Add("t=1700000000,v1=zz,v1=")
+	f.Add("t=-9223372036854775808,v1=00")
+	f.Add("t=9223372036854775807,v1=00")
+	f.Add(",,=,t,v1")
+
+	v := &StripeVerifier{Clock: clock.NewFake(time.Unix(1700000000, 0))}
+	f.Fuzz(func(t *testing.T, header string) {
+		// Must not panic, and only a header computed with the secret may pass
+		if v.Verify(payload, map[string]string{"Stripe-Signature": header}, secret) {
+			var ts string
+			for _, part := range strings.Split(header, ",") {
+				if k, val, _ := strings.Cut(part, "="); strings.TrimSpace(k) == "t" {
+					ts = strings.TrimSpace(val)
+				}
+			}
+			want := hex.EncodeToString(computeHMACSHA256([]byte(ts+"."+string(payload)), []byte(secret)))
+			if !strings.Contains(strings.ToLower(header), want) {
+				t.Errorf("forged header accepted: %q", header)
+			}
+		}
+	})
+}
+
+func FuzzParseVerificationConfig(f *testing.F) {
+	f.Add([]byte(`{"method":"hmac_sha256","signature_header":"X-Signature","signature_prefix":"sha256="}`))
+	f.Add([]byte(`{"method":"timestamped_hmac","signature_header":"X-Sig","timestamp_header":"X-Ts","timestamp_tolerance":-1}`))
+	f.Add([]byte(`{"method":"static","signature_header":"X-Token"}`))
+	f.Add([]byte(`[]`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		cfg, err := ParseVerificationConfig(data)
+		if err != nil {
+			return
+		}
+		if cfg.SignatureHeader == "" || cfg.TimestampTolerance < 0 {
+			t.Errorf("invalid config accepted: %+v", cfg)
+		}
+		// Any accepted config must be safe to verify with
+		NewCustomVerifier(cfg).Verify([]byte("{}"), map[string]string{
+			cfg.SignatureHeader: "00",
+			cfg.TimestampHeader: "1700000000",
+		}, "secret")
+	})
+}
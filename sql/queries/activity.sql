@@ -0,0 +1,27 @@
+-- name: RecordDeliveryActivity :exec
+-- System query: increments the hourly delivery rollup for an endpoint
+INSERT INTO activity_events (id, user_id, kind, endpoint_id, count, occurred_at)
+VALUES (?, ?, 'deliveries', ?, 1, strftime('%Y-%m-%d %H:00:00', 'now'))
+ON CONFLICT(id) DO UPDATE SET
+    count = count + 1,
+    updated_at = datetime('now');
+
+-- name: RecordHubActivity :exec
+-- System query: records a hub connect/disconnect event
+INSERT INTO activity_events (id, user_id, kind, hub_id)
+VALUES (?, ?, ?, ?);
+
+-- name: ListActivityEvents :many
+-- User-facing query: recent activity for the user's endpoints and hubs
+SELECT a.*, COALESCE(e.name, '') AS endpoint_name
+FROM activity_events a
+LEFT JOIN endpoints e ON a.endpoint_id = e.id
+WHERE a.user_id = sqlc.arg('user_id')
+  AND a.updated_at >= sqlc.arg('since')
+ORDER BY a.updated_at DESC
+LIMIT sqlc.arg('limit');
+
+-- name: DeleteOldActivityEvents :execrows
+-- System query: cleanup old activity events (no user filter)
+DELETE FROM activity_events
+WHERE updated_at < datetime('now', '-' || CAST(sqlc.arg('age_seconds') AS INTEGER) || ' seconds');
@@ -0,0 +1,18 @@
+-- name: RecordAuditEvent :exec
+-- System query: records a management action
+INSERT INTO audit_log (id, user_id, username, owner_id, token_id, action, target, ip, result)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?);
+
+-- name: ListAuditEvents :many
+-- User-facing query: actions on the owner's endpoints or by the user, newest first
+SELECT * FROM audit_log
+WHERE (owner_id = sqlc.arg('owner_id') OR user_id = sqlc.arg('owner_id'))
+  AND (sqlc.narg('action') IS NULL OR action = sqlc.narg('action'))
+  AND (sqlc.narg('since') IS NULL OR created_at >= sqlc.narg('since'))
+ORDER BY created_at DESC, rowid DESC
+LIMIT sqlc.arg('limit');
+
+-- name: DeleteOldAuditEvents :execrows
+-- System query: cleanup old audit events (no user filter)
+DELETE FROM audit_log
+WHERE created_at < datetime('now', '-' || CAST(sqlc.arg('age_seconds') AS INTEGER) || ' seconds');
@@ -0,0 +1,19 @@
+-- name: RecordConnectionEvent :exec
+-- System query: records a hub connecting or disconnecting for an endpoint
+INSERT INTO connection_events (id, endpoint_id, hub_id, event, transport)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: ListConnectionEvents :many
+-- User-facing query: connection history of the user's endpoints, newest first
+SELECT c.*, e.name AS endpoint_name
+FROM connection_events c
+JOIN endpoints e ON c.endpoint_id = e.id
+WHERE e.user_id = sqlc.arg('user_id')
+  AND (sqlc.narg('endpoint_id') IS NULL OR c.endpoint_id = sqlc.narg('endpoint_id'))
+ORDER BY c.occurred_at DESC, c.rowid DESC
+LIMIT sqlc.arg('limit');
+
+-- name: DeleteOldConnectionEvents :execrows
+-- System query: cleanup old connection events (no user filter)
+DELETE FROM connection_events
+WHERE occurred_at < datetime('now', '-' || CAST(sqlc.arg('age_seconds') AS INTEGER) || ' seconds');
@@ -0,0 +1,35 @@
+-- name: CreateEndpointDestination :one
+-- Callers check ownership of the endpoint
+INSERT INTO endpoint_destinations (id, endpoint_id, url)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: DeleteEndpointDestination :exec
+-- Callers check ownership of the endpoint
+DELETE FROM endpoint_destinations
+WHERE id = ? AND endpoint_id = ?;
+
+-- name: ListEndpointDestinations :many
+-- System query: the destinations an endpoint fans out to, oldest first
+SELECT * FROM endpoint_destinations
+WHERE endpoint_id = ?
+ORDER BY created_at ASC, id ASC;
+
+-- name: ListWebhookDeliveries :many
+-- System query: delivery of a webhook to each destination. Callers check ownership of the webhook.
+SELECT * FROM webhook_deliveries
+WHERE webhook_id = ?
+ORDER BY destination_id ASC;
+
+-- name: RecordWebhookDelivery :exec
+-- System query: records an attempt to deliver a webhook to one destination
+INSERT INTO webhook_deliveries (webhook_id, destination_id, status, attempts, status_code, error_message, last_attempt_at, delivered_at)
+VALUES (sqlc.arg('webhook_id'), sqlc.arg('destination_id'), sqlc.arg('status'), 1, sqlc.narg('status_code'), sqlc.narg('error_message'), datetime('now'),
+        CASE WHEN sqlc.arg('status') = 'delivered' THEN datetime('now') END)
+ON CONFLICT (webhook_id, destination_id) DO UPDATE
+SET status = excluded.status,
+    attempts = webhook_deliveries.attempts + 1,
+    status_code = excluded.status_code,
+    error_message = excluded.error_message,
+    last_attempt_at = excluded.last_attempt_at,
+    delivered_at = excluded.delivered_at;
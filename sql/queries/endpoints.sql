@@ -1,16 +1,35 @@
 -- name: CreateEndpoint :one
-INSERT INTO endpoints (id, user_id, name, provider_type, signature_secret_encrypted, verification_config_encrypted, destination_url, muted, created_at, updated_at)
-VALUES (?, ?, ?, ?, ?, ?, ?, 0, datetime('now'), datetime('now'))
+INSERT INTO endpoints (id, user_id, name, provider_type, signature_secret_encrypted, verification_config_encrypted, destination_url, muted, notify_first_event, home_region, ingest_auth_encrypted, honeypot, created_at, updated_at)
+VALUES (?, ?, ?, ?, ?, ?, ?, 0, ?, ?, ?, ?, datetime('now'), datetime('now'))
 RETURNING *;
 
 -- name: GetEndpoint :one
 SELECT * FROM endpoints WHERE id = ? AND user_id = ?;
 
 -- name: ListEndpoints :many
-SELECT * FROM endpoints WHERE user_id = ? ORDER BY created_at DESC LIMIT ? OFFSET ?;
+-- Filters are ignored when NULL. search is a LIKE pattern fragment with \ as escape.
+-- inactive_days keeps endpoints without a webhook for that many days since creation.
+-- sort: created_asc, last_received (never received last), or newest first.
+SELECT * FROM endpoints
+WHERE user_id = sqlc.arg('user_id')
+  AND (sqlc.arg('search') IS NULL OR name LIKE '%' || sqlc.arg('search') || '%' ESCAPE '\')
+  AND (sqlc.arg('provider_type') IS NULL OR provider_type = sqlc.arg('provider_type'))
+  AND (sqlc.arg('muted') IS NULL OR muted = sqlc.arg('muted'))
+  AND (sqlc.arg('inactive_days') IS NULL OR COALESCE(last_webhook_received_at, created_at) < datetime('now', '-' || sqlc.arg('inactive_days') || ' days'))
+ORDER BY
+  CASE WHEN sqlc.arg('sort') = 'last_received' THEN last_webhook_received_at END DESC,
+  CASE WHEN sqlc.arg('sort') = 'created_asc' THEN created_at END ASC,
+  created_at DESC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
 -- name: CountEndpoints :one
-SELECT COUNT(*) FROM endpoints WHERE user_id = ?;
+-- Counts the endpoints ListEndpoints returns for the same filters.
+SELECT COUNT(*) FROM endpoints
+WHERE user_id = sqlc.arg('user_id')
+  AND (sqlc.arg('search') IS NULL OR name LIKE '%' || sqlc.arg('search') || '%' ESCAPE '\')
+  AND (sqlc.arg('provider_type') IS NULL OR provider_type = sqlc.arg('provider_type'))
+  AND (sqlc.arg('muted') IS NULL OR muted = sqlc.arg('muted'))
+  AND (sqlc.arg('inactive_days') IS NULL OR COALESCE(last_webhook_received_at, created_at) < datetime('now', '-' || sqlc.arg('inactive_days') || ' days'));
 
 -- name: UpdateEndpoint :one
 UPDATE endpoints
@@ -19,8 +38,24 @@ SET name = COALESCE(sqlc.narg('name'), name),
     verification_config_encrypted = COALESCE(sqlc.narg('verification_config_encrypted'), verification_config_encrypted),
     destination_url = COALESCE(sqlc.narg('destination_url'), destination_url),
     muted = COALESCE(sqlc.narg('muted'), muted),
+    archived_at = CASE WHEN sqlc.narg('muted') = 0 THEN NULL ELSE archived_at END,
+    notify_first_event = COALESCE(sqlc.narg('notify_first_event'), notify_first_event),
+    slo_target = COALESCE(sqlc.narg('slo_target'), slo_target),
+    slo_latency_seconds = COALESCE(sqlc.narg('slo_latency_seconds'), slo_latency_seconds),
+    slo_window_hours = COALESCE(sqlc.narg('slo_window_hours'), slo_window_hours),
+    reject_duplicates = COALESCE(sqlc.narg('reject_duplicates'), reject_duplicates),
+    honeypot = COALESCE(sqlc.narg('honeypot'), honeypot),
+    conflict_as_duplicate = COALESCE(sqlc.narg('conflict_as_duplicate'), conflict_as_duplicate),
+    rate_limit_per_minute = COALESCE(sqlc.narg('rate_limit_per_minute'), rate_limit_per_minute),
+    answer_probes = COALESCE(sqlc.narg('answer_probes'), answer_probes),
+    retry_max_attempts = COALESCE(sqlc.narg('retry_max_attempts'), retry_max_attempts),
+    retry_backoff_base_seconds = COALESCE(sqlc.narg('retry_backoff_base_seconds'), retry_backoff_base_seconds),
+    retry_max_interval_seconds = COALESCE(sqlc.narg('retry_max_interval_seconds'), retry_max_interval_seconds),
+    retry_jitter = COALESCE(sqlc.narg('retry_jitter'), retry_jitter),
+    max_payload_bytes = COALESCE(sqlc.narg('max_payload_bytes'), max_payload_bytes),
+    allowed_content_types = COALESCE(sqlc.narg('allowed_content_types'), allowed_content_types),
     updated_at = datetime('now')
-WHERE id = ? AND user_id = ?
+WHERE id = sqlc.arg('id') AND user_id = sqlc.arg('user_id')
 RETURNING *;
 
 -- name: DeleteEndpoint :exec
@@ -28,10 +63,83 @@ DELETE FROM endpoints WHERE id = ? AND user_id = ?;
 
 -- name: GetEndpointByID :one
 -- Public query for webhook ingestion and relay auth - no user_id filter
-SELECT id, user_id, name, provider_type, signature_secret_encrypted, verification_config_encrypted, destination_url, muted
+SELECT id, user_id, name, provider_type, signature_secret_encrypted, verification_config_encrypted, destination_url, muted, reject_duplicates, ingest_auth_encrypted, honeypot, rate_limit_per_minute, answer_probes, ingest_response, max_payload_bytes, allowed_content_types
 FROM endpoints
 WHERE id = ?;
 
 -- name: GetEndpointsByIDs :many
 -- Get endpoints by list of IDs for a specific user
 SELECT id, name FROM endpoints WHERE user_id = ? AND id IN (sqlc.slice('ids'));
+
+-- name: MarkEndpointReceived :exec
+-- System query: records a webhook stored for the endpoint.
+UPDATE endpoints SET last_webhook_received_at = datetime('now') WHERE id = ?;
+
+-- name: MarkEndpointDelivered :exec
+-- System query: records a delivery acknowledged by the hub.
+UPDATE endpoints SET last_delivered_at = datetime('now') WHERE id = ?;
+
+-- name: MarkFirstEvent :one
+-- System query: records the first webhook for an endpoint. Returns no rows if
+-- the endpoint already received a webhook.
+UPDATE endpoints
+SET first_event_at = datetime('now')
+WHERE id = ? AND first_event_at IS NULL
+RETURNING notify_first_event;
+
+-- name: SetEndpointTelegramBotToken :exec
+UPDATE endpoints
+SET telegram_bot_token_encrypted = ?,
+    updated_at = datetime('now')
+WHERE id = ? AND user_id = ?;
+
+-- name: SetEndpointIngestAuth :exec
+-- Sets or clears (NULL) the ingestion credentials
+UPDATE endpoints
+SET ingest_auth_encrypted = ?,
+    updated_at = datetime('now')
+WHERE id = ? AND user_id = ?;
+
+-- name: SetEndpointTransform :exec
+-- Sets or clears (NULL) the rules the hub applies before forwarding
+UPDATE endpoints
+SET transform = ?,
+    updated_at = datetime('now')
+WHERE id = ? AND user_id = ?;
+
+-- name: SetEndpointIngestResponse :exec
+-- Sets or clears (NULL) the response sent once a webhook is stored
+UPDATE endpoints
+SET ingest_response = ?,
+    updated_at = datetime('now')
+WHERE id = ? AND user_id = ?;
+
+-- name: ListSLOEndpoints :many
+-- System query: endpoints with an SLO configured (no user filter)
+SELECT id, user_id, name, destination_url, slo_target, slo_latency_seconds, slo_window_hours, slo_breached_at
+FROM endpoints
+WHERE slo_target > 0;
+
+-- name: SetEndpointSLOBreached :execrows
+-- System query: marks the SLO breached. Affects no rows if already breached.
+UPDATE endpoints
+SET slo_breached_at = datetime('now')
+WHERE id = ? AND slo_breached_at IS NULL;
+
+-- name: ClearEndpointSLOBreached :execrows
+-- System query: clears the breach once the SLO is met again.
+UPDATE endpoints
+SET slo_breached_at = NULL
+WHERE id = ? AND slo_breached_at IS NOT NULL;
+
+-- name: ArchiveInactiveEndpoints :many
+-- System query: mutes endpoints without a webhook for age_seconds, counting
+-- from creation if none was received. Honeypots are meant to stay idle.
+UPDATE endpoints
+SET muted = 1,
+    archived_at = datetime('now'),
+    updated_at = datetime('now')
+WHERE muted = 0
+  AND honeypot = 0
+  AND COALESCE(last_webhook_received_at, created_at) < datetime('now', '-' || CAST(sqlc.arg('age_seconds') AS INTEGER) || ' seconds')
+RETURNING id, name, last_webhook_received_at;
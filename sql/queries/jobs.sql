@@ -0,0 +1,53 @@
+-- name: EnqueueJob :exec
+INSERT INTO jobs (id, kind, payload)
+VALUES (?, ?, ?);
+
+-- name: GetDueJobs :many
+-- Pending jobs whose run_at has passed, oldest first
+SELECT * FROM jobs
+WHERE status = 'pending' AND run_at <= datetime('now')
+ORDER BY run_at
+LIMIT ?;
+
+-- name: ClaimJob :execrows
+-- Marks a pending job running. Returns 0 if another worker claimed it.
+UPDATE jobs
+SET status = 'running', attempts = attempts + 1, updated_at = datetime('now')
+WHERE id = ? AND status = 'pending';
+
+-- name: CompleteJob :exec
+UPDATE jobs
+SET status = 'done', last_error = NULL, updated_at = datetime('now')
+WHERE id = ?;
+
+-- name: RetryJob :exec
+-- Puts a failed run back in the queue after delay_seconds
+UPDATE jobs
+SET status = 'pending',
+    last_error = sqlc.arg('last_error'),
+    run_at = datetime('now', '+' || CAST(sqlc.arg('delay_seconds') AS INTEGER) || ' seconds'),
+    updated_at = datetime('now')
+WHERE id = sqlc.arg('id');
+
+-- name: FailJob :exec
+-- Gives up on a job after its last attempt
+UPDATE jobs
+SET status = 'failed', last_error = ?, updated_at = datetime('now')
+WHERE id = ?;
+
+-- name: ResetRunningJobs :execrows
+-- Requeues jobs that were running when the process stopped
+UPDATE jobs
+SET status = 'pending', updated_at = datetime('now')
+WHERE status = 'running';
+
+-- name: GetJobStats :many
+SELECT kind, status, COUNT(*) AS count
+FROM jobs
+GROUP BY kind, status
+ORDER BY kind, status;
+
+-- name: DeleteOldJobs :execrows
+-- Retention: finished jobs are kept for 7 days for inspection
+DELETE FROM jobs
+WHERE status IN ('done', 'failed') AND updated_at < datetime('now', '-7 days');
@@ -0,0 +1,46 @@
+-- name: CreateOrg :one
+INSERT INTO orgs (id, name, created_by)
+VALUES (?, ?, ?)
+RETURNING *;
+
+-- name: GetOrg :one
+SELECT * FROM orgs WHERE id = ?;
+
+-- name: DeleteOrg :exec
+DELETE FROM orgs WHERE id = ?;
+
+-- name: DeleteEndpointsByOwner :many
+-- Deletes an org's endpoints, stored with its owner ID, with their webhooks
+DELETE FROM endpoints WHERE user_id = ?
+RETURNING id;
+
+-- name: UpsertOrgMember :one
+-- Adds a member, or changes the role of an existing one
+INSERT INTO org_members (org_id, user_id, username, role)
+VALUES (?, ?, ?, ?)
+ON CONFLICT (org_id, user_id) DO UPDATE SET
+    username = excluded.username,
+    role = excluded.role
+RETURNING *;
+
+-- name: GetOrgMember :one
+SELECT * FROM org_members WHERE org_id = ? AND user_id = ?;
+
+-- name: ListOrgMembers :many
+SELECT * FROM org_members
+WHERE org_id = ?
+ORDER BY created_at, username;
+
+-- name: ListUserOrgs :many
+-- The orgs a user belongs to, with the user's role
+SELECT o.*, m.role
+FROM orgs o
+JOIN org_members m ON m.org_id = o.id
+WHERE m.user_id = ?
+ORDER BY o.name, o.id;
+
+-- name: RemoveOrgMember :execrows
+DELETE FROM org_members WHERE org_id = ? AND user_id = ?;
+
+-- name: CountOrgOwners :one
+SELECT COUNT(*) FROM org_members WHERE org_id = ? AND role = 'owner';
@@ -0,0 +1,9 @@
+-- name: RecordSecretReveal :exec
+-- Audit log entry for a signature secret reveal
+INSERT INTO secret_reveals (id, user_id, endpoint_id, ip_address, user_agent)
+VALUES (?, ?, ?, ?, ?);
+
+-- name: CountRecentSecretReveals :one
+-- Number of secrets the user revealed in the last hour, for rate limiting
+SELECT COUNT(*) FROM secret_reveals
+WHERE user_id = ? AND revealed_at >= datetime('now', '-1 hour');
@@ -1,8 +1,13 @@
 -- name: CreateAPIToken :one
-INSERT INTO api_tokens (id, user_id, username, token_hash, name)
-VALUES (?, ?, ?, ?, ?)
+INSERT INTO api_tokens (id, user_id, username, token_hash, name, scope, expires_at)
+VALUES (?, ?, ?, ?, ?, ?, ?)
 RETURNING *;
 
+-- name: GetAPIToken :one
+SELECT * FROM api_tokens
+WHERE id = ?
+  AND user_id = ?;
+
 -- name: GetAPITokenByHash :one
 SELECT * FROM api_tokens
 WHERE token_hash = ?
@@ -30,5 +35,5 @@ WHERE user_id = ?;
 
 -- name: DeleteRevokedAPITokens :execrows
 DELETE FROM api_tokens
-WHERE revoked = 1
-  AND (last_used_at IS NULL OR last_used_at < datetime('now', '-30 days'));
+WHERE (revoked = 1 AND (last_used_at IS NULL OR last_used_at < datetime('now', '-30 days')))
+   OR expires_at < datetime('now', '-30 days');
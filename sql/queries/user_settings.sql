@@ -34,6 +34,31 @@ SET telegram_bot_token_encrypted = ?,
 WHERE user_id = ?
 RETURNING *;
 
+-- name: UpdateUserDiscordSettings :one
+UPDATE user_settings
+SET discord_webhook_url_encrypted = ?,
+    discord_enabled = ?,
+    updated_at = datetime('now')
+WHERE user_id = ?
+RETURNING *;
+
+-- name: UpdateUserEmailSettings :one
+UPDATE user_settings
+SET email_address = ?,
+    email_enabled = ?,
+    updated_at = datetime('now')
+WHERE user_id = ?
+RETURNING *;
+
+-- name: UpdateUserNotifyWebhookSettings :one
+UPDATE user_settings
+SET notify_webhook_url_encrypted = ?,
+    notify_webhook_secret_encrypted = ?,
+    notify_webhook_enabled = ?,
+    updated_at = datetime('now')
+WHERE user_id = ?
+RETURNING *;
+
 -- name: UpdateUserTheme :one
 UPDATE user_settings
 SET theme_preference = ?,
@@ -41,13 +66,20 @@ SET theme_preference = ?,
 WHERE user_id = ?
 RETURNING *;
 
--- name: GetEndpointOwnerTelegramConfig :one
--- Get the endpoint owner's Telegram configuration for sending notifications
+-- name: GetEndpointOwnerNotificationConfig :one
+-- Get the endpoint owner's Telegram, Discord, email and webhook configuration for sending notifications
 SELECT
     us.user_id,
     us.telegram_bot_token_encrypted,
     us.telegram_chat_id,
-    us.telegram_enabled
+    us.telegram_enabled,
+    us.discord_webhook_url_encrypted,
+    us.discord_enabled,
+    us.email_address,
+    us.email_enabled,
+    us.notify_webhook_url_encrypted,
+    us.notify_webhook_secret_encrypted,
+    us.notify_webhook_enabled
 FROM endpoints e
 JOIN user_settings us ON e.user_id = us.user_id
 WHERE e.id = ?;
@@ -1,13 +1,15 @@
 -- name: CreateWebhook :one
-INSERT INTO webhooks (id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts)
-VALUES (?, ?, datetime('now'), ?, ?, ?, 'pending', 0)
+-- Public query for webhook ingestion. Status defaults to pending.
+INSERT INTO webhooks (id, endpoint_id, received_at, headers, payload, signature_valid, status, attempts, event_type, delivery_id, duplicate_of, source_ip, traceparent)
+VALUES (?, ?, datetime('now'), ?, ?, ?, COALESCE(sqlc.narg('status'), 'pending'), 0, ?, ?, ?, ?, ?)
 RETURNING *;
 
 -- name: GetWebhook :one
 -- User-facing query: validates endpoint ownership via JOIN
 SELECT w.* FROM webhooks w
 JOIN endpoints e ON w.endpoint_id = e.id
-WHERE w.id = ? AND e.user_id = ?;
+WHERE w.id = ? AND e.user_id = ?
+  AND w.purged_at IS NULL;
 
 -- name: ListWebhooks :many
 -- User-facing query: filters by endpoint ownership
@@ -16,6 +18,8 @@ JOIN endpoints e ON w.endpoint_id = e.id
 WHERE e.user_id = sqlc.arg('user_id')
   AND (sqlc.arg('endpoint_id') IS NULL OR w.endpoint_id = sqlc.arg('endpoint_id'))
   AND (sqlc.arg('status') IS NULL OR w.status = sqlc.arg('status'))
+  AND (sqlc.arg('event_type') IS NULL OR w.event_type = sqlc.arg('event_type'))
+  AND (w.purged_at IS NOT NULL) = sqlc.arg('purged')
 ORDER BY w.received_at DESC
 LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
 
@@ -25,7 +29,9 @@ SELECT COUNT(*) FROM webhooks w
 JOIN endpoints e ON w.endpoint_id = e.id
 WHERE e.user_id = sqlc.arg('user_id')
   AND (sqlc.arg('endpoint_id') IS NULL OR w.endpoint_id = sqlc.arg('endpoint_id'))
-  AND (sqlc.arg('status') IS NULL OR w.status = sqlc.arg('status'));
+  AND (sqlc.arg('status') IS NULL OR w.status = sqlc.arg('status'))
+  AND (sqlc.arg('event_type') IS NULL OR w.event_type = sqlc.arg('event_type'))
+  AND (w.purged_at IS NOT NULL) = sqlc.arg('purged');
 
 -- name: MarkWebhookDelivered :one
 -- System query: no user filter (called by background dispatcher)
@@ -38,6 +44,20 @@ SET status = 'delivered',
 WHERE id = ?
 RETURNING *;
 
+-- name: MarkWebhookAcknowledgedDuplicate :one
+-- System query: records a 409 from the destination as already processed, if
+-- the webhook's endpoint opted in. Returns no rows otherwise.
+UPDATE webhooks
+SET status = 'acknowledged_duplicate',
+    attempts = attempts + 1,
+    last_attempt_at = datetime('now'),
+    delivered_at = datetime('now'),
+    error_message = sqlc.arg('error_message')
+WHERE id = sqlc.arg('id')
+  AND status = 'pending'
+  AND endpoint_id IN (SELECT id FROM endpoints WHERE conflict_as_duplicate = 1)
+RETURNING *;
+
 -- name: MarkWebhookFailed :one
 -- System query: no user filter (called by background dispatcher)
 UPDATE webhooks
@@ -48,27 +68,38 @@ SET status = 'failed',
 WHERE id = ?
 RETURNING *;
 
+-- name: MarkWebhookSkipped :exec
+-- System query: marks a pending webhook whose event type the hub doesn't want (no user filter)
+UPDATE webhooks
+SET status = 'skipped'
+WHERE id = ? AND status = 'pending';
+
 -- name: RecordWebhookAttempt :one
 -- System query: no user filter (called by background dispatcher)
 UPDATE webhooks
 SET attempts = attempts + 1,
     last_attempt_at = datetime('now'),
-    error_message = ?
-WHERE id = ?
+    next_attempt_at = datetime('now', '+' || CAST(sqlc.arg('retry_delay_seconds') AS INTEGER) || ' seconds'),
+    error_message = sqlc.arg('error_message')
+WHERE id = sqlc.arg('id')
 RETURNING *;
 
+-- name: GetWebhookRetryPolicy :one
+-- System query: a webhook's attempts and its endpoint's retry policy (no user filter)
+SELECT w.attempts, e.retry_max_attempts, e.retry_backoff_base_seconds, e.retry_max_interval_seconds, e.retry_jitter
+FROM webhooks w
+JOIN endpoints e ON w.endpoint_id = e.id
+WHERE w.id = ?;
+
 -- name: GetPendingWebhooks :many
 -- System query: gets all pending webhooks for dispatch (no user filter)
-SELECT w.*, e.destination_url, e.provider_type
+SELECT w.*, e.destination_url, e.provider_type, e.transform
 FROM webhooks w
 JOIN endpoints e ON w.endpoint_id = e.id
 WHERE w.status = 'pending'
   AND e.muted = 0
-  -- Respect backoff: either never attempted, or backoff delay has passed
-  AND (
-    w.last_attempt_at IS NULL
-    OR datetime(w.last_attempt_at, '+' || MIN(1 << w.attempts, 3600) || ' seconds') <= datetime('now')
-  )
+  -- Respect backoff: either never attempted, or the retry is due
+  AND (w.next_attempt_at IS NULL OR w.next_attempt_at <= datetime('now'))
   -- In-order delivery: only the oldest pending webhook per endpoint
   AND w.received_at = (
     SELECT MIN(w2.received_at)
@@ -79,13 +110,35 @@ WHERE w.status = 'pending'
 ORDER BY w.received_at ASC
 LIMIT ?;
 
+-- name: GetDispatchableWebhook :one
+-- System query: a just-stored webhook, if it is the next to dispatch for its endpoint (no user filter)
+SELECT w.*, e.destination_url, e.provider_type, e.transform
+FROM webhooks w
+JOIN endpoints e ON w.endpoint_id = e.id
+WHERE w.id = ?
+  AND w.status = 'pending'
+  AND e.muted = 0
+  AND w.next_attempt_at IS NULL
+  AND w.received_at = (
+    SELECT MIN(w2.received_at)
+    FROM webhooks w2
+    WHERE w2.endpoint_id = w.endpoint_id
+      AND w2.status = 'pending'
+  );
+
+-- name: MarkWebhookDispatched :exec
+-- System query: holds a pending webhook back from dispatch for lease_seconds, while its ack is awaited (no user filter)
+UPDATE webhooks
+SET next_attempt_at = datetime('now', '+' || CAST(sqlc.arg('lease_seconds') AS INTEGER) || ' seconds')
+WHERE id = sqlc.arg('id') AND status = 'pending';
+
 
 -- name: MarkDeadLetter :execrows
--- System query: marks old pending webhooks as dead_letter (no user filter)
+-- System query: marks pending webhooks older than age_seconds as dead_letter (no user filter)
 UPDATE webhooks
 SET status = 'dead_letter'
 WHERE status = 'pending'
-  AND received_at < datetime('now', '-7 days');
+  AND received_at < datetime('now', '-' || CAST(sqlc.arg('age_seconds') AS INTEGER) || ' seconds');
 
 -- name: GetDeadLetterWebhooks :many
 -- System query: gets dead letter webhooks for admin notification (no user filter)
@@ -93,26 +146,75 @@ SELECT w.*, e.name as endpoint_name, e.destination_url, e.provider_type
 FROM webhooks w
 JOIN endpoints e ON w.endpoint_id = e.id
 WHERE w.status = 'dead_letter'
+  AND w.purged_at IS NULL
 ORDER BY w.received_at DESC
 LIMIT ?;
 
--- name: DeleteDeliveredWebhooks :execrows
--- System query: cleanup old delivered webhooks (no user filter)
-DELETE FROM webhooks
-WHERE status = 'delivered'
-  AND delivered_at < datetime('now', '-7 days');
+-- name: PurgeDeliveredWebhooks :execrows
+-- System query: purges old delivered and acknowledged duplicate webhooks, deleted after the grace period (no user filter)
+UPDATE webhooks
+SET purged_at = datetime('now')
+WHERE status IN ('delivered', 'acknowledged_duplicate')
+  AND delivered_at < datetime('now', '-' || CAST(sqlc.arg('age_seconds') AS INTEGER) || ' seconds')
+  AND purged_at IS NULL
+  AND (undeleted_at IS NULL OR undeleted_at < datetime('now', '-' || CAST(sqlc.arg('age_seconds') AS INTEGER) || ' seconds'));
 
--- name: DeleteFailedWebhooks :execrows
--- System query: cleanup old failed webhooks (no user filter)
-DELETE FROM webhooks
+-- name: PurgeFailedWebhooks :execrows
+-- System query: purges old failed webhooks, deleted after the grace period (no user filter)
+UPDATE webhooks
+SET purged_at = datetime('now')
 WHERE status = 'failed'
-  AND last_attempt_at < datetime('now', '-7 days');
+  AND last_attempt_at < datetime('now', '-' || CAST(sqlc.arg('age_seconds') AS INTEGER) || ' seconds')
+  AND purged_at IS NULL
+  AND (undeleted_at IS NULL OR undeleted_at < datetime('now', '-' || CAST(sqlc.arg('age_seconds') AS INTEGER) || ' seconds'));
 
--- name: DeleteDeadLetterWebhooks :execrows
--- System query: cleanup old dead letter webhooks (no user filter)
-DELETE FROM webhooks
+-- name: PurgeDeadLetterWebhooks :execrows
+-- System query: purges old dead letter webhooks, deleted after the grace period (no user filter)
+UPDATE webhooks
+SET purged_at = datetime('now')
 WHERE status = 'dead_letter'
-  AND received_at < datetime('now', '-14 days');
+  AND received_at < datetime('now', '-' || CAST(sqlc.arg('age_seconds') AS INTEGER) || ' seconds')
+  AND purged_at IS NULL
+  AND (undeleted_at IS NULL OR undeleted_at < datetime('now', '-' || CAST(sqlc.arg('age_seconds') AS INTEGER) || ' seconds'));
+
+-- name: DeletePurgedWebhooks :execrows
+-- System query: deletes webhooks purged longer ago than the grace period (no user filter)
+DELETE FROM webhooks
+WHERE purged_at IS NOT NULL
+  AND purged_at < datetime('now', '-' || CAST(sqlc.arg('grace_seconds') AS INTEGER) || ' seconds');
+
+-- name: PurgeSkippedWebhooks :execrows
+-- System query: purges old skipped webhooks, including honeypot hits, deleted after the grace period (no user filter)
+UPDATE webhooks
+SET purged_at = datetime('now')
+WHERE status = 'skipped'
+  AND received_at < datetime('now', '-' || CAST(sqlc.arg('age_seconds') AS INTEGER) || ' seconds')
+  AND purged_at IS NULL
+  AND (undeleted_at IS NULL OR undeleted_at < datetime('now', '-' || CAST(sqlc.arg('age_seconds') AS INTEGER) || ' seconds'));
+
+-- name: ListExpiredWebhooks :many
+-- System query: webhooks past retention, exported to cold storage before they are purged (no user filter)
+SELECT * FROM webhooks
+WHERE purged_at IS NULL
+  AND ((status IN ('delivered', 'acknowledged_duplicate')
+      AND delivered_at < datetime('now', '-' || CAST(sqlc.arg('delivered_age_seconds') AS INTEGER) || ' seconds')
+      AND (undeleted_at IS NULL OR undeleted_at < datetime('now', '-' || CAST(sqlc.arg('delivered_age_seconds') AS INTEGER) || ' seconds')))
+    OR (status = 'failed'
+      AND last_attempt_at < datetime('now', '-' || CAST(sqlc.arg('failed_age_seconds') AS INTEGER) || ' seconds')
+      AND (undeleted_at IS NULL OR undeleted_at < datetime('now', '-' || CAST(sqlc.arg('failed_age_seconds') AS INTEGER) || ' seconds')))
+    OR (status = 'dead_letter'
+      AND received_at < datetime('now', '-' || CAST(sqlc.arg('dead_letter_age_seconds') AS INTEGER) || ' seconds')
+      AND (undeleted_at IS NULL OR undeleted_at < datetime('now', '-' || CAST(sqlc.arg('dead_letter_age_seconds') AS INTEGER) || ' seconds')))
+    OR (status = 'skipped'
+      AND received_at < datetime('now', '-' || CAST(sqlc.arg('skipped_age_seconds') AS INTEGER) || ' seconds')
+      AND (undeleted_at IS NULL OR undeleted_at < datetime('now', '-' || CAST(sqlc.arg('skipped_age_seconds') AS INTEGER) || ' seconds'))))
+LIMIT sqlc.arg('limit');
+
+-- name: PurgeExportedWebhook :execrows
+-- System query: purges an exported webhook unless its status changed since, e.g. by a replay (no user filter)
+UPDATE webhooks
+SET purged_at = datetime('now')
+WHERE id = ? AND status = ? AND purged_at IS NULL;
 
 -- name: GetQueueStats :one
 -- User-facing query: gets queue stats for user's endpoints
@@ -122,7 +224,16 @@ SELECT
     SUM(CASE WHEN w.status = 'dead_letter' THEN 1 ELSE 0 END) AS dead_letter_count
 FROM webhooks w
 JOIN endpoints e ON w.endpoint_id = e.id
-WHERE e.user_id = ?;
+WHERE e.user_id = ?
+  AND w.purged_at IS NULL;
+
+-- name: CountWebhooksByStatus :many
+-- System query: counts all webhooks by status, for the queue depth metric
+SELECT status, COUNT(*) AS count
+FROM webhooks
+WHERE purged_at IS NULL
+GROUP BY status
+ORDER BY status;
 
 -- name: ResetWebhookForReplay :one
 -- User-facing query: validates endpoint ownership via subquery
@@ -130,10 +241,15 @@ UPDATE webhooks
 SET status = 'pending',
     attempts = 0,
     last_attempt_at = NULL,
+    next_attempt_at = NULL,
     delivered_at = NULL,
     error_message = NULL,
-    notification_sent = 0
+    notification_sent = 0,
+    replayed_at = datetime('now'),
+    replayed_by = ?,
+    replay_count = replay_count + 1
 WHERE webhooks.id = ?
+  AND webhooks.purged_at IS NULL
   AND webhooks.endpoint_id IN (SELECT e.id FROM endpoints e WHERE e.user_id = ?)
 RETURNING *;
 
@@ -142,7 +258,8 @@ RETURNING *;
 SELECT w.*, e.name as endpoint_name, e.destination_url as endpoint_destination_url
 FROM webhooks w
 JOIN endpoints e ON w.endpoint_id = e.id
-WHERE w.id = ? AND e.user_id = ?;
+WHERE w.id = ? AND e.user_id = ?
+  AND w.purged_at IS NULL;
 
 -- name: GetWebhookWithEndpointByID :one
 -- System query: gets webhook with endpoint info for notifications (no user filter)
@@ -164,5 +281,145 @@ FROM webhooks w
 JOIN endpoints e ON w.endpoint_id = e.id
 WHERE w.status = 'dead_letter'
   AND w.notification_sent = 0
+  AND w.purged_at IS NULL
 ORDER BY w.received_at DESC
 LIMIT ?;
+
+-- name: CountPendingReplays :one
+-- System query: counts replayed webhooks still waiting for delivery on an endpoint
+SELECT COUNT(*) FROM webhooks
+WHERE endpoint_id = ?
+  AND status = 'pending'
+  AND replayed_at IS NOT NULL;
+
+-- name: CancelPendingReplays :execrows
+-- User-facing query: cancels queued replays, optionally for a single endpoint
+UPDATE webhooks
+SET status = 'failed',
+    error_message = 'replay cancelled',
+    last_attempt_at = datetime('now')
+WHERE status = 'pending'
+  AND replayed_at IS NOT NULL
+  AND endpoint_id IN (SELECT e.id FROM endpoints e WHERE e.user_id = sqlc.arg('user_id'))
+  AND (sqlc.arg('endpoint_id') IS NULL OR endpoint_id = sqlc.arg('endpoint_id'));
+
+-- name: CountBulkReplayable :one
+-- User-facing query: counts the webhooks BulkResetWebhooksForReplay would
+-- reset without its limit
+SELECT COUNT(*) FROM webhooks w
+JOIN endpoints e ON w.endpoint_id = e.id
+WHERE e.user_id = sqlc.arg('user_id')
+  AND e.honeypot = 0
+  AND w.purged_at IS NULL
+  AND w.status = sqlc.arg('status')
+  AND (sqlc.arg('endpoint_id') IS NULL OR w.endpoint_id = sqlc.arg('endpoint_id'))
+  AND (sqlc.arg('received_after') IS NULL OR w.received_at >= sqlc.arg('received_after'))
+  AND (sqlc.arg('received_before') IS NULL OR w.received_at < sqlc.arg('received_before'));
+
+-- name: BulkResetWebhooksForReplay :execrows
+-- User-facing query: resets up to max_count of the user's webhooks with a
+-- status, oldest first, for re-delivery like ResetWebhookForReplay. One
+-- statement, so all of them are reset or none. Honeypot hits and purged
+-- webhooks are never replayed.
+UPDATE webhooks
+SET status = 'pending',
+    attempts = 0,
+    last_attempt_at = NULL,
+    next_attempt_at = NULL,
+    delivered_at = NULL,
+    error_message = NULL,
+    notification_sent = 0,
+    replayed_at = datetime('now'),
+    replayed_by = sqlc.arg('replayed_by'),
+    replay_count = replay_count + 1
+WHERE id IN (
+    SELECT w.id FROM webhooks w
+    JOIN endpoints e ON w.endpoint_id = e.id
+    WHERE e.user_id = sqlc.arg('user_id')
+      AND e.honeypot = 0
+      AND w.purged_at IS NULL
+      AND w.status = sqlc.arg('status')
+      AND (sqlc.arg('endpoint_id') IS NULL OR w.endpoint_id = sqlc.arg('endpoint_id'))
+      AND (sqlc.arg('received_after') IS NULL OR w.received_at >= sqlc.arg('received_after'))
+      AND (sqlc.arg('received_before') IS NULL OR w.received_at < sqlc.arg('received_before'))
+    ORDER BY w.received_at
+    LIMIT sqlc.arg('max_count')
+);
+
+-- name: GetEventTypeCounts :many
+-- User-facing query: webhook counts per event type for an endpoint
+SELECT COALESCE(w.event_type, '') AS event_type, COUNT(*) AS count
+FROM webhooks w
+JOIN endpoints e ON w.endpoint_id = e.id
+WHERE e.user_id = ? AND w.endpoint_id = ?
+  AND w.purged_at IS NULL
+GROUP BY w.event_type
+ORDER BY count DESC;
+
+-- name: GetEndpointSLOStats :one
+-- System query: webhooks received in the SLO window and how many were delivered
+-- within the latency target. Pending webhooks younger than the latency target
+-- can still meet it and are not counted; skipped webhooks are never relayed.
+SELECT
+    COUNT(*) AS total,
+    COUNT(CASE WHEN w.status IN ('delivered', 'acknowledged_duplicate')
+        AND (julianday(w.delivered_at) - julianday(w.received_at)) * 86400 <= CAST(sqlc.arg('latency_seconds') AS INTEGER)
+        THEN 1 END) AS met
+FROM webhooks w
+WHERE w.endpoint_id = sqlc.arg('endpoint_id')
+  AND w.status != 'skipped'
+  AND w.received_at >= datetime('now', '-' || CAST(sqlc.arg('window_hours') AS INTEGER) || ' hours')
+  AND NOT (w.status = 'pending'
+    AND w.received_at > datetime('now', '-' || CAST(sqlc.arg('latency_seconds') AS INTEGER) || ' seconds'));
+
+-- name: FindWebhookByDeliveryID :one
+-- Public query for webhook ingestion: the first webhook received for an
+-- endpoint with a provider delivery ID in the last window_hours.
+SELECT id FROM webhooks
+WHERE endpoint_id = sqlc.arg('endpoint_id')
+  AND delivery_id = sqlc.arg('delivery_id')
+  AND duplicate_of IS NULL
+  AND received_at >= datetime('now', '-' || CAST(sqlc.arg('window_hours') AS INTEGER) || ' hours')
+ORDER BY received_at
+LIMIT 1;
+
+-- name: GetEndpointBytesToday :one
+-- Public query for webhook ingestion: payload bytes stored for an endpoint
+-- since the start of the UTC day.
+SELECT CAST(COALESCE(SUM(LENGTH(payload)), 0) AS INTEGER) AS bytes
+FROM webhooks
+WHERE endpoint_id = sqlc.arg('endpoint_id')
+  AND received_at >= date('now');
+
+-- name: GetLastStatusChangeID :one
+-- System query: the newest status history row, where a tail starts.
+SELECT CAST(COALESCE(MAX(id), 0) AS INTEGER) AS id FROM webhook_status_history;
+
+-- name: ListStatusChangesAfter :many
+-- Status changes of the user's webhooks after a history row, oldest first, for tailing.
+-- endpoint_id is ignored when NULL.
+SELECT h.id, h.webhook_id, h.from_status, h.to_status, h.reason, h.changed_at, h.changed_by
+FROM webhook_status_history h
+JOIN webhooks w ON w.id = h.webhook_id
+JOIN endpoints e ON e.id = w.endpoint_id
+WHERE h.id > sqlc.arg('after_id')
+  AND e.user_id = sqlc.arg('user_id')
+  AND (sqlc.arg('endpoint_id') IS NULL OR w.endpoint_id = sqlc.arg('endpoint_id'))
+ORDER BY h.id ASC
+LIMIT sqlc.arg('limit');
+
+-- name: ListWebhookStatusHistory :many
+-- System query: status changes of a webhook, oldest first. Callers check ownership of the webhook.
+SELECT * FROM webhook_status_history
+WHERE webhook_id = ?
+ORDER BY id ASC;
+
+-- name: UndeleteWebhook :one
+-- User-facing query: restores a purged webhook within the grace period, validates ownership via subquery
+UPDATE webhooks
+SET purged_at = NULL,
+    undeleted_at = datetime('now')
+WHERE webhooks.id = ?
+  AND webhooks.purged_at IS NOT NULL
+  AND webhooks.endpoint_id IN (SELECT e.id FROM endpoints e WHERE e.user_id = ?)
+RETURNING *;
@@ -6,13 +6,38 @@ CREATE TABLE IF NOT EXISTS endpoints (
     id TEXT PRIMARY KEY,
     user_id TEXT NOT NULL,
     name TEXT NOT NULL,
-    provider_type TEXT NOT NULL CHECK (provider_type IN ('stripe', 'github', 'telegram', 'generic', 'custom')),
+    provider_type TEXT NOT NULL CHECK (provider_type IN ('stripe', 'github', 'telegram', 'slack', 'shopify', 'square', 'paypal', 'generic', 'custom')),
     signature_secret_encrypted BLOB,
     verification_config_encrypted BLOB,
     destination_url TEXT NOT NULL,
     muted INTEGER NOT NULL DEFAULT 0,
     created_at TEXT NOT NULL DEFAULT (datetime('now')),
-    updated_at TEXT NOT NULL DEFAULT (datetime('now'))
+    updated_at TEXT NOT NULL DEFAULT (datetime('now')),
+    notify_first_event INTEGER NOT NULL DEFAULT 0,  -- Opt-in notification when the first webhook arrives
+    first_event_at TEXT,  -- Set when the first webhook is received
+    telegram_bot_token_encrypted BLOB,  -- Bot token for setWebhook automation (telegram endpoints only)
+    slo_target REAL NOT NULL DEFAULT 0,  -- Percent of webhooks to deliver within slo_latency_seconds (0 = no SLO)
+    slo_latency_seconds INTEGER NOT NULL DEFAULT 60,
+    slo_window_hours INTEGER NOT NULL DEFAULT 24,
+    slo_breached_at TEXT,  -- Set while the SLO is breached, so alerts fire once per breach
+    reject_duplicates INTEGER NOT NULL DEFAULT 0,  -- Drop re-deliveries of a known delivery ID instead of flagging them
+    home_region TEXT NOT NULL DEFAULT '',  -- Region of the edge the endpoint was created on ('' on single-region edges)
+    ingest_auth_encrypted BLOB,  -- Credentials required at the ingestion URL (NULL = open)
+    honeypot INTEGER NOT NULL DEFAULT 0,  -- Never relay; alert on every hit
+    last_webhook_received_at TEXT,  -- Last webhook stored for the endpoint
+    last_delivered_at TEXT,  -- Last webhook the hub acknowledged as delivered
+    archived_at TEXT,  -- Muted automatically for inactivity; cleared on unmute
+    conflict_as_duplicate INTEGER NOT NULL DEFAULT 0, -- Record a 409 from the destination as acknowledged_duplicate
+    rate_limit_per_minute INTEGER NOT NULL DEFAULT 0,  -- Ingestion limit overriding INGEST_RATE_LIMIT; 0 uses the edge's
+    transform TEXT,  -- JSON rewrite rules the hub applies before forwarding (NULL = forward as received)
+    answer_probes INTEGER NOT NULL DEFAULT 1,  -- Answer HEAD and OPTIONS at the ingestion URL with 204
+    ingest_response TEXT,  -- JSON status, body and content type sent once a webhook is stored (NULL = 200, no body)
+    retry_max_attempts INTEGER NOT NULL DEFAULT 0,  -- Attempts before a webhook fails; 0 = no limit
+    retry_backoff_base_seconds INTEGER NOT NULL DEFAULT 0,  -- First retry delay, doubling; 0 = 1s
+    retry_max_interval_seconds INTEGER NOT NULL DEFAULT 0,  -- Longest retry delay; 0 = 1h
+    retry_jitter REAL NOT NULL DEFAULT 0,  -- Fraction of each delay randomized, 0 to 1
+    max_payload_bytes INTEGER NOT NULL DEFAULT 0,  -- Largest payload accepted; 0 = the edge's 100 MB
+    allowed_content_types TEXT NOT NULL DEFAULT ''  -- Comma-separated media types accepted ('' = any)
 );
 
 CREATE INDEX IF NOT EXISTS idx_endpoints_user_id ON endpoints(user_id);
@@ -25,12 +50,23 @@ CREATE TABLE IF NOT EXISTS webhooks (
     headers TEXT NOT NULL,  -- JSON encoded
     payload BLOB NOT NULL,
     signature_valid INTEGER NOT NULL,
-    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed', 'dead_letter')),
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'delivered', 'failed', 'dead_letter', 'skipped', 'acknowledged_duplicate')),
     attempts INTEGER NOT NULL DEFAULT 0,
     last_attempt_at TEXT,
     delivered_at TEXT,
     error_message TEXT,
     notification_sent INTEGER NOT NULL DEFAULT 0,
+    replayed_at TEXT,  -- Set when the webhook was queued by a replay
+    event_type TEXT,  -- Provider event type (Stripe type, X-GitHub-Event, ...)
+    delivery_id TEXT,  -- Provider delivery ID (X-GitHub-Delivery, Stripe event id, Svix webhook-id)
+    duplicate_of TEXT,  -- Earlier webhook with the same delivery ID
+    source_ip TEXT NOT NULL DEFAULT '',  -- Client IP, resolved through trusted proxies
+    replayed_by TEXT,  -- Who last replayed the webhook
+    replay_count INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TEXT,  -- When a failed delivery is next retried, per the endpoint's retry policy
+    traceparent TEXT,  -- W3C trace context of the ingestion span
+    purged_at TEXT,  -- When cleanup purged the webhook; deleted after the grace period
+    undeleted_at TEXT,  -- When the webhook was last restored from a purge
     FOREIGN KEY (endpoint_id) REFERENCES endpoints(id) ON DELETE CASCADE
 );
 
@@ -38,6 +74,53 @@ CREATE INDEX IF NOT EXISTS idx_webhooks_endpoint_id ON webhooks(endpoint_id);
 CREATE INDEX IF NOT EXISTS idx_webhooks_status ON webhooks(status);
 CREATE INDEX IF NOT EXISTS idx_webhooks_received_at ON webhooks(received_at);
 CREATE INDEX IF NOT EXISTS idx_webhooks_status_received ON webhooks(status, received_at);
+CREATE INDEX IF NOT EXISTS idx_webhooks_replay_pending ON webhooks(endpoint_id, status) WHERE replayed_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_webhooks_endpoint_event_type ON webhooks(endpoint_id, event_type);
+CREATE INDEX IF NOT EXISTS idx_webhooks_endpoint_delivery_id ON webhooks(endpoint_id, delivery_id) WHERE delivery_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_webhooks_endpoint_status_received ON webhooks(endpoint_id, status, received_at);
+CREATE INDEX IF NOT EXISTS idx_webhooks_status_delivered ON webhooks(status, delivered_at);
+CREATE INDEX IF NOT EXISTS idx_webhooks_status_last_attempt ON webhooks(status, last_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_webhooks_purged ON webhooks(purged_at) WHERE purged_at IS NOT NULL;
+
+-- Every status change of a webhook. Rows are written by triggers on webhooks,
+-- which also reject changes the state machine doesn't allow (migration 024).
+CREATE TABLE IF NOT EXISTS webhook_status_history (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    webhook_id TEXT NOT NULL,
+    from_status TEXT,  -- NULL when the webhook was stored
+    to_status TEXT NOT NULL,
+    reason TEXT,  -- 'replayed' or the error message at the change
+    changed_at TEXT NOT NULL DEFAULT (datetime('now')),
+    changed_by TEXT,  -- Who replayed the webhook; NULL for changes made by the edge
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_status_history_webhook ON webhook_status_history(webhook_id, id);
+
+-- Destinations an endpoint fans out to besides its destination_url
+CREATE TABLE IF NOT EXISTS endpoint_destinations (
+    id TEXT PRIMARY KEY,
+    endpoint_id TEXT NOT NULL,
+    url TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    UNIQUE (endpoint_id, url),
+    FOREIGN KEY (endpoint_id) REFERENCES endpoints(id) ON DELETE CASCADE
+);
+
+-- Delivery of a fanned-out webhook to each destination. A replay clears the
+-- webhook's rows (trigger in migration 031).
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    webhook_id TEXT NOT NULL,
+    destination_id TEXT NOT NULL,  -- '' for the endpoint's destination_url
+    status TEXT NOT NULL CHECK (status IN ('pending', 'delivered', 'failed')),  -- pending: failed, will be retried
+    attempts INTEGER NOT NULL DEFAULT 0,
+    status_code INTEGER,
+    error_message TEXT,
+    last_attempt_at TEXT,
+    delivered_at TEXT,
+    PRIMARY KEY (webhook_id, destination_id),
+    FOREIGN KEY (webhook_id) REFERENCES webhooks(id) ON DELETE CASCADE
+);
 
 CREATE TABLE IF NOT EXISTS sessions (
     id TEXT PRIMARY KEY,
@@ -58,7 +141,9 @@ CREATE TABLE IF NOT EXISTS api_tokens (
     name TEXT NOT NULL,
     created_at TEXT NOT NULL DEFAULT (datetime('now')),
     last_used_at TEXT,
-    revoked INTEGER NOT NULL DEFAULT 0
+    revoked INTEGER NOT NULL DEFAULT 0,
+    scope TEXT NOT NULL DEFAULT 'admin' CHECK (scope IN ('admin', 'read', 'relay')),
+    expires_at TEXT  -- NULL for tokens that don't expire
 );
 
 CREATE INDEX IF NOT EXISTS idx_api_tokens_hash ON api_tokens(token_hash);
@@ -85,7 +170,114 @@ CREATE TABLE IF NOT EXISTS user_settings (
 
     created_at TEXT NOT NULL DEFAULT (datetime('now')),
     updated_at TEXT NOT NULL DEFAULT (datetime('now')),
-    last_login_at TEXT NOT NULL DEFAULT (datetime('now'))
+    last_login_at TEXT NOT NULL DEFAULT (datetime('now')),
+
+    -- Discord (webhook URL encrypted, it holds the webhook's token)
+    discord_webhook_url_encrypted BLOB,
+    discord_enabled INTEGER NOT NULL DEFAULT 0,
+
+    -- Email, sent through the edge's SMTP server
+    email_address TEXT,
+    email_enabled INTEGER NOT NULL DEFAULT 0,
+
+    -- Notification webhook (URL and signing secret encrypted)
+    notify_webhook_url_encrypted BLOB,
+    notify_webhook_secret_encrypted BLOB,
+    notify_webhook_enabled INTEGER NOT NULL DEFAULT 0
 );
 
 CREATE INDEX IF NOT EXISTS idx_user_settings_username ON user_settings(username);
+
+-- Activity feed: deliveries are rolled up per endpoint per hour
+CREATE TABLE IF NOT EXISTS activity_events (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    kind TEXT NOT NULL CHECK (kind IN ('deliveries', 'hub_connected', 'hub_disconnected')),
+    endpoint_id TEXT,
+    hub_id TEXT,
+    count INTEGER NOT NULL DEFAULT 1,
+    occurred_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_activity_events_user_updated ON activity_events(user_id, updated_at DESC);
+
+-- Audit log of signature secret reveals, also used to rate limit them.
+-- Kept when the endpoint is deleted.
+CREATE TABLE IF NOT EXISTS secret_reveals (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,
+    endpoint_id TEXT NOT NULL,
+    ip_address TEXT NOT NULL DEFAULT '',
+    user_agent TEXT NOT NULL DEFAULT '',
+    revealed_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_secret_reveals_user_revealed ON secret_reveals(user_id, revealed_at);
+
+-- Persistent queue for background side effects (notifications, bookkeeping)
+CREATE TABLE IF NOT EXISTS jobs (
+    id TEXT PRIMARY KEY,
+    kind TEXT NOT NULL,
+    payload TEXT NOT NULL DEFAULT '{}',  -- JSON encoded
+    status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'done', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    run_at TEXT NOT NULL DEFAULT (datetime('now')),  -- Not run before this time (retry backoff)
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_jobs_status_run_at ON jobs(status, run_at);
+
+-- Per-endpoint history of hubs connecting and disconnecting
+CREATE TABLE IF NOT EXISTS connection_events (
+    id TEXT PRIMARY KEY,
+    endpoint_id TEXT NOT NULL,
+    hub_id TEXT NOT NULL,
+    event TEXT NOT NULL CHECK (event IN ('connected', 'disconnected')),
+    transport TEXT NOT NULL DEFAULT '',
+    occurred_at TEXT NOT NULL DEFAULT (datetime('now')),
+    FOREIGN KEY (endpoint_id) REFERENCES endpoints(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_connection_events_endpoint_occurred ON connection_events(endpoint_id, occurred_at DESC);
+CREATE INDEX IF NOT EXISTS idx_connection_events_occurred ON connection_events(occurred_at);
+
+-- Organizations sharing endpoints; an org's endpoints have user_id 'org:' || id
+CREATE TABLE IF NOT EXISTS orgs (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_by TEXT NOT NULL,  -- GitHub user ID
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE TABLE IF NOT EXISTS org_members (
+    org_id TEXT NOT NULL,
+    user_id TEXT NOT NULL,  -- GitHub user ID
+    username TEXT NOT NULL,
+    role TEXT NOT NULL CHECK (role IN ('owner', 'member', 'viewer')),
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (org_id, user_id),
+    FOREIGN KEY (org_id) REFERENCES orgs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_org_members_user_id ON org_members(user_id);
+
+-- Audit log of management actions; owner_id is the user or org acted for
+CREATE TABLE IF NOT EXISTS audit_log (
+    id TEXT PRIMARY KEY,
+    user_id TEXT NOT NULL,  -- GitHub user ID of who acted
+    username TEXT NOT NULL,
+    owner_id TEXT NOT NULL,
+    token_id TEXT,  -- API token used, NULL for web sessions
+    action TEXT NOT NULL,  -- e.g. CreateEndpoint, Login
+    target TEXT NOT NULL DEFAULT '',  -- ID of the endpoint, webhook, token or org acted on
+    ip TEXT NOT NULL DEFAULT '',
+    result TEXT NOT NULL DEFAULT 'ok',  -- ok, or why it failed, e.g. permission_denied
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_owner_created ON audit_log(owner_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_user_created ON audit_log(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_audit_log_created ON audit_log(created_at);